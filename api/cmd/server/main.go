@@ -31,11 +31,24 @@ func main() {
 		}
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
 	deps, _, err := initDeps(ctx)
 	if err != nil {
 		log.Fatalf("init deps: %v", err)
 	}
 	defer deps.db.Close()
+	if deps.readDB != deps.db {
+		defer deps.readDB.Close()
+	}
+	if err := ensureSchemaCurrent(ctx, deps.db); err != nil {
+		log.Fatalf("schema check: %v", err)
+	}
 
 	preloadDynamicModels(ctx, deps)
 
@@ -46,6 +59,7 @@ func main() {
 		buildInternalModule(deps),
 		buildItemsModule(deps),
 		buildSourcesModule(deps),
+		buildImportsModule(deps),
 		buildSettingsModule(deps),
 		buildAudioBriefingModule(deps),
 		buildLLMModelsModule(deps),
@@ -53,6 +67,11 @@ func main() {
 		buildDigestModule(deps),
 		buildLLMUsageModule(deps),
 		buildDashboardModule(deps),
+		buildStoriesModule(deps),
+		buildTopicAdminModule(deps),
+		buildTopicAlertsModule(deps),
+		buildFilterSuggestionsModule(deps),
+		buildDigestConfigsModule(deps),
 		buildReviewsModule(deps),
 	}
 
@@ -68,7 +87,7 @@ func main() {
 	}
 
 	r.Route("/api", func(r chi.Router) {
-		r.Use(middleware.Auth(repository.NewUserIdentityRepo(deps.db), deps.clerkVerifier))
+		r.Use(middleware.Auth(repository.NewUserIdentityRepo(deps.db), deps.clerkVerifier, deps.sessions))
 		r.Use(rateLimiter.Middleware)
 
 		for _, m := range modules {
@@ -96,7 +115,15 @@ func main() {
 }
 
 func useCommonMiddleware(r chi.Router) {
+	r.Use(middleware.RequestID)
 	r.Use(chimiddleware.Logger)
 	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.BodyLimit)
 	r.Use(chimiddleware.Compress(5, "application/json", "application/rss+xml", "text/*"))
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		middleware.WriteError(w, http.StatusNotFound, middleware.ErrCodeNotFound, "route not found", nil)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		middleware.WriteError(w, http.StatusMethodNotAllowed, middleware.ErrCodeMethodNotAllowed, "method not allowed", nil)
+	})
 }
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/db/migrations"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/golang-migrate/migrate/v4"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const migrateUsage = "usage: server migrate up | down [n] | version | force <version>"
+
+// runMigrateCommand implements `server migrate ...` against DATABASE_URL.
+func runMigrateCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	db, err := repository.NewPool(ctx)
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	defer db.Close()
+	m, latest, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+	case "version":
+		version, dirty, err := schemaVersion(m)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version=%d dirty=%t latest=%d\n", version, dirty, latest)
+		return nil
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < -1 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := m.Force(version); err != nil {
+			return err
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+	version, dirty, err := schemaVersion(m)
+	if err != nil {
+		return err
+	}
+	log.Printf("schema version=%d dirty=%t", version, dirty)
+	return nil
+}
+
+// ensureSchemaCurrent refuses to start the server on a schema older than the binary.
+// AUTO_MIGRATE=true applies pending migrations first; SCHEMA_CHECK=off skips the check.
+// A schema ahead of the binary is accepted so rolling back the code does not take the
+// service down.
+func ensureSchemaCurrent(ctx context.Context, db *pgxpool.Pool) error {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("SCHEMA_CHECK")), "off") {
+		log.Printf("schema check skipped")
+		return nil
+	}
+	m, latest, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("AUTO_MIGRATE")), "true") {
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("auto migrate: %w", err)
+		}
+	}
+	version, dirty, err := schemaVersion(m)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty; fix it manually and run `server migrate force`", version)
+	}
+	if version < latest {
+		return fmt.Errorf("schema version %d is behind %d; run `server migrate up`", version, latest)
+	}
+	log.Printf("schema version=%d latest=%d", version, latest)
+	return nil
+}
+
+// newMigrate opens golang-migrate over the embedded migrations on its own connection, built
+// from the pool's config so closing it leaves the pool alone. It also returns the highest
+// embedded version.
+func newMigrate(db *pgxpool.Pool) (*migrate.Migrate, uint, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, 0, fmt.Errorf("migration source: %w", err)
+	}
+	latest, err := latestMigrationVersion(src)
+	if err != nil {
+		return nil, 0, err
+	}
+	sqlDB := stdlib.OpenDB(*db.Config().ConnConfig.Copy())
+	driver, err := migratepgx.WithInstance(sqlDB, &migratepgx.Config{})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, 0, fmt.Errorf("migration driver: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, 0, err
+	}
+	m.Log = migrateLogger{}
+	return m, latest, nil
+}
+
+func latestMigrationVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+// schemaVersion reports version 0 when nothing has been applied yet.
+func schemaVersion(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...any) { log.Printf("migrate: "+format, v...) }
+
+func (migrateLogger) Verbose() bool { return false }
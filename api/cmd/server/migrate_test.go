@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/db/migrations"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+func TestEmbeddedMigrationsAreContiguous(t *testing.T) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		t.Fatalf("iofs.New() error = %v", err)
+	}
+	defer src.Close()
+
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		t.Fatalf("glob up files: %v", err)
+	}
+	latest, err := latestMigrationVersion(src)
+	if err != nil {
+		t.Fatalf("latestMigrationVersion() error = %v", err)
+	}
+	if latest != uint(len(ups)) {
+		t.Fatalf("latest = %d, want %d (one up file per version, no gaps)", latest, len(ups))
+	}
+
+	version, err := src.First()
+	if err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	for want := uint(1); ; want++ {
+		if version != want {
+			t.Fatalf("migration version %d, want %d", version, want)
+		}
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next(%d) error = %v", version, err)
+		}
+		version = next
+	}
+}
@@ -18,6 +18,7 @@ import (
 
 type appDeps struct {
 	db             *pgxpool.Pool
+	readDB         *pgxpool.Pool // replica for lag-tolerant analytics reads; same as db when unset
 	worker         *service.WorkerClient
 	openAI         *service.OpenAIClient
 	resend         *service.ResendClient
@@ -29,6 +30,8 @@ type appDeps struct {
 	search         *service.MeilisearchService
 	eventPublisher *service.EventPublisher
 	keyProvider    *service.UserKeyProvider
+	auditLog       *service.AuditLogService
+	sessions       *service.UserSessionService
 
 	userSettingsRepo *repository.UserSettingsRepo
 	itemRepo         *repository.ItemRepo
@@ -47,6 +50,7 @@ func initDeps(ctx context.Context) (*appDeps, service.JSONCache, error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("db: %w", err)
 	}
+	readDB := repository.NewReadPool(ctx, db)
 
 	worker := service.NewWorkerClient()
 	openAI := service.NewOpenAIClient()
@@ -77,6 +81,7 @@ func initDeps(ctx context.Context) (*appDeps, service.JSONCache, error) {
 
 	return &appDeps{
 		db:               db,
+		readDB:           readDB,
 		worker:           worker,
 		openAI:           openAI,
 		resend:           resend,
@@ -88,6 +93,8 @@ func initDeps(ctx context.Context) (*appDeps, service.JSONCache, error) {
 		search:           search,
 		eventPublisher:   eventPublisher,
 		keyProvider:      keyProvider,
+		auditLog:         service.NewAuditLogService(repository.NewAuditLogRepo(db)),
+		sessions:         service.NewUserSessionService(repository.NewUserSessionRepo(db), service.NewDigestFeedService(userSettingsRepo, repository.NewDigestRepo(db)), cache).WithClerkRevoker(service.NewClerkSessionRevokerFromEnv()),
 		userSettingsRepo: userSettingsRepo,
 		itemRepo:         itemRepo,
 		sourceRepo:       sourceRepo,
@@ -108,10 +115,28 @@ func buildItemsModule(d *appDeps) appModule {
 	userSettingsRepo := d.userSettingsRepo
 	llmUsageRepo := d.llmUsageRepo
 
-	itemH := handler.NewItemHandler(itemRepo, sourceRepo, readingGoalRepo, streakRepo, snapshotRepo, prefProfileRepo, reviewQueueRepo, userSettingsRepo, llmUsageRepo, d.eventPublisher, d.secretCipher, d.worker, d.cache, d.search, d.keyProvider)
+	// The item list, stats and reading plan tolerate replica lag; everything else reads the primary.
+	itemH := handler.NewItemHandler(itemRepo, sourceRepo, readingGoalRepo, streakRepo, snapshotRepo, repository.NewReadingPlanSnapshotRepo(db), prefProfileRepo, reviewQueueRepo, userSettingsRepo, llmUsageRepo, d.eventPublisher, d.secretCipher, d.worker, d.cache, d.search, d.keyProvider).
+		WithAuditLog(d.auditLog).
+		WithReadRepo(repository.NewItemRepo(d.readDB))
 	notesH := handler.NewItemNotesHandler(itemRepo, reviewQueueRepo, d.eventPublisher)
+	importH := handler.NewItemImportHandler(service.NewItemImportService(sourceRepo, newImportJobService(d)))
+	traceH := handler.NewItemTraceHandler(repository.NewItemProcessingEventRepo(db))
+	snapshotH := handler.NewItemSnapshotHandler(service.NewItemSnapshotService(repository.NewItemHTMLSnapshotRepo(db), d.worker))
+	collectionsH := handler.NewCollectionsHandler(service.NewCollectionService(repository.NewCollectionRepo(db)))
+	askH := handler.NewAskHandler(itemRepo, userSettingsRepo, llmUsageRepo, d.secretCipher, d.worker, d.openAI, d.cache, d.keyProvider)
+	summaryAudioRepo := repository.NewSummaryAudioVoiceSettingsRepo(db)
+	itemAudioSynth := service.NewSummaryAudioPlayerService(itemRepo, summaryAudioRepo, d.userRepo, userSettingsRepo, d.secretCipher, d.worker, service.NewTTSMarkupPreprocessService(userSettingsRepo, d.secretCipher, d.worker, llmUsageRepo, d.cache))
+	itemAudioH := handler.NewItemAudioHandler(service.NewItemAudioService(repository.NewItemAudioRepo(db), itemRepo, summaryAudioRepo, itemAudioSynth, d.worker))
+	// Digest email feedback links apply through itemH so they refresh the same caches, search
+	// document and preference profile as the in-app buttons.
+	digestFeedbackH := handler.NewDigestFeedbackHandler(service.NewDigestLinkSignerFromEnv(), itemRepo, itemH)
 
 	return appModule{
+		registerPublic: func(r chi.Router) {
+			r.Get("/api/digest-feedback", digestFeedbackH.Page)
+			r.Post("/api/digest-feedback", digestFeedbackH.Submit)
+		},
 		registerAPI: func(r chi.Router) {
 			r.Route("/items", func(r chi.Router) {
 				r.Get("/", itemH.List)
@@ -121,6 +146,9 @@ func buildItemsModule(d *appDeps) appModule {
 				r.Get("/ux-metrics", itemH.UXMetrics)
 				r.Get("/topic-trends", itemH.TopicTrends)
 				r.Post("/retry-failed", itemH.RetryFailed)
+				r.Post("/import", importH.Import)
+				r.Post("/import/pocket", importH.ImportPocket)
+				r.Post("/import/instapaper", importH.ImportInstapaper)
 				r.Post("/bulk-jobs", itemH.CreateBulkJob)
 				r.Post("/retry-bulk", itemH.RetryBulk)
 				r.Post("/delete-bulk", itemH.DeleteBulk)
@@ -130,8 +158,16 @@ func buildItemsModule(d *appDeps) appModule {
 				r.Get("/triage-queue", itemH.TriageQueue)
 				r.Get("/today-queue", itemH.TodayQueue)
 				r.Get("/triage-all", itemH.TriageAll)
+				r.Post("/triage", itemH.Triage)
+				r.Post("/catch-up", itemH.CatchUp)
+				r.Post("/catch-up/mark-read", itemH.MarkCatchUpRead)
+				r.Post("/ask", askH.AskFeed)
 				r.Get("/{id}/related", itemH.Related)
 				r.Get("/{id}/navigator", itemH.Navigator)
+				r.Post("/{id}/translate", itemH.Translate)
+				r.Get("/{id}/audio", itemAudioH.Get)
+				r.Get("/{id}/trace", traceH.Get)
+				r.Get("/{id}/snapshot", snapshotH.Get)
 				r.Put("/{id}/note", func(w http.ResponseWriter, r *http.Request) {
 					notesH.UpsertNote(w, r, chi.URLParam(r, "id"))
 				})
@@ -153,6 +189,11 @@ func buildItemsModule(d *appDeps) appModule {
 				r.Post("/mark-read-bulk", itemH.MarkReadBulk)
 				r.Post("/mark-later-bulk", itemH.MarkLaterBulk)
 				r.Delete("/{id}/read", itemH.MarkUnread)
+				r.Post("/{id}/clicked", itemH.MarkClicked)
+				r.Post("/{id}/plan", itemH.PinToReadingPlan)
+				r.Delete("/{id}/plan", itemH.RemoveFromReadingPlan)
+				r.Post("/{id}/snooze", itemH.Snooze)
+				r.Delete("/{id}/snooze", itemH.Unsnooze)
 				r.Post("/{id}/later", itemH.MarkLater)
 				r.Delete("/{id}/later", itemH.UnmarkLater)
 				r.Post("/{id}/retry", itemH.Retry)
@@ -161,6 +202,33 @@ func buildItemsModule(d *appDeps) appModule {
 			r.Route("/topics", func(r chi.Router) {
 				r.Get("/pulse", itemH.TopicPulse)
 			})
+			r.Route("/collections", func(r chi.Router) {
+				r.Get("/", collectionsH.List)
+				r.Post("/", collectionsH.Create)
+				r.Get("/{id}", collectionsH.Get)
+				r.Put("/{id}", collectionsH.Update)
+				r.Delete("/{id}", collectionsH.Delete)
+				r.Get("/{id}/items", collectionsH.ListItems)
+				r.Post("/{id}/items", collectionsH.AddItem)
+				r.Put("/{id}/items/order", collectionsH.ReorderItems)
+				r.Delete("/{id}/items/{itemId}", collectionsH.RemoveItem)
+				r.Get("/{id}/summary", collectionsH.LatestSummary)
+			})
+		},
+	}
+}
+
+func newImportJobService(d *appDeps) *service.ImportJobService {
+	return service.NewImportJobService(repository.NewImportJobRepo(d.db), d.sourceRepo, d.itemRepo, d.eventPublisher).
+		WithUsageLimits(service.NewUsageLimitService(repository.NewUsageLimitRepo(d.db)))
+}
+
+func buildImportsModule(d *appDeps) appModule {
+	importsH := handler.NewImportJobHandler(newImportJobService(d))
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Get("/imports/{id}", importsH.Get)
 		},
 	}
 }
@@ -173,7 +241,14 @@ func buildSourcesModule(d *appDeps) appModule {
 	userSettingsRepo := d.userSettingsRepo
 	llmUsageRepo := d.llmUsageRepo
 
-	sourceH := handler.NewSourceHandler(sourceRepo, itemRepo, sourceOptimizationRepo, userSettingsRepo, llmUsageRepo, d.worker, d.secretCipher, d.eventPublisher, d.cache, d.keyProvider)
+	sourceH := handler.NewSourceHandler(sourceRepo, itemRepo, sourceOptimizationRepo, userSettingsRepo, llmUsageRepo, d.worker, d.secretCipher, d.eventPublisher, d.cache, d.keyProvider).
+		WithBudgetAllocations(repository.NewLLMBudgetAllocationRepo(db)).
+		WithCoSubscriptions(repository.NewSourceCoSubscriptionRepo(db)).
+		WithFeedly(service.NewFeedlyOAuthService(userSettingsRepo, d.secretCipher)).
+		WithImportJobs(newImportJobService(d)).
+		WithUsageLimits(service.NewUsageLimitService(repository.NewUsageLimitRepo(db))).
+		WithAuditLog(d.auditLog).
+		WithEstimates(service.NewSourceEstimateService(sourceRepo, itemRepo, userSettingsRepo, repository.NewModelPricingRepo(db)))
 
 	return appModule{
 		registerAPI: func(r chi.Router) {
@@ -182,6 +257,7 @@ func buildSourcesModule(d *appDeps) appModule {
 				r.Get("/opml", sourceH.ExportOPML)
 				r.Post("/opml/import", sourceH.ImportOPML)
 				r.Post("/inoreader/import", sourceH.ImportInoreader)
+				r.Post("/import/feedly", sourceH.ImportFeedly)
 				r.Get("/stats", sourceH.ItemStats)
 				r.Get("/daily-stats", sourceH.DailyStats)
 				r.Get("/health", sourceH.Health)
@@ -192,6 +268,8 @@ func buildSourcesModule(d *appDeps) appModule {
 				r.Post("/discover", sourceH.Discover)
 				r.Get("/suggestions", sourceH.Suggest)
 				r.Patch("/{id}", sourceH.Update)
+				r.Patch("/{id}/migrate", sourceH.Migrate)
+				r.Post("/{id}/estimate", sourceH.Estimate)
 				r.Delete("/{id}", sourceH.Delete)
 			})
 		},
@@ -216,7 +294,13 @@ func buildSettingsModule(d *appDeps) appModule {
 
 	obsidianExportSvc := service.NewObsidianExportService(d.itemRepo, repository.NewItemExportRepo(db), obsidianExportRepo, d.githubApp)
 
-	settingsH := handler.NewSettingsHandler(userSettingsRepo, userRepo, audioBriefingRepo, summaryAudioRepo, aivisModelRepo, obsidianExportRepo, notificationPriorityRepo, prefProfileRepo, llmUsageRepo, openRouterModelOverrideRepo, d.secretCipher, d.githubApp, obsidianExportSvc, d.worker, d.cache)
+	settingsH := handler.NewSettingsHandler(userSettingsRepo, userRepo, audioBriefingRepo, summaryAudioRepo, aivisModelRepo, obsidianExportRepo, notificationPriorityRepo, prefProfileRepo, llmUsageRepo, openRouterModelOverrideRepo, d.secretCipher, d.githubApp, obsidianExportSvc, d.worker, d.cache).
+		WithItemRepo(d.itemRepo).
+		WithEmbeddingReindex(service.NewEmbeddingReindexService(d.itemRepo, userSettingsRepo, d.eventPublisher)).
+		WithUsageLimits(service.NewUsageLimitService(repository.NewUsageLimitRepo(db))).
+		WithAuditLog(d.auditLog).
+		WithSessions(d.sessions).
+		WithProcessingPause(service.NewProcessingPauseService(userSettingsRepo, d.itemRepo, d.eventPublisher))
 	readingGoalsH := handler.NewReadingGoalsHandler(readingGoalRepo)
 	promptAdminH := handler.NewPromptAdminHandler(promptTemplateRepo, promptAdminAuth, userRepo)
 
@@ -235,8 +319,13 @@ func buildSettingsModule(d *appDeps) appModule {
 				r.Post("/reading-goals/{id}/restore", readingGoalsH.Restore)
 				r.Delete("/reading-goals/{id}", readingGoalsH.Delete)
 				r.Get("/llm-catalog", settingsH.GetLLMCatalog)
+				r.Get("/usage-limits", settingsH.GetUsageLimits)
+				r.Get("/audit-log", settingsH.GetAuditLog)
+				r.Get("/sessions", settingsH.GetSessions)
+				r.Delete("/sessions/{id}", settingsH.RevokeSession)
 				r.Get("/ui-font-catalog", settingsH.GetUIFontCatalog)
 				r.Patch("/", settingsH.UpdateBudget)
+				r.Patch("/budget-enforcement", settingsH.UpdateBudgetEnforcement)
 				r.Patch("/ui-fonts", settingsH.UpdateUIFontSettings)
 				r.Patch("/audio-briefing", settingsH.UpdateAudioBriefing)
 				r.Get("/summary-audio", settingsH.GetSummaryAudioVoiceSettings)
@@ -245,20 +334,54 @@ func buildSettingsModule(d *appDeps) appModule {
 				r.Post("/podcast-artwork", settingsH.UploadPodcastArtwork)
 				r.Patch("/audio-briefing/persona-voices", settingsH.UpdateAudioBriefingPersonaVoices)
 				r.Patch("/reading-plan", settingsH.UpdateReadingPlan)
+				r.Patch("/feed-migration", settingsH.UpdateFeedMigration)
+				r.Patch("/source-stats-sharing", settingsH.UpdateSourceStatsSharing)
+				r.Patch("/html-snapshots", settingsH.UpdateHTMLSnapshots)
+				r.Patch("/paywalled-items", settingsH.UpdateExcludePaywalled)
+				r.Patch("/social-boost", settingsH.UpdateSocialBoost)
+				r.Patch("/output-language", settingsH.UpdateOutputLanguage)
+				r.Patch("/locale", settingsH.UpdateLocale)
+				r.Patch("/digest-audio", settingsH.UpdateDigestAudio)
+				r.Patch("/digest-style", settingsH.UpdateDigestStyle)
+				r.Patch("/digest-topic-priorities", settingsH.UpdateDigestTopicPriorities)
+				r.Patch("/digest-schedule", settingsH.UpdateDigestSchedule)
+				r.Patch("/streak", settingsH.UpdateStreak)
+				r.Patch("/weekly-recap", settingsH.UpdateWeeklyRecap)
+				r.Patch("/llm-concurrency", settingsH.UpdateLLMConcurrency)
+				r.Patch("/prescreen", settingsH.UpdatePrescreen)
+				r.Get("/processing", settingsH.GetProcessingPause)
+				r.Post("/processing/pause", settingsH.PauseProcessing)
+				r.Post("/processing/resume", settingsH.ResumeProcessing)
+				r.Get("/prescreen/projection", settingsH.GetPrescreenProjection)
+				r.Get("/embeddings", settingsH.GetEmbeddingStatus)
+				r.Post("/embeddings/reindex", settingsH.ReindexEmbeddings)
+				r.Patch("/briefing-greeting", settingsH.UpdateBriefingGreeting)
+				r.Patch("/digest-approval", settingsH.UpdateDigestApproval)
 				r.Patch("/notification-priority", settingsH.UpdateNotificationPriority)
 				r.Patch("/llm-models", settingsH.UpdateLLMModels)
+				r.Get("/llm-models/available", settingsH.GetAvailableLLMModels)
 				r.Patch("/obsidian-export", settingsH.UpdateObsidianExport)
 				r.Post("/obsidian-export/run", settingsH.RunObsidianExport)
 				r.Get("/inoreader/connect", settingsH.InoreaderConnect)
 				r.Get("/inoreader/callback", settingsH.InoreaderCallback)
 				r.Delete("/inoreader-oauth", settingsH.DeleteInoreaderOAuth)
+				r.Patch("/inoreader-sync", settingsH.UpdateInoreaderSync)
+				r.Get("/feedly/connect", settingsH.FeedlyConnect)
+				r.Get("/feedly/callback", settingsH.FeedlyCallback)
+				r.Delete("/feedly-oauth", settingsH.DeleteFeedlyOAuth)
+				r.Get("/smtp", settingsH.GetSMTPSettings)
+				r.Put("/smtp", settingsH.SetSMTPSettings)
+				r.Delete("/smtp", settingsH.DeleteSMTPSettings)
 				r.Post("/anthropic-key", settingsH.SetAnthropicAPIKey)
+				r.Post("/anthropic-key/verify", settingsH.VerifyAnthropicAPIKey)
 				r.Delete("/anthropic-key", settingsH.DeleteAnthropicAPIKey)
 				r.Post("/openai-key", settingsH.SetOpenAIAPIKey)
+				r.Post("/openai-key/verify", settingsH.VerifyOpenAIAPIKey)
 				r.Delete("/openai-key", settingsH.DeleteOpenAIAPIKey)
 				r.Post("/cerebras-key", settingsH.SetCerebrasAPIKey)
 				r.Delete("/cerebras-key", settingsH.DeleteCerebrasAPIKey)
 				r.Post("/google-key", settingsH.SetGoogleAPIKey)
+				r.Post("/google-key/verify", settingsH.VerifyGoogleAPIKey)
 				r.Delete("/google-key", settingsH.DeleteGoogleAPIKey)
 				r.Post("/groq-key", settingsH.SetGroqAPIKey)
 				r.Delete("/groq-key", settingsH.DeleteGroqAPIKey)
@@ -362,6 +485,7 @@ func buildAudioBriefingModule(d *appDeps) appModule {
 	audioBriefingPresetsSvc.SetAudioBriefingPresetRepo(audioBriefingPresetRepo)
 	audioBriefingPresetsH := handler.NewAudioBriefingPresetsHandler(audioBriefingPresetsSvc)
 
+	readingStreakH := handler.NewReadingStreakHandler(service.NewReadingStreakService(repository.NewReadingStreakRepo(db)), d.cache)
 	briefingH := handler.NewBriefingHandler(itemRepo, repository.NewBriefingSnapshotRepo(db), repository.NewReadingStreakRepo(db), userSettingsRepo, llmUsageRepo, d.secretCipher, d.worker, d.cache, d.keyProvider)
 
 	return appModule{
@@ -374,6 +498,8 @@ func buildAudioBriefingModule(d *appDeps) appModule {
 		registerAPI: func(r chi.Router) {
 			r.Get("/briefing/today", briefingH.Today)
 			r.Get("/briefing/navigator", briefingH.Navigator)
+			r.Get("/streak", readingStreakH.Get)
+			r.Post("/streak/freezes", readingStreakH.ApplyFreeze)
 			r.Route("/ai-navigator-briefs", func(r chi.Router) {
 				r.Get("/", aiNavigatorBriefH.List)
 				r.Post("/generate", aiNavigatorBriefH.Generate)
@@ -550,14 +676,61 @@ func buildAskModule(d *appDeps) appModule {
 func buildDigestModule(d *appDeps) appModule {
 	db := d.db
 	digestRepo := repository.NewDigestRepo(db)
-	digestH := handler.NewDigestHandler(digestRepo)
+	digestRegenSvc := service.NewDigestRegenerationService(digestRepo, repository.NewUserRepo(db), d.eventPublisher)
+	digestApprovalSvc := service.NewDigestApprovalService(digestRepo, repository.NewUserRepo(db), d.eventPublisher)
+	digestH := handler.NewDigestHandlerWithAudio(digestRepo, service.NewDigestAudioService(nil, d.worker)).
+		WithRegeneration(digestRegenSvc).
+		WithApproval(digestApprovalSvc).
+		WithReadRepo(repository.NewDigestRepo(d.readDB))
+	digestFeedH := handler.NewDigestFeedHandler(service.NewDigestFeedService(repository.NewUserSettingsRepo(db), digestRepo)).
+		WithAuditLog(d.auditLog)
+	digestRecipientRepo := repository.NewDigestRecipientRepo(db)
+	digestRecipientH := handler.NewDigestRecipientsHandler(
+		service.NewDigestRecipientService(digestRecipientRepo, repository.NewUserRepo(db), d.eventPublisher),
+		digestRecipientRepo,
+	)
+	digestLinks := service.NewDigestLinkSignerFromEnv()
+	digestEmailH := handler.NewDigestEmailHandler(
+		digestLinks,
+		service.NewDigestEmailPreferencesService(repository.NewUserSettingsRepo(db), digestRecipientRepo),
+		d.cache,
+	)
 
 	return appModule{
+		registerPublic: func(r chi.Router) {
+			r.Get("/api/digests/feed", digestFeedH.Feed)
+			r.Head("/api/digests/feed", digestFeedH.Feed)
+			r.Get("/api/digest-recipients/confirm", digestRecipientH.ConfirmPage)
+			r.Post("/api/digest-recipients/confirm", digestRecipientH.Confirm)
+			r.Get("/api/digest-email/unsubscribe", digestEmailH.UnsubscribePage)
+			r.Post("/api/digest-email/unsubscribe", digestEmailH.Unsubscribe)
+			r.Get("/api/digest-email/preferences", digestEmailH.PreferencesPage)
+			r.Post("/api/digest-email/preferences", digestEmailH.UpdatePreferences)
+		},
 		registerAPI: func(r chi.Router) {
 			r.Route("/digests", func(r chi.Router) {
 				r.Get("/", digestH.List)
 				r.Get("/latest", digestH.GetLatest)
+				r.Get("/stats", digestH.GetStats)
+				r.Get("/feed-token", digestFeedH.GetToken)
+				r.Post("/feed-token", digestFeedH.RotateToken)
+				r.Delete("/feed-token", digestFeedH.RevokeToken)
 				r.Get("/{id}", digestH.GetDetail)
+				r.Get("/{id}/audio", digestH.GetAudio)
+				r.Get("/{id}/progress", digestH.GetProgress)
+				r.Post("/{id}/regenerate", digestH.Regenerate)
+				r.Post("/{id}/approve", digestH.Approve)
+				r.Delete("/{id}/items/{itemId}", digestH.RemoveItem)
+				r.Get("/{id}/clusters", digestH.ListClusters)
+				r.Post("/{id}/clusters/recompose", digestH.RecomposeClusters)
+				r.Patch("/{id}/clusters/{clusterId}", digestH.UpdateCluster)
+				r.Get("/{id}/deliveries", digestRecipientH.ListDeliveries)
+			})
+			r.Route("/digest-recipients", func(r chi.Router) {
+				r.Get("/", digestRecipientH.List)
+				r.Post("/", digestRecipientH.Create)
+				r.Post("/{id}/resend", digestRecipientH.Resend)
+				r.Delete("/{id}", digestRecipientH.Delete)
 			})
 		},
 	}
@@ -566,16 +739,26 @@ func buildDigestModule(d *appDeps) appModule {
 func buildLLMUsageModule(d *appDeps) appModule {
 	db := d.db
 	llmUsageRepo := d.llmUsageRepo
-	llmExecutionRepo := repository.NewLLMExecutionEventRepo(db)
-	llmValueMetricsRepo := repository.NewLLMValueMetricsRepo(db)
-	llmUsageH := handler.NewLLMUsageHandlerWithValueMetrics(llmUsageRepo, llmExecutionRepo, llmValueMetricsRepo, d.cache)
+	// Usage analytics only read, so they are served from the replica when one is configured.
+	llmUsageReadRepo := repository.NewLLMUsageLogRepo(d.readDB)
+	llmExecutionRepo := repository.NewLLMExecutionEventRepo(d.readDB)
+	llmValueMetricsRepo := repository.NewLLMValueMetricsRepo(d.readDB)
+	budgetAllocationSvc := service.NewLLMBudgetAllocationService(repository.NewLLMBudgetAllocationRepo(db), llmUsageRepo, d.userSettingsRepo)
+	llmUsageH := handler.NewLLMUsageHandlerWithValueMetrics(llmUsageReadRepo, llmExecutionRepo, llmValueMetricsRepo, d.cache).
+		WithForecast(service.NewLLMUsageForecastService(llmUsageReadRepo, d.userSettingsRepo)).
+		WithBudgetAllocations(budgetAllocationSvc)
+	budgetAllocationH := handler.NewLLMBudgetAllocationHandler(budgetAllocationSvc).
+		WithAuditLog(d.auditLog)
 
 	return appModule{
 		registerAPI: func(r chi.Router) {
 			r.Route("/llm-usage", func(r chi.Router) {
 				r.Get("/", llmUsageH.List)
 				r.Get("/summary", llmUsageH.DailySummary)
+				r.Get("/forecast", llmUsageH.Forecast)
+				r.Put("/allocations", budgetAllocationH.Replace)
 				r.Get("/by-model", llmUsageH.ModelSummary)
+				r.Get("/by-source", llmUsageH.SourceSummary)
 				r.Get("/analysis", llmUsageH.AnalysisSummary)
 				r.Get("/current-month/by-provider", llmUsageH.ProviderSummaryCurrentMonth)
 				r.Get("/current-month/by-purpose", llmUsageH.PurposeSummaryCurrentMonth)
@@ -588,15 +771,110 @@ func buildLLMUsageModule(d *appDeps) appModule {
 
 func buildDashboardModule(d *appDeps) appModule {
 	db := d.db
-	sourceRepo := d.sourceRepo
-	itemRepo := d.itemRepo
-	digestRepo := repository.NewDigestRepo(db)
-	llmUsageRepo := d.llmUsageRepo
-	dashboardH := handler.NewDashboardHandler(sourceRepo, itemRepo, digestRepo, llmUsageRepo, d.cache)
+	// Dashboard aggregates tolerate replication lag; layout settings stay on the primary.
+	readDB := d.readDB
+	sourceRepo := repository.NewSourceRepo(readDB)
+	itemRepo := repository.NewItemRepo(readDB)
+	digestRepo := repository.NewDigestRepo(readDB)
+	llmUsageRepo := repository.NewLLMUsageLogRepo(readDB)
+	entityRepo := repository.NewEntityRepo(readDB)
+	dashboardH := handler.NewDashboardHandler(sourceRepo, itemRepo, digestRepo, llmUsageRepo, entityRepo, d.userSettingsRepo, repository.NewMetricsDailyRepo(readDB), d.cache)
+	entitiesH := handler.NewEntitiesHandler(repository.NewEntityRepo(db), d.itemRepo)
 
 	return appModule{
 		registerAPI: func(r chi.Router) {
 			r.Get("/dashboard", dashboardH.Get)
+			r.Route("/dashboard/layout", func(r chi.Router) {
+				r.Get("/", dashboardH.Layout)
+				r.Put("/", dashboardH.UpdateLayout)
+				r.Delete("/", dashboardH.ResetLayout)
+			})
+			r.Get("/dashboard/widgets/{name}", dashboardH.Widget)
+			r.Get("/dashboard/history", dashboardH.History)
+			r.Route("/entities", func(r chi.Router) {
+				r.Get("/", entitiesH.List)
+				r.Get("/{id}/items", entitiesH.Items)
+			})
+		},
+	}
+}
+
+func buildStoriesModule(d *appDeps) appModule {
+	storiesH := handler.NewStoriesHandler(repository.NewStoryRepo(d.db), d.itemRepo, d.eventPublisher)
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Route("/stories", func(r chi.Router) {
+				r.Get("/", storiesH.List)
+				r.Get("/{id}/timeline", storiesH.Timeline)
+			})
+		},
+	}
+}
+
+func buildTopicAdminModule(d *appDeps) appModule {
+	taxonomy := service.NewTopicTaxonomyService(repository.NewTopicRepo(d.db))
+	topicAdminH := handler.NewTopicAdminHandler(taxonomy, service.NewPromptAdminAuthServiceFromEnv(), d.userRepo)
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Route("/topic-admin", func(r chi.Router) {
+				r.Get("/topics", topicAdminH.List)
+				r.Post("/normalize", topicAdminH.Normalize)
+				r.Post("/merge", topicAdminH.Merge)
+				r.Post("/topics/{id}/split", topicAdminH.Split)
+			})
+		},
+	}
+}
+
+func buildDigestConfigsModule(d *appDeps) appModule {
+	digestConfigsH := handler.NewDigestConfigsHandler(repository.NewDigestConfigRepo(d.db))
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Route("/digest-configs", func(r chi.Router) {
+				r.Get("/", digestConfigsH.List)
+				r.Post("/", digestConfigsH.Create)
+				r.Patch("/{id}", digestConfigsH.Update)
+				r.Delete("/{id}", digestConfigsH.Delete)
+			})
+		},
+	}
+}
+
+func buildTopicAlertsModule(d *appDeps) appModule {
+	topicAlertsH := handler.NewTopicAlertsHandler(repository.NewTopicAlertRepo(d.db))
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Route("/topic-alerts", func(r chi.Router) {
+				r.Get("/", topicAlertsH.List)
+				r.Post("/", topicAlertsH.Create)
+				r.Patch("/{id}", topicAlertsH.Update)
+				r.Delete("/{id}", topicAlertsH.Delete)
+			})
+		},
+	}
+}
+
+func buildFilterSuggestionsModule(d *appDeps) appModule {
+	filterRuleRepo := repository.NewFilterRuleRepo(d.db)
+	suggestionSvc := service.NewFilterSuggestionService(filterRuleRepo, repository.NewSourceRepo(d.db))
+	filtersH := handler.NewFilterSuggestionsHandler(filterRuleRepo, suggestionSvc, d.cache, repository.NewReadingPlanSnapshotRepo(d.db))
+
+	return appModule{
+		registerAPI: func(r chi.Router) {
+			r.Route("/suggestions/filters", func(r chi.Router) {
+				r.Get("/", filtersH.ListSuggestions)
+				r.Post("/{id}/accept", filtersH.AcceptSuggestion)
+				r.Post("/{id}/dismiss", filtersH.DismissSuggestion)
+			})
+			r.Route("/filter-rules", func(r chi.Router) {
+				r.Get("/", filtersH.ListRules)
+				r.Post("/", filtersH.CreateRule)
+				r.Delete("/{id}", filtersH.DeleteRule)
+			})
 		},
 	}
 }
@@ -632,10 +910,18 @@ func buildInternalModule(d *appDeps) appModule {
 	digestInngestRepo := repository.NewDigestInngestRepo(db)
 	userSettingsRepo := d.userSettingsRepo
 
+	handler.ShareInternalNonces(d.cache)
+
 	obsidianExportSvc := service.NewObsidianExportService(d.itemRepo, repository.NewItemExportRepo(db), obsidianExportRepo, d.githubApp)
 
 	internalH := handler.NewInternalHandler(userRepo, userIdentityRepo, obsidianExportRepo, itemInngestRepo, digestInngestRepo, userSettingsRepo, d.secretCipher, d.eventPublisher, db, d.cache, d.worker, d.oneSignal, d.githubApp, d.search)
 
+	internalPipelineH := handler.NewInternalPipelineHandler(service.NewPipelineStatusService(repository.NewPipelineStatusRepo(db)))
+	internalSecretsH := handler.NewInternalSecretsHandler(service.NewSecretRotationService(repository.NewUserSecretRepo(db), d.secretCipher))
+	internalDigestStatsH := handler.NewInternalDigestStatsHandler(repository.NewDigestRepo(d.readDB))
+	internalUsageLimitsH := handler.NewInternalUsageLimitsHandler(service.NewUsageLimitService(repository.NewUsageLimitRepo(db)))
+	internalModelPricingH := handler.NewInternalModelPricingHandler(service.NewModelPricingService(repository.NewModelPricingRepo(db), repository.NewLLMUsageLogRepo(db), d.cache))
+
 	inngestHandler := inngestfn.NewHandler(db, d.worker, d.resend, d.oneSignal, obsidianExportSvc, d.cache, d.search, d.keyProvider)
 
 	return appModule{
@@ -643,6 +929,8 @@ func buildInternalModule(d *appDeps) appModule {
 			r.Mount("/api/inngest", ensureInngestPutNoContent(inngestHandler))
 			r.Post("/api/internal/users/upsert", internalH.UpsertUser)
 			r.Post("/api/internal/users/resolve-identity", internalH.ResolveIdentity)
+			r.Get("/api/internal/users/{id}/usage-limits", internalUsageLimitsH.Status)
+			r.Put("/api/internal/users/{id}/plan", internalUsageLimitsH.SetPlan)
 			r.Post("/api/internal/settings/obsidian-github/installation", internalH.UpsertObsidianGitHubInstallation)
 			r.Post("/api/internal/debug/digests/generate", internalH.DebugGenerateDigest)
 			r.Post("/api/internal/debug/digests/send", internalH.DebugSendDigest)
@@ -654,6 +942,14 @@ func buildInternalModule(d *appDeps) appModule {
 			r.Delete("/api/internal/debug/search/backfill", internalH.DebugDeleteFinishedItemSearchBackfillRuns)
 			r.Post("/api/internal/debug/push/test", internalH.DebugSendPushTest)
 			r.Get("/api/internal/debug/system-status", internalH.DebugSystemStatus)
+			r.Get("/api/internal/pipeline/status", internalPipelineH.Status)
+			r.Get("/api/internal/digests/stats", internalDigestStatsH.Stats)
+			r.Get("/api/internal/pricing", internalModelPricingH.List)
+			r.Put("/api/internal/pricing", internalModelPricingH.Upsert)
+			r.Delete("/api/internal/pricing/{id}", internalModelPricingH.Delete)
+			r.Post("/api/internal/pricing/reconcile", internalModelPricingH.Reconcile)
+			r.Get("/api/internal/secrets/rotation-status", internalSecretsH.RotationStatus)
+			r.Post("/api/internal/secrets/rotate", internalSecretsH.Rotate)
 		},
 	}
 }
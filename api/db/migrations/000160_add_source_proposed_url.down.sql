@@ -0,0 +1,6 @@
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS feed_auto_migrate_enabled;
+
+ALTER TABLE sources
+  DROP COLUMN IF EXISTS proposed_url_detected_at,
+  DROP COLUMN IF EXISTS proposed_url;
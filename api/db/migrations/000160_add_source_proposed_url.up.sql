@@ -0,0 +1,6 @@
+ALTER TABLE sources
+  ADD COLUMN IF NOT EXISTS proposed_url TEXT,
+  ADD COLUMN IF NOT EXISTS proposed_url_detected_at TIMESTAMPTZ;
+
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS feed_auto_migrate_enabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS output_language;
+
+DROP INDEX IF EXISTS idx_items_language;
+
+ALTER TABLE items
+  DROP COLUMN IF EXISTS language;
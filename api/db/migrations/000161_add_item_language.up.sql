@@ -0,0 +1,7 @@
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS language TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_items_language ON items (language) WHERE language IS NOT NULL;
+
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS output_language TEXT;
@@ -0,0 +1,5 @@
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_locale_check;
+
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,8 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'ja';
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_locale_check;
+
+ALTER TABLE user_settings
+  ADD CONSTRAINT user_settings_locale_check CHECK (locale IN ('ja', 'en'));
@@ -0,0 +1,11 @@
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS digest_audio_enabled;
+
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS audio_generated_at,
+  DROP COLUMN IF EXISTS audio_error,
+  DROP COLUMN IF EXISTS audio_duration_sec,
+  DROP COLUMN IF EXISTS audio_content_type,
+  DROP COLUMN IF EXISTS audio_object_key,
+  DROP COLUMN IF EXISTS audio_bucket,
+  DROP COLUMN IF EXISTS audio_status;
@@ -0,0 +1,11 @@
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS audio_status TEXT,
+  ADD COLUMN IF NOT EXISTS audio_bucket TEXT,
+  ADD COLUMN IF NOT EXISTS audio_object_key TEXT,
+  ADD COLUMN IF NOT EXISTS audio_content_type TEXT,
+  ADD COLUMN IF NOT EXISTS audio_duration_sec INT,
+  ADD COLUMN IF NOT EXISTS audio_error TEXT,
+  ADD COLUMN IF NOT EXISTS audio_generated_at TIMESTAMPTZ;
+
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS digest_audio_enabled BOOLEAN NOT NULL DEFAULT FALSE;
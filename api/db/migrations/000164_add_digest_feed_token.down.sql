@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_user_settings_digest_feed_token;
+
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS digest_feed_token;
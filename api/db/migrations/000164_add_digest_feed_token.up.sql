@@ -0,0 +1,6 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS digest_feed_token TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_settings_digest_feed_token
+  ON user_settings (digest_feed_token)
+  WHERE digest_feed_token IS NOT NULL;
@@ -0,0 +1,14 @@
+DELETE FROM digests WHERE revision > 1;
+
+DROP INDEX IF EXISTS idx_digests_user_date_revision;
+DROP INDEX IF EXISTS idx_digests_user_date_primary;
+
+ALTER TABLE digests
+  DROP CONSTRAINT IF EXISTS digests_user_id_digest_date_key;
+
+ALTER TABLE digests
+  ADD CONSTRAINT digests_user_id_digest_date_key UNIQUE (user_id, digest_date);
+
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS parent_digest_id,
+  DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,13 @@
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 1,
+  ADD COLUMN IF NOT EXISTS parent_digest_id UUID REFERENCES digests(id) ON DELETE SET NULL;
+
+ALTER TABLE digests
+  DROP CONSTRAINT IF EXISTS digests_user_id_digest_date_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_primary
+  ON digests (user_id, digest_date)
+  WHERE revision = 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_revision
+  ON digests (user_id, digest_date, revision);
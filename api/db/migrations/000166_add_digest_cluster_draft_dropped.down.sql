@@ -0,0 +1,2 @@
+ALTER TABLE digest_cluster_drafts
+  DROP COLUMN IF EXISTS dropped;
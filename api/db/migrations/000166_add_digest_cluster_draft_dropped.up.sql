@@ -0,0 +1,2 @@
+ALTER TABLE digest_cluster_drafts
+  ADD COLUMN IF NOT EXISTS dropped BOOLEAN NOT NULL DEFAULT FALSE;
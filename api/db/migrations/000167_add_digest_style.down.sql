@@ -0,0 +1,14 @@
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS compose_max_clusters,
+  DROP COLUMN IF EXISTS compose_tone,
+  DROP COLUMN IF EXISTS compose_verbosity;
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_digest_max_clusters_check,
+  DROP CONSTRAINT IF EXISTS user_settings_digest_tone_check,
+  DROP CONSTRAINT IF EXISTS user_settings_digest_verbosity_check;
+
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS digest_max_clusters,
+  DROP COLUMN IF EXISTS digest_tone,
+  DROP COLUMN IF EXISTS digest_verbosity;
@@ -0,0 +1,30 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS digest_verbosity TEXT NOT NULL DEFAULT 'standard',
+  ADD COLUMN IF NOT EXISTS digest_tone TEXT NOT NULL DEFAULT 'neutral',
+  ADD COLUMN IF NOT EXISTS digest_max_clusters INT NOT NULL DEFAULT 20;
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_digest_verbosity_check;
+
+ALTER TABLE user_settings
+  ADD CONSTRAINT user_settings_digest_verbosity_check
+  CHECK (digest_verbosity IN ('short', 'standard', 'deep_dive'));
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_digest_tone_check;
+
+ALTER TABLE user_settings
+  ADD CONSTRAINT user_settings_digest_tone_check
+  CHECK (digest_tone IN ('neutral', 'analytical', 'casual'));
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_digest_max_clusters_check;
+
+ALTER TABLE user_settings
+  ADD CONSTRAINT user_settings_digest_max_clusters_check
+  CHECK (digest_max_clusters BETWEEN 1 AND 50);
+
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS compose_verbosity TEXT,
+  ADD COLUMN IF NOT EXISTS compose_tone TEXT,
+  ADD COLUMN IF NOT EXISTS compose_max_clusters INT;
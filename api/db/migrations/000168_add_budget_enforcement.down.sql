@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_items_budget_deferred;
+
+UPDATE items SET status = 'new' WHERE status = 'budget_deferred';
+
+ALTER TABLE items
+  DROP CONSTRAINT IF EXISTS items_status_check;
+
+ALTER TABLE items
+  ADD CONSTRAINT items_status_check
+  CHECK (status IN ('new', 'fetched', 'facts_extracted', 'summarized', 'failed'));
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_budget_hard_cap_usd_check;
+
+ALTER TABLE user_settings
+  DROP COLUMN IF EXISTS budget_hard_cap_usd,
+  DROP COLUMN IF EXISTS budget_enforcement_enabled;
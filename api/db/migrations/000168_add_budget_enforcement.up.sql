@@ -0,0 +1,21 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS budget_enforcement_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS budget_hard_cap_usd DOUBLE PRECISION;
+
+ALTER TABLE user_settings
+  DROP CONSTRAINT IF EXISTS user_settings_budget_hard_cap_usd_check;
+
+ALTER TABLE user_settings
+  ADD CONSTRAINT user_settings_budget_hard_cap_usd_check
+  CHECK (budget_hard_cap_usd IS NULL OR budget_hard_cap_usd > 0);
+
+ALTER TABLE items
+  DROP CONSTRAINT IF EXISTS items_status_check;
+
+ALTER TABLE items
+  ADD CONSTRAINT items_status_check
+  CHECK (status IN ('new', 'fetched', 'facts_extracted', 'summarized', 'failed', 'budget_deferred'));
+
+CREATE INDEX IF NOT EXISTS idx_items_budget_deferred
+  ON items (source_id, updated_at)
+  WHERE status = 'budget_deferred';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS llm_budget_allocations (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  purpose TEXT NOT NULL,
+  monthly_cap_usd DOUBLE PRECISION NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, purpose),
+  CONSTRAINT llm_budget_allocations_purpose_check
+    CHECK (purpose IN ('facts', 'summary', 'digest', 'embedding', 'source_suggestion')),
+  CONSTRAINT llm_budget_allocations_monthly_cap_usd_check
+    CHECK (monthly_cap_usd > 0)
+);
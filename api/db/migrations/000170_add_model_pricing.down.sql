@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_llm_usage_logs_unknown_pricing;
+DROP TABLE IF EXISTS model_pricing;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS model_pricing (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  provider TEXT NOT NULL,
+  model TEXT NOT NULL,
+  input_per_mtok_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  output_per_mtok_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  cache_read_per_mtok_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  cache_write_per_mtok_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  note TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT model_pricing_provider_model_key UNIQUE (provider, model),
+  CONSTRAINT model_pricing_non_negative_check
+    CHECK (
+      input_per_mtok_usd >= 0
+      AND output_per_mtok_usd >= 0
+      AND cache_read_per_mtok_usd >= 0
+      AND cache_write_per_mtok_usd >= 0
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_usage_logs_unknown_pricing
+  ON llm_usage_logs (provider, model)
+  WHERE pricing_source = 'unknown';
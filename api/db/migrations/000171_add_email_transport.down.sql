@@ -0,0 +1,10 @@
+ALTER TABLE digests
+    DROP COLUMN IF EXISTS send_transport;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS smtp_from_name,
+    DROP COLUMN IF EXISTS smtp_from_email,
+    DROP COLUMN IF EXISTS smtp_password_enc,
+    DROP COLUMN IF EXISTS smtp_username,
+    DROP COLUMN IF EXISTS smtp_port,
+    DROP COLUMN IF EXISTS smtp_host;
@@ -0,0 +1,10 @@
+ALTER TABLE user_settings
+    ADD COLUMN smtp_host TEXT,
+    ADD COLUMN smtp_port INTEGER,
+    ADD COLUMN smtp_username TEXT,
+    ADD COLUMN smtp_password_enc TEXT,
+    ADD COLUMN smtp_from_email TEXT,
+    ADD COLUMN smtp_from_name TEXT;
+
+ALTER TABLE digests
+    ADD COLUMN send_transport TEXT;
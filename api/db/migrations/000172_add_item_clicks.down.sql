@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_item_clicks_user_clicked_at;
+DROP TABLE IF EXISTS item_clicks;
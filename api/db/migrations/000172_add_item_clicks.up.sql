@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS item_clicks (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    click_count INTEGER NOT NULL DEFAULT 1,
+    last_via TEXT NOT NULL DEFAULT 'item',
+    first_clicked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_clicked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_clicks_user_clicked_at
+    ON item_clicks (user_id, last_clicked_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reading_plan_overrides_user_date;
+DROP TABLE IF EXISTS reading_plan_overrides;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reading_plan_overrides (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    plan_date DATE NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('pin', 'remove')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, plan_date, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reading_plan_overrides_user_date
+    ON reading_plan_overrides (user_id, plan_date, created_at);
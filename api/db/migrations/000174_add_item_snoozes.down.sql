@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_item_snoozes_user_until;
+DROP TABLE IF EXISTS item_snoozes;
@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    weekly_summary_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_items_collection_position
+    ON collection_items (collection_id, position);
+
+CREATE TABLE IF NOT EXISTS collection_summaries (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    model TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, week_start)
+);
+
+ALTER TABLE llm_usage_logs
+  DROP CONSTRAINT IF EXISTS llm_usage_logs_purpose_check;
+
+ALTER TABLE llm_usage_logs
+  ADD CONSTRAINT llm_usage_logs_purpose_check
+  CHECK (purpose IN (
+    'facts',
+    'facts_localization',
+    'facts_check',
+    'summary',
+    'digest',
+    'embedding',
+    'source_suggestion',
+    'digest_cluster_draft',
+    'ask',
+    'faithfulness_check',
+    'briefing_navigator',
+    'item_navigator',
+    'source_navigator',
+    'ask_navigator',
+    'audio_briefing_script',
+    'ai_navigator_brief',
+    'fish_preprocess',
+    'gemini_tts_preprocess',
+    'elevenlabs_tts_preprocess',
+    'xai_tts_preprocess',
+    'azure_speech_tts_preprocess',
+    'collection_summary'
+  ));
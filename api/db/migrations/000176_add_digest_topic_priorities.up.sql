@@ -0,0 +1,2 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS digest_topic_priorities TEXT[] NOT NULL DEFAULT '{}';
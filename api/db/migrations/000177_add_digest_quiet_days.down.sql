@@ -0,0 +1,8 @@
+ALTER TABLE digests
+    DROP COLUMN IF EXISTS window_start_date,
+    DROP COLUMN IF EXISTS compose_mode;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS digest_vacation_end,
+    DROP COLUMN IF EXISTS digest_vacation_start,
+    DROP COLUMN IF EXISTS digest_skip_weekdays;
@@ -0,0 +1,9 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS digest_skip_weekdays SMALLINT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS digest_vacation_start DATE,
+    ADD COLUMN IF NOT EXISTS digest_vacation_end DATE;
+
+ALTER TABLE digests
+    ADD COLUMN IF NOT EXISTS compose_mode TEXT NOT NULL DEFAULT 'daily'
+        CHECK (compose_mode IN ('daily', 'catch_up')),
+    ADD COLUMN IF NOT EXISTS window_start_date DATE;
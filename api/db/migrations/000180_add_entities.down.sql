@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_item_entities_entity_id;
+DROP TABLE IF EXISTS item_entities;
+DROP TABLE IF EXISTS entities;
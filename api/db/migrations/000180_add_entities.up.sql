@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS entities (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  normalized_name TEXT NOT NULL UNIQUE,
+  kind TEXT NOT NULL DEFAULT 'other' CHECK (kind IN ('company', 'person', 'product', 'other')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS item_entities (
+  item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+  entity_id UUID NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+  mention_count INT NOT NULL DEFAULT 1,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (item_id, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_entities_entity_id
+  ON item_entities (entity_id, item_id);
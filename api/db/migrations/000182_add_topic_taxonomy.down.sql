@@ -0,0 +1,9 @@
+UPDATE item_summaries
+SET topics = original_topics
+WHERE original_topics IS NOT NULL;
+
+ALTER TABLE item_summaries DROP COLUMN IF EXISTS original_topics;
+
+DROP INDEX IF EXISTS idx_topic_aliases_topic_id;
+DROP TABLE IF EXISTS topic_aliases;
+DROP TABLE IF EXISTS topics;
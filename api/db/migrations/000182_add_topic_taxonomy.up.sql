@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS topics (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  normalized_name TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS topic_aliases (
+  alias TEXT PRIMARY KEY,
+  topic_id UUID NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+  source TEXT NOT NULL CHECK (source IN ('lexical', 'embedding', 'manual')),
+  similarity DOUBLE PRECISION,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_topic_aliases_topic_id ON topic_aliases (topic_id);
+
+ALTER TABLE item_summaries ADD COLUMN IF NOT EXISTS original_topics TEXT[];
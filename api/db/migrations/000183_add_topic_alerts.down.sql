@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_topic_alert_logs_user_created;
+DROP TABLE IF EXISTS topic_alert_logs;
+DROP TABLE IF EXISTS topic_alert_subscriptions;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS topic_alert_subscriptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  topic TEXT NOT NULL,
+  min_delta INT NOT NULL DEFAULT 3 CHECK (min_delta > 0),
+  channels TEXT[] NOT NULL DEFAULT ARRAY['push']::text[]
+    CHECK (cardinality(channels) > 0 AND channels <@ ARRAY['email', 'webhook', 'push']::text[]),
+  webhook_url TEXT,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  last_alerted_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (user_id, topic)
+);
+
+CREATE TABLE IF NOT EXISTS topic_alert_logs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  subscription_id UUID NOT NULL REFERENCES topic_alert_subscriptions(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  topic TEXT NOT NULL,
+  count_24h INT NOT NULL,
+  count_prev_24h INT NOT NULL,
+  delta INT NOT NULL,
+  item_ids UUID[] NOT NULL DEFAULT '{}',
+  sent_channels TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_topic_alert_logs_user_created
+  ON topic_alert_logs (user_id, created_at DESC);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS metrics_daily (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  day_jst DATE NOT NULL,
+  items_ingested INTEGER NOT NULL DEFAULT 0,
+  items_summarized INTEGER NOT NULL DEFAULT 0,
+  items_read INTEGER NOT NULL DEFAULT 0,
+  llm_calls INTEGER NOT NULL DEFAULT 0,
+  llm_cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+  digests_sent INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, day_jst)
+);
+
+CREATE INDEX IF NOT EXISTS idx_metrics_daily_day
+  ON metrics_daily (day_jst);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS reading_plan_snapshots (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  plan_window TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  diversify_topics BOOLEAN NOT NULL,
+  exclude_read BOOLEAN NOT NULL,
+  status TEXT NOT NULL DEFAULT 'ready' CHECK (status IN ('ready', 'stale')),
+  payload_json JSONB NOT NULL,
+  generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
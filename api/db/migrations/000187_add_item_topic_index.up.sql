@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS item_topic_index (
+  item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  topic TEXT NOT NULL,
+  published_at TIMESTAMPTZ NOT NULL,
+  effective_at TIMESTAMPTZ NOT NULL,
+  score DOUBLE PRECISION,
+  PRIMARY KEY (item_id, topic)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_topic_index_user_published
+  ON item_topic_index (user_id, published_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_item_topic_index_user_effective
+  ON item_topic_index (user_id, effective_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_item_topic_index_user_topic_published
+  ON item_topic_index (user_id, topic, published_at DESC);
+
+INSERT INTO item_topic_index (item_id, user_id, topic, published_at, effective_at, score)
+SELECT i.id,
+       s.user_id,
+       t.topic,
+       COALESCE(i.published_at, i.created_at),
+       COALESCE(i.fetched_at, i.created_at, i.published_at),
+       sm.score
+FROM items i
+JOIN sources s ON s.id = i.source_id
+JOIN item_summaries sm ON sm.item_id = i.id
+CROSS JOIN LATERAL (
+  SELECT DISTINCT COALESCE(NULLIF(BTRIM(x), ''), '__untagged__') AS topic
+  FROM unnest(
+    CASE
+      WHEN COALESCE(array_length(sm.topics, 1), 0) = 0 THEN ARRAY['__untagged__']::text[]
+      ELSE sm.topics
+    END
+  ) AS x
+) AS t
+WHERE i.status = 'summarized'
+ON CONFLICT (item_id, topic) DO NOTHING;
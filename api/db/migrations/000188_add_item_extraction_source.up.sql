@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS extraction_source TEXT;
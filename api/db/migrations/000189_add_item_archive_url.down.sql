@@ -0,0 +1 @@
+ALTER TABLE items DROP COLUMN IF EXISTS archive_url;
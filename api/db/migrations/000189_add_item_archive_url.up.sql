@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS archive_url TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_items_canonical_url;
+ALTER TABLE items DROP COLUMN IF EXISTS canonical_url;
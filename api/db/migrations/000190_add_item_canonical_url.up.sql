@@ -0,0 +1,2 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS canonical_url TEXT;
+CREATE INDEX IF NOT EXISTS idx_items_canonical_url ON items (canonical_url) WHERE canonical_url IS NOT NULL;
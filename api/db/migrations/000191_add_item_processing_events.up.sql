@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS item_processing_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+  trigger_id TEXT,
+  trigger_reason TEXT,
+  step TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('succeeded', 'failed')),
+  model TEXT,
+  error_message TEXT,
+  started_at TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NOT NULL,
+  duration_ms INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_processing_events_item_started
+  ON item_processing_events (item_id, started_at);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_item_processing_events_started;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_item_processing_events_started
+  ON item_processing_events (started_at);
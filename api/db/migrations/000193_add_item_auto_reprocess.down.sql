@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_items_pipeline_pending;
+ALTER TABLE items
+  DROP COLUMN IF EXISTS auto_reprocessed_at,
+  DROP COLUMN IF EXISTS auto_reprocess_count;
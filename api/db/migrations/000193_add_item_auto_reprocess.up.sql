@@ -0,0 +1,7 @@
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS auto_reprocess_count INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS auto_reprocessed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_items_pipeline_pending
+  ON items (status, updated_at)
+  WHERE status IN ('new', 'fetched', 'facts_extracted') AND deleted_at IS NULL;
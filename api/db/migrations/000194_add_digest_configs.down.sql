@@ -0,0 +1,18 @@
+DELETE FROM digests WHERE digest_config_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_digests_config_date_revision;
+DROP INDEX IF EXISTS idx_digests_user_date_revision;
+DROP INDEX IF EXISTS idx_digests_user_date_primary;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_primary
+  ON digests (user_id, digest_date)
+  WHERE revision = 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_revision
+  ON digests (user_id, digest_date, revision);
+
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS digest_config_id;
+
+DROP INDEX IF EXISTS idx_digest_configs_due;
+DROP TABLE IF EXISTS digest_configs;
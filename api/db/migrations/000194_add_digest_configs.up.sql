@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS digest_configs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  scope_type TEXT NOT NULL CHECK (scope_type IN ('sources', 'search', 'topics')),
+  source_ids UUID[] NOT NULL DEFAULT '{}',
+  search_query TEXT,
+  topics TEXT[] NOT NULL DEFAULT '{}',
+  send_hour_jst SMALLINT NOT NULL DEFAULT 7 CHECK (send_hour_jst BETWEEN 0 AND 23),
+  send_weekdays SMALLINT[] NOT NULL DEFAULT '{0,1,2,3,4,5,6}',
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  last_generated_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_configs_due
+  ON digest_configs (send_hour_jst)
+  WHERE enabled = true;
+
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS digest_config_id UUID REFERENCES digest_configs(id) ON DELETE CASCADE;
+
+-- The daily digest keeps one primary revision per date; scoped digests get their own
+-- per-config numbering.
+DROP INDEX IF EXISTS idx_digests_user_date_primary;
+DROP INDEX IF EXISTS idx_digests_user_date_revision;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_primary
+  ON digests (user_id, digest_date)
+  WHERE revision = 1 AND digest_config_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_user_date_revision
+  ON digests (user_id, digest_date, revision)
+  WHERE digest_config_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digests_config_date_revision
+  ON digests (digest_config_id, digest_date, revision)
+  WHERE digest_config_id IS NOT NULL;
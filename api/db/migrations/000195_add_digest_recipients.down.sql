@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS digest_deliveries;
+DROP INDEX IF EXISTS idx_digest_recipients_config_email;
+DROP INDEX IF EXISTS idx_digest_recipients_daily_email;
+DROP TABLE IF EXISTS digest_recipients;
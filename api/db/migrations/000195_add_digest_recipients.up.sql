@@ -0,0 +1,34 @@
+-- Extra recipients of a user's daily digest (digest_config_id IS NULL) or of a scoped digest.
+-- They only receive mail once they confirm through the emailed link.
+CREATE TABLE IF NOT EXISTS digest_recipients (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  digest_config_id UUID REFERENCES digest_configs(id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed')),
+  confirm_token_hash TEXT UNIQUE,
+  confirm_expires_at TIMESTAMPTZ,
+  confirm_sent_at TIMESTAMPTZ,
+  confirmed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_recipients_daily_email
+  ON digest_recipients (user_id, lower(email))
+  WHERE digest_config_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_recipients_config_email
+  ON digest_recipients (digest_config_id, lower(email))
+  WHERE digest_config_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  digest_id UUID NOT NULL REFERENCES digests(id) ON DELETE CASCADE,
+  recipient_id UUID REFERENCES digest_recipients(id) ON DELETE SET NULL,
+  email TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('sent', 'failed')),
+  error TEXT,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (digest_id, email)
+);
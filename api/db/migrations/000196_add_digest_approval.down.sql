@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_digests_awaiting_approval;
+
+ALTER TABLE digests
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approval_requested_at;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS digest_auto_approve_minutes,
+    DROP COLUMN IF EXISTS digest_approval_enabled;
@@ -0,0 +1,14 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS digest_approval_enabled BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS digest_auto_approve_minutes INT NOT NULL DEFAULT 180
+        CHECK (digest_auto_approve_minutes BETWEEN 0 AND 10080);
+
+ALTER TABLE digests
+    ADD COLUMN IF NOT EXISTS approval_requested_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS approved_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS approved_by TEXT
+        CHECK (approved_by IN ('user', 'auto'));
+
+CREATE INDEX IF NOT EXISTS idx_digests_awaiting_approval
+    ON digests (approval_requested_at)
+    WHERE send_status = 'awaiting_approval';
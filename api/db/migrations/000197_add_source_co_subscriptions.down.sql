@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS source_co_subscriptions;
+DROP TABLE IF EXISTS source_feed_stats;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS source_stats_sharing_enabled;
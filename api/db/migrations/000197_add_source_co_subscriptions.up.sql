@@ -0,0 +1,18 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS source_stats_sharing_enabled BOOLEAN NOT NULL DEFAULT false;
+
+-- Anonymized aggregates rebuilt from the sources of users who opted in. No user IDs are stored,
+-- and feeds or pairs followed by fewer than a handful of users are left out.
+CREATE TABLE IF NOT EXISTS source_feed_stats (
+    feed_url TEXT PRIMARY KEY,
+    subscriber_count INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS source_co_subscriptions (
+    feed_url TEXT NOT NULL,
+    related_feed_url TEXT NOT NULL,
+    co_count INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (feed_url, related_feed_url)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS feedly_token_expires_at,
+    DROP COLUMN IF EXISTS feedly_refresh_token_enc,
+    DROP COLUMN IF EXISTS feedly_access_token_enc;
+
+ALTER TABLE sources
+    DROP COLUMN IF EXISTS group_name;
@@ -0,0 +1,7 @@
+ALTER TABLE sources
+    ADD COLUMN IF NOT EXISTS group_name TEXT;
+
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS feedly_access_token_enc TEXT,
+    ADD COLUMN IF NOT EXISTS feedly_refresh_token_enc TEXT,
+    ADD COLUMN IF NOT EXISTS feedly_token_expires_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_sources_user_sync_provider;
+
+ALTER TABLE sources
+    DROP COLUMN IF EXISTS sync_removed_at,
+    DROP COLUMN IF EXISTS sync_provider;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS inoreader_last_sync_error,
+    DROP COLUMN IF EXISTS inoreader_last_synced_at,
+    DROP COLUMN IF EXISTS inoreader_sync_read_state,
+    DROP COLUMN IF EXISTS inoreader_sync_enabled;
@@ -0,0 +1,15 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS inoreader_sync_enabled BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS inoreader_sync_read_state BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS inoreader_last_synced_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS inoreader_last_sync_error TEXT;
+
+-- sync_provider marks sources kept in sync with an external reader; sync_removed_at is set when
+-- the feed disappears from that reader's subscriptions. Flagged sources are left enabled.
+ALTER TABLE sources
+    ADD COLUMN IF NOT EXISTS sync_provider TEXT,
+    ADD COLUMN IF NOT EXISTS sync_removed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_sources_user_sync_provider
+    ON sources (user_id, sync_provider)
+    WHERE sync_provider IS NOT NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id text NOT NULL,
+  kind text NOT NULL,
+  source_id uuid REFERENCES sources(id) ON DELETE SET NULL,
+  status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+  total_count integer NOT NULL DEFAULT 0,
+  processed_count integer NOT NULL DEFAULT 0,
+  added_count integer NOT NULL DEFAULT 0,
+  duplicate_count integer NOT NULL DEFAULT 0,
+  invalid_count integer NOT NULL DEFAULT 0,
+  failed_count integer NOT NULL DEFAULT 0,
+  error_message text,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  completed_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS import_job_entries (
+  job_id uuid NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+  position integer NOT NULL,
+  url text NOT NULL,
+  title text,
+  added_at timestamptz,
+  archived boolean NOT NULL DEFAULT false,
+  status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'processing', 'added', 'duplicate', 'failed')),
+  item_id uuid REFERENCES items(id) ON DELETE SET NULL,
+  error_message text,
+  PRIMARY KEY (job_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_created ON import_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_job_entries_next ON import_job_entries(job_id, status, position);
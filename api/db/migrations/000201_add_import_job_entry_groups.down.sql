@@ -0,0 +1 @@
+ALTER TABLE import_job_entries DROP COLUMN IF EXISTS group_name;
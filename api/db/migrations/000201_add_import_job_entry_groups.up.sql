@@ -0,0 +1 @@
+ALTER TABLE import_job_entries ADD COLUMN IF NOT EXISTS group_name text;
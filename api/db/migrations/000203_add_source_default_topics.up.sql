@@ -0,0 +1 @@
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS default_topics text[] NOT NULL DEFAULT '{}';
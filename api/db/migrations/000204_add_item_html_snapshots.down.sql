@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS item_html_snapshots;
+
+ALTER TABLE user_settings DROP COLUMN IF EXISTS html_snapshots_enabled;
@@ -0,0 +1,12 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS html_snapshots_enabled BOOLEAN NOT NULL DEFAULT false;
+
+-- Sanitized copies of article pages kept in blob storage so favorites survive link rot.
+CREATE TABLE IF NOT EXISTS item_html_snapshots (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    bucket TEXT NOT NULL,
+    object_key TEXT NOT NULL,
+    source_url TEXT NOT NULL,
+    size_bytes INT NOT NULL,
+    captured_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
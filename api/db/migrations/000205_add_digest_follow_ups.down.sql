@@ -0,0 +1,2 @@
+ALTER TABLE digest_cluster_drafts DROP COLUMN IF EXISTS follow_up;
+ALTER TABLE digest_items DROP COLUMN IF EXISTS follow_up;
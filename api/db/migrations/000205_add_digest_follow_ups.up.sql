@@ -0,0 +1,4 @@
+-- The earlier digest story an item or cluster follows up on, denormalized so the email and the
+-- digest page keep showing it after the earlier digest is regenerated or deleted.
+ALTER TABLE digest_items ADD COLUMN IF NOT EXISTS follow_up JSONB;
+ALTER TABLE digest_cluster_drafts ADD COLUMN IF NOT EXISTS follow_up JSONB;
@@ -0,0 +1,3 @@
+ALTER TABLE digest_items DROP COLUMN IF EXISTS is_exploration;
+ALTER TABLE reading_plan_snapshots DROP COLUMN IF EXISTS exploration;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS reading_plan_exploration;
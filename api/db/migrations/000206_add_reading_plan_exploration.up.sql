@@ -0,0 +1,10 @@
+-- Share of reading plan and digest slots given to low-affinity or novel-topic items.
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS reading_plan_exploration DOUBLE PRECISION NOT NULL DEFAULT 0
+  CHECK (reading_plan_exploration >= 0 AND reading_plan_exploration <= 1);
+
+ALTER TABLE reading_plan_snapshots
+  ADD COLUMN IF NOT EXISTS exploration DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE digest_items
+  ADD COLUMN IF NOT EXISTS is_exploration BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS filter_suggestions;
+DROP TABLE IF EXISTS filter_rules;
+ALTER TABLE item_feedbacks DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,28 @@
+-- Why a thumbs-down was given; only set for negative ratings.
+ALTER TABLE item_feedbacks
+  ADD COLUMN IF NOT EXISTS reason TEXT
+  CHECK (reason IN ('off_topic', 'clickbait', 'too_long', 'duplicate'));
+
+-- Topics the user no longer wants in reading plans and digests.
+CREATE TABLE IF NOT EXISTS filter_rules (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  topic TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (user_id, topic)
+);
+
+-- Topic filters and source mutes proposed from recurring negative feedback reasons. target is
+-- the lowercased topic for kind 'topic' and the source id for kind 'source'.
+CREATE TABLE IF NOT EXISTS filter_suggestions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kind TEXT NOT NULL CHECK (kind IN ('topic', 'source')),
+  target TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  evidence_count INT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'dismissed')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (user_id, kind, target)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_item_summaries_depth;
+ALTER TABLE item_summaries DROP COLUMN IF EXISTS depth;
@@ -0,0 +1,6 @@
+-- Reading depth classified during summarization: a short news brief, an in-depth piece or a hands-on tutorial.
+ALTER TABLE item_summaries
+  ADD COLUMN IF NOT EXISTS depth TEXT
+  CHECK (depth IN ('news_brief', 'deep_dive', 'tutorial'));
+
+CREATE INDEX IF NOT EXISTS idx_item_summaries_depth ON item_summaries (depth) WHERE depth IS NOT NULL;
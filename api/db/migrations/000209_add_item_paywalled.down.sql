@@ -0,0 +1,2 @@
+ALTER TABLE user_settings DROP COLUMN IF EXISTS exclude_paywalled;
+ALTER TABLE items DROP COLUMN IF EXISTS paywalled;
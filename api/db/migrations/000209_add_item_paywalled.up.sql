@@ -0,0 +1,6 @@
+-- Set at extraction when the body is short and carries known paywall wording.
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS paywalled BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS exclude_paywalled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE user_settings DROP COLUMN IF EXISTS social_boost_enabled;
+DROP TABLE IF EXISTS item_discussions;
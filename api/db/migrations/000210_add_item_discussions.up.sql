@@ -0,0 +1,15 @@
+-- Hacker News and Reddit threads that link to an item, refreshed by the enrich-item-discussions job.
+CREATE TABLE IF NOT EXISTS item_discussions (
+  item_id        UUID        NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+  platform       TEXT        NOT NULL CHECK (platform IN ('hackernews', 'reddit')),
+  external_id    TEXT        NOT NULL,
+  discussion_url TEXT        NOT NULL,
+  title          TEXT,
+  points         INTEGER     NOT NULL DEFAULT 0,
+  comments_count INTEGER     NOT NULL DEFAULT 0,
+  fetched_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (item_id, platform)
+);
+
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS social_boost_enabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,15 @@
+-- Cached text-to-speech renderings of single items, one per item and text source.
+CREATE TABLE IF NOT EXISTS item_audio (
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    source TEXT NOT NULL CHECK (source IN ('summary', 'full')),
+    voice_key TEXT NOT NULL,
+    bucket TEXT NOT NULL,
+    object_key TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    duration_sec INT NOT NULL DEFAULT 0,
+    text_chars INT NOT NULL DEFAULT 0,
+    source_summarized_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, source)
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS reading_streak_freeze_balances;
+
+ALTER TABLE reading_streaks DROP COLUMN IF EXISTS freeze_source;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS streak_freeze_monthly_allowance,
+    DROP COLUMN IF EXISTS streak_daily_target;
@@ -0,0 +1,15 @@
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS streak_daily_target INT NOT NULL DEFAULT 3,
+    ADD COLUMN IF NOT EXISTS streak_freeze_monthly_allowance INT NOT NULL DEFAULT 2;
+
+-- A frozen day counts as completed without reads; the source says which token paid for it.
+ALTER TABLE reading_streaks
+    ADD COLUMN IF NOT EXISTS freeze_source TEXT CHECK (freeze_source IN ('monthly', 'earned'));
+
+-- Freeze tokens earned by keeping a streak going. Monthly tokens are derived from the allowance
+-- and the frozen days in that month, so only the earned ones need a balance.
+CREATE TABLE IF NOT EXISTS reading_streak_freeze_balances (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    earned INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
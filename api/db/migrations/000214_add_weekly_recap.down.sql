@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS weekly_recap_logs;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS weekly_recap_enabled;
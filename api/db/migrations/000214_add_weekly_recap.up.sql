@@ -0,0 +1,11 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS weekly_recap_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- One row per user and JST week (starting Monday) once the weekly recap email has gone out.
+CREATE TABLE IF NOT EXISTS weekly_recap_logs (
+  user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  week_start DATE        NOT NULL,
+  read_count INTEGER     NOT NULL DEFAULT 0,
+  sent_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, week_start)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_briefing_snapshots_last_viewed;
+ALTER TABLE briefing_snapshots DROP COLUMN IF EXISTS last_viewed_at;
@@ -0,0 +1,8 @@
+-- Last time the user opened the briefing for that date; the snapshot cron skips users who have
+-- neither opened a briefing nor read an item recently.
+ALTER TABLE briefing_snapshots
+  ADD COLUMN IF NOT EXISTS last_viewed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_briefing_snapshots_last_viewed
+  ON briefing_snapshots(last_viewed_at DESC)
+  WHERE last_viewed_at IS NOT NULL;
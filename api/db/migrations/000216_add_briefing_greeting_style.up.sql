@@ -0,0 +1,3 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS briefing_greeting_style TEXT NOT NULL DEFAULT 'standard'
+    CHECK (briefing_greeting_style IN ('standard', 'casual', 'formal', 'none'));
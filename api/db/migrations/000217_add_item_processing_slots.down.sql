@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS item_processing_slots;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS llm_max_concurrency;
@@ -0,0 +1,14 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS llm_max_concurrency INTEGER
+    CHECK (llm_max_concurrency IS NULL OR llm_max_concurrency BETWEEN 1 AND 10);
+
+-- One row per item while process-item is running its LLM stages. Rows older than the slot TTL
+-- are treated as abandoned.
+CREATE TABLE IF NOT EXISTS item_processing_slots (
+  item_id     UUID        PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_processing_slots_user_acquired
+  ON item_processing_slots (user_id, acquired_at);
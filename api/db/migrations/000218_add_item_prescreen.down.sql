@@ -0,0 +1,5 @@
+ALTER TABLE items
+  DROP COLUMN IF EXISTS prescreen_skipped,
+  DROP COLUMN IF EXISTS prescreen_score;
+
+ALTER TABLE user_settings DROP COLUMN IF EXISTS prescreen_threshold;
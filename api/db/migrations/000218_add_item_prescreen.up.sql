@@ -0,0 +1,9 @@
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS prescreen_threshold DOUBLE PRECISION
+    CHECK (prescreen_threshold IS NULL OR (prescreen_threshold >= 0 AND prescreen_threshold <= 1));
+
+-- prescreen_score is the cheap relevance guess made before fact extraction; items scored below
+-- the owner's threshold get a stub summary and prescreen_skipped = true.
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS prescreen_score DOUBLE PRECISION,
+  ADD COLUMN IF NOT EXISTS prescreen_skipped BOOLEAN NOT NULL DEFAULT false;
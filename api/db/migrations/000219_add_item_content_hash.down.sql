@@ -0,0 +1,3 @@
+ALTER TABLE items
+  DROP COLUMN IF EXISTS summary_input_hash,
+  DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,6 @@
+-- content_hash fingerprints the extracted title and body. summary_input_hash covers the content
+-- hash plus the model settings the stored facts and summary were made with; a retry whose inputs
+-- hash the same keeps them instead of calling the LLM again.
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS content_hash TEXT,
+  ADD COLUMN IF NOT EXISTS summary_input_hash TEXT;
@@ -0,0 +1,9 @@
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS compose_progress_at,
+  DROP COLUMN IF EXISTS compose_stage_started_at,
+  DROP COLUMN IF EXISTS compose_started_at,
+  DROP COLUMN IF EXISTS compose_sections_done,
+  DROP COLUMN IF EXISTS compose_sections_total,
+  DROP COLUMN IF EXISTS compose_clusters_done,
+  DROP COLUMN IF EXISTS compose_clusters_total,
+  DROP COLUMN IF EXISTS compose_stage;
@@ -0,0 +1,10 @@
+-- Progress of the running compose-digest-copy step, polled by GET /api/digests/{id}/progress.
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS compose_stage TEXT,
+  ADD COLUMN IF NOT EXISTS compose_clusters_total INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS compose_clusters_done INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS compose_sections_total INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS compose_sections_done INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS compose_started_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS compose_stage_started_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS compose_progress_at TIMESTAMPTZ;
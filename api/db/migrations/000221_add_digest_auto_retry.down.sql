@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_digests_auto_retry;
+
+ALTER TABLE digests
+  DROP COLUMN IF EXISTS auto_retried_at,
+  DROP COLUMN IF EXISTS auto_retry_count;
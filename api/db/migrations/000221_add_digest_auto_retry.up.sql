@@ -0,0 +1,7 @@
+ALTER TABLE digests
+  ADD COLUMN IF NOT EXISTS auto_retry_count INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS auto_retried_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_digests_auto_retry
+  ON digests (send_tried_at)
+  WHERE send_status IN ('compose_failed', 'send_email_failed') AND sent_at IS NULL;
@@ -0,0 +1,16 @@
+-- Every send_status transition of a digest; digests.send_status keeps the latest one for lists.
+CREATE TABLE IF NOT EXISTS digest_status_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  digest_id UUID NOT NULL REFERENCES digests(id) ON DELETE CASCADE,
+  status TEXT NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_status_events_digest
+  ON digest_status_events (digest_id, created_at);
+
+INSERT INTO digest_status_events (digest_id, status, error, created_at)
+SELECT id, send_status, send_error, COALESCE(send_tried_at, sent_at, created_at, NOW())
+FROM digests
+WHERE send_status IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE user_preference_profiles
+  DROP COLUMN IF EXISTS pref_embedding_model;
@@ -0,0 +1,2 @@
+ALTER TABLE user_preference_profiles
+  ADD COLUMN IF NOT EXISTS pref_embedding_model TEXT;
@@ -0,0 +1,4 @@
+ALTER TABLE stories
+  DROP COLUMN IF EXISTS centroid_dimensions,
+  DROP COLUMN IF EXISTS centroid_model,
+  DROP COLUMN IF EXISTS centroid;
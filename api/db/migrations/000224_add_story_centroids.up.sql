@@ -0,0 +1,4 @@
+ALTER TABLE stories
+  ADD COLUMN IF NOT EXISTS centroid DOUBLE PRECISION[],
+  ADD COLUMN IF NOT EXISTS centroid_model TEXT,
+  ADD COLUMN IF NOT EXISTS centroid_dimensions INT;
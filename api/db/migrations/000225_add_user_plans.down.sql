@@ -0,0 +1,5 @@
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_plan_check;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS plan;
@@ -0,0 +1,9 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS plan TEXT NOT NULL DEFAULT 'free';
+
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_plan_check;
+
+ALTER TABLE users
+  ADD CONSTRAINT users_plan_check
+  CHECK (plan IN ('free', 'pro', 'unlimited'));
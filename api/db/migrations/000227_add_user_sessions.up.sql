@@ -0,0 +1,18 @@
+-- Sign-in sessions seen by the API, so users can review where they are signed in and revoke
+-- API access for a session.
+CREATE TABLE IF NOT EXISTS user_sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider TEXT NOT NULL,
+  session_id TEXT NOT NULL,
+  ip_address TEXT,
+  user_agent TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMPTZ,
+  UNIQUE (provider, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_last_used
+  ON user_sessions (user_id, last_used_at DESC)
+  WHERE revoked_at IS NULL;
@@ -0,0 +1,3 @@
+-- While set, new items are fetched and extracted but no LLM steps run until the user resumes.
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS processing_paused_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+ALTER TABLE user_settings DROP COLUMN IF EXISTS inoreader_read_synced_until;
+DROP TABLE IF EXISTS source_sync_tombstones;
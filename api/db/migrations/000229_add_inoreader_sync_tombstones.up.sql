@@ -0,0 +1,12 @@
+-- Feeds the user deleted after a provider sync added them, so the next sync does not add them back.
+CREATE TABLE IF NOT EXISTS source_sync_tombstones (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider TEXT NOT NULL,
+  url TEXT NOT NULL,
+  deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, provider, url)
+);
+
+-- How far read states have been pushed to Inoreader; reads after it are pushed next.
+ALTER TABLE user_settings
+  ADD COLUMN IF NOT EXISTS inoreader_read_synced_until TIMESTAMPTZ;
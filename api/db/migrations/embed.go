@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so the server binary can apply them
+// itself. File names follow the golang-migrate convention: <version>_<name>.(up|down).sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
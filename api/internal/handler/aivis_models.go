@@ -78,7 +78,7 @@ func (h *AivisModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		} else {
-			http.Error(w, "aivis model sync already running", http.StatusConflict)
+			httpError(w, "aivis model sync already running", http.StatusConflict)
 			return
 		}
 	}
@@ -94,7 +94,7 @@ func (h *AivisModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 		if h.providerUpdateRepo != nil {
 			_ = h.providerUpdateRepo.UpsertSnapshot(r.Context(), "aivis", []string{}, "failed", &msg)
 		}
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 	fetchedAt := time.Now().UTC()
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -65,13 +64,12 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 		Limit      int      `json:"limit"`
 		SourceIDs  []string `json:"source_ids"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	query := strings.TrimSpace(body.Query)
 	if query == "" {
-		http.Error(w, "query is required", http.StatusBadRequest)
+		httpError(w, "query is required", http.StatusBadRequest)
 		return
 	}
 	if body.Days <= 0 {
@@ -89,10 +87,7 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 		writeRepoError(w, err)
 		return
 	}
-	embeddingModel := service.OpenAIEmbeddingModel()
-	if settings.EmbeddingModel != nil && service.IsSupportedOpenAIEmbeddingModel(*settings.EmbeddingModel) {
-		embeddingModel = *settings.EmbeddingModel
-	}
+	embeddingModel := service.UserEmbeddingModel(settings)
 	modelName := chooseAskModel(
 		settings,
 		settings.HasAnthropicAPIKey,
@@ -117,7 +112,7 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 		settings.HasOpenAIAPIKey,
 	)
 	if modelName == nil {
-		http.Error(w, "anthropic or google or fireworks or groq or deepseek or alibaba or mistral or together or moonshot or minimax or xiaomi_mimo_token_plan or xai or zai or openrouter or poe or siliconflow or deepinfra or featherless or cerebras or openai api key is required", http.StatusBadRequest)
+		httpError(w, "anthropic or google or fireworks or groq or deepseek or alibaba or mistral or together or moonshot or minimax or xiaomi_mimo_token_plan or xai or zai or openrouter or poe or siliconflow or deepinfra or featherless or cerebras or openai api key is required", http.StatusBadRequest)
 		return
 	}
 	cacheKey := cacheKeyAsk(userID, query, *modelName, embeddingModel, body.Days, body.UnreadOnly, body.Limit, body.SourceIDs)
@@ -142,19 +137,19 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 	openAIKey, err := h.keyProvider.GetAPIKey(r.Context(), userID, "openai")
 	if err != nil {
 		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
-			http.Error(w, "internal server error", http.StatusInternalServerError)
+			httpError(w, "internal server error", http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	if openAIKey == nil || *openAIKey == "" {
-		http.Error(w, "user openai api key is required", http.StatusBadRequest)
+		httpError(w, "user openai api key is required", http.StatusBadRequest)
 		return
 	}
 	embResp, err := h.openAI.CreateEmbedding(r.Context(), *openAIKey, embeddingModel, query)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("create query embedding: %v", err), http.StatusBadGateway)
+		httpError(w, fmt.Sprintf("create query embedding: %v", err), http.StatusBadGateway)
 		return
 	}
 	recordAskLLMUsage(r.Context(), h.llmUsageRepo, h.cache, "ask", embResp.LLM, &userID)
@@ -175,22 +170,10 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	allKeys := h.keyProvider.GetAllKeys(r.Context(), userID)
-	navKeys := navigatorKeys{
-		anthropicKey: allKeys["anthropic"],
-		googleKey:    allKeys["google"],
-		groqKey:      allKeys["groq"],
-		fireworksKey: allKeys["fireworks"],
-		deepseekKey:  allKeys["deepseek"],
-		alibabaKey:   allKeys["alibaba"],
-		mistralKey:   allKeys["mistral"],
-		xaiKey:       allKeys["xai"],
-		zaiKey:       allKeys["zai"],
-		minimaxKey:   allKeys["minimax"],
-		openAIKey:    h.keyProvider.ResolveOpenAIKey(allKeys, nil),
-	}
-	modelName = chooseAskModel(settings, navKeys.anthropicKey != nil, navKeys.googleKey != nil, navKeys.fireworksKey != nil, navKeys.groqKey != nil, navKeys.deepseekKey != nil, navKeys.alibabaKey != nil, navKeys.mistralKey != nil, allKeys["together"] != nil, allKeys["moonshot"] != nil, navKeys.minimaxKey != nil, allKeys["xiaomi_mimo_token_plan"] != nil, navKeys.xaiKey != nil, navKeys.zaiKey != nil, allKeys["openrouter"] != nil, allKeys["poe"] != nil, allKeys["siliconflow"] != nil, allKeys["deepinfra"] != nil, allKeys["featherless"] != nil, allKeys["cerebras"] != nil, allKeys["openai"] != nil)
+	navKeys := h.askNavigatorKeys(allKeys)
+	modelName = chooseAskModelForKeys(settings, allKeys)
 	if modelName == nil {
-		http.Error(w, "anthropic or google or fireworks or groq or deepseek or alibaba or mistral or together or moonshot or minimax or xai or zai or openrouter or poe or siliconflow or deepinfra or featherless or cerebras or openai api key is required", http.StatusBadRequest)
+		httpError(w, "anthropic or google or fireworks or groq or deepseek or alibaba or mistral or together or moonshot or minimax or xai or zai or openrouter or poe or siliconflow or deepinfra or featherless or cerebras or openai api key is required", http.StatusBadRequest)
 		return
 	}
 	openAIChatKey := h.keyProvider.ResolveOpenAIKey(allKeys, modelName)
@@ -208,7 +191,7 @@ func (h *AskHandler) Ask(w http.ResponseWriter, r *http.Request) {
 	workerCandidates = askWorkerCandidates(candidates)
 	askResp, err := h.worker.AskWithModel(r.Context(), query, workerCandidates, navKeys.anthropicKey, navKeys.googleKey, navKeys.groqKey, navKeys.deepseekKey, navKeys.alibabaKey, navKeys.mistralKey, navKeys.xaiKey, navKeys.zaiKey, navKeys.fireworksKey, openAIChatKey, modelName)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("ask worker: %v", err), http.StatusBadGateway)
+		httpError(w, fmt.Sprintf("ask worker: %v", err), http.StatusBadGateway)
 		return
 	}
 	askResp.LLM = service.NormalizeCatalogPricedUsage("ask", askResp.LLM)
@@ -313,14 +296,13 @@ func (h *AskHandler) Navigator(w http.ResponseWriter, r *http.Request) {
 		Citations    []model.AskCitation  `json:"citations"`
 		RelatedItems []model.AskCandidate `json:"related_items"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	body.Query = strings.TrimSpace(body.Query)
 	body.Answer = strings.TrimSpace(body.Answer)
 	if body.Query == "" || body.Answer == "" {
-		http.Error(w, "query and answer are required", http.StatusBadRequest)
+		httpError(w, "query and answer are required", http.StatusBadRequest)
 		return
 	}
 	settings, err := h.settingsRepo.EnsureDefaults(r.Context(), userID)
@@ -404,7 +386,7 @@ func (h *AskHandler) Navigator(w http.ResponseWriter, r *http.Request) {
 		modelName,
 	)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("ask navigator worker: %v", err), http.StatusBadGateway)
+		httpError(w, fmt.Sprintf("ask navigator worker: %v", err), http.StatusBadGateway)
 		return
 	}
 	recordAskLLMUsage(r.Context(), h.llmUsageRepo, h.cache, "ask_navigator", resp.LLM, &userID)
@@ -524,6 +506,31 @@ func trimAskCandidates(candidates []model.AskCandidate, limit int) []model.AskCa
 	return candidates[:limit]
 }
 
+func (h *AskHandler) askNavigatorKeys(allKeys map[string]*string) navigatorKeys {
+	return askNavigatorKeysFor(h.keyProvider, allKeys)
+}
+
+func askNavigatorKeysFor(keyProvider *service.UserKeyProvider, allKeys map[string]*string) navigatorKeys {
+	return navigatorKeys{
+		anthropicKey: allKeys["anthropic"],
+		googleKey:    allKeys["google"],
+		groqKey:      allKeys["groq"],
+		fireworksKey: allKeys["fireworks"],
+		deepseekKey:  allKeys["deepseek"],
+		alibabaKey:   allKeys["alibaba"],
+		mistralKey:   allKeys["mistral"],
+		xaiKey:       allKeys["xai"],
+		zaiKey:       allKeys["zai"],
+		minimaxKey:   allKeys["minimax"],
+		openAIKey:    keyProvider.ResolveOpenAIKey(allKeys, nil),
+	}
+}
+
+// chooseAskModelForKeys picks the ask model based on the keys the user actually has decrypted.
+func chooseAskModelForKeys(settings *model.UserSettings, allKeys map[string]*string) *string {
+	return chooseAskModel(settings, allKeys["anthropic"] != nil, allKeys["google"] != nil, allKeys["fireworks"] != nil, allKeys["groq"] != nil, allKeys["deepseek"] != nil, allKeys["alibaba"] != nil, allKeys["mistral"] != nil, allKeys["together"] != nil, allKeys["moonshot"] != nil, allKeys["minimax"] != nil, allKeys["xiaomi_mimo_token_plan"] != nil, allKeys["xai"] != nil, allKeys["zai"] != nil, allKeys["openrouter"] != nil, allKeys["poe"] != nil, allKeys["siliconflow"] != nil, allKeys["deepinfra"] != nil, allKeys["featherless"] != nil, allKeys["cerebras"] != nil, allKeys["openai"] != nil)
+}
+
 func chooseAskModel(settings *model.UserSettings, hasAnthropic, hasGoogle, hasFireworks, hasGroq, hasDeepSeek, hasAlibaba, hasMistral, hasTogether, hasMoonshot, hasMiniMax, hasXiaomiMiMoTokenPlan, hasXAI, hasZAI, hasOpenRouter, hasPoe, hasSiliconFlow, hasDeepInfra, hasFeatherless, hasCerebras, hasOpenAI bool) *string {
 	if settings != nil && settings.AskModel != nil && strings.TrimSpace(*settings.AskModel) != "" {
 		v := strings.TrimSpace(*settings.AskModel)
@@ -1,20 +1,25 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/model"
-	"github.com/enjoydarts/sifto/api/internal/repository"
 )
 
+type askInsightStore interface {
+	Save(ctx context.Context, insight model.AskInsight, itemIDs []string) (model.AskInsight, error)
+	ListRecent(ctx context.Context, userID string, limit int) ([]model.AskInsight, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
 type AskInsightsHandler struct {
-	repo *repository.AskInsightRepo
+	repo askInsightStore
 }
 
-func NewAskInsightsHandler(repo *repository.AskInsightRepo) *AskInsightsHandler {
+func NewAskInsightsHandler(repo askInsightStore) *AskInsightsHandler {
 	return &AskInsightsHandler{repo: repo}
 }
 
@@ -28,12 +33,11 @@ func (h *AskInsightsHandler) Save(w http.ResponseWriter, r *http.Request) {
 		Tags    []string `json:"tags"`
 		ItemIDs []string `json:"item_ids"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if strings.TrimSpace(body.Title) == "" || strings.TrimSpace(body.Body) == "" {
-		http.Error(w, "title and body are required", http.StatusBadRequest)
+		httpError(w, "title and body are required", http.StatusBadRequest)
 		return
 	}
 	insight, err := h.repo.Save(r.Context(), model.AskInsight{
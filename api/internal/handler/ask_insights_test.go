@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encoding/json"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestAskInsightsHandlerSaveListDelete(t *testing.T) {
+	store := repotest.NewAskInsightStore()
+	h := NewAskInsightsHandler(store)
+
+	rec := httptest.NewRecorder()
+	h.Save(rec, userRequest(http.MethodPost, "/ask/insights", "u1", `{"title":" ","body":"x"}`, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("blank title status = %d, want 400", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Save(rec, userRequest(http.MethodPost, "/ask/insights", "u1", `{"title":" T ","body":"B","tags":["a"," a ",""],"item_ids":["i1"]}`, nil))
+	var saved model.AskInsight
+	if err := json.Unmarshal(rec.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("decode: %v body=%s", err, rec.Body.String())
+	}
+	if saved.Title != "T" || len(saved.Tags) != 1 || len(saved.Items) != 1 {
+		t.Fatalf("saved = %+v", saved)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ListRecent(rec, userRequest(http.MethodGet, "/ask/insights", "u2", "", nil))
+	var listed struct {
+		Insights []model.AskInsight `json:"insights"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(listed.Insights) != 0 {
+		t.Fatalf("other user sees %d insights", len(listed.Insights))
+	}
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/", "u2", "", nil), saved.ID)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign delete status = %d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/", "u1", "", nil), saved.ID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("delete status = %d", rec.Code)
+	}
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
@@ -31,7 +30,7 @@ func NewAudioBriefingPresetsHandler(settings audioBriefingPresetsService) *Audio
 
 func (h *AudioBriefingPresetsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.settings == nil {
-		http.Error(w, "audio briefing presets unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing presets unavailable", http.StatusInternalServerError)
 		return
 	}
 	items, err := h.settings.ListAudioBriefingPresets(r.Context(), middleware.GetUserID(r))
@@ -48,21 +47,20 @@ func (h *AudioBriefingPresetsHandler) List(w http.ResponseWriter, r *http.Reques
 
 func (h *AudioBriefingPresetsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.settings == nil {
-		http.Error(w, "audio briefing presets unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing presets unavailable", http.StatusInternalServerError)
 		return
 	}
 	var body service.SaveAudioBriefingPresetInput
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	preset, err := h.settings.CreateAudioBriefingPreset(r.Context(), middleware.GetUserID(r), body)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 		}
 		return
 	}
@@ -71,28 +69,27 @@ func (h *AudioBriefingPresetsHandler) Create(w http.ResponseWriter, r *http.Requ
 
 func (h *AudioBriefingPresetsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.settings == nil {
-		http.Error(w, "audio briefing presets unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing presets unavailable", http.StatusInternalServerError)
 		return
 	}
 	presetID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if presetID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	var body service.SaveAudioBriefingPresetInput
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	preset, err := h.settings.UpdateAudioBriefingPreset(r.Context(), middleware.GetUserID(r), presetID, body)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, err.Error(), http.StatusNotFound)
 		case errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 		}
 		return
 	}
@@ -101,22 +98,22 @@ func (h *AudioBriefingPresetsHandler) Update(w http.ResponseWriter, r *http.Requ
 
 func (h *AudioBriefingPresetsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.settings == nil {
-		http.Error(w, "audio briefing presets unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing presets unavailable", http.StatusInternalServerError)
 		return
 	}
 	presetID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if presetID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if err := h.settings.DeleteAudioBriefingPreset(r.Context(), middleware.GetUserID(r), presetID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, err.Error(), http.StatusNotFound)
 		case errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 		}
 		return
 	}
@@ -140,8 +140,8 @@ func TestAudioBriefingPresetsHandlerRejectsInvalidJSON(t *testing.T) {
 	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, "u1"))
 	rr := httptest.NewRecorder()
 	h.Create(rr, req)
-	if rr.Code != http.StatusBadRequest {
-		t.Fatalf("status = %d, want 400", rr.Code)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422", rr.Code)
 	}
 }
 
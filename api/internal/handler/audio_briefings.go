@@ -56,7 +56,7 @@ func NewAudioBriefingsHandler(
 
 func (h *AudioBriefingsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -77,7 +77,7 @@ func (h *AudioBriefingsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 func (h *AudioBriefingsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -92,7 +92,7 @@ func (h *AudioBriefingsHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 func (h *AudioBriefingsHandler) Generate(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil || h.orchestrator == nil || h.eventPublisher == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -102,7 +102,7 @@ func (h *AudioBriefingsHandler) Generate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if err := h.enqueueRun(userID, job.ID, "manual"); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	payload, err := h.loadDetail(r.Context(), userID, job.ID)
@@ -115,25 +115,25 @@ func (h *AudioBriefingsHandler) Generate(w http.ResponseWriter, r *http.Request)
 
 func (h *AudioBriefingsHandler) StartConcat(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil || h.concatStarter == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if err := h.concatStarter.Start(r.Context(), userID, jobID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		case errors.Is(err, service.ErrAudioConcatRunnerDisabled):
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			httpError(w, err.Error(), http.StatusServiceUnavailable)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
@@ -147,29 +147,29 @@ func (h *AudioBriefingsHandler) StartConcat(w http.ResponseWriter, r *http.Reque
 
 func (h *AudioBriefingsHandler) Resume(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil || h.orchestrator == nil || h.eventPublisher == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	job, err := h.orchestrator.Resume(r.Context(), userID, jobID)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
 	if err := h.enqueueRun(userID, job.ID, "resume"); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	payload, err := h.loadDetail(r.Context(), userID, job.ID)
@@ -199,23 +199,23 @@ func (h *AudioBriefingsHandler) enqueueRun(userID, jobID, trigger string) error
 
 func (h *AudioBriefingsHandler) StartVoicing(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil || h.voiceRunner == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if _, err := h.voiceRunner.Start(r.Context(), userID, jobID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
@@ -229,23 +229,23 @@ func (h *AudioBriefingsHandler) StartVoicing(w http.ResponseWriter, r *http.Requ
 
 func (h *AudioBriefingsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil || h.deleteService == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if err := h.deleteService.Delete(r.Context(), userID, jobID); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
@@ -328,13 +328,13 @@ func audioBriefingUsedTTS(chunks []model.AudioBriefingScriptChunk) *audioBriefin
 
 func (h *AudioBriefingsHandler) updateArchiveStatus(w http.ResponseWriter, r *http.Request, archiveStatus string) {
 	if h.repo == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	job, err := h.repo.GetJobByID(r.Context(), userID, jobID)
@@ -345,26 +345,26 @@ func (h *AudioBriefingsHandler) updateArchiveStatus(w http.ResponseWriter, r *ht
 	switch archiveStatus {
 	case "archived":
 		if !service.AudioBriefingArchiveAllowed(job) {
-			http.Error(w, repository.ErrInvalidState.Error(), http.StatusConflict)
+			httpError(w, repository.ErrInvalidState.Error(), http.StatusConflict)
 			return
 		}
 	case "active":
 		if !service.AudioBriefingUnarchiveAllowed(job) {
-			http.Error(w, repository.ErrInvalidState.Error(), http.StatusConflict)
+			httpError(w, repository.ErrInvalidState.Error(), http.StatusConflict)
 			return
 		}
 	default:
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if _, err := h.repo.UpdateArchiveStatus(r.Context(), userID, jobID, archiveStatus); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
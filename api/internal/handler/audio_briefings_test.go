@@ -69,8 +69,8 @@ func TestResolvePlayableAudioURLPresignsObjectKey(t *testing.T) {
 		if r.URL.Path != "/audio-briefing/presign" {
 			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
-		if got := strings.TrimSpace(r.Header.Get("X-Internal-Worker-Secret")); got != "test-secret" {
-			t.Fatalf("unexpected worker secret: %q", got)
+		if err := service.NewInternalRequestVerifier().Verify(r, []string{"test-secret"}); err != nil {
+			t.Fatalf("worker request signature: %v", err)
 		}
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+// recordAudit logs an action by the request's user with its IP address and user agent. A nil
+// audit log records nothing.
+func recordAudit(audit *service.AuditLogService, r *http.Request, action, target string, metadata map[string]any) {
+	if audit == nil {
+		return
+	}
+	audit.Record(r.Context(), service.AuditEvent{
+		UserID:    middleware.GetUserID(r),
+		Action:    action,
+		Target:    target,
+		Metadata:  metadata,
+		IPAddress: middleware.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+}
+
+// GetAuditLog pages through the user's account activity, newest first.
+func (h *SettingsHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	if h.auditLog == nil {
+		httpError(w, "audit log unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	q := r.URL.Query()
+	limit := 0
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			httpError(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	page, err := h.auditLog.List(r.Context(), middleware.GetUserID(r), q.Get("before"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditLogCursor) {
+			httpError(w, "invalid before", http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, page)
+}
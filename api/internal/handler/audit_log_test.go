@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type fakeAuditLogRepo struct{}
+
+func (fakeAuditLogRepo) Insert(context.Context, repository.AuditLogInsert) error { return nil }
+
+func (fakeAuditLogRepo) ListByUser(context.Context, string, *repository.AuditLogCursor, int) ([]model.AuditLogEntry, error) {
+	return []model.AuditLogEntry{}, nil
+}
+
+func TestGetAuditLog(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&SettingsHandler{}).GetAuditLog(rec, httptest.NewRequest(http.MethodGet, "/api/settings/audit-log", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("without audit log code = %d", rec.Code)
+	}
+
+	h := (&SettingsHandler{}).WithAuditLog(service.NewAuditLogService(fakeAuditLogRepo{}))
+	for target, want := range map[string]int{
+		"/api/settings/audit-log":               http.StatusOK,
+		"/api/settings/audit-log?limit=0":       http.StatusBadRequest,
+		"/api/settings/audit-log?before=%21%21": http.StatusBadRequest,
+		// "1714564800000000:not-a-uuid"
+		"/api/settings/audit-log?before=MTcxNDU2NDgwMDAwMDAwMDpub3QtYS11dWlk": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		h.GetAuditLog(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("%s code = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
@@ -40,7 +40,7 @@ func (h *AzureSpeechVoicesHandler) List(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "azure speech api key is not configured", http.StatusBadRequest)
+		httpError(w, "azure speech api key is not configured", http.StatusBadRequest)
 		return
 	}
 	region, err := h.settingsRepo.GetAzureSpeechRegion(r.Context(), userID)
@@ -49,12 +49,12 @@ func (h *AzureSpeechVoicesHandler) List(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if region == nil || strings.TrimSpace(*region) == "" {
-		http.Error(w, "azure speech region is not configured", http.StatusBadRequest)
+		httpError(w, "azure speech region is not configured", http.StatusBadRequest)
 		return
 	}
 	catalog, err := h.service.FetchVoices(r.Context(), strings.TrimSpace(*apiKey), strings.TrimSpace(*region))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	if catalog == nil {
@@ -19,6 +19,7 @@ import (
 )
 
 var briefingSnapshotMaxAge = loadBriefingSnapshotMaxAge()
+var briefingRefreshMinInterval = loadBriefingDuration("BRIEFING_REFRESH_MIN_INTERVAL_SEC", time.Minute)
 
 const briefingNavigatorCacheTTL = 30 * time.Minute
 const briefingNavigatorPersonaHistoryTTL = 30 * 24 * time.Hour
@@ -78,6 +79,7 @@ func (h *BriefingHandler) Today(w http.ResponseWriter, r *http.Request) {
 		var cached model.BriefingTodayResponse
 		if ok, err := h.cache.GetJSON(r.Context(), cacheKey, &cached); err == nil && ok {
 			cached.Navigator = nil
+			annotateBriefingFreshness(&cached, now)
 			incrCacheMetric(r.Context(), h.cache, userID, "briefing.hit")
 			writeJSON(w, cached)
 			return
@@ -101,13 +103,23 @@ func (h *BriefingHandler) Today(w http.ResponseWriter, r *http.Request) {
 				if payload.Date == "" {
 					payload.Date = dateStr
 				}
-				if payload.Greeting == "" {
+				if payload.Greeting == "" && payload.GreetingStyle == "" {
 					payload.Greeting = service.GreetingByHour(timeutil.NowJST())
 				}
+				if payload.Blocks == nil {
+					payload.Blocks = []model.BriefingBlock{}
+				}
 				payload.Status = s.Status
 				payload.GeneratedAt = s.GeneratedAt
 				payload.Navigator = nil
-				if !cacheBust && isSnapshotFresh(s.GeneratedAt, now) {
+				rebuild, limited := briefingSnapshotAction(s.Status, s.GeneratedAt, now, cacheBust)
+				if !rebuild {
+					h.touchBriefingViewed(r.Context(), userID, dateStr)
+					annotateBriefingFreshness(&payload, now)
+					if limited {
+						payload.RefreshLimited = true
+						w.Header().Set("Retry-After", strconv.Itoa(briefingRefreshRetryAfter(s.GeneratedAt, now)))
+					}
 					writeJSON(w, payload)
 					return
 				}
@@ -117,9 +129,10 @@ func (h *BriefingHandler) Today(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	payload, err := service.BuildBriefingToday(r.Context(), h.itemRepo, h.streakRepo, userID, today, size)
+	payload, err := service.BuildBriefingToday(r.Context(), h.itemRepo, h.streakRepo, h.settingsRepo, userID, today, size)
 	if err != nil {
 		if fallbackSnapshot != nil {
+			annotateBriefingFreshness(fallbackSnapshot, now)
 			if h.cache != nil {
 				if cacheErr := h.cache.SetJSON(r.Context(), cacheKey, fallbackSnapshot, 15*time.Second); cacheErr != nil {
 					log.Printf("briefing cache set stale failed user_id=%s key=%s err=%v", userID, cacheKey, cacheErr)
@@ -135,9 +148,12 @@ func (h *BriefingHandler) Today(w http.ResponseWriter, r *http.Request) {
 	generatedAt := now
 	payload.GeneratedAt = &generatedAt
 	payload.Navigator = nil
+	annotateBriefingFreshness(payload, now)
 	if h.snapshotRepo != nil {
 		if err := h.snapshotRepo.Upsert(r.Context(), userID, dateStr, "ready", payload); err != nil {
 			log.Printf("briefing snapshot upsert user=%s date=%s: %v", userID, dateStr, err)
+		} else {
+			h.touchBriefingViewed(r.Context(), userID, dateStr)
 		}
 	}
 	if h.cache != nil {
@@ -149,6 +165,48 @@ func (h *BriefingHandler) Today(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, payload)
 }
 
+func (h *BriefingHandler) touchBriefingViewed(ctx context.Context, userID, date string) {
+	if err := h.snapshotRepo.TouchViewed(ctx, userID, date); err != nil {
+		log.Printf("briefing snapshot touch viewed user=%s date=%s: %v", userID, date, err)
+	}
+}
+
+// briefingSnapshotAction decides whether Today serves the stored snapshot or rebuilds it. A
+// snapshot is rebuilt when it is stale, too old or force is set, but never before it is
+// briefingRefreshMinInterval old; limited reports a rebuild that was due but held back.
+func briefingSnapshotAction(status string, generatedAt *time.Time, now time.Time, force bool) (rebuild, limited bool) {
+	if !force && status != "stale" && isSnapshotFresh(generatedAt, now) {
+		return false, false
+	}
+	if generatedAt != nil && now.Sub(*generatedAt) < briefingRefreshMinInterval {
+		return false, true
+	}
+	return true, false
+}
+
+func briefingRefreshRetryAfter(generatedAt *time.Time, now time.Time) int {
+	if generatedAt == nil {
+		return 1
+	}
+	sec := int((briefingRefreshMinInterval - now.Sub(*generatedAt)).Seconds())
+	if sec < 1 {
+		return 1
+	}
+	return sec
+}
+
+func annotateBriefingFreshness(payload *model.BriefingTodayResponse, now time.Time) {
+	payload.Stale = payload.Status == "stale" || !isSnapshotFresh(payload.GeneratedAt, now)
+	payload.AgeSec = nil
+	if payload.GeneratedAt != nil {
+		age := int(now.Sub(*payload.GeneratedAt).Seconds())
+		if age < 0 {
+			age = 0
+		}
+		payload.AgeSec = &age
+	}
+}
+
 func (h *BriefingHandler) Navigator(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -337,14 +395,17 @@ func isSnapshotFresh(generatedAt *time.Time, now time.Time) bool {
 }
 
 func loadBriefingSnapshotMaxAge() time.Duration {
-	const defaultMaxAge = 10 * time.Minute
-	v := strings.TrimSpace(os.Getenv("BRIEFING_SNAPSHOT_MAX_AGE_SEC"))
+	return loadBriefingDuration("BRIEFING_SNAPSHOT_MAX_AGE_SEC", 10*time.Minute)
+}
+
+func loadBriefingDuration(name string, fallback time.Duration) time.Duration {
+	v := strings.TrimSpace(os.Getenv(name))
 	if v == "" {
-		return defaultMaxAge
+		return fallback
 	}
 	sec, err := strconv.Atoi(v)
 	if err != nil || sec <= 0 {
-		return defaultMaxAge
+		return fallback
 	}
 	return time.Duration(sec) * time.Second
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
 )
 
 func TestBuildBriefingNavigatorIntroContext(t *testing.T) {
@@ -79,3 +80,51 @@ func TestHasNavigatorProviderKeySupportsDeepInfra(t *testing.T) {
 		t.Fatal("hasNavigatorProviderKey(deepinfra) = false, want true")
 	}
 }
+
+func TestBriefingSnapshotAction(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, timeutil.JST)
+	at := func(ago time.Duration) *time.Time {
+		v := now.Add(-ago)
+		return &v
+	}
+	cases := []struct {
+		name        string
+		status      string
+		generatedAt *time.Time
+		force       bool
+		wantRebuild bool
+		wantLimited bool
+	}{
+		{"fresh", "ready", at(2 * time.Minute), false, false, false},
+		{"forced refresh", "ready", at(2 * time.Minute), true, true, false},
+		{"forced refresh too soon", "ready", at(10 * time.Second), true, false, true},
+		{"marked stale", "stale", at(5 * time.Minute), false, true, false},
+		{"marked stale too soon", "stale", at(10 * time.Second), false, false, true},
+		{"expired", "ready", at(briefingSnapshotMaxAge + time.Minute), false, true, false},
+		{"never generated", "pending", nil, false, true, false},
+	}
+	for _, tc := range cases {
+		rebuild, limited := briefingSnapshotAction(tc.status, tc.generatedAt, now, tc.force)
+		if rebuild != tc.wantRebuild || limited != tc.wantLimited {
+			t.Fatalf("%s: got rebuild=%v limited=%v, want rebuild=%v limited=%v", tc.name, rebuild, limited, tc.wantRebuild, tc.wantLimited)
+		}
+	}
+	if got := briefingRefreshRetryAfter(at(10*time.Second), now); got != int((briefingRefreshMinInterval - 10*time.Second).Seconds()) {
+		t.Fatalf("briefingRefreshRetryAfter() = %d", got)
+	}
+}
+
+func TestAnnotateBriefingFreshness(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, timeutil.JST)
+	generatedAt := now.Add(-90 * time.Second)
+	payload := model.BriefingTodayResponse{Status: "ready", GeneratedAt: &generatedAt}
+	annotateBriefingFreshness(&payload, now)
+	if payload.Stale || payload.AgeSec == nil || *payload.AgeSec != 90 {
+		t.Fatalf("fresh payload = stale:%v age:%v", payload.Stale, payload.AgeSec)
+	}
+	payload.Status = "stale"
+	annotateBriefingFreshness(&payload, now)
+	if !payload.Stale {
+		t.Fatal("stale status not reported")
+	}
+}
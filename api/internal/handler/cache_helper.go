@@ -2,12 +2,44 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/service"
+	"golang.org/x/sync/singleflight"
 )
 
+var requestGroup singleflight.Group
+
+// coalesce runs fn once per key across concurrent callers and hands every caller the same
+// result, so a cache miss under load triggers a single heavy query. Results are shared, so
+// callers must not mutate them.
+func coalesce[T any](ctx context.Context, key string, counter *cacheCounter, fn func() (T, error)) (T, error) {
+	var zero T
+	ch := requestGroup.DoChan(key, func() (any, error) {
+		return fn()
+	})
+	select {
+	case res := <-ch:
+		if res.Shared && counter != nil {
+			counter.coalesced.Add(1)
+		}
+		if res.Err != nil {
+			// The leading request may have been cancelled by its client; that must not fail
+			// the callers that joined it, so they compute on their own instead.
+			if res.Shared && ctx.Err() == nil && (errors.Is(res.Err, context.Canceled) || errors.Is(res.Err, context.DeadlineExceeded)) {
+				return fn()
+			}
+			return zero, res.Err
+		}
+		v, _ := res.Val.(T)
+		return v, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
 func cachedFetch[T any](ctx context.Context, cache service.JSONCache, key string, ttl time.Duration, fetchFn func() (T, error)) (T, error) {
 	var zero T
 	if cache != nil {
@@ -35,6 +67,7 @@ type cacheFetchOptions struct {
 	logKeyPrefix        string
 	skipCacheSet        bool
 	cacheSetTTLOverride time.Duration
+	coalesce            bool
 }
 
 func cachedFetchWithOpts[T any](ctx context.Context, cache service.JSONCache, key string, ttl time.Duration, fetchFn func() (T, error), opts cacheFetchOptions) (T, error) {
@@ -78,25 +111,31 @@ func cachedFetchWithOpts[T any](ctx context.Context, cache service.JSONCache, ke
 		}
 	}
 
-	result, err := fetchFn()
-	if err != nil {
-		return zero, err
-	}
-
-	if !opts.skipCacheSet && cache != nil && opts.cacheKeyErr == nil {
-		setTTL := ttl
-		if opts.cacheSetTTLOverride > 0 {
-			setTTL = opts.cacheSetTTLOverride
+	load := func() (T, error) {
+		result, err := fetchFn()
+		if err != nil {
+			return zero, err
 		}
-		if err := cache.SetJSON(ctx, key, result, setTTL); err != nil {
-			if opts.counter != nil {
-				opts.counter.errors.Add(1)
+
+		if !opts.skipCacheSet && cache != nil && opts.cacheKeyErr == nil {
+			setTTL := ttl
+			if opts.cacheSetTTLOverride > 0 {
+				setTTL = opts.cacheSetTTLOverride
 			}
-			if opts.metricPrefix != "" {
-				incrCacheMetric(ctx, cache, opts.userID, opts.metricPrefix+".error")
+			if err := cache.SetJSON(ctx, key, result, setTTL); err != nil {
+				if opts.counter != nil {
+					opts.counter.errors.Add(1)
+				}
+				if opts.metricPrefix != "" {
+					incrCacheMetric(ctx, cache, opts.userID, opts.metricPrefix+".error")
+				}
+				log.Printf("%s cache set failed user_id=%s key=%s err=%v", opts.logKeyPrefix, opts.userID, key, err)
 			}
-			log.Printf("%s cache set failed user_id=%s key=%s err=%v", opts.logKeyPrefix, opts.userID, key, err)
 		}
+		return result, nil
 	}
-	return result, nil
+	if opts.coalesce && opts.cacheKeyErr == nil {
+		return coalesce(ctx, key, opts.counter, load)
+	}
+	return load()
 }
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceSharesOneComputation(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var counter cacheCounter
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := coalesce(context.Background(), "test:coalesce", &counter, fn)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("fn called %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("results[%d] = %d, want 42", i, v)
+		}
+	}
+	if counter.coalesced.Load() == 0 {
+		t.Fatal("expected coalesced requests to be counted")
+	}
+}
+
+func TestCoalesceRecomputesWhenLeaderCancelled(t *testing.T) {
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	leader := func() (string, error) {
+		close(started)
+		<-leaderCtx.Done()
+		return "", leaderCtx.Err()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := coalesce(leaderCtx, "test:coalesce-cancel", nil, leader)
+		done <- err
+	}()
+	<-started
+
+	followerResult := make(chan string, 1)
+	go func() {
+		v, err := coalesce(context.Background(), "test:coalesce-cancel", nil, func() (string, error) { return "own", nil })
+		if err != nil {
+			t.Errorf("follower error: %v", err)
+		}
+		followerResult <- v
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err == nil {
+		t.Fatal("leader should observe its cancellation")
+	}
+	if v := <-followerResult; v != "own" {
+		t.Fatalf("follower result = %q, want own", v)
+	}
+}
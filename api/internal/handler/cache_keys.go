@@ -7,12 +7,13 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 )
 
 const cacheKeyVersion = "v1"
 const navigatorCacheKeyVersion = "v2"
-const itemsListCacheSchemaVersion = 3
+const itemsListCacheSchemaVersion = 4
 const itemDetailCacheSchemaVersion = 3
 
 func cacheVersionKeyUserItems(userID string) string {
@@ -57,9 +58,9 @@ func cacheKeyItemsList(userID, status, sourceID, topic, genre, query, searchMode
 	)
 }
 
-func cacheKeyItemsListVersioned(userID string, version int64, status, sourceID, topic, genre, query, searchMode string, unreadOnly, readOnly, favoriteOnly, laterOnly bool, sort string, page, pageSize int) string {
+func cacheKeyItemsListVersioned(userID string, version int64, status, sourceID, topic, genre, language, depth, query, searchMode string, unreadOnly, readOnly, favoriteOnly, laterOnly bool, sort string, page, pageSize int) string {
 	return fmt.Sprintf(
-		"%s:items:list:%s:sv=%d:v=%d:status=%s:source=%s:topic=%s:genre=%s:q=%s:mode=%s:unread=%t:read=%t:fav=%t:later=%t:sort=%s:page=%d:size=%d",
+		"%s:items:list:%s:sv=%d:v=%d:status=%s:source=%s:topic=%s:genre=%s:lang=%s:depth=%s:q=%s:mode=%s:unread=%t:read=%t:fav=%t:later=%t:sort=%s:page=%d:size=%d",
 		cacheKeyVersion,
 		userID,
 		itemsListCacheSchemaVersion,
@@ -68,6 +69,8 @@ func cacheKeyItemsListVersioned(userID string, version int64, status, sourceID,
 		sourceID,
 		topic,
 		genre,
+		language,
+		depth,
 		query,
 		searchMode,
 		unreadOnly,
@@ -80,8 +83,8 @@ func cacheKeyItemsListVersioned(userID string, version int64, status, sourceID,
 	)
 }
 
-func cacheKeyReadingPlan(userID, window string, size int, diversifyTopics, excludeRead, excludeLater bool) string {
-	return fmt.Sprintf("%s:items:reading-plan:%s:window=%s:size=%d:div=%t:exclude_read=%t:exclude_later=%t", cacheKeyVersion, userID, window, size, diversifyTopics, excludeRead, excludeLater)
+func cacheKeyReadingPlan(userID, window string, size int, diversifyTopics, excludeRead, excludeLater, prioritizeSnoozed bool, exploration float64, depth string, availableMinutes int) string {
+	return fmt.Sprintf("%s:items:reading-plan:%s:window=%s:size=%d:div=%t:exclude_read=%t:exclude_later=%t:snoozed_first=%t:explore=%.2f:depth=%s:minutes=%d", cacheKeyVersion, userID, window, size, diversifyTopics, excludeRead, excludeLater, prioritizeSnoozed, exploration, depth, availableMinutes)
 }
 
 func cacheKeyFocusQueue(userID, window string, size int, diversifyTopics, excludeLater bool) string {
@@ -100,6 +103,10 @@ func cacheKeyRelated(userID, itemID string, limit int) string {
 	return fmt.Sprintf("%s:items:related:%s:item=%s:limit=%d", cacheKeyVersion, userID, itemID, limit)
 }
 
+func cacheKeyRelatedHybrid(userID, itemID string, limit int, p repository.RelatedFusionParams) string {
+	return fmt.Sprintf("%s:items:related:%s:item=%s:limit=%d:hybrid=%g,%g,%d", cacheKeyVersion, userID, itemID, limit, p.EmbeddingWeight, p.TextWeight, p.K)
+}
+
 func cacheKeyBriefingToday(userID string, size int) string {
 	return fmt.Sprintf("%s:briefing:today:%s:size=%d", cacheKeyVersion, userID, size)
 }
@@ -140,6 +147,12 @@ func cacheKeyDashboard(userID string, llmDays, topicLimit, digestLimit int) stri
 	return fmt.Sprintf("%s:dashboard:snapshot:%s:llm=%d:topic=%d:digest=%d", cacheKeyVersion, userID, llmDays, topicLimit, digestLimit)
 }
 
+// cacheKeyDashboardLastGood is outside the dashboard:snapshot prefix so user cache
+// invalidation does not drop the copy served while the database is unhealthy.
+func cacheKeyDashboardLastGood(userID string, llmDays, topicLimit, digestLimit int) string {
+	return fmt.Sprintf("%s:dashboard:lastgood:%s:llm=%d:topic=%d:digest=%d", cacheKeyVersion, userID, llmDays, topicLimit, digestLimit)
+}
+
 func cacheKeyDashboardPart(userID, part string, p1, p2 int) string {
 	switch part {
 	case "sources", "itemstats", "failedpreview":
@@ -148,7 +161,7 @@ func cacheKeyDashboardPart(userID, part string, p1, p2 int) string {
 		return fmt.Sprintf("%s:dashboard:part:%s:%s:limit=%d", cacheKeyVersion, userID, part, p1)
 	case "llm":
 		return fmt.Sprintf("%s:dashboard:part:%s:%s:days=%d", cacheKeyVersion, userID, part, p1)
-	case "topics":
+	case "topics", "entities":
 		return fmt.Sprintf("%s:dashboard:part:%s:%s:limit=%d", cacheKeyVersion, userID, part, p1)
 	default:
 		return fmt.Sprintf("%s:dashboard:part:%s:%s:%d:%d", cacheKeyVersion, userID, part, p1, p2)
@@ -209,6 +222,14 @@ func cacheKeyLLMUsageModelSummaryMonthVersioned(userID string, version int64, mo
 	return fmt.Sprintf("%s:llm_usage:model:%s:v=%d:month=%s", cacheKeyVersion, userID, version, month)
 }
 
+func cacheKeyLLMUsageSourceSummaryVersioned(userID string, version int64, days int) string {
+	return fmt.Sprintf("%s:llm_usage:source:%s:v=%d:days=%d", cacheKeyVersion, userID, version, days)
+}
+
+func cacheKeyLLMUsageSourceSummaryMonthVersioned(userID string, version int64, month string) string {
+	return fmt.Sprintf("%s:llm_usage:source:%s:v=%d:month=%s", cacheKeyVersion, userID, version, month)
+}
+
 func cacheKeyLLMUsageAnalysisVersioned(userID string, version int64, days int) string {
 	return fmt.Sprintf("%s:llm_usage:analysis:%s:v=%d:days=%d", cacheKeyVersion, userID, version, days)
 }
@@ -254,3 +275,7 @@ func cacheUserInvalidatePrefixes(userID string) []string {
 		fmt.Sprintf("%s:ask:%s:", cacheKeyVersion, userID),
 	}
 }
+
+func cacheKeyItemsListFields(listKey string, fields []string) string {
+	return listKey + ":fields=" + strings.Join(fields, ",")
+}
@@ -24,13 +24,15 @@ func TestCacheKeyVersionKeys(t *testing.T) {
 }
 
 func TestCacheKeyItemsListVersioned(t *testing.T) {
-	got := cacheKeyItemsListVersioned("u1", 7, "summarized", "src-1", "go", "analysis", "openai", "and", true, false, true, false, "score", 2, 50)
+	got := cacheKeyItemsListVersioned("u1", 7, "summarized", "src-1", "go", "analysis", "en", "tutorial", "openai", "and", true, false, true, false, "score", 2, 50)
 	wantParts := []string{
-		"v1:items:list:u1:sv=3:v=7",
+		"v1:items:list:u1:sv=4:v=7",
 		"status=summarized",
 		"source=src-1",
 		"topic=go",
 		"genre=analysis",
+		"lang=en",
+		"depth=tutorial",
 		"q=openai",
 		"mode=and",
 		"unread=true",
@@ -92,6 +94,12 @@ func TestCacheKeyLLMUsageVersioned(t *testing.T) {
 	if got, want = cacheKeyLLMUsageModelSummaryVersioned("u1", 5, 30), "v1:llm_usage:model:u1:v=5:days=30"; got != want {
 		t.Fatalf("cacheKeyLLMUsageModelSummaryVersioned = %q, want %q", got, want)
 	}
+	if got, want = cacheKeyLLMUsageSourceSummaryVersioned("u1", 5, 30), "v1:llm_usage:source:u1:v=5:days=30"; got != want {
+		t.Fatalf("cacheKeyLLMUsageSourceSummaryVersioned = %q, want %q", got, want)
+	}
+	if got, want = cacheKeyLLMUsageSourceSummaryMonthVersioned("u1", 5, "2026-03"), "v1:llm_usage:source:u1:v=5:month=2026-03"; got != want {
+		t.Fatalf("cacheKeyLLMUsageSourceSummaryMonthVersioned = %q, want %q", got, want)
+	}
 	if got, want = cacheKeyLLMUsageProviderCurrentMonthVersioned("u1", 2, "2026-03"), "v1:llm_usage:provider_current_month:u1:v=2:month=2026-03"; got != want {
 		t.Fatalf("cacheKeyLLMUsageProviderCurrentMonthVersioned = %q, want %q", got, want)
 	}
@@ -7,13 +7,16 @@ type cacheCounter struct {
 	misses atomic.Int64
 	bypass atomic.Int64
 	errors atomic.Int64
+	// coalesced counts requests that shared an in-flight computation with another request.
+	coalesced atomic.Int64
 }
 
 type cacheStatsSnapshot struct {
-	Hits   int64 `json:"hits"`
-	Misses int64 `json:"misses"`
-	Bypass int64 `json:"bypass"`
-	Errors int64 `json:"errors"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Bypass    int64 `json:"bypass"`
+	Errors    int64 `json:"errors"`
+	Coalesced int64 `json:"coalesced"`
 }
 
 var (
@@ -26,28 +29,32 @@ var (
 func cacheStatsSnapshotAll() map[string]cacheStatsSnapshot {
 	return map[string]cacheStatsSnapshot{
 		"dashboard": {
-			Hits:   dashboardCacheCounter.hits.Load(),
-			Misses: dashboardCacheCounter.misses.Load(),
-			Bypass: dashboardCacheCounter.bypass.Load(),
-			Errors: dashboardCacheCounter.errors.Load(),
+			Hits:      dashboardCacheCounter.hits.Load(),
+			Misses:    dashboardCacheCounter.misses.Load(),
+			Bypass:    dashboardCacheCounter.bypass.Load(),
+			Errors:    dashboardCacheCounter.errors.Load(),
+			Coalesced: dashboardCacheCounter.coalesced.Load(),
 		},
 		"reading_plan": {
-			Hits:   readingPlanCacheCounter.hits.Load(),
-			Misses: readingPlanCacheCounter.misses.Load(),
-			Bypass: readingPlanCacheCounter.bypass.Load(),
-			Errors: readingPlanCacheCounter.errors.Load(),
+			Hits:      readingPlanCacheCounter.hits.Load(),
+			Misses:    readingPlanCacheCounter.misses.Load(),
+			Bypass:    readingPlanCacheCounter.bypass.Load(),
+			Errors:    readingPlanCacheCounter.errors.Load(),
+			Coalesced: readingPlanCacheCounter.coalesced.Load(),
 		},
 		"items_list": {
-			Hits:   itemsListCacheCounter.hits.Load(),
-			Misses: itemsListCacheCounter.misses.Load(),
-			Bypass: itemsListCacheCounter.bypass.Load(),
-			Errors: itemsListCacheCounter.errors.Load(),
+			Hits:      itemsListCacheCounter.hits.Load(),
+			Misses:    itemsListCacheCounter.misses.Load(),
+			Bypass:    itemsListCacheCounter.bypass.Load(),
+			Errors:    itemsListCacheCounter.errors.Load(),
+			Coalesced: itemsListCacheCounter.coalesced.Load(),
 		},
 		"ask": {
-			Hits:   askCacheCounter.hits.Load(),
-			Misses: askCacheCounter.misses.Load(),
-			Bypass: askCacheCounter.bypass.Load(),
-			Errors: askCacheCounter.errors.Load(),
+			Hits:      askCacheCounter.hits.Load(),
+			Misses:    askCacheCounter.misses.Load(),
+			Bypass:    askCacheCounter.bypass.Load(),
+			Errors:    askCacheCounter.errors.Load(),
+			Coalesced: askCacheCounter.coalesced.Load(),
 		},
 	}
 }
@@ -40,7 +40,7 @@ func (h *CartesiaTTSCatalogHandler) List(w http.ResponseWriter, r *http.Request)
 	}
 	catalog, err := h.service.FetchCatalog(r.Context(), apiKey)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	if catalog == nil {
@@ -61,12 +61,12 @@ func (h *CartesiaTTSCatalogHandler) Preview(w http.ResponseWriter, r *http.Reque
 	}
 	voiceID := strings.TrimSpace(chi.URLParam(r, "voiceID"))
 	if voiceID == "" {
-		http.Error(w, "cartesia voice id is required", http.StatusBadRequest)
+		httpError(w, "cartesia voice id is required", http.StatusBadRequest)
 		return
 	}
 	audio, err := h.service.FetchVoicePreview(r.Context(), apiKey, voiceID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	w.Header().Set("Content-Type", audio.ContentType)
@@ -83,7 +83,7 @@ func (h *CartesiaTTSCatalogHandler) loadAPIKey(w http.ResponseWriter, r *http.Re
 		return "", false
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "cartesia api key is not configured", http.StatusBadRequest)
+		httpError(w, "cartesia api key is not configured", http.StatusBadRequest)
 		return "", false
 	}
 	return strings.TrimSpace(*apiKey), true
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type collectionsService interface {
+	ListCollections(ctx context.Context, userID string) ([]model.Collection, error)
+	GetCollection(ctx context.Context, userID, collectionID string) (*model.Collection, error)
+	CreateCollection(ctx context.Context, userID string, in service.SaveCollectionInput) (*model.Collection, error)
+	UpdateCollection(ctx context.Context, userID, collectionID string, in service.SaveCollectionInput) (*model.Collection, error)
+	DeleteCollection(ctx context.Context, userID, collectionID string) error
+	ListCollectionItems(ctx context.Context, userID, collectionID string) ([]model.CollectionItem, error)
+	AddCollectionItem(ctx context.Context, userID, collectionID, itemID string) error
+	RemoveCollectionItem(ctx context.Context, userID, collectionID, itemID string) error
+	ReorderCollectionItems(ctx context.Context, userID, collectionID string, itemIDs []string) error
+	LatestCollectionSummary(ctx context.Context, userID, collectionID string) (*model.CollectionSummary, error)
+}
+
+type CollectionsHandler struct {
+	collections collectionsService
+}
+
+func NewCollectionsHandler(collections collectionsService) *CollectionsHandler {
+	return &CollectionsHandler{collections: collections}
+}
+
+func writeCollectionError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	if errors.As(err, &ve) {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeRepoError(w, err)
+}
+
+func (h *CollectionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.collections.ListCollections(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"collections": collections})
+}
+
+func (h *CollectionsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	collectionID := strings.TrimSpace(chi.URLParam(r, "id"))
+	collection, err := h.collections.GetCollection(r.Context(), userID, collectionID)
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	items, err := h.collections.ListCollectionItems(r.Context(), userID, collectionID)
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"collection": collection, "items": items})
+}
+
+func (h *CollectionsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body service.SaveCollectionInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	collection, err := h.collections.CreateCollection(r.Context(), middleware.GetUserID(r), body)
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, map[string]any{"collection": collection})
+}
+
+func (h *CollectionsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var body service.SaveCollectionInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	collection, err := h.collections.UpdateCollection(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")), body)
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"collection": collection})
+}
+
+func (h *CollectionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.collections.DeleteCollection(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id"))); err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CollectionsHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.collections.ListCollectionItems(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"items": items})
+}
+
+func (h *CollectionsHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ItemID string `json:"item_id"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if strings.TrimSpace(body.ItemID) == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := h.collections.AddCollectionItem(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")), strings.TrimSpace(body.ItemID)); err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CollectionsHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	if err := h.collections.RemoveCollectionItem(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")), strings.TrimSpace(chi.URLParam(r, "itemId"))); err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CollectionsHandler) ReorderItems(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ItemIDs []string `json:"item_ids"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if err := h.collections.ReorderCollectionItems(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")), body.ItemIDs); err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CollectionsHandler) LatestSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.collections.LatestCollectionSummary(r.Context(), middleware.GetUserID(r), strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		writeCollectionError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"summary": summary})
+}
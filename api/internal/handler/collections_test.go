@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type fakeCollectionsService struct {
+	collectionsService
+	createErr  error
+	reorderErr error
+	removeErr  error
+	reordered  []string
+}
+
+func (f *fakeCollectionsService) CreateCollection(_ context.Context, userID string, in service.SaveCollectionInput) (*model.Collection, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	return &model.Collection{ID: "c1", UserID: userID, Name: in.Name}, nil
+}
+
+func (f *fakeCollectionsService) ReorderCollectionItems(_ context.Context, _ string, _ string, itemIDs []string) error {
+	f.reordered = itemIDs
+	return f.reorderErr
+}
+
+func (f *fakeCollectionsService) RemoveCollectionItem(_ context.Context, _ string, _ string, _ string) error {
+	return f.removeErr
+}
+
+func collectionRequest(method, target, body string, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	routeCtx := chi.NewRouteContext()
+	for k, v := range params {
+		routeCtx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, "u1")
+	return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, routeCtx))
+}
+
+func TestCollectionsHandlerCreateStatuses(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusCreated},
+		{&service.ValidationError{Field: "name", Message: "name is required"}, http.StatusBadRequest},
+		{repository.ErrConflict, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		h := NewCollectionsHandler(&fakeCollectionsService{createErr: tc.err})
+		rr := httptest.NewRecorder()
+		h.Create(rr, collectionRequest(http.MethodPost, "/api/collections", `{"name":"AI eval papers"}`, nil))
+		if rr.Code != tc.want {
+			t.Fatalf("err=%v: status = %d, want %d body=%s", tc.err, rr.Code, tc.want, rr.Body.String())
+		}
+	}
+}
+
+func TestCollectionsHandlerReorderItems(t *testing.T) {
+	svc := &fakeCollectionsService{}
+	h := NewCollectionsHandler(svc)
+	rr := httptest.NewRecorder()
+	h.ReorderItems(rr, collectionRequest(http.MethodPut, "/api/collections/c1/items/order", `{"item_ids":["b","a"]}`, map[string]string{"id": "c1"}))
+	if rr.Code != http.StatusNoContent || len(svc.reordered) != 2 || svc.reordered[0] != "b" {
+		t.Fatalf("status = %d reordered = %v", rr.Code, svc.reordered)
+	}
+
+	svc.reorderErr = &service.ValidationError{Field: "item_ids"}
+	rr = httptest.NewRecorder()
+	h.ReorderItems(rr, collectionRequest(http.MethodPut, "/api/collections/c1/items/order", `{"item_ids":["a"]}`, map[string]string{"id": "c1"}))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("validation error status = %d, want 400", rr.Code)
+	}
+}
+
+func TestCollectionsHandlerRemoveMissingItem(t *testing.T) {
+	h := NewCollectionsHandler(&fakeCollectionsService{removeErr: repository.ErrNotFound})
+	rr := httptest.NewRecorder()
+	h.RemoveItem(rr, collectionRequest(http.MethodDelete, "/api/collections/c1/items/i1", "", map[string]string{"id": "c1", "itemId": "i1"}))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rr.Code)
+	}
+}
@@ -15,21 +15,32 @@ import (
 const cacheMetricTTL = 8 * 24 * time.Hour
 const dashboardCacheTTL = 120 * time.Second
 const dashboardPartCacheTTL = 5 * time.Minute
+const dashboardLastGoodTTL = 24 * time.Hour
+
+// dashboardDBBreaker is shared by the dashboard endpoints; while it is open they serve the
+// last good snapshot instead of adding load to the database.
+var dashboardDBBreaker = service.NewDBBreaker(5, 30*time.Second)
 
 type DashboardHandler struct {
 	sourceRepo   *repository.SourceRepo
 	itemRepo     *repository.ItemRepo
 	digestRepo   *repository.DigestRepo
 	llmUsageRepo *repository.LLMUsageLogRepo
+	entityRepo   *repository.EntityRepo
+	settingsRepo *repository.UserSettingsRepo
+	metricsRepo  *repository.MetricsDailyRepo
 	cache        service.JSONCache
 }
 
-func NewDashboardHandler(sourceRepo *repository.SourceRepo, itemRepo *repository.ItemRepo, digestRepo *repository.DigestRepo, llmUsageRepo *repository.LLMUsageLogRepo, cache service.JSONCache) *DashboardHandler {
+func NewDashboardHandler(sourceRepo *repository.SourceRepo, itemRepo *repository.ItemRepo, digestRepo *repository.DigestRepo, llmUsageRepo *repository.LLMUsageLogRepo, entityRepo *repository.EntityRepo, settingsRepo *repository.UserSettingsRepo, metricsRepo *repository.MetricsDailyRepo, cache service.JSONCache) *DashboardHandler {
 	return &DashboardHandler{
 		sourceRepo:   sourceRepo,
 		itemRepo:     itemRepo,
 		digestRepo:   digestRepo,
 		llmUsageRepo: llmUsageRepo,
+		entityRepo:   entityRepo,
+		settingsRepo: settingsRepo,
+		metricsRepo:  metricsRepo,
 		cache:        cache,
 	}
 }
@@ -38,17 +49,17 @@ func (h *DashboardHandler) Get(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	llmDays := parseIntOrDefault(r.URL.Query().Get("llm_days"), 7)
 	if llmDays < 1 || llmDays > 365 {
-		http.Error(w, "invalid llm_days", http.StatusBadRequest)
+		httpError(w, "invalid llm_days", http.StatusBadRequest)
 		return
 	}
 	topicLimit := parseIntOrDefault(r.URL.Query().Get("topic_limit"), 8)
 	if topicLimit < 1 || topicLimit > 50 {
-		http.Error(w, "invalid topic_limit", http.StatusBadRequest)
+		httpError(w, "invalid topic_limit", http.StatusBadRequest)
 		return
 	}
 	digestLimit := parseIntOrDefault(r.URL.Query().Get("digest_limit"), 5)
 	if digestLimit < 1 || digestLimit > 20 {
-		http.Error(w, "invalid digest_limit", http.StatusBadRequest)
+		httpError(w, "invalid digest_limit", http.StatusBadRequest)
 		return
 	}
 	cacheKey := cacheKeyDashboard(userID, llmDays, topicLimit, digestLimit)
@@ -77,138 +88,211 @@ func (h *DashboardHandler) Get(w http.ResponseWriter, r *http.Request) {
 		log.Printf("dashboard cache bypass user_id=%s key=%s", userID, cacheKey)
 	}
 
-	var (
-		wg          sync.WaitGroup
-		mu          sync.Mutex
-		firstErr    error
-		sourceCnt   any
-		itemStats   any
-		digests     any
-		llmSummary  any
-		topics      any
-		failedItems any
-	)
-	setErr := func(err error) {
-		if err == nil {
+	lastGoodKey := cacheKeyDashboardLastGood(userID, llmDays, topicLimit, digestLimit)
+	if !dashboardDBBreaker.Allow() {
+		if h.writeDegradedDashboard(w, r, userID, lastGoodKey) {
 			return
 		}
-		mu.Lock()
-		defer mu.Unlock()
-		if firstErr == nil {
-			firstErr = err
-		}
+		httpError(w, "dashboard temporarily unavailable", http.StatusServiceUnavailable)
+		return
 	}
-	loadPart := func(part, key string, fetch func() (any, error), assign func(any)) {
-		if h.cache != nil && !cacheBust {
-			var cached any
-			if ok, err := h.cache.GetJSON(r.Context(), key, &cached); err == nil && ok {
-				incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.hit", part))
-				mu.Lock()
-				assign(cached)
-				mu.Unlock()
+
+	// Concurrent misses for the same snapshot share one build.
+	resp, err := coalesce(r.Context(), cacheKey, &dashboardCacheCounter, func() (dashboardResponse, error) {
+		var (
+			wg          sync.WaitGroup
+			mu          sync.Mutex
+			firstErr    error
+			sourceCnt   any
+			itemStats   any
+			digests     any
+			llmSummary  any
+			topics      any
+			entities    any
+			failedItems any
+		)
+		setErr := func(err error) {
+			if err == nil {
 				return
-			} else if err != nil {
-				incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.error", part))
-				log.Printf("dashboard-part cache get failed user_id=%s part=%s key=%s err=%v", userID, part, key, err)
 			}
-			incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.miss", part))
-		} else if cacheBust && h.cache != nil {
-			incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.bypass", part))
-		}
-		v, err := fetch()
-		if err != nil {
-			setErr(err)
-			return
+			mu.Lock()
+			defer mu.Unlock()
+			if firstErr == nil {
+				firstErr = err
+			}
 		}
-		mu.Lock()
-		assign(v)
-		mu.Unlock()
-		if h.cache != nil {
-			if err := h.cache.SetJSON(r.Context(), key, v, dashboardPartCacheTTL); err != nil {
-				incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.error", part))
-				log.Printf("dashboard-part cache set failed user_id=%s part=%s key=%s err=%v", userID, part, key, err)
+		loadPart := func(part, key string, fetch func() (any, error), assign func(any)) {
+			if h.cache != nil && !cacheBust {
+				var cached any
+				if ok, err := h.cache.GetJSON(r.Context(), key, &cached); err == nil && ok {
+					incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.hit", part))
+					mu.Lock()
+					assign(cached)
+					mu.Unlock()
+					return
+				} else if err != nil {
+					incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.error", part))
+					log.Printf("dashboard-part cache get failed user_id=%s part=%s key=%s err=%v", userID, part, key, err)
+				}
+				incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.miss", part))
+			} else if cacheBust && h.cache != nil {
+				incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.bypass", part))
+			}
+			v, err := fetch()
+			if err != nil {
+				setErr(err)
+				return
+			}
+			mu.Lock()
+			assign(v)
+			mu.Unlock()
+			if h.cache != nil {
+				if err := h.cache.SetJSON(r.Context(), key, v, dashboardPartCacheTTL); err != nil {
+					incrCacheMetric(r.Context(), h.cache, userID, fmt.Sprintf("dashboard_part.%s.error", part))
+					log.Printf("dashboard-part cache set failed user_id=%s part=%s key=%s err=%v", userID, part, key, err)
+				}
 			}
 		}
-	}
 
-	wg.Add(6)
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "sources", 0, 0)
-		loadPart("sources", partKey, func() (any, error) {
-			n, err := h.sourceRepo.CountByUser(r.Context(), userID)
-			if err != nil {
-				return nil, err
+		wg.Add(7)
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "sources", 0, 0)
+			loadPart("sources", partKey, func() (any, error) {
+				n, err := h.sourceRepo.CountByUser(r.Context(), userID)
+				if err != nil {
+					return nil, err
+				}
+				return n, nil
+			}, func(v any) { sourceCnt = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "itemstats", 0, 0)
+			loadPart("itemstats", partKey, func() (any, error) {
+				return h.itemRepo.Stats(r.Context(), userID)
+			}, func(v any) { itemStats = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "digests", digestLimit, 0)
+			loadPart("digests", partKey, func() (any, error) {
+				return h.digestRepo.ListLimit(r.Context(), userID, digestLimit)
+			}, func(v any) { digests = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "llm", llmDays, 0)
+			loadPart("llm", partKey, func() (any, error) {
+				return h.llmUsageRepo.DailySummaryByUser(r.Context(), userID, llmDays)
+			}, func(v any) { llmSummary = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "topics", topicLimit, 0)
+			loadPart("topics", partKey, func() (any, error) {
+				return h.itemRepo.TopicTrends(r.Context(), userID, topicLimit)
+			}, func(v any) { topics = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "entities", topicLimit, 0)
+			loadPart("entities", partKey, func() (any, error) {
+				return h.entityRepo.Trends(r.Context(), userID, topicLimit)
+			}, func(v any) { entities = v })
+		})
+		safeGo(func() {
+			defer wg.Done()
+			partKey := cacheKeyDashboardPart(userID, "failedpreview", 0, 0)
+			loadPart("failedpreview", partKey, func() (any, error) {
+				status := "failed"
+				return h.itemRepo.ListPage(r.Context(), userID, repository.ItemListParams{
+					Status:   &status,
+					Sort:     "newest",
+					Page:     1,
+					PageSize: 5,
+				})
+			}, func(v any) { failedItems = v })
+		})
+		wg.Wait()
+		dashboardDBBreaker.Record(firstErr)
+		if firstErr != nil {
+			return dashboardResponse{}, firstErr
+		}
+
+		resp := dashboardResponse{
+			SourcesCount: sourceCnt,
+			ItemStats:    itemStats,
+			Digests:      digests,
+			LLMSummary:   llmSummary,
+			TopicTrends: dashboardTopicTrends{
+				Items:  topics,
+				Limit:  topicLimit,
+				Period: "24h_vs_prev24h",
+			},
+			EntityTrends: dashboardTopicTrends{
+				Items:  entities,
+				Limit:  topicLimit,
+				Period: "24h_vs_prev24h",
+			},
+			FailedItemsPreview: failedItems,
+			LLMDays:            llmDays,
+		}
+		if h.cache != nil {
+			if err := h.cache.SetJSON(r.Context(), cacheKey, resp, dashboardCacheTTL); err != nil {
+				dashboardCacheCounter.errors.Add(1)
+				incrCacheMetric(r.Context(), h.cache, userID, "dashboard.error")
+				log.Printf("dashboard cache set failed user_id=%s key=%s err=%v", userID, cacheKey, err)
 			}
-			return n, nil
-		}, func(v any) { sourceCnt = v })
-	})
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "itemstats", 0, 0)
-		loadPart("itemstats", partKey, func() (any, error) {
-			return h.itemRepo.Stats(r.Context(), userID)
-		}, func(v any) { itemStats = v })
-	})
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "digests", digestLimit, 0)
-		loadPart("digests", partKey, func() (any, error) {
-			return h.digestRepo.ListLimit(r.Context(), userID, digestLimit)
-		}, func(v any) { digests = v })
-	})
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "llm", llmDays, 0)
-		loadPart("llm", partKey, func() (any, error) {
-			return h.llmUsageRepo.DailySummaryByUser(r.Context(), userID, llmDays)
-		}, func(v any) { llmSummary = v })
-	})
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "topics", topicLimit, 0)
-		loadPart("topics", partKey, func() (any, error) {
-			return h.itemRepo.TopicTrends(r.Context(), userID, topicLimit)
-		}, func(v any) { topics = v })
-	})
-	safeGo(func() {
-		defer wg.Done()
-		partKey := cacheKeyDashboardPart(userID, "failedpreview", 0, 0)
-		loadPart("failedpreview", partKey, func() (any, error) {
-			status := "failed"
-			return h.itemRepo.ListPage(r.Context(), userID, repository.ItemListParams{
-				Status:   &status,
-				Sort:     "newest",
-				Page:     1,
-				PageSize: 5,
-			})
-		}, func(v any) { failedItems = v })
+			if err := h.cache.SetJSON(r.Context(), lastGoodKey, resp, dashboardLastGoodTTL); err != nil {
+				log.Printf("dashboard last-good cache set failed user_id=%s key=%s err=%v", userID, lastGoodKey, err)
+			}
+		}
+		return resp, nil
 	})
-	wg.Wait()
-	if firstErr != nil {
-		writeRepoError(w, firstErr)
+	if err != nil {
+		if service.IsDBUnavailable(err) && h.writeDegradedDashboard(w, r, userID, lastGoodKey) {
+			return
+		}
+		writeRepoError(w, err)
 		return
 	}
+	writeJSON(w, resp)
+}
 
-	resp := dashboardResponse{
-		SourcesCount: sourceCnt,
-		ItemStats:    itemStats,
-		Digests:      digests,
-		LLMSummary:   llmSummary,
-		TopicTrends: dashboardTopicTrends{
-			Items:  topics,
-			Limit:  topicLimit,
-			Period: "24h_vs_prev24h",
-		},
-		FailedItemsPreview: failedItems,
-		LLMDays:            llmDays,
+// writeDegradedDashboard serves the last successfully built dashboard flagged as degraded.
+// It reports false when no copy is available.
+func (h *DashboardHandler) writeDegradedDashboard(w http.ResponseWriter, r *http.Request, userID, key string) bool {
+	if h.cache == nil {
+		return false
 	}
-	if h.cache != nil {
-		if err := h.cache.SetJSON(r.Context(), cacheKey, resp, dashboardCacheTTL); err != nil {
-			dashboardCacheCounter.errors.Add(1)
-			incrCacheMetric(r.Context(), h.cache, userID, "dashboard.error")
-			log.Printf("dashboard cache set failed user_id=%s key=%s err=%v", userID, cacheKey, err)
-		}
+	var cached map[string]any
+	ok, err := h.cache.GetJSON(r.Context(), key, &cached)
+	if err != nil {
+		log.Printf("dashboard last-good cache get failed user_id=%s key=%s err=%v", userID, key, err)
+		return false
 	}
-	writeJSON(w, resp)
+	if !ok {
+		return false
+	}
+	incrCacheMetric(r.Context(), h.cache, userID, "dashboard.degraded")
+	log.Printf("dashboard serving degraded snapshot user_id=%s breaker=%s", userID, dashboardDBBreaker.State())
+	cached["degraded"] = true
+	writeJSON(w, cached)
+	return true
+}
+
+// History serves the nightly metrics_daily snapshots for trend charts.
+func (h *DashboardHandler) History(w http.ResponseWriter, r *http.Request) {
+	days := parseIntOrDefault(r.URL.Query().Get("days"), 90)
+	if days < 1 || days > 365 {
+		httpError(w, "invalid days", http.StatusBadRequest)
+		return
+	}
+	metrics, err := h.metricsRepo.ListByUser(r.Context(), middleware.GetUserID(r), days)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"days": days, "metrics": metrics})
 }
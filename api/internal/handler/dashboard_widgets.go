@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+var errDashboardDBUnavailable = errors.New("dashboard database unavailable")
+
+type dashboardLayoutResponse struct {
+	Layout    model.DashboardLayout         `json:"layout"`
+	IsDefault bool                          `json:"is_default"`
+	Available []service.DashboardWidgetSpec `json:"available"`
+}
+
+type dashboardWidgetResponse struct {
+	Name   string         `json:"name"`
+	Params map[string]int `json:"params"`
+	Data   any            `json:"data"`
+}
+
+func (h *DashboardHandler) Layout(w http.ResponseWriter, r *http.Request) {
+	layout, err := h.settingsRepo.GetDashboardLayout(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	resp := dashboardLayoutResponse{Available: service.DashboardWidgetSpecs()}
+	if layout == nil {
+		resp.Layout = service.DefaultDashboardLayout()
+		resp.IsDefault = true
+	} else {
+		resp.Layout = *layout
+	}
+	writeJSON(w, resp)
+}
+
+func (h *DashboardHandler) UpdateLayout(w http.ResponseWriter, r *http.Request) {
+	var body model.DashboardLayout
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	layout, err := service.NormalizeDashboardLayout(body)
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.settingsRepo.SetDashboardLayout(r.Context(), middleware.GetUserID(r), &layout); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, dashboardLayoutResponse{Layout: layout, Available: service.DashboardWidgetSpecs()})
+}
+
+func (h *DashboardHandler) ResetLayout(w http.ResponseWriter, r *http.Request) {
+	if err := h.settingsRepo.SetDashboardLayout(r.Context(), middleware.GetUserID(r), nil); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, dashboardLayoutResponse{
+		Layout:    service.DefaultDashboardLayout(),
+		IsDefault: true,
+		Available: service.DashboardWidgetSpecs(),
+	})
+}
+
+// Widget loads a single dashboard widget so clients can render the layout lazily.
+// Params come from the saved layout and can be overridden per request via the query string.
+func (h *DashboardHandler) Widget(w http.ResponseWriter, r *http.Request) {
+	spec, ok := service.LookupDashboardWidget(chi.URLParam(r, "name"))
+	if !ok {
+		httpError(w, "unknown widget", http.StatusNotFound)
+		return
+	}
+	query, err := dashboardWidgetQueryParams(spec, r)
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	saved := map[string]int{}
+	if len(query) < len(spec.Params) {
+		layout, err := h.settingsRepo.GetDashboardLayout(r.Context(), userID)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		if layout != nil {
+			for _, wc := range layout.Widgets {
+				if wc.Name == spec.Name {
+					saved = wc.Params
+					break
+				}
+			}
+		}
+	}
+	merged := map[string]int{}
+	for k, v := range saved {
+		merged[k] = v
+	}
+	for k, v := range query {
+		merged[k] = v
+	}
+	params, err := spec.ResolveParams(merged)
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := h.loadWidget(r.Context(), userID, spec.Name, params, r.URL.Query().Get("cache_bust") == "1")
+	if errors.Is(err, errDashboardDBUnavailable) {
+		httpError(w, "widget temporarily unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, dashboardWidgetResponse{Name: spec.Name, Params: params, Data: data})
+}
+
+func dashboardWidgetQueryParams(spec service.DashboardWidgetSpec, r *http.Request) (map[string]int, error) {
+	out := map[string]int{}
+	for _, p := range spec.Params {
+		raw := r.URL.Query().Get(p.Name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", p.Name)
+		}
+		out[p.Name] = v
+	}
+	return out, nil
+}
+
+// loadWidget shares the part cache with the aggregate dashboard so switching between the
+// two endpoints does not recompute anything.
+func (h *DashboardHandler) loadWidget(ctx context.Context, userID, name string, params map[string]int, cacheBust bool) (any, error) {
+	var (
+		part  string
+		p1    int
+		fetch func() (any, error)
+	)
+	switch name {
+	case service.DashboardWidgetSources:
+		part = "sources"
+		fetch = func() (any, error) { return h.sourceRepo.CountByUser(ctx, userID) }
+	case service.DashboardWidgetItemStats:
+		part = "itemstats"
+		fetch = func() (any, error) { return h.itemRepo.Stats(ctx, userID) }
+	case service.DashboardWidgetDigests:
+		part, p1 = "digests", params["limit"]
+		fetch = func() (any, error) { return h.digestRepo.ListLimit(ctx, userID, p1) }
+	case service.DashboardWidgetLLMSummary:
+		part, p1 = "llm", params["days"]
+		fetch = func() (any, error) { return h.llmUsageRepo.DailySummaryByUser(ctx, userID, p1) }
+	case service.DashboardWidgetTopicTrends:
+		part, p1 = "topics", params["limit"]
+		fetch = func() (any, error) { return h.itemRepo.TopicTrends(ctx, userID, p1) }
+	case service.DashboardWidgetEntityTrends:
+		part, p1 = "entities", params["limit"]
+		fetch = func() (any, error) { return h.entityRepo.Trends(ctx, userID, p1) }
+	case service.DashboardWidgetFailedPreview:
+		part = "failedpreview"
+		fetch = func() (any, error) {
+			status := "failed"
+			return h.itemRepo.ListPage(ctx, userID, repository.ItemListParams{
+				Status:   &status,
+				Sort:     "newest",
+				Page:     1,
+				PageSize: 5,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown widget %q", name)
+	}
+
+	key := cacheKeyDashboardPart(userID, part, p1, 0)
+	if h.cache != nil && !cacheBust {
+		var cached any
+		if ok, err := h.cache.GetJSON(ctx, key, &cached); err == nil && ok {
+			incrCacheMetric(ctx, h.cache, userID, fmt.Sprintf("dashboard_widget.%s.hit", name))
+			return cached, nil
+		} else if err != nil {
+			log.Printf("dashboard-widget cache get failed user_id=%s widget=%s key=%s err=%v", userID, name, key, err)
+		}
+		incrCacheMetric(ctx, h.cache, userID, fmt.Sprintf("dashboard_widget.%s.miss", name))
+	}
+	if !dashboardDBBreaker.Allow() {
+		return nil, errDashboardDBUnavailable
+	}
+	v, err := fetch()
+	dashboardDBBreaker.Record(err)
+	if err != nil {
+		return nil, err
+	}
+	if h.cache != nil {
+		if err := h.cache.SetJSON(ctx, key, v, dashboardPartCacheTTL); err != nil {
+			log.Printf("dashboard-widget cache set failed user_id=%s widget=%s key=%s err=%v", userID, name, key, err)
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+func dashboardWidgetRequest(name, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/widgets/"+name+query, nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestDashboardWidgetRejectsUnknownWidget(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&DashboardHandler{}).Widget(rec, dashboardWidgetRequest("weather", ""))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestDashboardWidgetRejectsInvalidParams(t *testing.T) {
+	for _, query := range []string{"?days=abc", "?days=400"} {
+		rec := httptest.NewRecorder()
+		(&DashboardHandler{}).Widget(rec, dashboardWidgetRequest("llm_summary", query))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestDashboardUpdateLayoutRejectsUnknownWidget(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/dashboard/layout", strings.NewReader(`{"widgets":[{"name":"weather"}]}`))
+	rec := httptest.NewRecorder()
+	(&DashboardHandler{}).UpdateLayout(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestDashboardHistoryRejectsInvalidDays(t *testing.T) {
+	for _, query := range []string{"?days=0", "?days=366"} {
+		rec := httptest.NewRecorder()
+		(&DashboardHandler{}).History(rec, httptest.NewRequest(http.MethodGet, "/dashboard/history"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestDashboardReturnsUnavailableWhenBreakerOpenWithoutSnapshot(t *testing.T) {
+	prev := dashboardDBBreaker
+	dashboardDBBreaker = service.NewDBBreaker(1, time.Hour)
+	defer func() { dashboardDBBreaker = prev }()
+	dashboardDBBreaker.Record(context.DeadlineExceeded)
+
+	rec := httptest.NewRecorder()
+	(&DashboardHandler{}).Get(rec, httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("dashboard status = %d, want 503", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	(&DashboardHandler{}).Widget(rec, dashboardWidgetRequest("item_stats", ""))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("widget status = %d, want 503", rec.Code)
+	}
+}
@@ -105,11 +105,11 @@ func (h *DeepInfraModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	apiKey, err := loadAndDecryptUserSecret(r.Context(), h.settingsRepo.GetDeepInfraAPIKeyEncrypted, h.cipher, userID, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "deepinfra api key is not configured", http.StatusBadRequest)
+		httpError(w, "deepinfra api key is not configured", http.StatusBadRequest)
 		return
 	}
 
@@ -122,7 +122,7 @@ func (h *DeepInfraModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	if fetchErr != nil {
 		msg := fetchErr.Error()
 		_ = h.repo.FinishSyncRun(r.Context(), syncRunID, 0, 0, &msg)
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 	if cache, err := h.repo.ListLatestDescriptionCache(r.Context()); err == nil {
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type digestConfigStore interface {
+	ListByUser(ctx context.Context, userID string) ([]model.DigestConfig, error)
+	Get(ctx context.Context, userID, id string) (*model.DigestConfig, error)
+	CountOwnedSources(ctx context.Context, userID string, sourceIDs []string) (int, error)
+	Create(ctx context.Context, c model.DigestConfig) (*model.DigestConfig, error)
+	Update(ctx context.Context, c model.DigestConfig) (*model.DigestConfig, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
+type DigestConfigsHandler struct {
+	repo digestConfigStore
+}
+
+func NewDigestConfigsHandler(repo digestConfigStore) *DigestConfigsHandler {
+	return &DigestConfigsHandler{repo: repo}
+}
+
+func (h *DigestConfigsHandler) List(w http.ResponseWriter, r *http.Request) {
+	configs, err := h.repo.ListByUser(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"configs": configs})
+}
+
+func (h *DigestConfigsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body service.DigestConfigInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	cfg := model.DigestConfig{
+		UserID:       middleware.GetUserID(r),
+		SourceIDs:    []string{},
+		Topics:       []string{},
+		SendHourJST:  7,
+		SendWeekdays: []int{0, 1, 2, 3, 4, 5, 6},
+		Enabled:      true,
+	}
+	if !h.apply(w, r, &cfg, body) {
+		return
+	}
+	out, err := h.repo.Create(r.Context(), cfg)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, out)
+}
+
+func (h *DigestConfigsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var body service.DigestConfigInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	cfg, err := h.repo.Get(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if !h.apply(w, r, cfg, body) {
+		return
+	}
+	out, err := h.repo.Update(r.Context(), *cfg)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, out)
+}
+
+func (h *DigestConfigsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.repo.Delete(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id")); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apply validates the input into cfg and writes the error response when it is rejected.
+func (h *DigestConfigsHandler) apply(w http.ResponseWriter, r *http.Request, cfg *model.DigestConfig, in service.DigestConfigInput) bool {
+	if err := service.ApplyDigestConfigInput(cfg, in); err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+		} else {
+			writeRepoError(w, err)
+		}
+		return false
+	}
+	if cfg.ScopeType != service.DigestScopeSources {
+		return true
+	}
+	owned, err := h.repo.CountOwnedSources(r.Context(), cfg.UserID, cfg.SourceIDs)
+	if err != nil {
+		writeRepoError(w, err)
+		return false
+	}
+	if owned != len(cfg.SourceIDs) {
+		httpError(w, "source_ids contains unknown sources", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestDigestConfigsHandlerCreateValidatesSourceOwnership(t *testing.T) {
+	store := repotest.NewDigestConfigStore()
+	store.AddSource("u1", "src-1")
+	store.AddSource("u2", "src-2")
+	h := NewDigestConfigsHandler(store)
+
+	rec := httptest.NewRecorder()
+	h.Create(rec, userRequest(http.MethodPost, "/digest-configs", "u1", `{"name":"Team","scope_type":"sources","source_ids":["src-1","src-2"]}`, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("foreign source status = %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Create(rec, userRequest(http.MethodPost, "/digest-configs", "u1", `{"name":"Team","scope_type":"sources","source_ids":["src-1"],"send_hour_jst":18}`, nil))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	var created model.DigestConfig
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.SendHourJST != 18 || len(created.SendWeekdays) != 7 || !created.Enabled {
+		t.Fatalf("created = %+v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	h.List(rec, userRequest(http.MethodGet, "/digest-configs", "u2", "", nil))
+	var resp struct {
+		Configs []model.DigestConfig `json:"configs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || len(resp.Configs) != 0 {
+		t.Fatalf("other user's configs = %+v err=%v", resp.Configs, err)
+	}
+}
+
+func TestDigestConfigsHandlerUpdateSwitchesScope(t *testing.T) {
+	store := repotest.NewDigestConfigStore()
+	cfg, err := store.Create(context.Background(), model.DigestConfig{
+		UserID: "u1", Name: "AI", ScopeType: "topics", Topics: []string{"AI"}, SourceIDs: []string{},
+		SendHourJST: 7, SendWeekdays: []int{1}, Enabled: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewDigestConfigsHandler(store)
+	params := map[string]string{"id": cfg.ID}
+
+	rec := httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/digest-configs/"+cfg.ID, "u2", `{"enabled":false}`, params))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("other user update status = %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/digest-configs/"+cfg.ID, "u1", `{"scope_type":"search"}`, params))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("search without query status = %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/digest-configs/"+cfg.ID, "u1", `{"scope_type":"search","search_query":"rust"}`, params))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	got, _ := store.Get(context.Background(), "u1", cfg.ID)
+	if got.ScopeType != "search" || got.SearchQuery == nil || *got.SearchQuery != "rust" {
+		t.Fatalf("updated = %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/digest-configs/"+cfg.ID, "u1", "", params))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type digestEmailPreferencesService interface {
+	Unsubscribe(ctx context.Context, claim service.DigestEmailClaim) error
+	Frequency(ctx context.Context, userID string) (string, error)
+	SetFrequency(ctx context.Context, userID, frequency string) error
+}
+
+// DigestEmailHandler serves the signed unsubscribe and preferences links of digest emails. The
+// token is the only credential, so no session is required.
+type DigestEmailHandler struct {
+	signer *service.DigestLinkSigner
+	svc    digestEmailPreferencesService
+	cache  service.JSONCache
+}
+
+func NewDigestEmailHandler(signer *service.DigestLinkSigner, svc digestEmailPreferencesService, cache service.JSONCache) *DigestEmailHandler {
+	return &DigestEmailHandler{signer: signer, svc: svc, cache: cache}
+}
+
+// UnsubscribePage asks for confirmation instead of unsubscribing on GET, so link scanners that
+// prefetch the footer link cannot stop the digest.
+func (h *DigestEmailHandler) UnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	if _, ok := h.verify(w, token); !ok {
+		return
+	}
+	form := fmt.Sprintf(`<p>ダイジェストメールの配信を停止しますか？ / Stop receiving digest emails?</p><form method="post"><input type="hidden" name="token" value="%s"><button type="submit">配信を停止する / Unsubscribe</button></form>`, html.EscapeString(token))
+	writeDigestPublicPage(w, http.StatusOK, "配信停止 / Unsubscribe", form)
+}
+
+// Unsubscribe handles both the confirmation form and the RFC 8058 one-click POST, which mail
+// clients send to the List-Unsubscribe URL with the token still in the query string.
+func (h *DigestEmailHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeDigestPublicPage(w, http.StatusBadRequest, "リンクが正しくありません / Invalid link", "")
+		return
+	}
+	claim, ok := h.verify(w, r.FormValue("token"))
+	if !ok {
+		return
+	}
+	if err := h.svc.Unsubscribe(r.Context(), claim); err != nil {
+		log.Printf("digest unsubscribe failed scope=%s id=%s err=%v", claim.Scope, claim.SubjectID, err)
+		writeDigestPublicPage(w, http.StatusInternalServerError, "配信停止に失敗しました / Could not unsubscribe", "")
+		return
+	}
+	if claim.Scope == service.DigestEmailScopeOwner {
+		h.bumpUserSettingsVersion(r.Context(), claim.SubjectID)
+	}
+	writeDigestPublicPage(w, http.StatusOK, "配信を停止しました / You have been unsubscribed", "<p>今後ダイジェストメールは届きません。 / You will no longer receive digest emails.</p>")
+}
+
+func (h *DigestEmailHandler) PreferencesPage(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	claim, ok := h.verifyOwner(w, token)
+	if !ok {
+		return
+	}
+	current, err := h.svc.Frequency(r.Context(), claim.SubjectID)
+	if err != nil {
+		log.Printf("digest email preferences load failed user_id=%s err=%v", claim.SubjectID, err)
+		writeDigestPublicPage(w, http.StatusInternalServerError, "設定を読み込めませんでした / Could not load preferences", "")
+		return
+	}
+	writeDigestPublicPage(w, http.StatusOK, "メール配信設定 / Email preferences", digestEmailPreferencesForm(token, current))
+}
+
+func (h *DigestEmailHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeDigestPublicPage(w, http.StatusBadRequest, "リンクが正しくありません / Invalid link", "")
+		return
+	}
+	token := r.PostForm.Get("token")
+	claim, ok := h.verifyOwner(w, token)
+	if !ok {
+		return
+	}
+	frequency := strings.TrimSpace(r.PostForm.Get("frequency"))
+	if err := h.svc.SetFrequency(r.Context(), claim.SubjectID, frequency); err != nil {
+		var verr *service.ValidationError
+		if errors.As(err, &verr) {
+			writeDigestPublicPage(w, http.StatusBadRequest, "配信頻度を選んでください / Please choose a frequency", digestEmailPreferencesForm(token, ""))
+			return
+		}
+		log.Printf("digest email preferences save failed user_id=%s err=%v", claim.SubjectID, err)
+		writeDigestPublicPage(w, http.StatusInternalServerError, "保存に失敗しました / Could not save preferences", "")
+		return
+	}
+	h.bumpUserSettingsVersion(r.Context(), claim.SubjectID)
+	writeDigestPublicPage(w, http.StatusOK, "設定を保存しました / Preferences saved", digestEmailPreferencesForm(token, frequency))
+}
+
+func (h *DigestEmailHandler) verify(w http.ResponseWriter, token string) (service.DigestEmailClaim, bool) {
+	if h.signer == nil {
+		writeDigestPublicPage(w, http.StatusNotFound, "このリンクは無効です / This link is not available", "")
+		return service.DigestEmailClaim{}, false
+	}
+	claim, err := h.signer.VerifyEmail(token)
+	if err != nil {
+		writeDigestPublicPage(w, http.StatusNotFound, "リンクの有効期限が切れているか、正しくありません / This link has expired or is invalid", "")
+		return service.DigestEmailClaim{}, false
+	}
+	return claim, true
+}
+
+// verifyOwner rejects recipient tokens: extra recipients can only unsubscribe, the schedule is
+// the owner's.
+func (h *DigestEmailHandler) verifyOwner(w http.ResponseWriter, token string) (service.DigestEmailClaim, bool) {
+	claim, ok := h.verify(w, token)
+	if !ok {
+		return claim, false
+	}
+	if claim.Scope != service.DigestEmailScopeOwner {
+		writeDigestPublicPage(w, http.StatusNotFound, "このリンクは無効です / This link is not available", "")
+		return service.DigestEmailClaim{}, false
+	}
+	return claim, true
+}
+
+func (h *DigestEmailHandler) bumpUserSettingsVersion(ctx context.Context, userID string) {
+	if h.cache == nil {
+		return
+	}
+	if _, err := h.cache.BumpVersion(ctx, cacheVersionKeyUserSettings(userID)); err != nil {
+		log.Printf("digest email settings cache bump failed user_id=%s err=%v", userID, err)
+	}
+}
+
+func digestEmailPreferencesForm(token, current string) string {
+	var sb strings.Builder
+	sb.WriteString(`<form method="post">`)
+	sb.WriteString(fmt.Sprintf(`<input type="hidden" name="token" value="%s">`, html.EscapeString(token)))
+	if current == service.DigestEmailFrequencyCustom {
+		sb.WriteString(`<p style="color:#666">現在は設定画面で指定した曜日で配信しています。 / Your digest currently uses a custom schedule from the settings page.</p>`)
+	}
+	for _, opt := range []struct{ value, label string }{
+		{service.DigestEmailFrequencyDaily, "毎日 / Every day"},
+		{service.DigestEmailFrequencyWeekdays, "平日のみ / Weekdays only"},
+		{service.DigestEmailFrequencyWeekly, "週1回（月曜） / Weekly (Mondays)"},
+		{service.DigestEmailFrequencyOff, "配信停止 / Off"},
+	} {
+		checked := ""
+		if opt.value == current {
+			checked = " checked"
+		}
+		sb.WriteString(fmt.Sprintf(`<p><label><input type="radio" name="frequency" value="%s"%s> %s</label></p>`, opt.value, checked, html.EscapeString(opt.label)))
+	}
+	sb.WriteString(`<button type="submit">保存 / Save</button></form>`)
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type fakeDigestEmailPreferences struct {
+	unsubscribed []service.DigestEmailClaim
+	frequency    map[string]string
+}
+
+func (f *fakeDigestEmailPreferences) Unsubscribe(_ context.Context, claim service.DigestEmailClaim) error {
+	f.unsubscribed = append(f.unsubscribed, claim)
+	return nil
+}
+
+func (f *fakeDigestEmailPreferences) Frequency(_ context.Context, userID string) (string, error) {
+	return f.frequency[userID], nil
+}
+
+func (f *fakeDigestEmailPreferences) SetFrequency(_ context.Context, userID, frequency string) error {
+	f.frequency[userID] = frequency
+	return nil
+}
+
+func TestDigestEmailHandlerOneClickUnsubscribe(t *testing.T) {
+	prefs := &fakeDigestEmailPreferences{frequency: map[string]string{}}
+	signer := service.NewDigestLinkSigner("secret", "https://sifto.example")
+	h := NewDigestEmailHandler(signer, prefs, nil)
+	target := "/api/digest-email/unsubscribe?token=" + url.QueryEscape(signer.SignEmail(service.DigestEmailScopeOwner, "u1"))
+
+	rec := httptest.NewRecorder()
+	h.UnsubscribePage(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK || len(prefs.unsubscribed) != 0 {
+		t.Fatalf("GET status = %d unsubscribed=%v", rec.Code, prefs.unsubscribed)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader("List-Unsubscribe=One-Click"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.Unsubscribe(rec, req)
+	if rec.Code != http.StatusOK || len(prefs.unsubscribed) != 1 || prefs.unsubscribed[0].SubjectID != "u1" {
+		t.Fatalf("one-click status = %d unsubscribed=%v", rec.Code, prefs.unsubscribed)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Unsubscribe(rec, httptest.NewRequest(http.MethodPost, "/api/digest-email/unsubscribe?token=bogus", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("bogus token status = %d", rec.Code)
+	}
+}
+
+func TestDigestEmailHandlerPreferencesOwnerOnly(t *testing.T) {
+	prefs := &fakeDigestEmailPreferences{frequency: map[string]string{"u1": service.DigestEmailFrequencyDaily}}
+	signer := service.NewDigestLinkSigner("secret", "https://sifto.example")
+	h := NewDigestEmailHandler(signer, prefs, nil)
+
+	owner := signer.SignEmail(service.DigestEmailScopeOwner, "u1")
+	rec := httptest.NewRecorder()
+	h.PreferencesPage(rec, httptest.NewRequest(http.MethodGet, "/api/digest-email/preferences?token="+url.QueryEscape(owner), nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `value="daily" checked`) {
+		t.Fatalf("page status = %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	post := func(token string) *httptest.ResponseRecorder {
+		body := url.Values{"token": {token}, "frequency": {service.DigestEmailFrequencyWeekly}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/api/digest-email/preferences", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		h.UpdatePreferences(rec, req)
+		return rec
+	}
+	if rec := post(signer.SignEmail(service.DigestEmailScopeRecipient, "u1")); rec.Code != http.StatusNotFound {
+		t.Fatalf("recipient token status = %d", rec.Code)
+	}
+	if prefs.frequency["u1"] != service.DigestEmailFrequencyDaily {
+		t.Fatalf("recipient token changed frequency to %q", prefs.frequency["u1"])
+	}
+	if rec := post(owner); rec.Code != http.StatusOK || prefs.frequency["u1"] != service.DigestEmailFrequencyWeekly {
+		t.Fatalf("owner save status = %d frequency=%q", rec.Code, prefs.frequency["u1"])
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type digestFeedService interface {
+	Build(ctx context.Context, token string, format service.DigestFeedFormat) (*service.DigestFeedResult, error)
+	GetToken(ctx context.Context, userID string) (*string, error)
+	RotateToken(ctx context.Context, userID string) (string, error)
+	RevokeToken(ctx context.Context, userID string) error
+}
+
+type DigestFeedHandler struct {
+	feed     digestFeedService
+	auditLog *service.AuditLogService
+}
+
+// The feed is private, so it is never cached by shared proxies.
+const digestFeedCacheControl = "private, max-age=300"
+
+func NewDigestFeedHandler(feed digestFeedService) *DigestFeedHandler {
+	return &DigestFeedHandler{feed: feed}
+}
+
+// WithAuditLog records feed token creation and revocation.
+func (h *DigestFeedHandler) WithAuditLog(svc *service.AuditLogService) *DigestFeedHandler {
+	h.auditLog = svc
+	return h
+}
+
+func (h *DigestFeedHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	if h.feed == nil {
+		httpError(w, "digest feed unavailable", http.StatusInternalServerError)
+		return
+	}
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	if token == "" {
+		httpError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	format, ok := digestFeedFormat(r)
+	if !ok {
+		httpError(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+	result, err := h.feed.Build(r.Context(), token, format)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeRepoError(w, err)
+			return
+		}
+		httpError(w, "failed to build digest feed", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		httpError(w, "failed to build digest feed", http.StatusInternalServerError)
+		return
+	}
+	body := result.Body
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`W/"%x"`, sum)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Header().Set("Cache-Control", digestFeedCacheControl)
+	w.Header().Set("ETag", etag)
+	if !result.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", result.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if podcastFeedNotModified(r, etag, result.LastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// digestFeedFormat picks the feed format from ?format=rss|atom, falling back to the Accept
+// header so readers that ask for application/atom+xml get Atom. RSS is the default.
+func digestFeedFormat(r *http.Request) (service.DigestFeedFormat, bool) {
+	switch strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))) {
+	case "":
+	case "rss":
+		return service.DigestFeedFormatRSS, true
+	case "atom":
+		return service.DigestFeedFormatAtom, true
+	default:
+		return "", false
+	}
+	if strings.Contains(strings.ToLower(r.Header.Get("Accept")), "application/atom+xml") {
+		return service.DigestFeedFormatAtom, true
+	}
+	return service.DigestFeedFormatRSS, true
+}
+
+func (h *DigestFeedHandler) GetToken(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	token, err := h.feed.GetToken(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, digestFeedTokenResponse(token))
+}
+
+func (h *DigestFeedHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	token, err := h.feed.RotateToken(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionTokenCreate, "digest_feed", nil)
+	writeJSON(w, digestFeedTokenResponse(&token))
+}
+
+func (h *DigestFeedHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if err := h.feed.RevokeToken(r.Context(), userID); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionTokenRevoke, "digest_feed", nil)
+	writeJSON(w, digestFeedTokenResponse(nil))
+}
+
+func digestFeedTokenResponse(token *string) map[string]any {
+	if token == nil || strings.TrimSpace(*token) == "" {
+		return map[string]any{"enabled": false, "token": nil, "feed_url": nil}
+	}
+	return map[string]any{
+		"enabled":  true,
+		"token":    *token,
+		"feed_url": service.DigestFeedURL(*token),
+	}
+}
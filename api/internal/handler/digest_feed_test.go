@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type stubDigestFeedService struct {
+	body   []byte
+	err    error
+	token  string
+	format service.DigestFeedFormat
+}
+
+func (s *stubDigestFeedService) Build(_ context.Context, token string, format service.DigestFeedFormat) (*service.DigestFeedResult, error) {
+	s.token = token
+	s.format = format
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &service.DigestFeedResult{Body: s.body, LastModified: time.Date(2026, 3, 27, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (s *stubDigestFeedService) GetToken(context.Context, string) (*string, error) { return nil, nil }
+func (s *stubDigestFeedService) RotateToken(context.Context, string) (string, error) {
+	return "df_new", nil
+}
+func (s *stubDigestFeedService) RevokeToken(context.Context, string) error { return nil }
+
+func TestDigestFeedRequiresToken(t *testing.T) {
+	stub := &stubDigestFeedService{body: []byte("<rss/>")}
+	rec := httptest.NewRecorder()
+	NewDigestFeedHandler(stub).Feed(rec, httptest.NewRequest(http.MethodGet, "/api/digests/feed", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestDigestFeedUnknownTokenReturnsNotFound(t *testing.T) {
+	stub := &stubDigestFeedService{err: repository.ErrNotFound}
+	rec := httptest.NewRecorder()
+	NewDigestFeedHandler(stub).Feed(rec, httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=bogus", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	var got struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Code != middleware.ErrCodeNotFound {
+		t.Fatalf("body = %s, want the %s error envelope", rec.Body.String(), middleware.ErrCodeNotFound)
+	}
+}
+
+func TestDigestFeedServesRSSAndHonorsETag(t *testing.T) {
+	stub := &stubDigestFeedService{body: []byte("<rss/>")}
+	h := NewDigestFeedHandler(stub)
+
+	rec := httptest.NewRecorder()
+	h.Feed(rec, httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if stub.token != "df_abc" {
+		t.Fatalf("token = %q, want df_abc", stub.token)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/rss+xml; charset=utf-8" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != digestFeedCacheControl {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	h.Feed(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+}
+
+func TestDigestFeedSelectsAtomByQueryOrAccept(t *testing.T) {
+	stub := &stubDigestFeedService{body: []byte("<feed/>")}
+	h := NewDigestFeedHandler(stub)
+
+	rec := httptest.NewRecorder()
+	h.Feed(rec, httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc&format=atom", nil))
+	if rec.Code != http.StatusOK || stub.format != service.DigestFeedFormatAtom {
+		t.Fatalf("status = %d format = %q, want 200 atom", rec.Code, stub.format)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/atom+xml; charset=utf-8" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc", nil)
+	req.Header.Set("Accept", "application/atom+xml, application/xml;q=0.9")
+	rec = httptest.NewRecorder()
+	h.Feed(rec, req)
+	if stub.format != service.DigestFeedFormatAtom {
+		t.Fatalf("format from Accept = %q, want atom", stub.format)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc&format=rss", nil)
+	req.Header.Set("Accept", "application/atom+xml")
+	rec = httptest.NewRecorder()
+	h.Feed(rec, req)
+	if stub.format != service.DigestFeedFormatRSS {
+		t.Fatalf("explicit format = %q, want rss", stub.format)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Feed(rec, httptest.NewRequest(http.MethodGet, "/api/digests/feed?token=df_abc&format=json", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid format status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type digestFeedbackStore interface {
+	GetFeedback(ctx context.Context, userID, itemID string) (*model.ItemFeedback, error)
+}
+
+// digestFeedbackApplier records feedback with the same search, cache and preference updates as
+// the in-app buttons; ItemHandler implements it.
+type digestFeedbackApplier interface {
+	ApplyFeedback(ctx context.Context, userID, itemID string, rating int, isFavorite bool) error
+	ApplyLater(ctx context.Context, userID, itemID string) error
+}
+
+// DigestFeedbackHandler serves the signed one-click feedback links in digest emails. The token
+// is the only credential, so no session is required.
+type DigestFeedbackHandler struct {
+	signer *service.DigestLinkSigner
+	repo   digestFeedbackStore
+	items  digestFeedbackApplier
+}
+
+func NewDigestFeedbackHandler(signer *service.DigestLinkSigner, repo digestFeedbackStore, items digestFeedbackApplier) *DigestFeedbackHandler {
+	return &DigestFeedbackHandler{signer: signer, repo: repo, items: items}
+}
+
+// Page validates the link and submits it back as a POST from the browser. Mail scanners that
+// prefetch links do not run the script, so they cannot record feedback.
+func (h *DigestFeedbackHandler) Page(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	if _, ok := h.verify(w, token); !ok {
+		return
+	}
+	form := fmt.Sprintf(`<form method="post"><input type="hidden" name="token" value="%s"><noscript><button type="submit">送信 / Submit</button></noscript></form><script>document.forms[0].submit()</script>`, html.EscapeString(token))
+	writeDigestPublicPage(w, http.StatusOK, "フィードバックを送信しています… / Sending feedback…", form)
+}
+
+func (h *DigestFeedbackHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeDigestPublicPage(w, http.StatusBadRequest, "リンクが正しくありません / Invalid link", "")
+		return
+	}
+	claim, ok := h.verify(w, r.PostForm.Get("token"))
+	if !ok {
+		return
+	}
+	if err := h.apply(r.Context(), claim); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeDigestPublicPage(w, http.StatusNotFound, "記事が見つかりません / Item not found", "")
+			return
+		}
+		log.Printf("digest feedback failed user_id=%s item_id=%s action=%s err=%v", claim.UserID, claim.ItemID, claim.Action, err)
+		writeDigestPublicPage(w, http.StatusInternalServerError, "送信に失敗しました / Could not save feedback", "")
+		return
+	}
+	writeDigestPublicPage(w, http.StatusOK, "フィードバックを記録しました / Feedback saved", "<p>ありがとうございます。今後のダイジェストに反映されます。 / Thanks, future digests will take it into account.</p>")
+}
+
+func (h *DigestFeedbackHandler) verify(w http.ResponseWriter, token string) (service.DigestFeedbackClaim, bool) {
+	if h.signer == nil {
+		writeDigestPublicPage(w, http.StatusNotFound, "このリンクは無効です / This link is not available", "")
+		return service.DigestFeedbackClaim{}, false
+	}
+	claim, err := h.signer.Verify(token)
+	if err != nil {
+		writeDigestPublicPage(w, http.StatusNotFound, "リンクの有効期限が切れているか、正しくありません / This link has expired or is invalid", "")
+		return service.DigestFeedbackClaim{}, false
+	}
+	return claim, true
+}
+
+func (h *DigestFeedbackHandler) apply(ctx context.Context, claim service.DigestFeedbackClaim) error {
+	if claim.Action == service.DigestFeedbackSave {
+		return h.items.ApplyLater(ctx, claim.UserID, claim.ItemID)
+	}
+	isFavorite := false
+	current, err := h.repo.GetFeedback(ctx, claim.UserID, claim.ItemID)
+	switch {
+	case err == nil:
+		isFavorite = current.IsFavorite
+	case !errors.Is(err, repository.ErrNotFound):
+		return err
+	}
+	rating := 1
+	if claim.Action == service.DigestFeedbackDown {
+		rating = -1
+		isFavorite = false
+	}
+	return h.items.ApplyFeedback(ctx, claim.UserID, claim.ItemID, rating, isFavorite)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type fakeDigestFeedbackStore struct {
+	feedback map[string]model.ItemFeedback
+	later    []string
+}
+
+func (f *fakeDigestFeedbackStore) GetFeedback(_ context.Context, userID, itemID string) (*model.ItemFeedback, error) {
+	fb, ok := f.feedback[userID+"/"+itemID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &fb, nil
+}
+
+func (f *fakeDigestFeedbackStore) ApplyFeedback(_ context.Context, userID, itemID string, rating int, isFavorite bool) error {
+	f.feedback[userID+"/"+itemID] = model.ItemFeedback{UserID: userID, ItemID: itemID, Rating: rating, IsFavorite: isFavorite}
+	return nil
+}
+
+func (f *fakeDigestFeedbackStore) ApplyLater(_ context.Context, _, itemID string) error {
+	f.later = append(f.later, itemID)
+	return nil
+}
+
+func postDigestFeedback(h *DigestFeedbackHandler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/digest-feedback", strings.NewReader(url.Values{"token": {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.Submit(rec, req)
+	return rec
+}
+
+func TestDigestFeedbackHandlerRecordsOnPostOnly(t *testing.T) {
+	store := &fakeDigestFeedbackStore{feedback: map[string]model.ItemFeedback{
+		"u1/i1": {UserID: "u1", ItemID: "i1", IsFavorite: true},
+	}}
+	signer := service.NewDigestLinkSigner("secret", "https://sifto.example")
+	h := NewDigestFeedbackHandler(signer, store, store)
+
+	up := signer.Sign("u1", "i1", service.DigestFeedbackUp)
+	rec := httptest.NewRecorder()
+	h.Page(rec, httptest.NewRequest(http.MethodGet, "/api/digest-feedback?token="+url.QueryEscape(up), nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `method="post"`) {
+		t.Fatalf("page status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if store.feedback["u1/i1"].Rating != 0 {
+		t.Fatalf("GET recorded feedback: %+v", store.feedback["u1/i1"])
+	}
+
+	if rec := postDigestFeedback(h, up); rec.Code != http.StatusOK {
+		t.Fatalf("up status = %d", rec.Code)
+	}
+	if fb := store.feedback["u1/i1"]; fb.Rating != 1 || !fb.IsFavorite {
+		t.Fatalf("after up = %+v, want rating 1 keeping favorite", fb)
+	}
+	if rec := postDigestFeedback(h, signer.Sign("u1", "i1", service.DigestFeedbackDown)); rec.Code != http.StatusOK {
+		t.Fatalf("down status = %d", rec.Code)
+	}
+	if fb := store.feedback["u1/i1"]; fb.Rating != -1 || fb.IsFavorite {
+		t.Fatalf("after down = %+v", fb)
+	}
+	if rec := postDigestFeedback(h, signer.Sign("u1", "i2", service.DigestFeedbackSave)); rec.Code != http.StatusOK || len(store.later) != 1 {
+		t.Fatalf("save status = %d later = %v", rec.Code, store.later)
+	}
+	if rec := postDigestFeedback(h, up+"x"); rec.Code != http.StatusNotFound {
+		t.Fatalf("tampered status = %d", rec.Code)
+	}
+}
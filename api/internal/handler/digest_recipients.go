@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type digestRecipientService interface {
+	List(ctx context.Context, userID string) ([]model.DigestRecipient, error)
+	Add(ctx context.Context, userID string, configID *string, email string) (*model.DigestRecipient, error)
+	Resend(ctx context.Context, userID, id string) error
+	Delete(ctx context.Context, userID, id string) error
+	Confirm(ctx context.Context, token string) (*model.DigestRecipient, error)
+}
+
+type digestDeliveryStore interface {
+	ListDeliveries(ctx context.Context, userID, digestID string) ([]model.DigestDelivery, error)
+}
+
+type DigestRecipientsHandler struct {
+	svc        digestRecipientService
+	deliveries digestDeliveryStore
+}
+
+func NewDigestRecipientsHandler(svc digestRecipientService, deliveries digestDeliveryStore) *DigestRecipientsHandler {
+	return &DigestRecipientsHandler{svc: svc, deliveries: deliveries}
+}
+
+func (h *DigestRecipientsHandler) List(w http.ResponseWriter, r *http.Request) {
+	recipients, err := h.svc.List(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"recipients": recipients})
+}
+
+func (h *DigestRecipientsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Email          string  `json:"email"`
+		DigestConfigID *string `json:"digest_config_id"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.DigestConfigID != nil && strings.TrimSpace(*body.DigestConfigID) == "" {
+		body.DigestConfigID = nil
+	}
+	rec, err := h.svc.Add(r.Context(), middleware.GetUserID(r), body.DigestConfigID, body.Email)
+	if err != nil {
+		writeDigestRecipientError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, rec)
+}
+
+func (h *DigestRecipientsHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Resend(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id")); err != nil {
+		writeDigestRecipientError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]string{"status": "queued"})
+}
+
+func (h *DigestRecipientsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Delete(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id")); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DigestRecipientsHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.deliveries.ListDeliveries(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"deliveries": deliveries})
+}
+
+// ConfirmPage renders the landing page of the confirmation link. It only shows a button:
+// mail scanners prefetch links, so the subscription is confirmed by the POST it submits.
+func (h *DigestRecipientsHandler) ConfirmPage(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.URL.Query().Get("token"))
+	if token == "" {
+		writeDigestPublicPage(w, http.StatusBadRequest, "リンクが正しくありません / Invalid link", "")
+		return
+	}
+	form := fmt.Sprintf(`<form method="post"><input type="hidden" name="token" value="%s"><button type="submit">受信を確認する / Confirm</button></form>`, html.EscapeString(token))
+	writeDigestPublicPage(w, http.StatusOK, "Sifto ダイジェストの受信を確認 / Confirm Sifto digest delivery", form)
+}
+
+func (h *DigestRecipientsHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeDigestPublicPage(w, http.StatusBadRequest, "リンクが正しくありません / Invalid link", "")
+		return
+	}
+	token := r.PostForm.Get("token")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	if _, err := h.svc.Confirm(r.Context(), token); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeDigestPublicPage(w, http.StatusNotFound, "リンクの有効期限が切れているか、すでに使用されています / This link has expired or was already used", "")
+			return
+		}
+		writeDigestPublicPage(w, http.StatusInternalServerError, "確認に失敗しました / Confirmation failed", "")
+		return
+	}
+	writeDigestPublicPage(w, http.StatusOK, "受信を確認しました / Delivery confirmed", "<p>次回からダイジェストが届きます。 / You will receive the next digest.</p>")
+}
+
+func writeDigestRecipientError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	if errors.As(err, &ve) {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeRepoError(w, err)
+}
+
+func writeDigestPublicPage(w http.ResponseWriter, status int, heading, bodyHTML string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<!doctype html><html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1"><title>Sifto</title></head><body style="font-family:sans-serif;max-width:480px;margin:48px auto;padding:0 16px"><h1 style="font-size:20px">%s</h1>%s</body></html>`, html.EscapeString(heading), bodyHTML)
+}
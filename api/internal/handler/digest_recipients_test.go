@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type noopDigestRecipientUsers struct{}
+
+func (noopDigestRecipientUsers) GetByID(_ context.Context, id string) (*model.User, error) {
+	return &model.User{ID: id, Email: id + "@example.com"}, nil
+}
+
+type noopDigestRecipientPublisher struct{}
+
+func (noopDigestRecipientPublisher) SendDigestRecipientConfirmationE(context.Context, string) error {
+	return nil
+}
+
+func TestDigestRecipientsHandlerConfirmRequiresPost(t *testing.T) {
+	store := repotest.NewDigestRecipientStore()
+	h := NewDigestRecipientsHandler(service.NewDigestRecipientService(store, noopDigestRecipientUsers{}, noopDigestRecipientPublisher{}), store)
+
+	rec := httptest.NewRecorder()
+	h.Create(rec, userRequest(http.MethodPost, "/digest-recipients", "u1", `{"email":"team@example.com"}`, nil))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	created, _ := store.ListByUser(context.Background(), "u1")
+	token, hash, _ := service.NewDigestRecipientToken()
+	if _, err := store.IssueConfirmToken(context.Background(), created[0].ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("IssueConfirmToken: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ConfirmPage(rec, httptest.NewRequest(http.MethodGet, "/api/digest-recipients/confirm?token="+url.QueryEscape(token), nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `method="post"`) {
+		t.Fatalf("confirm page status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if got, _ := store.Get(context.Background(), "u1", created[0].ID); got.Status != service.DigestRecipientStatusPending {
+		t.Fatalf("GET confirmed the recipient: %+v", got)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/digest-recipients/confirm", strings.NewReader(url.Values{"token": {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.Confirm(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("confirm status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if got, _ := store.Get(context.Background(), "u1", created[0].ID); got.Status != service.DigestRecipientStatusConfirmed {
+		t.Fatalf("recipient = %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ListDeliveries(rec, userRequest(http.MethodGet, "/digests/d1/deliveries", "u2", "", map[string]string{"id": "d1"}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign deliveries status = %d", rec.Code)
+	}
+}
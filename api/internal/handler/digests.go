@@ -1,26 +1,71 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
 	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
 type DigestHandler struct {
-	repo   *repository.DigestRepo
-	detail *service.DigestDetailService
+	repo     *repository.DigestRepo
+	detail   *service.DigestDetailService
+	audio    *service.DigestAudioService
+	regen    *service.DigestRegenerationService
+	approval *service.DigestApprovalService
+	read     *repository.DigestRepo
 }
 
 func NewDigestHandler(repo *repository.DigestRepo) *DigestHandler {
 	return &DigestHandler{repo: repo, detail: service.NewDigestDetailService(repo)}
 }
 
+func NewDigestHandlerWithAudio(repo *repository.DigestRepo, audio *service.DigestAudioService) *DigestHandler {
+	h := NewDigestHandler(repo)
+	h.audio = audio
+	return h
+}
+
+func (h *DigestHandler) WithRegeneration(regen *service.DigestRegenerationService) *DigestHandler {
+	h.regen = regen
+	return h
+}
+
+func (h *DigestHandler) WithApproval(approval *service.DigestApprovalService) *DigestHandler {
+	h.approval = approval
+	return h
+}
+
+// WithReadRepo serves the digest history list and GetStats from repo, typically on the read
+// replica.
+func (h *DigestHandler) WithReadRepo(repo *repository.DigestRepo) *DigestHandler {
+	h.read = repo
+	return h
+}
+
+func (h *DigestHandler) reader() *repository.DigestRepo {
+	if h.read != nil {
+		return h.read
+	}
+	return h.repo
+}
+
 func (h *DigestHandler) List(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
-	digests, err := h.repo.List(r.Context(), userID)
+	var digests []model.Digest
+	var err error
+	if configID := strings.TrimSpace(r.URL.Query().Get("config_id")); configID != "" {
+		digests, err = h.reader().ListForConfig(r.Context(), userID, configID, 30)
+	} else {
+		digests, err = h.reader().List(r.Context(), userID)
+	}
 	if err != nil {
 		writeRepoError(w, err)
 		return
@@ -48,3 +93,210 @@ func (h *DigestHandler) GetLatest(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, d)
 }
+
+func (h *DigestHandler) GetAudio(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	audio, err := h.repo.GetAudio(r.Context(), id, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if audio.Status == nil {
+		httpError(w, "audio not available", http.StatusNotFound)
+		return
+	}
+	resp := map[string]any{
+		"digest_id":    audio.DigestID,
+		"status":       *audio.Status,
+		"content_type": audio.ContentType,
+		"duration_sec": audio.DurationSec,
+		"generated_at": audio.GeneratedAt,
+		"error":        audio.Error,
+	}
+	if *audio.Status == "ready" && audio.ObjectKey != nil && audio.Bucket != nil && h.audio != nil {
+		url, expiresIn, err := h.audio.APILinkURL(r.Context(), *audio.Bucket, *audio.ObjectKey)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		resp["audio_url"] = url
+		resp["expires_in_sec"] = expiresIn
+	}
+	writeJSON(w, resp)
+}
+
+// GetProgress reports how far composition of the digest has got, for the UI to poll.
+func (h *DigestHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	progress, err := h.repo.GetComposeProgress(r.Context(), id, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	service.FillDigestComposeETA(progress, time.Now())
+	writeJSON(w, progress)
+}
+
+// GetStats reports the user's digests per JST month over ?months= (default 6, max 24).
+func (h *DigestHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	writeDigestStats(w, r, h.reader(), &userID)
+}
+
+func writeDigestStats(w http.ResponseWriter, r *http.Request, repo *repository.DigestRepo, userID *string) {
+	months := service.ClampDigestStatsMonths(parseIntOrDefault(r.URL.Query().Get("months"), service.DefaultDigestStatsMonths))
+	since := service.DigestStatsSince(time.Now(), months)
+	stats, err := repo.MonthlyStats(r.Context(), userID, since)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"months": months,
+		"since":  since.Format("2006-01-02"),
+		"stats":  stats,
+	})
+}
+
+func (h *DigestHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	if h.regen == nil {
+		httpError(w, "digest regeneration unavailable", http.StatusInternalServerError)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	var body struct {
+		Model *string `json:"model"`
+		Force bool    `json:"force"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	result, err := h.regen.Regenerate(r.Context(), userID, id, service.DigestRegenerateInput{
+		ModelOverride: body.Model,
+		Force:         body.Force,
+	})
+	if err != nil {
+		var mve *service.ModelValidationError
+		switch {
+		case errors.As(err, &mve):
+			httpError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrDigestAlreadySent):
+			httpError(w, err.Error(), http.StatusConflict)
+		default:
+			writeRepoError(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, result)
+}
+
+func (h *DigestHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	if h.approval == nil {
+		httpError(w, "digest approval unavailable", http.StatusInternalServerError)
+		return
+	}
+	id := chi.URLParam(r, "id")
+	if err := h.approval.Approve(r.Context(), middleware.GetUserID(r), id); err != nil {
+		if errors.Is(err, service.ErrDigestNotAwaitingApproval) {
+			httpError(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]any{"status": "approved", "digest_id": id})
+}
+
+func (h *DigestHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	if h.regen == nil {
+		httpError(w, "digest regeneration unavailable", http.StatusInternalServerError)
+		return
+	}
+	id := chi.URLParam(r, "id")
+	itemID := chi.URLParam(r, "itemId")
+	if err := h.regen.RemoveItem(r.Context(), middleware.GetUserID(r), id, itemID); err != nil {
+		if errors.Is(err, service.ErrDigestAlreadySent) {
+			httpError(w, "digest has already been sent", http.StatusConflict)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]any{"status": "accepted", "digest_id": id, "item_id": itemID})
+}
+
+func (h *DigestHandler) ListClusters(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	drafts, err := h.repo.ListClusterDrafts(r.Context(), id, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"digest_id": id, "clusters": drafts})
+}
+
+const maxDigestClusterLabelRunes = 200
+
+func (h *DigestHandler) UpdateCluster(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	clusterID := chi.URLParam(r, "clusterId")
+	var body struct {
+		ClusterLabel *string `json:"cluster_label"`
+		Dropped      *bool   `json:"dropped"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.ClusterLabel == nil && body.Dropped == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if body.ClusterLabel != nil {
+		label := strings.TrimSpace(*body.ClusterLabel)
+		if label == "" || utf8.RuneCountInString(label) > maxDigestClusterLabelRunes {
+			httpError(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		body.ClusterLabel = &label
+	}
+	draft, err := h.repo.UpdateClusterDraft(r.Context(), id, userID, clusterID, body.ClusterLabel, body.Dropped)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidState) {
+			httpError(w, "digest has already been sent", http.StatusConflict)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, draft)
+}
+
+func (h *DigestHandler) RecomposeClusters(w http.ResponseWriter, r *http.Request) {
+	if h.regen == nil {
+		httpError(w, "digest regeneration unavailable", http.StatusInternalServerError)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	if err := h.regen.Recompose(r.Context(), userID, id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrDigestNoActiveClusterDrafts):
+			httpError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrDigestAlreadySent):
+			httpError(w, "digest has already been sent", http.StatusConflict)
+		default:
+			writeRepoError(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]any{"status": "accepted", "digest_id": id})
+}
@@ -39,12 +39,12 @@ func (h *ElevenLabsVoicesHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "elevenlabs api key is not configured", http.StatusBadRequest)
+		httpError(w, "elevenlabs api key is not configured", http.StatusBadRequest)
 		return
 	}
 	catalog, err := h.service.FetchVoices(r.Context(), strings.TrimSpace(*apiKey))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	if catalog == nil {
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type entityReader interface {
+	ListByUser(ctx context.Context, userID, query, kind string, limit int) ([]model.Entity, error)
+	GetForUser(ctx context.Context, userID, entityID string) (*model.Entity, error)
+	ItemIDs(ctx context.Context, userID, entityID string, limit int) ([]string, error)
+}
+
+type itemLoader interface {
+	LoadByIDsPreservingOrder(ctx context.Context, userID string, ids []string) ([]model.Item, error)
+}
+
+type EntitiesHandler struct {
+	entityRepo entityReader
+	itemRepo   itemLoader
+}
+
+func NewEntitiesHandler(entityRepo entityReader, itemRepo itemLoader) *EntitiesHandler {
+	return &EntitiesHandler{entityRepo: entityRepo, itemRepo: itemRepo}
+}
+
+func validEntityKind(kind string) bool {
+	switch kind {
+	case "", service.EntityKindCompany, service.EntityKindPerson, service.EntityKindProduct, service.EntityKindOther:
+		return true
+	}
+	return false
+}
+
+func (h *EntitiesHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	kind := strings.TrimSpace(q.Get("kind"))
+	if !validEntityKind(kind) {
+		httpError(w, "invalid kind", http.StatusBadRequest)
+		return
+	}
+	limit := parseIntOrDefault(q.Get("limit"), 50)
+	if limit < 1 || limit > 200 {
+		httpError(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	entities, err := h.entityRepo.ListByUser(r.Context(), middleware.GetUserID(r), q.Get("q"), kind, limit)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"entities": entities})
+}
+
+func (h *EntitiesHandler) Items(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	entityID := strings.TrimSpace(chi.URLParam(r, "id"))
+	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 50)
+	if limit < 1 || limit > 200 {
+		httpError(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	entity, err := h.entityRepo.GetForUser(r.Context(), userID, entityID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if entity == nil {
+		httpError(w, "not found", http.StatusNotFound)
+		return
+	}
+	ids, err := h.entityRepo.ItemIDs(r.Context(), userID, entityID, limit)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	items, err := h.itemRepo.LoadByIDsPreservingOrder(r.Context(), userID, ids)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"entity": entity, "items": items})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestEntitiesListRejectsInvalidParams(t *testing.T) {
+	h := &EntitiesHandler{}
+	for _, target := range []string{"/api/entities?kind=place", "/api/entities?limit=0", "/api/entities?limit=201"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+
+		h.List(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want %d", target, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestEntitiesItemsRejectsInvalidLimit(t *testing.T) {
+	h := &EntitiesHandler{}
+	req := httptest.NewRequest(http.MethodGet, "/api/entities/e1/items?limit=500", nil)
+	rec := httptest.NewRecorder()
+
+	h.Items(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestEntitiesHandlerItems(t *testing.T) {
+	entities := repotest.NewEntityStore()
+	entities.Add("u1", model.Entity{ID: "e1", Name: "OpenAI", Kind: "company"}, "i2", "i1")
+	items := repotest.NewItemStore()
+	items.Add("u1", model.Item{ID: "i1"})
+	items.Add("u1", model.Item{ID: "i2"})
+	h := NewEntitiesHandler(entities, items)
+
+	rec := httptest.NewRecorder()
+	h.Items(rec, userRequest(http.MethodGet, "/entities/e1/items", "u2", "", map[string]string{"id": "e1"}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign entity status = %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Items(rec, userRequest(http.MethodGet, "/entities/e1/items", "u1", "", map[string]string{"id": "e1"}))
+	var resp struct {
+		Entity model.Entity `json:"entity"`
+		Items  []model.Item `json:"items"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v body=%s", err, rec.Body.String())
+	}
+	if resp.Entity.ItemCount != 2 || len(resp.Items) != 2 || resp.Items[0].ID != "i2" {
+		t.Fatalf("response = %+v", resp)
+	}
+}
@@ -91,11 +91,11 @@ func (h *FeatherlessModelsHandler) Sync(w http.ResponseWriter, r *http.Request)
 	userID := middleware.GetUserID(r)
 	apiKey, err := loadAndDecryptUserSecret(r.Context(), h.settingsRepo.GetFeatherlessAPIKeyEncrypted, h.cipher, userID, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "featherless api key is not configured", http.StatusBadRequest)
+		httpError(w, "featherless api key is not configured", http.StatusBadRequest)
 		return
 	}
 	syncRunID, err := h.repo.StartSyncRun(r.Context(), "manual")
@@ -107,7 +107,7 @@ func (h *FeatherlessModelsHandler) Sync(w http.ResponseWriter, r *http.Request)
 	if fetchErr != nil {
 		msg := fetchErr.Error()
 		_ = h.repo.FinishSyncRun(r.Context(), syncRunID, 0, 0, &msg)
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 	fetchedAt := time.Now().UTC()
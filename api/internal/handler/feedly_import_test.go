@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+func TestFetchFeedlySubscriptionsMapsFolders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/subscriptions" || r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "feed/https://a.example/rss", "title": "A", "categories": [{"id": "user/u/category/tech", "label": "Tech"}, {"id": "user/u/category/news", "label": "News"}]},
+			{"id": "feed/https://b.example/rss", "title": "", "categories": [{"id": "user/u/category/global.uncategorized", "label": "Uncategorized"}]},
+			{"id": "feed/https://a.example/rss", "title": "dup"}
+		]`))
+	}))
+	defer srv.Close()
+	orig := service.FeedlyAPIBaseURL
+	service.FeedlyAPIBaseURL = srv.URL
+	defer func() { service.FeedlyAPIBaseURL = orig }()
+
+	pairs, err := fetchFeedlySubscriptions(context.Background(), "tok")
+	if err != nil {
+		t.Fatalf("fetchFeedlySubscriptions: %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("pairs = %+v", pairs)
+	}
+	if pairs[0].URL != "https://a.example/rss" || pairs[0].Title == nil || *pairs[0].Title != "A" || pairs[0].Group == nil || *pairs[0].Group != "Tech" {
+		t.Fatalf("first pair = %+v", pairs[0])
+	}
+	if pairs[1].URL != "https://b.example/rss" || pairs[1].Title != nil || pairs[1].Group != nil {
+		t.Fatalf("second pair = %+v", pairs[1])
+	}
+
+	if _, err := fetchFeedlySubscriptions(context.Background(), "bad"); err == nil {
+		t.Fatal("expected error for rejected token")
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+const maxFilterRuleTopicLength = 100
+
+type filterRuleRepo interface {
+	ListRules(ctx context.Context, userID string) ([]model.FilterRule, error)
+	CreateRule(ctx context.Context, userID, topic string) (*model.FilterRule, error)
+	DeleteRule(ctx context.Context, userID, id string) error
+	ListPendingSuggestions(ctx context.Context, userID string) ([]model.FilterSuggestion, error)
+}
+
+type filterSuggestionActions interface {
+	Accept(ctx context.Context, userID, id string) (*model.FilterSuggestion, error)
+	Dismiss(ctx context.Context, userID, id string) (*model.FilterSuggestion, error)
+}
+
+type readingPlanStaler interface {
+	MarkStale(ctx context.Context, userID string) error
+}
+
+type FilterSuggestionsHandler struct {
+	repo          filterRuleRepo
+	suggestions   filterSuggestionActions
+	cache         service.JSONCache
+	planSnapshots readingPlanStaler
+}
+
+func NewFilterSuggestionsHandler(repo filterRuleRepo, suggestions filterSuggestionActions, cache service.JSONCache, planSnapshots readingPlanStaler) *FilterSuggestionsHandler {
+	return &FilterSuggestionsHandler{repo: repo, suggestions: suggestions, cache: cache, planSnapshots: planSnapshots}
+}
+
+func (h *FilterSuggestionsHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	out, err := h.repo.ListPendingSuggestions(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"suggestions": out})
+}
+
+func (h *FilterSuggestionsHandler) AcceptSuggestion(w http.ResponseWriter, r *http.Request) {
+	h.resolveSuggestion(w, r, h.suggestions.Accept)
+}
+
+func (h *FilterSuggestionsHandler) DismissSuggestion(w http.ResponseWriter, r *http.Request) {
+	h.resolveSuggestion(w, r, h.suggestions.Dismiss)
+}
+
+func (h *FilterSuggestionsHandler) resolveSuggestion(w http.ResponseWriter, r *http.Request, resolve func(ctx context.Context, userID, id string) (*model.FilterSuggestion, error)) {
+	userID := middleware.GetUserID(r)
+	sg, err := resolve(r.Context(), userID, chi.URLParam(r, "id"))
+	if errors.Is(err, service.ErrFilterSuggestionClosed) {
+		httpError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if sg.Status == "accepted" {
+		h.invalidate(r.Context(), userID)
+	}
+	writeJSON(w, sg)
+}
+
+func (h *FilterSuggestionsHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.repo.ListRules(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"rules": rules})
+}
+
+func (h *FilterSuggestionsHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Topic string `json:"topic"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	topic := strings.TrimSpace(body.Topic)
+	if topic == "" || utf8.RuneCountInString(topic) > maxFilterRuleTopicLength {
+		httpError(w, "invalid topic", http.StatusBadRequest)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	rule, err := h.repo.CreateRule(r.Context(), userID, topic)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	h.invalidate(r.Context(), userID)
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, rule)
+}
+
+func (h *FilterSuggestionsHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if err := h.repo.DeleteRule(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	h.invalidate(r.Context(), userID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// invalidate drops cached plans and lists so a new filter or mute shows up right away.
+func (h *FilterSuggestionsHandler) invalidate(ctx context.Context, userID string) {
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(ctx, prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
+	if h.planSnapshots != nil {
+		if err := h.planSnapshots.MarkStale(ctx, userID); err != nil {
+			log.Printf("reading plan snapshot stale failed user_id=%s err=%v", userID, err)
+		}
+	}
+}
@@ -28,17 +28,17 @@ func NewFishAudioModelsHandler(svc fishAudioCatalogFetcher) *FishAudioModelsHand
 func (h *FishAudioModelsHandler) Browse(w http.ResponseWriter, r *http.Request) {
 	rawSort := strings.TrimSpace(r.URL.Query().Get("sort"))
 	if err := service.ValidateFishAudioBrowseSort(rawSort); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	page, err := parseFishAudioBrowseInt(r, "page", 1)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	pageSize, err := parseFishAudioBrowseInt(r, "page_size", 24)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	result, err := h.service.BrowseModels(r.Context(), service.FishAudioBrowseParams{
@@ -48,7 +48,7 @@ func (h *FishAudioModelsHandler) Browse(w http.ResponseWriter, r *http.Request)
 		PageSize: pageSize,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, map[string]any{
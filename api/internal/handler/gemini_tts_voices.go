@@ -22,7 +22,7 @@ func NewGeminiTTSVoicesHandler(svc geminiTTSVoiceCatalogLoader) *GeminiTTSVoices
 func (h *GeminiTTSVoicesHandler) List(w http.ResponseWriter, r *http.Request) {
 	catalog, err := h.service.LoadCatalog(r.Context())
 	if err != nil {
-		http.Error(w, "failed to load gemini tts voice catalog", http.StatusInternalServerError)
+		httpError(w, "failed to load gemini tts voice catalog", http.StatusInternalServerError)
 		return
 	}
 	if catalog == nil {
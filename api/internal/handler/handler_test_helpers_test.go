@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+func userRequest(method, target, userID string, body string, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if len(params) > 0 {
+		routeCtx := chi.NewRouteContext()
+		for k, v := range params {
+			routeCtx.URLParams.Add(k, v)
+		}
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, routeCtx)
+	}
+	return req.WithContext(ctx)
+}
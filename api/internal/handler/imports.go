@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type importJobService interface {
+	Get(ctx context.Context, id, userID string) (*service.ImportJobView, error)
+}
+
+// ImportJobHandler reports the progress of source and item import jobs.
+type ImportJobHandler struct {
+	svc importJobService
+}
+
+func NewImportJobHandler(svc importJobService) *ImportJobHandler {
+	return &ImportJobHandler{svc: svc}
+}
+
+func (h *ImportJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, job)
+}
@@ -2,8 +2,6 @@ package handler
 
 import (
 	"context"
-	"crypto/subtle"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -11,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/model"
 	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
@@ -70,28 +69,72 @@ func NewInternalHandler(
 	}
 }
 
-func checkInternalSecret(r *http.Request) bool {
-	secret := strings.TrimSpace(service.InternalAPISecretFromEnv())
-	provided := strings.TrimSpace(r.Header.Get("X-Internal-Secret"))
-	if secret == "" || provided == "" {
-		return false
+var internalRequestVerifier = service.NewInternalRequestVerifier()
+
+// ShareInternalNonces keeps accepted request nonces in the cache's Redis, so a captured
+// internal request cannot be replayed against another API instance. Without Redis they stay
+// in this process.
+func ShareInternalNonces(cache service.JSONCache) {
+	client, prefix := service.RedisClientFromCache(cache)
+	internalRequestVerifier = service.NewInternalRequestVerifier().WithRedis(client, prefix)
+}
+
+var errInternalForbidden = errors.New("internal request is not authorized")
+
+// verifyInternalRequest accepts requests signed with INTERNAL_API_SECRET (or the previous
+// secret during a rotation). The unsigned X-Internal-Secret header only passes while
+// INTERNAL_AUTH_ALLOW_STATIC_SECRET is on.
+func verifyInternalRequest(r *http.Request) error {
+	secrets := service.InternalAPISecretsFromEnv()
+	if len(secrets) == 0 {
+		return errInternalForbidden
+	}
+	if r.Header.Get(service.InternalSignatureHeader) == "" {
+		if service.InternalStaticSecretAllowedFromEnv() && service.MatchInternalSecret(r.Header.Get("X-Internal-Secret"), secrets) {
+			return nil
+		}
+		return errInternalForbidden
 	}
-	return subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) == 1
+	if err := internalRequestVerifier.Verify(r, secrets); err != nil {
+		log.Printf("internal auth rejected method=%s path=%s err=%v", r.Method, r.URL.Path, err)
+		return err
+	}
+	return nil
 }
 
-func checkInternalAdmin(r *http.Request) bool {
-	if !checkInternalSecret(r) {
-		return false
+// authorizeInternal answers 403 for requests that fail internal auth, or 413 when the body was
+// too large to check the signature, and reports whether the handler may go on.
+func authorizeInternal(w http.ResponseWriter, r *http.Request) bool {
+	return writeInternalAuthError(w, verifyInternalRequest(r))
+}
+
+// authorizeInternalAdmin also requires X-Internal-User-Email, which the signature covers, to be
+// a prompt admin.
+func authorizeInternalAdmin(w http.ResponseWriter, r *http.Request) bool {
+	err := verifyInternalRequest(r)
+	if err == nil && !service.NewPromptAdminAuthServiceFromEnv().CanManagePrompts(strings.TrimSpace(r.Header.Get(service.InternalUserEmailHeader))) {
+		err = errInternalForbidden
+	}
+	return writeInternalAuthError(w, err)
+}
+
+func writeInternalAuthError(w http.ResponseWriter, err error) bool {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &tooLarge):
+		middleware.WriteBodyTooLarge(w, tooLarge.Limit)
+	default:
+		httpError(w, "forbidden", http.StatusForbidden)
 	}
-	email := strings.TrimSpace(r.Header.Get("X-Internal-User-Email"))
-	return service.NewPromptAdminAuthServiceFromEnv().CanManagePrompts(email)
+	return false
 }
 
 // UpsertUser はメールアドレスでユーザーを取得または作成して UUID を返す内部エンドポイント。
-// Next.js の auth bridge / debug route から呼ばれる。X-Internal-Secret で保護。
+// Next.js の auth bridge / debug route から呼ばれる。署名付き内部リクエストで保護。
 func (h *InternalHandler) UpsertUser(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalSecret(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternal(w, r) {
 		return
 	}
 
@@ -99,15 +142,18 @@ func (h *InternalHandler) UpsertUser(w http.ResponseWriter, r *http.Request) {
 		Email string  `json:"email"`
 		Name  *string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Email == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Email == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	user, err := h.userRepo.Upsert(r.Context(), body.Email, body.Name)
 	if err != nil {
 		log.Printf("internal users upsert failed: email=%s err=%v", body.Email, err)
-		http.Error(w, fmt.Sprintf("upsert user failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("upsert user failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -117,8 +163,7 @@ func (h *InternalHandler) UpsertUser(w http.ResponseWriter, r *http.Request) {
 // ResolveIdentity は external auth provider の subject を internal user_id へ解決する。
 // identity が未登録なら email ベースで既存/新規 user を解決し、provider identity を保存する。
 func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalSecret(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternal(w, r) {
 		return
 	}
 
@@ -128,15 +173,14 @@ func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request
 		Email          string  `json:"email"`
 		Name           *string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	body.Provider = strings.TrimSpace(body.Provider)
 	body.ProviderUserID = strings.TrimSpace(body.ProviderUserID)
 	body.Email = strings.TrimSpace(strings.ToLower(body.Email))
 	if body.Provider == "" || body.ProviderUserID == "" || body.Email == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
@@ -155,7 +199,7 @@ func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request
 	}
 	if !errors.Is(err, pgx.ErrNoRows) {
 		log.Printf("internal resolve identity lookup failed: provider=%s provider_user_id=%s err=%v", body.Provider, body.ProviderUserID, err)
-		http.Error(w, fmt.Sprintf("lookup identity failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("lookup identity failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -163,14 +207,14 @@ func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request
 	userCreated := false
 	if getErr != nil && !errors.Is(getErr, pgx.ErrNoRows) {
 		log.Printf("internal resolve identity user lookup failed: email=%s err=%v", body.Email, getErr)
-		http.Error(w, fmt.Sprintf("resolve identity failed: %v", getErr), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("resolve identity failed: %v", getErr), http.StatusInternalServerError)
 		return
 	}
 	if errors.Is(getErr, pgx.ErrNoRows) {
 		user, err = h.userRepo.Upsert(r.Context(), body.Email, body.Name)
 		if err != nil {
 			log.Printf("internal resolve identity user upsert failed: provider=%s provider_user_id=%s email=%s err=%v", body.Provider, body.ProviderUserID, body.Email, err)
-			http.Error(w, fmt.Sprintf("resolve identity failed: %v", err), http.StatusInternalServerError)
+			httpError(w, fmt.Sprintf("resolve identity failed: %v", err), http.StatusInternalServerError)
 			return
 		}
 		userCreated = true
@@ -179,7 +223,7 @@ func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request
 	identity, err = h.identityRepo.Upsert(r.Context(), user.ID, body.Provider, body.ProviderUserID, &body.Email)
 	if err != nil {
 		log.Printf("internal resolve identity upsert failed: provider=%s provider_user_id=%s user_id=%s err=%v", body.Provider, body.ProviderUserID, user.ID, err)
-		http.Error(w, fmt.Sprintf("upsert identity failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("upsert identity failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -195,12 +239,11 @@ func (h *InternalHandler) ResolveIdentity(w http.ResponseWriter, r *http.Request
 }
 
 func (h *InternalHandler) UpsertObsidianGitHubInstallation(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalSecret(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternal(w, r) {
 		return
 	}
 	if h.obsidianRepo == nil || h.githubApp == nil || !h.githubApp.Enabled() {
-		http.Error(w, "github app unavailable", http.StatusInternalServerError)
+		httpError(w, "github app unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -208,19 +251,18 @@ func (h *InternalHandler) UpsertObsidianGitHubInstallation(w http.ResponseWriter
 		UserID         string `json:"user_id"`
 		InstallationID int64  `json:"installation_id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	body.UserID = strings.TrimSpace(body.UserID)
 	if body.UserID == "" || body.InstallationID <= 0 {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	installation, err := h.githubApp.GetInstallation(r.Context(), body.InstallationID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("get installation failed: %v", err), http.StatusBadGateway)
+		httpError(w, fmt.Sprintf("get installation failed: %v", err), http.StatusBadGateway)
 		return
 	}
 	var owner *string
@@ -232,7 +274,7 @@ func (h *InternalHandler) UpsertObsidianGitHubInstallation(w http.ResponseWriter
 	}
 	settings, err := h.obsidianRepo.UpsertInstallation(r.Context(), body.UserID, body.InstallationID, owner)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("save installation failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("save installation failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, map[string]any{
@@ -252,12 +294,11 @@ func (h *InternalHandler) UpsertObsidianGitHubInstallation(w http.ResponseWriter
 }
 
 func (h *InternalHandler) DebugGenerateDigest(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.userRepo == nil || h.itemRepo == nil || h.digestRepo == nil || h.publisher == nil {
-		http.Error(w, "debug digest unavailable", http.StatusInternalServerError)
+		httpError(w, "debug digest unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -266,13 +307,15 @@ func (h *InternalHandler) DebugGenerateDigest(w http.ResponseWriter, r *http.Req
 		DigestDate *string `json:"digest_date"` // JST date, YYYY-MM-DD
 		SkipSend   bool    `json:"skip_send"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&body)
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
 
 	targetDate := timeutil.StartOfDayJST(timeutil.NowJST())
 	if body.DigestDate != nil && *body.DigestDate != "" {
 		t, err := time.ParseInLocation("2006-01-02", *body.DigestDate, time.FixedZone("JST", 9*60*60))
 		if err != nil {
-			http.Error(w, "invalid digest_date", http.StatusBadRequest)
+			httpError(w, "invalid digest_date", http.StatusBadRequest)
 			return
 		}
 		targetDate = timeutil.StartOfDayJST(t)
@@ -282,7 +325,7 @@ func (h *InternalHandler) DebugGenerateDigest(w http.ResponseWriter, r *http.Req
 
 	users, err := h.userRepo.ListAll(r.Context())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list users: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list users: %v", err), http.StatusInternalServerError)
 		return
 	}
 	if body.UserID != nil && *body.UserID != "" {
@@ -375,26 +418,28 @@ func (h *InternalHandler) DebugGenerateDigest(w http.ResponseWriter, r *http.Req
 }
 
 func (h *InternalHandler) DebugSendDigest(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.digestRepo == nil || h.publisher == nil {
-		http.Error(w, "debug digest unavailable", http.StatusInternalServerError)
+		httpError(w, "debug digest unavailable", http.StatusInternalServerError)
 		return
 	}
 
 	var body struct {
 		DigestID string `json:"digest_id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.DigestID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.DigestID == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	digest, err := h.digestRepo.GetForEmail(r.Context(), body.DigestID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("fetch digest: %v", err), http.StatusNotFound)
+		httpError(w, fmt.Sprintf("fetch digest: %v", err), http.StatusNotFound)
 		return
 	}
 	userEmail := ""
@@ -403,7 +448,7 @@ func (h *InternalHandler) DebugSendDigest(w http.ResponseWriter, r *http.Request
 	}
 	users, err := h.userRepo.ListAll(r.Context())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list users: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list users: %v", err), http.StatusInternalServerError)
 		return
 	}
 	for _, u := range users {
@@ -413,12 +458,12 @@ func (h *InternalHandler) DebugSendDigest(w http.ResponseWriter, r *http.Request
 		}
 	}
 	if userEmail == "" {
-		http.Error(w, "digest user email not found", http.StatusNotFound)
+		httpError(w, "digest user email not found", http.StatusNotFound)
 		return
 	}
 
 	if err := h.publisher.SendDigestCreatedE(r.Context(), digest.ID, digest.UserID, userEmail); err != nil {
-		http.Error(w, "failed to enqueue digest send", http.StatusBadGateway)
+		httpError(w, "failed to enqueue digest send", http.StatusBadGateway)
 		return
 	}
 
@@ -432,12 +477,11 @@ func (h *InternalHandler) DebugSendDigest(w http.ResponseWriter, r *http.Request
 }
 
 func (h *InternalHandler) DebugBackfillEmbeddings(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.itemRepo == nil || h.publisher == nil {
-		http.Error(w, "embedding backfill unavailable", http.StatusInternalServerError)
+		httpError(w, "embedding backfill unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -446,18 +490,20 @@ func (h *InternalHandler) DebugBackfillEmbeddings(w http.ResponseWriter, r *http
 		Limit  int     `json:"limit"`
 		DryRun bool    `json:"dry_run"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&body)
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
 	if body.Limit <= 0 {
 		body.Limit = 100
 	}
 	if body.Limit > 1000 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 
 	targets, err := h.itemRepo.ListEmbeddingBackfillTargets(r.Context(), body.UserID, body.Limit)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list embedding backfill targets: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list embedding backfill targets: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -506,12 +552,11 @@ func (h *InternalHandler) DebugBackfillEmbeddings(w http.ResponseWriter, r *http
 }
 
 func (h *InternalHandler) DebugSendPushTest(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.oneSignal == nil || !h.oneSignal.Enabled() {
-		http.Error(w, "onesignal is not configured", http.StatusBadRequest)
+		httpError(w, "onesignal is not configured", http.StatusBadRequest)
 		return
 	}
 	var body struct {
@@ -522,7 +567,9 @@ func (h *InternalHandler) DebugSendPushTest(w http.ResponseWriter, r *http.Reque
 		URL            string         `json:"url"`
 		Data           map[string]any `json:"data"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&body)
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
 	title := strings.TrimSpace(body.Title)
 	if title == "" {
 		title = "Sifto: テスト通知"
@@ -540,7 +587,7 @@ func (h *InternalHandler) DebugSendPushTest(w http.ResponseWriter, r *http.Reque
 		subscriptionID = strings.TrimSpace(*body.SubscriptionID)
 	}
 	if externalID == "" && subscriptionID == "" {
-		http.Error(w, "external_id or subscription_id is required", http.StatusBadRequest)
+		httpError(w, "external_id or subscription_id is required", http.StatusBadRequest)
 		return
 	}
 	var (
@@ -556,7 +603,7 @@ func (h *InternalHandler) DebugSendPushTest(w http.ResponseWriter, r *http.Reque
 		res, err = h.oneSignal.SendToExternalID(r.Context(), externalID, title, message, body.URL, body.Data)
 	}
 	if err != nil {
-		http.Error(w, fmt.Sprintf("send push: %v", err), http.StatusBadGateway)
+		httpError(w, fmt.Sprintf("send push: %v", err), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, map[string]any{
@@ -571,12 +618,11 @@ func (h *InternalHandler) DebugSendPushTest(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *InternalHandler) DebugBackfillItemSearch(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -586,17 +632,17 @@ func (h *InternalHandler) DebugBackfillItemSearch(w http.ResponseWriter, r *http
 	limit := parseIntOrDefault(strings.TrimSpace(r.URL.Query().Get("limit")), 500)
 	allItems := parseBoolQuery(r.URL.Query().Get("all"))
 	if limit < 1 || limit > 5000 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	if offset < 0 {
-		http.Error(w, "invalid offset", http.StatusBadRequest)
+		httpError(w, "invalid offset", http.StatusBadRequest)
 		return
 	}
 
 	totalSummarized, err := docRepo.CountSummarized(r.Context())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("count search targets failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("count search targets failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 	remaining := totalSummarized - offset
@@ -623,17 +669,17 @@ func (h *InternalHandler) DebugBackfillItemSearch(w http.ResponseWriter, r *http
 
 	run, err := runRepo.Create(r.Context(), offset, limit, allItems, totalItems, queuedBatches)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("create backfill run failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("create backfill run failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	if queuedBatches > 0 {
 		if err := h.publisher.SendItemSearchBackfillRunE(r.Context(), run.ID); err != nil {
 			if _, markErr := runRepo.MarkFanoutFailed(r.Context(), run.ID, err.Error()); markErr != nil {
-				http.Error(w, fmt.Sprintf("mark backfill run failed: %v", markErr), http.StatusInternalServerError)
+				httpError(w, fmt.Sprintf("mark backfill run failed: %v", markErr), http.StatusInternalServerError)
 				return
 			}
-			http.Error(w, fmt.Sprintf("enqueue backfill failed: %v", err), http.StatusBadGateway)
+			httpError(w, fmt.Sprintf("enqueue backfill failed: %v", err), http.StatusBadGateway)
 			return
 		}
 	}
@@ -651,21 +697,20 @@ func (h *InternalHandler) DebugBackfillItemSearch(w http.ResponseWriter, r *http
 }
 
 func (h *InternalHandler) DebugGetItemSearchBackfillRuns(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 
 	runRepo := repository.NewSearchBackfillRunRepo(h.db)
 	limit := parseIntOrDefault(strings.TrimSpace(r.URL.Query().Get("limit")), 10)
 	if limit < 1 || limit > 100 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 
 	runs, err := runRepo.ListRecent(r.Context(), limit)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list backfill runs failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list backfill runs failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -675,15 +720,14 @@ func (h *InternalHandler) DebugGetItemSearchBackfillRuns(w http.ResponseWriter,
 }
 
 func (h *InternalHandler) DebugDeleteFinishedItemSearchBackfillRuns(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 
 	runRepo := repository.NewSearchBackfillRunRepo(h.db)
 	deleted, err := runRepo.DeleteFinished(r.Context())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("delete finished backfill runs failed: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("delete finished backfill runs failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -703,12 +747,11 @@ func parseBoolQuery(value string) bool {
 }
 
 func (h *InternalHandler) DebugBackfillTranslatedTitles(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.itemRepo == nil || h.worker == nil || h.settings == nil || h.cipher == nil {
-		http.Error(w, "translated-title backfill unavailable", http.StatusInternalServerError)
+		httpError(w, "translated-title backfill unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -717,18 +760,20 @@ func (h *InternalHandler) DebugBackfillTranslatedTitles(w http.ResponseWriter, r
 		Limit  int     `json:"limit"`
 		DryRun bool    `json:"dry_run"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&body)
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
 	if body.Limit <= 0 {
 		body.Limit = 100
 	}
 	if body.Limit > 2000 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 
 	targets, err := h.itemRepo.ListTranslatedTitleBackfillTargets(r.Context(), body.UserID, body.Limit)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list translated-title backfill targets: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list translated-title backfill targets: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -1192,8 +1237,7 @@ func (h *InternalHandler) loadCerebrasAPIKey(ctx context.Context, userID string)
 }
 
 func (h *InternalHandler) DebugSystemStatus(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	type checkResult struct {
@@ -1244,7 +1288,7 @@ func (h *InternalHandler) DebugSystemStatus(w http.ResponseWriter, r *http.Reque
 			return "", 0, nil, fmt.Errorf("worker client not configured")
 		}
 		err := h.worker.Health(ctx)
-		return "GET /health", 200, nil, err
+		return "GET /health", 200, map[string]any{"breaker": h.worker.BreakerSnapshot(), "native_llm_mode": h.worker.NativeLLMMode()}, err
 	})
 	run("meilisearch", func(ctx context.Context) (string, int, map[string]any, error) {
 		if h.search == nil {
@@ -1339,6 +1383,8 @@ func (h *InternalHandler) DebugSystemStatus(w http.ResponseWriter, r *http.Reque
 		"checked_at":                 now.Format(time.RFC3339Nano),
 		"checks":                     checks,
 		"cache_stats":                cacheStatsSnapshotAll(),
+		"db_pool":                    repository.PoolStatsOf(h.db),
+		"db_breakers":                map[string]any{"dashboard": dashboardDBBreaker.Snapshot()},
 		"cache_stats_by_window":      cacheWindows,
 		"cache_metrics_user_id":      metricUserID,
 		"cache_stats_by_window_user": cacheWindowsUser,
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
@@ -36,38 +35,37 @@ type concatCompleteRequest struct {
 
 func (h *InternalAudioBriefingsHandler) ConcatComplete(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 
 	rawToken := extractBearerToken(r)
 	if rawToken == "" {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpError(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	jobID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if jobID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	var body concatCompleteRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	body.RequestID = strings.TrimSpace(body.RequestID)
 	body.Status = strings.TrimSpace(body.Status)
 	if body.RequestID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if body.Status == "" {
 		body.Status = "published"
 	}
 	if body.Status != "published" && body.Status != "failed" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
@@ -79,7 +77,7 @@ func (h *InternalAudioBriefingsHandler) ConcatComplete(w http.ResponseWriter, r
 	errorMessage := trimOptionalString(body.ErrorMessage)
 
 	if body.Status == "published" && audioObjectKey == nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	if body.Status == "failed" && errorCode == nil {
@@ -87,7 +85,7 @@ func (h *InternalAudioBriefingsHandler) ConcatComplete(w http.ResponseWriter, r
 		errorCode = &defaultCode
 	}
 	if body.AudioDurationSec != nil && *body.AudioDurationSec < 0 {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
@@ -108,9 +106,9 @@ func (h *InternalAudioBriefingsHandler) ConcatComplete(w http.ResponseWriter, r
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrUnauthorized):
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			httpError(w, "unauthorized", http.StatusUnauthorized)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
 			writeRepoError(w, err)
 		}
@@ -138,28 +136,28 @@ func (h *InternalAudioBriefingsHandler) ConcatComplete(w http.ResponseWriter, r
 
 func (h *InternalAudioBriefingsHandler) ChunkHeartbeat(w http.ResponseWriter, r *http.Request) {
 	if h.repo == nil {
-		http.Error(w, "audio briefing unavailable", http.StatusInternalServerError)
+		httpError(w, "audio briefing unavailable", http.StatusInternalServerError)
 		return
 	}
 
 	rawToken := extractBearerToken(r)
 	if rawToken == "" {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpError(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	chunkID := strings.TrimSpace(chi.URLParam(r, "chunkID"))
 	if chunkID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.repo.TouchChunkHeartbeat(r.Context(), chunkID, service.HashAudioBriefingCallbackToken(rawToken)); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrUnauthorized):
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			httpError(w, "unauthorized", http.StatusUnauthorized)
 		case errors.Is(err, repository.ErrInvalidState), errors.Is(err, repository.ErrConflict):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
 			writeRepoError(w, err)
 		}
@@ -1,41 +1,102 @@
 package handler
 
 import (
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
 )
 
-func TestCheckInternalSecretFailsClosed(t *testing.T) {
+func authorizeInternalStatus(req *http.Request, admin bool) (bool, int) {
+	rec := httptest.NewRecorder()
+	if admin {
+		return authorizeInternalAdmin(rec, req), rec.Code
+	}
+	return authorizeInternal(rec, req), rec.Code
+}
+
+func TestAuthorizeInternalFailsClosed(t *testing.T) {
 	t.Setenv("INTERNAL_API_SECRET", "")
 	req := httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
-	if checkInternalSecret(req) {
-		t.Fatal("checkInternalSecret() = true with an empty configured secret")
+	service.SignInternalRequest(req, "", nil)
+	if ok, code := authorizeInternalStatus(req, false); ok || code != http.StatusForbidden {
+		t.Fatalf("authorizeInternal() = %v, %d with an empty configured secret", ok, code)
 	}
 }
 
-func TestCheckInternalSecretRequiresMatchingNonEmptyHeader(t *testing.T) {
+func TestAuthorizeInternalRequiresSignedRequest(t *testing.T) {
 	t.Setenv("INTERNAL_API_SECRET", "internal-secret")
-	req := httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
-	if checkInternalSecret(req) {
-		t.Fatal("checkInternalSecret() = true without a request header")
+	req := httptest.NewRequest("POST", "/api/internal/users/upsert", strings.NewReader(`{"email":"a@example.com"}`))
+	if ok, _ := authorizeInternalStatus(req, false); ok {
+		t.Fatal("authorizeInternal() = true without a signature")
 	}
 	req.Header.Set("X-Internal-Secret", "internal-secret")
-	if !checkInternalSecret(req) {
-		t.Fatal("checkInternalSecret() = false with the matching secret")
+	if ok, _ := authorizeInternalStatus(req, false); ok {
+		t.Fatal("authorizeInternal() = true for the static secret header")
+	}
+
+	service.SignInternalRequest(req, "internal-secret", []byte(`{"email":"a@example.com"}`))
+	if ok, _ := authorizeInternalStatus(req, false); !ok {
+		t.Fatal("authorizeInternal() = false for a signed request")
+	}
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"email":"a@example.com"}` {
+		t.Fatalf("body after verification = %q", body)
+	}
+}
+
+func TestAuthorizeInternalAllowsStaticSecretWhenEnabled(t *testing.T) {
+	t.Setenv("INTERNAL_API_SECRET", "internal-secret")
+	t.Setenv("INTERNAL_API_SECRET_PREVIOUS", "old-secret")
+	t.Setenv("INTERNAL_AUTH_ALLOW_STATIC_SECRET", "true")
+	req := httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
+	req.Header.Set("X-Internal-Secret", "old-secret")
+	if ok, _ := authorizeInternalStatus(req, false); !ok {
+		t.Fatal("authorizeInternal() = false for the previous static secret")
+	}
+	req.Header.Set("X-Internal-Secret", "wrong")
+	if ok, _ := authorizeInternalStatus(req, false); ok {
+		t.Fatal("authorizeInternal() = true for a wrong static secret")
 	}
 }
 
-func TestCheckInternalAdminRequiresAllowlistedEmail(t *testing.T) {
+func TestAuthorizeInternalAnswersTooLargeBody(t *testing.T) {
+	t.Setenv("INTERNAL_API_SECRET", "internal-secret")
+	body := strings.Repeat("x", 64)
+	req := httptest.NewRequest("POST", "/api/internal/users/upsert", strings.NewReader(body))
+	service.SignInternalRequest(req, "internal-secret", []byte(body))
+	rec := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(rec, req.Body, 16)
+	if authorizeInternal(rec, req) || rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestAuthorizeInternalAdminRequiresSignedAllowlistedEmail(t *testing.T) {
 	t.Setenv("INTERNAL_API_SECRET", "internal-secret")
 	t.Setenv("PROMPT_ADMIN_EMAILS", "admin@example.com")
 	req := httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
-	req.Header.Set("X-Internal-Secret", "internal-secret")
-	req.Header.Set("X-Internal-User-Email", "user@example.com")
-	if checkInternalAdmin(req) {
-		t.Fatal("checkInternalAdmin() = true for a non-admin email")
+	req.Header.Set(service.InternalUserEmailHeader, "user@example.com")
+	service.SignInternalRequest(req, "internal-secret", nil)
+	if ok, code := authorizeInternalStatus(req, true); ok || code != http.StatusForbidden {
+		t.Fatalf("authorizeInternalAdmin() = %v, %d for a non-admin email", ok, code)
 	}
-	req.Header.Set("X-Internal-User-Email", "ADMIN@example.com")
-	if !checkInternalAdmin(req) {
-		t.Fatal("checkInternalAdmin() = false for an allowlisted email")
+
+	req = httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
+	req.Header.Set(service.InternalUserEmailHeader, "ADMIN@example.com")
+	service.SignInternalRequest(req, "internal-secret", nil)
+	if ok, _ := authorizeInternalStatus(req, true); !ok {
+		t.Fatal("authorizeInternalAdmin() = false for an allowlisted email")
+	}
+
+	// Swapping the email after signing breaks the signature.
+	req = httptest.NewRequest("GET", "/api/internal/debug/system-status", nil)
+	req.Header.Set(service.InternalUserEmailHeader, "user@example.com")
+	service.SignInternalRequest(req, "internal-secret", nil)
+	req.Header.Set(service.InternalUserEmailHeader, "admin@example.com")
+	if ok, _ := authorizeInternalStatus(req, true); ok {
+		t.Fatal("authorizeInternalAdmin() = true for an email set after signing")
 	}
 }
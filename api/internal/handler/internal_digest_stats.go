@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type InternalDigestStatsHandler struct {
+	repo *repository.DigestRepo
+}
+
+func NewInternalDigestStatsHandler(repo *repository.DigestRepo) *InternalDigestStatsHandler {
+	return &InternalDigestStatsHandler{repo: repo}
+}
+
+// Stats is GET /api/internal/digests/stats: the DigestHandler.GetStats numbers across every
+// user, behind internal auth for monitors.
+func (h *InternalDigestStatsHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternal(w, r) {
+		return
+	}
+	writeDigestStats(w, r, h.repo, nil)
+}
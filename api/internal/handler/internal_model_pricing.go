@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type InternalModelPricingHandler struct {
+	svc *service.ModelPricingService
+}
+
+func NewInternalModelPricingHandler(svc *service.ModelPricingService) *InternalModelPricingHandler {
+	return &InternalModelPricingHandler{svc: svc}
+}
+
+type modelPricingRequest struct {
+	Provider             string  `json:"provider"`
+	Model                string  `json:"model"`
+	InputPerMTokUSD      float64 `json:"input_per_mtok_usd"`
+	OutputPerMTokUSD     float64 `json:"output_per_mtok_usd"`
+	CacheReadPerMTokUSD  float64 `json:"cache_read_per_mtok_usd"`
+	CacheWritePerMTokUSD float64 `json:"cache_write_per_mtok_usd"`
+	Note                 *string `json:"note"`
+}
+
+func (h *InternalModelPricingHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	rows, err := h.svc.List(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"items": rows})
+}
+
+// Upsert creates or replaces the price keyed by provider and model.
+func (h *InternalModelPricingHandler) Upsert(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	var body modelPricingRequest
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	row, err := h.svc.Upsert(r.Context(), repository.ModelPricingInput{
+		Provider:             body.Provider,
+		Model:                body.Model,
+		InputPerMTokUSD:      body.InputPerMTokUSD,
+		OutputPerMTokUSD:     body.OutputPerMTokUSD,
+		CacheReadPerMTokUSD:  body.CacheReadPerMTokUSD,
+		CacheWritePerMTokUSD: body.CacheWritePerMTokUSD,
+		Note:                 body.Note,
+	})
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, row)
+}
+
+func (h *InternalModelPricingHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InternalModelPricingHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	var body struct {
+		Limit  int  `json:"limit"`
+		DryRun bool `json:"dry_run"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	if body.Limit <= 0 {
+		body.Limit = 500
+	}
+	if body.Limit > 5000 {
+		httpError(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	result, err := h.svc.Reconcile(r.Context(), body.Limit, body.DryRun)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, result)
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -88,12 +87,11 @@ func parseOptionalBackfillTime(raw *string, inclusiveEnd bool) (*time.Time, erro
 }
 
 func (h *InternalHandler) DebugBackfillOpenRouterCosts(w http.ResponseWriter, r *http.Request) {
-	if !checkInternalAdmin(r) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+	if !authorizeInternalAdmin(w, r) {
 		return
 	}
 	if h.db == nil {
-		http.Error(w, "openrouter backfill unavailable", http.StatusInternalServerError)
+		httpError(w, "openrouter backfill unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -104,29 +102,31 @@ func (h *InternalHandler) DebugBackfillOpenRouterCosts(w http.ResponseWriter, r
 		From   *string `json:"from"`
 		To     *string `json:"to"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&body)
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
 	if body.Limit <= 0 {
 		body.Limit = 200
 	}
 	if body.Limit > 5000 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	from, err := parseOptionalBackfillTime(body.From, false)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	to, err := parseOptionalBackfillTime(body.To, true)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	repo := repository.NewLLMUsageLogRepo(h.db)
 	targets, err := repo.ListOpenRouterBackfillCandidates(r.Context(), body.UserID, body.Limit, from, to)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("list openrouter backfill targets: %v", err), http.StatusInternalServerError)
+		httpError(w, fmt.Sprintf("list openrouter backfill targets: %v", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type InternalPipelineHandler struct {
+	svc *service.PipelineStatusService
+}
+
+func NewInternalPipelineHandler(svc *service.PipelineStatusService) *InternalPipelineHandler {
+	return &InternalPipelineHandler{svc: svc}
+}
+
+// Status needs only internal auth, not an admin email, so monitors can poll it. With
+// ?fail_on_degraded=1 a degraded pipeline answers 503, for checks that only look at the
+// status code.
+func (h *InternalPipelineHandler) Status(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternal(w, r) {
+		return
+	}
+	status, err := h.svc.Status(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if status.Status != "ok" && r.URL.Query().Get("fail_on_degraded") == "1" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	writeJSON(w, status)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type InternalSecretsHandler struct {
+	svc *service.SecretRotationService
+}
+
+func NewInternalSecretsHandler(svc *service.SecretRotationService) *InternalSecretsHandler {
+	return &InternalSecretsHandler{svc: svc}
+}
+
+func (h *InternalSecretsHandler) RotationStatus(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	status, err := h.svc.Status(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
+			httpError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
+
+// Rotate re-encrypts user secrets to the newest key. Large installs call it repeatedly,
+// passing back next_cursor until it is null.
+func (h *InternalSecretsHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	var body struct {
+		Cursor     string `json:"cursor"`
+		BatchSize  int    `json:"batch_size"`
+		MaxBatches int    `json:"max_batches"`
+		DryRun     bool   `json:"dry_run"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	if body.BatchSize > 1000 || body.MaxBatches > 100 {
+		httpError(w, "invalid batch size", http.StatusBadRequest)
+		return
+	}
+	if body.MaxBatches <= 0 {
+		body.MaxBatches = 10
+	}
+	result, err := h.svc.Rotate(r.Context(), body.Cursor, body.BatchSize, body.MaxBatches, body.DryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
+			httpError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, result)
+}
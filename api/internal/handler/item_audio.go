@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type itemAudioGetter interface {
+	Get(ctx context.Context, userID, itemID, source string) (*model.ItemAudio, error)
+}
+
+type ItemAudioHandler struct {
+	audio itemAudioGetter
+}
+
+func NewItemAudioHandler(audio itemAudioGetter) *ItemAudioHandler {
+	return &ItemAudioHandler{audio: audio}
+}
+
+// Get returns a playable rendering of the item's summary, or of its full text with
+// ?source=full, generating it on first request.
+func (h *ItemAudioHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	itemID := strings.TrimSpace(chi.URLParam(r, "id"))
+	if itemID == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	ctx := service.SummaryAudioRequestContext(r.Context())
+	out, err := h.audio.Get(ctx, userID, itemID, r.URL.Query().Get("source"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrItemAudioInvalidSource):
+			httpError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrGeminiTTSNotAllowed):
+			httpError(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrItemAudioMissingText), errors.Is(err, service.ErrSummaryAudioMissingVoice), errors.Is(err, service.ErrSummaryAudioMissingModel):
+			httpError(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrItemAudioStorageNotConfigured):
+			httpError(w, err.Error(), http.StatusServiceUnavailable)
+		default:
+			writeRepoError(w, err)
+		}
+		return
+	}
+	writeJSON(w, out)
+}
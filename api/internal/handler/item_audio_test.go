@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type stubItemAudioGetter struct {
+	err error
+}
+
+func (s stubItemAudioGetter) Get(context.Context, string, string, string) (*model.ItemAudio, error) {
+	return nil, s.err
+}
+
+func TestItemAudioGetHidesUnexpectedErrors(t *testing.T) {
+	h := NewItemAudioHandler(stubItemAudioGetter{err: errors.New("worker: dial tcp 10.0.0.5:8000: connection refused")})
+
+	rec := httptest.NewRecorder()
+	h.Get(rec, userRequest(http.MethodGet, "/items/i1/audio", "u1", "", map[string]string{"id": "i1"}))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Fatalf("response leaks the internal error: %s", rec.Body.String())
+	}
+}
+
+func TestItemAudioGetMapsNotFound(t *testing.T) {
+	h := NewItemAudioHandler(stubItemAudioGetter{err: repository.ErrNotFound})
+
+	rec := httptest.NewRecorder()
+	h.Get(rec, userRequest(http.MethodGet, "/items/i1/audio", "u1", "", map[string]string{"id": "i1"}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
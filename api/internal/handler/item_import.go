@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type itemImportService interface {
+	StartJob(ctx context.Context, userID, provider string, entries []service.ItemImportEntry) (*model.ImportJob, error)
+}
+
+// ItemImportHandler imports read-later archives (Pocket, Instapaper) from their export files,
+// browser bookmarks and URL lists as manual items. Each import runs as a background import job.
+type ItemImportHandler struct {
+	svc itemImportService
+}
+
+func NewItemImportHandler(svc itemImportService) *ItemImportHandler {
+	return &ItemImportHandler{svc: svc}
+}
+
+func (h *ItemImportHandler) ImportPocket(w http.ResponseWriter, r *http.Request) {
+	h.importExport(w, r, service.ItemImportPocket, service.ParsePocketExport)
+}
+
+func (h *ItemImportHandler) ImportInstapaper(w http.ResponseWriter, r *http.Request) {
+	h.importExport(w, r, service.ItemImportInstapaper, service.ParseInstapaperExport)
+}
+
+// Import accepts either a Netscape bookmarks file in "html" or a URL list in "items", and
+// returns the queued job with 202.
+func (h *ItemImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		HTML  string                   `json:"html"`
+		Items []service.ImportURLEntry `json:"items"`
+	}
+	if !decodeStrictJSONBody(w, r, &body) {
+		return
+	}
+	var entries []service.ItemImportEntry
+	switch {
+	case strings.TrimSpace(body.HTML) != "":
+		entries = service.ParseBookmarksHTML(body.HTML)
+	case len(body.Items) > 0:
+		entries = service.ImportEntriesFromURLList(body.Items)
+	default:
+		httpError(w, "html or items is required", http.StatusBadRequest)
+		return
+	}
+	h.startJob(w, r, userID, service.ItemImportBookmarks, entries)
+}
+
+func (h *ItemImportHandler) importExport(w http.ResponseWriter, r *http.Request, provider string, parse func(string) ([]service.ItemImportEntry, error)) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Data string `json:"data"`
+	}
+	if !decodeStrictJSONBody(w, r, &body) {
+		return
+	}
+	if strings.TrimSpace(body.Data) == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	entries, err := parse(body.Data)
+	if err != nil {
+		writeItemImportError(w, err)
+		return
+	}
+	h.startJob(w, r, userID, provider, entries)
+}
+
+// startJob answers 202 with the queued job; progress is polled via GET /api/imports/{id}.
+func (h *ItemImportHandler) startJob(w http.ResponseWriter, r *http.Request, userID, provider string, entries []service.ItemImportEntry) {
+	job, err := h.svc.StartJob(r.Context(), userID, provider, entries)
+	if err != nil {
+		if !errors.Is(err, service.ErrItemImportEmpty) {
+			log.Printf("item import start failed provider=%s user_id=%s err=%v", provider, userID, err)
+		}
+		writeItemImportError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, job)
+}
+
+func writeItemImportError(w http.ResponseWriter, err error) {
+	var verr *service.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		httpError(w, verr.Message, http.StatusBadRequest)
+	case errors.Is(err, service.ErrItemImportEmpty):
+		httpError(w, err.Error(), http.StatusBadRequest)
+	default:
+		writeRepoError(w, err)
+	}
+}
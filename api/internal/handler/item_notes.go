@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
@@ -40,8 +39,7 @@ func (h *ItemNotesHandler) UpsertNote(w http.ResponseWriter, r *http.Request, it
 		Content string   `json:"content"`
 		Tags    []string `json:"tags"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	note, err := h.store.UpsertNote(r.Context(), model.ItemNote{
@@ -84,8 +82,7 @@ func (h *ItemNotesHandler) CreateHighlight(w http.ResponseWriter, r *http.Reques
 		AnchorText string `json:"anchor_text"`
 		Section    string `json:"section"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	highlight, err := h.store.CreateHighlight(r.Context(), model.ItemHighlight{
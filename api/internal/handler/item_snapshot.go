@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/go-chi/chi/v5"
+)
+
+// itemSnapshotCSP lets the stored page show its images and nothing else: no scripts, no
+// plugins, no requests back to the API's origin.
+const itemSnapshotCSP = "sandbox allow-popups allow-popups-to-escape-sandbox; default-src 'none'; img-src https: http: data:; media-src https: http:"
+
+type itemSnapshotLoader interface {
+	Load(ctx context.Context, itemID, userID string) (*model.ItemHTMLSnapshot, []byte, error)
+}
+
+type ItemSnapshotHandler struct {
+	snapshots itemSnapshotLoader
+}
+
+func NewItemSnapshotHandler(snapshots itemSnapshotLoader) *ItemSnapshotHandler {
+	return &ItemSnapshotHandler{snapshots: snapshots}
+}
+
+// Get serves the sanitized HTML copy of the item's page taken when it was processed.
+func (h *ItemSnapshotHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	itemID := chi.URLParam(r, "id")
+	snap, page, err := h.snapshots.Load(r.Context(), itemID, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", itemSnapshotCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Header().Set("X-Snapshot-Source-URL", snap.SourceURL)
+	w.Header().Set("X-Snapshot-Captured-At", snap.CapturedAt.UTC().Format(time.RFC3339))
+	_, _ = w.Write(page)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/go-chi/chi/v5"
+)
+
+const itemTraceLimit = 200
+
+type itemTraceStore interface {
+	ListByItem(ctx context.Context, userID, itemID string, limit int) ([]model.ItemProcessingEvent, error)
+}
+
+type ItemTraceHandler struct {
+	store itemTraceStore
+}
+
+func NewItemTraceHandler(store itemTraceStore) *ItemTraceHandler {
+	return &ItemTraceHandler{store: store}
+}
+
+// Get returns the item's processing timeline: one entry per pipeline step attempt with its
+// timing, model and error.
+func (h *ItemTraceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	itemID := chi.URLParam(r, "id")
+	events, err := h.store.ListByItem(r.Context(), userID, itemID, itemTraceLimit)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"item_id": itemID, "events": events})
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestItemTraceHandlerReturnsTimelineForOwner(t *testing.T) {
+	store := repotest.NewItemProcessingEventStore()
+	store.Own("u1", "item-1")
+	started := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	errMsg := "worker /summarize: status 503"
+	_ = store.Insert(context.Background(), model.ItemProcessingEvent{ItemID: "item-1", Step: "summarize", Status: "failed", ErrorMessage: &errMsg, StartedAt: started.Add(time.Minute)})
+	_ = store.Insert(context.Background(), model.ItemProcessingEvent{ItemID: "item-1", Step: "extract-body", Status: "succeeded", StartedAt: started, DurationMS: 1200})
+	h := NewItemTraceHandler(store)
+
+	rec := httptest.NewRecorder()
+	h.Get(rec, userRequest(http.MethodGet, "/items/item-1/trace", "u1", "", map[string]string{"id": "item-1"}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	var got struct {
+		ItemID string                      `json:"item_id"`
+		Events []model.ItemProcessingEvent `json:"events"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ItemID != "item-1" || len(got.Events) != 2 || got.Events[0].Step != "extract-body" || got.Events[1].ErrorMessage == nil {
+		t.Fatalf("got %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Get(rec, userRequest(http.MethodGet, "/items/item-1/trace", "u2", "", map[string]string{"id": "item-1"}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign status = %d, want 404", rec.Code)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,11 +23,13 @@ import (
 )
 
 type ItemHandler struct {
-	repo            *repository.ItemRepo
-	sourceRepo      *repository.SourceRepo
+	repo            repository.ItemReadWriter
+	readRepo        repository.ItemReader
+	sourceRepo      repository.SourceReader
 	readingGoalRepo *repository.ReadingGoalRepo
 	streakRepo      *repository.ReadingStreakRepo
 	snapshotRepo    *repository.BriefingSnapshotRepo
+	planSnapshots   *repository.ReadingPlanSnapshotRepo
 	prefProfileRepo *repository.PreferenceProfileRepo
 	reviewQueueRepo *repository.ReviewQueueRepo
 	settingsRepo    *repository.UserSettingsRepo
@@ -39,6 +43,7 @@ type ItemHandler struct {
 	searchSuggest   *service.SearchSuggestionService
 	detail          *service.ItemDetailService
 	keyProvider     *service.UserKeyProvider
+	auditLog        *service.AuditLogService
 }
 
 const itemsListCacheTTL = 30 * time.Second
@@ -183,31 +188,30 @@ func runDeleteBulk(
 func (h *ItemHandler) CreateBulkJob(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body createItemBulkJobRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	action, filters, validationMessage := validateCreateItemBulkJobRequest(body)
 	if validationMessage != "" {
-		http.Error(w, validationMessage, http.StatusBadRequest)
+		httpError(w, validationMessage, http.StatusBadRequest)
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
 
 	job, err := h.repo.CreateItemBulkJob(r.Context(), userID, action, filters)
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidState) {
-			http.Error(w, "invalid bulk job", http.StatusBadRequest)
+			httpError(w, "invalid bulk job", http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
 		return
 	}
 	if err := h.publisher.SendItemBulkJobRunE(r.Context(), job.ID, "manual"); err != nil {
-		http.Error(w, "failed to enqueue bulk job", http.StatusBadGateway)
+		httpError(w, "failed to enqueue bulk job", http.StatusBadGateway)
 		return
 	}
 
@@ -222,17 +226,16 @@ func (h *ItemHandler) CreateBulkJob(w http.ResponseWriter, r *http.Request) {
 func (h *ItemHandler) RetryBulk(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body retryBulkRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	itemIDs := normalizeBulkItemIDs(body.ItemIDs)
 	if len(itemIDs) == 0 {
-		http.Error(w, "item_ids is required", http.StatusBadRequest)
+		httpError(w, "item_ids is required", http.StatusBadRequest)
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -251,7 +254,7 @@ func (h *ItemHandler) RetryBulk(w http.ResponseWriter, r *http.Request) {
 			}, nil
 		},
 		func(ctx context.Context, item retryBulkCandidate) error {
-			return h.publisher.SendItemCreatedWithReasonE(ctx, item.ID, item.SourceID, item.URL, nil, "retry")
+			return h.publisher.SendItemCreatedWithReasonE(ctx, item.ID, item.SourceID, userID, item.URL, nil, "retry")
 		},
 	)
 
@@ -273,17 +276,16 @@ func (h *ItemHandler) RetryBulk(w http.ResponseWriter, r *http.Request) {
 func (h *ItemHandler) DeleteBulk(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body retryBulkRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	itemIDs := normalizeBulkItemIDs(body.ItemIDs)
 	if len(itemIDs) == 0 {
-		http.Error(w, "item_ids is required", http.StatusBadRequest)
+		httpError(w, "item_ids is required", http.StatusBadRequest)
 		return
 	}
 	if len(itemIDs) > 100 {
-		http.Error(w, "too many item_ids", http.StatusBadRequest)
+		httpError(w, "too many item_ids", http.StatusBadRequest)
 		return
 	}
 
@@ -432,11 +434,12 @@ func buildTriageQueueParams(q url.Values) (repository.ReadingPlanParams, error)
 }
 
 func NewItemHandler(
-	repo *repository.ItemRepo,
-	sourceRepo *repository.SourceRepo,
+	repo repository.ItemReadWriter,
+	sourceRepo repository.SourceReader,
 	readingGoalRepo *repository.ReadingGoalRepo,
 	streakRepo *repository.ReadingStreakRepo,
 	snapshotRepo *repository.BriefingSnapshotRepo,
+	planSnapshots *repository.ReadingPlanSnapshotRepo,
 	prefProfileRepo *repository.PreferenceProfileRepo,
 	reviewQueueRepo *repository.ReviewQueueRepo,
 	settingsRepo *repository.UserSettingsRepo,
@@ -454,6 +457,7 @@ func NewItemHandler(
 		readingGoalRepo: readingGoalRepo,
 		streakRepo:      streakRepo,
 		snapshotRepo:    snapshotRepo,
+		planSnapshots:   planSnapshots,
 		prefProfileRepo: prefProfileRepo,
 		reviewQueueRepo: reviewQueueRepo,
 		settingsRepo:    settingsRepo,
@@ -470,6 +474,26 @@ func NewItemHandler(
 	}
 }
 
+// WithAuditLog records favorites exports.
+func (h *ItemHandler) WithAuditLog(svc *service.AuditLogService) *ItemHandler {
+	h.auditLog = svc
+	return h
+}
+
+// WithReadRepo serves the item list, stats and reading plan from repo, typically on the read
+// replica. Writes and read-your-write paths stay on the primary.
+func (h *ItemHandler) WithReadRepo(repo repository.ItemReader) *ItemHandler {
+	h.readRepo = repo
+	return h
+}
+
+func (h *ItemHandler) reader() repository.ItemReader {
+	if h.readRepo != nil {
+		return h.readRepo
+	}
+	return h.repo
+}
+
 func (h *ItemHandler) Navigator(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	itemID := chi.URLParam(r, "id")
@@ -654,7 +678,7 @@ func itemsListCacheTTLForSort(sort string) time.Duration {
 	}
 }
 
-func (h *ItemHandler) itemsListCacheKey(ctx context.Context, userID, status, sourceID, topic, genre, searchQuery, searchMode string, unreadOnly, readOnly, favoriteOnly, laterOnly bool, sort string, page, pageSize int) (string, error) {
+func (h *ItemHandler) itemsListCacheKey(ctx context.Context, userID, status, sourceID, topic, genre, language, depth, searchQuery, searchMode string, unreadOnly, readOnly, favoriteOnly, laterOnly bool, sort string, page, pageSize int) (string, error) {
 	version := int64(0)
 	if h.cache != nil {
 		var err error
@@ -663,7 +687,7 @@ func (h *ItemHandler) itemsListCacheKey(ctx context.Context, userID, status, sou
 			return "", err
 		}
 	}
-	return cacheKeyItemsListVersioned(userID, version, status, sourceID, topic, genre, searchQuery, searchMode, unreadOnly, readOnly, favoriteOnly, laterOnly, sort, page, pageSize), nil
+	return cacheKeyItemsListVersioned(userID, version, status, sourceID, topic, genre, language, depth, searchQuery, searchMode, unreadOnly, readOnly, favoriteOnly, laterOnly, sort, page, pageSize), nil
 }
 
 func (h *ItemHandler) bumpUserItemsVersion(ctx context.Context, userID string) error {
@@ -729,7 +753,7 @@ func (h *ItemHandler) getItemDetail(ctx context.Context, userID, itemID string,
 func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	q := r.URL.Query()
-	var status, sourceID, topic, genre *string
+	var status, sourceID, topic, genre, language *string
 	if v := q.Get("status"); v != "" {
 		status = &v
 	}
@@ -742,14 +766,27 @@ func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 	if v := q.Get("genre"); v != "" {
 		genre = &v
 	}
+	languageParam := strings.ToLower(strings.TrimSpace(q.Get("language")))
+	if languageParam != "" {
+		language = &languageParam
+	}
+	var depth *string
+	depthParam := strings.TrimSpace(q.Get("depth"))
+	if depthParam != "" {
+		if !model.IsItemDepth(depthParam) {
+			httpError(w, "invalid depth", http.StatusBadRequest)
+			return
+		}
+		depth = &depthParam
+	}
 	page := parseIntOrDefault(q.Get("page"), 1)
 	pageSize := parseIntOrDefault(q.Get("page_size"), 20)
 	if page < 1 || page > 100000 {
-		http.Error(w, "invalid page", http.StatusBadRequest)
+		httpError(w, "invalid page", http.StatusBadRequest)
 		return
 	}
 	if pageSize < 1 || pageSize > 200 {
-		http.Error(w, "invalid page_size", http.StatusBadRequest)
+		httpError(w, "invalid page_size", http.StatusBadRequest)
 		return
 	}
 	sort := q.Get("sort")
@@ -757,7 +794,7 @@ func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 		sort = "newest"
 	}
 	if sort != "newest" && sort != "score" && sort != "personal_score" {
-		http.Error(w, "invalid sort", http.StatusBadRequest)
+		httpError(w, "invalid sort", http.StatusBadRequest)
 		return
 	}
 	unreadOnly := q.Get("unread_only") == "true"
@@ -766,17 +803,64 @@ func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 	laterOnly := q.Get("later_only") == "true"
 	searchQuery := strings.TrimSpace(q.Get("q"))
 	if unreadOnly && readOnly {
-		http.Error(w, "unread_only and read_only cannot both be true", http.StatusBadRequest)
+		httpError(w, "unread_only and read_only cannot both be true", http.StatusBadRequest)
+		return
+	}
+	fields, ok := parseItemListFields(q)
+	if !ok {
+		httpError(w, "invalid fields", http.StatusBadRequest)
 		return
 	}
 	searchMode := strings.TrimSpace(q.Get("search_mode"))
-	cacheKey, cacheKeyErr := h.itemsListCacheKey(r.Context(), userID, q.Get("status"), q.Get("source_id"), q.Get("topic"), q.Get("genre"), searchQuery, searchMode, unreadOnly, readOnly, favoriteOnly, laterOnly, sort, page, pageSize)
+	var queryPtr *string
+	if searchQuery != "" {
+		queryPtr = &searchQuery
+	}
+	listParams := repository.ItemListParams{
+		Status:       status,
+		SourceID:     sourceID,
+		Topic:        topic,
+		Genre:        genre,
+		Language:     language,
+		Depth:        depth,
+		Query:        queryPtr,
+		UnreadOnly:   unreadOnly,
+		ReadOnly:     readOnly,
+		FavoriteOnly: favoriteOnly,
+		LaterOnly:    laterOnly,
+		Sort:         sort,
+		Page:         page,
+		PageSize:     pageSize,
+	}
+	var searchParams *service.ItemSearchQuery
+	if queryPtr != nil && h.searchItems != nil {
+		searchParams = &service.ItemSearchQuery{
+			UserID:       userID,
+			Query:        searchQuery,
+			SearchMode:   searchMode,
+			Status:       status,
+			SourceID:     sourceID,
+			Topic:        topic,
+			Genre:        genre,
+			UnreadOnly:   unreadOnly,
+			ReadOnly:     readOnly,
+			FavoriteOnly: favoriteOnly,
+			LaterOnly:    laterOnly,
+			Page:         page,
+			PageSize:     pageSize,
+		}
+	}
+	cacheKey, cacheKeyErr := h.itemsListCacheKey(r.Context(), userID, q.Get("status"), q.Get("source_id"), q.Get("topic"), q.Get("genre"), languageParam, depthParam, searchQuery, searchMode, unreadOnly, readOnly, favoriteOnly, laterOnly, sort, page, pageSize)
 	cacheBust := q.Get("cache_bust") == "1"
 	if cacheKeyErr != nil {
 		itemsListCacheCounter.errors.Add(1)
 		incrCacheMetric(r.Context(), h.cache, userID, "items_list.error")
 		log.Printf("items-list cache key failed user_id=%s err=%v", userID, cacheKeyErr)
 	}
+	if fields != nil {
+		h.listItemFields(w, r, userID, listParams, searchParams, fields, cacheKeyItemsListFields(cacheKey, fields), cacheKeyErr, cacheBust)
+		return
+	}
 	if h.cache != nil && !cacheBust && cacheKeyErr == nil {
 		var cached model.ItemListResponse
 		if ok, err := h.cache.GetJSON(r.Context(), cacheKey, &cached); err == nil && ok {
@@ -798,84 +882,67 @@ func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var queryPtr *string
-	if searchQuery != "" {
-		queryPtr = &searchQuery
-	}
-	var resp *model.ItemListResponse
-	var err error
-	if queryPtr != nil && h.searchItems != nil {
-		resp, err = h.searchItems.Search(r.Context(), service.ItemSearchQuery{
-			UserID:       userID,
-			Query:        searchQuery,
-			SearchMode:   searchMode,
-			Status:       status,
-			SourceID:     sourceID,
-			Topic:        topic,
-			Genre:        genre,
-			UnreadOnly:   unreadOnly,
-			ReadOnly:     readOnly,
-			FavoriteOnly: favoriteOnly,
-			LaterOnly:    laterOnly,
-			Page:         page,
-			PageSize:     pageSize,
-		})
-		if err != nil {
-			log.Printf("items search unavailable user_id=%s err=%v", userID, err)
-			resp = &model.ItemListResponse{
-				Items:             []model.Item{},
-				Page:              page,
-				PageSize:          pageSize,
-				Total:             0,
-				HasNext:           false,
-				Sort:              "relevance",
-				Status:            status,
-				SourceID:          sourceID,
-				SearchUnavailable: true,
+	// Concurrent misses for the same key share one computation.
+	load := func() (*model.ItemListResponse, error) {
+		var resp *model.ItemListResponse
+		var err error
+		if searchParams != nil {
+			resp, err = h.searchItems.Search(r.Context(), *searchParams)
+			if err != nil {
+				log.Printf("items search unavailable user_id=%s err=%v", userID, err)
+				resp = &model.ItemListResponse{
+					Items:             []model.Item{},
+					Page:              page,
+					PageSize:          pageSize,
+					Total:             0,
+					HasNext:           false,
+					Sort:              "relevance",
+					Status:            status,
+					SourceID:          sourceID,
+					SearchUnavailable: true,
+				}
+				mode := service.NormalizeSearchMode(searchMode)
+				resp.SearchMode = &mode
+			}
+		} else {
+			resp, err = h.reader().ListPage(r.Context(), userID, listParams)
+			if err != nil {
+				return nil, err
 			}
-			mode := service.NormalizeSearchMode(searchMode)
-			resp.SearchMode = &mode
-		}
-	} else {
-		resp, err = h.repo.ListPage(r.Context(), userID, repository.ItemListParams{
-			Status:       status,
-			SourceID:     sourceID,
-			Topic:        topic,
-			Genre:        genre,
-			Query:        queryPtr,
-			UnreadOnly:   unreadOnly,
-			ReadOnly:     readOnly,
-			FavoriteOnly: favoriteOnly,
-			LaterOnly:    laterOnly,
-			Sort:         sort,
-			Page:         page,
-			PageSize:     pageSize,
-		})
-		if err != nil {
-			writeRepoError(w, err)
-			return
 		}
-	}
-	if resp != nil && sort == "personal_score" {
-		missingPersonalScores := make([]string, 0, len(resp.Items))
-		for _, item := range resp.Items {
-			if item.PersonalScore == nil {
-				missingPersonalScores = append(missingPersonalScores, item.ID)
+		if resp != nil && sort == "personal_score" {
+			missingPersonalScores := make([]string, 0, len(resp.Items))
+			for _, item := range resp.Items {
+				if item.PersonalScore == nil {
+					missingPersonalScores = append(missingPersonalScores, item.ID)
+				}
+			}
+			if len(missingPersonalScores) > 0 {
+				h.applyPersonalScoreSort(r.Context(), userID, resp)
+				if persistErr := h.repo.PersistPersonalScores(r.Context(), userID, missingPersonalScores); persistErr != nil {
+					log.Printf("personal_score persist on list failed user_id=%s count=%d err=%v", userID, len(missingPersonalScores), persistErr)
+				}
 			}
 		}
-		if len(missingPersonalScores) > 0 {
-			h.applyPersonalScoreSort(r.Context(), userID, resp)
-			if persistErr := h.repo.PersistPersonalScores(r.Context(), userID, missingPersonalScores); persistErr != nil {
-				log.Printf("personal_score persist on list failed user_id=%s count=%d err=%v", userID, len(missingPersonalScores), persistErr)
+		if h.cache != nil && resp != nil && cacheKeyErr == nil {
+			if err := h.cache.SetJSON(r.Context(), cacheKey, resp, itemsListCacheTTLForSort(sort)); err != nil {
+				itemsListCacheCounter.errors.Add(1)
+				incrCacheMetric(r.Context(), h.cache, userID, "items_list.error")
+				log.Printf("items-list cache set failed user_id=%s key=%s err=%v", userID, cacheKey, err)
 			}
 		}
+		return resp, nil
 	}
-	if h.cache != nil && resp != nil && cacheKeyErr == nil {
-		if err := h.cache.SetJSON(r.Context(), cacheKey, resp, itemsListCacheTTLForSort(sort)); err != nil {
-			itemsListCacheCounter.errors.Add(1)
-			incrCacheMetric(r.Context(), h.cache, userID, "items_list.error")
-			log.Printf("items-list cache set failed user_id=%s key=%s err=%v", userID, cacheKey, err)
-		}
+	var resp *model.ItemListResponse
+	var err error
+	if cacheKeyErr == nil {
+		resp, err = coalesce(r.Context(), cacheKey, &itemsListCacheCounter, load)
+	} else {
+		resp, err = load()
+	}
+	if err != nil {
+		writeRepoError(w, err)
+		return
 	}
 	writeJSON(w, resp)
 }
@@ -893,7 +960,7 @@ func (h *ItemHandler) applyPersonalScoreSort(ctx context.Context, userID string,
 	for i, it := range resp.Items {
 		itemIDs[i] = it.ID
 	}
-	embeddings, err := h.repo.LoadItemEmbeddingsByID(ctx, itemIDs)
+	embeddings, err := h.repo.LoadItemEmbeddingsForProfile(ctx, itemIDs, profile)
 	if err != nil {
 		log.Printf("personal_score: load embeddings failed user_id=%s err=%v", userID, err)
 		embeddings = nil
@@ -936,11 +1003,11 @@ func (h *ItemHandler) ExportFavoritesMarkdown(w http.ResponseWriter, r *http.Req
 	days := parseIntOrDefault(r.URL.Query().Get("days"), 30)
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 50)
 	if days < 0 || days > 3650 {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
 	if limit < 1 || limit > 200 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 
@@ -949,6 +1016,7 @@ func (h *ItemHandler) ExportFavoritesMarkdown(w http.ResponseWriter, r *http.Req
 		writeRepoError(w, err)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionExport, "favorites_markdown", map[string]any{"items": len(items), "days": days})
 
 	now := timeutil.NowJST()
 	rangeLabel := "all favorites"
@@ -1046,7 +1114,7 @@ func pickFavoriteExportTitle(item model.FavoriteExportItem) string {
 
 func (h *ItemHandler) Stats(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
-	resp, err := h.repo.Stats(r.Context(), userID)
+	resp, err := h.reader().Stats(r.Context(), userID)
 	if err != nil {
 		writeRepoError(w, err)
 		return
@@ -1058,7 +1126,7 @@ func (h *ItemHandler) UXMetrics(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	days := parseIntOrDefault(r.URL.Query().Get("days"), 7)
 	if days < 1 || days > 90 {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
 	today := timeutil.StartOfDayJST(timeutil.NowJST())
@@ -1130,10 +1198,15 @@ func (h *ItemHandler) invalidateUserCaches(ctx context.Context, userID string) {
 			log.Printf("briefing snapshot stale failed user_id=%s date=%s err=%v", userID, today, err)
 		}
 	}
+	if h.planSnapshots != nil {
+		if err := h.planSnapshots.MarkStale(ctx, userID); err != nil {
+			log.Printf("reading plan snapshot stale failed user_id=%s err=%v", userID, err)
+		}
+	}
 }
 
-func (h *ItemHandler) refreshPreferenceProfileAsync(userID, itemID string) {
-	if userID == "" || itemID == "" || h.prefProfileRepo == nil {
+func (h *ItemHandler) refreshPreferenceProfileAsync(userID string, itemIDs ...string) {
+	if userID == "" || len(itemIDs) == 0 || h.prefProfileRepo == nil {
 		return
 	}
 	safeGo(func() {
@@ -1149,8 +1222,8 @@ func (h *ItemHandler) refreshPreferenceProfileAsync(userID, itemID string) {
 			log.Printf("preference profile upsert failed user_id=%s err=%v", userID, upsertErr)
 			return
 		}
-		if persistErr := h.repo.PersistPersonalScores(ctx, userID, []string{itemID}); persistErr != nil {
-			log.Printf("personal score persist failed user_id=%s item_id=%s err=%v", userID, itemID, persistErr)
+		if persistErr := h.repo.PersistPersonalScores(ctx, userID, itemIDs); persistErr != nil {
+			log.Printf("personal score persist failed user_id=%s item_ids=%v err=%v", userID, itemIDs, persistErr)
 		}
 	})
 }
@@ -1159,7 +1232,7 @@ func (h *ItemHandler) TopicTrends(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 8)
 	if limit < 1 || limit > 50 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	rows, err := h.repo.TopicTrends(r.Context(), userID, limit)
@@ -1175,11 +1248,11 @@ func (h *ItemHandler) TopicPulse(w http.ResponseWriter, r *http.Request) {
 	days := parseIntOrDefault(r.URL.Query().Get("days"), 7)
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 12)
 	if days < 1 || days > 30 {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
 	if limit < 1 || limit > 50 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	rows, err := h.repo.TopicPulse(r.Context(), userID, days, limit)
@@ -1199,28 +1272,73 @@ func (h *ItemHandler) ReadingPlan(w http.ResponseWriter, r *http.Request) {
 	}
 	size := parseIntOrDefault(q.Get("size"), 15)
 	if size < 1 || size > 100 {
-		http.Error(w, "invalid size", http.StatusBadRequest)
+		httpError(w, "invalid size", http.StatusBadRequest)
 		return
 	}
 	diversify := q.Get("diversify_topics") != "false"
 	excludeRead := q.Get("exclude_read") != "false"
 	params := repository.ReadingPlanParams{
-		Window:          window,
-		Size:            size,
-		DiversifyTopics: diversify,
-		ExcludeRead:     excludeRead,
-		ExcludeLater:    q.Get("exclude_later") == "true",
+		Window:            window,
+		Size:              size,
+		DiversifyTopics:   diversify,
+		ExcludeRead:       excludeRead,
+		ExcludeLater:      q.Get("exclude_later") == "true",
+		PrioritizeSnoozed: q.Get("prioritize_snoozed") == "true",
+	}
+	exploration, err := h.readingPlanExploration(r.Context(), userID, q.Get("exploration"))
+	if err != nil {
+		httpError(w, "invalid exploration", http.StatusBadRequest)
+		return
+	}
+	params.Exploration = exploration
+	if depth := strings.TrimSpace(q.Get("depth")); depth != "" {
+		if !model.IsItemDepth(depth) {
+			httpError(w, "invalid depth", http.StatusBadRequest)
+			return
+		}
+		params.Depth = depth
+	}
+	if raw := strings.TrimSpace(q.Get("available_minutes")); raw != "" {
+		minutes, err := strconv.Atoi(raw)
+		if err != nil || minutes < 1 || minutes > 600 {
+			httpError(w, "invalid available_minutes", http.StatusBadRequest)
+			return
+		}
+		params.AvailableMinutes = minutes
 	}
-	cacheKey := cacheKeyReadingPlan(userID, params.Window, params.Size, params.DiversifyTopics, params.ExcludeRead, params.ExcludeLater)
+	cacheKey := cacheKeyReadingPlan(userID, params.Window, params.Size, params.DiversifyTopics, params.ExcludeRead, params.ExcludeLater, params.PrioritizeSnoozed, params.Exploration, params.Depth, params.AvailableMinutes)
 	cacheBust := q.Get("cache_bust") == "1"
+	// recompute=1 skips the precomputed snapshot and refreshes it from a live computation.
+	recompute := q.Get("recompute") == "1"
+	if !cacheBust && !recompute && h.planSnapshots != nil {
+		snap, err := h.planSnapshots.Get(r.Context(), userID)
+		if err != nil {
+			log.Printf("reading plan snapshot get failed user_id=%s err=%v", userID, err)
+		} else if service.ReadingPlanSnapshotUsable(snap, params, time.Now()) {
+			plan := *snap.Plan
+			plan.Source = "snapshot"
+			plan.GeneratedAt = &snap.GeneratedAt
+			writeJSON(w, &plan)
+			return
+		}
+	}
 	resp, err := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, 120*time.Second, func() (*model.ReadingPlanResponse, error) {
-		return h.repo.ReadingPlan(r.Context(), userID, params)
+		plan, err := h.reader().ReadingPlan(r.Context(), userID, params)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		plan.Source = "live"
+		plan.GeneratedAt = &now
+		h.refreshReadingPlanSnapshot(r.Context(), userID, params, plan)
+		return plan, nil
 	}, cacheFetchOptions{
-		cacheBust:    cacheBust,
+		cacheBust:    cacheBust || recompute,
 		metricPrefix: "reading_plan",
 		userID:       userID,
 		counter:      &readingPlanCacheCounter,
 		logKeyPrefix: "reading-plan",
+		coalesce:     true,
 	})
 	if err != nil {
 		writeRepoError(w, err)
@@ -1229,6 +1347,124 @@ func (h *ItemHandler) ReadingPlan(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp)
 }
 
+// readingPlanExploration returns the exploration rate from the query, or the user's saved
+// reading plan setting when the query does not set one.
+func (h *ItemHandler) readingPlanExploration(ctx context.Context, userID, raw string) (float64, error) {
+	if raw = strings.TrimSpace(raw); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 || v > 1 {
+			return 0, errors.New("invalid exploration")
+		}
+		return v, nil
+	}
+	if h.settingsRepo == nil {
+		return 0, nil
+	}
+	settings, err := h.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Printf("reading plan exploration settings failed user_id=%s err=%v", userID, err)
+		}
+		return 0, nil
+	}
+	return settings.ReadingPlanExploration, nil
+}
+
+// refreshReadingPlanSnapshot stores a live plan as the user's snapshot when it was computed
+// with their saved reading plan settings, so the next request can be served from it.
+func (h *ItemHandler) refreshReadingPlanSnapshot(ctx context.Context, userID string, params repository.ReadingPlanParams, plan *model.ReadingPlanResponse) {
+	if h.planSnapshots == nil || h.settingsRepo == nil || params.ExcludeLater || params.PrioritizeSnoozed {
+		return
+	}
+	settings, err := h.settingsRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		settings, err = nil, nil
+	}
+	if err != nil {
+		log.Printf("reading plan snapshot settings failed user_id=%s err=%v", userID, err)
+		return
+	}
+	if service.ReadingPlanParamsFromSettings(settings) != params {
+		return
+	}
+	if err := h.planSnapshots.Upsert(ctx, userID, params, plan); err != nil {
+		log.Printf("reading plan snapshot upsert failed user_id=%s err=%v", userID, err)
+	}
+}
+
+const maxSnoozeDays = 365
+
+type itemSnoozeResponse struct {
+	ItemID       string     `json:"item_id"`
+	SnoozedUntil *time.Time `json:"snoozed_until"`
+}
+
+// Snooze hides an item until the start of the given JST date.
+func (h *ItemHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	var body struct {
+		Until string `json:"until"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(body.Until), timeutil.JST)
+	if err != nil {
+		httpError(w, "until must be YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	today := timeutil.StartOfDayJST(timeutil.NowJST())
+	if !day.After(today) || day.After(today.AddDate(0, 0, maxSnoozeDays)) {
+		httpError(w, "until must be a future date within a year", http.StatusBadRequest)
+		return
+	}
+	if err := h.repo.Snooze(r.Context(), userID, id, day); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	h.invalidateUserCaches(r.Context(), userID)
+	writeJSON(w, itemSnoozeResponse{ItemID: id, SnoozedUntil: &day})
+}
+
+func (h *ItemHandler) Unsnooze(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	if err := h.repo.Unsnooze(r.Context(), userID, id); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	h.invalidateUserCaches(r.Context(), userID)
+	writeJSON(w, itemSnoozeResponse{ItemID: id})
+}
+
+type readingPlanOverrideResponse struct {
+	ItemID   string `json:"item_id"`
+	PlanDate string `json:"plan_date"`
+	Action   string `json:"action"`
+}
+
+func (h *ItemHandler) PinToReadingPlan(w http.ResponseWriter, r *http.Request) {
+	h.setReadingPlanOverride(w, r, repository.ReadingPlanOverridePin)
+}
+
+func (h *ItemHandler) RemoveFromReadingPlan(w http.ResponseWriter, r *http.Request) {
+	h.setReadingPlanOverride(w, r, repository.ReadingPlanOverrideRemove)
+}
+
+// setReadingPlanOverride applies to today's plan in JST; overrides from earlier days are ignored.
+func (h *ItemHandler) setReadingPlanOverride(w http.ResponseWriter, r *http.Request, action string) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	planDate := timeutil.StartOfDayJST(timeutil.NowJST())
+	if err := h.repo.SetReadingPlanOverride(r.Context(), userID, id, planDate, action); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	h.invalidateUserCaches(r.Context(), userID)
+	writeJSON(w, readingPlanOverrideResponse{ItemID: id, PlanDate: planDate.Format("2006-01-02"), Action: action})
+}
+
 func (h *ItemHandler) FocusQueue(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	q := r.URL.Query()
@@ -1238,7 +1474,7 @@ func (h *ItemHandler) FocusQueue(w http.ResponseWriter, r *http.Request) {
 	}
 	size := parseIntOrDefault(q.Get("size"), 20)
 	if size < 1 || size > 100 {
-		http.Error(w, "invalid size", http.StatusBadRequest)
+		httpError(w, "invalid size", http.StatusBadRequest)
 		return
 	}
 	params := repository.ReadingPlanParams{
@@ -1319,7 +1555,7 @@ func (h *ItemHandler) TriageQueue(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	params, err := buildTriageQueueParams(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	cacheKey := cacheKeyTriageQueue(userID, params.Window, params.Size, params.DiversifyTopics, params.ExcludeLater)
@@ -1506,9 +1742,35 @@ func (h *ItemHandler) GetDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	h.applyPersonalizationToDetail(r.Context(), userID, item)
+	h.applyProcessingQueueToDetail(r.Context(), userID, item)
 	writeJSON(w, item)
 }
 
+// applyProcessingQueueToDetail reports where an unfinished item waits behind the user's LLM
+// concurrency limit. It runs after the detail cache because queue positions move every few
+// seconds.
+func (h *ItemHandler) applyProcessingQueueToDetail(ctx context.Context, userID string, item *model.ItemDetail) {
+	if item == nil || h.settingsRepo == nil {
+		return
+	}
+	switch item.Status {
+	case "new", "fetched", "facts_extracted":
+	default:
+		return
+	}
+	queue, err := h.repo.ProcessingQueue(ctx, userID, item.ID)
+	if err != nil {
+		log.Printf("item processing queue failed user_id=%s item_id=%s err=%v", userID, item.ID, err)
+		return
+	}
+	if queue == nil {
+		return
+	}
+	settings, _ := h.settingsRepo.GetByUserID(ctx, userID)
+	queue.Limit = service.EffectiveLLMConcurrency(settings, service.ProcessItemUserConcurrency())
+	item.ProcessingQueue = queue
+}
+
 func (h *ItemHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
@@ -1516,8 +1778,7 @@ func (h *ItemHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
 		UserGenre           *string `json:"user_genre"`
 		UserOtherGenreLabel *string `json:"user_other_genre_label"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if err := h.repo.UpdateUserGenre(r.Context(), userID, id, body.UserGenre, body.UserOtherGenreLabel); err != nil {
@@ -1562,14 +1823,14 @@ func (h *ItemHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
 func (h *ItemHandler) SearchSuggestions(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	if h.searchSuggest == nil {
-		http.Error(w, "search suggestions unavailable", http.StatusServiceUnavailable)
+		httpError(w, "search suggestions unavailable", http.StatusServiceUnavailable)
 		return
 	}
 
 	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 10)
 	if limit < 1 || limit > 10 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 
@@ -1579,7 +1840,7 @@ func (h *ItemHandler) SearchSuggestions(w http.ResponseWriter, r *http.Request)
 		Limit:  limit,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, resp)
@@ -1608,7 +1869,7 @@ func (h *ItemHandler) applyPersonalizationToDetail(ctx context.Context, userID s
 		CreatedAt:      item.CreatedAt,
 	}
 	if h.repo != nil {
-		if embByID, embErr := h.repo.LoadItemEmbeddingsByID(ctx, []string{item.ID}); embErr == nil {
+		if embByID, embErr := h.repo.LoadItemEmbeddingsForProfile(ctx, []string{item.ID}, profile); embErr == nil {
 			input.Embedding = embByID[item.ID]
 		} else {
 			log.Printf("item detail embedding load failed item_id=%s err=%v", item.ID, embErr)
@@ -1710,10 +1971,18 @@ func (h *ItemHandler) Related(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 6)
 	if limit < 1 || limit > 20 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	fusion, hybrid, err := parseRelatedFusionParams(r.URL.Query())
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	cacheKey := cacheKeyRelated(userID, id, limit)
+	if hybrid {
+		cacheKey = cacheKeyRelatedHybrid(userID, id, limit, fusion)
+	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
 	if h.cache != nil && !cacheBust {
 		var cached map[string]any
@@ -1734,19 +2003,32 @@ func (h *ItemHandler) Related(w http.ResponseWriter, r *http.Request) {
 	if detail, err := h.getItemDetail(r.Context(), userID, id, false); err == nil && detail != nil && detail.Summary != nil {
 		targetTopics = detail.Summary.Topics
 	}
-	items, err := h.repo.ListRelated(r.Context(), id, userID, limit)
+	var items []model.RelatedItem
+	if hybrid {
+		items, err = h.repo.ListRelatedHybrid(r.Context(), id, userID, limit, fusion)
+	} else {
+		items, err = h.repo.ListRelated(r.Context(), id, userID, limit)
+	}
 	if err != nil {
 		writeRepoError(w, err)
 		return
 	}
-	items = rerankAndFilterRelated(items, targetTopics, limit)
+	if !hybrid {
+		items = rerankAndFilterRelated(items, targetTopics, limit)
+	}
 	annotateRelatedReasons(items, targetTopics)
 	clusters := clusterRelatedItems(items)
+	coverage, err := h.repo.RelatedEmbeddingCoverage(r.Context(), id, userID, limit)
+	if err != nil {
+		log.Printf("related embedding coverage failed user_id=%s item_id=%s err=%v", userID, id, err)
+		coverage = nil
+	}
 	out := relatedItemsResponse{
-		Items:    items,
-		Clusters: clusters,
-		Limit:    limit,
-		ItemID:   id,
+		Items:             items,
+		Clusters:          clusters,
+		Limit:             limit,
+		ItemID:            id,
+		EmbeddingCoverage: coverage,
 	}
 	if h.cache != nil {
 		if err := h.cache.SetJSON(r.Context(), cacheKey, out, relatedItemsCacheTTL); err != nil {
@@ -1757,6 +2039,47 @@ func (h *ItemHandler) Related(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, out)
 }
 
+// parseRelatedFusionParams reads ranking=hybrid and its optional fusion weights
+// (embedding_weight, text_weight, rrf_k). It reports false for the default embedding ranking.
+func parseRelatedFusionParams(q url.Values) (repository.RelatedFusionParams, bool, error) {
+	p := repository.DefaultRelatedFusionParams()
+	switch strings.TrimSpace(q.Get("ranking")) {
+	case "", "embedding":
+		return p, false, nil
+	case "hybrid":
+	default:
+		return p, false, errors.New("invalid ranking")
+	}
+	for _, w := range []struct {
+		key string
+		dst *float64
+	}{
+		{"embedding_weight", &p.EmbeddingWeight},
+		{"text_weight", &p.TextWeight},
+	} {
+		raw := strings.TrimSpace(q.Get(w.key))
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 || v > 10 {
+			return p, false, fmt.Errorf("invalid %s", w.key)
+		}
+		*w.dst = v
+	}
+	if p.EmbeddingWeight == 0 && p.TextWeight == 0 {
+		return p, false, errors.New("invalid fusion weights")
+	}
+	if raw := strings.TrimSpace(q.Get("rrf_k")); raw != "" {
+		k, err := strconv.Atoi(raw)
+		if err != nil || k < 1 || k > 1000 {
+			return p, false, errors.New("invalid rrf_k")
+		}
+		p.K = k
+	}
+	return p, true, nil
+}
+
 func rerankAndFilterRelated(items []model.RelatedItem, targetTopics []string, limit int) []model.RelatedItem {
 	if len(items) == 0 || limit <= 0 {
 		return nil
@@ -1865,6 +2188,11 @@ func annotateRelatedReasons(items []model.RelatedItem, targetTopics []string) {
 			items[i].Reason = &reason
 			continue
 		}
+		if f := items[i].Fusion; f != nil && f.TextContribution > f.EmbeddingContribution {
+			reason := "keyword match"
+			items[i].Reason = &reason
+			continue
+		}
 		switch {
 		case items[i].Similarity >= 0.8:
 			reason := "very high semantic similarity"
@@ -1988,7 +2316,7 @@ func (h *ItemHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if inserted && h.streakRepo != nil {
-		_ = h.streakRepo.IncrementRead(r.Context(), userID, timeutil.NowJST(), 3)
+		_ = h.streakRepo.IncrementRead(r.Context(), userID, timeutil.NowJST())
 	}
 	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
 		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
@@ -2003,6 +2331,32 @@ func (h *ItemHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, itemToggleResponse{ItemID: id, IsRead: true})
 }
 
+var itemClickVias = map[string]bool{"item": true, "digest": true, "email": true, "reading_plan": true, "search": true}
+
+// MarkClicked accepts link-click beacons. The body is optional because navigator.sendBeacon
+// posts as text/plain, so an empty or unparsable body falls back to via=item.
+func (h *ItemHandler) MarkClicked(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	var body struct {
+		Via string `json:"via"`
+	}
+	_ = json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&body)
+	via := strings.TrimSpace(body.Via)
+	if via == "" {
+		via = "item"
+	}
+	if !itemClickVias[via] {
+		httpError(w, "invalid via", http.StatusBadRequest)
+		return
+	}
+	if err := h.repo.RecordClick(r.Context(), userID, id, via); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *ItemHandler) MarkUnread(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
@@ -2036,13 +2390,12 @@ func (h *ItemHandler) MarkReadBulk(w http.ResponseWriter, r *http.Request) {
 		LaterOnly     bool     `json:"later_only"`
 		OlderThanDays *int     `json:"older_than_days"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if len(body.ItemIDs) > 0 {
 		if len(body.ItemIDs) > 100 {
-			http.Error(w, "too many item_ids", http.StatusBadRequest)
+			httpError(w, "too many item_ids", http.StatusBadRequest)
 			return
 		}
 		updated, err := h.repo.MarkReadBulkByIDs(r.Context(), userID, body.ItemIDs)
@@ -2058,7 +2411,7 @@ func (h *ItemHandler) MarkReadBulk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if body.UnreadOnly && body.ReadOnly {
-		http.Error(w, "unread_only and read_only cannot both be true", http.StatusBadRequest)
+		httpError(w, "unread_only and read_only cannot both be true", http.StatusBadRequest)
 		return
 	}
 	updated, err := h.repo.MarkReadBulk(r.Context(), userID, repository.BulkMarkReadParams{
@@ -2085,23 +2438,23 @@ func (h *ItemHandler) MarkReadBulk(w http.ResponseWriter, r *http.Request) {
 func (h *ItemHandler) MarkLater(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
-	if err := h.repo.MarkLater(r.Context(), userID, id); err != nil {
+	if err := h.ApplyLater(r.Context(), userID, id); err != nil {
 		writeRepoError(w, err)
 		return
 	}
-	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
-		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
-	}
-	if err := h.bumpItemDetailVersion(r.Context(), id); err != nil {
-		log.Printf("item-detail version bump failed item_id=%s err=%v", id, err)
-	}
-	if err := h.publisher.SendItemSearchUpsertE(r.Context(), id); err != nil {
-		log.Printf("item-search upsert enqueue failed item_id=%s err=%v", id, err)
-	}
-	h.invalidateUserCaches(r.Context(), userID)
 	writeJSON(w, itemLaterResponse{ItemID: id, IsLater: true})
 }
 
+// ApplyLater marks an item to read later with the same cache, search and snapshot updates as
+// MarkLater, for callers outside a user session such as digest email links.
+func (h *ItemHandler) ApplyLater(ctx context.Context, userID, itemID string) error {
+	if err := h.repo.MarkLater(ctx, userID, itemID); err != nil {
+		return err
+	}
+	h.afterItemStateChange(ctx, userID, itemID)
+	return nil
+}
+
 func (h *ItemHandler) UnmarkLater(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
@@ -2109,17 +2462,23 @@ func (h *ItemHandler) UnmarkLater(w http.ResponseWriter, r *http.Request) {
 		writeRepoError(w, err)
 		return
 	}
-	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
+	h.afterItemStateChange(r.Context(), userID, id)
+	writeJSON(w, itemLaterResponse{ItemID: id, IsLater: false})
+}
+
+// afterItemStateChange refreshes what depends on one item's per-user state: the list and detail
+// caches, the search document and the user's snapshots.
+func (h *ItemHandler) afterItemStateChange(ctx context.Context, userID, itemID string) {
+	if err := h.bumpUserItemsVersion(ctx, userID); err != nil {
 		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
 	}
-	if err := h.bumpItemDetailVersion(r.Context(), id); err != nil {
-		log.Printf("item-detail version bump failed item_id=%s err=%v", id, err)
+	if err := h.bumpItemDetailVersion(ctx, itemID); err != nil {
+		log.Printf("item-detail version bump failed item_id=%s err=%v", itemID, err)
 	}
-	if err := h.publisher.SendItemSearchUpsertE(r.Context(), id); err != nil {
-		log.Printf("item-search upsert enqueue failed item_id=%s err=%v", id, err)
+	if err := h.publisher.SendItemSearchUpsertE(ctx, itemID); err != nil {
+		log.Printf("item-search upsert enqueue failed item_id=%s err=%v", itemID, err)
 	}
-	h.invalidateUserCaches(r.Context(), userID)
-	writeJSON(w, itemLaterResponse{ItemID: id, IsLater: false})
+	h.invalidateUserCaches(ctx, userID)
 }
 
 func (h *ItemHandler) MarkLaterBulk(w http.ResponseWriter, r *http.Request) {
@@ -2127,16 +2486,15 @@ func (h *ItemHandler) MarkLaterBulk(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		ItemIDs []string `json:"item_ids"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if len(body.ItemIDs) == 0 {
-		http.Error(w, "item_ids is required", http.StatusBadRequest)
+		httpError(w, "item_ids is required", http.StatusBadRequest)
 		return
 	}
 	if len(body.ItemIDs) > 100 {
-		http.Error(w, "too many item_ids", http.StatusBadRequest)
+		httpError(w, "too many item_ids", http.StatusBadRequest)
 		return
 	}
 	updated, err := h.repo.MarkLaterBulk(r.Context(), userID, body.ItemIDs)
@@ -2160,37 +2518,52 @@ func (h *ItemHandler) SetFeedback(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
 	var body struct {
-		Rating     int  `json:"rating"`
-		IsFavorite bool `json:"is_favorite"`
+		Rating     int     `json:"rating"`
+		IsFavorite bool    `json:"is_favorite"`
+		Reason     *string `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if body.Rating < -1 || body.Rating > 1 {
-		http.Error(w, "invalid rating", http.StatusBadRequest)
+		httpError(w, "invalid rating", http.StatusBadRequest)
+		return
+	}
+	if body.Reason != nil && (body.Rating != -1 || !service.IsFeedbackReason(*body.Reason)) {
+		httpError(w, "invalid reason", http.StatusBadRequest)
 		return
 	}
-	fb, err := h.repo.UpsertFeedback(r.Context(), userID, id, body.Rating, body.IsFavorite)
+	fb, err := h.repo.UpsertFeedbackWithReason(r.Context(), userID, id, body.Rating, body.IsFavorite, body.Reason)
 	if err != nil {
 		writeRepoError(w, err)
 		return
 	}
-	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
-		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
-	}
-	if err := h.bumpItemDetailVersion(r.Context(), id); err != nil {
-		log.Printf("item-detail version bump failed item_id=%s err=%v", id, err)
+	h.afterFeedbackChange(r.Context(), userID, id, body.IsFavorite)
+	writeJSON(w, fb)
+}
+
+// ApplyFeedback stores a rating with the same side effects as SetFeedback, for callers outside
+// a user session such as digest email links.
+func (h *ItemHandler) ApplyFeedback(ctx context.Context, userID, itemID string, rating int, isFavorite bool) error {
+	if _, err := h.repo.UpsertFeedback(ctx, userID, itemID, rating, isFavorite); err != nil {
+		return err
 	}
-	if err := h.publisher.SendItemSearchUpsertE(r.Context(), id); err != nil {
-		log.Printf("item-search upsert enqueue failed item_id=%s err=%v", id, err)
+	h.afterFeedbackChange(ctx, userID, itemID, isFavorite)
+	return nil
+}
+
+func (h *ItemHandler) afterFeedbackChange(ctx context.Context, userID, itemID string, isFavorite bool) {
+	h.afterItemStateChange(ctx, userID, itemID)
+	if h.reviewQueueRepo != nil && isFavorite {
+		_ = h.reviewQueueRepo.EnqueueDefault(ctx, userID, itemID, "favorite", time.Now())
 	}
-	if h.reviewQueueRepo != nil && body.IsFavorite {
-		_ = h.reviewQueueRepo.EnqueueDefault(r.Context(), userID, id, "favorite", time.Now())
+	if isFavorite {
+		// Items processed before snapshots were enabled get theirs when favorited.
+		if err := h.publisher.SendItemSnapshotCaptureE(ctx, itemID); err != nil {
+			log.Printf("item snapshot capture enqueue failed item_id=%s err=%v", itemID, err)
+		}
 	}
-	h.invalidateUserCaches(r.Context(), userID)
-	h.refreshPreferenceProfileAsync(userID, id)
-	writeJSON(w, fb)
+	h.refreshPreferenceProfileAsync(userID, itemID)
 }
 
 func (h *ItemHandler) Retry(w http.ResponseWriter, r *http.Request) {
@@ -2202,11 +2575,16 @@ func (h *ItemHandler) Retry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
-	if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, item.URL, nil, "retry"); err != nil {
-		http.Error(w, "failed to enqueue retry", http.StatusBadGateway)
+	// force=true regenerates the summary even when the content and settings are unchanged.
+	reason := "retry"
+	if r.URL.Query().Get("force") == "true" {
+		reason = service.ItemForceReprocessReason
+	}
+	if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, userID, item.URL, nil, reason); err != nil {
+		httpError(w, "failed to enqueue retry", http.StatusBadGateway)
 		return
 	}
 	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
@@ -2225,18 +2603,18 @@ func (h *ItemHandler) RetryFromFacts(w http.ResponseWriter, r *http.Request) {
 	item, err := h.repo.ResetForFactsRetry(r.Context(), id, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrConflict) {
-			http.Error(w, "item cannot be retried from facts", http.StatusConflict)
+			httpError(w, "item cannot be retried from facts", http.StatusConflict)
 			return
 		}
 		writeRepoError(w, err)
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
-	if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, item.URL, nil, "retry_from_facts"); err != nil {
-		http.Error(w, "failed to enqueue retry", http.StatusBadGateway)
+	if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, userID, item.URL, nil, "retry_from_facts"); err != nil {
+		httpError(w, "failed to enqueue retry", http.StatusBadGateway)
 		return
 	}
 	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
@@ -2253,17 +2631,16 @@ func (h *ItemHandler) RetryFromFacts(w http.ResponseWriter, r *http.Request) {
 func (h *ItemHandler) RetryFromFactsBulk(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body retryBulkRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	itemIDs := normalizeBulkItemIDs(body.ItemIDs)
 	if len(itemIDs) == 0 {
-		http.Error(w, "item_ids is required", http.StatusBadRequest)
+		httpError(w, "item_ids is required", http.StatusBadRequest)
 		return
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -2282,7 +2659,7 @@ func (h *ItemHandler) RetryFromFactsBulk(w http.ResponseWriter, r *http.Request)
 			}, nil
 		},
 		func(ctx context.Context, item retryBulkCandidate) error {
-			return h.publisher.SendItemCreatedWithReasonE(ctx, item.ID, item.SourceID, item.URL, nil, "retry_from_facts")
+			return h.publisher.SendItemCreatedWithReasonE(ctx, item.ID, item.SourceID, userID, item.URL, nil, "retry_from_facts")
 		},
 	)
 
@@ -2309,7 +2686,7 @@ func (h *ItemHandler) RetryFailed(w http.ResponseWriter, r *http.Request) {
 		sourceID = &v
 	}
 	if h.publisher == nil {
-		http.Error(w, "event publisher unavailable", http.StatusInternalServerError)
+		httpError(w, "event publisher unavailable", http.StatusInternalServerError)
 		return
 	}
 
@@ -2322,7 +2699,7 @@ func (h *ItemHandler) RetryFailed(w http.ResponseWriter, r *http.Request) {
 	queued := 0
 	failed := 0
 	for _, item := range items {
-		if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, item.URL, nil, "retry_failed"); err != nil {
+		if err := h.publisher.SendItemCreatedWithReasonE(r.Context(), item.ID, item.SourceID, userID, item.URL, nil, "retry_failed"); err != nil {
 			failed++
 			continue
 		}
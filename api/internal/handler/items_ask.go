@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+const (
+	defaultFeedAnswerTopK = 8
+	maxFeedAnswerTopK     = 12
+	defaultFeedAnswerDays = 90
+	maxFeedAnswerDays     = 365
+)
+
+// AskFeed answers a natural-language question from the user's own items: the question is
+// embedded, the top-k nearest items are retrieved and the worker writes a cited answer.
+func (h *AskHandler) AskFeed(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Question  string   `json:"question"`
+		Days      int      `json:"days"`
+		TopK      int      `json:"top_k"`
+		SourceIDs []string `json:"source_ids"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	question := strings.TrimSpace(body.Question)
+	if question == "" {
+		httpError(w, "question is required", http.StatusBadRequest)
+		return
+	}
+	if body.Days <= 0 {
+		body.Days = defaultFeedAnswerDays
+	}
+	if body.Days > maxFeedAnswerDays {
+		body.Days = maxFeedAnswerDays
+	}
+	if body.TopK <= 0 {
+		body.TopK = defaultFeedAnswerTopK
+	}
+	if body.TopK > maxFeedAnswerTopK {
+		body.TopK = maxFeedAnswerTopK
+	}
+
+	ctx := r.Context()
+	settings, err := h.settingsRepo.EnsureDefaults(ctx, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	allKeys := h.keyProvider.GetAllKeys(ctx, userID)
+	modelName := chooseAskModelForKeys(settings, allKeys)
+	if modelName == nil {
+		httpError(w, "llm api key is required", http.StatusBadRequest)
+		return
+	}
+	openAIKey, err := h.keyProvider.GetAPIKey(ctx, userID, "openai")
+	if err != nil {
+		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
+			httpError(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if openAIKey == nil || *openAIKey == "" {
+		httpError(w, "user openai api key is required", http.StatusBadRequest)
+		return
+	}
+	embeddingModel := service.UserEmbeddingModel(settings)
+	embResp, err := h.openAI.CreateEmbedding(ctx, *openAIKey, embeddingModel, question)
+	if err != nil {
+		httpError(w, fmt.Sprintf("create question embedding: %v", err), http.StatusBadGateway)
+		return
+	}
+	recordAskLLMUsage(ctx, h.llmUsageRepo, h.cache, "qa", embResp.LLM, &userID)
+
+	candidates, err := h.itemRepo.AskCandidatesByEmbedding(ctx, userID, question, embResp.Embedding, body.Days, false, body.SourceIDs, body.TopK)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	resp := model.FeedAnswerResponse{Question: question, Citations: []model.AskCitation{}}
+	if len(candidates) == 0 {
+		resp.Answer = "該当する記事はまだ見つかりませんでした。"
+		writeJSON(w, resp)
+		return
+	}
+
+	navKeys := h.askNavigatorKeys(allKeys)
+	openAIChatKey := h.keyProvider.ResolveOpenAIKey(allKeys, modelName)
+	answer, err := h.worker.AnswerWithModel(ctx, question, askWorkerCandidates(candidates), navKeys.anthropicKey, navKeys.googleKey, navKeys.groqKey, navKeys.deepseekKey, navKeys.alibabaKey, navKeys.mistralKey, navKeys.xaiKey, navKeys.zaiKey, navKeys.fireworksKey, openAIChatKey, modelName)
+	if err != nil {
+		httpError(w, fmt.Sprintf("answer worker: %v", err), http.StatusBadGateway)
+		return
+	}
+	answer.LLM = service.NormalizeCatalogPricedUsage("qa", answer.LLM)
+	recordAskLLMUsage(ctx, h.llmUsageRepo, h.cache, "qa", answer.LLM, &userID)
+
+	resp.Citations = feedAnswerCitations(answer.Citations, candidates)
+	indexByItemID := make(map[string]int, len(resp.Citations))
+	for i, c := range resp.Citations {
+		indexByItemID[c.ItemID] = i + 1
+	}
+	resp.Answer = formatAskCitationMarkers(strings.TrimSpace(answer.Answer), indexByItemID)
+	for _, bullet := range answer.Bullets {
+		if formatted := formatAskCitationMarkers(strings.TrimSpace(bullet), indexByItemID); formatted != "" {
+			resp.Bullets = append(resp.Bullets, formatted)
+		}
+	}
+	if answer.LLM != nil {
+		resp.AskLLM = &model.AskLLM{
+			Provider:      answer.LLM.Provider,
+			Model:         answer.LLM.Model,
+			PricingSource: answer.LLM.PricingSource,
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// feedAnswerCitations keeps only citations that point at retrieved candidates, in the
+// worker's order, enriched with the candidate's metadata.
+func feedAnswerCitations(cited []service.AnswerCitation, candidates []model.AskCandidate) []model.AskCitation {
+	byID := make(map[string]model.AskCandidate, len(candidates))
+	for _, c := range candidates {
+		byID[c.ID] = c
+	}
+	out := make([]model.AskCitation, 0, len(cited))
+	seen := make(map[string]struct{}, len(cited))
+	for _, c := range cited {
+		item, ok := byID[c.ItemID]
+		if !ok {
+			continue
+		}
+		if _, dup := seen[c.ItemID]; dup {
+			continue
+		}
+		seen[c.ItemID] = struct{}{}
+		out = append(out, model.AskCitation{
+			ItemID:      item.ID,
+			Title:       askCitationTitle(item),
+			URL:         item.URL,
+			Reason:      strings.TrimSpace(c.Reason),
+			PublishedAt: askCitationPublishedAt(item),
+			Topics:      item.SummaryTopics,
+		})
+	}
+	return out
+}
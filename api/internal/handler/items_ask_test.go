@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+func TestAskFeedRequiresQuestion(t *testing.T) {
+	h := &AskHandler{}
+	for body, want := range map[string]int{
+		`{}`:                 http.StatusBadRequest,
+		`{"question":"   "}`: http.StatusBadRequest,
+		`not json`:           http.StatusUnprocessableEntity,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/items/ask", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.AskFeed(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("body %s: status = %d, want %d", body, rec.Code, want)
+		}
+	}
+}
+
+func TestFeedAnswerCitationsKeepsRetrievedItemsOnly(t *testing.T) {
+	title := "Kubernetes 1.32"
+	candidates := []model.AskCandidate{
+		{Item: model.Item{ID: "a", URL: "https://example.com/a", Title: &title, SummaryTopics: []string{"k8s"}}},
+		{Item: model.Item{ID: "b", URL: "https://example.com/b"}},
+	}
+	cited := []service.AnswerCitation{
+		{ItemID: "b", URL: "https://example.com/b", Reason: " second "},
+		{ItemID: "missing", URL: "https://example.com/x"},
+		{ItemID: "a", URL: "https://example.com/a"},
+		{ItemID: "b", URL: "https://example.com/b"},
+	}
+
+	got := feedAnswerCitations(cited, candidates)
+
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2", len(got))
+	}
+	if got[0].ItemID != "b" || got[0].Reason != "second" {
+		t.Fatalf("got[0] = %+v", got[0])
+	}
+	if got[1].ItemID != "a" || got[1].Title != title || got[1].URL != "https://example.com/a" {
+		t.Fatalf("got[1] = %+v", got[1])
+	}
+}
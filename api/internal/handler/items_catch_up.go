@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	defaultCatchUpDays      = 14
+	maxCatchUpDays          = 30
+	catchUpCandidateLimit   = 400
+	maxCatchUpMarkReadItems = 1000
+)
+
+// CatchUp composes a rollup of the unread backlog over the last N days, grouped by
+// embedding cluster. The returned item_ids can be passed to MarkCatchUpRead.
+func (h *ItemHandler) CatchUp(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Days *int `json:"days"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	days := defaultCatchUpDays
+	if body.Days != nil {
+		days = *body.Days
+	}
+	if days < 1 || days > maxCatchUpDays {
+		httpError(w, "invalid days", http.StatusBadRequest)
+		return
+	}
+	if h.settingsRepo == nil || h.worker == nil || h.keyProvider == nil {
+		httpError(w, "catch-up is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+	today := timeutil.StartOfDayJST(timeutil.NowJST())
+	since := today.AddDate(0, 0, -days)
+	rollup := model.CatchUpRollup{
+		WindowStart: since.Format("2006-01-02"),
+		WindowDays:  days,
+		Clusters:    []model.CatchUpCluster{},
+		ItemIDs:     []string{},
+	}
+
+	candidates, err := h.repo.CatchUpCandidates(ctx, userID, since, catchUpCandidateLimit)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if len(candidates) == 0 {
+		writeJSON(w, rollup)
+		return
+	}
+	clusters, err := h.repo.ClusterItemsByEmbeddings(ctx, candidates)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	settings, err := h.settingsRepo.EnsureDefaults(ctx, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	profile := service.CatchUpDigestComposeProfile(service.DigestComposeProfileFromSettings(settings))
+	if len(clusters) > profile.MaxClusters {
+		clusters = clusters[:profile.MaxClusters]
+	}
+	for _, c := range clusters {
+		rollup.Clusters = append(rollup.Clusters, catchUpClusterOf(c))
+		for _, it := range c.Items {
+			rollup.ItemIDs = append(rollup.ItemIDs, it.ID)
+		}
+	}
+	rollup.ItemCount = len(rollup.ItemIDs)
+
+	modelName := resolveCatchUpModel(settings)
+	if modelName == nil {
+		httpError(w, "no llm api key configured", http.StatusBadRequest)
+		return
+	}
+	representativeIDs := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		representativeIDs = append(representativeIDs, c.Representative.ID)
+	}
+	summaries, err := h.repo.SummariesByItemIDs(ctx, userID, representativeIDs)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	composeItems := buildCatchUpComposeItems(clusters, summaries)
+	if len(composeItems) == 0 {
+		writeJSON(w, rollup)
+		return
+	}
+
+	locale := service.NormalizeLocale(settings.Locale)
+	nk := loadNavigatorKeys(ctx, h.keyProvider, userID, modelName)
+	workerCtx := service.WithWorkerTraceMetadata(ctx, "catch_up", &userID, nil, nil, nil)
+	resp, err := h.worker.ComposeDigestWithModel(workerCtx, today.Format("2006-01-02"), composeItems, nk.anthropicKey, nk.googleKey, nk.groqKey, nk.deepseekKey, nk.alibabaKey, nk.mistralKey, nk.xaiKey, nk.zaiKey, nk.fireworksKey, nk.openAIKey, modelName, nil, service.DigestTargetLanguage(settings.OutputLanguage, locale), locale, profile.Verbosity, profile.Tone)
+	if err != nil {
+		log.Printf("catch-up compose user=%s model=%s: %v", userID, strings.TrimSpace(*modelName), err)
+		httpError(w, "failed to compose catch-up", http.StatusBadGateway)
+		return
+	}
+	recordAskLLMUsage(ctx, h.llmUsageRepo, h.cache, "catch_up", resp.LLM, &userID)
+	rollup.Subject = strings.TrimSpace(resp.Subject)
+	rollup.Body = strings.TrimSpace(resp.Body)
+	rollup.LLM = navigatorLLMMeta(resp.LLM)
+	writeJSON(w, rollup)
+}
+
+// MarkCatchUpRead marks every item covered by a catch-up rollup as read.
+func (h *ItemHandler) MarkCatchUpRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		ItemIDs []string `json:"item_ids"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if len(body.ItemIDs) == 0 {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if len(body.ItemIDs) > maxCatchUpMarkReadItems {
+		httpError(w, "too many item_ids", http.StatusBadRequest)
+		return
+	}
+	updated, err := h.repo.MarkReadBulkByIDs(r.Context(), userID, body.ItemIDs)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserItemsVersion(r.Context(), userID); err != nil {
+		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
+	}
+	h.invalidateUserCaches(r.Context(), userID)
+	writeJSON(w, bulkStatusResponse{Status: "ok", UpdatedCount: updated})
+}
+
+// resolveCatchUpModel prefers the user's digest model and otherwise falls back to the
+// cheapest provider the user has a key for.
+func resolveCatchUpModel(settings *model.UserSettings) *string {
+	if settings == nil {
+		return nil
+	}
+	if modelName := chooseNavigatorModelOverride(settings.DigestModel, settings); modelName != nil {
+		return modelName
+	}
+	for _, provider := range service.CostEfficientLLMProviders("") {
+		if !hasNavigatorProviderKey(settings, provider) {
+			continue
+		}
+		v := strings.TrimSpace(service.DefaultLLMModelForPurpose(provider, "digest"))
+		if v == "" {
+			continue
+		}
+		return &v
+	}
+	return nil
+}
+
+func catchUpClusterOf(c model.ReadingPlanCluster) model.CatchUpCluster {
+	ids := make([]string, 0, len(c.Items))
+	for _, it := range c.Items {
+		ids = append(ids, it.ID)
+	}
+	return model.CatchUpCluster{ID: c.ID, Label: c.Label, Size: len(ids), ItemIDs: ids}
+}
+
+// buildCatchUpComposeItems sends one entry per cluster, using the representative's summary
+// and the cluster label as topic so the worker writes one section per story.
+func buildCatchUpComposeItems(clusters []model.ReadingPlanCluster, summaries map[string]string) []service.ComposeDigestItem {
+	out := make([]service.ComposeDigestItem, 0, len(clusters))
+	for _, c := range clusters {
+		rep := c.Representative
+		summary := strings.TrimSpace(summaries[rep.ID])
+		if summary == "" {
+			continue
+		}
+		title := rep.Title
+		if rep.TranslatedTitle != nil && strings.TrimSpace(*rep.TranslatedTitle) != "" {
+			title = rep.TranslatedTitle
+		}
+		topics := rep.SummaryTopics
+		if label := strings.TrimSpace(c.Label); label != "" {
+			topics = append([]string{label}, topics...)
+		}
+		out = append(out, service.ComposeDigestItem{
+			Rank:    len(out) + 1,
+			Title:   title,
+			URL:     rep.URL,
+			Summary: summary,
+			Topics:  topics,
+			Score:   rep.SummaryScore,
+		})
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestCatchUpRejectsInvalidDays(t *testing.T) {
+	h := &ItemHandler{}
+	for body, want := range map[string]int{
+		`{"days":0}`:     http.StatusBadRequest,
+		`{"days":31}`:    http.StatusBadRequest,
+		`{"days":"14d"}`: http.StatusUnprocessableEntity,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/items/catch-up", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.CatchUp(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("body %s: status = %d, want %d", body, rec.Code, want)
+		}
+	}
+}
+
+func TestMarkCatchUpReadRejectsEmptyAndOversizedBodies(t *testing.T) {
+	h := &ItemHandler{}
+	tooMany := `{"item_ids":["` + strings.Repeat(`a","`, maxCatchUpMarkReadItems) + `a"]}`
+	for _, body := range []string{`{}`, `{"item_ids":[]}`, tooMany} {
+		req := httptest.NewRequest(http.MethodPost, "/api/items/catch-up/mark-read", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.MarkCatchUpRead(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestBuildCatchUpComposeItemsUsesRepresentativesWithSummaries(t *testing.T) {
+	title := "Original"
+	translated := "翻訳"
+	score := 0.8
+	clusters := []model.ReadingPlanCluster{
+		{
+			Label: "AI chips",
+			Representative: model.Item{
+				ID: "a", URL: "https://example.com/a", Title: &title, TranslatedTitle: &translated,
+				SummaryScore: &score, SummaryTopics: []string{"hardware"},
+			},
+		},
+		{Label: "No summary", Representative: model.Item{ID: "b", URL: "https://example.com/b"}},
+		{Representative: model.Item{ID: "c", URL: "https://example.com/c", Title: &title}},
+	}
+	items := buildCatchUpComposeItems(clusters, map[string]string{"a": "summary a", "c": " summary c "})
+
+	if len(items) != 2 {
+		t.Fatalf("len(items) = %d, want 2", len(items))
+	}
+	if items[0].Rank != 1 || *items[0].Title != translated || items[0].Summary != "summary a" {
+		t.Fatalf("items[0] = %+v", items[0])
+	}
+	if len(items[0].Topics) != 2 || items[0].Topics[0] != "AI chips" {
+		t.Fatalf("items[0].Topics = %v, want cluster label first", items[0].Topics)
+	}
+	if items[1].Rank != 2 || items[1].URL != "https://example.com/c" || items[1].Summary != "summary c" {
+		t.Fatalf("items[1] = %+v", items[1])
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMarkClickedRejectsUnknownVia(t *testing.T) {
+	h := &ItemHandler{}
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/clicked", strings.NewReader(`{"via":"newsletter"}`))
+	rec := httptest.NewRecorder()
+
+	h.MarkClicked(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
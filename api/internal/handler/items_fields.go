@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+// parseItemListFields reads the fields (comma separated) and view (full | minimal) parameters
+// of the item list. It returns nil fields for the full listing and false for an unknown field
+// or view.
+func parseItemListFields(q url.Values) ([]string, bool) {
+	raw := strings.TrimSpace(q.Get("fields"))
+	view := strings.TrimSpace(q.Get("view"))
+	switch {
+	case raw != "":
+		return repository.NormalizeItemListFields(strings.Split(raw, ","))
+	case view == "" || view == "full":
+		return nil, true
+	case view == "minimal":
+		return repository.NormalizeItemListFields(repository.ItemListMinimalFields)
+	default:
+		return nil, false
+	}
+}
+
+// listItemFields serves List when the client selected item fields. Search results come back
+// whole from the search index and are trimmed afterwards; everything else is trimmed by the
+// repository query itself. Missing personal scores are not recomputed in this mode.
+func (h *ItemHandler) listItemFields(w http.ResponseWriter, r *http.Request, userID string, p repository.ItemListParams, search *service.ItemSearchQuery, fields []string, cacheKey string, cacheKeyErr error, cacheBust bool) {
+	if h.cache != nil && !cacheBust && cacheKeyErr == nil {
+		var cached model.ItemFieldsListResponse
+		if ok, err := h.cache.GetJSON(r.Context(), cacheKey, &cached); err == nil && ok {
+			itemsListCacheCounter.hits.Add(1)
+			incrCacheMetric(r.Context(), h.cache, userID, "items_list.hit")
+			writeJSON(w, &cached)
+			return
+		} else if err != nil {
+			itemsListCacheCounter.errors.Add(1)
+			incrCacheMetric(r.Context(), h.cache, userID, "items_list.error")
+			log.Printf("items-list cache get failed user_id=%s key=%s err=%v", userID, cacheKey, err)
+		}
+		itemsListCacheCounter.misses.Add(1)
+		incrCacheMetric(r.Context(), h.cache, userID, "items_list.miss")
+	} else if cacheBust {
+		itemsListCacheCounter.bypass.Add(1)
+		if h.cache != nil {
+			incrCacheMetric(r.Context(), h.cache, userID, "items_list.bypass")
+		}
+	}
+
+	load := func() (*model.ItemFieldsListResponse, error) {
+		var resp *model.ItemFieldsListResponse
+		if search != nil {
+			full, err := h.searchItems.Search(r.Context(), *search)
+			if err != nil {
+				log.Printf("items search unavailable user_id=%s err=%v", userID, err)
+				mode := service.NormalizeSearchMode(search.SearchMode)
+				resp = &model.ItemFieldsListResponse{
+					Items:             []map[string]any{},
+					Fields:            fields,
+					Page:              search.Page,
+					PageSize:          search.PageSize,
+					Sort:              "relevance",
+					Status:            search.Status,
+					SourceID:          search.SourceID,
+					SearchMode:        &mode,
+					SearchUnavailable: true,
+				}
+			} else {
+				resp = &model.ItemFieldsListResponse{
+					Items:      repository.ProjectItemFields(full.Items, fields),
+					Fields:     fields,
+					Page:       full.Page,
+					PageSize:   full.PageSize,
+					Total:      full.Total,
+					HasNext:    full.HasNext,
+					Sort:       full.Sort,
+					Status:     full.Status,
+					SourceID:   full.SourceID,
+					SearchMode: full.SearchMode,
+				}
+			}
+		} else {
+			var err error
+			resp, err = h.reader().ListPageFields(r.Context(), userID, p, fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if h.cache != nil && cacheKeyErr == nil {
+			if err := h.cache.SetJSON(r.Context(), cacheKey, resp, itemsListCacheTTLForSort(p.Sort)); err != nil {
+				itemsListCacheCounter.errors.Add(1)
+				incrCacheMetric(r.Context(), h.cache, userID, "items_list.error")
+				log.Printf("items-list cache set failed user_id=%s key=%s err=%v", userID, cacheKey, err)
+			}
+		}
+		return resp, nil
+	}
+	var resp *model.ItemFieldsListResponse
+	var err error
+	if cacheKeyErr == nil {
+		resp, err = coalesce(r.Context(), cacheKey, &itemsListCacheCounter, load)
+	} else {
+		resp, err = load()
+	}
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseItemListFields(t *testing.T) {
+	if fields, ok := parseItemListFields(url.Values{}); !ok || fields != nil {
+		t.Fatalf("default = %v, %v", fields, ok)
+	}
+	if fields, ok := parseItemListFields(url.Values{"view": []string{"full"}}); !ok || fields != nil {
+		t.Fatalf("view=full = %v, %v", fields, ok)
+	}
+
+	fields, ok := parseItemListFields(url.Values{"fields": []string{"is_read, title,title"}})
+	if !ok || !reflect.DeepEqual(fields, []string{"id", "title", "is_read"}) {
+		t.Fatalf("fields = %v, %v", fields, ok)
+	}
+
+	fields, ok = parseItemListFields(url.Values{"view": []string{"minimal"}})
+	if !ok || len(fields) == 0 || fields[0] != "id" {
+		t.Fatalf("view=minimal = %v, %v", fields, ok)
+	}
+
+	for _, q := range []url.Values{
+		{"fields": []string{"id,embedding"}},
+		{"view": []string{"compact"}},
+	} {
+		if _, ok := parseItemListFields(q); ok {
+			t.Errorf("parseItemListFields(%v) accepted", q)
+		}
+	}
+}
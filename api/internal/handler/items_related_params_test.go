@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestParseRelatedFusionParams(t *testing.T) {
+	if _, hybrid, err := parseRelatedFusionParams(url.Values{}); err != nil || hybrid {
+		t.Fatalf("default ranking: hybrid=%v err=%v", hybrid, err)
+	}
+
+	p, hybrid, err := parseRelatedFusionParams(url.Values{
+		"ranking":          []string{"hybrid"},
+		"embedding_weight": []string{"0.5"},
+		"rrf_k":            []string{"20"},
+	})
+	if err != nil || !hybrid {
+		t.Fatalf("hybrid ranking: hybrid=%v err=%v", hybrid, err)
+	}
+	if p.EmbeddingWeight != 0.5 || p.TextWeight != 1 || p.K != 20 {
+		t.Fatalf("params = %+v", p)
+	}
+
+	for _, q := range []url.Values{
+		{"ranking": []string{"bm25"}},
+		{"ranking": []string{"hybrid"}, "text_weight": []string{"-1"}},
+		{"ranking": []string{"hybrid"}, "embedding_weight": []string{"0"}, "text_weight": []string{"0"}},
+		{"ranking": []string{"hybrid"}, "rrf_k": []string{"0"}},
+	} {
+		if _, _, err := parseRelatedFusionParams(q); err == nil {
+			t.Fatalf("parseRelatedFusionParams(%v) accepted invalid input", q)
+		}
+	}
+}
+
+func TestCacheKeyRelatedHybridIncludesWeights(t *testing.T) {
+	p := repository.RelatedFusionParams{EmbeddingWeight: 1, TextWeight: 0.5, K: 60}
+	got := cacheKeyRelatedHybrid("u1", "item-1", 6, p)
+	if !strings.HasPrefix(got, cacheKeyRelated("u1", "item-1", 6)) || !strings.HasSuffix(got, ":hybrid=1,0.5,60") {
+		t.Fatalf("cacheKeyRelatedHybrid = %q", got)
+	}
+}
+
+func TestAnnotateRelatedReasonsFlagsKeywordMatches(t *testing.T) {
+	items := []model.RelatedItem{
+		{ID: "a", Similarity: 0.2, Fusion: &model.RelatedFusion{TextContribution: 0.016, EmbeddingContribution: 0}},
+		{ID: "b", Similarity: 0.7, Fusion: &model.RelatedFusion{TextContribution: 0.01, EmbeddingContribution: 0.016}},
+	}
+	annotateRelatedReasons(items, nil)
+	if items[0].Reason == nil || *items[0].Reason != "keyword match" {
+		t.Fatalf("keyword item reason = %v", items[0].Reason)
+	}
+	if items[1].Reason == nil || *items[1].Reason != "high semantic similarity" {
+		t.Fatalf("semantic item reason = %v", items[1].Reason)
+	}
+}
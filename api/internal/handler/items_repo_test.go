@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestItemHandlerMarkReadChecksOwnership(t *testing.T) {
+	items := repotest.NewItemStore()
+	items.Add("u1", model.Item{ID: "i1", Status: "summarized"})
+	h := &ItemHandler{repo: items}
+
+	rec := httptest.NewRecorder()
+	h.MarkRead(rec, userRequest(http.MethodPost, "/items/i1/read", "u2", "", map[string]string{"id": "i1"}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign item status = %d, want 404", rec.Code)
+	}
+	if items.IsRead("i1") {
+		t.Fatal("foreign user marked the item read")
+	}
+
+	rec = httptest.NewRecorder()
+	h.MarkRead(rec, userRequest(http.MethodPost, "/items/i1/read", "u1", "", map[string]string{"id": "i1"}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	if !items.IsRead("i1") {
+		t.Fatal("item was not marked read")
+	}
+}
+
+func TestItemHandlerStatsReadsFromReadRepo(t *testing.T) {
+	primary := repotest.NewItemStore()
+	primary.Err = errors.New("primary should not be read")
+	replica := repotest.NewItemStore()
+	replica.Add("u1", model.Item{ID: "i1", Status: "summarized"})
+	replica.Add("u1", model.Item{ID: "i2", Status: "failed"})
+	replica.Add("u2", model.Item{ID: "i3", Status: "summarized"})
+	h := (&ItemHandler{repo: primary}).WithReadRepo(replica)
+
+	rec := httptest.NewRecorder()
+	h.Stats(rec, userRequest(http.MethodGet, "/items/stats", "u1", "", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	var resp model.ItemStatsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 2 || resp.Unread != 2 || resp.ByStatus["failed"] != 1 {
+		t.Fatalf("stats = %+v", resp)
+	}
+}
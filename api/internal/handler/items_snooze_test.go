@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSnoozeRejectsInvalidDates(t *testing.T) {
+	h := &ItemHandler{}
+	for _, body := range []string{`{"until":"tomorrow"}`, `{"until":"2000-01-01"}`, `{"until":"2999-01-01"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/snooze", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.Snooze(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: status = %d, want %d", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
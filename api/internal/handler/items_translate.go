@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// Full text beyond this is cut before translation to keep a single request bounded.
+const maxTranslationContentRunes = 20000
+
+// Translate returns the item's summary and facts, and with include_content its full text,
+// in the user's output language. Translations are stored per item and language and reused
+// until the item is summarized again.
+func (h *ItemHandler) Translate(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	itemID := chi.URLParam(r, "id")
+	var body struct {
+		IncludeContent bool `json:"include_content"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	if h.settingsRepo == nil || h.worker == nil || h.keyProvider == nil {
+		httpError(w, "translation is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+	settings, err := h.settingsRepo.EnsureDefaults(ctx, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	language := translationTargetLanguage(settings)
+	cached, err := h.repo.GetTranslation(ctx, userID, itemID, language)
+	if err == nil && (!body.IncludeContent || cached.Content != nil) {
+		cached.Cached = true
+		writeJSON(w, cached)
+		return
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		writeRepoError(w, err)
+		return
+	}
+
+	item, err := h.detail.Get(ctx, itemID, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if item.Summary == nil || strings.TrimSpace(item.Summary.Summary) == "" {
+		httpError(w, "item is not summarized yet", http.StatusConflict)
+		return
+	}
+	input := service.ItemTranslationInput{
+		Title:   item.Title,
+		Summary: strings.TrimSpace(item.Summary.Summary),
+		Facts:   []string{},
+	}
+	if item.Facts != nil {
+		for _, fact := range item.Facts.Facts {
+			if fact = strings.TrimSpace(fact); fact != "" {
+				input.Facts = append(input.Facts, fact)
+			}
+		}
+	}
+	if body.IncludeContent && item.ContentText != nil && strings.TrimSpace(*item.ContentText) != "" {
+		content := []rune(strings.TrimSpace(*item.ContentText))
+		if len(content) > maxTranslationContentRunes {
+			content = content[:maxTranslationContentRunes]
+		}
+		text := string(content)
+		input.Content = &text
+	}
+
+	modelName := resolveTranslationModel(settings)
+	if modelName == nil {
+		httpError(w, "no llm api key configured", http.StatusBadRequest)
+		return
+	}
+	nk := loadNavigatorKeys(ctx, h.keyProvider, userID, modelName)
+	workerCtx := service.WithWorkerTraceMetadata(ctx, "translation", &userID, &item.SourceID, &itemID, nil)
+	resp, err := h.worker.TranslateItemWithModel(workerCtx, input, language, nk.anthropicKey, nk.googleKey, nk.groqKey, nk.deepseekKey, nk.alibabaKey, nk.mistralKey, nk.xaiKey, nk.zaiKey, nk.fireworksKey, nk.openAIKey, modelName)
+	if err != nil {
+		log.Printf("item translate user=%s item=%s model=%s: %v", userID, itemID, strings.TrimSpace(*modelName), err)
+		httpError(w, "failed to translate item", http.StatusBadGateway)
+		return
+	}
+	recordAskLLMUsage(ctx, h.llmUsageRepo, h.cache, "translation", resp.LLM, &userID)
+	if strings.TrimSpace(resp.Summary) == "" {
+		httpError(w, "failed to translate item", http.StatusBadGateway)
+		return
+	}
+
+	translation := model.ItemTranslation{
+		ItemID:   itemID,
+		Language: language,
+		Summary:  strings.TrimSpace(resp.Summary),
+		Facts:    resp.Facts,
+		Model:    modelName,
+	}
+	if v := strings.TrimSpace(resp.Title); v != "" {
+		translation.Title = &v
+	}
+	if v := strings.TrimSpace(resp.Content); input.Content != nil && v != "" {
+		translation.Content = &v
+	}
+	saved, err := h.repo.UpsertTranslation(ctx, translation)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, saved)
+}
+
+// translationTargetLanguage is the user's output language, or their UI locale when none is set.
+func translationTargetLanguage(settings *model.UserSettings) string {
+	if v := service.NormalizeOutputLanguage(settings.OutputLanguage); v != nil {
+		return *v
+	}
+	return service.NormalizeLocale(settings.Locale)
+}
+
+func resolveTranslationModel(settings *model.UserSettings) *string {
+	if settings == nil {
+		return nil
+	}
+	if modelName := chooseNavigatorModelOverride(settings.SummaryModel, settings); modelName != nil {
+		return modelName
+	}
+	for _, provider := range service.CostEfficientLLMProviders("") {
+		if !hasNavigatorProviderKey(settings, provider) {
+			continue
+		}
+		v := strings.TrimSpace(service.DefaultLLMModelForPurpose(provider, "summary"))
+		if v == "" {
+			continue
+		}
+		return &v
+	}
+	return nil
+}
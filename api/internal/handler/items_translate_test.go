@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestTranslateRejectsMalformedBody(t *testing.T) {
+	h := &ItemHandler{}
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/translate", strings.NewReader(`{"include_content":"yes"}`))
+	rec := httptest.NewRecorder()
+
+	h.Translate(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestTranslateUnavailableWithoutWorker(t *testing.T) {
+	h := &ItemHandler{}
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/translate", nil)
+	rec := httptest.NewRecorder()
+
+	h.Translate(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestTranslationTargetLanguage(t *testing.T) {
+	fr := "FR"
+	unsupported := "xx"
+	tests := []struct {
+		name     string
+		settings model.UserSettings
+		want     string
+	}{
+		{name: "output language wins", settings: model.UserSettings{OutputLanguage: &fr, Locale: "en"}, want: "fr"},
+		{name: "falls back to locale", settings: model.UserSettings{OutputLanguage: &unsupported, Locale: "en"}, want: "en"},
+		{name: "defaults to japanese", settings: model.UserSettings{}, want: "ja"},
+	}
+	for _, tt := range tests {
+		if got := translationTargetLanguage(&tt.settings); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const maxTriageDecisions = 200
+
+type triageDecisionRequest struct {
+	ItemID string  `json:"item_id"`
+	Action string  `json:"action"`
+	Rating *int    `json:"rating"`
+	Reason *string `json:"reason"`
+	Until  *string `json:"until"`
+}
+
+// parseTriageDecisions validates a triage batch. Snoozes without until last until tomorrow
+// in JST.
+func parseTriageDecisions(in []triageDecisionRequest, today time.Time) ([]repository.TriageDecision, error) {
+	if len(in) == 0 {
+		return nil, errors.New("decisions is required")
+	}
+	if len(in) > maxTriageDecisions {
+		return nil, errors.New("too many decisions")
+	}
+	out := make([]repository.TriageDecision, 0, len(in))
+	for i, req := range in {
+		d := repository.TriageDecision{ItemID: strings.TrimSpace(req.ItemID), Action: strings.TrimSpace(req.Action)}
+		if d.ItemID == "" {
+			return nil, fmt.Errorf("decisions[%d]: item_id is required", i)
+		}
+		switch d.Action {
+		case repository.TriageActionRead, repository.TriageActionSkip, repository.TriageActionFavorite:
+		case repository.TriageActionRate:
+			if req.Rating == nil || *req.Rating < -1 || *req.Rating > 1 {
+				return nil, fmt.Errorf("decisions[%d]: invalid rating", i)
+			}
+			if req.Reason != nil && (*req.Rating != -1 || !service.IsFeedbackReason(*req.Reason)) {
+				return nil, fmt.Errorf("decisions[%d]: invalid reason", i)
+			}
+			d.Rating, d.Reason = *req.Rating, req.Reason
+		case repository.TriageActionSnooze:
+			d.Until = today.AddDate(0, 0, 1)
+			if req.Until != nil {
+				day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(*req.Until), timeutil.JST)
+				if err != nil || !day.After(today) || day.After(today.AddDate(0, 0, maxSnoozeDays)) {
+					return nil, fmt.Errorf("decisions[%d]: until must be a future date within a year", i)
+				}
+				d.Until = day
+			}
+		default:
+			return nil, fmt.Errorf("decisions[%d]: invalid action", i)
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// Triage applies the decisions of a keyboard triage session in one transaction. However many
+// items it marks read, the batch counts once toward the reading streak.
+func (h *ItemHandler) Triage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Decisions []triageDecisionRequest `json:"decisions"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	today := timeutil.StartOfDayJST(timeutil.NowJST())
+	decisions, err := parseTriageDecisions(body.Decisions, today)
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	results, applied, readCount, err := h.repo.ApplyTriage(ctx, userID, decisions)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	out := model.TriageBatchResult{Applied: applied, ReadCount: readCount, Results: results}
+	if !applied {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(out)
+		return
+	}
+	if readCount > 0 && h.streakRepo != nil {
+		if err := h.streakRepo.IncrementRead(ctx, userID, timeutil.NowJST()); err != nil {
+			log.Printf("reading streak increment failed user_id=%s err=%v", userID, err)
+		} else {
+			out.StreakCounted = true
+		}
+	}
+
+	if err := h.bumpUserItemsVersion(ctx, userID); err != nil {
+		log.Printf("items-list version bump failed user_id=%s err=%v", userID, err)
+	}
+	bumped := map[string]bool{}
+	var feedbackIDs []string
+	for i, res := range results {
+		if res.Status != model.TriageStatusApplied {
+			continue
+		}
+		if !bumped[res.ItemID] {
+			bumped[res.ItemID] = true
+			if err := h.bumpItemDetailVersion(ctx, res.ItemID); err != nil {
+				log.Printf("item-detail version bump failed item_id=%s err=%v", res.ItemID, err)
+			}
+			if err := h.publisher.SendItemSearchUpsertE(ctx, res.ItemID); err != nil {
+				log.Printf("item-search upsert enqueue failed item_id=%s err=%v", res.ItemID, err)
+			}
+		}
+		switch decisions[i].Action {
+		case repository.TriageActionFavorite:
+			if h.reviewQueueRepo != nil {
+				_ = h.reviewQueueRepo.EnqueueDefault(ctx, userID, res.ItemID, "favorite", time.Now())
+			}
+			if err := h.publisher.SendItemSnapshotCaptureE(ctx, res.ItemID); err != nil {
+				log.Printf("item snapshot capture enqueue failed item_id=%s err=%v", res.ItemID, err)
+			}
+			feedbackIDs = append(feedbackIDs, res.ItemID)
+		case repository.TriageActionRate:
+			feedbackIDs = append(feedbackIDs, res.ItemID)
+		}
+	}
+	h.invalidateUserCaches(ctx, userID)
+	h.refreshPreferenceProfileAsync(userID, feedbackIDs...)
+	writeJSON(w, out)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestTriageRejectsInvalidBatches(t *testing.T) {
+	h := &ItemHandler{}
+	tooMany := `{"decisions":[` + strings.Repeat(`{"item_id":"a","action":"read"},`, maxTriageDecisions) + `{"item_id":"a","action":"read"}]}`
+	for _, body := range []string{
+		`{}`,
+		`{"decisions":[]}`,
+		tooMany,
+		`{"decisions":[{"item_id":"","action":"read"}]}`,
+		`{"decisions":[{"item_id":"a","action":"archive"}]}`,
+		`{"decisions":[{"item_id":"a","action":"rate"}]}`,
+		`{"decisions":[{"item_id":"a","action":"rate","rating":2}]}`,
+		`{"decisions":[{"item_id":"a","action":"rate","rating":1,"reason":"off_topic"}]}`,
+		`{"decisions":[{"item_id":"a","action":"snooze","until":"2000-01-01"}]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/items/triage", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.Triage(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: status = %d, want %d", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestParseTriageDecisionsKeepsOrderAndDefaultsSnooze(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, timeutil.JST)
+	reason := "off_topic"
+	rating := -1
+	got, err := parseTriageDecisions([]triageDecisionRequest{
+		{ItemID: " a ", Action: "read"},
+		{ItemID: "b", Action: "rate", Rating: &rating, Reason: &reason},
+		{ItemID: "c", Action: "snooze"},
+		{ItemID: "a", Action: "favorite"},
+	}, today)
+	if err != nil {
+		t.Fatalf("parseTriageDecisions() error = %v", err)
+	}
+	if len(got) != 4 || got[0].ItemID != "a" || got[3].Action != repository.TriageActionFavorite {
+		t.Fatalf("decisions = %+v", got)
+	}
+	if got[1].Rating != -1 || got[1].Reason == nil || *got[1].Reason != reason {
+		t.Fatalf("rate decision = %+v", got[1])
+	}
+	if !got[2].Until.Equal(today.AddDate(0, 0, 1)) {
+		t.Fatalf("snooze until = %v", got[2].Until)
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type llmBudgetAllocationService interface {
+	Summary(ctx context.Context, userID string, month time.Time) (*service.LLMBudgetAllocationSummary, error)
+	Replace(ctx context.Context, userID string, in []service.LLMBudgetAllocationInput) (*service.LLMBudgetAllocationSummary, error)
+}
+
+type LLMBudgetAllocationHandler struct {
+	svc      llmBudgetAllocationService
+	auditLog *service.AuditLogService
+}
+
+func NewLLMBudgetAllocationHandler(svc llmBudgetAllocationService) *LLMBudgetAllocationHandler {
+	return &LLMBudgetAllocationHandler{svc: svc}
+}
+
+// WithAuditLog records allocation changes.
+func (h *LLMBudgetAllocationHandler) WithAuditLog(svc *service.AuditLogService) *LLMBudgetAllocationHandler {
+	h.auditLog = svc
+	return h
+}
+
+func (h *LLMBudgetAllocationHandler) Replace(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Allocations []service.LLMBudgetAllocationInput `json:"allocations"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	summary, err := h.svc.Replace(r.Context(), userID, body.Allocations)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionBudgetUpdate, "allocations", map[string]any{"allocations": len(body.Allocations)})
+	writeJSON(w, summary)
+}
@@ -14,8 +14,10 @@ import (
 )
 
 type LLMUsageHandler struct {
-	usage *service.LLMUsageService
-	cache service.JSONCache
+	usage       *service.LLMUsageService
+	forecast    *service.LLMUsageForecastService
+	allocations llmBudgetAllocationService
+	cache       service.JSONCache
 }
 
 func NewLLMUsageHandler(repo *repository.LLMUsageLogRepo, executionRepo *repository.LLMExecutionEventRepo, cache service.JSONCache) *LLMUsageHandler {
@@ -33,6 +35,22 @@ func NewLLMUsageHandlerWithValueMetrics(repo *repository.LLMUsageLogRepo, execut
 	return &LLMUsageHandler{usage: service.NewLLMUsageService(repo, executionRepo, valueRepo), cache: cache}
 }
 
+func (h *LLMUsageHandler) WithForecast(forecast *service.LLMUsageForecastService) *LLMUsageHandler {
+	h.forecast = forecast
+	return h
+}
+
+// WithBudgetAllocations adds the per-purpose cap, used and remaining figures to DailySummary.
+func (h *LLMUsageHandler) WithBudgetAllocations(svc llmBudgetAllocationService) *LLMUsageHandler {
+	h.allocations = svc
+	return h
+}
+
+type llmUsageDailySummaryResponse struct {
+	Days              []service.LLMUsageDailySummaryView  `json:"days"`
+	BudgetAllocations *service.LLMBudgetAllocationSummary `json:"budget_allocations"`
+}
+
 func (h *LLMUsageHandler) llmUsageCacheKey(ctx context.Context, userID, fallbackKey string) (string, error) {
 	version := int64(0)
 	if h.cache != nil {
@@ -87,7 +105,7 @@ func (h *LLMUsageHandler) List(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	limit, ok := parseUsageLimit(r)
 	if !ok {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -95,7 +113,7 @@ func (h *LLMUsageHandler) List(w http.ResponseWriter, r *http.Request) {
 	if monthRaw != "" {
 		monthTime, monthKey, ok := parseUsageMonthJST(r)
 		if !ok {
-			http.Error(w, "invalid month", http.StatusBadRequest)
+			httpError(w, "invalid month", http.StatusBadRequest)
 			return
 		}
 		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageListMonthVersioned(userID, 0, limit, monthKey))
@@ -120,19 +138,69 @@ func (h *LLMUsageHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, rows)
 }
 
+// DailySummary returns the daily usage rows together with the budget allocations of the
+// requested month (the current JST month in days mode). Allocations are read uncached because
+// their used and remaining figures are what the budget guard enforces.
 func (h *LLMUsageHandler) DailySummary(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	cacheBust := r.URL.Query().Get("cache_bust") == "1"
+	monthTime, monthKey, ok := parseUsageMonthJST(r)
+	if !ok {
+		httpError(w, "invalid month", http.StatusBadRequest)
+		return
+	}
+	var rows []service.LLMUsageDailySummaryView
+	if strings.TrimSpace(r.URL.Query().Get("month")) != "" {
+		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageDailySummaryMonthVersioned(userID, 0, monthKey))
+		var fetchErr error
+		rows, fetchErr = cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageDailySummaryCacheTTL, func() ([]service.LLMUsageDailySummaryView, error) {
+			return h.usage.DailySummaryMonth(r.Context(), userID, monthTime)
+		}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
+		if fetchErr != nil {
+			writeRepoError(w, fetchErr)
+			return
+		}
+	} else {
+		days, ok := parseUsageDays(r)
+		if !ok {
+			httpError(w, "invalid days", http.StatusBadRequest)
+			return
+		}
+		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageDailySummaryVersioned(userID, 0, days))
+		var fetchErr error
+		rows, fetchErr = cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageDailySummaryCacheTTL, func() ([]service.LLMUsageDailySummaryView, error) {
+			return h.usage.DailySummary(r.Context(), userID, days)
+		}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
+		if fetchErr != nil {
+			writeRepoError(w, fetchErr)
+			return
+		}
+	}
+	resp := llmUsageDailySummaryResponse{Days: rows}
+	if h.allocations != nil {
+		summary, err := h.allocations.Summary(r.Context(), userID, monthTime)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		resp.BudgetAllocations = summary
+	}
+	writeJSON(w, resp)
+}
+
+func (h *LLMUsageHandler) ModelSummary(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
 	monthRaw := strings.TrimSpace(r.URL.Query().Get("month"))
 	if monthRaw != "" {
 		monthTime, monthKey, ok := parseUsageMonthJST(r)
 		if !ok {
-			http.Error(w, "invalid month", http.StatusBadRequest)
+			httpError(w, "invalid month", http.StatusBadRequest)
 			return
 		}
-		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageDailySummaryMonthVersioned(userID, 0, monthKey))
-		rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageDailySummaryCacheTTL, func() ([]service.LLMUsageDailySummaryView, error) {
-			return h.usage.DailySummaryMonth(r.Context(), userID, monthTime)
+		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageModelSummaryMonthVersioned(userID, 0, monthKey))
+		rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageModelSummaryView, error) {
+			return h.usage.ModelSummaryMonth(r.Context(), userID, monthTime)
 		}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
 		if fetchErr != nil {
 			writeRepoError(w, fetchErr)
@@ -143,12 +211,12 @@ func (h *LLMUsageHandler) DailySummary(w http.ResponseWriter, r *http.Request) {
 	}
 	days, ok := parseUsageDays(r)
 	if !ok {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
-	cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageDailySummaryVersioned(userID, 0, days))
-	rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageDailySummaryCacheTTL, func() ([]service.LLMUsageDailySummaryView, error) {
-		return h.usage.DailySummary(r.Context(), userID, days)
+	cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageModelSummaryVersioned(userID, 0, days))
+	rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageModelSummaryView, error) {
+		return h.usage.ModelSummary(r.Context(), userID, days)
 	}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
 	if fetchErr != nil {
 		writeRepoError(w, fetchErr)
@@ -157,19 +225,19 @@ func (h *LLMUsageHandler) DailySummary(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, rows)
 }
 
-func (h *LLMUsageHandler) ModelSummary(w http.ResponseWriter, r *http.Request) {
+func (h *LLMUsageHandler) SourceSummary(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
 	monthRaw := strings.TrimSpace(r.URL.Query().Get("month"))
 	if monthRaw != "" {
 		monthTime, monthKey, ok := parseUsageMonthJST(r)
 		if !ok {
-			http.Error(w, "invalid month", http.StatusBadRequest)
+			httpError(w, "invalid month", http.StatusBadRequest)
 			return
 		}
-		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageModelSummaryMonthVersioned(userID, 0, monthKey))
-		rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageModelSummaryView, error) {
-			return h.usage.ModelSummaryMonth(r.Context(), userID, monthTime)
+		cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageSourceSummaryMonthVersioned(userID, 0, monthKey))
+		rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageSourceSummaryView, error) {
+			return h.usage.SourceSummaryMonth(r.Context(), userID, monthTime)
 		}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
 		if fetchErr != nil {
 			writeRepoError(w, fetchErr)
@@ -180,12 +248,12 @@ func (h *LLMUsageHandler) ModelSummary(w http.ResponseWriter, r *http.Request) {
 	}
 	days, ok := parseUsageDays(r)
 	if !ok {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
-	cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageModelSummaryVersioned(userID, 0, days))
-	rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageModelSummaryView, error) {
-		return h.usage.ModelSummary(r.Context(), userID, days)
+	cacheKey, err := h.llmUsageCacheKey(r.Context(), userID, cacheKeyLLMUsageSourceSummaryVersioned(userID, 0, days))
+	rows, fetchErr := cachedFetchWithOpts(r.Context(), h.cache, cacheKey, llmUsageModelSummaryCacheTTL, func() ([]service.LLMUsageSourceSummaryView, error) {
+		return h.usage.SourceSummary(r.Context(), userID, days)
 	}, cacheFetchOptions{cacheBust: cacheBust, cacheKeyErr: err})
 	if fetchErr != nil {
 		writeRepoError(w, fetchErr)
@@ -198,7 +266,7 @@ func (h *LLMUsageHandler) AnalysisSummary(w http.ResponseWriter, r *http.Request
 	userID := middleware.GetUserID(r)
 	days, ok := parseUsageDays(r)
 	if !ok {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -217,7 +285,7 @@ func (h *LLMUsageHandler) ProviderSummaryCurrentMonth(w http.ResponseWriter, r *
 	userID := middleware.GetUserID(r)
 	monthTime, monthKey, ok := parseUsageMonthJST(r)
 	if !ok {
-		http.Error(w, "invalid month", http.StatusBadRequest)
+		httpError(w, "invalid month", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -236,7 +304,7 @@ func (h *LLMUsageHandler) PurposeSummaryCurrentMonth(w http.ResponseWriter, r *h
 	userID := middleware.GetUserID(r)
 	monthTime, monthKey, ok := parseUsageMonthJST(r)
 	if !ok {
-		http.Error(w, "invalid month", http.StatusBadRequest)
+		httpError(w, "invalid month", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -257,7 +325,7 @@ func (h *LLMUsageHandler) ExecutionSummaryCurrentMonth(w http.ResponseWriter, r
 	if daysRaw != "" {
 		days, ok := parseUsageDays(r)
 		if !ok {
-			http.Error(w, "invalid days", http.StatusBadRequest)
+			httpError(w, "invalid days", http.StatusBadRequest)
 			return
 		}
 		cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -274,7 +342,7 @@ func (h *LLMUsageHandler) ExecutionSummaryCurrentMonth(w http.ResponseWriter, r
 	}
 	monthTime, monthKey, ok := parseUsageMonthJST(r)
 	if !ok {
-		http.Error(w, "invalid month", http.StatusBadRequest)
+		httpError(w, "invalid month", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -293,7 +361,7 @@ func (h *LLMUsageHandler) ValueMetricsCurrentMonth(w http.ResponseWriter, r *htt
 	userID := middleware.GetUserID(r)
 	monthTime, monthKey, ok := parseUsageMonthJST(r)
 	if !ok {
-		http.Error(w, "invalid month", http.StatusBadRequest)
+		httpError(w, "invalid month", http.StatusBadRequest)
 		return
 	}
 	cacheBust := r.URL.Query().Get("cache_bust") == "1"
@@ -318,3 +386,17 @@ func parseIntOrDefault(s string, d int) int {
 	}
 	return v
 }
+
+func (h *LLMUsageHandler) Forecast(w http.ResponseWriter, r *http.Request) {
+	if h.forecast == nil {
+		httpError(w, "llm usage forecast unavailable", http.StatusInternalServerError)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	forecast, err := h.forecast.Forecast(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, forecast)
+}
@@ -78,7 +78,7 @@ func (h *OpenAITTSVoicesHandler) Sync(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 			} else {
-				http.Error(w, "openai tts voice sync already running", http.StatusConflict)
+				httpError(w, "openai tts voice sync already running", http.StatusConflict)
 				return
 			}
 		}
@@ -105,7 +105,7 @@ func (h *OpenAITTSVoicesHandler) Sync(w http.ResponseWriter, r *http.Request) {
 		if h.providerUpdateRepo != nil {
 			_ = h.providerUpdateRepo.UpsertSnapshot(r.Context(), "openai", previousVoiceIDs, "failed", &msg)
 		}
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"sort"
@@ -106,7 +105,7 @@ func (h *OpenRouterModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	if fetchErr != nil {
 		msg := fetchErr.Error()
 		_ = h.repo.FinishSyncRun(r.Context(), syncRunID, 0, 0, &msg)
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 	if cache, err := h.repo.ListLatestDescriptionCache(r.Context()); err == nil {
@@ -175,17 +174,16 @@ func (h *OpenRouterModelsHandler) UpdateStructuredOutputOverride(w http.Response
 		ModelID               string `json:"model_id"`
 		AllowStructuredOutput bool   `json:"allow_structured_output"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	modelID := strings.TrimSpace(body.ModelID)
 	if modelID == "" {
-		http.Error(w, "model_id is required", http.StatusBadRequest)
+		httpError(w, "model_id is required", http.StatusBadRequest)
 		return
 	}
 	if h.overrideRepo == nil {
-		http.Error(w, "override repository is not configured", http.StatusInternalServerError)
+		httpError(w, "override repository is not configured", http.StatusInternalServerError)
 		return
 	}
 	if !body.AllowStructuredOutput {
@@ -214,12 +212,12 @@ func (h *OpenRouterModelsHandler) UpdateStructuredOutputOverride(w http.Response
 		}
 	}
 	if snapshot == nil {
-		http.Error(w, "removed models cannot be overridden", http.StatusBadRequest)
+		httpError(w, "removed models cannot be overridden", http.StatusBadRequest)
 		return
 	}
 	rawAvailability, _ := service.OpenRouterSnapshotAvailability(*snapshot)
 	if rawAvailability != service.OpenRouterModelConstrained {
-		http.Error(w, "only constrained models can be overridden", http.StatusBadRequest)
+		httpError(w, "only constrained models can be overridden", http.StatusBadRequest)
 		return
 	}
 	record, err := h.overrideRepo.Upsert(r.Context(), userID, modelID, true)
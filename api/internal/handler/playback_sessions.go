@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
@@ -23,7 +22,7 @@ func NewPlaybackSessionsHandler(service *service.PlaybackSessionsService) *Playb
 
 func (h *PlaybackSessionsHandler) Latest(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.service == nil {
-		http.Error(w, "playback sessions unavailable", http.StatusInternalServerError)
+		httpError(w, "playback sessions unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -37,7 +36,7 @@ func (h *PlaybackSessionsHandler) Latest(w http.ResponseWriter, r *http.Request)
 
 func (h *PlaybackSessionsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.service == nil {
-		http.Error(w, "playback sessions unavailable", http.StatusInternalServerError)
+		httpError(w, "playback sessions unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -59,8 +58,7 @@ func (h *PlaybackSessionsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 func (h *PlaybackSessionsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var input service.StartPlaybackSessionInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &input) {
 		return
 	}
 	input.UserID = middleware.GetUserID(r)
@@ -86,18 +84,17 @@ func (h *PlaybackSessionsHandler) Interrupt(w http.ResponseWriter, r *http.Reque
 
 func (h *PlaybackSessionsHandler) updateWith(r *http.Request, w http.ResponseWriter, complete bool, interrupt bool) {
 	if h == nil || h.service == nil {
-		http.Error(w, "playback sessions unavailable", http.StatusInternalServerError)
+		httpError(w, "playback sessions unavailable", http.StatusInternalServerError)
 		return
 	}
 	var input service.UpdatePlaybackSessionInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, http.ErrBodyNotAllowed) {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeOptionalJSONBody(w, r, &input) {
 		return
 	}
 	input.UserID = middleware.GetUserID(r)
 	input.SessionID = strings.TrimSpace(chi.URLParam(r, "id"))
 	if input.SessionID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	var (
@@ -115,9 +112,9 @@ func (h *PlaybackSessionsHandler) updateWith(r *http.Request, w http.ResponseWri
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
@@ -33,7 +33,7 @@ func NewPodcastsHandler(feed podcastFeedBuilder, cache service.JSONCache) *Podca
 
 func (h *PodcastsHandler) Feed(w http.ResponseWriter, r *http.Request) {
 	if h.feed == nil {
-		http.Error(w, "podcast feed unavailable", http.StatusInternalServerError)
+		httpError(w, "podcast feed unavailable", http.StatusInternalServerError)
 		return
 	}
 	slug := strings.TrimSpace(chi.URLParam(r, "slug"))
@@ -43,14 +43,14 @@ func (h *PodcastsHandler) Feed(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			http.NotFound(w, r)
+			writeRepoError(w, err)
 			return
 		}
-		http.Error(w, "failed to build podcast feed", http.StatusInternalServerError)
+		httpError(w, "failed to build podcast feed", http.StatusInternalServerError)
 		return
 	}
 	if result == nil {
-		http.Error(w, "failed to build podcast feed", http.StatusInternalServerError)
+		httpError(w, "failed to build podcast feed", http.StatusInternalServerError)
 		return
 	}
 	body := result.Body
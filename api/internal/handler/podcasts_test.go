@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 	"github.com/go-chi/chi/v5"
@@ -190,6 +191,12 @@ func TestPodcastsFeedNotFound(t *testing.T) {
 	if rec.Code != http.StatusNotFound {
 		t.Fatalf("expected status 404, got %d", rec.Code)
 	}
+	var got struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Code != middleware.ErrCodeNotFound {
+		t.Fatalf("expected the %s error envelope, got %s", middleware.ErrCodeNotFound, rec.Body.String())
+	}
 }
 
 func TestPodcastsFeedInternalError(t *testing.T) {
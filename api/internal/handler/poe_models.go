@@ -99,7 +99,7 @@ func (h *PoeModelsHandler) Usage(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	poeKey, err := loadAndDecryptUserSecret(r.Context(), h.settingsRepo.GetPoeAPIKeyEncrypted, h.cipher, userID, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if poeKey == nil || strings.TrimSpace(*poeKey) == "" {
@@ -121,7 +121,7 @@ func (h *PoeModelsHandler) Usage(w http.ResponseWriter, r *http.Request) {
 	}
 	overview, err := h.usageService.GetOverview(r.Context(), userID, *poeKey, usageRange, entryLimit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, overview)
@@ -131,16 +131,16 @@ func (h *PoeModelsHandler) SyncUsage(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	poeKey, err := loadAndDecryptUserSecret(r.Context(), h.settingsRepo.GetPoeAPIKeyEncrypted, h.cipher, userID, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if poeKey == nil || strings.TrimSpace(*poeKey) == "" {
-		http.Error(w, "poe api key is not configured", http.StatusBadRequest)
+		httpError(w, "poe api key is not configured", http.StatusBadRequest)
 		return
 	}
 	run, err := h.usageService.SyncHistory(r.Context(), userID, *poeKey, "manual")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, map[string]any{"run": run})
@@ -160,7 +160,7 @@ func (h *PoeModelsHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	if fetchErr != nil {
 		msg := fetchErr.Error()
 		_ = h.repo.FinishSyncRun(r.Context(), syncRunID, 0, 0, &msg)
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 	if cache, err := h.repo.ListLatestDescriptionCache(r.Context()); err == nil {
@@ -40,7 +40,7 @@ func (h *SettingsHandler) bumpPreferenceProfileVersion(ctx context.Context, user
 
 func (h *SettingsHandler) GetPreferenceProfile(w http.ResponseWriter, r *http.Request) {
 	if h.prefProfileRepo == nil {
-		http.Error(w, "preference profile is not available", http.StatusServiceUnavailable)
+		httpError(w, "preference profile is not available", http.StatusServiceUnavailable)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -61,7 +61,7 @@ func (h *SettingsHandler) GetPreferenceProfile(w http.ResponseWriter, r *http.Re
 
 func (h *SettingsHandler) GetPreferenceProfileSummary(w http.ResponseWriter, r *http.Request) {
 	if h.prefProfileRepo == nil {
-		http.Error(w, "preference profile is not available", http.StatusServiceUnavailable)
+		httpError(w, "preference profile is not available", http.StatusServiceUnavailable)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -82,7 +82,7 @@ func (h *SettingsHandler) GetPreferenceProfileSummary(w http.ResponseWriter, r *
 
 func (h *SettingsHandler) ResetPreferenceProfile(w http.ResponseWriter, r *http.Request) {
 	if h.prefProfileRepo == nil {
-		http.Error(w, "preference profile is not available", http.StatusServiceUnavailable)
+		httpError(w, "preference profile is not available", http.StatusServiceUnavailable)
 		return
 	}
 	userID := middleware.GetUserID(r)
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+)
+
+// GetProcessingPause reports whether LLM processing is paused and how many items wait for it.
+func (h *SettingsHandler) GetProcessingPause(w http.ResponseWriter, r *http.Request) {
+	if h.processingPause == nil {
+		httpError(w, "processing pause unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := h.processingPause.Status(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
+
+// PauseProcessing stops LLM steps for new items; they are still fetched and wait in 'fetched'.
+func (h *SettingsHandler) PauseProcessing(w http.ResponseWriter, r *http.Request) {
+	if h.processingPause == nil {
+		httpError(w, "processing pause unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	status, err := h.processingPause.Pause(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, status)
+}
+
+// ResumeProcessing lifts the pause and queues the waiting items in batches. It answers 202
+// since the backfill runs after the response.
+func (h *SettingsHandler) ResumeProcessing(w http.ResponseWriter, r *http.Request) {
+	if h.processingPause == nil {
+		httpError(w, "processing pause unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	result, err := h.processingPause.Resume(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, result)
+}
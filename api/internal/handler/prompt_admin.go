@@ -36,19 +36,28 @@ type promptAdminActor struct {
 }
 
 func (h *PromptAdminHandler) capabilities(r *http.Request) (*promptAdminActor, bool) {
-	if h == nil || h.auth == nil || h.users == nil {
+	if h == nil {
+		return nil, false
+	}
+	return promptAdminCapabilities(r, h.auth, h.users)
+}
+
+// promptAdminCapabilities resolves the requesting user and whether they are on the admin
+// allowlist. Other admin-only handlers share the same allowlist.
+func promptAdminCapabilities(r *http.Request, auth *service.PromptAdminAuthService, users *repository.UserRepo) (*promptAdminActor, bool) {
+	if auth == nil || users == nil {
 		return nil, false
 	}
 	userID := strings.TrimSpace(middleware.GetUserID(r))
 	if userID == "" {
 		return nil, false
 	}
-	user, err := h.users.GetByID(r.Context(), userID)
+	user, err := users.GetByID(r.Context(), userID)
 	if err != nil || user == nil {
 		return nil, false
 	}
 	email := strings.TrimSpace(user.Email)
-	if !h.auth.CanManagePrompts(email) {
+	if !auth.CanManagePrompts(email) {
 		return &promptAdminActor{userID: userID, email: email}, false
 	}
 	return &promptAdminActor{userID: userID, email: email}, true
@@ -70,7 +79,7 @@ func (h *PromptAdminHandler) GetCapabilities(w http.ResponseWriter, r *http.Requ
 
 func (h *PromptAdminHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
 	if _, allowed := h.capabilities(r); !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	out, err := h.repo.ListTemplates(r.Context())
@@ -83,7 +92,7 @@ func (h *PromptAdminHandler) ListTemplates(w http.ResponseWriter, r *http.Reques
 
 func (h *PromptAdminHandler) GetTemplateDetail(w http.ResponseWriter, r *http.Request) {
 	if _, allowed := h.capabilities(r); !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	detail, err := h.repo.GetTemplateDetail(r.Context(), chi.URLParam(r, "id"))
@@ -92,12 +101,12 @@ func (h *PromptAdminHandler) GetTemplateDetail(w http.ResponseWriter, r *http.Re
 		return
 	}
 	if detail == nil {
-		http.Error(w, "not found", http.StatusNotFound)
+		httpError(w, "not found", http.StatusNotFound)
 		return
 	}
 	defaultTemplate, err := service.LookupPromptTemplateDefault(detail.Template.Key)
 	if err != nil {
-		http.Error(w, "failed to load default template", http.StatusInternalServerError)
+		httpError(w, "failed to load default template", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, promptTemplateDetailResponse{
@@ -112,7 +121,7 @@ func (h *PromptAdminHandler) GetTemplateDetail(w http.ResponseWriter, r *http.Re
 func (h *PromptAdminHandler) CreateVersion(w http.ResponseWriter, r *http.Request) {
 	actor, allowed := h.capabilities(r)
 	if !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	var body struct {
@@ -123,12 +132,11 @@ func (h *PromptAdminHandler) CreateVersion(w http.ResponseWriter, r *http.Reques
 		VariablesSchema    json.RawMessage `json:"variables_schema"`
 		Notes              string          `json:"notes"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if strings.TrimSpace(body.PromptText) == "" {
-		http.Error(w, "prompt_text is required", http.StatusBadRequest)
+		httpError(w, "prompt_text is required", http.StatusBadRequest)
 		return
 	}
 	version, err := h.repo.CreateVersion(r.Context(), repository.PromptTemplateVersionInput{
@@ -155,7 +163,7 @@ func (h *PromptAdminHandler) CreateVersion(w http.ResponseWriter, r *http.Reques
 		VersionID:  &version.ID,
 		Metadata:   json.RawMessage(`{}`),
 	}); err != nil {
-		http.Error(w, "failed to write audit log", http.StatusInternalServerError)
+		httpError(w, "failed to write audit log", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, version)
@@ -164,14 +172,13 @@ func (h *PromptAdminHandler) CreateVersion(w http.ResponseWriter, r *http.Reques
 func (h *PromptAdminHandler) ActivateTemplateVersion(w http.ResponseWriter, r *http.Request) {
 	actor, allowed := h.capabilities(r)
 	if !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	var body struct {
 		VersionID *string `json:"version_id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	templateID := chi.URLParam(r, "id")
@@ -194,7 +201,7 @@ func (h *PromptAdminHandler) ActivateTemplateVersion(w http.ResponseWriter, r *h
 		VersionID:  versionID,
 		Metadata:   json.RawMessage(`{}`),
 	}); err != nil {
-		http.Error(w, "failed to write audit log", http.StatusInternalServerError)
+		httpError(w, "failed to write audit log", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, map[string]any{"ok": true})
@@ -203,7 +210,7 @@ func (h *PromptAdminHandler) ActivateTemplateVersion(w http.ResponseWriter, r *h
 func (h *PromptAdminHandler) CreateExperiment(w http.ResponseWriter, r *http.Request) {
 	actor, allowed := h.capabilities(r)
 	if !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	var body struct {
@@ -215,12 +222,11 @@ func (h *PromptAdminHandler) CreateExperiment(w http.ResponseWriter, r *http.Req
 		EndedAt        *time.Time                            `json:"ended_at"`
 		Arms           []repository.PromptExperimentArmInput `json:"arms"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if strings.TrimSpace(body.TemplateID) == "" || strings.TrimSpace(body.Name) == "" || strings.TrimSpace(body.AssignmentUnit) == "" {
-		http.Error(w, "template_id, name, assignment_unit are required", http.StatusBadRequest)
+		httpError(w, "template_id, name, assignment_unit are required", http.StatusBadRequest)
 		return
 	}
 	exp, arms, err := h.repo.CreateExperiment(r.Context(), repository.PromptExperimentInput{
@@ -245,7 +251,7 @@ func (h *PromptAdminHandler) CreateExperiment(w http.ResponseWriter, r *http.Req
 		ExperimentID: &exp.ID,
 		Metadata:     json.RawMessage(`{}`),
 	}); err != nil {
-		http.Error(w, "failed to write audit log", http.StatusInternalServerError)
+		httpError(w, "failed to write audit log", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, map[string]any{"experiment": exp, "arms": arms})
@@ -254,7 +260,7 @@ func (h *PromptAdminHandler) CreateExperiment(w http.ResponseWriter, r *http.Req
 func (h *PromptAdminHandler) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
 	actor, allowed := h.capabilities(r)
 	if !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		httpError(w, "forbidden", http.StatusForbidden)
 		return
 	}
 	var body struct {
@@ -263,8 +269,7 @@ func (h *PromptAdminHandler) UpdateExperiment(w http.ResponseWriter, r *http.Req
 		EndedAt   *time.Time                            `json:"ended_at"`
 		Arms      []repository.PromptExperimentArmInput `json:"arms"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	exp, arms, err := h.repo.UpdateExperiment(r.Context(), chi.URLParam(r, "id"), body.Status, body.StartedAt, body.EndedAt, body.Arms)
@@ -273,7 +278,7 @@ func (h *PromptAdminHandler) UpdateExperiment(w http.ResponseWriter, r *http.Req
 		return
 	}
 	if exp == nil {
-		http.Error(w, "not found", http.StatusNotFound)
+		httpError(w, "not found", http.StatusNotFound)
 		return
 	}
 	if err := h.repo.InsertAuditLog(r.Context(), repository.PromptAdminAuditLogInput{
@@ -284,7 +289,7 @@ func (h *PromptAdminHandler) UpdateExperiment(w http.ResponseWriter, r *http.Req
 		ExperimentID: &exp.ID,
 		Metadata:     json.RawMessage(`{}`),
 	}); err != nil {
-		http.Error(w, "failed to write audit log", http.StatusInternalServerError)
+		httpError(w, "failed to write audit log", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, map[string]any{"experiment": exp, "arms": arms})
@@ -33,12 +33,12 @@ func NewProviderModelUpdateHandler(repo *repository.ProviderModelUpdateRepo, syn
 func (h *ProviderModelUpdateHandler) ListRecent(w http.ResponseWriter, r *http.Request) {
 	days := parseIntOrDefault(r.URL.Query().Get("days"), 14)
 	if days < 1 || days > 90 {
-		http.Error(w, "invalid days", http.StatusBadRequest)
+		httpError(w, "invalid days", http.StatusBadRequest)
 		return
 	}
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 30)
 	if limit < 1 || limit > 200 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
@@ -53,12 +53,12 @@ func (h *ProviderModelUpdateHandler) ListRecent(w http.ResponseWriter, r *http.R
 func (h *ProviderModelUpdateHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 100)
 	if limit < 1 || limit > 500 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	offset := parseIntOrDefault(r.URL.Query().Get("offset"), 0)
 	if offset < 0 {
-		http.Error(w, "invalid offset", http.StatusBadRequest)
+		httpError(w, "invalid offset", http.StatusBadRequest)
 		return
 	}
 
@@ -94,12 +94,12 @@ func (h *ProviderModelUpdateHandler) ListSnapshots(w http.ResponseWriter, r *htt
 
 func (h *ProviderModelUpdateHandler) SyncSnapshots(w http.ResponseWriter, r *http.Request) {
 	if h.syncer == nil {
-		http.Error(w, "syncer is not configured", http.StatusInternalServerError)
+		httpError(w, "syncer is not configured", http.StatusInternalServerError)
 		return
 	}
 	result, err := h.syncer.SyncCommonProviders(r.Context(), "manual")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, result)
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -58,13 +57,12 @@ func (h *ReadingGoalsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Priority    int    `json:"priority"`
 		DueDate     string `json:"due_date"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	goal, err := service.NormalizeReadingGoalInput(service.ReadingGoalInput(body))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	goals, err := h.store.ListByUser(r.Context(), userID)
@@ -73,7 +71,7 @@ func (h *ReadingGoalsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := service.CanActivateAnotherReadingGoal(goals, nil); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	goal.UserID = userID
@@ -94,13 +92,12 @@ func (h *ReadingGoalsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Priority    int    `json:"priority"`
 		DueDate     string `json:"due_date"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	goal, err := service.NormalizeReadingGoalInput(service.ReadingGoalInput(body))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	goals, err := h.store.ListByUser(r.Context(), userID)
@@ -120,7 +117,7 @@ func (h *ReadingGoalsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := service.CanActivateAnotherReadingGoal(goals, current); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	goal.ID = id
@@ -146,7 +143,7 @@ func (h *ReadingGoalsHandler) Restore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := service.CanActivateAnotherReadingGoal(goals, nil); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	h.setStatus(w, r, "active")
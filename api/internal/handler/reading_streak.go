@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+type readingStreakService interface {
+	Status(ctx context.Context, userID string) (*model.ReadingStreakStatus, error)
+	Freeze(ctx context.Context, userID, date string) (*model.ReadingStreakStatus, error)
+}
+
+type ReadingStreakHandler struct {
+	service readingStreakService
+	cache   service.JSONCache
+}
+
+func NewReadingStreakHandler(service readingStreakService, cache service.JSONCache) *ReadingStreakHandler {
+	return &ReadingStreakHandler{service: service, cache: cache}
+}
+
+func (h *ReadingStreakHandler) Get(w http.ResponseWriter, r *http.Request) {
+	status, err := h.service.Status(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
+
+func (h *ReadingStreakHandler) ApplyFreeze(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Date string `json:"date"`
+	}
+	if !decodeOptionalJSONBody(w, r, &body) {
+		return
+	}
+	status, err := h.service.Freeze(r.Context(), userID, body.Date)
+	if err != nil {
+		var ve *service.ValidationError
+		switch {
+		case errors.As(err, &ve):
+			httpError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, repository.ErrNoStreakFreezes), errors.Is(err, repository.ErrStreakFreezeNotApplicable):
+			httpError(w, err.Error(), http.StatusConflict)
+		default:
+			writeRepoError(w, err)
+		}
+		return
+	}
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(r.Context(), prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
+	writeJSON(w, status)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+)
+
+// decodeJSONBody decodes the request body into dst. A body past the route's limit (see
+// middleware.BodyLimit) is answered with 413 and a body that is not JSON for dst with 422,
+// both with a machine-readable code. It reports whether the handler should go on.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	return decodeRequestJSON(w, r, dst, false, false)
+}
+
+// decodeStrictJSONBody is decodeJSONBody that also refuses fields dst does not declare.
+func decodeStrictJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	return decodeRequestJSON(w, r, dst, true, false)
+}
+
+// decodeOptionalJSONBody is decodeJSONBody that leaves dst as is for an empty body.
+func decodeOptionalJSONBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	return decodeRequestJSON(w, r, dst, false, true)
+}
+
+func decodeRequestJSON(w http.ResponseWriter, r *http.Request, dst any, strict, optional bool) bool {
+	dec := json.NewDecoder(r.Body)
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	err := dec.Decode(dst)
+	if err == nil {
+		if err = dec.Decode(&struct{}{}); errors.Is(err, io.EOF) {
+			return true
+		}
+		if err == nil {
+			err = errors.New("unexpected data after the JSON value")
+		}
+	} else if optional && errors.Is(err, io.EOF) {
+		return true
+	}
+	writeBodyDecodeError(w, err)
+	return false
+}
+
+func writeBodyDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &tooLarge):
+		middleware.WriteBodyTooLarge(w, tooLarge.Limit)
+	case errors.Is(err, io.EOF):
+		middleware.WriteError(w, http.StatusUnprocessableEntity, middleware.ErrCodeEmptyBody, "request body is empty", nil)
+	case errors.As(err, &typeErr):
+		middleware.WriteError(w, http.StatusUnprocessableEntity, middleware.ErrCodeInvalidFieldType,
+			"field "+typeErr.Field+" must be "+typeErr.Type.String(), map[string]string{"field": typeErr.Field})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		middleware.WriteError(w, http.StatusUnprocessableEntity, middleware.ErrCodeUnknownField, "unknown field "+field, map[string]string{"field": field})
+	default:
+		middleware.WriteError(w, http.StatusUnprocessableEntity, middleware.ErrCodeInvalidJSON, "request body is not valid JSON", nil)
+	}
+}
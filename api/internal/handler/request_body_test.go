@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+)
+
+func TestDecodeJSONBodyErrors(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	cases := []struct {
+		body   string
+		strict bool
+		status int
+		code   string
+		field  string
+	}{
+		{body: `{"name":"a","extra":1}`, status: http.StatusOK},
+		{body: `{"name":"a","extra":1}`, strict: true, status: http.StatusUnprocessableEntity, code: middleware.ErrCodeUnknownField, field: "extra"},
+		{body: `{"count":"1"}`, status: http.StatusUnprocessableEntity, code: middleware.ErrCodeInvalidFieldType, field: "count"},
+		{body: `{"name":`, status: http.StatusUnprocessableEntity, code: middleware.ErrCodeInvalidJSON},
+		{body: `{"name":"a"} {}`, status: http.StatusUnprocessableEntity, code: middleware.ErrCodeInvalidJSON},
+		{body: ``, status: http.StatusUnprocessableEntity, code: middleware.ErrCodeEmptyBody},
+	}
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/x", strings.NewReader(c.body))
+		var dst payload
+		decode := decodeJSONBody
+		if c.strict {
+			decode = decodeStrictJSONBody
+		}
+		if decode(rec, req, &dst) {
+			rec.WriteHeader(http.StatusOK)
+		}
+		if rec.Code != c.status {
+			t.Fatalf("body %q: status = %d, want %d", c.body, rec.Code, c.status)
+		}
+		if c.code == "" {
+			continue
+		}
+		var got struct {
+			Code    string `json:"code"`
+			Details struct {
+				Field string `json:"field"`
+			} `json:"details"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.Code != c.code || got.Details.Field != c.field {
+			t.Fatalf("body %q: response = %s (%v)", c.body, rec.Body.String(), err)
+		}
+	}
+}
+
+func TestDecodeJSONBodyMapsBodyLimitTo413(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/x", strings.NewReader(`{"name":"`+strings.Repeat("a", 64)+`"}`))
+	req.Body = http.MaxBytesReader(rec, req.Body, 16)
+	var dst struct {
+		Name string `json:"name"`
+	}
+	if decodeJSONBody(rec, req, &dst) {
+		t.Fatal("decodeJSONBody() = true past the body limit")
+	}
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestDecodeOptionalJSONBodyAcceptsEmptyBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	var dst struct{}
+	if !decodeOptionalJSONBody(rec, httptest.NewRequest(http.MethodPost, "/api/x", nil), &dst) {
+		t.Fatalf("decodeOptionalJSONBody() = false for an empty body: %s", rec.Body.String())
+	}
+}
@@ -47,6 +47,8 @@ type relatedItemsResponse struct {
 	Clusters []relatedClusterResponse `json:"clusters"`
 	Limit    int                      `json:"limit"`
 	ItemID   string                   `json:"item_id"`
+	// EmbeddingCoverage tells how much of the candidate pool shared the item's embedding space.
+	EmbeddingCoverage *model.EmbeddingCoverage `json:"embedding_coverage,omitempty"`
 }
 
 type retryItemResponse struct {
@@ -87,6 +89,7 @@ type dashboardResponse struct {
 	Digests            any `json:"digests"`
 	LLMSummary         any `json:"llm_summary"`
 	TopicTrends        any `json:"topic_trends"`
+	EntityTrends       any `json:"entity_trends"`
 	FailedItemsPreview any `json:"failed_items_preview"`
 	LLMDays            int `json:"llm_days"`
 }
@@ -97,12 +100,13 @@ type dashboardTopicTrends struct {
 	Period string `json:"period"`
 }
 
-type importResultResponse struct {
-	Status      string   `json:"status"`
-	Total       int      `json:"total"`
-	Added       int      `json:"added"`
-	Skipped     int      `json:"skipped"`
-	Invalid     int      `json:"invalid"`
-	ErrorCount  int      `json:"error_count"`
-	ErrorSample []string `json:"error_sample"`
+type duplicateSourceDetails struct {
+	ExistingSource *model.Source `json:"existing_source"`
+}
+
+type usageLimitDetails struct {
+	Limit   string `json:"limit"`
+	Plan    string `json:"plan"`
+	Max     int    `json:"max"`
+	Current int    `json:"current"`
 }
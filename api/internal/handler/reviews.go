@@ -1,22 +1,33 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/model"
-	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 	"github.com/enjoydarts/sifto/api/internal/timeutil"
 )
 
+type reviewQueueStore interface {
+	ListDue(ctx context.Context, userID string, now time.Time, limit int) ([]model.ReviewQueueItem, error)
+	MarkDone(ctx context.Context, userID, queueID string) error
+	Snooze(ctx context.Context, userID, queueID string, duration time.Duration) error
+}
+
+type weeklyReviewStore interface {
+	CollectInputs(ctx context.Context, userID, weekStart, weekEnd string) (readCount, noteCount, insightCount, favoriteCount int, topics []model.WeeklyReviewTopic, missed []model.Item, err error)
+	Upsert(ctx context.Context, userID string, snapshot model.WeeklyReviewSnapshot) (*model.WeeklyReviewSnapshot, error)
+}
+
 type ReviewsHandler struct {
-	queueRepo  *repository.ReviewQueueRepo
-	weeklyRepo *repository.WeeklyReviewRepo
+	queueRepo  reviewQueueStore
+	weeklyRepo weeklyReviewStore
 }
 
-func NewReviewsHandler(queueRepo *repository.ReviewQueueRepo, weeklyRepo *repository.WeeklyReviewRepo) *ReviewsHandler {
+func NewReviewsHandler(queueRepo reviewQueueStore, weeklyRepo weeklyReviewStore) *ReviewsHandler {
 	return &ReviewsHandler{queueRepo: queueRepo, weeklyRepo: weeklyRepo}
 }
 
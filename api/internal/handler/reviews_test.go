@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"encoding/json"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestReviewsHandlerDueMarkDoneAndSnooze(t *testing.T) {
+	past := timeutil.NowJST().Add(-time.Hour)
+	queue := repotest.NewReviewQueueStore(
+		model.ReviewQueueItem{ID: "q1", UserID: "u1", ReviewDueAt: past},
+		model.ReviewQueueItem{ID: "q2", UserID: "u1", ReviewDueAt: past.Add(48 * time.Hour)},
+		model.ReviewQueueItem{ID: "q3", UserID: "u2", ReviewDueAt: past},
+	)
+	h := NewReviewsHandler(queue, repotest.NewWeeklyReviewStore(repotest.WeeklyReviewInputs{}))
+
+	rec := httptest.NewRecorder()
+	h.Due(rec, userRequest(http.MethodGet, "/reviews/due", "u1", "", nil))
+	var due model.ReviewQueueResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &due); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(due.Items) != 1 || due.Items[0].ID != "q1" {
+		t.Fatalf("due = %+v", due.Items)
+	}
+
+	rec = httptest.NewRecorder()
+	h.MarkDone(rec, userRequest(http.MethodPost, "/", "u1", "", nil), "q3")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign mark done status = %d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Snooze(rec, userRequest(http.MethodPost, "/?days=2", "u1", "", nil), "q1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("snooze status = %d", rec.Code)
+	}
+	if it, _ := queue.Item("q1"); it.SnoozeCount != 1 || !it.ReviewDueAt.Equal(past.Add(48*time.Hour)) {
+		t.Fatalf("snoozed item = %+v", it)
+	}
+}
+
+func TestReviewsHandlerWeeklyLatestSurfacesStoreErrors(t *testing.T) {
+	weekly := repotest.NewWeeklyReviewStore(repotest.WeeklyReviewInputs{ReadCount: 4})
+	h := NewReviewsHandler(repotest.NewReviewQueueStore(), weekly)
+
+	rec := httptest.NewRecorder()
+	h.WeeklyLatest(rec, userRequest(http.MethodGet, "/reviews/weekly", "u1", "", nil))
+	var snap model.WeeklyReviewSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if snap.ReadCount != 4 || snap.UserID != "u1" {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+
+	weekly.Err = errors.New("boom")
+	rec = httptest.NewRecorder()
+	h.WeeklyLatest(rec, userRequest(http.MethodGet, "/reviews/weekly", "u1", "", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
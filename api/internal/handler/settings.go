@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,8 +26,17 @@ type SettingsHandler struct {
 	notificationRepo  *repository.NotificationPriorityRepo
 	prefProfileRepo   *repository.PreferenceProfileRepo
 	oauth             *service.InoreaderOAuthService
+	feedly            *service.FeedlyOAuthService
 	github            *service.GitHubAppClient
 	obsidianExport    *service.ObsidianExportService
+	keyVerifier       *service.APIKeyVerificationService
+	smtp              *service.UserSMTPSettingsService
+	itemRepo          *repository.ItemRepo
+	embeddings        *service.EmbeddingReindexService
+	usageLimits       *service.UsageLimitService
+	auditLog          *service.AuditLogService
+	sessions          *service.UserSessionService
+	processingPause   *service.ProcessingPauseService
 	cache             service.JSONCache
 }
 
@@ -72,14 +82,53 @@ func NewSettingsHandler(repo *repository.UserSettingsRepo, userRepo *repository.
 		notificationRepo:  notificationRepo,
 		prefProfileRepo:   prefProfileRepo,
 		oauth:             service.NewInoreaderOAuthService(repo, cipher),
+		feedly:            service.NewFeedlyOAuthService(repo, cipher),
 		github:            github,
 		obsidianExport:    obsidianExport,
+		keyVerifier:       service.NewAPIKeyVerificationService(worker),
+		smtp:              service.NewUserSMTPSettingsService(repo, cipher),
 		cache:             cache,
 	}
 	h.settings.SetNotificationRuleRepo(notificationRepo)
 	return h
 }
 
+// WithItemRepo enables the pre-screen savings projection.
+func (h *SettingsHandler) WithItemRepo(repo *repository.ItemRepo) *SettingsHandler {
+	h.itemRepo = repo
+	return h
+}
+
+// WithEmbeddingReindex enables the embedding status and reindex endpoints.
+func (h *SettingsHandler) WithEmbeddingReindex(svc *service.EmbeddingReindexService) *SettingsHandler {
+	h.embeddings = svc
+	return h
+}
+
+// WithUsageLimits enables the usage limits endpoint.
+func (h *SettingsHandler) WithUsageLimits(svc *service.UsageLimitService) *SettingsHandler {
+	h.usageLimits = svc
+	return h
+}
+
+// WithAuditLog records API key, OAuth, budget and export actions and enables the audit log endpoint.
+func (h *SettingsHandler) WithAuditLog(svc *service.AuditLogService) *SettingsHandler {
+	h.auditLog = svc
+	return h
+}
+
+// WithSessions enables the session list and revoke endpoints.
+func (h *SettingsHandler) WithSessions(svc *service.UserSessionService) *SettingsHandler {
+	h.sessions = svc
+	return h
+}
+
+// WithProcessingPause enables pausing and resuming LLM processing.
+func (h *SettingsHandler) WithProcessingPause(svc *service.ProcessingPauseService) *SettingsHandler {
+	h.processingPause = svc
+	return h
+}
+
 func (h *SettingsHandler) settingsCacheKey(ctx context.Context, userID string) (string, error) {
 	version := int64(0)
 	if h.cache != nil {
@@ -110,7 +159,7 @@ func (h *SettingsHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return h.settings.Get(r.Context(), userID)
 	}, cacheFetchOptions{cacheBust: r.URL.Query().Get("cache_bust") == "1", cacheKeyErr: cacheKeyErr, logKeyPrefix: "settings"})
 	if err != nil {
-		http.Error(w, "failed to load settings", http.StatusInternalServerError)
+		httpError(w, "failed to load settings", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, payload)
@@ -125,10 +174,19 @@ func (h *SettingsHandler) GetLLMCatalog(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, catalog)
 }
 
+func (h *SettingsHandler) GetAvailableLLMModels(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.settings.AvailableLLMModels(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"settings": rows})
+}
+
 func (h *SettingsHandler) GetUIFontCatalog(w http.ResponseWriter, r *http.Request) {
 	catalog, err := h.settings.LoadUIFontCatalog(r.Context())
 	if err != nil {
-		http.Error(w, "failed to load ui font catalog", http.StatusInternalServerError)
+		httpError(w, "failed to load ui font catalog", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, catalog)
@@ -138,19 +196,19 @@ func (h *SettingsHandler) GetNavigatorPersonas(w http.ResponseWriter, r *http.Re
 	personaPath, err := resolveNavigatorPersonasPath()
 	if err != nil {
 		log.Printf("navigator persona resolve failed err=%v", err)
-		http.Error(w, "failed to resolve persona definitions", http.StatusInternalServerError)
+		httpError(w, "failed to resolve persona definitions", http.StatusInternalServerError)
 		return
 	}
 	body, err := os.ReadFile(personaPath)
 	if err != nil {
 		log.Printf("navigator persona read failed path=%s err=%v", personaPath, err)
-		http.Error(w, "failed to load persona definitions", http.StatusInternalServerError)
+		httpError(w, "failed to load persona definitions", http.StatusInternalServerError)
 		return
 	}
 	var payload map[string]navigatorPersonaDefinition
 	if err := json.Unmarshal(body, &payload); err != nil {
 		log.Printf("navigator persona parse failed path=%s err=%v", personaPath, err)
-		http.Error(w, "failed to parse persona definitions", http.StatusInternalServerError)
+		httpError(w, "failed to parse persona definitions", http.StatusInternalServerError)
 		return
 	}
 	writeJSON(w, payload)
@@ -174,10 +232,10 @@ func (h *SettingsHandler) InoreaderConnect(w http.ResponseWriter, r *http.Reques
 	result, err := h.oauth.BuildConnect(r)
 	if err != nil {
 		if errors.Is(err, service.ErrInoreaderOAuthNotConfigured) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, "failed to build oauth state", http.StatusInternalServerError)
+		httpError(w, "failed to build oauth state", http.StatusInternalServerError)
 		return
 	}
 	http.SetCookie(w, &http.Cookie{
@@ -209,6 +267,7 @@ func (h *SettingsHandler) InoreaderCallback(w http.ResponseWriter, r *http.Reque
 		http.Redirect(w, r, "/settings?inoreader=error&reason="+err.Error(), http.StatusFound)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionOAuthConnect, "inoreader", nil)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -231,6 +290,7 @@ func (h *SettingsHandler) DeleteInoreaderOAuth(w http.ResponseWriter, r *http.Re
 		writeRepoError(w, err)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionOAuthDisconnect, "inoreader", nil)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -241,9 +301,82 @@ func (h *SettingsHandler) DeleteInoreaderOAuth(w http.ResponseWriter, r *http.Re
 	})
 }
 
+func (h *SettingsHandler) FeedlyConnect(w http.ResponseWriter, r *http.Request) {
+	result, err := h.feedly.BuildConnect(r)
+	if err != nil {
+		if errors.Is(err, service.ErrFeedlyOAuthNotConfigured) {
+			httpError(w, service.ErrFeedlyOAuthNotConfigured.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "feedly_oauth_state",
+		Value:    result.State,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   result.Secure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   10 * 60,
+	})
+	http.Redirect(w, r, result.URL, http.StatusFound)
+}
+
+func (h *SettingsHandler) FeedlyCallback(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	state := strings.TrimSpace(r.URL.Query().Get("state"))
+	code := strings.TrimSpace(r.URL.Query().Get("code"))
+	if code == "" {
+		http.Redirect(w, r, "/settings?feedly=error&reason=missing_code", http.StatusFound)
+		return
+	}
+	stateCookie, err := r.Cookie("feedly_oauth_state")
+	if err != nil || strings.TrimSpace(stateCookie.Value) == "" || stateCookie.Value != state {
+		http.Redirect(w, r, "/settings?feedly=error&reason=invalid_state", http.StatusFound)
+		return
+	}
+	if err := h.feedly.Complete(r.Context(), userID, code, h.feedly.RedirectURIFromRequest(r)); err != nil {
+		http.Redirect(w, r, "/settings?feedly=error&reason="+err.Error(), http.StatusFound)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionOAuthConnect, "feedly", nil)
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "feedly_oauth_state",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil || strings.EqualFold(strings.TrimSpace(r.Header.Get("X-Forwarded-Proto")), "https"),
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+	http.Redirect(w, r, "/settings?feedly=connected", http.StatusFound)
+}
+
+func (h *SettingsHandler) DeleteFeedlyOAuth(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	settings, err := h.feedly.Clear(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionOAuthDisconnect, "feedly", nil)
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                 settings.UserID,
+		"has_feedly_oauth":        settings.HasFeedlyOAuth,
+		"feedly_token_expires_at": settings.FeedlyTokenExpiresAt,
+	})
+}
+
 func (h *SettingsHandler) ObsidianGitHubConnect(w http.ResponseWriter, r *http.Request) {
 	if h.github == nil || strings.TrimSpace(h.github.InstallURL()) == "" {
-		http.Error(w, "github app is not configured", http.StatusInternalServerError)
+		httpError(w, "github app is not configured", http.StatusInternalServerError)
 		return
 	}
 	http.Redirect(w, r, h.github.InstallURL(), http.StatusFound)
@@ -264,6 +397,7 @@ func (h *SettingsHandler) ObsidianGitHubCallback(w http.ResponseWriter, r *http.
 		http.Redirect(w, r, "/settings?obsidian_github=error&reason=save_failed", http.StatusFound)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionOAuthConnect, "github", map[string]any{"installation_id": installationID})
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -331,8 +465,7 @@ func (h *SettingsHandler) UpdateLLMModels(w http.ResponseWriter, r *http.Request
 		AudioBriefingScriptFallback *string `json:"audio_briefing_script_fallback"`
 		TTSMarkupPreprocessModel    *string `json:"tts_markup_preprocess_model"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	beforeSettings, beforeErr := h.settings.GetUserSettings(r.Context(), userID)
@@ -373,7 +506,7 @@ func (h *SettingsHandler) UpdateLLMModels(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		var mve *service.ModelValidationError
 		if errors.As(err, &mve) || errors.Is(err, service.ErrInvalidEmbeddingModel) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -405,8 +538,7 @@ func (h *SettingsHandler) UpdateAudioBriefing(w http.ResponseWriter, r *http.Req
 		BGMEnabled                  bool    `json:"bgm_enabled"`
 		BGMR2Prefix                 *string `json:"bgm_r2_prefix"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	settings, err := h.settings.UpdateAudioBriefingSettings(r.Context(), userID, service.UpdateAudioBriefingSettingsInput{
@@ -425,7 +557,7 @@ func (h *SettingsHandler) UpdateAudioBriefing(w http.ResponseWriter, r *http.Req
 	})
 	if err != nil {
 		if service.IsUserError(err) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -453,8 +585,7 @@ func (h *SettingsHandler) UpdatePodcast(w http.ResponseWriter, r *http.Request)
 		Explicit    bool    `json:"explicit"`
 		ArtworkURL  *string `json:"artwork_url"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	settings, err := h.settings.UpdatePodcastSettings(r.Context(), userID, service.UpdatePodcastSettingsInput{
@@ -469,8 +600,589 @@ func (h *SettingsHandler) UpdatePodcast(w http.ResponseWriter, r *http.Request)
 		ArtworkURL:  body.ArtworkURL,
 	})
 	if err != nil {
-		if errors.Is(err, service.ErrInvalidPodcastCategory()) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if errors.Is(err, service.ErrInvalidPodcastCategory()) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id": settings.UserID,
+		"podcast": service.PodcastSettingsPayload(settings),
+	})
+}
+
+func (h *SettingsHandler) UploadPodcastArtwork(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if h.podcastArtwork == nil {
+		httpError(w, "podcast artwork unavailable", http.StatusInternalServerError)
+		return
+	}
+	var body struct {
+		ContentType   string `json:"content_type"`
+		ContentBase64 string `json:"content_base64"`
+	}
+	if !decodeStrictJSONBody(w, r, &body) {
+		return
+	}
+	artworkURL, err := h.podcastArtwork.Upload(r.Context(), userID, body.ContentType, body.ContentBase64)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedArtworkContentType) || errors.Is(err, service.ErrPublicBaseURLNotConfigured) || errors.Is(err, service.ErrPublicBucketNotConfigured) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		httpError(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":     userID,
+		"artwork_url": artworkURL,
+	})
+}
+
+func (h *SettingsHandler) UpdateAudioBriefingPersonaVoices(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Voices []struct {
+			Persona                  string  `json:"persona"`
+			TTSProvider              string  `json:"tts_provider"`
+			TTSModel                 string  `json:"tts_model"`
+			VoiceModel               string  `json:"voice_model"`
+			VoiceStyle               string  `json:"voice_style"`
+			ProviderVoiceLabel       string  `json:"provider_voice_label"`
+			ProviderVoiceDescription string  `json:"provider_voice_description"`
+			SpeechRate               float64 `json:"speech_rate"`
+			EmotionalIntensity       float64 `json:"emotional_intensity"`
+			TempoDynamics            float64 `json:"tempo_dynamics"`
+			LineBreakSilenceSeconds  float64 `json:"line_break_silence_seconds"`
+			Pitch                    float64 `json:"pitch"`
+			VolumeGain               float64 `json:"volume_gain"`
+		} `json:"voices"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	inputs := make([]service.UpdateAudioBriefingPersonaVoiceInput, 0, len(body.Voices))
+	usesGeminiTTS := false
+	for _, voice := range body.Voices {
+		if strings.EqualFold(strings.TrimSpace(voice.TTSProvider), "gemini_tts") {
+			usesGeminiTTS = true
+		}
+		inputs = append(inputs, service.UpdateAudioBriefingPersonaVoiceInput{
+			Persona:                  voice.Persona,
+			TTSProvider:              voice.TTSProvider,
+			TTSModel:                 voice.TTSModel,
+			VoiceModel:               voice.VoiceModel,
+			VoiceStyle:               voice.VoiceStyle,
+			ProviderVoiceLabel:       voice.ProviderVoiceLabel,
+			ProviderVoiceDescription: voice.ProviderVoiceDescription,
+			SpeechRate:               voice.SpeechRate,
+			EmotionalIntensity:       voice.EmotionalIntensity,
+			TempoDynamics:            voice.TempoDynamics,
+			LineBreakSilenceSeconds:  voice.LineBreakSilenceSeconds,
+			Pitch:                    voice.Pitch,
+			VolumeGain:               voice.VolumeGain,
+		})
+	}
+	if usesGeminiTTS {
+		if err := service.EnsureGeminiTTSEnabledForUser(r.Context(), h.settings.UserRepo(), userID); err != nil {
+			httpError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	rows, err := h.settings.UpdateAudioBriefingPersonaVoices(r.Context(), userID, inputs)
+	if err != nil {
+		if service.IsUserError(err) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                       userID,
+		"audio_briefing_persona_voices": service.AudioBriefingPersonaVoicesPayload(rows),
+	})
+}
+
+func (h *SettingsHandler) GetSummaryAudioVoiceSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	settings, err := h.settings.GetSummaryAudioVoiceSettings(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"user_id":       userID,
+		"summary_audio": service.SummaryAudioVoiceSettingsPayload(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateSummaryAudioVoiceSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		TTSProvider              string  `json:"tts_provider"`
+		TTSModel                 string  `json:"tts_model"`
+		VoiceModel               string  `json:"voice_model"`
+		VoiceStyle               string  `json:"voice_style"`
+		ProviderVoiceLabel       string  `json:"provider_voice_label"`
+		ProviderVoiceDescription string  `json:"provider_voice_description"`
+		SpeechRate               float64 `json:"speech_rate"`
+		EmotionalIntensity       float64 `json:"emotional_intensity"`
+		TempoDynamics            float64 `json:"tempo_dynamics"`
+		LineBreakSilenceSeconds  float64 `json:"line_break_silence_seconds"`
+		Pitch                    float64 `json:"pitch"`
+		VolumeGain               float64 `json:"volume_gain"`
+		AivisUserDictionaryUUID  *string `json:"aivis_user_dictionary_uuid"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if strings.EqualFold(strings.TrimSpace(body.TTSProvider), "gemini_tts") {
+		if err := service.EnsureGeminiTTSEnabledForUser(r.Context(), h.settings.UserRepo(), userID); err != nil {
+			httpError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	settings, err := h.settings.UpdateSummaryAudioVoiceSettings(r.Context(), userID, service.UpdateSummaryAudioVoiceSettingsInput{
+		TTSProvider:              body.TTSProvider,
+		TTSModel:                 body.TTSModel,
+		VoiceModel:               body.VoiceModel,
+		VoiceStyle:               body.VoiceStyle,
+		ProviderVoiceLabel:       body.ProviderVoiceLabel,
+		ProviderVoiceDescription: body.ProviderVoiceDescription,
+		SpeechRate:               body.SpeechRate,
+		EmotionalIntensity:       body.EmotionalIntensity,
+		TempoDynamics:            body.TempoDynamics,
+		LineBreakSilenceSeconds:  body.LineBreakSilenceSeconds,
+		Pitch:                    body.Pitch,
+		VolumeGain:               body.VolumeGain,
+		AivisUserDictionaryUUID:  body.AivisUserDictionaryUUID,
+	})
+	if err != nil {
+		if service.IsUserError(err) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":       userID,
+		"summary_audio": service.SummaryAudioVoiceSettingsPayload(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateReadingPlan(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Window          string   `json:"window"`
+		Size            int      `json:"size"`
+		DiversifyTopics bool     `json:"diversify_topics"`
+		ExcludeRead     bool     `json:"exclude_read"`
+		Exploration     *float64 `json:"exploration"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Window != "24h" && body.Window != "today_jst" && body.Window != "7d" {
+		httpError(w, "invalid window", http.StatusBadRequest)
+		return
+	}
+	if body.Size < 1 || body.Size > 100 {
+		httpError(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+	if body.Exploration != nil && (*body.Exploration < 0 || *body.Exploration > 1) {
+		httpError(w, "invalid exploration", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateReadingPlan(r.Context(), userID, body.Window, body.Size, body.DiversifyTopics, body.ExcludeRead, body.Exploration)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id": settings.UserID,
+		"reading_plan": map[string]any{
+			"window":           settings.ReadingPlanWindow,
+			"size":             settings.ReadingPlanSize,
+			"diversify_topics": settings.ReadingPlanDiversifyTopics,
+			"exclude_read":     settings.ReadingPlanExcludeRead,
+			"exploration":      settings.ReadingPlanExploration,
+		},
+	})
+}
+
+func (h *SettingsHandler) UpdateFeedMigration(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		AutoMigrate *bool `json:"auto_migrate"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.AutoMigrate == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateFeedAutoMigrate(r.Context(), userID, *body.AutoMigrate)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                   settings.UserID,
+		"feed_auto_migrate_enabled": settings.FeedAutoMigrateEnabled,
+	})
+}
+
+func (h *SettingsHandler) UpdateSourceStatsSharing(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateSourceStatsSharing(r.Context(), userID, *body.Enabled)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                      settings.UserID,
+		"source_stats_sharing_enabled": settings.SourceStatsSharingEnabled,
+	})
+}
+
+func (h *SettingsHandler) UpdateHTMLSnapshots(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateHTMLSnapshots(r.Context(), userID, *body.Enabled)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                settings.UserID,
+		"html_snapshots_enabled": settings.HTMLSnapshotsEnabled,
+	})
+}
+
+func (h *SettingsHandler) UpdateExcludePaywalled(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Exclude *bool `json:"exclude"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Exclude == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateExcludePaywalled(r.Context(), userID, *body.Exclude)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(r.Context(), prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
+	writeJSON(w, map[string]any{
+		"user_id":           settings.UserID,
+		"exclude_paywalled": settings.ExcludePaywalled,
+	})
+}
+
+func (h *SettingsHandler) UpdateSocialBoost(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateSocialBoost(r.Context(), userID, *body.Enabled)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(r.Context(), prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
+	writeJSON(w, map[string]any{
+		"user_id":              settings.UserID,
+		"social_boost_enabled": settings.SocialBoostEnabled,
+	})
+}
+
+func (h *SettingsHandler) UpdateInoreaderSync(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled   *bool `json:"enabled"`
+		ReadState bool  `json:"read_state"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateInoreaderSync(r.Context(), userID, *body.Enabled, body.ReadState)
+	if err != nil {
+		var verr *service.ValidationError
+		if errors.As(err, &verr) {
+			httpError(w, verr.Message, http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":        settings.UserID,
+		"inoreader_sync": service.NewInoreaderSyncView(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateOutputLanguage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		OutputLanguage *string `json:"output_language"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.OutputLanguage != nil && strings.TrimSpace(*body.OutputLanguage) != "" && service.NormalizeOutputLanguage(body.OutputLanguage) == nil {
+		httpError(w, "invalid output_language", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateOutputLanguage(r.Context(), userID, body.OutputLanguage)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":         settings.UserID,
+		"output_language": settings.OutputLanguage,
+	})
+}
+
+func (h *SettingsHandler) UpdateLocale(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Locale string `json:"locale"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if !service.IsSupportedLocale(body.Locale) {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateLocale(r.Context(), userID, body.Locale)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id": settings.UserID,
+		"locale":  settings.Locale,
+	})
+}
+
+func (h *SettingsHandler) UpdateDigestAudio(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateDigestAudio(r.Context(), userID, *body.Enabled)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":              settings.UserID,
+		"digest_audio_enabled": settings.DigestAudioEnabled,
+	})
+}
+
+func (h *SettingsHandler) UpdateDigestStyle(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Verbosity   string `json:"verbosity"`
+		Tone        string `json:"tone"`
+		MaxClusters int    `json:"max_clusters"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if !service.IsSupportedDigestVerbosity(body.Verbosity) ||
+		!service.IsSupportedDigestTone(body.Tone) ||
+		!service.IsValidDigestMaxClusters(body.MaxClusters) {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateDigestStyle(r.Context(), userID, model.DigestComposeProfile{
+		Verbosity:   body.Verbosity,
+		Tone:        body.Tone,
+		MaxClusters: body.MaxClusters,
+	})
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":      settings.UserID,
+		"digest_style": service.DigestComposeProfileFromSettings(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateDigestTopicPriorities(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Topics []string `json:"topics"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	settings, err := h.settings.UpdateDigestTopicPriorities(r.Context(), userID, body.Topics)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                 settings.UserID,
+		"digest_topic_priorities": service.DigestTopicPrioritiesFromSettings(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateDigestSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body service.DigestScheduleInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	settings, err := h.settings.UpdateDigestSchedule(r.Context(), userID, body)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":         settings.UserID,
+		"digest_schedule": service.NewDigestScheduleView(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateBriefingGreeting(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Style string `json:"style"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	settings, err := h.settings.UpdateBriefingGreeting(r.Context(), userID, body.Style)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -479,99 +1191,59 @@ func (h *SettingsHandler) UpdatePodcast(w http.ResponseWriter, r *http.Request)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(r.Context(), prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
 	writeJSON(w, map[string]any{
-		"user_id": settings.UserID,
-		"podcast": service.PodcastSettingsPayload(settings),
+		"user_id":                 settings.UserID,
+		"briefing_greeting_style": settings.BriefingGreetingStyle,
 	})
 }
 
-func (h *SettingsHandler) UploadPodcastArtwork(w http.ResponseWriter, r *http.Request) {
+func (h *SettingsHandler) UpdateLLMConcurrency(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
-	if h.podcastArtwork == nil {
-		http.Error(w, "podcast artwork unavailable", http.StatusInternalServerError)
-		return
-	}
 	var body struct {
-		ContentType   string `json:"content_type"`
-		ContentBase64 string `json:"content_base64"`
+		Max *int `json:"max"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
-	artworkURL, err := h.podcastArtwork.Upload(r.Context(), userID, body.ContentType, body.ContentBase64)
+	settings, err := h.settings.UpdateLLMConcurrency(r.Context(), userID, body.Max)
 	if err != nil {
-		if errors.Is(err, service.ErrUnsupportedArtworkContentType) || errors.Is(err, service.ErrPublicBaseURLNotConfigured) || errors.Is(err, service.ErrPublicBucketNotConfigured) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		writeRepoError(w, err)
 		return
 	}
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
 	writeJSON(w, map[string]any{
-		"user_id":     userID,
-		"artwork_url": artworkURL,
+		"user_id":         settings.UserID,
+		"llm_concurrency": service.NewLLMConcurrencyView(settings),
 	})
 }
 
-func (h *SettingsHandler) UpdateAudioBriefingPersonaVoices(w http.ResponseWriter, r *http.Request) {
+func (h *SettingsHandler) UpdatePrescreen(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body struct {
-		Voices []struct {
-			Persona                  string  `json:"persona"`
-			TTSProvider              string  `json:"tts_provider"`
-			TTSModel                 string  `json:"tts_model"`
-			VoiceModel               string  `json:"voice_model"`
-			VoiceStyle               string  `json:"voice_style"`
-			ProviderVoiceLabel       string  `json:"provider_voice_label"`
-			ProviderVoiceDescription string  `json:"provider_voice_description"`
-			SpeechRate               float64 `json:"speech_rate"`
-			EmotionalIntensity       float64 `json:"emotional_intensity"`
-			TempoDynamics            float64 `json:"tempo_dynamics"`
-			LineBreakSilenceSeconds  float64 `json:"line_break_silence_seconds"`
-			Pitch                    float64 `json:"pitch"`
-			VolumeGain               float64 `json:"volume_gain"`
-		} `json:"voices"`
+		Threshold *float64 `json:"threshold"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
-	inputs := make([]service.UpdateAudioBriefingPersonaVoiceInput, 0, len(body.Voices))
-	usesGeminiTTS := false
-	for _, voice := range body.Voices {
-		if strings.EqualFold(strings.TrimSpace(voice.TTSProvider), "gemini_tts") {
-			usesGeminiTTS = true
-		}
-		inputs = append(inputs, service.UpdateAudioBriefingPersonaVoiceInput{
-			Persona:                  voice.Persona,
-			TTSProvider:              voice.TTSProvider,
-			TTSModel:                 voice.TTSModel,
-			VoiceModel:               voice.VoiceModel,
-			VoiceStyle:               voice.VoiceStyle,
-			ProviderVoiceLabel:       voice.ProviderVoiceLabel,
-			ProviderVoiceDescription: voice.ProviderVoiceDescription,
-			SpeechRate:               voice.SpeechRate,
-			EmotionalIntensity:       voice.EmotionalIntensity,
-			TempoDynamics:            voice.TempoDynamics,
-			LineBreakSilenceSeconds:  voice.LineBreakSilenceSeconds,
-			Pitch:                    voice.Pitch,
-			VolumeGain:               voice.VolumeGain,
-		})
-	}
-	if usesGeminiTTS {
-		if err := service.EnsureGeminiTTSEnabledForUser(r.Context(), h.settings.UserRepo(), userID); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
-			return
-		}
-	}
-	rows, err := h.settings.UpdateAudioBriefingPersonaVoices(r.Context(), userID, inputs)
+	settings, err := h.settings.UpdatePrescreen(r.Context(), userID, body.Threshold)
 	if err != nil {
-		if service.IsUserError(err) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -581,71 +1253,62 @@ func (h *SettingsHandler) UpdateAudioBriefingPersonaVoices(w http.ResponseWriter
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
 	writeJSON(w, map[string]any{
-		"user_id":                       userID,
-		"audio_briefing_persona_voices": service.AudioBriefingPersonaVoicesPayload(rows),
+		"user_id":   settings.UserID,
+		"prescreen": service.NewPrescreenSettingsView(settings),
 	})
 }
 
-func (h *SettingsHandler) GetSummaryAudioVoiceSettings(w http.ResponseWriter, r *http.Request) {
+// GetPrescreenProjection estimates what the threshold query parameter, or the saved threshold
+// when it is omitted, would have saved over the last 30 days.
+func (h *SettingsHandler) GetPrescreenProjection(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
-	settings, err := h.settings.GetSummaryAudioVoiceSettings(r.Context(), userID)
+	if h.itemRepo == nil {
+		httpError(w, "prescreen projection unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	var threshold float64
+	if raw := strings.TrimSpace(r.URL.Query().Get("threshold")); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 || v > 1 {
+			httpError(w, "threshold must be between 0 and 1", http.StatusBadRequest)
+			return
+		}
+		threshold = v
+	} else {
+		settings, err := h.settings.GetUserSettings(r.Context(), userID)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		if settings.PrescreenThreshold == nil {
+			httpError(w, "threshold is required", http.StatusBadRequest)
+			return
+		}
+		threshold = *settings.PrescreenThreshold
+	}
+	since := time.Now().AddDate(0, 0, -service.PrescreenProjectionDays)
+	stats, err := h.itemRepo.PrescreenStats(r.Context(), userID, threshold, since)
 	if err != nil {
 		writeRepoError(w, err)
 		return
 	}
-	writeJSON(w, map[string]any{
-		"user_id":       userID,
-		"summary_audio": service.SummaryAudioVoiceSettingsPayload(settings),
-	})
+	writeJSON(w, service.NewPrescreenProjection(threshold, stats))
 }
 
-func (h *SettingsHandler) UpdateSummaryAudioVoiceSettings(w http.ResponseWriter, r *http.Request) {
+func (h *SettingsHandler) UpdateWeeklyRecap(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body struct {
-		TTSProvider              string  `json:"tts_provider"`
-		TTSModel                 string  `json:"tts_model"`
-		VoiceModel               string  `json:"voice_model"`
-		VoiceStyle               string  `json:"voice_style"`
-		ProviderVoiceLabel       string  `json:"provider_voice_label"`
-		ProviderVoiceDescription string  `json:"provider_voice_description"`
-		SpeechRate               float64 `json:"speech_rate"`
-		EmotionalIntensity       float64 `json:"emotional_intensity"`
-		TempoDynamics            float64 `json:"tempo_dynamics"`
-		LineBreakSilenceSeconds  float64 `json:"line_break_silence_seconds"`
-		Pitch                    float64 `json:"pitch"`
-		VolumeGain               float64 `json:"volume_gain"`
-		AivisUserDictionaryUUID  *string `json:"aivis_user_dictionary_uuid"`
+		Enabled *bool `json:"enabled"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
-	if strings.EqualFold(strings.TrimSpace(body.TTSProvider), "gemini_tts") {
-		if err := service.EnsureGeminiTTSEnabledForUser(r.Context(), h.settings.UserRepo(), userID); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
-			return
-		}
+	if body.Enabled == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
 	}
-	settings, err := h.settings.UpdateSummaryAudioVoiceSettings(r.Context(), userID, service.UpdateSummaryAudioVoiceSettingsInput{
-		TTSProvider:              body.TTSProvider,
-		TTSModel:                 body.TTSModel,
-		VoiceModel:               body.VoiceModel,
-		VoiceStyle:               body.VoiceStyle,
-		ProviderVoiceLabel:       body.ProviderVoiceLabel,
-		ProviderVoiceDescription: body.ProviderVoiceDescription,
-		SpeechRate:               body.SpeechRate,
-		EmotionalIntensity:       body.EmotionalIntensity,
-		TempoDynamics:            body.TempoDynamics,
-		LineBreakSilenceSeconds:  body.LineBreakSilenceSeconds,
-		Pitch:                    body.Pitch,
-		VolumeGain:               body.VolumeGain,
-		AivisUserDictionaryUUID:  body.AivisUserDictionaryUUID,
-	})
+	settings, err := h.settings.UpdateWeeklyRecap(r.Context(), userID, *body.Enabled)
 	if err != nil {
-		if service.IsUserError(err) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
 		writeRepoError(w, err)
 		return
 	}
@@ -653,33 +1316,56 @@ func (h *SettingsHandler) UpdateSummaryAudioVoiceSettings(w http.ResponseWriter,
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
 	writeJSON(w, map[string]any{
-		"user_id":       userID,
-		"summary_audio": service.SummaryAudioVoiceSettingsPayload(settings),
+		"user_id":              settings.UserID,
+		"weekly_recap_enabled": settings.WeeklyRecapEnabled,
 	})
 }
 
-func (h *SettingsHandler) UpdateReadingPlan(w http.ResponseWriter, r *http.Request) {
+func (h *SettingsHandler) UpdateStreak(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
-	var body struct {
-		Window          string `json:"window"`
-		Size            int    `json:"size"`
-		DiversifyTopics bool   `json:"diversify_topics"`
-		ExcludeRead     bool   `json:"exclude_read"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	var body service.StreakSettingsInput
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
-	if body.Window != "24h" && body.Window != "today_jst" && body.Window != "7d" {
-		http.Error(w, "invalid window", http.StatusBadRequest)
+	settings, err := h.settings.UpdateStreak(r.Context(), userID, body)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeRepoError(w, err)
 		return
 	}
-	if body.Size < 1 || body.Size > 100 {
-		http.Error(w, "invalid size", http.StatusBadRequest)
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	if h.cache != nil {
+		for _, prefix := range cacheUserInvalidatePrefixes(userID) {
+			if _, err := h.cache.DeleteByPrefix(r.Context(), prefix, 5000); err != nil {
+				log.Printf("cache invalidate failed user_id=%s prefix=%s err=%v", userID, prefix, err)
+			}
+		}
+	}
+	writeJSON(w, map[string]any{
+		"user_id": settings.UserID,
+		"streak":  service.NewStreakSettingsView(settings),
+	})
+}
+
+func (h *SettingsHandler) UpdateDigestApproval(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body service.DigestApprovalInput
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
-	settings, err := h.settings.UpdateReadingPlan(r.Context(), userID, body.Window, body.Size, body.DiversifyTopics, body.ExcludeRead)
+	settings, err := h.settings.UpdateDigestApproval(r.Context(), userID, body)
 	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		writeRepoError(w, err)
 		return
 	}
@@ -687,13 +1373,8 @@ func (h *SettingsHandler) UpdateReadingPlan(w http.ResponseWriter, r *http.Reque
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
 	writeJSON(w, map[string]any{
-		"user_id": settings.UserID,
-		"reading_plan": map[string]any{
-			"window":           settings.ReadingPlanWindow,
-			"size":             settings.ReadingPlanSize,
-			"diversify_topics": settings.ReadingPlanDiversifyTopics,
-			"exclude_read":     settings.ReadingPlanExcludeRead,
-		},
+		"user_id":         settings.UserID,
+		"digest_approval": service.NewDigestApprovalView(settings),
 	})
 }
 
@@ -707,8 +1388,7 @@ func (h *SettingsHandler) UpdateObsidianExport(w http.ResponseWriter, r *http.Re
 		VaultRootPath    *string `json:"vault_root_path"`
 		KeywordLinkMode  *string `json:"keyword_link_mode"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	settings, err := h.settings.UpdateObsidianExport(r.Context(), userID, service.UpdateObsidianExportInput{
@@ -721,7 +1401,7 @@ func (h *SettingsHandler) UpdateObsidianExport(w http.ResponseWriter, r *http.Re
 	})
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidKeywordLinkMode) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -739,7 +1419,7 @@ func (h *SettingsHandler) UpdateObsidianExport(w http.ResponseWriter, r *http.Re
 func (h *SettingsHandler) UpdateNotificationPriority(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	if h.notificationRepo == nil {
-		http.Error(w, "notification priority unavailable", http.StatusInternalServerError)
+		httpError(w, "notification priority unavailable", http.StatusInternalServerError)
 		return
 	}
 	var body struct {
@@ -751,20 +1431,19 @@ func (h *SettingsHandler) UpdateNotificationPriority(w http.ResponseWriter, r *h
 		ReviewEnabled    bool    `json:"review_enabled"`
 		GoalMatchEnabled bool    `json:"goal_match_enabled"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if body.Sensitivity != "low" && body.Sensitivity != "medium" && body.Sensitivity != "high" {
-		http.Error(w, "invalid sensitivity", http.StatusBadRequest)
+		httpError(w, "invalid sensitivity", http.StatusBadRequest)
 		return
 	}
 	if body.DailyCap < 0 || body.DailyCap > 20 {
-		http.Error(w, "invalid daily_cap", http.StatusBadRequest)
+		httpError(w, "invalid daily_cap", http.StatusBadRequest)
 		return
 	}
 	if body.ThemeWeight < 0.5 || body.ThemeWeight > 2.0 {
-		http.Error(w, "invalid theme_weight", http.StatusBadRequest)
+		httpError(w, "invalid theme_weight", http.StatusBadRequest)
 		return
 	}
 	rule, err := h.notificationRepo.Upsert(r.Context(), userID, body.Sensitivity, body.DailyCap, body.ThemeWeight, body.ImmediateEnabled, body.BriefingEnabled, body.ReviewEnabled, body.GoalMatchEnabled)
@@ -784,11 +1463,11 @@ func (h *SettingsHandler) UpdateNotificationPriority(w http.ResponseWriter, r *h
 func (h *SettingsHandler) RunObsidianExport(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	if h.obsidianExport == nil {
-		http.Error(w, "obsidian export unavailable", http.StatusInternalServerError)
+		httpError(w, "obsidian export unavailable", http.StatusInternalServerError)
 		return
 	}
 	if h.obsidianRepo == nil {
-		http.Error(w, "obsidian export unavailable", http.StatusInternalServerError)
+		httpError(w, "obsidian export unavailable", http.StatusInternalServerError)
 		return
 	}
 	cfg, err := h.obsidianRepo.EnsureDefaults(r.Context(), userID)
@@ -798,9 +1477,10 @@ func (h *SettingsHandler) RunObsidianExport(w http.ResponseWriter, r *http.Reque
 	}
 	res, err := h.obsidianExport.RunUser(r.Context(), *cfg, 20)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionExport, "obsidian", map[string]any{"updated": res.Updated})
 	writeJSON(w, res)
 }
 
@@ -812,16 +1492,15 @@ func (h *SettingsHandler) UpdateBudget(w http.ResponseWriter, r *http.Request) {
 		BudgetAlertThresholdPct int      `json:"budget_alert_threshold_pct"`
 		DigestEmailEnabled      bool     `json:"digest_email_enabled"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	if body.BudgetAlertThresholdPct < 1 || body.BudgetAlertThresholdPct > 99 {
-		http.Error(w, "invalid budget_alert_threshold_pct", http.StatusBadRequest)
+		httpError(w, "invalid budget_alert_threshold_pct", http.StatusBadRequest)
 		return
 	}
 	if body.MonthlyBudgetUSD != nil && *body.MonthlyBudgetUSD < 0 {
-		http.Error(w, "invalid monthly_budget_usd", http.StatusBadRequest)
+		httpError(w, "invalid monthly_budget_usd", http.StatusBadRequest)
 		return
 	}
 	var budget *float64
@@ -833,24 +1512,60 @@ func (h *SettingsHandler) UpdateBudget(w http.ResponseWriter, r *http.Request) {
 		writeRepoError(w, err)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionBudgetUpdate, "monthly_budget", map[string]any{
+		"monthly_budget_usd":         budget,
+		"budget_alert_enabled":       body.BudgetAlertEnabled,
+		"budget_alert_threshold_pct": body.BudgetAlertThresholdPct,
+	})
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
 	writeJSON(w, settings)
 }
 
+func (h *SettingsHandler) UpdateBudgetEnforcement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		Enabled    bool     `json:"enabled"`
+		HardCapUSD *float64 `json:"hard_cap_usd"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.HardCapUSD != nil && *body.HardCapUSD < 0 {
+		httpError(w, "invalid hard_cap_usd", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.settings.UpdateBudgetEnforcement(r.Context(), userID, body.Enabled, body.HardCapUSD)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionBudgetEnforcement, "budget_enforcement", map[string]any{
+		"enabled":      body.Enabled,
+		"hard_cap_usd": body.HardCapUSD,
+	})
+	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
+		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
+	}
+	writeJSON(w, map[string]any{
+		"user_id":                    settings.UserID,
+		"budget_enforcement_enabled": settings.BudgetEnforcementEnabled,
+		"budget_hard_cap_usd":        settings.BudgetHardCapUSD,
+	})
+}
+
 func (h *SettingsHandler) UpdateUIFontSettings(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body service.UpdateUIFontSettingsInput
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	settings, err := h.settings.UpdateUIFontSettings(r.Context(), userID, body)
 	if err != nil {
 		var ve *service.ValidationError
 		if errors.As(err, &ve) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		writeRepoError(w, err)
@@ -871,24 +1586,24 @@ func (h *SettingsHandler) setAPIKey(w http.ResponseWriter, r *http.Request, prov
 	var body struct {
 		APIKey string `json:"api_key"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	key := strings.TrimSpace(body.APIKey)
 	if key == "" {
-		http.Error(w, "api_key is required", http.StatusBadRequest)
+		httpError(w, "api_key is required", http.StatusBadRequest)
 		return
 	}
 	settings, err := h.settings.SetAPIKey(r.Context(), userID, provider, key)
 	if err != nil {
 		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionAPIKeySet, provider, nil)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -899,13 +1614,80 @@ func (h *SettingsHandler) setAPIKey(w http.ResponseWriter, r *http.Request, prov
 	writeJSON(w, resp)
 }
 
+func (h *SettingsHandler) GetSMTPSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	view, err := h.smtp.Get(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, view)
+}
+
+func (h *SettingsHandler) SetSMTPSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body service.UserSMTPSettingsInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	view, err := h.smtp.Set(r.Context(), userID, body)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, ve.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
+			httpError(w, "smtp password encryption unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionSMTPSet, view.Host, map[string]any{"port": view.Port, "from_email": view.FromEmail})
+	writeJSON(w, view)
+}
+
+func (h *SettingsHandler) DeleteSMTPSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	if err := h.smtp.Clear(r.Context(), userID); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	recordAudit(h.auditLog, r, service.AuditActionSMTPDelete, "", nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyAPIKey checks a key without saving it; an invalid key is reported in the body, not as an error status.
+func (h *SettingsHandler) verifyAPIKey(w http.ResponseWriter, r *http.Request, provider string) {
+	var body struct {
+		APIKey string `json:"api_key"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	resp, err := h.keyVerifier.Verify(r.Context(), provider, body.APIKey)
+	if err != nil {
+		var ve *service.ValidationError
+		if errors.As(err, &ve) {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("api key verification failed provider=%s err=%v", provider, err)
+		httpError(w, "failed to verify api key", http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, resp)
+}
+
 func (h *SettingsHandler) deleteAPIKey(w http.ResponseWriter, r *http.Request, provider string, payload map[string]func(*model.UserSettings) any) {
 	userID := middleware.GetUserID(r)
 	settings, err := h.settings.DeleteAPIKey(r.Context(), userID, provider)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionAPIKeyDelete, provider, nil)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -922,34 +1704,34 @@ func (h *SettingsHandler) SetAzureSpeechConfig(w http.ResponseWriter, r *http.Re
 		APIKey string `json:"api_key"`
 		Region string `json:"region"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	apiKey := strings.TrimSpace(body.APIKey)
 	region := strings.TrimSpace(body.Region)
 	if apiKey == "" {
-		http.Error(w, "api_key is required", http.StatusBadRequest)
+		httpError(w, "api_key is required", http.StatusBadRequest)
 		return
 	}
 	if region == "" {
-		http.Error(w, "region is required", http.StatusBadRequest)
+		httpError(w, "region is required", http.StatusBadRequest)
 		return
 	}
 	settings, err := h.settings.SetAPIKey(r.Context(), userID, "azure_speech", apiKey)
 	if err != nil {
 		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	settings, err = h.settings.SetAzureSpeechRegion(r.Context(), userID, region)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionAPIKeySet, "azure_speech", map[string]any{"region": region})
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -965,14 +1747,15 @@ func (h *SettingsHandler) DeleteAzureSpeechConfig(w http.ResponseWriter, r *http
 	userID := middleware.GetUserID(r)
 	settings, err := h.settings.DeleteAPIKey(r.Context(), userID, "azure_speech")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	settings, err = h.settings.ClearAzureSpeechRegion(r.Context(), userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionAPIKeyDelete, "azure_speech", nil)
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
 		log.Printf("settings version bump failed user_id=%s err=%v", userID, err)
 	}
@@ -991,6 +1774,10 @@ func (h *SettingsHandler) SetAnthropicAPIKey(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+func (h *SettingsHandler) VerifyAnthropicAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.verifyAPIKey(w, r, "anthropic")
+}
+
 func (h *SettingsHandler) DeleteAnthropicAPIKey(w http.ResponseWriter, r *http.Request) {
 	h.deleteAPIKey(w, r, "anthropic", map[string]func(*model.UserSettings) any{
 		"has_anthropic_api_key":   func(s *model.UserSettings) any { return s.HasAnthropicAPIKey },
@@ -1005,6 +1792,10 @@ func (h *SettingsHandler) SetOpenAIAPIKey(w http.ResponseWriter, r *http.Request
 	})
 }
 
+func (h *SettingsHandler) VerifyOpenAIAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.verifyAPIKey(w, r, "openai")
+}
+
 func (h *SettingsHandler) DeleteOpenAIAPIKey(w http.ResponseWriter, r *http.Request) {
 	h.deleteAPIKey(w, r, "openai", map[string]func(*model.UserSettings) any{
 		"has_openai_api_key":   func(s *model.UserSettings) any { return s.HasOpenAIAPIKey },
@@ -1033,6 +1824,10 @@ func (h *SettingsHandler) SetGoogleAPIKey(w http.ResponseWriter, r *http.Request
 	})
 }
 
+func (h *SettingsHandler) VerifyGoogleAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.verifyAPIKey(w, r, "google")
+}
+
 func (h *SettingsHandler) DeleteGoogleAPIKey(w http.ResponseWriter, r *http.Request) {
 	h.deleteAPIKey(w, r, "google", map[string]func(*model.UserSettings) any{
 		"has_google_api_key":   func(s *model.UserSettings) any { return s.HasGoogleAPIKey },
@@ -1339,14 +2134,14 @@ func (h *SettingsHandler) GetAivisUserDictionaries(w http.ResponseWriter, r *htt
 	items, err := h.aivisDictionaries.List(r.Context(), userID)
 	if err != nil {
 		if errors.Is(err, service.ErrAivisAPIKeyNotConfigured) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 		if errors.Is(err, service.ErrSecretEncryptionNotConfigured) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
 	writeJSON(w, map[string]any{"user_dictionaries": items})
@@ -1357,17 +2152,16 @@ func (h *SettingsHandler) SetAivisUserDictionary(w http.ResponseWriter, r *http.
 	var body struct {
 		AivisUserDictionaryUUID string `json:"aivis_user_dictionary_uuid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
 	settings, err := h.settings.SetAivisUserDictionaryUUID(r.Context(), userID, body.AivisUserDictionaryUUID)
 	if err != nil {
 		if errors.Is(err, service.ErrAivisDictionaryUUIDRequired) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
@@ -1383,7 +2177,7 @@ func (h *SettingsHandler) DeleteAivisUserDictionary(w http.ResponseWriter, r *ht
 	userID := middleware.GetUserID(r)
 	settings, err := h.settings.ClearAivisUserDictionaryUUID(r.Context(), userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if err := h.bumpUserSettingsVersion(r.Context(), userID); err != nil {
@@ -1394,3 +2188,48 @@ func (h *SettingsHandler) DeleteAivisUserDictionary(w http.ResponseWriter, r *ht
 		"aivis_user_dictionary_uuid": settings.AivisUserDictionaryUUID,
 	})
 }
+
+// GetEmbeddingStatus reports how many summarized items were embedded with a model other than
+// the current embedding model.
+func (h *SettingsHandler) GetEmbeddingStatus(w http.ResponseWriter, r *http.Request) {
+	if h.embeddings == nil {
+		httpError(w, "embedding status unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := h.embeddings.Status(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
+
+// ReindexEmbeddings queues re-embedding of every item whose embedding is stale or missing.
+func (h *SettingsHandler) ReindexEmbeddings(w http.ResponseWriter, r *http.Request) {
+	if h.embeddings == nil {
+		httpError(w, "embedding reindex unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	result, err := h.embeddings.Reindex(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, result)
+}
+
+// GetUsageLimits reports the user's plan, its limits and today's usage.
+func (h *SettingsHandler) GetUsageLimits(w http.ResponseWriter, r *http.Request) {
+	if h.usageLimits == nil {
+		httpError(w, "usage limits unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := h.usageLimits.Status(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// Estimate projects a month of LLM usage and cost for the source from its latest feed entries.
+// Nothing is sent to the LLM.
+func (h *SourceHandler) Estimate(w http.ResponseWriter, r *http.Request) {
+	if h.estimates == nil {
+		httpError(w, "source estimate unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	estimate, err := h.estimates.Estimate(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, service.ErrSourceNotFeed):
+		httpError(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, service.ErrSourceFeedUnavailable):
+		httpError(w, err.Error(), http.StatusBadGateway)
+		return
+	case err != nil:
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, estimate)
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/model"
@@ -46,8 +47,8 @@ type opmlOutline struct {
 }
 
 type SourceHandler struct {
-	repo                   *repository.SourceRepo
-	itemRepo               *repository.ItemRepo
+	repo                   repository.SourceReadWriter
+	itemRepo               repository.ItemReadWriter
 	sourceOptimizationRepo *repository.SourceOptimizationRepo
 	settingsRepo           *repository.UserSettingsRepo
 	llmUsageRepo           *repository.LLMUsageLogRepo
@@ -57,11 +58,17 @@ type SourceHandler struct {
 	cache                  service.JSONCache
 	keyProvider            *service.UserKeyProvider
 	suggestionSvc          *service.SourceSuggestionService
+	feedly                 *service.FeedlyOAuthService
+	imports                *service.ImportJobService
+	duplicates             *service.SourceDuplicateChecker
+	usageLimits            *service.UsageLimitService
+	auditLog               *service.AuditLogService
+	estimates              *service.SourceEstimateService
 }
 
 func NewSourceHandler(
-	repo *repository.SourceRepo,
-	itemRepo *repository.ItemRepo,
+	repo repository.SourceReadWriter,
+	itemRepo repository.ItemReadWriter,
 	sourceOptimizationRepo *repository.SourceOptimizationRepo,
 	settingsRepo *repository.UserSettingsRepo,
 	llmUsageRepo *repository.LLMUsageLogRepo,
@@ -82,6 +89,7 @@ func NewSourceHandler(
 		publisher:              publisher,
 		cache:                  cache,
 		keyProvider:            keyProvider,
+		duplicates:             service.NewSourceDuplicateChecker(repo),
 	}
 	h.suggestionSvc = service.NewSourceSuggestionService(
 		repo, itemRepo, settingsRepo, llmUsageRepo, worker, cache, keyProvider,
@@ -89,10 +97,47 @@ func NewSourceHandler(
 	return h
 }
 
+func (h *SourceHandler) WithBudgetAllocations(repo *repository.LLMBudgetAllocationRepo) *SourceHandler {
+	h.suggestionSvc.SetBudgetAllocationRepo(repo)
+	return h
+}
+
+func (h *SourceHandler) WithFeedly(feedly *service.FeedlyOAuthService) *SourceHandler {
+	h.feedly = feedly
+	return h
+}
+
+func (h *SourceHandler) WithImportJobs(imports *service.ImportJobService) *SourceHandler {
+	h.imports = imports
+	return h
+}
+
+// WithAuditLog records OPML exports.
+func (h *SourceHandler) WithAuditLog(svc *service.AuditLogService) *SourceHandler {
+	h.auditLog = svc
+	return h
+}
+
+// WithUsageLimits refuses new sources past the user's plan source limit.
+func (h *SourceHandler) WithUsageLimits(limits *service.UsageLimitService) *SourceHandler {
+	h.usageLimits = limits
+	return h
+}
+
+func (h *SourceHandler) WithEstimates(svc *service.SourceEstimateService) *SourceHandler {
+	h.estimates = svc
+	return h
+}
+
+func (h *SourceHandler) WithCoSubscriptions(repo *repository.SourceCoSubscriptionRepo) *SourceHandler {
+	h.suggestionSvc.SetCoSubscriptionRepo(repo)
+	return h
+}
+
 func (h *SourceHandler) Optimization(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	if h.sourceOptimizationRepo == nil {
-		http.Error(w, "source optimization unavailable", http.StatusInternalServerError)
+		httpError(w, "source optimization unavailable", http.StatusInternalServerError)
 		return
 	}
 	sources, err := h.repo.List(r.Context(), userID)
@@ -343,9 +388,10 @@ func (h *SourceHandler) ExportOPML(w http.ResponseWriter, r *http.Request) {
 	}
 	payload, err := xml.MarshalIndent(doc, "", "  ")
 	if err != nil {
-		http.Error(w, "failed to export opml", http.StatusInternalServerError)
+		httpError(w, "failed to export opml", http.StatusInternalServerError)
 		return
 	}
+	recordAudit(h.auditLog, r, service.AuditActionExport, "opml", map[string]any{"sources": len(sources)})
 	filename := fmt.Sprintf("sifto-sources-%s.opml", time.Now().Format("20060102"))
 	w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
 	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
@@ -356,29 +402,32 @@ func (h *SourceHandler) ExportOPML(w http.ResponseWriter, r *http.Request) {
 func (h *SourceHandler) ImportOPML(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body struct {
-		OPML string `json:"opml"`
+		OPML  string `json:"opml"`
+		Force bool   `json:"force"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.OPML) == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeStrictJSONBody(w, r, &body) {
+		return
+	}
+	if strings.TrimSpace(body.OPML) == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	var doc opmlDocument
 	if err := xml.Unmarshal([]byte(body.OPML), &doc); err != nil {
-		http.Error(w, "invalid opml", http.StatusBadRequest)
+		httpError(w, "invalid opml", http.StatusBadRequest)
 		return
 	}
-	urlTitlePairs := flattenOPMLOutlines(doc.Body.Outlines)
-	writeJSON(w, importURLTitlePairs(r.Context(), h.repo, userID, urlTitlePairs))
+	h.startSourceImport(w, r, userID, service.SourceImportOPML, body.Force, flattenOPMLOutlines(doc.Body.Outlines))
 }
 
 func (h *SourceHandler) ImportInoreader(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	var body struct {
 		AccessToken string `json:"access_token"`
+		Force       bool   `json:"force"`
 	}
 	if r.ContentLength > 0 {
-		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			http.Error(w, "invalid request", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, &body) {
 			return
 		}
 	}
@@ -392,15 +441,68 @@ func (h *SourceHandler) ImportInoreader(w http.ResponseWriter, r *http.Request)
 		}
 	}
 	if token == "" {
-		http.Error(w, "inoreader access token is not configured", http.StatusBadRequest)
+		httpError(w, "inoreader access token is not configured", http.StatusBadRequest)
 		return
 	}
 	pairs, err := fetchInoreaderSubscriptions(r.Context(), token)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		httpError(w, err.Error(), http.StatusBadGateway)
 		return
 	}
-	writeJSON(w, importURLTitlePairs(r.Context(), h.repo, userID, pairs))
+	h.startSourceImport(w, r, userID, service.SourceImportInoreader, body.Force, pairs)
+}
+
+// ImportFeedly imports the user's Feedly subscriptions, filing each feed under its Feedly
+// folder as the source group. A developer access token in the body overrides the stored OAuth
+// token.
+func (h *SourceHandler) ImportFeedly(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Force       bool   `json:"force"`
+	}
+	if r.ContentLength > 0 {
+		if !decodeJSONBody(w, r, &body) {
+			return
+		}
+	}
+	token := strings.TrimSpace(body.AccessToken)
+	if token == "" && h.feedly != nil {
+		stored, err := h.feedly.AccessToken(r.Context(), userID)
+		if err != nil {
+			log.Printf("feedly access token load failed user_id=%s err=%v", userID, err)
+			httpError(w, "feedly access token could not be refreshed; reconnect Feedly", http.StatusBadGateway)
+			return
+		}
+		token = stored
+	}
+	if token == "" {
+		httpError(w, "feedly access token is not configured", http.StatusBadRequest)
+		return
+	}
+	pairs, err := fetchFeedlySubscriptions(r.Context(), token)
+	if err != nil {
+		httpError(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	h.startSourceImport(w, r, userID, service.SourceImportFeedly, body.Force, pairs)
+}
+
+// startSourceImport queues the feeds as an import job and answers 202 with the job; progress is
+// polled via GET /api/imports/{id}.
+func (h *SourceHandler) startSourceImport(w http.ResponseWriter, r *http.Request, userID, kind string, force bool, entries []service.SourceImportEntry) {
+	if h.imports == nil {
+		httpError(w, "imports are not available", http.StatusServiceUnavailable)
+		return
+	}
+	job, err := h.imports.StartSourceImport(r.Context(), userID, kind, force, entries)
+	if err != nil {
+		log.Printf("source import start failed kind=%s user_id=%s err=%v", kind, userID, err)
+		writeUsageLimitError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, job)
 }
 
 func (h *SourceHandler) Health(w http.ResponseWriter, r *http.Request) {
@@ -441,7 +543,7 @@ func (h *SourceHandler) Recommended(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 8)
 	if limit < 1 || limit > 30 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	out, llmMeta, err := h.suggestionSvc.BuildSourceRecommendations(r.Context(), userID, limit)
@@ -452,21 +554,8 @@ func (h *SourceHandler) Recommended(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, sourceRecommendResponse{Items: out, Limit: limit, LLM: llmMeta})
 }
 
-type opmlURLTitle struct {
-	URL   string
-	Title *string
-}
-
-type inoreaderSubscriptionListResponse struct {
-	Subscriptions []struct {
-		ID      string `json:"id"`
-		Title   string `json:"title"`
-		HTMLURL string `json:"htmlUrl"`
-	} `json:"subscriptions"`
-}
-
-func flattenOPMLOutlines(outlines []opmlOutline) []opmlURLTitle {
-	out := make([]opmlURLTitle, 0)
+func flattenOPMLOutlines(outlines []opmlOutline) []service.SourceImportEntry {
+	out := make([]service.SourceImportEntry, 0)
 	var walk func(rows []opmlOutline)
 	walk = func(rows []opmlOutline) {
 		for _, o := range rows {
@@ -479,7 +568,7 @@ func flattenOPMLOutlines(outlines []opmlOutline) []opmlURLTitle {
 					t := strings.TrimSpace(o.Text)
 					title = &t
 				}
-				out = append(out, opmlURLTitle{
+				out = append(out, service.SourceImportEntry{
 					URL:   strings.TrimSpace(o.XMLURL),
 					Title: title,
 				})
@@ -493,99 +582,61 @@ func flattenOPMLOutlines(outlines []opmlOutline) []opmlURLTitle {
 	return out
 }
 
-func importURLTitlePairs(ctx context.Context, repo *repository.SourceRepo, userID string, pairs []opmlURLTitle) importResultResponse {
-	added := 0
-	skipped := 0
-	invalid := 0
-	errorsOut := make([]string, 0)
-	for _, pair := range pairs {
-		u := strings.TrimSpace(pair.URL)
-		parsed, err := url.ParseRequestURI(u)
-		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
-			invalid++
-			continue
-		}
-		title := pair.Title
-		if title != nil {
-			v := strings.TrimSpace(*title)
-			if v == "" {
-				title = nil
-			} else {
-				title = &v
-			}
-		}
-		if _, err := repo.Create(ctx, userID, u, "rss", title); err != nil {
-			if errors.Is(err, repository.ErrConflict) {
-				skipped++
-				continue
-			}
-			errorsOut = append(errorsOut, err.Error())
-			if len(errorsOut) >= 10 {
-				break
-			}
-			continue
-		}
-		added++
+func fetchInoreaderSubscriptions(ctx context.Context, accessToken string) ([]service.SourceImportEntry, error) {
+	subs, err := service.FetchInoreaderSubscriptions(ctx, accessToken)
+	if err != nil {
+		return nil, err
 	}
-	return importResultResponse{
-		Status:      "ok",
-		Total:       len(pairs),
-		Added:       added,
-		Skipped:     skipped,
-		Invalid:     invalid,
-		ErrorCount:  len(errorsOut),
-		ErrorSample: errorsOut,
+	out := make([]service.SourceImportEntry, 0, len(subs))
+	for _, s := range subs {
+		var title *string
+		if s.Title != "" {
+			v := s.Title
+			title = &v
+		}
+		out = append(out, service.SourceImportEntry{URL: s.URL, Title: title})
 	}
+	return out, nil
+}
+
+type feedlySubscription struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Categories []struct {
+		ID    string `json:"id"`
+		Label string `json:"label"`
+	} `json:"categories"`
 }
 
-func fetchInoreaderSubscriptions(ctx context.Context, accessToken string) ([]opmlURLTitle, error) {
+func fetchFeedlySubscriptions(ctx context.Context, accessToken string) ([]service.SourceImportEntry, error) {
 	if strings.TrimSpace(accessToken) == "" {
 		return nil, errors.New("access token is required")
 	}
-	client := &http.Client{Timeout: 20 * time.Second}
-	endpoint := "https://www.inoreader.com/reader/api/0/subscription/list?output=json"
-	call := func(authHeader string) (*http.Response, error) {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", authHeader)
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("User-Agent", "Sifto/1.0")
-		return client.Do(req)
-	}
-
-	resp, err := call("GoogleLogin auth=" + accessToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, service.FeedlyAPIBaseURL+"/v3/subscriptions", nil)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		_ = resp.Body.Close()
-		resp, err = call("Bearer " + accessToken)
-		if err != nil {
-			return nil, err
-		}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Sifto/1.0")
+	resp, err := (&http.Client{Timeout: 20 * time.Second}).Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return nil, fmt.Errorf("inoreader api status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return nil, fmt.Errorf("feedly api status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
 	}
-	var decoded inoreaderSubscriptionListResponse
+	var decoded []feedlySubscription
 	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
-		return nil, fmt.Errorf("decode inoreader subscriptions: %w", err)
+		return nil, fmt.Errorf("decode feedly subscriptions: %w", err)
 	}
-	out := make([]opmlURLTitle, 0, len(decoded.Subscriptions))
+	out := make([]service.SourceImportEntry, 0, len(decoded))
 	seen := map[string]struct{}{}
-	for _, s := range decoded.Subscriptions {
-		raw := strings.TrimSpace(s.ID)
-		if strings.HasPrefix(raw, "feed/") {
-			raw = strings.TrimPrefix(raw, "feed/")
-		}
-		if unescaped, err := url.QueryUnescape(raw); err == nil && strings.TrimSpace(unescaped) != "" {
-			raw = unescaped
-		}
-		raw = strings.TrimSpace(raw)
+	for _, s := range decoded {
+		// Feedly feed IDs are "feed/<feed url>".
+		raw := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s.ID), "feed/"))
 		if raw == "" {
 			continue
 		}
@@ -593,11 +644,18 @@ func fetchInoreaderSubscriptions(ctx context.Context, accessToken string) ([]opm
 			continue
 		}
 		seen[raw] = struct{}{}
-		var title *string
+		pair := service.SourceImportEntry{URL: raw}
 		if v := strings.TrimSpace(s.Title); v != "" {
-			title = &v
+			pair.Title = &v
 		}
-		out = append(out, opmlURLTitle{URL: raw, Title: title})
+		// A feed can sit in several Feedly folders; the first one becomes its group.
+		for _, c := range s.Categories {
+			if v := strings.TrimSpace(c.Label); v != "" && !strings.HasSuffix(c.ID, "/category/global.uncategorized") {
+				pair.Group = &v
+				break
+			}
+		}
+		out = append(out, pair)
 	}
 	return out, nil
 }
@@ -608,29 +666,53 @@ func (h *SourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 		URL   string  `json:"url"`
 		Type  string  `json:"type"`
 		Title *string `json:"title"`
+		Force bool    `json:"force"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.URL == "" || body.Type == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.URL == "" || body.Type == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	body.URL = strings.TrimSpace(body.URL)
 	body.Type = strings.TrimSpace(body.Type)
 	if body.URL == "" || body.Type == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	switch strings.ToLower(body.Type) {
 	case "rss", "manual":
 		body.Type = strings.ToLower(body.Type)
 	default:
-		http.Error(w, "invalid source type", http.StatusBadRequest)
+		httpError(w, "invalid source type", http.StatusBadRequest)
 		return
 	}
 	parsed, err := url.ParseRequestURI(body.URL)
 	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
-		http.Error(w, "invalid url", http.StatusBadRequest)
+		httpError(w, "invalid url", http.StatusBadRequest)
 		return
 	}
+	// Near-duplicates (scheme, "www.", trailing slash, feedburner alias, redirect) are reported
+	// with the existing source; force creates the source anyway.
+	if !body.Force && h.duplicates != nil {
+		existing, err := h.duplicates.Find(r.Context(), userID, body.Type, body.URL)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		if existing != nil {
+			middleware.WriteError(w, http.StatusConflict, errCodeDuplicateSource, "a source with this URL already exists", duplicateSourceDetails{ExistingSource: existing})
+			return
+		}
+	}
+
+	if h.usageLimits != nil {
+		if err := h.usageLimits.CheckSourceCapacity(r.Context(), userID); err != nil {
+			writeUsageLimitError(w, err)
+			return
+		}
+	}
 
 	s, err := h.repo.Create(r.Context(), userID, body.URL, body.Type, body.Title)
 	if err != nil {
@@ -648,7 +730,7 @@ func (h *SourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if created {
-			h.publisher.SendItemCreatedWithReasonE(r.Context(), itemID, s.ID, body.URL, body.Title, "manual_source")
+			h.publisher.SendItemCreatedWithReasonE(r.Context(), itemID, s.ID, userID, body.URL, body.Title, "manual_source")
 		}
 	}
 
@@ -660,14 +742,17 @@ func (h *SourceHandler) Discover(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		URL string `json:"url"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.URL) == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if strings.TrimSpace(body.URL) == "" {
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 
 	feeds, err := service.DiscoverRSSFeeds(r.Context(), strings.TrimSpace(body.URL))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		httpError(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
@@ -679,7 +764,7 @@ func (h *SourceHandler) Suggest(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	limit := parseIntOrDefault(q.Get("limit"), 24)
 	if limit < 1 || limit > 60 {
-		http.Error(w, "invalid limit", http.StatusBadRequest)
+		httpError(w, "invalid limit", http.StatusBadRequest)
 		return
 	}
 	out, llmMeta, err := h.suggestionSvc.BuildSourceRecommendations(r.Context(), userID, limit)
@@ -694,13 +779,39 @@ func (h *SourceHandler) Update(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
 	var body struct {
-		Enabled *bool   `json:"enabled"`
-		Title   *string `json:"title"`
+		Enabled       *bool     `json:"enabled"`
+		Title         *string   `json:"title"`
+		GroupName     *string   `json:"group_name"`
+		DefaultTopics *[]string `json:"default_topics"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || (body.Enabled == nil && body.Title == nil) {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &body) {
 		return
 	}
+	if body.Enabled == nil && body.Title == nil && body.GroupName == nil && body.DefaultTopics == nil {
+		httpError(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if body.DefaultTopics != nil {
+		topics, err := normalizeSourceDefaultTopics(*body.DefaultTopics)
+		if err != nil {
+			httpError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := h.repo.SetDefaultTopics(r.Context(), id, userID, topics); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+	}
+	if body.GroupName != nil {
+		var group *string
+		if v := strings.TrimSpace(*body.GroupName); v != "" {
+			group = &v
+		}
+		if err := h.repo.SetGroupName(r.Context(), id, userID, group); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+	}
 	var title *string
 	updateTitle := body.Title != nil
 	if body.Title != nil {
@@ -720,6 +831,55 @@ func (h *SourceHandler) Update(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, s)
 }
 
+const (
+	maxSourceDefaultTopics   = 5
+	maxSourceDefaultTopicLen = 40
+)
+
+func normalizeSourceDefaultTopics(in []string) ([]string, error) {
+	out := make([]string, 0, len(in))
+	seen := map[string]bool{}
+	for _, t := range in {
+		t = strings.TrimSpace(t)
+		key := strings.ToLower(t)
+		if t == "" || seen[key] {
+			continue
+		}
+		if utf8.RuneCountInString(t) > maxSourceDefaultTopicLen {
+			return nil, fmt.Errorf("default topic is too long (max %d characters)", maxSourceDefaultTopicLen)
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	if len(out) > maxSourceDefaultTopics {
+		return nil, fmt.Errorf("too many default topics (max %d)", maxSourceDefaultTopics)
+	}
+	return out, nil
+}
+
+func (h *SourceHandler) Migrate(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	id := chi.URLParam(r, "id")
+	proposed, err := h.repo.GetProposedURL(r.Context(), id, userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if proposed == nil || strings.TrimSpace(*proposed) == "" {
+		httpError(w, "no proposed url", http.StatusConflict)
+		return
+	}
+	s, err := h.repo.MigrateURL(r.Context(), id, userID, strings.TrimSpace(*proposed))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if err := h.publisher.SendSearchSuggestionSourceUpsertE(r.Context(), s.ID); err != nil {
+		log.Printf("search suggestion source upsert enqueue failed source_id=%s err=%v", s.ID, err)
+	}
+	writeJSON(w, s)
+}
+
 func (h *SourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	id := chi.URLParam(r, "id")
@@ -1 +1,86 @@
 package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestNormalizeSourceDefaultTopics(t *testing.T) {
+	got, err := normalizeSourceDefaultTopics([]string{" security ", "Security", "", "malware"})
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if want := []string{"security", "malware"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("topics = %v, want %v", got, want)
+	}
+	if _, err := normalizeSourceDefaultTopics([]string{"a", "b", "c", "d", "e", "f"}); err == nil {
+		t.Fatal("expected error for too many topics")
+	}
+	if _, err := normalizeSourceDefaultTopics([]string{strings.Repeat("x", maxSourceDefaultTopicLen+1)}); err == nil {
+		t.Fatal("expected error for a too long topic")
+	}
+}
+
+func TestSourceHandlerUpdateSetsGroupAndTitle(t *testing.T) {
+	sources := repotest.NewSourceStore()
+	src := sources.Add(model.Source{UserID: "u1", URL: "https://example.com/feed", Type: "rss", Enabled: true})
+	h := NewSourceHandler(sources, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/sources/"+src.ID, "u2", `{"title":"Other"}`, map[string]string{"id": src.ID}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign source status = %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/sources/"+src.ID, "u1", `{"title":" Example ","group_name":"News","enabled":false}`, map[string]string{"id": src.ID}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	list, err := sources.List(t.Context(), "u1")
+	if err != nil || len(list) != 1 {
+		t.Fatalf("List() = %v, %v", list, err)
+	}
+	got := list[0]
+	if got.Title == nil || *got.Title != "Example" || got.GroupName == nil || *got.GroupName != "News" || got.Enabled {
+		t.Fatalf("source = %+v", got)
+	}
+}
+
+func TestSourceHandlerListAndDelete(t *testing.T) {
+	sources := repotest.NewSourceStore()
+	src := sources.Add(model.Source{UserID: "u1", URL: "https://example.com/feed", Type: "rss"})
+	sources.Add(model.Source{UserID: "u2", URL: "https://example.org/feed", Type: "rss"})
+	h := NewSourceHandler(sources, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.List(rec, userRequest(http.MethodGet, "/sources", "u1", "", nil))
+	var list []model.Source
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v body=%s", err, rec.Body.String())
+	}
+	if len(list) != 1 || list[0].ID != src.ID {
+		t.Fatalf("list = %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/sources/"+src.ID, "u2", "", map[string]string{"id": src.ID}))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign delete status = %d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/sources/"+src.ID, "u1", "", map[string]string{"id": src.ID}))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", rec.Code)
+	}
+	if list, _ := sources.List(t.Context(), "u1"); len(list) != 0 {
+		t.Fatalf("source survived delete: %+v", list)
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type StoriesHandler struct {
+	storyRepo *repository.StoryRepo
+	itemRepo  *repository.ItemRepo
+	publisher *service.EventPublisher
+}
+
+func NewStoriesHandler(storyRepo *repository.StoryRepo, itemRepo *repository.ItemRepo, publisher *service.EventPublisher) *StoriesHandler {
+	return &StoriesHandler{storyRepo: storyRepo, itemRepo: itemRepo, publisher: publisher}
+}
+
+func (h *StoriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntOrDefault(r.URL.Query().Get("limit"), 30)
+	if limit < 1 || limit > 100 {
+		httpError(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	stories, err := h.storyRepo.ListByUser(r.Context(), middleware.GetUserID(r), limit)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"stories": stories})
+}
+
+// Timeline orders the story's items by JST day with their stored "what changed" notes. Days
+// without an up-to-date note come back as pending and are queued for the story-notes job.
+func (h *StoriesHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	storyID := strings.TrimSpace(chi.URLParam(r, "id"))
+	ctx := r.Context()
+
+	story, err := h.storyRepo.GetForUser(ctx, userID, storyID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if story == nil {
+		httpError(w, "not found", http.StatusNotFound)
+		return
+	}
+	ids, err := h.storyRepo.ItemIDs(ctx, story.ID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	items, err := h.itemRepo.LoadByIDsPreservingOrder(ctx, userID, ids)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	notes, err := h.storyRepo.DayNotes(ctx, story.ID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+
+	days := service.GroupStoryDays(items)
+	if service.ApplyStoryDayNotes(days, notes) > 0 {
+		if err := h.publisher.SendStoryNotesRequestedE(ctx, userID, story.ID); err != nil {
+			log.Printf("story notes enqueue failed story_id=%s err=%v", story.ID, err)
+		}
+	}
+	writeJSON(w, model.StoryTimeline{Story: *story, Days: days})
+}
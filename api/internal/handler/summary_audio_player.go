@@ -24,13 +24,13 @@ func NewSummaryAudioPlayerHandler(service *service.SummaryAudioPlayerService) *S
 
 func (h *SummaryAudioPlayerHandler) Synthesize(w http.ResponseWriter, r *http.Request) {
 	if h == nil || h.service == nil {
-		http.Error(w, "summary audio unavailable", http.StatusInternalServerError)
+		httpError(w, "summary audio unavailable", http.StatusInternalServerError)
 		return
 	}
 	userID := middleware.GetUserID(r)
 	itemID := strings.TrimSpace(chi.URLParam(r, "id"))
 	if itemID == "" {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		httpError(w, "invalid request", http.StatusBadRequest)
 		return
 	}
 	ctx := service.SummaryAudioRequestContext(r.Context())
@@ -41,13 +41,13 @@ func (h *SummaryAudioPlayerHandler) Synthesize(w http.ResponseWriter, r *http.Re
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrNotFound):
-			http.Error(w, "not found", http.StatusNotFound)
+			httpError(w, "not found", http.StatusNotFound)
 		case errors.Is(err, service.ErrGeminiTTSNotAllowed):
-			http.Error(w, err.Error(), http.StatusForbidden)
+			httpError(w, err.Error(), http.StatusForbidden)
 		case errors.Is(err, service.ErrSummaryAudioMissingSummary), errors.Is(err, service.ErrSummaryAudioMissingVoice), errors.Is(err, service.ErrSummaryAudioMissingModel):
-			http.Error(w, err.Error(), http.StatusConflict)
+			httpError(w, err.Error(), http.StatusConflict)
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httpError(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
 	}
@@ -56,7 +56,7 @@ func (h *SummaryAudioPlayerHandler) Synthesize(w http.ResponseWriter, r *http.Re
 
 func writeSummaryAudioBinary(w http.ResponseWriter, resp *service.SummaryAudioSynthesis) {
 	if resp == nil || len(resp.AudioBytes) == 0 {
-		http.Error(w, "summary audio response missing audio", http.StatusInternalServerError)
+		httpError(w, "summary audio response missing audio", http.StatusInternalServerError)
 		return
 	}
 	contentType := strings.TrimSpace(resp.ContentType)
@@ -14,7 +14,7 @@ func (h *ItemHandler) TodayQueue(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r)
 	size := parseIntOrDefault(r.URL.Query().Get("size"), 6)
 	if size < 1 || size > 12 {
-		http.Error(w, "invalid size", http.StatusBadRequest)
+		httpError(w, "invalid size", http.StatusBadRequest)
 		return
 	}
 
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type TopicAdminHandler struct {
+	taxonomy *service.TopicTaxonomyService
+	auth     *service.PromptAdminAuthService
+	users    *repository.UserRepo
+}
+
+func NewTopicAdminHandler(taxonomy *service.TopicTaxonomyService, auth *service.PromptAdminAuthService, users *repository.UserRepo) *TopicAdminHandler {
+	return &TopicAdminHandler{taxonomy: taxonomy, auth: auth, users: users}
+}
+
+func (h *TopicAdminHandler) allowed(r *http.Request) bool {
+	_, ok := promptAdminCapabilities(r, h.auth, h.users)
+	return ok
+}
+
+func (h *TopicAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !h.allowed(r) {
+		httpError(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	topics, err := h.taxonomy.List(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"topics": topics})
+}
+
+// Normalize runs the same pass as the scheduled job: alias new labels, then rewrite summaries.
+func (h *TopicAdminHandler) Normalize(w http.ResponseWriter, r *http.Request) {
+	if !h.allowed(r) {
+		httpError(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	result, err := h.taxonomy.Normalize(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, result)
+}
+
+func (h *TopicAdminHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	if !h.allowed(r) {
+		httpError(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	var body struct {
+		SourceTopicID string `json:"source_topic_id"`
+		TargetTopicID string `json:"target_topic_id"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	source := strings.TrimSpace(body.SourceTopicID)
+	target := strings.TrimSpace(body.TargetTopicID)
+	if source == "" || target == "" || source == target {
+		httpError(w, "source_topic_id and target_topic_id must be different topics", http.StatusBadRequest)
+		return
+	}
+	rewritten, err := h.taxonomy.Merge(r.Context(), source, target)
+	if err != nil {
+		writeTopicAdminError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"topic_id": target, "rewritten_summaries": rewritten})
+}
+
+func (h *TopicAdminHandler) Split(w http.ResponseWriter, r *http.Request) {
+	if !h.allowed(r) {
+		httpError(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	var body struct {
+		Name    string   `json:"name"`
+		Aliases []string `json:"aliases"`
+	}
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		httpError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if len(body.Aliases) == 0 {
+		httpError(w, "aliases are required", http.StatusBadRequest)
+		return
+	}
+	topicID, rewritten, err := h.taxonomy.Split(r.Context(), chi.URLParam(r, "id"), body.Name, body.Aliases)
+	if err != nil {
+		writeTopicAdminError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"topic_id": topicID, "rewritten_summaries": rewritten})
+}
+
+func writeTopicAdminError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrInvalidState) {
+		httpError(w, "aliases must belong to the topic", http.StatusBadRequest)
+		return
+	}
+	writeRepoError(w, err)
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTopicAdminForbiddenWithoutAdmin(t *testing.T) {
+	h := &TopicAdminHandler{}
+	for name, fn := range map[string]http.HandlerFunc{
+		"list":      h.List,
+		"normalize": h.Normalize,
+		"merge":     h.Merge,
+		"split":     h.Split,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/topic-admin", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s status = %d, want 403", name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxTopicAlertTopicLength = 100
+	maxTopicAlertMinDelta    = 100
+)
+
+type topicAlertStore interface {
+	ListByUser(ctx context.Context, userID string) ([]model.TopicAlertSubscription, error)
+	ListLogs(ctx context.Context, userID string, limit int) ([]model.TopicAlertLog, error)
+	Get(ctx context.Context, userID, id string) (*model.TopicAlertSubscription, error)
+	Create(ctx context.Context, s model.TopicAlertSubscription) (*model.TopicAlertSubscription, error)
+	Update(ctx context.Context, s model.TopicAlertSubscription) (*model.TopicAlertSubscription, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
+type TopicAlertsHandler struct {
+	repo topicAlertStore
+}
+
+func NewTopicAlertsHandler(repo topicAlertStore) *TopicAlertsHandler {
+	return &TopicAlertsHandler{repo: repo}
+}
+
+type topicAlertInput struct {
+	Topic      *string  `json:"topic"`
+	MinDelta   *int     `json:"min_delta"`
+	Channels   []string `json:"channels"`
+	WebhookURL *string  `json:"webhook_url"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+func (h *TopicAlertsHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	subs, err := h.repo.ListByUser(r.Context(), userID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	logs, err := h.repo.ListLogs(r.Context(), userID, 20)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"subscriptions": subs, "recent_alerts": logs})
+}
+
+func (h *TopicAlertsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body topicAlertInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Topic == nil {
+		httpError(w, "topic is required", http.StatusBadRequest)
+		return
+	}
+	sub := model.TopicAlertSubscription{
+		UserID:   middleware.GetUserID(r),
+		MinDelta: service.DefaultTopicAlertMinDelta,
+		Channels: []string{service.TopicAlertChannelPush},
+		Enabled:  true,
+	}
+	if msg := applyTopicAlertInput(&sub, body); msg != "" {
+		httpError(w, msg, http.StatusBadRequest)
+		return
+	}
+	if msg := h.validateWebhook(r, sub); msg != "" {
+		httpError(w, msg, http.StatusBadRequest)
+		return
+	}
+	out, err := h.repo.Create(r.Context(), sub)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, out)
+}
+
+func (h *TopicAlertsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var body topicAlertInput
+	if !decodeJSONBody(w, r, &body) {
+		return
+	}
+	if body.Topic != nil {
+		httpError(w, "topic cannot be changed", http.StatusBadRequest)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	sub, err := h.repo.Get(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if sub == nil {
+		httpError(w, "not found", http.StatusNotFound)
+		return
+	}
+	if msg := applyTopicAlertInput(sub, body); msg != "" {
+		httpError(w, msg, http.StatusBadRequest)
+		return
+	}
+	if msg := h.validateWebhook(r, *sub); msg != "" {
+		httpError(w, msg, http.StatusBadRequest)
+		return
+	}
+	out, err := h.repo.Update(r.Context(), *sub)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, out)
+}
+
+func (h *TopicAlertsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.repo.Delete(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id")); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyTopicAlertInput merges the provided fields into sub and returns a validation
+// message, or "" when the result is valid.
+func applyTopicAlertInput(sub *model.TopicAlertSubscription, in topicAlertInput) string {
+	if in.Topic != nil {
+		topic := strings.TrimSpace(*in.Topic)
+		if topic == "" {
+			return "topic is required"
+		}
+		if utf8.RuneCountInString(topic) > maxTopicAlertTopicLength {
+			return "topic is too long"
+		}
+		sub.Topic = topic
+	}
+	if in.MinDelta != nil {
+		if *in.MinDelta < 1 || *in.MinDelta > maxTopicAlertMinDelta {
+			return "min_delta must be between 1 and 100"
+		}
+		sub.MinDelta = *in.MinDelta
+	}
+	if in.Channels != nil {
+		channels, err := service.NormalizeTopicAlertChannels(in.Channels)
+		if err != nil {
+			return err.Error()
+		}
+		sub.Channels = channels
+	}
+	if in.WebhookURL != nil {
+		if v := strings.TrimSpace(*in.WebhookURL); v != "" {
+			sub.WebhookURL = &v
+		} else {
+			sub.WebhookURL = nil
+		}
+	}
+	if in.Enabled != nil {
+		sub.Enabled = *in.Enabled
+	}
+	for _, ch := range sub.Channels {
+		if ch == service.TopicAlertChannelWebhook && sub.WebhookURL == nil {
+			return "webhook_url is required for the webhook channel"
+		}
+	}
+	return ""
+}
+
+func (h *TopicAlertsHandler) validateWebhook(r *http.Request, sub model.TopicAlertSubscription) string {
+	if sub.WebhookURL == nil {
+		return ""
+	}
+	if err := service.ValidatePublicHTTPURL(r.Context(), *sub.WebhookURL); err != nil {
+		return "invalid webhook_url: " + err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encoding/json"
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+func TestApplyTopicAlertInput(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	cases := []struct {
+		name string
+		in   topicAlertInput
+		want string
+	}{
+		{"blank topic", topicAlertInput{Topic: str("  ")}, "topic is required"},
+		{"delta too small", topicAlertInput{Topic: str("LLM"), MinDelta: num(0)}, "min_delta must be between 1 and 100"},
+		{"unknown channel", topicAlertInput{Topic: str("LLM"), Channels: []string{"sms"}}, `unsupported channel "sms"`},
+		{"webhook without url", topicAlertInput{Topic: str("LLM"), Channels: []string{"webhook"}}, "webhook_url is required for the webhook channel"},
+		{"valid", topicAlertInput{Topic: str(" LLM "), MinDelta: num(5), Channels: []string{"email"}}, ""},
+	}
+	for _, tc := range cases {
+		sub := model.TopicAlertSubscription{MinDelta: 3, Channels: []string{"push"}}
+		if got := applyTopicAlertInput(&sub, tc.in); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+		if tc.want == "" && (sub.Topic != "LLM" || sub.MinDelta != 5 || sub.Channels[0] != "email") {
+			t.Errorf("%s: sub = %+v", tc.name, sub)
+		}
+	}
+}
+
+func TestTopicAlertsHandlerCreateListAndConflict(t *testing.T) {
+	store := repotest.NewTopicAlertStore()
+	h := NewTopicAlertsHandler(store)
+
+	rec := httptest.NewRecorder()
+	h.Create(rec, userRequest(http.MethodPost, "/topic-alerts", "u1", `{"topic":" LLM ","min_delta":4}`, nil))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	h.Create(rec, userRequest(http.MethodPost, "/topic-alerts", "u1", `{"topic":"LLM"}`, nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("duplicate create status = %d, want 409", rec.Code)
+	}
+
+	store.AddLog("u1", model.TopicAlertLog{Topic: "LLM", Delta: 5})
+	rec = httptest.NewRecorder()
+	h.List(rec, userRequest(http.MethodGet, "/topic-alerts", "u1", "", nil))
+	var resp struct {
+		Subscriptions []model.TopicAlertSubscription `json:"subscriptions"`
+		RecentAlerts  []model.TopicAlertLog          `json:"recent_alerts"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Subscriptions) != 1 || resp.Subscriptions[0].Topic != "LLM" || resp.Subscriptions[0].MinDelta != 4 {
+		t.Fatalf("subscriptions = %+v", resp.Subscriptions)
+	}
+	if len(resp.RecentAlerts) != 1 {
+		t.Fatalf("recent alerts = %+v", resp.RecentAlerts)
+	}
+}
+
+func TestTopicAlertsHandlerScopesUpdateAndDeleteToUser(t *testing.T) {
+	store := repotest.NewTopicAlertStore()
+	sub, err := store.Create(context.Background(), model.TopicAlertSubscription{UserID: "u1", Topic: "Go", MinDelta: 3, Channels: []string{"push"}, Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewTopicAlertsHandler(store)
+	params := map[string]string{"id": sub.ID}
+
+	rec := httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/topic-alerts/"+sub.ID, "u2", `{"enabled":false}`, params))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign update status = %d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Update(rec, userRequest(http.MethodPatch, "/topic-alerts/"+sub.ID, "u1", `{"enabled":false}`, params))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if got, _ := store.Get(context.Background(), "u1", sub.ID); got == nil || got.Enabled {
+		t.Fatalf("subscription after update = %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/topic-alerts/"+sub.ID, "u2", "", params))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("foreign delete status = %d, want 404", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.Delete(rec, userRequest(http.MethodDelete, "/topic-alerts/"+sub.ID, "u1", "", params))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", rec.Code)
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// writeUsageLimitError answers 422 with the limit that was hit, or falls back to
+// writeRepoError for any other error.
+func writeUsageLimitError(w http.ResponseWriter, err error) {
+	var limitErr *service.UsageLimitError
+	if !errors.As(err, &limitErr) {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.WriteError(w, http.StatusUnprocessableEntity, errCodeUsageLimitExceeded, limitErr.Error(), usageLimitDetails{
+		Limit:   limitErr.Limit,
+		Plan:    limitErr.Plan,
+		Max:     limitErr.Max,
+		Current: limitErr.Current,
+	})
+}
+
+type InternalUsageLimitsHandler struct {
+	svc *service.UsageLimitService
+}
+
+func NewInternalUsageLimitsHandler(svc *service.UsageLimitService) *InternalUsageLimitsHandler {
+	return &InternalUsageLimitsHandler{svc: svc}
+}
+
+func (h *InternalUsageLimitsHandler) Status(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	status, err := h.svc.Status(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
+
+// SetPlan moves a user to another plan. Sources and items over the new limits are kept.
+func (h *InternalUsageLimitsHandler) SetPlan(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInternalAdmin(w, r) {
+		return
+	}
+	var body struct {
+		Plan string `json:"plan"`
+	}
+	if !decodeStrictJSONBody(w, r, &body) {
+		return
+	}
+	if !service.IsUserPlan(strings.TrimSpace(body.Plan)) {
+		httpError(w, "invalid plan", http.StatusBadRequest)
+		return
+	}
+	status, err := h.svc.SetPlan(r.Context(), chi.URLParam(r, "id"), strings.TrimSpace(body.Plan))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, status)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+func TestWriteUsageLimitError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeUsageLimitError(rec, fmt.Errorf("start import: %w", &service.UsageLimitError{Limit: service.UsageLimitSources, Plan: service.UserPlanFree, Max: 500, Current: 500}))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("code = %d", rec.Code)
+	}
+	var body struct {
+		Code    string            `json:"code"`
+		Details usageLimitDetails `json:"details"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Code != errCodeUsageLimitExceeded || body.Details.Limit != service.UsageLimitSources || body.Details.Max != 500 || body.Details.Current != 500 {
+		t.Fatalf("body = %+v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	writeUsageLimitError(rec, repository.ErrNotFound)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("fallback code = %d", rec.Code)
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/middleware"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *SettingsHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	if h.sessions == nil {
+		httpError(w, "sessions unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	sessions, err := h.sessions.List(r.Context(), middleware.GetUserID(r), middleware.GetSessionID(r))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"sessions": sessions})
+}
+
+// RevokeSession ends API access for a session or revokes the digest feed token. Revoking the
+// caller's own session signs it out of the API as well.
+func (h *SettingsHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	if h.sessions == nil {
+		httpError(w, "sessions unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	kind, err := h.sessions.Revoke(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if kind == service.UserSessionKindDigestFeed {
+		recordAudit(h.auditLog, r, service.AuditActionTokenRevoke, "digest_feed", nil)
+	} else {
+		recordAudit(h.auditLog, r, service.AuditActionSessionRevoke, chi.URLParam(r, "id"), nil)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,11 +5,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
 	"net/http"
 	"runtime/debug"
 
+	"github.com/enjoydarts/sifto/api/internal/middleware"
 	"github.com/enjoydarts/sifto/api/internal/repository"
 )
 
@@ -18,16 +18,34 @@ func writeJSON(w http.ResponseWriter, v any) {
 	json.NewEncoder(w).Encode(v)
 }
 
+// Error codes specific to handlers; the status defaults live in middleware.
+const (
+	errCodeUsageLimitExceeded = "usage_limit_exceeded"
+	errCodeDuplicateSource    = "duplicate_source"
+)
+
+// httpError is http.Error answering with the JSON error envelope, under the status's
+// default code.
+func httpError(w http.ResponseWriter, message string, status int) {
+	middleware.WriteError(w, status, "", message, nil)
+}
+
+// writeRepoError maps repository errors to their status. Anything unexpected is logged under
+// the request ID, which the 500 answer carries for support to look up.
 func writeRepoError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, repository.ErrNotFound):
-		http.Error(w, "not found", http.StatusNotFound)
+		middleware.WriteError(w, http.StatusNotFound, middleware.ErrCodeNotFound, "not found", nil)
 	case errors.Is(err, repository.ErrConflict):
-		http.Error(w, "conflict", http.StatusConflict)
+		middleware.WriteError(w, http.StatusConflict, middleware.ErrCodeConflict, "conflict", nil)
 	default:
-		errID := generateErrorID()
-		log.Printf("internal error [%s]: %v", errID, err)
-		http.Error(w, fmt.Sprintf("internal server error (ref: %s)", errID), http.StatusInternalServerError)
+		ref := w.Header().Get(middleware.RequestIDHeader)
+		if ref == "" {
+			ref = generateErrorID()
+			w.Header().Set(middleware.RequestIDHeader, ref)
+		}
+		log.Printf("internal error [%s]: %v", ref, err)
+		middleware.WriteError(w, http.StatusInternalServerError, middleware.ErrCodeInternal, "internal server error", nil)
 	}
 }
 
@@ -101,7 +101,7 @@ func (h *XAIVoicesHandler) Sync(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		} else {
-			http.Error(w, "xai voice sync already running", http.StatusConflict)
+			httpError(w, "xai voice sync already running", http.StatusConflict)
 			return
 		}
 	}
@@ -113,7 +113,7 @@ func (h *XAIVoicesHandler) Sync(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if apiKey == nil || strings.TrimSpace(*apiKey) == "" {
-		http.Error(w, "xai api key is not configured", http.StatusBadRequest)
+		httpError(w, "xai api key is not configured", http.StatusBadRequest)
 		return
 	}
 
@@ -135,7 +135,7 @@ func (h *XAIVoicesHandler) Sync(w http.ResponseWriter, r *http.Request) {
 		if h.providerUpdateRepo != nil {
 			_ = h.providerUpdateRepo.UpsertSnapshot(r.Context(), "xai", previousModelIDs, "failed", &msg)
 		}
-		http.Error(w, fetchErr.Error(), http.StatusBadGateway)
+		httpError(w, fetchErr.Error(), http.StatusBadGateway)
 		return
 	}
 
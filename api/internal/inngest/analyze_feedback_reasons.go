@@ -0,0 +1,45 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// analyzeFeedbackReasonsFn proposes topic filters and source mutes for users whose negative
+// feedback reasons keep pointing at the same topic or source.
+func analyzeFeedbackReasonsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	filterRepo := repository.NewFilterRuleRepo(db)
+	suggestions := service.NewFilterSuggestionService(filterRepo, repository.NewSourceRepo(db))
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "analyze-feedback-reasons", Name: "Analyze Feedback Reasons"},
+		inngestgo.CronTrigger("50 19 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			userIDs, err := filterRepo.ListUserIDsWithFeedbackReasons(ctx, time.Now().Add(-service.FilterSuggestionLookback))
+			if err != nil {
+				return nil, fmt.Errorf("list users with feedback reasons: %w", err)
+			}
+			proposed := 0
+			failed := 0
+			for _, uid := range userIDs {
+				n, err := suggestions.Analyze(ctx, uid)
+				if err != nil {
+					slog.Error("analyze-feedback-reasons: analyze failed", "user_id", uid, "error", err)
+					failed++
+					continue
+				}
+				proposed += n
+			}
+			slog.Info("analyze-feedback-reasons: done", "users", len(userIDs), "proposed", proposed, "failed", failed)
+			return map[string]any{"users": len(userIDs), "proposed": proposed, "failed": failed}, nil
+		},
+	)
+}
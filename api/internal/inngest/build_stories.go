@@ -0,0 +1,84 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	storyWindowDays     = 7
+	storyCandidateLimit = 400
+)
+
+// buildStoriesFn clusters each active user's recent items and persists clusters that span
+// several days as stories, so the same story keeps its identity across runs. New clusters join
+// an existing story through shared items or a close centroid.
+func buildStoriesFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	itemRepo := repository.NewItemRepo(db)
+	storyRepo := repository.NewStoryRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "build-stories", Name: "Build Stories"},
+		inngestgo.CronTrigger("15 */3 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			userIDs, err := listRecentlyActiveUserIDs(ctx, db)
+			if err != nil {
+				return nil, fmt.Errorf("list active users: %w", err)
+			}
+			since := timeutil.StartOfDayJST(timeutil.NowJST()).AddDate(0, 0, -storyWindowDays)
+
+			updated := 0
+			failed := 0
+			for _, uid := range userIDs {
+				n, err := buildStoriesForUser(ctx, itemRepo, storyRepo, uid, since)
+				if err != nil {
+					slog.Error("build-stories: user failed", "user_id", uid, "error", err)
+					failed++
+					continue
+				}
+				updated += n
+			}
+
+			slog.Info("build-stories: done", "users", len(userIDs), "stories", updated, "failed", failed)
+			return map[string]any{"users": len(userIDs), "stories": updated, "failed": failed}, nil
+		},
+	)
+}
+
+func buildStoriesForUser(ctx context.Context, itemRepo *repository.ItemRepo, storyRepo *repository.StoryRepo, userID string, since time.Time) (int, error) {
+	items, err := storyRepo.Candidates(ctx, userID, since, storyCandidateLimit)
+	if err != nil {
+		return 0, fmt.Errorf("load candidates: %w", err)
+	}
+	if len(items) < 2 {
+		return 0, nil
+	}
+	clusters, err := itemRepo.ClusterItemsByEmbeddings(ctx, items)
+	if err != nil {
+		return 0, fmt.Errorf("cluster items: %w", err)
+	}
+	if _, err := storyRepo.RefreshMissingCentroids(ctx, userID); err != nil {
+		return 0, fmt.Errorf("refresh story centroids: %w", err)
+	}
+	storyByCluster, err := storyRepo.MatchClusters(ctx, userID, clusters)
+	if err != nil {
+		return 0, fmt.Errorf("match stories: %w", err)
+	}
+	n := 0
+	for _, a := range service.PlanStoryAssignments(clusters, storyByCluster) {
+		if _, err := storyRepo.Upsert(ctx, userID, a.StoryID, a.Label, a.ItemIDs); err != nil {
+			return n, fmt.Errorf("upsert story: %w", err)
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,40 @@
+package inngest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func captureItemSnapshotFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient) (inngestgo.ServableFunction, error) {
+	svc := service.NewItemSnapshotService(repository.NewItemHTMLSnapshotRepo(db), worker)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "capture-item-snapshot", Name: "Capture Item HTML Snapshot"},
+		inngestgo.EventTrigger("item/snapshot.capture", nil),
+		func(ctx context.Context, input inngestgo.Input[itemSearchUpsertEvent]) (any, error) {
+			itemID := input.Event.Data.ItemID
+			if itemID == "" {
+				return nil, fmt.Errorf("item_id is required")
+			}
+			captured, err := svc.Capture(ctx, itemID)
+			if errors.Is(err, service.ErrItemSnapshotStorageNotConfigured) {
+				return map[string]any{"item_id": itemID, "status": "storage_not_configured"}, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("capture item snapshot: %w", err)
+			}
+			status := "skipped"
+			if captured {
+				status = "captured"
+			}
+			return map[string]any{"item_id": itemID, "status": status}, nil
+		},
+	)
+}
@@ -32,6 +32,26 @@ func executeLLMCheck[T any](ctx context.Context, deps processItemDeps, cfg llmCh
 	if cfg.attempt > 0 {
 		stepName = fmt.Sprintf("%s-%d", cfg.baseStepName, cfg.attempt+1)
 	}
+	uid := ptrStringValue(cfg.userID)
+	checkModel := cfg.modelOverride
+	if chooseModelOverride(checkModel, nil) == nil && cfg.defaultRuntime != nil {
+		checkModel = cfg.defaultRuntime.Model
+	}
+	awaitCooldown := func(provider, stepID string) {
+		if uid != "" && deps.cooldown != nil {
+			awaitProviderCooldown(ctx, deps.cooldown, uid, provider, stepID)
+		}
+	}
+	// call records a provider 429 as the user's cooldown, so the same-model retry and later
+	// steps wait out the window instead of hitting it again.
+	call := func(ctx context.Context, runtime *llmRuntime) (*T, error) {
+		resp, err := cfg.call(runtime)
+		if p, retryAfter, ok := service.ProviderRateLimit(err); ok && uid != "" && deps.cooldown != nil {
+			deps.cooldown.Record(ctx, uid, p, retryAfter)
+		}
+		return resp, err
+	}
+	awaitCooldown(service.LLMProviderForModel(checkModel), stepName)
 
 	result, err := step.Run(ctx, stepName, func(ctx context.Context) (*T, error) {
 		runtime := cfg.defaultRuntime
@@ -42,7 +62,7 @@ func executeLLMCheck[T any](ctx context.Context, deps processItemDeps, cfg llmCh
 			}
 			runtime = resolved
 		}
-		resp, callErr := cfg.call(runtime)
+		resp, callErr := call(ctx, runtime)
 		if callErr != nil {
 			return nil, callErr
 		}
@@ -60,6 +80,7 @@ func executeLLMCheck[T any](ctx context.Context, deps processItemDeps, cfg llmCh
 		recordLLMExecutionFailure(ctx, deps.llmExecutionRepo, cfg.purpose, failedModel, cfg.attempt, cfg.userID, cfg.sourceID, cfg.itemID, nil, nil, err)
 		if shouldRetrySameModelLLMAttempt(err, false) {
 			retryStepName := stepName + "-retry"
+			awaitCooldown(service.LLMProviderForModel(failedModel), retryStepName)
 			log.Printf("process-item %s retry-same-model item_id=%s attempt=%d model=%s err=%v", cfg.purpose, ptrStringValue(cfg.itemID), cfg.attempt+1, ptrStringValue(failedModel), err)
 			retryResult, retryErr := step.Run(ctx, retryStepName, func(ctx context.Context) (*T, error) {
 				runtime := cfg.defaultRuntime
@@ -70,7 +91,7 @@ func executeLLMCheck[T any](ctx context.Context, deps processItemDeps, cfg llmCh
 					}
 					runtime = resolved
 				}
-				resp, callErr := cfg.call(runtime)
+				resp, callErr := call(ctx, runtime)
 				if callErr != nil {
 					return nil, callErr
 				}
@@ -89,13 +110,14 @@ func executeLLMCheck[T any](ctx context.Context, deps processItemDeps, cfg llmCh
 		}
 		if canUseLLMFallbackAfterRetry(failedModel, cfg.fallbackModel, err) {
 			fallbackStepName := stepName + "-fallback"
+			awaitCooldown(service.LLMProviderForModel(cfg.fallbackModel), fallbackStepName)
 			log.Printf("process-item %s fallback item_id=%s attempt=%d primary_model=%s fallback_model=%s err=%v", cfg.purpose, ptrStringValue(cfg.itemID), cfg.attempt+1, ptrStringValue(failedModel), ptrStringValue(cfg.fallbackModel), err)
 			fallbackResult, fallbackErr := step.Run(ctx, fallbackStepName, func(ctx context.Context) (*T, error) {
 				runtime, resolveErr := resolveLLMRuntime(ctx, deps.keyProvider, cfg.userID, cfg.fallbackModel, cfg.resolvePurpose)
 				if resolveErr != nil {
 					return nil, resolveErr
 				}
-				resp, callErr := cfg.call(runtime)
+				resp, callErr := call(ctx, runtime)
 				if callErr != nil {
 					return nil, callErr
 				}
@@ -0,0 +1,162 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/inngest/inngestgo"
+	"github.com/inngest/inngestgo/step"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const maxCollectionSummaryItems = 30
+
+// composeCollectionSummariesFn writes a weekly recap for every collection that opted in.
+// It runs Monday 07:00 JST and reuses the digest compose endpoint on the worker.
+func composeCollectionSummariesFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, keyProvider *service.UserKeyProvider) (inngestgo.ServableFunction, error) {
+	collectionRepo := repository.NewCollectionRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
+	llmUsageRepo := repository.NewLLMUsageLogRepo(db)
+	llmExecutionRepo := repository.NewLLMExecutionEventRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "compose-collection-summaries", Name: "Compose Weekly Collection Summaries"},
+		inngestgo.CronTrigger("0 22 * * 0"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			collections, err := collectionRepo.ListWeeklySummaryTargets(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("list collection summary targets: %w", err)
+			}
+			weekStart := collectionSummaryWeekStart(timeutil.NowJST())
+			composed, skipped, failed := 0, 0, 0
+			for _, c := range collections {
+				c := c
+				status, err := step.Run(ctx, "compose-collection-"+c.ID, func(ctx context.Context) (string, error) {
+					return composeCollectionSummary(ctx, collectionRepo, userSettingsRepo, llmUsageRepo, llmExecutionRepo, worker, keyProvider, c, weekStart)
+				})
+				switch {
+				case err != nil:
+					failed++
+					log.Printf("compose-collection-summaries collection_id=%s user_id=%s err=%v", c.ID, c.UserID, err)
+				case status == "composed":
+					composed++
+				default:
+					skipped++
+				}
+			}
+			return map[string]any{
+				"week_start": weekStart.Format("2006-01-02"),
+				"composed":   composed,
+				"skipped":    skipped,
+				"failed":     failed,
+			}, nil
+		},
+	)
+}
+
+func composeCollectionSummary(
+	ctx context.Context,
+	collectionRepo *repository.CollectionRepo,
+	userSettingsRepo *repository.UserSettingsRepo,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	worker *service.WorkerClient,
+	keyProvider *service.UserKeyProvider,
+	c model.Collection,
+	weekStart time.Time,
+) (string, error) {
+	exists, err := collectionRepo.HasSummary(ctx, c.ID, weekStart)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "already_composed", nil
+	}
+	items, err := collectionRepo.ListItems(ctx, c.UserID, c.ID)
+	if err != nil {
+		return "", err
+	}
+	composeItems := buildCollectionComposeItems(items, maxCollectionSummaryItems)
+	if len(composeItems) == 0 {
+		return "no_summarized_items", nil
+	}
+
+	userModelSettings, _ := userSettingsRepo.GetByUserID(ctx, c.UserID)
+	var modelOverride *string
+	locale := service.DefaultLocale
+	var targetLanguage *string
+	if userModelSettings != nil {
+		modelOverride = ptrStringOrNil(userModelSettings.DigestModel)
+		locale = service.NormalizeLocale(userModelSettings.Locale)
+		targetLanguage = service.DigestTargetLanguage(userModelSettings.OutputLanguage, locale)
+	}
+	runtime, err := resolveLLMRuntime(ctx, keyProvider, &c.UserID, modelOverride, "digest")
+	if err != nil {
+		return "", err
+	}
+	profile := service.DigestComposeProfileFromSettings(userModelSettings)
+	workerCtx := service.WithWorkerTraceMetadata(ctx, "collection_summary", &c.UserID, nil, nil, nil)
+	resp, err := worker.ComposeDigestWithModel(workerCtx, weekStart.Format("2006-01-02"), composeItems, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, nil, targetLanguage, locale, profile.Verbosity, profile.Tone)
+	if err != nil {
+		recordLLMExecutionFailure(ctx, llmExecutionRepo, "collection_summary", runtime.Model, 0, &c.UserID, nil, nil, nil, nil, err)
+		return "", err
+	}
+	recordLLMUsage(ctx, llmUsageRepo, "collection_summary", resp.LLM, &c.UserID, nil, nil, nil, nil)
+	if err := validateDigestCompletion(resp.Subject, resp.Body); err != nil {
+		recordLLMExecutionFailure(ctx, llmExecutionRepo, "collection_summary", runtime.Model, 0, &c.UserID, nil, nil, nil, nil, err)
+		return "", err
+	}
+	recordLLMExecutionSuccess(ctx, llmExecutionRepo, "collection_summary", resp.LLM, 0, &c.UserID, nil, nil, nil, nil)
+
+	if err := collectionRepo.UpsertSummary(ctx, model.CollectionSummary{
+		CollectionID: c.ID,
+		WeekStart:    weekStart.Format("2006-01-02"),
+		Subject:      fmt.Sprintf("%s: %s", c.Name, resp.Subject),
+		Body:         resp.Body,
+		ItemCount:    len(composeItems),
+		Model:        runtime.Model,
+	}); err != nil {
+		return "", err
+	}
+	return "composed", nil
+}
+
+// collectionSummaryWeekStart returns Monday 00:00 JST of the week containing now.
+func collectionSummaryWeekStart(now time.Time) time.Time {
+	day := timeutil.StartOfDayJST(now)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// buildCollectionComposeItems keeps the collection order and skips items that have no summary yet.
+func buildCollectionComposeItems(items []model.CollectionItem, limit int) []service.ComposeDigestItem {
+	out := make([]service.ComposeDigestItem, 0, len(items))
+	for _, it := range items {
+		if len(out) >= limit {
+			break
+		}
+		if it.Summary == nil || *it.Summary == "" {
+			continue
+		}
+		title := it.Title
+		if it.TranslatedTitle != nil && *it.TranslatedTitle != "" {
+			title = it.TranslatedTitle
+		}
+		out = append(out, service.ComposeDigestItem{
+			Rank:    len(out) + 1,
+			Title:   title,
+			URL:     it.URL,
+			Summary: *it.Summary,
+			Topics:  it.Topics,
+			Score:   it.SummaryScore,
+		})
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package inngest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestCollectionSummaryWeekStart(t *testing.T) {
+	// Sunday 23:30 UTC is Monday 08:30 JST.
+	now := time.Date(2026, 4, 5, 23, 30, 0, 0, time.UTC)
+	got := collectionSummaryWeekStart(now)
+	want := time.Date(2026, 4, 6, 0, 0, 0, 0, timeutil.JST)
+	if !got.Equal(want) {
+		t.Fatalf("collectionSummaryWeekStart() = %v, want %v", got, want)
+	}
+	if got := collectionSummaryWeekStart(time.Date(2026, 4, 12, 10, 0, 0, 0, timeutil.JST)); !got.Equal(want) {
+		t.Fatalf("Sunday JST should belong to the week starting %v, got %v", want, got)
+	}
+}
+
+func TestBuildCollectionComposeItems(t *testing.T) {
+	summary := "summary"
+	title := "Original"
+	translated := "翻訳タイトル"
+	items := []model.CollectionItem{
+		{ItemID: "a", URL: "https://a.example", Title: &title, TranslatedTitle: &translated, Summary: &summary},
+		{ItemID: "b", URL: "https://b.example", Title: &title},
+		{ItemID: "c", URL: "https://c.example", Title: &title, Summary: &summary},
+		{ItemID: "d", URL: "https://d.example", Title: &title, Summary: &summary},
+	}
+
+	got := buildCollectionComposeItems(items, 2)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if got[0].URL != "https://a.example" || *got[0].Title != translated || got[0].Rank != 1 {
+		t.Fatalf("first item = %+v", got[0])
+	}
+	if got[1].URL != "https://c.example" || got[1].Rank != 2 {
+		t.Fatalf("unsummarized items should be skipped, got %+v", got[1])
+	}
+}
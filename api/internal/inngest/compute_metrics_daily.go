@@ -0,0 +1,43 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	metricsDailyRebuildDays  = 2
+	metricsDailyBackfillDays = 90
+)
+
+// computeMetricsDailyFn snapshots per-user daily metrics after the JST day closes. Yesterday is
+// recomputed too so late writes (e.g. usage logged just before midnight) are captured.
+func computeMetricsDailyFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	metricsRepo := repository.NewMetricsDailyRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "compute-metrics-daily", Name: "Compute Metrics Daily"},
+		inngestgo.CronTrigger("30 15 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			days := metricsDailyRebuildDays
+			empty, err := metricsRepo.IsEmpty(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("check metrics daily: %w", err)
+			}
+			if empty {
+				days = metricsDailyBackfillDays
+			}
+			if err := metricsRepo.Rebuild(ctx, days); err != nil {
+				return nil, fmt.Errorf("rebuild metrics daily: %w", err)
+			}
+			slog.Info("compute-metrics-daily: rebuilt daily metrics", "days", days)
+			return map[string]any{"rebuilt_days": days}, nil
+		},
+	)
+}
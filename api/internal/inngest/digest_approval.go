@@ -0,0 +1,127 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/inngest/inngestgo/step"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const autoApproveDigestsLimit = 200
+
+// notifyDigestApprovalFn tells the user that a composed digest is held for review, by push and
+// email, with a link to the digest preview where it can be edited and approved.
+func notifyDigestApprovalFn(client inngestgo.Client, db *pgxpool.Pool, emails *service.EmailSenderResolver, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
+	digestRepo := repository.NewDigestInngestRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "notify-digest-approval", Name: "Notify Digest Awaiting Approval"},
+		inngestgo.EventTrigger("digest/approval-requested", nil),
+		func(ctx context.Context, input inngestgo.Input[DigestCopyComposedData]) (any, error) {
+			data := input.Event.Data
+			ctx = withRunRequestID(ctx, data.RequestID, input.InputCtx.RunID)
+			digest, err := digestRepo.GetForEmail(ctx, data.DigestID)
+			if err != nil {
+				return nil, fmt.Errorf("fetch digest: %w", err)
+			}
+			if digest.SendStatus == nil || *digest.SendStatus != service.DigestSendStatusAwaitingApproval {
+				return map[string]string{"status": "skipped", "reason": "not_awaiting_approval"}, nil
+			}
+			previewURL := appPageURL("/digests/" + data.DigestID)
+			subject := ""
+			if digest.EmailSubject != nil {
+				subject = *digest.EmailSubject
+			}
+
+			if oneSignal != nil && oneSignal.Enabled() {
+				if _, err := step.Run(ctx, "send-push", func(ctx context.Context) (string, error) {
+					_, err := oneSignal.SendToExternalID(
+						ctx,
+						data.To,
+						"Sifto: ダイジェストが承認待ちです",
+						fmt.Sprintf("%s のダイジェストを確認して送信を承認してください。", digest.DigestDate),
+						previewURL,
+						map[string]any{
+							"type":       "digest_awaiting_approval",
+							"digest_id":  data.DigestID,
+							"digest_url": previewURL,
+						},
+					)
+					return "sent", err
+				}); err != nil {
+					log.Printf("notify-digest-approval push failed digest_id=%s: %v", data.DigestID, err)
+				}
+			}
+
+			_, err = step.Run(ctx, "send-email", func(ctx context.Context) (string, error) {
+				settings, err := userSettingsRepo.GetByUserID(ctx, data.UserID)
+				if err != nil {
+					return "", fmt.Errorf("load settings: %w", err)
+				}
+				var autoApproveAt *time.Time
+				if settings.DigestAutoApproveMinutes > 0 {
+					at := time.Now().Add(time.Duration(settings.DigestAutoApproveMinutes) * time.Minute)
+					autoApproveAt = &at
+				}
+				return "sent", service.SendDigestApprovalEmail(ctx, emails.ForUser(ctx, data.UserID), data.To, service.DigestApprovalEmail{
+					Locale:        settings.Locale,
+					DigestDate:    digest.DigestDate,
+					Subject:       subject,
+					PreviewURL:    previewURL,
+					AutoApproveAt: autoApproveAt,
+				})
+			})
+			if err != nil {
+				return nil, fmt.Errorf("send approval email: %w", err)
+			}
+			return map[string]string{"status": "notified", "digest_id": data.DigestID}, nil
+		},
+	)
+}
+
+// autoApproveDigestsFn sends digests whose owner did not approve them within their
+// auto-approve timeout.
+func autoApproveDigestsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	digestRepo := repository.NewDigestInngestRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "auto-approve-digests", Name: "Auto Approve Digests"},
+		inngestgo.CronTrigger("*/10 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			targets, err := digestRepo.ClaimAutoApprovable(ctx, autoApproveDigestsLimit)
+			if err != nil {
+				return nil, fmt.Errorf("claim auto-approvable digests: %w", err)
+			}
+			approved := 0
+			for _, d := range targets {
+				if _, err := client.Send(ctx, inngestgo.Event{
+					Name: "digest/copy-composed",
+					Data: map[string]any{
+						"digest_id": d.DigestID,
+						"user_id":   d.UserID,
+						"to":        d.Email,
+					},
+				}); err != nil {
+					msg := err.Error()
+					if uErr := digestRepo.UpdateSendStatus(ctx, d.DigestID, "enqueue_send_failed", &msg); uErr != nil {
+						log.Printf("auto-approve-digests update-status failed digest_id=%s err=%v", d.DigestID, uErr)
+					}
+					log.Printf("auto-approve-digests send digest/copy-composed digest_id=%s: %v", d.DigestID, err)
+					continue
+				}
+				approved++
+			}
+			log.Printf("auto-approve-digests complete approved=%d", approved)
+			return map[string]int{"approved": approved}, nil
+		},
+	)
+}
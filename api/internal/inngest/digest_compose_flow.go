@@ -2,13 +2,16 @@ package inngest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
 	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo/step"
 )
 
 func composeDigestEmailCopy(
@@ -23,10 +26,165 @@ func composeDigestEmailCopy(
 	digest *model.DigestDetail,
 	userModelSettings *model.UserSettings,
 ) error {
-	const maxDigestClusterDraftRetries = 2
 	const maxDigestRetries = 2
 
 	log.Printf("compose-digest-copy step-exec digest_id=%s", data.DigestID)
+	progress := &digestComposeProgress{repo: digestRepo, digestID: data.DigestID}
+	// Drafts, sections and the final call are separate steps, so a retry after a failed stitch
+	// reuses the drafts and sections already paid for.
+	totalClusterDraftRetryCount, err := step.Run(ctx, "compose-digest-drafts", func(ctx context.Context) (int, error) {
+		progress.start(ctx, service.DigestComposeStageClustering)
+		var storedDrafts []model.DigestClusterDraft
+		var err error
+		if data.ReuseClusterDrafts {
+			storedDrafts, err = digestRepo.ListClusterDrafts(ctx, data.DigestID)
+			if err != nil {
+				return 0, fmt.Errorf("load digest cluster drafts: %w", err)
+			}
+			log.Printf("compose-digest-copy reuse-cluster-drafts digest_id=%s cluster_drafts=%d", data.DigestID, len(storedDrafts))
+		}
+		retries := 0
+		if len(storedDrafts) == 0 {
+			retries, err = generateDigestClusterDrafts(ctx, digestRepo, itemRepo, llmUsageRepo, llmExecutionRepo, workerDeps, data, digest, userModelSettings, progress)
+			if err != nil {
+				return 0, err
+			}
+			storedDrafts, err = digestRepo.ListClusterDrafts(ctx, data.DigestID)
+			if err != nil {
+				return 0, fmt.Errorf("reload digest cluster drafts: %w", err)
+			}
+		}
+		if sections := splitDigestComposeSections(storedDrafts, digestComposeSectionMaxRunes()); len(sections) > 1 {
+			progress.stage(ctx, service.DigestComposeStageSections, len(sections))
+		}
+		return retries, nil
+	})
+	if err != nil {
+		return err
+	}
+	storedDrafts, err := digestRepo.ListClusterDrafts(ctx, data.DigestID)
+	if err != nil {
+		return fmt.Errorf("load digest cluster drafts: %w", err)
+	}
+	composeProfile := digestComposeProfileFor(digest, userModelSettings)
+	items := buildComposeItemsFromClusterDrafts(storedDrafts, len(storedDrafts))
+	log.Printf("compose-digest-copy compacted digest_id=%s source_items=%d cluster_drafts=%d compose_items=%d", data.DigestID, len(digest.Items), len(storedDrafts), len(items))
+
+	var modelOverride *string
+	var digestTargetLanguage *string
+	digestLocale := service.DefaultLocale
+	if userModelSettings != nil {
+		modelOverride = ptrStringOrNil(userModelSettings.DigestModel)
+		digestLocale = service.NormalizeLocale(userModelSettings.Locale)
+		digestTargetLanguage = service.DigestTargetLanguage(userModelSettings.OutputLanguage, digestLocale)
+	}
+	if override := ptrStringOrNil(data.ModelOverride); override != nil {
+		modelOverride = override
+	}
+	digestRuntime, keyErr := resolveLLMRuntime(ctx, workerDeps.keyProvider, &data.UserID, modelOverride, "digest")
+	if keyErr != nil {
+		return keyErr
+	}
+	digestPromptResolution := service.ResolvePromptResolution(ctx, workerDeps.promptResolver, service.PromptResolveInput{
+		PromptKey:      "digest.default",
+		AssignmentUnit: "digest_id",
+		AssignmentKey:  data.DigestID,
+	})
+	composeCall := digestComposeCall{
+		digestDate:       digest.DigestDate,
+		runtime:          digestRuntime,
+		prompt:           service.WorkerPromptConfigFromResolution(digestPromptResolution),
+		promptResolution: digestPromptResolution,
+		targetLanguage:   digestTargetLanguage,
+		locale:           digestLocale,
+		profile:          composeProfile,
+	}
+	var sectionBodies []string
+	if sections := splitDigestComposeSections(storedDrafts, digestComposeSectionMaxRunes()); len(sections) > 1 {
+		sectionBodies = composeDigestSections(ctx, workerDeps, llmUsageRepo, llmExecutionRepo, data, composeCall, sections, progress)
+		items = buildDigestStitchItems(sections, sectionBodies)
+		log.Printf("compose-digest-copy sectioned digest_id=%s sections=%d", data.DigestID, len(sections))
+	}
+
+	_, err = step.Run(ctx, "compose-digest-final", func(ctx context.Context) (string, error) {
+		progress.stage(ctx, service.DigestComposeStageFinal, 0)
+		subject, body, digestRetryCount, err := stitchDigestCopy(ctx, llmUsageRepo, llmExecutionRepo, workerDeps, data, composeCall, items, maxDigestRetries)
+		if err != nil {
+			if sectionBodies == nil {
+				return "", err
+			}
+			// The sections are already composed prose, so they go out as they are rather than
+			// losing the whole digest to the last call.
+			log.Printf("compose-digest-copy stitch fallback digest_id=%s sections=%d err=%v", data.DigestID, len(sectionBodies), err)
+			subject, body, digestRetryCount = "", joinDigestSectionBodies(sectionBodies), maxDigestRetries
+		}
+		subject = service.FormatDigestEmailSubjectForDigest(digestLocale, digest, subject)
+		if err := digestRepo.UpdateComposeRetryCounts(ctx, data.DigestID, digestRetryCount, totalClusterDraftRetryCount); err != nil {
+			return "", fmt.Errorf("update digest retry counts: %w", err)
+		}
+		log.Printf("compose-digest-copy worker-done digest_id=%s subject_len=%d body_len=%d", data.DigestID, len(subject), len(body))
+		if err := digestRepo.UpdateComposeProfile(ctx, data.DigestID, composeProfile); err != nil {
+			return "", fmt.Errorf("update digest compose profile: %w", err)
+		}
+		if err := digestRepo.UpdateEmailCopy(ctx, data.DigestID, subject, body); err != nil {
+			return "", err
+		}
+		progress.stage(ctx, service.DigestComposeStageDone, 0)
+		return "stored", nil
+	})
+	return err
+}
+
+// stitchDigestCopy runs the final compose call, retrying incomplete responses, and returns the
+// subject, body and retries used.
+func stitchDigestCopy(
+	ctx context.Context,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	workerDeps processItemDeps,
+	data DigestCreatedData,
+	call digestComposeCall,
+	items []service.ComposeDigestItem,
+	maxRetries int,
+) (string, string, int, error) {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		workerCtx := service.WithWorkerTraceMetadata(ctx, "digest", &data.UserID, nil, nil, &data.DigestID)
+		resp, err := call.compose(workerCtx, workerDeps.worker, items)
+		if err != nil {
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", call.runtime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution, err)
+			return "", "", attempt, err
+		}
+		if resp == nil {
+			return "", "", attempt, fmt.Errorf("compose digest returned no response")
+		}
+		recordLLMUsage(ctx, llmUsageRepo, "digest", resp.LLM, &data.UserID, nil, nil, &data.DigestID, call.promptResolution)
+		err = validateDigestCompletion(resp.Subject, resp.Body)
+		if err == nil {
+			recordLLMExecutionSuccess(ctx, llmExecutionRepo, "digest", resp.LLM, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution)
+			return resp.Subject, resp.Body, attempt, nil
+		}
+		recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", call.runtime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution, err)
+		if attempt >= maxRetries {
+			return "", "", attempt, fmt.Errorf("compose digest incomplete after %d retries: %w", attempt, err)
+		}
+		log.Printf("compose-digest-copy digest retry digest_id=%s attempt=%d err=%v", data.DigestID, attempt+1, err)
+	}
+	return "", "", maxRetries, fmt.Errorf("compose digest returned no response")
+}
+
+func generateDigestClusterDrafts(
+	ctx context.Context,
+	digestRepo *repository.DigestInngestRepo,
+	itemRepo *repository.ItemRepo,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	workerDeps processItemDeps,
+	data DigestCreatedData,
+	digest *model.DigestDetail,
+	userModelSettings *model.UserSettings,
+	progress *digestComposeProgress,
+) (int, error) {
+	annotateDigestFollowUps(ctx, digestRepo, data.DigestID, digest.Items)
 	clusterItems := make([]model.Item, 0, len(digest.Items))
 	for _, di := range digest.Items {
 		it := di.Item
@@ -36,10 +194,11 @@ func composeDigestEmailCopy(
 	}
 	embClusters, err := itemRepo.ClusterItemsByEmbeddings(ctx, clusterItems)
 	if err != nil {
-		return fmt.Errorf("cluster digest items: %w", err)
+		return 0, fmt.Errorf("cluster digest items: %w", err)
 	}
 	drafts := buildDigestClusterDrafts(digest.Items, embClusters)
-	drafts = compressDigestClusterDrafts(drafts, 20)
+	drafts = prioritizeDigestClusterDrafts(drafts, service.DigestTopicPrioritiesFromSettings(userModelSettings))
+	drafts = compressDigestClusterDrafts(drafts, digestComposeProfileFor(digest, userModelSettings).MaxClusters)
 
 	var clusterDraftModel *string
 	if userModelSettings != nil {
@@ -47,163 +206,267 @@ func composeDigestEmailCopy(
 	}
 	clusterDraftRuntime, keyErr := resolveLLMRuntime(ctx, workerDeps.keyProvider, &data.UserID, clusterDraftModel, "digest_cluster_draft")
 	if keyErr != nil {
-		return keyErr
+		return 0, keyErr
+	}
+
+	totalClusterDraftRetryCount, err := composeDigestClusterDrafts(ctx, llmUsageRepo, llmExecutionRepo, workerDeps, data, clusterDraftRuntime, drafts, progress)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := digestRepo.ReplaceClusterDrafts(ctx, data.DigestID, drafts); err != nil {
+		return 0, fmt.Errorf("store digest cluster drafts: %w", err)
 	}
+	return totalClusterDraftRetryCount, nil
+}
 
-	totalClusterDraftRetryCount := 0
+// composeDigestClusterDrafts rewrites every draft with source lines concurrently, bounded by
+// digestClusterDraftConcurrency, and returns the total retries. All failures are reported
+// together once every call has finished.
+func composeDigestClusterDrafts(
+	ctx context.Context,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	workerDeps processItemDeps,
+	data DigestCreatedData,
+	clusterDraftRuntime *llmRuntime,
+	drafts []model.DigestClusterDraft,
+	progress *digestComposeProgress,
+) (int, error) {
+	retries := make([]int, len(drafts))
+	errs := make([]error, len(drafts))
+	pending := make([][]string, len(drafts))
+	total := 0
 	for i := range drafts {
-		sourceLines := draftSourceLines(drafts[i].DraftSummary)
+		if pending[i] = draftSourceLines(drafts[i].DraftSummary); len(pending[i]) > 0 {
+			total++
+		}
+	}
+	progress.stage(ctx, service.DigestComposeStageClusterDrafts, total)
+	sem := make(chan struct{}, digestClusterDraftConcurrency())
+	var wg sync.WaitGroup
+	for i, sourceLines := range pending {
 		if len(sourceLines) == 0 {
 			continue
 		}
-		valid := false
-		for attempt := 0; attempt <= maxDigestClusterDraftRetries; attempt++ {
-			workerCtx := service.WithWorkerTraceMetadata(ctx, "digest_cluster_draft", &data.UserID, nil, nil, &data.DigestID)
-			resp, err := workerDeps.worker.ComposeDigestClusterDraftWithModel(
-				workerCtx,
-				drafts[i].ClusterLabel,
-				drafts[i].ItemCount,
-				drafts[i].Topics,
-				sourceLines,
-				clusterDraftRuntime.AnthropicKey,
-				clusterDraftRuntime.GoogleKey,
-				clusterDraftRuntime.GroqKey,
-				clusterDraftRuntime.DeepSeekKey,
-				clusterDraftRuntime.AlibabaKey,
-				clusterDraftRuntime.MistralKey,
-				clusterDraftRuntime.XAIKey,
-				clusterDraftRuntime.ZAIKey,
-				clusterDraftRuntime.FireworksKey,
-				clusterDraftRuntime.OpenAIKey,
-				clusterDraftRuntime.Model,
-			)
-			if err != nil {
-				recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
-				return fmt.Errorf("compose digest cluster draft rank=%d attempt=%d: %w", drafts[i].Rank, attempt+1, err)
+		wg.Add(1)
+		go func(i int, sourceLines []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			retries[i], errs[i] = composeDigestClusterDraft(ctx, llmUsageRepo, llmExecutionRepo, workerDeps, data, clusterDraftRuntime, &drafts[i], sourceLines)
+			if errs[i] == nil {
+				progress.tick(ctx, service.DigestComposeStageClusterDrafts)
 			}
+		}(i, sourceLines)
+	}
+	wg.Wait()
+	// Drafts keep their index, so errors come back in rank order whichever call failed first.
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+	retryCount := 0
+	for _, n := range retries {
+		retryCount += n
+	}
+	return retryCount, nil
+}
+
+// composeDigestClusterDraft rewrites one cluster's draft through the worker, retrying truncated
+// drafts, and reports how many retries it took. It only touches draft, so drafts of one digest
+// can be composed concurrently.
+func composeDigestClusterDraft(
+	ctx context.Context,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	workerDeps processItemDeps,
+	data DigestCreatedData,
+	clusterDraftRuntime *llmRuntime,
+	draft *model.DigestClusterDraft,
+	sourceLines []string,
+) (int, error) {
+	const maxDigestClusterDraftRetries = 2
+
+	for attempt := 0; attempt <= maxDigestClusterDraftRetries; attempt++ {
+		workerCtx := service.WithWorkerTraceMetadata(ctx, "digest_cluster_draft", &data.UserID, nil, nil, &data.DigestID)
+		resp, err := workerDeps.worker.ComposeDigestClusterDraftWithModel(
+			workerCtx,
+			draft.ClusterLabel,
+			draft.ItemCount,
+			draft.Topics,
+			sourceLines,
+			clusterDraftRuntime.AnthropicKey,
+			clusterDraftRuntime.GoogleKey,
+			clusterDraftRuntime.GroqKey,
+			clusterDraftRuntime.DeepSeekKey,
+			clusterDraftRuntime.AlibabaKey,
+			clusterDraftRuntime.MistralKey,
+			clusterDraftRuntime.XAIKey,
+			clusterDraftRuntime.ZAIKey,
+			clusterDraftRuntime.FireworksKey,
+			clusterDraftRuntime.OpenAIKey,
+			clusterDraftRuntime.Model,
+		)
+		if err != nil {
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
+			return 0, fmt.Errorf("compose digest cluster draft rank=%d attempt=%d: %w", draft.Rank, attempt+1, err)
+		}
+		if resp != nil {
+			recordLLMUsage(ctx, llmUsageRepo, "digest_cluster_draft", resp.LLM, &data.UserID, nil, nil, &data.DigestID, nil)
+		}
+		candidate := draft.DraftSummary
+		if resp != nil && strings.TrimSpace(resp.DraftSummary) != "" {
+			candidate = resp.DraftSummary
+		}
+		if err := validateDigestClusterDraftCompletion(candidate); err == nil {
+			draft.DraftSummary = candidate
 			if resp != nil {
-				recordLLMUsage(ctx, llmUsageRepo, "digest_cluster_draft", resp.LLM, &data.UserID, nil, nil, &data.DigestID, nil)
+				recordLLMExecutionSuccess(ctx, llmExecutionRepo, "digest_cluster_draft", resp.LLM, attempt, &data.UserID, nil, nil, &data.DigestID, nil)
 			}
-			candidate := drafts[i].DraftSummary
-			if resp != nil && strings.TrimSpace(resp.DraftSummary) != "" {
-				candidate = resp.DraftSummary
+			return attempt, nil
+		} else if attempt >= maxDigestClusterDraftRetries {
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
+			return 0, fmt.Errorf("compose digest cluster draft rank=%d incomplete after %d retries: %w", draft.Rank, attempt, err)
+		} else {
+			reason := digestClusterDraftValidationReason(candidate)
+			lastLine := ""
+			trimmed := strings.TrimSpace(candidate)
+			if trimmed != "" {
+				lines := strings.Split(trimmed, "\n")
+				lastLine = strings.TrimSpace(lines[len(lines)-1])
 			}
-			if err := validateDigestClusterDraftCompletion(candidate); err == nil {
-				drafts[i].DraftSummary = candidate
-				if resp != nil {
-					recordLLMExecutionSuccess(ctx, llmExecutionRepo, "digest_cluster_draft", resp.LLM, attempt, &data.UserID, nil, nil, &data.DigestID, nil)
-				}
-				totalClusterDraftRetryCount += attempt
-				valid = true
-				break
-			} else if attempt >= maxDigestClusterDraftRetries {
-				recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
-				return fmt.Errorf("compose digest cluster draft rank=%d incomplete after %d retries: %w", drafts[i].Rank, attempt, err)
-			} else {
-				reason := digestClusterDraftValidationReason(candidate)
-				lastLine := ""
-				trimmed := strings.TrimSpace(candidate)
-				if trimmed != "" {
-					lines := strings.Split(trimmed, "\n")
-					lastLine = strings.TrimSpace(lines[len(lines)-1])
-				}
-				lineCount := 0
-				if trimmed != "" {
-					for _, line := range strings.Split(trimmed, "\n") {
-						if strings.TrimSpace(line) != "" {
-							lineCount++
-						}
+			lineCount := 0
+			if trimmed != "" {
+				for _, line := range strings.Split(trimmed, "\n") {
+					if strings.TrimSpace(line) != "" {
+						lineCount++
 					}
 				}
-				inputTokens, outputTokens := 0, 0
-				modelName := ""
-				if resp != nil && resp.LLM != nil {
-					inputTokens = resp.LLM.InputTokens
-					outputTokens = resp.LLM.OutputTokens
-					modelName = strings.TrimSpace(resp.LLM.ResolvedModel)
-					if modelName == "" {
-						modelName = strings.TrimSpace(resp.LLM.Model)
-					}
+			}
+			inputTokens, outputTokens := 0, 0
+			modelName := ""
+			if resp != nil && resp.LLM != nil {
+				inputTokens = resp.LLM.InputTokens
+				outputTokens = resp.LLM.OutputTokens
+				modelName = strings.TrimSpace(resp.LLM.ResolvedModel)
+				if modelName == "" {
+					modelName = strings.TrimSpace(resp.LLM.Model)
 				}
-				recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
-				log.Printf(
-					"compose-digest-copy cluster-draft retry digest_id=%s rank=%d attempt=%d reason=%s model=%s input_tokens=%d output_tokens=%d candidate_chars=%d line_count=%d last_line=%q err=%v",
-					data.DigestID,
-					drafts[i].Rank,
-					attempt+1,
-					reason,
-					modelName,
-					inputTokens,
-					outputTokens,
-					len([]rune(trimmed)),
-					lineCount,
-					lastLine,
-					err,
-				)
 			}
-		}
-		if !valid {
-			return fmt.Errorf("compose digest cluster draft rank=%d produced no valid draft", drafts[i].Rank)
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest_cluster_draft", clusterDraftRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, nil, err)
+			log.Printf(
+				"compose-digest-copy cluster-draft retry digest_id=%s rank=%d attempt=%d reason=%s model=%s input_tokens=%d output_tokens=%d candidate_chars=%d line_count=%d last_line=%q err=%v",
+				data.DigestID,
+				draft.Rank,
+				attempt+1,
+				reason,
+				modelName,
+				inputTokens,
+				outputTokens,
+				len([]rune(trimmed)),
+				lineCount,
+				lastLine,
+				err,
+			)
 		}
 	}
+	return 0, fmt.Errorf("compose digest cluster draft rank=%d produced no valid draft", draft.Rank)
+}
 
-	if err := digestRepo.ReplaceClusterDrafts(ctx, data.DigestID, drafts); err != nil {
-		return fmt.Errorf("store digest cluster drafts: %w", err)
+// annotateDigestFollowUps marks the items that continue a story from the user's digests of the
+// past month and stores the marks for the email and the digest page. A failure only costs the
+// annotations.
+func annotateDigestFollowUps(ctx context.Context, digestRepo *repository.DigestInngestRepo, digestID string, items []model.DigestItemDetail) {
+	current, err := digestRepo.ListItemEmbeddings(ctx, digestID)
+	if err != nil {
+		log.Printf("compose-digest-copy follow-up embeddings failed digest_id=%s err=%v", digestID, err)
+		return
 	}
-	storedDrafts, err := digestRepo.ListClusterDrafts(ctx, data.DigestID)
+	prior, err := digestRepo.ListPriorDigestItems(ctx, digestID, service.DigestFollowUpLookbackDays)
 	if err != nil {
-		return fmt.Errorf("reload digest cluster drafts: %w", err)
+		log.Printf("compose-digest-copy follow-up lookback failed digest_id=%s err=%v", digestID, err)
+		return
 	}
-	items := buildComposeItemsFromClusterDrafts(storedDrafts, len(storedDrafts))
-	log.Printf("compose-digest-copy compacted digest_id=%s source_items=%d cluster_drafts=%d compose_items=%d", data.DigestID, len(digest.Items), len(storedDrafts), len(items))
-
-	var modelOverride *string
-	if userModelSettings != nil {
-		modelOverride = ptrStringOrNil(userModelSettings.DigestModel)
+	followUps := service.MatchDigestFollowUps(current, prior)
+	if err := digestRepo.ReplaceItemFollowUps(ctx, digestID, followUps); err != nil {
+		log.Printf("compose-digest-copy follow-up store failed digest_id=%s err=%v", digestID, err)
+		return
 	}
-	digestRuntime, keyErr := resolveLLMRuntime(ctx, workerDeps.keyProvider, &data.UserID, modelOverride, "digest")
-	if keyErr != nil {
-		return keyErr
+	for i := range items {
+		items[i].FollowUp = nil
+		if f, ok := followUps[items[i].Item.ID]; ok {
+			items[i].FollowUp = &f
+		}
 	}
-	digestPromptResolution := service.ResolvePromptResolution(ctx, workerDeps.promptResolver, service.PromptResolveInput{
-		PromptKey:      "digest.default",
-		AssignmentUnit: "digest_id",
-		AssignmentKey:  data.DigestID,
-	})
-	digestPromptConfig := service.WorkerPromptConfigFromResolution(digestPromptResolution)
+	log.Printf("compose-digest-copy follow-ups digest_id=%s prior_items=%d matched=%d", digestID, len(prior), len(followUps))
+}
 
-	var resp *service.ComposeDigestResponse
-	digestRetryCount := 0
-	for attempt := 0; attempt <= maxDigestRetries; attempt++ {
-		workerCtx := service.WithWorkerTraceMetadata(ctx, "digest", &data.UserID, nil, nil, &data.DigestID)
-		resp, err = workerDeps.worker.ComposeDigestWithModel(workerCtx, digest.DigestDate, items, digestRuntime.AnthropicKey, digestRuntime.GoogleKey, digestRuntime.GroqKey, digestRuntime.DeepSeekKey, digestRuntime.AlibabaKey, digestRuntime.MistralKey, digestRuntime.XAIKey, digestRuntime.ZAIKey, digestRuntime.FireworksKey, digestRuntime.OpenAIKey, digestRuntime.Model, digestPromptConfig)
-		if err != nil {
-			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", digestRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, digestPromptResolution, err)
-			return err
-		}
-		recordLLMUsage(ctx, llmUsageRepo, "digest", resp.LLM, &data.UserID, nil, nil, &data.DigestID, digestPromptResolution)
-		if err := validateDigestCompletion(resp.Subject, resp.Body); err == nil {
-			recordLLMExecutionSuccess(ctx, llmExecutionRepo, "digest", resp.LLM, attempt, &data.UserID, nil, nil, &data.DigestID, digestPromptResolution)
-			digestRetryCount = attempt
-			break
-		} else if attempt >= maxDigestRetries {
-			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", digestRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, digestPromptResolution, err)
-			return fmt.Errorf("compose digest incomplete after %d retries: %w", attempt, err)
-		} else {
-			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", digestRuntime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, digestPromptResolution, err)
-			log.Printf("compose-digest-copy digest retry digest_id=%s attempt=%d err=%v", data.DigestID, attempt+1, err)
+func generateDigestAudioIfEnabled(
+	ctx context.Context,
+	digestRepo *repository.DigestInngestRepo,
+	audio *service.DigestAudioService,
+	data DigestCreatedData,
+	userModelSettings *model.UserSettings,
+) {
+	if audio == nil || userModelSettings == nil || !userModelSettings.DigestAudioEnabled {
+		return
+	}
+	existing, err := digestRepo.GetAudio(ctx, data.DigestID)
+	if err == nil && existing.Status != nil && *existing.Status == "ready" {
+		log.Printf("compose-digest-copy reuse-audio digest_id=%s", data.DigestID)
+		return
+	}
+	digest, err := digestRepo.GetForEmail(ctx, data.DigestID)
+	if err != nil {
+		log.Printf("compose-digest-copy audio fetch failed digest_id=%s err=%v", data.DigestID, err)
+		return
+	}
+	if digest.EmailSubject == nil || digest.EmailBody == nil {
+		return
+	}
+	if err := digestRepo.UpdateAudioStatus(ctx, data.DigestID, "processing", nil); err != nil {
+		log.Printf("compose-digest-copy audio status failed digest_id=%s err=%v", data.DigestID, err)
+	}
+	result, err := audio.Generate(ctx, data.UserID, data.DigestID, *digest.EmailSubject, *digest.EmailBody)
+	if err != nil {
+		msg := err.Error()
+		if len(msg) > 2000 {
+			msg = msg[:2000]
 		}
+		if updateErr := digestRepo.UpdateAudioStatus(ctx, data.DigestID, "failed", &msg); updateErr != nil {
+			log.Printf("compose-digest-copy audio status failed digest_id=%s err=%v", data.DigestID, updateErr)
+		}
+		log.Printf("compose-digest-copy audio failed digest_id=%s err=%v", data.DigestID, err)
+		return
 	}
-	if resp == nil {
-		return fmt.Errorf("compose digest returned no response")
+	if err := digestRepo.UpdateAudioReady(ctx, data.DigestID, result.Bucket, result.ObjectKey, result.ContentType, result.DurationSec); err != nil {
+		log.Printf("compose-digest-copy audio store failed digest_id=%s err=%v", data.DigestID, err)
+		return
 	}
-	resp.Subject = service.FormatDigestEmailSubject(digest.DigestDate, resp.Subject)
-	if err := digestRepo.UpdateComposeRetryCounts(ctx, data.DigestID, digestRetryCount, totalClusterDraftRetryCount); err != nil {
-		return fmt.Errorf("update digest retry counts: %w", err)
+	log.Printf("compose-digest-copy audio ready digest_id=%s object_key=%s", data.DigestID, result.ObjectKey)
+}
+
+func digestEmailAudioURL(ctx context.Context, digestRepo *repository.DigestInngestRepo, audio *service.DigestAudioService, digestID string) string {
+	if audio == nil {
+		return ""
 	}
-	log.Printf("compose-digest-copy worker-done digest_id=%s subject_len=%d body_len=%d", data.DigestID, len(resp.Subject), len(resp.Body))
-	if err := digestRepo.UpdateEmailCopy(ctx, data.DigestID, resp.Subject, resp.Body); err != nil {
-		return err
+	info, err := digestRepo.GetAudio(ctx, digestID)
+	if err != nil || info.Status == nil || *info.Status != "ready" || info.ObjectKey == nil || info.Bucket == nil {
+		return ""
+	}
+	url, err := audio.EmailLinkURL(ctx, *info.Bucket, *info.ObjectKey)
+	if err != nil {
+		log.Printf("send-digest audio presign failed digest_id=%s err=%v", digestID, err)
+		return ""
+	}
+	return url
+}
+
+// digestComposeProfileFor applies the tighter catch-up profile to digests that span quiet days.
+func digestComposeProfileFor(digest *model.DigestDetail, settings *model.UserSettings) model.DigestComposeProfile {
+	profile := service.DigestComposeProfileFromSettings(settings)
+	if digest != nil && digest.ComposeMode == service.DigestComposeModeCatchUp {
+		profile = service.CatchUpDigestComposeProfile(profile)
 	}
-	return nil
+	return profile
 }
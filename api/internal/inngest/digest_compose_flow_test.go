@@ -0,0 +1,84 @@
+package inngest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+func TestComposeDigestClusterDraftsRunsConcurrentlyAndJoinsErrors(t *testing.T) {
+	var inFlight, peak int32
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		mu.Lock()
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+
+		var req struct {
+			ClusterLabel string `json:"cluster_label"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.ClusterLabel == "broken" {
+			http.Error(w, "boom", http.StatusUnprocessableEntity)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"draft_summary": "- " + req.ClusterLabel + " の新しいリリースが本日正式に発表された。\n- 対応環境や価格などの詳細は各社の公式発表で確認できる。",
+		})
+	}))
+	defer server.Close()
+	t.Setenv("PYTHON_WORKER_URL", server.URL)
+	t.Setenv("DIGEST_CLUSTER_DRAFT_CONCURRENCY", "3")
+	deps := processItemDeps{worker: service.NewWorkerClient()}
+
+	labels := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta"}
+	drafts := make([]model.DigestClusterDraft, len(labels))
+	for i, label := range labels {
+		drafts[i] = model.DigestClusterDraft{ClusterLabel: label, Rank: i + 1, ItemCount: 1, DraftSummary: "- source line"}
+	}
+	drafts = append(drafts, model.DigestClusterDraft{ClusterLabel: "empty", Rank: 7})
+
+	retries, err := composeDigestClusterDrafts(context.Background(), nil, nil, deps, DigestCreatedData{DigestID: "d1", UserID: "u1"}, &llmRuntime{}, drafts, nil)
+	if err != nil {
+		t.Fatalf("composeDigestClusterDrafts error = %v", err)
+	}
+	if retries != 0 {
+		t.Fatalf("retries = %d, want 0", retries)
+	}
+	for _, d := range drafts[:len(labels)] {
+		if !strings.Contains(d.DraftSummary, d.ClusterLabel) {
+			t.Fatalf("draft %s not rewritten: %q", d.ClusterLabel, d.DraftSummary)
+		}
+	}
+	if drafts[len(labels)].DraftSummary != "" {
+		t.Fatal("draft without source lines should be left alone")
+	}
+	if peak < 2 || peak > 3 {
+		t.Fatalf("peak concurrent calls = %d, want 2..3", peak)
+	}
+
+	drafts[1].ClusterLabel = "broken"
+	drafts[4].ClusterLabel = "broken"
+	_, err = composeDigestClusterDrafts(context.Background(), nil, nil, deps, DigestCreatedData{DigestID: "d1", UserID: "u1"}, &llmRuntime{}, drafts, nil)
+	if err == nil {
+		t.Fatal("expected an error for the broken drafts")
+	}
+	msg := err.Error()
+	if i, j := strings.Index(msg, "rank=2"), strings.Index(msg, "rank=5"); i < 0 || j < i {
+		t.Fatalf("error should list rank=2 then rank=5, got %q", msg)
+	}
+}
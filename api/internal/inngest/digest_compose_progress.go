@@ -0,0 +1,42 @@
+package inngest
+
+import (
+	"context"
+	"log"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// digestComposeProgress records how far compose-digest-copy has got for the progress API.
+// Failures are logged and only cost the progress display. A nil progress records nothing.
+type digestComposeProgress struct {
+	repo     *repository.DigestInngestRepo
+	digestID string
+}
+
+func (p *digestComposeProgress) start(ctx context.Context, stage string) {
+	if p == nil || p.repo == nil {
+		return
+	}
+	if err := p.repo.StartComposeProgress(ctx, p.digestID, stage); err != nil {
+		log.Printf("compose-digest-copy progress start failed digest_id=%s err=%v", p.digestID, err)
+	}
+}
+
+func (p *digestComposeProgress) stage(ctx context.Context, stage string, total int) {
+	if p == nil || p.repo == nil {
+		return
+	}
+	if err := p.repo.UpdateComposeStage(ctx, p.digestID, stage, total); err != nil {
+		log.Printf("compose-digest-copy progress stage failed digest_id=%s stage=%s err=%v", p.digestID, stage, err)
+	}
+}
+
+func (p *digestComposeProgress) tick(ctx context.Context, stage string) {
+	if p == nil || p.repo == nil {
+		return
+	}
+	if err := p.repo.IncrementComposeProgress(ctx, p.digestID, stage); err != nil {
+		log.Printf("compose-digest-copy progress tick failed digest_id=%s stage=%s err=%v", p.digestID, stage, err)
+	}
+}
@@ -0,0 +1,242 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo/group"
+	"github.com/inngest/inngestgo/step"
+)
+
+const (
+	defaultDigestComposeSectionMaxRunes = 24000
+	// Sections stay under the cutoff where buildComposeItemsFromClusterDrafts starts
+	// shortening drafts, so every cluster reaches its section call in full.
+	digestComposeSectionMaxDrafts   = 12
+	digestComposeSectionConcurrency = 4
+	// Cluster drafts are short calls on a cheaper model, so more of them run at once.
+	defaultDigestClusterDraftConcurrency = 6
+	maxDigestSectionAttempts             = 2
+	digestStitchTitleLabels              = 3
+)
+
+// digestComposeCall is one compose-digest request shape: the same model, prompt and profile
+// serve the section calls and the final stitching call.
+type digestComposeCall struct {
+	digestDate       string
+	runtime          *llmRuntime
+	prompt           *service.PromptConfig
+	promptResolution *service.PromptResolution
+	targetLanguage   *string
+	locale           string
+	profile          model.DigestComposeProfile
+}
+
+func (c digestComposeCall) compose(ctx context.Context, worker *service.WorkerClient, items []service.ComposeDigestItem) (*service.ComposeDigestResponse, error) {
+	r := c.runtime
+	return worker.ComposeDigestWithModel(ctx, c.digestDate, items, r.AnthropicKey, r.GoogleKey, r.GroqKey, r.DeepSeekKey, r.AlibabaKey, r.MistralKey, r.XAIKey, r.ZAIKey, r.FireworksKey, r.OpenAIKey, r.Model, c.prompt, c.targetLanguage, c.locale, c.profile.Verbosity, c.profile.Tone)
+}
+
+// digestClusterDraftConcurrency bounds the cluster draft calls of one digest in flight at once.
+func digestClusterDraftConcurrency() int {
+	if n := envIntOrDefault("DIGEST_CLUSTER_DRAFT_CONCURRENCY", defaultDigestClusterDraftConcurrency); n > 0 {
+		return n
+	}
+	return defaultDigestClusterDraftConcurrency
+}
+
+func digestComposeSectionMaxRunes() int {
+	if n := envIntOrDefault("DIGEST_COMPOSE_SECTION_MAX_RUNES", defaultDigestComposeSectionMaxRunes); n > 0 {
+		return n
+	}
+	return defaultDigestComposeSectionMaxRunes
+}
+
+func digestDraftRunes(d model.DigestClusterDraft) int {
+	return utf8.RuneCountInString(d.ClusterLabel) + utf8.RuneCountInString(d.DraftSummary)
+}
+
+// splitDigestComposeSections groups drafts, in rank order, into sections of at most maxRunes
+// and digestComposeSectionMaxDrafts drafts. It returns nil when all drafts fit one compose call.
+func splitDigestComposeSections(drafts []model.DigestClusterDraft, maxRunes int) [][]model.DigestClusterDraft {
+	total := 0
+	for _, d := range drafts {
+		total += digestDraftRunes(d)
+	}
+	if total <= maxRunes {
+		return nil
+	}
+	ordered := append([]model.DigestClusterDraft(nil), drafts...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Rank < ordered[j].Rank })
+
+	var sections [][]model.DigestClusterDraft
+	var current []model.DigestClusterDraft
+	currentRunes := 0
+	for _, d := range ordered {
+		n := digestDraftRunes(d)
+		if len(current) > 0 && (currentRunes+n > maxRunes || len(current) >= digestComposeSectionMaxDrafts) {
+			sections = append(sections, current)
+			current, currentRunes = nil, 0
+		}
+		current = append(current, d)
+		currentRunes += n
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// composeDigestSections composes every section in its own step, digestComposeSectionConcurrency
+// at a time, and returns their bodies in section order. A section whose calls fail or come back
+// truncated falls back to its raw cluster drafts, so one bad call costs polish rather than the
+// whole digest, and a retried run does not pay again for sections already composed.
+func composeDigestSections(
+	ctx context.Context,
+	deps processItemDeps,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	data DigestCreatedData,
+	call digestComposeCall,
+	sections [][]model.DigestClusterDraft,
+	progress *digestComposeProgress,
+) []string {
+	bodies := make([]string, len(sections))
+	maxRunes := digestComposeSectionMaxRunes()
+	for start := 0; start < len(sections); start += digestComposeSectionConcurrency {
+		end := min(start+digestComposeSectionConcurrency, len(sections))
+		fns := make([]func(ctx context.Context) (any, error), 0, end-start)
+		for i := start; i < end; i++ {
+			section := sections[i]
+			fns = append(fns, func(ctx context.Context) (any, error) {
+				body, err := step.Run(ctx, fmt.Sprintf("compose-digest-section-%d", i+1), func(ctx context.Context) (string, error) {
+					body, err := composeDigestSection(ctx, deps, llmUsageRepo, llmExecutionRepo, data, call, section)
+					if err != nil {
+						log.Printf("compose-digest-copy section fallback digest_id=%s section=%d clusters=%d err=%v", data.DigestID, i+1, len(section), err)
+						body = rawDigestSectionBody(section, maxRunes)
+					}
+					progress.tick(ctx, service.DigestComposeStageSections)
+					return body, nil
+				})
+				bodies[i] = body
+				return body, err
+			})
+		}
+		group.Parallel(ctx, fns...)
+	}
+	return bodies
+}
+
+func composeDigestSection(
+	ctx context.Context,
+	deps processItemDeps,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	llmExecutionRepo *repository.LLMExecutionEventRepo,
+	data DigestCreatedData,
+	call digestComposeCall,
+	section []model.DigestClusterDraft,
+) (string, error) {
+	items := buildComposeItemsFromClusterDrafts(section, len(section))
+	var lastErr error
+	for attempt := 0; attempt < maxDigestSectionAttempts; attempt++ {
+		workerCtx := service.WithWorkerTraceMetadata(ctx, "digest", &data.UserID, nil, nil, &data.DigestID)
+		resp, err := call.compose(workerCtx, deps.worker, items)
+		if err != nil {
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", call.runtime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution, err)
+			lastErr = err
+			continue
+		}
+		recordLLMUsage(ctx, llmUsageRepo, "digest", resp.LLM, &data.UserID, nil, nil, &data.DigestID, call.promptResolution)
+		if err := validateDigestCompletion(resp.Subject, resp.Body); err != nil {
+			recordLLMExecutionFailure(ctx, llmExecutionRepo, "digest", call.runtime.Model, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution, err)
+			lastErr = err
+			continue
+		}
+		recordLLMExecutionSuccess(ctx, llmExecutionRepo, "digest", resp.LLM, attempt, &data.UserID, nil, nil, &data.DigestID, call.promptResolution)
+		return strings.TrimSpace(resp.Body), nil
+	}
+	return "", fmt.Errorf("compose digest section after %d attempts: %w", maxDigestSectionAttempts, lastErr)
+}
+
+// rawDigestSectionBody is the fallback text of a section: each cluster's label and draft, cut
+// at maxRunes so a fallback cannot push the stitching call past the model context.
+func rawDigestSectionBody(section []model.DigestClusterDraft, maxRunes int) string {
+	var b strings.Builder
+	for i, d := range section {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString("■ ")
+		b.WriteString(strings.TrimSpace(d.ClusterLabel))
+		b.WriteString("\n")
+		b.WriteString(strings.TrimSpace(d.DraftSummary))
+	}
+	return truncateRunes(b.String(), maxRunes)
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if maxRunes <= 0 || utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
+
+// joinDigestSectionBodies is the digest body used when the stitching call fails: the section
+// bodies in section order.
+func joinDigestSectionBodies(bodies []string) string {
+	parts := make([]string, 0, len(bodies))
+	for _, body := range bodies {
+		if body = strings.TrimSpace(body); body != "" {
+			parts = append(parts, body)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// buildDigestStitchItems turns composed sections into the input of the final compose call, one
+// item per section in section order.
+func buildDigestStitchItems(sections [][]model.DigestClusterDraft, bodies []string) []service.ComposeDigestItem {
+	out := make([]service.ComposeDigestItem, 0, len(sections))
+	for i, section := range sections {
+		labels := make([]string, 0, digestStitchTitleLabels)
+		var topics []string
+		seenTopics := map[string]bool{}
+		var maxScore *float64
+		items := 0
+		for _, d := range section {
+			if len(labels) < digestStitchTitleLabels {
+				labels = append(labels, strings.TrimSpace(d.ClusterLabel))
+			}
+			for _, t := range d.Topics {
+				if !seenTopics[t] {
+					seenTopics[t] = true
+					topics = append(topics, t)
+				}
+			}
+			if d.MaxScore != nil && (maxScore == nil || *d.MaxScore > *maxScore) {
+				maxScore = d.MaxScore
+			}
+			items += d.ItemCount
+		}
+		title := strings.Join(labels, " / ")
+		if extra := len(section) - len(labels); extra > 0 {
+			title += fmt.Sprintf(" (+%d)", extra)
+		}
+		title = fmt.Sprintf("%s (%d items)", title, items)
+		out = append(out, service.ComposeDigestItem{
+			Rank:    i + 1,
+			Title:   &title,
+			Summary: bodies[i],
+			Topics:  topics,
+			Score:   maxScore,
+		})
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package inngest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func sectionTestDraft(rank int, label string, summaryRunes int) model.DigestClusterDraft {
+	return model.DigestClusterDraft{
+		ClusterKey:   label,
+		ClusterLabel: label,
+		Rank:         rank,
+		ItemCount:    2,
+		Topics:       []string{label},
+		DraftSummary: strings.Repeat("x", summaryRunes),
+	}
+}
+
+func TestSplitDigestComposeSectionsLeavesSmallDigestsWhole(t *testing.T) {
+	drafts := []model.DigestClusterDraft{sectionTestDraft(1, "a", 100), sectionTestDraft(2, "b", 100)}
+	if got := splitDigestComposeSections(drafts, 1000); got != nil {
+		t.Fatalf("splitDigestComposeSections = %d sections, want nil", len(got))
+	}
+}
+
+func TestSplitDigestComposeSectionsKeepsRankOrderAndLimits(t *testing.T) {
+	var drafts []model.DigestClusterDraft
+	for rank := 30; rank >= 1; rank-- {
+		drafts = append(drafts, sectionTestDraft(rank, string(rune('A'+rank%26)), 99))
+	}
+	sections := splitDigestComposeSections(drafts, 500)
+	if len(sections) != 6 {
+		t.Fatalf("sections = %d, want 6 of 5 drafts", len(sections))
+	}
+	next := 1
+	for i, section := range sections {
+		runes := 0
+		for _, d := range section {
+			if d.Rank != next {
+				t.Fatalf("section %d has rank %d, want %d", i, d.Rank, next)
+			}
+			next++
+			runes += digestDraftRunes(d)
+		}
+		if runes > 500 {
+			t.Fatalf("section %d has %d runes, want <= 500", i, runes)
+		}
+	}
+
+	sections = splitDigestComposeSections(drafts, 2000)
+	for i, section := range sections {
+		if len(section) > digestComposeSectionMaxDrafts {
+			t.Fatalf("section %d has %d drafts, want <= %d", i, len(section), digestComposeSectionMaxDrafts)
+		}
+	}
+}
+
+func TestBuildDigestStitchItems(t *testing.T) {
+	high := 0.9
+	sections := [][]model.DigestClusterDraft{
+		{sectionTestDraft(1, "AI", 10), sectionTestDraft(2, "Cloud", 10), sectionTestDraft(3, "Chips", 10), sectionTestDraft(4, "Robots", 10)},
+		{sectionTestDraft(5, "Security", 10)},
+	}
+	sections[0][1].MaxScore = &high
+	sections[1][0].DraftSummary = "- patch released"
+	bodies := []string{"composed section", rawDigestSectionBody(sections[1], 1000)}
+
+	got := buildDigestStitchItems(sections, bodies)
+	if len(got) != 2 {
+		t.Fatalf("items = %d, want 2", len(got))
+	}
+	if got[0].Rank != 1 || *got[0].Title != "AI / Cloud / Chips (+1) (8 items)" || got[0].Summary != "composed section" {
+		t.Fatalf("first item = rank %d title %q summary %q", got[0].Rank, *got[0].Title, got[0].Summary)
+	}
+	if got[0].Score == nil || *got[0].Score != high {
+		t.Fatalf("first item score = %v, want %v", got[0].Score, high)
+	}
+	if len(got[0].Topics) != 4 {
+		t.Fatalf("first item topics = %v, want 4", got[0].Topics)
+	}
+	if got[1].Rank != 2 || got[1].Summary != "■ Security\n- patch released" {
+		t.Fatalf("fallback item = rank %d summary %q", got[1].Rank, got[1].Summary)
+	}
+}
+
+func TestRawDigestSectionBodyStaysUnderMaxRunes(t *testing.T) {
+	section := []model.DigestClusterDraft{sectionTestDraft(1, "日本語", 50), sectionTestDraft(2, "b", 50)}
+	if got := rawDigestSectionBody(section, 1000); !strings.HasPrefix(got, "■ 日本語\n") || !strings.Contains(got, "■ b\n") {
+		t.Fatalf("raw body = %q", got)
+	}
+	got := rawDigestSectionBody(section, 20)
+	if n := len([]rune(got)); n != 20 || !strings.HasPrefix(got, "■ 日本語") {
+		t.Fatalf("truncated raw body = %q (%d runes), want 20 runes", got, n)
+	}
+}
+
+func TestJoinDigestSectionBodiesKeepsSectionOrder(t *testing.T) {
+	if got := joinDigestSectionBodies([]string{" first\n", "", "second"}); got != "first\n\nsecond" {
+		t.Fatalf("joined = %q", got)
+	}
+}
@@ -182,6 +182,10 @@ func buildDigestClusterDrafts(details []model.DigestItemDetail, embClusters []mo
 			v := maxScore
 			scorePtr = &v
 		}
+		var followUp *model.DigestFollowUp
+		for _, it := range group {
+			followUp = strongerDigestFollowUp(followUp, it.FollowUp)
+		}
 		out = append(out, model.DigestClusterDraft{
 			ClusterKey:   key,
 			ClusterLabel: label,
@@ -190,6 +194,7 @@ func buildDigestClusterDrafts(details []model.DigestItemDetail, embClusters []mo
 			Topics:       group[0].Summary.Topics,
 			MaxScore:     scorePtr,
 			DraftSummary: draftSummary,
+			FollowUp:     followUp,
 		})
 	}
 
@@ -231,6 +236,14 @@ func buildDigestClusterDrafts(details []model.DigestItemDetail, embClusters []mo
 	return out
 }
 
+// strongerDigestFollowUp returns whichever of two follow-up annotations is the closer match.
+func strongerDigestFollowUp(a, b *model.DigestFollowUp) *model.DigestFollowUp {
+	if a == nil || (b != nil && b.Similarity > a.Similarity) {
+		return b
+	}
+	return a
+}
+
 func draftSourceLines(draftSummary string) []string {
 	lines := strings.Split(draftSummary, "\n")
 	out := make([]string, 0, len(lines))
@@ -254,10 +267,12 @@ func minInt(a, b int) int {
 func buildBroadDigestDraftFromChunk(chunk []model.DigestClusterDraft, key, label string) model.DigestClusterDraft {
 	itemCount := 0
 	var maxScore *float64
+	var followUp *model.DigestFollowUp
 	lines := make([]string, 0, len(chunk))
 	topicsSet := map[string]struct{}{}
 	for _, d := range chunk {
 		itemCount += d.ItemCount
+		followUp = strongerDigestFollowUp(followUp, d.FollowUp)
 		if d.MaxScore != nil && (maxScore == nil || *d.MaxScore > *maxScore) {
 			v := *d.MaxScore
 			maxScore = &v
@@ -288,9 +303,55 @@ func buildBroadDigestDraftFromChunk(chunk []model.DigestClusterDraft, key, label
 		Topics:       topics,
 		MaxScore:     maxScore,
 		DraftSummary: strings.Join(lines, "\n"),
+		FollowUp:     followUp,
 	}
 }
 
+// prioritizeDigestClusterDrafts moves drafts matching the user's topic priorities to the front,
+// in priority order, and renumbers ranks. A draft matches when one of its topics equals a
+// priority or its label contains it (case-insensitive). Unmatched drafts keep their order.
+func prioritizeDigestClusterDrafts(drafts []model.DigestClusterDraft, priorities []string) []model.DigestClusterDraft {
+	if len(drafts) == 0 || len(priorities) == 0 {
+		return drafts
+	}
+	lowered := make([]string, 0, len(priorities))
+	for _, p := range priorities {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			lowered = append(lowered, p)
+		}
+	}
+	priorityOf := func(d model.DigestClusterDraft) int {
+		label := strings.ToLower(d.ClusterLabel)
+		for i, p := range lowered {
+			if strings.Contains(label, p) {
+				return i
+			}
+			for _, t := range d.Topics {
+				if strings.ToLower(strings.TrimSpace(t)) == p {
+					return i
+				}
+			}
+		}
+		return len(lowered)
+	}
+	order := make([]int, len(drafts))
+	ranks := make([]int, len(drafts))
+	for i, d := range drafts {
+		order[i] = i
+		ranks[i] = priorityOf(d)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranks[order[a]] < ranks[order[b]]
+	})
+	out := make([]model.DigestClusterDraft, 0, len(drafts))
+	for i, idx := range order {
+		d := drafts[idx]
+		d.Rank = i + 1
+		out = append(out, d)
+	}
+	return out
+}
+
 func compressDigestClusterDrafts(drafts []model.DigestClusterDraft, target int) []model.DigestClusterDraft {
 	if target <= 0 {
 		target = 20
@@ -389,6 +450,9 @@ func buildComposeItemsFromClusterDrafts(drafts []model.DigestClusterDraft, maxIt
 				summary += fmt.Sprintf("\n- ...%d more lines omitted in compose input", len(lines)-1)
 			}
 		}
+		if note := service.DigestFollowUpNote(d.FollowUp); note != "" {
+			summary += "\n" + note
+		}
 		titlePtr := title
 		out = append(out, service.ComposeDigestItem{
 			Rank:    i + 1,
@@ -0,0 +1,59 @@
+package inngest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestPrioritizeDigestClusterDrafts(t *testing.T) {
+	drafts := []model.DigestClusterDraft{
+		{ClusterKey: "ai", ClusterLabel: "AI models", Rank: 1, Topics: []string{"AI"}},
+		{ClusterKey: "sec", ClusterLabel: "Vulnerability roundup", Rank: 2, Topics: []string{"Security"}},
+		{ClusterKey: "cloud", ClusterLabel: "Cloud outage", Rank: 3, Topics: []string{"Cloud"}},
+		{ClusterKey: "misc", ClusterLabel: "Other", Rank: 4},
+	}
+
+	got := prioritizeDigestClusterDrafts(drafts, []string{"security", "cloud"})
+	wantKeys := []string{"sec", "cloud", "ai", "misc"}
+	for i, key := range wantKeys {
+		if got[i].ClusterKey != key || got[i].Rank != i+1 {
+			t.Fatalf("got[%d] = (%s, rank %d), want (%s, rank %d)", i, got[i].ClusterKey, got[i].Rank, key, i+1)
+		}
+	}
+	if drafts[0].ClusterKey != "ai" || drafts[1].Rank != 2 {
+		t.Fatal("input drafts must not be mutated")
+	}
+
+	if got := prioritizeDigestClusterDrafts(drafts, []string{"outage"}); got[0].ClusterKey != "cloud" {
+		t.Fatalf("label substring should match, got first %s", got[0].ClusterKey)
+	}
+}
+
+func TestDigestClusterDraftsCarryFollowUps(t *testing.T) {
+	weak := &model.DigestFollowUp{ItemID: "p1", Title: "Earlier rumor", DigestDate: "2026-10-01", Similarity: 0.75}
+	strong := &model.DigestFollowUp{ItemID: "p2", Title: "Launch announced", DigestDate: "2026-10-10", Similarity: 0.9}
+	details := []model.DigestItemDetail{
+		{Item: model.Item{ID: "a", URL: "https://example.com/a"}, Summary: model.ItemSummary{Summary: "A", Topics: []string{"AI"}}, FollowUp: weak},
+		{Item: model.Item{ID: "b", URL: "https://example.com/b"}, Summary: model.ItemSummary{Summary: "B", Topics: []string{"AI"}}, FollowUp: strong},
+		{Item: model.Item{ID: "c", URL: "https://example.com/c"}, Summary: model.ItemSummary{Summary: "C", Topics: []string{"Cloud"}}},
+	}
+	clusters := []model.ReadingPlanCluster{{ID: "k1", Label: "Launch", Items: []model.Item{{ID: "a"}, {ID: "b"}}}}
+
+	drafts := buildDigestClusterDrafts(details, clusters)
+	if len(drafts) != 2 || drafts[0].FollowUp != strong || drafts[1].FollowUp != nil {
+		t.Fatalf("drafts = %+v", drafts)
+	}
+	if broad := buildBroadDigestDraftFromChunk(drafts, "broad-1", "Broad"); broad.FollowUp != strong {
+		t.Fatalf("broad follow-up = %+v", broad.FollowUp)
+	}
+
+	items := buildComposeItemsFromClusterDrafts(drafts, len(drafts))
+	if !strings.HasSuffix(items[0].Summary, "\n- Follow-up to a story from 2026-10-10: Launch announced") {
+		t.Fatalf("compose summary = %q", items[0].Summary)
+	}
+	if strings.Contains(items[1].Summary, "Follow-up") {
+		t.Fatalf("unannotated compose summary = %q", items[1].Summary)
+	}
+}
@@ -0,0 +1,125 @@
+package inngest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/inngest/inngestgo/step"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DigestRecipientConfirmationData struct {
+	RecipientID string `json:"recipient_id"`
+}
+
+// sendDigestRecipientConfirmationFn issues a confirmation token for a pending digest recipient
+// and emails the link to the address, through the digest owner's mail transport.
+func sendDigestRecipientConfirmationFn(client inngestgo.Client, db *pgxpool.Pool, emails *service.EmailSenderResolver) (inngestgo.ServableFunction, error) {
+	recipientRepo := repository.NewDigestRecipientRepo(db)
+	configRepo := repository.NewDigestConfigRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "send-digest-recipient-confirmation", Name: "Send Digest Recipient Confirmation"},
+		inngestgo.EventTrigger("digest-recipient/confirmation-requested", nil),
+		func(ctx context.Context, input inngestgo.Input[DigestRecipientConfirmationData]) (any, error) {
+			recipientID := input.Event.Data.RecipientID
+			return step.Run(ctx, "send-confirmation", func(ctx context.Context) (string, error) {
+				token, hash, err := service.NewDigestRecipientToken()
+				if err != nil {
+					return "", err
+				}
+				rec, err := recipientRepo.IssueConfirmToken(ctx, recipientID, hash, service.DigestRecipientTokenExpiry(time.Now()))
+				if errors.Is(err, repository.ErrNotFound) {
+					return "skipped", nil
+				}
+				if err != nil {
+					return "", fmt.Errorf("issue confirm token: %w", err)
+				}
+				owner, err := userRepo.GetByID(ctx, rec.UserID)
+				if err != nil {
+					return "", fmt.Errorf("load digest owner: %w", err)
+				}
+				digestName := ""
+				if rec.DigestConfigID != nil {
+					if cfg, err := configRepo.Get(ctx, rec.UserID, *rec.DigestConfigID); err == nil {
+						digestName = cfg.Name
+					}
+				}
+				locale, err := userSettingsRepo.GetLocale(ctx, rec.UserID)
+				if err != nil {
+					locale = service.DefaultLocale
+				}
+				if err := service.SendDigestRecipientConfirmationEmail(ctx, emails.ForUser(ctx, rec.UserID), rec.Email, service.DigestRecipientConfirmationEmail{
+					Locale:     locale,
+					OwnerEmail: owner.Email,
+					DigestName: digestName,
+					ConfirmURL: service.DigestRecipientConfirmURL(token),
+				}); err != nil {
+					return "", fmt.Errorf("send confirmation: %w", err)
+				}
+				log.Printf("digest recipient confirmation sent recipient_id=%s", rec.ID)
+				return "sent", nil
+			})
+		},
+	)
+}
+
+// sendDigestToRecipients fans a sent digest out to the owner's confirmed extra recipients, one
+// step per address so a retry does not mail anyone twice. Failures are recorded per recipient
+// and do not fail the owner's delivery. Recipients never get the owner's feedback or
+// preferences links; their unsubscribe link removes only their own address.
+func sendDigestToRecipients(ctx context.Context, repo *repository.DigestRecipientRepo, sender service.EmailSender, links *service.DigestLinkSigner, locale string, digest *model.DigestDetail, ownerCopy *service.DigestEmailCopy) (sent, failed int) {
+	base := *ownerCopy
+	base.FeedbackURL = nil
+	base.PreferencesURL = ""
+	base.UnsubscribeURL = ""
+	recipients, err := repo.ListConfirmedForDigest(ctx, digest.ID)
+	if err != nil {
+		log.Printf("send-digest list recipients failed digest_id=%s err=%v", digest.ID, err)
+		return 0, 0
+	}
+	for _, rec := range recipients {
+		copy := base
+		copy.UnsubscribeURL = links.UnsubscribeURL(service.DigestEmailScopeRecipient, rec.ID)
+		status, _ := step.Run(ctx, "send-email-"+rec.ID, func(ctx context.Context) (string, error) {
+			status := "sent"
+			var msg *string
+			if err := service.SendDigestEmail(ctx, sender, rec.Email, locale, digest, &copy); err != nil {
+				status = "failed"
+				msg = truncateDeliveryError(err)
+				log.Printf("send-digest recipient failed digest_id=%s recipient_id=%s err=%v", digest.ID, rec.ID, err)
+			}
+			if err := repo.RecordDelivery(ctx, digest.ID, &rec.ID, rec.Email, status, msg); err != nil {
+				log.Printf("send-digest record delivery failed digest_id=%s recipient_id=%s err=%v", digest.ID, rec.ID, err)
+			}
+			return status, nil
+		})
+		if status == "sent" {
+			sent++
+		} else {
+			failed++
+		}
+	}
+	return sent, failed
+}
+
+func truncateDeliveryError(err error) *string {
+	if err == nil {
+		return nil
+	}
+	s := err.Error()
+	if len(s) > 2000 {
+		s = s[:2000]
+	}
+	return &s
+}
@@ -0,0 +1,85 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// Discussions are refreshed while an item is this recent; older threads rarely still move.
+	discussionRefreshWindow = 72 * time.Hour
+	// Items whose discussions were fetched within this interval are left alone.
+	discussionRefreshInterval = 6 * time.Hour
+	discussionRefreshLimit    = 300
+)
+
+type itemDiscussionsEnrichEvent struct {
+	ItemID string `json:"item_id"`
+}
+
+func enrichItemDiscussionsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	svc := service.NewItemDiscussionService(repository.NewItemDiscussionRepo(db), service.NewDiscussionFinder())
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{
+			ID:   "enrich-item-discussions",
+			Name: "Enrich Item Discussions",
+			// Keep the public Hacker News and Reddit APIs well under their rate limits.
+			Concurrency: []inngestgo.ConfigStepConcurrency{
+				{Limit: 2},
+			},
+		},
+		inngestgo.EventTrigger("item/discussions.enrich", nil),
+		func(ctx context.Context, input inngestgo.Input[itemDiscussionsEnrichEvent]) (any, error) {
+			itemID := input.Event.Data.ItemID
+			if itemID == "" {
+				return nil, fmt.Errorf("item_id is required")
+			}
+			found, err := svc.Enrich(ctx, itemID)
+			if err != nil {
+				return nil, fmt.Errorf("enrich item discussions: %w", err)
+			}
+			return map[string]any{"item_id": itemID, "discussions": found}, nil
+		},
+	)
+}
+
+// refreshItemDiscussionsFn re-queues discussion lookups for recent items, so points and comment
+// counts keep up with threads that are still active and threads posted after the first lookup
+// are picked up.
+func refreshItemDiscussionsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	repo := repository.NewItemDiscussionRepo(db)
+	publisher := mustEventPublisher()
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "refresh-item-discussions", Name: "Refresh Item Discussions"},
+		inngestgo.CronTrigger("40 */6 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			now := time.Now()
+			itemIDs, err := repo.ListRefreshTargets(ctx, now.Add(-discussionRefreshWindow), now.Add(-discussionRefreshInterval), discussionRefreshLimit)
+			if err != nil {
+				return nil, fmt.Errorf("list discussion refresh targets: %w", err)
+			}
+			queued := 0
+			failed := 0
+			for _, itemID := range itemIDs {
+				if err := publisher.SendItemDiscussionsEnrichE(ctx, itemID); err != nil {
+					failed++
+					continue
+				}
+				queued++
+			}
+			slog.Info("refresh-item-discussions: done", "candidates", len(itemIDs), "queued", queued, "failed", failed)
+			return map[string]any{"candidates": len(itemIDs), "queued": queued, "failed": failed}, nil
+		},
+	)
+}
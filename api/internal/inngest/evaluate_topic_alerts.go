@@ -0,0 +1,193 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const topicAlertTopItems = 5
+
+// evaluateTopicAlertsFn runs after each fetch cycle and notifies users whose followed
+// topics spiked: the 24h count grew over the previous 24h by at least the threshold.
+func evaluateTopicAlertsFn(client inngestgo.Client, db *pgxpool.Pool, emails *service.EmailSenderResolver, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
+	alertRepo := repository.NewTopicAlertRepo(db)
+	itemRepo := repository.NewItemRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
+	webhookClient := service.NewPublicHTTPClient(10 * time.Second)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{
+			ID:   "evaluate-topic-alerts",
+			Name: "Evaluate Topic Alerts",
+			Concurrency: []inngestgo.ConfigStepConcurrency{
+				{Limit: 1},
+			},
+		},
+		inngestgo.EventTrigger("topic-alerts/evaluate", nil),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			subs, err := alertRepo.ListEvaluable(ctx, service.TopicAlertCooldown)
+			if err != nil {
+				return nil, fmt.Errorf("list topic alert subscriptions: %w", err)
+			}
+			byUser := map[string][]model.TopicAlertSubscription{}
+			userOrder := []string{}
+			for _, s := range subs {
+				if _, ok := byUser[s.UserID]; !ok {
+					userOrder = append(userOrder, s.UserID)
+				}
+				byUser[s.UserID] = append(byUser[s.UserID], s)
+			}
+
+			sent := 0
+			for _, userID := range userOrder {
+				trends, err := itemRepo.TopicTrends(ctx, userID, 50)
+				if err != nil {
+					log.Printf("evaluate-topic-alerts trends user_id=%s: %v", userID, err)
+					continue
+				}
+				matches := service.MatchTopicAlerts(byUser[userID], trends)
+				if len(matches) == 0 {
+					continue
+				}
+				user, err := userRepo.GetByID(ctx, userID)
+				if err != nil {
+					log.Printf("evaluate-topic-alerts user user_id=%s: %v", userID, err)
+					continue
+				}
+				locale, err := userSettingsRepo.GetLocale(ctx, userID)
+				if err != nil {
+					locale = service.DefaultLocale
+				}
+				for _, m := range matches {
+					if sendTopicAlert(ctx, alertRepo, itemRepo, emails, oneSignal, webhookClient, user, locale, m) {
+						sent++
+					}
+				}
+			}
+			return map[string]int{"subscriptions": len(subs), "sent": sent}, nil
+		},
+	)
+}
+
+// sendTopicAlert delivers one alert on every configured channel and records it when at
+// least one channel succeeded.
+func sendTopicAlert(
+	ctx context.Context,
+	alertRepo *repository.TopicAlertRepo,
+	itemRepo *repository.ItemRepo,
+	emails *service.EmailSenderResolver,
+	oneSignal *service.OneSignalClient,
+	webhookClient *http.Client,
+	user *model.User,
+	locale string,
+	m service.TopicAlertMatch,
+) bool {
+	sub := m.Subscription
+	ids, err := alertRepo.TopItemIDs(ctx, user.ID, m.Trend.Topic, topicAlertTopItems)
+	if err != nil {
+		log.Printf("evaluate-topic-alerts items user_id=%s topic=%s: %v", user.ID, m.Trend.Topic, err)
+		return false
+	}
+	items, err := itemRepo.LoadByIDsPreservingOrder(ctx, user.ID, ids)
+	if err != nil {
+		log.Printf("evaluate-topic-alerts load items user_id=%s topic=%s: %v", user.ID, m.Trend.Topic, err)
+		return false
+	}
+	alertItems := make([]service.TopicAlertItem, 0, len(items))
+	for _, it := range items {
+		title := ""
+		if it.TranslatedTitle != nil && strings.TrimSpace(*it.TranslatedTitle) != "" {
+			title = *it.TranslatedTitle
+		} else if it.Title != nil {
+			title = *it.Title
+		}
+		alertItems = append(alertItems, service.TopicAlertItem{ID: it.ID, Title: title, URL: it.URL})
+	}
+	pageURL := appPageURL("/items?topic=" + url.QueryEscape(m.Trend.Topic))
+
+	var sentChannels []string
+	for _, ch := range sub.Channels {
+		var err error
+		switch ch {
+		case service.TopicAlertChannelEmail:
+			sender := emails.ForUser(ctx, user.ID)
+			if sender == nil || !sender.Enabled() {
+				continue
+			}
+			err = service.SendTopicAlertEmail(ctx, sender, user.Email, service.TopicAlertEmail{
+				Locale:       locale,
+				Topic:        m.Trend.Topic,
+				Count24h:     m.Trend.Count24h,
+				CountPrev24h: m.Trend.CountPrev24h,
+				Delta:        m.Trend.Delta,
+				Items:        alertItems,
+				PageURL:      pageURL,
+			})
+		case service.TopicAlertChannelPush:
+			if oneSignal == nil || !oneSignal.Enabled() {
+				continue
+			}
+			_, err = oneSignal.SendToExternalID(
+				ctx,
+				user.Email,
+				fmt.Sprintf("Sifto: 「%s」が急上昇", m.Trend.Topic),
+				fmt.Sprintf("直近24時間で%d件（前日比 +%d件）", m.Trend.Count24h, m.Trend.Delta),
+				pageURL,
+				map[string]any{
+					"type":       "topic_alert",
+					"topic":      m.Trend.Topic,
+					"delta":      m.Trend.Delta,
+					"target_url": pageURL,
+				},
+			)
+		case service.TopicAlertChannelWebhook:
+			if sub.WebhookURL == nil || strings.TrimSpace(*sub.WebhookURL) == "" {
+				continue
+			}
+			err = service.PostTopicAlertWebhook(ctx, webhookClient, *sub.WebhookURL, service.TopicAlertWebhookPayload{
+				Type:         "topic_alert",
+				Topic:        m.Trend.Topic,
+				Count24h:     m.Trend.Count24h,
+				CountPrev24h: m.Trend.CountPrev24h,
+				Delta:        m.Trend.Delta,
+				Items:        alertItems,
+				SentAt:       time.Now().UTC(),
+			})
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf("evaluate-topic-alerts %s user_id=%s topic=%s: %v", ch, user.ID, m.Trend.Topic, err)
+			continue
+		}
+		sentChannels = append(sentChannels, ch)
+	}
+	if len(sentChannels) == 0 {
+		return false
+	}
+	if err := alertRepo.RecordAlert(ctx, user.ID, model.TopicAlertLog{
+		SubscriptionID: sub.ID,
+		Topic:          m.Trend.Topic,
+		Count24h:       m.Trend.Count24h,
+		CountPrev24h:   m.Trend.CountPrev24h,
+		Delta:          m.Trend.Delta,
+		ItemIDs:        ids,
+		SentChannels:   sentChannels,
+	}); err != nil {
+		log.Printf("evaluate-topic-alerts record user_id=%s topic=%s: %v", user.ID, m.Trend.Topic, err)
+	}
+	return true
+}
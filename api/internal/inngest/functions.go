@@ -57,6 +57,10 @@ func generateAudioBriefingsFn(client inngestgo.Client, db *pgxpool.Pool, worker
 
 			for _, row := range settings {
 				processed++
+				if userSettings, err := userSettingsRepo.GetByUserID(ctx, row.UserID); err == nil && service.ProcessingPaused(userSettings) {
+					skipped++
+					continue
+				}
 				job, err := orchestrator.GenerateScheduled(ctx, row.UserID, now)
 				if err != nil {
 					failed++
@@ -140,6 +144,10 @@ func generateAINavigatorBriefsFn(client inngestgo.Client, db *pgxpool.Pool, work
 
 			for _, userID := range userIDs {
 				processed++
+				if userSettings, err := settingsRepo.GetByUserID(ctx, userID); err == nil && service.ProcessingPaused(userSettings) {
+					skipped++
+					continue
+				}
 				latest, err := briefRepo.LatestBriefByUserSlot(ctx, userID, slot)
 				switch {
 				case err == nil && latest != nil:
@@ -391,6 +399,7 @@ func generateBriefingSnapshotsFn(client inngestgo.Client, db *pgxpool.Pool, oneS
 	userRepo := repository.NewUserRepo(db)
 	itemRepo := repository.NewItemRepo(db)
 	streakRepo := repository.NewReadingStreakRepo(db)
+	settingsRepo := repository.NewUserSettingsRepo(db)
 	snapshotRepo := repository.NewBriefingSnapshotRepo(db)
 	pushLogRepo := repository.NewPushNotificationLogRepo(db)
 	notificationRepo := repository.NewNotificationPriorityRepo(db)
@@ -401,16 +410,21 @@ func generateBriefingSnapshotsFn(client inngestgo.Client, db *pgxpool.Pool, oneS
 		inngestgo.FunctionOpts{ID: "generate-briefing-snapshots", Name: "Generate Briefing Snapshots"},
 		inngestgo.CronTrigger("0 21 * * *"),
 		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
-			users, err := userRepo.ListAll(ctx)
+			today := timeutil.StartOfDayJST(timeutil.NowJST())
+			// Inactive users get their snapshot built on demand when they come back.
+			activeDays := envIntOrDefault("BRIEFING_SNAPSHOT_ACTIVE_DAYS", 14)
+			if activeDays <= 0 {
+				activeDays = 14
+			}
+			users, err := userRepo.ListActiveSince(ctx, today.AddDate(0, 0, -activeDays))
 			if err != nil {
-				return nil, fmt.Errorf("list users: %w", err)
+				return nil, fmt.Errorf("list active users: %w", err)
 			}
-			today := timeutil.StartOfDayJST(timeutil.NowJST())
 			dateStr := today.Format("2006-01-02")
 			updated := 0
 			failed := 0
 			for _, u := range users {
-				payload, err := service.BuildBriefingToday(ctx, itemRepo, streakRepo, u.ID, today, 18)
+				payload, err := service.BuildBriefingToday(ctx, itemRepo, streakRepo, settingsRepo, u.ID, today, 18)
 				if err != nil {
 					failed++
 					log.Printf("generate-briefing-snapshots build user=%s: %v", u.ID, err)
@@ -479,10 +493,11 @@ func generateBriefingSnapshotsFn(client inngestgo.Client, db *pgxpool.Pool, oneS
 				updated++
 			}
 			return map[string]any{
-				"date":    dateStr,
-				"users":   len(users),
-				"updated": updated,
-				"failed":  failed,
+				"date":        dateStr,
+				"active_days": activeDays,
+				"users":       len(users),
+				"updated":     updated,
+				"failed":      failed,
 			}, nil
 		},
 	)
@@ -901,6 +916,8 @@ func diffOpenRouterModelAvailability(previous, current []repository.OpenRouterMo
 func fetchRSSFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
 	sourceRepo := repository.NewSourceRepo(db)
 	itemRepo := repository.NewItemRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
+	usageLimits := service.NewUsageLimitService(repository.NewUsageLimitRepo(db))
 	httpClient := service.NewPublicHTTPClient(30 * time.Second)
 
 	return inngestgo.CreateFunction(
@@ -908,16 +925,29 @@ func fetchRSSFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFu
 		inngestgo.FunctionOpts{ID: "fetch-rss", Name: "Fetch RSS Feeds"},
 		inngestgo.CronTrigger("*/10 * * * *"),
 		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			ctx = withRunRequestID(ctx, "", input.InputCtx.RunID)
 			sources, err := sourceRepo.ListEnabled(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("list sources: %w", err)
 			}
+			// Users who used up today's LLM item allowance are not fetched until the JST day
+			// rolls over. Their feeds keep the previous validators, so entries skipped today
+			// are picked up by the first fetch after that.
+			allowances, err := usageLimits.LLMItemAllowances(ctx, sourceUserIDs(sources))
+			if err != nil {
+				return nil, fmt.Errorf("load usage limits: %w", err)
+			}
+			limitedUsers := map[string]struct{}{}
 
 			newCount := 0
 
 			for _, src := range sources {
+				if allowances[src.UserID] == 0 {
+					limitedUsers[src.UserID] = struct{}{}
+					continue
+				}
 				sourceNewCount := 0
-				feed, notModified, etag, lastModified, err := fetchRSSFeed(ctx, httpClient, src)
+				fetched, err := fetchRSSFeed(ctx, httpClient, src)
 				if err != nil {
 					log.Printf("fetch rss %s: %v", src.URL, err)
 					_ = sourceRepo.UpdateLastFetchedAt(ctx, src.ID, timeutil.NowJST())
@@ -926,17 +956,27 @@ func fetchRSSFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFu
 					continue
 				}
 				fetchedAt := timeutil.NowJST()
-				if err := sourceRepo.UpdateFeedFetchMetadata(ctx, src.ID, fetchedAt, etag, lastModified); err != nil {
-					log.Printf("update rss metadata %s: %v", src.URL, err)
+				if fetched.MovedTo != nil {
+					handleFeedPermanentRedirect(ctx, sourceRepo, userSettingsRepo, src, *fetched.MovedTo)
 				}
-				if notModified {
+				if fetched.NotModified {
+					if err := sourceRepo.UpdateFeedFetchMetadata(ctx, src.ID, fetchedAt, fetched.ETag, fetched.LastModified); err != nil {
+						log.Printf("update rss metadata %s: %v", src.URL, err)
+					}
 					continue
 				}
+				feed := fetched.Feed
 
+				// The new validators are only saved once every entry has been considered;
+				// otherwise the next fetch would get a 304 and never see the skipped entries.
+				etag, lastModified := fetched.ETag, fetched.LastModified
 				urls := feedItemURLs(feed)
 				existingURLs, err := itemRepo.ExistingFeedURLs(ctx, src.ID, urls)
 				if err != nil {
 					log.Printf("load existing rss items %s: %v", src.URL, err)
+					if err := sourceRepo.UpdateFeedFetchMetadata(ctx, src.ID, fetchedAt, src.FeedETag, src.FeedLastModified); err != nil {
+						log.Printf("update rss metadata %s: %v", src.URL, err)
+					}
 					continue
 				}
 
@@ -951,6 +991,11 @@ func fetchRSSFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFu
 					if _, exists := existingURLs[entryURL]; exists {
 						continue
 					}
+					if allowances[src.UserID] == 0 {
+						limitedUsers[src.UserID] = struct{}{}
+						etag, lastModified = src.FeedETag, src.FeedLastModified
+						break
+					}
 					var title *string
 					if entry.Title != "" {
 						title = &entry.Title
@@ -966,26 +1011,62 @@ func fetchRSSFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFu
 					}
 					newCount++
 					sourceNewCount++
+					if allowances[src.UserID] > 0 {
+						allowances[src.UserID]--
+					}
 					existingURLs[entryURL] = struct{}{}
 					reason := "fetch_rss"
 					titleVal := title
-					if _, err := client.Send(ctx, service.NewItemCreatedEvent(itemID, src.ID, entryURL, titleVal, reason)); err != nil {
+					if _, err := client.Send(ctx, service.StampRequestID(ctx, service.NewItemCreatedEvent(itemID, src.ID, src.UserID, entryURL, titleVal, reason))); err != nil {
 						log.Printf("send item/created: %v", err)
 					}
 				}
+				if err := sourceRepo.UpdateFeedFetchMetadata(ctx, src.ID, fetchedAt, etag, lastModified); err != nil {
+					log.Printf("update rss metadata %s: %v", src.URL, err)
+				}
 				if sourceNewCount > 0 {
 					_ = sourceRepo.RefreshHealthSnapshot(ctx, src.ID, nil)
 				}
 			}
-			return map[string]int{"new_items": newCount}, nil
+			if _, err := client.Send(ctx, service.NewTopicAlertsEvaluateEvent("fetch_rss")); err != nil {
+				log.Printf("send topic-alerts/evaluate: %v", err)
+			}
+			if len(limitedUsers) > 0 {
+				log.Printf("fetch rss: %d users reached their daily llm item limit", len(limitedUsers))
+			}
+			return map[string]int{"new_items": newCount, "limited_users": len(limitedUsers)}, nil
 		},
 	)
 }
 
-func fetchRSSFeed(ctx context.Context, httpClient *http.Client, source model.Source) (*gofeed.Feed, bool, *string, *string, error) {
+func sourceUserIDs(sources []model.Source) []string {
+	seen := map[string]struct{}{}
+	ids := []string{}
+	for _, src := range sources {
+		if _, ok := seen[src.UserID]; ok {
+			continue
+		}
+		seen[src.UserID] = struct{}{}
+		ids = append(ids, src.UserID)
+	}
+	return ids
+}
+
+type rssFetchResult struct {
+	Feed         *gofeed.Feed
+	NotModified  bool
+	ETag         *string
+	LastModified *string
+	// MovedTo is set when every redirect hop was permanent (301/308) and the
+	// final URL differs from the source URL.
+	MovedTo *string
+}
+
+func fetchRSSFeed(ctx context.Context, httpClient *http.Client, source model.Source) (rssFetchResult, error) {
+	out := rssFetchResult{ETag: source.FeedETag, LastModified: source.FeedLastModified}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
 	if err != nil {
-		return nil, false, source.FeedETag, source.FeedLastModified, err
+		return out, err
 	}
 	req.Header.Set("User-Agent", "Sifto RSS Fetcher/1.0")
 	if source.FeedETag != nil && strings.TrimSpace(*source.FeedETag) != "" {
@@ -996,33 +1077,90 @@ func fetchRSSFeed(ctx context.Context, httpClient *http.Client, source model.Sou
 	}
 	res, err := httpClient.Do(req)
 	if err != nil {
-		return nil, false, source.FeedETag, source.FeedLastModified, err
+		return out, err
 	}
 	defer res.Body.Close()
-	etag := headerValueOrPrevious(res.Header.Get("ETag"), source.FeedETag)
-	lastModified := headerValueOrPrevious(res.Header.Get("Last-Modified"), source.FeedLastModified)
+	out.ETag = headerValueOrPrevious(res.Header.Get("ETag"), source.FeedETag)
+	out.LastModified = headerValueOrPrevious(res.Header.Get("Last-Modified"), source.FeedLastModified)
 	if res.StatusCode == http.StatusNotModified {
-		return nil, true, etag, lastModified, nil
+		out.NotModified = true
+		out.MovedTo = permanentRedirectTarget(res, source.URL)
+		return out, nil
 	}
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
-		return nil, false, etag, lastModified, fmt.Errorf("unexpected RSS response status: %s", res.Status)
+		return out, fmt.Errorf("unexpected RSS response status: %s", res.Status)
 	}
 	const maxRSSBytes = 10 << 20
 	if res.ContentLength > maxRSSBytes {
-		return nil, false, etag, lastModified, fmt.Errorf("RSS response exceeds %d bytes", maxRSSBytes)
+		return out, fmt.Errorf("RSS response exceeds %d bytes", maxRSSBytes)
 	}
 	body, err := io.ReadAll(io.LimitReader(res.Body, maxRSSBytes+1))
 	if err != nil {
-		return nil, false, etag, lastModified, err
+		return out, err
 	}
 	if len(body) > maxRSSBytes {
-		return nil, false, etag, lastModified, fmt.Errorf("RSS response exceeds %d bytes", maxRSSBytes)
+		return out, fmt.Errorf("RSS response exceeds %d bytes", maxRSSBytes)
 	}
 	feed, err := gofeed.NewParser().Parse(bytes.NewReader(body))
 	if err != nil {
-		return nil, false, etag, lastModified, err
+		return out, err
+	}
+	out.Feed = feed
+	out.MovedTo = permanentRedirectTarget(res, source.URL)
+	return out, nil
+}
+
+// permanentRedirectTarget walks the redirect chain behind res and returns the
+// final URL only when every hop was a permanent redirect. Temporary redirects
+// (302/303/307) must keep polling the original URL.
+func permanentRedirectTarget(res *http.Response, originalURL string) *string {
+	if res == nil || res.Request == nil || res.Request.URL == nil {
+		return nil
+	}
+	hops := 0
+	for req := res.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		switch req.Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+			hops++
+		default:
+			return nil
+		}
+	}
+	if hops == 0 {
+		return nil
+	}
+	finalURL := res.Request.URL.String()
+	if finalURL == "" || finalURL == strings.TrimSpace(originalURL) {
+		return nil
+	}
+	return &finalURL
+}
+
+// handleFeedPermanentRedirect either moves the source to movedTo (when the user
+// opted into auto-migration) or records it as a proposal for confirmation.
+func handleFeedPermanentRedirect(ctx context.Context, sourceRepo *repository.SourceRepo, userSettingsRepo *repository.UserSettingsRepo, src model.Source, movedTo string) {
+	autoMigrate, err := userSettingsRepo.IsFeedAutoMigrateEnabled(ctx, src.UserID)
+	if err != nil {
+		log.Printf("load feed auto migrate setting user_id=%s: %v", src.UserID, err)
+	}
+	if autoMigrate {
+		_, err := sourceRepo.MigrateURL(ctx, src.ID, src.UserID, movedTo)
+		if err == nil {
+			log.Printf("rss source migrated source_id=%s from=%s to=%s", src.ID, src.URL, movedTo)
+			return
+		}
+		// A conflict means the user already has a source for movedTo; fall
+		// back to a proposal so the duplicate can be resolved manually.
+		if !errors.Is(err, repository.ErrConflict) {
+			log.Printf("migrate rss source %s: %v", src.URL, err)
+		}
+	}
+	if src.ProposedURL != nil && *src.ProposedURL == movedTo {
+		return
+	}
+	if err := sourceRepo.SetProposedURL(ctx, src.ID, movedTo); err != nil {
+		log.Printf("record proposed rss url %s: %v", src.URL, err)
 	}
-	return feed, false, etag, lastModified, nil
 }
 
 func headerValueOrPrevious(value string, previous *string) *string {
@@ -1080,6 +1218,7 @@ func runItemBulkJobFn(client inngestgo.Client, db *pgxpool.Pool, cache service.J
 		},
 		inngestgo.EventTrigger("item-bulk-job/run", nil),
 		func(ctx context.Context, input inngestgo.Input[ItemBulkJobRunData]) (any, error) {
+			ctx = withRunRequestID(ctx, input.Event.Data.RequestID, input.InputCtx.RunID)
 			jobID := strings.TrimSpace(input.Event.Data.JobID)
 			if jobID == "" {
 				return nil, fmt.Errorf("item bulk job id is required")
@@ -1103,7 +1242,7 @@ func runItemBulkJobFn(client inngestgo.Client, db *pgxpool.Pool, cache service.J
 					continue
 				}
 				reason := string(job.Action)
-				if _, err := client.Send(ctx, service.NewItemCreatedEvent(resetItem.ID, resetItem.SourceID, resetItem.URL, nil, reason)); err != nil {
+				if _, err := client.Send(ctx, service.StampRequestID(ctx, service.NewItemCreatedEvent(resetItem.ID, resetItem.SourceID, job.UserID, resetItem.URL, nil, reason))); err != nil {
 					log.Printf("item bulk job enqueue failed job_id=%s item_id=%s err=%v", jobID, candidate.ID, err)
 					_ = itemRepo.MarkItemBulkJobItemSkipped(ctx, jobID, candidate.ID, err.Error())
 					continue
@@ -1149,24 +1288,34 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 	deps := processItemDeps{
 		itemRepo:           repository.NewItemInngestRepo(db),
 		itemViewRepo:       repository.NewItemRepo(db),
+		entityRepo:         repository.NewEntityRepo(db),
+		topicTaxonomy:      service.NewTopicTaxonomyService(repository.NewTopicRepo(db)),
 		llmUsageRepo:       repository.NewLLMUsageLogRepo(db),
 		llmExecutionRepo:   repository.NewLLMExecutionEventRepo(db),
+		processingEvents:   repository.NewItemProcessingEventRepo(db),
 		sourceRepo:         repository.NewSourceRepo(db),
 		userSettingsRepo:   repository.NewUserSettingsRepo(db),
+		preferenceRepo:     repository.NewPreferenceProfileRepo(db),
 		userRepo:           repository.NewUserRepo(db),
 		pushLogRepo:        repository.NewPushNotificationLogRepo(db),
 		notificationRepo:   repository.NewNotificationPriorityRepo(db),
 		readingGoalRepo:    repository.NewReadingGoalRepo(db),
 		promptResolver:     service.NewPromptResolver(repository.NewPromptTemplateRepo(db)),
+		budgetGuard:        service.NewBudgetGuard(repository.NewLLMUsageLogRepo(db)).WithAllocations(repository.NewLLMBudgetAllocationRepo(db)),
+		usageLimits:        service.NewUsageLimitService(repository.NewUsageLimitRepo(db)),
 		worker:             worker,
+		extractor:          service.NewDefaultBodyExtractionChain(worker),
 		openAI:             openAI,
 		oneSignal:          oneSignal,
 		publisher:          mustEventPublisher(),
 		keyProvider:        keyProvider,
 		cache:              cache,
+		cooldown:           service.NewProviderCooldown(cache),
+		responseCache:      service.NewLLMResponseCache(cache),
 		pickScoreThreshold: envFloat64OrDefault("ONESIGNAL_PICK_SCORE_THRESHOLD", 0.90),
 		pickMaxPerDay:      envIntOrDefault("ONESIGNAL_PICK_MAX_PER_DAY", 2),
 	}
+	userConcurrency := service.ProcessItemUserConcurrency()
 
 	return inngestgo.CreateFunction(
 		client,
@@ -1177,6 +1326,10 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 				{
 					Limit: 5,
 				},
+				{
+					Limit: userConcurrency,
+					Key:   inngestgo.StrPtr("event.data.user_id"),
+				},
 			},
 			Throttle: &inngestgo.ConfigThrottle{
 				Limit:  30,
@@ -1188,10 +1341,13 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 		func(ctx context.Context, input inngestgo.Input[processItemEventData]) (any, error) {
 			data := input.Event.Data
 			ctx = withLLMExecutionTrigger(ctx, data.TriggerID, data.Reason)
+			ctx = withRunRequestID(ctx, data.RequestID, input.InputCtx.RunID)
 			itemID := data.ItemID
 			url := data.URL
 			var userIDPtr *string
-			if data.SourceID != "" {
+			if data.UserID != "" {
+				userIDPtr = &data.UserID
+			} else if data.SourceID != "" {
 				if uid, err := deps.sourceRepo.GetUserIDBySourceID(ctx, data.SourceID); err == nil {
 					userIDPtr = &uid
 				} else {
@@ -1202,18 +1358,56 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 			if userIDPtr != nil && *userIDPtr != "" {
 				userModelSettings, _ = deps.userSettingsRepo.GetByUserID(ctx, *userIDPtr)
 			}
-			log.Printf("process-item start item_id=%s url=%s trigger_id=%s reason=%s", itemID, url, strings.TrimSpace(data.TriggerID), strings.TrimSpace(data.Reason))
+			log.Printf("process-item start item_id=%s url=%s trigger_id=%s reason=%s request_id=%s", itemID, url, strings.TrimSpace(data.TriggerID), strings.TrimSpace(data.Reason), service.RequestIDFromContext(ctx))
+			budget, err := runTracedStep(ctx, deps, data, itemID, "budget-guard", nil, func(ctx context.Context) (service.BudgetGuardStatus, error) {
+				return deps.budgetGuard.CheckPurposes(ctx, userModelSettings, service.LLMBudgetPurposeFacts, service.LLMBudgetPurposeSummary)
+			})
+			if err != nil {
+				log.Printf("process-item budget-guard failed item_id=%s err=%v", itemID, err)
+			} else if budget.Exceeded {
+				log.Printf("process-item budget-deferred item_id=%s purpose=%s used_usd=%.4f cap_usd=%.4f", itemID, budget.Purpose, budget.UsedCostUSD, budget.CapUSD)
+				deferred, err := markProcessItemBudgetDeferred(ctx, deps.itemRepo, deps.cache, itemID)
+				if err != nil {
+					return nil, err
+				}
+				if !deferred {
+					return map[string]string{"item_id": itemID, "status": "skipped", "reason": "not_deferrable"}, nil
+				}
+				return map[string]string{"item_id": itemID, "status": "budget_deferred"}, nil
+			}
+			// The plan's daily LLM item limit covers manual adds, imports and reprocessing too,
+			// not only what fetch-rss creates. Deferred items resume once the JST day rolls over.
+			if userIDPtr != nil && *userIDPtr != "" {
+				allowance, err := runTracedStep(ctx, deps, data, itemID, "usage-limit", nil, func(ctx context.Context) (int, error) {
+					return deps.usageLimits.LLMItemAllowance(ctx, *userIDPtr)
+				})
+				if err != nil {
+					log.Printf("process-item usage-limit failed item_id=%s err=%v", itemID, err)
+				} else if allowance == 0 {
+					log.Printf("process-item usage-limited item_id=%s user_id=%s", itemID, *userIDPtr)
+					deferred, err := markProcessItemBudgetDeferred(ctx, deps.itemRepo, deps.cache, itemID)
+					if err != nil {
+						return nil, err
+					}
+					if !deferred {
+						return map[string]string{"item_id": itemID, "status": "skipped", "reason": "not_deferrable"}, nil
+					}
+					return map[string]string{"item_id": itemID, "status": "usage_limited"}, nil
+				}
+			}
+			if userIDPtr != nil && *userIDPtr != "" {
+				waitForProcessingSlot(ctx, deps, *userIDPtr, itemID, service.EffectiveLLMConcurrency(userModelSettings, userConcurrency))
+			}
 
 			var extracted *service.ExtractBodyResponse
-			var err error
 			for attempt := 0; attempt < 3; attempt++ {
 				stepLabel := "extract-body"
 				if attempt > 0 {
 					stepLabel = fmt.Sprintf("extract-body-%d", attempt+1)
 				}
-				extracted, err = step.Run(ctx, stepLabel, func(ctx context.Context) (*service.ExtractBodyResponse, error) {
+				extracted, err = runTracedStep(ctx, deps, data, itemID, stepLabel, nil, func(ctx context.Context) (*service.ExtractBodyResponse, error) {
 					log.Printf("process-item extract-body start item_id=%s attempt=%d", itemID, attempt+1)
-					return deps.worker.ExtractBody(ctx, url)
+					return deps.extractor.ExtractBody(ctx, url)
 				})
 				if err == nil {
 					break
@@ -1233,13 +1427,40 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 				return nil, markProcessItemDeleted(ctx, deps.itemRepo, deps.cache, itemID, reason, fmt.Errorf("content rejected after extract"))
 			}
 
-			if err := updateItemAfterExtract(ctx, deps.itemRepo, itemID, extracted); err != nil {
+			titleForLLM := resolveProcessItemTitleForLLM(extracted.Title, data.Title)
+			contentHash := service.ItemContentHash(ptrStringValue(titleForLLM), extracted.Content)
+			if err := updateItemAfterExtract(ctx, deps.itemRepo, itemID, extracted, contentHash); err != nil {
 				log.Printf("process-item update-after-extract failed item_id=%s err=%v", itemID, err)
 				return nil, fmt.Errorf("update after extract: %w", err)
 			}
 			bumpProcessItemDetailCacheVersion(ctx, deps.cache, itemID)
 			log.Printf("process-item update-after-extract done item_id=%s", itemID)
-			titleForLLM := resolveProcessItemTitleForLLM(extracted.Title, data.Title)
+			// A paused user's items wait in 'fetched' until resuming queues them again.
+			if service.ProcessingPaused(userModelSettings) {
+				log.Printf("process-item paused item_id=%s user_id=%s", itemID, userModelSettings.UserID)
+				releaseProcessingSlot(ctx, deps, itemID)
+				return map[string]string{"item_id": itemID, "status": "paused"}, nil
+			}
+			summaryInputHash := service.ItemSummaryInputHash(contentHash, userModelSettings,
+				service.ResolvePromptResolution(ctx, deps.promptResolver, factsPromptInput(itemID)),
+				service.ResolvePromptResolution(ctx, deps.promptResolver, summaryPromptInput(itemID)),
+			)
+			unchanged, err := reuseUnchangedSummary(ctx, deps, data, itemID, userIDPtr, summaryInputHash)
+			if err != nil {
+				return nil, err
+			}
+			if unchanged {
+				releaseProcessingSlot(ctx, deps, itemID)
+				return map[string]string{"item_id": itemID, "status": "unchanged"}, nil
+			}
+			skipped, err := prescreenItem(ctx, deps, data, itemID, userIDPtr, userModelSettings, titleForLLM, extracted.Content)
+			if err != nil {
+				return nil, err
+			}
+			if skipped {
+				releaseProcessingSlot(ctx, deps, itemID)
+				return map[string]string{"item_id": itemID, "status": "prescreened"}, nil
+			}
 			factsStage, err := extractAndPersistFacts(ctx, deps, data, itemID, userIDPtr, userModelSettings, titleForLLM, extracted.Content)
 			if err != nil {
 				return nil, err
@@ -1248,8 +1469,10 @@ func processItemFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wo
 			if err != nil {
 				return nil, err
 			}
+			storeSummaryInputHash(ctx, deps, data, itemID, summaryInputHash)
 			sendPickNotificationIfNeeded(ctx, deps, itemID, url, userIDPtr, titleForLLM, summaryStage.Summary)
 			createEmbeddingIfPossible(ctx, deps, data, itemID, userIDPtr, userModelSettings, titleForLLM, summaryStage.Summary, factsStage.Facts.Facts)
+			releaseProcessingSlot(ctx, deps, itemID)
 			log.Printf("process-item complete item_id=%s", itemID)
 
 			return map[string]string{"item_id": itemID, "status": "summarized"}, nil
@@ -1270,6 +1493,7 @@ func embedItemFn(client inngestgo.Client, db *pgxpool.Pool, openAI *service.Open
 	llmUsageRepo := repository.NewLLMUsageLogRepo(db)
 	llmExecutionRepo := repository.NewLLMExecutionEventRepo(db)
 	userSettingsRepo := repository.NewUserSettingsRepo(db)
+	budgetGuard := service.NewBudgetGuard(llmUsageRepo).WithAllocations(repository.NewLLMBudgetAllocationRepo(db))
 
 	type EventData struct {
 		ItemID   string `json:"item_id"`
@@ -1296,12 +1520,18 @@ func embedItemFn(client inngestgo.Client, db *pgxpool.Pool, openAI *service.Open
 				return nil, err
 			}
 			userModelSettings, _ := userSettingsRepo.GetByUserID(ctx, userID)
+			if service.ProcessingPaused(userModelSettings) {
+				return map[string]any{"item_id": candidate.ItemID, "status": "skipped", "reason": "processing_paused"}, nil
+			}
+			budget, err := step.Run(ctx, "budget-guard", func(ctx context.Context) (service.BudgetGuardStatus, error) {
+				return budgetGuard.CheckPurposes(ctx, userModelSettings, service.LLMBudgetPurposeEmbedding)
+			})
+			if err == nil && budget.Exceeded {
+				return map[string]any{"item_id": candidate.ItemID, "status": "skipped", "reason": "budget_cap"}, nil
+			}
 
 			inputText := buildItemEmbeddingInput(candidate.Title, candidate.Summary, candidate.Topics, candidate.Facts)
-			embModel := service.OpenAIEmbeddingModel()
-			if userModelSettings != nil && userModelSettings.EmbeddingModel != nil && service.IsSupportedOpenAIEmbeddingModel(*userModelSettings.EmbeddingModel) {
-				embModel = *userModelSettings.EmbeddingModel
-			}
+			embModel := service.UserEmbeddingModel(userModelSettings)
 			embResp, err := step.Run(ctx, "create-embedding", func(ctx context.Context) (*service.CreateEmbeddingResponse, error) {
 				return openAI.CreateEmbedding(ctx, *userOpenAIKey, embModel, inputText)
 			})
@@ -1350,27 +1580,51 @@ func buildItemEmbeddingInput(title *string, summary string, topics, facts []stri
 	return out
 }
 
+// generateDigestFn runs hourly. At DailyDigestHourJST it builds every user's daily digest;
+// every hour it also builds the scoped digest configs scheduled for that JST hour.
 func generateDigestFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
 	userRepo := repository.NewUserRepo(db)
 	itemRepo := repository.NewItemInngestRepo(db)
 	digestRepo := repository.NewDigestInngestRepo(db)
+	digestConfigRepo := repository.NewDigestConfigRepo(db)
+	userSettingsRepo := repository.NewUserSettingsRepo(db)
 
 	return inngestgo.CreateFunction(
 		client,
 		inngestgo.FunctionOpts{ID: "generate-digest", Name: "Generate Daily Digest"},
-		inngestgo.CronTrigger("0 21 * * *"),
+		inngestgo.CronTrigger("0 * * * *"),
 		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
-			users, err := userRepo.ListAll(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("list users: %w", err)
-			}
-
-			today := timeutil.StartOfDayJST(timeutil.NowJST())
-			since := today.AddDate(0, 0, -1)
+			ctx = withRunRequestID(ctx, "", input.InputCtx.RunID)
+			now := timeutil.NowJST()
+			today := timeutil.StartOfDayJST(now)
 
 			created := 0
+			catchUps := 0
 			skippedSent := 0
+			skippedQuiet := 0
+			skippedPaused := 0
+			var users []model.User
+			if now.Hour() == service.DailyDigestHourJST {
+				var err error
+				users, err = userRepo.ListAll(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("list users: %w", err)
+				}
+			}
 			for _, u := range users {
+				settings, err := userSettingsRepo.GetByUserID(ctx, u.ID)
+				if err != nil && !errors.Is(err, repository.ErrNotFound) {
+					log.Printf("load digest schedule for %s: %v", u.Email, err)
+				}
+				if service.ProcessingPaused(settings) {
+					skippedPaused++
+					continue
+				}
+				if service.IsDigestQuietDay(settings, today) {
+					skippedQuiet++
+					continue
+				}
+				since, catchUp := service.DigestWindowStart(settings, today)
 				items, err := itemRepo.ListSummarizedForUser(ctx, u.ID, since, today)
 				if err != nil || len(items) == 0 {
 					continue
@@ -1385,34 +1639,100 @@ func generateDigestFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.Serv
 					skippedSent++
 					continue
 				}
+				mode, windowStart := service.DigestComposeModeDaily, (*time.Time)(nil)
+				if catchUp {
+					mode, windowStart = service.DigestComposeModeCatchUp, &since
+					catchUps++
+				}
+				if err := digestRepo.UpdateComposeWindow(ctx, digestID, mode, windowStart); err != nil {
+					log.Printf("update digest compose window for %s: %v", u.Email, err)
+				}
 
-				if _, err := client.Send(ctx, inngestgo.Event{
+				if _, err := client.Send(ctx, service.StampRequestID(ctx, inngestgo.Event{
 					Name: "digest/created",
 					Data: map[string]any{
 						"digest_id": digestID,
 						"user_id":   u.ID,
 						"to":        u.Email,
 					},
-				}); err != nil {
+				})); err != nil {
 					log.Printf("send digest/created: %v", err)
 				}
 				created++
 			}
+
+			scopedCreated := 0
+			configs, err := digestConfigRepo.ListDue(ctx, now.Hour(), int(now.Weekday()), today)
+			if err != nil {
+				return nil, fmt.Errorf("list due digest configs: %w", err)
+			}
+			for _, cfg := range configs {
+				user, err := userRepo.GetByID(ctx, cfg.UserID)
+				if err != nil {
+					log.Printf("load user for digest config %s: %v", cfg.ID, err)
+					continue
+				}
+				if settings, err := userSettingsRepo.GetByUserID(ctx, cfg.UserID); err == nil && service.ProcessingPaused(settings) {
+					skippedPaused++
+					continue
+				}
+				since, until := service.DigestConfigWindow(cfg, now)
+				items, err := itemRepo.ListSummarizedForDigestConfig(ctx, cfg, since, until)
+				if err != nil {
+					log.Printf("list items for digest config %s: %v", cfg.ID, err)
+					continue
+				}
+				if err := digestConfigRepo.MarkGenerated(ctx, cfg.ID, now); err != nil {
+					log.Printf("mark digest config %s generated: %v", cfg.ID, err)
+				}
+				if len(items) == 0 {
+					continue
+				}
+				digestID, alreadySent, err := digestRepo.CreateForConfig(ctx, cfg.UserID, cfg.ID, today, items)
+				if err != nil {
+					log.Printf("create digest for config %s: %v", cfg.ID, err)
+					continue
+				}
+				if alreadySent {
+					skippedSent++
+					continue
+				}
+				if _, err := client.Send(ctx, service.StampRequestID(ctx, inngestgo.Event{
+					Name: "digest/created",
+					Data: map[string]any{
+						"digest_id": digestID,
+						"user_id":   cfg.UserID,
+						"to":        user.Email,
+					},
+				})); err != nil {
+					log.Printf("send digest/created: %v", err)
+				}
+				scopedCreated++
+			}
 			return map[string]int{
-				"digests_created":      created,
-				"digests_skipped_sent": skippedSent,
+				"digests_created":        created,
+				"digests_catch_up":       catchUps,
+				"digests_skipped_sent":   skippedSent,
+				"digests_skipped_quiet":  skippedQuiet,
+				"digests_skipped_paused": skippedPaused,
+				"scoped_digests_created": scopedCreated,
 			}, nil
 		},
 	)
 }
 
-func composeDigestCopyFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, keyProvider *service.UserKeyProvider) (inngestgo.ServableFunction, error) {
+func composeDigestCopyFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, keyProvider *service.UserKeyProvider, cache service.JSONCache) (inngestgo.ServableFunction, error) {
 	digestRepo := repository.NewDigestInngestRepo(db)
 	itemRepo := repository.NewItemRepo(db)
 	llmUsageRepo := repository.NewLLMUsageLogRepo(db)
 	llmExecutionRepo := repository.NewLLMExecutionEventRepo(db)
 	userSettingsRepo := repository.NewUserSettingsRepo(db)
 	promptResolver := service.NewPromptResolver(repository.NewPromptTemplateRepo(db))
+	secretCipher := service.NewSecretCipher()
+	ttsMarkupPreprocessSvc := service.NewTTSMarkupPreprocessService(userSettingsRepo, secretCipher, worker, llmUsageRepo, cache)
+	summaryAudioSvc := service.NewSummaryAudioPlayerService(itemRepo, repository.NewSummaryAudioVoiceSettingsRepo(db), repository.NewUserRepo(db), userSettingsRepo, secretCipher, worker, ttsMarkupPreprocessSvc)
+	digestAudioSvc := service.NewDigestAudioService(summaryAudioSvc, worker)
+	budgetGuard := service.NewBudgetGuard(llmUsageRepo).WithAllocations(repository.NewLLMBudgetAllocationRepo(db))
 
 	return inngestgo.CreateFunction(
 		client,
@@ -1420,7 +1740,8 @@ func composeDigestCopyFn(client inngestgo.Client, db *pgxpool.Pool, worker *serv
 		inngestgo.EventTrigger("digest/created", nil),
 		func(ctx context.Context, input inngestgo.Input[DigestCreatedData]) (any, error) {
 			data := input.Event.Data
-			log.Printf("compose-digest-copy start digest_id=%s", data.DigestID)
+			ctx = withRunRequestID(ctx, data.RequestID, input.InputCtx.RunID)
+			log.Printf("compose-digest-copy start digest_id=%s request_id=%s", data.DigestID, service.RequestIDFromContext(ctx))
 			markStatus := func(status string, sendErr error) {
 				var msg *string
 				if sendErr != nil {
@@ -1448,31 +1769,72 @@ func composeDigestCopyFn(client inngestgo.Client, db *pgxpool.Pool, worker *serv
 				markStatus("skipped_no_items", nil)
 				return map[string]string{"status": "skipped", "reason": "no items"}, nil
 			}
+			if (digest.EmailSubject == nil || digest.EmailBody == nil) && service.ProcessingPaused(userModelSettings) {
+				log.Printf("compose-digest-copy skip-paused digest_id=%s", data.DigestID)
+				markStatus("skipped_paused", nil)
+				return map[string]string{"status": "skipped", "reason": "processing_paused"}, nil
+			}
+			if digest.EmailSubject == nil || digest.EmailBody == nil {
+				budget, err := step.Run(ctx, "budget-guard", func(ctx context.Context) (service.BudgetGuardStatus, error) {
+					return budgetGuard.CheckPurposes(ctx, userModelSettings, service.LLMBudgetPurposeDigest)
+				})
+				if err != nil {
+					log.Printf("compose-digest-copy budget-guard failed digest_id=%s err=%v", data.DigestID, err)
+				} else if budget.Exceeded {
+					log.Printf("compose-digest-copy skip-budget digest_id=%s purpose=%s used_usd=%.4f cap_usd=%.4f", data.DigestID, budget.Purpose, budget.UsedCostUSD, budget.CapUSD)
+					markStatus("skipped_budget", nil)
+					return map[string]string{"status": "skipped", "reason": "budget_cap"}, nil
+				}
+			}
 			markStatus("processing", nil)
 
 			if digest.EmailSubject != nil && digest.EmailBody != nil {
 				log.Printf("compose-digest-copy reuse-copy digest_id=%s", data.DigestID)
 			} else {
-				_, err := step.Run(ctx, "compose-digest-copy", func(ctx context.Context) (string, error) {
-					if err := composeDigestEmailCopy(ctx, digestRepo, itemRepo, userSettingsRepo, llmUsageRepo, llmExecutionRepo, processItemDeps{worker: worker, keyProvider: keyProvider, promptResolver: promptResolver}, data, digest, userModelSettings); err != nil {
-						return "", err
-					}
-					return "stored", nil
-				})
+				// composeDigestEmailCopy runs its drafts, sections and final call as separate steps.
+				err := composeDigestEmailCopy(ctx, digestRepo, itemRepo, userSettingsRepo, llmUsageRepo, llmExecutionRepo, processItemDeps{worker: worker, keyProvider: keyProvider, promptResolver: promptResolver}, data, digest, userModelSettings)
 				if err != nil {
 					markStatus("compose_failed", err)
 					return nil, fmt.Errorf("compose digest copy: %w", err)
 				}
 			}
 
-			if _, err := client.Send(ctx, inngestgo.Event{
+			if userModelSettings != nil && userModelSettings.DigestAudioEnabled {
+				if _, err := step.Run(ctx, "generate-digest-audio", func(ctx context.Context) (string, error) {
+					generateDigestAudioIfEnabled(ctx, digestRepo, digestAudioSvc, data, userModelSettings)
+					return "done", nil
+				}); err != nil {
+					log.Printf("compose-digest-copy audio step failed digest_id=%s err=%v", data.DigestID, err)
+				}
+			}
+
+			if userModelSettings != nil && userModelSettings.DigestApprovalEnabled {
+				if err := digestRepo.MarkAwaitingApproval(ctx, data.DigestID); err != nil {
+					markStatus("approval_request_failed", err)
+					return nil, fmt.Errorf("mark awaiting approval: %w", err)
+				}
+				if _, err := client.Send(ctx, service.StampRequestID(ctx, inngestgo.Event{
+					Name: "digest/approval-requested",
+					Data: map[string]any{
+						"digest_id": data.DigestID,
+						"user_id":   data.UserID,
+						"to":        data.To,
+					},
+				})); err != nil {
+					log.Printf("compose-digest-copy approval notify failed digest_id=%s err=%v", data.DigestID, err)
+				}
+				log.Printf("compose-digest-copy awaiting-approval digest_id=%s", data.DigestID)
+				return map[string]string{"status": service.DigestSendStatusAwaitingApproval, "digest_id": data.DigestID}, nil
+			}
+
+			if _, err := client.Send(ctx, service.StampRequestID(ctx, inngestgo.Event{
 				Name: "digest/copy-composed",
 				Data: map[string]any{
 					"digest_id": data.DigestID,
 					"user_id":   data.UserID,
 					"to":        data.To,
 				},
-			}); err != nil {
+			})); err != nil {
 				markStatus("enqueue_send_failed", err)
 				return nil, fmt.Errorf("send digest/copy-composed: %w", err)
 			}
@@ -1482,10 +1844,12 @@ func composeDigestCopyFn(client inngestgo.Client, db *pgxpool.Pool, worker *serv
 	)
 }
 
-func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, resend *service.ResendClient, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
-	_ = worker
+func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, emails *service.EmailSenderResolver, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
 	digestRepo := repository.NewDigestInngestRepo(db)
+	recipientRepo := repository.NewDigestRecipientRepo(db)
+	digestAudioSvc := service.NewDigestAudioService(nil, worker)
 	userSettingsRepo := repository.NewUserSettingsRepo(db)
+	digestLinks := service.NewDigestLinkSignerFromEnv()
 
 	return inngestgo.CreateFunction(
 		client,
@@ -1493,6 +1857,7 @@ func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wor
 		inngestgo.EventTrigger("digest/copy-composed", nil),
 		func(ctx context.Context, input inngestgo.Input[DigestCopyComposedData]) (any, error) {
 			data := input.Event.Data
+			ctx = withRunRequestID(ctx, data.RequestID, input.InputCtx.RunID)
 			log.Printf("send-digest start digest_id=%s to=%s", data.DigestID, data.To)
 			markStatus := func(status string, sendErr error) {
 				var msg *string
@@ -1518,9 +1883,21 @@ func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wor
 				markStatus("compose_failed", err)
 				return nil, err
 			}
-			if !resend.Enabled() {
-				markStatus("skipped_resend_disabled", nil)
-				return map[string]string{"status": "skipped", "reason": "resend_disabled"}, nil
+			if digest.SendStatus != nil && *digest.SendStatus == service.DigestSendStatusAwaitingApproval {
+				log.Printf("send-digest skip-awaiting-approval digest_id=%s", data.DigestID)
+				return map[string]string{"status": "skipped", "reason": "awaiting_approval"}, nil
+			}
+			sender := emails.ForUser(ctx, data.UserID)
+			if sender == nil || !sender.Enabled() {
+				transport := service.EmailTransportResend
+				if sender != nil {
+					transport = sender.Transport()
+				}
+				markStatus("skipped_"+transport+"_disabled", nil)
+				return map[string]string{"status": "skipped", "reason": transport + "_disabled"}, nil
+			}
+			if err := digestRepo.UpdateSendTransport(ctx, data.DigestID, sender.Transport()); err != nil {
+				log.Printf("send-digest update-transport failed digest_id=%s err=%v", data.DigestID, err)
 			}
 			digestEmailEnabled, err := userSettingsRepo.IsDigestEmailEnabled(ctx, data.UserID)
 			if err != nil {
@@ -1531,24 +1908,45 @@ func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wor
 				markStatus("skipped_user_disabled", nil)
 				return map[string]string{"status": "skipped", "reason": "user_disabled"}, nil
 			}
+			locale, err := userSettingsRepo.GetLocale(ctx, data.UserID)
+			if err != nil {
+				log.Printf("send-digest load locale failed user_id=%s err=%v", data.UserID, err)
+				locale = service.DefaultLocale
+			}
 			markStatus("processing", nil)
 
+			emailCopy := &service.DigestEmailCopy{
+				Subject:  *digest.EmailSubject,
+				Body:     *digest.EmailBody,
+				AudioURL: digestEmailAudioURL(ctx, digestRepo, digestAudioSvc, data.DigestID),
+			}
+			if digestLinks != nil {
+				emailCopy.FeedbackURL = func(itemID, action string) string {
+					return digestLinks.URL(data.UserID, itemID, action)
+				}
+				emailCopy.UnsubscribeURL = digestLinks.UnsubscribeURL(service.DigestEmailScopeOwner, data.UserID)
+				emailCopy.PreferencesURL = digestLinks.PreferencesURL(data.UserID)
+			}
 			_, err = step.Run(ctx, "send-email", func(ctx context.Context) (string, error) {
-				if err := resend.SendDigest(ctx, data.To, digest, &service.DigestEmailCopy{
-					Subject: *digest.EmailSubject,
-					Body:    *digest.EmailBody,
-				}); err != nil {
+				if err := service.SendDigestEmail(ctx, sender, data.To, locale, digest, emailCopy); err != nil {
 					return "", err
 				}
 				return "sent", nil
 			})
 			if err != nil {
 				markStatus("send_email_failed", err)
+				if rErr := recipientRepo.RecordDelivery(ctx, data.DigestID, nil, data.To, "failed", truncateDeliveryError(err)); rErr != nil {
+					log.Printf("send-digest record delivery failed digest_id=%s err=%v", data.DigestID, rErr)
+				}
 				return nil, fmt.Errorf("send email: %w", err)
 			}
+			if err := recipientRepo.RecordDelivery(ctx, data.DigestID, nil, data.To, "sent", nil); err != nil {
+				log.Printf("send-digest record delivery failed digest_id=%s err=%v", data.DigestID, err)
+			}
 			if err := digestRepo.UpdateSentAt(ctx, data.DigestID); err != nil {
 				log.Printf("update sent_at: %v", err)
 			}
+			recipientsSent, recipientsFailed := sendDigestToRecipients(ctx, recipientRepo, sender, digestLinks, locale, digest, emailCopy)
 			if oneSignal != nil && oneSignal.Enabled() {
 				_, pErr := oneSignal.SendToExternalID(
 					ctx,
@@ -1566,27 +1964,145 @@ func sendDigestFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.Wor
 					log.Printf("send-digest push failed digest_id=%s to=%s: %v", data.DigestID, data.To, pErr)
 				}
 			}
-			log.Printf("send-digest complete digest_id=%s", data.DigestID)
-			return map[string]string{"status": "sent", "to": data.To}, nil
+			log.Printf("send-digest complete digest_id=%s transport=%s recipients_sent=%d recipients_failed=%d", data.DigestID, sender.Transport(), recipientsSent, recipientsFailed)
+			return map[string]any{
+				"status":            "sent",
+				"to":                data.To,
+				"transport":         sender.Transport(),
+				"recipients_sent":   recipientsSent,
+				"recipients_failed": recipientsFailed,
+			}, nil
 		},
 	)
 }
 
-func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *service.ResendClient, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
+// resumeBudgetDeferredFn backfills items and digests that were paused by the budget cap once
+// the user is back under it, either because the JST month rolled over or the cap was raised.
+// Items deferred by the daily LLM item limit resume within the day's new allowance.
+func resumeBudgetDeferredFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	settingsRepo := repository.NewUserSettingsRepo(db)
+	itemRepo := repository.NewItemInngestRepo(db)
+	digestRepo := repository.NewDigestInngestRepo(db)
+	budgetGuard := service.NewBudgetGuard(repository.NewLLMUsageLogRepo(db)).WithAllocations(repository.NewLLMBudgetAllocationRepo(db))
+	usageLimits := service.NewUsageLimitService(repository.NewUsageLimitRepo(db))
+	const perUserLimit = 200
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "resume-budget-deferred", Name: "Resume Budget Deferred Processing"},
+		inngestgo.CronTrigger("30 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			ctx = withRunRequestID(ctx, "", input.InputCtx.RunID)
+			userIDs, err := itemRepo.ListBudgetDeferredUserIDs(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("list budget deferred users: %w", err)
+			}
+			since := timeutil.StartOfDayJST(timeutil.NowJST()).AddDate(0, 0, -2)
+			resumedUsers := 0
+			resumedItems := 0
+			resumedDigests := 0
+			for _, userID := range userIDs {
+				settings, err := settingsRepo.GetByUserID(ctx, userID)
+				if err != nil {
+					log.Printf("resume-budget-deferred settings user_id=%s: %v", userID, err)
+					continue
+				}
+				itemBudget, err := budgetGuard.CheckPurposes(ctx, settings, service.LLMBudgetPurposeFacts, service.LLMBudgetPurposeSummary)
+				if err != nil {
+					log.Printf("resume-budget-deferred budget user_id=%s: %v", userID, err)
+					continue
+				}
+				allowance, err := usageLimits.LLMItemAllowance(ctx, userID)
+				if err != nil {
+					log.Printf("resume-budget-deferred usage limit user_id=%s: %v", userID, err)
+					continue
+				}
+				var items []repository.ItemBudgetDeferredTarget
+				if !itemBudget.Exceeded && allowance != 0 {
+					limit := perUserLimit
+					if allowance > 0 {
+						limit = min(limit, allowance)
+					}
+					items, err = itemRepo.ReleaseBudgetDeferred(ctx, userID, limit)
+					if err != nil {
+						log.Printf("resume-budget-deferred release user_id=%s: %v", userID, err)
+					}
+				}
+				for _, it := range items {
+					if _, err := client.Send(ctx, service.StampRequestID(ctx, service.NewItemCreatedEvent(it.ItemID, it.SourceID, userID, it.URL, it.Title, "budget_resume"))); err != nil {
+						log.Printf("resume-budget-deferred send item/created item_id=%s: %v", it.ItemID, err)
+						continue
+					}
+					resumedItems++
+				}
+				var digests []repository.DigestBudgetSkippedTarget
+				if digestBudget, err := budgetGuard.CheckPurposes(ctx, settings, service.LLMBudgetPurposeDigest); err != nil {
+					log.Printf("resume-budget-deferred digest budget user_id=%s: %v", userID, err)
+				} else if !digestBudget.Exceeded {
+					digests, err = digestRepo.ListBudgetSkipped(ctx, userID, since)
+					if err != nil {
+						log.Printf("resume-budget-deferred list digests user_id=%s: %v", userID, err)
+					}
+				}
+				for _, d := range digests {
+					if _, err := client.Send(ctx, service.StampRequestID(ctx, inngestgo.Event{
+						Name: "digest/created",
+						Data: map[string]any{
+							"digest_id": d.DigestID,
+							"user_id":   userID,
+							"to":        d.Email,
+						},
+					})); err != nil {
+						log.Printf("resume-budget-deferred send digest/created digest_id=%s: %v", d.DigestID, err)
+						continue
+					}
+					resumedDigests++
+				}
+				if len(items) > 0 || len(digests) > 0 {
+					resumedUsers++
+				}
+			}
+			return map[string]int{
+				"users_checked":   len(userIDs),
+				"users_resumed":   resumedUsers,
+				"items_resumed":   resumedItems,
+				"digests_resumed": resumedDigests,
+			}, nil
+		},
+	)
+}
+
+// reconcileModelPricingFn reprices usage rows that were logged with unknown pricing once an
+// admin has added the model to the pricing catalog.
+func reconcileModelPricingFn(client inngestgo.Client, db *pgxpool.Pool, cache service.JSONCache) (inngestgo.ServableFunction, error) {
+	pricingSvc := service.NewModelPricingService(repository.NewModelPricingRepo(db), repository.NewLLMUsageLogRepo(db), cache)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "reconcile-model-pricing", Name: "Reconcile Unknown Model Pricing"},
+		inngestgo.CronTrigger("45 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			result, err := pricingSvc.Reconcile(ctx, 2000, false)
+			if err != nil {
+				return nil, fmt.Errorf("reconcile model pricing: %w", err)
+			}
+			return result, nil
+		},
+	)
+}
+
+func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, emails *service.EmailSenderResolver, oneSignal *service.OneSignalClient) (inngestgo.ServableFunction, error) {
 	settingsRepo := repository.NewUserSettingsRepo(db)
 	alertLogRepo := repository.NewBudgetAlertLogRepo(db)
 	forecastAlertLogRepo := repository.NewBudgetForecastAlertLogRepo(db)
 	llmUsageRepo := repository.NewLLMUsageLogRepo(db)
+	forecastSvc := service.NewLLMUsageForecastService(llmUsageRepo, nil)
 
 	return inngestgo.CreateFunction(
 		client,
 		inngestgo.FunctionOpts{ID: "check-budget-alerts", Name: "Check Monthly Budget Alerts"},
 		inngestgo.CronTrigger("0 0 * * *"),
 		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
-			if (resend == nil || !resend.Enabled()) && (oneSignal == nil || !oneSignal.Enabled()) {
-				return map[string]any{"status": "skipped", "reason": "no_budget_alert_channel"}, nil
-			}
-
 			targets, err := settingsRepo.ListBudgetAlertTargets(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("list budget alert targets: %w", err)
@@ -1595,8 +2111,6 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 			nowJST := timeutil.NowJST()
 			monthStartJST := time.Date(nowJST.Year(), nowJST.Month(), 1, 0, 0, 0, 0, timeutil.JST)
 			nextMonthJST := monthStartJST.AddDate(0, 1, 0)
-			daysInMonth := nextMonthJST.AddDate(0, 0, -1).Day()
-			elapsedDays := nowJST.Day()
 			checked := 0
 			sent := 0
 			skipped := 0
@@ -1615,14 +2129,30 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 				remainingRatio := (tgt.MonthlyBudgetUSD - usedCostUSD) / tgt.MonthlyBudgetUSD
 				thresholdRatio := float64(tgt.BudgetAlertThresholdPct) / 100.0
 				remainingUSD := tgt.MonthlyBudgetUSD - usedCostUSD
-				monthAvgDailyPace := 0.0
-				if elapsedDays > 0 {
-					monthAvgDailyPace = usedCostUSD / float64(elapsedDays)
+				budgetUSD := tgt.MonthlyBudgetUSD
+				forecast, err := forecastSvc.ForecastWithBudget(ctx, tgt.UserID, &budgetUSD, nowJST)
+				if err != nil {
+					log.Printf("check-budget-alerts forecast user_id=%s: %v", tgt.UserID, err)
+				}
+				forecastCostUSD := usedCostUSD
+				forecastReliable := false
+				var exceedDateJST string
+				if forecast != nil {
+					forecastCostUSD = forecast.ProjectedMonthEndUSD
+					forecastReliable = forecast.Reliable()
+					if forecast.ProjectedExceedDateJST != nil {
+						exceedDateJST = *forecast.ProjectedExceedDateJST
+					}
 				}
-				forecastCostUSD := monthAvgDailyPace * float64(daysInMonth)
 				forecastDeltaUSD := forecastCostUSD - tgt.MonthlyBudgetUSD
 
 				sentThisTarget := false
+				sender := emails.ForUser(ctx, tgt.UserID)
+				emailEnabled := sender != nil && sender.Enabled()
+				if !emailEnabled && (oneSignal == nil || !oneSignal.Enabled()) {
+					skipped++
+					continue
+				}
 
 				if remainingRatio < thresholdRatio {
 					alreadySent, err := alertLogRepo.Exists(ctx, tgt.UserID, monthStartJST, tgt.BudgetAlertThresholdPct)
@@ -1631,8 +2161,9 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 					} else if !alreadySent {
 						emailSent := false
 						pushSent := false
-						if resend != nil && resend.Enabled() {
-							if err := resend.SendBudgetAlert(ctx, tgt.Email, service.BudgetAlertEmail{
+						if emailEnabled {
+							if err := service.SendBudgetAlertEmail(ctx, sender, tgt.Email, service.BudgetAlertEmail{
+								Locale:             tgt.Locale,
 								MonthJST:           monthStartJST.Format("2006-01"),
 								MonthlyBudgetUSD:   tgt.MonthlyBudgetUSD,
 								UsedCostUSD:        usedCostUSD,
@@ -1673,7 +2204,7 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 					}
 				}
 
-				shouldForecastAlert := usedCostUSD > tgt.MonthlyBudgetUSD || (elapsedDays >= 3 && forecastDeltaUSD > 0)
+				shouldForecastAlert := usedCostUSD > tgt.MonthlyBudgetUSD || (forecastReliable && forecastDeltaUSD > 0)
 				if shouldForecastAlert {
 					forecastSent, err := forecastAlertLogRepo.Exists(ctx, tgt.UserID, monthStartJST)
 					if err != nil {
@@ -1681,13 +2212,15 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 					} else if !forecastSent {
 						emailSent := false
 						pushSent := false
-						if resend != nil && resend.Enabled() {
-							if err := resend.SendBudgetForecastAlert(ctx, tgt.Email, service.BudgetForecastAlertEmail{
+						if emailEnabled {
+							if err := service.SendBudgetForecastAlertEmail(ctx, sender, tgt.Email, service.BudgetForecastAlertEmail{
+								Locale:           tgt.Locale,
 								MonthJST:         monthStartJST.Format("2006-01"),
 								MonthlyBudgetUSD: tgt.MonthlyBudgetUSD,
 								UsedCostUSD:      usedCostUSD,
 								ForecastCostUSD:  forecastCostUSD,
 								ForecastDeltaUSD: forecastDeltaUSD,
+								ExceedDateJST:    exceedDateJST,
 							}); err != nil {
 								log.Printf("check-budget-alerts forecast email user_id=%s email=%s: %v", tgt.UserID, tgt.Email, err)
 							} else {
@@ -1696,6 +2229,9 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 						}
 						if oneSignal != nil && oneSignal.Enabled() {
 							message := fmt.Sprintf("月末着地予測が予算を $%.4f 上回っています。", forecastDeltaUSD)
+							if exceedDate, err := time.ParseInLocation("2006-01-02", exceedDateJST, timeutil.JST); err == nil {
+								message = fmt.Sprintf("現在のペースでは%d日頃に今月のLLM予算を超過する見込みです。", exceedDate.Day())
+							}
 							if usedCostUSD > tgt.MonthlyBudgetUSD {
 								message = "今月のLLM予算をすでに超過しています。"
 							}
@@ -1710,6 +2246,7 @@ func checkBudgetAlertsFn(client inngestgo.Client, db *pgxpool.Pool, resend *serv
 									"month_jst":          monthStartJST.Format("2006-01"),
 									"forecast_cost_usd":  forecastCostUSD,
 									"forecast_delta_usd": forecastDeltaUSD,
+									"exceed_date_jst":    exceedDateJST,
 									"target_url":         appPageURL("/llm-usage"),
 								},
 							); pErr != nil {
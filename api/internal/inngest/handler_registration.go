@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 	"github.com/inngest/inngestgo"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,25 +17,31 @@ type ItemCreatedData struct {
 }
 
 type DigestCreatedData struct {
-	DigestID string `json:"digest_id"`
-	UserID   string `json:"user_id"`
-	To       string `json:"to"`
+	DigestID           string  `json:"digest_id"`
+	UserID             string  `json:"user_id"`
+	To                 string  `json:"to"`
+	ModelOverride      *string `json:"model_override,omitempty"`
+	ReuseClusterDrafts bool    `json:"reuse_cluster_drafts,omitempty"`
+	RequestID          string  `json:"request_id,omitempty"`
 }
 
 type DigestCopyComposedData struct {
-	DigestID string `json:"digest_id"`
-	UserID   string `json:"user_id"`
-	To       string `json:"to"`
+	DigestID  string `json:"digest_id"`
+	UserID    string `json:"user_id"`
+	To        string `json:"to"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type ItemBulkJobRunData struct {
 	JobID     string `json:"job_id"`
 	Trigger   string `json:"trigger"`
 	TriggerID string `json:"trigger_id"`
+	RequestID string `json:"request_id"`
 }
 
 func NewHandler(db *pgxpool.Pool, worker *service.WorkerClient, resend *service.ResendClient, oneSignal *service.OneSignalClient, obsidianExport *service.ObsidianExportService, cache service.JSONCache, search *service.MeilisearchService, keyProvider *service.UserKeyProvider) http.Handler {
 	openAI := service.NewOpenAIClient()
+	emailSenders := service.NewEmailSenderResolver(service.NewDefaultEmailSender(resend), repository.NewUserSettingsRepo(db), service.NewSecretCipher())
 	llmUsageCache = cache
 	_ = search
 	client, err := service.NewInngestClient("sifto-api")
@@ -50,9 +57,13 @@ func NewHandler(db *pgxpool.Pool, worker *service.WorkerClient, resend *service.
 
 	register(fetchRSSFn(client, db))
 	register(runItemBulkJobFn(client, db, cache))
+	register(runImportJobFn(client, db))
 	register(processItemFn(client, db, worker, openAI, oneSignal, keyProvider, cache))
 	register(itemSearchUpsertFn(client, db, search))
 	register(itemSearchDeleteFn(client, search))
+	register(captureItemSnapshotFn(client, db, worker))
+	register(enrichItemDiscussionsFn(client, db))
+	register(refreshItemDiscussionsFn(client, db))
 	register(searchSuggestionArticleUpsertFn(client, db, search))
 	register(searchSuggestionArticleDeleteFn(client, search))
 	register(searchSuggestionSourceUpsertFn(client, db, search))
@@ -72,11 +83,30 @@ func NewHandler(db *pgxpool.Pool, worker *service.WorkerClient, resend *service.
 	register(failStaleAudioBriefingVoicingFn(client, db))
 	register(moveAudioBriefingsToIAFn(client, db, worker))
 	register(generateDigestFn(client, db))
-	register(composeDigestCopyFn(client, db, worker, keyProvider))
-	register(sendDigestFn(client, db, worker, resend, oneSignal))
-	register(checkBudgetAlertsFn(client, db, resend, oneSignal))
+	register(composeDigestCopyFn(client, db, worker, keyProvider, cache))
+	register(sendDigestFn(client, db, worker, emailSenders, oneSignal))
+	register(sendDigestRecipientConfirmationFn(client, db, emailSenders))
+	register(notifyDigestApprovalFn(client, db, emailSenders, oneSignal))
+	register(autoApproveDigestsFn(client, db))
+	register(checkBudgetAlertsFn(client, db, emailSenders, oneSignal))
+	register(evaluateTopicAlertsFn(client, db, emailSenders, oneSignal))
+	register(sendWeeklyRecapsFn(client, db, emailSenders))
+	register(resumeBudgetDeferredFn(client, db))
+	register(reprocessStuckItemsFn(client, db))
+	register(continueProcessingBackfillFn(client, db))
+	register(retryFailedDigestsFn(client, db))
+	register(reconcileModelPricingFn(client, db, cache))
 	register(computePreferenceProfilesFn(client, db))
+	register(analyzeFeedbackReasonsFn(client, db))
+	register(buildStoriesFn(client, db))
+	register(storyNotesFn(client, db, worker, keyProvider))
+	register(normalizeTopicsFn(client, db))
 	register(computeTopicPulseDailyFn(client, db))
+	register(computeMetricsDailyFn(client, db))
+	register(rebuildSourceCoSubscriptionsFn(client, db))
+	register(syncInoreaderSubscriptionsFn(client, db))
+	register(precomputeReadingPlansFn(client, db))
+	register(composeCollectionSummariesFn(client, db, worker, keyProvider))
 	register(generateAINavigatorBriefsFn(client, db, worker, oneSignal))
 	register(runAINavigatorBriefPipelineFn(client, db, worker, oneSignal, llmUsageCache))
 
@@ -50,6 +50,14 @@ func recordLLMUsage(ctx context.Context, repo *repository.LLMUsageLogRepo, purpo
 		return
 	}
 	idempotencyKey := llmUsageIdempotencyKey(purpose, usage, userID, sourceID, itemID, digestID, prompt)
+	if usage.PricingSource == service.LLMPricingSourceCache {
+		// Cache hits carry no tokens, so two runs of the same item would share a key; the
+		// trigger keeps each run's hit.
+		if trigger := llmExecutionTriggerFromContext(ctx); trigger != nil {
+			sum := sha256.Sum256([]byte(idempotencyKey + "|trigger=" + trigger.TriggerID))
+			idempotencyKey = hex.EncodeToString(sum[:])
+		}
+	}
 	pricingSource := usage.PricingSource
 	if pricingSource == "" {
 		pricingSource = "unknown"
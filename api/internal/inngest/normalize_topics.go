@@ -0,0 +1,38 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// normalizeTopicsFn keeps the topic taxonomy up to date: new labels get an alias and
+// summaries still carrying non-canonical labels are rewritten.
+func normalizeTopicsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	taxonomy := service.NewTopicTaxonomyService(repository.NewTopicRepo(db))
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "normalize-topics", Name: "Normalize Topics"},
+		inngestgo.CronTrigger("40 19 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			result, err := taxonomy.Normalize(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("normalize topics: %w", err)
+			}
+			slog.Info("normalize-topics: done",
+				"raw_topics", result.RawTopics,
+				"created_topics", result.CreatedTopics,
+				"lexical_aliases", result.LexicalAliases,
+				"embedding_aliases", result.EmbeddingAliases,
+				"rewritten_summaries", result.RewrittenSummaries,
+			)
+			return result, nil
+		},
+	)
+}
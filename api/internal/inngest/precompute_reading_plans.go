@@ -0,0 +1,64 @@
+package inngest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// precomputeReadingPlansFn stores each active user's reading plan, computed with their saved
+// settings, so GET /items/reading-plan can be served without running MMR and clustering.
+func precomputeReadingPlansFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	itemRepo := repository.NewItemRepo(db)
+	settingsRepo := repository.NewUserSettingsRepo(db)
+	snapshotRepo := repository.NewReadingPlanSnapshotRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "precompute-reading-plans", Name: "Precompute Reading Plans"},
+		inngestgo.CronTrigger("25 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			userIDs, err := listRecentlyActiveUserIDs(ctx, db)
+			if err != nil {
+				return nil, fmt.Errorf("list active users: %w", err)
+			}
+
+			stored := 0
+			failed := 0
+			for _, uid := range userIDs {
+				settings, err := settingsRepo.GetByUserID(ctx, uid)
+				if errors.Is(err, repository.ErrNotFound) {
+					settings, err = nil, nil
+				}
+				if err != nil {
+					slog.Error("precompute-reading-plans: settings failed", "user_id", uid, "error", err)
+					failed++
+					continue
+				}
+				params := service.ReadingPlanParamsFromSettings(settings)
+				plan, err := itemRepo.ReadingPlan(ctx, uid, params)
+				if err != nil {
+					slog.Error("precompute-reading-plans: plan failed", "user_id", uid, "error", err)
+					failed++
+					continue
+				}
+				plan.Source = "snapshot"
+				if err := snapshotRepo.Upsert(ctx, uid, params, plan); err != nil {
+					slog.Error("precompute-reading-plans: store failed", "user_id", uid, "error", err)
+					failed++
+					continue
+				}
+				stored++
+			}
+
+			slog.Info("precompute-reading-plans: done", "users", len(userIDs), "stored", stored, "failed", failed)
+			return map[string]any{"users": len(userIDs), "stored": stored, "failed": failed}, nil
+		},
+	)
+}
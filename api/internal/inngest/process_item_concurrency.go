@@ -0,0 +1,52 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/inngest/inngestgo/step"
+)
+
+const (
+	processItemSlotBaseWait    = 20 * time.Second
+	processItemSlotMaxWait     = 2 * time.Minute
+	processItemSlotMaxAttempts = 90
+)
+
+// processItemSlotDelay doubles the wait between slot claims, from 20s up to 2m.
+func processItemSlotDelay(attempt int) time.Duration {
+	d := processItemSlotBaseWait
+	for i := 0; i < attempt && d < processItemSlotMaxWait; i++ {
+		d *= 2
+	}
+	return min(d, processItemSlotMaxWait)
+}
+
+// waitForProcessingSlot holds the run until one of the user's processing slots is free. The
+// Inngest concurrency key caps a user at the server ceiling; the slot table enforces a lower
+// llm_max_concurrency and lets the item detail report queue positions. It fails open on
+// errors and after processItemSlotMaxAttempts so a broken gate cannot stall the pipeline.
+func waitForProcessingSlot(ctx context.Context, deps processItemDeps, userID, itemID string, limit int) {
+	for attempt := 0; attempt < processItemSlotMaxAttempts; attempt++ {
+		acquired, err := step.Run(ctx, fmt.Sprintf("acquire-slot-%d", attempt+1), func(ctx context.Context) (bool, error) {
+			return deps.itemRepo.AcquireProcessingSlot(ctx, userID, itemID, limit)
+		})
+		if err != nil {
+			log.Printf("process-item acquire-slot failed item_id=%s user_id=%s err=%v", itemID, userID, err)
+			return
+		}
+		if acquired {
+			return
+		}
+		step.Sleep(ctx, fmt.Sprintf("wait-slot-%d", attempt+1), processItemSlotDelay(attempt))
+	}
+	log.Printf("process-item slot wait exhausted item_id=%s user_id=%s limit=%d", itemID, userID, limit)
+}
+
+func releaseProcessingSlot(ctx context.Context, deps processItemDeps, itemID string) {
+	if err := deps.itemRepo.ReleaseProcessingSlot(ctx, itemID); err != nil {
+		log.Printf("process-item release-slot failed item_id=%s err=%v", itemID, err)
+	}
+}
@@ -0,0 +1,18 @@
+package inngest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProcessItemSlotDelayBacksOffToCap(t *testing.T) {
+	want := []time.Duration{20 * time.Second, 40 * time.Second, 80 * time.Second, 2 * time.Minute, 2 * time.Minute}
+	for attempt, w := range want {
+		if got := processItemSlotDelay(attempt); got != w {
+			t.Fatalf("processItemSlotDelay(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+	if got := processItemSlotDelay(80); got != processItemSlotMaxWait {
+		t.Fatalf("processItemSlotDelay(80) = %v", got)
+	}
+}
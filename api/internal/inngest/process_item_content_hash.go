@@ -0,0 +1,67 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+// reuseUnchangedSummary short-circuits fact extraction and summarization when the item's stored
+// summary was made from the same content, model settings and prompts (inputHash). It reports
+// true when the existing summary was kept and the item is complete. A forced reprocess never
+// reuses.
+func reuseUnchangedSummary(
+	ctx context.Context,
+	deps processItemDeps,
+	data processItemEventData,
+	itemID string,
+	userIDPtr *string,
+	inputHash string,
+) (bool, error) {
+	if inputHash == "" || data.Reason == service.ItemForceReprocessReason {
+		return false, nil
+	}
+	reusable, err := runTracedStep(ctx, deps, data, itemID, "summary-unchanged-check", nil, func(ctx context.Context) (bool, error) {
+		return deps.itemRepo.ReusableSummary(ctx, itemID, inputHash)
+	})
+	if err != nil {
+		log.Printf("process-item summary-unchanged-check failed item_id=%s err=%v", itemID, err)
+		return false, nil
+	}
+	if !reusable {
+		return false, nil
+	}
+	if _, err := runTracedStep(ctx, deps, data, itemID, "summary-unchanged-apply", nil, func(ctx context.Context) (bool, error) {
+		if err := deps.itemRepo.MarkSummarized(ctx, itemID); err != nil {
+			return false, fmt.Errorf("mark summarized: %w", err)
+		}
+		if userID := ptrStringValue(userIDPtr); userID != "" {
+			if err := deps.itemViewRepo.PersistPersonalScores(ctx, userID, []string{itemID}); err != nil {
+				log.Printf("process-item personal score persist failed item_id=%s user_id=%s err=%v", itemID, userID, err)
+			}
+			bumpProcessUserItemsCacheVersion(ctx, deps.cache, userID)
+		}
+		bumpProcessItemDetailCacheVersion(ctx, deps.cache, itemID)
+		if err := deps.publisher.SendItemSearchUpsertE(ctx, itemID); err != nil {
+			log.Printf("process-item search upsert event failed item_id=%s err=%v", itemID, err)
+		}
+		return true, nil
+	}); err != nil {
+		return false, err
+	}
+	log.Printf("process-item summary unchanged item_id=%s", itemID)
+	return true, nil
+}
+
+// storeSummaryInputHash records the inputs the new summary was made from, so a later retry with
+// the same inputs can keep it.
+func storeSummaryInputHash(ctx context.Context, deps processItemDeps, data processItemEventData, itemID, inputHash string) {
+	_, err := runTracedStep(ctx, deps, data, itemID, "summary-input-hash", nil, func(ctx context.Context) (bool, error) {
+		return true, deps.itemRepo.SetSummaryInputHash(ctx, itemID, inputHash)
+	})
+	if err != nil {
+		log.Printf("process-item summary input hash store failed item_id=%s err=%v", itemID, err)
+	}
+}
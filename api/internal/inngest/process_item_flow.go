@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -13,36 +14,47 @@ import (
 	"github.com/enjoydarts/sifto/api/internal/repository"
 	"github.com/enjoydarts/sifto/api/internal/service"
 	"github.com/enjoydarts/sifto/api/internal/timeutil"
-	"github.com/inngest/inngestgo/step"
+	"github.com/enjoydarts/sifto/api/internal/urlutil"
 )
 
 type processItemEventData struct {
 	ItemID    string `json:"item_id"`
 	SourceID  string `json:"source_id"`
+	UserID    string `json:"user_id"`
 	URL       string `json:"url"`
 	Title     string `json:"title"`
 	TriggerID string `json:"trigger_id"`
 	Reason    string `json:"reason"`
+	RequestID string `json:"request_id"`
 }
 
 type processItemDeps struct {
 	itemRepo           *repository.ItemInngestRepo
 	itemViewRepo       *repository.ItemRepo
+	entityRepo         *repository.EntityRepo
+	topicTaxonomy      *service.TopicTaxonomyService
 	llmUsageRepo       *repository.LLMUsageLogRepo
 	llmExecutionRepo   *repository.LLMExecutionEventRepo
+	processingEvents   itemProcessingEventWriter
 	sourceRepo         *repository.SourceRepo
 	userSettingsRepo   *repository.UserSettingsRepo
+	preferenceRepo     *repository.PreferenceProfileRepo
 	userRepo           *repository.UserRepo
 	pushLogRepo        *repository.PushNotificationLogRepo
 	notificationRepo   *repository.NotificationPriorityRepo
 	readingGoalRepo    *repository.ReadingGoalRepo
 	worker             *service.WorkerClient
+	extractor          service.BodyExtractor
 	openAI             *service.OpenAIClient
 	oneSignal          *service.OneSignalClient
 	publisher          *service.EventPublisher
 	keyProvider        *service.UserKeyProvider
 	cache              service.JSONCache
+	cooldown           *service.ProviderCooldown
+	responseCache      *service.LLMResponseCache
 	promptResolver     *service.PromptResolver
+	budgetGuard        *service.BudgetGuard
+	usageLimits        *service.UsageLimitService
 	pickScoreThreshold float64
 	pickMaxPerDay      int
 }
@@ -196,6 +208,9 @@ func isTransientLLMWorkerError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if service.IsWorkerUnavailable(err) {
+		return true
+	}
 	msg := strings.ToLower(strings.TrimSpace(err.Error()))
 	if msg == "" {
 		return false
@@ -362,6 +377,19 @@ func markProcessItemFailed(ctx context.Context, itemRepo *repository.ItemInngest
 	return fmt.Errorf("%s: %w", stage, err)
 }
 
+func markProcessItemBudgetDeferred(ctx context.Context, itemRepo *repository.ItemInngestRepo, cache service.JSONCache, itemID string) (bool, error) {
+	deferred, err := itemRepo.MarkBudgetDeferred(ctx, itemID)
+	if err != nil {
+		return false, fmt.Errorf("mark budget deferred: %w", err)
+	}
+	if !deferred {
+		log.Printf("process-item budget-defer no-op item_id=%s: item is not in a deferrable status", itemID)
+		return false, nil
+	}
+	bumpProcessItemDetailCacheVersion(ctx, cache, itemID)
+	return true, nil
+}
+
 func markProcessItemDeleted(ctx context.Context, itemRepo *repository.ItemInngestRepo, cache service.JSONCache, itemID, reason string, err error) error {
 	msg := fmt.Sprintf("%s: %v", reason, err)
 	_ = itemRepo.MarkDeleted(ctx, itemID, &msg)
@@ -407,20 +435,29 @@ func extractAndPersistFacts(
 	currentModelOverride := primaryModelOverride
 	usingFallback := false
 	sameModelRetried := false
-	factsPromptResolution := service.ResolvePromptResolution(ctx, deps.promptResolver, service.PromptResolveInput{
-		PromptKey:      "facts.default",
-		AssignmentUnit: "item_id",
-		AssignmentKey:  itemID,
-	})
+	factsPromptResolution := service.ResolvePromptResolution(ctx, deps.promptResolver, factsPromptInput(itemID))
 	factsPromptConfig := service.WorkerPromptConfigFromResolution(factsPromptResolution)
+	factsCache := lookupLLMResponse(ctx, deps, "facts-cache-lookup", "facts", userIDPtr, primaryModelOverride, factsPromptConfig, func(c *service.CachedFacts) bool {
+		return c.Facts != nil && len(c.Facts.Facts) > 0 && c.Check != nil && c.Check.Verdict != "fail"
+	}, ptrStringValue(titleForLLM), content)
+	var factsAcceptedModel *string
+	if hit := factsCache.Hit; hit != nil {
+		hit.ServedFromCache()
+		factsResp, finalFactsCheck = hit.Facts, hit.Check
+		recordLLMUsage(ctx, deps.llmUsageRepo, "facts", factsResp.LLM, userIDPtr, &data.SourceID, &itemID, nil, factsPromptResolution)
+		recordLLMUsage(ctx, deps.llmUsageRepo, "facts_localization", factsResp.FactsLocalizationLLM, userIDPtr, &data.SourceID, &itemID, nil, nil)
+		recordLLMUsage(ctx, deps.llmUsageRepo, "facts_check", finalFactsCheck.LLM, userIDPtr, &data.SourceID, &itemID, nil, nil)
+		log.Printf("process-item extract-facts cache-hit item_id=%s facts=%d", itemID, len(factsResp.Facts))
+	}
 
-	for attempt := 0; attempt < maxFactsAttempts; attempt++ {
+	for attempt := 0; factsCache.Hit == nil && attempt < maxFactsAttempts; attempt++ {
 		stepLabel := "extract-facts"
 		if attempt > 0 {
 			stepLabel = fmt.Sprintf("extract-facts-%d", attempt+1)
 		}
 		var currentRuntime *llmRuntime
-		factsAttempt, err := step.Run(ctx, stepLabel, func(ctx context.Context) (*processFactsAttemptResult, error) {
+		factsModel := func() *string { return executionFailedModel(currentRuntime, currentModelOverride) }
+		factsAttempt, err := runLLMStep(ctx, deps, data, itemID, stepLabel, userIDPtr, currentModelOverride, factsModel, func(ctx context.Context) (*processFactsAttemptResult, error) {
 			log.Printf("process-item extract-facts start item_id=%s attempt=%d", itemID, attempt+1)
 			runtime, err := resolveLLMRuntime(ctx, deps.keyProvider, userIDPtr, currentModelOverride, "facts")
 			if err != nil {
@@ -475,6 +512,7 @@ func extractAndPersistFacts(
 		}
 
 		factsResp = factsAttempt.Facts
+		factsAcceptedModel = factsAttempt.Runtime.Model
 		recordLLMExecutionFailuresFromUsage(ctx, deps.llmExecutionRepo, "facts", factsResp.LLM, attempt, userIDPtr, &data.SourceID, &itemID, nil, factsPromptResolution)
 		recordLLMUsage(ctx, deps.llmUsageRepo, "facts", factsResp.LLM, userIDPtr, &data.SourceID, &itemID, nil, factsPromptResolution)
 		recordLLMExecutionFailuresFromUsage(ctx, deps.llmExecutionRepo, "facts_localization", factsResp.FactsLocalizationLLM, attempt, userIDPtr, &data.SourceID, &itemID, nil, nil)
@@ -573,6 +611,11 @@ func extractAndPersistFacts(
 	if err := deps.itemRepo.InsertFacts(ctx, itemID, factsResp.Facts); err != nil {
 		return nil, fmt.Errorf("insert facts: %w", err)
 	}
+	if deps.entityRepo != nil {
+		if err := deps.entityRepo.ReplaceItemEntities(ctx, itemID, service.ExtractEntities(factsResp.Facts)); err != nil {
+			log.Printf("process-item entities failed item_id=%s err=%v", itemID, err)
+		}
+	}
 	var factsCheckComment *string
 	if comment := strings.TrimSpace(finalFactsCheck.ShortComment); comment != "" {
 		factsCheckComment = &comment
@@ -583,6 +626,7 @@ func extractAndPersistFacts(
 	if finalFactsCheck.Verdict == "fail" {
 		return nil, markProcessItemFailed(ctx, deps.itemRepo, deps.cache, itemID, "facts check", fmt.Errorf("%s", finalFactsCheck.ShortComment))
 	}
+	storeLLMResponse(ctx, deps, factsCache, factsAcceptedModel, &service.CachedFacts{Facts: factsResp, Check: finalFactsCheck})
 	bumpProcessItemDetailCacheVersion(ctx, deps.cache, itemID)
 	log.Printf("process-item insert-facts done item_id=%s retries=%d facts_check=%s", itemID, factsRetryCount, finalFactsCheck.Verdict)
 
@@ -609,14 +653,37 @@ func summarizeAndPersistItem(
 	var summary *service.SummarizeResponse
 	var finalFaithfulness *service.SummaryFaithfulnessResponse
 	var summaryRetryCount int
-	summaryPromptResolution := service.ResolvePromptResolution(ctx, deps.promptResolver, service.PromptResolveInput{
-		PromptKey:      "summary.default",
-		AssignmentUnit: "item_id",
-		AssignmentKey:  itemID,
-	})
+	summaryPromptResolution := service.ResolvePromptResolution(ctx, deps.promptResolver, summaryPromptInput(itemID))
 	summaryPromptConfig := service.WorkerPromptConfigFromResolution(summaryPromptResolution)
+	var summaryTargetLanguage *string
+	if userModelSettings != nil {
+		summaryTargetLanguage = service.NormalizeOutputLanguage(userModelSettings.OutputLanguage)
+	}
+	var summaryLookupModel *string
+	if userModelSettings != nil {
+		summaryLookupModel = service.ChooseSplitPrimaryModelWithUsage(
+			ctx,
+			deps.cache,
+			ptrStringValue(userIDPtr),
+			"summary",
+			ptrStringOrNil(userModelSettings.SummaryModel),
+			ptrStringOrNil(userModelSettings.SummarySecondaryModel),
+			userModelSettings.SummarySecondaryRatePercent,
+		)
+	}
+	summaryCache := lookupLLMResponse(ctx, deps, "summary-cache-lookup", "summary", userIDPtr, summaryLookupModel, summaryPromptConfig, func(c *service.CachedSummary) bool {
+		return c.Summary != nil && strings.TrimSpace(c.Summary.Summary) != "" && c.Check != nil && c.Check.Verdict != "fail"
+	}, ptrStringValue(titleForLLM), strings.Join(facts, "\n"), strconv.Itoa(len(sourceContent)), ptrStringValue(summaryTargetLanguage))
+	var summaryAcceptedModel *string
+	if hit := summaryCache.Hit; hit != nil {
+		hit.ServedFromCache()
+		summary, finalFaithfulness = hit.Summary, hit.Check
+		recordLLMUsage(ctx, deps.llmUsageRepo, "summary", summary.LLM, userIDPtr, &data.SourceID, &itemID, nil, summaryPromptResolution)
+		recordLLMUsage(ctx, deps.llmUsageRepo, "faithfulness_check", finalFaithfulness.LLM, userIDPtr, &data.SourceID, &itemID, nil, nil)
+		log.Printf("process-item summarize cache-hit item_id=%s", itemID)
+	}
 
-	for attempt := 0; attempt <= maxSummaryFaithfulnessRetries; attempt++ {
+	for attempt := 0; summaryCache.Hit == nil && attempt <= maxSummaryFaithfulnessRetries; attempt++ {
 		stepLabel := "summarize"
 		if attempt > 0 {
 			stepLabel = fmt.Sprintf("summarize-%d", attempt+1)
@@ -630,19 +697,25 @@ func summarizeAndPersistItem(
 			summaryPrimaryModel = ptrStringOrNil(userModelSettings.SummaryModel)
 			summarySecondaryModel = ptrStringOrNil(userModelSettings.SummarySecondaryModel)
 			summarySecondaryRatePercent = userModelSettings.SummarySecondaryRatePercent
-			primaryModelOverride = service.ChooseSplitPrimaryModelWithUsage(
-				ctx,
-				deps.cache,
-				ptrStringValue(userIDPtr),
-				"summary",
-				summaryPrimaryModel,
-				summarySecondaryModel,
-				summarySecondaryRatePercent,
-			)
+			if attempt == 0 {
+				// The cache lookup already picked the first attempt's model.
+				primaryModelOverride = summaryLookupModel
+			} else {
+				primaryModelOverride = service.ChooseSplitPrimaryModelWithUsage(
+					ctx,
+					deps.cache,
+					ptrStringValue(userIDPtr),
+					"summary",
+					summaryPrimaryModel,
+					summarySecondaryModel,
+					summarySecondaryRatePercent,
+				)
+			}
 			fallbackModelOverride = ptrStringOrNil(userModelSettings.SummaryFallbackModel)
 		}
 		var primaryRuntime *llmRuntime
-		summaryAttempt, err := step.Run(ctx, stepLabel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
+		primaryModel := func() *string { return executionFailedModel(primaryRuntime, primaryModelOverride) }
+		summaryAttempt, err := runLLMStep(ctx, deps, data, itemID, stepLabel, userIDPtr, primaryModelOverride, primaryModel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
 			log.Printf("process-item summarize start item_id=%s attempt=%d", itemID, attempt+1)
 			runtime, err := resolveLLMRuntime(ctx, deps.keyProvider, userIDPtr, primaryModelOverride, "summary")
 			if err != nil {
@@ -651,7 +724,7 @@ func summarizeAndPersistItem(
 			primaryRuntime = runtime
 			sourceChars := len(sourceContent)
 			workerCtx := service.WithWorkerTraceMetadata(ctx, "summary", userIDPtr, &data.SourceID, &itemID, nil)
-			resp, err := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig)
+			resp, err := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig, summaryTargetLanguage)
 			if err != nil {
 				return nil, err
 			}
@@ -670,7 +743,8 @@ func summarizeAndPersistItem(
 				retryStepLabel := stepLabel + "-retry"
 				log.Printf("process-item summarize retry-same-model item_id=%s attempt=%d model=%s", itemID, attempt+1, ptrStringValue(failedModel))
 				var retryRuntime *llmRuntime
-				retryAttempt, retryErr := step.Run(ctx, retryStepLabel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
+				retryModel := func() *string { return executionFailedModel(retryRuntime, primaryModelOverride) }
+				retryAttempt, retryErr := runLLMStep(ctx, deps, data, itemID, retryStepLabel, userIDPtr, primaryModelOverride, retryModel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
 					log.Printf("process-item summarize retry start item_id=%s attempt=%d", itemID, attempt+1)
 					runtime, runtimeErr := resolveLLMRuntime(ctx, deps.keyProvider, userIDPtr, primaryModelOverride, "summary")
 					if runtimeErr != nil {
@@ -679,7 +753,7 @@ func summarizeAndPersistItem(
 					retryRuntime = runtime
 					sourceChars := len(sourceContent)
 					workerCtx := service.WithWorkerTraceMetadata(ctx, "summary", userIDPtr, &data.SourceID, &itemID, nil)
-					resp, workerErr := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig)
+					resp, workerErr := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig, summaryTargetLanguage)
 					if workerErr != nil {
 						return nil, workerErr
 					}
@@ -700,7 +774,8 @@ func summarizeAndPersistItem(
 				fallbackStepLabel := stepLabel + "-fallback"
 				log.Printf("process-item summarize fallback item_id=%s attempt=%d primary_model=%s fallback_model=%s", itemID, attempt+1, ptrStringValue(failedModel), ptrStringValue(fallbackModelOverride))
 				var fallbackRuntime *llmRuntime
-				fallbackAttempt, fallbackErr := step.Run(ctx, fallbackStepLabel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
+				fallbackModel := func() *string { return executionFailedModel(fallbackRuntime, fallbackModelOverride) }
+				fallbackAttempt, fallbackErr := runLLMStep(ctx, deps, data, itemID, fallbackStepLabel, userIDPtr, fallbackModelOverride, fallbackModel, func(ctx context.Context) (*processSummaryAttemptResult, error) {
 					log.Printf("process-item summarize fallback start item_id=%s attempt=%d", itemID, attempt+1)
 					runtime, runtimeErr := resolveLLMRuntime(ctx, deps.keyProvider, userIDPtr, fallbackModelOverride, "summary")
 					if runtimeErr != nil {
@@ -709,7 +784,7 @@ func summarizeAndPersistItem(
 					fallbackRuntime = runtime
 					sourceChars := len(sourceContent)
 					workerCtx := service.WithWorkerTraceMetadata(ctx, "summary", userIDPtr, &data.SourceID, &itemID, nil)
-					resp, workerErr := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig)
+					resp, workerErr := deps.worker.SummarizeWithModel(workerCtx, titleForLLM, facts, &sourceChars, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model, summaryPromptConfig, summaryTargetLanguage)
 					if workerErr != nil {
 						return nil, workerErr
 					}
@@ -730,6 +805,7 @@ func summarizeAndPersistItem(
 		}
 
 		summary = summaryAttempt.Summary
+		summaryAcceptedModel = summaryAttempt.Runtime.Model
 		summary.Summary = strings.TrimSpace(summary.Summary)
 		recordLLMExecutionFailuresFromUsage(ctx, deps.llmExecutionRepo, "summary", summary.LLM, attempt, userIDPtr, &data.SourceID, &itemID, nil, summaryPromptResolution)
 		recordLLMUsage(ctx, deps.llmUsageRepo, "summary", summary.LLM, userIDPtr, &data.SourceID, &itemID, nil, summaryPromptResolution)
@@ -816,6 +892,7 @@ func summarizeAndPersistItem(
 		summary.Topics,
 		summary.Genre,
 		summary.OtherGenreLabel,
+		summary.Depth,
 		summary.TranslatedTitle,
 		summary.Score,
 		summary.ScoreBreakdown,
@@ -824,6 +901,12 @@ func summarizeAndPersistItem(
 	); err != nil {
 		return nil, fmt.Errorf("insert summary: %w", err)
 	}
+	storeLLMResponse(ctx, deps, summaryCache, summaryAcceptedModel, &service.CachedSummary{Summary: summary, Check: finalFaithfulness})
+	if deps.topicTaxonomy != nil {
+		if err := deps.topicTaxonomy.NormalizeItem(ctx, itemID); err != nil {
+			log.Printf("process-item topic normalization failed item_id=%s err=%v", itemID, err)
+		}
+	}
 	if userIDPtr != nil {
 		if err := deps.itemViewRepo.PersistPersonalScores(ctx, *userIDPtr, []string{itemID}); err != nil {
 			log.Printf("process-item personal score persist failed item_id=%s user_id=%s err=%v", itemID, *userIDPtr, err)
@@ -845,6 +928,14 @@ func summarizeAndPersistItem(
 			log.Printf("process-item search suggestion topics refresh failed item_id=%s user_id=%s err=%v", itemID, *userIDPtr, err)
 		}
 	}
+	if userModelSettings != nil && userModelSettings.HTMLSnapshotsEnabled {
+		if err := deps.publisher.SendItemSnapshotCaptureE(ctx, itemID); err != nil {
+			log.Printf("process-item snapshot capture enqueue failed item_id=%s err=%v", itemID, err)
+		}
+	}
+	if err := deps.publisher.SendItemDiscussionsEnrichE(ctx, itemID); err != nil {
+		log.Printf("process-item discussions enrich enqueue failed item_id=%s err=%v", itemID, err)
+	}
 	log.Printf("process-item summarize done item_id=%s topics=%d score=%.3f retries=%d faithfulness=%s", itemID, len(summary.Topics), summary.Score, summaryRetryCount, finalFaithfulness.Verdict)
 
 	return &processSummaryStageResult{
@@ -999,12 +1090,16 @@ func createEmbeddingIfPossible(
 		log.Printf("process-item embedding skip item_id=%s reason=%v", itemID, err)
 		return
 	}
-	inputText := buildItemEmbeddingInput(titleForLLM, summary.Summary, summary.Topics, facts)
-	embModel := service.OpenAIEmbeddingModel()
-	if userModelSettings != nil && userModelSettings.EmbeddingModel != nil && service.IsSupportedOpenAIEmbeddingModel(*userModelSettings.EmbeddingModel) {
-		embModel = *userModelSettings.EmbeddingModel
+	budget, err := runTracedStep(ctx, deps, data, itemID, "budget-guard-embedding", nil, func(ctx context.Context) (service.BudgetGuardStatus, error) {
+		return deps.budgetGuard.CheckPurposes(ctx, userModelSettings, service.LLMBudgetPurposeEmbedding)
+	})
+	if err == nil && budget.Exceeded {
+		log.Printf("process-item embedding skip item_id=%s reason=budget purpose=%s", itemID, budget.Purpose)
+		return
 	}
-	embResp, err := step.Run(ctx, "create-embedding", func(ctx context.Context) (*service.CreateEmbeddingResponse, error) {
+	inputText := buildItemEmbeddingInput(titleForLLM, summary.Summary, summary.Topics, facts)
+	embModel := service.UserEmbeddingModel(userModelSettings)
+	embResp, err := runTracedStep(ctx, deps, data, itemID, "create-embedding", func() *string { return &embModel }, func(ctx context.Context) (*service.CreateEmbeddingResponse, error) {
 		log.Printf("process-item create-embedding start item_id=%s model=%s", itemID, embModel)
 		return deps.openAI.CreateEmbedding(ctx, *userOpenAIKey, embModel, inputText)
 	})
@@ -1022,11 +1117,22 @@ func createEmbeddingIfPossible(
 	log.Printf("process-item create-embedding done item_id=%s dims=%d", itemID, len(embResp.Embedding))
 }
 
+// factsPromptInput and summaryPromptInput assign prompt experiments per item, so every resolve
+// for the same item picks the same version.
+func factsPromptInput(itemID string) service.PromptResolveInput {
+	return service.PromptResolveInput{PromptKey: "facts.default", AssignmentUnit: "item_id", AssignmentKey: itemID}
+}
+
+func summaryPromptInput(itemID string) service.PromptResolveInput {
+	return service.PromptResolveInput{PromptKey: "summary.default", AssignmentUnit: "item_id", AssignmentKey: itemID}
+}
+
 func updateItemAfterExtract(
 	ctx context.Context,
 	itemRepo *repository.ItemInngestRepo,
 	itemID string,
 	extracted *service.ExtractBodyResponse,
+	contentHash string,
 ) error {
 	var publishedAt *time.Time
 	if extracted.PublishedAt != nil {
@@ -1035,5 +1141,19 @@ func updateItemAfterExtract(
 			publishedAt = &t
 		}
 	}
-	return itemRepo.UpdateAfterExtract(ctx, itemID, extracted.Content, extracted.Title, extracted.ImageURL, publishedAt)
+	var language *string
+	if detected := service.DetectContentLanguage(extracted.Content); detected != "" {
+		language = &detected
+	}
+	var canonicalURL *string
+	if extracted.CanonicalURL != nil {
+		if c := urlutil.Canonicalize(*extracted.CanonicalURL); strings.HasPrefix(c, "http") {
+			canonicalURL = &c
+		}
+	}
+	paywalled := service.LooksPaywalled(extracted.Content)
+	if paywalled {
+		log.Printf("process-item paywall detected item_id=%s content_len=%d", itemID, len(extracted.Content))
+	}
+	return itemRepo.UpdateAfterExtract(ctx, itemID, extracted.Content, extracted.Title, extracted.ImageURL, publishedAt, language, extracted.Source, extracted.ArchiveURL, canonicalURL, paywalled, contentHash)
 }
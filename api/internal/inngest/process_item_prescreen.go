@@ -0,0 +1,69 @@
+package inngest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+// prescreenItem scores the extracted item against the owner's preference profile before any
+// LLM call. The score is always recorded so settings can project savings; when it falls under
+// the user's prescreen_threshold the item gets a stub summary instead of facts and a summary,
+// and prescreenItem reports true. Scoring failures never hold up the item.
+func prescreenItem(
+	ctx context.Context,
+	deps processItemDeps,
+	data processItemEventData,
+	itemID string,
+	userIDPtr *string,
+	userModelSettings *model.UserSettings,
+	titleForLLM *string,
+	content string,
+) (bool, error) {
+	userID := ptrStringValue(userIDPtr)
+	if userID == "" || deps.preferenceRepo == nil || !service.PrescreenApplies(data.Reason) {
+		return false, nil
+	}
+	result, err := runTracedStep(ctx, deps, data, itemID, "prescreen", nil, func(ctx context.Context) (*service.PrescreenResult, error) {
+		profile, err := deps.preferenceRepo.GetProfile(ctx, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		res, ok := service.Prescreen(ptrStringValue(titleForLLM), content, data.SourceID, profile)
+		if !ok {
+			return nil, nil
+		}
+		if err := deps.itemRepo.SetPrescreenScore(ctx, itemID, res.Score); err != nil {
+			return nil, err
+		}
+		return &res, nil
+	})
+	if err != nil {
+		log.Printf("process-item prescreen failed item_id=%s err=%v", itemID, err)
+		return false, nil
+	}
+	if result == nil || !service.PrescreenSkips(userModelSettings, result.Score) {
+		return false, nil
+	}
+	if err := deps.itemRepo.InsertPrescreenStub(ctx, itemID, service.PrescreenStubSummary(content), result.Score); err != nil {
+		return false, fmt.Errorf("insert prescreen stub: %w", err)
+	}
+	if err := deps.itemViewRepo.PersistPersonalScores(ctx, userID, []string{itemID}); err != nil {
+		log.Printf("process-item personal score persist failed item_id=%s user_id=%s err=%v", itemID, userID, err)
+	}
+	bumpProcessUserItemsCacheVersion(ctx, deps.cache, userID)
+	bumpProcessItemDetailCacheVersion(ctx, deps.cache, itemID)
+	if err := deps.publisher.SendItemSearchUpsertE(ctx, itemID); err != nil {
+		log.Printf("process-item search upsert event failed item_id=%s err=%v", itemID, err)
+	}
+	log.Printf("process-item prescreen skipped item_id=%s score=%.3f threshold=%.3f matched=%v", itemID, result.Score, *userModelSettings.PrescreenThreshold, result.MatchedTopics)
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+	inngesterrors "github.com/inngest/inngestgo/errors"
+	"github.com/inngest/inngestgo/step"
+)
+
+// processItemRateLimitMaxWaits is how many provider windows one LLM step waits out before it
+// reports the 429 to the usual retry and fallback handling.
+const processItemRateLimitMaxWaits = 3
+
+// providerRateLimitedError marks a step that hit a provider 429. Inngest replays step errors
+// as plain messages, so the provider is recovered from Error() rather than the type.
+type providerRateLimitedError struct {
+	provider string
+	until    time.Time
+	err      error
+}
+
+func (e *providerRateLimitedError) Error() string {
+	return fmt.Sprintf("provider %s rate limited until %s: %v", e.provider, e.until.UTC().Format(time.RFC3339), e.err)
+}
+
+func (e *providerRateLimitedError) Unwrap() error { return e.err }
+
+var providerRateLimitedPattern = regexp.MustCompile(`provider (\S+) rate limited until \S+:`)
+
+func providerRateLimitedFrom(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	m := providerRateLimitedPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// runLLMStep runs an LLM call as a traced step that respects provider rate limits. Before the
+// call it sleeps through any cooldown recorded for the user and provider; a 429 relayed by
+// the worker records the cooldown and fails the step without Inngest's blind retries, and the
+// call is repeated once the window resets.
+func runLLMStep[T any](ctx context.Context, deps processItemDeps, data processItemEventData, itemID, stepID string, userID, modelHint *string, modelOf func() *string, fn func(ctx context.Context) (T, error)) (T, error) {
+	uid := ptrStringValue(userID)
+	provider := service.LLMProviderForModel(modelHint)
+	for wait := 0; ; wait++ {
+		label := stepID
+		if wait > 0 {
+			label = fmt.Sprintf("%s-after-rate-limit-%d", stepID, wait)
+		}
+		if uid != "" && deps.cooldown != nil {
+			awaitProviderCooldown(ctx, deps.cooldown, uid, provider, label)
+		}
+		out, err := runTracedStep(ctx, deps, data, itemID, label, modelOf, func(ctx context.Context) (T, error) {
+			out, err := fn(ctx)
+			if p, retryAfter, ok := service.ProviderRateLimit(err); ok && uid != "" && deps.cooldown != nil {
+				until := deps.cooldown.Record(ctx, uid, p, retryAfter)
+				return out, inngesterrors.NoRetryError(&providerRateLimitedError{provider: p, until: until, err: err})
+			}
+			return out, err
+		})
+		limited, ok := providerRateLimitedFrom(err)
+		if !ok || wait >= processItemRateLimitMaxWaits {
+			return out, err
+		}
+		log.Printf("process-item rate-limited item_id=%s step=%s provider=%s wait=%d", itemID, label, limited, wait+1)
+		provider = limited
+	}
+}
+
+// awaitProviderCooldown sleeps until the user's cooldown for provider ends. The lookup is a
+// step of its own so replays wait for the same deadline.
+func awaitProviderCooldown(ctx context.Context, cooldown *service.ProviderCooldown, userID, provider, stepID string) {
+	until, err := step.Run(ctx, stepID+"-cooldown", func(ctx context.Context) (time.Time, error) {
+		until, _ := cooldown.Until(ctx, userID, provider)
+		return until, nil
+	})
+	if err != nil || !until.After(time.Now()) {
+		return
+	}
+	step.SleepUntil(ctx, stepID+"-cooldown-wait", until)
+}
@@ -0,0 +1,24 @@
+package inngest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProviderRateLimitedSurvivesReplayAsMessage(t *testing.T) {
+	err := &providerRateLimitedError{provider: "openai", until: time.Date(2026, 3, 10, 12, 0, 30, 0, time.UTC), err: errors.New("worker /summarize: status 429")}
+	// A replayed step error only keeps its message.
+	replayed := fmt.Errorf("step failed: %s", err.Error())
+
+	provider, ok := providerRateLimitedFrom(replayed)
+	if !ok || provider != "openai" {
+		t.Fatalf("providerRateLimitedFrom() = %q, %v", provider, ok)
+	}
+	for _, err := range []error{nil, errors.New("worker /summarize: status 429 detail=rate limited")} {
+		if _, ok := providerRateLimitedFrom(err); ok {
+			t.Fatalf("providerRateLimitedFrom(%v) ok", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package inngest
+
+import (
+	"context"
+	"log"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo/step"
+)
+
+// llmCacheLookup is the memoized result of a response cache lookup. Model is the model the
+// first attempt resolves to; only results produced by that model are stored under Key.
+type llmCacheLookup[T any] struct {
+	Key   string `json:"key,omitempty"`
+	Model string `json:"model,omitempty"`
+	Hit   *T     `json:"hit,omitempty"`
+}
+
+// lookupLLMResponse checks the response cache before a stage's first LLM call. The lookup is
+// its own step so replays see the same answer even if the entry expires meanwhile. Any
+// failure is a miss: the stage then calls the worker and reports errors as usual.
+func lookupLLMResponse[T any](
+	ctx context.Context,
+	deps processItemDeps,
+	stepID, purpose string,
+	userIDPtr, modelOverride *string,
+	prompt *service.PromptConfig,
+	valid func(*T) bool,
+	inputs ...string,
+) llmCacheLookup[T] {
+	if deps.responseCache == nil {
+		return llmCacheLookup[T]{}
+	}
+	out, err := step.Run(ctx, stepID, func(ctx context.Context) (llmCacheLookup[T], error) {
+		runtime, err := resolveLLMRuntime(ctx, deps.keyProvider, userIDPtr, modelOverride, purpose)
+		if err != nil || ptrStringValue(runtime.Model) == "" {
+			return llmCacheLookup[T]{}, nil
+		}
+		lookup := llmCacheLookup[T]{Model: ptrStringValue(runtime.Model)}
+		lookup.Key = service.LLMResponseCacheKey(purpose, lookup.Model, prompt, inputs...)
+		var hit T
+		if deps.responseCache.Get(ctx, lookup.Key, &hit) && valid(&hit) {
+			lookup.Hit = &hit
+		}
+		return lookup, nil
+	})
+	if err != nil {
+		log.Printf("process-item %s failed err=%v", stepID, err)
+		return llmCacheLookup[T]{}
+	}
+	return out
+}
+
+// storeLLMResponse caches an accepted result when the model that produced it is the one the
+// lookup keyed on; results from fallback models would never be looked up.
+func storeLLMResponse[T any](ctx context.Context, deps processItemDeps, lookup llmCacheLookup[T], acceptedModel *string, value *T) {
+	if lookup.Key == "" || lookup.Hit != nil || ptrStringValue(acceptedModel) != lookup.Model {
+		return
+	}
+	if err := deps.responseCache.Put(ctx, lookup.Key, value); err != nil {
+		log.Printf("process-item response cache store failed key=%s err=%v", lookup.Key, err)
+	}
+}
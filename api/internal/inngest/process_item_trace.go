@@ -0,0 +1,65 @@
+package inngest
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/inngest/inngestgo/step"
+)
+
+type itemProcessingEventWriter interface {
+	Insert(ctx context.Context, ev model.ItemProcessingEvent) error
+}
+
+// runTracedStep runs fn as an Inngest step and appends its outcome to the item's processing
+// trace. Memoized steps are not re-recorded on replay because fn does not run again. modelOf,
+// when set, is read after fn returns so steps can report the model they resolved.
+func runTracedStep[T any](ctx context.Context, deps processItemDeps, data processItemEventData, itemID, stepID string, modelOf func() *string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return step.Run(ctx, stepID, func(ctx context.Context) (T, error) {
+		startedAt := time.Now()
+		out, err := fn(ctx)
+		var modelUsed *string
+		if modelOf != nil {
+			modelUsed = modelOf()
+		}
+		if deps.processingEvents != nil {
+			recordItemProcessingEvent(ctx, deps.processingEvents, buildItemProcessingEvent(data, itemID, stepID, startedAt, time.Now(), modelUsed, err))
+		}
+		return out, err
+	})
+}
+
+func buildItemProcessingEvent(data processItemEventData, itemID, stepID string, startedAt, finishedAt time.Time, modelUsed *string, err error) model.ItemProcessingEvent {
+	ev := model.ItemProcessingEvent{
+		ItemID:        itemID,
+		TriggerID:     ptrStringOrNil(&data.TriggerID),
+		TriggerReason: ptrStringOrNil(&data.Reason),
+		Step:          stepID,
+		Status:        "succeeded",
+		Model:         ptrStringOrNil(modelUsed),
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+		DurationMS:    finishedAt.Sub(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		msg := err.Error()
+		if len(msg) > 2000 {
+			msg = msg[:2000]
+		}
+		ev.Status = "failed"
+		ev.ErrorMessage = &msg
+	}
+	return ev
+}
+
+func recordItemProcessingEvent(ctx context.Context, writer itemProcessingEventWriter, ev model.ItemProcessingEvent) {
+	// The step context may already be cancelled when a step times out; the trace is most
+	// useful exactly then.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := writer.Insert(ctx, ev); err != nil {
+		log.Printf("process-item trace insert failed item_id=%s step=%s err=%v", ev.ItemID, ev.Step, err)
+	}
+}
@@ -0,0 +1,24 @@
+package inngest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildItemProcessingEvent(t *testing.T) {
+	started := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	model := "claude-haiku-4-5"
+	data := processItemEventData{TriggerID: "t-1"}
+
+	ok := buildItemProcessingEvent(data, "item-1", "extract-facts", started, started.Add(1500*time.Millisecond), &model, nil)
+	if ok.Status != "succeeded" || ok.DurationMS != 1500 || ok.Model == nil || *ok.Model != model || ok.TriggerReason != nil || *ok.TriggerID != "t-1" {
+		t.Fatalf("event = %+v", ok)
+	}
+
+	failed := buildItemProcessingEvent(data, "item-1", "summarize", started, started, nil, errors.New(strings.Repeat("x", 3000)))
+	if failed.Status != "failed" || failed.ErrorMessage == nil || len(*failed.ErrorMessage) != 2000 || failed.Model != nil {
+		t.Fatalf("event = %+v", failed)
+	}
+}
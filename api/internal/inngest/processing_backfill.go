@@ -0,0 +1,35 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// continueProcessingBackfillFn queues the next page of a resumed user's backlog. Resuming
+// sends the first page; each full page schedules this function after its last batch, so a
+// backlog larger than one page drains at the same pace.
+func continueProcessingBackfillFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	pauses := service.NewProcessingPauseService(repository.NewUserSettingsRepo(db), repository.NewItemRepo(db), mustEventPublisher())
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "continue-processing-backfill", Name: "Continue Processing Backfill"},
+		inngestgo.EventTrigger(service.ProcessingBackfillContinueEvent, nil),
+		func(ctx context.Context, input inngestgo.Input[service.ProcessingBackfillContinueData]) (any, error) {
+			data := input.Event.Data
+			if data.UserID == "" || data.AfterItemID == "" {
+				return nil, fmt.Errorf("user_id and after_item_id are required")
+			}
+			queued, err := pauses.ContinueBackfill(ctx, data)
+			if err != nil {
+				return nil, fmt.Errorf("continue processing backfill: %w", err)
+			}
+			return map[string]any{"user_id": data.UserID, "queued": queued}, nil
+		},
+	)
+}
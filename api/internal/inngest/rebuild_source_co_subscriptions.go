@@ -0,0 +1,30 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func rebuildSourceCoSubscriptionsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	svc := service.NewSourceCoSubscriptionService(repository.NewSourceCoSubscriptionRepo(db))
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "rebuild-source-co-subscriptions", Name: "Rebuild Source Co-Subscription Stats"},
+		inngestgo.CronTrigger("40 19 * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			feeds, pairs, err := svc.Rebuild(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("rebuild source co-subscriptions: %w", err)
+			}
+			slog.Info("rebuild-source-co-subscriptions: done", "feeds", feeds, "pairs", pairs)
+			return map[string]any{"feeds": feeds, "pairs": pairs}, nil
+		},
+	)
+}
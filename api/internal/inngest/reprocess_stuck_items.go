@@ -0,0 +1,82 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const reprocessStuckItemsPerStatus = 200
+
+// reprocessStuckItemsFn re-emits item/created for items stuck past their pipeline SLA, e.g.
+// after a lost event or a crashed run. Each claim bumps items.auto_reprocess_count; items that
+// are still stuck after PIPELINE_AUTO_REPROCESS_MAX attempts are marked failed so they show up
+// in the manual retry flow instead of looping forever.
+func reprocessStuckItemsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	itemRepo := repository.NewItemInngestRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "reprocess-stuck-items", Name: "Reprocess Stuck Items"},
+		inngestgo.CronTrigger("*/15 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			ctx = withRunRequestID(ctx, "", input.InputCtx.RunID)
+			maxAttempts := service.PipelineAutoReprocessMaxAttempts()
+			if maxAttempts == 0 {
+				return map[string]any{"status": "disabled"}, nil
+			}
+			slas := service.PipelineStuckSLAs()
+			statuses := make([]string, 0, len(slas))
+			for status := range slas {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+
+			now := time.Now()
+			reprocessed, exhausted := 0, int64(0)
+			for _, status := range statuses {
+				olderThan := now.Add(-slas[status])
+				n, err := itemRepo.FailExhaustedStuck(ctx, status, olderThan, maxAttempts,
+					fmt.Sprintf("stuck in %s; auto reprocess limit (%d) reached", status, maxAttempts))
+				if err != nil {
+					log.Printf("reprocess-stuck-items fail exhausted status=%s: %v", status, err)
+				}
+				exhausted += n
+
+				targets, err := itemRepo.ClaimStuckForReprocess(ctx, status, olderThan, maxAttempts, reprocessStuckItemsPerStatus)
+				if err != nil {
+					return nil, fmt.Errorf("claim stuck %s items: %w", status, err)
+				}
+				for _, it := range targets {
+					if _, err := client.Send(ctx, service.StampRequestID(ctx, autoReprocessEvent(it))); err != nil {
+						log.Printf("reprocess-stuck-items send item/created item_id=%s: %v", it.ItemID, err)
+						continue
+					}
+					reprocessed++
+				}
+			}
+			log.Printf("reprocess-stuck-items complete reprocessed=%d exhausted=%d", reprocessed, exhausted)
+			return map[string]any{
+				"reprocessed": reprocessed,
+				"exhausted":   exhausted,
+			}, nil
+		},
+	)
+}
+
+// autoReprocessEvent keys the event ID on the item and attempt so a retried cron run cannot
+// start the same item twice for one claim.
+func autoReprocessEvent(it repository.ItemStuckTarget) inngestgo.Event {
+	ev := service.NewItemCreatedEvent(it.ItemID, it.SourceID, it.UserID, it.URL, it.Title, "auto_reprocess")
+	id := fmt.Sprintf("item-auto-reprocess-%s-%d", it.ItemID, it.Attempt)
+	ev.ID = &id
+	ev.Data["previous_status"] = it.Status
+	return ev
+}
@@ -0,0 +1,22 @@
+package inngest
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestAutoReprocessEventIsKeyedOnAttempt(t *testing.T) {
+	it := repository.ItemStuckTarget{ItemID: "item-1", SourceID: "src-1", URL: "https://example.com/a", Status: "fetched", Attempt: 2}
+	ev := autoReprocessEvent(it)
+	if ev.Name != "item/created" || ev.ID == nil || *ev.ID != "item-auto-reprocess-item-1-2" {
+		t.Fatalf("event = %+v", ev)
+	}
+	if ev.Data["reason"] != "auto_reprocess" || ev.Data["previous_status"] != "fetched" {
+		t.Fatalf("data = %v", ev.Data)
+	}
+	it.Attempt = 3
+	if next := autoReprocessEvent(it); *next.ID == *ev.ID {
+		t.Fatalf("attempts share event id %s", *ev.ID)
+	}
+}
@@ -0,0 +1,19 @@
+package inngest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+// withRunRequestID continues the request ID the triggering event carries, so worker calls and
+// the events this run sends trace back to the fetch or API call behind it. Cron runs and
+// events sent without one use the run ID, which stays the same across the run's steps.
+func withRunRequestID(ctx context.Context, eventRequestID, runID string) context.Context {
+	id := strings.TrimSpace(eventRequestID)
+	if id == "" {
+		id = strings.TrimSpace(runID)
+	}
+	return service.WithRequestID(ctx, id)
+}
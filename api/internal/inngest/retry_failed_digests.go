@@ -0,0 +1,93 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const retryFailedDigestsLimit = 200
+
+// retryFailedDigestsFn retries digests that failed to compose or send within the last 48h.
+// Retry n waits DIGEST_AUTO_RETRY_BASE_MINUTES * 2^(n-1) after the latest failure, and
+// digests stay failed once DIGEST_AUTO_RETRY_MAX attempts are used up. Each attempt passes
+// through auto_retry_queued, so it shows in the digest's send status.
+func retryFailedDigestsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	digestRepo := repository.NewDigestInngestRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "retry-failed-digests", Name: "Retry Failed Digests"},
+		inngestgo.CronTrigger("*/10 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			ctx = withRunRequestID(ctx, "", input.InputCtx.RunID)
+			maxAttempts := service.DigestAutoRetryMaxAttempts()
+			if maxAttempts == 0 {
+				return map[string]any{"status": "disabled"}, nil
+			}
+			base := service.DigestAutoRetryBaseDelay()
+			now := time.Now()
+			candidates, err := digestRepo.ListAutoRetryCandidates(ctx, service.DigestAutoRetryStatuses, now.Add(-service.DigestAutoRetryWindow), maxAttempts, retryFailedDigestsLimit)
+			if err != nil {
+				return nil, fmt.Errorf("list failed digests: %w", err)
+			}
+			retried, waiting := 0, 0
+			for _, c := range candidates {
+				if !service.DigestAutoRetryDue(c.FailedAt, c.Attempts, base, now) {
+					waiting++
+					continue
+				}
+				note := fmt.Sprintf("auto retry %d/%d after %s", c.Attempts+1, maxAttempts, c.SendStatus)
+				claimed, err := digestRepo.ClaimAutoRetry(ctx, c, note)
+				if err != nil {
+					log.Printf("retry-failed-digests claim digest_id=%s: %v", c.DigestID, err)
+					continue
+				}
+				if !claimed {
+					continue
+				}
+				if _, err := client.Send(ctx, service.StampRequestID(ctx, digestAutoRetryEvent(c))); err != nil {
+					log.Printf("retry-failed-digests send digest_id=%s: %v", c.DigestID, err)
+					msg := fmt.Sprintf("%s; enqueue failed: %v", note, err)
+					if uErr := digestRepo.UpdateSendStatus(ctx, c.DigestID, c.SendStatus, &msg); uErr != nil {
+						log.Printf("retry-failed-digests restore status digest_id=%s: %v", c.DigestID, uErr)
+					}
+					continue
+				}
+				retried++
+			}
+			log.Printf("retry-failed-digests complete candidates=%d retried=%d waiting=%d", len(candidates), retried, waiting)
+			return map[string]any{
+				"candidates": len(candidates),
+				"retried":    retried,
+				"waiting":    waiting,
+			}, nil
+		},
+	)
+}
+
+// digestAutoRetryEvent restarts a digest where it failed: a digest without copy is composed
+// again, one whose email failed goes straight back to send-digest. The event ID is keyed on
+// the attempt so a retried cron run cannot start the same attempt twice.
+func digestAutoRetryEvent(c repository.DigestAutoRetryCandidate) inngestgo.Event {
+	name := "digest/created"
+	if c.SendStatus == service.DigestSendStatusSendEmailFailed {
+		name = "digest/copy-composed"
+	}
+	id := fmt.Sprintf("digest-auto-retry-%s-%d", c.DigestID, c.Attempts+1)
+	return inngestgo.Event{
+		ID:   &id,
+		Name: name,
+		Data: map[string]any{
+			"digest_id": c.DigestID,
+			"user_id":   c.UserID,
+			"to":        c.Email,
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package inngest
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestDigestAutoRetryEventResumesFailedStage(t *testing.T) {
+	c := repository.DigestAutoRetryCandidate{DigestID: "d-1", UserID: "u-1", Email: "a@example.com", SendStatus: "compose_failed", Attempts: 0}
+	ev := digestAutoRetryEvent(c)
+	if ev.Name != "digest/created" || ev.ID == nil || *ev.ID != "digest-auto-retry-d-1-1" {
+		t.Fatalf("event = %+v", ev)
+	}
+	if ev.Data["to"] != "a@example.com" || ev.Data["user_id"] != "u-1" {
+		t.Fatalf("data = %v", ev.Data)
+	}
+
+	c.SendStatus = "send_email_failed"
+	c.Attempts = 1
+	ev = digestAutoRetryEvent(c)
+	if ev.Name != "digest/copy-composed" || *ev.ID != "digest-auto-retry-d-1-2" {
+		t.Fatalf("event = %+v", ev)
+	}
+}
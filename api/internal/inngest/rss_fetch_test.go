@@ -24,7 +24,7 @@ func TestFetchRSSFeedUsesConditionalHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	feed, notModified, gotETag, gotLastModified, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{
+	res, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{
 		URL:              server.URL,
 		FeedETag:         &etag,
 		FeedLastModified: &lastModified,
@@ -32,6 +32,7 @@ func TestFetchRSSFeedUsesConditionalHeaders(t *testing.T) {
 	if err != nil {
 		t.Fatalf("fetchRSSFeed() error = %v", err)
 	}
+	feed, notModified, gotETag, gotLastModified := res.Feed, res.NotModified, res.ETag, res.LastModified
 	if feed != nil || !notModified {
 		t.Fatalf("feed = %#v, notModified = %v; want nil, true", feed, notModified)
 	}
@@ -48,10 +49,11 @@ func TestFetchRSSFeedParsesFeedAndCapturesMetadata(t *testing.T) {
 	}))
 	defer server.Close()
 
-	feed, notModified, etag, lastModified, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{URL: server.URL})
+	res, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{URL: server.URL})
 	if err != nil {
 		t.Fatalf("fetchRSSFeed() error = %v", err)
 	}
+	feed, notModified, etag, lastModified := res.Feed, res.NotModified, res.ETag, res.LastModified
 	if notModified || feed == nil || len(feed.Items) != 1 {
 		t.Fatalf("feed = %#v, notModified = %v; want one item, false", feed, notModified)
 	}
@@ -60,6 +62,49 @@ func TestFetchRSSFeedParsesFeedAndCapturesMetadata(t *testing.T) {
 	}
 }
 
+func TestFetchRSSFeedReportsPermanentRedirectTarget(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title></channel></rss>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	res, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{URL: server.URL + "/old"})
+	if err != nil {
+		t.Fatalf("fetchRSSFeed() error = %v", err)
+	}
+	if res.MovedTo == nil || *res.MovedTo != server.URL+"/new" {
+		t.Fatalf("MovedTo = %v, want %q", res.MovedTo, server.URL+"/new")
+	}
+}
+
+func TestFetchRSSFeedIgnoresTemporaryRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/mid", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/mid", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title></channel></rss>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	res, err := fetchRSSFeed(context.Background(), server.Client(), model.Source{URL: server.URL + "/old"})
+	if err != nil {
+		t.Fatalf("fetchRSSFeed() error = %v", err)
+	}
+	if res.MovedTo != nil {
+		t.Fatalf("MovedTo = %q, want nil", *res.MovedTo)
+	}
+}
+
 func TestFeedItemURLsDeduplicatesAndSkipsEmptyLinks(t *testing.T) {
 	feed := &gofeed.Feed{Items: []*gofeed.Item{
 		{Link: "https://example.com/1"},
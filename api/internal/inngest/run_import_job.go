@@ -0,0 +1,57 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ImportJobRunData struct {
+	JobID     string `json:"job_id"`
+	Trigger   string `json:"trigger"`
+	TriggerID string `json:"trigger_id"`
+	RequestID string `json:"request_id"`
+}
+
+// runImportJobFn imports one batch of an import job per run. The service schedules the next
+// run itself, so item imports are spread out instead of flooding process-item.
+func runImportJobFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	svc := service.NewImportJobService(
+		repository.NewImportJobRepo(db),
+		repository.NewSourceRepo(db),
+		repository.NewItemRepo(db),
+		mustEventPublisher(),
+	).WithUsageLimits(service.NewUsageLimitService(repository.NewUsageLimitRepo(db)))
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{
+			ID:   "run-import-job",
+			Name: "Run Import Job",
+			Concurrency: []inngestgo.ConfigStepConcurrency{
+				{
+					Limit: 1,
+					Key:   inngestgo.StrPtr("event.data.job_id"),
+				},
+			},
+		},
+		inngestgo.EventTrigger("import-job/run", nil),
+		func(ctx context.Context, input inngestgo.Input[ImportJobRunData]) (any, error) {
+			ctx = withRunRequestID(ctx, input.Event.Data.RequestID, input.InputCtx.RunID)
+			jobID := strings.TrimSpace(input.Event.Data.JobID)
+			if jobID == "" {
+				return nil, fmt.Errorf("import job id is required")
+			}
+			done, err := svc.RunChunk(ctx, jobID)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"job_id": jobID, "done": done}, nil
+		},
+	)
+}
@@ -0,0 +1,114 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/inngest/inngestgo"
+	"github.com/inngest/inngestgo/step"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sendWeeklyRecapsFn emails every opted-in user a recap of the JST week (Monday to Sunday).
+// It runs Sunday 19:00 JST; weeks without any reads are skipped, and weekly_recap_logs keeps
+// retries from sending twice.
+func sendWeeklyRecapsFn(client inngestgo.Client, db *pgxpool.Pool, emails *service.EmailSenderResolver) (inngestgo.ServableFunction, error) {
+	recapRepo := repository.NewWeeklyRecapRepo(db)
+	llmUsageRepo := repository.NewLLMUsageLogRepo(db)
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "send-weekly-recaps", Name: "Send Weekly Recap Emails"},
+		inngestgo.CronTrigger("0 10 * * 0"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			targets, err := recapRepo.ListTargets(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("list weekly recap targets: %w", err)
+			}
+			weekStart := collectionSummaryWeekStart(timeutil.NowJST())
+			sent, skipped, failed := 0, 0, 0
+			for _, tgt := range targets {
+				tgt := tgt
+				status, err := step.Run(ctx, "weekly-recap-"+tgt.UserID, func(ctx context.Context) (string, error) {
+					return sendWeeklyRecap(ctx, recapRepo, llmUsageRepo, emails, tgt, weekStart)
+				})
+				switch {
+				case err != nil:
+					failed++
+					log.Printf("send-weekly-recaps user_id=%s err=%v", tgt.UserID, err)
+				case status == "sent":
+					sent++
+				default:
+					skipped++
+				}
+			}
+			return map[string]any{
+				"week_start": weekStart.Format("2006-01-02"),
+				"targets":    len(targets),
+				"sent":       sent,
+				"skipped":    skipped,
+				"failed":     failed,
+			}, nil
+		},
+	)
+}
+
+func sendWeeklyRecap(
+	ctx context.Context,
+	recapRepo *repository.WeeklyRecapRepo,
+	llmUsageRepo *repository.LLMUsageLogRepo,
+	emails *service.EmailSenderResolver,
+	tgt repository.WeeklyRecapTarget,
+	weekStart time.Time,
+) (string, error) {
+	weekStartDate := weekStart.Format("2006-01-02")
+	already, err := recapRepo.Sent(ctx, tgt.UserID, weekStartDate)
+	if err != nil {
+		return "", err
+	}
+	if already {
+		return "already_sent", nil
+	}
+	sender := emails.ForUser(ctx, tgt.UserID)
+	if sender == nil || !sender.Enabled() {
+		return "email_disabled", nil
+	}
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	stats, err := recapRepo.CollectStats(ctx, tgt.UserID, weekStartDate, weekEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return "", err
+	}
+	if stats.ReadCount == 0 {
+		return "no_reads", nil
+	}
+	weekCost, err := llmUsageRepo.SumEstimatedCostByUserBetween(ctx, tgt.UserID, weekStart, weekEnd)
+	if err != nil {
+		return "", err
+	}
+	recap := service.WeeklyRecapEmail{
+		Locale:           tgt.Locale,
+		Stats:            stats,
+		WeekCostUSD:      weekCost,
+		MonthlyBudgetUSD: tgt.MonthlyBudgetUSD,
+		PageURL:          appPageURL("/"),
+	}
+	if tgt.MonthlyBudgetUSD != nil && *tgt.MonthlyBudgetUSD > 0 {
+		lastDay := weekEnd.AddDate(0, 0, -1)
+		monthStart := time.Date(lastDay.Year(), lastDay.Month(), 1, 0, 0, 0, 0, timeutil.JST)
+		if recap.MonthCostUSD, err = llmUsageRepo.SumEstimatedCostByUserBetween(ctx, tgt.UserID, monthStart, weekEnd); err != nil {
+			return "", err
+		}
+	}
+	if err := service.SendWeeklyRecapEmail(ctx, sender, tgt.Email, recap); err != nil {
+		return "", err
+	}
+	if err := recapRepo.MarkSent(ctx, tgt.UserID, weekStartDate, stats.ReadCount); err != nil {
+		return "", err
+	}
+	return "sent", nil
+}
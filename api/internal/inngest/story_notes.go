@@ -0,0 +1,176 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/inngest/inngestgo/step"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	maxStoryNotesPerRun   = 8
+	maxStoryDayCandidates = 8
+)
+
+type storyNotesRequestedData struct {
+	UserID  string `json:"user_id"`
+	StoryID string `json:"story_id"`
+}
+
+type storyNotesDeps struct {
+	storyRepo    *repository.StoryRepo
+	itemRepo     *repository.ItemRepo
+	settingsRepo *repository.UserSettingsRepo
+	llmUsageRepo *repository.LLMUsageLogRepo
+	worker       *service.WorkerClient
+	keyProvider  *service.UserKeyProvider
+}
+
+// storyNotesFn writes the "what changed" note of each story day that has none or whose items
+// changed since. Timeline views and build-stories request it; runs are debounced per story.
+func storyNotesFn(client inngestgo.Client, db *pgxpool.Pool, worker *service.WorkerClient, keyProvider *service.UserKeyProvider) (inngestgo.ServableFunction, error) {
+	deps := storyNotesDeps{
+		storyRepo:    repository.NewStoryRepo(db),
+		itemRepo:     repository.NewItemRepo(db),
+		settingsRepo: repository.NewUserSettingsRepo(db),
+		llmUsageRepo: repository.NewLLMUsageLogRepo(db),
+		worker:       worker,
+		keyProvider:  keyProvider,
+	}
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{
+			ID:       "write-story-notes",
+			Name:     "Write Story Day Notes",
+			Debounce: &inngestgo.ConfigDebounce{Key: "event.data.story_id", Period: time.Minute},
+		},
+		inngestgo.EventTrigger("story/notes.requested", nil),
+		func(ctx context.Context, input inngestgo.Input[storyNotesRequestedData]) (any, error) {
+			data := input.Event.Data
+			if data.UserID == "" || data.StoryID == "" {
+				return nil, fmt.Errorf("user_id and story_id are required")
+			}
+			written, err := step.Run(ctx, "write-notes", func(ctx context.Context) (int, error) {
+				return writeStoryDayNotes(ctx, deps, data.UserID, data.StoryID)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"story_id": data.StoryID, "notes": written}, nil
+		},
+	)
+}
+
+// writeStoryDayNotes generates notes for pending days in chronological order so each day can be
+// described against what came before. It stops at the first failure so later days are not
+// described against a gap.
+func writeStoryDayNotes(ctx context.Context, deps storyNotesDeps, userID, storyID string) (int, error) {
+	story, err := deps.storyRepo.GetForUser(ctx, userID, storyID)
+	if err != nil || story == nil {
+		return 0, err
+	}
+	ids, err := deps.storyRepo.ItemIDs(ctx, story.ID)
+	if err != nil {
+		return 0, err
+	}
+	items, err := deps.itemRepo.LoadByIDsPreservingOrder(ctx, userID, ids)
+	if err != nil {
+		return 0, err
+	}
+	notes, err := deps.storyRepo.DayNotes(ctx, story.ID)
+	if err != nil {
+		return 0, err
+	}
+	days := service.GroupStoryDays(items)
+	if service.ApplyStoryDayNotes(days, notes) == 0 {
+		return 0, nil
+	}
+
+	settings, err := deps.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	locale := service.NormalizeLocale(settings.Locale)
+	runtime, err := resolveLLMRuntime(ctx, deps.keyProvider, &userID, ptrStringOrNil(settings.AskModel), "ask")
+	if err != nil {
+		return 0, err
+	}
+	if runtime.Model == nil {
+		return 0, nil
+	}
+
+	written := 0
+	var previous []string
+	for i := range days {
+		if !days[i].Pending {
+			previous = append(previous, days[i].Bullets...)
+			continue
+		}
+		if written >= maxStoryNotesPerRun {
+			break
+		}
+		candidates, err := storyDayCandidates(ctx, deps.itemRepo, userID, days[i].Items)
+		if err != nil {
+			return written, err
+		}
+		question := service.StoryDayQuestion(locale, story.Label, days[i].Day, previous)
+		workerCtx := service.WithWorkerTraceMetadata(ctx, "story_timeline", &userID, nil, nil, nil)
+		resp, err := deps.worker.AskWithModel(workerCtx, question, candidates, runtime.AnthropicKey, runtime.GoogleKey, runtime.GroqKey, runtime.DeepSeekKey, runtime.AlibabaKey, runtime.MistralKey, runtime.XAIKey, runtime.ZAIKey, runtime.FireworksKey, runtime.OpenAIKey, runtime.Model)
+		if err != nil {
+			return written, fmt.Errorf("story %s day %s: %w", story.ID, days[i].Day, err)
+		}
+		recordLLMUsage(ctx, deps.llmUsageRepo, "story_timeline", resp.LLM, &userID, nil, nil, nil, nil)
+
+		note := model.StoryDayNote{Day: days[i].Day, Bullets: service.StoryDayBullets(resp), ItemCount: len(days[i].Items)}
+		if resp.LLM != nil {
+			note.Model = &resp.LLM.Model
+		}
+		if err := deps.storyRepo.UpsertDayNote(ctx, story.ID, note); err != nil {
+			return written, fmt.Errorf("save story note: %w", err)
+		}
+		written++
+		previous = append(previous, note.Bullets...)
+	}
+	log.Printf("write-story-notes story_id=%s notes=%d", story.ID, written)
+	return written, nil
+}
+
+func storyDayCandidates(ctx context.Context, itemRepo *repository.ItemRepo, userID string, items []model.Item) ([]service.AskCandidate, error) {
+	if len(items) > maxStoryDayCandidates {
+		items = items[:maxStoryDayCandidates]
+	}
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	summaries, err := itemRepo.SummariesByItemIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]service.AskCandidate, 0, len(items))
+	for _, it := range items {
+		var publishedAt *string
+		if it.PublishedAt != nil {
+			v := it.PublishedAt.Format(time.RFC3339)
+			publishedAt = &v
+		}
+		candidates = append(candidates, service.AskCandidate{
+			ItemID:          it.ID,
+			Title:           it.Title,
+			TranslatedTitle: it.TranslatedTitle,
+			URL:             it.URL,
+			Summary:         summaries[it.ID],
+			Topics:          it.SummaryTopics,
+			PublishedAt:     publishedAt,
+		})
+	}
+	return candidates, nil
+}
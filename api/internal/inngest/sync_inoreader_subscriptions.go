@@ -0,0 +1,49 @@
+package inngest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/service"
+	"github.com/inngest/inngestgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func syncInoreaderSubscriptionsFn(client inngestgo.Client, db *pgxpool.Pool) (inngestgo.ServableFunction, error) {
+	settingsRepo := repository.NewUserSettingsRepo(db)
+	svc := service.NewInoreaderSyncService(
+		service.NewInoreaderOAuthService(settingsRepo, service.NewSecretCipher()),
+		repository.NewSourceRepo(db),
+		settingsRepo,
+	).WithUsageLimits(service.NewUsageLimitService(repository.NewUsageLimitRepo(db)))
+
+	return inngestgo.CreateFunction(
+		client,
+		inngestgo.FunctionOpts{ID: "sync-inoreader-subscriptions", Name: "Sync Inoreader Subscriptions"},
+		inngestgo.CronTrigger("15 * * * *"),
+		func(ctx context.Context, input inngestgo.Input[any]) (any, error) {
+			targets, err := svc.ListTargets(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("list inoreader sync targets: %w", err)
+			}
+			synced, failed, added, removed, readMarked, overLimit := 0, 0, 0, 0, 0, 0
+			for _, target := range targets {
+				res, err := svc.SyncUser(ctx, target)
+				added += res.Added
+				removed += res.Removed
+				readMarked += res.ReadMarked
+				overLimit += res.OverLimit
+				if err != nil {
+					failed++
+					slog.Warn("sync-inoreader-subscriptions: user failed", "user_id", target.UserID, "error", err)
+					continue
+				}
+				synced++
+			}
+			slog.Info("sync-inoreader-subscriptions: done", "users", len(targets), "synced", synced, "failed", failed, "added", added, "removed", removed, "read_marked", readMarked, "over_limit", overLimit)
+			return map[string]any{"users": len(targets), "synced": synced, "failed": failed, "added": added, "removed": removed, "read_marked": readMarked, "over_limit": overLimit}, nil
+		},
+	)
+}
@@ -2,12 +2,15 @@ package middleware
 
 import (
 	"context"
+	"log"
+	"net"
 	"net/http"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
-	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/model"
 	"github.com/enjoydarts/sifto/api/internal/service"
 )
 
@@ -15,9 +18,31 @@ type contextKey string
 
 const UserIDKey contextKey = "userID"
 
+// SessionIDKey holds the sign-in session ID of the token, when it carries one.
+const SessionIDKey contextKey = "sessionID"
+
+// IdentityLookup maps a Clerk user to the local user.
+type IdentityLookup interface {
+	GetByProviderUserID(ctx context.Context, provider, providerUserID string) (*model.UserIdentity, error)
+}
+
+// TokenVerifier verifies a bearer token and returns its claims.
+type TokenVerifier interface {
+	Enabled() bool
+	Verify(ctx context.Context, token string) (*service.ClerkClaims, error)
+}
+
+// SessionValidator records a use of a sign-in session and reports whether it is still allowed.
+type SessionValidator interface {
+	Validate(ctx context.Context, userID, sessionID, ipAddress, userAgent string) (bool, error)
+}
+
 var uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[1-5][0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$")
 
-func Auth(identityRepo *repository.UserIdentityRepo, clerkVerifier *service.ClerkTokenVerifier) func(http.Handler) http.Handler {
+// Auth resolves the bearer token to a user. With sessions set, tokens of a revoked session are
+// rejected, and a failed session lookup answers 503 rather than letting a possibly revoked
+// session through.
+func Auth(identityRepo IdentityLookup, clerkVerifier TokenVerifier, sessions SessionValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if os.Getenv("INNGEST_DEV") == "true" && os.Getenv("ALLOW_DEV_AUTH_BYPASS") == "true" {
@@ -30,27 +55,42 @@ func Auth(identityRepo *repository.UserIdentityRepo, clerkVerifier *service.Cler
 
 			bearerToken := extractBearerToken(r)
 			if bearerToken == "" {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "unauthorized", nil)
 				return
 			}
 
 			if identityRepo == nil || clerkVerifier == nil || !clerkVerifier.Enabled() {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "unauthorized", nil)
 				return
 			}
 
 			claims, err := clerkVerifier.Verify(r.Context(), bearerToken)
 			if err != nil {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "unauthorized", nil)
 				return
 			}
 			identity, lookupErr := identityRepo.GetByProviderUserID(r.Context(), "clerk", claims.Subject)
 			if lookupErr != nil || !uuidPattern.MatchString(identity.UserID) {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "unauthorized", nil)
 				return
 			}
 
 			ctx := context.WithValue(r.Context(), UserIDKey, identity.UserID)
+			if claims.SessionID != "" {
+				ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
+				if sessions != nil {
+					ok, err := sessions.Validate(r.Context(), identity.UserID, claims.SessionID, ClientIP(r), r.UserAgent())
+					if err != nil {
+						log.Printf("session validation failed user_id=%s err=%v", identity.UserID, err)
+						WriteError(w, http.StatusServiceUnavailable, ErrCodeUnavailable, "session check unavailable", nil)
+						return
+					}
+					if !ok {
+						WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "unauthorized", nil)
+						return
+					}
+				}
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -71,6 +111,45 @@ func GetUserID(r *http.Request) string {
 	return v
 }
 
+func GetSessionID(r *http.Request) string {
+	v, _ := r.Context().Value(SessionIDKey).(string)
+	return v
+}
+
+// ClientIP is the caller's address. Each of the TRUSTED_PROXY_COUNT proxies in front of the API
+// (default 1) appends the address it received the request from to X-Forwarded-For, so the
+// client is that many hops from the right; hops further left are whatever the client sent and
+// are ignored. With no trusted proxies, or without the header, it falls back to X-Real-IP (only
+// behind a proxy) and then the connection's remote address.
+func ClientIP(r *http.Request) string {
+	proxies := trustedProxyCount()
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" && proxies > 0 {
+		hops := strings.Split(fwd, ",")
+		if ip := strings.TrimSpace(hops[max(len(hops)-proxies, 0)]); ip != "" {
+			return ip
+		}
+	}
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" && proxies > 0 {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func trustedProxyCount() int {
+	v := strings.TrimSpace(os.Getenv("TRUSTED_PROXY_COUNT"))
+	if v == "" {
+		return 1
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 1
+	}
+	return n
+}
+
 func extractBearerToken(r *http.Request) string {
 	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
 		return strings.TrimPrefix(h, "Bearer ")
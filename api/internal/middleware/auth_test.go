@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/service"
+)
+
+const authTestUserID = "00000000-0000-4000-8000-000000000001"
+
+type fakeTokenVerifier struct{ claims *service.ClerkClaims }
+
+func (f fakeTokenVerifier) Enabled() bool { return true }
+
+func (f fakeTokenVerifier) Verify(_ context.Context, token string) (*service.ClerkClaims, error) {
+	if token != "good" {
+		return nil, errors.New("bad token")
+	}
+	return f.claims, nil
+}
+
+type fakeIdentityLookup struct{}
+
+func (fakeIdentityLookup) GetByProviderUserID(context.Context, string, string) (*model.UserIdentity, error) {
+	return &model.UserIdentity{UserID: authTestUserID}, nil
+}
+
+type fakeSessionValidator struct {
+	ok  bool
+	err error
+}
+
+func (f fakeSessionValidator) Validate(context.Context, string, string, string, string) (bool, error) {
+	return f.ok, f.err
+}
+
+func TestAuthSessionValidation(t *testing.T) {
+	cases := []struct {
+		name      string
+		sessionID string
+		sessions  SessionValidator
+		want      int
+	}{
+		{"active session", "sess_1", fakeSessionValidator{ok: true}, http.StatusOK},
+		{"revoked session", "sess_1", fakeSessionValidator{ok: false}, http.StatusUnauthorized},
+		{"validation error fails closed", "sess_1", fakeSessionValidator{err: errors.New("db down")}, http.StatusServiceUnavailable},
+		{"token without session", "", fakeSessionValidator{err: errors.New("not called")}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		verifier := fakeTokenVerifier{claims: &service.ClerkClaims{Subject: "user_1", SessionID: tc.sessionID}}
+		var gotUserID, gotSessionID string
+		h := Auth(fakeIdentityLookup{}, verifier, tc.sessions)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotUserID, gotSessionID = GetUserID(r), GetSessionID(r)
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		r.Header.Set("Authorization", "Bearer good")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, rec.Code, tc.want)
+		}
+		if tc.want == http.StatusOK && (gotUserID != authTestUserID || gotSessionID != tc.sessionID) {
+			t.Errorf("%s: user %q session %q", tc.name, gotUserID, gotSessionID)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		name    string
+		proxies string
+		headers map[string]string
+		remote  string
+		want    string
+	}{
+		{"rightmost hop", "", map[string]string{"X-Forwarded-For": "198.51.100.99, 203.0.113.7"}, "10.0.0.2:443", "203.0.113.7"},
+		{"two proxies", "2", map[string]string{"X-Forwarded-For": "198.51.100.99, 203.0.113.7, 10.0.0.1"}, "10.0.0.2:443", "203.0.113.7"},
+		{"fewer hops than proxies", "3", map[string]string{"X-Forwarded-For": "203.0.113.7"}, "10.0.0.2:443", "203.0.113.7"},
+		{"no trusted proxy", "0", map[string]string{"X-Forwarded-For": "198.51.100.99", "X-Real-IP": "198.51.100.4"}, "192.0.2.1:51234", "192.0.2.1"},
+		{"real ip", "", map[string]string{"X-Real-IP": "198.51.100.4"}, "10.0.0.2:443", "198.51.100.4"},
+		{"remote addr", "", nil, "192.0.2.1:51234", "192.0.2.1"},
+		{"remote without port", "", nil, "192.0.2.1", "192.0.2.1"},
+	}
+	for _, tc := range cases {
+		t.Setenv("TRUSTED_PROXY_COUNT", tc.proxies)
+		r := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		r.RemoteAddr = tc.remote
+		for k, v := range tc.headers {
+			r.Header.Set(k, v)
+		}
+		if got := ClientIP(r); got != tc.want {
+			t.Errorf("%s: ClientIP = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
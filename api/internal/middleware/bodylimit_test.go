@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimitForRoutes(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         int64
+	}{
+		{http.MethodPost, "/api/sources", DefaultBodyLimit},
+		{http.MethodPost, "/api/sources/opml/import", 10 << 20},
+		{http.MethodGet, "/api/sources/opml/import", DefaultBodyLimit},
+		{http.MethodPost, "/api/items/import/pocket", 20 << 20},
+		{http.MethodPut, "/api/inngest", 0},
+		{http.MethodPost, "/api/inngest/fn", 0},
+	}
+	for _, c := range cases {
+		if got := BodyLimitFor(httptest.NewRequest(c.method, c.path, nil)); got != c.want {
+			t.Errorf("%s %s limit = %d, want %d", c.method, c.path, got, c.want)
+		}
+	}
+}
+
+func TestBodyLimitRejectsOversizedBodies(t *testing.T) {
+	var readErr error
+	h := BodyLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	big := strings.Repeat("a", int(DefaultBodyLimit)+1)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/sources", strings.NewReader(big)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			LimitBytes int64 `json:"limit_bytes"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Code != ErrCodeBodyTooLarge || body.Details.LimitBytes != DefaultBodyLimit {
+		t.Fatalf("body = %s (%v)", rec.Body.String(), err)
+	}
+
+	// Without a Content-Length the handler sees the limit as a read error instead.
+	req := httptest.NewRequest(http.MethodPost, "/api/sources", strings.NewReader(big))
+	req.ContentLength = -1
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if _, ok := readErr.(*http.MaxBytesError); !ok {
+		t.Fatalf("read error = %v, want *http.MaxBytesError", readErr)
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Stable error codes. Every status has a default code; the more specific ones are listed in
+// docs/api-errors.md with the endpoints that answer them.
+const (
+	ErrCodeInvalidRequest   = "invalid_request"
+	ErrCodeUnauthorized     = "unauthorized"
+	ErrCodeForbidden        = "forbidden"
+	ErrCodeNotFound         = "not_found"
+	ErrCodeMethodNotAllowed = "method_not_allowed"
+	ErrCodeConflict         = "conflict"
+	ErrCodeGone             = "gone"
+	ErrCodeUnprocessable    = "unprocessable"
+	ErrCodeRateLimited      = "rate_limited"
+	ErrCodeInternal         = "internal_error"
+	ErrCodeNotImplemented   = "not_implemented"
+	ErrCodeUpstream         = "upstream_error"
+	ErrCodeUnavailable      = "unavailable"
+	ErrCodeUpstreamTimeout  = "upstream_timeout"
+
+	ErrCodeBodyTooLarge     = "body_too_large"
+	ErrCodeEmptyBody        = "empty_body"
+	ErrCodeInvalidJSON      = "invalid_json"
+	ErrCodeUnknownField     = "unknown_field"
+	ErrCodeInvalidFieldType = "invalid_field_type"
+)
+
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:            ErrCodeInvalidRequest,
+	http.StatusUnauthorized:          ErrCodeUnauthorized,
+	http.StatusPaymentRequired:       ErrCodeForbidden,
+	http.StatusForbidden:             ErrCodeForbidden,
+	http.StatusNotFound:              ErrCodeNotFound,
+	http.StatusMethodNotAllowed:      ErrCodeMethodNotAllowed,
+	http.StatusConflict:              ErrCodeConflict,
+	http.StatusGone:                  ErrCodeGone,
+	http.StatusRequestEntityTooLarge: ErrCodeBodyTooLarge,
+	http.StatusUnprocessableEntity:   ErrCodeUnprocessable,
+	http.StatusTooManyRequests:       ErrCodeRateLimited,
+	http.StatusNotImplemented:        ErrCodeNotImplemented,
+	http.StatusBadGateway:            ErrCodeUpstream,
+	http.StatusServiceUnavailable:    ErrCodeUnavailable,
+	http.StatusGatewayTimeout:        ErrCodeUpstreamTimeout,
+}
+
+// ErrorCodeForStatus is the default code of an error answered with status.
+func ErrorCodeForStatus(status int) string {
+	if code, ok := statusErrorCodes[status]; ok {
+		return code
+	}
+	if status >= 500 {
+		return ErrCodeInternal
+	}
+	return ErrCodeInvalidRequest
+}
+
+// ErrorResponse is the body of every error answer.
+type ErrorResponse struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// WriteError answers status with the error envelope. An empty code picks the status's default
+// code, and the request ID comes from the header RequestID set on w.
+func WriteError(w http.ResponseWriter, status int, code, message string, details any) {
+	if code == "" {
+		code = ErrorCodeForStatus(status)
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: h.Get(RequestIDHeader),
+	})
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDKeepsValidIncomingID(t *testing.T) {
+	var seen string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetRequestID(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+	req.Header.Set(RequestIDHeader, "web-1234")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if seen != "web-1234" || rec.Header().Get(RequestIDHeader) != "web-1234" {
+		t.Fatalf("request id = %q, header = %q", seen, rec.Header().Get(RequestIDHeader))
+	}
+
+	for _, incoming := range []string{"", "has space", strings.Repeat("a", maxRequestIDLength+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		req.Header.Set(RequestIDHeader, incoming)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if seen == incoming || len(seen) != 32 {
+			t.Errorf("incoming %q: request id = %q", incoming, seen)
+		}
+	}
+}
+
+func TestWriteErrorEnvelope(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(RequestIDHeader, "req-1")
+	WriteError(rec, http.StatusNotFound, "", "", map[string]string{"id": "x"})
+
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("status = %d, content type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var got ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	details, _ := got.Details.(map[string]any)
+	if got.Code != ErrCodeNotFound || got.Message != "Not Found" || got.RequestID != "req-1" || details["id"] != "x" {
+		t.Fatalf("envelope = %+v", got)
+	}
+
+	if code := ErrorCodeForStatus(599); code != ErrCodeInternal {
+		t.Fatalf("ErrorCodeForStatus(599) = %q", code)
+	}
+}
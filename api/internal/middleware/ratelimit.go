@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -68,12 +67,7 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 
 		if !allowed {
 			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusTooManyRequests)
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"error":       "rate limit exceeded",
-				"retry_after": retryAfter,
-			})
+			WriteError(w, http.StatusTooManyRequests, ErrCodeRateLimited, "rate limit exceeded", map[string]int{"retry_after": retryAfter})
 			return
 		}
 
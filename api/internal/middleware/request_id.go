@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/enjoydarts/sifto/api/internal/service"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+const RequestIDHeader = service.RequestIDHeader
+
+const maxRequestIDLength = 64
+
+// RequestID gives every request an ID, taken from an incoming X-Request-Id when it is a short
+// token and generated otherwise. The ID goes into the context, where worker calls, events and
+// the access log pick it up, and into the response header, where error answers do.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = service.NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		appendExposeHeaders(w.Header(), RequestIDHeader)
+		ctx := service.WithRequestID(r.Context(), id)
+		ctx = context.WithValue(ctx, chimiddleware.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func GetRequestID(r *http.Request) string {
+	return service.RequestIDFromContext(r.Context())
+}
@@ -21,6 +21,54 @@ type UserIdentity struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// UsageLimits are a plan's soft limits. Zero means unlimited.
+type UsageLimits struct {
+	MaxSources        int `json:"max_sources"`
+	MaxLLMItemsPerDay int `json:"max_llm_items_per_day"`
+}
+
+// UsageLimitStatus is the user's plan, its limits and how much of them is used. LLM items are
+// counted per JST day; the remaining counts are nil when the limit is off.
+type UsageLimitStatus struct {
+	Plan              string      `json:"plan"`
+	Limits            UsageLimits `json:"limits"`
+	Sources           int         `json:"sources"`
+	LLMItemsToday     int         `json:"llm_items_today"`
+	SourcesRemaining  *int        `json:"sources_remaining"`
+	LLMItemsRemaining *int        `json:"llm_items_remaining"`
+	ResetsAt          time.Time   `json:"resets_at"`
+}
+
+// AuditLogEntry is one security-sensitive action taken on a user's account.
+type AuditLogEntry struct {
+	ID        string         `json:"id"`
+	Action    string         `json:"action"`
+	Target    *string        `json:"target,omitempty"`
+	Metadata  map[string]any `json:"metadata"`
+	IPAddress *string        `json:"ip_address,omitempty"`
+	UserAgent *string        `json:"user_agent,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AuditLogPage is a newest-first page of audit log entries; NextBefore is set when older
+// entries remain and is passed back as before.
+type AuditLogPage struct {
+	Entries    []AuditLogEntry `json:"entries"`
+	NextBefore *string         `json:"next_before"`
+}
+
+// UserSession is a way into the account: a signed-in browser session or the digest feed
+// token. Current marks the session making the request.
+type UserSession struct {
+	ID         string     `json:"id"`
+	Kind       string     `json:"kind"`
+	IPAddress  *string    `json:"ip_address,omitempty"`
+	UserAgent  *string    `json:"user_agent,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Current    bool       `json:"current"`
+}
+
 type UserSettings struct {
 	UserID                           string     `json:"user_id"`
 	AnthropicAPIKeyLast4             *string    `json:"anthropic_api_key_last4,omitempty"`
@@ -91,11 +139,14 @@ type UserSettings struct {
 	MonthlyBudgetUSD                 *float64   `json:"monthly_budget_usd,omitempty"`
 	BudgetAlertEnabled               bool       `json:"budget_alert_enabled"`
 	BudgetAlertThresholdPct          int        `json:"budget_alert_threshold_pct"`
+	BudgetEnforcementEnabled         bool       `json:"budget_enforcement_enabled"`
+	BudgetHardCapUSD                 *float64   `json:"budget_hard_cap_usd,omitempty"`
 	DigestEmailEnabled               bool       `json:"digest_email_enabled"`
 	ReadingPlanWindow                string     `json:"reading_plan_window"`
 	ReadingPlanSize                  int        `json:"reading_plan_size"`
 	ReadingPlanDiversifyTopics       bool       `json:"reading_plan_diversify_topics"`
 	ReadingPlanExcludeRead           bool       `json:"reading_plan_exclude_read"`
+	ReadingPlanExploration           float64    `json:"reading_plan_exploration"`
 	FactsModel                       *string    `json:"facts_model,omitempty"`
 	FactsSecondaryModel              *string    `json:"facts_secondary_model,omitempty"`
 	FactsSecondaryRatePercent        int        `json:"facts_secondary_rate_percent"`
@@ -126,8 +177,38 @@ type UserSettings struct {
 	TTSMarkupPreprocessModel         *string    `json:"tts_markup_preprocess_model,omitempty"`
 	UIFontSansKey                    string     `json:"ui_font_sans_key"`
 	UIFontSerifKey                   string     `json:"ui_font_serif_key"`
+	FeedAutoMigrateEnabled           bool       `json:"feed_auto_migrate_enabled"`
+	OutputLanguage                   *string    `json:"output_language,omitempty"`
+	Locale                           string     `json:"locale"`
+	DigestAudioEnabled               bool       `json:"digest_audio_enabled"`
+	DigestVerbosity                  string     `json:"digest_verbosity"`
+	DigestTone                       string     `json:"digest_tone"`
+	DigestMaxClusters                int        `json:"digest_max_clusters"`
+	DigestTopicPriorities            []string   `json:"digest_topic_priorities"`
+	DigestSkipWeekdays               []int      `json:"digest_skip_weekdays"`
+	DigestVacationStart              *time.Time `json:"digest_vacation_start,omitempty"`
+	DigestVacationEnd                *time.Time `json:"digest_vacation_end,omitempty"`
+	DigestApprovalEnabled            bool       `json:"digest_approval_enabled"`
+	DigestAutoApproveMinutes         int        `json:"digest_auto_approve_minutes"`
+	SourceStatsSharingEnabled        bool       `json:"source_stats_sharing_enabled"`
+	HTMLSnapshotsEnabled             bool       `json:"html_snapshots_enabled"`
+	ExcludePaywalled                 bool       `json:"exclude_paywalled"`
+	SocialBoostEnabled               bool       `json:"social_boost_enabled"`
+	StreakDailyTarget                int        `json:"streak_daily_target"`
+	StreakFreezeMonthlyAllowance     int        `json:"streak_freeze_monthly_allowance"`
+	WeeklyRecapEnabled               bool       `json:"weekly_recap_enabled"`
+	BriefingGreetingStyle            string     `json:"briefing_greeting_style"`
+	LLMMaxConcurrency                *int       `json:"llm_max_concurrency,omitempty"`
+	PrescreenThreshold               *float64   `json:"prescreen_threshold,omitempty"`
+	ProcessingPausedAt               *time.Time `json:"processing_paused_at,omitempty"`
 	HasInoreaderOAuth                bool       `json:"has_inoreader_oauth"`
 	InoreaderTokenExpiresAt          *time.Time `json:"inoreader_token_expires_at,omitempty"`
+	InoreaderSyncEnabled             bool       `json:"inoreader_sync_enabled"`
+	InoreaderSyncReadState           bool       `json:"inoreader_sync_read_state"`
+	InoreaderLastSyncedAt            *time.Time `json:"inoreader_last_synced_at,omitempty"`
+	InoreaderLastSyncError           *string    `json:"inoreader_last_sync_error,omitempty"`
+	HasFeedlyOAuth                   bool       `json:"has_feedly_oauth"`
+	FeedlyTokenExpiresAt             *time.Time `json:"feedly_token_expires_at,omitempty"`
 	CreatedAt                        time.Time  `json:"created_at"`
 	UpdatedAt                        time.Time  `json:"updated_at"`
 }
@@ -332,17 +413,80 @@ type ItemExportRecord struct {
 }
 
 type Source struct {
-	ID               string     `json:"id"`
-	UserID           string     `json:"user_id"`
-	URL              string     `json:"url"`
-	Type             string     `json:"type"` // rss | manual
-	Title            *string    `json:"title"`
-	Enabled          bool       `json:"enabled"`
-	LastFetchedAt    *time.Time `json:"last_fetched_at,omitempty"`
-	FeedETag         *string    `json:"-"`
-	FeedLastModified *string    `json:"-"`
-	CreatedAt        time.Time  `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
+	ID                    string     `json:"id"`
+	UserID                string     `json:"user_id"`
+	URL                   string     `json:"url"`
+	Type                  string     `json:"type"` // rss | manual
+	Title                 *string    `json:"title"`
+	Enabled               bool       `json:"enabled"`
+	LastFetchedAt         *time.Time `json:"last_fetched_at,omitempty"`
+	FeedETag              *string    `json:"-"`
+	FeedLastModified      *string    `json:"-"`
+	ProposedURL           *string    `json:"proposed_url,omitempty"`
+	ProposedURLDetectedAt *time.Time `json:"proposed_url_detected_at,omitempty"`
+	GroupName             *string    `json:"group_name,omitempty"`
+	SyncProvider          *string    `json:"sync_provider,omitempty"`
+	SyncRemovedAt         *time.Time `json:"sync_removed_at,omitempty"`
+	DefaultTopics         []string   `json:"default_topics"` // merged into new summaries' topics
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// ImportJob tracks an import that runs in chunks in the background. ProcessedCount covers added,
+// duplicate and failed entries; InvalidCount entries were rejected before the job started. Force
+// skips the near-duplicate source check of source imports.
+type ImportJob struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	Kind           string     `json:"kind"`
+	SourceID       *string    `json:"source_id,omitempty"`
+	Force          bool       `json:"force"`
+	Status         string     `json:"status"`
+	TotalCount     int        `json:"total_count"`
+	ProcessedCount int        `json:"processed_count"`
+	AddedCount     int        `json:"added_count"`
+	DuplicateCount int        `json:"duplicate_count"`
+	InvalidCount   int        `json:"invalid_count"`
+	FailedCount    int        `json:"failed_count"`
+	ErrorMessage   *string    `json:"error_message,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// ImportJobEntry is one URL waiting in an import job. GroupName is only used by source imports.
+type ImportJobEntry struct {
+	Position  int
+	URL       string
+	Title     *string
+	GroupName *string
+	AddedAt   *time.Time
+	Archived  bool
+}
+
+// ImportJobFailure is a failed import job entry with its reason.
+type ImportJobFailure struct {
+	Position int     `json:"position"`
+	URL      string  `json:"url"`
+	Title    *string `json:"title,omitempty"`
+	Message  string  `json:"message"`
+}
+
+// InoreaderSyncTarget is a user whose Inoreader subscriptions are synced by the recurring job.
+type InoreaderSyncTarget struct {
+	UserID          string
+	SyncReadState   bool
+	LastSyncedAt    *time.Time
+	ReadSyncedUntil *time.Time
+}
+
+// SourceCoSubscription is one anonymized "subscribers of FeedURL also follow RelatedFeedURL"
+// aggregate. SubscriberCount is the number of sharing users following FeedURL.
+type SourceCoSubscription struct {
+	FeedURL         string `json:"feed_url"`
+	RelatedFeedURL  string `json:"related_feed_url"`
+	CoCount         int    `json:"co_count"`
+	SubscriberCount int    `json:"subscriber_count"`
 }
 
 type ReadingGoal struct {
@@ -481,8 +625,14 @@ type Item struct {
 	UserOtherGenreLabel    *string                    `json:"user_other_genre_label,omitempty"`
 	Genre                  string                     `json:"genre,omitempty"`
 	OtherGenreLabel        *string                    `json:"other_genre_label,omitempty"`
+	SummaryDepth           *string                    `json:"summary_depth,omitempty"` // news_brief | deep_dive | tutorial
+	Paywalled              bool                       `json:"paywalled,omitempty"`
 	RecommendationReason   *string                    `json:"recommendation_reason,omitempty"`
+	PlanPinned             bool                       `json:"plan_pinned,omitempty"`
+	Exploration            bool                       `json:"exploration,omitempty"`   // picked by the exploration layer, not by preference
+	SnoozedUntil           *time.Time                 `json:"snoozed_until,omitempty"` // set once a snooze has expired
 	TranslatedTitle        *string                    `json:"translated_title,omitempty"`
+	Language               *string                    `json:"language,omitempty"`
 	SearchMatchCount       int                        `json:"search_match_count,omitempty"`
 	SearchSnippets         []ItemSearchSnippet        `json:"search_snippets,omitempty"`
 	PublishedAt            *time.Time                 `json:"published_at,omitempty"`
@@ -560,6 +710,7 @@ type ItemSummary struct {
 	Topics             []string                   `json:"topics"`
 	Genre              *string                    `json:"genre,omitempty"`
 	OtherGenreLabel    *string                    `json:"other_genre_label,omitempty"`
+	Depth              *string                    `json:"depth,omitempty"`
 	TranslatedTitle    *string                    `json:"translated_title,omitempty"`
 	Score              *float64                   `json:"score,omitempty"`
 	ScoreBreakdown     *ItemSummaryScoreBreakdown `json:"score_breakdown,omitempty"`
@@ -645,6 +796,9 @@ type PersonalScoreBreakdown struct {
 
 type ItemDetail struct {
 	Item
+	ExtractionSource  *string                   `json:"extraction_source,omitempty"`
+	ArchiveURL        *string                   `json:"archive_url,omitempty"`
+	CanonicalURL      *string                   `json:"canonical_url,omitempty"`
 	Facts             *ItemFacts                `json:"facts,omitempty"`
 	FactsLLM          *ItemSummaryLLM           `json:"facts_llm,omitempty"`
 	FactsExecutions   []ItemLLMExecutionAttempt `json:"facts_executions,omitempty"`
@@ -658,16 +812,152 @@ type ItemDetail struct {
 	Feedback          *ItemFeedback             `json:"feedback,omitempty"`
 	Note              *ItemNote                 `json:"note,omitempty"`
 	Highlights        []ItemHighlight           `json:"highlights,omitempty"`
+	Discussions       []ItemDiscussion          `json:"discussions,omitempty"`
+	ProcessingQueue   *ItemProcessingQueue      `json:"processing_queue,omitempty"`
+	Prescreen         *ItemPrescreen            `json:"prescreen,omitempty"`
+}
+
+// ItemPrescreen is the cheap relevance guess made before fact extraction. Skipped items carry a
+// stub summary until they are retried.
+type ItemPrescreen struct {
+	Score   float64 `json:"score"`
+	Skipped bool    `json:"skipped"`
+}
+
+// ItemProcessingQueue is where an unfinished item stands behind its owner's LLM concurrency
+// limit.
+type ItemProcessingQueue struct {
+	State    string `json:"state"`    // running | queued
+	Position int    `json:"position"` // 1-based among the owner's waiting items; 0 while running
+	InFlight int    `json:"in_flight"`
+	Limit    int    `json:"limit"`
+}
+
+// ItemDiscussion is a Hacker News or Reddit thread about an item, with its engagement when it
+// was last fetched.
+type ItemDiscussion struct {
+	ItemID        string    `json:"item_id"`
+	Platform      string    `json:"platform"` // hackernews | reddit
+	ExternalID    string    `json:"external_id"`
+	URL           string    `json:"url"`
+	Title         *string   `json:"title,omitempty"`
+	Points        int       `json:"points"`
+	CommentsCount int       `json:"comments_count"`
+	FetchedAt     time.Time `json:"fetched_at"`
+}
+
+// ItemTranslation is an item's summary and facts, and optionally its full text, translated
+// into one language. Cached is set when the stored translation was returned without calling
+// the LLM.
+type ItemTranslation struct {
+	ItemID    string    `json:"item_id"`
+	Language  string    `json:"language"`
+	Title     *string   `json:"title,omitempty"`
+	Summary   string    `json:"summary"`
+	Facts     []string  `json:"facts"`
+	Content   *string   `json:"content,omitempty"`
+	Model     *string   `json:"model,omitempty"`
+	Cached    bool      `json:"cached"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ItemAudio is a spoken rendering of an item's summary or full text. AudioURL is a short-lived
+// presigned link; Cached is set when the stored rendering was reused.
+type ItemAudio struct {
+	ItemID             string     `json:"item_id"`
+	Source             string     `json:"source"`
+	VoiceKey           string     `json:"-"`
+	Bucket             string     `json:"-"`
+	ObjectKey          string     `json:"-"`
+	AudioURL           string     `json:"audio_url"`
+	ContentType        string     `json:"content_type"`
+	DurationSec        int        `json:"duration_sec"`
+	TextChars          int        `json:"text_chars"`
+	SourceSummarizedAt *time.Time `json:"-"`
+	Cached             bool       `json:"cached"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+const (
+	TriageStatusApplied    = "applied"
+	TriageStatusUnchanged  = "unchanged"
+	TriageStatusSkipped    = "skipped"
+	TriageStatusNotFound   = "not_found"
+	TriageStatusDeleted    = "deleted"
+	TriageStatusNotApplied = "not_applied"
+)
+
+// TriageResult is the outcome of one decision in a triage batch.
+type TriageResult struct {
+	ItemID string `json:"item_id"`
+	Action string `json:"action"`
+	Status string `json:"status"`
+}
+
+// TriageBatchResult reports a triage batch. When Applied is false nothing was written and
+// the not_found or deleted results say why.
+type TriageBatchResult struct {
+	Applied       bool           `json:"applied"`
+	ReadCount     int            `json:"read_count"`
+	StreakCounted bool           `json:"streak_counted"`
+	Results       []TriageResult `json:"results"`
 }
 
 type ItemFeedback struct {
 	ItemID     string    `json:"item_id"`
 	UserID     string    `json:"user_id"`
-	Rating     int       `json:"rating"`      // -1 | 0 | 1
-	IsFavorite bool      `json:"is_favorite"` // quick-save
+	Rating     int       `json:"rating"`           // -1 | 0 | 1
+	IsFavorite bool      `json:"is_favorite"`      // quick-save
+	Reason     *string   `json:"reason,omitempty"` // why a negative rating was given
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+const (
+	ItemDepthNewsBrief = "news_brief"
+	ItemDepthDeepDive  = "deep_dive"
+	ItemDepthTutorial  = "tutorial"
+)
+
+func IsItemDepth(v string) bool {
+	switch v {
+	case ItemDepthNewsBrief, ItemDepthDeepDive, ItemDepthTutorial:
+		return true
+	}
+	return false
+}
+
+const (
+	FeedbackReasonOffTopic  = "off_topic"
+	FeedbackReasonClickbait = "clickbait"
+	FeedbackReasonTooLong   = "too_long"
+	FeedbackReasonDuplicate = "duplicate"
+)
+
+// FilterRule hides items tagged with Topic from the user's reading plan, digests and catch-up.
+type FilterRule struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Topic     string    `json:"topic"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FilterSuggestion proposes a topic filter or a source mute learned from recurring negative
+// feedback reasons. Target is the topic or the source ID, depending on Kind.
+type FilterSuggestion struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	Kind          string    `json:"kind"` // topic | source
+	Target        string    `json:"target"`
+	SourceTitle   *string   `json:"source_title,omitempty"`
+	Reason        string    `json:"reason"`
+	EvidenceCount int       `json:"evidence_count"`
+	Status        string    `json:"status"` // pending | accepted | dismissed
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 type ItemNote struct {
 	ID        string    `json:"id"`
 	UserID    string    `json:"user_id"`
@@ -688,6 +978,113 @@ type ItemHighlight struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// ItemHTMLSnapshot locates the sanitized copy of an item's page in blob storage.
+type ItemHTMLSnapshot struct {
+	ItemID     string    `json:"item_id"`
+	Bucket     string    `json:"-"`
+	ObjectKey  string    `json:"-"`
+	SourceURL  string    `json:"source_url"`
+	SizeBytes  int       `json:"size_bytes"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// ItemProcessingEvent is one step of the process-item pipeline as it ran for an item.
+type ItemProcessingEvent struct {
+	ID            string    `json:"id"`
+	ItemID        string    `json:"item_id"`
+	TriggerID     *string   `json:"trigger_id,omitempty"`
+	TriggerReason *string   `json:"trigger_reason,omitempty"`
+	Step          string    `json:"step"`
+	Status        string    `json:"status"`
+	Model         *string   `json:"model,omitempty"`
+	ErrorMessage  *string   `json:"error_message,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	DurationMS    int64     `json:"duration_ms"`
+}
+
+// PipelineStatus is the operator overview returned by GET /api/internal/pipeline/status.
+type PipelineStatus struct {
+	Status         string                    `json:"status"`
+	Alerts         []string                  `json:"alerts"`
+	GeneratedAt    time.Time                 `json:"generated_at"`
+	QueueDepth     map[string]int            `json:"queue_depth"`
+	Stuck          []PipelineStuckItems      `json:"stuck"`
+	StepFailures   []PipelineStepFailureRate `json:"step_failures"`
+	SlowestSources []PipelineSlowSource      `json:"slowest_sources"`
+	AutoReprocess  PipelineAutoReprocess     `json:"auto_reprocess"`
+}
+
+// PipelineAutoReprocess counts items the reprocess-stuck-items cron re-emitted, and those it
+// gave up on, over the last 24h.
+type PipelineAutoReprocess struct {
+	MaxAttempts int `json:"max_attempts"`
+	Reprocessed int `json:"reprocessed"`
+	Exhausted   int `json:"exhausted"`
+}
+
+type PipelineStuckItems struct {
+	Status          string     `json:"status"`
+	SLAMinutes      int        `json:"sla_minutes"`
+	Count           int        `json:"count"`
+	OldestUpdatedAt *time.Time `json:"oldest_updated_at,omitempty"`
+	SampleItemIDs   []string   `json:"sample_item_ids"`
+}
+
+type PipelineStepFailureRate struct {
+	Step          string  `json:"step"`
+	Attempts      int     `json:"attempts"`
+	Failures      int     `json:"failures"`
+	FailureRate   float64 `json:"failure_rate"`
+	AvgDurationMS int64   `json:"avg_duration_ms"`
+}
+
+type PipelineSlowSource struct {
+	SourceID        string  `json:"source_id"`
+	SourceURL       string  `json:"source_url"`
+	SourceTitle     *string `json:"source_title,omitempty"`
+	Items           int     `json:"items"`
+	AvgProcessingMS int64   `json:"avg_processing_ms"`
+	MaxProcessingMS int64   `json:"max_processing_ms"`
+}
+
+type Collection struct {
+	ID                   string    `json:"id"`
+	UserID               string    `json:"user_id"`
+	Name                 string    `json:"name"`
+	Description          *string   `json:"description,omitempty"`
+	WeeklySummaryEnabled bool      `json:"weekly_summary_enabled"`
+	ItemCount            int       `json:"item_count"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type CollectionItem struct {
+	ItemID          string     `json:"item_id"`
+	Position        int        `json:"position"`
+	URL             string     `json:"url"`
+	Title           *string    `json:"title"`
+	TranslatedTitle *string    `json:"translated_title,omitempty"`
+	ThumbnailURL    *string    `json:"thumbnail_url,omitempty"`
+	Status          string     `json:"status"`
+	IsRead          bool       `json:"is_read"`
+	Summary         *string    `json:"summary,omitempty"`
+	Topics          []string   `json:"topics,omitempty"`
+	SummaryScore    *float64   `json:"summary_score,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	AddedAt         time.Time  `json:"added_at"`
+}
+
+type CollectionSummary struct {
+	CollectionID string    `json:"collection_id"`
+	WeekStart    string    `json:"week_start"`
+	Subject      string    `json:"subject"`
+	Body         string    `json:"body"`
+	ItemCount    int       `json:"item_count"`
+	Model        *string   `json:"model,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type RelatedItem struct {
 	ID           string     `json:"id"`
 	SourceID     string     `json:"source_id"`
@@ -701,6 +1098,19 @@ type RelatedItem struct {
 	ReasonTopics []string   `json:"reason_topics,omitempty"`
 	PublishedAt  *time.Time `json:"published_at,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
+	// Fusion is set when the item was ranked by the hybrid (full-text + embedding) ranking.
+	Fusion *RelatedFusion `json:"fusion,omitempty"`
+}
+
+// RelatedFusion breaks a hybrid related-item score into its signals. Each ranking adds
+// weight/(k+rank) for the items it ranked; an item missing from a ranking gets nothing from it.
+type RelatedFusion struct {
+	EmbeddingRank         *int    `json:"embedding_rank,omitempty"`
+	TextRank              *int    `json:"text_rank,omitempty"`
+	TextScore             float64 `json:"text_score"`
+	EmbeddingContribution float64 `json:"embedding_contribution"`
+	TextContribution      float64 `json:"text_contribution"`
+	Score                 float64 `json:"score"`
 }
 
 type AskCandidate struct {
@@ -731,6 +1141,14 @@ type AskResponse struct {
 	AskLLM       *AskLLM        `json:"ask_llm,omitempty"`
 }
 
+type FeedAnswerResponse struct {
+	Question  string        `json:"question"`
+	Answer    string        `json:"answer"`
+	Bullets   []string      `json:"bullets,omitempty"`
+	Citations []AskCitation `json:"citations"`
+	AskLLM    *AskLLM       `json:"ask_llm,omitempty"`
+}
+
 type AskNavigator struct {
 	Enabled        bool       `json:"enabled"`
 	Persona        string     `json:"persona"`
@@ -768,6 +1186,22 @@ type ItemListResponse struct {
 	SearchUnavailable bool         `json:"search_unavailable,omitempty"`
 }
 
+// ItemFieldsListResponse is an item list page carrying only the item fields the client asked
+// for. Genre counts are left out to keep the request cheap.
+type ItemFieldsListResponse struct {
+	Items             []map[string]any `json:"items"`
+	Fields            []string         `json:"fields"`
+	Page              int              `json:"page"`
+	PageSize          int              `json:"page_size"`
+	Total             int              `json:"total"`
+	HasNext           bool             `json:"has_next"`
+	Sort              string           `json:"sort"`
+	Status            *string          `json:"status,omitempty"`
+	SourceID          *string          `json:"source_id,omitempty"`
+	SearchMode        *string          `json:"search_mode,omitempty"`
+	SearchUnavailable bool             `json:"search_unavailable,omitempty"`
+}
+
 type GenreCount struct {
 	Genre string `json:"genre"`
 	Count int    `json:"count"`
@@ -792,14 +1226,22 @@ type FavoriteExportItem struct {
 }
 
 type ReadingPlanResponse struct {
-	Items           []Item               `json:"items"`
-	Window          string               `json:"window"`
-	Size            int                  `json:"size"`
-	DiversifyTopics bool                 `json:"diversify_topics"`
-	ExcludeRead     bool                 `json:"exclude_read"`
-	SourcePoolCount int                  `json:"source_pool_count"`
-	Topics          []ReadingPlanTopic   `json:"topics"`
-	Clusters        []ReadingPlanCluster `json:"clusters,omitempty"`
+	Items           []Item  `json:"items"`
+	Window          string  `json:"window"`
+	Size            int     `json:"size"`
+	DiversifyTopics bool    `json:"diversify_topics"`
+	ExcludeRead     bool    `json:"exclude_read"`
+	Exploration     float64 `json:"exploration"`
+	Depth           string  `json:"depth,omitempty"`
+	// AvailableMinutes is the reading time the plan was fitted to, 0 when it was not.
+	AvailableMinutes  int                  `json:"available_minutes,omitempty"`
+	SourcePoolCount   int                  `json:"source_pool_count"`
+	Topics            []ReadingPlanTopic   `json:"topics"`
+	Clusters          []ReadingPlanCluster `json:"clusters,omitempty"`
+	EmbeddingCoverage *EmbeddingCoverage   `json:"embedding_coverage,omitempty"`
+	// Source is "snapshot" when served from the precomputed plan and "live" otherwise.
+	Source      string     `json:"source,omitempty"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
 }
 
 type ReadingPlanTopic struct {
@@ -808,6 +1250,16 @@ type ReadingPlanTopic struct {
 	MaxScore *float64 `json:"max_score,omitempty"`
 }
 
+// EmbeddingCoverage reports how many items could be compared by embedding. Only vectors from
+// the same model and dimensions are compared, so items embedded by another model drop out.
+type EmbeddingCoverage struct {
+	Model      string  `json:"model,omitempty"`
+	Dimensions int     `json:"dimensions,omitempty"`
+	Comparable int     `json:"comparable"`
+	Total      int     `json:"total"`
+	Percent    float64 `json:"percent"`
+}
+
 type ReadingPlanCluster struct {
 	ID             string  `json:"id"`
 	Label          string  `json:"label"`
@@ -815,6 +1267,8 @@ type ReadingPlanCluster struct {
 	MaxSimilarity  float64 `json:"max_similarity"`
 	Representative Item    `json:"representative"`
 	Items          []Item  `json:"items"`
+	// Story is the persisted story the cluster continues, so clients can follow it across days.
+	Story *ClusterStory `json:"story,omitempty"`
 }
 
 type TriageBundle struct {
@@ -863,6 +1317,24 @@ type TodayQueueResponse struct {
 	Items []TodayQueueItem `json:"items"`
 }
 
+type CatchUpCluster struct {
+	ID      string   `json:"id"`
+	Label   string   `json:"label"`
+	Size    int      `json:"size"`
+	ItemIDs []string `json:"item_ids"`
+}
+
+type CatchUpRollup struct {
+	WindowStart string           `json:"window_start"`
+	WindowDays  int              `json:"window_days"`
+	Subject     string           `json:"subject"`
+	Body        string           `json:"body"`
+	ItemCount   int              `json:"item_count"`
+	Clusters    []CatchUpCluster `json:"clusters"`
+	ItemIDs     []string         `json:"item_ids"`
+	LLM         *NavigatorLLM    `json:"llm,omitempty"`
+}
+
 type ReviewQueueItem struct {
 	ID             string     `json:"id"`
 	UserID         string     `json:"user_id"`
@@ -923,6 +1395,21 @@ type WeeklyReviewSnapshot struct {
 	CreatedAt       time.Time           `json:"created_at"`
 }
 
+// WeeklyRecapStats is what the Sunday recap email reports for one JST week.
+type WeeklyRecapStats struct {
+	WeekStart      string              `json:"week_start"`
+	WeekEnd        string              `json:"week_end"`
+	ReadCount      int                 `json:"read_count"`
+	TopTopics      []WeeklyReviewTopic `json:"top_topics,omitempty"`
+	BestStreakDays int                 `json:"best_streak_days"`
+	TopSource      *WeeklyRecapSource  `json:"top_source,omitempty"`
+}
+
+type WeeklyRecapSource struct {
+	Title     string `json:"title"`
+	ReadCount int    `json:"read_count"`
+}
+
 type SourceOptimizationSnapshot struct {
 	ID             string    `json:"id"`
 	UserID         string    `json:"user_id"`
@@ -949,6 +1436,27 @@ type NotificationPriorityRule struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 
+type LLMBudgetAllocation struct {
+	UserID        string    `json:"user_id"`
+	Purpose       string    `json:"purpose"`
+	MonthlyCapUSD float64   `json:"monthly_cap_usd"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ModelPricing struct {
+	ID                   string    `json:"id"`
+	Provider             string    `json:"provider"`
+	Model                string    `json:"model"`
+	InputPerMTokUSD      float64   `json:"input_per_mtok_usd"`
+	OutputPerMTokUSD     float64   `json:"output_per_mtok_usd"`
+	CacheReadPerMTokUSD  float64   `json:"cache_read_per_mtok_usd"`
+	CacheWritePerMTokUSD float64   `json:"cache_write_per_mtok_usd"`
+	Note                 *string   `json:"note,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
 type ItemStatsResponse struct {
 	Total    int            `json:"total"`
 	Read     int            `json:"read"`
@@ -976,6 +1484,144 @@ type TopicTrend struct {
 	MaxScore24h  *float64 `json:"max_score_24h,omitempty"`
 }
 
+type DashboardWidgetConfig struct {
+	Name   string         `json:"name"`
+	Params map[string]int `json:"params,omitempty"`
+}
+
+type DashboardLayout struct {
+	Widgets []DashboardWidgetConfig `json:"widgets"`
+}
+
+type TopicAlertSubscription struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"-"`
+	Topic         string     `json:"topic"`
+	MinDelta      int        `json:"min_delta"`
+	Channels      []string   `json:"channels"`
+	WebhookURL    *string    `json:"webhook_url,omitempty"`
+	Enabled       bool       `json:"enabled"`
+	LastAlertedAt *time.Time `json:"last_alerted_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type TopicAlertLog struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Topic          string    `json:"topic"`
+	Count24h       int       `json:"count_24h"`
+	CountPrev24h   int       `json:"count_prev_24h"`
+	Delta          int       `json:"delta"`
+	ItemIDs        []string  `json:"item_ids"`
+	SentChannels   []string  `json:"sent_channels"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ExtractedEntity struct {
+	Name           string `json:"name"`
+	NormalizedName string `json:"normalized_name"`
+	Kind           string `json:"kind"` // company | person | product | other
+	Mentions       int    `json:"mentions"`
+}
+
+type Entity struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Kind       string     `json:"kind"`
+	ItemCount  int        `json:"item_count"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+type EntityTrend struct {
+	EntityID     string `json:"entity_id"`
+	Name         string `json:"name"`
+	Kind         string `json:"kind"`
+	Count24h     int    `json:"count_24h"`
+	CountPrev24h int    `json:"count_prev_24h"`
+	Delta        int    `json:"delta"`
+}
+
+type Story struct {
+	ID          string    `json:"id"`
+	Label       string    `json:"label"`
+	ItemCount   int       `json:"item_count"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ClusterStory links a freshly computed cluster to a persisted story. MatchedBy is "items" when
+// the cluster's items already belong to the story and "centroid" when the cluster is new but
+// its centroid is close to the story's; Similarity is set for centroid matches. Ongoing means
+// the story started before today (JST).
+type ClusterStory struct {
+	StoryID     string    `json:"story_id"`
+	Label       string    `json:"label"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	Ongoing     bool      `json:"ongoing"`
+	MatchedBy   string    `json:"matched_by"`
+	Similarity  *float64  `json:"similarity,omitempty"`
+}
+
+type StoryDayNote struct {
+	Day       string   `json:"day"`
+	Bullets   []string `json:"bullets"`
+	ItemCount int      `json:"item_count"`
+	Model     *string  `json:"model,omitempty"`
+}
+
+type StoryTimelineDay struct {
+	Day     string   `json:"day"`
+	Bullets []string `json:"bullets"`
+	Pending bool     `json:"pending"`
+	Items   []Item   `json:"items"`
+}
+
+type StoryTimeline struct {
+	Story Story              `json:"story"`
+	Days  []StoryTimelineDay `json:"days"`
+}
+
+type Topic struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Aliases   []TopicAlias `json:"aliases"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type TopicAlias struct {
+	Alias      string   `json:"alias"`
+	TopicID    string   `json:"topic_id"`
+	Source     string   `json:"source"`
+	Similarity *float64 `json:"similarity,omitempty"`
+}
+
+type TopicRawCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type TopicNormalizationResult struct {
+	RawTopics          int   `json:"raw_topics"`
+	CreatedTopics      int   `json:"created_topics"`
+	LexicalAliases     int   `json:"lexical_aliases"`
+	EmbeddingAliases   int   `json:"embedding_aliases"`
+	RewrittenSummaries int64 `json:"rewritten_summaries"`
+}
+
+type MetricsDaily struct {
+	Date            string  `json:"date"`
+	ItemsIngested   int     `json:"items_ingested"`
+	ItemsSummarized int     `json:"items_summarized"`
+	ItemsRead       int     `json:"items_read"`
+	LLMCalls        int     `json:"llm_calls"`
+	LLMCostUSD      float64 `json:"llm_cost_usd"`
+	DigestsSent     int     `json:"digests_sent"`
+}
+
 type TopicPulsePoint struct {
 	Date     string   `json:"date"`
 	Count    int      `json:"count"`
@@ -1000,29 +1646,76 @@ type BriefingCluster struct {
 }
 
 type BriefingStats struct {
-	TotalUnread         int  `json:"total_unread"`
-	TodayHighlightCount int  `json:"today_highlight_count"`
-	YesterdayRead       int  `json:"yesterday_read"`
-	YesterdaySkipped    int  `json:"yesterday_skipped"`
-	StreakDays          int  `json:"streak_days"`
-	TodayReadCount      int  `json:"today_read_count"`
-	StreakTarget        int  `json:"streak_target"`
-	StreakRemaining     int  `json:"streak_remaining"`
-	StreakAtRisk        bool `json:"streak_at_risk"`
+	TotalUnread            int  `json:"total_unread"`
+	TodayHighlightCount    int  `json:"today_highlight_count"`
+	YesterdayRead          int  `json:"yesterday_read"`
+	YesterdaySkipped       int  `json:"yesterday_skipped"`
+	StreakDays             int  `json:"streak_days"`
+	TodayReadCount         int  `json:"today_read_count"`
+	StreakTarget           int  `json:"streak_target"`
+	StreakRemaining        int  `json:"streak_remaining"`
+	StreakAtRisk           bool `json:"streak_at_risk"`
+	StreakFreezesAvailable int  `json:"streak_freezes_available"`
+	StreakYesterdayFrozen  bool `json:"streak_yesterday_frozen"`
+}
+
+// ReadingStreakDay is one JST day of a reading streak. FreezeSource is set when a freeze token
+// covered the day.
+type ReadingStreakDay struct {
+	Date         string  `json:"date"`
+	ReadCount    int     `json:"read_count"`
+	StreakDays   int     `json:"streak_days"`
+	IsCompleted  bool    `json:"is_completed"`
+	FreezeSource *string `json:"freeze_source,omitempty"`
+}
+
+type StreakFreezeBalance struct {
+	MonthlyAllowance int `json:"monthly_allowance"`
+	MonthlyUsed      int `json:"monthly_used"`
+	MonthlyRemaining int `json:"monthly_remaining"`
+	Earned           int `json:"earned"`
+	Available        int `json:"available"`
+}
+
+type ReadingStreakStatus struct {
+	Date           string              `json:"date"`
+	DailyTarget    int                 `json:"daily_target"`
+	TodayReadCount int                 `json:"today_read_count"`
+	StreakDays     int                 `json:"streak_days"`
+	Freezes        StreakFreezeBalance `json:"freezes"`
+	FreezableDate  *string             `json:"freezable_date,omitempty"`
+	FrozenDates    []string            `json:"frozen_dates"`
 }
 
 type BriefingTodayResponse struct {
 	Date           string             `json:"date"`
 	Greeting       string             `json:"greeting"`
 	GreetingKey    string             `json:"greeting_key,omitempty"`
+	GreetingStyle  string             `json:"greeting_style,omitempty"`
 	Status         string             `json:"status"` // pending | ready | stale
 	GeneratedAt    *time.Time         `json:"generated_at,omitempty"`
+	Stale          bool               `json:"stale"`
+	AgeSec         *int               `json:"age_sec,omitempty"`
+	RefreshLimited bool               `json:"refresh_limited,omitempty"` // a due refresh was held back; see Retry-After
 	HighlightItems []Item             `json:"highlight_items"`
 	Clusters       []BriefingCluster  `json:"clusters"`
 	Stats          BriefingStats      `json:"stats"`
+	Blocks         []BriefingBlock    `json:"blocks"`
 	Navigator      *BriefingNavigator `json:"navigator,omitempty"`
 }
 
+// BriefingBlock is an optional personalized section of the briefing. Type says which fields
+// are set; clients render the types they know and skip the rest.
+type BriefingBlock struct {
+	Type    string   `json:"type"` // favorite_follow_up | reading_time_nudge
+	Title   string   `json:"title"`
+	Message string   `json:"message,omitempty"`
+	Topics  []string `json:"topics,omitempty"`
+	Items   []Item   `json:"items,omitempty"`
+	Hour    *int     `json:"hour,omitempty"`
+	Share   *float64 `json:"share,omitempty"`
+}
+
 type BriefingNavigatorPick struct {
 	ItemID      string   `json:"item_id"`
 	Rank        int      `json:"rank"`
@@ -1102,9 +1795,104 @@ type Digest struct {
 	SendError              *string    `json:"send_error,omitempty"`
 	SendTriedAt            *time.Time `json:"send_tried_at,omitempty"`
 	SentAt                 *time.Time `json:"sent_at,omitempty"`
+	SendTransport          *string    `json:"send_transport,omitempty"`
+	ComposeMode            string     `json:"compose_mode"`
+	WindowStartDate        *string    `json:"window_start_date,omitempty"`
+	ApprovalRequestedAt    *time.Time `json:"approval_requested_at,omitempty"`
+	ApprovedAt             *time.Time `json:"approved_at,omitempty"`
+	ApprovedBy             *string    `json:"approved_by,omitempty"`
+	AudioStatus            *string    `json:"audio_status,omitempty"`
+	AudioDurationSec       *int       `json:"audio_duration_sec,omitempty"`
+	Revision               int        `json:"revision"`
+	ParentDigestID         *string    `json:"parent_digest_id,omitempty"`
+	DigestConfigID         *string    `json:"digest_config_id,omitempty"`
 	CreatedAt              time.Time  `json:"created_at"`
 }
 
+// DigestConfig is an additional digest scoped to a set of sources, a search query or a topic
+// set, generated on its own JST schedule next to the daily digest.
+type DigestConfig struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"-"`
+	Name            string     `json:"name"`
+	ScopeType       string     `json:"scope_type"`
+	SourceIDs       []string   `json:"source_ids"`
+	SearchQuery     *string    `json:"search_query,omitempty"`
+	Topics          []string   `json:"topics"`
+	SendHourJST     int        `json:"send_hour_jst"`
+	SendWeekdays    []int      `json:"send_weekdays"`
+	Enabled         bool       `json:"enabled"`
+	LastGeneratedAt *time.Time `json:"last_generated_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// DigestRecipient is an extra address that receives the daily digest (DigestConfigID nil) or
+// a scoped digest after confirming the double-opt-in email.
+type DigestRecipient struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"-"`
+	DigestConfigID *string    `json:"digest_config_id,omitempty"`
+	Email          string     `json:"email"`
+	Status         string     `json:"status"`
+	ConfirmSentAt  *time.Time `json:"confirm_sent_at,omitempty"`
+	ConfirmedAt    *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DigestDelivery is the send result of one digest to one address.
+type DigestDelivery struct {
+	ID          string    `json:"id"`
+	DigestID    string    `json:"digest_id"`
+	RecipientID *string   `json:"recipient_id,omitempty"`
+	Email       string    `json:"email"`
+	Status      string    `json:"status"`
+	Error       *string   `json:"error,omitempty"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type DigestAudio struct {
+	DigestID    string     `json:"digest_id"`
+	Status      *string    `json:"status,omitempty"`
+	Bucket      *string    `json:"-"`
+	ObjectKey   *string    `json:"-"`
+	ContentType *string    `json:"content_type,omitempty"`
+	DurationSec *int       `json:"duration_sec,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
+}
+
+// DigestComposeProgress is how far compose-digest-copy has got with a digest. Stage is nil
+// until composition starts; the done/total pairs count cluster drafts and composed sections.
+type DigestComposeProgress struct {
+	DigestID       string     `json:"digest_id"`
+	SendStatus     *string    `json:"send_status,omitempty"`
+	Stage          *string    `json:"stage,omitempty"`
+	ClustersTotal  int        `json:"clusters_total"`
+	ClustersDone   int        `json:"clusters_done"`
+	SectionsTotal  int        `json:"sections_total"`
+	SectionsDone   int        `json:"sections_done"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	StageStartedAt *time.Time `json:"stage_started_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	ETASec         *int       `json:"eta_sec,omitempty"`
+}
+
+// DigestMonthStats summarizes the digests of one JST month. Averages are nil when no digest
+// in the month has the data behind them.
+type DigestMonthStats struct {
+	Month         string   `json:"month"`
+	Generated     int      `json:"generated"`
+	Sent          int      `json:"sent"`
+	Failed        int      `json:"failed"`
+	Skipped       int      `json:"skipped"`
+	AvgItems      float64  `json:"avg_items"`
+	AvgComposeSec *float64 `json:"avg_compose_sec"`
+	CostedDigests int      `json:"costed_digests"`
+	TotalCostUSD  float64  `json:"total_cost_usd"`
+	AvgCostUSD    *float64 `json:"avg_cost_usd"`
+}
+
 type DigestItem struct {
 	ID       string `json:"id"`
 	DigestID string `json:"digest_id"`
@@ -1112,44 +1900,76 @@ type DigestItem struct {
 	Rank     int    `json:"rank"`
 }
 
+type DigestComposeProfile struct {
+	Verbosity   string `json:"verbosity"`
+	Tone        string `json:"tone"`
+	MaxClusters int    `json:"max_clusters"`
+}
+
 type DigestDetail struct {
 	Digest
-	DigestLLM       *ItemSummaryLLM      `json:"digest_llm,omitempty"`
-	ClusterDraftLLM *ItemSummaryLLM      `json:"cluster_draft_llm,omitempty"`
-	Items           []DigestItemDetail   `json:"items"`
-	ClusterDrafts   []DigestClusterDraft `json:"cluster_drafts,omitempty"`
+	ComposeProfile  *DigestComposeProfile `json:"compose_profile,omitempty"`
+	DigestLLM       *ItemSummaryLLM       `json:"digest_llm,omitempty"`
+	ClusterDraftLLM *ItemSummaryLLM       `json:"cluster_draft_llm,omitempty"`
+	Items           []DigestItemDetail    `json:"items"`
+	ClusterDrafts   []DigestClusterDraft  `json:"cluster_drafts,omitempty"`
+	StatusHistory   []DigestStatusEvent   `json:"status_history"`
+}
+
+// DigestStatusEvent is one send_status transition of a digest, oldest first in StatusHistory.
+type DigestStatusEvent struct {
+	Status    string    `json:"status"`
+	Error     *string   `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type DigestItemDetail struct {
-	Rank    int         `json:"rank"`
-	Item    Item        `json:"item"`
-	Summary ItemSummary `json:"summary"`
-	Facts   []string    `json:"facts,omitempty"`
+	Rank     int             `json:"rank"`
+	Item     Item            `json:"item"`
+	Summary  ItemSummary     `json:"summary"`
+	Facts    []string        `json:"facts,omitempty"`
+	FollowUp *DigestFollowUp `json:"follow_up,omitempty"`
+}
+
+// DigestFollowUp points at the story from one of the user's earlier digests that an item, or a
+// cluster of items, continues.
+type DigestFollowUp struct {
+	ItemID     string  `json:"item_id"`
+	Title      string  `json:"title"`
+	URL        string  `json:"url"`
+	DigestID   string  `json:"digest_id"`
+	DigestDate string  `json:"digest_date"`
+	Similarity float64 `json:"similarity"`
 }
 
 type DigestClusterDraft struct {
-	ID           string    `json:"id"`
-	DigestID     string    `json:"digest_id"`
-	ClusterKey   string    `json:"cluster_key"`
-	ClusterLabel string    `json:"cluster_label"`
-	Rank         int       `json:"rank"`
-	ItemCount    int       `json:"item_count"`
-	Topics       []string  `json:"topics"`
-	MaxScore     *float64  `json:"max_score,omitempty"`
-	DraftSummary string    `json:"draft_summary"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           string          `json:"id"`
+	DigestID     string          `json:"digest_id"`
+	ClusterKey   string          `json:"cluster_key"`
+	ClusterLabel string          `json:"cluster_label"`
+	Rank         int             `json:"rank"`
+	ItemCount    int             `json:"item_count"`
+	Topics       []string        `json:"topics"`
+	MaxScore     *float64        `json:"max_score,omitempty"`
+	DraftSummary string          `json:"draft_summary"`
+	FollowUp     *DigestFollowUp `json:"follow_up,omitempty"`
+	Dropped      bool            `json:"dropped"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
 }
 
 type UserPreferenceProfile struct {
-	UserID           string             `json:"user_id"`
-	LearnedWeights   map[string]float64 `json:"learned_weights"`
-	TopicInterests   map[string]float64 `json:"topic_interests"`
-	PrefEmbedding    []float64          `json:"pref_embedding,omitempty"`
-	SourceAffinities map[string]float64 `json:"source_affinities"`
-	FeedbackCount    int                `json:"feedback_count"`
-	ReadCount        int                `json:"read_count"`
-	ComputedAt       *time.Time         `json:"computed_at,omitempty"`
+	UserID         string             `json:"user_id"`
+	LearnedWeights map[string]float64 `json:"learned_weights"`
+	TopicInterests map[string]float64 `json:"topic_interests"`
+	PrefEmbedding  []float64          `json:"pref_embedding,omitempty"`
+	// PrefEmbeddingModel is the embedding model PrefEmbedding was averaged from; empty for
+	// profiles computed before it was tracked.
+	PrefEmbeddingModel string             `json:"pref_embedding_model,omitempty"`
+	SourceAffinities   map[string]float64 `json:"source_affinities"`
+	FeedbackCount      int                `json:"feedback_count"`
+	ReadCount          int                `json:"read_count"`
+	ComputedAt         *time.Time         `json:"computed_at,omitempty"`
 }
 
 type PreferenceProfileWeight struct {
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditLogRepo struct{ db *pgxpool.Pool }
+
+func NewAuditLogRepo(db *pgxpool.Pool) *AuditLogRepo { return &AuditLogRepo{db} }
+
+// AuditLogInsert is one action to record. Empty strings are stored as NULL.
+type AuditLogInsert struct {
+	UserID    string
+	Action    string
+	Target    string
+	Metadata  map[string]any
+	IPAddress string
+	UserAgent string
+}
+
+func (r *AuditLogRepo) Insert(ctx context.Context, e AuditLogInsert) error {
+	metadata := e.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO audit_logs (user_id, action, target, metadata, ip_address, user_agent)
+		VALUES ($1, $2, NULLIF($3, ''), $4::jsonb, NULLIF($5, ''), NULLIF($6, ''))`,
+		e.UserID, e.Action, e.Target, raw, e.IPAddress, e.UserAgent,
+	)
+	return err
+}
+
+// AuditLogCursor points at the last entry of a page; entries strictly older come next.
+type AuditLogCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+const listAuditLogsSQL = `
+	SELECT id, action, target, metadata, ip_address, user_agent, created_at
+	FROM audit_logs
+	WHERE user_id = $1
+	  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4`
+
+// ListByUser returns up to limit entries newest first, starting after the cursor when given.
+func (r *AuditLogRepo) ListByUser(ctx context.Context, userID string, before *AuditLogCursor, limit int) ([]model.AuditLogEntry, error) {
+	var beforeAt *time.Time
+	var beforeID *string
+	if before != nil {
+		beforeAt, beforeID = &before.CreatedAt, &before.ID
+	}
+	rows, err := r.db.Query(ctx, listAuditLogsSQL, userID, beforeAt, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]model.AuditLogEntry, 0, limit)
+	for rows.Next() {
+		var e model.AuditLogEntry
+		var metadata []byte
+		if err := rows.Scan(&e.ID, &e.Action, &e.Target, &metadata, &e.IPAddress, &e.UserAgent, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Metadata = map[string]any{}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
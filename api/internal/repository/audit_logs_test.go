@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+const auditLogRepoTestUserID = "00000000-0000-4000-8000-000000000261"
+
+func TestAuditLogRepoListByUserPagesByKeyset(t *testing.T) {
+	ctx := context.Background()
+	pool, err := NewPool(ctx)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, auditLogRepoTestUserID); err != nil {
+		t.Fatalf("reset audit log repo user: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO users (id, email, name) VALUES ($1, 'audit-log-repo@example.com', 'Audit Log Repo')`, auditLogRepoTestUserID); err != nil {
+		t.Fatalf("insert audit log repo user: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, auditLogRepoTestUserID)
+	})
+
+	// Two entries share a timestamp so the page boundary falls between them and only the id
+	// breaks the tie.
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, createdAt := range []time.Time{at.Add(-time.Minute), at, at, at.Add(time.Minute)} {
+		if _, err := pool.Exec(ctx, `
+			INSERT INTO audit_logs (id, user_id, action, created_at)
+			VALUES (('00000000-0000-4000-8000-00000000027' || $1::text)::uuid, $2, 'api_key.set', $3)`,
+			i, auditLogRepoTestUserID, createdAt,
+		); err != nil {
+			t.Fatalf("insert audit log %d: %v", i, err)
+		}
+	}
+
+	repo := NewAuditLogRepo(pool)
+	first, err := repo.ListByUser(ctx, auditLogRepoTestUserID, nil, 2)
+	if err != nil {
+		t.Fatalf("ListByUser() error = %v", err)
+	}
+	if len(first) != 2 || first[0].ID != "00000000-0000-4000-8000-000000000273" || first[1].ID != "00000000-0000-4000-8000-000000000272" {
+		t.Fatalf("first page = %+v", first)
+	}
+	last := first[len(first)-1]
+	rest, err := repo.ListByUser(ctx, auditLogRepoTestUserID, &AuditLogCursor{CreatedAt: last.CreatedAt, ID: last.ID}, 10)
+	if err != nil {
+		t.Fatalf("ListByUser(before) error = %v", err)
+	}
+	if len(rest) != 2 || rest[0].ID != "00000000-0000-4000-8000-000000000271" || rest[1].ID != "00000000-0000-4000-8000-000000000270" {
+		t.Fatalf("second page = %+v", rest)
+	}
+	if other, err := repo.ListByUser(ctx, "00000000-0000-4000-8000-000000000262", nil, 10); err != nil || len(other) != 0 {
+		t.Fatalf("other user = %+v, %v", other, err)
+	}
+}
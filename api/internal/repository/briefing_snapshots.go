@@ -64,6 +64,20 @@ func (r *BriefingSnapshotRepo) Upsert(ctx context.Context, userID, date, status
 	return err
 }
 
+// TouchViewed records that the user opened the briefing for date. Writes are skipped while the
+// previous view is less than an hour old.
+func (r *BriefingSnapshotRepo) TouchViewed(ctx context.Context, userID, date string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE briefing_snapshots
+		SET last_viewed_at = NOW()
+		WHERE user_id = $1
+		  AND briefing_date = $2
+		  AND (last_viewed_at IS NULL OR last_viewed_at < NOW() - INTERVAL '1 hour')`,
+		userID, date,
+	)
+	return err
+}
+
 func (r *BriefingSnapshotRepo) MarkStale(ctx context.Context, userID, date string) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE briefing_snapshots
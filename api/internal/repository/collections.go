@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CollectionRepo struct{ db *pgxpool.Pool }
+
+func NewCollectionRepo(db *pgxpool.Pool) *CollectionRepo { return &CollectionRepo{db: db} }
+
+const collectionSelectSQL = `
+	SELECT c.id, c.user_id, c.name, c.description, c.weekly_summary_enabled,
+	       (SELECT COUNT(*) FROM collection_items ci WHERE ci.collection_id = c.id)::int AS item_count,
+	       c.created_at, c.updated_at
+	FROM collections c`
+
+func scanCollection(row pgx.Row) (*model.Collection, error) {
+	var c model.Collection
+	if err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.Description, &c.WeeklySummaryEnabled, &c.ItemCount, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CollectionRepo) ListByUser(ctx context.Context, userID string) ([]model.Collection, error) {
+	rows, err := r.db.Query(ctx, collectionSelectSQL+`
+		WHERE c.user_id = $1
+		ORDER BY c.updated_at DESC, c.name ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]model.Collection, 0)
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
+
+func (r *CollectionRepo) GetByID(ctx context.Context, userID, collectionID string) (*model.Collection, error) {
+	c, err := scanCollection(r.db.QueryRow(ctx, collectionSelectSQL+`
+		WHERE c.user_id = $1 AND c.id = $2`, userID, collectionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, c model.Collection) (*model.Collection, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO collections (user_id, name, description, weekly_summary_enabled)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		c.UserID, c.Name, c.Description, c.WeeklySummaryEnabled,
+	).Scan(&id)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return r.GetByID(ctx, c.UserID, id)
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, c model.Collection) (*model.Collection, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE collections
+		SET name = $3,
+		    description = $4,
+		    weekly_summary_enabled = $5,
+		    updated_at = NOW()
+		WHERE user_id = $1 AND id = $2`,
+		c.UserID, c.ID, c.Name, c.Description, c.WeeklySummaryEnabled,
+	)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrNotFound
+	}
+	return r.GetByID(ctx, c.UserID, c.ID)
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, userID, collectionID string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM collections WHERE user_id = $1 AND id = $2`, userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// lockOwned locks the collection row for the rest of tx so concurrent edits to its items
+// serialize.
+func (r *CollectionRepo) lockOwned(ctx context.Context, tx pgx.Tx, userID, collectionID string) error {
+	var id string
+	err := tx.QueryRow(ctx, `
+		SELECT id FROM collections WHERE user_id = $1 AND id = $2 FOR UPDATE`,
+		userID, collectionID,
+	).Scan(&id)
+	return mapDBError(err)
+}
+
+func (r *CollectionRepo) ListItems(ctx context.Context, userID, collectionID string) ([]model.CollectionItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, ci.position, i.url, i.title, sm.translated_title, i.thumbnail_url, i.status,
+		       (ir.item_id IS NOT NULL) AS is_read,
+		       sm.summary, COALESCE(sm.topics, '{}'::text[]), sm.score,
+		       i.published_at, ci.added_at
+		FROM collection_items ci
+		JOIN collections c ON c.id = ci.collection_id
+		JOIN items i ON i.id = ci.item_id
+		LEFT JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		WHERE c.user_id = $1
+		  AND c.id = $2
+		  AND i.deleted_at IS NULL
+		ORDER BY ci.position ASC, ci.added_at ASC`,
+		userID, collectionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]model.CollectionItem, 0)
+	for rows.Next() {
+		var it model.CollectionItem
+		if err := rows.Scan(
+			&it.ItemID, &it.Position, &it.URL, &it.Title, &it.TranslatedTitle, &it.ThumbnailURL, &it.Status,
+			&it.IsRead, &it.Summary, &it.Topics, &it.SummaryScore, &it.PublishedAt, &it.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+// AddItem appends the item to the end of the collection. Adding an item that is already in
+// the collection keeps its current position.
+func (r *CollectionRepo) AddItem(ctx context.Context, userID, collectionID, itemID string) error {
+	if err := NewItemRepo(r.db).ensureOwned(ctx, userID, itemID); err != nil {
+		return err
+	}
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := r.lockOwned(ctx, tx, userID, collectionID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO collection_items (collection_id, item_id, position)
+		SELECT $1, $2, COALESCE(MAX(position) + 1, 0)
+		FROM collection_items
+		WHERE collection_id = $1
+		ON CONFLICT (collection_id, item_id) DO NOTHING`,
+		collectionID, itemID,
+	); err != nil {
+		return err
+	}
+	if err := touchCollection(ctx, tx, collectionID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *CollectionRepo) RemoveItem(ctx context.Context, userID, collectionID, itemID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := r.lockOwned(ctx, tx, userID, collectionID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM collection_items WHERE collection_id = $1 AND item_id = $2`, collectionID, itemID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if err := touchCollection(ctx, tx, collectionID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ReorderItems rewrites positions to follow itemIDs. The list must contain exactly the items
+// currently in the collection; otherwise ErrInvalidState is returned and nothing changes.
+func (r *CollectionRepo) ReorderItems(ctx context.Context, userID, collectionID string, itemIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := r.lockOwned(ctx, tx, userID, collectionID); err != nil {
+		return err
+	}
+	rows, err := tx.Query(ctx, `SELECT item_id::text FROM collection_items WHERE collection_id = $1`, collectionID)
+	if err != nil {
+		return err
+	}
+	current := make([]string, 0, len(itemIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		current = append(current, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !sameCollectionItemSet(current, itemIDs) {
+		return ErrInvalidState
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE collection_items ci
+		SET position = o.ord - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(item_id, ord)
+		WHERE ci.collection_id = $1 AND ci.item_id = o.item_id`,
+		collectionID, itemIDs,
+	); err != nil {
+		return err
+	}
+	if err := touchCollection(ctx, tx, collectionID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func sameCollectionItemSet(current, next []string) bool {
+	if len(current) != len(next) {
+		return false
+	}
+	seen := make(map[string]struct{}, len(current))
+	for _, id := range current {
+		seen[id] = struct{}{}
+	}
+	for _, id := range next {
+		if _, ok := seen[id]; !ok {
+			return false
+		}
+		delete(seen, id)
+	}
+	return len(seen) == 0
+}
+
+func touchCollection(ctx context.Context, tx pgx.Tx, collectionID string) error {
+	_, err := tx.Exec(ctx, `UPDATE collections SET updated_at = NOW() WHERE id = $1`, collectionID)
+	return err
+}
+
+// ListWeeklySummaryTargets returns collections that opted into the weekly summary and
+// have at least one item.
+func (r *CollectionRepo) ListWeeklySummaryTargets(ctx context.Context) ([]model.Collection, error) {
+	rows, err := r.db.Query(ctx, collectionSelectSQL+`
+		WHERE c.weekly_summary_enabled = TRUE
+		  AND EXISTS (SELECT 1 FROM collection_items ci WHERE ci.collection_id = c.id)
+		ORDER BY c.user_id, c.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]model.Collection, 0)
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
+
+func (r *CollectionRepo) UpsertSummary(ctx context.Context, s model.CollectionSummary) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO collection_summaries (collection_id, week_start, subject, body, item_count, model)
+		VALUES ($1, $2::date, $3, $4, $5, $6)
+		ON CONFLICT (collection_id, week_start) DO UPDATE
+		SET subject = EXCLUDED.subject,
+		    body = EXCLUDED.body,
+		    item_count = EXCLUDED.item_count,
+		    model = EXCLUDED.model,
+		    created_at = NOW()`,
+		s.CollectionID, s.WeekStart, s.Subject, s.Body, s.ItemCount, s.Model,
+	)
+	return err
+}
+
+func (r *CollectionRepo) HasSummary(ctx context.Context, collectionID string, weekStart time.Time) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM collection_summaries WHERE collection_id = $1 AND week_start = $2::date)`,
+		collectionID, weekStart.Format("2006-01-02"),
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *CollectionRepo) LatestSummary(ctx context.Context, userID, collectionID string) (*model.CollectionSummary, error) {
+	var s model.CollectionSummary
+	var weekStart time.Time
+	err := r.db.QueryRow(ctx, `
+		SELECT cs.collection_id, cs.week_start, cs.subject, cs.body, cs.item_count, cs.model, cs.created_at
+		FROM collection_summaries cs
+		JOIN collections c ON c.id = cs.collection_id
+		WHERE c.user_id = $1 AND cs.collection_id = $2
+		ORDER BY cs.week_start DESC
+		LIMIT 1`,
+		userID, collectionID,
+	).Scan(&s.CollectionID, &weekStart, &s.Subject, &s.Body, &s.ItemCount, &s.Model, &s.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.WeekStart = weekStart.Format("2006-01-02")
+	return &s, nil
+}
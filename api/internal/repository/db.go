@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -14,13 +17,9 @@ func NewPool(ctx context.Context) (*pgxpool.Pool, error) {
 	if dsn == "" {
 		return nil, fmt.Errorf("DATABASE_URL is not set")
 	}
-	cfg, err := pgxpool.ParseConfig(dsn)
+	cfg, err := parsePoolConfig(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("pgxpool.ParseConfig: %w", err)
-	}
-	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
-		_, err := conn.Exec(ctx, "SET TIME ZONE 'Asia/Tokyo'")
-		return err
+		return nil, err
 	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, cfg)
@@ -35,3 +34,52 @@ func NewPool(ctx context.Context) (*pgxpool.Pool, error) {
 	}
 	return pool, nil
 }
+
+// NewReadPool connects to DATABASE_READ_REPLICA_URL for read-only queries that tolerate
+// replication lag (dashboards, usage analytics). It returns primary when no replica is
+// configured or reachable. The primary hosts are added as connection fallbacks, so while the
+// replica is down new connections land on the primary instead of failing; this relies on the
+// replica accepting the same credentials, which holds for streaming replicas.
+func NewReadPool(ctx context.Context, primary *pgxpool.Pool) *pgxpool.Pool {
+	dsn := strings.TrimSpace(os.Getenv("DATABASE_READ_REPLICA_URL"))
+	if dsn == "" {
+		return primary
+	}
+	cfg, err := parsePoolConfig(dsn)
+	if err != nil {
+		log.Printf("read replica disabled: %v", err)
+		return primary
+	}
+	primaryConn := primary.Config().ConnConfig
+	cfg.ConnConfig.Fallbacks = append(cfg.ConnConfig.Fallbacks, &pgconn.FallbackConfig{
+		Host:      primaryConn.Host,
+		Port:      primaryConn.Port,
+		TLSConfig: primaryConn.TLSConfig,
+	})
+	cfg.ConnConfig.Fallbacks = append(cfg.ConnConfig.Fallbacks, primaryConn.Fallbacks...)
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		log.Printf("read replica disabled: pgxpool.NewWithConfig: %v", err)
+		return primary
+	}
+	if err := pool.Ping(ctx); err != nil {
+		log.Printf("read replica disabled: ping: %v", err)
+		pool.Close()
+		return primary
+	}
+	log.Printf("read replica enabled host=%s", cfg.ConnConfig.Host)
+	return pool
+}
+
+func parsePoolConfig(dsn string) (*pgxpool.Config, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("pgxpool.ParseConfig: %w", err)
+	}
+	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		_, err := conn.Exec(ctx, "SET TIME ZONE 'Asia/Tokyo'")
+		return err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewReadPoolFallsBackToPrimary(t *testing.T) {
+	for name, dsn := range map[string]string{
+		"unset":   "",
+		"invalid": "postgres://%zz",
+	} {
+		t.Setenv("DATABASE_READ_REPLICA_URL", dsn)
+		if got := NewReadPool(context.Background(), nil); got != nil {
+			t.Errorf("%s: expected the primary pool to be returned", name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// StartComposeProgress resets the digest's progress to stage at the start of a compose run.
+func (r *DigestInngestRepo) StartComposeProgress(ctx context.Context, digestID, stage string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET compose_stage = $2,
+		    compose_clusters_total = 0, compose_clusters_done = 0,
+		    compose_sections_total = 0, compose_sections_done = 0,
+		    compose_started_at = NOW(), compose_stage_started_at = NOW(), compose_progress_at = NOW()
+		WHERE id = $1`, digestID, stage)
+	return err
+}
+
+// UpdateComposeStage moves the digest to stage. For the counted stages total sets the number
+// of cluster drafts or sections to go.
+func (r *DigestInngestRepo) UpdateComposeStage(ctx context.Context, digestID, stage string, total int) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET compose_stage = $2,
+		    compose_clusters_total = CASE WHEN $2 = 'cluster_drafts' THEN $3 ELSE compose_clusters_total END,
+		    compose_clusters_done = CASE WHEN $2 = 'cluster_drafts' THEN 0 ELSE compose_clusters_done END,
+		    compose_sections_total = CASE WHEN $2 = 'sections' THEN $3 ELSE compose_sections_total END,
+		    compose_sections_done = CASE WHEN $2 = 'sections' THEN 0 ELSE compose_sections_done END,
+		    compose_stage_started_at = NOW(), compose_progress_at = NOW()
+		WHERE id = $1`, digestID, stage, total)
+	return err
+}
+
+// IncrementComposeProgress counts one finished cluster draft or section of the current stage.
+func (r *DigestInngestRepo) IncrementComposeProgress(ctx context.Context, digestID, stage string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET compose_clusters_done = CASE WHEN $2 = 'cluster_drafts' THEN compose_clusters_done + 1 ELSE compose_clusters_done END,
+		    compose_sections_done = CASE WHEN $2 = 'sections' THEN compose_sections_done + 1 ELSE compose_sections_done END,
+		    compose_progress_at = NOW()
+		WHERE id = $1`, digestID, stage)
+	return err
+}
+
+func (r *DigestRepo) GetComposeProgress(ctx context.Context, id, userID string) (*model.DigestComposeProgress, error) {
+	var p model.DigestComposeProgress
+	err := r.db.QueryRow(ctx, `
+		SELECT id, send_status, compose_stage,
+		       compose_clusters_total, compose_clusters_done, compose_sections_total, compose_sections_done,
+		       compose_started_at, compose_stage_started_at, compose_progress_at
+		FROM digests
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(&p.DigestID, &p.SendStatus, &p.Stage,
+		&p.ClustersTotal, &p.ClustersDone, &p.SectionsTotal, &p.SectionsDone,
+		&p.StartedAt, &p.StageStartedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &p, nil
+}
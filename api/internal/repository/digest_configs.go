@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DigestConfigRepo struct{ db *pgxpool.Pool }
+
+func NewDigestConfigRepo(db *pgxpool.Pool) *DigestConfigRepo { return &DigestConfigRepo{db} }
+
+const digestConfigColumns = `id, user_id, name, scope_type, source_ids::text[], search_query, topics,
+	send_hour_jst, send_weekdays, enabled, last_generated_at, created_at, updated_at`
+
+func scanDigestConfig(row pgx.Row) (*model.DigestConfig, error) {
+	var c model.DigestConfig
+	var hour int16
+	var weekdays []int16
+	if err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.ScopeType, &c.SourceIDs, &c.SearchQuery, &c.Topics,
+		&hour, &weekdays, &c.Enabled, &c.LastGeneratedAt, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	c.SendHourJST = int(hour)
+	c.SendWeekdays = make([]int, 0, len(weekdays))
+	for _, d := range weekdays {
+		c.SendWeekdays = append(c.SendWeekdays, int(d))
+	}
+	return &c, nil
+}
+
+func (r *DigestConfigRepo) ListByUser(ctx context.Context, userID string) ([]model.DigestConfig, error) {
+	return r.list(ctx, `
+		SELECT `+digestConfigColumns+`
+		FROM digest_configs
+		WHERE user_id = $1
+		ORDER BY name`, userID)
+}
+
+// ListDue returns enabled configs scheduled for the given JST hour and weekday that have not
+// been generated since notGeneratedSince.
+func (r *DigestConfigRepo) ListDue(ctx context.Context, hourJST, weekday int, notGeneratedSince time.Time) ([]model.DigestConfig, error) {
+	return r.list(ctx, `
+		SELECT `+digestConfigColumns+`
+		FROM digest_configs
+		WHERE enabled = true
+		  AND send_hour_jst = $1
+		  AND $2 = ANY(send_weekdays)
+		  AND (last_generated_at IS NULL OR last_generated_at < $3)
+		ORDER BY user_id, name`, hourJST, weekday, notGeneratedSince)
+}
+
+func (r *DigestConfigRepo) list(ctx context.Context, query string, args ...any) ([]model.DigestConfig, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.DigestConfig{}
+	for rows.Next() {
+		c, err := scanDigestConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
+
+func (r *DigestConfigRepo) Get(ctx context.Context, userID, id string) (*model.DigestConfig, error) {
+	c, err := scanDigestConfig(r.db.QueryRow(ctx, `
+		SELECT `+digestConfigColumns+`
+		FROM digest_configs
+		WHERE id = $1 AND user_id = $2`, id, userID))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return c, nil
+}
+
+// CountOwnedSources counts how many of sourceIDs belong to the user.
+func (r *DigestConfigRepo) CountOwnedSources(ctx context.Context, userID string, sourceIDs []string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM sources WHERE user_id = $1 AND id::text = ANY($2::text[])`,
+		userID, sourceIDs,
+	).Scan(&n)
+	return n, err
+}
+
+func (r *DigestConfigRepo) Create(ctx context.Context, c model.DigestConfig) (*model.DigestConfig, error) {
+	out, err := scanDigestConfig(r.db.QueryRow(ctx, `
+		INSERT INTO digest_configs (user_id, name, scope_type, source_ids, search_query, topics, send_hour_jst, send_weekdays, enabled)
+		VALUES ($1, $2, $3, $4::uuid[], $5, $6, $7, $8::smallint[], $9)
+		RETURNING `+digestConfigColumns,
+		c.UserID, c.Name, c.ScopeType, c.SourceIDs, c.SearchQuery, c.Topics, c.SendHourJST, c.SendWeekdays, c.Enabled,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return out, nil
+}
+
+func (r *DigestConfigRepo) Update(ctx context.Context, c model.DigestConfig) (*model.DigestConfig, error) {
+	out, err := scanDigestConfig(r.db.QueryRow(ctx, `
+		UPDATE digest_configs
+		SET name = $3,
+		    scope_type = $4,
+		    source_ids = $5::uuid[],
+		    search_query = $6,
+		    topics = $7,
+		    send_hour_jst = $8,
+		    send_weekdays = $9::smallint[],
+		    enabled = $10,
+		    updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+digestConfigColumns,
+		c.ID, c.UserID, c.Name, c.ScopeType, c.SourceIDs, c.SearchQuery, c.Topics, c.SendHourJST, c.SendWeekdays, c.Enabled,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return out, nil
+}
+
+// Delete removes the config together with its digest history.
+func (r *DigestConfigRepo) Delete(ctx context.Context, userID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM digest_configs WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *DigestConfigRepo) MarkGenerated(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE digest_configs SET last_generated_at = $2 WHERE id = $1`, id, at)
+	return err
+}
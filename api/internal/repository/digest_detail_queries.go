@@ -8,18 +8,33 @@ import (
 
 func (r *DigestRepo) loadDigestDetailBase(ctx context.Context, id, userID string) (*model.DigestDetail, error) {
 	var d model.DigestDetail
+	var composeVerbosity, composeTone *string
+	var composeMaxClusters *int
 	err := r.db.QueryRow(ctx, `
 		SELECT id, user_id, digest_date::text, email_subject, email_body,
 		       digest_retry_count, cluster_draft_retry_count,
-		       send_status, send_error, send_tried_at, sent_at, created_at
+		       send_status, send_error, send_tried_at, sent_at, send_transport,
+		       audio_status, audio_duration_sec, revision, parent_digest_id, digest_config_id,
+		       compose_verbosity, compose_tone, compose_max_clusters,
+		       compose_mode, window_start_date::text, approval_requested_at, approved_at, approved_by, created_at
 		FROM digests
 		WHERE id = $1 AND user_id = $2`, id, userID,
 	).Scan(&d.ID, &d.UserID, &d.DigestDate, &d.EmailSubject, &d.EmailBody,
 		&d.DigestRetryCount, &d.ClusterDraftRetryCount,
-		&d.SendStatus, &d.SendError, &d.SendTriedAt, &d.SentAt, &d.CreatedAt)
+		&d.SendStatus, &d.SendError, &d.SendTriedAt, &d.SentAt, &d.SendTransport,
+		&d.AudioStatus, &d.AudioDurationSec, &d.Revision, &d.ParentDigestID, &d.DigestConfigID,
+		&composeVerbosity, &composeTone, &composeMaxClusters,
+		&d.ComposeMode, &d.WindowStartDate, &d.ApprovalRequestedAt, &d.ApprovedAt, &d.ApprovedBy, &d.CreatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
+	if composeVerbosity != nil && composeTone != nil && composeMaxClusters != nil {
+		d.ComposeProfile = &model.DigestComposeProfile{
+			Verbosity:   *composeVerbosity,
+			Tone:        *composeTone,
+			MaxClusters: *composeMaxClusters,
+		}
+	}
 	return &d, nil
 }
 
@@ -30,7 +45,8 @@ func (r *DigestRepo) queryDigestItems(ctx context.Context, digestID string) ([]m
 		       i.published_at, i.fetched_at, i.created_at, i.updated_at,
 		       s.id, s.item_id, s.summary, s.topics, s.translated_title, s.score,
 		       s.score_breakdown, s.score_reason, s.score_policy_version, s.summarized_at,
-		       COALESCE(f.facts, '[]'::jsonb) AS facts
+		       COALESCE(f.facts, '[]'::jsonb) AS facts,
+		       di.follow_up, di.is_exploration
 		FROM digest_items di
 		JOIN items i ON i.id = di.item_id
 		JOIN item_summaries s ON s.item_id = i.id
@@ -56,6 +72,7 @@ func (r *DigestRepo) queryDigestItems(ctx context.Context, digestID string) ([]m
 			scoreBreakdownScanner{dst: &did.Summary.ScoreBreakdown}, &did.Summary.ScoreReason,
 			&did.Summary.ScorePolicyVersion, &did.Summary.SummarizedAt,
 			jsonStringArrayScanner{dst: &did.Facts},
+			&did.FollowUp, &did.Item.Exploration,
 		); err != nil {
 			return nil, err
 		}
@@ -66,7 +83,7 @@ func (r *DigestRepo) queryDigestItems(ctx context.Context, digestID string) ([]m
 
 func (r *DigestRepo) queryDigestClusterDrafts(ctx context.Context, digestID string) ([]model.DigestClusterDraft, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT id, digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, created_at, updated_at
+		SELECT id, digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, follow_up, dropped, created_at, updated_at
 		FROM digest_cluster_drafts
 		WHERE digest_id = $1
 		ORDER BY rank ASC, created_at ASC`, digestID)
@@ -80,7 +97,7 @@ func (r *DigestRepo) queryDigestClusterDrafts(ctx context.Context, digestID stri
 		var cd model.DigestClusterDraft
 		if err := rows.Scan(
 			&cd.ID, &cd.DigestID, &cd.ClusterKey, &cd.ClusterLabel, &cd.Rank, &cd.ItemCount,
-			&cd.Topics, &cd.MaxScore, &cd.DraftSummary, &cd.CreatedAt, &cd.UpdatedAt,
+			&cd.Topics, &cd.MaxScore, &cd.DraftSummary, &cd.FollowUp, &cd.Dropped, &cd.CreatedAt, &cd.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -88,3 +105,25 @@ func (r *DigestRepo) queryDigestClusterDrafts(ctx context.Context, digestID stri
 	}
 	return out, rows.Err()
 }
+
+func (r *DigestRepo) queryDigestStatusHistory(ctx context.Context, digestID string) ([]model.DigestStatusEvent, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT status, error, created_at
+		FROM digest_status_events
+		WHERE digest_id = $1
+		ORDER BY created_at ASC`, digestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]model.DigestStatusEvent, 0)
+	for rows.Next() {
+		var ev model.DigestStatusEvent
+		if err := rows.Scan(&ev.Status, &ev.Error, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, ev)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DigestRecipientRepo struct{ db *pgxpool.Pool }
+
+func NewDigestRecipientRepo(db *pgxpool.Pool) *DigestRecipientRepo { return &DigestRecipientRepo{db} }
+
+const digestRecipientColumns = `id, user_id, digest_config_id, email, status, confirm_sent_at, confirmed_at, created_at`
+
+func scanDigestRecipient(row pgx.Row) (*model.DigestRecipient, error) {
+	var v model.DigestRecipient
+	if err := row.Scan(&v.ID, &v.UserID, &v.DigestConfigID, &v.Email, &v.Status, &v.ConfirmSentAt, &v.ConfirmedAt, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *DigestRecipientRepo) ListByUser(ctx context.Context, userID string) ([]model.DigestRecipient, error) {
+	return r.list(ctx, `
+		SELECT `+digestRecipientColumns+`
+		FROM digest_recipients
+		WHERE user_id = $1
+		ORDER BY digest_config_id NULLS FIRST, email`, userID)
+}
+
+// ListConfirmedForDigest returns the confirmed recipients of the digest's owner and config.
+func (r *DigestRecipientRepo) ListConfirmedForDigest(ctx context.Context, digestID string) ([]model.DigestRecipient, error) {
+	return r.list(ctx, `
+		SELECT dr.id, dr.user_id, dr.digest_config_id, dr.email, dr.status, dr.confirm_sent_at, dr.confirmed_at, dr.created_at
+		FROM digest_recipients dr
+		JOIN digests d ON d.user_id = dr.user_id
+		             AND d.digest_config_id IS NOT DISTINCT FROM dr.digest_config_id
+		WHERE d.id = $1 AND dr.status = 'confirmed'
+		ORDER BY dr.email`, digestID)
+}
+
+func (r *DigestRecipientRepo) list(ctx context.Context, query string, args ...any) ([]model.DigestRecipient, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.DigestRecipient{}
+	for rows.Next() {
+		v, err := scanDigestRecipient(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *v)
+	}
+	return out, rows.Err()
+}
+
+// CountForDigest counts the recipients, pending or confirmed, of the daily digest (configID
+// nil) or of one scoped digest.
+func (r *DigestRecipientRepo) CountForDigest(ctx context.Context, userID string, configID *string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM digest_recipients
+		WHERE user_id = $1 AND digest_config_id IS NOT DISTINCT FROM $2::uuid`, userID, configID,
+	).Scan(&n)
+	return n, err
+}
+
+// ConfigOwned reports whether the digest config belongs to the user.
+func (r *DigestRecipientRepo) ConfigOwned(ctx context.Context, userID, configID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM digest_configs WHERE id = $1 AND user_id = $2)`, configID, userID,
+	).Scan(&ok)
+	return ok, err
+}
+
+func (r *DigestRecipientRepo) Create(ctx context.Context, userID string, configID *string, email string) (*model.DigestRecipient, error) {
+	v, err := scanDigestRecipient(r.db.QueryRow(ctx, `
+		INSERT INTO digest_recipients (user_id, digest_config_id, email)
+		VALUES ($1, $2, $3)
+		RETURNING `+digestRecipientColumns, userID, configID, email))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+func (r *DigestRecipientRepo) Get(ctx context.Context, userID, id string) (*model.DigestRecipient, error) {
+	v, err := scanDigestRecipient(r.db.QueryRow(ctx, `
+		SELECT `+digestRecipientColumns+`
+		FROM digest_recipients
+		WHERE id = $1 AND user_id = $2`, id, userID))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+func (r *DigestRecipientRepo) Delete(ctx context.Context, userID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM digest_recipients WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByID removes a recipient without an owner check; it serves the recipient's own signed
+// unsubscribe link.
+func (r *DigestRecipientRepo) DeleteByID(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM digest_recipients WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// IssueConfirmToken stores a new confirmation token hash for a pending recipient, replacing
+// any earlier one. Confirmed or deleted recipients return ErrNotFound.
+func (r *DigestRecipientRepo) IssueConfirmToken(ctx context.Context, id, tokenHash string, expiresAt time.Time) (*model.DigestRecipient, error) {
+	v, err := scanDigestRecipient(r.db.QueryRow(ctx, `
+		UPDATE digest_recipients
+		SET confirm_token_hash = $2,
+		    confirm_expires_at = $3,
+		    confirm_sent_at = NOW(),
+		    updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+digestRecipientColumns, id, tokenHash, expiresAt))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+// ConfirmByTokenHash confirms the recipient holding an unexpired token. The token is single use.
+func (r *DigestRecipientRepo) ConfirmByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*model.DigestRecipient, error) {
+	v, err := scanDigestRecipient(r.db.QueryRow(ctx, `
+		UPDATE digest_recipients
+		SET status = 'confirmed',
+		    confirmed_at = $2,
+		    confirm_token_hash = NULL,
+		    confirm_expires_at = NULL,
+		    updated_at = NOW()
+		WHERE confirm_token_hash = $1 AND status = 'pending' AND confirm_expires_at > $2
+		RETURNING `+digestRecipientColumns, tokenHash, now))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+// RecordDelivery stores the latest send result of a digest to one address.
+func (r *DigestRecipientRepo) RecordDelivery(ctx context.Context, digestID string, recipientID *string, email, status string, sendErr *string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO digest_deliveries (digest_id, recipient_id, email, status, error)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (digest_id, email) DO UPDATE
+		SET recipient_id = EXCLUDED.recipient_id,
+		    status = EXCLUDED.status,
+		    error = EXCLUDED.error,
+		    attempted_at = NOW()`, digestID, recipientID, email, status, sendErr)
+	return err
+}
+
+func (r *DigestRecipientRepo) ListDeliveries(ctx context.Context, userID, digestID string) ([]model.DigestDelivery, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM digests WHERE id = $1 AND user_id = $2)`, digestID, userID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id, digest_id, recipient_id, email, status, error, attempted_at
+		FROM digest_deliveries
+		WHERE digest_id = $1
+		ORDER BY recipient_id NULLS FIRST, email`, digestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.DigestDelivery{}
+	for rows.Next() {
+		var v model.DigestDelivery
+		if err := rows.Scan(&v.ID, &v.DigestID, &v.RecipientID, &v.Email, &v.Status, &v.Error, &v.AttemptedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
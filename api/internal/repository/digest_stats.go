@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// MonthlyStats summarizes digests dated on or after since by JST month, newest first. A nil
+// userID covers every user, for operator monitoring. The cost average counts only digests with
+// usage rows, so skipped digests do not dilute it.
+func (r *DigestRepo) MonthlyStats(ctx context.Context, userID *string, since time.Time) ([]model.DigestMonthStats, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH scoped AS (
+			SELECT d.id, d.digest_date, d.sent_at, d.send_status, d.compose_stage,
+			       d.compose_started_at, d.compose_stage_started_at,
+			       (SELECT COUNT(*) FROM digest_items di WHERE di.digest_id = d.id) AS item_count,
+			       (SELECT SUM(l.estimated_cost_usd) FROM llm_usage_logs l WHERE l.digest_id = d.id) AS cost_usd
+			FROM digests d
+			WHERE ($1::uuid IS NULL OR d.user_id = $1)
+			  AND d.digest_date >= $2::date
+		)
+		SELECT to_char(digest_date, 'YYYY-MM') AS month,
+		       COUNT(*)::int,
+		       COUNT(*) FILTER (WHERE sent_at IS NOT NULL)::int,
+		       COUNT(*) FILTER (WHERE sent_at IS NULL AND send_status LIKE '%\_failed')::int,
+		       COUNT(*) FILTER (WHERE sent_at IS NULL AND send_status LIKE 'skipped\_%')::int,
+		       COALESCE(AVG(item_count), 0)::float8,
+		       (AVG(EXTRACT(EPOCH FROM compose_stage_started_at - compose_started_at))
+		         FILTER (WHERE compose_stage = 'done' AND compose_started_at IS NOT NULL))::float8,
+		       COUNT(cost_usd)::int,
+		       COALESCE(SUM(cost_usd), 0)::float8,
+		       AVG(cost_usd)::float8
+		FROM scoped
+		GROUP BY month
+		ORDER BY month DESC`,
+		userID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]model.DigestMonthStats, 0)
+	for rows.Next() {
+		var v model.DigestMonthStats
+		if err := rows.Scan(&v.Month, &v.Generated, &v.Sent, &v.Failed, &v.Skipped, &v.AvgItems,
+			&v.AvgComposeSec, &v.CostedDigests, &v.TotalCostUSD, &v.AvgCostUSD); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
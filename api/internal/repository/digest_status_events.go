@@ -0,0 +1,30 @@
+package repository
+
+// DigestStatusEventReset is logged when a digest's output is cleared for recomposition, so the
+// history shows where one compose run ends and the next begins.
+const DigestStatusEventReset = "reset"
+
+// withDigestStatusEvent wraps an UPDATE of digests ending in RETURNING id, send_status,
+// send_error, so the same statement appends each new status to digest_status_events and
+// digests.send_status never changes without a history row. Inngest replays re-apply the same
+// status, so a row repeating the digest's latest event is skipped. The statement returns the
+// number of digests updated.
+func withDigestStatusEvent(update string) string {
+	return `
+		WITH changed AS (` + update + `),
+		logged AS (
+			INSERT INTO digest_status_events (digest_id, status, error)
+			SELECT c.id, c.send_status, c.send_error
+			FROM changed c
+			LEFT JOIN LATERAL (
+				SELECT e.status, e.error
+				FROM digest_status_events e
+				WHERE e.digest_id = c.id
+				ORDER BY e.created_at DESC
+				LIMIT 1
+			) last ON TRUE
+			WHERE c.send_status IS NOT NULL
+			  AND (last.status IS DISTINCT FROM c.send_status OR last.error IS DISTINCT FROM c.send_error)
+		)
+		SELECT COUNT(*) FROM changed`
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithDigestStatusEventLogsReturnedStatus(t *testing.T) {
+	query := withDigestStatusEvent(`UPDATE digests SET send_status = $1 WHERE id = $2 RETURNING id, send_status, send_error`)
+	if !strings.Contains(query, "WITH changed AS (UPDATE digests") {
+		t.Fatalf("update is not wrapped in the changed CTE: %s", query)
+	}
+	if !strings.Contains(query, "INSERT INTO digest_status_events (digest_id, status, error)") {
+		t.Fatalf("query does not log the returned status: %s", query)
+	}
+	if !strings.Contains(query, "last.status IS DISTINCT FROM c.send_status") {
+		t.Fatalf("query does not skip replayed statuses: %s", query)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(query), "SELECT COUNT(*) FROM changed") {
+		t.Fatalf("query does not return the updated count: %s", query)
+	}
+}
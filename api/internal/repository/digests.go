@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,11 +24,26 @@ func (r *DigestRepo) ListLimit(ctx context.Context, userID string, limit int) ([
 	if limit > 100 {
 		limit = 100
 	}
+	return r.listDigests(ctx, `user_id = $1`, userID, limit)
+}
+
+// ListForConfig returns the history of one scoped digest config.
+func (r *DigestRepo) ListForConfig(ctx context.Context, userID, configID string, limit int) ([]model.Digest, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 30
+	}
+	return r.listDigests(ctx, `user_id = $1 AND digest_config_id = $3`, userID, limit, configID)
+}
+
+func (r *DigestRepo) listDigests(ctx context.Context, where, userID string, limit int, args ...any) ([]model.Digest, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT id, user_id, digest_date::text, email_subject, email_body,
 		       digest_retry_count, cluster_draft_retry_count,
-		       send_status, send_error, send_tried_at, sent_at, created_at
-		FROM digests WHERE user_id = $1 ORDER BY digest_date DESC LIMIT $2`, userID, limit)
+		       send_status, send_error, send_tried_at, sent_at, send_transport,
+		       audio_status, audio_duration_sec, revision, parent_digest_id, digest_config_id,
+		       compose_mode, window_start_date::text, approval_requested_at, approved_at, approved_by, created_at
+		FROM digests WHERE `+where+` ORDER BY digest_date DESC, revision DESC LIMIT $2`,
+		append([]any{userID, limit}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +54,9 @@ func (r *DigestRepo) ListLimit(ctx context.Context, userID string, limit int) ([
 		var d model.Digest
 		if err := rows.Scan(&d.ID, &d.UserID, &d.DigestDate, &d.EmailSubject, &d.EmailBody,
 			&d.DigestRetryCount, &d.ClusterDraftRetryCount,
-			&d.SendStatus, &d.SendError, &d.SendTriedAt, &d.SentAt, &d.CreatedAt); err != nil {
+			&d.SendStatus, &d.SendError, &d.SendTriedAt, &d.SentAt, &d.SendTransport,
+			&d.AudioStatus, &d.AudioDurationSec, &d.Revision, &d.ParentDigestID, &d.DigestConfigID,
+			&d.ComposeMode, &d.WindowStartDate, &d.ApprovalRequestedAt, &d.ApprovedAt, &d.ApprovedBy, &d.CreatedAt); err != nil {
 			return nil, err
 		}
 		digests = append(digests, d)
@@ -64,16 +83,268 @@ func (r *DigestRepo) GetDetail(ctx context.Context, id, userID string) (*model.D
 		return nil, err
 	}
 	d.ClusterDrafts = clusterDrafts
+
+	history, err := r.queryDigestStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	d.StatusHistory = history
 	return d, nil
 }
 
 func (r *DigestRepo) GetLatest(ctx context.Context, userID string) (*model.DigestDetail, error) {
 	var id string
 	err := r.db.QueryRow(ctx, `
-		SELECT id FROM digests WHERE user_id = $1 ORDER BY digest_date DESC LIMIT 1`, userID,
+		SELECT id FROM digests
+		WHERE user_id = $1 AND digest_config_id IS NULL
+		ORDER BY digest_date DESC, revision DESC LIMIT 1`, userID,
 	).Scan(&id)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
 	return r.GetDetail(ctx, id, userID)
 }
+
+func (r *DigestRepo) GetAudio(ctx context.Context, id, userID string) (*model.DigestAudio, error) {
+	var a model.DigestAudio
+	err := r.db.QueryRow(ctx, `
+		SELECT id, audio_status, audio_bucket, audio_object_key, audio_content_type,
+		       audio_duration_sec, audio_error, audio_generated_at
+		FROM digests
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(&a.DigestID, &a.Status, &a.Bucket, &a.ObjectKey, &a.ContentType,
+		&a.DurationSec, &a.Error, &a.GeneratedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &a, nil
+}
+
+// Approve releases a digest that is awaiting approval for sending. Digests in any other state
+// return ErrInvalidState, so a digest is never handed to send-digest twice.
+func (r *DigestRepo) Approve(ctx context.Context, id, userID string) error {
+	var approved int
+	err := r.db.QueryRow(ctx, withDigestStatusEvent(`
+		UPDATE digests
+		SET send_status = 'approved',
+		    approved_at = NOW(),
+		    approved_by = 'user'
+		WHERE id = $1
+		  AND user_id = $2
+		  AND send_status = 'awaiting_approval'
+		  AND sent_at IS NULL
+		RETURNING id, send_status, send_error`), id, userID).Scan(&approved)
+	if err != nil {
+		return err
+	}
+	if approved > 0 {
+		return nil
+	}
+	var exists bool
+	if err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM digests WHERE id = $1 AND user_id = $2)`, id, userID,
+	).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrInvalidState
+}
+
+// ResetForRegeneration clears the composed copy, cluster drafts and audio of an unsent digest
+// so the compose pipeline runs again. Sent digests return ErrInvalidState.
+func (r *DigestRepo) ResetForRegeneration(ctx context.Context, id, userID string) error {
+	return r.resetDigestOutput(ctx, id, userID, true)
+}
+
+// ResetForRecompose is like ResetForRegeneration but keeps the (possibly edited) cluster drafts.
+func (r *DigestRepo) ResetForRecompose(ctx context.Context, id, userID string) error {
+	return r.resetDigestOutput(ctx, id, userID, false)
+}
+
+func (r *DigestRepo) resetDigestOutput(ctx context.Context, id, userID string, clearClusterDrafts bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUnsentDigest(ctx, tx, id, userID); err != nil {
+		return err
+	}
+	if err := clearDigestOutput(ctx, tx, id, clearClusterDrafts); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RemoveItem drops one item from an unsent digest, closes the gap in the ranks and clears the
+// composed output and cluster drafts, which were written with the item in them.
+func (r *DigestRepo) RemoveItem(ctx context.Context, id, userID, itemID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUnsentDigest(ctx, tx, id, userID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM digest_items WHERE digest_id = $1 AND item_id = $2`, id, itemID)
+	if err != nil {
+		return mapDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE digest_items di
+		SET rank = ranked.new_rank
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY rank, id) AS new_rank
+			FROM digest_items
+			WHERE digest_id = $1
+		) ranked
+		WHERE di.id = ranked.id
+		  AND di.rank <> ranked.new_rank`, id); err != nil {
+		return err
+	}
+	if err := clearDigestOutput(ctx, tx, id, true); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func clearDigestOutput(ctx context.Context, tx pgx.Tx, id string, clearClusterDrafts bool) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE digests
+		SET email_subject = NULL,
+		    email_body = NULL,
+		    digest_retry_count = 0,
+		    cluster_draft_retry_count = 0,
+		    send_status = NULL,
+		    send_error = NULL,
+		    send_tried_at = NULL,
+		    send_transport = NULL,
+		    approval_requested_at = NULL,
+		    approved_at = NULL,
+		    approved_by = NULL,
+		    audio_status = NULL,
+		    audio_bucket = NULL,
+		    audio_object_key = NULL,
+		    audio_content_type = NULL,
+		    audio_duration_sec = NULL,
+		    audio_error = NULL,
+		    audio_generated_at = NULL
+		WHERE id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO digest_status_events (digest_id, status)
+		VALUES ($1, '`+DigestStatusEventReset+`')`, id); err != nil {
+		return err
+	}
+	if clearClusterDrafts {
+		if _, err := tx.Exec(ctx, `DELETE FROM digest_cluster_drafts WHERE digest_id = $1`, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lockUnsentDigest(ctx context.Context, tx pgx.Tx, id, userID string) error {
+	var sentAt *time.Time
+	if err := tx.QueryRow(ctx, `
+		SELECT sent_at FROM digests WHERE id = $1 AND user_id = $2 FOR UPDATE`, id, userID,
+	).Scan(&sentAt); err != nil {
+		return mapDBError(err)
+	}
+	if sentAt != nil {
+		return ErrInvalidState
+	}
+	return nil
+}
+
+func (r *DigestRepo) ListClusterDrafts(ctx context.Context, id, userID string) ([]model.DigestClusterDraft, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM digests WHERE id = $1 AND user_id = $2)`, id, userID,
+	).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return r.queryDigestClusterDrafts(ctx, id)
+}
+
+// UpdateClusterDraft renames or drops a cluster draft of an unsent digest.
+// Nil arguments leave the corresponding column unchanged.
+func (r *DigestRepo) UpdateClusterDraft(ctx context.Context, id, userID, clusterID string, label *string, dropped *bool) (*model.DigestClusterDraft, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUnsentDigest(ctx, tx, id, userID); err != nil {
+		return nil, err
+	}
+	var cd model.DigestClusterDraft
+	if err := tx.QueryRow(ctx, `
+		UPDATE digest_cluster_drafts
+		SET cluster_label = COALESCE($3, cluster_label),
+		    dropped = COALESCE($4, dropped),
+		    updated_at = NOW()
+		WHERE id = $1 AND digest_id = $2
+		RETURNING id, digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, dropped, created_at, updated_at`,
+		clusterID, id, label, dropped,
+	).Scan(
+		&cd.ID, &cd.DigestID, &cd.ClusterKey, &cd.ClusterLabel, &cd.Rank, &cd.ItemCount,
+		&cd.Topics, &cd.MaxScore, &cd.DraftSummary, &cd.Dropped, &cd.CreatedAt, &cd.UpdatedAt,
+	); err != nil {
+		return nil, mapDBError(err)
+	}
+	return &cd, tx.Commit(ctx)
+}
+
+// CreateRevision copies a digest's items into a new, unsent revision for the same date.
+// Already-sent digests are kept immutable; the revision is composed and sent on its own.
+func (r *DigestRepo) CreateRevision(ctx context.Context, id, userID string) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var digestDate time.Time
+	var rootID string
+	var configID *string
+	if err := tx.QueryRow(ctx, `
+		SELECT digest_date, COALESCE(parent_digest_id, id), digest_config_id
+		FROM digests
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(&digestDate, &rootID, &configID); err != nil {
+		return "", mapDBError(err)
+	}
+	var newID string
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO digests (user_id, digest_date, revision, parent_digest_id, compose_mode, window_start_date, digest_config_id)
+		SELECT $1, $2, COALESCE(MAX(revision), 1) + 1, $3,
+		       (SELECT compose_mode FROM digests WHERE id = $3),
+		       (SELECT window_start_date FROM digests WHERE id = $3),
+		       $4::uuid
+		FROM digests
+		WHERE user_id = $1 AND digest_date = $2 AND digest_config_id IS NOT DISTINCT FROM $4::uuid
+		RETURNING id`, userID, digestDate, rootID, configID,
+	).Scan(&newID); err != nil {
+		return "", mapDBError(err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO digest_items (digest_id, item_id, rank, is_exploration)
+		SELECT $1, item_id, rank, is_exploration FROM digest_items WHERE digest_id = $2`, newID, id); err != nil {
+		return "", err
+	}
+	return newID, tx.Commit(ctx)
+}
@@ -12,7 +12,48 @@ type DigestInngestRepo struct{ db *pgxpool.Pool }
 
 func NewDigestInngestRepo(db *pgxpool.Pool) *DigestInngestRepo { return &DigestInngestRepo{db} }
 
+type DigestBudgetSkippedTarget struct {
+	DigestID string
+	Email    string
+}
+
+// DigestAutoRetryCandidate is a failed, unsent digest the retry cron may pick up. FailedAt is
+// when its status last changed; Attempts counts earlier automatic retries.
+type DigestAutoRetryCandidate struct {
+	DigestID   string
+	UserID     string
+	Email      string
+	SendStatus string
+	Attempts   int
+	FailedAt   time.Time
+}
+
+type DigestApprovedTarget struct {
+	DigestID string
+	UserID   string
+	Email    string
+}
+
 func (r *DigestInngestRepo) Create(ctx context.Context, userID string, date time.Time, items []model.DigestItemDetail) (string, bool, error) {
+	return r.create(ctx, `
+		INSERT INTO digests (user_id, digest_date)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, digest_date) WHERE revision = 1 AND digest_config_id IS NULL
+		DO UPDATE SET digest_date = EXCLUDED.digest_date
+		RETURNING id, sent_at`, date, items, userID)
+}
+
+// CreateForConfig is Create for a scoped digest config; each config has its own digest per date.
+func (r *DigestInngestRepo) CreateForConfig(ctx context.Context, userID, configID string, date time.Time, items []model.DigestItemDetail) (string, bool, error) {
+	return r.create(ctx, `
+		INSERT INTO digests (user_id, digest_date, digest_config_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (digest_config_id, digest_date, revision) WHERE digest_config_id IS NOT NULL
+		DO UPDATE SET digest_date = EXCLUDED.digest_date
+		RETURNING id, sent_at`, date, items, userID, configID)
+}
+
+func (r *DigestInngestRepo) create(ctx context.Context, upsertSQL string, date time.Time, items []model.DigestItemDetail, userID string, extraArgs ...any) (string, bool, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return "", false, err
@@ -22,13 +63,7 @@ func (r *DigestInngestRepo) Create(ctx context.Context, userID string, date time
 	dateStr := date.Format("2006-01-02")
 	var digestID string
 	var sentAt *time.Time
-	err = tx.QueryRow(ctx, `
-		INSERT INTO digests (user_id, digest_date)
-		VALUES ($1, $2)
-		ON CONFLICT (user_id, digest_date) DO UPDATE SET digest_date = EXCLUDED.digest_date
-		RETURNING id, sent_at`,
-		userID, dateStr,
-	).Scan(&digestID, &sentAt)
+	err = tx.QueryRow(ctx, upsertSQL, append([]any{userID, dateStr}, extraArgs...)...).Scan(&digestID, &sentAt)
 	if err != nil {
 		return "", false, err
 	}
@@ -48,8 +83,8 @@ func (r *DigestInngestRepo) Create(ctx context.Context, userID string, date time
 
 	for _, item := range items {
 		if _, err := tx.Exec(ctx, `
-			INSERT INTO digest_items (digest_id, item_id, rank) VALUES ($1, $2, $3)`,
-			digestID, item.Item.ID, item.Rank); err != nil {
+			INSERT INTO digest_items (digest_id, item_id, rank, is_exploration) VALUES ($1, $2, $3, $4)`,
+			digestID, item.Item.ID, item.Rank, item.Item.Exploration); err != nil {
 			return "", false, err
 		}
 	}
@@ -57,14 +92,39 @@ func (r *DigestInngestRepo) Create(ctx context.Context, userID string, date time
 	return digestID, false, tx.Commit(ctx)
 }
 
-func (r *DigestInngestRepo) UpdateSentAt(ctx context.Context, digestID string) error {
+// UpdateComposeWindow records whether the digest is a regular daily digest or a catch-up
+// digest covering the quiet days since windowStart.
+func (r *DigestInngestRepo) UpdateComposeWindow(ctx context.Context, digestID, mode string, windowStart *time.Time) error {
+	var start *string
+	if windowStart != nil {
+		v := windowStart.Format("2006-01-02")
+		start = &v
+	}
 	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET compose_mode = $2,
+		    window_start_date = $3::date
+		WHERE id = $1`, digestID, mode, start)
+	return err
+}
+
+func (r *DigestInngestRepo) UpdateSentAt(ctx context.Context, digestID string) error {
+	_, err := r.db.Exec(ctx, withDigestStatusEvent(`
 		UPDATE digests
 		SET sent_at = NOW(),
 		    send_status = 'sent',
 		    send_error = NULL,
 		    send_tried_at = NOW()
-		WHERE id = $1`, digestID)
+		WHERE id = $1
+		RETURNING id, send_status, send_error`), digestID)
+	return err
+}
+
+func (r *DigestInngestRepo) UpdateSendTransport(ctx context.Context, digestID, transport string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET send_transport = NULLIF($2, '')
+		WHERE id = $1`, digestID, transport)
 	return err
 }
 
@@ -77,6 +137,17 @@ func (r *DigestInngestRepo) UpdateEmailCopy(ctx context.Context, digestID string
 	return err
 }
 
+func (r *DigestInngestRepo) UpdateComposeProfile(ctx context.Context, digestID string, profile model.DigestComposeProfile) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET compose_verbosity = $1,
+		    compose_tone = $2,
+		    compose_max_clusters = $3
+		WHERE id = $4`,
+		profile.Verbosity, profile.Tone, profile.MaxClusters, digestID)
+	return err
+}
+
 func (r *DigestInngestRepo) UpdateComposeRetryCounts(ctx context.Context, digestID string, digestRetryCount int, clusterDraftRetryCount int) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE digests
@@ -100,9 +171,9 @@ func (r *DigestInngestRepo) ReplaceClusterDrafts(ctx context.Context, digestID s
 	for _, d := range drafts {
 		if _, err := tx.Exec(ctx, `
 			INSERT INTO digest_cluster_drafts (
-				digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-			digestID, d.ClusterKey, d.ClusterLabel, d.Rank, d.ItemCount, d.Topics, d.MaxScore, d.DraftSummary,
+				digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, follow_up
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			digestID, d.ClusterKey, d.ClusterLabel, d.Rank, d.ItemCount, d.Topics, d.MaxScore, d.DraftSummary, d.FollowUp,
 		); err != nil {
 			return err
 		}
@@ -112,9 +183,10 @@ func (r *DigestInngestRepo) ReplaceClusterDrafts(ctx context.Context, digestID s
 
 func (r *DigestInngestRepo) ListClusterDrafts(ctx context.Context, digestID string) ([]model.DigestClusterDraft, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT id, digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, created_at, updated_at
+		SELECT id, digest_id, cluster_key, cluster_label, rank, item_count, topics, max_score, draft_summary, follow_up, dropped, created_at, updated_at
 		FROM digest_cluster_drafts
 		WHERE digest_id = $1
+		  AND NOT dropped
 		ORDER BY rank ASC, created_at ASC`, digestID)
 	if err != nil {
 		return nil, err
@@ -125,7 +197,7 @@ func (r *DigestInngestRepo) ListClusterDrafts(ctx context.Context, digestID stri
 		var d model.DigestClusterDraft
 		if err := rows.Scan(
 			&d.ID, &d.DigestID, &d.ClusterKey, &d.ClusterLabel, &d.Rank, &d.ItemCount,
-			&d.Topics, &d.MaxScore, &d.DraftSummary, &d.CreatedAt, &d.UpdatedAt,
+			&d.Topics, &d.MaxScore, &d.DraftSummary, &d.FollowUp, &d.Dropped, &d.CreatedAt, &d.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -134,17 +206,291 @@ func (r *DigestInngestRepo) ListClusterDrafts(ctx context.Context, digestID stri
 	return out, rows.Err()
 }
 
+// DigestPriorItem is an item from one of the user's earlier digests, with its embedding.
+type DigestPriorItem struct {
+	ItemID     string
+	Title      string
+	URL        string
+	DigestID   string
+	DigestDate string
+	Embedding  []float64
+}
+
+// ListItemEmbeddings returns the embeddings of the digest's items, keyed by item ID. Items that
+// have not been embedded are missing from the map.
+func (r *DigestInngestRepo) ListItemEmbeddings(ctx context.Context, digestID string) (map[string][]float64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT di.item_id::text, ie.embedding
+		FROM digest_items di
+		JOIN item_embeddings ie ON ie.item_id = di.item_id
+		WHERE di.digest_id = $1`, digestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string][]float64{}
+	for rows.Next() {
+		var itemID string
+		var emb []float64
+		if err := rows.Scan(&itemID, &emb); err != nil {
+			return nil, err
+		}
+		out[itemID] = emb
+	}
+	return out, rows.Err()
+}
+
+// ListPriorDigestItems returns the embedded items of the user's digests dated within
+// lookbackDays before this digest, excluding items this digest contains. An item that appeared
+// in several digests is reported with the latest one.
+func (r *DigestInngestRepo) ListPriorDigestItems(ctx context.Context, digestID string, lookbackDays int) ([]DigestPriorItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (di.item_id)
+		       di.item_id::text, COALESCE(NULLIF(sm.translated_title, ''), i.title, i.url), i.url,
+		       d.id::text, d.digest_date::text, ie.embedding
+		FROM digests cur
+		JOIN digests d ON d.user_id = cur.user_id
+		              AND d.digest_date < cur.digest_date
+		              AND d.digest_date >= cur.digest_date - $2::int
+		JOIN digest_items di ON di.digest_id = d.id
+		JOIN items i ON i.id = di.item_id AND i.deleted_at IS NULL
+		JOIN item_embeddings ie ON ie.item_id = i.id
+		LEFT JOIN item_summaries sm ON sm.item_id = i.id
+		WHERE cur.id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM digest_items cdi
+			WHERE cdi.digest_id = cur.id AND cdi.item_id = di.item_id
+		  )
+		ORDER BY di.item_id, d.digest_date DESC, d.created_at DESC`, digestID, lookbackDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []DigestPriorItem{}
+	for rows.Next() {
+		var v DigestPriorItem
+		if err := rows.Scan(&v.ItemID, &v.Title, &v.URL, &v.DigestID, &v.DigestDate, &v.Embedding); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ReplaceItemFollowUps stores the follow-up annotation of each digest item; items missing from
+// followUps are cleared.
+func (r *DigestInngestRepo) ReplaceItemFollowUps(ctx context.Context, digestID string, followUps map[string]model.DigestFollowUp) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE digest_items SET follow_up = NULL WHERE digest_id = $1 AND follow_up IS NOT NULL`, digestID); err != nil {
+		return err
+	}
+	for itemID, f := range followUps {
+		if _, err := tx.Exec(ctx, `
+			UPDATE digest_items SET follow_up = $3
+			WHERE digest_id = $1 AND item_id = $2`,
+			digestID, itemID, f,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 func (r *DigestInngestRepo) UpdateSendStatus(ctx context.Context, digestID, status string, sendErr *string) error {
-	_, err := r.db.Exec(ctx, `
+	_, err := r.db.Exec(ctx, withDigestStatusEvent(`
 		UPDATE digests
 		SET send_status = $1,
 		    send_error = $2,
 		    send_tried_at = NOW()
-		WHERE id = $3`,
+		WHERE id = $3
+		RETURNING id, send_status, send_error`),
 		status, sendErr, digestID)
 	return err
 }
 
+// MarkAwaitingApproval parks a composed digest until the user approves it or it is auto-approved.
+func (r *DigestInngestRepo) MarkAwaitingApproval(ctx context.Context, digestID string) error {
+	_, err := r.db.Exec(ctx, withDigestStatusEvent(`
+		UPDATE digests
+		SET send_status = 'awaiting_approval',
+		    send_error = NULL,
+		    send_tried_at = NOW(),
+		    approval_requested_at = NOW(),
+		    approved_at = NULL,
+		    approved_by = NULL
+		WHERE id = $1
+		  AND sent_at IS NULL
+		RETURNING id, send_status, send_error`),
+		digestID)
+	return err
+}
+
+// ClaimAutoApprovable approves up to limit digests that have waited longer than their owner's
+// auto-approve timeout and returns them, so each is handed to send-digest exactly once.
+func (r *DigestInngestRepo) ClaimAutoApprovable(ctx context.Context, limit int) ([]DigestApprovedTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH changed AS (
+		UPDATE digests d
+		SET send_status = 'approved',
+		    approved_at = NOW(),
+		    approved_by = 'auto'
+		FROM users u
+		WHERE d.id IN (
+			SELECT d2.id
+			FROM digests d2
+			JOIN user_settings us ON us.user_id = d2.user_id
+			WHERE d2.send_status = 'awaiting_approval'
+			  AND d2.sent_at IS NULL
+			  AND us.digest_auto_approve_minutes > 0
+			  AND d2.approval_requested_at < NOW() - make_interval(mins => us.digest_auto_approve_minutes)
+			ORDER BY d2.approval_requested_at ASC
+			LIMIT $1
+			FOR UPDATE OF d2 SKIP LOCKED
+		)
+		  AND u.id = d.user_id
+		RETURNING d.id, d.user_id, u.email, d.send_status, d.send_error
+		), logged AS (
+			INSERT INTO digest_status_events (digest_id, status, error)
+			SELECT id, send_status, send_error FROM changed
+		)
+		SELECT id, user_id, email FROM changed`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DigestApprovedTarget
+	for rows.Next() {
+		var v DigestApprovedTarget
+		if err := rows.Scan(&v.DigestID, &v.UserID, &v.Email); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ListBudgetSkipped returns unsent digests on or after since whose composition was skipped by the budget cap.
+func (r *DigestInngestRepo) ListBudgetSkipped(ctx context.Context, userID string, since time.Time) ([]DigestBudgetSkippedTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.id, u.email
+		FROM digests d
+		JOIN users u ON u.id = d.user_id
+		WHERE d.user_id = $1
+		  AND d.send_status = 'skipped_budget'
+		  AND d.sent_at IS NULL
+		  AND d.digest_date >= $2
+		ORDER BY d.digest_date ASC, d.revision ASC`,
+		userID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DigestBudgetSkippedTarget
+	for rows.Next() {
+		var v DigestBudgetSkippedTarget
+		if err := rows.Scan(&v.DigestID, &v.Email); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ListAutoRetryCandidates returns up to limit unsent digests created since since whose status is
+// one of statuses and that were retried automatically fewer than maxAttempts times, oldest
+// failure first. Digests of users who paused processing wait until they resume.
+func (r *DigestInngestRepo) ListAutoRetryCandidates(ctx context.Context, statuses []string, since time.Time, maxAttempts, limit int) ([]DigestAutoRetryCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.id, d.user_id, u.email, d.send_status, d.auto_retry_count, COALESCE(d.send_tried_at, d.created_at)
+		FROM digests d
+		JOIN users u ON u.id = d.user_id
+		WHERE d.send_status = ANY($1::text[])
+		  AND d.sent_at IS NULL
+		  AND d.created_at >= $2
+		  AND d.auto_retry_count < $3`+userNotPausedSQL("d.user_id")+`
+		ORDER BY COALESCE(d.send_tried_at, d.created_at) ASC
+		LIMIT $4`,
+		statuses, since, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DigestAutoRetryCandidate
+	for rows.Next() {
+		var v DigestAutoRetryCandidate
+		if err := rows.Scan(&v.DigestID, &v.UserID, &v.Email, &v.SendStatus, &v.Attempts, &v.FailedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ClaimAutoRetry moves a candidate to auto_retry_queued and bumps its attempt counter, recording
+// note as the send error. It reports false when the digest changed since it was listed, so
+// overlapping cron runs retry it once.
+func (r *DigestInngestRepo) ClaimAutoRetry(ctx context.Context, c DigestAutoRetryCandidate, note string) (bool, error) {
+	var claimed int
+	err := r.db.QueryRow(ctx, withDigestStatusEvent(`
+		UPDATE digests
+		SET send_status = 'auto_retry_queued',
+		    send_error = $4,
+		    send_tried_at = NOW(),
+		    auto_retry_count = auto_retry_count + 1,
+		    auto_retried_at = NOW()
+		WHERE id = $1
+		  AND send_status = $2
+		  AND auto_retry_count = $3
+		  AND sent_at IS NULL
+		RETURNING id, send_status, send_error`),
+		c.DigestID, c.SendStatus, c.Attempts, note).Scan(&claimed)
+	if err != nil {
+		return false, err
+	}
+	return claimed == 1, nil
+}
+
+func (r *DigestInngestRepo) UpdateAudioStatus(ctx context.Context, digestID, status string, audioErr *string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET audio_status = $1,
+		    audio_error = $2
+		WHERE id = $3`,
+		status, audioErr, digestID)
+	return err
+}
+
+func (r *DigestInngestRepo) UpdateAudioReady(ctx context.Context, digestID, bucket, objectKey, contentType string, durationSec int) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE digests
+		SET audio_status = 'ready',
+		    audio_bucket = $1,
+		    audio_object_key = $2,
+		    audio_content_type = $3,
+		    audio_duration_sec = $4,
+		    audio_error = NULL,
+		    audio_generated_at = NOW()
+		WHERE id = $5`,
+		bucket, objectKey, contentType, durationSec, digestID)
+	return err
+}
+
+func (r *DigestInngestRepo) GetAudio(ctx context.Context, digestID string) (*model.DigestAudio, error) {
+	var userID string
+	if err := r.db.QueryRow(ctx, `SELECT user_id FROM digests WHERE id = $1`, digestID).Scan(&userID); err != nil {
+		return nil, mapDBError(err)
+	}
+	return (&DigestRepo{db: r.db}).GetAudio(ctx, digestID, userID)
+}
+
 func (r *DigestInngestRepo) GetForEmail(ctx context.Context, digestID string) (*model.DigestDetail, error) {
 	repo := &DigestRepo{db: r.db}
 	var userID string
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// embeddingSpace is the model and dimensions a vector was produced in. Two models can share a
+// dimension count, so vectors are only comparable within one space. An empty model stands for
+// a vector whose model was not recorded and matches any model of the same dimensions.
+type embeddingSpace struct {
+	model      string
+	dimensions int
+}
+
+func (s embeddingSpace) valid() bool {
+	return s.dimensions > 0
+}
+
+func (s embeddingSpace) compatible(o embeddingSpace) bool {
+	if !s.valid() || s.dimensions != o.dimensions {
+		return false
+	}
+	return s.model == "" || o.model == "" || s.model == o.model
+}
+
+func (s embeddingSpace) less(o embeddingSpace) bool {
+	if s.model != o.model {
+		return s.model < o.model
+	}
+	return s.dimensions < o.dimensions
+}
+
+type spacedEmbedding struct {
+	space  embeddingSpace
+	vector []float64
+}
+
+// embeddingRegistry holds the embedding of each item together with the space it lives in.
+type embeddingRegistry map[string]spacedEmbedding
+
+func loadEmbeddingRegistry(ctx context.Context, db *pgxpool.Pool, itemIDs []string) (embeddingRegistry, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := db.Query(ctx, `
+		SELECT item_id, model, dimensions, embedding
+		FROM item_embeddings
+		WHERE item_id = ANY($1::uuid[])`, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanEmbeddingRegistry(rows)
+}
+
+// scanEmbeddingRegistry reads (item_id, model, dimensions, embedding) rows and closes them.
+func scanEmbeddingRegistry(rows pgx.Rows) (embeddingRegistry, error) {
+	defer rows.Close()
+	out := embeddingRegistry{}
+	for rows.Next() {
+		var itemID string
+		var e spacedEmbedding
+		if err := rows.Scan(&itemID, &e.space.model, &e.space.dimensions, &e.vector); err != nil {
+			return nil, err
+		}
+		if len(e.vector) == 0 || len(e.vector) != e.space.dimensions {
+			continue
+		}
+		out[itemID] = e
+	}
+	return out, rows.Err()
+}
+
+// dominantSpace is the space holding the most items. A tie goes to a space compatible with
+// preferred, then to the first space in model order so the choice is stable.
+func (reg embeddingRegistry) dominantSpace(preferred embeddingSpace) embeddingSpace {
+	counts := map[embeddingSpace]int{}
+	for _, e := range reg {
+		counts[e.space]++
+	}
+	spaces := make([]embeddingSpace, 0, len(counts))
+	for s := range counts {
+		spaces = append(spaces, s)
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		a, b := spaces[i], spaces[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		if pa, pb := preferred.compatible(a), preferred.compatible(b); pa != pb {
+			return pa
+		}
+		return a.less(b)
+	})
+	if len(spaces) == 0 {
+		return embeddingSpace{}
+	}
+	return spaces[0]
+}
+
+// vectors returns the embeddings of the items comparable with space.
+func (reg embeddingRegistry) vectors(space embeddingSpace) map[string][]float64 {
+	out := make(map[string][]float64, len(reg))
+	for itemID, e := range reg {
+		if space.compatible(e.space) {
+			out[itemID] = e.vector
+		}
+	}
+	return out
+}
+
+// coverage reports how many of total items have an embedding comparable with space.
+func (reg embeddingRegistry) coverage(space embeddingSpace, total int) *model.EmbeddingCoverage {
+	comparable := 0
+	if space.valid() {
+		for _, e := range reg {
+			if space.compatible(e.space) {
+				comparable++
+			}
+		}
+	}
+	return newEmbeddingCoverage(space, comparable, total)
+}
+
+func newEmbeddingCoverage(space embeddingSpace, comparable, total int) *model.EmbeddingCoverage {
+	out := &model.EmbeddingCoverage{
+		Model:      space.model,
+		Dimensions: space.dimensions,
+		Comparable: comparable,
+		Total:      total,
+	}
+	if total > 0 {
+		out.Percent = math.Round(float64(comparable)/float64(total)*1000) / 10
+	}
+	return out
+}
+
+// profileEmbeddingSpace is the space a preference profile's embedding was averaged in.
+func profileEmbeddingSpace(profile *model.UserPreferenceProfile) embeddingSpace {
+	if profile == nil {
+		return embeddingSpace{}
+	}
+	return embeddingSpace{model: profile.PrefEmbeddingModel, dimensions: len(profile.PrefEmbedding)}
+}
+
+// profileInSpace drops the profile's embedding when it cannot be compared with space, so
+// personal scoring falls back to its other signals instead of comparing unrelated vectors.
+func profileInSpace(profile *model.UserPreferenceProfile, space embeddingSpace) *model.UserPreferenceProfile {
+	if profile == nil || len(profile.PrefEmbedding) == 0 || space.compatible(profileEmbeddingSpace(profile)) {
+		return profile
+	}
+	p := *profile
+	p.PrefEmbedding = nil
+	return &p
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestEmbeddingRegistryRestrictsToDominantSpace(t *testing.T) {
+	small := embeddingSpace{model: "text-embedding-3-small", dimensions: 3}
+	other := embeddingSpace{model: "other-embedding", dimensions: 3}
+	large := embeddingSpace{model: "text-embedding-3-large", dimensions: 4}
+	reg := embeddingRegistry{
+		"a": {space: small, vector: []float64{1, 0, 0}},
+		"b": {space: small, vector: []float64{0, 1, 0}},
+		"c": {space: other, vector: []float64{0, 0, 1}},
+		"d": {space: large, vector: []float64{1, 0, 0, 0}},
+	}
+
+	space := reg.dominantSpace(embeddingSpace{})
+	if space != small {
+		t.Fatalf("dominant space = %+v", space)
+	}
+	vectors := reg.vectors(space)
+	if len(vectors) != 2 || vectors["a"] == nil || vectors["b"] == nil {
+		t.Fatalf("vectors = %v", vectors)
+	}
+
+	cov := reg.coverage(space, 5)
+	if cov.Comparable != 2 || cov.Total != 5 || cov.Percent != 40 || cov.Model != small.model || cov.Dimensions != 3 {
+		t.Fatalf("coverage = %+v", cov)
+	}
+}
+
+func TestEmbeddingRegistryTieGoesToPreferredSpace(t *testing.T) {
+	a := embeddingSpace{model: "a-model", dimensions: 2}
+	b := embeddingSpace{model: "b-model", dimensions: 2}
+	reg := embeddingRegistry{
+		"1": {space: a, vector: []float64{1, 0}},
+		"2": {space: b, vector: []float64{0, 1}},
+	}
+	if got := reg.dominantSpace(embeddingSpace{}); got != a {
+		t.Fatalf("unpreferred tie = %+v", got)
+	}
+	if got := reg.dominantSpace(b); got != b {
+		t.Fatalf("preferred tie = %+v", got)
+	}
+}
+
+func TestProfileInSpaceDropsIncomparableEmbedding(t *testing.T) {
+	profile := &model.UserPreferenceProfile{PrefEmbedding: []float64{1, 0}, PrefEmbeddingModel: "a-model"}
+	if got := profileInSpace(profile, embeddingSpace{model: "a-model", dimensions: 2}); got != profile {
+		t.Fatal("comparable profile was copied")
+	}
+	got := profileInSpace(profile, embeddingSpace{model: "b-model", dimensions: 2})
+	if got.PrefEmbedding != nil || profile.PrefEmbedding == nil {
+		t.Fatalf("incomparable profile = %+v, original = %+v", got, profile)
+	}
+
+	legacy := &model.UserPreferenceProfile{PrefEmbedding: []float64{1, 0}}
+	if got := profileInSpace(legacy, embeddingSpace{model: "b-model", dimensions: 2}); got.PrefEmbedding == nil {
+		t.Fatal("profile without a recorded model was dropped despite matching dimensions")
+	}
+}
+
+func TestRelatedEmbeddingCoverageSQLMatchesModelAndDimensions(t *testing.T) {
+	for _, want := range []string{
+		"AND ie.model = t.model",
+		"AND ie.dimensions = t.dimensions",
+		"AND i.status = 'summarized'",
+		"LIMIT $3",
+	} {
+		if !strings.Contains(relatedEmbeddingCoverageSQL, want) {
+			t.Fatalf("coverage query missing %q: %s", want, relatedEmbeddingCoverageSQL)
+		}
+	}
+}
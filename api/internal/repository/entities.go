@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EntityRepo struct{ db *pgxpool.Pool }
+
+func NewEntityRepo(db *pgxpool.Pool) *EntityRepo { return &EntityRepo{db} }
+
+// ReplaceItemEntities swaps the entity links of one item. Entities are shared across users
+// and keyed by normalized name; a specific kind replaces a previously stored "other".
+func (r *EntityRepo) ReplaceItemEntities(ctx context.Context, itemID string, entities []model.ExtractedEntity) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM item_entities WHERE item_id = $1`, itemID); err != nil {
+		return err
+	}
+	for _, e := range entities {
+		var entityID string
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO entities (name, normalized_name, kind)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (normalized_name) DO UPDATE
+			SET kind = CASE WHEN entities.kind = 'other' THEN EXCLUDED.kind ELSE entities.kind END
+			RETURNING id`,
+			e.Name, e.NormalizedName, e.Kind,
+		).Scan(&entityID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO item_entities (item_id, entity_id, mention_count)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (item_id, entity_id) DO UPDATE SET mention_count = EXCLUDED.mention_count`,
+			itemID, entityID, e.Mentions,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ListByUser returns entities that appear in the user's items, most frequent first.
+func (r *EntityRepo) ListByUser(ctx context.Context, userID, query, kind string, limit int) ([]model.Entity, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	args := []any{userID, limit}
+	filters := ""
+	if q := strings.ToLower(strings.TrimSpace(query)); q != "" {
+		args = append(args, "%"+q+"%")
+		filters += ` AND e.normalized_name LIKE $` + itoa(len(args))
+	}
+	if kind = strings.TrimSpace(kind); kind != "" {
+		args = append(args, kind)
+		filters += ` AND e.kind = $` + itoa(len(args))
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT e.id, e.name, e.kind, COUNT(DISTINCT i.id)::int AS item_count,
+		       MAX(COALESCE(i.published_at, i.created_at)) AS last_seen_at
+		FROM entities e
+		JOIN item_entities ie ON ie.entity_id = e.id
+		JOIN items i ON i.id = ie.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL`+filters+`
+		GROUP BY e.id, e.name, e.kind
+		ORDER BY item_count DESC, last_seen_at DESC NULLS LAST, e.name ASC
+		LIMIT $2`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Entity{}
+	for rows.Next() {
+		var e model.Entity
+		if err := rows.Scan(&e.ID, &e.Name, &e.Kind, &e.ItemCount, &e.LastSeenAt); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// GetForUser returns the entity only if it appears in at least one of the user's items.
+func (r *EntityRepo) GetForUser(ctx context.Context, userID, entityID string) (*model.Entity, error) {
+	var e model.Entity
+	err := r.db.QueryRow(ctx, `
+		SELECT e.id, e.name, e.kind, COUNT(DISTINCT i.id)::int AS item_count,
+		       MAX(COALESCE(i.published_at, i.created_at)) AS last_seen_at
+		FROM entities e
+		JOIN item_entities ie ON ie.entity_id = e.id
+		JOIN items i ON i.id = ie.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE e.id = $2
+		  AND s.user_id = $1
+		  AND i.deleted_at IS NULL
+		GROUP BY e.id, e.name, e.kind`,
+		userID, entityID,
+	).Scan(&e.ID, &e.Name, &e.Kind, &e.ItemCount, &e.LastSeenAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ItemIDs lists the user's items mentioning the entity, newest first.
+func (r *EntityRepo) ItemIDs(ctx context.Context, userID, entityID string, limit int) ([]string, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id
+		FROM item_entities ie
+		JOIN items i ON i.id = ie.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE ie.entity_id = $2
+		  AND s.user_id = $1
+		  AND i.deleted_at IS NULL
+		ORDER BY COALESCE(i.published_at, i.created_at) DESC, i.id
+		LIMIT $3`,
+		userID, entityID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Trends compares mentions in the last 24h with the 24h before, like ItemRepo.TopicTrends.
+func (r *EntityRepo) Trends(ctx context.Context, userID string, limit int) ([]model.EntityTrend, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryAnalytics)
+	defer cancel()
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	rows, err := r.db.Query(ctx, `
+		WITH base AS (
+			SELECT e.id, e.name, e.kind, COALESCE(i.published_at, i.created_at) AS ts
+			FROM entities e
+			JOIN item_entities ie ON ie.entity_id = e.id
+			JOIN items i ON i.id = ie.item_id
+			JOIN sources s ON s.id = i.source_id
+			WHERE s.user_id = $1
+			  AND i.deleted_at IS NULL
+			  AND COALESCE(i.published_at, i.created_at) >= NOW() - INTERVAL '48 hours'
+		)
+		SELECT id, name, kind,
+		       COUNT(*) FILTER (WHERE ts >= NOW() - INTERVAL '24 hours')::int AS count_24h,
+		       COUNT(*) FILTER (WHERE ts < NOW() - INTERVAL '24 hours')::int AS count_prev_24h
+		FROM base
+		GROUP BY id, name, kind
+		HAVING COUNT(*) FILTER (WHERE ts >= NOW() - INTERVAL '24 hours') > 0
+		ORDER BY
+		  (COUNT(*) FILTER (WHERE ts >= NOW() - INTERVAL '24 hours')
+		   - COUNT(*) FILTER (WHERE ts < NOW() - INTERVAL '24 hours')) DESC,
+		  COUNT(*) FILTER (WHERE ts >= NOW() - INTERVAL '24 hours') DESC,
+		  name ASC
+		LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.EntityTrend{}
+	for rows.Next() {
+		var v model.EntityTrend
+		if err := rows.Scan(&v.EntityID, &v.Name, &v.Kind, &v.Count24h, &v.CountPrev24h); err != nil {
+			return nil, err
+		}
+		v.Delta = v.Count24h - v.CountPrev24h
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const readingPlanExplorationReason = "exploration"
+
+// loadReadingPlanExploration returns the user's exploration setting, 0 when they have none.
+func loadReadingPlanExploration(ctx context.Context, db *pgxpool.Pool, userID string) (float64, error) {
+	var v float64
+	err := db.QueryRow(ctx, `SELECT reading_plan_exploration FROM user_settings WHERE user_id = $1`, userID).Scan(&v)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return v, err
+}
+
+// explorationRNG is seeded per user and JST day so a plan keeps the same exploration picks
+// across refreshes during the day.
+func explorationRNG(userID, day string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(userID + ":" + day))
+	seed := h.Sum64()
+	return rand.New(rand.NewPCG(seed, seed>>1|1))
+}
+
+// isExplorationCandidate reports whether an item lies outside the user's usual reading: it
+// scores below the median of its candidates, or none of its topics has a positive interest in
+// the preference profile.
+func isExplorationCandidate(score, median float64, topics []string, profile *model.UserPreferenceProfile) bool {
+	if score < median {
+		return true
+	}
+	if profile == nil || len(profile.TopicInterests) == 0 || len(topics) == 0 {
+		return false
+	}
+	for _, t := range topics {
+		if profile.TopicInterests[strings.ToLower(strings.TrimSpace(t))] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func medianScore(scores []float64) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// epsilonGreedyOrder mixes exploration picks into a greedy ranking. Each slot after the first
+// keep takes, with probability rate, a random unused index from pool instead of the next
+// unused index from greedy. It returns up to n indices and the set that came from pool.
+func epsilonGreedyOrder(greedy, pool []int, n, keep int, rate float64, rng *rand.Rand) ([]int, map[int]bool) {
+	used := make(map[int]bool, n)
+	explored := map[int]bool{}
+	open := append([]int(nil), pool...)
+	drawExploration := func() (int, bool) {
+		for len(open) > 0 {
+			j := rng.IntN(len(open))
+			idx := open[j]
+			open[j] = open[len(open)-1]
+			open = open[:len(open)-1]
+			if !used[idx] {
+				return idx, true
+			}
+		}
+		return 0, false
+	}
+	out := make([]int, 0, n)
+	next := 0
+	for len(out) < n {
+		if len(out) >= keep && rate > 0 && rng.Float64() < rate {
+			if idx, ok := drawExploration(); ok {
+				used[idx], explored[idx] = true, true
+				out = append(out, idx)
+				continue
+			}
+		}
+		for next < len(greedy) && used[greedy[next]] {
+			next++
+		}
+		if next >= len(greedy) {
+			break
+		}
+		used[greedy[next]] = true
+		out = append(out, greedy[next])
+	}
+	return out, explored
+}
+
+// exploreReadingPlan is the exploration layer over an MMR selection. Pinned items and the top
+// recommendation keep their places; each later slot is, with probability rate, given to a
+// random low-affinity or novel-topic candidate that MMR did not select. Those picks are marked
+// with Exploration.
+func exploreReadingPlan(selected, candidates []model.Item, pinnedCount int, rate float64, profile *model.UserPreferenceProfile, rng *rand.Rand) []model.Item {
+	if rate <= 0 || len(selected) == 0 {
+		return selected
+	}
+	inSelection := make(map[string]bool, len(selected))
+	for _, it := range selected {
+		inSelection[it.ID] = true
+	}
+	all := append(make([]model.Item, 0, len(selected)+len(candidates)), selected...)
+	scores := make([]float64, 0, len(candidates))
+	for _, it := range candidates {
+		scores = append(scores, itemPersonalScoreValue(it))
+		if !inSelection[it.ID] {
+			all = append(all, it)
+		}
+	}
+	median := medianScore(scores)
+	greedy := make([]int, len(selected))
+	for i := range selected {
+		greedy[i] = i
+	}
+	var pool []int
+	for i := len(selected); i < len(all); i++ {
+		if isExplorationCandidate(itemPersonalScoreValue(all[i]), median, all[i].SummaryTopics, profile) {
+			pool = append(pool, i)
+		}
+	}
+	order, explored := epsilonGreedyOrder(greedy, pool, len(selected), pinnedCount+1, rate, rng)
+	out := make([]model.Item, 0, len(order))
+	for _, idx := range order {
+		it := all[idx]
+		if explored[idx] {
+			it.Exploration = true
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// exploreDigestItems reorders ranked digest items so that, with probability rate, each slot
+// after the first is taken by a random low-affinity or novel-topic item. Those items are
+// marked with Exploration and ranks are renumbered.
+func exploreDigestItems(items []model.DigestItemDetail, rate float64, profile *model.UserPreferenceProfile, rng *rand.Rand) []model.DigestItemDetail {
+	if rate <= 0 || len(items) < 2 {
+		return items
+	}
+	scores := make([]float64, len(items))
+	for i := range items {
+		scores[i] = digestPersonalScore(items[i], profile)
+	}
+	median := medianScore(scores)
+	greedy := make([]int, len(items))
+	var pool []int
+	for i := range items {
+		greedy[i] = i
+		if i > 0 && isExplorationCandidate(scores[i], median, items[i].Summary.Topics, profile) {
+			pool = append(pool, i)
+		}
+	}
+	order, explored := epsilonGreedyOrder(greedy, pool, len(items), 1, rate, rng)
+	out := make([]model.DigestItemDetail, 0, len(order))
+	for _, idx := range order {
+		d := items[idx]
+		d.Item.Exploration = explored[idx]
+		d.Rank = len(out) + 1
+		out = append(out, d)
+	}
+	return out
+}
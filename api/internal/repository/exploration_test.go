@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func scoredItem(id string, score float64, topics ...string) model.Item {
+	return model.Item{ID: id, PersonalScore: &score, SummaryTopics: topics}
+}
+
+func TestExploreReadingPlanZeroRateKeepsSelection(t *testing.T) {
+	selected := []model.Item{scoredItem("a", 0.9), scoredItem("b", 0.8)}
+	candidates := append(selected, scoredItem("c", 0.1))
+	got := exploreReadingPlan(selected, candidates, 0, 0, nil, explorationRNG("u1", "2026-10-16"))
+	if ids := itemIDs(got); len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Fatalf("items = %v, want [a b]", ids)
+	}
+}
+
+func TestExploreReadingPlanFullRateUsesExplorationPool(t *testing.T) {
+	pinned := scoredItem("pin", 0.5)
+	pinned.PlanPinned = true
+	selected := []model.Item{pinned, scoredItem("a", 0.9, "go"), scoredItem("b", 0.85, "go"), scoredItem("c", 0.8, "go")}
+	candidates := []model.Item{
+		scoredItem("a", 0.9, "go"), scoredItem("b", 0.85, "go"), scoredItem("c", 0.8, "go"),
+		scoredItem("liked", 0.88, "go"), scoredItem("novel", 0.86, "gardening"), scoredItem("low", 0.1, "go"),
+	}
+	profile := &model.UserPreferenceProfile{TopicInterests: map[string]float64{"go": 0.9}}
+
+	got := exploreReadingPlan(selected, candidates, 1, 1, profile, explorationRNG("u1", "2026-10-16"))
+	if len(got) != len(selected) {
+		t.Fatalf("len = %d, want %d", len(got), len(selected))
+	}
+	if got[0].ID != "pin" || got[1].ID != "a" || got[0].Exploration || got[1].Exploration {
+		t.Fatalf("pinned and top pick must stay first: %v", itemIDs(got))
+	}
+	explored := map[string]bool{}
+	for _, it := range got[2:] {
+		if it.Exploration {
+			explored[it.ID] = true
+		}
+	}
+	if len(explored) != 2 || !explored["novel"] || !explored["low"] {
+		t.Fatalf("explored = %v, want novel and low", explored)
+	}
+	if got[3].ID == "b" || got[2].ID == "liked" || got[3].ID == "liked" {
+		t.Fatalf("items = %v", itemIDs(got))
+	}
+}
+
+func TestExploreReadingPlanFallsBackToGreedyWhenPoolIsEmpty(t *testing.T) {
+	selected := []model.Item{scoredItem("a", 0.9), scoredItem("b", 0.9)}
+	got := exploreReadingPlan(selected, selected, 0, 1, nil, explorationRNG("u1", "2026-10-16"))
+	if ids := itemIDs(got); len(ids) != 2 || ids[0] != "a" || ids[1] != "b" || got[1].Exploration {
+		t.Fatalf("items = %+v", got)
+	}
+}
+
+func TestExploreDigestItemsRenumbersRanks(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	items := []model.DigestItemDetail{
+		{Rank: 1, Item: model.Item{ID: "a"}, Summary: model.ItemSummary{Score: score(0.9)}},
+		{Rank: 2, Item: model.Item{ID: "b"}, Summary: model.ItemSummary{Score: score(0.8)}},
+		{Rank: 3, Item: model.Item{ID: "c"}, Summary: model.ItemSummary{Score: score(0.1)}},
+	}
+	got := exploreDigestItems(items, 1, nil, explorationRNG("u1", "2026-10-16"))
+	if len(got) != 3 || got[0].Item.ID != "a" || got[1].Item.ID != "c" || !got[1].Item.Exploration || got[2].Item.ID != "b" {
+		t.Fatalf("items = %+v", got)
+	}
+	for i, d := range got {
+		if d.Rank != i+1 {
+			t.Fatalf("rank[%d] = %d", i, d.Rank)
+		}
+	}
+	if same := exploreDigestItems(items, 0, nil, nil); same[1].Item.ID != "b" || same[1].Item.Exploration {
+		t.Fatalf("zero rate reordered items: %+v", same)
+	}
+}
@@ -14,12 +14,14 @@ import (
 type feedbackPreferenceProfile struct {
 	prefEmbedding []float64
 	embeddingDims int
+	// embeddingModel is the model of the space the embedding was averaged in.
+	embeddingModel string
 }
 
 func loadFeedbackPreferenceProfile(ctx context.Context, db *pgxpool.Pool, userID string) (*feedbackPreferenceProfile, error) {
 	profile := &feedbackPreferenceProfile{}
 	embeddingRows, err := db.Query(ctx, `
-		SELECT ie.dimensions, ie.embedding,
+		SELECT ie.model, ie.dimensions, ie.embedding,
 		       (
 		         CASE
 		           WHEN fb.rating > 0 THEN 1.0
@@ -29,6 +31,7 @@ func loadFeedbackPreferenceProfile(ctx context.Context, db *pgxpool.Pool, userID
 		         + CASE WHEN fb.is_favorite THEN 1.4 ELSE 0.0 END
 		         + CASE WHEN il.item_id IS NOT NULL THEN 0.45 ELSE 0.0 END
 		         + CASE WHEN ir.item_id IS NOT NULL THEN 0.2 ELSE 0.0 END
+		         + CASE WHEN ic.item_id IS NOT NULL THEN 0.3 ELSE 0.0 END
 		         + CASE WHEN i.deleted_at IS NOT NULL THEN -0.7 ELSE 0.0 END
 		       )::double precision AS signal
 		FROM items i
@@ -37,18 +40,21 @@ func loadFeedbackPreferenceProfile(ctx context.Context, db *pgxpool.Pool, userID
 		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
 		LEFT JOIN item_laters il ON il.item_id = i.id AND il.user_id = $1
 		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		LEFT JOIN item_clicks ic ON ic.item_id = i.id AND ic.user_id = $1
 		WHERE s.user_id = $1
 		  AND (
 		    fb.rating <> 0
 		    OR fb.is_favorite = true
 		    OR il.item_id IS NOT NULL
 		    OR ir.item_id IS NOT NULL
+		    OR ic.item_id IS NOT NULL
 		    OR i.deleted_at IS NOT NULL
 		  )
 		ORDER BY GREATEST(
 			COALESCE(fb.updated_at, '-infinity'::timestamptz),
 			COALESCE(il.created_at, '-infinity'::timestamptz),
 			COALESCE(ir.read_at, '-infinity'::timestamptz),
+			COALESCE(ic.last_clicked_at, '-infinity'::timestamptz),
 			COALESCE(i.deleted_at, '-infinity'::timestamptz)
 		) DESC`, userID)
 	if err != nil {
@@ -56,44 +62,53 @@ func loadFeedbackPreferenceProfile(ctx context.Context, db *pgxpool.Pool, userID
 	}
 	defer embeddingRows.Close()
 
-	var sum []float64
-	var sumAbs float64
-	var dims int
+	// Feedback on items embedded by different models is averaged per space, and the space
+	// with the most signals wins; ties go to the space of the most recent signal.
+	type spaceSum struct {
+		sum    []float64
+		sumAbs float64
+		count  int
+		order  int
+	}
+	sums := map[embeddingSpace]*spaceSum{}
 	for embeddingRows.Next() {
-		var rowDims int
+		var space embeddingSpace
 		var vec []float64
 		var signal float64
-		if err := embeddingRows.Scan(&rowDims, &vec, &signal); err != nil {
+		if err := embeddingRows.Scan(&space.model, &space.dimensions, &vec, &signal); err != nil {
 			return nil, err
 		}
-		if signal == 0 || rowDims <= 0 || len(vec) != rowDims {
+		if signal == 0 || space.dimensions <= 0 || len(vec) != space.dimensions {
 			continue
 		}
-		if dims == 0 {
-			dims = rowDims
-			sum = make([]float64, dims)
-		}
-		if rowDims != dims {
-			continue
+		acc, ok := sums[space]
+		if !ok {
+			acc = &spaceSum{sum: make([]float64, space.dimensions), order: len(sums)}
+			sums[space] = acc
 		}
 		for i := range vec {
-			sum[i] += vec[i] * signal
-		}
-		if signal < 0 {
-			sumAbs += -signal
-		} else {
-			sumAbs += signal
+			acc.sum[i] += vec[i] * signal
 		}
+		acc.sumAbs += math.Abs(signal)
+		acc.count++
 	}
 	if err := embeddingRows.Err(); err != nil {
 		return nil, err
 	}
-	if dims > 0 && sumAbs > 0 {
-		profile.prefEmbedding = make([]float64, dims)
-		for i := range sum {
-			profile.prefEmbedding[i] = sum[i] / sumAbs
+	var best *spaceSum
+	var bestSpace embeddingSpace
+	for space, acc := range sums {
+		if best == nil || acc.count > best.count || (acc.count == best.count && acc.order < best.order) {
+			best, bestSpace = acc, space
 		}
-		profile.embeddingDims = dims
+	}
+	if best != nil && best.sumAbs > 0 {
+		profile.prefEmbedding = make([]float64, bestSpace.dimensions)
+		for i := range best.sum {
+			profile.prefEmbedding[i] = best.sum[i] / best.sumAbs
+		}
+		profile.embeddingDims = bestSpace.dimensions
+		profile.embeddingModel = bestSpace.model
 	}
 
 	return profile, nil
@@ -168,36 +183,58 @@ func selectItemsByMMR(
 	diversifyTopics bool,
 	embByItemID map[string][]float64,
 ) []model.Item {
-	if size <= 0 || len(candidates) == 0 {
+	return selectItemsByMMRWithPinned(nil, candidates, size, diversifyTopics, embByItemID)
+}
+
+// selectItemsByMMRWithPinned keeps every pinned item, in order, even past size, and fills the
+// remaining slots by MMR with the pinned items already counted toward the diversity penalties.
+func selectItemsByMMRWithPinned(
+	pinned []model.Item,
+	candidates []model.Item,
+	size int,
+	diversifyTopics bool,
+	embByItemID map[string][]float64,
+) []model.Item {
+	if size <= 0 && len(pinned) == 0 {
 		return nil
 	}
-	if len(candidates) <= size {
+	if len(pinned) == 0 && len(candidates) == 0 {
+		return nil
+	}
+	if len(pinned) == 0 && len(candidates) <= size {
 		out := make([]model.Item, len(candidates))
 		copy(out, candidates)
 		return out
 	}
 	remaining := make([]model.Item, len(candidates))
 	copy(remaining, candidates)
-	selected := make([]model.Item, 0, size)
+	selected := make([]model.Item, 0, max(size, len(pinned)))
 
 	sourceCounts := map[string]int{}
 	topicCounts := map[string]int{}
+	for _, it := range pinned {
+		selected = append(selected, it)
+		sourceCounts[it.SourceID]++
+		topicCounts[firstTopicKey(it.SummaryTopics)]++
+	}
 
-	// Seed with the strongest recommendation by PersonalScore.
-	bestSeedIdx := 0
-	bestSeedScore := -1e9
-	for i, it := range remaining {
-		s := itemPersonalScoreValue(it)
-		if s > bestSeedScore {
-			bestSeedScore = s
-			bestSeedIdx = i
+	if len(selected) == 0 {
+		// Seed with the strongest recommendation by PersonalScore.
+		bestSeedIdx := 0
+		bestSeedScore := -1e9
+		for i, it := range remaining {
+			s := itemPersonalScoreValue(it)
+			if s > bestSeedScore {
+				bestSeedScore = s
+				bestSeedIdx = i
+			}
 		}
+		first := remaining[bestSeedIdx]
+		selected = append(selected, first)
+		sourceCounts[first.SourceID]++
+		topicCounts[firstTopicKey(first.SummaryTopics)]++
+		remaining = append(remaining[:bestSeedIdx], remaining[bestSeedIdx+1:]...)
 	}
-	first := remaining[bestSeedIdx]
-	selected = append(selected, first)
-	sourceCounts[first.SourceID]++
-	topicCounts[firstTopicKey(first.SummaryTopics)]++
-	remaining = append(remaining[:bestSeedIdx], remaining[bestSeedIdx+1:]...)
 
 	for len(selected) < size && len(remaining) > 0 {
 		bestIdx := 0
@@ -305,8 +342,9 @@ func loadEmbeddingBiasByItemID(ctx context.Context, db *pgxpool.Pool, itemIDs []
 		SELECT item_id, embedding
 		FROM item_embeddings
 		WHERE dimensions = $2
+		  AND model = $3
 		  AND item_id = ANY($1::uuid[])`,
-		itemIDs, profile.embeddingDims)
+		itemIDs, profile.embeddingDims, profile.embeddingModel)
 	if err != nil {
 		return nil, err
 	}
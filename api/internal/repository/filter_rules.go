@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// itemNotFilteredSQL excludes items tagged with a topic the user has a filter rule for. Like
+// itemNotSnoozedSQL it expects the items alias i and the user ID as $1.
+const itemNotFilteredSQL = ` AND NOT EXISTS (
+			SELECT 1 FROM filter_rules fr
+			JOIN item_summaries fsm ON fsm.item_id = i.id
+			WHERE fr.user_id = $1
+			  AND fr.topic IN (SELECT lower(btrim(t)) FROM unnest(fsm.topics) AS t)
+		)`
+
+type FilterRuleRepo struct{ db *pgxpool.Pool }
+
+func NewFilterRuleRepo(db *pgxpool.Pool) *FilterRuleRepo {
+	return &FilterRuleRepo{db: db}
+}
+
+// FeedbackReasonSignal is one rated item the suggestion analysis looks at.
+type FeedbackReasonSignal struct {
+	ItemID        string
+	SourceID      string
+	SourceEnabled bool
+	Topics        []string
+	Rating        int
+	IsFavorite    bool
+	Reason        *string
+}
+
+func NormalizeFilterTopic(topic string) string {
+	return strings.ToLower(strings.TrimSpace(topic))
+}
+
+func (r *FilterRuleRepo) ListRules(ctx context.Context, userID string) ([]model.FilterRule, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, topic, created_at
+		FROM filter_rules
+		WHERE user_id = $1
+		ORDER BY topic`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.FilterRule{}
+	for rows.Next() {
+		var v model.FilterRule
+		if err := rows.Scan(&v.ID, &v.UserID, &v.Topic, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// CreateRule adds a topic filter; creating an existing one returns it unchanged.
+func (r *FilterRuleRepo) CreateRule(ctx context.Context, userID, topic string) (*model.FilterRule, error) {
+	var v model.FilterRule
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO filter_rules (user_id, topic)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, topic) DO UPDATE SET topic = EXCLUDED.topic
+		RETURNING id, user_id, topic, created_at`,
+		userID, NormalizeFilterTopic(topic),
+	).Scan(&v.ID, &v.UserID, &v.Topic, &v.CreatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &v, nil
+}
+
+func (r *FilterRuleRepo) DeleteRule(ctx context.Context, userID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM filter_rules WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return mapDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListUserIDsWithFeedbackReasons returns users who gave a negative rating with a reason since.
+func (r *FilterRuleRepo) ListUserIDsWithFeedbackReasons(ctx context.Context, since time.Time) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT user_id
+		FROM item_feedbacks
+		WHERE reason IS NOT NULL AND rating < 0 AND updated_at >= $1`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListFeedbackReasonSignals returns the user's rated or favorited items since the given time.
+func (r *FilterRuleRepo) ListFeedbackReasonSignals(ctx context.Context, userID string, since time.Time) ([]FeedbackReasonSignal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT fb.item_id, i.source_id, s.enabled, COALESCE(sm.topics, '{}'::text[]),
+		       fb.rating, fb.is_favorite, fb.reason
+		FROM item_feedbacks fb
+		JOIN items i ON i.id = fb.item_id
+		JOIN sources s ON s.id = i.source_id
+		LEFT JOIN item_summaries sm ON sm.item_id = i.id
+		WHERE fb.user_id = $1
+		  AND s.user_id = $1
+		  AND fb.updated_at >= $2
+		  AND (fb.rating <> 0 OR fb.is_favorite)`, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []FeedbackReasonSignal
+	for rows.Next() {
+		var v FeedbackReasonSignal
+		if err := rows.Scan(&v.ItemID, &v.SourceID, &v.SourceEnabled, &v.Topics, &v.Rating, &v.IsFavorite, &v.Reason); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// UpsertSuggestions records proposals for a user. Pending ones get fresh evidence; accepted
+// and dismissed ones are left alone so they are not proposed again.
+func (r *FilterRuleRepo) UpsertSuggestions(ctx context.Context, userID string, suggestions []model.FilterSuggestion) error {
+	for _, sg := range suggestions {
+		if _, err := r.db.Exec(ctx, `
+			INSERT INTO filter_suggestions (user_id, kind, target, reason, evidence_count)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, kind, target) DO UPDATE SET
+			  reason = EXCLUDED.reason,
+			  evidence_count = EXCLUDED.evidence_count,
+			  updated_at = NOW()
+			WHERE filter_suggestions.status = 'pending'`,
+			userID, sg.Kind, sg.Target, sg.Reason, sg.EvidenceCount,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const filterSuggestionSelectSQL = `
+		SELECT fs.id, fs.user_id, fs.kind, fs.target, s.title, fs.reason, fs.evidence_count, fs.status,
+		       fs.created_at, fs.updated_at
+		FROM filter_suggestions fs
+		LEFT JOIN sources s ON fs.kind = 'source' AND s.id::text = fs.target`
+
+func scanFilterSuggestion(row interface{ Scan(...any) error }) (*model.FilterSuggestion, error) {
+	var v model.FilterSuggestion
+	if err := row.Scan(&v.ID, &v.UserID, &v.Kind, &v.Target, &v.SourceTitle, &v.Reason, &v.EvidenceCount, &v.Status, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ListPendingSuggestions returns open proposals, skipping source mutes whose source is gone.
+func (r *FilterRuleRepo) ListPendingSuggestions(ctx context.Context, userID string) ([]model.FilterSuggestion, error) {
+	rows, err := r.db.Query(ctx, filterSuggestionSelectSQL+`
+		WHERE fs.user_id = $1
+		  AND fs.status = 'pending'
+		  AND (fs.kind <> 'source' OR s.id IS NOT NULL)
+		ORDER BY fs.evidence_count DESC, fs.updated_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.FilterSuggestion{}
+	for rows.Next() {
+		v, err := scanFilterSuggestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *v)
+	}
+	return out, rows.Err()
+}
+
+func (r *FilterRuleRepo) GetSuggestion(ctx context.Context, userID, id string) (*model.FilterSuggestion, error) {
+	v, err := scanFilterSuggestion(r.db.QueryRow(ctx, filterSuggestionSelectSQL+`
+		WHERE fs.id = $1 AND fs.user_id = $2`, id, userID))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+func (r *FilterRuleRepo) SetSuggestionStatus(ctx context.Context, userID, id, status string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE filter_suggestions
+		SET status = $3, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2`, id, userID, status)
+	if err != nil {
+		return mapDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
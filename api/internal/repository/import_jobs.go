@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Import job entry outcomes.
+const (
+	ImportEntryAdded     = "added"
+	ImportEntryDuplicate = "duplicate"
+	ImportEntryFailed    = "failed"
+)
+
+type ImportJobRepo struct{ db *pgxpool.Pool }
+
+func NewImportJobRepo(db *pgxpool.Pool) *ImportJobRepo { return &ImportJobRepo{db} }
+
+// ImportEntryResult is the outcome of one claimed entry.
+type ImportEntryResult struct {
+	Position int
+	Status   string
+	ItemID   *string
+	Message  *string
+}
+
+const importJobColumns = `id, user_id, kind, source_id, force, status, total_count, processed_count, added_count, duplicate_count, invalid_count, failed_count, error_message, created_at, updated_at, completed_at`
+
+func scanImportJob(row pgx.Row) (*model.ImportJob, error) {
+	var j model.ImportJob
+	err := row.Scan(&j.ID, &j.UserID, &j.Kind, &j.SourceID, &j.Force, &j.Status, &j.TotalCount, &j.ProcessedCount, &j.AddedCount,
+		&j.DuplicateCount, &j.InvalidCount, &j.FailedCount, &j.ErrorMessage, &j.CreatedAt, &j.UpdatedAt, &j.CompletedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &j, nil
+}
+
+// Create stores a queued job with its entries. invalid counts entries rejected up front; they
+// are part of total but never stored.
+func (r *ImportJobRepo) Create(ctx context.Context, userID, kind string, sourceID *string, force bool, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	job, err := scanImportJob(tx.QueryRow(ctx, `
+		INSERT INTO import_jobs (user_id, kind, source_id, force, total_count, invalid_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+importJobColumns,
+		userID, kind, sourceID, force, len(entries)+invalid, invalid,
+	))
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]any, 0, len(entries))
+	for i, e := range entries {
+		rows = append(rows, []any{job.ID, i, e.URL, e.Title, e.GroupName, e.AddedAt, e.Archived})
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"import_job_entries"}, []string{"job_id", "position", "url", "title", "group_name", "added_at", "archived"}, pgx.CopyFromRows(rows)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (r *ImportJobRepo) GetByID(ctx context.Context, id string) (*model.ImportJob, error) {
+	return scanImportJob(r.db.QueryRow(ctx, `SELECT `+importJobColumns+` FROM import_jobs WHERE id = $1`, id))
+}
+
+// Get returns the job only when it belongs to userID.
+func (r *ImportJobRepo) Get(ctx context.Context, id, userID string) (*model.ImportJob, error) {
+	return scanImportJob(r.db.QueryRow(ctx, `SELECT `+importJobColumns+` FROM import_jobs WHERE id = $1 AND user_id = $2`, id, userID))
+}
+
+// ListFailures returns the first failed entries of a job in import order.
+func (r *ImportJobRepo) ListFailures(ctx context.Context, jobID string, limit int) ([]model.ImportJobFailure, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT position, url, title, COALESCE(error_message, '')
+		FROM import_job_entries
+		WHERE job_id = $1 AND status = 'failed'
+		ORDER BY position
+		LIMIT $2
+	`, jobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]model.ImportJobFailure, 0)
+	for rows.Next() {
+		var f model.ImportJobFailure
+		if err := rows.Scan(&f.Position, &f.URL, &f.Title, &f.Message); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+// ClaimEntries moves the job to running and hands out the next queued entries in order.
+// Entries left in processing by a crashed run are handed out again.
+func (r *ImportJobRepo) ClaimEntries(ctx context.Context, jobID string, limit int) ([]model.ImportJobEntry, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE import_jobs
+		SET status = 'running', updated_at = NOW()
+		WHERE id = $1 AND status IN ('queued', 'running')
+	`, jobID); err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, `
+		WITH claimed AS (
+			SELECT position
+			FROM import_job_entries
+			WHERE job_id = $1 AND status IN ('queued', 'processing')
+			ORDER BY position
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE import_job_entries e
+		SET status = 'processing'
+		FROM claimed
+		WHERE e.job_id = $1 AND e.position = claimed.position
+		RETURNING e.position, e.url, e.title, e.group_name, e.added_at, e.archived
+	`, jobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]model.ImportJobEntry, 0, limit)
+	for rows.Next() {
+		var e model.ImportJobEntry
+		if err := rows.Scan(&e.Position, &e.URL, &e.Title, &e.GroupName, &e.AddedAt, &e.Archived); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ImportJobRepo) FinishEntries(ctx context.Context, jobID string, results []ImportEntryResult) error {
+	batch := &pgx.Batch{}
+	for _, res := range results {
+		batch.Queue(`
+			UPDATE import_job_entries
+			SET status = $3, item_id = $4, error_message = $5
+			WHERE job_id = $1 AND position = $2
+		`, jobID, res.Position, res.Status, res.ItemID, res.Message)
+	}
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// RefreshCounts recomputes the job counters from its entries and returns how many are left.
+func (r *ImportJobRepo) RefreshCounts(ctx context.Context, jobID string) (int, error) {
+	var remaining int
+	err := r.db.QueryRow(ctx, `
+		WITH counts AS (
+			SELECT
+				COUNT(*) FILTER (WHERE status = 'added')::int AS added_count,
+				COUNT(*) FILTER (WHERE status = 'duplicate')::int AS duplicate_count,
+				COUNT(*) FILTER (WHERE status = 'failed')::int AS failed_count,
+				COUNT(*) FILTER (WHERE status IN ('queued', 'processing'))::int AS remaining
+			FROM import_job_entries
+			WHERE job_id = $1
+		)
+		UPDATE import_jobs j
+		SET added_count = counts.added_count,
+		    duplicate_count = counts.duplicate_count,
+		    failed_count = counts.failed_count,
+		    processed_count = counts.added_count + counts.duplicate_count + counts.failed_count,
+		    updated_at = NOW()
+		FROM counts
+		WHERE j.id = $1
+		RETURNING counts.remaining
+	`, jobID).Scan(&remaining)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return remaining, err
+}
+
+func (r *ImportJobRepo) Complete(ctx context.Context, jobID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE import_jobs
+		SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, jobID)
+	return err
+}
+
+func (r *ImportJobRepo) Fail(ctx context.Context, jobID, message string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE import_jobs
+		SET status = 'failed', error_message = $2, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, jobID, strings.TrimSpace(message))
+	return err
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// ItemReader is the read side of ItemRepo that handlers and services depend on, so they can
+// be served from the read replica or tested against a fake.
+type ItemReader interface {
+	ListPage(ctx context.Context, userID string, p ItemListParams) (*model.ItemListResponse, error)
+	ListPageFields(ctx context.Context, userID string, p ItemListParams, fields []string) (*model.ItemFieldsListResponse, error)
+	GetDetail(ctx context.Context, id, userID string) (*model.ItemDetail, error)
+	LoadByIDsPreservingOrder(ctx context.Context, userID string, itemIDs []string) ([]model.Item, error)
+	Stats(ctx context.Context, userID string) (*model.ItemStatsResponse, error)
+	ReadingPlan(ctx context.Context, userID string, p ReadingPlanParams) (*model.ReadingPlanResponse, error)
+	CatchUpCandidates(ctx context.Context, userID string, since time.Time, limit int) ([]model.Item, error)
+	ClusterItemsByEmbeddings(ctx context.Context, items []model.Item) ([]model.ReadingPlanCluster, error)
+	TriageClustersByEmbeddings(ctx context.Context, items []model.Item, selectedItemIDs []string) ([]model.ReadingPlanCluster, error)
+	LoadItemEmbeddingsForProfile(ctx context.Context, itemIDs []string, profile *model.UserPreferenceProfile) (map[string][]float64, error)
+	SummariesByItemIDs(ctx context.Context, userID string, itemIDs []string) (map[string]string, error)
+	ListRelated(ctx context.Context, id, userID string, limit int) ([]model.RelatedItem, error)
+	ListRelatedHybrid(ctx context.Context, id, userID string, limit int, p RelatedFusionParams) ([]model.RelatedItem, error)
+	RelatedEmbeddingCoverage(ctx context.Context, id, userID string, limit int) (*model.EmbeddingCoverage, error)
+	ListFailedForRetry(ctx context.Context, userID string, sourceID *string) ([]model.Item, error)
+	ProcessingQueue(ctx context.Context, userID, itemID string) (*model.ItemProcessingQueue, error)
+	GetTranslation(ctx context.Context, userID, itemID, language string) (*model.ItemTranslation, error)
+	FavoriteExportItems(ctx context.Context, userID string, days, limit int) ([]model.FavoriteExportItem, error)
+	TopicTrends(ctx context.Context, userID string, limit int) ([]model.TopicTrend, error)
+	TopicPulse(ctx context.Context, userID string, days, limit int) ([]model.TopicPulseItem, error)
+	PositiveFeedbackTopics(ctx context.Context, userID string, limit int) ([]string, error)
+	CountNewOnDateJST(ctx context.Context, userID, date string) (int, error)
+	CountReadOnDateJST(ctx context.Context, userID, date string) (int, error)
+	ReadActivityInRangeJST(ctx context.Context, userID string, from, to string) (readCount int, activeDays int, err error)
+}
+
+// ItemWriter is the write side of ItemRepo used by the item and source handlers.
+type ItemWriter interface {
+	UpsertFromFeed(ctx context.Context, sourceID, url string, title *string) (string, bool, error)
+	MarkRead(ctx context.Context, userID, itemID string) (bool, error)
+	MarkUnread(ctx context.Context, userID, itemID string) error
+	MarkReadBulk(ctx context.Context, userID string, p BulkMarkReadParams) (int, error)
+	MarkReadBulkByIDs(ctx context.Context, userID string, itemIDs []string) (int, error)
+	MarkLater(ctx context.Context, userID, itemID string) error
+	UnmarkLater(ctx context.Context, userID, itemID string) error
+	MarkLaterBulk(ctx context.Context, userID string, itemIDs []string) (int, error)
+	Snooze(ctx context.Context, userID, itemID string, until time.Time) error
+	Unsnooze(ctx context.Context, userID, itemID string) error
+	RecordClick(ctx context.Context, userID, itemID, via string) error
+	UpsertFeedback(ctx context.Context, userID, itemID string, rating int, isFavorite bool) (*model.ItemFeedback, error)
+	UpsertFeedbackWithReason(ctx context.Context, userID, itemID string, rating int, isFavorite bool, reason *string) (*model.ItemFeedback, error)
+	UpdateUserGenre(ctx context.Context, userID, itemID string, genre, otherLabel *string) error
+	UpsertTranslation(ctx context.Context, t model.ItemTranslation) (*model.ItemTranslation, error)
+	SetReadingPlanOverride(ctx context.Context, userID, itemID string, planDate time.Time, action string) error
+	ApplyTriage(ctx context.Context, userID string, decisions []TriageDecision) (results []model.TriageResult, applied bool, readInserted int, err error)
+	PersistPersonalScores(ctx context.Context, userID string, itemIDs []string) error
+	ResetForExtractRetry(ctx context.Context, id, userID string) (*model.Item, error)
+	ResetForFactsRetry(ctx context.Context, id, userID string) (*model.Item, error)
+	CreateItemBulkJob(ctx context.Context, userID string, action ItemBulkJobAction, filters ItemBulkJobFilters) (ItemBulkJob, error)
+	Delete(ctx context.Context, itemID, userID string) error
+	Restore(ctx context.Context, itemID, userID string) error
+}
+
+type ItemReadWriter interface {
+	ItemReader
+	ItemWriter
+}
+
+// SourceReader is the read side of SourceRepo used by the source handler and its services.
+type SourceReader interface {
+	List(ctx context.Context, userID string) ([]model.Source, error)
+	GetProposedURL(ctx context.Context, id, userID string) (*string, error)
+	HealthByUser(ctx context.Context, userID string) ([]model.SourceHealth, error)
+	ItemStatsByUser(ctx context.Context, userID string) ([]model.SourceItemStats, error)
+	DailyStatsByUser(ctx context.Context, userID string, days int) ([]model.SourceDailyStats, error)
+	NavigatorCandidates30d(ctx context.Context, userID string) ([]model.SourceNavigatorCandidate, error)
+	RecommendedByUser(ctx context.Context, userID string, limit int) ([]model.RecommendedSource, error)
+	LowAffinityByUser(ctx context.Context, userID string, limit int) ([]model.RecommendedSource, error)
+}
+
+// SourceWriter is the write side of SourceRepo used by the source handler.
+type SourceWriter interface {
+	Create(ctx context.Context, userID, url, srcType string, title *string) (*model.Source, error)
+	Update(ctx context.Context, id, userID string, enabled *bool, updateTitle bool, title *string) (*model.Source, error)
+	SetGroupName(ctx context.Context, id, userID string, groupName *string) error
+	SetDefaultTopics(ctx context.Context, id, userID string, topics []string) error
+	MigrateURL(ctx context.Context, id, userID, newURL string) (*model.Source, error)
+	Delete(ctx context.Context, id, userID string) error
+}
+
+type SourceReadWriter interface {
+	SourceReader
+	SourceWriter
+}
+
+var (
+	_ ItemReadWriter   = (*ItemRepo)(nil)
+	_ SourceReadWriter = (*SourceRepo)(nil)
+)
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ItemAudioRepo struct{ db *pgxpool.Pool }
+
+func NewItemAudioRepo(db *pgxpool.Pool) *ItemAudioRepo {
+	return &ItemAudioRepo{db: db}
+}
+
+// Get returns the stored rendering of an item owned by userID for one text source.
+func (r *ItemAudioRepo) Get(ctx context.Context, itemID, userID, source string) (*model.ItemAudio, error) {
+	var v model.ItemAudio
+	err := r.db.QueryRow(ctx, `
+		SELECT ia.item_id::text, ia.source, ia.voice_key, ia.bucket, ia.object_key, ia.content_type,
+		       ia.duration_sec, ia.text_chars, ia.source_summarized_at, ia.created_at, ia.updated_at
+		FROM item_audio ia
+		JOIN items i ON i.id = ia.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE ia.item_id = $1 AND s.user_id = $2 AND ia.source = $3`, itemID, userID, source,
+	).Scan(&v.ItemID, &v.Source, &v.VoiceKey, &v.Bucket, &v.ObjectKey, &v.ContentType,
+		&v.DurationSec, &v.TextChars, &v.SourceSummarizedAt, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &v, nil
+}
+
+func (r *ItemAudioRepo) Upsert(ctx context.Context, v model.ItemAudio) (*model.ItemAudio, error) {
+	out := v
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO item_audio (item_id, source, voice_key, bucket, object_key, content_type,
+		                        duration_sec, text_chars, source_summarized_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (item_id, source) DO UPDATE
+		SET voice_key = EXCLUDED.voice_key,
+		    bucket = EXCLUDED.bucket,
+		    object_key = EXCLUDED.object_key,
+		    content_type = EXCLUDED.content_type,
+		    duration_sec = EXCLUDED.duration_sec,
+		    text_chars = EXCLUDED.text_chars,
+		    source_summarized_at = EXCLUDED.source_summarized_at,
+		    updated_at = NOW()
+		RETURNING created_at, updated_at`,
+		v.ItemID, v.Source, v.VoiceKey, v.Bucket, v.ObjectKey, v.ContentType,
+		v.DurationSec, v.TextChars, v.SourceSummarizedAt,
+	).Scan(&out.CreatedAt, &out.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &out, nil
+}
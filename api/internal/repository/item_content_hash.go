@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReusableSummary reports whether the item's stored facts and summary were made from inputs
+// hashing to inputHash. When they were made from other inputs they are stale, so they are
+// deleted here and the item is processed from scratch.
+func (r *ItemInngestRepo) ReusableSummary(ctx context.Context, itemID, inputHash string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var stored *string
+	var hasSummary bool
+	err = tx.QueryRow(ctx, `
+		SELECT i.summary_input_hash, EXISTS (SELECT 1 FROM item_summaries sm WHERE sm.item_id = i.id)
+		FROM items i
+		WHERE i.id = $1
+		FOR UPDATE OF i`, itemID,
+	).Scan(&stored, &hasSummary)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if stored == nil {
+		return false, nil
+	}
+	if *stored == inputHash && hasSummary {
+		return true, nil
+	}
+	if err := deleteItemDerived(ctx, tx, itemID); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE items SET summary_input_hash = NULL WHERE id = $1`, itemID); err != nil {
+		return false, err
+	}
+	return false, tx.Commit(ctx)
+}
+
+func (r *ItemInngestRepo) SetSummaryInputHash(ctx context.Context, itemID, inputHash string) error {
+	_, err := r.db.Exec(ctx, `UPDATE items SET summary_input_hash = NULLIF($2, '') WHERE id = $1`, itemID, inputHash)
+	return err
+}
+
+// MarkSummarized restores the summarized status of an item whose existing summary was reused.
+func (r *ItemInngestRepo) MarkSummarized(ctx context.Context, itemID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE items SET status = 'summarized', processing_error = NULL, auto_reprocess_count = 0, updated_at = NOW() WHERE id = $1`, itemID)
+	return err
+}
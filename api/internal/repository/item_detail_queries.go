@@ -20,14 +20,14 @@ func (r *ItemRepo) loadItemDetailBase(ctx context.Context, id, userID string) (*
 		       EXISTS (
 		           SELECT 1 FROM item_reads ir
 		           WHERE ir.item_id = i.id AND ir.user_id = $2
-		       ) AS is_read, i.processing_error,
+		       ) AS is_read, i.processing_error, i.extraction_source, i.archive_url, i.canonical_url, i.paywalled,
 		       i.published_at, i.fetched_at, i.created_at, i.updated_at
 		FROM items i
 		JOIN sources s ON s.id = i.source_id
 		LEFT JOIN item_summaries sm ON sm.item_id = i.id
 		WHERE i.id = $1 AND s.user_id = $2`, id, userID,
 	).Scan(&d.ID, &d.SourceID, &d.SourceTitle, &d.URL, &d.Title, &d.ThumbnailURL, &d.ContentText,
-		&d.Status, &deleted, &d.TranslatedTitle, &d.UserGenre, &d.UserOtherGenreLabel, &d.Genre, &d.OtherGenreLabel, &d.IsRead, &d.ProcessingError, &d.PublishedAt, &d.FetchedAt, &d.CreatedAt, &d.UpdatedAt)
+		&d.Status, &deleted, &d.TranslatedTitle, &d.UserGenre, &d.UserOtherGenreLabel, &d.Genre, &d.OtherGenreLabel, &d.IsRead, &d.ProcessingError, &d.ExtractionSource, &d.ArchiveURL, &d.CanonicalURL, &d.Paywalled, &d.PublishedAt, &d.FetchedAt, &d.CreatedAt, &d.UpdatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
@@ -58,10 +58,10 @@ func (r *ItemRepo) queryFactsDetail(ctx context.Context, itemID string) (*model.
 func (r *ItemRepo) querySummaryDetail(ctx context.Context, itemID string) (*model.ItemSummary, error) {
 	var summary model.ItemSummary
 	err := r.db.QueryRow(ctx, `
-		SELECT id, item_id, summary, topics, genre, other_genre_label, translated_title, score, score_breakdown, score_reason, score_policy_version, summarized_at
+		SELECT id, item_id, summary, topics, genre, other_genre_label, depth, translated_title, score, score_breakdown, score_reason, score_policy_version, summarized_at
 		FROM item_summaries
 		WHERE item_id = $1`, itemID,
-	).Scan(&summary.ID, &summary.ItemID, &summary.Summary, &summary.Topics, &summary.Genre, &summary.OtherGenreLabel, &summary.TranslatedTitle, &summary.Score,
+	).Scan(&summary.ID, &summary.ItemID, &summary.Summary, &summary.Topics, &summary.Genre, &summary.OtherGenreLabel, &summary.Depth, &summary.TranslatedTitle, &summary.Score,
 		scoreBreakdownScanner{dst: &summary.ScoreBreakdown}, &summary.ScoreReason, &summary.ScorePolicyVersion, &summary.SummarizedAt)
 	if err != nil {
 		return nil, err
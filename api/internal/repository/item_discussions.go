@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Upper bound of the personal score boost a very popular discussion can give.
+const socialBoostMax = 0.08
+
+type ItemDiscussionRepo struct{ db *pgxpool.Pool }
+
+func NewItemDiscussionRepo(db *pgxpool.Pool) *ItemDiscussionRepo {
+	return &ItemDiscussionRepo{db: db}
+}
+
+// ItemDiscussionTarget is the URLs the enrichment job searches discussions for.
+type ItemDiscussionTarget struct {
+	ItemID       string
+	URL          string
+	CanonicalURL *string
+}
+
+func (r *ItemDiscussionRepo) GetTarget(ctx context.Context, itemID string) (*ItemDiscussionTarget, error) {
+	var t ItemDiscussionTarget
+	err := r.db.QueryRow(ctx, `
+		SELECT id::text, url, canonical_url
+		FROM items
+		WHERE id = $1 AND deleted_at IS NULL`, itemID,
+	).Scan(&t.ItemID, &t.URL, &t.CanonicalURL)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &t, nil
+}
+
+// ListRefreshTargets returns recent summarized items whose discussions were never looked up or
+// were last fetched before staleBefore, newest first. Threads keep gaining points and comments
+// for a day or two after an article is posted, so the counts saved right after summarizing age
+// quickly.
+func (r *ItemDiscussionRepo) ListRefreshTargets(ctx context.Context, createdSince, staleBefore time.Time, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id::text
+		FROM items i
+		LEFT JOIN LATERAL (
+			SELECT MAX(d.fetched_at) AS fetched_at
+			FROM item_discussions d
+			WHERE d.item_id = i.id
+		) d ON true
+		WHERE i.deleted_at IS NULL
+		  AND i.status = 'summarized'
+		  AND i.created_at >= $1
+		  AND (d.fetched_at IS NULL OR d.fetched_at < $2)
+		ORDER BY i.created_at DESC
+		LIMIT $3`, createdSince, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Replace stores the discussions found for an item, dropping platforms no longer found.
+func (r *ItemDiscussionRepo) Replace(ctx context.Context, itemID string, discussions []model.ItemDiscussion) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	platforms := make([]string, 0, len(discussions))
+	for _, d := range discussions {
+		platforms = append(platforms, d.Platform)
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO item_discussions (item_id, platform, external_id, discussion_url, title, points, comments_count)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (item_id, platform) DO UPDATE SET
+			  external_id = EXCLUDED.external_id,
+			  discussion_url = EXCLUDED.discussion_url,
+			  title = EXCLUDED.title,
+			  points = EXCLUDED.points,
+			  comments_count = EXCLUDED.comments_count,
+			  fetched_at = NOW()`,
+			itemID, d.Platform, d.ExternalID, d.URL, d.Title, d.Points, d.CommentsCount,
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM item_discussions
+		WHERE item_id = $1 AND NOT (platform = ANY($2::text[]))`, itemID, platforms); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func listItemDiscussions(ctx context.Context, db *pgxpool.Pool, itemID string) ([]model.ItemDiscussion, error) {
+	rows, err := db.Query(ctx, `
+		SELECT item_id::text, platform, external_id, discussion_url, title, points, comments_count, fetched_at
+		FROM item_discussions
+		WHERE item_id = $1
+		ORDER BY points DESC, platform`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []model.ItemDiscussion
+	for rows.Next() {
+		var d model.ItemDiscussion
+		if err := rows.Scan(&d.ItemID, &d.Platform, &d.ExternalID, &d.URL, &d.Title, &d.Points, &d.CommentsCount, &d.FetchedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// socialSignalBoost grows with the log of points and comments and reaches socialBoostMax
+// around a thousand points with a few hundred comments.
+func socialSignalBoost(points, comments int) float64 {
+	if points <= 0 && comments <= 0 {
+		return 0
+	}
+	signal := math.Log10(1+float64(max(points, 0))) + 0.5*math.Log10(1+float64(max(comments, 0)))
+	return min(signal/4.25, 1) * socialBoostMax
+}
+
+// loadSocialBoosts returns the personal score boost for items with discussions, or nil when the
+// user has not turned the social boost on.
+func loadSocialBoosts(ctx context.Context, db *pgxpool.Pool, userID string, itemIDs []string) (map[string]float64, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	var enabled bool
+	err := db.QueryRow(ctx, `SELECT social_boost_enabled FROM user_settings WHERE user_id = $1`, userID).Scan(&enabled)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !enabled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(ctx, `
+		SELECT item_id::text, SUM(points)::int, SUM(comments_count)::int
+		FROM item_discussions
+		WHERE item_id = ANY($1::uuid[])
+		GROUP BY item_id`, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]float64{}
+	for rows.Next() {
+		var itemID string
+		var points, comments int
+		if err := rows.Scan(&itemID, &points, &comments); err != nil {
+			return nil, err
+		}
+		if boost := socialSignalBoost(points, comments); boost > 0 {
+			out[itemID] = boost
+		}
+	}
+	return out, rows.Err()
+}
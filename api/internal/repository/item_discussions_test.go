@@ -0,0 +1,17 @@
+package repository
+
+import "testing"
+
+func TestSocialSignalBoost(t *testing.T) {
+	if got := socialSignalBoost(0, 0); got != 0 {
+		t.Fatalf("no signal boost = %v, want 0", got)
+	}
+	small := socialSignalBoost(10, 5)
+	large := socialSignalBoost(800, 300)
+	if small <= 0 || large <= small {
+		t.Fatalf("boosts small=%v large=%v, want 0 < small < large", small, large)
+	}
+	if got := socialSignalBoost(100000, 50000); got != socialBoostMax {
+		t.Fatalf("capped boost = %v, want %v", got, socialBoostMax)
+	}
+}
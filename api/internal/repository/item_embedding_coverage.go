@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+)
+
+// EmbeddingCoverage counts a user's summarized items by the state of their embedding relative
+// to the model new embeddings are made with. Stale embeddings come from another model, whose
+// vectors never match the current one's.
+type EmbeddingCoverage struct {
+	Summarized int
+	Current    int
+	Stale      int
+	Missing    int
+}
+
+func (r *ItemRepo) EmbeddingCoverage(ctx context.Context, userID, model string) (EmbeddingCoverage, error) {
+	var v EmbeddingCoverage
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::int,
+		       COUNT(*) FILTER (WHERE ie.model = $2)::int,
+		       COUNT(*) FILTER (WHERE ie.model <> $2)::int,
+		       COUNT(*) FILTER (WHERE ie.item_id IS NULL)::int
+		FROM items i
+		JOIN sources src ON src.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_embeddings ie ON ie.item_id = i.id
+		WHERE src.user_id = $1
+		  AND i.status = 'summarized'
+		  AND i.deleted_at IS NULL`, userID, model,
+	).Scan(&v.Summarized, &v.Current, &v.Stale, &v.Missing)
+	return v, err
+}
+
+// ListStaleEmbeddingTargets returns up to limit of the user's summarized items whose embedding
+// is missing or was made with a model other than model, newest summary first.
+func (r *ItemRepo) ListStaleEmbeddingTargets(ctx context.Context, userID, model string, limit int) ([]ItemEmbeddingBackfillTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.source_id, src.user_id, i.url
+		FROM items i
+		JOIN sources src ON src.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_embeddings ie ON ie.item_id = i.id
+		WHERE src.user_id = $1
+		  AND i.status = 'summarized'
+		  AND i.deleted_at IS NULL
+		  AND (ie.item_id IS NULL OR ie.model <> $2)
+		ORDER BY sm.summarized_at DESC
+		LIMIT $3`, userID, model, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ItemEmbeddingBackfillTarget
+	for rows.Next() {
+		var v ItemEmbeddingBackfillTarget
+		if err := rows.Scan(&v.ItemID, &v.SourceID, &v.UserID, &v.URL); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ItemHTMLSnapshotRepo struct{ db *pgxpool.Pool }
+
+func NewItemHTMLSnapshotRepo(db *pgxpool.Pool) *ItemHTMLSnapshotRepo {
+	return &ItemHTMLSnapshotRepo{db: db}
+}
+
+// ItemHTMLSnapshotTarget is what the capture job needs to know about an item.
+type ItemHTMLSnapshotTarget struct {
+	ItemID      string
+	UserID      string
+	URL         string
+	Enabled     bool
+	HasSnapshot bool
+}
+
+func (r *ItemHTMLSnapshotRepo) GetTarget(ctx context.Context, itemID string) (*ItemHTMLSnapshotTarget, error) {
+	var t ItemHTMLSnapshotTarget
+	err := r.db.QueryRow(ctx, `
+		SELECT i.id::text, s.user_id::text, i.url,
+		       COALESCE(us.html_snapshots_enabled, false),
+		       EXISTS (SELECT 1 FROM item_html_snapshots hs WHERE hs.item_id = i.id)
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		LEFT JOIN user_settings us ON us.user_id = s.user_id
+		WHERE i.id = $1 AND i.deleted_at IS NULL`, itemID,
+	).Scan(&t.ItemID, &t.UserID, &t.URL, &t.Enabled, &t.HasSnapshot)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &t, nil
+}
+
+// Get returns the snapshot of an item owned by userID.
+func (r *ItemHTMLSnapshotRepo) Get(ctx context.Context, itemID, userID string) (*model.ItemHTMLSnapshot, error) {
+	var v model.ItemHTMLSnapshot
+	err := r.db.QueryRow(ctx, `
+		SELECT hs.item_id::text, hs.bucket, hs.object_key, hs.source_url, hs.size_bytes, hs.captured_at
+		FROM item_html_snapshots hs
+		JOIN items i ON i.id = hs.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE hs.item_id = $1 AND s.user_id = $2`, itemID, userID,
+	).Scan(&v.ItemID, &v.Bucket, &v.ObjectKey, &v.SourceURL, &v.SizeBytes, &v.CapturedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &v, nil
+}
+
+func (r *ItemHTMLSnapshotRepo) Upsert(ctx context.Context, v model.ItemHTMLSnapshot) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO item_html_snapshots (item_id, bucket, object_key, source_url, size_bytes, captured_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (item_id) DO UPDATE
+		SET bucket = EXCLUDED.bucket,
+		    object_key = EXCLUDED.object_key,
+		    source_url = EXCLUDED.source_url,
+		    size_bytes = EXCLUDED.size_bytes,
+		    captured_at = EXCLUDED.captured_at`,
+		v.ItemID, v.Bucket, v.ObjectKey, v.SourceURL, v.SizeBytes, v.CapturedAt,
+	)
+	return err
+}
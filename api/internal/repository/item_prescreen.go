@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PrescreenScoreReason and PrescreenScorePolicyVersion mark stub summaries written for items
+// that the pre-screen skipped.
+const (
+	PrescreenScoreReason        = "prescreen"
+	PrescreenScorePolicyVersion = "prescreen-v1"
+)
+
+// prescreenCostPurposes are the LLM calls a skipped item avoids.
+var prescreenCostPurposes = []string{"facts", "facts_localization", "facts_check", "summary", "faithfulness_check"}
+
+// PrescreenStats feeds the pre-screen savings projection: how many recently scored items of a
+// user fall under a threshold, and what fact extraction and summarization cost per item.
+type PrescreenStats struct {
+	ScoredItems    int
+	BelowThreshold int
+	CostUSD        float64
+	CostedItems    int
+}
+
+func (r *ItemInngestRepo) SetPrescreenScore(ctx context.Context, itemID string, score float64) error {
+	_, err := r.db.Exec(ctx, `UPDATE items SET prescreen_score = $2, updated_at = NOW() WHERE id = $1`, itemID, score)
+	return err
+}
+
+// InsertPrescreenStub stores summary as the item's stub summary and marks it skipped, which
+// completes the item without facts.
+func (r *ItemInngestRepo) InsertPrescreenStub(ctx context.Context, itemID, summary string, score float64) error {
+	if _, err := r.db.Exec(ctx, `
+		UPDATE items SET prescreen_score = $2, prescreen_skipped = true, summary_input_hash = NULL, updated_at = NOW() WHERE id = $1`,
+		itemID, score,
+	); err != nil {
+		return err
+	}
+	return r.InsertSummary(ctx, itemID, summary, nil, nil, nil, nil, "", score, nil, PrescreenScoreReason, PrescreenScorePolicyVersion)
+}
+
+func loadItemPrescreen(ctx context.Context, db *pgxpool.Pool, itemID string) (*model.ItemPrescreen, error) {
+	var score *float64
+	var out model.ItemPrescreen
+	if err := db.QueryRow(ctx, `
+		SELECT prescreen_score, prescreen_skipped FROM items WHERE id = $1`, itemID,
+	).Scan(&score, &out.Skipped); err != nil {
+		return nil, mapDBError(err)
+	}
+	if score == nil {
+		return nil, nil
+	}
+	out.Score = *score
+	return &out, nil
+}
+
+// PrescreenStats counts the user's items scored since since and those under threshold, with
+// the LLM cost of the items that went through fact extraction and summarization.
+func (r *ItemRepo) PrescreenStats(ctx context.Context, userID string, threshold float64, since time.Time) (PrescreenStats, error) {
+	var out PrescreenStats
+	err := r.db.QueryRow(ctx, `
+		WITH scored AS (
+			SELECT i.prescreen_score
+			FROM items i
+			JOIN sources s ON s.id = i.source_id
+			WHERE s.user_id = $1
+			  AND i.prescreen_score IS NOT NULL
+			  AND i.created_at >= $2
+			  AND i.deleted_at IS NULL
+		)
+		SELECT
+			(SELECT COUNT(*) FROM scored)::int,
+			(SELECT COUNT(*) FROM scored WHERE prescreen_score < $3)::int,
+			COALESCE(SUM(l.estimated_cost_usd), 0)::double precision,
+			COUNT(DISTINCT l.item_id)::int
+		FROM llm_usage_logs l
+		WHERE l.user_id = $1
+		  AND l.item_id IS NOT NULL
+		  AND l.created_at >= $2
+		  AND l.purpose = ANY($4)`,
+		userID, since, threshold, prescreenCostPurposes,
+	).Scan(&out.ScoredItems, &out.BelowThreshold, &out.CostUSD, &out.CostedItems)
+	return out, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ItemProcessingEventRepo struct{ db *pgxpool.Pool }
+
+func NewItemProcessingEventRepo(db *pgxpool.Pool) *ItemProcessingEventRepo {
+	return &ItemProcessingEventRepo{db: db}
+}
+
+func (r *ItemProcessingEventRepo) Insert(ctx context.Context, ev model.ItemProcessingEvent) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO item_processing_events (
+			item_id, trigger_id, trigger_reason, step, status, model, error_message,
+			started_at, finished_at, duration_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		ev.ItemID, ev.TriggerID, ev.TriggerReason, ev.Step, ev.Status, ev.Model, ev.ErrorMessage,
+		ev.StartedAt, ev.FinishedAt, ev.DurationMS,
+	)
+	return err
+}
+
+// ListByItem returns the item's processing timeline, oldest first. Deleted items keep their
+// trace so users can see why they were dropped.
+func (r *ItemProcessingEventRepo) ListByItem(ctx context.Context, userID, itemID string, limit int) ([]model.ItemProcessingEvent, error) {
+	var owned bool
+	if err := r.db.QueryRow(ctx, `
+		SELECT true
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		WHERE i.id = $1 AND s.user_id = $2`, itemID, userID).Scan(&owned); err != nil {
+		return nil, mapDBError(err)
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id, item_id, trigger_id, trigger_reason, step, status, model, error_message,
+		       started_at, finished_at, duration_ms
+		FROM (
+			SELECT *
+			FROM item_processing_events
+			WHERE item_id = $1
+			ORDER BY started_at DESC
+			LIMIT $2
+		) recent
+		ORDER BY started_at, created_at`, itemID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.ItemProcessingEvent{}
+	for rows.Next() {
+		var ev model.ItemProcessingEvent
+		if err := rows.Scan(&ev.ID, &ev.ItemID, &ev.TriggerID, &ev.TriggerReason, &ev.Step, &ev.Status, &ev.Model, &ev.ErrorMessage,
+			&ev.StartedAt, &ev.FinishedAt, &ev.DurationMS); err != nil {
+			return nil, err
+		}
+		out = append(out, ev)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// ItemProcessingSlotTTL bounds how long a slot counts against its user's limit, so a run that
+// died without releasing it does not block the queue for good.
+const ItemProcessingSlotTTL = 15 * time.Minute
+
+// liveProcessingSlotsSQL matches slots of user $1 still held by a run: younger than $3 seconds
+// and on an item that has not left the pipeline. Failed, deleted or deferred items free their
+// slot without an explicit release.
+const liveProcessingSlotsSQL = `
+	SELECT ps.item_id
+	FROM item_processing_slots ps
+	JOIN items li ON li.id = ps.item_id
+	WHERE ps.user_id = $1
+	  AND ps.acquired_at >= NOW() - make_interval(secs => $3)
+	  AND li.status IN ('new', 'fetched', 'facts_extracted')
+	  AND li.deleted_at IS NULL`
+
+// AcquireProcessingSlot claims one of the user's processing slots for itemID. It reports false
+// while limit other items hold live slots; an item that already holds one (a retried run)
+// keeps it.
+func (r *ItemInngestRepo) AcquireProcessingSlot(ctx context.Context, userID, itemID string, limit int) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('item_processing_slots:' || $1))`, userID); err != nil {
+		return false, err
+	}
+	var held bool
+	var live int
+	if err := tx.QueryRow(ctx, `
+		WITH live AS (`+liveProcessingSlotsSQL+`)
+		SELECT EXISTS (SELECT 1 FROM live WHERE item_id = $2), COUNT(*)
+		FROM live`, userID, itemID, ItemProcessingSlotTTL.Seconds()).Scan(&held, &live); err != nil {
+		return false, err
+	}
+	if !held && live >= limit {
+		return false, nil
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO item_processing_slots (item_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (item_id) DO UPDATE SET acquired_at = NOW()`, itemID, userID); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ItemInngestRepo) ReleaseProcessingSlot(ctx context.Context, itemID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM item_processing_slots WHERE item_id = $1`, itemID)
+	return err
+}
+
+// ProcessingQueue reports the item's place behind its owner's processing slots, or nil once
+// the item is no longer waiting on the pipeline. Limit is left for the caller to fill in.
+func (r *ItemRepo) ProcessingQueue(ctx context.Context, userID, itemID string) (*model.ItemProcessingQueue, error) {
+	var status string
+	var running bool
+	var inFlight, ahead int
+	err := r.db.QueryRow(ctx, `
+		WITH live AS (`+liveProcessingSlotsSQL+`),
+		target AS (
+			SELECT i.id, i.status, i.created_at
+			FROM items i
+			JOIN sources s ON s.id = i.source_id
+			WHERE s.user_id = $1
+			  AND i.id = $2
+			  AND i.deleted_at IS NULL
+		)
+		SELECT t.status,
+		       EXISTS (SELECT 1 FROM live WHERE live.item_id = t.id),
+		       (SELECT COUNT(*) FROM live),
+		       (SELECT COUNT(*)
+		        FROM items i
+		        JOIN sources s ON s.id = i.source_id
+		        WHERE s.user_id = $1
+		          AND i.deleted_at IS NULL
+		          AND i.status IN ('new', 'fetched', 'facts_extracted')
+		          AND (i.created_at, i.id) < (t.created_at, t.id)
+		          AND NOT EXISTS (SELECT 1 FROM live WHERE live.item_id = i.id))
+		FROM target t`, userID, itemID, ItemProcessingSlotTTL.Seconds()).Scan(&status, &running, &inFlight, &ahead)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	switch status {
+	case "new", "fetched", "facts_extracted":
+	default:
+		return nil, nil
+	}
+	out := &model.ItemProcessingQueue{State: "running", InFlight: inFlight}
+	if !running {
+		out.State = "queued"
+		out.Position = ahead + 1
+	}
+	return out, nil
+}
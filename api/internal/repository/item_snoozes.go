@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// itemNotSnoozedSQL excludes items the user has snoozed past NOW(). It expects the items
+// alias i and the user id bound as $1.
+const itemNotSnoozedSQL = ` AND NOT EXISTS (
+			SELECT 1 FROM item_snoozes isz
+			WHERE isz.item_id = i.id AND isz.user_id = $1 AND isz.snoozed_until > NOW()
+		)`
+
+func (r *ItemRepo) Snooze(ctx context.Context, userID, itemID string, until time.Time) error {
+	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO item_snoozes (user_id, item_id, snoozed_until)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, item_id) DO UPDATE
+		SET snoozed_until = EXCLUDED.snoozed_until,
+		    updated_at = NOW()`,
+		userID, itemID, until,
+	)
+	return err
+}
+
+func (r *ItemRepo) Unsnooze(ctx context.Context, userID, itemID string) error {
+	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `DELETE FROM item_snoozes WHERE user_id = $1 AND item_id = $2`, userID, itemID)
+	return err
+}
+
+// annotateResurfacedSnoozes flags items whose snooze has expired so the UI can show a badge.
+// The snooze row is kept until the item is read or unsnoozed.
+func (r *ItemRepo) annotateResurfacedSnoozes(ctx context.Context, userID string, items []model.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT item_id::text, snoozed_until
+		FROM item_snoozes
+		WHERE user_id = $1
+		  AND item_id = ANY($2::uuid[])
+		  AND snoozed_until <= NOW()`,
+		userID, ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	until := make(map[string]time.Time, len(ids))
+	for rows.Next() {
+		var itemID string
+		var t time.Time
+		if err := rows.Scan(&itemID, &t); err != nil {
+			return err
+		}
+		until[itemID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := range items {
+		if t, ok := until[items[i].ID]; ok {
+			items[i].SnoozedUntil = &t
+		}
+	}
+	return nil
+}
+
+// splitResurfacedSnoozes moves up to limit unread resurfaced items out of candidates so the
+// reading plan can place them ahead of MMR selection.
+func splitResurfacedSnoozes(candidates []model.Item, limit int) (resurfaced, rest []model.Item) {
+	rest = make([]model.Item, 0, len(candidates))
+	for _, it := range candidates {
+		if len(resurfaced) < limit && it.SnoozedUntil != nil && !it.IsRead {
+			resurfaced = append(resurfaced, it)
+			continue
+		}
+		rest = append(rest, it)
+	}
+	return resurfaced, rest
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestSplitResurfacedSnoozes(t *testing.T) {
+	until := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	candidates := []model.Item{
+		{ID: "a"},
+		{ID: "b", SnoozedUntil: &until},
+		{ID: "c", SnoozedUntil: &until, IsRead: true},
+		{ID: "d", SnoozedUntil: &until},
+		{ID: "e", SnoozedUntil: &until},
+	}
+
+	resurfaced, rest := splitResurfacedSnoozes(candidates, 2)
+	if got := itemIDs(resurfaced); len(got) != 2 || got[0] != "b" || got[1] != "d" {
+		t.Fatalf("resurfaced = %v, want [b d]", got)
+	}
+	if got := itemIDs(rest); len(got) != 3 || got[0] != "a" || got[1] != "c" || got[2] != "e" {
+		t.Fatalf("rest = %v, want [a c e]", got)
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// refreshItemTopicIndex rewrites the item_topic_index rows of the given items from their
+// current summaries. Items that are not summarized end up without rows. The index backs the
+// per-user topic aggregations so they avoid unnesting item_summaries.topics on every call.
+func refreshItemTopicIndex(ctx context.Context, db *pgxpool.Pool, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM item_topic_index WHERE item_id = ANY($1::uuid[])`, itemIDs); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO item_topic_index (item_id, user_id, topic, published_at, effective_at, score)
+		SELECT i.id,
+		       s.user_id,
+		       t.topic,
+		       COALESCE(i.published_at, i.created_at),
+		       `+briefingEffectiveTimeSQL+`,
+		       sm.score
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT COALESCE(NULLIF(BTRIM(x), ''), '__untagged__') AS topic
+			FROM unnest(
+				CASE
+					WHEN COALESCE(array_length(sm.topics, 1), 0) = 0 THEN ARRAY['__untagged__']::text[]
+					ELSE sm.topics
+				END
+			) AS x
+		) AS t
+		WHERE i.id = ANY($1::uuid[])
+		  AND i.status = 'summarized'`, itemIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
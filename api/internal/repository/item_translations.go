@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const itemTranslationColumns = `t.item_id, t.language, t.title, t.summary, t.facts, t.content, t.model, t.created_at, t.updated_at`
+
+func scanItemTranslation(row interface{ Scan(...any) error }) (*model.ItemTranslation, error) {
+	var v model.ItemTranslation
+	if err := row.Scan(&v.ItemID, &v.Language, &v.Title, &v.Summary, &v.Facts, &v.Content, &v.Model, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// GetTranslation returns the stored translation of an item. A translation made before the item
+// was last summarized is treated as missing.
+func (r *ItemRepo) GetTranslation(ctx context.Context, userID, itemID, language string) (*model.ItemTranslation, error) {
+	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
+		return nil, err
+	}
+	v, err := scanItemTranslation(r.db.QueryRow(ctx, `
+		SELECT `+itemTranslationColumns+`
+		FROM item_translations t
+		JOIN item_summaries sm ON sm.item_id = t.item_id
+		WHERE t.item_id = $1 AND t.language = $2
+		  AND t.source_summarized_at IS NOT DISTINCT FROM sm.summarized_at`,
+		itemID, language,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+// UpsertTranslation stores a translation against the item's current summary.
+func (r *ItemRepo) UpsertTranslation(ctx context.Context, t model.ItemTranslation) (*model.ItemTranslation, error) {
+	facts := t.Facts
+	if facts == nil {
+		facts = []string{}
+	}
+	v, err := scanItemTranslation(r.db.QueryRow(ctx, `
+		INSERT INTO item_translations AS t (item_id, language, title, summary, facts, content, model, source_summarized_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT summarized_at FROM item_summaries WHERE item_id = $1))
+		ON CONFLICT (item_id, language) DO UPDATE SET
+		  title = EXCLUDED.title,
+		  summary = EXCLUDED.summary,
+		  facts = EXCLUDED.facts,
+		  content = EXCLUDED.content,
+		  model = EXCLUDED.model,
+		  source_summarized_at = EXCLUDED.source_summarized_at,
+		  updated_at = NOW()
+		RETURNING `+itemTranslationColumns,
+		t.ItemID, t.Language, t.Title, t.Summary, facts, t.Content, t.Model,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	TriageActionRead     = "read"
+	TriageActionSkip     = "skip"
+	TriageActionFavorite = "favorite"
+	TriageActionSnooze   = "snooze"
+	TriageActionRate     = "rate"
+)
+
+// TriageDecision is one choice from a keyboard triage session. Rating and Reason are used by
+// rate, Until by snooze.
+type TriageDecision struct {
+	ItemID string
+	Action string
+	Rating int
+	Reason *string
+	Until  time.Time
+}
+
+// ApplyTriage applies decisions in the order given inside one transaction. When any decision
+// names an item the user does not have (or has deleted), nothing is written and the results
+// mark those decisions; applied is false in that case. readInserted counts items that became
+// read.
+func (r *ItemRepo) ApplyTriage(ctx context.Context, userID string, decisions []TriageDecision) (results []model.TriageResult, applied bool, readInserted int, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	states, err := triageItemStates(ctx, tx, userID, decisions)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	results = make([]model.TriageResult, len(decisions))
+	valid := true
+	for i, d := range decisions {
+		results[i] = model.TriageResult{ItemID: d.ItemID, Action: d.Action}
+		switch state, ok := states[d.ItemID]; {
+		case !ok || state == ownedItemMissing:
+			results[i].Status = model.TriageStatusNotFound
+			valid = false
+		case state == ownedItemDeleted:
+			results[i].Status = model.TriageStatusDeleted
+			valid = false
+		}
+	}
+	if !valid {
+		for i := range results {
+			if results[i].Status == "" {
+				results[i].Status = model.TriageStatusNotApplied
+			}
+		}
+		return results, false, 0, nil
+	}
+
+	for i, d := range decisions {
+		changed, err := applyTriageDecision(ctx, tx, userID, d)
+		if err != nil {
+			return nil, false, 0, err
+		}
+		switch {
+		case d.Action == TriageActionSkip:
+			results[i].Status = model.TriageStatusSkipped
+		case changed:
+			results[i].Status = model.TriageStatusApplied
+			if d.Action == TriageActionRead {
+				readInserted++
+			}
+		default:
+			results[i].Status = model.TriageStatusUnchanged
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, 0, err
+	}
+	return results, true, readInserted, nil
+}
+
+func triageItemStates(ctx context.Context, tx pgx.Tx, userID string, decisions []TriageDecision) (map[string]ownedItemState, error) {
+	ids := make([]string, 0, len(decisions))
+	seen := make(map[string]bool, len(decisions))
+	for _, d := range decisions {
+		if !seen[d.ItemID] {
+			seen[d.ItemID] = true
+			ids = append(ids, d.ItemID)
+		}
+	}
+	// Lock the rows so a concurrent delete cannot land between the check and the writes.
+	rows, err := tx.Query(ctx, `
+		SELECT i.id::text, i.deleted_at IS NOT NULL
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		WHERE s.user_id = $1 AND i.id::text = ANY($2::text[])
+		FOR SHARE OF i`, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make(map[string]ownedItemState, len(ids))
+	for rows.Next() {
+		var id string
+		var deleted bool
+		if err := rows.Scan(&id, &deleted); err != nil {
+			return nil, err
+		}
+		out[id] = ownedItemActive
+		if deleted {
+			out[id] = ownedItemDeleted
+		}
+	}
+	return out, rows.Err()
+}
+
+func applyTriageDecision(ctx context.Context, tx pgx.Tx, userID string, d TriageDecision) (bool, error) {
+	switch d.Action {
+	case TriageActionRead:
+		var inserted int
+		err := tx.QueryRow(ctx, `
+			INSERT INTO item_reads (user_id, item_id)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id, item_id) DO NOTHING
+			RETURNING 1`, userID, d.ItemID).Scan(&inserted)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return false, err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM item_laters WHERE user_id = $1 AND item_id = $2`, userID, d.ItemID); err != nil {
+			return false, err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM item_snoozes WHERE user_id = $1 AND item_id = $2`, userID, d.ItemID); err != nil {
+			return false, err
+		}
+		return inserted == 1, nil
+	case TriageActionFavorite:
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO item_feedbacks (user_id, item_id, rating, is_favorite)
+			VALUES ($1, $2, 0, true)
+			ON CONFLICT (user_id, item_id) DO UPDATE
+			SET is_favorite = true, updated_at = NOW()
+			WHERE item_feedbacks.is_favorite = false`, userID, d.ItemID)
+		if err != nil {
+			return false, err
+		}
+		return tag.RowsAffected() > 0, nil
+	case TriageActionRate:
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO item_feedbacks (user_id, item_id, rating, is_favorite, reason)
+			VALUES ($1, $2, $3, false, CASE WHEN $3 < 0 THEN $4::text END)
+			ON CONFLICT (user_id, item_id) DO UPDATE SET
+			  rating = EXCLUDED.rating,
+			  reason = CASE WHEN EXCLUDED.rating < 0 THEN COALESCE(EXCLUDED.reason, item_feedbacks.reason) END,
+			  updated_at = NOW()
+			WHERE item_feedbacks.rating IS DISTINCT FROM EXCLUDED.rating
+			   OR (EXCLUDED.reason IS NOT NULL AND item_feedbacks.reason IS DISTINCT FROM EXCLUDED.reason)`,
+			userID, d.ItemID, d.Rating, d.Reason)
+		if err != nil {
+			return false, err
+		}
+		return tag.RowsAffected() > 0, nil
+	case TriageActionSnooze:
+		_, err := tx.Exec(ctx, `
+			INSERT INTO item_snoozes (user_id, item_id, snoozed_until)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, item_id) DO UPDATE
+			SET snoozed_until = EXCLUDED.snoozed_until,
+			    updated_at = NOW()`, userID, d.ItemID, d.Until)
+		return err == nil, err
+	}
+	return false, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/urlutil"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -29,6 +30,8 @@ type ItemListParams struct {
 	SourceID     *string
 	Topic        *string
 	Genre        *string
+	Language     *string
+	Depth        *string // news_brief | deep_dive | tutorial
 	Query        *string
 	UnreadOnly   bool
 	ReadOnly     bool
@@ -90,7 +93,7 @@ func scanItemsWithBreakdown(rows itemRowScanner) ([]model.Item, error) {
 	for rows.Next() {
 		var it model.Item
 		if err := rows.Scan(&it.ID, &it.SourceID, &it.SourceTitle, &it.URL, &it.Title, &it.ThumbnailURL, &it.ContentText,
-			&it.Status, &it.ProcessingError, &it.FactsCheckResult, &it.FaithfulnessResult, &it.IsRead, &it.IsFavorite, &it.FeedbackRating, &it.SummaryScore, &it.SummaryScoreBreakdown, &it.PersonalScore, &it.PersonalScoreReason, &it.SummaryTopics, &it.TranslatedTitle, &it.PublishedAt, &it.FetchedAt, &it.CreatedAt, &it.UpdatedAt); err != nil {
+			&it.Status, &it.ProcessingError, &it.FactsCheckResult, &it.FaithfulnessResult, &it.IsRead, &it.IsFavorite, &it.FeedbackRating, &it.SummaryScore, &it.SummaryScoreBreakdown, &it.PersonalScore, &it.PersonalScoreReason, &it.SummaryTopics, &it.TranslatedTitle, &it.SummaryDepth, &it.Paywalled, &it.PublishedAt, &it.FetchedAt, &it.CreatedAt, &it.UpdatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, it)
@@ -103,7 +106,7 @@ func scanItemsWithGenres(rows itemRowScanner) ([]model.Item, error) {
 	for rows.Next() {
 		var it model.Item
 		if err := rows.Scan(&it.ID, &it.SourceID, &it.SourceTitle, &it.URL, &it.Title, &it.ThumbnailURL, &it.ContentText,
-			&it.Status, &it.ProcessingError, &it.FactsCheckResult, &it.FaithfulnessResult, &it.IsRead, &it.IsFavorite, &it.FeedbackRating, &it.SummaryScore, &it.PersonalScore, &it.PersonalScoreReason, &it.SummaryTopics, &it.TranslatedTitle, &it.UserGenre, &it.UserOtherGenreLabel, &it.Genre, &it.OtherGenreLabel, &it.PublishedAt, &it.FetchedAt, &it.CreatedAt, &it.UpdatedAt); err != nil {
+			&it.Status, &it.ProcessingError, &it.FactsCheckResult, &it.FaithfulnessResult, &it.IsRead, &it.IsFavorite, &it.FeedbackRating, &it.SummaryScore, &it.PersonalScore, &it.PersonalScoreReason, &it.SummaryTopics, &it.TranslatedTitle, &it.SummaryDepth, &it.Paywalled, &it.UserGenre, &it.UserOtherGenreLabel, &it.Genre, &it.OtherGenreLabel, &it.Language, &it.PublishedAt, &it.FetchedAt, &it.CreatedAt, &it.UpdatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, it)
@@ -131,6 +134,9 @@ func buildItemListFilterParts(userID string, p ItemListParams, includeGenre bool
 	} else {
 		where += ` AND i.deleted_at IS NULL`
 	}
+	if p.Status == nil || *p.Status != "deleted" {
+		where += itemNotSnoozedSQL
+	}
 	if p.SourceID != nil {
 		args = append(args, *p.SourceID)
 		where += ` AND i.source_id = $` + itoa(len(args))
@@ -139,6 +145,14 @@ func buildItemListFilterParts(userID string, p ItemListParams, includeGenre bool
 		args = append(args, *p.Topic)
 		where += ` AND COALESCE(sm.topics, '{}'::text[]) @> ARRAY[$` + itoa(len(args)) + `::text]`
 	}
+	if p.Language != nil && *p.Language != "" {
+		args = append(args, *p.Language)
+		where += ` AND i.language = $` + itoa(len(args))
+	}
+	if p.Depth != nil && *p.Depth != "" {
+		args = append(args, *p.Depth)
+		where += ` AND sm.depth = $` + itoa(len(args))
+	}
 	if p.Query != nil && strings.TrimSpace(*p.Query) != "" {
 		args = append(args, "%"+strings.TrimSpace(*p.Query)+"%")
 		where += ` AND (
@@ -234,6 +248,9 @@ func (r *ItemRepo) List(ctx context.Context, userID string, status, sourceID *st
 	} else {
 		query += ` AND i.deleted_at IS NULL`
 	}
+	if status == nil || *status != "deleted" {
+		query += itemNotSnoozedSQL
+	}
 	if sourceID != nil {
 		args = append(args, *sourceID)
 		query += ` AND i.source_id = $` + itoa(len(args))
@@ -262,7 +279,7 @@ func (r *ItemRepo) List(ctx context.Context, userID string, status, sourceID *st
 	return items, nil
 }
 
-func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams) (*model.ItemListResponse, error) {
+func normalizeItemListParams(p ItemListParams) ItemListParams {
 	if p.Page <= 0 {
 		p.Page = 1
 	}
@@ -275,6 +292,22 @@ func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams
 	if p.Sort != "score" && p.Sort != "personal_score" {
 		p.Sort = "newest"
 	}
+	return p
+}
+
+func itemListOrderBy(sort string) string {
+	switch sort {
+	case "score":
+		return ` ORDER BY sm.score DESC NULLS LAST, i.created_at DESC`
+	case "personal_score":
+		return ` ORDER BY sm.personal_score DESC NULLS LAST, sm.score DESC NULLS LAST, i.created_at DESC`
+	default:
+		return ` ORDER BY i.created_at DESC`
+	}
+}
+
+func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams) (*model.ItemListResponse, error) {
+	p = normalizeItemListParams(p)
 
 	countJoins, countWhere, countArgs := buildItemListFilterParts(userID, p, true)
 	genreCountJoins, genreCountWhere, genreCountArgs := buildItemListFilterParts(userID, p, false)
@@ -293,12 +326,7 @@ func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams
 	limitArg := `$` + itoa(len(listArgs)-1)
 	offsetArg := `$` + itoa(len(listArgs))
 
-	orderBy := ` ORDER BY i.created_at DESC`
-	if p.Sort == "score" {
-		orderBy = ` ORDER BY sm.score DESC NULLS LAST, i.created_at DESC`
-	} else if p.Sort == "personal_score" {
-		orderBy = ` ORDER BY sm.personal_score DESC NULLS LAST, sm.score DESC NULLS LAST, i.created_at DESC`
-	}
+	orderBy := itemListOrderBy(p.Sort)
 
 	rows, err := r.db.Query(ctx, `
 		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, COALESCE(sm.summary, i.content_text) AS content_text, i.status, i.processing_error,
@@ -307,10 +335,10 @@ func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams
 		       (ir.item_id IS NOT NULL) AS is_read,
 		       COALESCE(fb.is_favorite, false) AS is_favorite,
 		       COALESCE(fb.rating, 0) AS feedback_rating,
-		       sm.score, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title,
+		       sm.score, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title, sm.depth, i.paywalled,
 		       i.user_genre, i.user_other_genre_label, `+effectiveGenreExpr("i", "sm")+` AS genre,
 		       `+effectiveOtherGenreLabelExpr("i", "sm")+` AS other_genre_label,
-		       i.published_at, i.fetched_at, i.created_at, i.updated_at
+		       i.language, i.published_at, i.fetched_at, i.created_at, i.updated_at
 		FROM items i
 		`+countJoins+`
 		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
@@ -330,6 +358,9 @@ func (r *ItemRepo) ListPage(ctx context.Context, userID string, p ItemListParams
 	if err != nil {
 		return nil, err
 	}
+	if err := r.annotateResurfacedSnoozes(ctx, userID, items); err != nil {
+		return nil, err
+	}
 	return &model.ItemListResponse{
 		Items:       items,
 		GenreCounts: genreCounts,
@@ -467,7 +498,15 @@ func (r *ItemRepo) GetDetail(ctx context.Context, id, userID string) (*model.Ite
 		d.Note = note
 		d.Highlights = highlights
 	}
-	if d.Status == "summarized" && (len(d.FactsExecutions) == 0 || len(d.SummaryExecutions) == 0) {
+	if discussions, err := listItemDiscussions(ctx, r.db, id); err == nil {
+		d.Discussions = discussions
+	} else {
+		log.Printf("item detail discussions item_id=%s: %v", id, err)
+	}
+	if prescreen, err := loadItemPrescreen(ctx, r.db, id); err == nil {
+		d.Prescreen = prescreen
+	}
+	if d.Status == "summarized" && (d.Prescreen == nil || !d.Prescreen.Skipped) && (len(d.FactsExecutions) == 0 || len(d.SummaryExecutions) == 0) {
 		log.Printf(
 			"item detail executions missing item_id=%s facts_exec=%d summary_exec=%d has_facts=%t has_summary=%t",
 			id,
@@ -484,11 +523,11 @@ func (r *ItemRepo) GetDetail(ctx context.Context, id, userID string) (*model.Ite
 func (r *ItemRepo) GetFeedback(ctx context.Context, userID, itemID string) (*model.ItemFeedback, error) {
 	var fb model.ItemFeedback
 	err := r.db.QueryRow(ctx, `
-		SELECT user_id, item_id, rating, is_favorite, updated_at
+		SELECT user_id, item_id, rating, is_favorite, reason, updated_at
 		FROM item_feedbacks
 		WHERE user_id = $1 AND item_id = $2`,
 		userID, itemID,
-	).Scan(&fb.UserID, &fb.ItemID, &fb.Rating, &fb.IsFavorite, &fb.UpdatedAt)
+	).Scan(&fb.UserID, &fb.ItemID, &fb.Rating, &fb.IsFavorite, &fb.Reason, &fb.UpdatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
@@ -496,20 +535,27 @@ func (r *ItemRepo) GetFeedback(ctx context.Context, userID, itemID string) (*mod
 }
 
 func (r *ItemRepo) UpsertFeedback(ctx context.Context, userID, itemID string, rating int, isFavorite bool) (*model.ItemFeedback, error) {
+	return r.UpsertFeedbackWithReason(ctx, userID, itemID, rating, isFavorite, nil)
+}
+
+// UpsertFeedbackWithReason stores feedback with the reason for a negative rating. A nil reason
+// keeps the stored one while the rating stays negative; other ratings clear it.
+func (r *ItemRepo) UpsertFeedbackWithReason(ctx context.Context, userID, itemID string, rating int, isFavorite bool, reason *string) (*model.ItemFeedback, error) {
 	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
 		return nil, err
 	}
 	var fb model.ItemFeedback
 	err := r.db.QueryRow(ctx, `
-		INSERT INTO item_feedbacks (user_id, item_id, rating, is_favorite)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO item_feedbacks (user_id, item_id, rating, is_favorite, reason)
+		VALUES ($1, $2, $3, $4, CASE WHEN $3 < 0 THEN $5::text END)
 		ON CONFLICT (user_id, item_id) DO UPDATE SET
 		  rating = EXCLUDED.rating,
 		  is_favorite = EXCLUDED.is_favorite,
+		  reason = CASE WHEN EXCLUDED.rating < 0 THEN COALESCE(EXCLUDED.reason, item_feedbacks.reason) END,
 		  updated_at = NOW()
-		RETURNING user_id, item_id, rating, is_favorite, updated_at`,
-		userID, itemID, rating, isFavorite,
-	).Scan(&fb.UserID, &fb.ItemID, &fb.Rating, &fb.IsFavorite, &fb.UpdatedAt)
+		RETURNING user_id, item_id, rating, is_favorite, reason, updated_at`,
+		userID, itemID, rating, isFavorite, reason,
+	).Scan(&fb.UserID, &fb.ItemID, &fb.Rating, &fb.IsFavorite, &fb.Reason, &fb.UpdatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
@@ -536,9 +582,27 @@ func (r *ItemRepo) MarkRead(ctx context.Context, userID, itemID string) (bool, e
 		return false, err
 	}
 	_, _ = r.db.Exec(ctx, `DELETE FROM item_laters WHERE user_id = $1 AND item_id = $2`, userID, itemID)
+	_, _ = r.db.Exec(ctx, `DELETE FROM item_snoozes WHERE user_id = $1 AND item_id = $2`, userID, itemID)
 	return true, nil
 }
 
+// RecordClick stores an outbound link click as an implicit preference signal.
+func (r *ItemRepo) RecordClick(ctx context.Context, userID, itemID, via string) error {
+	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO item_clicks (user_id, item_id, last_via)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, item_id) DO UPDATE
+		SET click_count = item_clicks.click_count + 1,
+		    last_via = EXCLUDED.last_via,
+		    last_clicked_at = NOW()`,
+		userID, itemID, via,
+	)
+	return err
+}
+
 func (r *ItemRepo) MarkUnread(ctx context.Context, userID, itemID string) error {
 	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
 		return err
@@ -659,15 +723,35 @@ func errForRestoreOwnedItemState(state ownedItemState) error {
 	}
 }
 
+// UpsertFromFeed inserts an item for url unless the source already has it or another source
+// of the same user has an item with the same canonical URL (tracking parameters and AMP
+// variants removed). The raw URL is kept in url for fetching and display.
 func (r *ItemRepo) UpsertFromFeed(ctx context.Context, sourceID, url string, title *string) (string, bool, error) {
 	var id string
-	var created bool
+	canonicalURL := urlutil.Canonicalize(url)
 	err := r.db.QueryRow(ctx, `
-		INSERT INTO items (source_id, url, title)
-		VALUES ($1, $2, $3)
+		SELECT i.id
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		WHERE s.user_id = (SELECT user_id FROM sources WHERE id = $1)
+		  AND i.canonical_url = $2
+		ORDER BY i.created_at
+		LIMIT 1`,
+		sourceID, canonicalURL,
+	).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", false, err
+	}
+	var created bool
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO items (source_id, url, canonical_url, title)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (source_id, url) DO NOTHING
 		RETURNING id, true`,
-		sourceID, url, title,
+		sourceID, url, canonicalURL, title,
 	).Scan(&id, &created)
 	if err != nil {
 		err2 := r.db.QueryRow(ctx, `SELECT id FROM items WHERE source_id = $1 AND url = $2`, sourceID, url).Scan(&id)
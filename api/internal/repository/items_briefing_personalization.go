@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// FavoritedTopicsOnDateJST returns the topics of items the user favorited on the JST date,
+// most frequent first.
+func (r *ItemRepo) FavoritedTopicsOnDateJST(ctx context.Context, userID, date string, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT topic
+		FROM (
+			SELECT BTRIM(t) AS topic
+			FROM item_feedbacks fb
+			JOIN item_summaries sm ON sm.item_id = fb.item_id
+			CROSS JOIN LATERAL unnest(COALESCE(sm.topics, '{}'::text[])) AS t
+			WHERE fb.user_id = $1
+			  AND fb.is_favorite = true
+			  AND (fb.updated_at AT TIME ZONE 'Asia/Tokyo')::date = $2::date
+		) x
+		WHERE topic <> ''
+		GROUP BY topic
+		ORDER BY COUNT(*) DESC, topic ASC
+		LIMIT $3`,
+		userID, date, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	topics := []string{}
+	for rows.Next() {
+		var topic string
+		if err := rows.Scan(&topic); err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	return topics, rows.Err()
+}
+
+// BriefingTopicFollowUps returns unread, unfavorited summarized items that arrived since the
+// given time and share a topic with topics, highest score first.
+func (r *ItemRepo) BriefingTopicFollowUps(ctx context.Context, userID string, topics []string, since time.Time, limit int) ([]model.Item, error) {
+	if len(topics) == 0 {
+		return []model.Item{}, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, NULL::text AS content_text, i.status, i.processing_error,
+		       fc.final_result AS facts_check_result,
+		       sfc.final_result AS faithfulness_result,
+		       (ir.item_id IS NOT NULL) AS is_read,
+		       COALESCE(fb.is_favorite, false) AS is_favorite,
+		       COALESCE(fb.rating, 0) AS feedback_rating,
+		       sm.score, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title,
+		       i.published_at, i.fetched_at, i.created_at, i.updated_at
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
+		LEFT JOIN item_facts_checks fc ON fc.item_id = i.id
+		LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.status = 'summarized'
+		  AND `+briefingEffectiveTimeSQL+` >= $3
+		  AND ir.item_id IS NULL
+		  AND COALESCE(fb.is_favorite, false) = false
+		  AND sm.topics && $2::text[]`+itemNotSnoozedSQL+itemNotFilteredSQL+`
+		ORDER BY sm.score DESC NULLS LAST, `+briefingEffectiveTimeSQL+` DESC
+		LIMIT $4`,
+		userID, topics, since, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanItems(rows)
+}
+
+// ReadHourHistogram counts the user's reads since the given time by JST hour of day.
+func (r *ItemRepo) ReadHourHistogram(ctx context.Context, userID string, since time.Time) ([24]int, error) {
+	var hist [24]int
+	rows, err := r.db.Query(ctx, `
+		SELECT EXTRACT(HOUR FROM ir.read_at AT TIME ZONE 'Asia/Tokyo')::int AS hour, COUNT(*)::int
+		FROM item_reads ir
+		WHERE ir.user_id = $1
+		  AND ir.read_at >= $2
+		GROUP BY hour`,
+		userID, since,
+	)
+	if err != nil {
+		return hist, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hour, count int
+		if err := rows.Scan(&hour, &count); err != nil {
+			return hist, err
+		}
+		if hour >= 0 && hour < 24 {
+			hist[hour] = count
+		}
+	}
+	return hist, rows.Err()
+}
+
+// TopReadTopicInHours returns the topic the user read most since the given time during the
+// listed JST hours of day, or "" when there is none.
+func (r *ItemRepo) TopReadTopicInHours(ctx context.Context, userID string, since time.Time, hours []int) (string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT BTRIM(t) AS topic
+		FROM item_reads ir
+		JOIN item_summaries sm ON sm.item_id = ir.item_id
+		CROSS JOIN LATERAL unnest(COALESCE(sm.topics, '{}'::text[])) AS t
+		WHERE ir.user_id = $1
+		  AND ir.read_at >= $2
+		  AND EXTRACT(HOUR FROM ir.read_at AT TIME ZONE 'Asia/Tokyo')::int = ANY($3::int[])
+		  AND BTRIM(t) <> ''
+		GROUP BY BTRIM(t)
+		ORDER BY COUNT(*) DESC, BTRIM(t) ASC
+		LIMIT 1`,
+		userID, since, hours,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	topic := ""
+	if rows.Next() {
+		if err := rows.Scan(&topic); err != nil {
+			return "", err
+		}
+	}
+	return topic, rows.Err()
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// CatchUpCandidates returns unread summarized items that arrived since the given time,
+// highest score first. Snoozed and read-later items are left out.
+func (r *ItemRepo) CatchUpCandidates(ctx context.Context, userID string, since time.Time, limit int) ([]model.Item, error) {
+	if limit <= 0 {
+		limit = 300
+	}
+	if limit > 800 {
+		limit = 800
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, NULL::text AS content_text, i.status, i.processing_error,
+		       fc.final_result AS facts_check_result,
+		       sfc.final_result AS faithfulness_result,
+		       (ir.item_id IS NOT NULL) AS is_read,
+		       COALESCE(fb.is_favorite, false) AS is_favorite,
+		       COALESCE(fb.rating, 0) AS feedback_rating,
+		       sm.score, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title,
+		       i.published_at, i.fetched_at, i.created_at, i.updated_at
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
+		LEFT JOIN item_facts_checks fc ON fc.item_id = i.id
+		LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.status = 'summarized'
+		  AND `+briefingEffectiveTimeSQL+` >= $2
+		  AND ir.item_id IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM item_laters il
+			WHERE il.user_id = $1
+			  AND il.item_id = i.id
+		  )`+itemNotSnoozedSQL+itemNotFilteredSQL+`
+		ORDER BY sm.score DESC NULLS LAST, `+briefingEffectiveTimeSQL+` DESC
+		LIMIT $3`,
+		userID, since, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanItems(rows)
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// itemListField is one item field a list request can select: the column expression it reads,
+// the join it needs beyond sources and summaries, where its value is scanned to and how it is
+// written back out.
+type itemListField struct {
+	name  string
+	expr  string
+	join  string
+	dest  func(*model.Item) any
+	value func(*model.Item) any
+}
+
+const (
+	itemFieldJoinRead         = `LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1`
+	itemFieldJoinFeedback     = `LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1`
+	itemFieldJoinFactsCheck   = `LEFT JOIN item_facts_checks fc ON fc.item_id = i.id`
+	itemFieldJoinFaithfulness = `LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id`
+)
+
+var itemListFields = []itemListField{
+	{name: "id", expr: "i.id", dest: func(it *model.Item) any { return &it.ID }, value: func(it *model.Item) any { return it.ID }},
+	{name: "source_id", expr: "i.source_id", dest: func(it *model.Item) any { return &it.SourceID }, value: func(it *model.Item) any { return it.SourceID }},
+	{name: "source_title", expr: "s.title", dest: func(it *model.Item) any { return &it.SourceTitle }, value: func(it *model.Item) any { return it.SourceTitle }},
+	{name: "url", expr: "i.url", dest: func(it *model.Item) any { return &it.URL }, value: func(it *model.Item) any { return it.URL }},
+	{name: "title", expr: "i.title", dest: func(it *model.Item) any { return &it.Title }, value: func(it *model.Item) any { return it.Title }},
+	{name: "thumbnail_url", expr: "i.thumbnail_url", dest: func(it *model.Item) any { return &it.ThumbnailURL }, value: func(it *model.Item) any { return it.ThumbnailURL }},
+	{name: "content_text", expr: "COALESCE(sm.summary, i.content_text)", dest: func(it *model.Item) any { return &it.ContentText }, value: func(it *model.Item) any { return it.ContentText }},
+	{name: "status", expr: "i.status", dest: func(it *model.Item) any { return &it.Status }, value: func(it *model.Item) any { return it.Status }},
+	{name: "processing_error", expr: "i.processing_error", dest: func(it *model.Item) any { return &it.ProcessingError }, value: func(it *model.Item) any { return it.ProcessingError }},
+	{name: "facts_check_result", expr: "fc.final_result", join: itemFieldJoinFactsCheck, dest: func(it *model.Item) any { return &it.FactsCheckResult }, value: func(it *model.Item) any { return it.FactsCheckResult }},
+	{name: "faithfulness_result", expr: "sfc.final_result", join: itemFieldJoinFaithfulness, dest: func(it *model.Item) any { return &it.FaithfulnessResult }, value: func(it *model.Item) any { return it.FaithfulnessResult }},
+	{name: "is_read", expr: "(ir.item_id IS NOT NULL)", join: itemFieldJoinRead, dest: func(it *model.Item) any { return &it.IsRead }, value: func(it *model.Item) any { return it.IsRead }},
+	{name: "is_favorite", expr: "COALESCE(fb.is_favorite, false)", join: itemFieldJoinFeedback, dest: func(it *model.Item) any { return &it.IsFavorite }, value: func(it *model.Item) any { return it.IsFavorite }},
+	{name: "feedback_rating", expr: "COALESCE(fb.rating, 0)", join: itemFieldJoinFeedback, dest: func(it *model.Item) any { return &it.FeedbackRating }, value: func(it *model.Item) any { return it.FeedbackRating }},
+	{name: "summary_score", expr: "sm.score", dest: func(it *model.Item) any { return &it.SummaryScore }, value: func(it *model.Item) any { return it.SummaryScore }},
+	{name: "personal_score", expr: "sm.personal_score", dest: func(it *model.Item) any { return &it.PersonalScore }, value: func(it *model.Item) any { return it.PersonalScore }},
+	{name: "personal_score_reason", expr: "sm.personal_score_reason", dest: func(it *model.Item) any { return &it.PersonalScoreReason }, value: func(it *model.Item) any { return it.PersonalScoreReason }},
+	{name: "summary_topics", expr: "COALESCE(sm.topics, '{}'::text[])", dest: func(it *model.Item) any { return &it.SummaryTopics }, value: func(it *model.Item) any { return it.SummaryTopics }},
+	{name: "translated_title", expr: "sm.translated_title", dest: func(it *model.Item) any { return &it.TranslatedTitle }, value: func(it *model.Item) any { return it.TranslatedTitle }},
+	{name: "summary_depth", expr: "sm.depth", dest: func(it *model.Item) any { return &it.SummaryDepth }, value: func(it *model.Item) any { return it.SummaryDepth }},
+	{name: "paywalled", expr: "i.paywalled", dest: func(it *model.Item) any { return &it.Paywalled }, value: func(it *model.Item) any { return it.Paywalled }},
+	{name: "user_genre", expr: "i.user_genre", dest: func(it *model.Item) any { return &it.UserGenre }, value: func(it *model.Item) any { return it.UserGenre }},
+	{name: "user_other_genre_label", expr: "i.user_other_genre_label", dest: func(it *model.Item) any { return &it.UserOtherGenreLabel }, value: func(it *model.Item) any { return it.UserOtherGenreLabel }},
+	{name: "genre", expr: effectiveGenreExpr("i", "sm"), dest: func(it *model.Item) any { return &it.Genre }, value: func(it *model.Item) any { return it.Genre }},
+	{name: "other_genre_label", expr: effectiveOtherGenreLabelExpr("i", "sm"), dest: func(it *model.Item) any { return &it.OtherGenreLabel }, value: func(it *model.Item) any { return it.OtherGenreLabel }},
+	{name: "language", expr: "i.language", dest: func(it *model.Item) any { return &it.Language }, value: func(it *model.Item) any { return it.Language }},
+	{name: "published_at", expr: "i.published_at", dest: func(it *model.Item) any { return &it.PublishedAt }, value: func(it *model.Item) any { return it.PublishedAt }},
+	{name: "fetched_at", expr: "i.fetched_at", dest: func(it *model.Item) any { return &it.FetchedAt }, value: func(it *model.Item) any { return it.FetchedAt }},
+	{name: "created_at", expr: "i.created_at", dest: func(it *model.Item) any { return &it.CreatedAt }, value: func(it *model.Item) any { return it.CreatedAt }},
+	{name: "updated_at", expr: "i.updated_at", dest: func(it *model.Item) any { return &it.UpdatedAt }, value: func(it *model.Item) any { return it.UpdatedAt }},
+}
+
+// ItemListMinimalFields is what view=minimal selects: enough to render a title row and count
+// what is unread.
+var ItemListMinimalFields = []string{"id", "source_id", "url", "title", "translated_title", "is_read", "published_at", "created_at"}
+
+// NormalizeItemListFields puts the requested field names in a fixed order, drops duplicates
+// and always includes id. It reports false when a name is not a selectable field.
+func NormalizeItemListFields(names []string) ([]string, bool) {
+	want := map[string]bool{"id": true}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if itemListFieldByName(name) == nil {
+			return nil, false
+		}
+		want[name] = true
+	}
+	out := make([]string, 0, len(want))
+	for _, f := range itemListFields {
+		if want[f.name] {
+			out = append(out, f.name)
+		}
+	}
+	return out, true
+}
+
+func itemListFieldByName(name string) *itemListField {
+	for i := range itemListFields {
+		if itemListFields[i].name == name {
+			return &itemListFields[i]
+		}
+	}
+	return nil
+}
+
+// itemFieldsSelect builds the select list and the extra joins for normalized field names.
+func itemFieldsSelect(fields []string) (string, string) {
+	exprs := make([]string, 0, len(fields))
+	var joins []string
+	seen := map[string]bool{}
+	for _, name := range fields {
+		f := itemListFieldByName(name)
+		exprs = append(exprs, f.expr)
+		if f.join != "" && !seen[f.join] {
+			seen[f.join] = true
+			joins = append(joins, f.join)
+		}
+	}
+	return strings.Join(exprs, ", "), strings.Join(joins, "\n\t\t")
+}
+
+// ProjectItemFields keeps only the given normalized fields of each item.
+func ProjectItemFields(items []model.Item, fields []string) []map[string]any {
+	out := make([]map[string]any, 0, len(items))
+	for i := range items {
+		row := make(map[string]any, len(fields))
+		for _, name := range fields {
+			row[name] = itemListFieldByName(name).value(&items[i])
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// ListPageFields is ListPage for clients that need only some item fields. It reads only those
+// columns, joins only the tables they come from and skips genre counts and snooze annotations.
+// fields must come from NormalizeItemListFields.
+func (r *ItemRepo) ListPageFields(ctx context.Context, userID string, p ItemListParams, fields []string) (*model.ItemFieldsListResponse, error) {
+	p = normalizeItemListParams(p)
+	joins, where, args := buildItemListFilterParts(userID, p, true)
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM items i `+joins+` WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	offset := (p.Page - 1) * p.PageSize
+	listArgs := append(append([]any{}, args...), p.PageSize, offset)
+	selectSQL, fieldJoins := itemFieldsSelect(fields)
+	rows, err := r.db.Query(ctx, `
+		SELECT `+selectSQL+`
+		FROM items i
+		`+joins+`
+		`+fieldJoins+`
+		WHERE `+where+
+		itemListOrderBy(p.Sort)+` LIMIT $`+itoa(len(listArgs)-1)+` OFFSET $`+itoa(len(listArgs)),
+		listArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.Item
+	for rows.Next() {
+		var it model.Item
+		dest := make([]any, len(fields))
+		for i, name := range fields {
+			dest[i] = itemListFieldByName(name).dest(&it)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &model.ItemFieldsListResponse{
+		Items:    ProjectItemFields(items, fields),
+		Fields:   fields,
+		Page:     p.Page,
+		PageSize: p.PageSize,
+		Total:    total,
+		HasNext:  offset+len(items) < total,
+		Sort:     p.Sort,
+		Status:   p.Status,
+		SourceID: p.SourceID,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestItemFieldsSelectJoinsOnlyWhatFieldsNeed(t *testing.T) {
+	selectSQL, joins := itemFieldsSelect([]string{"id", "title", "is_read"})
+	if selectSQL != "i.id, i.title, (ir.item_id IS NOT NULL)" {
+		t.Fatalf("select = %q", selectSQL)
+	}
+	if joins != itemFieldJoinRead {
+		t.Fatalf("joins = %q", joins)
+	}
+
+	_, joins = itemFieldsSelect([]string{"id", "is_favorite", "feedback_rating"})
+	if strings.Count(joins, "item_feedbacks") != 1 || strings.Contains(joins, "item_reads") {
+		t.Fatalf("feedback joins = %q", joins)
+	}
+}
+
+func TestItemListFieldsMatchItemJSON(t *testing.T) {
+	for _, f := range itemListFields {
+		if f.dest == nil || f.value == nil || f.expr == "" {
+			t.Errorf("field %q is incomplete", f.name)
+		}
+	}
+	for _, name := range ItemListMinimalFields {
+		if itemListFieldByName(name) == nil {
+			t.Errorf("minimal field %q is not selectable", name)
+		}
+	}
+
+	title := "title"
+	rows := ProjectItemFields([]model.Item{{ID: "a", Title: &title, URL: "https://example.com"}}, []string{"id", "title"})
+	if len(rows) != 1 || len(rows[0]) != 2 || rows[0]["id"] != "a" || rows[0]["title"] != &title {
+		t.Fatalf("rows = %+v", rows)
+	}
+}
@@ -3,10 +3,15 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,6 +36,23 @@ type ItemEmbeddingBackfillTarget struct {
 	URL      string
 }
 
+type ItemBudgetDeferredTarget struct {
+	ItemID   string
+	SourceID string
+	URL      string
+	Title    *string
+}
+
+type ItemStuckTarget struct {
+	ItemID   string
+	SourceID string
+	UserID   string
+	URL      string
+	Title    *string
+	Status   string
+	Attempt  int
+}
+
 type ItemTranslatedTitleBackfillTarget struct {
 	ItemID   string
 	SourceID string
@@ -38,13 +60,15 @@ type ItemTranslatedTitleBackfillTarget struct {
 	Title    string
 }
 
-func (r *ItemInngestRepo) UpdateAfterExtract(ctx context.Context, id, contentText string, title, thumbnailURL *string, publishedAt *time.Time) error {
+func (r *ItemInngestRepo) UpdateAfterExtract(ctx context.Context, id, contentText string, title, thumbnailURL *string, publishedAt *time.Time, language *string, extractionSource string, archiveURL, canonicalURL *string, paywalled bool, contentHash string) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE items
 		SET content_text = $1, title = COALESCE($2, title), thumbnail_url = COALESCE($3, thumbnail_url), published_at = $4,
+		    language = COALESCE($6, language), extraction_source = NULLIF($7, ''), archive_url = $8,
+		    canonical_url = COALESCE($9, canonical_url), paywalled = $10, content_hash = NULLIF($11, ''),
 		    status = 'fetched', fetched_at = NOW(), processing_error = NULL, updated_at = NOW()
 		WHERE id = $5`,
-		contentText, title, thumbnailURL, publishedAt, id)
+		contentText, title, thumbnailURL, publishedAt, id, language, extractionSource, archiveURL, canonicalURL, paywalled, contentHash)
 	return err
 }
 
@@ -80,6 +104,7 @@ func (r *ItemInngestRepo) InsertSummary(
 	topics []string,
 	genre *string,
 	otherGenreLabel *string,
+	depth *string,
 	translatedTitle string,
 	score float64,
 	scoreBreakdown map[string]any,
@@ -106,26 +131,75 @@ func (r *ItemInngestRepo) InsertSummary(
 		translatedTitlePtr = &translatedTitle
 	}
 	genre, otherGenreLabel = normalizeGenreInput(genre, otherGenreLabel)
+	depth = normalizeItemDepth(depth)
+	var defaultTopics []string
+	if err := r.db.QueryRow(ctx, `
+		SELECT s.default_topics
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		WHERE i.id = $1`, itemID).Scan(&defaultTopics); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	topics = mergeSourceDefaultTopics(defaultTopics, topics)
 	_, err := r.db.Exec(ctx, `
-		INSERT INTO item_summaries (item_id, summary, topics, genre, other_genre_label, translated_title, score, score_breakdown, score_reason, score_policy_version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO item_summaries (item_id, summary, topics, genre, other_genre_label, depth, translated_title, score, score_breakdown, score_reason, score_policy_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (item_id) DO UPDATE SET
 		    summary = EXCLUDED.summary, topics = EXCLUDED.topics,
+		    original_topics = NULL,
 		    genre = EXCLUDED.genre,
 		    other_genre_label = EXCLUDED.other_genre_label,
+		    depth = EXCLUDED.depth,
 		    translated_title = EXCLUDED.translated_title,
 		    score = EXCLUDED.score,
 		    score_breakdown = EXCLUDED.score_breakdown,
 		    score_reason = EXCLUDED.score_reason,
 		    score_policy_version = EXCLUDED.score_policy_version,
 		    summarized_at = NOW()`,
-		itemID, summary, topics, genre, otherGenreLabel, translatedTitlePtr, score, scoreBreakdownJSON, scoreReasonPtr, scorePolicyVersionPtr)
+		itemID, summary, topics, genre, otherGenreLabel, depth, translatedTitlePtr, score, scoreBreakdownJSON, scoreReasonPtr, scorePolicyVersionPtr)
 	if err != nil {
 		return err
 	}
 	_, err = r.db.Exec(ctx, `
-		UPDATE items SET status = 'summarized', processing_error = NULL, updated_at = NOW() WHERE id = $1`, itemID)
-	return err
+		UPDATE items SET status = 'summarized', processing_error = NULL, auto_reprocess_count = 0, updated_at = NOW() WHERE id = $1`, itemID)
+	if err != nil {
+		return err
+	}
+	return refreshItemTopicIndex(ctx, r.db, []string{itemID})
+}
+
+// normalizeItemDepth drops depth labels outside the fixed set so the column check never fails.
+func normalizeItemDepth(depth *string) *string {
+	if depth == nil {
+		return nil
+	}
+	v := strings.ToLower(strings.TrimSpace(*depth))
+	if !model.IsItemDepth(v) {
+		return nil
+	}
+	return &v
+}
+
+// mergeSourceDefaultTopics puts the source's default topics ahead of the generated ones, so a
+// generic LLM topic does not outrank them in cluster labels, and drops case-insensitive repeats.
+func mergeSourceDefaultTopics(defaults, topics []string) []string {
+	if len(defaults) == 0 {
+		return topics
+	}
+	out := make([]string, 0, len(defaults)+len(topics))
+	seen := map[string]bool{}
+	for _, list := range [][]string{defaults, topics} {
+		for _, t := range list {
+			t = strings.TrimSpace(t)
+			key := strings.ToLower(t)
+			if t == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, t)
+		}
+	}
+	return out
 }
 
 func (r *ItemInngestRepo) UpsertSummaryFaithfulnessCheck(
@@ -202,6 +276,154 @@ func (r *ItemInngestRepo) MarkDeleted(ctx context.Context, id string, processing
 	return err
 }
 
+// MarkBudgetDeferred parks an item that was skipped because the user's LLM budget cap was reached.
+// It covers every status the manual retry flow re-enqueues, and reports false when the item was
+// not in one of them (already summarized, deleted or gone), so callers don't claim a deferral
+// the resume cron will never see.
+func (r *ItemInngestRepo) MarkBudgetDeferred(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE items
+		SET status = 'budget_deferred',
+		    processing_error = NULL,
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		  AND status IN ('new', 'fetched', 'facts_extracted', 'failed')`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListBudgetDeferredUserIDs returns users with deferred items or budget-skipped digests awaiting resume.
+func (r *ItemInngestRepo) ListBudgetDeferredUserIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT src.user_id
+		FROM items i
+		JOIN sources src ON src.id = i.source_id
+		WHERE i.status = 'budget_deferred'
+		  AND i.deleted_at IS NULL
+		UNION
+		SELECT d.user_id
+		FROM digests d
+		WHERE d.send_status = 'skipped_budget'
+		  AND d.sent_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		out = append(out, userID)
+	}
+	return out, rows.Err()
+}
+
+// ReleaseBudgetDeferred moves up to limit deferred items of a user back to 'new' and returns them,
+// oldest first, so the caller can re-emit item/created for each.
+func (r *ItemInngestRepo) ReleaseBudgetDeferred(ctx context.Context, userID string, limit int) ([]ItemBudgetDeferredTarget, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	rows, err := r.db.Query(ctx, `
+		UPDATE items i
+		SET status = 'new',
+		    updated_at = NOW()
+		WHERE i.id IN (
+			SELECT i2.id
+			FROM items i2
+			JOIN sources src ON src.id = i2.source_id
+			WHERE src.user_id = $1
+			  AND i2.status = 'budget_deferred'
+			  AND i2.deleted_at IS NULL
+			ORDER BY i2.updated_at ASC
+			LIMIT $2
+			FOR UPDATE OF i2 SKIP LOCKED
+		)
+		RETURNING i.id, i.source_id, i.url, i.title`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ItemBudgetDeferredTarget
+	for rows.Next() {
+		var v ItemBudgetDeferredTarget
+		if err := rows.Scan(&v.ItemID, &v.SourceID, &v.URL, &v.Title); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ClaimStuckForReprocess returns up to limit items that have sat in status since before
+// olderThan and were automatically reprocessed fewer than maxAttempts times. Claiming bumps
+// the attempt counter and updated_at, so overlapping runs skip the item until it goes stale
+// again. Items held back by a processing pause are not stuck and are skipped.
+func (r *ItemInngestRepo) ClaimStuckForReprocess(ctx context.Context, status string, olderThan time.Time, maxAttempts, limit int) ([]ItemStuckTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE items i
+		SET auto_reprocess_count = i.auto_reprocess_count + 1,
+		    auto_reprocessed_at = NOW(),
+		    updated_at = NOW()
+		WHERE i.id IN (
+			SELECT i2.id
+			FROM items i2
+			WHERE i2.status = $1
+			  AND i2.deleted_at IS NULL
+			  AND i2.updated_at < $2
+			  AND i2.auto_reprocess_count < $3`+itemOwnerNotPausedSQL("i2")+`
+			ORDER BY i2.updated_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING i.id, i.source_id, (SELECT s.user_id FROM sources s WHERE s.id = i.source_id), i.url, i.title, i.status, i.auto_reprocess_count`,
+		status, olderThan, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ItemStuckTarget
+	for rows.Next() {
+		var v ItemStuckTarget
+		if err := rows.Scan(&v.ItemID, &v.SourceID, &v.UserID, &v.URL, &v.Title, &v.Status, &v.Attempt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// FailExhaustedStuck marks items that are still stuck after maxAttempts automatic reprocesses
+// as failed, handing them over to the manual retry flow. Items of users who paused processing
+// are left alone, like in ClaimStuckForReprocess.
+func (r *ItemInngestRepo) FailExhaustedStuck(ctx context.Context, status string, olderThan time.Time, maxAttempts int, processingError string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE items
+		SET status = 'failed',
+		    processing_error = $4,
+		    updated_at = NOW()
+		WHERE status = $1
+		  AND deleted_at IS NULL
+		  AND updated_at < $2
+		  AND auto_reprocess_count >= $3`+itemOwnerNotPausedSQL("items"),
+		status, olderThan, maxAttempts, processingError)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
 func (r *ItemInngestRepo) UpsertEmbedding(ctx context.Context, itemID, model string, embedding []float64) error {
 	if len(embedding) == 0 {
 		return nil
@@ -280,6 +502,33 @@ func (r *ItemInngestRepo) ListEmbeddingBackfillTargets(ctx context.Context, user
 }
 
 func (r *ItemInngestRepo) ListSummarizedForUser(ctx context.Context, userID string, since, until time.Time) ([]model.DigestItemDetail, error) {
+	return r.listSummarizedForDigest(ctx, userID, since, until, "")
+}
+
+// ListSummarizedForDigestConfig is ListSummarizedForUser narrowed to a digest config's scope:
+// its sources, items whose title, URL or summary match its search query, or items tagged with
+// any of its topics.
+func (r *ItemInngestRepo) ListSummarizedForDigestConfig(ctx context.Context, cfg model.DigestConfig, since, until time.Time) ([]model.DigestItemDetail, error) {
+	switch cfg.ScopeType {
+	case "sources":
+		return r.listSummarizedForDigest(ctx, cfg.UserID, since, until, ` AND i.source_id = ANY($4::uuid[])`, cfg.SourceIDs)
+	case "topics":
+		return r.listSummarizedForDigest(ctx, cfg.UserID, since, until, ` AND s.topics && $4::text[]`, cfg.Topics)
+	case "search":
+		if cfg.SearchQuery == nil || strings.TrimSpace(*cfg.SearchQuery) == "" {
+			return nil, nil
+		}
+		return r.listSummarizedForDigest(ctx, cfg.UserID, since, until, ` AND (
+			COALESCE(i.title, '') ILIKE $4
+			OR i.url ILIKE $4
+			OR COALESCE(s.translated_title, '') ILIKE $4
+			OR s.summary ILIKE $4
+		)`, "%"+strings.TrimSpace(*cfg.SearchQuery)+"%")
+	}
+	return nil, fmt.Errorf("unknown digest scope %q", cfg.ScopeType)
+}
+
+func (r *ItemInngestRepo) listSummarizedForDigest(ctx context.Context, userID string, since, until time.Time, scopeSQL string, scopeArgs ...any) ([]model.DigestItemDetail, error) {
 	rows, err := r.db.Query(ctx, `
 			SELECT i.id, i.source_id, i.url, i.title, i.thumbnail_url, i.content_text, i.status,
 			       COALESCE(fb.is_favorite, false) AS is_favorite,
@@ -298,9 +547,9 @@ func (r *ItemInngestRepo) ListSummarizedForUser(ctx context.Context, userID stri
 		  AND i.deleted_at IS NULL
 		  AND i.published_at IS NOT NULL
 		  AND i.published_at >= $2
-		  AND i.published_at < $3
+		  AND i.published_at < $3`+itemNotSnoozedSQL+itemNotFilteredSQL+itemPaywallAllowedSQL+scopeSQL+`
 		ORDER BY s.score DESC NULLS LAST, i.published_at DESC NULLS LAST`,
-		userID, since, until)
+		append([]any{userID, since, until}, scopeArgs...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +577,12 @@ func (r *ItemInngestRepo) ListSummarizedForUser(ctx context.Context, userID stri
 	for i := range items {
 		items[i].Rank = i + 1
 	}
-	return items, nil
+	exploration, err := loadReadingPlanExploration(ctx, r.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	rng := explorationRNG(userID, until.In(timeutil.JST).Format("2006-01-02"))
+	return exploreDigestItems(items, exploration, profile, rng), nil
 }
 
 func (r *ItemInngestRepo) ListTranslatedTitleBackfillTargets(ctx context.Context, userID *string, limit int) ([]ItemTranslatedTitleBackfillTarget, error) {
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -22,6 +23,7 @@ func testItemInngestRepoDB(t *testing.T) *pgxpool.Pool {
 		ALTER TABLE items ADD COLUMN IF NOT EXISTS user_other_genre_label text;
 		ALTER TABLE item_summaries ADD COLUMN IF NOT EXISTS genre text;
 		ALTER TABLE item_summaries ADD COLUMN IF NOT EXISTS other_genre_label text;
+		ALTER TABLE sources ADD COLUMN IF NOT EXISTS default_topics text[] NOT NULL DEFAULT '{}';
 
 		DELETE FROM item_summaries WHERE item_id = '00000000-0000-4000-8000-000000000241';
 		DELETE FROM items WHERE id = '00000000-0000-4000-8000-000000000241';
@@ -71,6 +73,7 @@ func TestItemInngestRepoInsertSummaryPersistsOtherGenreLabel(t *testing.T) {
 		[]string{"ai"},
 		testItemInngestRepoStringPtr("other"),
 		testItemInngestRepoStringPtr("Observability"),
+		testItemInngestRepoStringPtr("deep_dive"),
 		"Translated",
 		0.9,
 		nil,
@@ -97,3 +100,49 @@ func TestItemInngestRepoInsertSummaryPersistsOtherGenreLabel(t *testing.T) {
 		t.Fatalf("other_genre_label = %#v, want Observability", otherLabel)
 	}
 }
+
+func TestItemInngestRepoMarkBudgetDeferredCoversRetriedFactsExtractedItems(t *testing.T) {
+	ctx := context.Background()
+	pool := testItemInngestRepoDB(t)
+	repo := NewItemInngestRepo(pool)
+	const itemID = "00000000-0000-4000-8000-000000000241"
+
+	// The manual retry flow re-enqueues facts_extracted items, so the budget guard must be able
+	// to park them where the resume cron looks.
+	deferred, err := repo.MarkBudgetDeferred(ctx, itemID)
+	if err != nil {
+		t.Fatalf("MarkBudgetDeferred() error = %v", err)
+	}
+	if !deferred {
+		t.Fatal("MarkBudgetDeferred() = false, want true for a facts_extracted item")
+	}
+	var status string
+	if err := pool.QueryRow(ctx, `SELECT status FROM items WHERE id = $1`, itemID).Scan(&status); err != nil {
+		t.Fatalf("query item status: %v", err)
+	}
+	if status != "budget_deferred" {
+		t.Fatalf("status = %q, want budget_deferred", status)
+	}
+
+	if _, err := pool.Exec(ctx, `UPDATE items SET status = 'summarized' WHERE id = $1`, itemID); err != nil {
+		t.Fatalf("mark item summarized: %v", err)
+	}
+	deferred, err = repo.MarkBudgetDeferred(ctx, itemID)
+	if err != nil {
+		t.Fatalf("MarkBudgetDeferred() on summarized item error = %v", err)
+	}
+	if deferred {
+		t.Fatal("MarkBudgetDeferred() = true, want false for a summarized item")
+	}
+}
+
+func TestMergeSourceDefaultTopics(t *testing.T) {
+	got := mergeSourceDefaultTopics([]string{"Security", " security "}, []string{"technology", "SECURITY", "malware"})
+	want := []string{"Security", "technology", "malware"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("merge = %v, want %v", got, want)
+	}
+	if got := mergeSourceDefaultTopics(nil, []string{"ai"}); !reflect.DeepEqual(got, []string{"ai"}) {
+		t.Fatalf("merge without defaults = %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ItemBacklogTarget is an item that stopped at 'fetched' while the owner paused processing.
+type ItemBacklogTarget struct {
+	ItemID    string
+	SourceID  string
+	URL       string
+	Title     *string
+	CreatedAt time.Time
+}
+
+// ItemBacklogCursor is the last backlog item a backfill page queued; the next page starts
+// after it.
+type ItemBacklogCursor struct {
+	CreatedAt time.Time
+	ItemID    string
+}
+
+// itemOwnerNotPausedSQL drops items whose owner paused processing; those wait in 'fetched' on
+// purpose and must not count as stuck. alias names the items table in the outer query.
+func itemOwnerNotPausedSQL(alias string) string {
+	return ` AND NOT EXISTS (
+			SELECT 1
+			FROM sources pss
+			JOIN user_settings pus ON pus.user_id = pss.user_id
+			WHERE pss.id = ` + alias + `.source_id
+			  AND pus.processing_paused_at IS NOT NULL
+		)`
+}
+
+// userNotPausedSQL drops rows whose user paused processing, so scheduled retries do not spend
+// LLM budget for them. column is the outer query's user id expression.
+func userNotPausedSQL(column string) string {
+	return ` AND NOT EXISTS (
+			SELECT 1
+			FROM user_settings pus
+			WHERE pus.user_id = ` + column + `
+			  AND pus.processing_paused_at IS NOT NULL
+		)`
+}
+
+// CountProcessingBacklog counts the user's live items waiting in 'fetched'.
+func (r *ItemRepo) CountProcessingBacklog(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM items i
+		JOIN sources src ON src.id = i.source_id
+		WHERE src.user_id = $1
+		  AND i.status = 'fetched'
+		  AND i.deleted_at IS NULL`,
+		userID,
+	).Scan(&n)
+	return n, err
+}
+
+// ClaimProcessingBacklog returns up to limit of the user's items waiting in 'fetched' after
+// the cursor, oldest first, and bumps their updated_at so the stuck item reprocessing leaves
+// them to the caller. Pages are keyed on (created_at, id), so each page of one backfill picks
+// up where the previous one stopped even while earlier items still wait in 'fetched'.
+func (r *ItemRepo) ClaimProcessingBacklog(ctx context.Context, userID string, after *ItemBacklogCursor, limit int) ([]ItemBacklogTarget, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var afterCreatedAt *time.Time
+	var afterID *string
+	if after != nil {
+		afterCreatedAt, afterID = &after.CreatedAt, &after.ItemID
+	}
+	rows, err := r.db.Query(ctx, `
+		WITH picked AS (
+			SELECT i2.id, i2.created_at
+			FROM items i2
+			JOIN sources src ON src.id = i2.source_id
+			WHERE src.user_id = $1
+			  AND i2.status = 'fetched'
+			  AND i2.deleted_at IS NULL
+			  AND ($3::timestamptz IS NULL OR (i2.created_at, i2.id) > ($3::timestamptz, $4::uuid))
+			ORDER BY i2.created_at ASC, i2.id ASC
+			LIMIT $2
+			FOR UPDATE OF i2 SKIP LOCKED
+		), claimed AS (
+			UPDATE items i
+			SET updated_at = NOW()
+			FROM picked
+			WHERE i.id = picked.id
+			RETURNING i.id, i.source_id, i.url, i.title, picked.created_at
+		)
+		SELECT id, source_id, url, title, created_at
+		FROM claimed
+		ORDER BY created_at ASC, id ASC`,
+		userID, limit, afterCreatedAt, afterID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ItemBacklogTarget
+	for rows.Next() {
+		var v ItemBacklogTarget
+		if err := rows.Scan(&v.ItemID, &v.SourceID, &v.URL, &v.Title, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
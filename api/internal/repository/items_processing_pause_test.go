@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestItemOwnerNotPausedSQLUsesAlias(t *testing.T) {
+	got := itemOwnerNotPausedSQL("i2")
+	for _, want := range []string{"WHERE pss.id = i2.source_id", "pus.processing_paused_at IS NOT NULL", "AND NOT EXISTS"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("itemOwnerNotPausedSQL missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
 )
 
 const (
@@ -17,11 +18,15 @@ const (
 )
 
 type ReadingPlanParams struct {
-	Window          string // 24h | today_jst | 7d
-	Size            int
-	DiversifyTopics bool
-	ExcludeRead     bool
-	ExcludeLater    bool
+	Window            string // 24h | today_jst | 7d
+	Size              int
+	DiversifyTopics   bool
+	ExcludeRead       bool
+	ExcludeLater      bool
+	PrioritizeSnoozed bool    // place resurfaced snoozed items right after pinned ones
+	Exploration       float64 // 0-1 chance that a slot goes to a low-affinity or novel-topic item
+	Depth             string  // only items of this depth when set
+	AvailableMinutes  int     // fit the plan into this much reading time when > 0
 }
 
 type briefingNavigatorCandidateWindow struct {
@@ -35,6 +40,23 @@ var briefingNavigatorCandidateWindows = []briefingNavigatorCandidateWindow{
 	{minAge: 12 * time.Hour, maxAge: 24 * time.Hour},
 }
 
+const readingPlanItemSelectSQL = `
+		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, NULL::text AS content_text, i.status, i.processing_error,
+		       fc.final_result AS facts_check_result,
+		       sfc.final_result AS faithfulness_result,
+		       (ir.item_id IS NOT NULL) AS is_read,
+		       COALESCE(fb.is_favorite, false) AS is_favorite,
+		       COALESCE(fb.rating, 0) AS feedback_rating,
+		       sm.score, sm.score_breakdown, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title, sm.depth, i.paywalled,
+		       i.published_at, i.fetched_at, i.created_at, i.updated_at
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
+		LEFT JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_facts_checks fc ON fc.item_id = i.id
+		LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id`
+
 const briefingEffectiveTimeSQL = "COALESCE(i.fetched_at, i.created_at, i.published_at, i.created_at)"
 
 func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlanParams) (*model.ReadingPlanResponse, error) {
@@ -47,6 +69,11 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 	if p.Window == "" {
 		p.Window = "24h"
 	}
+	p.Exploration = min(max(p.Exploration, 0), 1)
+	if !model.IsItemDepth(p.Depth) {
+		p.Depth = ""
+	}
+	p.AvailableMinutes = max(p.AvailableMinutes, 0)
 	candidateLimit := readingPlanCandidateLimit24h
 	filterSQL := ``
 	switch p.Window {
@@ -68,6 +95,12 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 			WHERE il.item_id = i.id AND il.user_id = $1
 		)`
 	}
+	filterSQL += itemNotSnoozedSQL + itemNotFilteredSQL + itemPaywallAllowedSQL
+	args := []any{userID}
+	if p.Depth != "" {
+		args = append(args, p.Depth)
+		filterSQL += itemDepthSQL(len(args))
+	}
 
 	var poolCount int
 	if err := r.db.QueryRow(ctx, `
@@ -77,31 +110,16 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
 		WHERE s.user_id = $1
 		  AND i.deleted_at IS NULL
-		  AND i.status = 'summarized'`+filterSQL, userID).Scan(&poolCount); err != nil {
+		  AND i.status = 'summarized'`+filterSQL, args...).Scan(&poolCount); err != nil {
 		return nil, err
 	}
 
-	rows, err := r.db.Query(ctx, `
-		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, NULL::text AS content_text, i.status, i.processing_error,
-		       fc.final_result AS facts_check_result,
-		       sfc.final_result AS faithfulness_result,
-		       (ir.item_id IS NOT NULL) AS is_read,
-		       COALESCE(fb.is_favorite, false) AS is_favorite,
-		       COALESCE(fb.rating, 0) AS feedback_rating,
-		       sm.score, sm.score_breakdown, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title,
-		       i.published_at, i.fetched_at, i.created_at, i.updated_at
-		FROM items i
-		JOIN sources s ON s.id = i.source_id
-		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
-		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
-		LEFT JOIN item_summaries sm ON sm.item_id = i.id
-		LEFT JOIN item_facts_checks fc ON fc.item_id = i.id
-		LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id
+	rows, err := r.db.Query(ctx, readingPlanItemSelectSQL+`
 		WHERE s.user_id = $1
 		  AND i.deleted_at IS NULL
 		  AND i.status = 'summarized'`+filterSQL+`
 		ORDER BY sm.score DESC NULLS LAST, i.created_at DESC
-		LIMIT $2`, userID, candidateLimit)
+		LIMIT $`+itoa(len(args)+1), append(args, candidateLimit)...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,30 +129,66 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 		return nil, err
 	}
 
+	overrides, err := r.loadReadingPlanOverrides(ctx, userID, timeutil.StartOfDayJST(timeutil.NowJST()))
+	if err != nil {
+		return nil, err
+	}
+	candidates = applyReadingPlanOverrides(candidates, overrides)
+	pinned, err := r.readingPlanPinnedItems(ctx, userID, overrides.pinnedIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.annotateResurfacedSnoozes(ctx, userID, candidates); err != nil {
+		return nil, err
+	}
+	if err := r.annotateResurfacedSnoozes(ctx, userID, pinned); err != nil {
+		return nil, err
+	}
+	if p.PrioritizeSnoozed {
+		var resurfaced []model.Item
+		resurfaced, candidates = splitResurfacedSnoozes(candidates, p.Size/2)
+		pinned = append(pinned, resurfaced...)
+	}
+
 	prefRepo := NewPreferenceProfileRepo(r.db)
 	prefProfile, _ := prefRepo.GetProfile(ctx, userID)
 
-	candidateIDs := make([]string, 0, len(candidates))
+	candidateIDs := make([]string, 0, len(candidates)+len(pinned))
 	for _, it := range candidates {
 		candidateIDs = append(candidateIDs, it.ID)
 	}
-	candidateEmbByItemID, err := loadItemEmbeddingsByID(ctx, r.db, candidateIDs)
+	for _, it := range pinned {
+		candidateIDs = append(candidateIDs, it.ID)
+	}
+	embeddings, err := loadEmbeddingRegistry(ctx, r.db, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	space := embeddings.dominantSpace(profileEmbeddingSpace(prefProfile))
+	candidateEmbByItemID := embeddings.vectors(space)
+	prefProfile = profileInSpace(prefProfile, space)
+	socialBoosts, err := loadSocialBoosts(ctx, r.db, userID, candidateIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := range candidates {
-		input := PersonalScoreInput{
-			SummaryScore:   candidates[i].SummaryScore,
-			ScoreBreakdown: candidates[i].SummaryScoreBreakdown,
-			Topics:         candidates[i].SummaryTopics,
-			Embedding:      candidateEmbByItemID[candidates[i].ID],
-			SourceID:       candidates[i].SourceID,
+	scoreItems := func(items []model.Item) {
+		for i := range items {
+			input := PersonalScoreInput{
+				SummaryScore:   items[i].SummaryScore,
+				ScoreBreakdown: items[i].SummaryScoreBreakdown,
+				Topics:         items[i].SummaryTopics,
+				Embedding:      candidateEmbByItemID[items[i].ID],
+				SourceID:       items[i].SourceID,
+			}
+			score, reason := CalcPersonalScore(input, prefProfile)
+			score = clamp01(score + socialBoosts[items[i].ID])
+			items[i].PersonalScore = &score
+			items[i].PersonalScoreReason = &reason
 		}
-		score, reason := CalcPersonalScore(input, prefProfile)
-		candidates[i].PersonalScore = &score
-		candidates[i].PersonalScoreReason = &reason
 	}
+	scoreItems(candidates)
+	scoreItems(pinned)
 
 	sort.SliceStable(candidates, func(i, j int) bool {
 		si, sj := 0.0, 0.0
@@ -150,9 +204,18 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
 	})
 
-	selected := selectItemsByMMR(candidates, p.Size, p.DiversifyTopics, candidateEmbByItemID)
+	selected := selectItemsByMMRWithPinned(pinned, candidates, p.Size, p.DiversifyTopics, candidateEmbByItemID)
+	rng := explorationRNG(userID, timeutil.NowJST().Format("2006-01-02"))
+	selected = exploreReadingPlan(selected, candidates, len(pinned), p.Exploration, prefProfile, rng)
+	selected = fitReadingPlanToTime(selected, candidates, p.AvailableMinutes, p.Size)
 	for i := range selected {
-		if selected[i].PersonalScoreReason != nil && *selected[i].PersonalScoreReason != "attention" {
+		if selected[i].PlanPinned {
+			reason := "pinned"
+			selected[i].RecommendationReason = &reason
+		} else if selected[i].Exploration {
+			reason := readingPlanExplorationReason
+			selected[i].RecommendationReason = &reason
+		} else if selected[i].PersonalScoreReason != nil && *selected[i].PersonalScoreReason != "attention" {
 			selected[i].RecommendationReason = selected[i].PersonalScoreReason
 		} else {
 			reason := itemRecommendationReason(selected[i], nil)
@@ -167,24 +230,68 @@ func (r *ItemRepo) ReadingPlan(ctx context.Context, userID string, p ReadingPlan
 	for _, it := range selected {
 		selectedIDs = append(selectedIDs, it.ID)
 	}
-	clusters, err := r.readingPlanClustersByEmbeddings(ctx, candidates, selectedIDs)
+	clusterPool := append(append(make([]model.Item, 0, len(pinned)+len(candidates)), pinned...), candidates...)
+	clusters, err := r.readingPlanClustersByEmbeddings(ctx, clusterPool, selectedIDs)
 	if err != nil {
 		return nil, err
 	}
+	storyByCluster, err := NewStoryRepo(r.db).matchClusters(ctx, userID, clusters, embeddings)
+	if err != nil {
+		return nil, err
+	}
+	attachClusterStories(clusters, storyByCluster)
 
 	return &model.ReadingPlanResponse{
-		Items:           selected,
-		Window:          p.Window,
-		Size:            p.Size,
-		DiversifyTopics: p.DiversifyTopics,
-		ExcludeRead:     p.ExcludeRead,
-		SourcePoolCount: poolCount,
-		Topics:          topics,
-		Clusters:        clusters,
+		Items:             selected,
+		Window:            p.Window,
+		Size:              p.Size,
+		DiversifyTopics:   p.DiversifyTopics,
+		ExcludeRead:       p.ExcludeRead,
+		Exploration:       p.Exploration,
+		Depth:             p.Depth,
+		AvailableMinutes:  p.AvailableMinutes,
+		SourcePoolCount:   poolCount,
+		Topics:            topics,
+		Clusters:          clusters,
+		EmbeddingCoverage: embeddings.coverage(space, len(candidateIDs)),
 	}, nil
 }
 
+// readingPlanPinnedItems loads pinned items regardless of the plan window or read filters,
+// preserving pin order.
+func (r *ItemRepo) readingPlanPinnedItems(ctx context.Context, userID string, itemIDs []string) ([]model.Item, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(ctx, readingPlanItemSelectSQL+`
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.id = ANY($2::uuid[])`, userID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items, err := scanItemsWithBreakdown(rows)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]model.Item, len(items))
+	for _, it := range items {
+		it.PlanPinned = true
+		byID[it.ID] = it
+	}
+	out := make([]model.Item, 0, len(items))
+	for _, id := range itemIDs {
+		if it, ok := byID[id]; ok {
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
 func (r *ItemRepo) ClusterItemsByEmbeddings(ctx context.Context, items []model.Item) ([]model.ReadingPlanCluster, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryHeavy)
+	defer cancel()
 	return r.readingPlanClustersByEmbeddings(ctx, items, nil)
 }
 
@@ -249,37 +356,28 @@ func (r *ItemRepo) readingPlanTopics(ctx context.Context, userID string, p Readi
 	filterSQL := ``
 	switch p.Window {
 	case "today_jst":
-		filterSQL = ` AND (COALESCE(i.published_at, i.created_at) AT TIME ZONE 'Asia/Tokyo')::date = (NOW() AT TIME ZONE 'Asia/Tokyo')::date`
+		// Range form of "published on the current JST date" so the index can be used.
+		filterSQL = ` AND x.published_at >= (date_trunc('day', NOW() AT TIME ZONE 'Asia/Tokyo') AT TIME ZONE 'Asia/Tokyo')` +
+			` AND x.published_at < ((date_trunc('day', NOW() AT TIME ZONE 'Asia/Tokyo') + INTERVAL '1 day') AT TIME ZONE 'Asia/Tokyo')`
 	case "7d":
-		filterSQL = ` AND COALESCE(i.published_at, i.created_at) >= NOW() - INTERVAL '7 days'`
+		filterSQL = ` AND x.published_at >= NOW() - INTERVAL '7 days'`
 	default:
-		filterSQL = ` AND ` + briefingEffectiveTimeSQL + ` >= NOW() - INTERVAL '24 hours'`
+		filterSQL = ` AND x.effective_at >= NOW() - INTERVAL '24 hours'`
 	}
 	if p.ExcludeRead {
 		filterSQL += ` AND ir.item_id IS NULL`
 	}
 
 	rows, err := r.db.Query(ctx, `
-		WITH base AS (
-			SELECT COALESCE(NULLIF(BTRIM(t.topic), ''), '__untagged__') AS topic_key, sm.score
-			FROM items i
-			JOIN sources s ON s.id = i.source_id
-			LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
-			JOIN item_summaries sm ON sm.item_id = i.id
-			CROSS JOIN LATERAL unnest(
-				CASE
-					WHEN COALESCE(array_length(sm.topics, 1), 0) = 0 THEN ARRAY['__untagged__']::text[]
-					ELSE sm.topics
-				END
-			) AS t(topic)
-			WHERE s.user_id = $1
-			  AND i.deleted_at IS NULL
-			  AND i.status = 'summarized'`+filterSQL+`
-		)
-		SELECT topic_key, COUNT(*)::int, MAX(score)::double precision
-		FROM base
-		GROUP BY topic_key
-		ORDER BY COUNT(*) DESC, MAX(score) DESC NULLS LAST, topic_key ASC
+		SELECT x.topic, COUNT(*)::int, MAX(x.score)::double precision
+		FROM item_topic_index x
+		JOIN items i ON i.id = x.item_id
+		LEFT JOIN item_reads ir ON ir.item_id = x.item_id AND ir.user_id = $1
+		WHERE x.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.status = 'summarized'`+filterSQL+`
+		GROUP BY x.topic
+		ORDER BY COUNT(*) DESC, MAX(x.score) DESC NULLS LAST, x.topic ASC
 		LIMIT 12`, userID)
 	if err != nil {
 		return nil, err
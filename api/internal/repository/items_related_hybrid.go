@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	DefaultRelatedFusionK      = 60
+	relatedHybridMinSimilarity = 0.35
+)
+
+// RelatedFusionParams weights the two rankings ListRelatedHybrid fuses. K damps the lead of the
+// top ranks: the larger it is, the more a lower rank in one signal can be made up by the other.
+type RelatedFusionParams struct {
+	EmbeddingWeight float64
+	TextWeight      float64
+	K               int
+}
+
+func DefaultRelatedFusionParams() RelatedFusionParams {
+	return RelatedFusionParams{EmbeddingWeight: 1, TextWeight: 1, K: DefaultRelatedFusionK}
+}
+
+// ListRelatedHybrid ranks the same candidate pool as ListRelated twice, by embedding similarity
+// and by full-text rank against the target's title and topics, and merges the rankings with
+// reciprocal rank fusion. Keyword matches surface even when their embeddings are far apart or
+// the target has no comparable embedding.
+func (r *ItemRepo) ListRelatedHybrid(ctx context.Context, id, userID string, limit int, p RelatedFusionParams) ([]model.RelatedItem, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryHeavy)
+	defer cancel()
+	if limit <= 0 {
+		limit = 6
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	if p.K <= 0 {
+		p.K = DefaultRelatedFusionK
+	}
+	_, candidateLimit := relatedFetchLimits(limit)
+
+	rows, err := r.db.Query(ctx, relatedHybridSQL,
+		id, userID, limit, candidateLimit, relatedHybridMinSimilarity,
+		p.EmbeddingWeight, p.TextWeight, p.K)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.RelatedItem
+	for rows.Next() {
+		var v model.RelatedItem
+		f := &model.RelatedFusion{}
+		if err := rows.Scan(
+			&v.ID, &v.SourceID, &v.URL, &v.Title,
+			&v.Summary, &v.Topics, &v.SummaryScore,
+			&v.Similarity, &v.PublishedAt, &v.CreatedAt,
+			&f.EmbeddingRank, &f.TextRank, &f.TextScore,
+			&f.EmbeddingContribution, &f.TextContribution,
+		); err != nil {
+			return nil, err
+		}
+		f.Score = f.EmbeddingContribution + f.TextContribution
+		v.Fusion = f
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// relatedHybridSQL builds the full-text query from the lexemes of the target's titles and
+// topics OR-ed together, and the candidates' documents from their titles, summary and topics.
+// The 'simple' configuration is used because items mix languages. Each lexeme is quoted the way
+// tsquery input expects, doubling quotes and escaping backslashes; quote_literal would emit an
+// E'...' string for lexemes with a backslash, which to_tsquery rejects.
+const relatedHybridSQL = `
+		WITH target AS (
+			SELECT ie.embedding AS emb, ie.model AS model, ie.dimensions AS dims,
+			       (
+			         SELECT to_tsquery('simple', string_agg('''' || replace(replace(lexeme, '\', '\\'), '''', '''''') || '''', ' | '))
+			         FROM unnest(tsvector_to_array(to_tsvector('simple',
+			           COALESCE(ti.title, '') || ' ' ||
+			           COALESCE(tsm.translated_title, '') || ' ' ||
+			           array_to_string(COALESCE(tsm.topics, '{}'::text[]), ' ')
+			         ))) AS lexeme
+			       ) AS query
+			FROM items ti
+			JOIN sources ts ON ts.id = ti.source_id
+			LEFT JOIN item_summaries tsm ON tsm.item_id = ti.id
+			LEFT JOIN item_embeddings ie ON ie.item_id = ti.id
+			WHERE ti.id = $1
+			  AND ts.user_id = $2
+		), candidate_items AS (` + relatedCandidateItemsSQL + `
+			LIMIT $4
+		), docs AS (
+			SELECT ci.id, ci.effective_published_at,
+			       (
+			         SELECT SUM(tv * cv)
+			         FROM unnest(t.emb) WITH ORDINALITY AS tval(tv, idx)
+			         JOIN unnest(ie.embedding) WITH ORDINALITY AS cval(cv, idx) USING (idx)
+			       )::double precision AS similarity,
+			       COALESCE(ts_rank_cd(
+			         to_tsvector('simple',
+			           COALESCE(i.title, '') || ' ' ||
+			           COALESCE(sm.translated_title, '') || ' ' ||
+			           COALESCE(sm.summary, '') || ' ' ||
+			           array_to_string(COALESCE(sm.topics, '{}'::text[]), ' ')
+			         ),
+			         t.query
+			       ), 0)::double precision AS text_score
+			FROM target t
+			JOIN candidate_items ci ON true
+			JOIN items i ON i.id = ci.id
+			LEFT JOIN item_summaries sm ON sm.item_id = ci.id
+			LEFT JOIN item_embeddings ie
+			       ON ie.item_id = ci.id
+			      AND ie.model = t.model
+			      AND ie.dimensions = t.dims
+		), ranked AS (
+			SELECT docs.*,
+			       CASE WHEN similarity >= $5
+			         THEN ROW_NUMBER() OVER (ORDER BY similarity DESC NULLS LAST, effective_published_at DESC)
+			       END AS embedding_rank,
+			       CASE WHEN text_score > 0
+			         THEN ROW_NUMBER() OVER (ORDER BY text_score DESC, effective_published_at DESC)
+			       END AS text_rank
+			FROM docs
+		), fused AS (
+			SELECT ranked.*,
+			       COALESCE($6::double precision / ($8::int + embedding_rank), 0)::double precision AS embedding_contribution,
+			       COALESCE($7::double precision / ($8::int + text_rank), 0)::double precision AS text_contribution
+			FROM ranked
+			WHERE embedding_rank IS NOT NULL OR text_rank IS NOT NULL
+		)
+		SELECT i.id, i.source_id, i.url, i.title,
+		       sm.summary, COALESCE(sm.topics, '{}'::text[]), sm.score,
+		       COALESCE(f.similarity, 0)::double precision, i.published_at, i.created_at,
+		       f.embedding_rank::int, f.text_rank::int, f.text_score,
+		       f.embedding_contribution, f.text_contribution
+		FROM fused f
+		JOIN items i ON i.id = f.id
+		LEFT JOIN item_summaries sm ON sm.item_id = i.id
+		WHERE f.embedding_contribution + f.text_contribution > 0
+		ORDER BY f.embedding_contribution + f.text_contribution DESC,
+		         f.similarity DESC NULLS LAST,
+		         f.effective_published_at DESC
+		LIMIT $3`
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"testing"
+)
+
+const (
+	relatedHybridTestUserID   = "00000000-0000-4000-8000-000000000281"
+	relatedHybridTestSourceID = "00000000-0000-4000-8000-000000000282"
+	relatedHybridTestTargetID = "00000000-0000-4000-8000-000000000283"
+	relatedHybridTestMatchID  = "00000000-0000-4000-8000-000000000284"
+	relatedHybridTestOtherID  = "00000000-0000-4000-8000-000000000285"
+)
+
+func TestListRelatedHybridQuotesLexemesWithQuotesAndBackslashes(t *testing.T) {
+	ctx := context.Background()
+	pool, err := NewPool(ctx)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	t.Cleanup(pool.Close)
+	reset := func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, relatedHybridTestUserID)
+	}
+	reset()
+	t.Cleanup(reset)
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO users (id, email, name)
+		VALUES ('`+relatedHybridTestUserID+`', 'related-hybrid-repo@example.com', 'Related Hybrid Repo');
+		INSERT INTO sources (id, user_id, url, type, title)
+		VALUES ('`+relatedHybridTestSourceID+`', '`+relatedHybridTestUserID+`', 'https://example.com/related-feed', 'manual', 'Related Feed');
+		INSERT INTO items (id, source_id, url, title, status) VALUES
+		  ('`+relatedHybridTestTargetID+`', '`+relatedHybridTestSourceID+`', 'https://example.com/related/1', 'Kernel scheduler at https://example.com/o''brien\path', 'summarized'),
+		  ('`+relatedHybridTestMatchID+`', '`+relatedHybridTestSourceID+`', 'https://example.com/related/2', 'A new kernel scheduler lands', 'summarized'),
+		  ('`+relatedHybridTestOtherID+`', '`+relatedHybridTestSourceID+`', 'https://example.com/related/3', 'Gardening tips for spring', 'summarized');
+	`); err != nil {
+		t.Fatalf("seed related hybrid items: %v", err)
+	}
+
+	got, err := NewItemRepo(pool).ListRelatedHybrid(ctx, relatedHybridTestTargetID, relatedHybridTestUserID, 6, DefaultRelatedFusionParams())
+	if err != nil {
+		t.Fatalf("ListRelatedHybrid() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != relatedHybridTestMatchID {
+		t.Fatalf("related = %+v, want only the keyword match", got)
+	}
+	if f := got[0].Fusion; f == nil || f.TextRank == nil || *f.TextRank != 1 || f.EmbeddingRank != nil || f.TextContribution <= 0 {
+		t.Fatalf("fusion = %+v, want a text-only rank 1", f)
+	}
+}
@@ -11,6 +11,9 @@ import (
 type retryCandidate struct {
 	item      model.Item
 	isDeleted bool
+	// reusable marks a summary whose inputs were recorded; the retry keeps it so process-item
+	// can return it unchanged when the content and model settings still hash the same.
+	reusable bool
 }
 
 func (r *ItemRepo) GetForRetry(ctx context.Context, id, userID string) (*model.Item, error) {
@@ -34,20 +37,10 @@ func (r *ItemRepo) ResetForExtractRetry(ctx context.Context, id, userID string)
 	}
 	it := candidate.item
 
-	if _, err := tx.Exec(ctx, `DELETE FROM item_embeddings WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM summary_faithfulness_checks WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_summaries WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_facts_checks WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_facts WHERE item_id = $1`, id); err != nil {
-		return nil, err
+	if !candidate.reusable {
+		if err := deleteItemDerived(ctx, tx, id); err != nil {
+			return nil, err
+		}
 	}
 	if _, err := tx.Exec(ctx, `
 		UPDATE items
@@ -55,6 +48,8 @@ func (r *ItemRepo) ResetForExtractRetry(ctx context.Context, id, userID string)
 		    content_text = NULL,
 		    fetched_at = NULL,
 		    processing_error = NULL,
+		    auto_reprocess_count = 0,
+		    prescreen_skipped = false,
 		    updated_at = NOW()
 		WHERE id = $1`, id); err != nil {
 		return nil, err
@@ -85,20 +80,10 @@ func (r *ItemRepo) ResetForFactsRetry(ctx context.Context, id, userID string) (*
 		return nil, ErrConflict
 	}
 
-	if _, err := tx.Exec(ctx, `DELETE FROM item_embeddings WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM summary_faithfulness_checks WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_summaries WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_facts_checks WHERE item_id = $1`, id); err != nil {
-		return nil, err
-	}
-	if _, err := tx.Exec(ctx, `DELETE FROM item_facts WHERE item_id = $1`, id); err != nil {
-		return nil, err
+	if !candidate.reusable {
+		if err := deleteItemDerived(ctx, tx, id); err != nil {
+			return nil, err
+		}
 	}
 	if _, err := tx.Exec(ctx, `
 		UPDATE items
@@ -117,6 +102,16 @@ func (r *ItemRepo) ResetForFactsRetry(ctx context.Context, id, userID string) (*
 	return &it, nil
 }
 
+// deleteItemDerived removes everything process-item derives from an item's content.
+func deleteItemDerived(ctx context.Context, tx pgx.Tx, id string) error {
+	for _, table := range []string{"item_embeddings", "summary_faithfulness_checks", "item_summaries", "item_facts_checks", "item_facts"} {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE item_id = $1`, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type retryCandidateQuerier interface {
 	QueryRow(context.Context, string, ...any) pgx.Row
 }
@@ -125,6 +120,7 @@ func loadRetryCandidateQuery(forUpdate bool) string {
 	query := `
 		SELECT i.id, i.source_id, i.url, i.title, i.thumbnail_url, i.content_text, sm.summary, i.status,
 		       i.deleted_at IS NOT NULL AS is_deleted,
+		       i.summary_input_hash IS NOT NULL AND sm.item_id IS NOT NULL AS reusable,
 		       FALSE AS is_read,
 		       FALSE AS is_favorite,
 		       0 AS feedback_rating,
@@ -152,6 +148,7 @@ func (r *ItemRepo) loadRetryCandidate(ctx context.Context, q retryCandidateQueri
 		&candidate.item.Summary,
 		&candidate.item.Status,
 		&candidate.isDeleted,
+		&candidate.reusable,
 		&candidate.item.IsRead,
 		&candidate.item.IsFavorite,
 		&candidate.item.FeedbackRating,
@@ -131,6 +131,8 @@ func TestScanItemsWithBreakdownIncludesSourceTitle(t *testing.T) {
 			"topic:ai",
 			[]string{"ai"},
 			"Translated",
+			"deep_dive",
+			true,
 			now,
 			now,
 			now,
@@ -148,6 +150,12 @@ func TestScanItemsWithBreakdownIncludesSourceTitle(t *testing.T) {
 	if items[0].SourceTitle == nil || *items[0].SourceTitle != "Source One" {
 		t.Fatalf("source title = %v, want Source One", items[0].SourceTitle)
 	}
+	if items[0].SummaryDepth == nil || *items[0].SummaryDepth != "deep_dive" {
+		t.Fatalf("summary depth = %v, want deep_dive", items[0].SummaryDepth)
+	}
+	if !items[0].Paywalled {
+		t.Fatalf("paywalled = false, want true")
+	}
 }
 
 func ptrFloat64(v float64) *float64 {
@@ -23,6 +23,8 @@ type topicPulseRow struct {
 }
 
 func (r *ItemRepo) TopicTrends(ctx context.Context, userID string, limit int) ([]model.TopicTrend, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryAnalytics)
+	defer cancel()
 	if limit <= 0 {
 		limit = 10
 	}
@@ -31,22 +33,15 @@ func (r *ItemRepo) TopicTrends(ctx context.Context, userID string, limit int) ([
 	}
 	rows, err := r.db.Query(ctx, `
 		WITH base AS (
-			SELECT COALESCE(NULLIF(BTRIM(t.topic), ''), '__untagged__') AS topic_key,
-			       COALESCE(sm.score, 0)::double precision AS score,
-			       COALESCE(i.published_at, i.created_at) AS ts
-			FROM items i
-			JOIN sources s ON s.id = i.source_id
-			JOIN item_summaries sm ON sm.item_id = i.id
-			CROSS JOIN LATERAL unnest(
-				CASE
-					WHEN COALESCE(array_length(sm.topics, 1), 0) = 0 THEN ARRAY['__untagged__']::text[]
-					ELSE sm.topics
-				END
-			) AS t(topic)
-			WHERE s.user_id = $1
+			SELECT x.topic AS topic_key,
+			       COALESCE(x.score, 0)::double precision AS score,
+			       x.published_at AS ts
+			FROM item_topic_index x
+			JOIN items i ON i.id = x.item_id
+			WHERE x.user_id = $1
+			  AND x.published_at >= NOW() - INTERVAL '48 hours'
 			  AND i.deleted_at IS NULL
 			  AND i.status = 'summarized'
-			  AND COALESCE(i.published_at, i.created_at) >= NOW() - INTERVAL '48 hours'
 		)
 		SELECT topic_key,
 		       COUNT(*) FILTER (WHERE ts >= NOW() - INTERVAL '24 hours')::int AS count_24h,
@@ -359,47 +354,58 @@ func (r *ItemRepo) PositiveFeedbackTopics(ctx context.Context, userID string, li
 	return out, rows.Err()
 }
 
-func (r *ItemRepo) ListRelated(ctx context.Context, id, userID string, limit int) ([]model.RelatedItem, error) {
+// relatedCandidateItemsSQL selects the user's recent summarized items other than $1, the
+// pool ListRelated compares against. Callers append the LIMIT.
+const relatedCandidateItemsSQL = `
+			SELECT i.id, i.source_id, COALESCE(i.published_at, i.created_at) AS effective_published_at
+			FROM items i
+			JOIN sources s ON s.id = i.source_id
+			LEFT JOIN item_summaries sm ON sm.item_id = i.id
+			WHERE s.user_id = $2
+			  AND i.deleted_at IS NULL
+			  AND i.status = 'summarized'
+			  AND i.id <> $1
+			ORDER BY COALESCE(i.published_at, i.created_at) DESC, sm.score DESC NULLS LAST`
+
+func relatedFetchLimits(limit int) (fetchLimit, candidateLimit int) {
 	if limit <= 0 {
 		limit = 6
 	}
 	if limit > 50 {
 		limit = 50
 	}
-	const minSimilarity = 0.35
-	fetchLimit := limit * 5
+	fetchLimit = limit * 5
 	if fetchLimit < 30 {
 		fetchLimit = 30
 	}
 	if fetchLimit > 120 {
 		fetchLimit = 120
 	}
-	candidateLimit := fetchLimit * 8
+	candidateLimit = fetchLimit * 8
 	if candidateLimit < 240 {
 		candidateLimit = 240
 	}
 	if candidateLimit > relatedCandidateLimitMax {
 		candidateLimit = relatedCandidateLimitMax
 	}
+	return fetchLimit, candidateLimit
+}
+
+func (r *ItemRepo) ListRelated(ctx context.Context, id, userID string, limit int) ([]model.RelatedItem, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryHeavy)
+	defer cancel()
+	const minSimilarity = 0.35
+	fetchLimit, candidateLimit := relatedFetchLimits(limit)
 
 	rows, err := r.db.Query(ctx, `
 		WITH target AS (
-			SELECT ie.embedding AS emb, ie.dimensions AS dims, ti.source_id AS target_source_id
+			SELECT ie.embedding AS emb, ie.model AS model, ie.dimensions AS dims, ti.source_id AS target_source_id
 			FROM item_embeddings ie
 			JOIN items ti ON ti.id = ie.item_id
 			JOIN sources ts ON ts.id = ti.source_id
 			WHERE ie.item_id = $1
 			  AND ts.user_id = $2
-		), candidate_items AS (
-			SELECT i.id, i.source_id, COALESCE(i.published_at, i.created_at) AS effective_published_at
-			FROM items i
-			JOIN sources s ON s.id = i.source_id
-			LEFT JOIN item_summaries sm ON sm.item_id = i.id
-			WHERE s.user_id = $2
-			  AND i.deleted_at IS NULL
-			  AND i.status = 'summarized'
-			  AND i.id <> $1
-			ORDER BY COALESCE(i.published_at, i.created_at) DESC, sm.score DESC NULLS LAST
+		), candidate_items AS (`+relatedCandidateItemsSQL+`
 			LIMIT $5
 		), scored AS (
 			SELECT i.id, i.source_id, i.url, i.title,
@@ -417,7 +423,7 @@ func (r *ItemRepo) ListRelated(ctx context.Context, id, userID string, limit int
 			       ci.effective_published_at
 			FROM target t
 			JOIN candidate_items ci ON true
-			JOIN item_embeddings ie ON ie.item_id = ci.id AND ie.dimensions = t.dims
+			JOIN item_embeddings ie ON ie.item_id = ci.id AND ie.model = t.model AND ie.dimensions = t.dims
 			JOIN items i ON i.id = ie.item_id
 			LEFT JOIN item_summaries sm ON sm.item_id = i.id
 		)
@@ -447,6 +453,43 @@ func (r *ItemRepo) ListRelated(ctx context.Context, id, userID string, limit int
 	return out, rows.Err()
 }
 
+// RelatedEmbeddingCoverage reports how much of ListRelated's candidate pool shares the
+// target item's embedding space and so could be compared with it at all.
+func (r *ItemRepo) RelatedEmbeddingCoverage(ctx context.Context, id, userID string, limit int) (*model.EmbeddingCoverage, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryHeavy)
+	defer cancel()
+	_, candidateLimit := relatedFetchLimits(limit)
+	var space embeddingSpace
+	var comparable, total int
+	err := r.db.QueryRow(ctx, relatedEmbeddingCoverageSQL, id, userID, candidateLimit).Scan(&space.model, &space.dimensions, &comparable, &total)
+	if err != nil {
+		return nil, err
+	}
+	return newEmbeddingCoverage(space, comparable, total), nil
+}
+
+const relatedEmbeddingCoverageSQL = `
+		WITH target AS (
+			SELECT ie.model, ie.dimensions
+			FROM item_embeddings ie
+			JOIN items ti ON ti.id = ie.item_id
+			JOIN sources ts ON ts.id = ti.source_id
+			WHERE ie.item_id = $1
+			  AND ts.user_id = $2
+		), candidate_items AS (` + relatedCandidateItemsSQL + `
+			LIMIT $3
+		)
+		SELECT COALESCE((SELECT model FROM target), ''),
+		       COALESCE((SELECT dimensions FROM target), 0),
+		       COUNT(ie.item_id)::int,
+		       COUNT(*)::int
+		FROM candidate_items ci
+		LEFT JOIN target t ON true
+		LEFT JOIN item_embeddings ie
+		       ON ie.item_id = ci.id
+		      AND ie.model = t.model
+		      AND ie.dimensions = t.dimensions`
+
 func (r *ItemRepo) AskCandidatesByEmbedding(
 	ctx context.Context,
 	userID string,
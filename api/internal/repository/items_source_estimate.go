@@ -0,0 +1,31 @@
+package repository
+
+import "context"
+
+// StoredContentByURL returns the extracted body of the source's items among urls, keyed by
+// URL. Items not stored yet or without a body are left out.
+func (r *ItemRepo) StoredContentByURL(ctx context.Context, sourceID string, urls []string) (map[string]string, error) {
+	out := make(map[string]string)
+	if len(urls) == 0 {
+		return out, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT url, content_text
+		FROM items
+		WHERE source_id = $1
+		  AND url = ANY($2::text[])
+		  AND deleted_at IS NULL
+		  AND COALESCE(content_text, '') <> ''`, sourceID, urls)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var url, content string
+		if err := rows.Scan(&url, &content); err != nil {
+			return nil, err
+		}
+		out[url] = content
+	}
+	return out, rows.Err()
+}
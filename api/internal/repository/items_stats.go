@@ -7,6 +7,8 @@ import (
 )
 
 func (r *ItemRepo) Stats(ctx context.Context, userID string) (*model.ItemStatsResponse, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryAnalytics)
+	defer cancel()
 	rows, err := r.db.Query(ctx, `
 		SELECT i.status,
 		       COUNT(*)::int AS total,
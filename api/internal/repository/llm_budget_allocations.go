@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LLMBudgetAllocationRepo struct{ db *pgxpool.Pool }
+
+func NewLLMBudgetAllocationRepo(db *pgxpool.Pool) *LLMBudgetAllocationRepo {
+	return &LLMBudgetAllocationRepo{db: db}
+}
+
+func (r *LLMBudgetAllocationRepo) ListByUser(ctx context.Context, userID string) ([]model.LLMBudgetAllocation, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, purpose, monthly_cap_usd, created_at, updated_at
+		FROM llm_budget_allocations
+		WHERE user_id = $1
+		ORDER BY purpose`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.LLMBudgetAllocation{}
+	for rows.Next() {
+		var v model.LLMBudgetAllocation
+		if err := rows.Scan(&v.UserID, &v.Purpose, &v.MonthlyCapUSD, &v.CreatedAt, &v.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+func (r *LLMBudgetAllocationRepo) GetByUserPurpose(ctx context.Context, userID, purpose string) (*model.LLMBudgetAllocation, error) {
+	var v model.LLMBudgetAllocation
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, purpose, monthly_cap_usd, created_at, updated_at
+		FROM llm_budget_allocations
+		WHERE user_id = $1 AND purpose = $2`, userID, purpose,
+	).Scan(&v.UserID, &v.Purpose, &v.MonthlyCapUSD, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ReplaceForUser swaps the user's whole allocation set; purposes missing from caps become uncapped.
+func (r *LLMBudgetAllocationRepo) ReplaceForUser(ctx context.Context, userID string, caps map[string]float64) ([]model.LLMBudgetAllocation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM llm_budget_allocations WHERE user_id = $1`, userID); err != nil {
+		return nil, err
+	}
+	for purpose, capUSD := range caps {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO llm_budget_allocations (user_id, purpose, monthly_cap_usd)
+			VALUES ($1, $2, $3)`, userID, purpose, capUSD); err != nil {
+			return nil, mapDBError(err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.ListByUser(ctx, userID)
+}
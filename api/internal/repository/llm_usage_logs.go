@@ -89,6 +89,20 @@ type LLMUsageModelSummary struct {
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
 }
 
+type LLMUsageSourceSummary struct {
+	SourceID              string   `json:"source_id"`
+	SourceTitle           *string  `json:"source_title,omitempty"`
+	SourceURL             string   `json:"source_url"`
+	SourceEnabled         bool     `json:"source_enabled"`
+	Calls                 int      `json:"calls"`
+	ItemsProcessed        int      `json:"items_processed"`
+	InputTokens           int64    `json:"input_tokens"`
+	OutputTokens          int64    `json:"output_tokens"`
+	EstimatedCostUSD      float64  `json:"estimated_cost_usd"`
+	AvgCostPerItemUSD     *float64 `json:"avg_cost_per_item_usd"`
+	ShareOfTotalCostRatio float64  `json:"share_of_total_cost_ratio"`
+}
+
 type LLMUsageProviderMonthSummary struct {
 	MonthJST                 string  `json:"month_jst"`
 	Provider                 string  `json:"provider"`
@@ -98,6 +112,7 @@ type LLMUsageProviderMonthSummary struct {
 	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens"`
 	CacheReadInputTokens     int64   `json:"cache_read_input_tokens"`
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
+	CacheHits                int     `json:"cache_hits"`
 }
 
 type LLMUsagePurposeMonthSummary struct {
@@ -109,6 +124,12 @@ type LLMUsagePurposeMonthSummary struct {
 	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens"`
 	CacheReadInputTokens     int64   `json:"cache_read_input_tokens"`
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
+	CacheHits                int     `json:"cache_hits"`
+}
+
+type LLMUsageDailyCost struct {
+	DateJST          string  `json:"date_jst"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
 }
 
 type LLMUsageAnalysisSummary struct {
@@ -294,6 +315,47 @@ func (r *LLMUsageLogRepo) ListOpenRouterBackfillCandidates(ctx context.Context,
 	return out, rows.Err()
 }
 
+// ListUnknownPricingCandidates returns usage rows logged without a known price whose
+// provider/model (or resolved model) now has a model_pricing entry.
+func (r *LLMUsageLogRepo) ListUnknownPricingCandidates(ctx context.Context, limit int) ([]LLMUsageLog, error) {
+	if limit <= 0 || limit > 5000 {
+		limit = 500
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT l.id, l.user_id, l.source_id, l.item_id, l.digest_id,
+		       l.provider, l.model, l.requested_model, l.resolved_model, l.pricing_model_family, l.pricing_source, l.openrouter_cost_usd, l.openrouter_generation_id, l.purpose,
+		       l.input_tokens, l.output_tokens, l.cache_creation_input_tokens, l.cache_read_input_tokens,
+		       l.estimated_cost_usd, l.created_at
+		FROM llm_usage_logs l
+		WHERE l.pricing_source = 'unknown'
+		  AND EXISTS (
+			SELECT 1 FROM model_pricing p
+			WHERE p.provider = l.provider
+			  AND (p.model = l.model OR p.model = l.resolved_model)
+		  )
+		ORDER BY l.created_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]LLMUsageLog, 0)
+	for rows.Next() {
+		var v LLMUsageLog
+		if err := rows.Scan(
+			&v.ID, &v.UserID, &v.SourceID, &v.ItemID, &v.DigestID,
+			&v.Provider, &v.Model, &v.RequestedModel, &v.ResolvedModel, &v.PricingModelFamily, &v.PricingSource, &v.OpenRouterCostUSD, &v.OpenRouterGenerationID, &v.Purpose,
+			&v.InputTokens, &v.OutputTokens, &v.CacheCreationInputTokens, &v.CacheReadInputTokens,
+			&v.EstimatedCostUSD, &v.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
 func (r *LLMUsageLogRepo) UpdateOpenRouterBackfill(ctx context.Context, id string, pricingModelFamily *string, pricingSource string, estimatedCostUSD float64) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE llm_usage_logs
@@ -305,6 +367,8 @@ func (r *LLMUsageLogRepo) UpdateOpenRouterBackfill(ctx context.Context, id strin
 }
 
 func (r *LLMUsageLogRepo) DailySummaryByUser(ctx context.Context, userID string, days int) ([]LLMUsageDailySummary, error) {
+	ctx, cancel := withQueryTimeout(ctx, QueryCategoryAnalytics)
+	defer cancel()
 	if days <= 0 || days > 365 {
 		days = 14
 	}
@@ -442,6 +506,77 @@ func (r *LLMUsageLogRepo) ModelSummaryByUser(ctx context.Context, userID string,
 	return out, rows.Err()
 }
 
+func (r *LLMUsageLogRepo) SourceSummaryByUser(ctx context.Context, userID string, days int) ([]LLMUsageSourceSummary, error) {
+	if days <= 0 || days > 365 {
+		days = 14
+	}
+	loc := time.FixedZone("JST", 9*60*60)
+	nowJST := time.Now().In(loc)
+	until := time.Date(nowJST.Year(), nowJST.Month(), nowJST.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	return r.sourceSummaryBetween(ctx, userID, until.AddDate(0, 0, -days), until)
+}
+
+func (r *LLMUsageLogRepo) SourceSummaryByUserMonth(ctx context.Context, userID string, month time.Time) ([]LLMUsageSourceSummary, error) {
+	loc := time.FixedZone("JST", 9*60*60)
+	monthJST := month.In(loc)
+	monthStart := time.Date(monthJST.Year(), monthJST.Month(), 1, 0, 0, 0, 0, loc)
+	return r.sourceSummaryBetween(ctx, userID, monthStart, monthStart.AddDate(0, 1, 0))
+}
+
+// sourceSummaryBetween attributes usage to the source of the logged item when the log row
+// itself has no source_id. Usage that cannot be tied to a source (digests, ask, ...) is excluded.
+func (r *LLMUsageLogRepo) sourceSummaryBetween(ctx context.Context, userID string, since, until time.Time) ([]LLMUsageSourceSummary, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH attributed AS (
+			SELECT COALESCE(l.source_id, i.source_id) AS source_id,
+			       l.item_id,
+			       l.input_tokens,
+			       l.output_tokens,
+			       l.estimated_cost_usd
+			FROM llm_usage_logs l
+			LEFT JOIN items i ON i.id = l.item_id
+			WHERE l.user_id = $1
+			  AND l.created_at >= $2
+			  AND l.created_at < $3
+		),
+		per_source AS (
+			SELECT a.source_id,
+			       COUNT(*)::int AS calls,
+			       COUNT(DISTINCT a.item_id)::int AS items_processed,
+			       COALESCE(SUM(a.input_tokens),0)::bigint AS input_tokens,
+			       COALESCE(SUM(a.output_tokens),0)::bigint AS output_tokens,
+			       COALESCE(SUM(a.estimated_cost_usd),0)::double precision AS estimated_cost_usd
+			FROM attributed a
+			WHERE a.source_id IS NOT NULL
+			GROUP BY a.source_id
+		)
+		SELECT s.id, s.title, s.url, s.enabled,
+		       p.calls, p.items_processed, p.input_tokens, p.output_tokens, p.estimated_cost_usd,
+		       CASE WHEN p.items_processed > 0 THEN p.estimated_cost_usd / p.items_processed END AS avg_cost_per_item_usd,
+		       COALESCE(p.estimated_cost_usd / NULLIF(SUM(p.estimated_cost_usd) OVER (), 0), 0)::double precision AS share
+		FROM per_source p
+		JOIN sources s ON s.id = p.source_id AND s.user_id = $1
+		ORDER BY p.estimated_cost_usd DESC, p.calls DESC, s.url ASC`, userID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []LLMUsageSourceSummary{}
+	for rows.Next() {
+		var v LLMUsageSourceSummary
+		if err := rows.Scan(
+			&v.SourceID, &v.SourceTitle, &v.SourceURL, &v.SourceEnabled,
+			&v.Calls, &v.ItemsProcessed, &v.InputTokens, &v.OutputTokens, &v.EstimatedCostUSD,
+			&v.AvgCostPerItemUSD, &v.ShareOfTotalCostRatio,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
 func (r *LLMUsageLogRepo) ModelSummaryByUserMonth(ctx context.Context, userID string, month time.Time) ([]LLMUsageModelSummary, error) {
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
@@ -499,6 +634,50 @@ func (r *LLMUsageLogRepo) SumEstimatedCostByUserBetween(ctx context.Context, use
 	return total, err
 }
 
+func (r *LLMUsageLogRepo) SumEstimatedCostByUserPurposesBetween(ctx context.Context, userID string, purposes []string, since, until time.Time) (float64, error) {
+	if len(purposes) == 0 {
+		return 0, nil
+	}
+	var total float64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(estimated_cost_usd), 0)::double precision
+		FROM llm_usage_logs
+		WHERE user_id = $1
+		  AND purpose = ANY($2)
+		  AND created_at >= $3
+		  AND created_at < $4`,
+		userID, purposes, since, until,
+	).Scan(&total)
+	return total, err
+}
+
+// DailyCostByUserBetween returns per-JST-day cost totals in [since, until). Days without usage are omitted.
+func (r *LLMUsageLogRepo) DailyCostByUserBetween(ctx context.Context, userID string, since, until time.Time) ([]LLMUsageDailyCost, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT (created_at AT TIME ZONE 'Asia/Tokyo')::date::text AS date_jst,
+		       COALESCE(SUM(estimated_cost_usd), 0)::double precision AS estimated_cost_usd
+		FROM llm_usage_logs
+		WHERE user_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		GROUP BY 1
+		ORDER BY 1`, userID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []LLMUsageDailyCost
+	for rows.Next() {
+		var v LLMUsageDailyCost
+		if err := rows.Scan(&v.DateJST, &v.EstimatedCostUSD); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
 func (r *LLMUsageLogRepo) ProviderSummaryCurrentMonthByUser(ctx context.Context, userID string) ([]LLMUsageProviderMonthSummary, error) {
 	return r.ProviderSummaryByUserMonth(ctx, userID, time.Now())
 }
@@ -520,7 +699,8 @@ func (r *LLMUsageLogRepo) ProviderSummaryByUserMonth(ctx context.Context, userID
 		       COALESCE(SUM(l.output_tokens),0)::bigint AS output_tokens,
 		       COALESCE(SUM(l.cache_creation_input_tokens),0)::bigint AS cache_creation_input_tokens,
 		       COALESCE(SUM(l.cache_read_input_tokens),0)::bigint AS cache_read_input_tokens,
-		       COALESCE(SUM(l.estimated_cost_usd),0)::double precision AS estimated_cost_usd
+		       COALESCE(SUM(l.estimated_cost_usd),0)::double precision AS estimated_cost_usd,
+		       COUNT(*) FILTER (WHERE l.pricing_source = 'cache')::int AS cache_hits
 		FROM llm_usage_logs l
 		WHERE l.user_id = $1
 		  AND (l.created_at AT TIME ZONE 'Asia/Tokyo') >= $3
@@ -538,7 +718,7 @@ func (r *LLMUsageLogRepo) ProviderSummaryByUserMonth(ctx context.Context, userID
 		if err := rows.Scan(
 			&v.MonthJST, &v.Provider, &v.Calls,
 			&v.InputTokens, &v.OutputTokens, &v.CacheCreationInputTokens,
-			&v.CacheReadInputTokens, &v.EstimatedCostUSD,
+			&v.CacheReadInputTokens, &v.EstimatedCostUSD, &v.CacheHits,
 		); err != nil {
 			return nil, err
 		}
@@ -568,7 +748,8 @@ func (r *LLMUsageLogRepo) PurposeSummaryByUserMonth(ctx context.Context, userID
 		       COALESCE(SUM(l.output_tokens),0)::bigint AS output_tokens,
 		       COALESCE(SUM(l.cache_creation_input_tokens),0)::bigint AS cache_creation_input_tokens,
 		       COALESCE(SUM(l.cache_read_input_tokens),0)::bigint AS cache_read_input_tokens,
-		       COALESCE(SUM(l.estimated_cost_usd),0)::double precision AS estimated_cost_usd
+		       COALESCE(SUM(l.estimated_cost_usd),0)::double precision AS estimated_cost_usd,
+		       COUNT(*) FILTER (WHERE l.pricing_source = 'cache')::int AS cache_hits
 		FROM llm_usage_logs l
 		WHERE l.user_id = $1
 		  AND (l.created_at AT TIME ZONE 'Asia/Tokyo') >= $3
@@ -586,7 +767,7 @@ func (r *LLMUsageLogRepo) PurposeSummaryByUserMonth(ctx context.Context, userID
 		if err := rows.Scan(
 			&v.MonthJST, &v.Purpose, &v.Calls,
 			&v.InputTokens, &v.OutputTokens, &v.CacheCreationInputTokens,
-			&v.CacheReadInputTokens, &v.EstimatedCostUSD,
+			&v.CacheReadInputTokens, &v.EstimatedCostUSD, &v.CacheHits,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MetricsDailyRepo struct{ db *pgxpool.Pool }
+
+func NewMetricsDailyRepo(db *pgxpool.Pool) *MetricsDailyRepo { return &MetricsDailyRepo{db} }
+
+func (r *MetricsDailyRepo) IsEmpty(ctx context.Context) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM metrics_daily)`).Scan(&exists); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// Rebuild recomputes the per-user daily metrics for the last days JST days, today included.
+func (r *MetricsDailyRepo) Rebuild(ctx context.Context, days int) error {
+	if days <= 0 {
+		days = 2
+	}
+	cutoff := timeutil.StartOfDayJST(timeutil.NowJST()).AddDate(0, 0, -(days - 1))
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM metrics_daily WHERE day_jst >= $1::date`, cutoff.Format("2006-01-02")); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		WITH ingested AS (
+			SELECT s.user_id,
+			       (i.created_at AT TIME ZONE 'Asia/Tokyo')::date AS day_jst,
+			       COUNT(*)::int AS n
+			FROM items i
+			JOIN sources s ON s.id = i.source_id
+			WHERE i.created_at >= $1
+			GROUP BY 1, 2
+		),
+		summarized AS (
+			SELECT s.user_id,
+			       (sm.summarized_at AT TIME ZONE 'Asia/Tokyo')::date AS day_jst,
+			       COUNT(*)::int AS n
+			FROM item_summaries sm
+			JOIN items i ON i.id = sm.item_id
+			JOIN sources s ON s.id = i.source_id
+			WHERE sm.summarized_at >= $1
+			GROUP BY 1, 2
+		),
+		reads AS (
+			SELECT user_id,
+			       (read_at AT TIME ZONE 'Asia/Tokyo')::date AS day_jst,
+			       COUNT(*)::int AS n
+			FROM item_reads
+			WHERE read_at >= $1
+			GROUP BY 1, 2
+		),
+		llm AS (
+			SELECT user_id,
+			       (created_at AT TIME ZONE 'Asia/Tokyo')::date AS day_jst,
+			       COUNT(*)::int AS n,
+			       COALESCE(SUM(estimated_cost_usd), 0)::double precision AS cost
+			FROM llm_usage_logs
+			WHERE user_id IS NOT NULL
+			  AND created_at >= $1
+			GROUP BY 1, 2
+		),
+		digests_sent AS (
+			SELECT user_id,
+			       (sent_at AT TIME ZONE 'Asia/Tokyo')::date AS day_jst,
+			       COUNT(*)::int AS n
+			FROM digests
+			WHERE sent_at >= $1
+			GROUP BY 1, 2
+		),
+		keys AS (
+			SELECT user_id, day_jst FROM ingested
+			UNION SELECT user_id, day_jst FROM summarized
+			UNION SELECT user_id, day_jst FROM reads
+			UNION SELECT user_id, day_jst FROM llm
+			UNION SELECT user_id, day_jst FROM digests_sent
+		)
+		INSERT INTO metrics_daily (user_id, day_jst, items_ingested, items_summarized, items_read, llm_calls, llm_cost_usd, digests_sent, updated_at)
+		SELECT k.user_id,
+		       k.day_jst,
+		       COALESCE(ing.n, 0),
+		       COALESCE(sm.n, 0),
+		       COALESCE(rd.n, 0),
+		       COALESCE(llm.n, 0),
+		       COALESCE(llm.cost, 0),
+		       COALESCE(ds.n, 0),
+		       NOW()
+		FROM keys k
+		JOIN users u ON u.id = k.user_id
+		LEFT JOIN ingested ing ON ing.user_id = k.user_id AND ing.day_jst = k.day_jst
+		LEFT JOIN summarized sm ON sm.user_id = k.user_id AND sm.day_jst = k.day_jst
+		LEFT JOIN reads rd ON rd.user_id = k.user_id AND rd.day_jst = k.day_jst
+		LEFT JOIN llm ON llm.user_id = k.user_id AND llm.day_jst = k.day_jst
+		LEFT JOIN digests_sent ds ON ds.user_id = k.user_id AND ds.day_jst = k.day_jst
+		WHERE k.day_jst >= $2::date`, cutoff, cutoff.Format("2006-01-02")); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListByUser returns one row per JST day for the last days days, oldest first.
+// Days without activity are filled with zeros so the result can be charted directly.
+func (r *MetricsDailyRepo) ListByUser(ctx context.Context, userID string, days int) ([]model.MetricsDaily, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.day::date::text,
+		       COALESCE(m.items_ingested, 0),
+		       COALESCE(m.items_summarized, 0),
+		       COALESCE(m.items_read, 0),
+		       COALESCE(m.llm_calls, 0),
+		       COALESCE(m.llm_cost_usd, 0),
+		       COALESCE(m.digests_sent, 0)
+		FROM generate_series(
+			(NOW() AT TIME ZONE 'Asia/Tokyo')::date - ($2::int - 1),
+			(NOW() AT TIME ZONE 'Asia/Tokyo')::date,
+			INTERVAL '1 day'
+		) AS d(day)
+		LEFT JOIN metrics_daily m
+		  ON m.user_id = $1
+		 AND m.day_jst = d.day::date
+		ORDER BY d.day ASC`,
+		userID, days,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.MetricsDaily{}
+	for rows.Next() {
+		var m model.MetricsDaily
+		if err := rows.Scan(&m.Date, &m.ItemsIngested, &m.ItemsSummarized, &m.ItemsRead, &m.LLMCalls, &m.LLMCostUSD, &m.DigestsSent); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ModelPricingRepo struct{ db *pgxpool.Pool }
+
+func NewModelPricingRepo(db *pgxpool.Pool) *ModelPricingRepo { return &ModelPricingRepo{db: db} }
+
+type ModelPricingInput struct {
+	Provider             string
+	Model                string
+	InputPerMTokUSD      float64
+	OutputPerMTokUSD     float64
+	CacheReadPerMTokUSD  float64
+	CacheWritePerMTokUSD float64
+	Note                 *string
+}
+
+const modelPricingColumns = `id, provider, model, input_per_mtok_usd, output_per_mtok_usd, cache_read_per_mtok_usd, cache_write_per_mtok_usd, note, created_at, updated_at`
+
+func scanModelPricing(row pgx.Row) (*model.ModelPricing, error) {
+	var v model.ModelPricing
+	if err := row.Scan(
+		&v.ID, &v.Provider, &v.Model,
+		&v.InputPerMTokUSD, &v.OutputPerMTokUSD, &v.CacheReadPerMTokUSD, &v.CacheWritePerMTokUSD,
+		&v.Note, &v.CreatedAt, &v.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *ModelPricingRepo) List(ctx context.Context) ([]model.ModelPricing, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+modelPricingColumns+`
+		FROM model_pricing
+		ORDER BY provider, model`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.ModelPricing{}
+	for rows.Next() {
+		v, err := scanModelPricing(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *v)
+	}
+	return out, rows.Err()
+}
+
+func (r *ModelPricingRepo) GetByID(ctx context.Context, id string) (*model.ModelPricing, error) {
+	v, err := scanModelPricing(r.db.QueryRow(ctx, `
+		SELECT `+modelPricingColumns+`
+		FROM model_pricing
+		WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+// Upsert creates or replaces the price for a provider/model pair.
+func (r *ModelPricingRepo) Upsert(ctx context.Context, in ModelPricingInput) (*model.ModelPricing, error) {
+	v, err := scanModelPricing(r.db.QueryRow(ctx, `
+		INSERT INTO model_pricing (
+			provider, model, input_per_mtok_usd, output_per_mtok_usd, cache_read_per_mtok_usd, cache_write_per_mtok_usd, note
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (provider, model) DO UPDATE SET
+			input_per_mtok_usd = EXCLUDED.input_per_mtok_usd,
+			output_per_mtok_usd = EXCLUDED.output_per_mtok_usd,
+			cache_read_per_mtok_usd = EXCLUDED.cache_read_per_mtok_usd,
+			cache_write_per_mtok_usd = EXCLUDED.cache_write_per_mtok_usd,
+			note = EXCLUDED.note,
+			updated_at = NOW()
+		RETURNING `+modelPricingColumns,
+		in.Provider, in.Model, in.InputPerMTokUSD, in.OutputPerMTokUSD, in.CacheReadPerMTokUSD, in.CacheWritePerMTokUSD, in.Note,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return v, nil
+}
+
+func (r *ModelPricingRepo) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM model_pricing WHERE id = $1`, id)
+	if err != nil {
+		return mapDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -108,10 +108,11 @@ func (r *ItemRepo) PersistPersonalScores(ctx context.Context, userID string, ite
 		return nil
 	}
 
-	embeddings, err := loadItemEmbeddingsByID(ctx, r.db, itemIDs)
+	reg, err := loadEmbeddingRegistry(ctx, r.db, itemIDs)
 	if err != nil {
 		return err
 	}
+	embeddings := reg.vectors(profileEmbeddingSpace(profile))
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
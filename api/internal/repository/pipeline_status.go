@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PipelineStatusRepo struct{ db *pgxpool.Pool }
+
+func NewPipelineStatusRepo(db *pgxpool.Pool) *PipelineStatusRepo { return &PipelineStatusRepo{db} }
+
+func (r *PipelineStatusRepo) QueueDepth(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT status, COUNT(*)
+		FROM items
+		WHERE deleted_at IS NULL
+		GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]int{}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, err
+		}
+		out[status] = n
+	}
+	return out, rows.Err()
+}
+
+// StuckItems counts live items that have sat in status since before olderThan, leaving out
+// items held back by a processing pause.
+func (r *PipelineStatusRepo) StuckItems(ctx context.Context, status string, olderThan time.Time, sampleLimit int) (model.PipelineStuckItems, error) {
+	out := model.PipelineStuckItems{Status: status, SampleItemIDs: []string{}}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), MIN(updated_at),
+		       COALESCE((ARRAY_AGG(id::text ORDER BY updated_at))[1:$3], '{}')
+		FROM items
+		WHERE status = $1 AND deleted_at IS NULL AND updated_at < $2`+itemOwnerNotPausedSQL("items"),
+		status, olderThan, sampleLimit,
+	).Scan(&out.Count, &out.OldestUpdatedAt, &out.SampleItemIDs)
+	return out, err
+}
+
+// StepFailureRates groups item processing events since the given time by step, folding
+// attempt, retry and fallback variants ("summarize-2-fallback") into their base step.
+func (r *PipelineStatusRepo) StepFailureRates(ctx context.Context, since time.Time) ([]model.PipelineStepFailureRate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT regexp_replace(step, '(-[0-9]+)?(-retry|-fallback)?$', '') AS base_step,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'failed'),
+		       COALESCE(AVG(duration_ms), 0)::bigint
+		FROM item_processing_events
+		WHERE started_at >= $1
+		GROUP BY base_step
+		ORDER BY base_step`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.PipelineStepFailureRate{}
+	for rows.Next() {
+		var v model.PipelineStepFailureRate
+		if err := rows.Scan(&v.Step, &v.Attempts, &v.Failures, &v.AvgDurationMS); err != nil {
+			return nil, err
+		}
+		if v.Attempts > 0 {
+			v.FailureRate = float64(v.Failures) / float64(v.Attempts)
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// SlowestSources ranks sources by the average total step time their items spent in the
+// pipeline since the given time.
+func (r *PipelineStatusRepo) SlowestSources(ctx context.Context, since time.Time, limit int) ([]model.PipelineSlowSource, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH per_item AS (
+			SELECT item_id, SUM(duration_ms) AS total_ms
+			FROM item_processing_events
+			WHERE started_at >= $1
+			GROUP BY item_id
+		)
+		SELECT s.id, s.url, s.title, COUNT(*), AVG(p.total_ms)::bigint, MAX(p.total_ms)
+		FROM per_item p
+		JOIN items i ON i.id = p.item_id
+		JOIN sources s ON s.id = i.source_id
+		GROUP BY s.id, s.url, s.title
+		ORDER BY AVG(p.total_ms) DESC
+		LIMIT $2`, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.PipelineSlowSource{}
+	for rows.Next() {
+		var v model.PipelineSlowSource
+		if err := rows.Scan(&v.SourceID, &v.SourceURL, &v.SourceTitle, &v.Items, &v.AvgProcessingMS, &v.MaxProcessingMS); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// AutoReprocessCounts counts items automatically reprocessed since the given time and those
+// that were failed after reaching maxAttempts.
+func (r *PipelineStatusRepo) AutoReprocessCounts(ctx context.Context, since time.Time, maxAttempts int) (model.PipelineAutoReprocess, error) {
+	var out model.PipelineAutoReprocess
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'failed' AND auto_reprocess_count >= $2)
+		FROM items
+		WHERE auto_reprocessed_at >= $1 AND deleted_at IS NULL`,
+		since, maxAttempts,
+	).Scan(&out.Reprocessed, &out.Exhausted)
+	return out, err
+}
@@ -171,7 +171,7 @@ func (r *PreferenceProfileRepo) BuildProfileForUser(ctx context.Context, userID
 	}
 	topicInterests := computeTopicInterests(actions)
 
-	prefEmb, _, err := r.loadPrefEmbedding(ctx, userID)
+	prefEmb, prefEmbModel, err := r.loadPrefEmbedding(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -188,14 +188,15 @@ func (r *PreferenceProfileRepo) BuildProfileForUser(ctx context.Context, userID
 
 	now := time.Now()
 	return &model.UserPreferenceProfile{
-		UserID:           userID,
-		LearnedWeights:   learnedWeights,
-		TopicInterests:   topicInterests,
-		PrefEmbedding:    prefEmb,
-		SourceAffinities: sourceAffinities,
-		FeedbackCount:    feedbackCount,
-		ReadCount:        readCount,
-		ComputedAt:       &now,
+		UserID:             userID,
+		LearnedWeights:     learnedWeights,
+		TopicInterests:     topicInterests,
+		PrefEmbedding:      prefEmb,
+		PrefEmbeddingModel: prefEmbModel,
+		SourceAffinities:   sourceAffinities,
+		FeedbackCount:      feedbackCount,
+		ReadCount:          readCount,
+		ComputedAt:         &now,
 	}, nil
 }
 
@@ -203,13 +204,14 @@ func (r *PreferenceProfileRepo) BuildProfileForUser(ctx context.Context, userID
 func (r *PreferenceProfileRepo) UpsertProfile(ctx context.Context, profile *model.UserPreferenceProfile) error {
 	_, err := r.db.Exec(ctx, `
 		INSERT INTO user_preference_profiles (
-			user_id, learned_weights, topic_interests, pref_embedding,
+			user_id, learned_weights, topic_interests, pref_embedding, pref_embedding_model,
 			source_affinities, feedback_count, read_count, computed_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		) VALUES ($1, $2, $3, $4, NULLIF($9, ''), $5, $6, $7, $8)
 		ON CONFLICT (user_id) DO UPDATE SET
 			learned_weights = EXCLUDED.learned_weights,
 			topic_interests = EXCLUDED.topic_interests,
 			pref_embedding = EXCLUDED.pref_embedding,
+			pref_embedding_model = EXCLUDED.pref_embedding_model,
 			source_affinities = EXCLUDED.source_affinities,
 			feedback_count = EXCLUDED.feedback_count,
 			read_count = EXCLUDED.read_count,
@@ -222,6 +224,7 @@ func (r *PreferenceProfileRepo) UpsertProfile(ctx context.Context, profile *mode
 		profile.FeedbackCount,
 		profile.ReadCount,
 		profile.ComputedAt,
+		profile.PrefEmbeddingModel,
 	)
 	return err
 }
@@ -230,7 +233,7 @@ func (r *PreferenceProfileRepo) UpsertProfile(ctx context.Context, profile *mode
 func (r *PreferenceProfileRepo) GetProfile(ctx context.Context, userID string) (*model.UserPreferenceProfile, error) {
 	var p model.UserPreferenceProfile
 	err := r.db.QueryRow(ctx, `
-		SELECT user_id, learned_weights, topic_interests, pref_embedding,
+		SELECT user_id, learned_weights, topic_interests, pref_embedding, COALESCE(pref_embedding_model, ''),
 		       source_affinities, feedback_count, read_count, computed_at
 		FROM user_preference_profiles
 		WHERE user_id = $1`, userID,
@@ -239,6 +242,7 @@ func (r *PreferenceProfileRepo) GetProfile(ctx context.Context, userID string) (
 		&p.LearnedWeights,
 		&p.TopicInterests,
 		&p.PrefEmbedding,
+		&p.PrefEmbeddingModel,
 		&p.SourceAffinities,
 		&p.FeedbackCount,
 		&p.ReadCount,
@@ -601,22 +605,24 @@ func (r *PreferenceProfileRepo) loadTopicActions(ctx context.Context, userID str
 						WHEN fb.rating > 0 THEN 1.0
 						WHEN fb.rating < 0 THEN -1.2
 						WHEN il.item_id IS NOT NULL THEN 0.45
+						WHEN ic.item_id IS NOT NULL THEN 0.3
 						WHEN ir.item_id IS NOT NULL THEN 0.2
 						ELSE 0.0
 					END
 				)::double precision AS signal,
-				COALESCE(fb.updated_at, il.created_at, ir.read_at, i.deleted_at, i.created_at) AS acted_at
+				COALESCE(fb.updated_at, il.created_at, ic.last_clicked_at, ir.read_at, i.deleted_at, i.created_at) AS acted_at
 			FROM items i
 			JOIN sources s ON s.id = i.source_id
 			JOIN item_summaries isb ON isb.item_id = i.id
 			LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1::uuid
 			LEFT JOIN item_laters il ON il.item_id = i.id AND il.user_id = $1::uuid
 			LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1::uuid
+			LEFT JOIN item_clicks ic ON ic.item_id = i.id AND ic.user_id = $1::uuid
 			WHERE s.user_id = $1::uuid
 			  AND isb.topics IS NOT NULL
 			  AND array_length(isb.topics, 1) > 0
-			  AND COALESCE(fb.updated_at, il.created_at, ir.read_at, i.deleted_at, i.created_at) >= NOW() - INTERVAL '90 days'
-			  AND (fb.item_id IS NOT NULL OR il.item_id IS NOT NULL OR ir.item_id IS NOT NULL OR i.deleted_at IS NOT NULL)
+			  AND COALESCE(fb.updated_at, il.created_at, ic.last_clicked_at, ir.read_at, i.deleted_at, i.created_at) >= NOW() - INTERVAL '90 days'
+			  AND (fb.item_id IS NOT NULL OR il.item_id IS NOT NULL OR ic.item_id IS NOT NULL OR ir.item_id IS NOT NULL OR i.deleted_at IS NOT NULL)
 			UNION ALL
 			SELECT isb.topics, 2.0::double precision AS signal, n.updated_at AS acted_at
 			FROM item_notes n
@@ -663,15 +669,15 @@ func (r *PreferenceProfileRepo) loadTopicActions(ctx context.Context, userID str
 	return actions, rows.Err()
 }
 
-func (r *PreferenceProfileRepo) loadPrefEmbedding(ctx context.Context, userID string) ([]float64, int, error) {
+func (r *PreferenceProfileRepo) loadPrefEmbedding(ctx context.Context, userID string) ([]float64, string, error) {
 	fp, err := loadFeedbackPreferenceProfile(ctx, r.db, userID)
 	if err != nil {
-		return nil, 0, err
+		return nil, "", err
 	}
 	if fp == nil {
-		return nil, 0, nil
+		return nil, "", nil
 	}
-	return fp.prefEmbedding, fp.embeddingDims, nil
+	return fp.prefEmbedding, fp.embeddingModel, nil
 }
 
 func (r *PreferenceProfileRepo) loadSourceAffinities(ctx context.Context, userID string) (map[string]float64, error) {
@@ -689,6 +695,7 @@ func (r *PreferenceProfileRepo) loadSourceAffinities(ctx context.Context, userID
 						WHEN fb.is_favorite = true THEN 2.0
 						WHEN fb.rating > 0 THEN 1.0
 						WHEN fb.rating < 0 THEN -1.2
+						WHEN ic.item_id IS NOT NULL THEN 0.3
 						ELSE 0.0
 					END
 				), 0)::double precision AS feedback_signal
@@ -705,6 +712,9 @@ func (r *PreferenceProfileRepo) loadSourceAffinities(ctx context.Context, userID
 			LEFT JOIN item_feedbacks fb
 			       ON fb.item_id = i.id
 			      AND fb.user_id = $1
+			LEFT JOIN item_clicks ic
+			       ON ic.item_id = i.id
+			      AND ic.user_id = $1
 			WHERE s.user_id = $1
 			  AND s.enabled = true
 			GROUP BY s.id
@@ -175,6 +175,21 @@ func TestPreferenceProfileIncludesNewSignals(t *testing.T) {
 	}
 }
 
+func TestClickSignalWeakerThanExplicitRating(t *testing.T) {
+	actions := []topicAction{
+		{Topics: []string{"Rated"}, Signal: 1.0, DaysAgo: 0}, // explicit thumbs up
+		{Topics: []string{"Clicked"}, Signal: 0.3, DaysAgo: 0},
+	}
+
+	interests := computeTopicInterests(actions)
+	if interests["clicked"] <= 0 {
+		t.Fatalf("clicked should be a positive signal, got %f", interests["clicked"])
+	}
+	if interests["clicked"] >= interests["rated"] {
+		t.Fatalf("clicked (%f) should be < rated (%f)", interests["clicked"], interests["rated"])
+	}
+}
+
 func TestPreferenceProfileStatusAndConfidence(t *testing.T) {
 	if got, want := preferenceStatusFromFeedbackCount(0), "cold_start"; got != want {
 		t.Fatalf("status(0) = %q, want %q", got, want)
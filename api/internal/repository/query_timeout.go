@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// QueryCategory groups queries that share a timeout budget.
+type QueryCategory string
+
+const (
+	QueryCategoryDefault   QueryCategory = "default"
+	QueryCategoryHeavy     QueryCategory = "heavy"
+	QueryCategoryAnalytics QueryCategory = "analytics"
+)
+
+var queryTimeoutDefaults = map[QueryCategory]time.Duration{
+	QueryCategoryDefault:   5 * time.Second,
+	QueryCategoryHeavy:     15 * time.Second,
+	QueryCategoryAnalytics: 10 * time.Second,
+}
+
+var queryTimeoutEnv = map[QueryCategory]string{
+	QueryCategoryDefault:   "DB_QUERY_TIMEOUT_DEFAULT_MS",
+	QueryCategoryHeavy:     "DB_QUERY_TIMEOUT_HEAVY_MS",
+	QueryCategoryAnalytics: "DB_QUERY_TIMEOUT_ANALYTICS_MS",
+}
+
+// QueryTimeout returns the timeout for category. DB_QUERY_TIMEOUT_<CATEGORY>_MS overrides
+// the default; 0 disables the timeout for that category.
+func QueryTimeout(category QueryCategory) time.Duration {
+	fallback, ok := queryTimeoutDefaults[category]
+	if !ok {
+		category = QueryCategoryDefault
+		fallback = queryTimeoutDefaults[category]
+	}
+	raw := strings.TrimSpace(os.Getenv(queryTimeoutEnv[category]))
+	if raw == "" {
+		return fallback
+	}
+	ms, err := strconv.Atoi(raw)
+	if err != nil || ms < 0 {
+		return fallback
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// withQueryTimeout bounds a query so a slow plan releases its connection instead of
+// starving the pool. A caller deadline that is already shorter wins.
+func withQueryTimeout(ctx context.Context, category QueryCategory) (context.Context, context.CancelFunc) {
+	d := QueryTimeout(category)
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+type PoolStats struct {
+	MaxConns             int32   `json:"max_conns"`
+	TotalConns           int32   `json:"total_conns"`
+	AcquiredConns        int32   `json:"acquired_conns"`
+	IdleConns            int32   `json:"idle_conns"`
+	ConstructingConns    int32   `json:"constructing_conns"`
+	AcquireCount         int64   `json:"acquire_count"`
+	EmptyAcquireCount    int64   `json:"empty_acquire_count"`
+	CanceledAcquireCount int64   `json:"canceled_acquire_count"`
+	AcquireWaitMs        int64   `json:"acquire_wait_ms"`
+	Saturation           float64 `json:"saturation"`
+}
+
+// PoolStatsOf reports pool usage. Saturation is acquired/max; a value near 1 with a growing
+// empty_acquire_count means requests are queueing for connections.
+func PoolStatsOf(pool *pgxpool.Pool) PoolStats {
+	if pool == nil {
+		return PoolStats{}
+	}
+	s := pool.Stat()
+	out := PoolStats{
+		MaxConns:             s.MaxConns(),
+		TotalConns:           s.TotalConns(),
+		AcquiredConns:        s.AcquiredConns(),
+		IdleConns:            s.IdleConns(),
+		ConstructingConns:    s.ConstructingConns(),
+		AcquireCount:         s.AcquireCount(),
+		EmptyAcquireCount:    s.EmptyAcquireCount(),
+		CanceledAcquireCount: s.CanceledAcquireCount(),
+		AcquireWaitMs:        s.AcquireDuration().Milliseconds(),
+	}
+	if out.MaxConns > 0 {
+		out.Saturation = float64(out.AcquiredConns) / float64(out.MaxConns)
+	}
+	return out
+}
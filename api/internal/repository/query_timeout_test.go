@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	t.Setenv("DB_QUERY_TIMEOUT_HEAVY_MS", "2500")
+	t.Setenv("DB_QUERY_TIMEOUT_ANALYTICS_MS", "oops")
+	t.Setenv("DB_QUERY_TIMEOUT_DEFAULT_MS", "0")
+
+	if got := QueryTimeout(QueryCategoryHeavy); got != 2500*time.Millisecond {
+		t.Errorf("heavy = %v, want 2.5s", got)
+	}
+	if got := QueryTimeout(QueryCategoryAnalytics); got != 10*time.Second {
+		t.Errorf("analytics = %v, want the 10s default for an invalid value", got)
+	}
+	if got := QueryTimeout(QueryCategoryDefault); got != 0 {
+		t.Errorf("default = %v, want 0 (disabled)", got)
+	}
+	if got := QueryTimeout("unknown"); got != 0 {
+		t.Errorf("unknown category = %v, want the default category value", got)
+	}
+}
@@ -11,37 +11,24 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// LoadItemEmbeddingsByID fetches embeddings for the given item IDs.
-func (r *ItemRepo) LoadItemEmbeddingsByID(ctx context.Context, itemIDs []string) (map[string][]float64, error) {
-	return loadItemEmbeddingsByID(ctx, r.db, itemIDs)
+// LoadItemEmbeddingsForProfile fetches the embeddings of the given items that can be compared
+// with the profile's preference embedding.
+func (r *ItemRepo) LoadItemEmbeddingsForProfile(ctx context.Context, itemIDs []string, profile *model.UserPreferenceProfile) (map[string][]float64, error) {
+	reg, err := loadEmbeddingRegistry(ctx, r.db, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	return reg.vectors(profileEmbeddingSpace(profile)), nil
 }
 
+// loadItemEmbeddingsByID returns the embeddings of the given items that share the dominant
+// embedding space; items embedded by another model are left out rather than compared.
 func loadItemEmbeddingsByID(ctx context.Context, db *pgxpool.Pool, itemIDs []string) (map[string][]float64, error) {
-	if len(itemIDs) == 0 {
-		return nil, nil
-	}
-	rows, err := db.Query(ctx, `
-		SELECT item_id, embedding
-		FROM item_embeddings
-		WHERE item_id = ANY($1::uuid[])`, itemIDs)
+	reg, err := loadEmbeddingRegistry(ctx, db, itemIDs)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	out := make(map[string][]float64, len(itemIDs))
-	for rows.Next() {
-		var itemID string
-		var emb []float64
-		if err := rows.Scan(&itemID, &emb); err != nil {
-			return nil, err
-		}
-		if len(emb) == 0 {
-			continue
-		}
-		out[itemID] = emb
-	}
-	return out, rows.Err()
+	return reg.vectors(reg.dominantSpace(embeddingSpace{})), nil
 }
 
 func loadItemFactsByID(ctx context.Context, db *pgxpool.Pool, itemIDs []string) (map[string][]string, error) {
@@ -211,9 +198,46 @@ func (r *ItemRepo) readingPlanClustersByEmbeddings(ctx context.Context, items []
 	if len(embByID) < 2 {
 		return nil, nil
 	}
+	return buildReadingPlanClusters(items, embByID, selectedSet), nil
+}
+
+// buildReadingPlanClusters clusters each partition of the pool separately and merges groups
+// that share an item; see readingPlanClusterPartitions for when the pool is split.
+func buildReadingPlanClusters(items []model.Item, embByID map[string][]float64, selectedSet map[string]struct{}) []model.ReadingPlanCluster {
+	unit := unitEmbeddings(embByID)
+	var groups []readingPlanGroup
+	for _, part := range readingPlanClusterPartitions(items, unit) {
+		groups = append(groups, greedyReadingPlanGroups(part, unit)...)
+	}
+	clusters := make([]model.ReadingPlanCluster, 0, len(groups))
+	for _, g := range mergeReadingPlanGroups(groups) {
+		if c, ok := readingPlanClusterFromGroup(g, selectedSet); ok {
+			clusters = append(clusters, c)
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Size != clusters[j].Size {
+			return clusters[i].Size > clusters[j].Size
+		}
+		if clusters[i].MaxSimilarity != clusters[j].MaxSimilarity {
+			return clusters[i].MaxSimilarity > clusters[j].MaxSimilarity
+		}
+		return clusters[i].Representative.CreatedAt.After(clusters[j].Representative.CreatedAt)
+	})
+	return reorderReadingPlanClustersMMR(clusters, embByID)
+}
+
+type readingPlanGroup struct {
+	members []model.Item
+	maxSim  float64
+}
 
+// greedyReadingPlanGroups grows a group from each ungrouped item in order, adding later items
+// that match any member, and returns the groups of two or more. It compares every pair, so
+// callers keep items small, and embByID must hold unit vectors.
+func greedyReadingPlanGroups(items []model.Item, embByID map[string][]float64) []readingPlanGroup {
 	used := make([]bool, len(items))
-	clusters := make([]model.ReadingPlanCluster, 0, len(items)/2)
+	var groups []readingPlanGroup
 	for i := range items {
 		if used[i] {
 			continue
@@ -242,7 +266,7 @@ func (r *ItemRepo) readingPlanClustersByEmbeddings(ctx context.Context, items []
 				if !ok || len(mEmb) == 0 {
 					continue
 				}
-				sim := cosineSimilarity(mEmb, cEmb)
+				sim := dotProduct(mEmb, cEmb)
 				if sim > bestSim {
 					bestSim = sim
 				}
@@ -259,72 +283,100 @@ func (r *ItemRepo) readingPlanClustersByEmbeddings(ctx context.Context, items []
 				}
 			}
 		}
-		selectedMembers := make([]model.Item, 0, len(members))
-		if len(selectedSet) > 0 {
-			for _, m := range members {
-				if _, ok := selectedSet[m.ID]; ok {
-					selectedMembers = append(selectedMembers, m)
-				}
-			}
-		}
-		if len(members) < 2 {
-			continue
-		}
-		if len(selectedSet) > 0 && len(selectedMembers) == 0 {
-			continue
+		if len(members) >= 2 {
+			groups = append(groups, readingPlanGroup{members: members, maxSim: maxSim})
 		}
-		sort.SliceStable(members, func(a, b int) bool {
-			as := -1.0
-			if members[a].SummaryScore != nil {
-				as = *members[a].SummaryScore
-			}
-			bs := -1.0
-			if members[b].SummaryScore != nil {
-				bs = *members[b].SummaryScore
+	}
+	return groups
+}
+
+// mergeReadingPlanGroups unions groups that share an item, which happens when overlapping
+// partitions each grouped part of the same story. Groups keep their first-seen order.
+func mergeReadingPlanGroups(groups []readingPlanGroup) []readingPlanGroup {
+	parent := make([]int, len(groups))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	owner := map[string]int{}
+	for gi, g := range groups {
+		for _, m := range g.members {
+			if prev, ok := owner[m.ID]; ok {
+				a, b := find(prev), find(gi)
+				if a < b {
+					parent[b] = a
+				} else if b < a {
+					parent[a] = b
+				}
+				continue
 			}
-			if as != bs {
-				return as > bs
+			owner[m.ID] = gi
+		}
+	}
+	merged := map[int]*readingPlanGroup{}
+	seen := map[string]bool{}
+	var order []int
+	for gi, g := range groups {
+		root := find(gi)
+		dst, ok := merged[root]
+		if !ok {
+			dst = &readingPlanGroup{}
+			merged[root] = dst
+			order = append(order, root)
+		}
+		for _, m := range g.members {
+			if !seen[m.ID] {
+				seen[m.ID] = true
+				dst.members = append(dst.members, m)
 			}
-			return members[a].CreatedAt.After(members[b].CreatedAt)
-		})
-		representative := members[0]
-		if len(selectedMembers) > 0 {
-			sort.SliceStable(selectedMembers, func(a, b int) bool {
-				as := -1.0
-				if selectedMembers[a].SummaryScore != nil {
-					as = *selectedMembers[a].SummaryScore
-				}
-				bs := -1.0
-				if selectedMembers[b].SummaryScore != nil {
-					bs = *selectedMembers[b].SummaryScore
-				}
-				if as != bs {
-					return as > bs
-				}
-				return selectedMembers[a].CreatedAt.After(selectedMembers[b].CreatedAt)
-			})
-			representative = selectedMembers[0]
 		}
-		clusters = append(clusters, model.ReadingPlanCluster{
-			ID:             representative.ID,
-			Label:          readingPlanClusterLabel(representative),
-			Size:           len(members),
-			MaxSimilarity:  maxSim,
-			Representative: representative,
-			Items:          members,
-		})
+		if g.maxSim > dst.maxSim {
+			dst.maxSim = g.maxSim
+		}
 	}
+	out := make([]readingPlanGroup, 0, len(order))
+	for _, root := range order {
+		out = append(out, *merged[root])
+	}
+	return out
+}
 
-	sort.SliceStable(clusters, func(i, j int) bool {
-		if clusters[i].Size != clusters[j].Size {
-			return clusters[i].Size > clusters[j].Size
+// readingPlanClusterFromGroup turns a group into a cluster represented by its best member,
+// preferring members already selected for the plan. When selectedSet is set, groups without
+// a selected member are dropped.
+func readingPlanClusterFromGroup(g readingPlanGroup, selectedSet map[string]struct{}) (model.ReadingPlanCluster, bool) {
+	members := g.members
+	selectedMembers := make([]model.Item, 0, len(members))
+	if len(selectedSet) > 0 {
+		for _, m := range members {
+			if _, ok := selectedSet[m.ID]; ok {
+				selectedMembers = append(selectedMembers, m)
+			}
 		}
-		if clusters[i].MaxSimilarity != clusters[j].MaxSimilarity {
-			return clusters[i].MaxSimilarity > clusters[j].MaxSimilarity
+		if len(selectedMembers) == 0 {
+			return model.ReadingPlanCluster{}, false
 		}
-		return clusters[i].Representative.CreatedAt.After(clusters[j].Representative.CreatedAt)
-	})
-	return reorderReadingPlanClustersMMR(clusters, embByID), nil
+	}
+	sortClusterMembers(members)
+	representative := members[0]
+	if len(selectedMembers) > 0 {
+		sortClusterMembers(selectedMembers)
+		representative = selectedMembers[0]
+	}
+	return model.ReadingPlanCluster{
+		ID:             representative.ID,
+		Label:          readingPlanClusterLabel(representative),
+		Size:           len(members),
+		MaxSimilarity:  g.maxSim,
+		Representative: representative,
+		Items:          members,
+	}, true
 }
 
 func (r *ItemRepo) briefingClustersByEmbeddings(ctx context.Context, items []model.Item) ([]model.ReadingPlanCluster, error) {
@@ -606,26 +658,44 @@ func reorderReadingPlanClustersMMR(clusters []model.ReadingPlanCluster, embByID
 	remaining := make([]model.ReadingPlanCluster, len(clusters))
 	copy(remaining, clusters)
 	out := make([]model.ReadingPlanCluster, 0, len(clusters))
+	// penalty[i] is remaining[i]'s highest similarity to a chosen cluster, updated with each
+	// pick so a step costs one comparison per remaining cluster.
+	penalty := make([]float64, len(remaining))
+	pick := func(idx int) {
+		chosen := remaining[idx]
+		out = append(out, chosen)
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+		penalty = append(penalty[:idx], penalty[idx+1:]...)
+		chosenEmb := embByID[chosen.Representative.ID]
+		if len(chosenEmb) == 0 {
+			return
+		}
+		for i, c := range remaining {
+			emb := embByID[c.Representative.ID]
+			if len(emb) == 0 {
+				continue
+			}
+			if sim := cosineSimilarity(emb, chosenEmb); sim > penalty[i] {
+				penalty[i] = sim
+			}
+		}
+	}
 
 	// Seed with the strongest cluster from the existing sort order.
-	out = append(out, remaining[0])
-	remaining = remaining[1:]
-
+	pick(0)
 	for len(remaining) > 0 {
 		bestIdx := 0
 		bestScore := -1e9
 		for i, c := range remaining {
 			relevance := clusterRelevanceScore(c)
-			divPenalty := maxClusterSimilarityToSelected(c, out, embByID)
 			// MMR-ish: prioritize relevance while penalizing similarity to already chosen clusters.
-			score := 0.72*relevance - 0.28*divPenalty
+			score := 0.72*relevance - 0.28*penalty[i]
 			if score > bestScore {
 				bestScore = score
 				bestIdx = i
 			}
 		}
-		out = append(out, remaining[bestIdx])
-		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+		pick(bestIdx)
 	}
 	return out
 }
@@ -639,28 +709,6 @@ func clusterRelevanceScore(c model.ReadingPlanCluster) float64 {
 	return score + math.Min(0.12, float64(c.Size-1)*0.03)
 }
 
-func maxClusterSimilarityToSelected(c model.ReadingPlanCluster, selected []model.ReadingPlanCluster, embByID map[string][]float64) float64 {
-	if len(selected) == 0 {
-		return 0
-	}
-	emb := embByID[c.Representative.ID]
-	if len(emb) == 0 {
-		return 0
-	}
-	maxSim := 0.0
-	for _, s := range selected {
-		other := embByID[s.Representative.ID]
-		if len(other) == 0 {
-			continue
-		}
-		sim := cosineSimilarity(emb, other)
-		if sim > maxSim {
-			maxSim = sim
-		}
-	}
-	return maxSim
-}
-
 func cosineSimilarity(a, b []float64) float64 {
 	if len(a) == 0 || len(a) != len(b) {
 		return 0
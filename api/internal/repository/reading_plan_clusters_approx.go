@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"math"
+	"sort"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	// Pools up to this many embedded items are clustered in one exact pass; larger pools are
+	// split into partitions of at most this size.
+	readingPlanExactClusterLimit = 300
+	// Each half of a split also takes the len/divisor items nearest the median from the other
+	// half, so close pairs straddling the median still meet in one partition.
+	readingPlanPartitionSpillDivisor = 10
+	// Independent partitionings of the pool; a pair split apart by one usually meets in another.
+	readingPlanPartitionTrees = 2
+)
+
+// readingPlanClusterPartitions splits a large pool into overlapping partitions of nearby items
+// so the pairwise greedy pass only runs inside each one. Each of readingPlanPartitionTrees
+// random-projection trees halves the pool at the median of the items' projections onto the
+// axis between two far-apart items found from a per-tree pivot, recursively, which keeps partitions
+// balanced and costs O(n·d) per level. Items near a median go to both halves, and the trees
+// start from different pivots, so an item sits in several partitions and the groups found in
+// them are merged afterwards. A loose story whose items never share a partition is split;
+// that is the approximation. Pool order is kept inside each partition, so seeds are picked as
+// in the exact pass.
+func readingPlanClusterPartitions(items []model.Item, embByID map[string][]float64) [][]model.Item {
+	embedded := make([]model.Item, 0, len(items))
+	for _, it := range items {
+		if len(embByID[it.ID]) > 0 {
+			embedded = append(embedded, it)
+		}
+	}
+	if len(embedded) <= readingPlanExactClusterLimit {
+		return [][]model.Item{embedded}
+	}
+	vecs := make([][]float64, len(embedded))
+	idx := make([]int, len(embedded))
+	for i, it := range embedded {
+		vecs[i] = embByID[it.ID]
+		idx[i] = i
+	}
+	var out [][]model.Item
+	for tree := 0; tree < readingPlanPartitionTrees; tree++ {
+		for _, leaf := range splitByProjection(idx, vecs, readingPlanExactClusterLimit, tree) {
+			// Leaves share backing arrays where they overlap, so sort a copy.
+			leaf = append([]int(nil), leaf...)
+			sort.Ints(leaf)
+			part := make([]model.Item, len(leaf))
+			for i, j := range leaf {
+				part[i] = embedded[j]
+			}
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// splitByProjection halves idx until each part has at most leafSize vectors; tree picks the
+// pivot so that trees differ. vecs must be unit vectors.
+func splitByProjection(idx []int, vecs [][]float64, leafSize, tree int) [][]int {
+	if len(idx) <= leafSize {
+		return [][]int{idx}
+	}
+	a := farthestFrom(vecs[idx[tree*len(idx)/readingPlanPartitionTrees]], idx, vecs)
+	b := farthestFrom(vecs[a], idx, vecs)
+	axis := make([]float64, len(vecs[a]))
+	for d := range axis {
+		axis[d] = vecs[a][d] - vecs[b][d]
+	}
+	proj := make(map[int]float64, len(idx))
+	for _, i := range idx {
+		proj[i] = dotProduct(vecs[i], axis)
+	}
+	sorted := append([]int(nil), idx...)
+	sort.SliceStable(sorted, func(i, j int) bool { return proj[sorted[i]] < proj[sorted[j]] })
+	mid := len(sorted) / 2
+	spill := len(sorted) / readingPlanPartitionSpillDivisor
+	return append(
+		splitByProjection(sorted[:mid+spill], vecs, leafSize, tree),
+		splitByProjection(sorted[mid-spill:], vecs, leafSize, tree)...,
+	)
+}
+
+// farthestFrom is the vector in idx least similar to v.
+func farthestFrom(v []float64, idx []int, vecs [][]float64) int {
+	best, bestDot := idx[0], math.Inf(1)
+	for _, i := range idx {
+		if d := dotProduct(v, vecs[i]); d < bestDot {
+			best, bestDot = i, d
+		}
+	}
+	return best
+}
+
+// unitEmbeddings scales every embedding to unit length, so cosine similarity becomes a dot
+// product.
+func unitEmbeddings(embByID map[string][]float64) map[string][]float64 {
+	out := make(map[string][]float64, len(embByID))
+	for id, v := range embByID {
+		if u := unitVector(v); u != nil {
+			out[id] = u
+		}
+	}
+	return out
+}
+
+// unitVector is v scaled to unit length, or nil for a zero vector.
+func unitVector(v []float64) []float64 {
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	u := make([]float64, len(v))
+	for i, x := range v {
+		u[i] = x / norm
+	}
+	return u
+}
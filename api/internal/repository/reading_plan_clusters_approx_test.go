@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// syntheticReadingPlanPool builds stories of three items, each its story's vector plus noise,
+// mixed with unrelated singletons in a shuffled order.
+func syntheticReadingPlanPool(stories, singletons, dims int, noise float64) ([]model.Item, map[string][]float64) {
+	rng := rand.New(rand.NewSource(42))
+	randomVec := func() []float64 {
+		v := make([]float64, dims)
+		for i := range v {
+			v[i] = rng.NormFloat64()
+		}
+		return v
+	}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	var items []model.Item
+	embByID := map[string][]float64{}
+	add := func(id string, emb []float64) {
+		items = append(items, model.Item{ID: id, SummaryTopics: []string{id}, CreatedAt: now})
+		embByID[id] = emb
+	}
+	for s := 0; s < stories; s++ {
+		base := randomVec()
+		for m := 0; m < 3; m++ {
+			emb := make([]float64, dims)
+			for i := range emb {
+				emb[i] = base[i] + noise*rng.NormFloat64()
+			}
+			add(fmt.Sprintf("story-%d-%d", s, m), emb)
+		}
+	}
+	for i := 0; i < singletons; i++ {
+		add(fmt.Sprintf("single-%d", i), randomVec())
+	}
+	rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	return items, embByID
+}
+
+func TestBuildReadingPlanClustersPartitionsLargePools(t *testing.T) {
+	items, embByID := syntheticReadingPlanPool(150, 1500, 64, 0.1)
+	if parts := readingPlanClusterPartitions(items, embByID); len(parts) < 2 {
+		t.Fatalf("pool of %d items was not partitioned", len(items))
+	}
+	clusters := buildReadingPlanClusters(items, embByID, nil)
+	complete := 0
+	for _, c := range clusters {
+		if c.Size > 3 {
+			t.Fatalf("cluster %s mixes stories: %d items", c.ID, c.Size)
+		}
+		if c.Size == 3 {
+			complete++
+		}
+	}
+	if complete < 140 {
+		t.Fatalf("recovered %d of 150 stories", complete)
+	}
+}
+
+func TestBuildReadingPlanClustersKeepsLooseStoriesOfExactPass(t *testing.T) {
+	// Noise of 0.6 puts story members near the 0.68 similarity cutoff.
+	items, embByID := syntheticReadingPlanPool(150, 1500, 64, 0.6)
+	countComplete := func(sizes []int) int {
+		n := 0
+		for _, size := range sizes {
+			if size == 3 {
+				n++
+			}
+		}
+		return n
+	}
+	var exact, approx []int
+	for _, g := range greedyReadingPlanGroups(items, unitEmbeddings(embByID)) {
+		exact = append(exact, len(g.members))
+	}
+	for _, c := range buildReadingPlanClusters(items, embByID, nil) {
+		approx = append(approx, c.Size)
+	}
+	if got, want := countComplete(approx), countComplete(exact); got*100 < want*90 {
+		t.Fatalf("approximate pass recovered %d stories, exact pass %d", got, want)
+	}
+}
+
+func TestReadingPlanClusterPartitionsKeepsSmallPoolsExact(t *testing.T) {
+	items, embByID := syntheticReadingPlanPool(10, 50, 64, 0.1)
+	parts := readingPlanClusterPartitions(items, embByID)
+	if len(parts) != 1 || len(parts[0]) != len(items) {
+		t.Fatalf("small pool partitions = %d", len(parts))
+	}
+	if got := len(buildReadingPlanClusters(items, embByID, nil)); got != 10 {
+		t.Fatalf("clusters = %d, want 10", got)
+	}
+}
+
+func BenchmarkBuildReadingPlanClusters5k(b *testing.B) {
+	items, embByID := syntheticReadingPlanPool(500, 3500, 1536, 0.6)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buildReadingPlanClusters(items, embByID, nil)
+	}
+}
+
+func BenchmarkGreedyReadingPlanGroups5kExact(b *testing.B) {
+	items, embByID := syntheticReadingPlanPool(500, 3500, 1536, 0.6)
+	unit := unitEmbeddings(embByID)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		greedyReadingPlanGroups(items, unit)
+	}
+}
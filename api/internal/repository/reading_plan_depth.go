@@ -0,0 +1,78 @@
+package repository
+
+import "github.com/enjoydarts/sifto/api/internal/model"
+
+// Rough reading times per depth, used to fit a reading plan into the user's available time.
+const (
+	newsBriefReadingMinutes    = 3
+	deepDiveReadingMinutes     = 12
+	tutorialReadingMinutes     = 15
+	unknownDepthReadingMinutes = 5
+)
+
+// itemDepthSQL restricts items to the depth bound at placeholder arg. It expects the items
+// alias i.
+func itemDepthSQL(arg int) string {
+	return ` AND EXISTS (
+			SELECT 1 FROM item_summaries dsm
+			WHERE dsm.item_id = i.id AND dsm.depth = $` + itoa(arg) + `
+		)`
+}
+
+func itemReadingMinutes(it model.Item) int {
+	if it.SummaryDepth == nil {
+		return unknownDepthReadingMinutes
+	}
+	switch *it.SummaryDepth {
+	case model.ItemDepthNewsBrief:
+		return newsBriefReadingMinutes
+	case model.ItemDepthDeepDive:
+		return deepDiveReadingMinutes
+	case model.ItemDepthTutorial:
+		return tutorialReadingMinutes
+	}
+	return unknownDepthReadingMinutes
+}
+
+func isDeepRead(it model.Item) bool {
+	return it.SummaryDepth != nil && (*it.SummaryDepth == model.ItemDepthDeepDive || *it.SummaryDepth == model.ItemDepthTutorial)
+}
+
+// fitReadingPlanToTime trims a ranked plan to the available minutes. Pinned items are always
+// kept. Deep dives and tutorials may take at most half of the time, so a short break is filled
+// with quick reads and a longer one gets a mix; time left over is backfilled from lower-ranked
+// candidates in order.
+func fitReadingPlanToTime(selected, candidates []model.Item, minutes, size int) []model.Item {
+	if minutes <= 0 {
+		return selected
+	}
+	deepBudget := minutes / 2
+	used, deepUsed := 0, 0
+	added := make(map[string]bool, size)
+	out := make([]model.Item, 0, len(selected))
+	consider := func(it model.Item) {
+		if added[it.ID] || len(out) >= size {
+			return
+		}
+		cost := itemReadingMinutes(it)
+		deep := isDeepRead(it)
+		if !it.PlanPinned {
+			if used+cost > minutes || (deep && deepUsed+cost > deepBudget) {
+				return
+			}
+		}
+		added[it.ID] = true
+		used += cost
+		if deep {
+			deepUsed += cost
+		}
+		out = append(out, it)
+	}
+	for _, it := range selected {
+		consider(it)
+	}
+	for _, it := range candidates {
+		consider(it)
+	}
+	return out
+}
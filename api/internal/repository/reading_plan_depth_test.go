@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func depthItem(id, depth string) model.Item {
+	return model.Item{ID: id, SummaryDepth: &depth}
+}
+
+func TestFitReadingPlanToTimeShortBreakGetsQuickReads(t *testing.T) {
+	selected := []model.Item{depthItem("deep", model.ItemDepthDeepDive), depthItem("brief1", model.ItemDepthNewsBrief), depthItem("brief2", model.ItemDepthNewsBrief)}
+	got := fitReadingPlanToTime(selected, selected, 10, 15)
+	if ids := strings.Join(itemIDs(got), ","); ids != "brief1,brief2" {
+		t.Fatalf("items = %s, want brief1,brief2", ids)
+	}
+}
+
+func TestFitReadingPlanToTimeMixesDeepReadsAndBackfills(t *testing.T) {
+	selected := []model.Item{
+		depthItem("deep1", model.ItemDepthDeepDive),
+		depthItem("tutorial", model.ItemDepthTutorial),
+		depthItem("brief1", model.ItemDepthNewsBrief),
+	}
+	candidates := append(append([]model.Item{}, selected...), depthItem("brief2", model.ItemDepthNewsBrief), depthItem("brief3", model.ItemDepthNewsBrief), depthItem("brief4", model.ItemDepthNewsBrief))
+
+	got := fitReadingPlanToTime(selected, candidates, 24, 15)
+	// Half of 24 minutes allows one 12-minute deep dive; the tutorial would exceed it.
+	if ids := strings.Join(itemIDs(got), ","); ids != "deep1,brief1,brief2,brief3,brief4" {
+		t.Fatalf("items = %s, want deep1,brief1,brief2,brief3,brief4", ids)
+	}
+}
+
+func TestFitReadingPlanToTimeKeepsPinnedAndRespectsSize(t *testing.T) {
+	pinned := depthItem("pin", model.ItemDepthTutorial)
+	pinned.PlanPinned = true
+	selected := []model.Item{pinned, depthItem("brief1", model.ItemDepthNewsBrief), depthItem("brief2", model.ItemDepthNewsBrief)}
+	got := fitReadingPlanToTime(selected, selected, 5, 2)
+	if ids := strings.Join(itemIDs(got), ","); ids != "pin" {
+		t.Fatalf("items = %s, want pin", ids)
+	}
+	if got := fitReadingPlanToTime(selected, selected, 0, 2); len(got) != len(selected) {
+		t.Fatalf("zero minutes must keep the plan, got %d items", len(got))
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	ReadingPlanOverridePin    = "pin"
+	ReadingPlanOverrideRemove = "remove"
+)
+
+type readingPlanOverrides struct {
+	pinnedIDs  []string
+	removedIDs map[string]struct{}
+}
+
+// SetReadingPlanOverride pins an item into, or removes it from, the user's plan for planDate (JST).
+// A later call for the same item replaces the earlier action.
+func (r *ItemRepo) SetReadingPlanOverride(ctx context.Context, userID, itemID string, planDate time.Time, action string) error {
+	if err := r.ensureOwned(ctx, userID, itemID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO reading_plan_overrides (user_id, item_id, plan_date, action)
+		VALUES ($1, $2, $3::date, $4)
+		ON CONFLICT (user_id, plan_date, item_id) DO UPDATE
+		SET action = EXCLUDED.action,
+		    created_at = CASE
+		      WHEN reading_plan_overrides.action = EXCLUDED.action THEN reading_plan_overrides.created_at
+		      ELSE NOW()
+		    END,
+		    updated_at = NOW()`,
+		userID, itemID, planDate.Format("2006-01-02"), action,
+	)
+	return err
+}
+
+func (r *ItemRepo) loadReadingPlanOverrides(ctx context.Context, userID string, planDate time.Time) (*readingPlanOverrides, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT item_id::text, action
+		FROM reading_plan_overrides
+		WHERE user_id = $1
+		  AND plan_date = $2::date
+		ORDER BY created_at ASC, item_id`,
+		userID, planDate.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &readingPlanOverrides{removedIDs: map[string]struct{}{}}
+	for rows.Next() {
+		var itemID, action string
+		if err := rows.Scan(&itemID, &action); err != nil {
+			return nil, err
+		}
+		switch action {
+		case ReadingPlanOverridePin:
+			out.pinnedIDs = append(out.pinnedIDs, itemID)
+		case ReadingPlanOverrideRemove:
+			out.removedIDs[itemID] = struct{}{}
+		}
+	}
+	return out, rows.Err()
+}
+
+// applyReadingPlanOverrides drops removed and pinned items from the candidate pool; pinned
+// items are merged back ahead of MMR selection by the caller.
+func applyReadingPlanOverrides(candidates []model.Item, overrides *readingPlanOverrides) []model.Item {
+	if overrides == nil || (len(overrides.pinnedIDs) == 0 && len(overrides.removedIDs) == 0) {
+		return candidates
+	}
+	pinned := make(map[string]struct{}, len(overrides.pinnedIDs))
+	for _, id := range overrides.pinnedIDs {
+		pinned[id] = struct{}{}
+	}
+	out := make([]model.Item, 0, len(candidates))
+	for _, it := range candidates {
+		if _, ok := overrides.removedIDs[it.ID]; ok {
+			continue
+		}
+		if _, ok := pinned[it.ID]; ok {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
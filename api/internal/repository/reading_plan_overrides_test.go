@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func planItem(id, sourceID string, score float64) model.Item {
+	return model.Item{ID: id, SourceID: sourceID, PersonalScore: &score}
+}
+
+func TestApplyReadingPlanOverrides(t *testing.T) {
+	candidates := []model.Item{planItem("a", "s1", 0.9), planItem("b", "s1", 0.8), planItem("c", "s2", 0.7)}
+	overrides := &readingPlanOverrides{
+		pinnedIDs:  []string{"c"},
+		removedIDs: map[string]struct{}{"a": {}},
+	}
+
+	got := applyReadingPlanOverrides(candidates, overrides)
+	if len(got) != 1 || got[0].ID != "b" {
+		t.Fatalf("applyReadingPlanOverrides() = %+v, want only b", got)
+	}
+}
+
+func TestSelectItemsByMMRWithPinnedKeepsPinnedFirst(t *testing.T) {
+	pinned := []model.Item{planItem("p1", "s9", 0.1), planItem("p2", "s9", 0.05)}
+	candidates := []model.Item{planItem("a", "s1", 0.9), planItem("b", "s2", 0.8), planItem("c", "s3", 0.7)}
+
+	got := selectItemsByMMRWithPinned(pinned, candidates, 3, false, nil)
+	if len(got) != 3 || got[0].ID != "p1" || got[1].ID != "p2" || got[2].ID != "a" {
+		t.Fatalf("selectItemsByMMRWithPinned() ids = %v, want [p1 p2 a]", itemIDs(got))
+	}
+
+	got = selectItemsByMMRWithPinned(pinned, candidates, 1, false, nil)
+	if len(got) != 2 || got[0].ID != "p1" || got[1].ID != "p2" {
+		t.Fatalf("pinned items must not be evicted when size is smaller, got %v", itemIDs(got))
+	}
+}
+
+func TestSelectItemsByMMRWithoutPinnedMatchesSeedBehavior(t *testing.T) {
+	candidates := []model.Item{planItem("a", "s1", 0.5), planItem("b", "s2", 0.9), planItem("c", "s3", 0.7)}
+
+	got := selectItemsByMMR(candidates, 2, false, nil)
+	if len(got) != 2 || got[0].ID != "b" || got[1].ID != "c" {
+		t.Fatalf("selectItemsByMMR() ids = %v, want [b c]", itemIDs(got))
+	}
+}
+
+func itemIDs(items []model.Item) []string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		out = append(out, it.ID)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReadingPlanSnapshotRepo struct{ db *pgxpool.Pool }
+
+func NewReadingPlanSnapshotRepo(db *pgxpool.Pool) *ReadingPlanSnapshotRepo {
+	return &ReadingPlanSnapshotRepo{db: db}
+}
+
+type ReadingPlanSnapshot struct {
+	UserID      string
+	Params      ReadingPlanParams
+	Status      string
+	Plan        *model.ReadingPlanResponse
+	GeneratedAt time.Time
+}
+
+func (r *ReadingPlanSnapshotRepo) Get(ctx context.Context, userID string) (*ReadingPlanSnapshot, error) {
+	var (
+		s       ReadingPlanSnapshot
+		payload []byte
+	)
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, plan_window, size, diversify_topics, exclude_read, exploration, status, payload_json, generated_at
+		FROM reading_plan_snapshots
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&s.UserID, &s.Params.Window, &s.Params.Size, &s.Params.DiversifyTopics, &s.Params.ExcludeRead, &s.Params.Exploration, &s.Status, &payload, &s.GeneratedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var plan model.ReadingPlanResponse
+	if err := json.Unmarshal(payload, &plan); err != nil {
+		return nil, err
+	}
+	s.Plan = &plan
+	return &s, nil
+}
+
+func (r *ReadingPlanSnapshotRepo) Upsert(ctx context.Context, userID string, params ReadingPlanParams, plan *model.ReadingPlanResponse) error {
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO reading_plan_snapshots (user_id, plan_window, size, diversify_topics, exclude_read, exploration, status, payload_json, generated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 'ready', $7, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+		  plan_window = EXCLUDED.plan_window,
+		  size = EXCLUDED.size,
+		  diversify_topics = EXCLUDED.diversify_topics,
+		  exclude_read = EXCLUDED.exclude_read,
+		  exploration = EXCLUDED.exploration,
+		  status = 'ready',
+		  payload_json = EXCLUDED.payload_json,
+		  generated_at = EXCLUDED.generated_at,
+		  updated_at = NOW()`,
+		userID, params.Window, params.Size, params.DiversifyTopics, params.ExcludeRead, params.Exploration, payload,
+	)
+	return err
+}
+
+func (r *ReadingPlanSnapshotRepo) MarkStale(ctx context.Context, userID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE reading_plan_snapshots
+		SET status = 'stale',
+		    updated_at = NOW()
+		WHERE user_id = $1
+		  AND status <> 'stale'`,
+		userID,
+	)
+	return err
+}
@@ -2,11 +2,30 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	DefaultStreakDailyTarget            = 3
+	DefaultStreakFreezeMonthlyAllowance = 2
+	// A freeze can cover a missed day up to this many days back.
+	StreakFreezeMaxAgeDays    = 7
+	streakFreezeEarnEveryDays = 7
+	maxEarnedStreakFreezes    = 3
+	StreakFreezeSourceMonthly = "monthly"
+	StreakFreezeSourceEarned  = "earned"
+)
+
+var (
+	ErrNoStreakFreezes           = errors.New("no streak freezes left")
+	ErrStreakFreezeNotApplicable = errors.New("day cannot be frozen: it is completed, or the day before it did not keep a streak")
+)
+
 type ReadingStreakRepo struct{ db *pgxpool.Pool }
 
 func NewReadingStreakRepo(db *pgxpool.Pool) *ReadingStreakRepo { return &ReadingStreakRepo{db: db} }
@@ -24,14 +43,16 @@ func (r *ReadingStreakRepo) GetByUserAndDate(ctx context.Context, userID, date s
 	return readCount, streakDays, isCompleted, nil
 }
 
-func (r *ReadingStreakRepo) IncrementRead(ctx context.Context, userID string, date time.Time, minReadCount int) error {
-	if minReadCount <= 0 {
-		minReadCount = 3
-	}
+// IncrementRead counts one read for the JST day toward the user's daily target. Reaching the
+// target on every streakFreezeEarnEveryDays-th day of a streak earns a freeze token.
+func (r *ReadingStreakRepo) IncrementRead(ctx context.Context, userID string, date time.Time) error {
 	dateStr := date.Format("2006-01-02")
 	prevDateStr := date.AddDate(0, 0, -1).Format("2006-01-02")
-	_, err := r.db.Exec(ctx, `
-		WITH prev AS (
+	var readCount, streakDays, target int
+	err := r.db.QueryRow(ctx, `
+		WITH target AS (
+			SELECT COALESCE((SELECT streak_daily_target FROM user_settings WHERE user_id = $1), $4) AS n
+		), prev AS (
 			SELECT streak_days, is_completed
 			FROM reading_streaks
 			WHERE user_id = $1 AND streak_date = $2
@@ -42,7 +63,7 @@ func (r *ReadingStreakRepo) IncrementRead(ctx context.Context, userID string, da
 		  $3,
 		  1,
 		  CASE
-		    WHEN 1 >= $4 THEN
+		    WHEN 1 >= (SELECT n FROM target) THEN
 		      CASE
 		        WHEN EXISTS (SELECT 1 FROM prev WHERE is_completed = true)
 		          THEN COALESCE((SELECT streak_days FROM prev), 0) + 1
@@ -55,13 +76,13 @@ func (r *ReadingStreakRepo) IncrementRead(ctx context.Context, userID string, da
 		        ELSE 0
 		      END
 		  END,
-		  (1 >= $4)
+		  (1 >= (SELECT n FROM target))
 		)
 		ON CONFLICT (user_id, streak_date) DO UPDATE SET
 		  read_count = reading_streaks.read_count + 1,
-		  is_completed = (reading_streaks.read_count + 1) >= $4,
+		  is_completed = reading_streaks.is_completed OR (reading_streaks.read_count + 1) >= (SELECT n FROM target),
 		  streak_days = CASE
-		    WHEN (reading_streaks.read_count + 1) >= $4 THEN
+		    WHEN reading_streaks.freeze_source IS NULL AND (reading_streaks.read_count + 1) >= (SELECT n FROM target) THEN
 		      CASE
 		        WHEN EXISTS (SELECT 1 FROM prev WHERE is_completed = true)
 		          THEN COALESCE((SELECT streak_days FROM prev), 0) + 1
@@ -69,8 +90,218 @@ func (r *ReadingStreakRepo) IncrementRead(ctx context.Context, userID string, da
 		      END
 		    ELSE reading_streaks.streak_days
 		  END,
-		  updated_at = NOW()`,
-		userID, prevDateStr, dateStr, minReadCount,
+		  updated_at = NOW()
+		RETURNING read_count, streak_days, (SELECT n FROM target)`,
+		userID, prevDateStr, dateStr, DefaultStreakDailyTarget,
+	).Scan(&readCount, &streakDays, &target)
+	if err != nil {
+		return err
+	}
+	if readCount != target || streakDays <= 0 || streakDays%streakFreezeEarnEveryDays != 0 {
+		return nil
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO reading_streak_freeze_balances (user_id, earned)
+		VALUES ($1, 1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET earned = LEAST(reading_streak_freeze_balances.earned + 1, $2),
+		    updated_at = NOW()`,
+		userID, maxEarnedStreakFreezes,
 	)
 	return err
 }
+
+// DailyTarget returns the reads per JST day that complete a streak day for the user.
+func (r *ReadingStreakRepo) DailyTarget(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT streak_daily_target FROM user_settings WHERE user_id = $1), $2)`,
+		userID, DefaultStreakDailyTarget,
+	).Scan(&n)
+	return n, err
+}
+
+// ListDays returns the user's streak rows between from and to (JST dates, inclusive), oldest
+// first. Days without reads have no row.
+func (r *ReadingStreakRepo) ListDays(ctx context.Context, userID string, from, to time.Time) ([]model.ReadingStreakDay, error) {
+	return listStreakDays(ctx, r.db, userID, from, to, false)
+}
+
+func listStreakDays(ctx context.Context, q interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}, userID string, from, to time.Time, forUpdate bool) ([]model.ReadingStreakDay, error) {
+	sql := `
+		SELECT streak_date, read_count, streak_days, is_completed, freeze_source
+		FROM reading_streaks
+		WHERE user_id = $1 AND streak_date BETWEEN $2 AND $3
+		ORDER BY streak_date`
+	if forUpdate {
+		sql += ` FOR UPDATE`
+	}
+	rows, err := q.Query(ctx, sql, userID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []model.ReadingStreakDay
+	for rows.Next() {
+		var v model.ReadingStreakDay
+		var date time.Time
+		if err := rows.Scan(&date, &v.ReadCount, &v.StreakDays, &v.IsCompleted, &v.FreezeSource); err != nil {
+			return nil, err
+		}
+		v.Date = date.Format("2006-01-02")
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// FreezeBalance returns the monthly allowance, how many monthly freezes were used in the JST
+// month containing month, and the earned tokens left.
+func (r *ReadingStreakRepo) FreezeBalance(ctx context.Context, userID string, month time.Time) (model.StreakFreezeBalance, error) {
+	var b model.StreakFreezeBalance
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT streak_freeze_monthly_allowance FROM user_settings WHERE user_id = $1), $3),
+		       (SELECT COUNT(*)::int FROM reading_streaks
+		        WHERE user_id = $1 AND freeze_source = 'monthly'
+		          AND date_trunc('month', streak_date) = date_trunc('month', $2::date)),
+		       COALESCE((SELECT earned FROM reading_streak_freeze_balances WHERE user_id = $1), 0)`,
+		userID, month.Format("2006-01-02"), DefaultStreakFreezeMonthlyAllowance,
+	).Scan(&b.MonthlyAllowance, &b.MonthlyUsed, &b.Earned)
+	if err != nil {
+		return b, err
+	}
+	b.MonthlyRemaining = max(b.MonthlyAllowance-b.MonthlyUsed, 0)
+	b.Available = b.MonthlyRemaining + b.Earned
+	return b, nil
+}
+
+// CanFreezeStreakDay reports whether day (nil when the user did not read at all) can be frozen:
+// it must not be completed, and prev, the day before, must have kept the streak going.
+func CanFreezeStreakDay(prev, day *model.ReadingStreakDay) bool {
+	if prev == nil || !prev.IsCompleted || prev.StreakDays <= 0 {
+		return false
+	}
+	return day == nil || !day.IsCompleted
+}
+
+// RecomputeStreakDays redoes streak_days for days after start, following the IncrementRead
+// rules: a completed day extends the previous day's streak if that was completed and otherwise
+// starts at 1, a frozen day carries the streak without extending it, and an incomplete day
+// keeps the previous streak or 0. days must be consecutive dates after start; a missing date
+// breaks the chain.
+func RecomputeStreakDays(start model.ReadingStreakDay, days []model.ReadingStreakDay) []model.ReadingStreakDay {
+	out := make([]model.ReadingStreakDay, len(days))
+	prev := start
+	prevDate, _ := time.Parse("2006-01-02", start.Date)
+	for i, d := range days {
+		date, _ := time.Parse("2006-01-02", d.Date)
+		prevKept := prev.IsCompleted && date.Equal(prevDate.AddDate(0, 0, 1))
+		switch {
+		case d.FreezeSource != nil:
+			d.StreakDays = 0
+			if prevKept {
+				d.StreakDays = prev.StreakDays
+			}
+		case d.IsCompleted:
+			d.StreakDays = 1
+			if prevKept {
+				d.StreakDays = prev.StreakDays + 1
+			}
+		default:
+			d.StreakDays = 0
+			if prevKept {
+				d.StreakDays = prev.StreakDays
+			}
+		}
+		out[i] = d
+		prev, prevDate = d, date
+	}
+	return out
+}
+
+// ApplyFreeze covers a missed JST day with a freeze token, monthly ones first, so the streak
+// carries over it, and recomputes the streak of the days after it up to today.
+func (r *ReadingStreakRepo) ApplyFreeze(ctx context.Context, userID string, date, today time.Time) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize freezes per user so two requests cannot spend the same token.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO reading_streak_freeze_balances (user_id) VALUES ($1)
+		ON CONFLICT (user_id) DO UPDATE SET updated_at = reading_streak_freeze_balances.updated_at`, userID); err != nil {
+		return "", err
+	}
+	var earned int
+	if err := tx.QueryRow(ctx, `SELECT earned FROM reading_streak_freeze_balances WHERE user_id = $1 FOR UPDATE`, userID).Scan(&earned); err != nil {
+		return "", err
+	}
+	days, err := listStreakDays(ctx, tx, userID, date.AddDate(0, 0, -1), today, true)
+	if err != nil {
+		return "", err
+	}
+	dateStr := date.Format("2006-01-02")
+	var prev, day *model.ReadingStreakDay
+	var after []model.ReadingStreakDay
+	for i := range days {
+		switch {
+		case days[i].Date < dateStr:
+			prev = &days[i]
+		case days[i].Date == dateStr:
+			day = &days[i]
+		default:
+			after = append(after, days[i])
+		}
+	}
+	if !CanFreezeStreakDay(prev, day) {
+		return "", ErrStreakFreezeNotApplicable
+	}
+
+	var allowance, monthlyUsed int
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE((SELECT streak_freeze_monthly_allowance FROM user_settings WHERE user_id = $1), $3),
+		       (SELECT COUNT(*)::int FROM reading_streaks
+		        WHERE user_id = $1 AND freeze_source = 'monthly'
+		          AND date_trunc('month', streak_date) = date_trunc('month', $2::date))`,
+		userID, dateStr, DefaultStreakFreezeMonthlyAllowance,
+	).Scan(&allowance, &monthlyUsed); err != nil {
+		return "", err
+	}
+	source := StreakFreezeSourceMonthly
+	switch {
+	case monthlyUsed < allowance:
+	case earned > 0:
+		source = StreakFreezeSourceEarned
+		if _, err := tx.Exec(ctx, `
+			UPDATE reading_streak_freeze_balances SET earned = earned - 1, updated_at = NOW()
+			WHERE user_id = $1`, userID); err != nil {
+			return "", err
+		}
+	default:
+		return "", ErrNoStreakFreezes
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO reading_streaks (user_id, streak_date, read_count, streak_days, is_completed, freeze_source)
+		VALUES ($1, $2, 0, $3, true, $4)
+		ON CONFLICT (user_id, streak_date) DO UPDATE
+		SET streak_days = EXCLUDED.streak_days,
+		    is_completed = true,
+		    freeze_source = EXCLUDED.freeze_source,
+		    updated_at = NOW()`,
+		userID, dateStr, prev.StreakDays, source); err != nil {
+		return "", err
+	}
+	frozen := model.ReadingStreakDay{Date: dateStr, StreakDays: prev.StreakDays, IsCompleted: true, FreezeSource: &source}
+	for _, d := range RecomputeStreakDays(frozen, after) {
+		if _, err := tx.Exec(ctx, `
+			UPDATE reading_streaks SET streak_days = $3, updated_at = NOW()
+			WHERE user_id = $1 AND streak_date = $2`, userID, d.Date, d.StreakDays); err != nil {
+			return "", err
+		}
+	}
+	return source, tx.Commit(ctx)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestCanFreezeStreakDay(t *testing.T) {
+	kept := &model.ReadingStreakDay{Date: "2026-03-09", IsCompleted: true, StreakDays: 4}
+	cases := []struct {
+		name string
+		prev *model.ReadingStreakDay
+		day  *model.ReadingStreakDay
+		want bool
+	}{
+		{"missing day after completed", kept, nil, true},
+		{"incomplete day after completed", kept, &model.ReadingStreakDay{Date: "2026-03-10", ReadCount: 1}, true},
+		{"completed day", kept, &model.ReadingStreakDay{Date: "2026-03-10", IsCompleted: true, StreakDays: 5}, false},
+		{"no previous day", nil, nil, false},
+		{"previous day incomplete", &model.ReadingStreakDay{Date: "2026-03-09", ReadCount: 2}, nil, false},
+	}
+	for _, tc := range cases {
+		if got := CanFreezeStreakDay(tc.prev, tc.day); got != tc.want {
+			t.Fatalf("%s: CanFreezeStreakDay() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRecomputeStreakDaysCarriesFrozenDays(t *testing.T) {
+	source := StreakFreezeSourceMonthly
+	start := model.ReadingStreakDay{Date: "2026-03-09", IsCompleted: true, StreakDays: 4}
+	got := RecomputeStreakDays(start, []model.ReadingStreakDay{
+		{Date: "2026-03-10", IsCompleted: true, FreezeSource: &source},
+		{Date: "2026-03-11", IsCompleted: true, StreakDays: 1},
+		{Date: "2026-03-12", ReadCount: 1},
+		{Date: "2026-03-14", IsCompleted: true, StreakDays: 9},
+	})
+	want := []int{4, 5, 5, 1}
+	for i, d := range got {
+		if d.StreakDays != want[i] {
+			t.Fatalf("day %s streak_days = %d, want %d", d.Date, d.StreakDays, want[i])
+		}
+	}
+}
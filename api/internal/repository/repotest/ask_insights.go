@@ -0,0 +1,70 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type AskInsightStore struct {
+	mu       sync.Mutex
+	insights []model.AskInsight
+	Err      error
+}
+
+func NewAskInsightStore() *AskInsightStore {
+	return &AskInsightStore{}
+}
+
+func (s *AskInsightStore) Save(_ context.Context, insight model.AskInsight, itemIDs []string) (model.AskInsight, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return model.AskInsight{}, s.Err
+	}
+	now := time.Now()
+	insight.ID = nextID("insight")
+	insight.CreatedAt, insight.UpdatedAt = now, now
+	insight.Items = make([]model.AskInsightItemRef, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		insight.Items = append(insight.Items, model.AskInsightItemRef{ItemID: id})
+	}
+	s.insights = append(s.insights, insight)
+	return insight, nil
+}
+
+func (s *AskInsightStore) ListRecent(_ context.Context, userID string, limit int) ([]model.AskInsight, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if limit <= 0 {
+		limit = 8
+	}
+	out := []model.AskInsight{}
+	for i := len(s.insights) - 1; i >= 0 && len(out) < limit; i-- {
+		if s.insights[i].UserID == userID {
+			out = append(out, s.insights[i])
+		}
+	}
+	return out, nil
+}
+
+func (s *AskInsightStore) Delete(_ context.Context, userID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	for i, insight := range s.insights {
+		if insight.ID == id && insight.UserID == userID {
+			s.insights = append(s.insights[:i], s.insights[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
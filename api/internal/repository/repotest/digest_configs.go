@@ -0,0 +1,122 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type DigestConfigStore struct {
+	mu      sync.Mutex
+	configs map[string]model.DigestConfig
+	sources map[string][]string
+	Err     error
+}
+
+func NewDigestConfigStore() *DigestConfigStore {
+	return &DigestConfigStore{configs: map[string]model.DigestConfig{}, sources: map[string][]string{}}
+}
+
+// AddSource registers sourceID as owned by userID for CountOwnedSources.
+func (s *DigestConfigStore) AddSource(userID, sourceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources[userID] = append(s.sources[userID], sourceID)
+}
+
+func (s *DigestConfigStore) ListByUser(_ context.Context, userID string) ([]model.DigestConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := []model.DigestConfig{}
+	for _, c := range s.configs {
+		if c.UserID == userID {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (s *DigestConfigStore) Get(_ context.Context, userID, id string) (*model.DigestConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	c, ok := s.configs[id]
+	if !ok || c.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	return &c, nil
+}
+
+func (s *DigestConfigStore) CountOwnedSources(_ context.Context, userID string, sourceIDs []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return 0, s.Err
+	}
+	n := 0
+	for _, id := range sourceIDs {
+		if slices.Contains(s.sources[userID], id) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *DigestConfigStore) Create(_ context.Context, c model.DigestConfig) (*model.DigestConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for _, existing := range s.configs {
+		if existing.UserID == c.UserID && existing.Name == c.Name {
+			return nil, repository.ErrConflict
+		}
+	}
+	now := time.Now()
+	c.ID = nextID("digest-config")
+	c.CreatedAt, c.UpdatedAt = now, now
+	s.configs[c.ID] = c
+	return &c, nil
+}
+
+func (s *DigestConfigStore) Update(_ context.Context, c model.DigestConfig) (*model.DigestConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	existing, ok := s.configs[c.ID]
+	if !ok || existing.UserID != c.UserID {
+		return nil, repository.ErrNotFound
+	}
+	c.CreatedAt = existing.CreatedAt
+	c.UpdatedAt = time.Now()
+	s.configs[c.ID] = c
+	return &c, nil
+}
+
+func (s *DigestConfigStore) Delete(_ context.Context, userID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	c, ok := s.configs[id]
+	if !ok || c.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(s.configs, id)
+	return nil
+}
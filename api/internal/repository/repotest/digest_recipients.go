@@ -0,0 +1,200 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type digestRecipientToken struct {
+	hash      string
+	expiresAt time.Time
+}
+
+type DigestRecipientStore struct {
+	mu         sync.Mutex
+	recipients map[string]model.DigestRecipient
+	tokens     map[string]digestRecipientToken
+	configs    map[string]string
+	deliveries map[string][]model.DigestDelivery
+	Err        error
+}
+
+func NewDigestRecipientStore() *DigestRecipientStore {
+	return &DigestRecipientStore{
+		recipients: map[string]model.DigestRecipient{},
+		tokens:     map[string]digestRecipientToken{},
+		configs:    map[string]string{},
+		deliveries: map[string][]model.DigestDelivery{},
+	}
+}
+
+// AddConfig registers configID as owned by userID for ConfigOwned.
+func (s *DigestRecipientStore) AddConfig(userID, configID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configs[configID] = userID
+}
+
+// AddDelivery records a delivery of a digest owned by userID for ListDeliveries.
+func (s *DigestRecipientStore) AddDelivery(userID string, d model.DigestDelivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[userID+"/"+d.DigestID] = append(s.deliveries[userID+"/"+d.DigestID], d)
+}
+
+func (s *DigestRecipientStore) ListByUser(_ context.Context, userID string) ([]model.DigestRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := []model.DigestRecipient{}
+	for _, rec := range s.recipients {
+		if rec.UserID == userID {
+			out = append(out, rec)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *DigestRecipientStore) CountForDigest(_ context.Context, userID string, configID *string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return 0, s.Err
+	}
+	n := 0
+	for _, rec := range s.recipients {
+		if rec.UserID == userID && sameConfig(rec.DigestConfigID, configID) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *DigestRecipientStore) ConfigOwned(_ context.Context, userID, configID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return false, s.Err
+	}
+	return s.configs[configID] == userID, nil
+}
+
+func (s *DigestRecipientStore) Create(_ context.Context, userID string, configID *string, email string) (*model.DigestRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for _, rec := range s.recipients {
+		if rec.UserID == userID && sameConfig(rec.DigestConfigID, configID) && strings.EqualFold(rec.Email, email) {
+			return nil, repository.ErrConflict
+		}
+	}
+	rec := model.DigestRecipient{
+		ID:             nextID("recipient"),
+		UserID:         userID,
+		DigestConfigID: configID,
+		Email:          email,
+		Status:         "pending",
+		CreatedAt:      time.Now(),
+	}
+	s.recipients[rec.ID] = rec
+	return &rec, nil
+}
+
+func (s *DigestRecipientStore) Get(_ context.Context, userID, id string) (*model.DigestRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	rec, ok := s.recipients[id]
+	if !ok || rec.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	return &rec, nil
+}
+
+func (s *DigestRecipientStore) Delete(_ context.Context, userID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	rec, ok := s.recipients[id]
+	if !ok || rec.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(s.recipients, id)
+	delete(s.tokens, id)
+	return nil
+}
+
+func (s *DigestRecipientStore) IssueConfirmToken(_ context.Context, id, tokenHash string, expiresAt time.Time) (*model.DigestRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	rec, ok := s.recipients[id]
+	if !ok || rec.Status != "pending" {
+		return nil, repository.ErrNotFound
+	}
+	now := time.Now()
+	rec.ConfirmSentAt = &now
+	s.recipients[id] = rec
+	s.tokens[id] = digestRecipientToken{hash: tokenHash, expiresAt: expiresAt}
+	return &rec, nil
+}
+
+func (s *DigestRecipientStore) ConfirmByTokenHash(_ context.Context, tokenHash string, now time.Time) (*model.DigestRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for id, tok := range s.tokens {
+		if tok.hash != tokenHash {
+			continue
+		}
+		if !now.Before(tok.expiresAt) {
+			return nil, repository.ErrNotFound
+		}
+		rec := s.recipients[id]
+		rec.Status = "confirmed"
+		rec.ConfirmedAt = &now
+		s.recipients[id] = rec
+		delete(s.tokens, id)
+		return &rec, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s *DigestRecipientStore) ListDeliveries(_ context.Context, userID, digestID string) ([]model.DigestDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out, ok := s.deliveries[userID+"/"+digestID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return out, nil
+}
+
+func sameConfig(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,96 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// EntityStore holds entities and the item ids mentioning them, scoped per user.
+type EntityStore struct {
+	mu       sync.Mutex
+	entities map[string]map[string]model.Entity
+	itemIDs  map[string]map[string][]string
+	Err      error
+}
+
+func NewEntityStore() *EntityStore {
+	return &EntityStore{entities: map[string]map[string]model.Entity{}, itemIDs: map[string]map[string][]string{}}
+}
+
+// Add registers entity for userID with the given item ids, newest first.
+func (s *EntityStore) Add(userID string, e model.Entity, itemIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entities[userID] == nil {
+		s.entities[userID] = map[string]model.Entity{}
+		s.itemIDs[userID] = map[string][]string{}
+	}
+	if e.ID == "" {
+		e.ID = nextID("entity")
+	}
+	e.ItemCount = len(itemIDs)
+	s.entities[userID][e.ID] = e
+	s.itemIDs[userID][e.ID] = itemIDs
+}
+
+func (s *EntityStore) ListByUser(_ context.Context, userID, query, kind string, limit int) ([]model.Entity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	q := strings.ToLower(strings.TrimSpace(query))
+	out := []model.Entity{}
+	for _, e := range s.entities[userID] {
+		if kind != "" && e.Kind != kind {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(e.Name), q) {
+			continue
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ItemCount != out[j].ItemCount {
+			return out[i].ItemCount > out[j].ItemCount
+		}
+		return out[i].Name < out[j].Name
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *EntityStore) GetForUser(_ context.Context, userID, entityID string) (*model.Entity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	e, ok := s.entities[userID][entityID]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (s *EntityStore) ItemIDs(_ context.Context, userID, entityID string, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	ids := s.itemIDs[userID][entityID]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return append([]string(nil), ids...), nil
+}
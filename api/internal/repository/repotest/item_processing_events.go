@@ -0,0 +1,63 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// ItemProcessingEventStore keeps processing traces for items registered with Own.
+type ItemProcessingEventStore struct {
+	mu     sync.Mutex
+	owner  map[string]string
+	events []model.ItemProcessingEvent
+	Err    error
+}
+
+func NewItemProcessingEventStore() *ItemProcessingEventStore {
+	return &ItemProcessingEventStore{owner: map[string]string{}}
+}
+
+func (s *ItemProcessingEventStore) Own(userID, itemID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.owner[itemID] = userID
+}
+
+func (s *ItemProcessingEventStore) Insert(_ context.Context, ev model.ItemProcessingEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	if ev.ID == "" {
+		ev.ID = nextID("trace")
+	}
+	s.events = append(s.events, ev)
+	return nil
+}
+
+func (s *ItemProcessingEventStore) ListByItem(_ context.Context, userID, itemID string, limit int) ([]model.ItemProcessingEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if owner, ok := s.owner[itemID]; !ok || owner != userID {
+		return nil, repository.ErrNotFound
+	}
+	out := []model.ItemProcessingEvent{}
+	for _, ev := range s.events {
+		if ev.ItemID == itemID {
+			out = append(out, ev)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartedAt.Before(out[j].StartedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// ItemStore keeps items, read state and soft deletes in memory. It satisfies
+// repository.ItemReadWriter; methods without an in-memory implementation fall through to
+// the nil embedded interface and panic, so a test that reaches one fails loudly.
+type ItemStore struct {
+	repository.ItemReadWriter
+
+	mu      sync.Mutex
+	items   map[string]model.Item
+	owner   map[string]string
+	read    map[string]bool
+	deleted map[string]bool
+	Err     error
+}
+
+func NewItemStore() *ItemStore {
+	return &ItemStore{
+		items:   map[string]model.Item{},
+		owner:   map[string]string{},
+		read:    map[string]bool{},
+		deleted: map[string]bool{},
+	}
+}
+
+func (s *ItemStore) Add(userID string, it model.Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[it.ID] = it
+	s.owner[it.ID] = userID
+}
+
+// IsRead reports whether MarkRead has recorded itemID as read.
+func (s *ItemStore) IsRead(itemID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read[itemID]
+}
+
+// IsDeleted reports whether itemID is soft-deleted.
+func (s *ItemStore) IsDeleted(itemID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleted[itemID]
+}
+
+// owned must be called with mu held.
+func (s *ItemStore) owned(userID, itemID string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	if _, ok := s.items[itemID]; !ok || s.owner[itemID] != userID {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (s *ItemStore) LoadByIDsPreservingOrder(_ context.Context, userID string, ids []string) ([]model.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := make([]model.Item, 0, len(ids))
+	for _, id := range ids {
+		if it, ok := s.items[id]; ok && s.owner[id] == userID && !s.deleted[id] {
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+func (s *ItemStore) Stats(_ context.Context, userID string) (*model.ItemStatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := &model.ItemStatsResponse{ByStatus: map[string]int{}}
+	for id, it := range s.items {
+		if s.owner[id] != userID || s.deleted[id] {
+			continue
+		}
+		out.Total++
+		out.ByStatus[it.Status]++
+		if s.read[id] {
+			out.Read++
+		} else {
+			out.Unread++
+		}
+	}
+	return out, nil
+}
+
+// MarkRead reports whether the read was newly recorded, like the real repository.
+func (s *ItemStore) MarkRead(_ context.Context, userID, itemID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.owned(userID, itemID); err != nil {
+		return false, err
+	}
+	if s.read[itemID] {
+		return false, nil
+	}
+	s.read[itemID] = true
+	return true, nil
+}
+
+func (s *ItemStore) MarkUnread(_ context.Context, userID, itemID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.owned(userID, itemID); err != nil {
+		return err
+	}
+	delete(s.read, itemID)
+	return nil
+}
+
+func (s *ItemStore) Delete(_ context.Context, itemID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.owned(userID, itemID); err != nil {
+		return err
+	}
+	s.deleted[itemID] = true
+	return nil
+}
@@ -0,0 +1,15 @@
+// Package repotest provides in-memory fakes of the repositories so handlers can be tested
+// without a database. The fakes follow the not-found and conflict semantics of the real
+// repositories; set Err to make every call fail.
+package repotest
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var idSeq atomic.Int64
+
+func nextID(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, idSeq.Add(1))
+}
@@ -0,0 +1,142 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type ReviewQueueStore struct {
+	mu    sync.Mutex
+	items map[string]model.ReviewQueueItem
+	Err   error
+}
+
+func NewReviewQueueStore(items ...model.ReviewQueueItem) *ReviewQueueStore {
+	s := &ReviewQueueStore{items: map[string]model.ReviewQueueItem{}}
+	for _, it := range items {
+		if it.ID == "" {
+			it.ID = nextID("review")
+		}
+		if it.Status == "" {
+			it.Status = "pending"
+		}
+		s.items[it.ID] = it
+	}
+	return s
+}
+
+// Item returns the current state of a queue entry.
+func (s *ReviewQueueStore) Item(id string) (model.ReviewQueueItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[id]
+	return it, ok
+}
+
+func (s *ReviewQueueStore) ListDue(_ context.Context, userID string, now time.Time, limit int) ([]model.ReviewQueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if limit <= 0 {
+		limit = 5
+	}
+	out := []model.ReviewQueueItem{}
+	for _, it := range s.items {
+		if it.UserID == userID && it.Status == "pending" && !it.ReviewDueAt.After(now) {
+			out = append(out, it)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ReviewDueAt.Before(out[j].ReviewDueAt) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *ReviewQueueStore) MarkDone(_ context.Context, userID, queueID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	it, ok := s.items[queueID]
+	if !ok || it.UserID != userID {
+		return repository.ErrNotFound
+	}
+	now := time.Now()
+	it.Status = "done"
+	it.CompletedAt = &now
+	s.items[queueID] = it
+	return nil
+}
+
+func (s *ReviewQueueStore) Snooze(_ context.Context, userID, queueID string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	it, ok := s.items[queueID]
+	if !ok || it.UserID != userID || it.Status != "pending" {
+		return repository.ErrNotFound
+	}
+	it.ReviewDueAt = it.ReviewDueAt.Add(duration)
+	it.SnoozeCount++
+	s.items[queueID] = it
+	return nil
+}
+
+// WeeklyReviewStore returns fixed inputs and keeps the last upserted snapshot per user.
+type WeeklyReviewStore struct {
+	mu        sync.Mutex
+	Inputs    WeeklyReviewInputs
+	snapshots map[string]model.WeeklyReviewSnapshot
+	Err       error
+}
+
+type WeeklyReviewInputs struct {
+	ReadCount     int
+	NoteCount     int
+	InsightCount  int
+	FavoriteCount int
+	Topics        []model.WeeklyReviewTopic
+	Missed        []model.Item
+}
+
+func NewWeeklyReviewStore(inputs WeeklyReviewInputs) *WeeklyReviewStore {
+	return &WeeklyReviewStore{Inputs: inputs, snapshots: map[string]model.WeeklyReviewSnapshot{}}
+}
+
+func (s *WeeklyReviewStore) CollectInputs(_ context.Context, _, _, _ string) (readCount, noteCount, insightCount, favoriteCount int, topics []model.WeeklyReviewTopic, missed []model.Item, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return 0, 0, 0, 0, nil, nil, s.Err
+	}
+	in := s.Inputs
+	return in.ReadCount, in.NoteCount, in.InsightCount, in.FavoriteCount, in.Topics, in.Missed, nil
+}
+
+func (s *WeeklyReviewStore) Upsert(_ context.Context, userID string, snapshot model.WeeklyReviewSnapshot) (*model.WeeklyReviewSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if prev, ok := s.snapshots[userID]; ok && prev.WeekStart == snapshot.WeekStart {
+		snapshot.ID = prev.ID
+	} else {
+		snapshot.ID = nextID("weekly-review")
+	}
+	snapshot.UserID = userID
+	snapshot.CreatedAt = time.Now()
+	s.snapshots[userID] = snapshot
+	return &snapshot, nil
+}
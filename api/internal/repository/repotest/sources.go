@@ -0,0 +1,115 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// SourceStore keeps sources in memory. It satisfies repository.SourceReadWriter; methods
+// without an in-memory implementation fall through to the nil embedded interface and panic.
+type SourceStore struct {
+	repository.SourceReadWriter
+
+	mu      sync.Mutex
+	sources map[string]model.Source
+	Err     error
+}
+
+func NewSourceStore() *SourceStore {
+	return &SourceStore{sources: map[string]model.Source{}}
+}
+
+func (s *SourceStore) Add(src model.Source) model.Source {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if src.ID == "" {
+		src.ID = nextID("source")
+	}
+	s.sources[src.ID] = src
+	return src
+}
+
+// get must be called with mu held.
+func (s *SourceStore) get(id, userID string) (model.Source, error) {
+	if s.Err != nil {
+		return model.Source{}, s.Err
+	}
+	src, ok := s.sources[id]
+	if !ok || src.UserID != userID {
+		return model.Source{}, repository.ErrNotFound
+	}
+	return src, nil
+}
+
+func (s *SourceStore) List(_ context.Context, userID string) ([]model.Source, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := []model.Source{}
+	for _, src := range s.sources {
+		if src.UserID == userID {
+			out = append(out, src)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *SourceStore) Update(_ context.Context, id, userID string, enabled *bool, updateTitle bool, title *string) (*model.Source, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, err := s.get(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled != nil {
+		src.Enabled = *enabled
+	}
+	if updateTitle {
+		src.Title = title
+	}
+	src.UpdatedAt = time.Now()
+	s.sources[id] = src
+	return &src, nil
+}
+
+func (s *SourceStore) SetGroupName(_ context.Context, id, userID string, groupName *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, err := s.get(id, userID)
+	if err != nil {
+		return err
+	}
+	src.GroupName = groupName
+	s.sources[id] = src
+	return nil
+}
+
+func (s *SourceStore) SetDefaultTopics(_ context.Context, id, userID string, topics []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, err := s.get(id, userID)
+	if err != nil {
+		return err
+	}
+	src.DefaultTopics = topics
+	s.sources[id] = src
+	return nil
+}
+
+func (s *SourceStore) Delete(_ context.Context, id, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.get(id, userID); err != nil {
+		return err
+	}
+	delete(s.sources, id)
+	return nil
+}
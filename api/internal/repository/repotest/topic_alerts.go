@@ -0,0 +1,126 @@
+package repotest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type TopicAlertStore struct {
+	mu   sync.Mutex
+	subs map[string]model.TopicAlertSubscription
+	logs map[string][]model.TopicAlertLog
+	Err  error
+}
+
+func NewTopicAlertStore() *TopicAlertStore {
+	return &TopicAlertStore{subs: map[string]model.TopicAlertSubscription{}, logs: map[string][]model.TopicAlertLog{}}
+}
+
+// AddLog records an alert log for userID, newest last.
+func (s *TopicAlertStore) AddLog(userID string, l model.TopicAlertLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l.ID == "" {
+		l.ID = nextID("alert-log")
+	}
+	s.logs[userID] = append(s.logs[userID], l)
+}
+
+func (s *TopicAlertStore) ListByUser(_ context.Context, userID string) ([]model.TopicAlertSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	out := []model.TopicAlertSubscription{}
+	for _, sub := range s.subs {
+		if sub.UserID == userID {
+			out = append(out, sub)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Topic < out[j].Topic })
+	return out, nil
+}
+
+func (s *TopicAlertStore) ListLogs(_ context.Context, userID string, limit int) ([]model.TopicAlertLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	logs := s.logs[userID]
+	out := []model.TopicAlertLog{}
+	for i := len(logs) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, logs[i])
+	}
+	return out, nil
+}
+
+func (s *TopicAlertStore) Get(_ context.Context, userID, id string) (*model.TopicAlertSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	sub, ok := s.subs[id]
+	if !ok || sub.UserID != userID {
+		return nil, nil
+	}
+	return &sub, nil
+}
+
+func (s *TopicAlertStore) Create(_ context.Context, sub model.TopicAlertSubscription) (*model.TopicAlertSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for _, existing := range s.subs {
+		if existing.UserID == sub.UserID && existing.Topic == sub.Topic {
+			return nil, repository.ErrConflict
+		}
+	}
+	now := time.Now()
+	sub.ID = nextID("topic-alert")
+	sub.CreatedAt, sub.UpdatedAt = now, now
+	s.subs[sub.ID] = sub
+	return &sub, nil
+}
+
+func (s *TopicAlertStore) Update(_ context.Context, sub model.TopicAlertSubscription) (*model.TopicAlertSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	existing, ok := s.subs[sub.ID]
+	if !ok || existing.UserID != sub.UserID {
+		return nil, repository.ErrNotFound
+	}
+	existing.MinDelta = sub.MinDelta
+	existing.Channels = sub.Channels
+	existing.WebhookURL = sub.WebhookURL
+	existing.Enabled = sub.Enabled
+	existing.UpdatedAt = time.Now()
+	s.subs[sub.ID] = existing
+	return &existing, nil
+}
+
+func (s *TopicAlertStore) Delete(_ context.Context, userID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	sub, ok := s.subs[id]
+	if !ok || sub.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(s.subs, id)
+	return nil
+}
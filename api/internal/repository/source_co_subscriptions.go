@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SourceCoSubscriptionRepo struct{ db *pgxpool.Pool }
+
+func NewSourceCoSubscriptionRepo(db *pgxpool.Pool) *SourceCoSubscriptionRepo {
+	return &SourceCoSubscriptionRepo{db}
+}
+
+// ListSharedFeedURLs returns the RSS source URLs of every user who opted in to source
+// statistics, keyed by user. The user IDs never leave the statistics rebuild.
+func (r *SourceCoSubscriptionRepo) ListSharedFeedURLs(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.user_id, s.url
+		FROM sources s
+		JOIN user_settings us ON us.user_id = s.user_id
+		WHERE us.source_stats_sharing_enabled
+		  AND s.type = 'rss'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string][]string{}
+	for rows.Next() {
+		var userID, url string
+		if err := rows.Scan(&userID, &url); err != nil {
+			return nil, err
+		}
+		out[userID] = append(out[userID], url)
+	}
+	return out, rows.Err()
+}
+
+// Replace swaps the whole statistics snapshot in one transaction, so readers never see a
+// half-built set and opted-out users drop out completely.
+func (r *SourceCoSubscriptionRepo) Replace(ctx context.Context, feedCounts map[string]int, pairs []model.SourceCoSubscription) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, `DELETE FROM source_co_subscriptions`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM source_feed_stats`); err != nil {
+		return err
+	}
+	feedRows := make([][]any, 0, len(feedCounts))
+	for feedURL, n := range feedCounts {
+		feedRows = append(feedRows, []any{feedURL, n})
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"source_feed_stats"}, []string{"feed_url", "subscriber_count"}, pgx.CopyFromRows(feedRows)); err != nil {
+		return err
+	}
+	pairRows := make([][]any, 0, len(pairs))
+	for _, p := range pairs {
+		pairRows = append(pairRows, []any{p.FeedURL, p.RelatedFeedURL, p.CoCount})
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"source_co_subscriptions"}, []string{"feed_url", "related_feed_url", "co_count"}, pgx.CopyFromRows(pairRows)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListRelated returns the strongest co-subscriptions of the given normalized feed URLs.
+func (r *SourceCoSubscriptionRepo) ListRelated(ctx context.Context, feedURLs []string, limit int) ([]model.SourceCoSubscription, error) {
+	if len(feedURLs) == 0 {
+		return []model.SourceCoSubscription{}, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT c.feed_url, c.related_feed_url, c.co_count, f.subscriber_count
+		FROM source_co_subscriptions c
+		JOIN source_feed_stats f ON f.feed_url = c.feed_url
+		WHERE c.feed_url = ANY($1)
+		ORDER BY c.co_count DESC, c.related_feed_url
+		LIMIT $2`, feedURLs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []model.SourceCoSubscription{}
+	for rows.Next() {
+		var v model.SourceCoSubscription
+		if err := rows.Scan(&v.FeedURL, &v.RelatedFeedURL, &v.CoCount, &v.SubscriberCount); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -25,7 +25,7 @@ func (r *SourceRepo) CountByUser(ctx context.Context, userID string) (int, error
 
 func (r *SourceRepo) List(ctx context.Context, userID string) ([]model.Source, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, created_at, updated_at
+		SELECT id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at
 		FROM sources WHERE user_id = $1 ORDER BY created_at DESC`, userID)
 	if err != nil {
 		return nil, err
@@ -36,7 +36,7 @@ func (r *SourceRepo) List(ctx context.Context, userID string) ([]model.Source, e
 	for rows.Next() {
 		var s model.Source
 		if err := rows.Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
-			&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt); err != nil {
 			return nil, err
 		}
 		sources = append(sources, s)
@@ -49,10 +49,28 @@ func (r *SourceRepo) Create(ctx context.Context, userID, url, srcType string, ti
 	err := r.db.QueryRow(ctx, `
 		INSERT INTO sources (user_id, url, type, title)
 		VALUES ($1, $2, $3, $4)
-		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, created_at, updated_at`,
+		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at`,
 		userID, url, srcType, title,
 	).Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
-		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.CreatedAt, &s.UpdatedAt)
+		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &s, nil
+}
+
+// EnsureImportSource returns the user's manual source that imported items are filed under,
+// creating it on first use.
+func (r *SourceRepo) EnsureImportSource(ctx context.Context, userID, url, title string) (*model.Source, error) {
+	var s model.Source
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO sources (user_id, url, type, title)
+		VALUES ($1, $2, 'manual', $3)
+		ON CONFLICT (user_id, url) DO UPDATE SET updated_at = sources.updated_at
+		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at`,
+		userID, url, title,
+	).Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
+		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
@@ -67,19 +85,28 @@ func (r *SourceRepo) Update(ctx context.Context, id, userID string, enabled *boo
 		    title = CASE WHEN $2 THEN $3 ELSE title END,
 		    updated_at = NOW()
 		WHERE id = $4 AND user_id = $5
-		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, created_at, updated_at`,
+		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at`,
 		enabled, updateTitle, title, id, userID,
 	).Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
-		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.CreatedAt, &s.UpdatedAt)
+		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt)
 	if err != nil {
 		return nil, mapDBError(err)
 	}
 	return &s, nil
 }
 
-func (r *SourceRepo) Delete(ctx context.Context, id, userID string) error {
-	tag, err := r.db.Exec(ctx,
-		`DELETE FROM sources WHERE id = $1 AND user_id = $2`, id, userID)
+// SetDefaultTopics replaces the topics merged into new summaries of the source's items.
+func (r *SourceRepo) SetDefaultTopics(ctx context.Context, id, userID string, topics []string) error {
+	if topics == nil {
+		topics = []string{}
+	}
+	tag, err := r.db.Exec(ctx, `
+		UPDATE sources
+		SET default_topics = $1,
+		    updated_at = NOW()
+		WHERE id = $2 AND user_id = $3`,
+		topics, id, userID,
+	)
 	if err != nil {
 		return err
 	}
@@ -89,9 +116,149 @@ func (r *SourceRepo) Delete(ctx context.Context, id, userID string) error {
 	return nil
 }
 
+// SetGroupName files a source under a group (e.g. an imported Feedly folder); nil ungroups it.
+func (r *SourceRepo) SetGroupName(ctx context.Context, id, userID string, groupName *string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE sources
+		SET group_name = $1,
+		    updated_at = NOW()
+		WHERE id = $2 AND user_id = $3`,
+		groupName, id, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MarkSynced records that the sources are mirrored from provider and clears any removal flag,
+// e.g. when a feed is re-subscribed upstream.
+func (r *SourceRepo) MarkSynced(ctx context.Context, userID, provider string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `
+		UPDATE sources
+		SET sync_provider = $1,
+		    sync_removed_at = NULL,
+		    updated_at = NOW()
+		WHERE user_id = $2 AND id = ANY($3::uuid[])
+		  AND (sync_provider IS DISTINCT FROM $1 OR sync_removed_at IS NOT NULL)`,
+		provider, userID, ids,
+	)
+	return err
+}
+
+// FlagSyncRemoved marks synced sources whose feed is gone from the provider. The first removal
+// time is kept; the sources themselves are left for the user to delete or keep.
+func (r *SourceRepo) FlagSyncRemoved(ctx context.Context, userID, provider string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `
+		UPDATE sources
+		SET sync_removed_at = NOW(),
+		    updated_at = NOW()
+		WHERE user_id = $1 AND sync_provider = $2 AND id = ANY($3::uuid[])
+		  AND sync_removed_at IS NULL`,
+		userID, provider, ids,
+	)
+	return err
+}
+
+// SyncedReadItem is an item the user read in a source synced from a provider.
+type SyncedReadItem struct {
+	SourceURL string
+	ItemURL   string
+	ReadAt    time.Time
+}
+
+// ListSyncedReadItems returns up to limit items of synced sources the user read after since,
+// oldest read first, so a capped push can resume where it stopped. It feeds the read-state
+// push back to the provider.
+func (r *SourceRepo) ListSyncedReadItems(ctx context.Context, userID, provider string, since time.Time, limit int) ([]SyncedReadItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.url, i.url, ir.read_at
+		FROM item_reads ir
+		JOIN items i ON i.id = ir.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE ir.user_id = $1
+		  AND s.user_id = $1
+		  AND s.sync_provider = $2
+		  AND s.sync_removed_at IS NULL
+		  AND ir.read_at > $3
+		ORDER BY ir.read_at ASC
+		LIMIT $4`,
+		userID, provider, since, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]SyncedReadItem, 0)
+	for rows.Next() {
+		var it SyncedReadItem
+		if err := rows.Scan(&it.SourceURL, &it.ItemURL, &it.ReadAt); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+// ListSyncTombstones returns the URLs of sources the user deleted after a sync with provider
+// added or linked them.
+func (r *SourceRepo) ListSyncTombstones(ctx context.Context, userID, provider string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT url FROM source_sync_tombstones WHERE user_id = $1 AND provider = $2`,
+		userID, provider,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]string, 0)
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes a source. A source kept in sync with a provider leaves a tombstone so the
+// next sync does not add it back.
+func (r *SourceRepo) Delete(ctx context.Context, id, userID string) error {
+	var deleted int
+	err := r.db.QueryRow(ctx, `
+		WITH deleted AS (
+			DELETE FROM sources WHERE id = $1 AND user_id = $2
+			RETURNING user_id, url, sync_provider
+		), tombstone AS (
+			INSERT INTO source_sync_tombstones (user_id, provider, url)
+			SELECT user_id, sync_provider, url FROM deleted WHERE sync_provider IS NOT NULL
+			ON CONFLICT (user_id, provider, url) DO UPDATE SET deleted_at = NOW()
+		)
+		SELECT COUNT(*) FROM deleted`,
+		id, userID,
+	).Scan(&deleted)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 func (r *SourceRepo) ListEnabled(ctx context.Context) ([]model.Source, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, created_at, updated_at
+		SELECT id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at
 		FROM sources WHERE enabled = true AND type = 'rss'`)
 	if err != nil {
 		return nil, err
@@ -102,7 +269,7 @@ func (r *SourceRepo) ListEnabled(ctx context.Context) ([]model.Source, error) {
 	for rows.Next() {
 		var s model.Source
 		if err := rows.Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
-			&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt); err != nil {
 			return nil, err
 		}
 		sources = append(sources, s)
@@ -131,6 +298,72 @@ func (r *SourceRepo) UpdateFeedFetchMetadata(ctx context.Context, id string, fet
 	return err
 }
 
+func (r *SourceRepo) SetProposedURL(ctx context.Context, id, proposedURL string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE sources
+		SET proposed_url = $1,
+		    proposed_url_detected_at = CASE
+		        WHEN proposed_url IS DISTINCT FROM $1 THEN NOW()
+		        ELSE proposed_url_detected_at
+		    END,
+		    updated_at = NOW()
+		WHERE id = $2`,
+		proposedURL, id)
+	return err
+}
+
+// MigrateURL replaces the source URL with newURL and clears any pending proposal.
+// Conditional fetch metadata is reset because it belongs to the old endpoint.
+func (r *SourceRepo) MigrateURL(ctx context.Context, id, userID, newURL string) (*model.Source, error) {
+	var s model.Source
+	err := r.db.QueryRow(ctx, `
+		UPDATE sources
+		SET url = $1,
+		    proposed_url = NULL,
+		    proposed_url_detected_at = NULL,
+		    feed_etag = NULL,
+		    feed_last_modified = NULL,
+		    updated_at = NOW()
+		WHERE id = $2 AND user_id = $3
+		RETURNING id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at`,
+		newURL, id, userID,
+	).Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
+		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &s, nil
+}
+
+func (r *SourceRepo) GetByID(ctx context.Context, id, userID string) (*model.Source, error) {
+	var s model.Source
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, url, type, title, enabled, last_fetched_at, feed_etag, feed_last_modified, proposed_url, proposed_url_detected_at, group_name, sync_provider, sync_removed_at, default_topics, created_at, updated_at
+		FROM sources
+		WHERE id = $1 AND user_id = $2`,
+		id, userID,
+	).Scan(&s.ID, &s.UserID, &s.URL, &s.Type, &s.Title,
+		&s.Enabled, &s.LastFetchedAt, &s.FeedETag, &s.FeedLastModified, &s.ProposedURL, &s.ProposedURLDetectedAt, &s.GroupName, &s.SyncProvider, &s.SyncRemovedAt, &s.DefaultTopics, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return &s, nil
+}
+
+func (r *SourceRepo) GetProposedURL(ctx context.Context, id, userID string) (*string, error) {
+	var proposed *string
+	err := r.db.QueryRow(ctx, `
+		SELECT proposed_url
+		FROM sources
+		WHERE id = $1 AND user_id = $2`,
+		id, userID,
+	).Scan(&proposed)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return proposed, nil
+}
+
 func (r *SourceRepo) GetUserIDBySourceID(ctx context.Context, sourceID string) (string, error) {
 	var userID string
 	err := r.db.QueryRow(ctx, `SELECT user_id FROM sources WHERE id = $1`, sourceID).Scan(&userID)
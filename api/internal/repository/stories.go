@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StoryRepo struct{ db *pgxpool.Pool }
+
+func NewStoryRepo(db *pgxpool.Pool) *StoryRepo { return &StoryRepo{db} }
+
+const storyItemTimeSQL = "COALESCE(i.published_at, i.created_at)"
+
+// Candidates returns the user's summarized items since the given time, read or not, as input
+// for story clustering.
+func (r *StoryRepo) Candidates(ctx context.Context, userID string, since time.Time, limit int) ([]model.Item, error) {
+	if limit <= 0 || limit > 800 {
+		limit = 400
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.source_id, s.title AS source_title, i.url, i.title, i.thumbnail_url, NULL::text AS content_text, i.status, i.processing_error,
+		       fc.final_result AS facts_check_result,
+		       sfc.final_result AS faithfulness_result,
+		       (ir.item_id IS NOT NULL) AS is_read,
+		       COALESCE(fb.is_favorite, false) AS is_favorite,
+		       COALESCE(fb.rating, 0) AS feedback_rating,
+		       sm.score, sm.personal_score, sm.personal_score_reason, COALESCE(sm.topics, '{}'::text[]), sm.translated_title,
+		       i.published_at, i.fetched_at, i.created_at, i.updated_at
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		LEFT JOIN item_reads ir ON ir.item_id = i.id AND ir.user_id = $1
+		LEFT JOIN item_feedbacks fb ON fb.item_id = i.id AND fb.user_id = $1
+		LEFT JOIN item_facts_checks fc ON fc.item_id = i.id
+		LEFT JOIN summary_faithfulness_checks sfc ON sfc.item_id = i.id
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.status = 'summarized'
+		  AND `+storyItemTimeSQL+` >= $2
+		ORDER BY `+storyItemTimeSQL+` DESC
+		LIMIT $3`,
+		userID, since, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanItems(rows)
+}
+
+// StoryIDsByItems maps each of the given items that already belongs to one of the user's
+// stories to that story.
+func (r *StoryRepo) StoryIDsByItems(ctx context.Context, userID string, itemIDs []string) (map[string]string, error) {
+	out := map[string]string{}
+	if len(itemIDs) == 0 {
+		return out, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT si.item_id, si.story_id
+		FROM story_items si
+		JOIN stories st ON st.id = si.story_id
+		WHERE st.user_id = $1
+		  AND si.item_id = ANY($2::uuid[])`,
+		userID, itemIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var itemID, storyID string
+		if err := rows.Scan(&itemID, &storyID); err != nil {
+			return nil, err
+		}
+		out[itemID] = storyID
+	}
+	return out, rows.Err()
+}
+
+// Upsert creates a story when storyID is empty, attaches the items that are not in any story
+// yet and refreshes the story's time range and centroid. It returns the story ID.
+func (r *StoryRepo) Upsert(ctx context.Context, userID, storyID, label string, itemIDs []string) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if storyID == "" {
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO stories (user_id, label, first_seen_at, last_seen_at)
+			VALUES ($1, $2, NOW(), NOW())
+			RETURNING id`,
+			userID, label,
+		).Scan(&storyID); err != nil {
+			return "", err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO story_items (story_id, item_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING`,
+		storyID, itemIDs,
+	); err != nil {
+		return "", err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE stories st
+		SET first_seen_at = r.first_seen_at,
+		    last_seen_at = r.last_seen_at,
+		    updated_at = NOW()
+		FROM (
+			SELECT MIN(`+storyItemTimeSQL+`) AS first_seen_at, MAX(`+storyItemTimeSQL+`) AS last_seen_at
+			FROM story_items si
+			JOIN items i ON i.id = si.item_id
+			WHERE si.story_id = $1
+		) r
+		WHERE st.id = $1
+		  AND st.user_id = $2
+		  AND r.first_seen_at IS NOT NULL`,
+		storyID, userID,
+	)
+	if err != nil {
+		return "", err
+	}
+	if tag.RowsAffected() == 0 {
+		return "", ErrNotFound
+	}
+	if err := refreshStoryCentroid(ctx, tx, storyID); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return storyID, nil
+}
+
+// ListByUser returns the user's stories, most recently active first.
+func (r *StoryRepo) ListByUser(ctx context.Context, userID string, limit int) ([]model.Story, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 30
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT st.id, st.label, COUNT(i.id)::int, st.first_seen_at, st.last_seen_at, st.created_at, st.updated_at
+		FROM stories st
+		LEFT JOIN story_items si ON si.story_id = st.id
+		LEFT JOIN items i ON i.id = si.item_id AND i.deleted_at IS NULL
+		WHERE st.user_id = $1
+		GROUP BY st.id
+		ORDER BY st.last_seen_at DESC, st.id
+		LIMIT $2`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Story{}
+	for rows.Next() {
+		var s model.Story
+		if err := rows.Scan(&s.ID, &s.Label, &s.ItemCount, &s.FirstSeenAt, &s.LastSeenAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *StoryRepo) GetForUser(ctx context.Context, userID, storyID string) (*model.Story, error) {
+	var s model.Story
+	err := r.db.QueryRow(ctx, `
+		SELECT st.id, st.label, COUNT(i.id)::int, st.first_seen_at, st.last_seen_at, st.created_at, st.updated_at
+		FROM stories st
+		LEFT JOIN story_items si ON si.story_id = st.id
+		LEFT JOIN items i ON i.id = si.item_id AND i.deleted_at IS NULL
+		WHERE st.id = $2
+		  AND st.user_id = $1
+		GROUP BY st.id`,
+		userID, storyID,
+	).Scan(&s.ID, &s.Label, &s.ItemCount, &s.FirstSeenAt, &s.LastSeenAt, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ItemIDs lists the story's items in chronological order.
+func (r *StoryRepo) ItemIDs(ctx context.Context, storyID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id
+		FROM story_items si
+		JOIN items i ON i.id = si.item_id
+		WHERE si.story_id = $1
+		  AND i.deleted_at IS NULL
+		ORDER BY `+storyItemTimeSQL+` ASC, i.id`,
+		storyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// DayNotes returns the stored "what changed" notes keyed by JST day (YYYY-MM-DD).
+func (r *StoryRepo) DayNotes(ctx context.Context, storyID string) (map[string]model.StoryDayNote, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), bullets, item_count, model
+		FROM story_day_notes
+		WHERE story_id = $1`,
+		storyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]model.StoryDayNote{}
+	for rows.Next() {
+		var n model.StoryDayNote
+		if err := rows.Scan(&n.Day, &n.Bullets, &n.ItemCount, &n.Model); err != nil {
+			return nil, err
+		}
+		out[n.Day] = n
+	}
+	return out, rows.Err()
+}
+
+func (r *StoryRepo) UpsertDayNote(ctx context.Context, storyID string, note model.StoryDayNote) error {
+	bullets := note.Bullets
+	if bullets == nil {
+		bullets = []string{}
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO story_day_notes (story_id, day, bullets, item_count, model)
+		VALUES ($1, $2::date, $3, $4, $5)
+		ON CONFLICT (story_id, day) DO UPDATE
+		SET bullets = EXCLUDED.bullets,
+		    item_count = EXCLUDED.item_count,
+		    model = EXCLUDED.model,
+		    created_at = NOW()`,
+		storyID, note.Day, bullets, note.ItemCount, note.Model,
+	)
+	return err
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// A cluster none of whose items belong to a story yet continues the story whose centroid
+	// is at least this similar to its own.
+	storyCentroidMatchThreshold = 0.8
+	// Stories idle for longer than this are not matched by centroid.
+	storyCentroidLookback       = 14 * 24 * time.Hour
+	storyCentroidCandidateLimit = 200
+
+	clusterStoryMatchedByItems    = "items"
+	clusterStoryMatchedByCentroid = "centroid"
+)
+
+type storyCentroidQueryer interface {
+	Query(context.Context, string, ...any) (pgx.Rows, error)
+	Exec(context.Context, string, ...any) (pgconn.CommandTag, error)
+}
+
+const storyCentroidEmbeddingsSQL = `
+	SELECT ie.item_id, ie.model, ie.dimensions, ie.embedding
+	FROM story_items si
+	JOIN items i ON i.id = si.item_id
+	JOIN item_embeddings ie ON ie.item_id = si.item_id
+	WHERE si.story_id = $1
+	  AND i.deleted_at IS NULL`
+
+const updateStoryCentroidSQL = `
+	UPDATE stories
+	SET centroid = $2,
+	    centroid_model = NULLIF($3, ''),
+	    centroid_dimensions = NULLIF($4::int, 0)
+	WHERE id = $1`
+
+// refreshStoryCentroid stores the mean direction of the story's item embeddings in their
+// dominant space, or clears it when none of the items has an embedding.
+func refreshStoryCentroid(ctx context.Context, q storyCentroidQueryer, storyID string) error {
+	rows, err := q.Query(ctx, storyCentroidEmbeddingsSQL, storyID)
+	if err != nil {
+		return err
+	}
+	reg, err := scanEmbeddingRegistry(rows)
+	if err != nil {
+		return err
+	}
+	space, centroid := reg.centroid()
+	_, err = q.Exec(ctx, updateStoryCentroidSQL, storyID, centroid, space.model, space.dimensions)
+	return err
+}
+
+// RefreshMissingCentroids computes the centroid of the user's recently active stories that have
+// none, such as stories built before centroids were stored.
+func (r *StoryRepo) RefreshMissingCentroids(ctx context.Context, userID string) (int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id
+		FROM stories
+		WHERE user_id = $1
+		  AND centroid IS NULL
+		  AND last_seen_at >= $2
+		ORDER BY last_seen_at DESC, id
+		LIMIT $3`,
+		userID, timeutil.NowJST().Add(-storyCentroidLookback), storyCentroidCandidateLimit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := refreshStoryCentroid(ctx, r.db, id); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// MatchClusters links freshly computed clusters to the user's stories, keyed by cluster ID.
+func (r *StoryRepo) MatchClusters(ctx context.Context, userID string, clusters []model.ReadingPlanCluster) (map[string]model.ClusterStory, error) {
+	reg, err := loadEmbeddingRegistry(ctx, r.db, clusterItemIDs(clusters))
+	if err != nil {
+		return nil, err
+	}
+	return r.matchClusters(ctx, userID, clusters, reg)
+}
+
+func (r *StoryRepo) matchClusters(ctx context.Context, userID string, clusters []model.ReadingPlanCluster, reg embeddingRegistry) (map[string]model.ClusterStory, error) {
+	if len(clusters) == 0 {
+		return map[string]model.ClusterStory{}, nil
+	}
+	storyByItem, err := r.StoryIDsByItems(ctx, userID, clusterItemIDs(clusters))
+	if err != nil {
+		return nil, err
+	}
+	votedIDs := make([]string, 0, len(storyByItem))
+	for _, id := range storyByItem {
+		votedIDs = append(votedIDs, id)
+	}
+	now := timeutil.NowJST()
+	stories, err := r.loadStoryCentroids(ctx, userID, now.Add(-storyCentroidLookback), votedIDs)
+	if err != nil {
+		return nil, err
+	}
+	return matchClusterStories(clusters, storyByItem, stories, reg, timeutil.StartOfDayJST(now)), nil
+}
+
+// attachClusterStories sets each matched cluster's story and gives the cluster the story's label,
+// so a storyline keeps one name from day to day.
+func attachClusterStories(clusters []model.ReadingPlanCluster, storyByCluster map[string]model.ClusterStory) {
+	for i := range clusters {
+		if m, ok := storyByCluster[clusters[i].ID]; ok {
+			clusters[i].Story = &m
+			clusters[i].Label = m.Label
+		}
+	}
+}
+
+type storyCentroid struct {
+	story  model.ClusterStory
+	space  embeddingSpace
+	vector []float64
+}
+
+// loadStoryCentroids returns the user's stories active since the given time plus the listed
+// ones, whatever their age.
+func (r *StoryRepo) loadStoryCentroids(ctx context.Context, userID string, since time.Time, storyIDs []string) ([]storyCentroid, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, label, first_seen_at, centroid, COALESCE(centroid_model, ''), COALESCE(centroid_dimensions, 0)
+		FROM stories
+		WHERE user_id = $1
+		  AND (last_seen_at >= $2 OR id = ANY($3::uuid[]))
+		ORDER BY (id = ANY($3::uuid[])) DESC, last_seen_at DESC, id
+		LIMIT $4`,
+		userID, since, storyIDs, storyCentroidCandidateLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []storyCentroid
+	for rows.Next() {
+		var s storyCentroid
+		if err := rows.Scan(&s.story.StoryID, &s.story.Label, &s.story.FirstSeenAt, &s.vector, &s.space.model, &s.space.dimensions); err != nil {
+			return nil, err
+		}
+		if len(s.vector) != s.space.dimensions {
+			s.vector, s.space = nil, embeddingSpace{}
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// matchClusterStories gives each cluster the story most of its items already belong to, or
+// else the story with the most similar centroid in the same embedding space, provided the
+// similarity reaches storyCentroidMatchThreshold.
+func matchClusterStories(clusters []model.ReadingPlanCluster, storyByItem map[string]string, stories []storyCentroid, reg embeddingRegistry, dayStart time.Time) map[string]model.ClusterStory {
+	byID := make(map[string]storyCentroid, len(stories))
+	for _, s := range stories {
+		byID[s.story.StoryID] = s
+	}
+	out := map[string]model.ClusterStory{}
+	for _, c := range clusters {
+		ids := make([]string, 0, len(c.Items))
+		votes := map[string]int{}
+		for _, it := range c.Items {
+			ids = append(ids, it.ID)
+			if storyID, ok := storyByItem[it.ID]; ok {
+				votes[storyID]++
+			}
+		}
+		if s, ok := byID[majorityStory(votes)]; ok {
+			m := s.story
+			m.MatchedBy = clusterStoryMatchedByItems
+			m.Ongoing = m.FirstSeenAt.Before(dayStart)
+			out[c.ID] = m
+			continue
+		}
+		if len(votes) > 0 {
+			continue
+		}
+		space, centroid := reg.subset(ids).centroid()
+		if centroid == nil {
+			continue
+		}
+		best, bestSim := -1, storyCentroidMatchThreshold
+		for i, s := range stories {
+			if s.vector == nil || !space.compatible(s.space) {
+				continue
+			}
+			if sim := dotProduct(centroid, s.vector); sim >= bestSim {
+				best, bestSim = i, sim
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		m := stories[best].story
+		m.MatchedBy = clusterStoryMatchedByCentroid
+		m.Ongoing = m.FirstSeenAt.Before(dayStart)
+		m.Similarity = &bestSim
+		out[c.ID] = m
+	}
+	return out
+}
+
+// majorityStory is the story with the most votes, ties going to the smallest ID.
+func majorityStory(votes map[string]int) string {
+	ids := make([]string, 0, len(votes))
+	for id := range votes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if votes[ids[i]] != votes[ids[j]] {
+			return votes[ids[i]] > votes[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// subset is the part of the registry holding the given items.
+func (reg embeddingRegistry) subset(itemIDs []string) embeddingRegistry {
+	out := make(embeddingRegistry, len(itemIDs))
+	for _, id := range itemIDs {
+		if e, ok := reg[id]; ok {
+			out[id] = e
+		}
+	}
+	return out
+}
+
+// centroid is the unit-length mean of the registry's unit embeddings in its dominant space.
+func (reg embeddingRegistry) centroid() (embeddingSpace, []float64) {
+	space := reg.dominantSpace(embeddingSpace{})
+	if !space.valid() {
+		return embeddingSpace{}, nil
+	}
+	sum := make([]float64, space.dimensions)
+	for _, v := range unitEmbeddings(reg.vectors(space)) {
+		for i, x := range v {
+			sum[i] += x
+		}
+	}
+	centroid := unitVector(sum)
+	if centroid == nil {
+		return embeddingSpace{}, nil
+	}
+	return space, centroid
+}
+
+func clusterItemIDs(clusters []model.ReadingPlanCluster) []string {
+	var ids []string
+	for _, c := range clusters {
+		for _, it := range c.Items {
+			ids = append(ids, it.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestEmbeddingRegistryCentroidUsesDominantSpace(t *testing.T) {
+	a := embeddingSpace{model: "a-model", dimensions: 2}
+	reg := embeddingRegistry{
+		"1": {space: a, vector: []float64{2, 0}},
+		"2": {space: a, vector: []float64{0, 3}},
+		"3": {space: embeddingSpace{model: "b-model", dimensions: 2}, vector: []float64{-1, 0}},
+	}
+	space, centroid := reg.centroid()
+	if space != a {
+		t.Fatalf("space = %+v", space)
+	}
+	if len(centroid) != 2 || math.Abs(centroid[0]-math.Sqrt2/2) > 1e-9 || math.Abs(centroid[1]-math.Sqrt2/2) > 1e-9 {
+		t.Fatalf("centroid = %v", centroid)
+	}
+	if space, centroid := (embeddingRegistry{}).centroid(); space.valid() || centroid != nil {
+		t.Fatalf("empty registry centroid = %+v %v", space, centroid)
+	}
+}
+
+func TestMatchClusterStories(t *testing.T) {
+	space := embeddingSpace{model: "a-model", dimensions: 2}
+	dayStart := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	story := func(id string, firstSeen time.Time, s embeddingSpace, vector []float64) storyCentroid {
+		return storyCentroid{
+			story:  model.ClusterStory{StoryID: id, Label: "label " + id, FirstSeenAt: firstSeen},
+			space:  s,
+			vector: vector,
+		}
+	}
+	stories := []storyCentroid{
+		story("old", dayStart.Add(-72*time.Hour), space, []float64{1, 0}),
+		story("today", dayStart.Add(time.Hour), space, []float64{0, 1}),
+		story("other-space", dayStart.Add(-72*time.Hour), embeddingSpace{model: "b-model", dimensions: 2}, []float64{-1, 0}),
+	}
+	reg := embeddingRegistry{
+		"close-1": {space: space, vector: []float64{0.95, 0.1}},
+		"close-2": {space: space, vector: []float64{0.9, 0.2}},
+		"far-1":   {space: space, vector: []float64{-1, 0.1}},
+		"far-2":   {space: space, vector: []float64{-0.9, -0.1}},
+	}
+	cluster := func(id string, itemIDs ...string) model.ReadingPlanCluster {
+		c := model.ReadingPlanCluster{ID: id}
+		for _, itemID := range itemIDs {
+			c.Items = append(c.Items, model.Item{ID: itemID})
+		}
+		return c
+	}
+	clusters := []model.ReadingPlanCluster{
+		cluster("voted", "v1", "v2", "v3"),
+		cluster("close", "close-1", "close-2"),
+		cluster("far", "far-1", "far-2"),
+		cluster("voted-unknown", "u1", "close-1"),
+	}
+	storyByItem := map[string]string{"v1": "today", "v2": "old", "v3": "today", "u1": "gone"}
+
+	got := matchClusterStories(clusters, storyByItem, stories, reg, dayStart)
+
+	if m := got["voted"]; m.StoryID != "today" || m.MatchedBy != clusterStoryMatchedByItems || m.Ongoing || m.Similarity != nil {
+		t.Errorf("voted = %+v", m)
+	}
+	if m := got["close"]; m.StoryID != "old" || m.MatchedBy != clusterStoryMatchedByCentroid || !m.Ongoing || m.Similarity == nil || *m.Similarity < storyCentroidMatchThreshold {
+		t.Errorf("close = %+v", m)
+	}
+	if m, ok := got["far"]; ok {
+		t.Errorf("far matched a story in another space or below the threshold: %+v", m)
+	}
+	if m, ok := got["voted-unknown"]; ok {
+		t.Errorf("cluster voting for an unloaded story fell back to its centroid: %+v", m)
+	}
+}
+
+func TestAttachClusterStoriesKeepsStoryLabel(t *testing.T) {
+	clusters := []model.ReadingPlanCluster{{ID: "a", Label: "today's title"}, {ID: "b", Label: "unmatched"}}
+	attachClusterStories(clusters, map[string]model.ClusterStory{"a": {StoryID: "s1", Label: "ongoing story"}})
+	if clusters[0].Story == nil || clusters[0].Story.StoryID != "s1" || clusters[0].Label != "ongoing story" {
+		t.Fatalf("matched cluster = %+v", clusters[0])
+	}
+	if clusters[1].Story != nil || clusters[1].Label != "unmatched" {
+		t.Fatalf("unmatched cluster = %+v", clusters[1])
+	}
+}
+
+func TestStoryCentroidSQL(t *testing.T) {
+	if !strings.Contains(storyCentroidEmbeddingsSQL, "AND i.deleted_at IS NULL") {
+		t.Fatalf("centroid embeddings include deleted items: %s", storyCentroidEmbeddingsSQL)
+	}
+	for _, want := range []string{"centroid_model = NULLIF($3, '')", "centroid_dimensions = NULLIF($4::int, 0)"} {
+		if !strings.Contains(updateStoryCentroidSQL, want) {
+			t.Fatalf("centroid update missing %q: %s", want, updateStoryCentroidSQL)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TopicAlertRepo struct{ db *pgxpool.Pool }
+
+func NewTopicAlertRepo(db *pgxpool.Pool) *TopicAlertRepo { return &TopicAlertRepo{db} }
+
+const topicAlertSubscriptionColumns = `id, user_id, topic, min_delta, channels, webhook_url, enabled, last_alerted_at, created_at, updated_at`
+
+func scanTopicAlertSubscription(row pgx.Row) (*model.TopicAlertSubscription, error) {
+	var s model.TopicAlertSubscription
+	if err := row.Scan(&s.ID, &s.UserID, &s.Topic, &s.MinDelta, &s.Channels, &s.WebhookURL, &s.Enabled, &s.LastAlertedAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TopicAlertRepo) ListByUser(ctx context.Context, userID string) ([]model.TopicAlertSubscription, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+topicAlertSubscriptionColumns+`
+		FROM topic_alert_subscriptions
+		WHERE user_id = $1
+		ORDER BY topic`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TopicAlertSubscription{}
+	for rows.Next() {
+		s, err := scanTopicAlertSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+func (r *TopicAlertRepo) Get(ctx context.Context, userID, id string) (*model.TopicAlertSubscription, error) {
+	s, err := scanTopicAlertSubscription(r.db.QueryRow(ctx, `
+		SELECT `+topicAlertSubscriptionColumns+`
+		FROM topic_alert_subscriptions
+		WHERE id = $1 AND user_id = $2`, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+func (r *TopicAlertRepo) Create(ctx context.Context, s model.TopicAlertSubscription) (*model.TopicAlertSubscription, error) {
+	out, err := scanTopicAlertSubscription(r.db.QueryRow(ctx, `
+		INSERT INTO topic_alert_subscriptions (user_id, topic, min_delta, channels, webhook_url, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+topicAlertSubscriptionColumns,
+		s.UserID, s.Topic, s.MinDelta, s.Channels, s.WebhookURL, s.Enabled,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return out, nil
+}
+
+func (r *TopicAlertRepo) Update(ctx context.Context, s model.TopicAlertSubscription) (*model.TopicAlertSubscription, error) {
+	out, err := scanTopicAlertSubscription(r.db.QueryRow(ctx, `
+		UPDATE topic_alert_subscriptions
+		SET min_delta = $3,
+		    channels = $4,
+		    webhook_url = $5,
+		    enabled = $6,
+		    updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+topicAlertSubscriptionColumns,
+		s.ID, s.UserID, s.MinDelta, s.Channels, s.WebhookURL, s.Enabled,
+	))
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return out, nil
+}
+
+func (r *TopicAlertRepo) Delete(ctx context.Context, userID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM topic_alert_subscriptions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListEvaluable returns enabled subscriptions that have not alerted within the cooldown,
+// grouped by user.
+func (r *TopicAlertRepo) ListEvaluable(ctx context.Context, cooldown time.Duration) ([]model.TopicAlertSubscription, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+topicAlertSubscriptionColumns+`
+		FROM topic_alert_subscriptions
+		WHERE enabled = true
+		  AND (last_alerted_at IS NULL OR last_alerted_at < $1)
+		ORDER BY user_id, topic`, time.Now().Add(-cooldown))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TopicAlertSubscription{}
+	for rows.Next() {
+		s, err := scanTopicAlertSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+// TopItemIDs returns the user's highest scored items of the last 24 hours tagged with topic.
+func (r *TopicAlertRepo) TopItemIDs(ctx context.Context, userID, topic string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id
+		FROM items i
+		JOIN sources s ON s.id = i.source_id
+		JOIN item_summaries sm ON sm.item_id = i.id
+		WHERE s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND i.status = 'summarized'
+		  AND $2 = ANY(sm.topics)
+		  AND COALESCE(i.published_at, i.created_at) >= NOW() - INTERVAL '24 hours'
+		ORDER BY sm.score DESC NULLS LAST, COALESCE(i.published_at, i.created_at) DESC
+		LIMIT $3`, userID, topic, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RecordAlert logs a sent alert and starts the subscription's cooldown.
+func (r *TopicAlertRepo) RecordAlert(ctx context.Context, userID string, l model.TopicAlertLog) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO topic_alert_logs (subscription_id, user_id, topic, count_24h, count_prev_24h, delta, item_ids, sent_channels)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::uuid[], $8)`,
+		l.SubscriptionID, userID, l.Topic, l.Count24h, l.CountPrev24h, l.Delta, l.ItemIDs, l.SentChannels,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE topic_alert_subscriptions
+		SET last_alerted_at = NOW()
+		WHERE id = $1`, l.SubscriptionID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TopicAlertRepo) ListLogs(ctx context.Context, userID string, limit int) ([]model.TopicAlertLog, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 30
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id, subscription_id, topic, count_24h, count_prev_24h, delta, item_ids::text[], sent_channels, created_at
+		FROM topic_alert_logs
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TopicAlertLog{}
+	for rows.Next() {
+		var l model.TopicAlertLog
+		if err := rows.Scan(&l.ID, &l.SubscriptionID, &l.Topic, &l.Count24h, &l.CountPrev24h, &l.Delta, &l.ItemIDs, &l.SentChannels, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TopicRepo struct{ db *pgxpool.Pool }
+
+func NewTopicRepo(db *pgxpool.Pool) *TopicRepo { return &TopicRepo{db} }
+
+// RawTopicCounts counts every topic label the LLM produced, looking through rewritten
+// summaries to their original labels.
+func (r *TopicRepo) RawTopicCounts(ctx context.Context) ([]model.TopicRawCount, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT t.name, COUNT(*)::int
+		FROM item_summaries sm
+		CROSS JOIN LATERAL unnest(COALESCE(sm.original_topics, sm.topics)) AS t(name)
+		WHERE btrim(t.name) <> ''
+		GROUP BY t.name
+		ORDER BY COUNT(*) DESC, t.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TopicRawCount{}
+	for rows.Next() {
+		var v model.TopicRawCount
+		if err := rows.Scan(&v.Name, &v.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// List returns canonical topics with their aliases, alphabetically.
+func (r *TopicRepo) List(ctx context.Context) ([]model.Topic, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, created_at, updated_at
+		FROM topics
+		ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []model.Topic{}
+	index := map[string]int{}
+	for rows.Next() {
+		var t model.Topic
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		t.Aliases = []model.TopicAlias{}
+		index[t.ID] = len(topics)
+		topics = append(topics, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	aliases, err := r.Aliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases {
+		if i, ok := index[a.TopicID]; ok {
+			topics[i].Aliases = append(topics[i].Aliases, a)
+		}
+	}
+	return topics, nil
+}
+
+func (r *TopicRepo) Aliases(ctx context.Context) ([]model.TopicAlias, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT alias, topic_id, source, similarity
+		FROM topic_aliases
+		ORDER BY alias`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.TopicAlias{}
+	for rows.Next() {
+		var a model.TopicAlias
+		if err := rows.Scan(&a.Alias, &a.TopicID, &a.Source, &a.Similarity); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// CanonicalNames resolves alias keys to canonical topic names. Unknown keys are left out.
+func (r *TopicRepo) CanonicalNames(ctx context.Context, aliases []string) (map[string]string, error) {
+	out := map[string]string{}
+	if len(aliases) == 0 {
+		return out, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT ta.alias, t.name
+		FROM topic_aliases ta
+		JOIN topics t ON t.id = ta.topic_id
+		WHERE ta.alias = ANY($1::text[])`, aliases)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias, name string
+		if err := rows.Scan(&alias, &name); err != nil {
+			return nil, err
+		}
+		out[alias] = name
+	}
+	return out, rows.Err()
+}
+
+// Create inserts a canonical topic, returning the existing one when the key is taken.
+func (r *TopicRepo) Create(ctx context.Context, name, normalizedName string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO topics (name, normalized_name)
+		VALUES ($1, $2)
+		ON CONFLICT (normalized_name) DO UPDATE SET updated_at = topics.updated_at
+		RETURNING id`,
+		name, normalizedName,
+	).Scan(&id)
+	return id, err
+}
+
+// AddAlias records an automatic alias; existing aliases, manual ones included, are kept.
+func (r *TopicRepo) AddAlias(ctx context.Context, alias, topicID, source string, similarity *float64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO topic_aliases (alias, topic_id, source, similarity)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alias) DO NOTHING`,
+		alias, topicID, source, similarity,
+	)
+	return err
+}
+
+// Merge moves every alias of source onto target and deletes source.
+func (r *TopicRepo) Merge(ctx context.Context, sourceID, targetID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var n int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM topics WHERE id = ANY($1::uuid[])`, []string{sourceID, targetID}).Scan(&n); err != nil {
+		return err
+	}
+	if n != 2 {
+		return ErrNotFound
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE topic_aliases
+		SET topic_id = $2, source = 'manual', similarity = NULL, updated_at = NOW()
+		WHERE topic_id = $1`,
+		sourceID, targetID,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM topics WHERE id = $1`, sourceID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE topics SET updated_at = NOW() WHERE id = $1`, targetID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Split creates a new canonical topic and moves the given aliases of topicID onto it.
+func (r *TopicRepo) Split(ctx context.Context, topicID, name, normalizedName string, aliases []string) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var newID string
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO topics (name, normalized_name)
+		VALUES ($1, $2)
+		RETURNING id`,
+		name, normalizedName,
+	).Scan(&newID); err != nil {
+		return "", mapDBError(err)
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE topic_aliases
+		SET topic_id = $2, source = 'manual', similarity = NULL, updated_at = NOW()
+		WHERE topic_id = $1
+		  AND alias = ANY($3::text[])`,
+		topicID, newID, aliases,
+	)
+	if err != nil {
+		return "", err
+	}
+	if tag.RowsAffected() != int64(len(aliases)) {
+		return "", ErrInvalidState
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO topic_aliases (alias, topic_id, source)
+		VALUES ($1, $2, 'manual')
+		ON CONFLICT (alias) DO NOTHING`,
+		normalizedName, newID,
+	); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return newID, nil
+}
+
+// RewriteSummaryTopics rebuilds topics from the original labels of summaries whose current
+// topics include any of the affected labels. rawNames and canonicalNames are parallel;
+// labels without a mapping are kept as they are, and duplicates collapse in first-seen order.
+func (r *TopicRepo) RewriteSummaryTopics(ctx context.Context, rawNames, canonicalNames, affected []string) (int64, error) {
+	if len(affected) == 0 {
+		return 0, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		WITH mapping AS (
+			SELECT raw, canonical FROM unnest($1::text[], $2::text[]) AS m(raw, canonical)
+		)
+		UPDATE item_summaries sm
+		SET original_topics = COALESCE(sm.original_topics, sm.topics),
+		    topics = ARRAY(
+		      SELECT x.name
+		      FROM (
+		        SELECT COALESCE(m.canonical, t.raw) AS name, MIN(t.ord) AS ord
+		        FROM unnest(COALESCE(sm.original_topics, sm.topics)) WITH ORDINALITY AS t(raw, ord)
+		        LEFT JOIN mapping m ON m.raw = t.raw
+		        GROUP BY 1
+		      ) x
+		      ORDER BY x.ord
+		    )
+		WHERE sm.topics && $3::text[]
+		RETURNING sm.item_id`,
+		rawNames, canonicalNames, affected,
+	)
+	if err != nil {
+		return 0, err
+	}
+	itemIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		itemIDs = append(itemIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if err := refreshItemTopicIndex(ctx, r.db, itemIDs); err != nil {
+		return 0, err
+	}
+	return int64(len(itemIDs)), nil
+}
+
+func (r *TopicRepo) SummaryTopics(ctx context.Context, itemID string) ([]string, error) {
+	var topics []string
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(original_topics, topics)
+		FROM item_summaries
+		WHERE item_id = $1`, itemID).Scan(&topics)
+	if err != nil {
+		return nil, mapDBError(err)
+	}
+	return topics, nil
+}
+
+func (r *TopicRepo) SetSummaryTopics(ctx context.Context, itemID string, topics []string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE item_summaries
+		SET original_topics = COALESCE(original_topics, topics),
+		    topics = $2
+		WHERE item_id = $1`, itemID, topics)
+	if err != nil {
+		return err
+	}
+	return refreshItemTopicIndex(ctx, r.db, []string{itemID})
+}
+
+// TopicCentroid averages the embeddings of the latest items labelled with any of the names.
+// Embeddings whose dimensions differ from the first one are skipped.
+func (r *TopicRepo) TopicCentroid(ctx context.Context, names []string, limit int) ([]float64, int, error) {
+	if len(names) == 0 {
+		return nil, 0, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT e.embedding
+		FROM item_summaries sm
+		JOIN item_embeddings e ON e.item_id = sm.item_id
+		WHERE COALESCE(sm.original_topics, sm.topics) && $1::text[]
+		ORDER BY sm.summarized_at DESC
+		LIMIT $2`, names, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var sum []float64
+	n := 0
+	for rows.Next() {
+		var emb []float64
+		if err := rows.Scan(&emb); err != nil {
+			return nil, 0, err
+		}
+		if len(emb) == 0 {
+			continue
+		}
+		if sum == nil {
+			sum = make([]float64, len(emb))
+		}
+		if len(emb) != len(sum) {
+			continue
+		}
+		for i, v := range emb {
+			sum[i] += v
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if n == 0 {
+		return nil, 0, nil
+	}
+	for i := range sum {
+		sum[i] /= float64(n)
+	}
+	return sum, n, nil
+}
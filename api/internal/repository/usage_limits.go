@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UsageLimitRepo struct{ db *pgxpool.Pool }
+
+func NewUsageLimitRepo(db *pgxpool.Pool) *UsageLimitRepo { return &UsageLimitRepo{db} }
+
+// UserPlanUsage is a user's plan with their source count and the items that went through the
+// LLM pipeline since a point in time, however they were added or reprocessed.
+type UserPlanUsage struct {
+	Plan     string
+	Sources  int
+	LLMItems int
+}
+
+const usageByUsersSQL = `
+	SELECT u.id, u.plan,
+	       (SELECT COUNT(*) FROM sources s WHERE s.user_id = u.id)::int,
+	       (
+	         SELECT COUNT(DISTINCT l.item_id)
+	         FROM llm_usage_logs l
+	         WHERE l.user_id = u.id
+	           AND l.purpose = 'facts'
+	           AND l.item_id IS NOT NULL
+	           AND l.created_at >= $2
+	       )::int
+	FROM users u
+	WHERE u.id = ANY($1::uuid[])`
+
+// UsageByUsers returns the plan usage of each of the given users that exists.
+func (r *UsageLimitRepo) UsageByUsers(ctx context.Context, userIDs []string, since time.Time) (map[string]UserPlanUsage, error) {
+	out := make(map[string]UserPlanUsage, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	rows, err := r.db.Query(ctx, usageByUsersSQL, userIDs, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID string
+		var u UserPlanUsage
+		if err := rows.Scan(&userID, &u.Plan, &u.Sources, &u.LLMItems); err != nil {
+			return nil, err
+		}
+		out[userID] = u
+	}
+	return out, rows.Err()
+}
+
+func (r *UsageLimitRepo) SetPlan(ctx context.Context, userID, plan string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET plan = $2,
+		    updated_at = NOW()
+		WHERE id = $1`,
+		userID, plan,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UserSecretColumns lists every user_settings column holding a SecretCipher ciphertext.
+var UserSecretColumns = []string{
+	"anthropic_api_key_enc",
+	"openai_api_key_enc",
+	"google_api_key_enc",
+	"groq_api_key_enc",
+	"deepseek_api_key_enc",
+	"alibaba_api_key_enc",
+	"mistral_api_key_enc",
+	"xai_api_key_enc",
+	"zai_api_key_enc",
+	"openrouter_api_key_enc",
+	"fireworks_api_key_enc",
+	"poe_api_key_enc",
+	"aivis_api_key_enc",
+	"moonshot_api_key_enc",
+	"siliconflow_api_key_enc",
+	"fish_api_key_enc",
+	"elevenlabs_api_key_enc",
+	"together_api_key_enc",
+	"azure_speech_api_key_enc",
+	"minimax_api_key_enc",
+	"xiaomi_mimo_token_plan_api_key_enc",
+	"featherless_api_key_enc",
+	"deepinfra_api_key_enc",
+	"cerebras_api_key_enc",
+	"cartesia_api_key_enc",
+	"plamo_api_key_enc",
+	"inoreader_access_token_enc",
+	"inoreader_refresh_token_enc",
+	"feedly_access_token_enc",
+	"feedly_refresh_token_enc",
+	"smtp_password_enc",
+}
+
+type UserSecretRepo struct{ db *pgxpool.Pool }
+
+func NewUserSecretRepo(db *pgxpool.Pool) *UserSecretRepo { return &UserSecretRepo{db: db} }
+
+type UserSecretRow struct {
+	UserID string
+	// Values maps column name to ciphertext for the non-null secret columns.
+	Values map[string]string
+}
+
+type UserSecretKeyCount struct {
+	Column string `json:"column"`
+	KeyID  string `json:"key_id"`
+	Count  int    `json:"count"`
+}
+
+func isUserSecretColumn(column string) bool {
+	for _, c := range UserSecretColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// secretKeyIDExpr mirrors service.KeyIDOf: unprefixed ciphertexts belong to the legacy key "".
+func secretKeyIDExpr(column string) string {
+	return fmt.Sprintf("CASE WHEN position(':' in %s) > 0 THEN split_part(%s, ':', 1) ELSE '' END", column, column)
+}
+
+// ListBatchAfter pages through users with at least one stored secret in user_id order.
+func (r *UserSecretRepo) ListBatchAfter(ctx context.Context, afterUserID string, limit int) ([]UserSecretRow, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 200
+	}
+	nonNull := make([]string, 0, len(UserSecretColumns))
+	for _, c := range UserSecretColumns {
+		nonNull = append(nonNull, c+" IS NOT NULL")
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id::text, `+strings.Join(UserSecretColumns, ", ")+`
+		FROM user_settings
+		WHERE ($1 = '' OR user_id > $1::uuid)
+		  AND (`+strings.Join(nonNull, " OR ")+`)
+		ORDER BY user_id
+		LIMIT $2`, afterUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []UserSecretRow{}
+	for rows.Next() {
+		values := make([]*string, len(UserSecretColumns))
+		dest := make([]any, 0, len(values)+1)
+		var row UserSecretRow
+		dest = append(dest, &row.UserID)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row.Values = map[string]string{}
+		for i, v := range values {
+			if v != nil && *v != "" {
+				row.Values[UserSecretColumns[i]] = *v
+			}
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// ReplaceIfUnchanged swaps a ciphertext only when the stored value still equals oldValue, so a
+// key saved by the user mid-rotation is never overwritten.
+func (r *UserSecretRepo) ReplaceIfUnchanged(ctx context.Context, userID, column, oldValue, newValue string) (bool, error) {
+	if !isUserSecretColumn(column) {
+		return false, fmt.Errorf("unknown secret column: %s", column)
+	}
+	tag, err := r.db.Exec(ctx, `
+		UPDATE user_settings
+		SET `+column+` = $3
+		WHERE user_id = $1 AND `+column+` = $2`, userID, oldValue, newValue)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *UserSecretRepo) CountByKeyID(ctx context.Context) ([]UserSecretKeyCount, error) {
+	parts := make([]string, 0, len(UserSecretColumns))
+	for _, c := range UserSecretColumns {
+		parts = append(parts, fmt.Sprintf(`
+		SELECT '%s' AS column_name, %s AS key_id, COUNT(*)::int AS cnt
+		FROM user_settings
+		WHERE %s IS NOT NULL AND %s <> ''
+		GROUP BY 2`, c, secretKeyIDExpr(c), c, c))
+	}
+	rows, err := r.db.Query(ctx, strings.Join(parts, "\n\t\tUNION ALL")+"\n\t\tORDER BY 1, 2")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []UserSecretKeyCount{}
+	for rows.Next() {
+		var v UserSecretKeyCount
+		if err := rows.Scan(&v.Column, &v.KeyID, &v.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// CountRowsNotOnKey counts users with at least one secret written under a key other than keyID.
+func (r *UserSecretRepo) CountRowsNotOnKey(ctx context.Context, keyID string) (int, error) {
+	conds := make([]string, 0, len(UserSecretColumns))
+	for _, c := range UserSecretColumns {
+		conds = append(conds, fmt.Sprintf("(%s IS NOT NULL AND %s <> '' AND %s <> $1)", c, c, secretKeyIDExpr(c)))
+	}
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::int
+		FROM user_settings
+		WHERE `+strings.Join(conds, "\n\t\t   OR "), keyID).Scan(&n)
+	return n, err
+}
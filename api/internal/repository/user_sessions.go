@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserSessionRepo struct{ db *pgxpool.Pool }
+
+func NewUserSessionRepo(db *pgxpool.Pool) *UserSessionRepo { return &UserSessionRepo{db} }
+
+// touchUserSessionSQL records a use of the session. The conflict update is skipped for a
+// revoked session or one owned by another user, so no row comes back for those.
+const touchUserSessionSQL = `
+	INSERT INTO user_sessions (user_id, provider, session_id, ip_address, user_agent)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''))
+	ON CONFLICT (provider, session_id) DO UPDATE
+	SET last_used_at = NOW(),
+	    ip_address = COALESCE(EXCLUDED.ip_address, user_sessions.ip_address),
+	    user_agent = COALESCE(EXCLUDED.user_agent, user_sessions.user_agent)
+	WHERE user_sessions.user_id = EXCLUDED.user_id
+	  AND user_sessions.revoked_at IS NULL
+	RETURNING id`
+
+// Touch records a use of the session and reports whether it may still be used.
+func (r *UserSessionRepo) Touch(ctx context.Context, userID, provider, sessionID, ipAddress, userAgent string) (bool, error) {
+	var id string
+	err := r.db.QueryRow(ctx, touchUserSessionSQL, userID, provider, sessionID, ipAddress, userAgent).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const listActiveUserSessionsSQL = `
+	SELECT id, session_id, ip_address, user_agent, created_at, last_used_at
+	FROM user_sessions
+	WHERE user_id = $1
+	  AND revoked_at IS NULL
+	  AND last_used_at >= $2
+	ORDER BY last_used_at DESC`
+
+// ActiveUserSession is a session that is not revoked, with the provider's session ID.
+type ActiveUserSession struct {
+	Session   model.UserSession
+	SessionID string
+}
+
+func (r *UserSessionRepo) ListActive(ctx context.Context, userID string, usedSince time.Time) ([]ActiveUserSession, error) {
+	rows, err := r.db.Query(ctx, listActiveUserSessionsSQL, userID, usedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []ActiveUserSession{}
+	for rows.Next() {
+		var s ActiveUserSession
+		var createdAt, lastUsedAt time.Time
+		if err := rows.Scan(&s.Session.ID, &s.SessionID, &s.Session.IPAddress, &s.Session.UserAgent, &createdAt, &lastUsedAt); err != nil {
+			return nil, err
+		}
+		s.Session.CreatedAt, s.Session.LastUsedAt = &createdAt, &lastUsedAt
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// Revoke marks the user's session revoked and returns its provider session ID.
+func (r *UserSessionRepo) Revoke(ctx context.Context, userID, id string) (string, error) {
+	var sessionID string
+	err := r.db.QueryRow(ctx, `
+		UPDATE user_sessions
+		SET revoked_at = NOW()
+		WHERE id = $1
+		  AND user_id = $2
+		  AND revoked_at IS NULL
+		RETURNING session_id`,
+		id, userID,
+	).Scan(&sessionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return sessionID, err
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTouchUserSessionSQLKeepsRevokedSessionsRevoked(t *testing.T) {
+	for _, want := range []string{
+		"ON CONFLICT (provider, session_id) DO UPDATE",
+		"WHERE user_sessions.user_id = EXCLUDED.user_id",
+		"AND user_sessions.revoked_at IS NULL",
+		"RETURNING id",
+	} {
+		if !strings.Contains(touchUserSessionSQL, want) {
+			t.Errorf("touchUserSessionSQL missing %q", want)
+		}
+	}
+	if !strings.Contains(listActiveUserSessionsSQL, "revoked_at IS NULL") {
+		t.Error("listActiveUserSessionsSQL lists revoked sessions")
+	}
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,6 +23,7 @@ type BudgetAlertTarget struct {
 	Name                    *string
 	MonthlyBudgetUSD        float64
 	BudgetAlertThresholdPct int
+	Locale                  string
 }
 
 func (r *UserSettingsRepo) ListUserIDsWithPoeAPIKey(ctx context.Context) ([]string, error) {
@@ -97,6 +100,8 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 	var elevenLabsAPIKeyEnc *string
 	var cartesiaAPIKeyEnc *string
 	var inoreaderAccessTokenEnc *string
+	var feedlyAccessTokenEnc *string
+	var skipWeekdays []int16
 	err := r.db.QueryRow(ctx, `
 		SELECT user_id,
 		       anthropic_api_key_enc,
@@ -166,11 +171,14 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 		       monthly_budget_usd,
 		       budget_alert_enabled,
 		       budget_alert_threshold_pct,
+		       budget_enforcement_enabled,
+		       budget_hard_cap_usd,
 		       digest_email_enabled,
 		       reading_plan_window,
 		       reading_plan_size,
 		       reading_plan_diversify_topics,
 		       reading_plan_exclude_read,
+		       reading_plan_exploration,
 		       facts_model,
 		       facts_secondary_model,
 		       facts_secondary_rate_percent,
@@ -201,8 +209,38 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 		       tts_markup_preprocess_model,
 		       ui_font_sans_key,
 		       ui_font_serif_key,
+		       feed_auto_migrate_enabled,
+		       output_language,
+		       locale,
+		       digest_audio_enabled,
+		       digest_verbosity,
+		       digest_tone,
+		       digest_max_clusters,
+		       digest_topic_priorities,
+		       digest_skip_weekdays,
+		       digest_vacation_start,
+		       digest_vacation_end,
+		       digest_approval_enabled,
+		       digest_auto_approve_minutes,
+		       source_stats_sharing_enabled,
+		       html_snapshots_enabled,
+		       exclude_paywalled,
+		       social_boost_enabled,
+		       streak_daily_target,
+		       streak_freeze_monthly_allowance,
+		       weekly_recap_enabled,
+		       briefing_greeting_style,
+		       llm_max_concurrency,
+		       prescreen_threshold,
+		       processing_paused_at,
 	       inoreader_access_token_enc,
 		       inoreader_token_expires_at,
+		       inoreader_sync_enabled,
+		       inoreader_sync_read_state,
+		       inoreader_last_synced_at,
+		       inoreader_last_sync_error,
+		       feedly_access_token_enc,
+		       feedly_token_expires_at,
 		       created_at,
 		       updated_at
 		FROM user_settings
@@ -277,11 +315,14 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 		&v.MonthlyBudgetUSD,
 		&v.BudgetAlertEnabled,
 		&v.BudgetAlertThresholdPct,
+		&v.BudgetEnforcementEnabled,
+		&v.BudgetHardCapUSD,
 		&v.DigestEmailEnabled,
 		&v.ReadingPlanWindow,
 		&v.ReadingPlanSize,
 		&v.ReadingPlanDiversifyTopics,
 		&v.ReadingPlanExcludeRead,
+		&v.ReadingPlanExploration,
 		&v.FactsModel,
 		&v.FactsSecondaryModel,
 		&v.FactsSecondaryRatePercent,
@@ -312,8 +353,38 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 		&v.TTSMarkupPreprocessModel,
 		&v.UIFontSansKey,
 		&v.UIFontSerifKey,
+		&v.FeedAutoMigrateEnabled,
+		&v.OutputLanguage,
+		&v.Locale,
+		&v.DigestAudioEnabled,
+		&v.DigestVerbosity,
+		&v.DigestTone,
+		&v.DigestMaxClusters,
+		&v.DigestTopicPriorities,
+		&skipWeekdays,
+		&v.DigestVacationStart,
+		&v.DigestVacationEnd,
+		&v.DigestApprovalEnabled,
+		&v.DigestAutoApproveMinutes,
+		&v.SourceStatsSharingEnabled,
+		&v.HTMLSnapshotsEnabled,
+		&v.ExcludePaywalled,
+		&v.SocialBoostEnabled,
+		&v.StreakDailyTarget,
+		&v.StreakFreezeMonthlyAllowance,
+		&v.WeeklyRecapEnabled,
+		&v.BriefingGreetingStyle,
+		&v.LLMMaxConcurrency,
+		&v.PrescreenThreshold,
+		&v.ProcessingPausedAt,
 		&inoreaderAccessTokenEnc,
 		&v.InoreaderTokenExpiresAt,
+		&v.InoreaderSyncEnabled,
+		&v.InoreaderSyncReadState,
+		&v.InoreaderLastSyncedAt,
+		&v.InoreaderLastSyncError,
+		&feedlyAccessTokenEnc,
+		&v.FeedlyTokenExpiresAt,
 		&v.CreatedAt,
 		&v.UpdatedAt,
 	)
@@ -347,6 +418,11 @@ func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID string) (*mod
 	v.HasElevenLabsAPIKey = elevenLabsAPIKeyEnc != nil && *elevenLabsAPIKeyEnc != ""
 	v.HasCartesiaAPIKey = cartesiaAPIKeyEnc != nil && *cartesiaAPIKeyEnc != ""
 	v.HasInoreaderOAuth = inoreaderAccessTokenEnc != nil && *inoreaderAccessTokenEnc != ""
+	v.HasFeedlyOAuth = feedlyAccessTokenEnc != nil && *feedlyAccessTokenEnc != ""
+	v.DigestSkipWeekdays = make([]int, 0, len(skipWeekdays))
+	for _, d := range skipWeekdays {
+		v.DigestSkipWeekdays = append(v.DigestSkipWeekdays, int(d))
+	}
 	return &v, nil
 }
 
@@ -518,22 +594,477 @@ func (r *UserSettingsRepo) IsDigestEmailEnabled(ctx context.Context, userID stri
 	return enabled, nil
 }
 
-func (r *UserSettingsRepo) UpsertReadingPlanConfig(ctx context.Context, userID, window string, size int, diversifyTopics, excludeRead bool) (*model.UserSettings, error) {
+// SetDigestEmailEnabled flips only digest_email_enabled; it backs the unsubscribe link and
+// leaves the budget settings that share the column's settings form untouched.
+func (r *UserSettingsRepo) SetDigestEmailEnabled(ctx context.Context, userID string, enabled bool) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_email_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_email_enabled = EXCLUDED.digest_email_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	return err
+}
+
+func (r *UserSettingsRepo) GetLocale(ctx context.Context, userID string) (string, error) {
+	var locale string
+	err := r.db.QueryRow(ctx, `
+		SELECT locale
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&locale)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "ja", nil
+		}
+		return "", err
+	}
+	return locale, nil
+}
+
+func (r *UserSettingsRepo) SetLocale(ctx context.Context, userID, locale string) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, locale)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET locale = EXCLUDED.locale,
+		    updated_at = NOW()`,
+		userID, locale,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) SetDigestAudioEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_audio_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_audio_enabled = EXCLUDED.digest_audio_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) SetDigestStyle(ctx context.Context, userID, verbosity, tone string, maxClusters int) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_verbosity, digest_tone, digest_max_clusters)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_verbosity = EXCLUDED.digest_verbosity,
+		    digest_tone = EXCLUDED.digest_tone,
+		    digest_max_clusters = EXCLUDED.digest_max_clusters,
+		    updated_at = NOW()`,
+		userID, verbosity, tone, maxClusters,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) SetDigestTopicPriorities(ctx context.Context, userID string, topics []string) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_topic_priorities)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_topic_priorities = EXCLUDED.digest_topic_priorities,
+		    updated_at = NOW()`,
+		userID, topics,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetDigestSchedule stores the weekdays (0 = Sunday, JST) and the optional vacation range on
+// which no daily digest is sent.
+func (r *UserSettingsRepo) SetDigestSchedule(ctx context.Context, userID string, skipWeekdays []int, vacationStart, vacationEnd *time.Time) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_skip_weekdays, digest_vacation_start, digest_vacation_end)
+		VALUES ($1, $2::smallint[], $3::date, $4::date)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_skip_weekdays = EXCLUDED.digest_skip_weekdays,
+		    digest_vacation_start = EXCLUDED.digest_vacation_start,
+		    digest_vacation_end = EXCLUDED.digest_vacation_end,
+		    updated_at = NOW()`,
+		userID, skipWeekdays, formatOptionalDate(vacationStart), formatOptionalDate(vacationEnd),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetDigestSkipWeekdays replaces the skipped weekdays and keeps any vacation range.
+func (r *UserSettingsRepo) SetDigestSkipWeekdays(ctx context.Context, userID string, skipWeekdays []int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_skip_weekdays)
+		VALUES ($1, $2::smallint[])
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_skip_weekdays = EXCLUDED.digest_skip_weekdays,
+		    updated_at = NOW()`,
+		userID, skipWeekdays,
+	)
+	return err
+}
+
+// SetDigestApproval turns the review-before-send mode on or off. autoApproveMinutes is how long a
+// composed digest waits for approval before it is sent anyway; 0 waits indefinitely.
+func (r *UserSettingsRepo) SetDigestApproval(ctx context.Context, userID string, enabled bool, autoApproveMinutes int) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_approval_enabled, digest_auto_approve_minutes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_approval_enabled = EXCLUDED.digest_approval_enabled,
+		    digest_auto_approve_minutes = EXCLUDED.digest_auto_approve_minutes,
+		    updated_at = NOW()`,
+		userID, enabled, autoApproveMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.Format("2006-01-02")
+	return &v
+}
+
+func (r *UserSettingsRepo) SetBudgetEnforcement(ctx context.Context, userID string, enabled bool, hardCapUSD *float64) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, budget_enforcement_enabled, budget_hard_cap_usd)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET budget_enforcement_enabled = EXCLUDED.budget_enforcement_enabled,
+		    budget_hard_cap_usd = EXCLUDED.budget_hard_cap_usd,
+		    updated_at = NOW()`,
+		userID, enabled, hardCapUSD,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) GetDigestFeedToken(ctx context.Context, userID string) (*string, error) {
+	var token *string
+	err := r.db.QueryRow(ctx, `
+		SELECT digest_feed_token
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&token)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *UserSettingsRepo) SetDigestFeedToken(ctx context.Context, userID string, token *string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, digest_feed_token)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET digest_feed_token = EXCLUDED.digest_feed_token,
+		    updated_at = NOW()`,
+		userID, token,
+	)
+	return mapDBError(err)
+}
+
+func (r *UserSettingsRepo) GetByDigestFeedToken(ctx context.Context, token string) (*model.UserSettings, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, nil
+	}
+	var v model.UserSettings
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, locale, created_at, updated_at
+		FROM user_settings
+		WHERE digest_feed_token = $1`,
+		token,
+	).Scan(&v.UserID, &v.Locale, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *UserSettingsRepo) IsFeedAutoMigrateEnabled(ctx context.Context, userID string) (bool, error) {
+	var enabled bool
+	err := r.db.QueryRow(ctx, `
+		SELECT feed_auto_migrate_enabled
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&enabled)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return enabled, nil
+}
+
+func (r *UserSettingsRepo) SetFeedAutoMigrateEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, feed_auto_migrate_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET feed_auto_migrate_enabled = EXCLUDED.feed_auto_migrate_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetSourceStatsSharingEnabled opts the user's sources in or out of the anonymized
+// co-subscription statistics. Opting out takes effect at the next statistics rebuild.
+func (r *UserSettingsRepo) SetSourceStatsSharingEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, source_stats_sharing_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET source_stats_sharing_enabled = EXCLUDED.source_stats_sharing_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetHTMLSnapshotsEnabled toggles keeping a sanitized HTML copy of each processed article.
+// Existing snapshots are kept when it is turned off.
+func (r *UserSettingsRepo) SetHTMLSnapshotsEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, html_snapshots_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET html_snapshots_enabled = EXCLUDED.html_snapshots_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// itemPaywallAllowedSQL drops paywalled items for users who opted out of them. It expects the
+// items alias i and the user id bound as $1.
+const itemPaywallAllowedSQL = ` AND NOT (i.paywalled AND EXISTS (
+			SELECT 1 FROM user_settings pus
+			WHERE pus.user_id = $1 AND pus.exclude_paywalled
+		))`
+
+// SetExcludePaywalled toggles leaving paywalled items out of reading plans and digests. The
+// precomputed reading plan is marked stale so the change applies on the next request.
+func (r *UserSettingsRepo) SetExcludePaywalled(ctx context.Context, userID string, exclude bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, exclude_paywalled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET exclude_paywalled = EXCLUDED.exclude_paywalled,
+		    updated_at = NOW()`,
+		userID, exclude,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewReadingPlanSnapshotRepo(r.db).MarkStale(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetSocialBoostEnabled toggles ranking items discussed on Hacker News or Reddit a little higher
+// in reading plans. The precomputed reading plan is marked stale so the change applies on the
+// next request.
+func (r *UserSettingsRepo) SetSocialBoostEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, social_boost_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET social_boost_enabled = EXCLUDED.social_boost_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewReadingPlanSnapshotRepo(r.db).MarkStale(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetProcessingPaused pauses or resumes LLM processing of the user's items. Pausing again keeps
+// the original pause time.
+func (r *UserSettingsRepo) SetProcessingPaused(ctx context.Context, userID string, paused bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, processing_paused_at)
+		VALUES ($1, CASE WHEN $2::boolean THEN NOW() END)
+		ON CONFLICT (user_id) DO UPDATE
+		SET processing_paused_at = CASE WHEN $2::boolean THEN COALESCE(user_settings.processing_paused_at, NOW()) END,
+		    updated_at = NOW()`,
+		userID, paused,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetWeeklyRecapEnabled opts the user in or out of the Sunday recap email.
+func (r *UserSettingsRepo) SetWeeklyRecapEnabled(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, weekly_recap_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET weekly_recap_enabled = EXCLUDED.weekly_recap_enabled,
+		    updated_at = NOW()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetBriefingGreetingStyle stores the greeting style and marks today's briefing snapshot stale
+// so the next request greets in the new style.
+func (r *UserSettingsRepo) SetBriefingGreetingStyle(ctx context.Context, userID, style string) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, briefing_greeting_style)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET briefing_greeting_style = EXCLUDED.briefing_greeting_style,
+		    updated_at = NOW()`,
+		userID, style,
+	)
+	if err != nil {
+		return nil, err
+	}
+	today := timeutil.StartOfDayJST(timeutil.NowJST()).Format("2006-01-02")
+	if err := NewBriefingSnapshotRepo(r.db).MarkStale(ctx, userID, today); err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetLLMMaxConcurrency caps how many of the user's items run LLM processing at once; nil
+// falls back to the server default.
+func (r *UserSettingsRepo) SetLLMMaxConcurrency(ctx context.Context, userID string, max *int) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, llm_max_concurrency)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET llm_max_concurrency = EXCLUDED.llm_max_concurrency,
+		    updated_at = NOW()`,
+		userID, max,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetPrescreenThreshold sets the pre-screen score below which new items skip fact extraction
+// and summarization; nil turns pre-screening off.
+func (r *UserSettingsRepo) SetPrescreenThreshold(ctx context.Context, userID string, threshold *float64) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, prescreen_threshold)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET prescreen_threshold = EXCLUDED.prescreen_threshold,
+		    updated_at = NOW()`,
+		userID, threshold,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// SetStreakSettings stores the reads per JST day that complete a streak day and how many
+// freezes the user gets each month.
+func (r *UserSettingsRepo) SetStreakSettings(ctx context.Context, userID string, dailyTarget, monthlyFreezeAllowance int) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, streak_daily_target, streak_freeze_monthly_allowance)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET streak_daily_target = EXCLUDED.streak_daily_target,
+		    streak_freeze_monthly_allowance = EXCLUDED.streak_freeze_monthly_allowance,
+		    updated_at = NOW()`,
+		userID, dailyTarget, monthlyFreezeAllowance,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) SetOutputLanguage(ctx context.Context, userID string, language *string) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, output_language)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET output_language = EXCLUDED.output_language,
+		    updated_at = NOW()`,
+		userID, language,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// UpsertReadingPlanConfig saves the reading plan settings. A nil exploration keeps the stored value.
+func (r *UserSettingsRepo) UpsertReadingPlanConfig(ctx context.Context, userID, window string, size int, diversifyTopics, excludeRead bool, exploration *float64) (*model.UserSettings, error) {
 	_, err := r.db.Exec(ctx, `
 		INSERT INTO user_settings (
 			user_id,
 			reading_plan_window,
 			reading_plan_size,
 			reading_plan_diversify_topics,
-			reading_plan_exclude_read
-		) VALUES ($1, $2, $3, $4, $5)
+			reading_plan_exclude_read,
+			reading_plan_exploration
+		) VALUES ($1, $2, $3, $4, $5, COALESCE($6, 0))
 		ON CONFLICT (user_id) DO UPDATE
 		SET reading_plan_window = EXCLUDED.reading_plan_window,
 		    reading_plan_size = EXCLUDED.reading_plan_size,
 		    reading_plan_diversify_topics = EXCLUDED.reading_plan_diversify_topics,
 		    reading_plan_exclude_read = EXCLUDED.reading_plan_exclude_read,
+		    reading_plan_exploration = COALESCE($6, user_settings.reading_plan_exploration),
 		    updated_at = NOW()`,
-		userID, window, size, diversifyTopics, excludeRead,
+		userID, window, size, diversifyTopics, excludeRead, exploration,
 	)
 	if err != nil {
 		return nil, err
@@ -1214,7 +1745,7 @@ func (r *UserSettingsRepo) SetInoreaderOAuthTokens(ctx context.Context, userID,
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (user_id) DO UPDATE
 		SET inoreader_access_token_enc = EXCLUDED.inoreader_access_token_enc,
-		    inoreader_refresh_token_enc = EXCLUDED.inoreader_refresh_token_enc,
+		    inoreader_refresh_token_enc = COALESCE(EXCLUDED.inoreader_refresh_token_enc, user_settings.inoreader_refresh_token_enc),
 		    inoreader_token_expires_at = EXCLUDED.inoreader_token_expires_at,
 		    updated_at = NOW()`,
 		userID, accessTokenEnc, refreshTokenEnc, expiresAt,
@@ -1242,6 +1773,179 @@ func (r *UserSettingsRepo) ClearInoreaderOAuthTokens(ctx context.Context, userID
 	return r.GetByUserID(ctx, userID)
 }
 
+func (r *UserSettingsRepo) SetInoreaderSync(ctx context.Context, userID string, enabled, readState bool) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, inoreader_sync_enabled, inoreader_sync_read_state)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET inoreader_sync_enabled = EXCLUDED.inoreader_sync_enabled,
+		    inoreader_sync_read_state = EXCLUDED.inoreader_sync_read_state,
+		    updated_at = NOW()`,
+		userID, enabled, readState,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+// RecordInoreaderSync stores the outcome of a sync run; a nil syncErr clears the previous error.
+// readSyncedUntil advances the read-state watermark when set.
+func (r *UserSettingsRepo) RecordInoreaderSync(ctx context.Context, userID string, syncedAt time.Time, syncErr *string, readSyncedUntil *time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_settings
+		SET inoreader_last_synced_at = CASE WHEN $3::text IS NULL THEN $2 ELSE inoreader_last_synced_at END,
+		    inoreader_last_sync_error = $3,
+		    inoreader_read_synced_until = COALESCE($4, inoreader_read_synced_until),
+		    updated_at = NOW()
+		WHERE user_id = $1`,
+		userID, syncedAt, syncErr, readSyncedUntil,
+	)
+	return err
+}
+
+// ListInoreaderSyncTargets returns the users with sync enabled and a stored token.
+func (r *UserSettingsRepo) ListInoreaderSyncTargets(ctx context.Context) ([]model.InoreaderSyncTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, inoreader_sync_read_state, inoreader_last_synced_at, inoreader_read_synced_until
+		FROM user_settings
+		WHERE inoreader_sync_enabled = true
+		  AND COALESCE(inoreader_access_token_enc, '') <> ''
+		ORDER BY inoreader_last_synced_at ASC NULLS FIRST`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]model.InoreaderSyncTarget, 0)
+	for rows.Next() {
+		var t model.InoreaderSyncTarget
+		if err := rows.Scan(&t.UserID, &t.SyncReadState, &t.LastSyncedAt, &t.ReadSyncedUntil); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *UserSettingsRepo) GetFeedlyTokensEncrypted(ctx context.Context, userID string) (accessTokenEnc, refreshTokenEnc *string, expiresAt *time.Time, err error) {
+	err = r.db.QueryRow(ctx, `
+		SELECT feedly_access_token_enc, feedly_refresh_token_enc, feedly_token_expires_at
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&accessTokenEnc, &refreshTokenEnc, &expiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil, nil, nil
+		}
+		return nil, nil, nil, err
+	}
+	return accessTokenEnc, refreshTokenEnc, expiresAt, nil
+}
+
+func (r *UserSettingsRepo) SetFeedlyOAuthTokens(ctx context.Context, userID, accessTokenEnc string, refreshTokenEnc *string, expiresAt *time.Time) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, feedly_access_token_enc, feedly_refresh_token_enc, feedly_token_expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET feedly_access_token_enc = EXCLUDED.feedly_access_token_enc,
+		    feedly_refresh_token_enc = COALESCE(EXCLUDED.feedly_refresh_token_enc, user_settings.feedly_refresh_token_enc),
+		    feedly_token_expires_at = EXCLUDED.feedly_token_expires_at,
+		    updated_at = NOW()`,
+		userID, accessTokenEnc, refreshTokenEnc, expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *UserSettingsRepo) ClearFeedlyOAuthTokens(ctx context.Context, userID string) (*model.UserSettings, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, feedly_access_token_enc, feedly_refresh_token_enc, feedly_token_expires_at)
+		VALUES ($1, NULL, NULL, NULL)
+		ON CONFLICT (user_id) DO UPDATE
+		SET feedly_access_token_enc = NULL,
+		    feedly_refresh_token_enc = NULL,
+		    feedly_token_expires_at = NULL,
+		    updated_at = NOW()`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+type UserSMTPSettings struct {
+	Host        string
+	Port        int
+	Username    *string
+	PasswordEnc *string
+	FromEmail   string
+	FromName    *string
+}
+
+func (r *UserSettingsRepo) GetSMTPSettings(ctx context.Context, userID string) (*UserSMTPSettings, error) {
+	var host, fromEmail *string
+	var port *int
+	var v UserSMTPSettings
+	err := r.db.QueryRow(ctx, `
+		SELECT smtp_host, smtp_port, smtp_username, smtp_password_enc, smtp_from_email, smtp_from_name
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&host, &port, &v.Username, &v.PasswordEnc, &fromEmail, &v.FromName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if host == nil || *host == "" || fromEmail == nil || *fromEmail == "" {
+		return nil, nil
+	}
+	v.Host = *host
+	v.FromEmail = *fromEmail
+	if port != nil {
+		v.Port = *port
+	}
+	return &v, nil
+}
+
+func (r *UserSettingsRepo) SetSMTPSettings(ctx context.Context, userID string, in UserSMTPSettings) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, smtp_host, smtp_port, smtp_username, smtp_password_enc, smtp_from_email, smtp_from_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET smtp_host = EXCLUDED.smtp_host,
+		    smtp_port = EXCLUDED.smtp_port,
+		    smtp_username = EXCLUDED.smtp_username,
+		    smtp_password_enc = EXCLUDED.smtp_password_enc,
+		    smtp_from_email = EXCLUDED.smtp_from_email,
+		    smtp_from_name = EXCLUDED.smtp_from_name,
+		    updated_at = NOW()`,
+		userID, in.Host, in.Port, in.Username, in.PasswordEnc, in.FromEmail, in.FromName,
+	)
+	return err
+}
+
+func (r *UserSettingsRepo) ClearSMTPSettings(ctx context.Context, userID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_settings
+		SET smtp_host = NULL,
+		    smtp_port = NULL,
+		    smtp_username = NULL,
+		    smtp_password_enc = NULL,
+		    smtp_from_email = NULL,
+		    smtp_from_name = NULL,
+		    updated_at = NOW()
+		WHERE user_id = $1`,
+		userID,
+	)
+	return err
+}
+
 func (r *UserSettingsRepo) SetAnthropicAPIKey(ctx context.Context, userID, encryptedKey, last4 string) (*model.UserSettings, error) {
 	_, err := r.db.Exec(ctx, `
 		INSERT INTO user_settings (user_id, anthropic_api_key_enc, anthropic_api_key_last4)
@@ -2138,7 +2842,8 @@ func (r *UserSettingsRepo) ListBudgetAlertTargets(ctx context.Context) ([]Budget
 	rows, err := r.db.Query(ctx, `
 		SELECT u.id, u.email, u.name,
 		       us.monthly_budget_usd,
-		       us.budget_alert_threshold_pct
+		       us.budget_alert_threshold_pct,
+		       us.locale
 		FROM user_settings us
 		JOIN users u ON u.id = us.user_id
 		WHERE us.budget_alert_enabled = TRUE
@@ -2153,10 +2858,56 @@ func (r *UserSettingsRepo) ListBudgetAlertTargets(ctx context.Context) ([]Budget
 	var out []BudgetAlertTarget
 	for rows.Next() {
 		var v BudgetAlertTarget
-		if err := rows.Scan(&v.UserID, &v.Email, &v.Name, &v.MonthlyBudgetUSD, &v.BudgetAlertThresholdPct); err != nil {
+		if err := rows.Scan(&v.UserID, &v.Email, &v.Name, &v.MonthlyBudgetUSD, &v.BudgetAlertThresholdPct, &v.Locale); err != nil {
 			return nil, err
 		}
 		out = append(out, v)
 	}
 	return out, rows.Err()
 }
+
+// GetDashboardLayout returns the stored dashboard layout, or nil when the user keeps the default.
+func (r *UserSettingsRepo) GetDashboardLayout(ctx context.Context, userID string) (*model.DashboardLayout, error) {
+	var raw []byte
+	err := r.db.QueryRow(ctx, `
+		SELECT dashboard_layout
+		FROM user_settings
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var layout model.DashboardLayout
+	if err := json.Unmarshal(raw, &layout); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// SetDashboardLayout stores layout; nil resets the user to the default dashboard.
+func (r *UserSettingsRepo) SetDashboardLayout(ctx context.Context, userID string, layout *model.DashboardLayout) error {
+	var raw []byte
+	if layout != nil {
+		b, err := json.Marshal(layout)
+		if err != nil {
+			return err
+		}
+		raw = b
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_settings (user_id, dashboard_layout)
+		VALUES ($1, $2::jsonb)
+		ON CONFLICT (user_id) DO UPDATE
+		SET dashboard_layout = EXCLUDED.dashboard_layout,
+		    updated_at = NOW()`,
+		userID, raw,
+	)
+	return mapDBError(err)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/enjoydarts/sifto/api/internal/model"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -32,6 +33,39 @@ func (r *UserRepo) ListAll(ctx context.Context) ([]model.User, error) {
 	return users, nil
 }
 
+// ListActiveSince returns users who signed up, opened a briefing or read an item at or after
+// since.
+func (r *UserRepo) ListActiveSince(ctx context.Context, since time.Time) ([]model.User, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.email, u.name, u.email_verified_at, u.created_at, u.updated_at
+		FROM users u
+		WHERE u.created_at >= $1
+		   OR EXISTS (
+		        SELECT 1 FROM briefing_snapshots bs
+		        WHERE bs.user_id = u.id AND bs.last_viewed_at >= $1
+		      )
+		   OR EXISTS (
+		        SELECT 1 FROM item_reads ir
+		        WHERE ir.user_id = u.id AND ir.read_at >= $1
+		      )
+		ORDER BY u.created_at`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name,
+			&u.EmailVerifiedAt, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
 func (r *UserRepo) Upsert(ctx context.Context, email string, name *string) (*model.User, error) {
 	var u model.User
 	err := r.db.QueryRow(ctx, `
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const weeklyRecapTopTopics = 3
+
+type WeeklyRecapTarget struct {
+	UserID           string
+	Email            string
+	Name             *string
+	Locale           string
+	MonthlyBudgetUSD *float64
+}
+
+type WeeklyRecapRepo struct{ db *pgxpool.Pool }
+
+func NewWeeklyRecapRepo(db *pgxpool.Pool) *WeeklyRecapRepo { return &WeeklyRecapRepo{db: db} }
+
+// ListTargets returns users who have not opted out of the weekly recap. Users without a
+// settings row get the default, which is opted in.
+func (r *WeeklyRecapRepo) ListTargets(ctx context.Context) ([]WeeklyRecapTarget, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.email, u.name,
+		       COALESCE(us.locale, ''),
+		       us.monthly_budget_usd
+		FROM users u
+		LEFT JOIN user_settings us ON us.user_id = u.id
+		WHERE COALESCE(us.weekly_recap_enabled, TRUE)
+		  AND u.email <> ''
+		ORDER BY u.created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WeeklyRecapTarget
+	for rows.Next() {
+		var v WeeklyRecapTarget
+		if err := rows.Scan(&v.UserID, &v.Email, &v.Name, &v.Locale, &v.MonthlyBudgetUSD); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// CollectStats gathers the reading side of the recap for the JST dates weekStart..weekEnd.
+func (r *WeeklyRecapRepo) CollectStats(ctx context.Context, userID, weekStart, weekEnd string) (model.WeeklyRecapStats, error) {
+	stats := model.WeeklyRecapStats{WeekStart: weekStart, WeekEnd: weekEnd, TopTopics: []model.WeeklyReviewTopic{}}
+	if err := r.db.QueryRow(ctx, weeklyReviewReadCountQuery(), userID, weekStart, weekEnd).Scan(&stats.ReadCount); err != nil {
+		return stats, err
+	}
+
+	rows, err := r.db.Query(ctx, weeklyReviewTopicsQuery(), userID, weekStart, weekEnd)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var topic model.WeeklyReviewTopic
+		if err := rows.Scan(&topic.Topic, &topic.Count); err != nil {
+			return stats, err
+		}
+		if len(stats.TopTopics) < weeklyRecapTopTopics {
+			stats.TopTopics = append(stats.TopTopics, topic)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	if err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(MAX(streak_days), 0)::int
+		FROM reading_streaks
+		WHERE user_id = $1
+		  AND streak_date BETWEEN $2::date AND $3::date`,
+		userID, weekStart, weekEnd,
+	).Scan(&stats.BestStreakDays); err != nil {
+		return stats, err
+	}
+
+	var source model.WeeklyRecapSource
+	err = r.db.QueryRow(ctx, `
+		SELECT COALESCE(NULLIF(BTRIM(s.title), ''), s.url), COUNT(*)::int AS count
+		FROM item_reads ir
+		JOIN items i ON i.id = ir.item_id
+		JOIN sources s ON s.id = i.source_id
+		WHERE ir.user_id = $1
+		  AND s.user_id = $1
+		  AND i.deleted_at IS NULL
+		  AND (ir.read_at AT TIME ZONE 'Asia/Tokyo')::date BETWEEN $2::date AND $3::date
+		GROUP BY s.id, s.title, s.url
+		ORDER BY count DESC, s.created_at ASC
+		LIMIT 1`,
+		userID, weekStart, weekEnd,
+	).Scan(&source.Title, &source.ReadCount)
+	switch {
+	case err == nil:
+		stats.TopSource = &source
+	case err != pgx.ErrNoRows:
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (r *WeeklyRecapRepo) Sent(ctx context.Context, userID, weekStart string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM weekly_recap_logs WHERE user_id = $1 AND week_start = $2::date
+		)`,
+		userID, weekStart,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *WeeklyRecapRepo) MarkSent(ctx context.Context, userID, weekStart string, readCount int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO weekly_recap_logs (user_id, week_start, read_count)
+		VALUES ($1, $2::date, $3)
+		ON CONFLICT (user_id, week_start) DO NOTHING`,
+		userID, weekStart, readCount,
+	)
+	return err
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+var VerifiableAPIKeyProviders = []string{"anthropic", "openai", "google"}
+
+type apiKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, provider, apiKey string) (*VerifyAPIKeyResponse, error)
+}
+
+// APIKeyVerificationService checks a provider key before it is saved so bad keys fail in
+// settings instead of on the next processed item.
+type APIKeyVerificationService struct {
+	worker apiKeyVerifier
+}
+
+func NewAPIKeyVerificationService(worker *WorkerClient) *APIKeyVerificationService {
+	if worker == nil {
+		return &APIKeyVerificationService{}
+	}
+	return &APIKeyVerificationService{worker: worker}
+}
+
+func (s *APIKeyVerificationService) Verify(ctx context.Context, provider, apiKey string) (*VerifyAPIKeyResponse, error) {
+	provider = strings.TrimSpace(provider)
+	apiKey = strings.TrimSpace(apiKey)
+	if !slices.Contains(VerifiableAPIKeyProviders, provider) {
+		return nil, &ValidationError{Field: "provider", Message: "unsupported provider"}
+	}
+	if apiKey == "" {
+		return nil, &ValidationError{Field: "api_key", Message: "api_key is required"}
+	}
+	if s == nil || s.worker == nil {
+		return nil, ErrWorkerUnavailable
+	}
+	resp, err := s.worker.VerifyAPIKey(ctx, provider, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Limits == nil {
+		resp.Limits = map[string]int64{}
+	}
+	return resp, nil
+}
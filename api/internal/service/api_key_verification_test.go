@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeAPIKeyVerifier struct {
+	provider string
+	apiKey   string
+	resp     *VerifyAPIKeyResponse
+}
+
+func (f *fakeAPIKeyVerifier) VerifyAPIKey(_ context.Context, provider, apiKey string) (*VerifyAPIKeyResponse, error) {
+	f.provider = provider
+	f.apiKey = apiKey
+	return f.resp, nil
+}
+
+func TestAPIKeyVerificationServiceVerify(t *testing.T) {
+	fake := &fakeAPIKeyVerifier{resp: &VerifyAPIKeyResponse{Provider: "anthropic", Valid: true, StatusCode: 200}}
+	svc := &APIKeyVerificationService{worker: fake}
+
+	got, err := svc.Verify(context.Background(), "anthropic", "  sk-test  ")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !got.Valid || got.Limits == nil {
+		t.Fatalf("Verify() = %+v, want valid with non-nil limits", got)
+	}
+	if fake.apiKey != "sk-test" {
+		t.Fatalf("worker api key = %q, want trimmed key", fake.apiKey)
+	}
+
+	var ve *ValidationError
+	if _, err := svc.Verify(context.Background(), "groq", "key"); !errors.As(err, &ve) || ve.Field != "provider" {
+		t.Fatalf("Verify(groq) error = %v, want provider validation error", err)
+	}
+	if _, err := svc.Verify(context.Background(), "openai", " "); !errors.As(err, &ve) || ve.Field != "api_key" {
+		t.Fatalf("Verify(empty key) error = %v, want api_key validation error", err)
+	}
+	if _, err := (&APIKeyVerificationService{}).Verify(context.Background(), "openai", "key"); !errors.Is(err, ErrWorkerUnavailable) {
+		t.Fatalf("Verify() without worker error = %v, want ErrWorkerUnavailable", err)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const archivePaywallMaxChars = 2000
+
+// ArchiveExtractor reads a cached copy of a page from the Wayback Machine and, when enabled,
+// archive.today. It is the last resort for pages that are gone or sit behind a paywall.
+type ArchiveExtractor struct {
+	http            *http.Client
+	reader          *ReadabilityExtractor
+	waybackAPIURL   string
+	archiveTodayURL string
+	useArchiveToday bool
+}
+
+// NewArchiveExtractor reads EXTRACT_ARCHIVE_TODAY=true to also try archive.today after the
+// Wayback Machine.
+func NewArchiveExtractor() *ArchiveExtractor {
+	client := NewPublicHTTPClient(30 * time.Second)
+	reader := NewReadabilityExtractor()
+	reader.http = client
+	return &ArchiveExtractor{
+		http:            client,
+		reader:          reader,
+		waybackAPIURL:   "https://archive.org/wayback/available",
+		archiveTodayURL: "https://archive.ph",
+		useArchiveToday: strings.EqualFold(strings.TrimSpace(os.Getenv("EXTRACT_ARCHIVE_TODAY")), "true"),
+	}
+}
+
+func (e *ArchiveExtractor) ExtractBody(ctx context.Context, rawURL string) (*ExtractBodyResponse, error) {
+	snapshot, err := e.waybackSnapshot(ctx, rawURL)
+	if err == nil {
+		resp, readErr := e.readSnapshot(ctx, waybackRawURL(snapshot), snapshot, ExtractionSourceWayback)
+		if readErr == nil {
+			return resp, nil
+		}
+		err = readErr
+	}
+	if !e.useArchiveToday {
+		return nil, err
+	}
+	resp, todayErr := e.readSnapshot(ctx, strings.TrimRight(e.archiveTodayURL, "/")+"/newest/"+rawURL, "", ExtractionSourceArchiveToday)
+	if todayErr != nil {
+		return nil, fmt.Errorf("%v; archive.today: %w", err, todayErr)
+	}
+	return resp, nil
+}
+
+func (e *ArchiveExtractor) waybackSnapshot(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.waybackAPIURL+"?url="+url.QueryEscape(rawURL), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("wayback lookup: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("wayback lookup: status %d", resp.StatusCode)
+	}
+	var out struct {
+		ArchivedSnapshots struct {
+			Closest *struct {
+				Available bool   `json:"available"`
+				URL       string `json:"url"`
+				Status    string `json:"status"`
+			} `json:"closest"`
+		} `json:"archived_snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("wayback lookup decode: %w", err)
+	}
+	closest := out.ArchivedSnapshots.Closest
+	if closest == nil || !closest.Available || closest.URL == "" || (closest.Status != "" && closest.Status != "200") {
+		return "", fmt.Errorf("wayback: no snapshot")
+	}
+	return strings.Replace(closest.URL, "http://web.archive.org/", "https://web.archive.org/", 1), nil
+}
+
+// readSnapshot fetches fetchURL and records displayURL (or the final URL after redirects) as
+// the item's archive_url.
+func (e *ArchiveExtractor) readSnapshot(ctx context.Context, fetchURL, displayURL, source string) (*ExtractBodyResponse, error) {
+	doc, finalURL, err := e.reader.fetchDocument(ctx, fetchURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ExtractReadableContent(doc)
+	if err != nil {
+		return nil, err
+	}
+	if LooksPaywalled(resp.Content) {
+		return nil, fmt.Errorf("%s: snapshot is paywalled too", source)
+	}
+	if displayURL == "" {
+		displayURL = finalURL
+	}
+	if source == ExtractionSourceArchiveToday {
+		// archive.today declares its own snapshot page as canonical.
+		resp.CanonicalURL = nil
+	}
+	resp.Source = source
+	resp.ArchiveURL = &displayURL
+	return resp, nil
+}
+
+var waybackTimestampRe = regexp.MustCompile(`^(https?://web\.archive\.org/web/\d+)(/)`)
+
+// waybackRawURL points a snapshot URL at the original page bytes, without the Wayback toolbar
+// and rewritten links.
+func waybackRawURL(snapshot string) string {
+	return waybackTimestampRe.ReplaceAllString(snapshot, "${1}id_${2}")
+}
+
+// archiveWorthyStatusRe matches the Go readability fallback's "readability fetch: status 404".
+var archiveWorthyStatusRe = regexp.MustCompile(`\bstatus[ =](401|402|403|404|410|451)\b`)
+
+// archiveWorthyHTTPXRe matches the httpx error a worker without structured statuses passes
+// through, such as "Client error '403 Forbidden' for url ...".
+var archiveWorthyHTTPXRe = regexp.MustCompile(`\bClient error '(401|402|403|404|410|451)\b`)
+
+func archiveWorthyStatus(code int) bool {
+	switch code {
+	case 401, 402, 403, 404, 410, 451:
+		return true
+	}
+	return false
+}
+
+// ShouldTryArchive reports whether an extraction error means the live page is gone or
+// blocked, as opposed to a transient or worker-side failure. The worker answers 422 for any
+// failed extraction, so for its errors only the status of the page itself counts.
+func ShouldTryArchive(err error) bool {
+	if err == nil || ExtractBodyPartial(err) != nil {
+		return false
+	}
+	var extractErr *ExtractBodyError
+	if errors.As(err, &extractErr) {
+		if extractErr.UpstreamStatus > 0 {
+			return archiveWorthyStatus(extractErr.UpstreamStatus)
+		}
+		return archiveWorthyHTTPXRe.MatchString(extractErr.Message)
+	}
+	return archiveWorthyStatusRe.MatchString(err.Error())
+}
+
+var paywallMarkers = []string{
+	"subscribe to continue reading",
+	"subscribe to read",
+	"subscribers only",
+	"this article is for subscribers",
+	"this content is for subscribers",
+	"to continue reading, please",
+	"already a subscriber? log in",
+	"create a free account to continue",
+	"sign in to continue reading",
+	"you've reached your free article limit",
+	"この記事は有料会員限定",
+	"有料会員限定",
+	"会員限定記事",
+	"この記事の続きを読むには",
+	"続きを読むには会員登録",
+	"有料会員になると",
+	"購読者限定",
+}
+
+// LooksPaywalled reports whether extracted text is a short teaser ending in a subscription
+// prompt. Long bodies are assumed complete even if they mention subscribing.
+func LooksPaywalled(content string) bool {
+	if len([]rune(content)) > archivePaywallMaxChars {
+		return false
+	}
+	lower := strings.ToLower(content)
+	for _, m := range paywallMarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
+}
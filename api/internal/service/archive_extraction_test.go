@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestArchiveExtractor(t *testing.T, handler http.HandlerFunc) *ArchiveExtractor {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &ArchiveExtractor{
+		http:            srv.Client(),
+		reader:          &ReadabilityExtractor{http: srv.Client()},
+		waybackAPIURL:   srv.URL + "/wayback/available",
+		archiveTodayURL: srv.URL + "/today",
+	}
+}
+
+func archiveArticleHTML() string {
+	return "<html><head><title>Archived</title></head><body><article><p>" +
+		strings.Repeat("This paragraph survived in the archive, with commas, and detail. ", 6) +
+		"</p></article></body></html>"
+}
+
+func TestArchiveExtractorReadsRawWaybackSnapshot(t *testing.T) {
+	var fetched string
+	e := newTestArchiveExtractor(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wayback/available":
+			if r.URL.Query().Get("url") != "https://example.com/a" {
+				t.Errorf("lookup url = %q", r.URL.Query().Get("url"))
+			}
+			_, _ = w.Write([]byte(`{"archived_snapshots":{"closest":{"available":true,"status":"200","url":"http://web.archive.org/web/20240101000000/https://example.com/a"}}}`))
+		default:
+			fetched = r.URL.Path
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(archiveArticleHTML()))
+		}
+	})
+	// Route web.archive.org to the test server.
+	e.reader.http.Transport = rewriteHostTransport{base: e.http.Transport, target: e.waybackAPIURL}
+
+	resp, err := e.ExtractBody(context.Background(), "https://example.com/a")
+	if err != nil {
+		t.Fatalf("ExtractBody() error = %v", err)
+	}
+	if fetched != "/web/20240101000000id_/https://example.com/a" {
+		t.Fatalf("fetched %q, want raw snapshot path", fetched)
+	}
+	if resp.Source != ExtractionSourceWayback || resp.ArchiveURL == nil || *resp.ArchiveURL != "https://web.archive.org/web/20240101000000/https://example.com/a" {
+		t.Fatalf("resp source=%q archive=%v", resp.Source, resp.ArchiveURL)
+	}
+}
+
+func TestArchiveExtractorFallsBackToArchiveToday(t *testing.T) {
+	e := newTestArchiveExtractor(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wayback/available":
+			_, _ = w.Write([]byte(`{"archived_snapshots":{}}`))
+		case strings.HasPrefix(r.URL.Path, "/today/newest/"):
+			http.Redirect(w, r, "/today/AbCd1", http.StatusFound)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(archiveArticleHTML()))
+		}
+	})
+
+	if _, err := e.ExtractBody(context.Background(), "https://example.com/a"); err == nil {
+		t.Fatal("expected error without archive.today enabled")
+	}
+	e.useArchiveToday = true
+	resp, err := e.ExtractBody(context.Background(), "https://example.com/a")
+	if err != nil {
+		t.Fatalf("ExtractBody() error = %v", err)
+	}
+	if resp.Source != ExtractionSourceArchiveToday || resp.ArchiveURL == nil || !strings.HasSuffix(*resp.ArchiveURL, "/today/AbCd1") {
+		t.Fatalf("resp source=%q archive=%v", resp.Source, resp.ArchiveURL)
+	}
+}
+
+func TestShouldTryArchive(t *testing.T) {
+	cases := map[string]bool{
+		"readability fetch: status 410":             true,
+		"readability fetch: status 503":             false,
+		"worker /extract-body: worker circuit open": false,
+	}
+	for msg, want := range cases {
+		if got := ShouldTryArchive(errors.New(msg)); got != want {
+			t.Errorf("ShouldTryArchive(%q) = %v, want %v", msg, got, want)
+		}
+	}
+
+	// Bodies as the worker's /extract-body returns them.
+	forbidden := `{"detail":{"code":"upstream_http_status","message":"Client error '403 Forbidden' for url 'https://example.com/a'\nFor more information check: https://developer.mozilla.org/en-US/docs/Web/HTTP/Status/403","upstream_status":403}}`
+	unavailable := `{"detail":{"code":"upstream_http_status","message":"Server error '503 Service Unavailable' for url 'https://example.com/a'","upstream_status":503}}`
+	workerCases := map[string]bool{
+		forbidden:                             true,
+		unavailable:                           false,
+		`{"detail":"Failed to extract body"}`: false,
+		// A worker without structured statuses still passes the httpx message through.
+		`{"detail":"Client error '404 Not Found' for url 'https://example.com/a'"}`: true,
+	}
+	for body, want := range workerCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(body))
+		}))
+		client := &WorkerClient{baseURL: srv.URL, http: srv.Client()}
+		_, err := client.ExtractBody(context.Background(), "https://example.com/a")
+		srv.Close()
+		if got := ShouldTryArchive(err); got != want {
+			t.Errorf("ShouldTryArchive(%v) = %v, want %v", err, got, want)
+		}
+	}
+
+	partial := &ExtractBodyError{Message: "status 404", Partial: &ExtractBodyResponse{}, UpstreamStatus: 404}
+	if ShouldTryArchive(partial) {
+		t.Error("partial results should not go to the archive")
+	}
+}
+
+func TestLooksPaywalled(t *testing.T) {
+	cases := map[string]bool{
+		"Markets rallied today. Subscribe to continue reading.":              true,
+		"新製品の概要です。この記事は有料会員限定です。":                                            true,
+		"Short note. You've reached your free article limit for this month.": true,
+		"A short announcement with no subscription wording.":                 false,
+		strings.Repeat("Long body. ", 300) + "Subscribe to read more.":       false,
+	}
+	for content, want := range cases {
+		if got := LooksPaywalled(content); got != want {
+			t.Errorf("LooksPaywalled(%.40q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
+func TestBodyExtractionChainUpgradesPaywalledBody(t *testing.T) {
+	teaser := &ExtractBodyResponse{Content: "Lead paragraph.\n\nSubscribe to continue reading."}
+	archived := "https://web.archive.org/web/1/https://example.com/a"
+	archive := &stubBodyExtractor{resp: &ExtractBodyResponse{Content: "full body", ArchiveURL: &archived}}
+	chain := NewBodyExtractionChain(
+		ExtractionStep{Source: ExtractionSourceWorker, Extractor: &stubBodyExtractor{resp: teaser}},
+		ExtractionStep{Source: ExtractionSourceWayback, Extractor: archive,
+			When:    func(_ string, err error) bool { return ShouldTryArchive(err) },
+			Upgrade: func(resp *ExtractBodyResponse) bool { return LooksPaywalled(resp.Content) }},
+	)
+
+	got, err := chain.ExtractBody(context.Background(), "https://example.com/a")
+	if err != nil || got.Content != "full body" || got.Source != ExtractionSourceWayback {
+		t.Fatalf("got %+v err=%v", got, err)
+	}
+
+	archive.resp, archive.err = nil, errors.New("wayback: no snapshot")
+	got, err = chain.ExtractBody(context.Background(), "https://example.com/a")
+	if err != nil || got != teaser || got.Source != ExtractionSourceWorker {
+		t.Fatalf("failed upgrade should keep the original: got %+v err=%v", got, err)
+	}
+}
+
+type rewriteHostTransport struct {
+	base   http.RoundTripper
+	target string
+}
+
+func (t rewriteHostTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	target, err := http.NewRequest(r.Method, t.target, nil)
+	if err != nil {
+		return nil, err
+	}
+	r = r.Clone(r.Context())
+	r.URL.Scheme, r.URL.Host, r.Host = target.URL.Scheme, target.URL.Host, ""
+	return t.base.RoundTrip(r)
+}
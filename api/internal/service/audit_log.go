@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionAPIKeySet         = "api_key.set"
+	AuditActionAPIKeyDelete      = "api_key.delete"
+	AuditActionOAuthConnect      = "oauth.connect"
+	AuditActionOAuthDisconnect   = "oauth.disconnect"
+	AuditActionBudgetUpdate      = "budget.update"
+	AuditActionBudgetEnforcement = "budget.enforcement_update"
+	AuditActionTokenCreate       = "token.create"
+	AuditActionTokenRevoke       = "token.revoke"
+	AuditActionExport            = "account.export"
+	AuditActionSessionRevoke     = "session.revoke"
+	AuditActionSMTPSet           = "smtp.set"
+	AuditActionSMTPDelete        = "smtp.delete"
+)
+
+const (
+	defaultAuditLogPageSize   = 50
+	maxAuditLogPageSize       = 200
+	auditLogUserAgentMaxRunes = 512
+)
+
+var ErrInvalidAuditLogCursor = errors.New("invalid audit log cursor")
+
+// AuditEvent is an action to record. Target names what was acted on, such as the API key
+// provider or the OAuth service.
+type AuditEvent struct {
+	UserID    string
+	Action    string
+	Target    string
+	Metadata  map[string]any
+	IPAddress string
+	UserAgent string
+}
+
+type auditLogRepo interface {
+	Insert(ctx context.Context, e repository.AuditLogInsert) error
+	ListByUser(ctx context.Context, userID string, before *repository.AuditLogCursor, limit int) ([]model.AuditLogEntry, error)
+}
+
+type AuditLogService struct {
+	repo auditLogRepo
+}
+
+func NewAuditLogService(repo auditLogRepo) *AuditLogService {
+	return &AuditLogService{repo: repo}
+}
+
+// Record stores the event. The action it describes has already happened, so a failed write is
+// logged rather than returned.
+func (s *AuditLogService) Record(ctx context.Context, e AuditEvent) {
+	if s == nil || strings.TrimSpace(e.UserID) == "" {
+		return
+	}
+	ua := strings.TrimSpace(e.UserAgent)
+	if r := []rune(ua); len(r) > auditLogUserAgentMaxRunes {
+		ua = string(r[:auditLogUserAgentMaxRunes])
+	}
+	err := s.repo.Insert(context.WithoutCancel(ctx), repository.AuditLogInsert{
+		UserID:    e.UserID,
+		Action:    e.Action,
+		Target:    strings.TrimSpace(e.Target),
+		Metadata:  e.Metadata,
+		IPAddress: strings.TrimSpace(e.IPAddress),
+		UserAgent: ua,
+	})
+	if err != nil {
+		log.Printf("audit log write failed user_id=%s action=%s target=%s err=%v", e.UserID, e.Action, e.Target, err)
+	}
+}
+
+// List returns a newest-first page of the user's audit log. before is the NextBefore of the
+// previous page; limit is clamped to 1..200 and defaults to 50.
+func (s *AuditLogService) List(ctx context.Context, userID, before string, limit int) (*model.AuditLogPage, error) {
+	if limit <= 0 {
+		limit = defaultAuditLogPageSize
+	}
+	limit = min(limit, maxAuditLogPageSize)
+	var cursor *repository.AuditLogCursor
+	if strings.TrimSpace(before) != "" {
+		c, err := decodeAuditLogCursor(before)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &c
+	}
+	entries, err := s.repo.ListByUser(ctx, userID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &model.AuditLogPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		next := encodeAuditLogCursor(repository.AuditLogCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextBefore = &next
+	}
+	return page, nil
+}
+
+func encodeAuditLogCursor(c repository.AuditLogCursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuditLogCursor(v string) (repository.AuditLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(v))
+	if err != nil {
+		return repository.AuditLogCursor{}, ErrInvalidAuditLogCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok || uuid.Validate(id) != nil {
+		return repository.AuditLogCursor{}, ErrInvalidAuditLogCursor
+	}
+	n, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return repository.AuditLogCursor{}, ErrInvalidAuditLogCursor
+	}
+	return repository.AuditLogCursor{CreatedAt: time.UnixMicro(n), ID: id}, nil
+}
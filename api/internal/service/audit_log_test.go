@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeAuditLogRepo struct {
+	inserted []repository.AuditLogInsert
+	entries  []model.AuditLogEntry
+	before   *repository.AuditLogCursor
+	limit    int
+}
+
+func (f *fakeAuditLogRepo) Insert(_ context.Context, e repository.AuditLogInsert) error {
+	f.inserted = append(f.inserted, e)
+	return nil
+}
+
+func (f *fakeAuditLogRepo) ListByUser(_ context.Context, _ string, before *repository.AuditLogCursor, limit int) ([]model.AuditLogEntry, error) {
+	f.before, f.limit = before, limit
+	return f.entries[:min(limit, len(f.entries))], nil
+}
+
+func TestAuditLogServiceRecord(t *testing.T) {
+	repo := &fakeAuditLogRepo{}
+	svc := NewAuditLogService(repo)
+	svc.Record(context.Background(), AuditEvent{UserID: "u1", Action: AuditActionAPIKeySet, Target: " openai ", UserAgent: strings.Repeat("a", 600)})
+	svc.Record(context.Background(), AuditEvent{Action: AuditActionAPIKeySet})
+	if len(repo.inserted) != 1 {
+		t.Fatalf("inserted = %+v", repo.inserted)
+	}
+	if got := repo.inserted[0]; got.Target != "openai" || len([]rune(got.UserAgent)) != auditLogUserAgentMaxRunes {
+		t.Fatalf("inserted = %+v", got)
+	}
+
+	var nilSvc *AuditLogService
+	nilSvc.Record(context.Background(), AuditEvent{UserID: "u1"})
+}
+
+func TestAuditLogServiceList(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 123456000, time.UTC)
+	repo := &fakeAuditLogRepo{}
+	for i := range 3 {
+		repo.entries = append(repo.entries, model.AuditLogEntry{ID: fmt.Sprintf("00000000-0000-4000-8000-00000000000%d", i), CreatedAt: base.Add(-time.Duration(i) * time.Minute)})
+	}
+	svc := NewAuditLogService(repo)
+
+	page, err := svc.List(context.Background(), "u1", "", 2)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if repo.limit != 3 || len(page.Entries) != 2 || page.NextBefore == nil {
+		t.Fatalf("page = %+v, limit = %d", page, repo.limit)
+	}
+
+	if _, err := svc.List(context.Background(), "u1", *page.NextBefore, 500); err != nil {
+		t.Fatalf("List next: %v", err)
+	}
+	if repo.before == nil || repo.before.ID != repo.entries[1].ID || !repo.before.CreatedAt.Equal(repo.entries[1].CreatedAt) || repo.limit != maxAuditLogPageSize+1 {
+		t.Fatalf("before = %+v, limit = %d", repo.before, repo.limit)
+	}
+
+	page, err = svc.List(context.Background(), "u1", "", 0)
+	if err != nil || len(page.Entries) != 3 || page.NextBefore != nil {
+		t.Fatalf("last page = %+v, %v", page, err)
+	}
+
+	for _, bad := range []string{"!!", encodeBase64URL("no-separator"), encodeBase64URL("x:e1"), encodeBase64URL("1714564800000000:e1")} {
+		if _, err := svc.List(context.Background(), "u1", bad, 0); !errors.Is(err, ErrInvalidAuditLogCursor) {
+			t.Errorf("List(%q) err = %v", bad, err)
+		}
+	}
+}
+
+func encodeBase64URL(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Extraction sources recorded on items.extraction_source.
+const (
+	ExtractionSourceWorker       = "worker"
+	ExtractionSourceReadability  = "readability"
+	ExtractionSourceWayback      = "wayback"
+	ExtractionSourceArchiveToday = "archive_today"
+)
+
+// BodyExtractor extracts the readable body of an article URL.
+type BodyExtractor interface {
+	ExtractBody(ctx context.Context, url string) (*ExtractBodyResponse, error)
+}
+
+// ExtractionStep is one extractor in a BodyExtractionChain. When decides whether the step
+// runs after the previous steps failed with err (the worker's error when it ran); nil means
+// always. Primary steps run before the chain's first step for URLs they claim. Upgrade lets a
+// step replace a successful result, e.g. an archived copy of a paywalled page.
+type ExtractionStep struct {
+	Source    string
+	Extractor BodyExtractor
+	When      func(url string, err error) bool
+	Primary   func(url string) bool
+	Upgrade   func(resp *ExtractBodyResponse) bool
+}
+
+// BodyExtractionChain tries extractors in order until one succeeds and records which one
+// produced the body in ExtractBodyResponse.Source. When every step fails the worker's error
+// is returned, since retry and delete decisions are keyed on its messages.
+type BodyExtractionChain struct {
+	steps []ExtractionStep
+}
+
+func NewBodyExtractionChain(steps ...ExtractionStep) *BodyExtractionChain {
+	return &BodyExtractionChain{steps: steps}
+}
+
+// NewDefaultBodyExtractionChain uses the worker first, readability as a fallback and archived
+// snapshots for dead or paywalled pages. EXTRACT_READABILITY_FALLBACK=false and
+// EXTRACT_ARCHIVE_FALLBACK=false disable the fallbacks; EXTRACT_READABILITY_PRIMARY_HOSTS lists
+// hosts (and their subdomains) simple enough to try readability before the worker.
+func NewDefaultBodyExtractionChain(worker *WorkerClient) *BodyExtractionChain {
+	steps := []ExtractionStep{{Source: ExtractionSourceWorker, Extractor: worker}}
+	primaryHosts := parseHostList(os.Getenv("EXTRACT_READABILITY_PRIMARY_HOSTS"))
+	fallback := !strings.EqualFold(strings.TrimSpace(os.Getenv("EXTRACT_READABILITY_FALLBACK")), "false")
+	if fallback || len(primaryHosts) > 0 {
+		step := ExtractionStep{
+			Source:    ExtractionSourceReadability,
+			Extractor: NewReadabilityExtractor(),
+			When: func(_ string, err error) bool {
+				// A partial result means the worker identified the page (e.g. a YouTube video
+				// without transcript); there is no article body for readability to find.
+				return fallback && ExtractBodyPartial(err) == nil
+			},
+		}
+		if len(primaryHosts) > 0 {
+			step.Primary = func(rawURL string) bool { return hostMatches(rawURL, primaryHosts) }
+		}
+		steps = append(steps, step)
+	}
+	if !strings.EqualFold(strings.TrimSpace(os.Getenv("EXTRACT_ARCHIVE_FALLBACK")), "false") {
+		steps = append(steps, ExtractionStep{
+			Source:    ExtractionSourceWayback,
+			Extractor: NewArchiveExtractor(),
+			When:      func(_ string, err error) bool { return ShouldTryArchive(err) },
+			Upgrade:   func(resp *ExtractBodyResponse) bool { return LooksPaywalled(resp.Content) },
+		})
+	}
+	return NewBodyExtractionChain(steps...)
+}
+
+func (c *BodyExtractionChain) ExtractBody(ctx context.Context, rawURL string) (*ExtractBodyResponse, error) {
+	ordered := make([]ExtractionStep, 0, len(c.steps))
+	for _, s := range c.steps {
+		if s.Primary != nil && s.Primary(rawURL) {
+			ordered = append(ordered, s)
+		}
+	}
+	for _, s := range c.steps {
+		if s.Primary == nil || !s.Primary(rawURL) {
+			ordered = append(ordered, s)
+		}
+	}
+
+	var firstErr, workerErr, lastErr error
+	for i, s := range ordered {
+		prevErr := workerErr
+		if prevErr == nil {
+			prevErr = lastErr
+		}
+		if i > 0 && s.When != nil && !s.When(rawURL, prevErr) {
+			continue
+		}
+		resp, err := s.Extractor.ExtractBody(ctx, rawURL)
+		if err == nil && resp != nil {
+			if resp.Source == "" {
+				resp.Source = s.Source
+			}
+			if i > 0 {
+				log.Printf("extract-body fallback succeeded source=%s url=%s previous_err=%v", resp.Source, rawURL, prevErr)
+			}
+			return c.upgrade(ctx, rawURL, ordered[i+1:], resp), nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if s.Source == ExtractionSourceWorker {
+			workerErr = err
+		}
+		lastErr = err
+	}
+	if workerErr != nil {
+		return nil, workerErr
+	}
+	return nil, firstErr
+}
+
+func (c *BodyExtractionChain) upgrade(ctx context.Context, rawURL string, rest []ExtractionStep, resp *ExtractBodyResponse) *ExtractBodyResponse {
+	for _, s := range rest {
+		if s.Upgrade == nil || !s.Upgrade(resp) {
+			continue
+		}
+		better, err := s.Extractor.ExtractBody(ctx, rawURL)
+		if err != nil || better == nil {
+			log.Printf("extract-body upgrade failed source=%s url=%s err=%v", s.Source, rawURL, err)
+			continue
+		}
+		if better.Source == "" {
+			better.Source = s.Source
+		}
+		return better
+	}
+	return resp
+}
+
+func parseHostList(raw string) []string {
+	var out []string
+	for _, h := range strings.Split(raw, ",") {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			out = append(out, strings.TrimPrefix(h, "www."))
+		}
+	}
+	return out
+}
+
+func hostMatches(rawURL string, hosts []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubBodyExtractor struct {
+	resp  *ExtractBodyResponse
+	err   error
+	calls int
+}
+
+func (s *stubBodyExtractor) ExtractBody(context.Context, string) (*ExtractBodyResponse, error) {
+	s.calls++
+	return s.resp, s.err
+}
+
+func TestBodyExtractionChainFallsBackAndRecordsSource(t *testing.T) {
+	worker := &stubBodyExtractor{err: errors.New("worker /extract-body: status 422 detail=failed to extract body")}
+	readability := &stubBodyExtractor{resp: &ExtractBodyResponse{Content: "body"}}
+	chain := NewBodyExtractionChain(
+		ExtractionStep{Source: ExtractionSourceWorker, Extractor: worker},
+		ExtractionStep{Source: ExtractionSourceReadability, Extractor: readability},
+	)
+
+	got, err := chain.ExtractBody(context.Background(), "https://example.com/a")
+	if err != nil || got.Source != ExtractionSourceReadability {
+		t.Fatalf("got %+v err=%v", got, err)
+	}
+}
+
+func TestBodyExtractionChainReturnsWorkerErrorWhenAllFail(t *testing.T) {
+	workerErr := errors.New("worker /extract-body: status 404")
+	readability := &stubBodyExtractor{err: errors.New("readability fetch: status 404")}
+	chain := NewBodyExtractionChain(
+		ExtractionStep{Source: ExtractionSourceReadability, Extractor: readability, Primary: func(string) bool { return true }},
+		ExtractionStep{Source: ExtractionSourceWorker, Extractor: &stubBodyExtractor{err: workerErr}},
+	)
+
+	if _, err := chain.ExtractBody(context.Background(), "https://example.com/a"); err != workerErr {
+		t.Fatalf("err = %v, want worker error", err)
+	}
+	if readability.calls != 1 {
+		t.Fatalf("readability calls = %d", readability.calls)
+	}
+}
+
+func TestBodyExtractionChainSkipsFallbackForPartialResult(t *testing.T) {
+	title := "video"
+	partialErr := &ExtractBodyError{Message: "youtube transcript unavailable", Partial: &ExtractBodyResponse{Title: &title}}
+	readability := &stubBodyExtractor{resp: &ExtractBodyResponse{Content: "body"}}
+	chain := NewBodyExtractionChain(
+		ExtractionStep{Source: ExtractionSourceWorker, Extractor: &stubBodyExtractor{err: partialErr}},
+		ExtractionStep{Source: ExtractionSourceReadability, Extractor: readability, When: func(_ string, err error) bool {
+			return ExtractBodyPartial(err) == nil
+		}},
+	)
+
+	if _, err := chain.ExtractBody(context.Background(), "https://youtube.com/watch?v=x"); err != partialErr {
+		t.Fatalf("err = %v", err)
+	}
+	if readability.calls != 0 {
+		t.Fatal("readability should not run after a partial result")
+	}
+}
+
+func TestHostMatches(t *testing.T) {
+	hosts := parseHostList(" Example.com ,www.blog.dev")
+	for url, want := range map[string]bool{
+		"https://www.example.com/a": true,
+		"https://news.example.com":  true,
+		"https://notexample.com":    false,
+		"https://blog.dev/post":     true,
+	} {
+		if got := hostMatches(url, hosts); got != want {
+			t.Errorf("hostMatches(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
@@ -12,14 +12,7 @@ import (
 )
 
 func GreetingByHour(now time.Time) string {
-	hour := now.Hour()
-	if hour < 11 {
-		return "おはようございます"
-	}
-	if hour < 18 {
-		return "こんにちは"
-	}
-	return "こんばんは"
+	return GreetingForStyle(BriefingGreetingStyleStandard, now)
 }
 
 func GreetingKeyByHour(now time.Time) string {
@@ -37,6 +30,7 @@ func BuildBriefingToday(
 	ctx context.Context,
 	itemRepo *repository.ItemRepo,
 	streakRepo *repository.ReadingStreakRepo,
+	settingsRepo *repository.UserSettingsRepo,
 	userID string,
 	targetDate time.Time,
 	size int,
@@ -47,7 +41,6 @@ func BuildBriefingToday(
 	if size > 30 {
 		size = 30
 	}
-	const streakTarget = 3
 	const clusterLimit = 16
 	start := timeutil.StartOfDayJST(targetDate)
 	dateStr := start.Format("2006-01-02")
@@ -72,14 +65,28 @@ func BuildBriefingToday(
 	streak := 0
 	yesterdayCompleted := false
 	todayRead := 0
+	streakTarget := repository.DefaultStreakDailyTarget
+	freezesAvailable := 0
+	yesterdayFrozen := false
 	yesterday := start.AddDate(0, 0, -1).Format("2006-01-02")
 	if streakRepo != nil {
-		if _, streakDays, isCompleted, err := streakRepo.GetByUserAndDate(ctx, userID, yesterday); err == nil {
-			streak = streakDays
-			yesterdayCompleted = isCompleted
+		if target, err := streakRepo.DailyTarget(ctx, userID); err == nil {
+			streakTarget = target
+		}
+		if days, err := streakRepo.ListDays(ctx, userID, start.AddDate(0, 0, -1), start); err == nil {
+			for _, d := range days {
+				switch d.Date {
+				case yesterday:
+					streak = d.StreakDays
+					yesterdayCompleted = d.IsCompleted
+					yesterdayFrozen = d.FreezeSource != nil
+				case dateStr:
+					todayRead = d.ReadCount
+				}
+			}
 		}
-		if readCount, _, _, err := streakRepo.GetByUserAndDate(ctx, userID, dateStr); err == nil {
-			todayRead = readCount
+		if balance, err := streakRepo.FreezeBalance(ctx, userID, start); err == nil {
+			freezesAvailable = balance.Available
 		}
 	}
 	if readCount, unreadCount, err := itemRepo.CountSummarizedReadUnreadOnDateJST(ctx, userID, yesterday); err == nil {
@@ -172,39 +179,44 @@ func BuildBriefingToday(
 		})
 	}
 
-	streakDisplay := streak
-	if todayRead >= streakTarget {
-		if yesterdayCompleted {
-			streakDisplay = streak + 1
-		} else {
-			streakDisplay = 1
-		}
-	}
+	streakDisplay := StreakDisplayDays(streak, yesterdayCompleted, todayRead, streakTarget)
 	streakRemaining := streakTarget - todayRead
 	if streakRemaining < 0 {
 		streakRemaining = 0
 	}
 	streakAtRisk := streakDisplay > 0 && streakRemaining > 0 && timeutil.NowJST().Hour() >= 18
 	nowJST := timeutil.NowJST()
+	greetingStyle := BriefingGreetingStyleStandard
+	if settingsRepo != nil {
+		if settings, err := settingsRepo.GetByUserID(ctx, userID); err == nil {
+			if style, ok := NormalizeBriefingGreetingStyle(settings.BriefingGreetingStyle); ok {
+				greetingStyle = style
+			}
+		}
+	}
 
 	return &model.BriefingTodayResponse{
 		Date:           dateStr,
-		Greeting:       GreetingByHour(nowJST),
+		Greeting:       GreetingForStyle(greetingStyle, nowJST),
 		GreetingKey:    GreetingKeyByHour(nowJST),
+		GreetingStyle:  greetingStyle,
 		Status:         "ready",
 		HighlightItems: highlight,
 		Clusters:       clusters,
 		Stats: model.BriefingStats{
-			TotalUnread:         stats.Unread,
-			TodayHighlightCount: len(plan.Items),
-			YesterdayRead:       yRead,
-			YesterdaySkipped:    ySkipped,
-			StreakDays:          streakDisplay,
-			TodayReadCount:      todayRead,
-			StreakTarget:        streakTarget,
-			StreakRemaining:     streakRemaining,
-			StreakAtRisk:        streakAtRisk,
+			TotalUnread:            stats.Unread,
+			TodayHighlightCount:    len(plan.Items),
+			YesterdayRead:          yRead,
+			YesterdaySkipped:       ySkipped,
+			StreakDays:             streakDisplay,
+			TodayReadCount:         todayRead,
+			StreakTarget:           streakTarget,
+			StreakRemaining:        streakRemaining,
+			StreakAtRisk:           streakAtRisk,
+			StreakFreezesAvailable: freezesAvailable,
+			StreakYesterdayFrozen:  yesterdayFrozen,
 		},
+		Blocks: buildBriefingBlocks(ctx, itemRepo, userID, start, nowJST),
 	}, nil
 }
 
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	BriefingGreetingStyleStandard = "standard"
+	BriefingGreetingStyleCasual   = "casual"
+	BriefingGreetingStyleFormal   = "formal"
+	BriefingGreetingStyleNone     = "none"
+
+	BriefingBlockFavoriteFollowUp = "favorite_follow_up"
+	BriefingBlockReadingTimeNudge = "reading_time_nudge"
+
+	briefingFollowUpTopicLimit = 5
+	briefingFollowUpItemLimit  = 5
+	readingTimeHistogramDays   = 28
+	readingTimeNudgeMinReads   = 20
+	readingTimeNudgeMinShare   = 0.25
+)
+
+var briefingGreetings = map[string][3]string{
+	BriefingGreetingStyleStandard: {"おはようございます", "こんにちは", "こんばんは"},
+	BriefingGreetingStyleCasual:   {"おはよう！", "やあ、こんにちは！", "おつかれさま！"},
+	BriefingGreetingStyleFormal:   {"おはようございます。本日のブリーフィングをお届けします", "こんにちは。本日のブリーフィングをお届けします", "こんばんは。本日のブリーフィングをお届けします"},
+}
+
+// NormalizeBriefingGreetingStyle lower-cases style and reports whether it is supported.
+func NormalizeBriefingGreetingStyle(style string) (string, bool) {
+	v := strings.ToLower(strings.TrimSpace(style))
+	if v == BriefingGreetingStyleNone {
+		return v, true
+	}
+	_, ok := briefingGreetings[v]
+	return v, ok
+}
+
+// GreetingForStyle is GreetingByHour in the user's greeting style. The none style greets with
+// an empty string, and unknown styles fall back to standard.
+func GreetingForStyle(style string, now time.Time) string {
+	style, ok := NormalizeBriefingGreetingStyle(style)
+	if !ok {
+		style = BriefingGreetingStyleStandard
+	}
+	if style == BriefingGreetingStyleNone {
+		return ""
+	}
+	greetings := briefingGreetings[style]
+	switch GreetingKeyByHour(now) {
+	case "morning":
+		return greetings[0]
+	case "afternoon":
+		return greetings[1]
+	default:
+		return greetings[2]
+	}
+}
+
+// ReadingTimeNudge reports whether the hour and its neighbours hold at least
+// readingTimeNudgeMinShare of the reads in hist, and that share. Histograms with fewer than
+// readingTimeNudgeMinReads reads never nudge.
+func ReadingTimeNudge(hist [24]int, hour int) (float64, bool) {
+	total := 0
+	for _, n := range hist {
+		total += n
+	}
+	if total < readingTimeNudgeMinReads {
+		return 0, false
+	}
+	window := 0
+	for _, h := range readingTimeNudgeHours(hour) {
+		window += hist[h]
+	}
+	share := float64(window) / float64(total)
+	return share, share >= readingTimeNudgeMinShare
+}
+
+func readingTimeNudgeHours(hour int) []int {
+	return []int{(hour + 23) % 24, hour, (hour + 1) % 24}
+}
+
+// buildBriefingBlocks assembles the personalized sections. Each block is best effort: a failed
+// query drops that block instead of failing the briefing.
+func buildBriefingBlocks(ctx context.Context, itemRepo *repository.ItemRepo, userID string, start, now time.Time) []model.BriefingBlock {
+	blocks := []model.BriefingBlock{}
+	yesterday := start.AddDate(0, 0, -1)
+	topics, err := itemRepo.FavoritedTopicsOnDateJST(ctx, userID, yesterday.Format("2006-01-02"), briefingFollowUpTopicLimit)
+	if err != nil {
+		log.Printf("briefing favorite topics user=%s: %v", userID, err)
+	} else if len(topics) > 0 {
+		items, err := itemRepo.BriefingTopicFollowUps(ctx, userID, topics, yesterday, briefingFollowUpItemLimit)
+		if err != nil {
+			log.Printf("briefing favorite follow-ups user=%s: %v", userID, err)
+		} else if len(items) > 0 {
+			blocks = append(blocks, model.BriefingBlock{
+				Type:    BriefingBlockFavoriteFollowUp,
+				Title:   "昨日お気に入りにしたトピックの続報",
+				Message: fmt.Sprintf("「%s」の新着が %d 件あります", strings.Join(topics, "」「"), len(items)),
+				Topics:  topics,
+				Items:   items,
+			})
+		}
+	}
+
+	since := start.AddDate(0, 0, -readingTimeHistogramDays)
+	hist, err := itemRepo.ReadHourHistogram(ctx, userID, since)
+	if err != nil {
+		log.Printf("briefing read histogram user=%s: %v", userID, err)
+		return blocks
+	}
+	hour := now.Hour()
+	share, ok := ReadingTimeNudge(hist, hour)
+	if !ok {
+		return blocks
+	}
+	topic, err := itemRepo.TopReadTopicInHours(ctx, userID, since, readingTimeNudgeHours(hour))
+	if err != nil {
+		log.Printf("briefing read-time topic user=%s: %v", userID, err)
+	}
+	block := model.BriefingBlock{
+		Type:    BriefingBlockReadingTimeNudge,
+		Title:   "いつもの読書タイム",
+		Message: fmt.Sprintf("%d時ごろはいつも記事を読んでいる時間です", hour),
+		Hour:    &hour,
+		Share:   &share,
+	}
+	if topic != "" {
+		block.Message = fmt.Sprintf("%d時ごろはいつも「%s」の記事を読んでいる時間です", hour, topic)
+		block.Topics = []string{topic}
+	}
+	return append(blocks, block)
+}
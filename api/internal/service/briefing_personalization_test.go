@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestGreetingForStyle(t *testing.T) {
+	morning := time.Date(2026, 3, 10, 8, 0, 0, 0, timeutil.JST)
+	evening := time.Date(2026, 3, 10, 20, 0, 0, 0, timeutil.JST)
+	cases := []struct {
+		style string
+		now   time.Time
+		want  string
+	}{
+		{"standard", morning, "おはようございます"},
+		{"casual", evening, "おつかれさま！"},
+		{"Formal", morning, "おはようございます。本日のブリーフィングをお届けします"},
+		{"none", morning, ""},
+		{"unknown", evening, "こんばんは"},
+	}
+	for _, tc := range cases {
+		if got := GreetingForStyle(tc.style, tc.now); got != tc.want {
+			t.Fatalf("GreetingForStyle(%q) = %q, want %q", tc.style, got, tc.want)
+		}
+	}
+	if _, ok := NormalizeBriefingGreetingStyle("loud"); ok {
+		t.Fatal("unknown style accepted")
+	}
+}
+
+func TestReadingTimeNudge(t *testing.T) {
+	var hist [24]int
+	hist[7], hist[8], hist[23] = 4, 6, 2
+	if _, ok := ReadingTimeNudge(hist, 8); ok {
+		t.Fatal("nudged with too few reads")
+	}
+	hist[12], hist[21] = 10, 8
+	share, ok := ReadingTimeNudge(hist, 8)
+	if !ok || share < 0.33 || share > 0.34 {
+		t.Fatalf("ReadingTimeNudge(8) = %v, %v", share, ok)
+	}
+	if _, ok := ReadingTimeNudge(hist, 16); ok {
+		t.Fatal("nudged for a quiet hour")
+	}
+	// The window wraps around midnight: 23, 0 and 1.
+	if share, _ := ReadingTimeNudge(hist, 0); share != 2.0/30 {
+		t.Fatalf("ReadingTimeNudge(0) share = %v", share)
+	}
+}
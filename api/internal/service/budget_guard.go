@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+type budgetUsageSummer interface {
+	SumEstimatedCostByUserBetween(ctx context.Context, userID string, since, until time.Time) (float64, error)
+	SumEstimatedCostByUserPurposesBetween(ctx context.Context, userID string, purposes []string, since, until time.Time) (float64, error)
+}
+
+type budgetAllocationReader interface {
+	GetByUserPurpose(ctx context.Context, userID, purpose string) (*model.LLMBudgetAllocation, error)
+}
+
+type BudgetGuardStatus struct {
+	Enforced    bool    `json:"enforced"`
+	Purpose     string  `json:"purpose,omitempty"`
+	CapUSD      float64 `json:"cap_usd"`
+	UsedCostUSD float64 `json:"used_cost_usd"`
+	Exceeded    bool    `json:"exceeded"`
+}
+
+// BudgetGuard decides whether LLM processing should be paused because the user's
+// estimated monthly cost has reached their cap.
+type BudgetGuard struct {
+	usage       budgetUsageSummer
+	allocations budgetAllocationReader
+}
+
+func NewBudgetGuard(usage budgetUsageSummer) *BudgetGuard {
+	return &BudgetGuard{usage: usage}
+}
+
+func (g *BudgetGuard) WithAllocations(allocations budgetAllocationReader) *BudgetGuard {
+	g.allocations = allocations
+	return g
+}
+
+// BudgetMonthWindowJST returns the [start, end) range of the JST month containing now.
+func BudgetMonthWindowJST(now time.Time) (time.Time, time.Time) {
+	nowJST := now.In(timeutil.JST)
+	start := time.Date(nowJST.Year(), nowJST.Month(), 1, 0, 0, 0, 0, timeutil.JST)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// EffectiveBudgetCapUSD returns the enforced cap: the hard cap when set, otherwise 100% of the monthly budget.
+func EffectiveBudgetCapUSD(settings *model.UserSettings) (float64, bool) {
+	if settings == nil || !settings.BudgetEnforcementEnabled {
+		return 0, false
+	}
+	if settings.BudgetHardCapUSD != nil && *settings.BudgetHardCapUSD > 0 {
+		return *settings.BudgetHardCapUSD, true
+	}
+	if settings.MonthlyBudgetUSD != nil && *settings.MonthlyBudgetUSD > 0 {
+		return *settings.MonthlyBudgetUSD, true
+	}
+	return 0, false
+}
+
+func (g *BudgetGuard) Check(ctx context.Context, settings *model.UserSettings) (BudgetGuardStatus, error) {
+	capUSD, ok := EffectiveBudgetCapUSD(settings)
+	if !ok || g == nil || g.usage == nil {
+		return BudgetGuardStatus{}, nil
+	}
+	start, end := BudgetMonthWindowJST(timeutil.NowJST())
+	used, err := g.usage.SumEstimatedCostByUserBetween(ctx, settings.UserID, start, end)
+	if err != nil {
+		return BudgetGuardStatus{}, err
+	}
+	return BudgetGuardStatus{
+		Enforced:    true,
+		CapUSD:      capUSD,
+		UsedCostUSD: used,
+		Exceeded:    used >= capUSD,
+	}, nil
+}
+
+// CheckPurposes runs the overall check and then each per-purpose allocation, returning the
+// first exceeded status. Per-purpose caps apply whenever configured, independent of the
+// overall enforcement toggle.
+func (g *BudgetGuard) CheckPurposes(ctx context.Context, settings *model.UserSettings, purposes ...string) (BudgetGuardStatus, error) {
+	status, err := g.Check(ctx, settings)
+	if err != nil || status.Exceeded {
+		return status, err
+	}
+	if g == nil || g.usage == nil || g.allocations == nil || settings == nil {
+		return status, nil
+	}
+	start, end := BudgetMonthWindowJST(timeutil.NowJST())
+	for _, purpose := range purposes {
+		allocation, err := g.allocations.GetByUserPurpose(ctx, settings.UserID, purpose)
+		if err != nil {
+			return BudgetGuardStatus{}, err
+		}
+		if allocation == nil || allocation.MonthlyCapUSD <= 0 {
+			continue
+		}
+		used, err := g.usage.SumEstimatedCostByUserPurposesBetween(ctx, settings.UserID, LLMUsagePurposesForBudget(purpose), start, end)
+		if err != nil {
+			return BudgetGuardStatus{}, err
+		}
+		if used >= allocation.MonthlyCapUSD {
+			return BudgetGuardStatus{
+				Enforced:    true,
+				Purpose:     purpose,
+				CapUSD:      allocation.MonthlyCapUSD,
+				UsedCostUSD: used,
+				Exceeded:    true,
+			}, nil
+		}
+	}
+	return status, nil
+}
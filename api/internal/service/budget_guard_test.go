@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+type fakeBudgetUsage struct {
+	used        float64
+	usedPurpose float64
+	calls       int
+	purposes    []string
+}
+
+func (f *fakeBudgetUsage) SumEstimatedCostByUserBetween(_ context.Context, _ string, _, _ time.Time) (float64, error) {
+	f.calls++
+	return f.used, nil
+}
+
+func (f *fakeBudgetUsage) SumEstimatedCostByUserPurposesBetween(_ context.Context, _ string, purposes []string, _, _ time.Time) (float64, error) {
+	f.purposes = purposes
+	return f.usedPurpose, nil
+}
+
+type fakeBudgetAllocations map[string]float64
+
+func (f fakeBudgetAllocations) GetByUserPurpose(_ context.Context, userID, purpose string) (*model.LLMBudgetAllocation, error) {
+	capUSD, ok := f[purpose]
+	if !ok {
+		return nil, nil
+	}
+	return &model.LLMBudgetAllocation{UserID: userID, Purpose: purpose, MonthlyCapUSD: capUSD}, nil
+}
+
+func TestEffectiveBudgetCapUSD(t *testing.T) {
+	budget := 10.0
+	hardCap := 15.0
+	cases := []struct {
+		name     string
+		settings *model.UserSettings
+		wantCap  float64
+		wantOK   bool
+	}{
+		{name: "nil settings", settings: nil},
+		{name: "disabled", settings: &model.UserSettings{MonthlyBudgetUSD: &budget}},
+		{name: "monthly budget", settings: &model.UserSettings{BudgetEnforcementEnabled: true, MonthlyBudgetUSD: &budget}, wantCap: 10, wantOK: true},
+		{name: "hard cap wins", settings: &model.UserSettings{BudgetEnforcementEnabled: true, MonthlyBudgetUSD: &budget, BudgetHardCapUSD: &hardCap}, wantCap: 15, wantOK: true},
+		{name: "no cap configured", settings: &model.UserSettings{BudgetEnforcementEnabled: true}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := EffectiveBudgetCapUSD(tc.settings)
+			if ok != tc.wantOK || got != tc.wantCap {
+				t.Fatalf("EffectiveBudgetCapUSD() = (%v, %v), want (%v, %v)", got, ok, tc.wantCap, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestBudgetGuardCheck(t *testing.T) {
+	budget := 10.0
+	settings := &model.UserSettings{UserID: "u1", BudgetEnforcementEnabled: true, MonthlyBudgetUSD: &budget}
+
+	usage := &fakeBudgetUsage{used: 9.99}
+	status, err := NewBudgetGuard(usage).Check(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !status.Enforced || status.Exceeded {
+		t.Fatalf("Check() = %+v, want enforced and not exceeded", status)
+	}
+
+	usage.used = 10
+	status, err = NewBudgetGuard(usage).Check(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !status.Exceeded {
+		t.Fatalf("Check() = %+v, want exceeded at 100%%", status)
+	}
+
+	usage.calls = 0
+	status, err = NewBudgetGuard(usage).Check(context.Background(), &model.UserSettings{UserID: "u1", MonthlyBudgetUSD: &budget})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if status.Enforced || usage.calls != 0 {
+		t.Fatalf("Check() = %+v calls=%d, want no usage lookup when enforcement is off", status, usage.calls)
+	}
+}
+
+func TestBudgetMonthWindowJST(t *testing.T) {
+	now := time.Date(2026, 3, 31, 16, 30, 0, 0, time.UTC) // 2026-04-01 01:30 JST
+	start, end := BudgetMonthWindowJST(now)
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, timeutil.JST); !start.Equal(want) {
+		t.Fatalf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2026, 5, 1, 0, 0, 0, 0, timeutil.JST); !end.Equal(want) {
+		t.Fatalf("end = %v, want %v", end, want)
+	}
+}
+
+func TestBudgetGuardCheckPurposes(t *testing.T) {
+	settings := &model.UserSettings{UserID: "u1"}
+	usage := &fakeBudgetUsage{usedPurpose: 2}
+	guard := NewBudgetGuard(usage).WithAllocations(fakeBudgetAllocations{LLMBudgetPurposeSummary: 2})
+
+	status, err := guard.CheckPurposes(context.Background(), settings, LLMBudgetPurposeFacts)
+	if err != nil {
+		t.Fatalf("CheckPurposes() error = %v", err)
+	}
+	if status.Exceeded {
+		t.Fatalf("CheckPurposes(facts) = %+v, want not exceeded without allocation", status)
+	}
+
+	status, err = guard.CheckPurposes(context.Background(), settings, LLMBudgetPurposeFacts, LLMBudgetPurposeSummary)
+	if err != nil {
+		t.Fatalf("CheckPurposes() error = %v", err)
+	}
+	if !status.Exceeded || status.Purpose != LLMBudgetPurposeSummary {
+		t.Fatalf("CheckPurposes(summary) = %+v, want summary exceeded", status)
+	}
+	if want := []string{"summary", "faithfulness_check"}; !slices.Equal(usage.purposes, want) {
+		t.Fatalf("usage purposes = %v, want %v", usage.purposes, want)
+	}
+}
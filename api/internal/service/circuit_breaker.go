@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// CircuitBreaker trips after consecutive failures of a dependency so callers can fail fast
+// or serve degraded responses instead of piling more work onto it. After the cooldown a
+// single probe request is let through; its outcome closes or re-opens the breaker.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	isFailure func(error) bool
+	now       func() time.Time
+	failures  int
+	open      bool
+	probing   bool
+	openedAt  time.Time
+	trips     int64
+}
+
+// NewCircuitBreaker counts errors for which isFailure reports true; other errors neither
+// trip nor reset the breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, isFailure func(error) bool) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, isFailure: isFailure, now: time.Now}
+}
+
+// NewDBBreaker trips on errors caused by the database being slow or unreachable.
+func NewDBBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return NewCircuitBreaker(threshold, cooldown, IsDBUnavailable)
+}
+
+// Allow reports whether the caller may call the dependency.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// Record feeds the result of a call back into the breaker.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.open = false
+		b.probing = false
+	case b.isFailure(err):
+		b.failures++
+		if b.probing || (!b.open && b.failures >= b.threshold) {
+			if !b.open {
+				b.trips++
+			}
+			b.open = true
+			b.openedAt = b.now()
+		}
+		b.probing = false
+	default:
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case !b.open:
+		return BreakerClosed
+	case b.probing || b.now().Sub(b.openedAt) >= b.cooldown:
+		return BreakerHalfOpen
+	default:
+		return BreakerOpen
+	}
+}
+
+func (b *CircuitBreaker) Snapshot() map[string]any {
+	state := b.State()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return map[string]any{
+		"state":                state,
+		"consecutive_failures": b.failures,
+		"trips":                b.trips,
+	}
+}
+
+// IsDBUnavailable reports errors caused by the database being slow or unreachable, as
+// opposed to errors in the query or its result.
+func IsDBUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return true
+	}
+	var connErr *pgconn.ConnectError
+	if errors.As(err, &connErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "57014", // query_canceled (statement_timeout)
+			pgErr.Code == "53300", // too_many_connections
+			pgErr.Code == "57P01", // admin_shutdown
+			pgErr.Code == "57P03", // cannot_connect_now
+			len(pgErr.Code) == 5 && pgErr.Code[:2] == "08":
+			return true
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
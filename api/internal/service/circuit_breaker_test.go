@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestBreakerOpensAfterThresholdAndProbes(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewDBBreaker(3, 30*time.Second)
+	b.now = func() time.Time { return now }
+	timeout := fmt.Errorf("query: %w", context.DeadlineExceeded)
+
+	for i := 0; i < 2; i++ {
+		b.Record(timeout)
+	}
+	if !b.Allow() || b.State() != BreakerClosed {
+		t.Fatalf("breaker should stay closed below the threshold")
+	}
+	b.Record(timeout)
+	if b.Allow() || b.State() != BreakerOpen {
+		t.Fatalf("breaker should open at the threshold, state=%s", b.State())
+	}
+
+	now = now.Add(31 * time.Second)
+	if !b.Allow() {
+		t.Fatalf("one probe should be allowed after the cooldown")
+	}
+	if b.Allow() {
+		t.Fatalf("only one probe may run at a time")
+	}
+	b.Record(timeout)
+	if b.State() != BreakerOpen {
+		t.Fatalf("failed probe should re-open the breaker, state=%s", b.State())
+	}
+
+	now = now.Add(31 * time.Second)
+	if !b.Allow() {
+		t.Fatalf("probe should be allowed after the second cooldown")
+	}
+	b.Record(nil)
+	if b.State() != BreakerClosed || !b.Allow() {
+		t.Fatalf("successful probe should close the breaker")
+	}
+}
+
+func TestDBBreakerIgnoresNonAvailabilityErrors(t *testing.T) {
+	b := NewDBBreaker(1, time.Minute)
+	b.Record(errors.New("not found"))
+	b.Record(context.Canceled)
+	b.Record(&pgconn.PgError{Code: "23505"})
+	if b.State() != BreakerClosed {
+		t.Fatalf("state = %s, want closed", b.State())
+	}
+}
+
+func TestIsDBUnavailable(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"nil":               {nil, false},
+		"deadline":          {fmt.Errorf("wrap: %w", context.DeadlineExceeded), true},
+		"canceled":          {context.Canceled, false},
+		"statement timeout": {&pgconn.PgError{Code: "57014"}, true},
+		"connection class":  {&pgconn.PgError{Code: "08006"}, true},
+		"unique violation":  {&pgconn.PgError{Code: "23505"}, false},
+		"plain":             {errors.New("boom"), false},
+	}
+	for name, tc := range cases {
+		if got := IsDBUnavailable(tc.err); got != tc.want {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+		}
+	}
+}
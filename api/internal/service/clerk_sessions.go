@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const clerkAPIBaseURL = "https://api.clerk.com/v1"
+
+// ClerkSessionRevoker ends sign-in sessions through the Clerk Backend API, so a revoked session
+// is also signed out of the web app instead of only being refused by this API.
+type ClerkSessionRevoker struct {
+	baseURL   string
+	secretKey string
+	client    *http.Client
+}
+
+// NewClerkSessionRevokerFromEnv returns nil when CLERK_SECRET_KEY is unset.
+func NewClerkSessionRevokerFromEnv() *ClerkSessionRevoker {
+	secretKey := strings.TrimSpace(os.Getenv("CLERK_SECRET_KEY"))
+	if secretKey == "" {
+		return nil
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(os.Getenv("CLERK_API_URL")), "/")
+	if baseURL == "" {
+		baseURL = clerkAPIBaseURL
+	}
+	return &ClerkSessionRevoker{
+		baseURL:   baseURL,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *ClerkSessionRevoker) RevokeSession(ctx context.Context, sessionID string) error {
+	endpoint := c.baseURL + "/sessions/" + url.PathEscape(sessionID) + "/revoke"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("clerk revoke session failed: %s", resp.Status)
+	}
+	return nil
+}
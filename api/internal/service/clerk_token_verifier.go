@@ -18,8 +18,9 @@ import (
 )
 
 type ClerkClaims struct {
-	Subject string
-	Email   string
+	Subject   string
+	Email     string
+	SessionID string
 }
 
 type ClerkTokenVerifier struct {
@@ -110,9 +111,11 @@ func (v *ClerkTokenVerifier) Verify(ctx context.Context, token string) (*ClerkCl
 	}
 
 	email, _ := claims["email"].(string)
+	sessionID, _ := claims["sid"].(string)
 	return &ClerkClaims{
-		Subject: subject,
-		Email:   email,
+		Subject:   subject,
+		Email:     email,
+		SessionID: strings.TrimSpace(sessionID),
 	}, nil
 }
 
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	maxCollectionNameLength        = 80
+	maxCollectionDescriptionLength = 500
+	maxCollectionItems             = 500
+)
+
+type SaveCollectionInput struct {
+	Name                 string  `json:"name"`
+	Description          *string `json:"description"`
+	WeeklySummaryEnabled bool    `json:"weekly_summary_enabled"`
+}
+
+type CollectionService struct {
+	repo *repository.CollectionRepo
+}
+
+func NewCollectionService(repo *repository.CollectionRepo) *CollectionService {
+	return &CollectionService{repo: repo}
+}
+
+func (s *CollectionService) ListCollections(ctx context.Context, userID string) ([]model.Collection, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *CollectionService) GetCollection(ctx context.Context, userID, collectionID string) (*model.Collection, error) {
+	c, err := s.repo.GetByID(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, repository.ErrNotFound
+	}
+	return c, nil
+}
+
+func (s *CollectionService) CreateCollection(ctx context.Context, userID string, in SaveCollectionInput) (*model.Collection, error) {
+	c, err := normalizeCollectionInput(in)
+	if err != nil {
+		return nil, err
+	}
+	c.UserID = userID
+	return s.repo.Create(ctx, c)
+}
+
+func (s *CollectionService) UpdateCollection(ctx context.Context, userID, collectionID string, in SaveCollectionInput) (*model.Collection, error) {
+	c, err := normalizeCollectionInput(in)
+	if err != nil {
+		return nil, err
+	}
+	c.UserID = userID
+	c.ID = collectionID
+	return s.repo.Update(ctx, c)
+}
+
+func (s *CollectionService) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	return s.repo.Delete(ctx, userID, collectionID)
+}
+
+func (s *CollectionService) ListCollectionItems(ctx context.Context, userID, collectionID string) ([]model.CollectionItem, error) {
+	if _, err := s.GetCollection(ctx, userID, collectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListItems(ctx, userID, collectionID)
+}
+
+func (s *CollectionService) AddCollectionItem(ctx context.Context, userID, collectionID, itemID string) error {
+	c, err := s.GetCollection(ctx, userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if c.ItemCount >= maxCollectionItems {
+		return &ValidationError{Field: "item_id", Message: "collection is full"}
+	}
+	return s.repo.AddItem(ctx, userID, collectionID, itemID)
+}
+
+func (s *CollectionService) RemoveCollectionItem(ctx context.Context, userID, collectionID, itemID string) error {
+	return s.repo.RemoveItem(ctx, userID, collectionID, itemID)
+}
+
+func (s *CollectionService) ReorderCollectionItems(ctx context.Context, userID, collectionID string, itemIDs []string) error {
+	seen := make(map[string]struct{}, len(itemIDs))
+	for _, id := range itemIDs {
+		if strings.TrimSpace(id) == "" {
+			return &ValidationError{Field: "item_ids", Message: "item_ids must not contain empty values"}
+		}
+		if _, ok := seen[id]; ok {
+			return &ValidationError{Field: "item_ids", Message: "item_ids must not contain duplicates"}
+		}
+		seen[id] = struct{}{}
+	}
+	err := s.repo.ReorderItems(ctx, userID, collectionID, itemIDs)
+	if errors.Is(err, repository.ErrInvalidState) {
+		return &ValidationError{Field: "item_ids", Message: "item_ids must list every item in the collection exactly once"}
+	}
+	return err
+}
+
+func (s *CollectionService) LatestCollectionSummary(ctx context.Context, userID, collectionID string) (*model.CollectionSummary, error) {
+	if _, err := s.GetCollection(ctx, userID, collectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.LatestSummary(ctx, userID, collectionID)
+}
+
+func normalizeCollectionInput(in SaveCollectionInput) (model.Collection, error) {
+	out := model.Collection{
+		Name:                 strings.TrimSpace(in.Name),
+		Description:          trimmedOptional(in.Description),
+		WeeklySummaryEnabled: in.WeeklySummaryEnabled,
+	}
+	if out.Name == "" {
+		return out, &ValidationError{Field: "name", Message: "name is required"}
+	}
+	if utf8.RuneCountInString(out.Name) > maxCollectionNameLength {
+		return out, &ValidationError{Field: "name", Message: "name is too long"}
+	}
+	if out.Description != nil && utf8.RuneCountInString(*out.Description) > maxCollectionDescriptionLength {
+		return out, &ValidationError{Field: "description", Message: "description is too long"}
+	}
+	return out, nil
+}
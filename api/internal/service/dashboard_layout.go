@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	DashboardWidgetSources       = "sources_count"
+	DashboardWidgetItemStats     = "item_stats"
+	DashboardWidgetDigests       = "digests"
+	DashboardWidgetLLMSummary    = "llm_summary"
+	DashboardWidgetTopicTrends   = "topic_trends"
+	DashboardWidgetEntityTrends  = "entity_trends"
+	DashboardWidgetFailedPreview = "failed_items_preview"
+)
+
+type DashboardWidgetParam struct {
+	Name    string `json:"name"`
+	Default int    `json:"default"`
+	Min     int    `json:"min"`
+	Max     int    `json:"max"`
+}
+
+type DashboardWidgetSpec struct {
+	Name   string                 `json:"name"`
+	Params []DashboardWidgetParam `json:"params"`
+}
+
+// dashboardWidgetSpecs lists the widgets in the order the aggregate dashboard returns them.
+// Param ranges match the query validation of GET /dashboard.
+var dashboardWidgetSpecs = []DashboardWidgetSpec{
+	{Name: DashboardWidgetSources, Params: []DashboardWidgetParam{}},
+	{Name: DashboardWidgetItemStats, Params: []DashboardWidgetParam{}},
+	{Name: DashboardWidgetDigests, Params: []DashboardWidgetParam{{Name: "limit", Default: 5, Min: 1, Max: 20}}},
+	{Name: DashboardWidgetLLMSummary, Params: []DashboardWidgetParam{{Name: "days", Default: 7, Min: 1, Max: 365}}},
+	{Name: DashboardWidgetTopicTrends, Params: []DashboardWidgetParam{{Name: "limit", Default: 8, Min: 1, Max: 50}}},
+	{Name: DashboardWidgetEntityTrends, Params: []DashboardWidgetParam{{Name: "limit", Default: 8, Min: 1, Max: 50}}},
+	{Name: DashboardWidgetFailedPreview, Params: []DashboardWidgetParam{}},
+}
+
+func DashboardWidgetSpecs() []DashboardWidgetSpec {
+	return append([]DashboardWidgetSpec(nil), dashboardWidgetSpecs...)
+}
+
+func LookupDashboardWidget(name string) (DashboardWidgetSpec, bool) {
+	for _, spec := range dashboardWidgetSpecs {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return DashboardWidgetSpec{}, false
+}
+
+// DefaultDashboardLayout shows every widget with default params, same as the aggregate endpoint.
+func DefaultDashboardLayout() model.DashboardLayout {
+	widgets := make([]model.DashboardWidgetConfig, 0, len(dashboardWidgetSpecs))
+	for _, spec := range dashboardWidgetSpecs {
+		widgets = append(widgets, model.DashboardWidgetConfig{Name: spec.Name, Params: spec.defaultParams()})
+	}
+	return model.DashboardLayout{Widgets: widgets}
+}
+
+func (s DashboardWidgetSpec) defaultParams() map[string]int {
+	if len(s.Params) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(s.Params))
+	for _, p := range s.Params {
+		out[p.Name] = p.Default
+	}
+	return out
+}
+
+// ResolveParams fills defaults and validates every value in params against the spec.
+func (s DashboardWidgetSpec) ResolveParams(params map[string]int) (map[string]int, error) {
+	out := s.defaultParams()
+	for name, v := range params {
+		var param *DashboardWidgetParam
+		for i := range s.Params {
+			if s.Params[i].Name == name {
+				param = &s.Params[i]
+				break
+			}
+		}
+		if param == nil {
+			return nil, fmt.Errorf("widget %s does not accept param %q", s.Name, name)
+		}
+		if v < param.Min || v > param.Max {
+			return nil, fmt.Errorf("widget %s param %s must be between %d and %d", s.Name, name, param.Min, param.Max)
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+// NormalizeDashboardLayout validates a user supplied layout, rejecting unknown or
+// duplicate widgets and filling param defaults. The widget order is preserved.
+func NormalizeDashboardLayout(layout model.DashboardLayout) (model.DashboardLayout, error) {
+	out := model.DashboardLayout{Widgets: make([]model.DashboardWidgetConfig, 0, len(layout.Widgets))}
+	seen := map[string]struct{}{}
+	for _, w := range layout.Widgets {
+		name := strings.TrimSpace(w.Name)
+		spec, ok := LookupDashboardWidget(name)
+		if !ok {
+			return model.DashboardLayout{}, fmt.Errorf("unknown widget %q", name)
+		}
+		if _, dup := seen[name]; dup {
+			return model.DashboardLayout{}, fmt.Errorf("duplicate widget %q", name)
+		}
+		seen[name] = struct{}{}
+		params, err := spec.ResolveParams(w.Params)
+		if err != nil {
+			return model.DashboardLayout{}, err
+		}
+		out.Widgets = append(out.Widgets, model.DashboardWidgetConfig{Name: name, Params: params})
+	}
+	return out, nil
+}
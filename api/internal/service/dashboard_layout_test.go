@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestDefaultDashboardLayoutCoversAllWidgets(t *testing.T) {
+	layout := DefaultDashboardLayout()
+	if len(layout.Widgets) != len(DashboardWidgetSpecs()) {
+		t.Fatalf("widgets = %d, want %d", len(layout.Widgets), len(DashboardWidgetSpecs()))
+	}
+	for _, w := range layout.Widgets {
+		if w.Name == DashboardWidgetLLMSummary && w.Params["days"] != 7 {
+			t.Fatalf("llm_summary params = %v", w.Params)
+		}
+	}
+}
+
+func TestNormalizeDashboardLayout(t *testing.T) {
+	got, err := NormalizeDashboardLayout(model.DashboardLayout{Widgets: []model.DashboardWidgetConfig{
+		{Name: DashboardWidgetTopicTrends, Params: map[string]int{"limit": 12}},
+		{Name: " " + DashboardWidgetDigests + " "},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Widgets) != 2 || got.Widgets[0].Name != DashboardWidgetTopicTrends || got.Widgets[1].Name != DashboardWidgetDigests {
+		t.Fatalf("widgets = %+v", got.Widgets)
+	}
+	if got.Widgets[0].Params["limit"] != 12 || got.Widgets[1].Params["limit"] != 5 {
+		t.Fatalf("params = %+v", got.Widgets)
+	}
+
+	for name, layout := range map[string]model.DashboardLayout{
+		"unknown":      {Widgets: []model.DashboardWidgetConfig{{Name: "weather"}}},
+		"duplicate":    {Widgets: []model.DashboardWidgetConfig{{Name: DashboardWidgetItemStats}, {Name: DashboardWidgetItemStats}}},
+		"bad param":    {Widgets: []model.DashboardWidgetConfig{{Name: DashboardWidgetItemStats, Params: map[string]int{"limit": 1}}}},
+		"out of range": {Widgets: []model.DashboardWidgetConfig{{Name: DashboardWidgetLLMSummary, Params: map[string]int{"days": 0}}}},
+	} {
+		if _, err := NormalizeDashboardLayout(layout); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
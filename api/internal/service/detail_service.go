@@ -8,10 +8,10 @@ import (
 )
 
 type ItemDetailService struct {
-	repo *repository.ItemRepo
+	repo repository.ItemReader
 }
 
-func NewItemDetailService(repo *repository.ItemRepo) *ItemDetailService {
+func NewItemDetailService(repo repository.ItemReader) *ItemDetailService {
 	return &ItemDetailService{repo: repo}
 }
 
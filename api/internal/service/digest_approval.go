@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	DigestSendStatusAwaitingApproval = "awaiting_approval"
+	DigestSendStatusApproved         = "approved"
+
+	DefaultDigestAutoApproveMinutes = 180
+	maxDigestAutoApproveMinutes     = 7 * 24 * 60
+)
+
+var ErrDigestNotAwaitingApproval = errors.New("digest is not awaiting approval")
+
+type DigestApprovalView struct {
+	Enabled            bool `json:"enabled"`
+	AutoApproveMinutes int  `json:"auto_approve_minutes"`
+}
+
+type DigestApprovalInput struct {
+	Enabled            bool `json:"enabled"`
+	AutoApproveMinutes *int `json:"auto_approve_minutes"`
+}
+
+func NewDigestApprovalView(settings *model.UserSettings) DigestApprovalView {
+	if settings == nil {
+		return DigestApprovalView{AutoApproveMinutes: DefaultDigestAutoApproveMinutes}
+	}
+	return DigestApprovalView{
+		Enabled:            settings.DigestApprovalEnabled,
+		AutoApproveMinutes: settings.DigestAutoApproveMinutes,
+	}
+}
+
+// NormalizeDigestApprovalInput validates the auto-approve timeout; 0 means the digest waits
+// until the user approves it.
+func NormalizeDigestApprovalInput(in DigestApprovalInput) (DigestApprovalView, error) {
+	out := DigestApprovalView{Enabled: in.Enabled, AutoApproveMinutes: DefaultDigestAutoApproveMinutes}
+	if in.AutoApproveMinutes != nil {
+		out.AutoApproveMinutes = *in.AutoApproveMinutes
+	}
+	if out.AutoApproveMinutes < 0 || out.AutoApproveMinutes > maxDigestAutoApproveMinutes {
+		return out, &ValidationError{Field: "auto_approve_minutes", Message: "auto_approve_minutes must be between 0 and 10080"}
+	}
+	return out, nil
+}
+
+type digestApprovalRepo interface {
+	Approve(ctx context.Context, id, userID string) error
+}
+
+type digestApprovalUserRepo interface {
+	GetByID(ctx context.Context, id string) (*model.User, error)
+}
+
+type digestCopyComposedPublisher interface {
+	SendDigestCopyComposedE(ctx context.Context, digestID, userID, to string) error
+}
+
+// DigestApprovalService releases digests held in review-before-send mode.
+type DigestApprovalService struct {
+	repo      digestApprovalRepo
+	users     digestApprovalUserRepo
+	publisher digestCopyComposedPublisher
+}
+
+func NewDigestApprovalService(repo digestApprovalRepo, users digestApprovalUserRepo, publisher digestCopyComposedPublisher) *DigestApprovalService {
+	return &DigestApprovalService{repo: repo, users: users, publisher: publisher}
+}
+
+// Approve marks the digest approved and hands it to send-digest.
+func (s *DigestApprovalService) Approve(ctx context.Context, userID, digestID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Approve(ctx, strings.TrimSpace(digestID), userID); err != nil {
+		if errors.Is(err, repository.ErrInvalidState) {
+			return ErrDigestNotAwaitingApproval
+		}
+		return err
+	}
+	return s.publisher.SendDigestCopyComposedE(ctx, strings.TrimSpace(digestID), userID, user.Email)
+}
+
+type DigestApprovalEmail struct {
+	Locale        string
+	DigestDate    string
+	Subject       string
+	PreviewURL    string
+	AutoApproveAt *time.Time
+}
+
+func SendDigestApprovalEmail(ctx context.Context, sender EmailSender, to string, a DigestApprovalEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip digest approval request to %s", to)
+		return nil
+	}
+	strs := emailStringsFor(a.Locale)
+	note := strs.ApprovalManualNote
+	if a.AutoApproveAt != nil {
+		note = fmt.Sprintf(strs.ApprovalAutoNote, a.AutoApproveAt.In(timeutil.JST).Format("2006-01-02 15:04"))
+	}
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.ApprovalHeading)))
+	sb.WriteString(`<p style="line-height:1.7;color:#333">` + html.EscapeString(fmt.Sprintf(strs.ApprovalLead, a.DigestDate, a.Subject)) + `</p>`)
+	sb.WriteString(fmt.Sprintf(`<p><a href="%s" style="display:inline-block;padding:10px 18px;background:#2563eb;color:#fff;border-radius:6px;text-decoration:none">%s</a></p>`,
+		html.EscapeString(a.PreviewURL), html.EscapeString(strs.ApprovalButton)))
+	sb.WriteString(fmt.Sprintf(`<p style="margin-top:12px;color:#666;line-height:1.6">%s</p>`, html.EscapeString(note)))
+	sb.WriteString(`</body></html>`)
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf(strs.ApprovalSubject, a.DigestDate),
+		HTML:    sb.String(),
+	})
+}
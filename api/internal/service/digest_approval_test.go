@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeDigestApprovalRepo struct {
+	err      error
+	approved []string
+}
+
+func (f *fakeDigestApprovalRepo) Approve(_ context.Context, id, _ string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.approved = append(f.approved, id)
+	return nil
+}
+
+type fakeDigestCopyComposedPublisher struct {
+	digestID string
+	to       string
+	calls    int
+}
+
+func (f *fakeDigestCopyComposedPublisher) SendDigestCopyComposedE(_ context.Context, digestID, _, to string) error {
+	f.calls++
+	f.digestID = digestID
+	f.to = to
+	return nil
+}
+
+func TestDigestApprovalServiceApprove(t *testing.T) {
+	repo := &fakeDigestApprovalRepo{}
+	pub := &fakeDigestCopyComposedPublisher{}
+	svc := NewDigestApprovalService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	if err := svc.Approve(context.Background(), "u1", " d1 "); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if pub.calls != 1 || pub.digestID != "d1" || pub.to != "u1@example.com" {
+		t.Fatalf("publisher = %+v", pub)
+	}
+
+	repo.err = repository.ErrInvalidState
+	if err := svc.Approve(context.Background(), "u1", "d1"); !errors.Is(err, ErrDigestNotAwaitingApproval) {
+		t.Fatalf("second approve err = %v", err)
+	}
+	repo.err = repository.ErrNotFound
+	if err := svc.Approve(context.Background(), "u1", "d2"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("unknown digest err = %v", err)
+	}
+	if pub.calls != 1 {
+		t.Fatalf("publisher called %d times", pub.calls)
+	}
+}
+
+func TestNormalizeDigestApprovalInput(t *testing.T) {
+	got, err := NormalizeDigestApprovalInput(DigestApprovalInput{Enabled: true})
+	if err != nil || !got.Enabled || got.AutoApproveMinutes != DefaultDigestAutoApproveMinutes {
+		t.Fatalf("default = %+v, %v", got, err)
+	}
+	zero := 0
+	if got, err := NormalizeDigestApprovalInput(DigestApprovalInput{Enabled: true, AutoApproveMinutes: &zero}); err != nil || got.AutoApproveMinutes != 0 {
+		t.Fatalf("manual only = %+v, %v", got, err)
+	}
+	tooLong := maxDigestAutoApproveMinutes + 1
+	var ve *ValidationError
+	if _, err := NormalizeDigestApprovalInput(DigestApprovalInput{AutoApproveMinutes: &tooLong}); !errors.As(err, &ve) {
+		t.Fatalf("too long err = %v", err)
+	}
+}
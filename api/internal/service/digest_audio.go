@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	digestAudioEmailLinkExpiresSec = 7 * 24 * 60 * 60
+	digestAudioAPILinkExpiresSec   = 60 * 60
+)
+
+var ErrDigestAudioStorageNotConfigured = errors.New("digest audio storage bucket is not configured")
+
+type digestAudioSynthesizer interface {
+	SynthesizeText(ctx context.Context, userID, text string) (*SummaryAudioSynthesis, error)
+}
+
+type digestAudioStorage interface {
+	UploadAudioBriefingObject(ctx context.Context, bucket string, objectKey string, contentBase64 string, contentType string) (*AudioBriefingUploadObjectResponse, error)
+	PresignAudioBriefingObjectInBucket(ctx context.Context, objectKey string, bucket string, expiresSec int) (*AudioBriefingPresignResponse, error)
+}
+
+type DigestAudioResult struct {
+	Bucket      string
+	ObjectKey   string
+	ContentType string
+	DurationSec int
+}
+
+type DigestAudioService struct {
+	synth   digestAudioSynthesizer
+	storage digestAudioStorage
+}
+
+func NewDigestAudioService(synth digestAudioSynthesizer, storage digestAudioStorage) *DigestAudioService {
+	return &DigestAudioService{synth: synth, storage: storage}
+}
+
+func BuildDigestAudioNarration(subject, body string) string {
+	subject = strings.TrimSpace(digestSubjectPrefixPattern.ReplaceAllString(strings.TrimSpace(subject), ""))
+	body = strings.TrimSpace(body)
+	if subject == "" {
+		return body
+	}
+	if body == "" {
+		return subject
+	}
+	return subject + "\n\n" + body
+}
+
+func DigestAudioObjectKey(userID, digestID, contentType string) string {
+	return fmt.Sprintf("digest-audio/%s/%s.%s", strings.TrimSpace(userID), strings.TrimSpace(digestID), digestAudioExtension(contentType))
+}
+
+func digestAudioExtension(contentType string) string {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return "wav"
+	case "audio/ogg", "audio/opus":
+		return "ogg"
+	case "audio/aac":
+		return "aac"
+	default:
+		return "mp3"
+	}
+}
+
+func (s *DigestAudioService) Generate(ctx context.Context, userID, digestID, subject, body string) (*DigestAudioResult, error) {
+	if s == nil || s.synth == nil || s.storage == nil {
+		return nil, errors.New("digest audio service is not configured")
+	}
+	bucket := AudioBriefingStandardBucketFromEnv()
+	if bucket == "" {
+		return nil, ErrDigestAudioStorageNotConfigured
+	}
+	synthesis, err := s.synth.SynthesizeText(ctx, userID, BuildDigestAudioNarration(subject, body))
+	if err != nil {
+		return nil, err
+	}
+	contentType := strings.TrimSpace(synthesis.ContentType)
+	if contentType == "" {
+		contentType = "audio/mpeg"
+	}
+	objectKey := DigestAudioObjectKey(userID, digestID, contentType)
+	uploaded, err := s.storage.UploadAudioBriefingObject(ctx, bucket, objectKey, base64.StdEncoding.EncodeToString(synthesis.AudioBytes), contentType)
+	if err != nil {
+		return nil, err
+	}
+	if uploaded != nil && strings.TrimSpace(uploaded.ObjectKey) != "" {
+		objectKey = strings.TrimSpace(uploaded.ObjectKey)
+	}
+	return &DigestAudioResult{
+		Bucket:      bucket,
+		ObjectKey:   objectKey,
+		ContentType: contentType,
+		DurationSec: synthesis.DurationSec,
+	}, nil
+}
+
+func (s *DigestAudioService) PresignURL(ctx context.Context, bucket, objectKey string, expiresSec int) (string, error) {
+	if s == nil || s.storage == nil {
+		return "", errors.New("digest audio service is not configured")
+	}
+	resp, err := s.storage.PresignAudioBriefingObjectInBucket(ctx, objectKey, NormalizeAudioBriefingStorageBucket(bucket), expiresSec)
+	if err != nil {
+		return "", err
+	}
+	if resp == nil || strings.TrimSpace(resp.AudioURL) == "" {
+		return "", errors.New("digest audio presign returned empty url")
+	}
+	return resp.AudioURL, nil
+}
+
+func (s *DigestAudioService) EmailLinkURL(ctx context.Context, bucket, objectKey string) (string, error) {
+	return s.PresignURL(ctx, bucket, objectKey, digestAudioEmailLinkExpiresSec)
+}
+
+func (s *DigestAudioService) APILinkURL(ctx context.Context, bucket, objectKey string) (string, int, error) {
+	url, err := s.PresignURL(ctx, bucket, objectKey, digestAudioAPILinkExpiresSec)
+	return url, digestAudioAPILinkExpiresSec, err
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+)
+
+type stubDigestAudioSynth struct {
+	gotText string
+	result  *SummaryAudioSynthesis
+}
+
+func (s *stubDigestAudioSynth) SynthesizeText(_ context.Context, _ string, text string) (*SummaryAudioSynthesis, error) {
+	s.gotText = text
+	return s.result, nil
+}
+
+type stubDigestAudioStorage struct {
+	bucket      string
+	objectKey   string
+	content     string
+	contentType string
+}
+
+func (s *stubDigestAudioStorage) UploadAudioBriefingObject(_ context.Context, bucket string, objectKey string, contentBase64 string, contentType string) (*AudioBriefingUploadObjectResponse, error) {
+	s.bucket = bucket
+	s.objectKey = objectKey
+	s.content = contentBase64
+	s.contentType = contentType
+	return &AudioBriefingUploadObjectResponse{ObjectKey: objectKey}, nil
+}
+
+func (s *stubDigestAudioStorage) PresignAudioBriefingObjectInBucket(_ context.Context, objectKey string, bucket string, _ int) (*AudioBriefingPresignResponse, error) {
+	return &AudioBriefingPresignResponse{AudioURL: "https://example.com/" + bucket + "/" + objectKey}, nil
+}
+
+func TestBuildDigestAudioNarrationStripsSubjectPrefix(t *testing.T) {
+	got := BuildDigestAudioNarration("【2026年3月5日ダイジェスト】AIの話題", "本文")
+	if got != "AIの話題\n\n本文" {
+		t.Fatalf("BuildDigestAudioNarration() = %q", got)
+	}
+}
+
+func TestDigestAudioGenerateUploadsSynthesizedAudio(t *testing.T) {
+	t.Setenv("AUDIO_BRIEFING_R2_STANDARD_BUCKET", "audio-bucket")
+	synth := &stubDigestAudioSynth{result: &SummaryAudioSynthesis{AudioBytes: []byte("mp3"), ContentType: "audio/mpeg", DurationSec: 42}}
+	storage := &stubDigestAudioStorage{}
+	svc := NewDigestAudioService(synth, storage)
+
+	result, err := svc.Generate(context.Background(), "u1", "d1", "Subject", "Body")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if synth.gotText != "Subject\n\nBody" {
+		t.Fatalf("narration = %q", synth.gotText)
+	}
+	if storage.bucket != "audio-bucket" || storage.objectKey != "digest-audio/u1/d1.mp3" {
+		t.Fatalf("upload target = %s/%s", storage.bucket, storage.objectKey)
+	}
+	if storage.content != base64.StdEncoding.EncodeToString([]byte("mp3")) {
+		t.Fatalf("upload content = %q", storage.content)
+	}
+	if result.DurationSec != 42 || result.ContentType != "audio/mpeg" {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestDigestAudioGenerateRequiresBucket(t *testing.T) {
+	t.Setenv("AUDIO_BRIEFING_R2_STANDARD_BUCKET", "")
+	t.Setenv("AUDIO_BRIEFING_R2_BUCKET", "")
+	svc := NewDigestAudioService(&stubDigestAudioSynth{}, &stubDigestAudioStorage{})
+	if _, err := svc.Generate(context.Background(), "u1", "d1", "s", "b"); err != ErrDigestAudioStorageNotConfigured {
+		t.Fatalf("Generate() error = %v, want ErrDigestAudioStorageNotConfigured", err)
+	}
+}
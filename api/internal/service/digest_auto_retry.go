@@ -0,0 +1,59 @@
+package service
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DigestSendStatusComposeFailed    = "compose_failed"
+	DigestSendStatusSendEmailFailed  = "send_email_failed"
+	DigestSendStatusAutoRetryQueued  = "auto_retry_queued"
+	DigestAutoRetryWindow            = 48 * time.Hour
+	defaultDigestAutoRetryMax        = 3
+	defaultDigestAutoRetryBaseMinute = 15
+	maxDigestAutoRetryBackoff        = 12 * time.Hour
+)
+
+// DigestAutoRetryStatuses are the failures the retry cron picks up. Other failures (fetch,
+// settings, keys) need the user or an operator and are left alone.
+var DigestAutoRetryStatuses = []string{DigestSendStatusComposeFailed, DigestSendStatusSendEmailFailed}
+
+// DigestAutoRetryMaxAttempts is how many times a failed digest is retried automatically
+// (DIGEST_AUTO_RETRY_MAX, default 3). Zero disables auto retry.
+func DigestAutoRetryMaxAttempts() int {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DIGEST_AUTO_RETRY_MAX"))); err == nil && v >= 0 {
+		return v
+	}
+	return defaultDigestAutoRetryMax
+}
+
+// DigestAutoRetryBaseDelay is the wait before the first retry (DIGEST_AUTO_RETRY_BASE_MINUTES,
+// default 15); each further attempt doubles it.
+func DigestAutoRetryBaseDelay() time.Duration {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DIGEST_AUTO_RETRY_BASE_MINUTES"))); err == nil && v > 0 {
+		return time.Duration(v) * time.Minute
+	}
+	return defaultDigestAutoRetryBaseMinute * time.Minute
+}
+
+// DigestAutoRetryBackoff is how long to wait after a failure before retry number attempt+1:
+// base, 2*base, 4*base, ... capped at 12h.
+func DigestAutoRetryBackoff(base time.Duration, attempt int) time.Duration {
+	d := base
+	for i := 0; i < attempt && d < maxDigestAutoRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > maxDigestAutoRetryBackoff {
+		return maxDigestAutoRetryBackoff
+	}
+	return d
+}
+
+// DigestAutoRetryDue reports whether a digest that failed at failedAt after attempts automatic
+// retries has waited out its backoff.
+func DigestAutoRetryDue(failedAt time.Time, attempts int, base time.Duration, now time.Time) bool {
+	return !now.Before(failedAt.Add(DigestAutoRetryBackoff(base, attempts)))
+}
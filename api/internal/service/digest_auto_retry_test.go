@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestAutoRetryBackoffDoublesUpToCap(t *testing.T) {
+	base := 15 * time.Minute
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 15 * time.Minute},
+		{1, 30 * time.Minute},
+		{2, time.Hour},
+		{5, 8 * time.Hour},
+		{6, 12 * time.Hour},
+		{40, 12 * time.Hour},
+	}
+	for _, tc := range cases {
+		if got := DigestAutoRetryBackoff(base, tc.attempt); got != tc.want {
+			t.Fatalf("DigestAutoRetryBackoff(%d) = %s, want %s", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestDigestAutoRetryDue(t *testing.T) {
+	failedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	base := 15 * time.Minute
+	if DigestAutoRetryDue(failedAt, 1, base, failedAt.Add(29*time.Minute)) {
+		t.Fatal("second retry due before its 30m backoff")
+	}
+	if !DigestAutoRetryDue(failedAt, 1, base, failedAt.Add(30*time.Minute)) {
+		t.Fatal("second retry not due after its 30m backoff")
+	}
+}
+
+func TestDigestAutoRetryMaxAttemptsEnv(t *testing.T) {
+	t.Setenv("DIGEST_AUTO_RETRY_MAX", "")
+	if got := DigestAutoRetryMaxAttempts(); got != 3 {
+		t.Fatalf("default = %d", got)
+	}
+	t.Setenv("DIGEST_AUTO_RETRY_MAX", "0")
+	if got := DigestAutoRetryMaxAttempts(); got != 0 {
+		t.Fatalf("disabled = %d", got)
+	}
+	t.Setenv("DIGEST_AUTO_RETRY_MAX", "-1")
+	if got := DigestAutoRetryMaxAttempts(); got != 3 {
+		t.Fatalf("invalid = %d", got)
+	}
+}
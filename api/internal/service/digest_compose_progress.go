@@ -0,0 +1,61 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// Compose stages of a digest, in order.
+const (
+	DigestComposeStageClustering    = "clustering"
+	DigestComposeStageClusterDrafts = "cluster_drafts"
+	DigestComposeStageSections      = "sections"
+	DigestComposeStageFinal         = "final"
+	DigestComposeStageDone          = "done"
+)
+
+// digestFinalComposeEstimate is a typical final compose call, added to the ETA of the stages
+// that still have it ahead.
+const digestFinalComposeEstimate = 45 * time.Second
+
+// FillDigestComposeETA estimates the seconds left from the pace of the current counted stage.
+// The ETA stays nil until that stage has finished at least one unit, and once composition is
+// done or has stopped.
+func FillDigestComposeETA(p *model.DigestComposeProgress, now time.Time) {
+	if p == nil {
+		return
+	}
+	p.ETASec = nil
+	if p.Stage == nil || p.StageStartedAt == nil {
+		return
+	}
+	var total, done int
+	switch *p.Stage {
+	case DigestComposeStageClusterDrafts:
+		total, done = p.ClustersTotal, p.ClustersDone
+	case DigestComposeStageSections:
+		total, done = p.SectionsTotal, p.SectionsDone
+	case DigestComposeStageFinal:
+		left := digestFinalComposeEstimate - now.Sub(*p.StageStartedAt)
+		p.ETASec = etaSeconds(left)
+		return
+	default:
+		return
+	}
+	if done <= 0 {
+		return
+	}
+	perUnit := now.Sub(*p.StageStartedAt) / time.Duration(done)
+	left := perUnit*time.Duration(max(total-done, 0)) + digestFinalComposeEstimate
+	p.ETASec = etaSeconds(left)
+}
+
+func etaSeconds(d time.Duration) *int {
+	sec := int(math.Ceil(d.Seconds()))
+	if sec < 0 {
+		sec = 0
+	}
+	return &sec
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestFillDigestComposeETA(t *testing.T) {
+	now := time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC)
+	stage := func(s string) *string { return &s }
+	startedAgo := func(d time.Duration) *time.Time { at := now.Add(-d); return &at }
+
+	cases := []struct {
+		name string
+		p    model.DigestComposeProgress
+		want *int
+	}{
+		{"not started", model.DigestComposeProgress{}, nil},
+		{"no drafts done yet", model.DigestComposeProgress{Stage: stage(DigestComposeStageClusterDrafts), ClustersTotal: 10, StageStartedAt: startedAgo(5 * time.Second)}, nil},
+		{"drafts pace", model.DigestComposeProgress{Stage: stage(DigestComposeStageClusterDrafts), ClustersTotal: 10, ClustersDone: 4, StageStartedAt: startedAgo(20 * time.Second)}, progressETA(30 + 45)},
+		{"sections pace", model.DigestComposeProgress{Stage: stage(DigestComposeStageSections), SectionsTotal: 3, SectionsDone: 2, StageStartedAt: startedAgo(40 * time.Second)}, progressETA(20 + 45)},
+		{"final", model.DigestComposeProgress{Stage: stage(DigestComposeStageFinal), StageStartedAt: startedAgo(15 * time.Second)}, progressETA(30)},
+		{"final overdue", model.DigestComposeProgress{Stage: stage(DigestComposeStageFinal), StageStartedAt: startedAgo(2 * time.Minute)}, progressETA(0)},
+		{"done", model.DigestComposeProgress{Stage: stage(DigestComposeStageDone), StageStartedAt: startedAgo(time.Second)}, nil},
+	}
+	for _, tc := range cases {
+		p := tc.p
+		FillDigestComposeETA(&p, now)
+		switch {
+		case tc.want == nil && p.ETASec != nil:
+			t.Fatalf("%s: eta = %d, want nil", tc.name, *p.ETASec)
+		case tc.want != nil && (p.ETASec == nil || *p.ETASec != *tc.want):
+			t.Fatalf("%s: eta = %v, want %d", tc.name, p.ETASec, *tc.want)
+		}
+	}
+}
+
+func progressETA(v int) *int { return &v }
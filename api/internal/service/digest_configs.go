@@ -0,0 +1,143 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	DigestScopeSources = "sources"
+	DigestScopeSearch  = "search"
+	DigestScopeTopics  = "topics"
+
+	// DailyDigestHourJST is when the unscoped daily digest is generated.
+	DailyDigestHourJST = 6
+
+	maxDigestConfigNameLength   = 100
+	maxDigestConfigQueryLength  = 200
+	maxDigestConfigScopeEntries = 50
+)
+
+// DigestConfigInput is a create or partial update of a scoped digest config.
+type DigestConfigInput struct {
+	Name         *string  `json:"name"`
+	ScopeType    *string  `json:"scope_type"`
+	SourceIDs    []string `json:"source_ids"`
+	SearchQuery  *string  `json:"search_query"`
+	Topics       []string `json:"topics"`
+	SendHourJST  *int     `json:"send_hour_jst"`
+	SendWeekdays []int    `json:"send_weekdays"`
+	Enabled      *bool    `json:"enabled"`
+}
+
+// ApplyDigestConfigInput merges the provided fields into cfg and validates the result.
+func ApplyDigestConfigInput(cfg *model.DigestConfig, in DigestConfigInput) error {
+	if in.Name != nil {
+		name := strings.TrimSpace(*in.Name)
+		if name == "" {
+			return &ValidationError{Field: "name", Message: "name is required"}
+		}
+		if utf8.RuneCountInString(name) > maxDigestConfigNameLength {
+			return &ValidationError{Field: "name", Message: "name is too long"}
+		}
+		cfg.Name = name
+	}
+	if in.ScopeType != nil {
+		cfg.ScopeType = strings.TrimSpace(*in.ScopeType)
+	}
+	if in.SourceIDs != nil {
+		cfg.SourceIDs = dedupeTrimmed(in.SourceIDs)
+	}
+	if in.SearchQuery != nil {
+		if q := strings.TrimSpace(*in.SearchQuery); q != "" {
+			cfg.SearchQuery = &q
+		} else {
+			cfg.SearchQuery = nil
+		}
+	}
+	if in.Topics != nil {
+		cfg.Topics = dedupeTrimmed(in.Topics)
+	}
+	if in.SendHourJST != nil {
+		if *in.SendHourJST < 0 || *in.SendHourJST > 23 {
+			return &ValidationError{Field: "send_hour_jst", Message: "send_hour_jst must be between 0 and 23"}
+		}
+		cfg.SendHourJST = *in.SendHourJST
+	}
+	if in.SendWeekdays != nil {
+		days := []int{}
+		for _, d := range in.SendWeekdays {
+			if d < 0 || d > 6 {
+				return &ValidationError{Field: "send_weekdays", Message: "send_weekdays must be between 0 (Sunday) and 6 (Saturday)"}
+			}
+			if !slices.Contains(days, d) {
+				days = append(days, d)
+			}
+		}
+		if len(days) == 0 {
+			return &ValidationError{Field: "send_weekdays", Message: "at least one weekday is required"}
+		}
+		slices.Sort(days)
+		cfg.SendWeekdays = days
+	}
+	if in.Enabled != nil {
+		cfg.Enabled = *in.Enabled
+	}
+
+	if cfg.Name == "" {
+		return &ValidationError{Field: "name", Message: "name is required"}
+	}
+	if len(cfg.SourceIDs) > maxDigestConfigScopeEntries || len(cfg.Topics) > maxDigestConfigScopeEntries {
+		return &ValidationError{Field: "scope_type", Message: "a digest scope can list at most 50 sources or topics"}
+	}
+	switch cfg.ScopeType {
+	case DigestScopeSources:
+		if len(cfg.SourceIDs) == 0 {
+			return &ValidationError{Field: "source_ids", Message: "source_ids is required for the sources scope"}
+		}
+	case DigestScopeSearch:
+		if cfg.SearchQuery == nil {
+			return &ValidationError{Field: "search_query", Message: "search_query is required for the search scope"}
+		}
+		if utf8.RuneCountInString(*cfg.SearchQuery) > maxDigestConfigQueryLength {
+			return &ValidationError{Field: "search_query", Message: "search_query is too long"}
+		}
+	case DigestScopeTopics:
+		if len(cfg.Topics) == 0 {
+			return &ValidationError{Field: "topics", Message: "topics is required for the topics scope"}
+		}
+	default:
+		return &ValidationError{Field: "scope_type", Message: "scope_type must be sources, search or topics"}
+	}
+	return nil
+}
+
+func dedupeTrimmed(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// DigestConfigWindow returns the published_at window for a scoped digest generated at now:
+// from the config's previous scheduled slot up to the current hour, so weekly configs cover
+// the whole week.
+func DigestConfigWindow(cfg model.DigestConfig, now time.Time) (since, until time.Time) {
+	until = now.In(timeutil.JST).Truncate(time.Hour)
+	day := timeutil.StartOfDayJST(until)
+	for i := 1; i <= 7; i++ {
+		prev := day.AddDate(0, 0, -i)
+		if slices.Contains(cfg.SendWeekdays, int(prev.Weekday())) {
+			return prev.Add(time.Duration(cfg.SendHourJST) * time.Hour), until
+		}
+	}
+	return until.AddDate(0, 0, -1), until
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestApplyDigestConfigInput(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	cases := []struct {
+		name string
+		in   DigestConfigInput
+		want string
+	}{
+		{"blank name", DigestConfigInput{Name: str(" "), ScopeType: str("topics"), Topics: []string{"AI"}}, "name is required"},
+		{"unknown scope", DigestConfigInput{Name: str("Team"), ScopeType: str("tags")}, "scope_type must be sources, search or topics"},
+		{"sources without ids", DigestConfigInput{Name: str("Team"), ScopeType: str("sources"), SourceIDs: []string{" "}}, "source_ids is required for the sources scope"},
+		{"search without query", DigestConfigInput{Name: str("Team"), ScopeType: str("search"), SearchQuery: str("  ")}, "search_query is required for the search scope"},
+		{"bad hour", DigestConfigInput{Name: str("Team"), ScopeType: str("topics"), Topics: []string{"AI"}, SendHourJST: num(24)}, "send_hour_jst must be between 0 and 23"},
+		{"no weekdays", DigestConfigInput{Name: str("Team"), ScopeType: str("topics"), Topics: []string{"AI"}, SendWeekdays: []int{}}, "at least one weekday is required"},
+		{"valid", DigestConfigInput{Name: str(" Team "), ScopeType: str("topics"), Topics: []string{"AI", " AI ", "Go"}, SendHourJST: num(18), SendWeekdays: []int{5, 1, 5}}, ""},
+	}
+	for _, tc := range cases {
+		cfg := model.DigestConfig{SendHourJST: 7, SendWeekdays: []int{0, 1, 2, 3, 4, 5, 6}}
+		err := ApplyDigestConfigInput(&cfg, tc.in)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+		if tc.want == "" && (cfg.Name != "Team" || len(cfg.Topics) != 2 || cfg.SendHourJST != 18 || len(cfg.SendWeekdays) != 2 || cfg.SendWeekdays[0] != 1) {
+			t.Errorf("%s: cfg = %+v", tc.name, cfg)
+		}
+	}
+}
+
+func TestDigestConfigWindowReachesBackToPreviousSlot(t *testing.T) {
+	// 2026-10-16 is a Friday; a Monday/Friday 18:00 config covers Monday 18:00 onwards.
+	now := time.Date(2026, 10, 16, 18, 4, 0, 0, timeutil.JST)
+	cfg := model.DigestConfig{SendHourJST: 18, SendWeekdays: []int{int(time.Monday), int(time.Friday)}}
+	since, until := DigestConfigWindow(cfg, now)
+	if !since.Equal(time.Date(2026, 10, 12, 18, 0, 0, 0, timeutil.JST)) || !until.Equal(time.Date(2026, 10, 16, 18, 0, 0, 0, timeutil.JST)) {
+		t.Fatalf("DigestConfigWindow() = (%v, %v)", since, until)
+	}
+
+	cfg.SendWeekdays = []int{int(time.Friday)}
+	since, _ = DigestConfigWindow(cfg, now)
+	if !since.Equal(time.Date(2026, 10, 9, 18, 0, 0, 0, timeutil.JST)) {
+		t.Fatalf("weekly since = %v", since)
+	}
+}
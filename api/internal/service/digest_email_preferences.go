@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// Frequencies offered on the login-free email preferences page. They are presets over
+// digest_email_enabled and digest_skip_weekdays; any other weekday mix reads back as custom.
+const (
+	DigestEmailFrequencyDaily    = "daily"
+	DigestEmailFrequencyWeekdays = "weekdays"
+	DigestEmailFrequencyWeekly   = "weekly"
+	DigestEmailFrequencyOff      = "off"
+	DigestEmailFrequencyCustom   = "custom"
+)
+
+var digestEmailFrequencySkipWeekdays = map[string][]int{
+	DigestEmailFrequencyDaily:    {},
+	DigestEmailFrequencyWeekdays: {0, 6},
+	// Weekly digests go out on Monday (JST).
+	DigestEmailFrequencyWeekly: {0, 2, 3, 4, 5, 6},
+}
+
+type digestEmailSettingsStore interface {
+	GetByUserID(ctx context.Context, userID string) (*model.UserSettings, error)
+	SetDigestEmailEnabled(ctx context.Context, userID string, enabled bool) error
+	SetDigestSkipWeekdays(ctx context.Context, userID string, skipWeekdays []int) error
+}
+
+type digestEmailRecipientStore interface {
+	DeleteByID(ctx context.Context, id string) error
+}
+
+// DigestEmailPreferencesService backs the unsubscribe and preferences links in digest emails.
+// Callers must have verified the signed token; the claim is the only authorization.
+type DigestEmailPreferencesService struct {
+	settings   digestEmailSettingsStore
+	recipients digestEmailRecipientStore
+}
+
+func NewDigestEmailPreferencesService(settings digestEmailSettingsStore, recipients digestEmailRecipientStore) *DigestEmailPreferencesService {
+	return &DigestEmailPreferencesService{settings: settings, recipients: recipients}
+}
+
+// Unsubscribe stops the digest mail named by the claim: the owner's own copy is switched off,
+// an extra recipient is removed. Repeating it is a no-op.
+func (s *DigestEmailPreferencesService) Unsubscribe(ctx context.Context, claim DigestEmailClaim) error {
+	if claim.Scope == DigestEmailScopeRecipient {
+		if err := s.recipients.DeleteByID(ctx, claim.SubjectID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	return s.settings.SetDigestEmailEnabled(ctx, claim.SubjectID, false)
+}
+
+// Frequency reports the owner's current preset, or custom when the skipped weekdays were set
+// from the settings screen to something the page does not offer.
+func (s *DigestEmailPreferencesService) Frequency(ctx context.Context, userID string) (string, error) {
+	settings, err := s.settings.GetByUserID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return DigestEmailFrequencyDaily, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !settings.DigestEmailEnabled {
+		return DigestEmailFrequencyOff, nil
+	}
+	skip := slices.Clone(settings.DigestSkipWeekdays)
+	slices.Sort(skip)
+	for _, freq := range []string{DigestEmailFrequencyDaily, DigestEmailFrequencyWeekdays, DigestEmailFrequencyWeekly} {
+		if slices.Equal(skip, digestEmailFrequencySkipWeekdays[freq]) {
+			return freq, nil
+		}
+	}
+	return DigestEmailFrequencyCustom, nil
+}
+
+func (s *DigestEmailPreferencesService) SetFrequency(ctx context.Context, userID, frequency string) error {
+	if frequency == DigestEmailFrequencyOff {
+		return s.settings.SetDigestEmailEnabled(ctx, userID, false)
+	}
+	skip, ok := digestEmailFrequencySkipWeekdays[frequency]
+	if !ok {
+		return &ValidationError{Field: "frequency", Message: "frequency must be daily, weekdays, weekly or off"}
+	}
+	if err := s.settings.SetDigestSkipWeekdays(ctx, userID, skip); err != nil {
+		return err
+	}
+	return s.settings.SetDigestEmailEnabled(ctx, userID, true)
+}
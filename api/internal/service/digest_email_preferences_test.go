@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeDigestEmailSettings struct {
+	settings *model.UserSettings
+}
+
+func (f *fakeDigestEmailSettings) GetByUserID(_ context.Context, _ string) (*model.UserSettings, error) {
+	if f.settings == nil {
+		return nil, repository.ErrNotFound
+	}
+	v := *f.settings
+	return &v, nil
+}
+
+func (f *fakeDigestEmailSettings) ensure() {
+	if f.settings == nil {
+		f.settings = &model.UserSettings{DigestEmailEnabled: true}
+	}
+}
+
+func (f *fakeDigestEmailSettings) SetDigestEmailEnabled(_ context.Context, _ string, enabled bool) error {
+	f.ensure()
+	f.settings.DigestEmailEnabled = enabled
+	return nil
+}
+
+func (f *fakeDigestEmailSettings) SetDigestSkipWeekdays(_ context.Context, _ string, skip []int) error {
+	f.ensure()
+	f.settings.DigestSkipWeekdays = skip
+	return nil
+}
+
+type fakeDigestEmailRecipients struct {
+	deleted []string
+}
+
+func (f *fakeDigestEmailRecipients) DeleteByID(_ context.Context, id string) error {
+	if slices.Contains(f.deleted, id) {
+		return repository.ErrNotFound
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestDigestEmailPreferencesFrequency(t *testing.T) {
+	ctx := context.Background()
+	settings := &fakeDigestEmailSettings{}
+	svc := NewDigestEmailPreferencesService(settings, &fakeDigestEmailRecipients{})
+
+	if got, err := svc.Frequency(ctx, "u1"); err != nil || got != DigestEmailFrequencyDaily {
+		t.Fatalf("no settings row: Frequency = %q, %v", got, err)
+	}
+	for _, freq := range []string{DigestEmailFrequencyWeekly, DigestEmailFrequencyWeekdays, DigestEmailFrequencyOff, DigestEmailFrequencyDaily} {
+		if err := svc.SetFrequency(ctx, "u1", freq); err != nil {
+			t.Fatalf("SetFrequency(%s): %v", freq, err)
+		}
+		if got, _ := svc.Frequency(ctx, "u1"); got != freq {
+			t.Fatalf("Frequency after %s = %q", freq, got)
+		}
+	}
+	settings.settings.DigestSkipWeekdays = []int{3}
+	if got, _ := svc.Frequency(ctx, "u1"); got != DigestEmailFrequencyCustom {
+		t.Fatalf("Frequency with custom weekdays = %q", got)
+	}
+
+	var verr *ValidationError
+	if err := svc.SetFrequency(ctx, "u1", "hourly"); !errors.As(err, &verr) {
+		t.Fatalf("SetFrequency(hourly) err = %v", err)
+	}
+}
+
+func TestDigestEmailPreferencesUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	settings := &fakeDigestEmailSettings{}
+	recipients := &fakeDigestEmailRecipients{}
+	svc := NewDigestEmailPreferencesService(settings, recipients)
+
+	if err := svc.Unsubscribe(ctx, DigestEmailClaim{Scope: DigestEmailScopeOwner, SubjectID: "u1"}); err != nil {
+		t.Fatalf("owner Unsubscribe: %v", err)
+	}
+	if settings.settings.DigestEmailEnabled {
+		t.Fatal("owner unsubscribe should disable digest email")
+	}
+	claim := DigestEmailClaim{Scope: DigestEmailScopeRecipient, SubjectID: "r1"}
+	for i := 0; i < 2; i++ {
+		if err := svc.Unsubscribe(ctx, claim); err != nil {
+			t.Fatalf("recipient Unsubscribe #%d: %v", i+1, err)
+		}
+	}
+	if !slices.Equal(recipients.deleted, []string{"r1"}) {
+		t.Fatalf("deleted = %v", recipients.deleted)
+	}
+}
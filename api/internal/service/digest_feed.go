@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	digestFeedItemLimit      = 20
+	digestFeedDescriptionMax = 400
+)
+
+// DigestFeedFormat selects the syndication format of the digest feed.
+type DigestFeedFormat string
+
+const (
+	DigestFeedFormatRSS  DigestFeedFormat = "rss"
+	DigestFeedFormatAtom DigestFeedFormat = "atom"
+)
+
+// ContentType is the media type the feed is served with.
+func (f DigestFeedFormat) ContentType() string {
+	if f == DigestFeedFormatAtom {
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "application/rss+xml; charset=utf-8"
+}
+
+type DigestFeedResult struct {
+	Body         []byte    `json:"body"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+type DigestFeedService struct {
+	settingsRepo *repository.UserSettingsRepo
+	digestRepo   *repository.DigestRepo
+}
+
+func NewDigestFeedService(settingsRepo *repository.UserSettingsRepo, digestRepo *repository.DigestRepo) *DigestFeedService {
+	return &DigestFeedService{settingsRepo: settingsRepo, digestRepo: digestRepo}
+}
+
+type digestRSS struct {
+	XMLName      xml.Name         `xml:"rss"`
+	Version      string           `xml:"version,attr"`
+	XMLNSContent string           `xml:"xmlns:content,attr"`
+	Channel      digestRSSChannel `xml:"channel"`
+}
+
+type digestRSSChannel struct {
+	Title         string          `xml:"title"`
+	Link          string          `xml:"link"`
+	Description   string          `xml:"description"`
+	Language      string          `xml:"language"`
+	LastBuildDate string          `xml:"lastBuildDate,omitempty"`
+	Items         []digestRSSItem `xml:"item"`
+}
+
+type digestRSSContent struct {
+	Value string `xml:",cdata"`
+}
+
+type digestRSSItem struct {
+	Title       string           `xml:"title"`
+	Link        string           `xml:"link,omitempty"`
+	Description string           `xml:"description"`
+	Content     digestRSSContent `xml:"content:encoded"`
+	GUID        podcastRSSGUID   `xml:"guid"`
+	PubDate     string           `xml:"pubDate"`
+}
+
+type digestAtom struct {
+	XMLName xml.Name          `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string            `xml:"title"`
+	ID      string            `xml:"id"`
+	Updated string            `xml:"updated"`
+	Links   []digestAtomLink  `xml:"link"`
+	Author  digestAtomAuthor  `xml:"author"`
+	Entries []digestAtomEntry `xml:"entry"`
+}
+
+type digestAtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type digestAtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type digestAtomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type digestAtomEntry struct {
+	Title     string           `xml:"title"`
+	ID        string           `xml:"id"`
+	Updated   string           `xml:"updated"`
+	Published string           `xml:"published"`
+	Links     []digestAtomLink `xml:"link,omitempty"`
+	Summary   digestAtomText   `xml:"summary"`
+	Content   digestAtomText   `xml:"content"`
+}
+
+// digestFeedEntry is one digest, rendered into either format.
+type digestFeedEntry struct {
+	ID          string
+	Title       string
+	Link        string
+	Description string
+	HTML        string
+	Published   time.Time
+}
+
+func digestFeedBaseURL() string {
+	if v := strings.TrimSpace(os.Getenv("DIGEST_FEED_BASE_URL")); v != "" {
+		return strings.TrimRight(v, "/")
+	}
+	if v := strings.TrimSpace(os.Getenv("APP_BASE_URL")); v != "" {
+		return strings.TrimRight(v, "/") + "/api/digests/feed"
+	}
+	return ""
+}
+
+func DigestFeedURL(token string) *string {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+	base := digestFeedBaseURL()
+	if base == "" {
+		return nil
+	}
+	v := base + "?token=" + url.QueryEscape(token)
+	return &v
+}
+
+// digestFeedSelfURL is the feed's own URL in format, used as the Atom self link and id.
+func digestFeedSelfURL(token string, format DigestFeedFormat) string {
+	v := stringValue(DigestFeedURL(token))
+	if v != "" && format == DigestFeedFormatAtom {
+		v += "&format=atom"
+	}
+	return v
+}
+
+func generateDigestFeedToken() (string, error) {
+	var buf [20]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return "df_" + hex.EncodeToString(buf[:]), nil
+}
+
+func (s *DigestFeedService) GetToken(ctx context.Context, userID string) (*string, error) {
+	return s.settingsRepo.GetDigestFeedToken(ctx, userID)
+}
+
+// RotateToken issues a new feed token, invalidating any previously shared feed URL.
+func (s *DigestFeedService) RotateToken(ctx context.Context, userID string) (string, error) {
+	for i := 0; i < 4; i++ {
+		token, err := generateDigestFeedToken()
+		if err != nil {
+			return "", err
+		}
+		err = s.settingsRepo.SetDigestFeedToken(ctx, userID, &token)
+		if errors.Is(err, repository.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return token, nil
+	}
+	return "", fmt.Errorf("failed to generate unique digest_feed_token")
+}
+
+func (s *DigestFeedService) RevokeToken(ctx context.Context, userID string) error {
+	return s.settingsRepo.SetDigestFeedToken(ctx, userID, nil)
+}
+
+func (s *DigestFeedService) Build(ctx context.Context, token string, format DigestFeedFormat) (*DigestFeedResult, error) {
+	settings, err := s.settingsRepo.GetByDigestFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, repository.ErrNotFound
+	}
+	digests, err := s.digestRepo.ListLimit(ctx, settings.UserID, digestFeedItemLimit)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]model.DigestDetail, 0, len(digests))
+	for _, d := range digests {
+		if d.EmailBody == nil || strings.TrimSpace(*d.EmailBody) == "" {
+			continue
+		}
+		detail, err := s.digestRepo.GetDetail(ctx, d.ID, settings.UserID)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *detail)
+	}
+	selfURL := digestFeedSelfURL(token, format)
+	if format == DigestFeedFormatAtom {
+		return buildDigestAtomFeed(settings.Locale, selfURL, details, settings.UpdatedAt)
+	}
+	return buildDigestFeed(settings.Locale, selfURL, details, settings.UpdatedAt)
+}
+
+func digestFeedEntries(locale string, details []model.DigestDetail, lastModified time.Time) ([]digestFeedEntry, time.Time) {
+	appBaseURL := strings.TrimRight(strings.TrimSpace(AppBaseURLFromEnv()), "/")
+	entries := make([]digestFeedEntry, 0, len(details))
+	for i := range details {
+		d := &details[i]
+		subject := strings.TrimSpace(stringValue(d.EmailSubject))
+		if subject == "" {
+			subject = "Sifto Digest — " + d.DigestDate
+		}
+		body := strings.TrimSpace(stringValue(d.EmailBody))
+		pubTime := digestFeedPubTime(d.Digest)
+		if pubTime.After(lastModified) {
+			lastModified = pubTime
+		}
+		entry := digestFeedEntry{
+			ID:          d.ID,
+			Title:       subject,
+			Description: truncateRunes(body, digestFeedDescriptionMax),
+			HTML:        buildDigestHTML(locale, d, &DigestEmailCopy{Subject: subject, Body: body}),
+			Published:   pubTime,
+		}
+		if appBaseURL != "" {
+			entry.Link = appBaseURL + "/digests/" + d.ID
+		}
+		entries = append(entries, entry)
+	}
+	return entries, lastModified
+}
+
+func buildDigestFeed(locale, selfURL string, details []model.DigestDetail, lastModified time.Time) (*DigestFeedResult, error) {
+	locale = NormalizeLocale(locale)
+	appBaseURL := strings.TrimRight(strings.TrimSpace(AppBaseURLFromEnv()), "/")
+	entries, lastModified := digestFeedEntries(locale, details, lastModified)
+	items := make([]digestRSSItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, digestRSSItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			Description: e.Description,
+			Content:     digestRSSContent{Value: e.HTML},
+			GUID:        podcastRSSGUID{IsPermaLink: "false", Value: "sifto-digest-" + e.ID},
+			PubDate:     e.Published.Format(time.RFC1123Z),
+		})
+	}
+	channel := digestRSSChannel{
+		Title:       "Sifto Digest",
+		Link:        firstNonEmptyTrimmed(appBaseURL, selfURL),
+		Description: "Sifto digest history",
+		Language:    locale,
+		Items:       items,
+	}
+	if !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.Format(time.RFC1123Z)
+	}
+	body, err := xml.MarshalIndent(digestRSS{
+		Version:      "2.0",
+		XMLNSContent: "http://purl.org/rss/1.0/modules/content/",
+		Channel:      channel,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &DigestFeedResult{
+		Body:         append([]byte(xml.Header), body...),
+		LastModified: lastModified,
+	}, nil
+}
+
+// buildDigestAtomFeed renders the same entries as Atom 1.0. Entry ids are URNs on the digest
+// id so they stay stable when the app URL changes.
+func buildDigestAtomFeed(locale, selfURL string, details []model.DigestDetail, lastModified time.Time) (*DigestFeedResult, error) {
+	locale = NormalizeLocale(locale)
+	appBaseURL := strings.TrimRight(strings.TrimSpace(AppBaseURLFromEnv()), "/")
+	entries, lastModified := digestFeedEntries(locale, details, lastModified)
+	feed := digestAtom{
+		Title:   "Sifto Digest",
+		ID:      firstNonEmptyTrimmed(selfURL, "urn:sifto:digest-feed"),
+		Updated: lastModified.UTC().Format(time.RFC3339),
+		Author:  digestAtomAuthor{Name: "Sifto"},
+		Entries: make([]digestAtomEntry, 0, len(entries)),
+	}
+	if selfURL != "" {
+		feed.Links = append(feed.Links, digestAtomLink{Rel: "self", Type: "application/atom+xml", Href: selfURL})
+	}
+	if appBaseURL != "" {
+		feed.Links = append(feed.Links, digestAtomLink{Rel: "alternate", Type: "text/html", Href: appBaseURL})
+	}
+	for _, e := range entries {
+		published := e.Published.UTC().Format(time.RFC3339)
+		entry := digestAtomEntry{
+			Title:     e.Title,
+			ID:        "urn:sifto:digest:" + e.ID,
+			Updated:   published,
+			Published: published,
+			Summary:   digestAtomText{Type: "text", Value: e.Description},
+			Content:   digestAtomText{Type: "html", Value: e.HTML},
+		}
+		if e.Link != "" {
+			entry.Links = []digestAtomLink{{Rel: "alternate", Type: "text/html", Href: e.Link}}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &DigestFeedResult{
+		Body:         append([]byte(xml.Header), body...),
+		LastModified: lastModified,
+	}, nil
+}
+
+func digestFeedPubTime(d model.Digest) time.Time {
+	if d.SentAt != nil && !d.SentAt.IsZero() {
+		return d.SentAt.In(timeutil.JST)
+	}
+	return d.CreatedAt.In(timeutil.JST)
+}
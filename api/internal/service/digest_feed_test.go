@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestBuildDigestFeedIncludesComposedHTML(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "https://app.example.com/")
+	sentAt := time.Date(2026, 3, 26, 22, 0, 0, 0, time.UTC)
+	details := []model.DigestDetail{{
+		Digest: model.Digest{
+			ID:           "digest-1",
+			DigestDate:   "2026-03-27",
+			EmailSubject: strptr("AI roundup ]]> edition"),
+			EmailBody:    strptr("First paragraph.\n\nSecond <b>paragraph</b>."),
+			SentAt:       &sentAt,
+			CreatedAt:    sentAt.Add(-time.Hour),
+		},
+	}}
+
+	result, err := buildDigestFeed("en", "https://api.example.com/api/digests/feed?token=x", details, time.Time{})
+	if err != nil {
+		t.Fatalf("buildDigestFeed() error = %v", err)
+	}
+	if !result.LastModified.Equal(sentAt) {
+		t.Fatalf("LastModified = %s, want %s", result.LastModified, sentAt)
+	}
+
+	var parsed struct {
+		Channel struct {
+			Link     string `xml:"link"`
+			Language string `xml:"language"`
+			Items    []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				GUID    string `xml:"guid"`
+				Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(result.Body, &parsed); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v\n%s", err, result.Body)
+	}
+	if parsed.Channel.Link != "https://app.example.com" || parsed.Channel.Language != "en" {
+		t.Fatalf("channel = %+v", parsed.Channel)
+	}
+	if len(parsed.Channel.Items) != 1 {
+		t.Fatalf("items = %d, want 1", len(parsed.Channel.Items))
+	}
+	item := parsed.Channel.Items[0]
+	if item.Title != "AI roundup ]]> edition" {
+		t.Fatalf("title = %q", item.Title)
+	}
+	if item.Link != "https://app.example.com/digests/digest-1" || item.GUID != "sifto-digest-digest-1" {
+		t.Fatalf("item link/guid = %q / %q", item.Link, item.GUID)
+	}
+	if !strings.Contains(item.Content, "Second &lt;b&gt;paragraph&lt;/b&gt;.") {
+		t.Fatalf("content missing escaped body: %s", item.Content)
+	}
+	if !strings.Contains(item.Content, "Here is your digest for today.") {
+		t.Fatalf("content not localized: %s", item.Content)
+	}
+}
+
+func TestBuildDigestAtomFeed(t *testing.T) {
+	t.Setenv("APP_BASE_URL", "https://app.example.com/")
+	sentAt := time.Date(2026, 3, 26, 22, 0, 0, 0, time.UTC)
+	details := []model.DigestDetail{{
+		Digest: model.Digest{
+			ID:           "digest-1",
+			DigestDate:   "2026-03-27",
+			EmailSubject: strptr("AI roundup"),
+			EmailBody:    strptr("Body with <b>markup</b>."),
+			SentAt:       &sentAt,
+		},
+	}}
+
+	selfURL := "https://api.example.com/api/digests/feed?token=x&format=atom"
+	result, err := buildDigestAtomFeed("en", selfURL, details, time.Time{})
+	if err != nil {
+		t.Fatalf("buildDigestAtomFeed() error = %v", err)
+	}
+
+	type link struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+	}
+	var parsed struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Links   []link   `xml:"link"`
+		Entries []struct {
+			Title   string `xml:"title"`
+			ID      string `xml:"id"`
+			Links   []link `xml:"link"`
+			Content struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(result.Body, &parsed); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v\n%s", err, result.Body)
+	}
+	if parsed.ID != selfURL || parsed.Updated != "2026-03-26T22:00:00Z" {
+		t.Fatalf("feed id/updated = %q / %q", parsed.ID, parsed.Updated)
+	}
+	if len(parsed.Links) != 2 || parsed.Links[0].Rel != "self" || parsed.Links[0].Href != selfURL {
+		t.Fatalf("feed links = %+v", parsed.Links)
+	}
+	if len(parsed.Entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(parsed.Entries))
+	}
+	entry := parsed.Entries[0]
+	if entry.ID != "urn:sifto:digest:digest-1" || entry.Title != "AI roundup" {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if len(entry.Links) != 1 || entry.Links[0].Href != "https://app.example.com/digests/digest-1" {
+		t.Fatalf("entry links = %+v", entry.Links)
+	}
+	if entry.Content.Type != "html" || !strings.Contains(entry.Content.Value, "Body with &lt;b&gt;markup&lt;/b&gt;.") {
+		t.Fatalf("content = %+v", entry.Content)
+	}
+}
+
+func TestDigestFeedURL(t *testing.T) {
+	t.Setenv("DIGEST_FEED_BASE_URL", "")
+	t.Setenv("APP_BASE_URL", "")
+	if got := DigestFeedURL("df_abc"); got != nil {
+		t.Fatalf("DigestFeedURL() = %q, want nil without base url", *got)
+	}
+	t.Setenv("APP_BASE_URL", "https://app.example.com/")
+	got := DigestFeedURL("df_abc")
+	if got == nil || *got != "https://app.example.com/api/digests/feed?token=df_abc" {
+		t.Fatalf("DigestFeedURL() = %v", got)
+	}
+}
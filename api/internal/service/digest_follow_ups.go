@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	DigestFollowUpLookbackDays = 30
+	// Roughly the similarity at which the reading-plan clustering merges two items into a story.
+	digestFollowUpMinSimilarity = 0.72
+)
+
+// MatchDigestFollowUps pairs each current item with the most similar item from an earlier digest,
+// when they are close enough to be the same story. Embeddings of different dimensions are never
+// compared.
+func MatchDigestFollowUps(current map[string][]float64, prior []repository.DigestPriorItem) map[string]model.DigestFollowUp {
+	out := map[string]model.DigestFollowUp{}
+	for itemID, emb := range current {
+		var best *repository.DigestPriorItem
+		bestSim := digestFollowUpMinSimilarity
+		for i := range prior {
+			sim := topicCosine(emb, prior[i].Embedding)
+			if sim >= bestSim {
+				best, bestSim = &prior[i], sim
+			}
+		}
+		if best == nil {
+			continue
+		}
+		out[itemID] = model.DigestFollowUp{
+			ItemID:     best.ItemID,
+			Title:      strings.TrimSpace(best.Title),
+			URL:        best.URL,
+			DigestID:   best.DigestID,
+			DigestDate: best.DigestDate,
+			Similarity: bestSim,
+		}
+	}
+	return out
+}
+
+// DigestFollowUpNote is the line added to a cluster's compose input so the digest copy can
+// mention that the story continues from an earlier digest.
+func DigestFollowUpNote(f *model.DigestFollowUp) string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("- Follow-up to a story from %s: %s", f.DigestDate, f.Title)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestMatchDigestFollowUpsPicksClosestPriorStory(t *testing.T) {
+	current := map[string][]float64{
+		"new-launch": {1, 0.1, 0},
+		"unrelated":  {0, 0, 1},
+		"other-dims": {1, 0},
+	}
+	prior := []repository.DigestPriorItem{
+		{ItemID: "old-rumor", Title: "Launch rumored", URL: "https://example.com/rumor", DigestID: "d1", DigestDate: "2026-10-01", Embedding: []float64{0.9, 0.3, 0}},
+		{ItemID: "old-launch", Title: " Launch announced ", URL: "https://example.com/launch", DigestID: "d2", DigestDate: "2026-10-10", Embedding: []float64{1, 0.12, 0}},
+		{ItemID: "old-sports", Title: "Match result", URL: "https://example.com/sports", DigestID: "d2", DigestDate: "2026-10-10", Embedding: []float64{0, 1, 0}},
+	}
+
+	got := MatchDigestFollowUps(current, prior)
+	if len(got) != 1 {
+		t.Fatalf("matches = %+v, want only new-launch", got)
+	}
+	f := got["new-launch"]
+	if f.ItemID != "old-launch" || f.DigestDate != "2026-10-10" || f.Title != "Launch announced" || f.Similarity < 0.99 {
+		t.Fatalf("new-launch follow-up = %+v", f)
+	}
+}
+
+func TestBuildDigestHTMLFollowUp(t *testing.T) {
+	title := "Launch day"
+	digest := &model.DigestDetail{
+		Digest: model.Digest{DigestDate: "2026-10-16"},
+		Items: []model.DigestItemDetail{{
+			Rank: 1,
+			Item: model.Item{ID: "i1", URL: "https://example.com/a", Title: &title},
+			FollowUp: &model.DigestFollowUp{
+				Title: "Launch <announced>", URL: "https://example.com/launch", DigestDate: "2026-10-10",
+			},
+		}},
+	}
+	got := buildDigestHTML("en", digest, &DigestEmailCopy{Body: "Body"})
+	for _, want := range []string{"Follow-up to a story from 2026-10-10", `href="https://example.com/launch"`, "Launch &lt;announced&gt;"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("html missing %q: %s", want, got)
+		}
+	}
+}
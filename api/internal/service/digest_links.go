@@ -0,0 +1,183 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DigestFeedbackUp   = "up"
+	DigestFeedbackDown = "down"
+	DigestFeedbackSave = "save"
+
+	// DigestEmailScopeOwner links manage the digest owner's own mail by user ID;
+	// DigestEmailScopeRecipient links manage one extra recipient by recipient ID.
+	DigestEmailScopeOwner     = "owner"
+	DigestEmailScopeRecipient = "recipient"
+
+	digestFeedbackLinkTTL = 30 * 24 * time.Hour
+	// Unsubscribe links must keep working from old emails sitting in an inbox.
+	digestEmailLinkTTL = 365 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidDigestFeedbackToken = errors.New("invalid or expired feedback link")
+	ErrInvalidDigestEmailToken    = errors.New("invalid or expired email preferences link")
+)
+
+// DigestFeedbackClaim is what a signed digest feedback link grants: one action on one item of
+// one user.
+type DigestFeedbackClaim struct {
+	UserID    string
+	ItemID    string
+	Action    string
+	ExpiresAt time.Time
+}
+
+// DigestEmailClaim is what a signed unsubscribe or preferences link grants: control over the
+// digest mail of one owner or one extra recipient.
+type DigestEmailClaim struct {
+	Scope     string
+	SubjectID string
+	ExpiresAt time.Time
+}
+
+// DigestLinkSigner signs the one-click links embedded in digest emails: item feedback,
+// unsubscribe and email preferences. The links are stateless HMAC tokens, so the endpoints need
+// no session and no lookup table.
+type DigestLinkSigner struct {
+	secret  []byte
+	baseURL string
+	now     func() time.Time
+}
+
+func NewDigestLinkSigner(secret, baseURL string) *DigestLinkSigner {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil
+	}
+	return &DigestLinkSigner{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		now:     time.Now,
+	}
+}
+
+// NewDigestLinkSignerFromEnv returns nil when DIGEST_LINK_SECRET is unset, which turns the
+// digest email links off.
+func NewDigestLinkSignerFromEnv() *DigestLinkSigner {
+	return NewDigestLinkSigner(os.Getenv("DIGEST_LINK_SECRET"), AppBaseURLFromEnv())
+}
+
+func IsDigestFeedbackAction(action string) bool {
+	switch action {
+	case DigestFeedbackUp, DigestFeedbackDown, DigestFeedbackSave:
+		return true
+	}
+	return false
+}
+
+func (s *DigestLinkSigner) Sign(userID, itemID, action string) string {
+	return s.sign("v1", userID, itemID, action, strconv.FormatInt(s.now().Add(digestFeedbackLinkTTL).Unix(), 10))
+}
+
+func (s *DigestLinkSigner) Verify(token string) (DigestFeedbackClaim, error) {
+	parts, ok := s.open(token)
+	if !ok || len(parts) != 5 || parts[0] != "v1" || !IsDigestFeedbackAction(parts[3]) {
+		return DigestFeedbackClaim{}, ErrInvalidDigestFeedbackToken
+	}
+	exp, ok := s.unexpired(parts[4])
+	if !ok {
+		return DigestFeedbackClaim{}, ErrInvalidDigestFeedbackToken
+	}
+	return DigestFeedbackClaim{UserID: parts[1], ItemID: parts[2], Action: parts[3], ExpiresAt: exp}, nil
+}
+
+// URL returns the public feedback link for one item and action, or "" when no base URL is set.
+func (s *DigestLinkSigner) URL(userID, itemID, action string) string {
+	if s == nil || s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + "/api/digest-feedback?token=" + url.QueryEscape(s.Sign(userID, itemID, action))
+}
+
+// SignEmail signs an unsubscribe/preferences token. It uses its own version tag so a feedback
+// token can never be replayed as an unsubscribe token or the other way round.
+func (s *DigestLinkSigner) SignEmail(scope, subjectID string) string {
+	return s.sign("e1", scope, subjectID, strconv.FormatInt(s.now().Add(digestEmailLinkTTL).Unix(), 10))
+}
+
+func (s *DigestLinkSigner) VerifyEmail(token string) (DigestEmailClaim, error) {
+	parts, ok := s.open(token)
+	if !ok || len(parts) != 4 || parts[0] != "e1" || parts[2] == "" {
+		return DigestEmailClaim{}, ErrInvalidDigestEmailToken
+	}
+	if parts[1] != DigestEmailScopeOwner && parts[1] != DigestEmailScopeRecipient {
+		return DigestEmailClaim{}, ErrInvalidDigestEmailToken
+	}
+	exp, ok := s.unexpired(parts[3])
+	if !ok {
+		return DigestEmailClaim{}, ErrInvalidDigestEmailToken
+	}
+	return DigestEmailClaim{Scope: parts[1], SubjectID: parts[2], ExpiresAt: exp}, nil
+}
+
+// UnsubscribeURL is the List-Unsubscribe target; it accepts the RFC 8058 one-click POST.
+func (s *DigestLinkSigner) UnsubscribeURL(scope, subjectID string) string {
+	if s == nil || s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + "/api/digest-email/unsubscribe?token=" + url.QueryEscape(s.SignEmail(scope, subjectID))
+}
+
+// PreferencesURL links the owner to the login-free frequency page.
+func (s *DigestLinkSigner) PreferencesURL(userID string) string {
+	if s == nil || s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + "/api/digest-email/preferences?token=" + url.QueryEscape(s.SignEmail(DigestEmailScopeOwner, userID))
+}
+
+func (s *DigestLinkSigner) sign(fields ...string) string {
+	enc := base64.RawURLEncoding.EncodeToString([]byte(strings.Join(fields, "|")))
+	return enc + "." + base64.RawURLEncoding.EncodeToString(s.mac(enc))
+}
+
+// open checks the MAC and returns the signed fields.
+func (s *DigestLinkSigner) open(token string) ([]string, bool) {
+	enc, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return nil, false
+	}
+	gotMAC, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMAC, s.mac(enc)) {
+		return nil, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, false
+	}
+	return strings.Split(string(raw), "|"), true
+}
+
+func (s *DigestLinkSigner) unexpired(unix string) (time.Time, bool) {
+	exp, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	expiresAt := time.Unix(exp, 0)
+	return expiresAt, s.now().Before(expiresAt)
+}
+
+func (s *DigestLinkSigner) mac(payload string) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(payload))
+	return m.Sum(nil)
+}
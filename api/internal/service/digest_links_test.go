@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestDigestLinkSignerRoundTrip(t *testing.T) {
+	if NewDigestLinkSigner(" ", "https://sifto.example") != nil {
+		t.Fatal("signer without secret should be nil")
+	}
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := NewDigestLinkSigner("secret", "https://sifto.example/")
+	s.now = func() time.Time { return now }
+
+	token := s.Sign("u1", "i1", DigestFeedbackDown)
+	claim, err := s.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claim.UserID != "u1" || claim.ItemID != "i1" || claim.Action != DigestFeedbackDown {
+		t.Fatalf("claim = %+v", claim)
+	}
+
+	other := NewDigestLinkSigner("other", "")
+	other.now = s.now
+	if _, err := other.Verify(token); !errors.Is(err, ErrInvalidDigestFeedbackToken) {
+		t.Fatalf("foreign secret err = %v", err)
+	}
+	forged := other.Sign("u2", "i1", DigestFeedbackDown)
+	if _, err := s.Verify(forged); !errors.Is(err, ErrInvalidDigestFeedbackToken) {
+		t.Fatalf("forged token err = %v", err)
+	}
+
+	now = now.Add(digestFeedbackLinkTTL)
+	if _, err := s.Verify(token); !errors.Is(err, ErrInvalidDigestFeedbackToken) {
+		t.Fatalf("expired token err = %v", err)
+	}
+
+	u, err := url.Parse(s.URL("u1", "i1", DigestFeedbackSave))
+	if err != nil || u.Host != "sifto.example" || u.Path != "/api/digest-feedback" || u.Query().Get("token") == "" {
+		t.Fatalf("URL = %v, %v", u, err)
+	}
+}
+
+func TestBuildDigestHTMLFeedbackLinks(t *testing.T) {
+	title := "Title"
+	digest := &model.DigestDetail{
+		Digest: model.Digest{DigestDate: "2026-03-01"},
+		Items:  []model.DigestItemDetail{{Rank: 1, Item: model.Item{ID: "i1", URL: "https://example.com/a", Title: &title}}},
+	}
+	plain := buildDigestHTML("en", digest, &DigestEmailCopy{Body: "Body"})
+	if strings.Contains(plain, "Read later") {
+		t.Fatalf("links rendered without FeedbackURL: %s", plain)
+	}
+	withLinks := buildDigestHTML("en", digest, &DigestEmailCopy{
+		Body:        "Body",
+		FeedbackURL: func(itemID, action string) string { return "https://sifto.example/f?" + itemID + "&amp;" + action },
+	})
+	for _, want := range []string{"Useful", "Not for me", "Read later", "i1&amp;amp;save"} {
+		if !strings.Contains(withLinks, want) {
+			t.Fatalf("html missing %q: %s", want, withLinks)
+		}
+	}
+}
+
+func TestDigestLinkSignerEmailTokens(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := NewDigestLinkSigner("secret", "https://sifto.example")
+	s.now = func() time.Time { return now }
+
+	claim, err := s.VerifyEmail(s.SignEmail(DigestEmailScopeRecipient, "r1"))
+	if err != nil || claim.Scope != DigestEmailScopeRecipient || claim.SubjectID != "r1" {
+		t.Fatalf("VerifyEmail = %+v, %v", claim, err)
+	}
+	if _, err := s.VerifyEmail(s.Sign("u1", "i1", DigestFeedbackUp)); !errors.Is(err, ErrInvalidDigestEmailToken) {
+		t.Fatalf("feedback token accepted as email token: %v", err)
+	}
+	if _, err := s.Verify(s.SignEmail(DigestEmailScopeOwner, "u1")); !errors.Is(err, ErrInvalidDigestFeedbackToken) {
+		t.Fatalf("email token accepted as feedback token: %v", err)
+	}
+
+	u, err := url.Parse(s.UnsubscribeURL(DigestEmailScopeOwner, "u1"))
+	if err != nil || u.Path != "/api/digest-email/unsubscribe" {
+		t.Fatalf("UnsubscribeURL = %v, %v", u, err)
+	}
+	token := u.Query().Get("token")
+	now = now.Add(digestEmailLinkTTL)
+	if _, err := s.VerifyEmail(token); !errors.Is(err, ErrInvalidDigestEmailToken) {
+		t.Fatalf("expired token err = %v", err)
+	}
+}
+
+func TestSendDigestEmailUnsubscribeHeaders(t *testing.T) {
+	digest := &model.DigestDetail{Digest: model.Digest{DigestDate: "2026-03-01"}}
+	sender := &recordingEmailSender{}
+	err := SendDigestEmail(context.Background(), sender, "a@example.com", "en", digest, &DigestEmailCopy{
+		UnsubscribeURL: "https://sifto.example/api/digest-email/unsubscribe?token=t",
+		PreferencesURL: "https://sifto.example/api/digest-email/preferences?token=t",
+	})
+	if err != nil {
+		t.Fatalf("SendDigestEmail: %v", err)
+	}
+	msg := sender.sent[0]
+	if msg.Headers["List-Unsubscribe"] != "<https://sifto.example/api/digest-email/unsubscribe?token=t>" || msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Fatalf("headers = %v", msg.Headers)
+	}
+	if !strings.Contains(msg.HTML, "Unsubscribe") || !strings.Contains(msg.HTML, "Email preferences") {
+		t.Fatalf("footer links missing: %s", msg.HTML)
+	}
+
+	sender.sent = nil
+	if err := SendDigestEmail(context.Background(), sender, "a@example.com", "en", digest, &DigestEmailCopy{}); err != nil {
+		t.Fatalf("SendDigestEmail: %v", err)
+	}
+	if sender.sent[0].Headers != nil {
+		t.Fatalf("headers without unsubscribe URL = %v", sender.sent[0].Headers)
+	}
+}
+
+type recordingEmailSender struct {
+	sent []EmailMessage
+}
+
+func (s *recordingEmailSender) Enabled() bool     { return true }
+func (s *recordingEmailSender) Transport() string { return "test" }
+func (s *recordingEmailSender) Send(_ context.Context, msg EmailMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
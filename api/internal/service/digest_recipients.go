@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	DigestRecipientStatusPending   = "pending"
+	DigestRecipientStatusConfirmed = "confirmed"
+
+	maxDigestRecipients           = 10
+	digestRecipientTokenTTL       = 7 * 24 * time.Hour
+	digestRecipientResendInterval = time.Minute
+)
+
+type digestRecipientStore interface {
+	ListByUser(ctx context.Context, userID string) ([]model.DigestRecipient, error)
+	CountForDigest(ctx context.Context, userID string, configID *string) (int, error)
+	ConfigOwned(ctx context.Context, userID, configID string) (bool, error)
+	Create(ctx context.Context, userID string, configID *string, email string) (*model.DigestRecipient, error)
+	Get(ctx context.Context, userID, id string) (*model.DigestRecipient, error)
+	Delete(ctx context.Context, userID, id string) error
+	ConfirmByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*model.DigestRecipient, error)
+}
+
+type digestRecipientUserRepo interface {
+	GetByID(ctx context.Context, id string) (*model.User, error)
+}
+
+type digestRecipientPublisher interface {
+	SendDigestRecipientConfirmationE(ctx context.Context, recipientID string) error
+}
+
+// DigestRecipientService manages extra digest recipients. New addresses stay pending until
+// the owner of the address follows the emailed confirmation link, so a digest can only be
+// CC'd to mailboxes that asked for it.
+type DigestRecipientService struct {
+	repo      digestRecipientStore
+	users     digestRecipientUserRepo
+	publisher digestRecipientPublisher
+	now       func() time.Time
+}
+
+func NewDigestRecipientService(repo digestRecipientStore, users digestRecipientUserRepo, publisher digestRecipientPublisher) *DigestRecipientService {
+	return &DigestRecipientService{repo: repo, users: users, publisher: publisher, now: time.Now}
+}
+
+func (s *DigestRecipientService) List(ctx context.Context, userID string) ([]model.DigestRecipient, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Add registers a pending recipient for the daily digest (configID nil) or a scoped digest and
+// sends the confirmation email.
+func (s *DigestRecipientService) Add(ctx context.Context, userID string, configID *string, email string) (*model.DigestRecipient, error) {
+	addr, err := NormalizeDigestRecipientEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(user.Email, addr) {
+		return nil, &ValidationError{Field: "email", Message: "your own address already receives the digest"}
+	}
+	if configID != nil {
+		owned, err := s.repo.ConfigOwned(ctx, userID, *configID)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, &ValidationError{Field: "digest_config_id", Message: "unknown digest_config_id"}
+		}
+	}
+	n, err := s.repo.CountForDigest(ctx, userID, configID)
+	if err != nil {
+		return nil, err
+	}
+	if n >= maxDigestRecipients {
+		return nil, &ValidationError{Field: "email", Message: fmt.Sprintf("a digest can have at most %d extra recipients", maxDigestRecipients)}
+	}
+	rec, err := s.repo.Create(ctx, userID, configID, addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.publisher.SendDigestRecipientConfirmationE(ctx, rec.ID); err != nil {
+		log.Printf("digest recipient confirmation publish failed recipient_id=%s: %v", rec.ID, err)
+	}
+	return rec, nil
+}
+
+// Resend re-sends the confirmation email of a pending recipient.
+func (s *DigestRecipientService) Resend(ctx context.Context, userID, id string) error {
+	rec, err := s.repo.Get(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if rec.Status != DigestRecipientStatusPending {
+		return &ValidationError{Field: "status", Message: "recipient is already confirmed"}
+	}
+	if rec.ConfirmSentAt != nil && s.now().Sub(*rec.ConfirmSentAt) < digestRecipientResendInterval {
+		return &ValidationError{Field: "status", Message: "confirmation email was sent less than a minute ago"}
+	}
+	return s.publisher.SendDigestRecipientConfirmationE(ctx, rec.ID)
+}
+
+func (s *DigestRecipientService) Delete(ctx context.Context, userID, id string) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// Confirm activates the recipient holding token. Unknown, used or expired tokens return
+// repository.ErrNotFound.
+func (s *DigestRecipientService) Confirm(ctx context.Context, token string) (*model.DigestRecipient, error) {
+	return s.repo.ConfirmByTokenHash(ctx, HashDigestRecipientToken(strings.TrimSpace(token)), s.now())
+}
+
+func NormalizeDigestRecipientEmail(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	addr, err := mail.ParseAddress(raw)
+	if err != nil || addr.Address != raw {
+		return "", &ValidationError{Field: "email", Message: "email must be a plain email address"}
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+// NewDigestRecipientToken returns a confirmation token and the hash stored in the database.
+func NewDigestRecipientToken() (token, hash string, err error) {
+	var buf [20]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", "", err
+	}
+	token = "drc_" + hex.EncodeToString(buf[:])
+	return token, HashDigestRecipientToken(token), nil
+}
+
+func HashDigestRecipientToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func DigestRecipientTokenExpiry(now time.Time) time.Time {
+	return now.Add(digestRecipientTokenTTL)
+}
+
+func DigestRecipientConfirmURL(token string) string {
+	return strings.TrimRight(AppBaseURLFromEnv(), "/") + "/api/digest-recipients/confirm?token=" + url.QueryEscape(token)
+}
+
+type DigestRecipientConfirmationEmail struct {
+	Locale     string
+	OwnerEmail string
+	DigestName string
+	ConfirmURL string
+}
+
+func SendDigestRecipientConfirmationEmail(ctx context.Context, sender EmailSender, to string, c DigestRecipientConfirmationEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip digest recipient confirmation to %s", to)
+		return nil
+	}
+	strs := emailStringsFor(c.Locale)
+	name := c.DigestName
+	if name == "" {
+		name = strs.RecipientConfirmDailyDigest
+	}
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.RecipientConfirmHeading)))
+	sb.WriteString(`<p style="line-height:1.7;color:#333">` + html.EscapeString(fmt.Sprintf(strs.RecipientConfirmLead, c.OwnerEmail, name)) + `</p>`)
+	sb.WriteString(fmt.Sprintf(`<p><a href="%s" style="display:inline-block;padding:10px 18px;background:#2563eb;color:#fff;border-radius:6px;text-decoration:none">%s</a></p>`,
+		html.EscapeString(c.ConfirmURL), html.EscapeString(strs.RecipientConfirmButton)))
+	sb.WriteString(fmt.Sprintf(`<p style="margin-top:12px;color:#666;line-height:1.6">%s</p>`, html.EscapeString(strs.RecipientConfirmFooter)))
+	sb.WriteString(`</body></html>`)
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf(strs.RecipientConfirmSubject, c.OwnerEmail),
+		HTML:    sb.String(),
+	})
+}
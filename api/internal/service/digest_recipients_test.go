@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/repository/repotest"
+)
+
+type fakeDigestRecipientPublisher struct {
+	sent []string
+}
+
+func (f *fakeDigestRecipientPublisher) SendDigestRecipientConfirmationE(_ context.Context, recipientID string) error {
+	f.sent = append(f.sent, recipientID)
+	return nil
+}
+
+func TestDigestRecipientServiceAddValidates(t *testing.T) {
+	ctx := context.Background()
+	store := repotest.NewDigestRecipientStore()
+	store.AddConfig("u1", "cfg-1")
+	store.AddConfig("u2", "cfg-2")
+	pub := &fakeDigestRecipientPublisher{}
+	svc := NewDigestRecipientService(store, fakeDigestRegenerationUsers{}, pub)
+
+	var ve *ValidationError
+	if _, err := svc.Add(ctx, "u1", nil, "not-an-address"); !errors.As(err, &ve) {
+		t.Fatalf("invalid address err = %v", err)
+	}
+	if _, err := svc.Add(ctx, "u1", nil, " U1@Example.com "); !errors.As(err, &ve) {
+		t.Fatalf("own address err = %v", err)
+	}
+	foreign := "cfg-2"
+	if _, err := svc.Add(ctx, "u1", &foreign, "team@example.com"); !errors.As(err, &ve) {
+		t.Fatalf("foreign config err = %v", err)
+	}
+
+	cfg := "cfg-1"
+	rec, err := svc.Add(ctx, "u1", &cfg, " Team@Example.com ")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if rec.Email != "team@example.com" || rec.Status != DigestRecipientStatusPending {
+		t.Fatalf("recipient = %+v", rec)
+	}
+	if len(pub.sent) != 1 || pub.sent[0] != rec.ID {
+		t.Fatalf("confirmation events = %v", pub.sent)
+	}
+
+	for i := 0; i <= maxDigestRecipients; i++ {
+		addr := "r" + strings.Repeat("x", i) + "@example.com"
+		_, err = svc.Add(ctx, "u1", nil, addr)
+	}
+	if !errors.As(err, &ve) {
+		t.Fatalf("recipient over the limit err = %v", err)
+	}
+}
+
+func TestDigestRecipientServiceResendAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	store := repotest.NewDigestRecipientStore()
+	pub := &fakeDigestRecipientPublisher{}
+	svc := NewDigestRecipientService(store, fakeDigestRegenerationUsers{}, pub)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	rec, err := svc.Add(ctx, "u1", nil, "team@example.com")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	token, hash, err := NewDigestRecipientToken()
+	if err != nil {
+		t.Fatalf("NewDigestRecipientToken: %v", err)
+	}
+	if hash != HashDigestRecipientToken(token) || strings.Contains(hash, token) {
+		t.Fatalf("token %q hash %q", token, hash)
+	}
+	if _, err := store.IssueConfirmToken(ctx, rec.ID, hash, DigestRecipientTokenExpiry(now)); err != nil {
+		t.Fatalf("IssueConfirmToken: %v", err)
+	}
+
+	var ve *ValidationError
+	if err := svc.Resend(ctx, "u1", rec.ID); !errors.As(err, &ve) {
+		t.Fatalf("resend right after sending err = %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := svc.Resend(ctx, "u1", rec.ID); err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	if err := svc.Resend(ctx, "u2", rec.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("resend of another user's recipient err = %v", err)
+	}
+
+	if _, err := svc.Confirm(ctx, "drc_wrong"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("wrong token err = %v", err)
+	}
+	confirmed, err := svc.Confirm(ctx, token)
+	if err != nil || confirmed.Status != DigestRecipientStatusConfirmed {
+		t.Fatalf("Confirm = %+v, %v", confirmed, err)
+	}
+	if _, err := svc.Confirm(ctx, token); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("reused token err = %v", err)
+	}
+	if err := svc.Resend(ctx, "u1", rec.ID); !errors.As(err, &ve) {
+		t.Fatalf("resend after confirm err = %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+var (
+	ErrDigestAlreadySent           = errors.New("digest has already been sent; set force to create a new revision")
+	ErrDigestNoActiveClusterDrafts = errors.New("digest has no active cluster drafts")
+)
+
+type digestRegenerationRepo interface {
+	ResetForRegeneration(ctx context.Context, id, userID string) error
+	ResetForRecompose(ctx context.Context, id, userID string) error
+	CreateRevision(ctx context.Context, id, userID string) (string, error)
+	ListClusterDrafts(ctx context.Context, id, userID string) ([]model.DigestClusterDraft, error)
+	RemoveItem(ctx context.Context, id, userID, itemID string) error
+}
+
+type digestRegenerationUserRepo interface {
+	GetByID(ctx context.Context, id string) (*model.User, error)
+}
+
+type digestCreatedPublisher interface {
+	SendDigestCreatedWithOptionsE(ctx context.Context, digestID, userID, to string, opts DigestCreatedOptions) error
+}
+
+type DigestRegenerateInput struct {
+	ModelOverride *string
+	Force         bool
+}
+
+type DigestRegenerateResult struct {
+	DigestID       string  `json:"digest_id"`
+	SourceDigestID string  `json:"source_digest_id"`
+	NewRevision    bool    `json:"new_revision"`
+	ModelOverride  *string `json:"model_override,omitempty"`
+}
+
+type DigestRegenerationService struct {
+	repo      digestRegenerationRepo
+	users     digestRegenerationUserRepo
+	publisher digestCreatedPublisher
+}
+
+func NewDigestRegenerationService(repo digestRegenerationRepo, users digestRegenerationUserRepo, publisher digestCreatedPublisher) *DigestRegenerationService {
+	return &DigestRegenerationService{repo: repo, users: users, publisher: publisher}
+}
+
+// Regenerate clears an unsent digest's composed output and re-emits digest/created.
+// Sent digests are only regenerated with Force, which creates a new revision instead.
+func (s *DigestRegenerationService) Regenerate(ctx context.Context, userID, digestID string, in DigestRegenerateInput) (*DigestRegenerateResult, error) {
+	modelOverride := normalizeOptionalModel(in.ModelOverride)
+	if modelOverride != nil && !CatalogModelSupportsPurposeInCatalog(LLMCatalogData(), *modelOverride, "digest") {
+		return nil, &ModelValidationError{SettingKey: "model"}
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DigestRegenerateResult{
+		DigestID:       strings.TrimSpace(digestID),
+		SourceDigestID: strings.TrimSpace(digestID),
+		ModelOverride:  modelOverride,
+	}
+	err = s.repo.ResetForRegeneration(ctx, result.SourceDigestID, userID)
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrInvalidState):
+		if !in.Force {
+			return nil, ErrDigestAlreadySent
+		}
+		newID, err := s.repo.CreateRevision(ctx, result.SourceDigestID, userID)
+		if err != nil {
+			return nil, err
+		}
+		result.DigestID = newID
+		result.NewRevision = true
+	default:
+		return nil, err
+	}
+
+	if err := s.publisher.SendDigestCreatedWithOptionsE(ctx, result.DigestID, userID, user.Email, DigestCreatedOptions{ModelOverride: modelOverride}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Recompose re-runs only the final digest composition from the stored cluster drafts,
+// so label edits and dropped clusters are reflected without re-drafting.
+func (s *DigestRegenerationService) Recompose(ctx context.Context, userID, digestID string) error {
+	drafts, err := s.repo.ListClusterDrafts(ctx, digestID, userID)
+	if err != nil {
+		return err
+	}
+	active := 0
+	for _, d := range drafts {
+		if !d.Dropped {
+			active++
+		}
+	}
+	if active == 0 {
+		return ErrDigestNoActiveClusterDrafts
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ResetForRecompose(ctx, digestID, userID); err != nil {
+		if errors.Is(err, repository.ErrInvalidState) {
+			return ErrDigestAlreadySent
+		}
+		return err
+	}
+	return s.publisher.SendDigestCreatedWithOptionsE(ctx, digestID, userID, user.Email, DigestCreatedOptions{ReuseClusterDrafts: true})
+}
+
+// RemoveItem takes one item out of an unsent digest and re-emits digest/created so the email
+// copy and cluster drafts are composed again without it.
+func (s *DigestRegenerationService) RemoveItem(ctx context.Context, userID, digestID, itemID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RemoveItem(ctx, digestID, userID, itemID); err != nil {
+		if errors.Is(err, repository.ErrInvalidState) {
+			return ErrDigestAlreadySent
+		}
+		return err
+	}
+	return s.publisher.SendDigestCreatedWithOptionsE(ctx, digestID, userID, user.Email, DigestCreatedOptions{})
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeDigestRegenerationRepo struct {
+	resetErr       error
+	revisionID     string
+	resetCalls     int
+	recomposeCalls int
+	revisionFor    string
+	drafts         []model.DigestClusterDraft
+	removed        []string
+}
+
+func (f *fakeDigestRegenerationRepo) ResetForRegeneration(_ context.Context, _, _ string) error {
+	f.resetCalls++
+	return f.resetErr
+}
+
+func (f *fakeDigestRegenerationRepo) ResetForRecompose(_ context.Context, _, _ string) error {
+	f.recomposeCalls++
+	return f.resetErr
+}
+
+func (f *fakeDigestRegenerationRepo) RemoveItem(_ context.Context, _, _, itemID string) error {
+	if f.resetErr != nil {
+		return f.resetErr
+	}
+	f.removed = append(f.removed, itemID)
+	return nil
+}
+
+func (f *fakeDigestRegenerationRepo) ListClusterDrafts(_ context.Context, _, _ string) ([]model.DigestClusterDraft, error) {
+	return f.drafts, nil
+}
+
+func (f *fakeDigestRegenerationRepo) CreateRevision(_ context.Context, id, _ string) (string, error) {
+	f.revisionFor = id
+	return f.revisionID, nil
+}
+
+type fakeDigestRegenerationUsers struct{}
+
+func (fakeDigestRegenerationUsers) GetByID(_ context.Context, id string) (*model.User, error) {
+	return &model.User{ID: id, Email: id + "@example.com"}, nil
+}
+
+type fakeDigestCreatedPublisher struct {
+	digestID      string
+	to            string
+	modelOverride *string
+	reuseDrafts   bool
+	calls         int
+}
+
+func (f *fakeDigestCreatedPublisher) SendDigestCreatedWithOptionsE(_ context.Context, digestID, _, to string, opts DigestCreatedOptions) error {
+	f.calls++
+	f.digestID = digestID
+	f.to = to
+	f.modelOverride = opts.ModelOverride
+	f.reuseDrafts = opts.ReuseClusterDrafts
+	return nil
+}
+
+func TestDigestRegenerateResetsUnsentDigestInPlace(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{}
+	pub := &fakeDigestCreatedPublisher{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	got, err := svc.Regenerate(context.Background(), "u1", "d1", DigestRegenerateInput{})
+	if err != nil {
+		t.Fatalf("Regenerate() error = %v", err)
+	}
+	if got.DigestID != "d1" || got.NewRevision {
+		t.Fatalf("result = %+v, want in-place regeneration", got)
+	}
+	if pub.calls != 1 || pub.digestID != "d1" || pub.to != "u1@example.com" || pub.modelOverride != nil {
+		t.Fatalf("publisher = %+v", pub)
+	}
+}
+
+func TestDigestRegenerateSentDigestRequiresForce(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{resetErr: repository.ErrInvalidState, revisionID: "d2"}
+	pub := &fakeDigestCreatedPublisher{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	if _, err := svc.Regenerate(context.Background(), "u1", "d1", DigestRegenerateInput{}); !errors.Is(err, ErrDigestAlreadySent) {
+		t.Fatalf("Regenerate() error = %v, want ErrDigestAlreadySent", err)
+	}
+	if pub.calls != 0 {
+		t.Fatalf("publisher called %d times, want 0", pub.calls)
+	}
+
+	got, err := svc.Regenerate(context.Background(), "u1", "d1", DigestRegenerateInput{Force: true})
+	if err != nil {
+		t.Fatalf("Regenerate(force) error = %v", err)
+	}
+	if got.DigestID != "d2" || got.SourceDigestID != "d1" || !got.NewRevision {
+		t.Fatalf("result = %+v, want new revision d2", got)
+	}
+	if repo.revisionFor != "d1" || pub.digestID != "d2" {
+		t.Fatalf("revisionFor = %q, published = %q", repo.revisionFor, pub.digestID)
+	}
+}
+
+func TestDigestRegenerateRejectsUnknownModel(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, &fakeDigestCreatedPublisher{})
+
+	_, err := svc.Regenerate(context.Background(), "u1", "d1", DigestRegenerateInput{ModelOverride: strptr("no-such-model")})
+	var mve *ModelValidationError
+	if !errors.As(err, &mve) {
+		t.Fatalf("Regenerate() error = %v, want ModelValidationError", err)
+	}
+	if repo.resetCalls != 0 {
+		t.Fatalf("reset called %d times, want 0", repo.resetCalls)
+	}
+}
+
+func TestDigestRecomposeReusesClusterDrafts(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{drafts: []model.DigestClusterDraft{{ID: "c1", Dropped: true}, {ID: "c2"}}}
+	pub := &fakeDigestCreatedPublisher{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	if err := svc.Recompose(context.Background(), "u1", "d1"); err != nil {
+		t.Fatalf("Recompose() error = %v", err)
+	}
+	if repo.recomposeCalls != 1 || repo.resetCalls != 0 {
+		t.Fatalf("recompose resets = %d, full resets = %d", repo.recomposeCalls, repo.resetCalls)
+	}
+	if pub.calls != 1 || !pub.reuseDrafts {
+		t.Fatalf("publisher = %+v, want reuse_cluster_drafts", pub)
+	}
+}
+
+func TestDigestRecomposeRequiresActiveCluster(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{drafts: []model.DigestClusterDraft{{ID: "c1", Dropped: true}}}
+	pub := &fakeDigestCreatedPublisher{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	if err := svc.Recompose(context.Background(), "u1", "d1"); !errors.Is(err, ErrDigestNoActiveClusterDrafts) {
+		t.Fatalf("Recompose() error = %v, want ErrDigestNoActiveClusterDrafts", err)
+	}
+	if repo.recomposeCalls != 0 || pub.calls != 0 {
+		t.Fatalf("recompose resets = %d, publisher calls = %d", repo.recomposeCalls, pub.calls)
+	}
+}
+
+func TestDigestRemoveItemRecomposesUnsentDigest(t *testing.T) {
+	repo := &fakeDigestRegenerationRepo{}
+	pub := &fakeDigestCreatedPublisher{}
+	svc := NewDigestRegenerationService(repo, fakeDigestRegenerationUsers{}, pub)
+
+	if err := svc.RemoveItem(context.Background(), "u1", "d1", "i1"); err != nil {
+		t.Fatalf("RemoveItem() error = %v", err)
+	}
+	if len(repo.removed) != 1 || repo.removed[0] != "i1" {
+		t.Fatalf("removed = %v", repo.removed)
+	}
+	if pub.calls != 1 || pub.digestID != "d1" || pub.reuseDrafts {
+		t.Fatalf("publisher = %+v, want full recompose of d1", pub)
+	}
+
+	repo.resetErr = repository.ErrInvalidState
+	if err := svc.RemoveItem(context.Background(), "u1", "d1", "i2"); !errors.Is(err, ErrDigestAlreadySent) {
+		t.Fatalf("RemoveItem(sent) error = %v, want ErrDigestAlreadySent", err)
+	}
+	if pub.calls != 1 {
+		t.Fatalf("publisher called %d times, want 1", pub.calls)
+	}
+}
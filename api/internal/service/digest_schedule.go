@@ -0,0 +1,145 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	DigestComposeModeDaily   = "daily"
+	DigestComposeModeCatchUp = "catch_up"
+
+	// MaxDigestCatchUpDays caps how far back a catch-up digest reaches after quiet days.
+	MaxDigestCatchUpDays  = 14
+	maxDigestVacationDays = 60
+)
+
+type DigestScheduleView struct {
+	SkipWeekdays  []int   `json:"skip_weekdays"`
+	VacationStart *string `json:"vacation_start,omitempty"`
+	VacationEnd   *string `json:"vacation_end,omitempty"`
+}
+
+type DigestScheduleInput struct {
+	SkipWeekdays  []int   `json:"skip_weekdays"`
+	VacationStart *string `json:"vacation_start"`
+	VacationEnd   *string `json:"vacation_end"`
+}
+
+// NormalizedDigestSchedule is the validated form of DigestScheduleInput with vacation dates in JST.
+type NormalizedDigestSchedule struct {
+	SkipWeekdays  []int
+	VacationStart *time.Time
+	VacationEnd   *time.Time
+}
+
+func NewDigestScheduleView(settings *model.UserSettings) DigestScheduleView {
+	view := DigestScheduleView{SkipWeekdays: []int{}}
+	if settings == nil {
+		return view
+	}
+	if settings.DigestSkipWeekdays != nil {
+		view.SkipWeekdays = settings.DigestSkipWeekdays
+	}
+	if settings.DigestVacationStart != nil && settings.DigestVacationEnd != nil {
+		start := settings.DigestVacationStart.Format("2006-01-02")
+		end := settings.DigestVacationEnd.Format("2006-01-02")
+		view.VacationStart = &start
+		view.VacationEnd = &end
+	}
+	return view
+}
+
+func NormalizeDigestScheduleInput(in DigestScheduleInput) (NormalizedDigestSchedule, error) {
+	out := NormalizedDigestSchedule{SkipWeekdays: []int{}}
+	for _, d := range in.SkipWeekdays {
+		if d < 0 || d > 6 {
+			return out, &ValidationError{Field: "skip_weekdays", Message: "skip_weekdays must be between 0 (Sunday) and 6 (Saturday)"}
+		}
+		if !slices.Contains(out.SkipWeekdays, d) {
+			out.SkipWeekdays = append(out.SkipWeekdays, d)
+		}
+	}
+	if len(out.SkipWeekdays) == 7 {
+		return out, &ValidationError{Field: "skip_weekdays", Message: "at least one weekday must receive a digest"}
+	}
+	slices.Sort(out.SkipWeekdays)
+
+	start, err := parseOptionalJSTDate(in.VacationStart, "vacation_start")
+	if err != nil {
+		return out, err
+	}
+	end, err := parseOptionalJSTDate(in.VacationEnd, "vacation_end")
+	if err != nil {
+		return out, err
+	}
+	if (start == nil) != (end == nil) {
+		return out, &ValidationError{Field: "vacation_end", Message: "vacation_start and vacation_end must be set together"}
+	}
+	if start != nil {
+		if end.Before(*start) {
+			return out, &ValidationError{Field: "vacation_end", Message: "vacation_end must not be before vacation_start"}
+		}
+		if end.Sub(*start) >= maxDigestVacationDays*24*time.Hour {
+			return out, &ValidationError{Field: "vacation_end", Message: "vacation must be at most 60 days"}
+		}
+		out.VacationStart = start
+		out.VacationEnd = end
+	}
+	return out, nil
+}
+
+func parseOptionalJSTDate(v *string, field string) (*time.Time, error) {
+	if v == nil || *v == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", *v, timeutil.JST)
+	if err != nil {
+		return nil, &ValidationError{Field: field, Message: field + " must be YYYY-MM-DD"}
+	}
+	return &t, nil
+}
+
+// IsDigestQuietDay reports whether no daily digest should be sent on day (JST) because it is
+// a skipped weekday or falls inside the vacation range (inclusive).
+func IsDigestQuietDay(settings *model.UserSettings, day time.Time) bool {
+	if settings == nil {
+		return false
+	}
+	d := timeutil.StartOfDayJST(day)
+	if slices.Contains(settings.DigestSkipWeekdays, int(d.Weekday())) {
+		return true
+	}
+	if settings.DigestVacationStart != nil && settings.DigestVacationEnd != nil {
+		start := timeutil.StartOfDayJST(*settings.DigestVacationStart)
+		end := timeutil.StartOfDayJST(*settings.DigestVacationEnd)
+		if !d.Before(start) && !d.After(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// DigestWindowStart returns the start of the item window for the digest dated today. A regular
+// digest covers the previous day; when the preceding days were quiet, the window reaches back
+// over them (up to MaxDigestCatchUpDays) and catchUp is true.
+func DigestWindowStart(settings *model.UserSettings, today time.Time) (since time.Time, catchUp bool) {
+	today = timeutil.StartOfDayJST(today)
+	since = today.AddDate(0, 0, -1)
+	for i := 0; i < MaxDigestCatchUpDays && IsDigestQuietDay(settings, since); i++ {
+		since = since.AddDate(0, 0, -1)
+		catchUp = true
+	}
+	return since, catchUp
+}
+
+// CatchUpDigestComposeProfile tightens the user's profile for catch-up digests, which span
+// several days and would otherwise be much longer than a daily digest.
+func CatchUpDigestComposeProfile(profile model.DigestComposeProfile) model.DigestComposeProfile {
+	profile.Verbosity = "short"
+	profile.MaxClusters = max(5, profile.MaxClusters/2)
+	return profile
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func jstDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, timeutil.JST)
+}
+
+func TestDigestWindowStartAfterWeekend(t *testing.T) {
+	settings := &model.UserSettings{DigestSkipWeekdays: []int{int(time.Saturday), int(time.Sunday)}}
+
+	// 2026-04-06 is a Monday: the Saturday and Sunday digests were skipped.
+	monday := jstDate(2026, 4, 6)
+	if IsDigestQuietDay(settings, monday) {
+		t.Fatal("Monday should not be quiet")
+	}
+	since, catchUp := DigestWindowStart(settings, monday)
+	if !catchUp || !since.Equal(jstDate(2026, 4, 3)) {
+		t.Fatalf("DigestWindowStart(Monday) = (%v, %v), want Friday catch-up", since, catchUp)
+	}
+
+	since, catchUp = DigestWindowStart(settings, jstDate(2026, 4, 8))
+	if catchUp || !since.Equal(jstDate(2026, 4, 7)) {
+		t.Fatalf("DigestWindowStart(Wednesday) = (%v, %v), want previous day", since, catchUp)
+	}
+}
+
+func TestDigestWindowStartAfterVacation(t *testing.T) {
+	// Dates read from a DATE column come back as UTC midnight.
+	start := time.Date(2026, 8, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 8, 14, 0, 0, 0, 0, time.UTC)
+	settings := &model.UserSettings{DigestVacationStart: &start, DigestVacationEnd: &end}
+
+	if !IsDigestQuietDay(settings, jstDate(2026, 8, 14)) || IsDigestQuietDay(settings, jstDate(2026, 8, 15)) {
+		t.Fatal("vacation range should be inclusive of both ends only")
+	}
+	since, catchUp := DigestWindowStart(settings, jstDate(2026, 8, 15))
+	if !catchUp || !since.Equal(jstDate(2026, 8, 9)) {
+		t.Fatalf("DigestWindowStart() = (%v, %v), want 2026-08-09 catch-up", since, catchUp)
+	}
+}
+
+func TestNormalizeDigestScheduleInput(t *testing.T) {
+	start, end := "2026-08-10", "2026-08-14"
+	got, err := NormalizeDigestScheduleInput(DigestScheduleInput{SkipWeekdays: []int{6, 0, 6}, VacationStart: &start, VacationEnd: &end})
+	if err != nil {
+		t.Fatalf("NormalizeDigestScheduleInput() error = %v", err)
+	}
+	if len(got.SkipWeekdays) != 2 || got.SkipWeekdays[0] != 0 || got.SkipWeekdays[1] != 6 {
+		t.Fatalf("SkipWeekdays = %v, want [0 6]", got.SkipWeekdays)
+	}
+
+	cases := []DigestScheduleInput{
+		{SkipWeekdays: []int{7}},
+		{SkipWeekdays: []int{0, 1, 2, 3, 4, 5, 6}},
+		{VacationStart: &start},
+		{VacationStart: &end, VacationEnd: &start},
+	}
+	for _, in := range cases {
+		if _, err := NormalizeDigestScheduleInput(in); err == nil {
+			t.Fatalf("NormalizeDigestScheduleInput(%+v) should fail", in)
+		}
+	}
+}
+
+func TestFormatDigestEmailSubjectForCatchUpDigest(t *testing.T) {
+	windowStart := "2026-04-03"
+	digest := &model.DigestDetail{Digest: model.Digest{DigestDate: "2026-04-06", ComposeMode: DigestComposeModeCatchUp, WindowStartDate: &windowStart}}
+
+	got := FormatDigestEmailSubjectForDigest("ja", digest, "【2026年4月6日ダイジェスト】週末の話題")
+	if got != "【4月3日〜4月5日 おかえりなさいダイジェスト】週末の話題" {
+		t.Fatalf("subject = %q", got)
+	}
+	if again := FormatDigestEmailSubjectForDigest("ja", digest, got); again != got {
+		t.Fatalf("reformatting should be idempotent, got %q", again)
+	}
+	if en := FormatDigestEmailSubjectForDigest("en", digest, "Weekend"); !strings.HasPrefix(en, "[Sifto Digest Apr 3 – Apr 5, 2026: while you were away] ") {
+		t.Fatalf("en subject = %q", en)
+	}
+}
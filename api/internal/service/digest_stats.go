@@ -0,0 +1,30 @@
+package service
+
+import (
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	DefaultDigestStatsMonths = 6
+	MaxDigestStatsMonths     = 24
+)
+
+// ClampDigestStatsMonths keeps the stats window between one month and MaxDigestStatsMonths.
+func ClampDigestStatsMonths(months int) int {
+	if months <= 0 {
+		return DefaultDigestStatsMonths
+	}
+	if months > MaxDigestStatsMonths {
+		return MaxDigestStatsMonths
+	}
+	return months
+}
+
+// DigestStatsSince is the first day of the JST month months-1 months before now, so a window
+// of 1 covers the current month.
+func DigestStatsSince(now time.Time, months int) time.Time {
+	t := now.In(timeutil.JST)
+	return time.Date(t.Year(), t.Month()-time.Month(months-1), 1, 0, 0, 0, 0, timeutil.JST)
+}
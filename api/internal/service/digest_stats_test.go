@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestStatsSinceStartsAtJSTMonth(t *testing.T) {
+	// 2026-01-31 20:00 UTC is already February 1st in JST.
+	now := time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC)
+	if got := DigestStatsSince(now, 1).Format("2006-01-02"); got != "2026-02-01" {
+		t.Fatalf("1 month since = %s", got)
+	}
+	if got := DigestStatsSince(now, 3).Format("2006-01-02"); got != "2025-12-01" {
+		t.Fatalf("3 months since = %s", got)
+	}
+}
+
+func TestClampDigestStatsMonths(t *testing.T) {
+	for in, want := range map[int]int{0: DefaultDigestStatsMonths, -3: DefaultDigestStatsMonths, 1: 1, 12: 12, 99: MaxDigestStatsMonths} {
+		if got := ClampDigestStatsMonths(in); got != want {
+			t.Fatalf("ClampDigestStatsMonths(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
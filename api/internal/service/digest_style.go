@@ -0,0 +1,91 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	DefaultDigestVerbosity   = "standard"
+	DefaultDigestTone        = "neutral"
+	DefaultDigestMaxClusters = 20
+	MaxDigestMaxClusters     = 50
+
+	MaxDigestTopicPriorities     = 20
+	maxDigestTopicPriorityLength = 64
+)
+
+var (
+	SupportedDigestVerbosities = []string{"short", "standard", "deep_dive"}
+	SupportedDigestTones       = []string{"neutral", "analytical", "casual"}
+)
+
+func IsSupportedDigestVerbosity(v string) bool {
+	return slices.Contains(SupportedDigestVerbosities, v)
+}
+
+func IsSupportedDigestTone(v string) bool {
+	return slices.Contains(SupportedDigestTones, v)
+}
+
+func IsValidDigestMaxClusters(n int) bool {
+	return n >= 1 && n <= MaxDigestMaxClusters
+}
+
+// DigestComposeProfileFromSettings resolves the digest style, falling back to defaults for unset or unknown values.
+func DigestComposeProfileFromSettings(settings *model.UserSettings) model.DigestComposeProfile {
+	profile := model.DigestComposeProfile{
+		Verbosity:   DefaultDigestVerbosity,
+		Tone:        DefaultDigestTone,
+		MaxClusters: DefaultDigestMaxClusters,
+	}
+	if settings == nil {
+		return profile
+	}
+	if v := strings.TrimSpace(settings.DigestVerbosity); IsSupportedDigestVerbosity(v) {
+		profile.Verbosity = v
+	}
+	if v := strings.TrimSpace(settings.DigestTone); IsSupportedDigestTone(v) {
+		profile.Tone = v
+	}
+	if IsValidDigestMaxClusters(settings.DigestMaxClusters) {
+		profile.MaxClusters = settings.DigestMaxClusters
+	}
+	return profile
+}
+
+// NormalizeDigestTopicPriorities trims entries and drops blanks and case-insensitive duplicates,
+// keeping the first occurrence so the user's order is preserved.
+func NormalizeDigestTopicPriorities(topics []string) ([]string, error) {
+	out := make([]string, 0, len(topics))
+	seen := make(map[string]struct{}, len(topics))
+	for _, t := range topics {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if utf8.RuneCountInString(t) > maxDigestTopicPriorityLength {
+			return nil, &ValidationError{Field: "topics", Message: "topic is too long"}
+		}
+		key := strings.ToLower(t)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, t)
+	}
+	if len(out) > MaxDigestTopicPriorities {
+		return nil, &ValidationError{Field: "topics", Message: "too many topics"}
+	}
+	return out, nil
+}
+
+func DigestTopicPrioritiesFromSettings(settings *model.UserSettings) []string {
+	if settings == nil || settings.DigestTopicPriorities == nil {
+		return []string{}
+	}
+	return settings.DigestTopicPriorities
+}
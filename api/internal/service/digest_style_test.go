@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestDigestComposeProfileFromSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *model.UserSettings
+		want     model.DigestComposeProfile
+	}{
+		{
+			name:     "nil settings use defaults",
+			settings: nil,
+			want:     model.DigestComposeProfile{Verbosity: "standard", Tone: "neutral", MaxClusters: 20},
+		},
+		{
+			name:     "configured values are kept",
+			settings: &model.UserSettings{DigestVerbosity: "deep_dive", DigestTone: "analytical", DigestMaxClusters: 8},
+			want:     model.DigestComposeProfile{Verbosity: "deep_dive", Tone: "analytical", MaxClusters: 8},
+		},
+		{
+			name:     "unknown values fall back",
+			settings: &model.UserSettings{DigestVerbosity: "verbose", DigestTone: "angry", DigestMaxClusters: 0},
+			want:     model.DigestComposeProfile{Verbosity: "standard", Tone: "neutral", MaxClusters: 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DigestComposeProfileFromSettings(tt.settings); got != tt.want {
+				t.Fatalf("DigestComposeProfileFromSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDigestTopicPriorities(t *testing.T) {
+	got, err := NormalizeDigestTopicPriorities([]string{" Security ", "", "AI", "security"})
+	if err != nil {
+		t.Fatalf("NormalizeDigestTopicPriorities() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "Security" || got[1] != "AI" {
+		t.Fatalf("NormalizeDigestTopicPriorities() = %v, want [Security AI]", got)
+	}
+
+	tooMany := make([]string, 0, MaxDigestTopicPriorities+1)
+	for i := 0; i <= MaxDigestTopicPriorities; i++ {
+		tooMany = append(tooMany, string(rune('a'+i)))
+	}
+	if _, err := NormalizeDigestTopicPriorities(tooMany); err == nil {
+		t.Fatal("expected an error for too many topics")
+	}
+}
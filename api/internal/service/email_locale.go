@@ -0,0 +1,236 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const DefaultLocale = "ja"
+
+var SupportedLocales = []string{"ja", "en"}
+
+type emailStrings struct {
+	DigestSubjectPrefix         func(date time.Time) string
+	CatchUpSubjectPrefix        func(from, to time.Time) string
+	DigestGreeting              string
+	DigestCatchUpGreeting       string
+	DigestListenLabel           string
+	DigestFollowUpLabel         string
+	DigestFeedbackUpLabel       string
+	DigestFeedbackDownLabel     string
+	DigestFeedbackSaveLabel     string
+	DigestPreferencesLabel      string
+	DigestUnsubscribeLabel      string
+	UntitledItem                string
+	BudgetAlertSubject          string
+	BudgetAlertHeading          string
+	BudgetAlertLead             string
+	BudgetAlertMonthly          string
+	BudgetAlertUsed             string
+	BudgetAlertRemaining        string
+	BudgetAlertRemainingPct     string
+	BudgetAlertFooter           string
+	ForecastSubject             string
+	ForecastHeading             string
+	ForecastLead                string
+	ForecastMonthly             string
+	ForecastUsed                string
+	ForecastProjected           string
+	ForecastDelta               string
+	ForecastExceedDate          string
+	ForecastFooter              string
+	TopicAlertSubject           string
+	TopicAlertHeading           string
+	TopicAlertLead              string
+	TopicAlertFooter            string
+	RecipientConfirmSubject     string
+	RecipientConfirmHeading     string
+	RecipientConfirmLead        string
+	RecipientConfirmButton      string
+	RecipientConfirmFooter      string
+	RecipientConfirmDailyDigest string
+	ApprovalSubject             string
+	ApprovalHeading             string
+	ApprovalLead                string
+	ApprovalButton              string
+	ApprovalAutoNote            string
+	ApprovalManualNote          string
+	WeeklyRecapSubject          string
+	WeeklyRecapHeading          string
+	WeeklyRecapLeadHeavy        string
+	WeeklyRecapLeadSteady       string
+	WeeklyRecapLeadLight        string
+	WeeklyRecapRead             string
+	WeeklyRecapTopics           string
+	WeeklyRecapStreak           string
+	WeeklyRecapStreakDays       string
+	WeeklyRecapSource           string
+	WeeklyRecapSourceCount      string
+	WeeklyRecapSpend            string
+	WeeklyRecapBudget           string
+	WeeklyRecapFooter           string
+}
+
+var emailStringsByLocale = map[string]emailStrings{
+	"ja": {
+		DigestSubjectPrefix: func(date time.Time) string {
+			return fmt.Sprintf("【%d年%d月%d日ダイジェスト】", date.Year(), date.Month(), date.Day())
+		},
+		CatchUpSubjectPrefix: func(from, to time.Time) string {
+			return fmt.Sprintf("【%d月%d日〜%d月%d日 おかえりなさいダイジェスト】", from.Month(), from.Day(), to.Month(), to.Day())
+		},
+		DigestGreeting:              "本日のダイジェストをお届けします。",
+		DigestCatchUpGreeting:       "おかえりなさい。お休み中の話題をまとめてお届けします。",
+		DigestListenLabel:           "音声で聴く",
+		DigestFollowUpLabel:         "%s のダイジェストで取り上げた話題の続報",
+		DigestFeedbackUpLabel:       "👍 役に立った",
+		DigestFeedbackDownLabel:     "👎 興味なし",
+		DigestFeedbackSaveLabel:     "🔖 あとで読む",
+		DigestPreferencesLabel:      "配信頻度を変更",
+		DigestUnsubscribeLabel:      "配信停止",
+		UntitledItem:                "（タイトルなし）",
+		BudgetAlertSubject:          "Sifto: 月次LLM予算の残りが%d%%を下回りました",
+		BudgetAlertHeading:          "Sifto 予算アラート",
+		BudgetAlertLead:             "%s の月次LLM予算の残りが <strong>%d%%</strong> を下回りました。",
+		BudgetAlertMonthly:          "月次予算",
+		BudgetAlertUsed:             "利用額（推定）",
+		BudgetAlertRemaining:        "残額（推定）",
+		BudgetAlertRemainingPct:     "残り比率",
+		BudgetAlertFooter:           "設定画面で予算・警告しきい値・Anthropic APIキー（ユーザー別）を管理できます。",
+		ForecastSubject:             "Sifto: 月次LLM予算の着地予測が予算を超えそうです",
+		ForecastHeading:             "Sifto 予算着地アラート",
+		ForecastLead:                "%s の月末着地予測が、設定予算を上回っています。",
+		ForecastMonthly:             "月次予算:",
+		ForecastUsed:                "今月使用額:",
+		ForecastProjected:           "月末着地予測:",
+		ForecastDelta:               "予算差分:",
+		ForecastExceedDate:          "予算超過の見込み日:",
+		ForecastFooter:              "LLM Usage 画面で直近の利用状況と予測ペースを確認してください。",
+		TopicAlertSubject:           "Sifto: 「%s」の記事が急増しています",
+		TopicAlertHeading:           "トピック急上昇アラート",
+		TopicAlertLead:              "「%s」の記事が直近24時間で %d 件（前日比 +%d 件）になりました。",
+		TopicAlertFooter:            "アラートの対象トピックやしきい値は設定画面で変更できます。",
+		RecipientConfirmSubject:     "Sifto: %s さんからダイジェストの共有依頼が届いています",
+		RecipientConfirmHeading:     "ダイジェスト受信の確認",
+		RecipientConfirmLead:        "%s さんが「%s」の配信先にこのアドレスを追加しました。受け取る場合は下のボタンから確認してください。",
+		RecipientConfirmButton:      "受信を承認する",
+		RecipientConfirmFooter:      "心当たりがない場合はこのメールを無視してください。確認されるまでダイジェストは送信されません。",
+		RecipientConfirmDailyDigest: "デイリーダイジェスト",
+		ApprovalSubject:             "Sifto: %s のダイジェストが承認待ちです",
+		ApprovalHeading:             "ダイジェストの送信前確認",
+		ApprovalLead:                "%s のダイジェスト「%s」の作成が完了しました。プレビューで内容を確認し、必要に応じて編集してから承認してください。",
+		ApprovalButton:              "プレビューを開く",
+		ApprovalAutoNote:            "承認されない場合も %s（JST）に自動で送信されます。",
+		ApprovalManualNote:          "承認するまでダイジェストは送信されません。",
+		WeeklyRecapSubject:          "Sifto: 今週のふりかえり（%s〜%s）",
+		WeeklyRecapHeading:          "今週のふりかえり",
+		WeeklyRecapLeadHeavy:        "今週は %d 件も読みました。もはや情報の大食いチャンピオンです。",
+		WeeklyRecapLeadSteady:       "今週は %d 件を読みました。いいペースです、この調子でいきましょう。",
+		WeeklyRecapLeadLight:        "今週は %d 件を読みました。少なめでも、読んだ分はちゃんと身についています。",
+		WeeklyRecapRead:             "読んだ記事",
+		WeeklyRecapTopics:           "よく読んだトピック",
+		WeeklyRecapStreak:           "最長ストリーク",
+		WeeklyRecapStreakDays:       "%d 日",
+		WeeklyRecapSource:           "一番読んだソース",
+		WeeklyRecapSourceCount:      "%s（%d 件）",
+		WeeklyRecapSpend:            "今週の LLM 利用額（推定）",
+		WeeklyRecapBudget:           "今月の利用額 / 月次予算",
+		WeeklyRecapFooter:           "ふりかえりメールは設定画面で停止できます。",
+	},
+	"en": {
+		DigestSubjectPrefix: func(date time.Time) string {
+			return fmt.Sprintf("[Sifto Digest %s] ", date.Format("Jan 2, 2006"))
+		},
+		CatchUpSubjectPrefix: func(from, to time.Time) string {
+			return fmt.Sprintf("[Sifto Digest %s – %s: while you were away] ", from.Format("Jan 2"), to.Format("Jan 2, 2006"))
+		},
+		DigestGreeting:              "Here is your digest for today.",
+		DigestCatchUpGreeting:       "Welcome back. Here is what happened while you were away.",
+		DigestListenLabel:           "Listen to this digest",
+		DigestFollowUpLabel:         "Follow-up to a story from %s",
+		DigestFeedbackUpLabel:       "👍 Useful",
+		DigestFeedbackDownLabel:     "👎 Not for me",
+		DigestFeedbackSaveLabel:     "🔖 Read later",
+		DigestPreferencesLabel:      "Email preferences",
+		DigestUnsubscribeLabel:      "Unsubscribe",
+		UntitledItem:                "(Untitled)",
+		BudgetAlertSubject:          "Sifto: less than %d%% of your monthly LLM budget remains",
+		BudgetAlertHeading:          "Sifto budget alert",
+		BudgetAlertLead:             "Less than <strong>%[2]d%%</strong> of your monthly LLM budget for %[1]s remains.",
+		BudgetAlertMonthly:          "Monthly budget",
+		BudgetAlertUsed:             "Used (estimated)",
+		BudgetAlertRemaining:        "Remaining (estimated)",
+		BudgetAlertRemainingPct:     "Remaining ratio",
+		BudgetAlertFooter:           "You can manage your budget, alert threshold, and per-user Anthropic API key in Settings.",
+		ForecastSubject:             "Sifto: your monthly LLM spend is forecast to exceed the budget",
+		ForecastHeading:             "Sifto budget forecast alert",
+		ForecastLead:                "The month-end forecast for %s is above your budget.",
+		ForecastMonthly:             "Monthly budget:",
+		ForecastUsed:                "Spent this month:",
+		ForecastProjected:           "Month-end forecast:",
+		ForecastDelta:               "Over budget by:",
+		ForecastExceedDate:          "Projected to exceed budget around:",
+		ForecastFooter:              "Check recent usage and the forecast pace on the LLM Usage page.",
+		TopicAlertSubject:           "Sifto: \"%s\" is spiking",
+		TopicAlertHeading:           "Trending topic alert",
+		TopicAlertLead:              "\"%s\" had %d items in the last 24 hours (+%d versus the day before).",
+		TopicAlertFooter:            "You can change followed topics and thresholds in Settings.",
+		RecipientConfirmSubject:     "Sifto: %s wants to share a digest with you",
+		RecipientConfirmHeading:     "Confirm digest subscription",
+		RecipientConfirmLead:        "%s added this address as a recipient of \"%s\". Confirm below if you want to receive it.",
+		RecipientConfirmButton:      "Confirm subscription",
+		RecipientConfirmFooter:      "If you did not expect this, ignore this email. No digest is sent until the address is confirmed.",
+		RecipientConfirmDailyDigest: "Daily digest",
+		ApprovalSubject:             "Sifto: Your digest for %s is awaiting approval",
+		ApprovalHeading:             "Review your digest before it is sent",
+		ApprovalLead:                "Your digest for %s, \"%s\", is ready. Open the preview to review or edit it, then approve it to send.",
+		ApprovalButton:              "Open preview",
+		ApprovalAutoNote:            "If you do not approve it, it is sent automatically at %s (JST).",
+		ApprovalManualNote:          "The digest is not sent until you approve it.",
+		WeeklyRecapSubject:          "Sifto: your week in reading (%s – %s)",
+		WeeklyRecapHeading:          "Your week in reading",
+		WeeklyRecapLeadHeavy:        "You read %d items this week. Someone get this reader a bigger inbox.",
+		WeeklyRecapLeadSteady:       "You read %d items this week. A nice, steady pace. Keep it going.",
+		WeeklyRecapLeadLight:        "You read %d items this week. Quality over quantity, right?",
+		WeeklyRecapRead:             "Items read",
+		WeeklyRecapTopics:           "Top topics",
+		WeeklyRecapStreak:           "Best streak",
+		WeeklyRecapStreakDays:       "%d days",
+		WeeklyRecapSource:           "Most-read source",
+		WeeklyRecapSourceCount:      "%s (%d items)",
+		WeeklyRecapSpend:            "LLM spend this week (estimated)",
+		WeeklyRecapBudget:           "Spent this month / monthly budget",
+		WeeklyRecapFooter:           "You can turn off the weekly recap in Settings.",
+	},
+}
+
+func NormalizeLocale(locale string) string {
+	v := strings.ToLower(strings.TrimSpace(locale))
+	if _, ok := emailStringsByLocale[v]; ok {
+		return v
+	}
+	return DefaultLocale
+}
+
+func IsSupportedLocale(locale string) bool {
+	_, ok := emailStringsByLocale[strings.ToLower(strings.TrimSpace(locale))]
+	return ok
+}
+
+func emailStringsFor(locale string) emailStrings {
+	return emailStringsByLocale[NormalizeLocale(locale)]
+}
+
+// DigestTargetLanguage resolves the language passed to the digest composer.
+// An explicit output language wins; otherwise non-default locales map to their own language.
+func DigestTargetLanguage(outputLanguage *string, locale string) *string {
+	if v := NormalizeOutputLanguage(outputLanguage); v != nil {
+		return v
+	}
+	normalized := NormalizeLocale(locale)
+	if normalized == DefaultLocale {
+		return nil
+	}
+	return &normalized
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatDigestEmailSubjectForLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		subject string
+		want    string
+	}{
+		{name: "japanese default", locale: "ja", subject: "AIの話題", want: "【2026年3月5日ダイジェスト】AIの話題"},
+		{name: "english", locale: "en", subject: "AI news", want: "[Sifto Digest Mar 5, 2026] AI news"},
+		{name: "english strips japanese prefix", locale: "en", subject: "【2026年3月5日ダイジェスト】AI news", want: "[Sifto Digest Mar 5, 2026] AI news"},
+		{name: "japanese strips english prefix", locale: "ja", subject: "[Sifto Digest Mar 5, 2026] AIの話題", want: "【2026年3月5日ダイジェスト】AIの話題"},
+		{name: "english empty subject", locale: "en", subject: "", want: "[Sifto Digest Mar 5, 2026]"},
+		{name: "unknown locale falls back", locale: "fr", subject: "x", want: "【2026年3月5日ダイジェスト】x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDigestEmailSubjectForLocale(tt.locale, "2026-03-05", tt.subject); got != tt.want {
+				t.Fatalf("FormatDigestEmailSubjectForLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildBudgetAlertHTMLUsesLocale(t *testing.T) {
+	en := buildBudgetAlertHTML(BudgetAlertEmail{Locale: "en", MonthJST: "2026-03", ThresholdPct: 20})
+	if !strings.Contains(en, "Less than <strong>20%</strong> of your monthly LLM budget for 2026-03 remains.") {
+		t.Fatalf("english budget alert missing lead: %s", en)
+	}
+	ja := buildBudgetAlertHTML(BudgetAlertEmail{MonthJST: "2026-03", ThresholdPct: 20})
+	if !strings.Contains(ja, "2026-03 の月次LLM予算の残りが <strong>20%</strong> を下回りました。") {
+		t.Fatalf("japanese budget alert missing lead: %s", ja)
+	}
+}
+
+func TestDigestTargetLanguage(t *testing.T) {
+	if got := DigestTargetLanguage(nil, "ja"); got != nil {
+		t.Fatalf("DigestTargetLanguage(nil, ja) = %q, want nil", *got)
+	}
+	if got := DigestTargetLanguage(nil, "en"); got == nil || *got != "en" {
+		t.Fatalf("DigestTargetLanguage(nil, en) = %v, want en", got)
+	}
+	explicit := "ko"
+	if got := DigestTargetLanguage(&explicit, "en"); got == nil || *got != "ko" {
+		t.Fatalf("DigestTargetLanguage(ko, en) = %v, want ko", got)
+	}
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	EmailTransportResend   = "resend"
+	EmailTransportSMTP     = "smtp"
+	EmailTransportUserSMTP = "user_smtp"
+
+	defaultSMTPPort = 587
+)
+
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	// Headers are extra message headers such as List-Unsubscribe.
+	Headers map[string]string
+}
+
+// EmailSender is a transport that can deliver a rendered HTML email.
+type EmailSender interface {
+	Enabled() bool
+	Transport() string
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+func SendDigestEmail(ctx context.Context, sender EmailSender, to, locale string, digest *model.DigestDetail, copy *DigestEmailCopy) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip digest send to %s", to)
+		return nil
+	}
+	subject := FormatDigestEmailSubjectForDigest(locale, digest, "")
+	if copy != nil && strings.TrimSpace(copy.Subject) != "" {
+		subject = FormatDigestEmailSubjectForDigest(locale, digest, copy.Subject)
+	}
+	return sender.Send(ctx, EmailMessage{To: to, Subject: subject, HTML: buildDigestHTML(locale, digest, copy), Headers: digestEmailHeaders(copy)})
+}
+
+// digestEmailHeaders advertises RFC 8058 one-click unsubscribe so mail clients can show their
+// native unsubscribe button.
+func digestEmailHeaders(copy *DigestEmailCopy) map[string]string {
+	if copy == nil || strings.TrimSpace(copy.UnsubscribeURL) == "" {
+		return nil
+	}
+	return map[string]string{
+		"List-Unsubscribe":      "<" + strings.TrimSpace(copy.UnsubscribeURL) + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+func SendBudgetAlertEmail(ctx context.Context, sender EmailSender, to string, alert BudgetAlertEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip budget alert to %s", to)
+		return nil
+	}
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf(emailStringsFor(alert.Locale).BudgetAlertSubject, alert.ThresholdPct),
+		HTML:    buildBudgetAlertHTML(alert),
+	})
+}
+
+func SendBudgetForecastAlertEmail(ctx context.Context, sender EmailSender, to string, alert BudgetForecastAlertEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip budget forecast alert to %s", to)
+		return nil
+	}
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: emailStringsFor(alert.Locale).ForecastSubject,
+		HTML:    buildBudgetForecastAlertHTML(alert),
+	})
+}
+
+// NewDefaultEmailSender picks the server-wide transport. EMAIL_TRANSPORT forces one;
+// otherwise Resend is used when configured and SMTP env settings are the fallback.
+func NewDefaultEmailSender(resend *ResendClient) EmailSender {
+	smtpSender := NewSMTPSenderFromEnv()
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("EMAIL_TRANSPORT"))) {
+	case EmailTransportSMTP:
+		return smtpSender
+	case EmailTransportResend:
+		return resend
+	}
+	if !resend.Enabled() && smtpSender.Enabled() {
+		return smtpSender
+	}
+	return resend
+}
+
+func formatEmailFrom(addr, name string) string {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return ""
+	}
+	if strings.Contains(addr, "<") && strings.Contains(addr, ">") {
+		return addr
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Sifto"
+	}
+	return fmt.Sprintf("%s <%s>", name, addr)
+}
+
+type SMTPConfig struct {
+	Host      string
+	Port      int
+	Username  string
+	Password  string
+	FromEmail string
+	FromName  string
+}
+
+type SMTPSender struct {
+	cfg       SMTPConfig
+	transport string
+	timeout   time.Duration
+	// publicOnly restricts the server to public addresses. It is set for servers users
+	// configure, so their settings cannot reach hosts on the internal network.
+	publicOnly bool
+}
+
+func NewSMTPSender(cfg SMTPConfig, transport string) *SMTPSender {
+	if cfg.Port <= 0 {
+		cfg.Port = defaultSMTPPort
+	}
+	return &SMTPSender{cfg: cfg, transport: transport, timeout: 20 * time.Second}
+}
+
+func NewSMTPSenderFromEnv() *SMTPSender {
+	port, _ := strconv.Atoi(strings.TrimSpace(os.Getenv("SMTP_PORT")))
+	return NewSMTPSender(SMTPConfig{
+		Host:      strings.TrimSpace(os.Getenv("SMTP_HOST")),
+		Port:      port,
+		Username:  os.Getenv("SMTP_USERNAME"),
+		Password:  os.Getenv("SMTP_PASSWORD"),
+		FromEmail: strings.TrimSpace(os.Getenv("SMTP_FROM_EMAIL")),
+		FromName:  os.Getenv("SMTP_FROM_NAME"),
+	}, EmailTransportSMTP)
+}
+
+func (s *SMTPSender) Enabled() bool {
+	return s != nil && s.cfg.Host != "" && s.cfg.FromEmail != ""
+}
+
+func (s *SMTPSender) Transport() string {
+	if s == nil || s.transport == "" {
+		return EmailTransportSMTP
+	}
+	return s.transport
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg EmailMessage) error {
+	if !s.Enabled() {
+		return fmt.Errorf("smtp: not configured")
+	}
+	from, err := mail.ParseAddress(formatEmailFrom(s.cfg.FromEmail, s.cfg.FromName))
+	if err != nil {
+		return fmt.Errorf("smtp: invalid from address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("smtp: invalid recipient: %w", err)
+	}
+	body, err := buildSMTPMessage(from, to, msg.Subject, msg.HTML, msg.Headers, time.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if s.publicOnly {
+		ips, err := ResolvePublicHost(ctx, s.cfg.Host)
+		if err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+		addr = net.JoinHostPort(ips[0].String(), strconv.Itoa(s.cfg.Port))
+	}
+	dialer := &net.Dialer{Timeout: s.timeout}
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+	var conn net.Conn
+	if s.cfg.Port == 465 {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("smtp: dial: %w", err)
+	}
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: handshake: %w", err)
+	}
+	defer c.Close()
+	if s.cfg.Port != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("smtp: starttls: %w", err)
+			}
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp: auth: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp: mail from: %w", err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("smtp: rcpt to: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return fmt.Errorf("smtp: write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: data close: %w", err)
+	}
+	return c.Quit()
+}
+
+func buildSMTPMessage(from, to *mail.Address, subject, htmlBody string, headers map[string]string, now time.Time) ([]byte, error) {
+	var id [12]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	domain := "sifto.local"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 && at < len(from.Address)-1 {
+		domain = from.Address[at+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + from.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Message-ID: <" + hex.EncodeToString(id[:]) + "@" + domain + ">\r\n")
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.ContainsAny(name, "\r\n: ") || strings.ContainsAny(headers[name], "\r\n") {
+			return nil, fmt.Errorf("smtp: invalid header %q", name)
+		}
+		b.WriteString(name + ": " + headers[name] + "\r\n")
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+	encoded := base64.StdEncoding.EncodeToString([]byte(htmlBody))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	if encoded != "" {
+		b.WriteString(encoded + "\r\n")
+	}
+	return []byte(b.String()), nil
+}
+
+// EmailSenderResolver chooses the transport for a user's mail: their own SMTP server when
+// configured, otherwise the server-wide default.
+type EmailSenderResolver struct {
+	fallback EmailSender
+	repo     *repository.UserSettingsRepo
+	cipher   *SecretCipher
+}
+
+func NewEmailSenderResolver(fallback EmailSender, repo *repository.UserSettingsRepo, cipher *SecretCipher) *EmailSenderResolver {
+	return &EmailSenderResolver{fallback: fallback, repo: repo, cipher: cipher}
+}
+
+func (r *EmailSenderResolver) Default() EmailSender {
+	if r == nil {
+		return nil
+	}
+	return r.fallback
+}
+
+func (r *EmailSenderResolver) ForUser(ctx context.Context, userID string) EmailSender {
+	if r == nil {
+		return nil
+	}
+	if r.repo == nil || strings.TrimSpace(userID) == "" {
+		return r.fallback
+	}
+	stored, err := r.repo.GetSMTPSettings(ctx, userID)
+	if err != nil {
+		log.Printf("email sender resolve user smtp failed user_id=%s err=%v", userID, err)
+		return r.fallback
+	}
+	sender, err := userSMTPSender(stored, r.cipher)
+	if err != nil {
+		log.Printf("email sender decrypt user smtp failed user_id=%s err=%v", userID, err)
+		return r.fallback
+	}
+	if sender == nil {
+		return r.fallback
+	}
+	return sender
+}
+
+func userSMTPSender(stored *repository.UserSMTPSettings, cipher *SecretCipher) (*SMTPSender, error) {
+	if stored == nil {
+		return nil, nil
+	}
+	cfg := SMTPConfig{
+		Host:      stored.Host,
+		Port:      stored.Port,
+		FromEmail: stored.FromEmail,
+	}
+	if stored.Username != nil {
+		cfg.Username = *stored.Username
+	}
+	if stored.FromName != nil {
+		cfg.FromName = *stored.FromName
+	}
+	if stored.PasswordEnc != nil && *stored.PasswordEnc != "" {
+		if cipher == nil || !cipher.Enabled() {
+			return nil, ErrSecretEncryptionNotConfigured
+		}
+		password, err := cipher.DecryptString(*stored.PasswordEnc)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Password = password
+	}
+	sender := NewSMTPSender(cfg, EmailTransportUserSMTP)
+	sender.publicOnly = true
+	return sender, nil
+}
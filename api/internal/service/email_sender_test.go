@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestBuildSMTPMessage(t *testing.T) {
+	from := &mail.Address{Name: "Sifto", Address: "digest@example.com"}
+	to := &mail.Address{Address: "reader@example.org"}
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	raw, err := buildSMTPMessage(from, to, "【2026年4月1日ダイジェスト】今日の話題", "<p>hello</p>", map[string]string{"List-Unsubscribe": "<https://sifto.example/u>"}, now)
+	if err != nil {
+		t.Fatalf("buildSMTPMessage() error = %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if got := msg.Header.Get("From"); got != `"Sifto" <digest@example.com>` {
+		t.Fatalf("From = %q", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "【2026年4月1日ダイジェスト】今日の話題" {
+		t.Fatalf("Subject = (%q, %v)", subject, err)
+	}
+	if got := msg.Header.Get("List-Unsubscribe"); got != "<https://sifto.example/u>" {
+		t.Fatalf("List-Unsubscribe = %q", got)
+	}
+	if _, err := buildSMTPMessage(from, to, "s", "b", map[string]string{"X-Bad": "a\r\nBcc: x@example.com"}, now); err == nil {
+		t.Fatal("header with CRLF should be rejected")
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Fatalf("Message-ID = %q, want sender domain", msg.Header.Get("Message-ID"))
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(body), "\r\n", ""))
+	if err != nil || string(decoded) != "<p>hello</p>" {
+		t.Fatalf("body = (%q, %v)", decoded, err)
+	}
+}
+
+func TestNewDefaultEmailSender(t *testing.T) {
+	resend := &ResendClient{apiKey: "re_test", from: "digest@example.com"}
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_FROM_EMAIL", "digest@example.com")
+	t.Setenv("EMAIL_TRANSPORT", "")
+	if got := NewDefaultEmailSender(resend).Transport(); got != EmailTransportResend {
+		t.Fatalf("configured resend: transport = %q, want resend", got)
+	}
+	if got := NewDefaultEmailSender(&ResendClient{}).Transport(); got != EmailTransportSMTP {
+		t.Fatalf("resend disabled: transport = %q, want smtp fallback", got)
+	}
+
+	t.Setenv("EMAIL_TRANSPORT", "smtp")
+	sender := NewDefaultEmailSender(resend)
+	if sender.Transport() != EmailTransportSMTP || !sender.Enabled() {
+		t.Fatalf("EMAIL_TRANSPORT=smtp: transport = %q enabled = %v", sender.Transport(), sender.Enabled())
+	}
+}
+
+func TestUserSMTPSender(t *testing.T) {
+	cipher, err := newSecretCipherFromConfig("k1:test-secret", "")
+	if err != nil {
+		t.Fatalf("newSecretCipherFromConfig() error = %v", err)
+	}
+	enc, err := cipher.EncryptString("app-password")
+	if err != nil {
+		t.Fatalf("EncryptString() error = %v", err)
+	}
+	username := "reader"
+	sender, err := userSMTPSender(&repository.UserSMTPSettings{
+		Host:        "smtp.example.org",
+		Username:    &username,
+		PasswordEnc: &enc,
+		FromEmail:   "reader@example.org",
+	}, cipher)
+	if err != nil {
+		t.Fatalf("userSMTPSender() error = %v", err)
+	}
+	if sender.Transport() != EmailTransportUserSMTP || sender.cfg.Port != defaultSMTPPort || sender.cfg.Password != "app-password" {
+		t.Fatalf("userSMTPSender() = %+v", sender.cfg)
+	}
+
+	if _, err := userSMTPSender(&repository.UserSMTPSettings{Host: "h", FromEmail: "a@b.c", PasswordEnc: &enc}, nil); err != ErrSecretEncryptionNotConfigured {
+		t.Fatalf("userSMTPSender(no cipher) error = %v, want ErrSecretEncryptionNotConfigured", err)
+	}
+	if sender, err := userSMTPSender(nil, cipher); sender != nil || err != nil {
+		t.Fatalf("userSMTPSender(nil) = (%v, %v), want nil", sender, err)
+	}
+}
+
+func TestNormalizeUserSMTPSettingsInput(t *testing.T) {
+	out, err := normalizeUserSMTPSettingsInput(UserSMTPSettingsInput{Host: " smtp.example.org ", FromEmail: "me@example.org"})
+	if err != nil {
+		t.Fatalf("normalize() error = %v", err)
+	}
+	if out.Host != "smtp.example.org" || out.Port != defaultSMTPPort {
+		t.Fatalf("normalize() = %+v", out)
+	}
+
+	cases := map[string]UserSMTPSettingsInput{
+		"host":       {FromEmail: "me@example.org"},
+		"port":       {Host: "smtp.example.org", Port: 70000, FromEmail: "me@example.org"},
+		"from_email": {Host: "smtp.example.org", FromEmail: "Me <me@example.org>"},
+	}
+	for field, in := range cases {
+		_, err := normalizeUserSMTPSettingsInput(in)
+		ve, ok := err.(*ValidationError)
+		if !ok || ve.Field != field {
+			t.Fatalf("normalize(%s) error = %v, want validation error on %s", field, err, field)
+		}
+	}
+	if _, err := normalizeUserSMTPSettingsInput(UserSMTPSettingsInput{Host: "smtp://x", FromEmail: "me@example.org"}); err == nil {
+		t.Fatal("normalize() should reject a host with a scheme")
+	}
+}
+
+func TestUserSMTPSettingsSetRejectsNonPublicHosts(t *testing.T) {
+	svc := NewUserSMTPSettingsService(nil, nil)
+	for _, host := range []string{"127.0.0.1", "10.1.2.3", "169.254.169.254", "100.100.100.200"} {
+		_, err := svc.Set(context.Background(), "u-1", UserSMTPSettingsInput{Host: host, FromEmail: "me@example.org"})
+		ve, ok := err.(*ValidationError)
+		if !ok || ve.Field != "host" {
+			t.Errorf("Set(host=%s) error = %v, want validation error on host", host, err)
+		}
+	}
+}
+
+func TestUserSMTPSenderRefusesNonPublicHostWhenSending(t *testing.T) {
+	sender, err := userSMTPSender(&repository.UserSMTPSettings{Host: "127.0.0.1", Port: 25, FromEmail: "me@example.org"}, nil)
+	if err != nil {
+		t.Fatalf("userSMTPSender() error = %v", err)
+	}
+	err = sender.Send(context.Background(), EmailMessage{To: "you@example.org", Subject: "s", HTML: "<p>x</p>"})
+	if err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Fatalf("Send() error = %v, want non-public host refusal", err)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+)
+
+// MaxEmbeddingReindexItems caps the item/embed events one reindex request queues; a larger
+// library is caught up by requesting again once these are done.
+const MaxEmbeddingReindexItems = 5000
+
+type embeddingReindexItemRepo interface {
+	EmbeddingCoverage(ctx context.Context, userID, model string) (repository.EmbeddingCoverage, error)
+	ListStaleEmbeddingTargets(ctx context.Context, userID, model string, limit int) ([]repository.ItemEmbeddingBackfillTarget, error)
+}
+
+type embeddingReindexSettingsRepo interface {
+	EnsureDefaults(ctx context.Context, userID string) (*model.UserSettings, error)
+}
+
+type embeddingReindexPublisher interface {
+	SendEventsE(ctx context.Context, events []inngestgo.Event) error
+}
+
+// EmbeddingStatusView tells the user how many summarized items were embedded with a model other
+// than the current one and so drop out of related items and clustering.
+type EmbeddingStatusView struct {
+	Model      string `json:"model"`
+	Summarized int    `json:"summarized"`
+	Current    int    `json:"current"`
+	Stale      int    `json:"stale"`
+	Missing    int    `json:"missing"`
+}
+
+type EmbeddingReindexResult struct {
+	Model     string `json:"model"`
+	Queued    int    `json:"queued"`
+	Remaining int    `json:"remaining"`
+}
+
+// EmbeddingReindexService re-embeds items after the user switches embedding models, through
+// the same item/embed events the embedding backfill sends.
+type EmbeddingReindexService struct {
+	items     embeddingReindexItemRepo
+	settings  embeddingReindexSettingsRepo
+	publisher embeddingReindexPublisher
+}
+
+func NewEmbeddingReindexService(items embeddingReindexItemRepo, settings embeddingReindexSettingsRepo, publisher embeddingReindexPublisher) *EmbeddingReindexService {
+	return &EmbeddingReindexService{items: items, settings: settings, publisher: publisher}
+}
+
+func (s *EmbeddingReindexService) Status(ctx context.Context, userID string) (*EmbeddingStatusView, error) {
+	embModel, err := s.userModel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.items.EmbeddingCoverage(ctx, userID, embModel)
+	if err != nil {
+		return nil, err
+	}
+	return &EmbeddingStatusView{Model: embModel, Summarized: c.Summarized, Current: c.Current, Stale: c.Stale, Missing: c.Missing}, nil
+}
+
+// Reindex queues an item/embed event for every summarized item whose embedding is stale or
+// missing, up to MaxEmbeddingReindexItems.
+func (s *EmbeddingReindexService) Reindex(ctx context.Context, userID string) (*EmbeddingReindexResult, error) {
+	embModel, err := s.userModel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.items.EmbeddingCoverage(ctx, userID, embModel)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := s.items.ListStaleEmbeddingTargets(ctx, userID, embModel, MaxEmbeddingReindexItems)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]inngestgo.Event, 0, len(targets))
+	for _, t := range targets {
+		events = append(events, NewItemEmbedEvent(t.ItemID, t.SourceID))
+	}
+	if err := s.publisher.SendEventsE(ctx, events); err != nil {
+		return nil, err
+	}
+	return &EmbeddingReindexResult{
+		Model:     embModel,
+		Queued:    len(events),
+		Remaining: max(c.Stale+c.Missing-len(events), 0),
+	}, nil
+}
+
+func (s *EmbeddingReindexService) userModel(ctx context.Context, userID string) (string, error) {
+	settings, err := s.settings.EnsureDefaults(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return UserEmbeddingModel(settings), nil
+}
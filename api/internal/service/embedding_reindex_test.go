@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+)
+
+type fakeEmbeddingReindexItems struct {
+	coverage  repository.EmbeddingCoverage
+	targets   []repository.ItemEmbeddingBackfillTarget
+	lastModel string
+}
+
+func (f *fakeEmbeddingReindexItems) EmbeddingCoverage(_ context.Context, _, model string) (repository.EmbeddingCoverage, error) {
+	f.lastModel = model
+	return f.coverage, nil
+}
+
+func (f *fakeEmbeddingReindexItems) ListStaleEmbeddingTargets(_ context.Context, _, model string, limit int) ([]repository.ItemEmbeddingBackfillTarget, error) {
+	f.lastModel = model
+	if len(f.targets) > limit {
+		return f.targets[:limit], nil
+	}
+	return f.targets, nil
+}
+
+type fakeEmbeddingReindexSettings struct{ settings *model.UserSettings }
+
+func (f fakeEmbeddingReindexSettings) EnsureDefaults(context.Context, string) (*model.UserSettings, error) {
+	return f.settings, nil
+}
+
+type fakeEventSender struct{ events []inngestgo.Event }
+
+func (f *fakeEventSender) SendEventsE(_ context.Context, events []inngestgo.Event) error {
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func TestEmbeddingReindexQueuesStaleItemsForUserModel(t *testing.T) {
+	embModel := "text-embedding-3-large"
+	items := &fakeEmbeddingReindexItems{
+		coverage: repository.EmbeddingCoverage{Summarized: 5, Current: 2, Stale: 2, Missing: 1},
+		targets: []repository.ItemEmbeddingBackfillTarget{
+			{ItemID: "i-1", SourceID: "s-1"},
+			{ItemID: "i-2", SourceID: "s-1"},
+			{ItemID: "i-3", SourceID: "s-2"},
+		},
+	}
+	events := &fakeEventSender{}
+	svc := NewEmbeddingReindexService(items, fakeEmbeddingReindexSettings{&model.UserSettings{EmbeddingModel: &embModel}}, events)
+
+	res, err := svc.Reindex(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if items.lastModel != embModel || res.Model != embModel {
+		t.Fatalf("model = %q / %q, want %q", items.lastModel, res.Model, embModel)
+	}
+	if res.Queued != 3 || res.Remaining != 0 || len(events.events) != 3 {
+		t.Fatalf("result = %+v events = %d", res, len(events.events))
+	}
+	if ev := events.events[2]; ev.Name != "item/embed" || ev.Data["item_id"] != "i-3" || ev.Data["source_id"] != "s-2" {
+		t.Fatalf("event = %+v", ev)
+	}
+}
+
+func TestEmbeddingStatusFallsBackToDefaultModel(t *testing.T) {
+	t.Setenv("OPENAI_EMBEDDING_MODEL", "")
+	unsupported := "not-an-embedding-model"
+	items := &fakeEmbeddingReindexItems{coverage: repository.EmbeddingCoverage{Summarized: 4, Current: 1, Stale: 3}}
+	svc := NewEmbeddingReindexService(items, fakeEmbeddingReindexSettings{&model.UserSettings{EmbeddingModel: &unsupported}}, &fakeEventSender{})
+
+	view, err := svc.Status(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if view.Model != "text-embedding-3-small" || view.Stale != 3 || view.Current != 1 {
+		t.Fatalf("view = %+v", view)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	EntityKindCompany = "company"
+	EntityKindPerson  = "person"
+	EntityKindProduct = "product"
+	EntityKindOther   = "other"
+
+	maxItemEntities = 20
+)
+
+var (
+	latinEntityToken   = `(?:[A-Z][A-Za-z0-9]*|[a-z]+[A-Z][A-Za-z0-9]*)(?:[.&+\-][A-Za-z0-9]+)*`
+	latinEntityPattern = regexp.MustCompile(latinEntityToken + `(?:\s+` + latinEntityToken + `)*`)
+	jaCompanyPattern   = regexp.MustCompile(`株式会社\s*([ァ-ヶー]+|[A-Za-z][A-Za-z0-9&.\-]*)|([ァ-ヶー]+|[A-Za-z][A-Za-z0-9&.\-]*)\s*株式会社`)
+
+	// entitySplitTokens never belong to a name; a span is cut at them ("OpenAI CEO Sam Altman").
+	entitySplitTokens = stringSet(
+		"The", "A", "An", "And", "Or", "Of", "In", "On", "At", "For", "To", "By", "With", "From", "As", "But",
+		"It", "Its", "This", "That", "These", "Those", "He", "She", "They", "We", "I", "You", "His", "Her", "Their", "Our",
+		"CEO", "CTO", "CFO", "COO", "President", "Chairman", "Founder",
+		"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December",
+		"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+	)
+	// genericEntityTerms are acronyms and places that show up in facts but are not worth following.
+	genericEntityTerms = stringSet(
+		"ai", "api", "apis", "gpu", "gpus", "cpu", "llm", "llms", "ml", "it", "pc", "os", "sdk", "ui", "ux", "url", "http", "https",
+		"saas", "dx", "iot", "vr", "ar", "ev", "sns", "faq", "pdf", "oss", "us", "usa", "uk", "eu", "japan",
+	)
+	companySuffixTokens = stringSet("Inc", "Corp", "Corporation", "Ltd", "LLC", "Co", "Company", "Group", "Holdings", "Labs", "Technologies")
+	productHintTokens   = stringSet("Cloud", "Studio", "Pro", "Max", "Mini", "Ultra", "Code", "Chrome", "Windows", "Office", "Azure", "Web", "Services", "Platform", "Engine", "Model", "Vision", "Search")
+)
+
+func stringSet(values ...string) map[string]struct{} {
+	out := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		out[v] = struct{}{}
+	}
+	return out
+}
+
+// ExtractEntities picks named entities out of an item's facts with lightweight heuristics:
+// Latin-script proper nouns and Japanese "株式会社" names. Kinds are best-effort and fall back
+// to "other"; the result is ordered by mention count and capped per item.
+func ExtractEntities(facts []string) []model.ExtractedEntity {
+	type seenEntity struct {
+		entity model.ExtractedEntity
+		order  int
+	}
+	byName := map[string]*seenEntity{}
+	add := func(name, kind string) {
+		name = strings.Join(strings.Fields(name), " ")
+		normalized := strings.ToLower(name)
+		if len([]rune(name)) < 2 {
+			return
+		}
+		if _, generic := genericEntityTerms[normalized]; generic {
+			return
+		}
+		if e, ok := byName[normalized]; ok {
+			e.entity.Mentions++
+			if e.entity.Kind == EntityKindOther {
+				e.entity.Kind = kind
+			}
+			return
+		}
+		byName[normalized] = &seenEntity{
+			entity: model.ExtractedEntity{Name: name, NormalizedName: normalized, Kind: kind, Mentions: 1},
+			order:  len(byName),
+		}
+	}
+
+	for _, fact := range facts {
+		for _, m := range jaCompanyPattern.FindAllStringSubmatch(fact, -1) {
+			name := m[1]
+			if name == "" {
+				name = m[2]
+			}
+			add(name, EntityKindCompany)
+		}
+		for _, loc := range latinEntityPattern.FindAllStringIndex(fact, -1) {
+			following := fact[loc[1]:]
+			for _, span := range splitEntitySpan(strings.Fields(fact[loc[0]:loc[1]])) {
+				if loc[0] == 0 && span.start == 0 && len(span.tokens) == 1 && isPlainTitleWord(span.tokens[0]) && strings.HasPrefix(fact[loc[0]+len(span.tokens[0]):], " ") {
+					// A lone capitalised word opening an English sentence is usually not a name.
+					continue
+				}
+				trailing := ""
+				if span.end == span.total {
+					trailing = following
+				}
+				tokens, kind := classifyEntityTokens(span.tokens, trailing)
+				add(strings.Join(tokens, " "), kind)
+			}
+		}
+	}
+
+	out := make([]*seenEntity, 0, len(byName))
+	for _, e := range byName {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].entity.Mentions != out[j].entity.Mentions {
+			return out[i].entity.Mentions > out[j].entity.Mentions
+		}
+		return out[i].order < out[j].order
+	})
+	if len(out) > maxItemEntities {
+		out = out[:maxItemEntities]
+	}
+	entities := make([]model.ExtractedEntity, 0, len(out))
+	for _, e := range out {
+		entities = append(entities, e.entity)
+	}
+	return entities
+}
+
+type entitySpan struct {
+	tokens     []string
+	start, end int
+	total      int
+}
+
+func splitEntitySpan(tokens []string) []entitySpan {
+	var spans []entitySpan
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			spans = append(spans, entitySpan{tokens: tokens[start:end], start: start, end: end, total: len(tokens)})
+		}
+	}
+	for i, tok := range tokens {
+		if _, split := entitySplitTokens[tok]; split {
+			flush(i)
+			start = i + 1
+		}
+	}
+	flush(len(tokens))
+	return spans
+}
+
+func classifyEntityTokens(tokens []string, following string) ([]string, string) {
+	following = strings.TrimSpace(following)
+	last := tokens[len(tokens)-1]
+	if _, ok := companySuffixTokens[last]; ok && len(tokens) > 1 {
+		return tokens[:len(tokens)-1], EntityKindCompany
+	}
+	switch {
+	case strings.HasPrefix(following, "社"):
+		return tokens, EntityKindCompany
+	case strings.HasPrefix(following, "氏"), strings.HasPrefix(following, "さん"):
+		return tokens, EntityKindPerson
+	}
+	for _, tok := range tokens {
+		if _, ok := productHintTokens[tok]; ok {
+			return tokens, EntityKindProduct
+		}
+		if strings.ContainsFunc(tok, unicode.IsDigit) || unicode.IsLower([]rune(tok)[0]) {
+			return tokens, EntityKindProduct
+		}
+	}
+	if len(tokens) >= 2 && len(tokens) <= 3 {
+		person := true
+		for _, tok := range tokens {
+			if !isPlainTitleWord(tok) {
+				person = false
+				break
+			}
+		}
+		if person {
+			return tokens, EntityKindPerson
+		}
+	}
+	return tokens, EntityKindOther
+}
+
+// isPlainTitleWord reports whether tok looks like "Altman": one capital followed by lowercase letters.
+func isPlainTitleWord(tok string) bool {
+	runes := []rune(tok)
+	if len(runes) < 2 || !unicode.IsUpper(runes[0]) {
+		return false
+	}
+	for _, r := range runes[1:] {
+		if !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package service
+
+import "testing"
+
+func TestExtractEntities(t *testing.T) {
+	facts := []string{
+		"OpenAI CEO Sam Altman said GPT-5 would ship to ChatGPT users.",
+		"OpenAI社は新しいAPIを公開した。",
+		"Researchers at Google found that Kubernetes clusters run on Google Cloud.",
+		"株式会社メルカリとApple Inc.が提携した。",
+		"Altman氏はiPhone向けのアプリにも言及した。",
+	}
+
+	got := ExtractEntities(facts)
+	byName := map[string]string{}
+	mentions := map[string]int{}
+	for _, e := range got {
+		byName[e.NormalizedName] = e.Kind
+		mentions[e.NormalizedName] = e.Mentions
+	}
+
+	want := map[string]string{
+		"openai":       EntityKindCompany,
+		"sam altman":   EntityKindPerson,
+		"gpt-5":        EntityKindProduct,
+		"chatgpt":      EntityKindOther,
+		"google":       EntityKindOther,
+		"kubernetes":   EntityKindOther,
+		"google cloud": EntityKindProduct,
+		"メルカリ":         EntityKindCompany,
+		"apple":        EntityKindCompany,
+		"altman":       EntityKindPerson,
+		"iphone":       EntityKindProduct,
+	}
+	for name, kind := range want {
+		if got, ok := byName[name]; !ok || got != kind {
+			t.Errorf("entity %q kind = %q (present=%v), want %q", name, got, ok, kind)
+		}
+	}
+	for _, unwanted := range []string{"researchers", "api", "ceo"} {
+		if _, ok := byName[unwanted]; ok {
+			t.Errorf("unexpected entity %q", unwanted)
+		}
+	}
+	if mentions["openai"] != 2 {
+		t.Errorf("openai mentions = %d, want 2", mentions["openai"])
+	}
+	if got[0].NormalizedName != "openai" {
+		t.Errorf("first entity = %q, want most-mentioned openai", got[0].NormalizedName)
+	}
+}
+
+func TestExtractEntitiesCapsResult(t *testing.T) {
+	facts := []string{}
+	for _, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel", "India", "Juliett", "Kilo", "Lima", "Mike", "November", "Oscar", "Papa", "Quebec", "Romeo", "Sierra", "Tango", "Uniform", "Victor"} {
+		facts = append(facts, "これは"+name+"の話。")
+	}
+	if got := ExtractEntities(facts); len(got) != maxItemEntities {
+		t.Fatalf("len = %d, want %d", len(got), maxItemEntities)
+	}
+}
@@ -42,12 +42,16 @@ var (
 	ErrSecretEncryptionNotConfigured = errors.New("user secret encryption is not configured")
 	ErrAivisAPIKeyNotConfigured      = errors.New("aivis api key is not configured")
 	ErrInoreaderOAuthNotConfigured   = errors.New("inoreader oauth is not configured")
+	ErrFeedlyOAuthNotConfigured      = errors.New("feedly oauth is not configured")
 	ErrAivisDictionaryUUIDRequired   = errors.New("aivis_user_dictionary_uuid is required")
 	ErrInvalidEmbeddingModel         = errors.New("invalid embedding model")
 	ErrInvalidKeywordLinkMode        = errors.New("invalid keyword_link_mode")
 	ErrUnsupportedArtworkContentType = errors.New("unsupported podcast artwork content_type")
 	ErrPublicBaseURLNotConfigured    = errors.New("AUDIO_BRIEFING_PUBLIC_BASE_URL is not configured")
 	ErrPublicBucketNotConfigured     = errors.New("AUDIO_BRIEFING_PUBLIC_BUCKET is not configured")
+	ErrWorkerUnavailable             = errors.New("worker is not configured")
+	ErrSourceNotFeed                 = errors.New("source is not a feed")
+	ErrSourceFeedUnavailable         = errors.New("source feed unavailable")
 )
 
 func IsUserError(err error) bool {
@@ -21,18 +21,21 @@ func NewEventPublisher() (*EventPublisher, error) {
 	return &EventPublisher{client: client}, nil
 }
 
-func (p *EventPublisher) SendItemCreated(ctx context.Context, itemID, sourceID, url string) {
-	_ = p.SendItemCreatedWithReasonE(ctx, itemID, sourceID, url, nil, "unknown")
+func (p *EventPublisher) SendItemCreated(ctx context.Context, itemID, sourceID, userID, url string) {
+	_ = p.SendItemCreatedWithReasonE(ctx, itemID, sourceID, userID, url, nil, "unknown")
 }
 
-func (p *EventPublisher) SendItemCreatedE(ctx context.Context, itemID, sourceID, url string) error {
-	return p.SendItemCreatedWithReasonE(ctx, itemID, sourceID, url, nil, "unknown")
+func (p *EventPublisher) SendItemCreatedE(ctx context.Context, itemID, sourceID, userID, url string) error {
+	return p.SendItemCreatedWithReasonE(ctx, itemID, sourceID, userID, url, nil, "unknown")
 }
 
-func NewItemCreatedEvent(itemID, sourceID, url string, title *string, reason string) inngestgo.Event {
+// NewItemCreatedEvent builds the item/created event. user_id is the source owner; process-item
+// keys its per-user concurrency limit on it.
+func NewItemCreatedEvent(itemID, sourceID, userID, url string, title *string, reason string) inngestgo.Event {
 	data := map[string]any{
 		"item_id":    itemID,
 		"source_id":  sourceID,
+		"user_id":    userID,
 		"url":        url,
 		"trigger_id": uuid.NewString(),
 		"reason":     reason,
@@ -46,11 +49,22 @@ func NewItemCreatedEvent(itemID, sourceID, url string, title *string, reason str
 	}
 }
 
-func (p *EventPublisher) SendItemCreatedWithReasonE(ctx context.Context, itemID, sourceID, url string, title *string, reason string) error {
+// NewTopicAlertsEvaluateEvent asks the topic alert evaluator to run, typically after a fetch cycle.
+func NewTopicAlertsEvaluateEvent(trigger string) inngestgo.Event {
+	return inngestgo.Event{
+		Name: "topic-alerts/evaluate",
+		Data: map[string]any{
+			"trigger":    trigger,
+			"trigger_id": uuid.NewString(),
+		},
+	}
+}
+
+func (p *EventPublisher) SendItemCreatedWithReasonE(ctx context.Context, itemID, sourceID, userID, url string, title *string, reason string) error {
 	if p == nil {
 		return nil
 	}
-	if _, err := p.client.Send(ctx, NewItemCreatedEvent(itemID, sourceID, url, title, reason)); err != nil {
+	if _, err := p.client.Send(ctx, StampRequestID(ctx, NewItemCreatedEvent(itemID, sourceID, userID, url, title, reason))); err != nil {
 		log.Printf("send item/created: %v", err)
 		return err
 	}
@@ -68,11 +82,43 @@ func NewItemBulkJobRunEvent(jobID, trigger string) inngestgo.Event {
 	}
 }
 
+// SendEventsE sends pre-built events in chunks, e.g. item/created events scheduled with a
+// future Timestamp.
+func (p *EventPublisher) SendEventsE(ctx context.Context, events []inngestgo.Event) error {
+	if p == nil {
+		return nil
+	}
+	const chunk = 100
+	for start := 0; start < len(events); start += chunk {
+		end := min(start+chunk, len(events))
+		batch := make([]any, 0, end-start)
+		for _, ev := range events[start:end] {
+			batch = append(batch, StampRequestID(ctx, ev))
+		}
+		if _, err := p.client.SendMany(ctx, batch); err != nil {
+			log.Printf("send events: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func NewImportJobRunEvent(jobID, trigger string) inngestgo.Event {
+	return inngestgo.Event{
+		Name: "import-job/run",
+		Data: map[string]any{
+			"job_id":     strings.TrimSpace(jobID),
+			"trigger":    strings.TrimSpace(trigger),
+			"trigger_id": uuid.NewString(),
+		},
+	}
+}
+
 func (p *EventPublisher) SendItemBulkJobRunE(ctx context.Context, jobID, trigger string) error {
 	if p == nil || strings.TrimSpace(jobID) == "" {
 		return nil
 	}
-	if _, err := p.client.Send(ctx, NewItemBulkJobRunEvent(jobID, trigger)); err != nil {
+	if _, err := p.client.Send(ctx, StampRequestID(ctx, NewItemBulkJobRunEvent(jobID, trigger))); err != nil {
 		log.Printf("send item-bulk-job/run: %v", err)
 		return err
 	}
@@ -80,18 +126,73 @@ func (p *EventPublisher) SendItemBulkJobRunE(ctx context.Context, jobID, trigger
 }
 
 func (p *EventPublisher) SendDigestCreatedE(ctx context.Context, digestID, userID, to string) error {
+	return p.SendDigestCreatedWithOptionsE(ctx, digestID, userID, to, DigestCreatedOptions{})
+}
+
+type DigestCreatedOptions struct {
+	ModelOverride      *string
+	ReuseClusterDrafts bool
+}
+
+func (p *EventPublisher) SendDigestCreatedWithOptionsE(ctx context.Context, digestID, userID, to string, opts DigestCreatedOptions) error {
 	if p == nil {
 		return nil
 	}
-	if _, err := p.client.Send(ctx, inngestgo.Event{
+	data := map[string]any{
+		"digest_id": digestID,
+		"user_id":   userID,
+		"to":        to,
+	}
+	if opts.ModelOverride != nil && strings.TrimSpace(*opts.ModelOverride) != "" {
+		data["model_override"] = strings.TrimSpace(*opts.ModelOverride)
+	}
+	if opts.ReuseClusterDrafts {
+		data["reuse_cluster_drafts"] = true
+	}
+	if _, err := p.client.Send(ctx, StampRequestID(ctx, inngestgo.Event{
 		Name: "digest/created",
+		Data: data,
+	})); err != nil {
+		log.Printf("send digest/created: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SendDigestCopyComposedE hands a composed digest to send-digest, used when a digest held for
+// approval is released.
+func (p *EventPublisher) SendDigestCopyComposedE(ctx context.Context, digestID, userID, to string) error {
+	if p == nil {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, StampRequestID(ctx, inngestgo.Event{
+		Name: "digest/copy-composed",
 		Data: map[string]any{
 			"digest_id": digestID,
 			"user_id":   userID,
 			"to":        to,
 		},
+	})); err != nil {
+		log.Printf("send digest/copy-composed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SendDigestRecipientConfirmationE asks the Inngest handler to issue a fresh confirmation token and
+// email it; the token never travels through the event payload.
+func (p *EventPublisher) SendDigestRecipientConfirmationE(ctx context.Context, recipientID string) error {
+	if p == nil || strings.TrimSpace(recipientID) == "" {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, inngestgo.Event{
+		Name: "digest-recipient/confirmation-requested",
+		Data: map[string]any{
+			"recipient_id": recipientID,
+			"trigger_id":   uuid.NewString(),
+		},
 	}); err != nil {
-		log.Printf("send digest/created: %v", err)
+		log.Printf("send digest-recipient/confirmation-requested: %v", err)
 		return err
 	}
 	return nil
@@ -143,17 +244,21 @@ func (p *EventPublisher) SendAINavigatorBriefRunE(ctx context.Context, userID, b
 	return nil
 }
 
-func (p *EventPublisher) SendItemEmbedE(ctx context.Context, itemID, sourceID string) error {
-	if p == nil {
-		return nil
-	}
-	if _, err := p.client.Send(ctx, inngestgo.Event{
+func NewItemEmbedEvent(itemID, sourceID string) inngestgo.Event {
+	return inngestgo.Event{
 		Name: "item/embed",
 		Data: map[string]any{
 			"item_id":   itemID,
 			"source_id": sourceID,
 		},
-	}); err != nil {
+	}
+}
+
+func (p *EventPublisher) SendItemEmbedE(ctx context.Context, itemID, sourceID string) error {
+	if p == nil {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, NewItemEmbedEvent(itemID, sourceID)); err != nil {
 		log.Printf("send item/embed: %v", err)
 		return err
 	}
@@ -176,6 +281,61 @@ func (p *EventPublisher) SendItemSearchUpsertE(ctx context.Context, itemID strin
 	return nil
 }
 
+// SendStoryNotesRequestedE asks the story-notes job to write the missing day notes of a story.
+// The job debounces per story, so repeated timeline views queue one run.
+func (p *EventPublisher) SendStoryNotesRequestedE(ctx context.Context, userID, storyID string) error {
+	if p == nil || strings.TrimSpace(storyID) == "" {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, inngestgo.Event{
+		Name: "story/notes.requested",
+		Data: map[string]any{
+			"user_id":  userID,
+			"story_id": storyID,
+		},
+	}); err != nil {
+		log.Printf("send story/notes.requested: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SendItemSnapshotCaptureE asks for the item's page to be snapshotted. The capture job skips
+// items whose owner has snapshots disabled or that already have one.
+func (p *EventPublisher) SendItemSnapshotCaptureE(ctx context.Context, itemID string) error {
+	if p == nil || strings.TrimSpace(itemID) == "" {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, inngestgo.Event{
+		Name: "item/snapshot.capture",
+		Data: map[string]any{
+			"item_id": itemID,
+		},
+	}); err != nil {
+		log.Printf("send item/snapshot.capture: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SendItemDiscussionsEnrichE asks for the Hacker News and Reddit threads about the item to be
+// looked up.
+func (p *EventPublisher) SendItemDiscussionsEnrichE(ctx context.Context, itemID string) error {
+	if p == nil || strings.TrimSpace(itemID) == "" {
+		return nil
+	}
+	if _, err := p.client.Send(ctx, inngestgo.Event{
+		Name: "item/discussions.enrich",
+		Data: map[string]any{
+			"item_id": itemID,
+		},
+	}); err != nil {
+		log.Printf("send item/discussions.enrich: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (p *EventPublisher) SendItemSearchDeleteE(ctx context.Context, itemID string) error {
 	if p == nil || strings.TrimSpace(itemID) == "" {
 		return nil
@@ -5,7 +5,7 @@ import "testing"
 func TestNewItemCreatedEventIncludesReasonAndTriggerID(t *testing.T) {
 	title := "Example title"
 
-	event := NewItemCreatedEvent("item-1", "source-1", "https://example.com/a", &title, "retry")
+	event := NewItemCreatedEvent("item-1", "source-1", "user-1", "https://example.com/a", &title, "retry")
 
 	if event.Name != "item/created" {
 		t.Fatalf("event.Name = %q, want %q", event.Name, "item/created")
@@ -17,6 +17,9 @@ func TestNewItemCreatedEventIncludesReasonAndTriggerID(t *testing.T) {
 	if got := data["source_id"]; got != "source-1" {
 		t.Fatalf("source_id = %v, want %q", got, "source-1")
 	}
+	if got := data["user_id"]; got != "user-1" {
+		t.Fatalf("user_id = %v, want %q", got, "user-1")
+	}
 	if got := data["url"]; got != "https://example.com/a" {
 		t.Fatalf("url = %v, want %q", got, "https://example.com/a")
 	}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const feedlyTokenRefreshMargin = 5 * time.Minute
+
+// FeedlyAPIBaseURL is the Feedly cloud API root, shared by the OAuth flow and the
+// subscriptions import.
+var FeedlyAPIBaseURL = "https://cloud.feedly.com"
+
+type FeedlyOAuthService struct {
+	repo   *repository.UserSettingsRepo
+	cipher *SecretCipher
+	http   *http.Client
+}
+
+type FeedlyConnectResult struct {
+	URL    string
+	State  string
+	Secure bool
+}
+
+func NewFeedlyOAuthService(repo *repository.UserSettingsRepo, cipher *SecretCipher) *FeedlyOAuthService {
+	return &FeedlyOAuthService{repo: repo, cipher: cipher, http: &http.Client{Timeout: 20 * time.Second}}
+}
+
+func (s *FeedlyOAuthService) RedirectURIFromRequest(r *http.Request) string {
+	if v := strings.TrimSpace(os.Getenv("FEEDLY_OAUTH_REDIRECT_URI")); v != "" {
+		return v
+	}
+	scheme := "https"
+	if xf := strings.TrimSpace(r.Header.Get("X-Forwarded-Proto")); xf != "" {
+		scheme = xf
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+	host := strings.TrimSpace(r.Header.Get("X-Forwarded-Host"))
+	if host == "" {
+		host = r.Host
+	}
+	return fmt.Sprintf("%s://%s/api/settings/feedly/callback", scheme, host)
+}
+
+func (s *FeedlyOAuthService) Enabled() bool {
+	return strings.TrimSpace(os.Getenv("FEEDLY_CLIENT_ID")) != "" && strings.TrimSpace(os.Getenv("FEEDLY_CLIENT_SECRET")) != ""
+}
+
+func (s *FeedlyOAuthService) BuildConnect(r *http.Request) (*FeedlyConnectResult, error) {
+	if !s.Enabled() {
+		return nil, ErrFeedlyOAuthNotConfigured
+	}
+	state, err := randomOAuthState()
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	q.Set("client_id", strings.TrimSpace(os.Getenv("FEEDLY_CLIENT_ID")))
+	q.Set("redirect_uri", s.RedirectURIFromRequest(r))
+	q.Set("response_type", "code")
+	q.Set("scope", "https://cloud.feedly.com/subscriptions")
+	q.Set("state", state)
+	return &FeedlyConnectResult{
+		URL:    FeedlyAPIBaseURL + "/v3/auth/auth?" + q.Encode(),
+		State:  state,
+		Secure: r.TLS != nil || strings.EqualFold(strings.TrimSpace(r.Header.Get("X-Forwarded-Proto")), "https"),
+	}, nil
+}
+
+func (s *FeedlyOAuthService) Complete(ctx context.Context, userID, code, redirectURI string) error {
+	if strings.TrimSpace(code) == "" {
+		return fmt.Errorf("missing_code")
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	return s.exchange(ctx, userID, form)
+}
+
+// AccessToken returns the user's stored Feedly access token, refreshing it first when it is
+// about to expire. It returns "" when Feedly is not connected.
+func (s *FeedlyOAuthService) AccessToken(ctx context.Context, userID string) (string, error) {
+	if s.cipher == nil || !s.cipher.Enabled() {
+		return "", nil
+	}
+	accessEnc, refreshEnc, expiresAt, err := s.repo.GetFeedlyTokensEncrypted(ctx, userID)
+	if err != nil || accessEnc == nil || strings.TrimSpace(*accessEnc) == "" {
+		return "", err
+	}
+	expiring := expiresAt != nil && time.Until(*expiresAt) < feedlyTokenRefreshMargin
+	if expiring && refreshEnc != nil && strings.TrimSpace(*refreshEnc) != "" {
+		refresh, err := s.cipher.DecryptString(*refreshEnc)
+		if err != nil {
+			return "", err
+		}
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", refresh)
+		if err := s.exchange(ctx, userID, form); err != nil {
+			return "", fmt.Errorf("feedly token refresh: %w", err)
+		}
+		if accessEnc, _, _, err = s.repo.GetFeedlyTokensEncrypted(ctx, userID); err != nil || accessEnc == nil {
+			return "", err
+		}
+	}
+	token, err := s.cipher.DecryptString(*accessEnc)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(token), nil
+}
+
+func (s *FeedlyOAuthService) Clear(ctx context.Context, userID string) (*model.UserSettings, error) {
+	return s.repo.ClearFeedlyOAuthTokens(ctx, userID)
+}
+
+// exchange posts a token grant and stores the result. Errors are short reason codes that the
+// callback passes on to the settings page.
+func (s *FeedlyOAuthService) exchange(ctx context.Context, userID string, form url.Values) error {
+	if s.cipher == nil || !s.cipher.Enabled() {
+		return fmt.Errorf("cipher")
+	}
+	form.Set("client_id", strings.TrimSpace(os.Getenv("FEEDLY_CLIENT_ID")))
+	form.Set("client_secret", strings.TrimSpace(os.Getenv("FEEDLY_CLIENT_SECRET")))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, FeedlyAPIBaseURL+"/v3/auth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("token_request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("token_exchange")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("token_status")
+	}
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil || strings.TrimSpace(tokenResp.AccessToken) == "" {
+		return fmt.Errorf("token_parse")
+	}
+	accessEnc, err := s.cipher.EncryptString(tokenResp.AccessToken)
+	if err != nil {
+		return fmt.Errorf("encrypt_access")
+	}
+	// A refresh grant does not return a new refresh token; nil keeps the stored one.
+	var refreshEnc *string
+	if strings.TrimSpace(tokenResp.RefreshToken) != "" {
+		v, err := s.cipher.EncryptString(tokenResp.RefreshToken)
+		if err != nil {
+			return fmt.Errorf("encrypt_refresh")
+		}
+		refreshEnc = &v
+	}
+	var expiresAt *time.Time
+	if tokenResp.ExpiresIn > 0 {
+		v := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+		expiresAt = &v
+	}
+	if _, err := s.repo.SetFeedlyOAuthTokens(ctx, userID, accessEnc, refreshEnc, expiresAt); err != nil {
+		return fmt.Errorf("save")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	FilterSuggestionLookback = 30 * 24 * time.Hour
+	// Reasons needed before a topic or source is proposed.
+	filterSuggestionMinEvidence = 3
+	// Share of a source's rated items that must be reasoned thumbs-downs to propose muting it.
+	sourceMuteMinNegativeShare = 0.6
+)
+
+var ErrFilterSuggestionClosed = errors.New("filter suggestion is no longer pending")
+
+// topicFilterReasons are the reasons that speak against a topic rather than a single article.
+var topicFilterReasons = map[string]bool{
+	model.FeedbackReasonOffTopic:  true,
+	model.FeedbackReasonClickbait: true,
+}
+
+func IsFeedbackReason(reason string) bool {
+	switch reason {
+	case model.FeedbackReasonOffTopic, model.FeedbackReasonClickbait, model.FeedbackReasonTooLong, model.FeedbackReasonDuplicate:
+		return true
+	}
+	return false
+}
+
+type filterRuleStore interface {
+	ListRules(ctx context.Context, userID string) ([]model.FilterRule, error)
+	CreateRule(ctx context.Context, userID, topic string) (*model.FilterRule, error)
+	ListFeedbackReasonSignals(ctx context.Context, userID string, since time.Time) ([]repository.FeedbackReasonSignal, error)
+	UpsertSuggestions(ctx context.Context, userID string, suggestions []model.FilterSuggestion) error
+	GetSuggestion(ctx context.Context, userID, id string) (*model.FilterSuggestion, error)
+	SetSuggestionStatus(ctx context.Context, userID, id, status string) error
+}
+
+type sourceMuter interface {
+	Update(ctx context.Context, id, userID string, enabled *bool, updateTitle bool, title *string) (*model.Source, error)
+}
+
+// FilterSuggestionService turns recurring negative feedback reasons into topic filter and
+// source mute proposals, and applies the ones the user accepts.
+type FilterSuggestionService struct {
+	store   filterRuleStore
+	sources sourceMuter
+	now     func() time.Time
+}
+
+func NewFilterSuggestionService(store filterRuleStore, sources sourceMuter) *FilterSuggestionService {
+	return &FilterSuggestionService{store: store, sources: sources, now: time.Now}
+}
+
+// Analyze refreshes the user's proposals from the last FilterSuggestionLookback of feedback and
+// returns how many were proposed.
+func (s *FilterSuggestionService) Analyze(ctx context.Context, userID string) (int, error) {
+	signals, err := s.store.ListFeedbackReasonSignals(ctx, userID, s.now().Add(-FilterSuggestionLookback))
+	if err != nil {
+		return 0, err
+	}
+	rules, err := s.store.ListRules(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	filtered := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		filtered[rule.Topic] = true
+	}
+	suggestions := BuildFilterSuggestions(signals, filtered)
+	if len(suggestions) == 0 {
+		return 0, nil
+	}
+	return len(suggestions), s.store.UpsertSuggestions(ctx, userID, suggestions)
+}
+
+// Accept applies a pending proposal: a topic filter rule, or disabling the source.
+func (s *FilterSuggestionService) Accept(ctx context.Context, userID, id string) (*model.FilterSuggestion, error) {
+	sg, err := s.pending(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch sg.Kind {
+	case "topic":
+		if _, err := s.store.CreateRule(ctx, userID, sg.Target); err != nil {
+			return nil, err
+		}
+	case "source":
+		disabled := false
+		if _, err := s.sources.Update(ctx, sg.Target, userID, &disabled, false, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.store.SetSuggestionStatus(ctx, userID, id, "accepted"); err != nil {
+		return nil, err
+	}
+	sg.Status = "accepted"
+	return sg, nil
+}
+
+func (s *FilterSuggestionService) Dismiss(ctx context.Context, userID, id string) (*model.FilterSuggestion, error) {
+	sg, err := s.pending(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SetSuggestionStatus(ctx, userID, id, "dismissed"); err != nil {
+		return nil, err
+	}
+	sg.Status = "dismissed"
+	return sg, nil
+}
+
+func (s *FilterSuggestionService) pending(ctx context.Context, userID, id string) (*model.FilterSuggestion, error) {
+	sg, err := s.store.GetSuggestion(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if sg.Status != "pending" {
+		return nil, ErrFilterSuggestionClosed
+	}
+	return sg, nil
+}
+
+type reasonTally struct {
+	negatives int
+	positives int
+	rated     int
+	reasons   map[string]int
+}
+
+func (t *reasonTally) add(reason string) {
+	if t.reasons == nil {
+		t.reasons = map[string]int{}
+	}
+	t.negatives++
+	t.reasons[reason]++
+}
+
+// dominantReason returns the most frequent reason, ties broken alphabetically.
+func (t *reasonTally) dominantReason() string {
+	best, bestN := "", 0
+	for reason, n := range t.reasons {
+		if n > bestN || (n == bestN && reason < best) {
+			best, bestN = reason, n
+		}
+	}
+	return best
+}
+
+// BuildFilterSuggestions proposes a topic filter when a topic collected enough off-topic or
+// clickbait thumbs-downs and no positive feedback, and a source mute when most of a source's
+// rated items were thumbs-downs with a reason. Topics in filtered and disabled sources are
+// skipped.
+func BuildFilterSuggestions(signals []repository.FeedbackReasonSignal, filtered map[string]bool) []model.FilterSuggestion {
+	topics := map[string]*reasonTally{}
+	sources := map[string]*reasonTally{}
+	for _, sig := range signals {
+		positive := sig.Rating > 0 || sig.IsFavorite
+		reason := ""
+		if sig.Rating < 0 && sig.Reason != nil {
+			reason = *sig.Reason
+		}
+		if sig.SourceEnabled {
+			src := sources[sig.SourceID]
+			if src == nil {
+				src = &reasonTally{}
+				sources[sig.SourceID] = src
+			}
+			src.rated++
+			if reason != "" {
+				src.add(reason)
+			}
+		}
+		seen := map[string]bool{}
+		for _, raw := range sig.Topics {
+			topic := repository.NormalizeFilterTopic(raw)
+			if topic == "" || seen[topic] || filtered[topic] {
+				continue
+			}
+			seen[topic] = true
+			t := topics[topic]
+			if t == nil {
+				t = &reasonTally{}
+				topics[topic] = t
+			}
+			if positive {
+				t.positives++
+			}
+			if topicFilterReasons[reason] {
+				t.add(reason)
+			}
+		}
+	}
+
+	var out []model.FilterSuggestion
+	for topic, t := range topics {
+		if t.negatives >= filterSuggestionMinEvidence && t.positives == 0 {
+			out = append(out, model.FilterSuggestion{Kind: "topic", Target: topic, Reason: t.dominantReason(), EvidenceCount: t.negatives})
+		}
+	}
+	for sourceID, t := range sources {
+		if t.negatives >= filterSuggestionMinEvidence && float64(t.negatives)/float64(t.rated) >= sourceMuteMinNegativeShare {
+			out = append(out, model.FilterSuggestion{Kind: "source", Target: sourceID, Reason: t.dominantReason(), EvidenceCount: t.negatives})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].EvidenceCount != out[j].EvidenceCount {
+			return out[i].EvidenceCount > out[j].EvidenceCount
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Target < out[j].Target
+	})
+	return out
+}
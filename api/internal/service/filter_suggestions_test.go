@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func reasonSignal(sourceID string, rating int, reason string, topics ...string) repository.FeedbackReasonSignal {
+	sig := repository.FeedbackReasonSignal{SourceID: sourceID, SourceEnabled: true, Rating: rating, Topics: topics}
+	if reason != "" {
+		sig.Reason = &reason
+	}
+	return sig
+}
+
+func TestBuildFilterSuggestions(t *testing.T) {
+	signals := []repository.FeedbackReasonSignal{
+		// "Crypto" collects three off-topic/clickbait thumbs-downs across sources.
+		reasonSignal("s1", -1, model.FeedbackReasonOffTopic, "Crypto"),
+		reasonSignal("s2", -1, model.FeedbackReasonClickbait, "crypto "),
+		reasonSignal("s3", -1, model.FeedbackReasonOffTopic, "Crypto", "Finance"),
+		// "Finance" would qualify but the user also liked a finance article.
+		reasonSignal("s3", -1, model.FeedbackReasonOffTopic, "Finance"),
+		reasonSignal("s3", -1, model.FeedbackReasonOffTopic, "Finance"),
+		reasonSignal("s4", 1, "", "Finance"),
+		// Too-long reasons never suggest topic filters, but do count against the source.
+		reasonSignal("s5", -1, model.FeedbackReasonTooLong, "Essays"),
+		reasonSignal("s5", -1, model.FeedbackReasonTooLong, "Essays"),
+		reasonSignal("s5", -1, model.FeedbackReasonDuplicate, "Essays"),
+		reasonSignal("s5", 1, "", "Essays"),
+		// Muted sources are not proposed again; filtered topics neither.
+		{SourceID: "s6", Rating: -1, Reason: ptr(model.FeedbackReasonOffTopic), Topics: []string{"Sports"}},
+		{SourceID: "s6", Rating: -1, Reason: ptr(model.FeedbackReasonOffTopic), Topics: []string{"Sports"}},
+		{SourceID: "s6", Rating: -1, Reason: ptr(model.FeedbackReasonOffTopic), Topics: []string{"Sports"}},
+	}
+
+	got := BuildFilterSuggestions(signals, map[string]bool{"sports": true})
+	if len(got) != 3 {
+		t.Fatalf("suggestions = %+v", got)
+	}
+	want := []model.FilterSuggestion{
+		{Kind: "source", Target: "s3", Reason: model.FeedbackReasonOffTopic, EvidenceCount: 3},
+		{Kind: "source", Target: "s5", Reason: model.FeedbackReasonTooLong, EvidenceCount: 3},
+		{Kind: "topic", Target: "crypto", Reason: model.FeedbackReasonOffTopic, EvidenceCount: 3},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("suggestion[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func ptr(s string) *string { return &s }
+
+type fakeFilterRuleStore struct {
+	rules       []model.FilterRule
+	suggestions map[string]*model.FilterSuggestion
+}
+
+func (f *fakeFilterRuleStore) ListRules(context.Context, string) ([]model.FilterRule, error) {
+	return f.rules, nil
+}
+
+func (f *fakeFilterRuleStore) CreateRule(_ context.Context, userID, topic string) (*model.FilterRule, error) {
+	rule := model.FilterRule{UserID: userID, Topic: topic}
+	f.rules = append(f.rules, rule)
+	return &rule, nil
+}
+
+func (f *fakeFilterRuleStore) ListFeedbackReasonSignals(context.Context, string, time.Time) ([]repository.FeedbackReasonSignal, error) {
+	return nil, nil
+}
+
+func (f *fakeFilterRuleStore) UpsertSuggestions(context.Context, string, []model.FilterSuggestion) error {
+	return nil
+}
+
+func (f *fakeFilterRuleStore) GetSuggestion(_ context.Context, userID, id string) (*model.FilterSuggestion, error) {
+	sg, ok := f.suggestions[id]
+	if !ok || sg.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	out := *sg
+	return &out, nil
+}
+
+func (f *fakeFilterRuleStore) SetSuggestionStatus(_ context.Context, _, id, status string) error {
+	f.suggestions[id].Status = status
+	return nil
+}
+
+type fakeSourceMuter struct{ disabled []string }
+
+func (f *fakeSourceMuter) Update(_ context.Context, id, _ string, enabled *bool, _ bool, _ *string) (*model.Source, error) {
+	if enabled != nil && !*enabled {
+		f.disabled = append(f.disabled, id)
+	}
+	return &model.Source{ID: id}, nil
+}
+
+func TestFilterSuggestionAcceptAppliesOnce(t *testing.T) {
+	store := &fakeFilterRuleStore{suggestions: map[string]*model.FilterSuggestion{
+		"t1": {ID: "t1", UserID: "u1", Kind: "topic", Target: "crypto", Status: "pending"},
+		"s1": {ID: "s1", UserID: "u1", Kind: "source", Target: "src-1", Status: "pending"},
+	}}
+	sources := &fakeSourceMuter{}
+	svc := NewFilterSuggestionService(store, sources)
+	ctx := context.Background()
+
+	if _, err := svc.Accept(ctx, "u2", "t1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("other user's accept err = %v", err)
+	}
+	if sg, err := svc.Accept(ctx, "u1", "t1"); err != nil || sg.Status != "accepted" {
+		t.Fatalf("Accept(topic) = %+v, %v", sg, err)
+	}
+	if len(store.rules) != 1 || store.rules[0].Topic != "crypto" {
+		t.Fatalf("rules = %+v", store.rules)
+	}
+	if _, err := svc.Accept(ctx, "u1", "t1"); !errors.Is(err, ErrFilterSuggestionClosed) {
+		t.Fatalf("second accept err = %v", err)
+	}
+	if _, err := svc.Accept(ctx, "u1", "s1"); err != nil {
+		t.Fatalf("Accept(source) err = %v", err)
+	}
+	if len(sources.disabled) != 1 || sources.disabled[0] != "src-1" {
+		t.Fatalf("disabled sources = %v", sources.disabled)
+	}
+	if _, err := svc.Dismiss(ctx, "u1", "s1"); !errors.Is(err, ErrFilterSuggestionClosed) {
+		t.Fatalf("dismiss after accept err = %v", err)
+	}
+}
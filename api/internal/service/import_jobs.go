@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+)
+
+// Source import job kinds. Item import kinds are the ItemImport* providers.
+const (
+	SourceImportOPML      = "opml"
+	SourceImportInoreader = "inoreader"
+	SourceImportFeedly    = "feedly"
+)
+
+const (
+	// Imported items are enqueued in small batches spread over time so a large archive does not
+	// monopolize the shared process-item throttle.
+	itemImportBatchSize     = 20
+	itemImportBatchInterval = 2 * time.Minute
+	// Sources are only fetched by the next fetch-rss run, so their batches follow immediately.
+	sourceImportBatchSize = 50
+
+	maxImportJobFailures = 100
+)
+
+// SourceImportEntry is one feed from an OPML file or a reader's subscription list. Group is the
+// reader's folder, applied to newly created sources only.
+type SourceImportEntry struct {
+	URL   string
+	Title *string
+	Group *string
+}
+
+// ImportJobView is a job with a sample of its failed entries and their reasons.
+type ImportJobView struct {
+	model.ImportJob
+	Failures []model.ImportJobFailure `json:"failures"`
+}
+
+type importJobStore interface {
+	Create(ctx context.Context, userID, kind string, sourceID *string, force bool, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error)
+	GetByID(ctx context.Context, id string) (*model.ImportJob, error)
+	Get(ctx context.Context, id, userID string) (*model.ImportJob, error)
+	ListFailures(ctx context.Context, jobID string, limit int) ([]model.ImportJobFailure, error)
+	ClaimEntries(ctx context.Context, jobID string, limit int) ([]model.ImportJobEntry, error)
+	FinishEntries(ctx context.Context, jobID string, results []repository.ImportEntryResult) error
+	RefreshCounts(ctx context.Context, jobID string) (int, error)
+	Complete(ctx context.Context, jobID string) error
+	Fail(ctx context.Context, jobID, message string) error
+}
+
+type importJobSourceStore interface {
+	List(ctx context.Context, userID string) ([]model.Source, error)
+	Create(ctx context.Context, userID, url, srcType string, title *string) (*model.Source, error)
+	SetGroupName(ctx context.Context, id, userID string, groupName *string) error
+}
+
+type importJobItemStore interface {
+	UpsertFromFeed(ctx context.Context, sourceID, url string, title *string) (string, bool, error)
+	MarkRead(ctx context.Context, userID, itemID string) (bool, error)
+}
+
+type importJobSourceLimiter interface {
+	CheckSourceCapacity(ctx context.Context, userID string) error
+	SourceCapacity(ctx context.Context, userID string) (int, error)
+}
+
+type importJobEventSender interface {
+	SendEventsE(ctx context.Context, events []inngestgo.Event) error
+}
+
+// ImportJobService runs imports as background jobs: entries are stored up front, then the
+// run-import-job function works through them one batch per event, persisting each entry's
+// outcome so progress and failure reasons can be polled.
+type ImportJobService struct {
+	jobs       importJobStore
+	sources    importJobSourceStore
+	items      importJobItemStore
+	events     importJobEventSender
+	duplicates *SourceDuplicateChecker
+	limits     importJobSourceLimiter
+	now        func() time.Time
+}
+
+func NewImportJobService(jobs importJobStore, sources importJobSourceStore, items importJobItemStore, events importJobEventSender) *ImportJobService {
+	return &ImportJobService{
+		jobs:       jobs,
+		sources:    sources,
+		items:      items,
+		events:     events,
+		duplicates: NewSourceDuplicateChecker(sources),
+		now:        time.Now,
+	}
+}
+
+// WithUsageLimits stops source imports at the user's plan source limit.
+func (s *ImportJobService) WithUsageLimits(limits importJobSourceLimiter) *ImportJobService {
+	s.limits = limits
+	return s
+}
+
+// Start stores a queued job and kicks off its first run.
+func (s *ImportJobService) Start(ctx context.Context, userID, kind string, sourceID *string, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error) {
+	return s.start(ctx, userID, kind, sourceID, false, entries, invalid)
+}
+
+func (s *ImportJobService) start(ctx context.Context, userID, kind string, sourceID *string, force bool, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error) {
+	job, err := s.jobs.Create(ctx, userID, kind, sourceID, force, entries, invalid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.events.SendEventsE(ctx, []inngestgo.Event{NewImportJobRunEvent(job.ID, "start")}); err != nil {
+		_ = s.jobs.Fail(ctx, job.ID, err.Error())
+		return nil, err
+	}
+	return job, nil
+}
+
+// StartSourceImport queues feeds to be registered as RSS sources. Entries without an http(s)
+// URL count as invalid and near-duplicates within the list are dropped. Unless force is set,
+// feeds that near-duplicate an existing source are skipped as duplicates. A user already at
+// the plan's source limit gets a *UsageLimitError; otherwise feeds past the limit fail.
+func (s *ImportJobService) StartSourceImport(ctx context.Context, userID, kind string, force bool, entries []SourceImportEntry) (*model.ImportJob, error) {
+	if s.limits != nil {
+		if err := s.limits.CheckSourceCapacity(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	rows := make([]model.ImportJobEntry, 0, len(entries))
+	seen := map[string]bool{}
+	invalid := 0
+	for _, e := range entries {
+		u := strings.TrimSpace(e.URL)
+		parsed, err := url.ParseRequestURI(u)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			invalid++
+			continue
+		}
+		key := SourceDuplicateKey(u)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, model.ImportJobEntry{URL: u, Title: trimmedOrNil(e.Title), GroupName: trimmedOrNil(e.Group)})
+	}
+	return s.start(ctx, userID, kind, nil, force, rows, invalid)
+}
+
+func (s *ImportJobService) Get(ctx context.Context, id, userID string) (*ImportJobView, error) {
+	job, err := s.jobs.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	failures := []model.ImportJobFailure{}
+	if job.FailedCount > 0 {
+		if failures, err = s.jobs.ListFailures(ctx, job.ID, maxImportJobFailures); err != nil {
+			return nil, err
+		}
+	}
+	return &ImportJobView{ImportJob: *job, Failures: failures}, nil
+}
+
+// RunChunk imports the next batch of a job and schedules the following run. It reports whether
+// the job is finished.
+func (s *ImportJobService) RunChunk(ctx context.Context, jobID string) (bool, error) {
+	job, err := s.jobs.GetByID(ctx, jobID)
+	if err != nil {
+		return false, err
+	}
+	if job.Status == "completed" || job.Status == "failed" {
+		return true, nil
+	}
+	sourceJob := isSourceImportKind(job.Kind)
+	if !sourceJob && job.SourceID == nil {
+		return true, s.jobs.Fail(ctx, jobID, "import source was deleted")
+	}
+	batchSize, interval := itemImportBatchSize, itemImportBatchInterval
+	if sourceJob {
+		batchSize, interval = sourceImportBatchSize, 0
+	}
+	entries, err := s.jobs.ClaimEntries(ctx, jobID, batchSize)
+	if err != nil {
+		_ = s.jobs.Fail(ctx, jobID, err.Error())
+		return false, err
+	}
+	var results []repository.ImportEntryResult
+	if sourceJob {
+		results, err = s.importSourceEntries(ctx, job, entries)
+	} else {
+		results, err = s.importItemEntries(ctx, job, entries)
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := s.jobs.FinishEntries(ctx, jobID, results); err != nil {
+		return false, err
+	}
+	remaining, err := s.jobs.RefreshCounts(ctx, jobID)
+	if err != nil {
+		return false, err
+	}
+	if remaining == 0 {
+		return true, s.jobs.Complete(ctx, jobID)
+	}
+	next := NewImportJobRunEvent(jobID, "continue")
+	if interval > 0 {
+		next.Timestamp = inngestgo.Timestamp(s.now().Add(interval))
+	}
+	if err := s.events.SendEventsE(ctx, []inngestgo.Event{next}); err != nil {
+		_ = s.jobs.Fail(ctx, jobID, err.Error())
+		return false, err
+	}
+	return false, nil
+}
+
+func (s *ImportJobService) importItemEntries(ctx context.Context, job *model.ImportJob, entries []model.ImportJobEntry) ([]repository.ImportEntryResult, error) {
+	results := make([]repository.ImportEntryResult, 0, len(entries))
+	events := make([]inngestgo.Event, 0, len(entries))
+	for _, e := range entries {
+		res := repository.ImportEntryResult{Position: e.Position}
+		itemID, created, err := s.items.UpsertFromFeed(ctx, *job.SourceID, e.URL, e.Title)
+		switch {
+		case err != nil:
+			res.Status, res.Message = repository.ImportEntryFailed, importFailureReason(err)
+		case !created:
+			res.Status, res.ItemID = repository.ImportEntryDuplicate, &itemID
+		default:
+			res.Status, res.ItemID = repository.ImportEntryAdded, &itemID
+			if e.Archived {
+				_, _ = s.items.MarkRead(ctx, job.UserID, itemID)
+			}
+			events = append(events, NewItemCreatedEvent(itemID, *job.SourceID, job.UserID, e.URL, e.Title, "import_"+job.Kind))
+		}
+		results = append(results, res)
+	}
+	if err := s.events.SendEventsE(ctx, events); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *ImportJobService) importSourceEntries(ctx context.Context, job *model.ImportJob, entries []model.ImportJobEntry) ([]repository.ImportEntryResult, error) {
+	var existing *SourceDuplicateIndex
+	if !job.Force {
+		idx, err := s.duplicates.Load(ctx, job.UserID, "rss")
+		if err != nil {
+			return nil, err
+		}
+		urls := make([]string, 0, len(entries))
+		for _, e := range entries {
+			urls = append(urls, e.URL)
+		}
+		idx.Prefetch(ctx, urls)
+		existing = idx
+	}
+	capacity := -1
+	if s.limits != nil {
+		n, err := s.limits.SourceCapacity(ctx, job.UserID)
+		if err != nil {
+			return nil, err
+		}
+		capacity = n
+	}
+	results := make([]repository.ImportEntryResult, 0, len(entries))
+	for _, e := range entries {
+		res := repository.ImportEntryResult{Position: e.Position, Status: repository.ImportEntryAdded}
+		if existing != nil {
+			if dup := existing.Match(ctx, e.URL); dup != nil {
+				msg := "near-duplicate of " + dup.URL
+				res.Status, res.Message = repository.ImportEntryDuplicate, &msg
+				results = append(results, res)
+				continue
+			}
+		}
+		if capacity == 0 {
+			msg := "source limit of your plan reached"
+			res.Status, res.Message = repository.ImportEntryFailed, &msg
+			results = append(results, res)
+			continue
+		}
+		created, err := s.sources.Create(ctx, job.UserID, e.URL, "rss", e.Title)
+		switch {
+		case errors.Is(err, repository.ErrConflict):
+			res.Status = repository.ImportEntryDuplicate
+		case err != nil:
+			res.Status, res.Message = repository.ImportEntryFailed, importFailureReason(err)
+		default:
+			if capacity > 0 {
+				capacity--
+			}
+			if existing != nil {
+				existing.Add(created)
+			}
+			if e.GroupName != nil {
+				if err := s.sources.SetGroupName(ctx, created.ID, job.UserID, e.GroupName); err != nil {
+					msg := "added, but the folder could not be applied"
+					res.Message = &msg
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func isSourceImportKind(kind string) bool {
+	switch kind {
+	case SourceImportOPML, SourceImportInoreader, SourceImportFeedly:
+		return true
+	}
+	return false
+}
+
+func importFailureReason(err error) *string {
+	msg := strings.TrimSpace(err.Error())
+	if r := []rune(msg); len(r) > 500 {
+		msg = string(r[:500])
+	}
+	return &msg
+}
+
+func trimmedOrNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*v)
+	if t == "" {
+		return nil
+	}
+	return &t
+}
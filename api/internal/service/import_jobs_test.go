@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+)
+
+type fakeImportSources struct {
+	existing map[string]bool
+	broken   map[string]bool
+	groups   map[string]string
+	list     []model.Source
+}
+
+func (f *fakeImportSources) List(_ context.Context, _ string) ([]model.Source, error) {
+	return f.list, nil
+}
+
+func (fakeImportSources) EnsureImportSource(_ context.Context, userID, url, title string) (*model.Source, error) {
+	return &model.Source{ID: "src-" + title, UserID: userID, URL: url}, nil
+}
+
+func (f *fakeImportSources) Create(_ context.Context, userID, url, _ string, _ *string) (*model.Source, error) {
+	switch {
+	case f.existing[url]:
+		return nil, repository.ErrConflict
+	case f.broken[url]:
+		return nil, errors.New("feed url rejected")
+	}
+	return &model.Source{ID: "src-" + url, UserID: userID, URL: url}, nil
+}
+
+func (f *fakeImportSources) SetGroupName(_ context.Context, id, _ string, groupName *string) error {
+	f.groups[id] = *groupName
+	return nil
+}
+
+type fakeImportItems struct {
+	existing map[string]bool
+	created  []string
+	read     []string
+}
+
+func (f *fakeImportItems) UpsertFromFeed(_ context.Context, _ string, url string, _ *string) (string, bool, error) {
+	if f.existing[url] {
+		return "existing", false, nil
+	}
+	f.created = append(f.created, url)
+	return "item-" + url, true, nil
+}
+
+func (f *fakeImportItems) MarkRead(_ context.Context, _, itemID string) (bool, error) {
+	f.read = append(f.read, itemID)
+	return true, nil
+}
+
+type fakeImportEvents struct{ sent []inngestgo.Event }
+
+func (f *fakeImportEvents) SendEventsE(_ context.Context, events []inngestgo.Event) error {
+	f.sent = append(f.sent, events...)
+	return nil
+}
+
+type fakeImportJobs struct {
+	job       model.ImportJob
+	entries   []model.ImportJobEntry
+	results   []repository.ImportEntryResult
+	completed bool
+}
+
+func (f *fakeImportJobs) Create(_ context.Context, userID, kind string, sourceID *string, force bool, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error) {
+	f.job = model.ImportJob{ID: "job-1", UserID: userID, Kind: kind, SourceID: sourceID, Force: force, Status: "queued", TotalCount: len(entries) + invalid, InvalidCount: invalid}
+	for i := range entries {
+		entries[i].Position = i
+	}
+	f.entries = entries
+	return &f.job, nil
+}
+
+func (f *fakeImportJobs) GetByID(_ context.Context, _ string) (*model.ImportJob, error) {
+	j := f.job
+	return &j, nil
+}
+
+func (f *fakeImportJobs) Get(ctx context.Context, id, _ string) (*model.ImportJob, error) {
+	return f.GetByID(ctx, id)
+}
+
+func (f *fakeImportJobs) ListFailures(_ context.Context, _ string, limit int) ([]model.ImportJobFailure, error) {
+	out := []model.ImportJobFailure{}
+	for _, r := range f.results {
+		if r.Status == repository.ImportEntryFailed && len(out) < limit {
+			out = append(out, model.ImportJobFailure{Position: r.Position, Message: *r.Message})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeImportJobs) ClaimEntries(_ context.Context, _ string, limit int) ([]model.ImportJobEntry, error) {
+	if limit > len(f.entries) {
+		limit = len(f.entries)
+	}
+	claimed := f.entries[:limit]
+	f.entries = f.entries[limit:]
+	return claimed, nil
+}
+
+func (f *fakeImportJobs) FinishEntries(_ context.Context, _ string, results []repository.ImportEntryResult) error {
+	f.results = append(f.results, results...)
+	return nil
+}
+
+func (f *fakeImportJobs) RefreshCounts(_ context.Context, _ string) (int, error) {
+	f.job.ProcessedCount = len(f.results)
+	f.job.FailedCount = 0
+	for _, r := range f.results {
+		if r.Status == repository.ImportEntryFailed {
+			f.job.FailedCount++
+		}
+	}
+	return len(f.entries), nil
+}
+
+func (f *fakeImportJobs) Complete(_ context.Context, _ string) error {
+	f.completed = true
+	f.job.Status = "completed"
+	return nil
+}
+
+func (f *fakeImportJobs) Fail(_ context.Context, _, message string) error {
+	f.job.Status = "failed"
+	f.job.ErrorMessage = &message
+	return nil
+}
+
+func TestImportJobServiceItemImport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []ItemImportEntry{{URL: "https://g.example/known"}, {URL: "not a url"}, {URL: "https://g.example/read", Archived: true}}
+	for i := 0; i < itemImportBatchSize; i++ {
+		entries = append(entries, ItemImportEntry{URL: "https://h.example/" + string(rune('a'+i))})
+	}
+	items := &fakeImportItems{existing: map[string]bool{"https://g.example/known": true}}
+	jobs := &fakeImportJobs{}
+	events := &fakeImportEvents{}
+	jobSvc := NewImportJobService(jobs, &fakeImportSources{}, items, events)
+	jobSvc.now = func() time.Time { return now }
+	svc := NewItemImportService(&fakeImportSources{}, jobSvc)
+
+	job, err := svc.StartJob(context.Background(), "u1", ItemImportBookmarks, entries)
+	if err != nil {
+		t.Fatalf("StartJob: %v", err)
+	}
+	if job.TotalCount != len(entries) || job.InvalidCount != 1 || *job.SourceID != "src-Bookmarks" || len(events.sent) != 1 || events.sent[0].Name != "import-job/run" {
+		t.Fatalf("job = %+v, events = %d", job, len(events.sent))
+	}
+
+	done, err := jobSvc.RunChunk(context.Background(), job.ID)
+	if err != nil || done {
+		t.Fatalf("first chunk done=%v err=%v", done, err)
+	}
+	last := events.sent[len(events.sent)-1]
+	if last.Name != "import-job/run" || last.Timestamp != inngestgo.Timestamp(now.Add(itemImportBatchInterval)) {
+		t.Fatalf("next run event = %+v", last)
+	}
+	done, err = jobSvc.RunChunk(context.Background(), job.ID)
+	if err != nil || !done || !jobs.completed {
+		t.Fatalf("second chunk done=%v err=%v completed=%v", done, err, jobs.completed)
+	}
+
+	counts := map[string]int{}
+	for _, r := range jobs.results {
+		counts[r.Status]++
+	}
+	if counts[repository.ImportEntryAdded] != itemImportBatchSize+1 || counts[repository.ImportEntryDuplicate] != 1 {
+		t.Fatalf("result counts = %v", counts)
+	}
+	if len(items.read) != 1 || items.read[0] != "item-https://g.example/read" {
+		t.Fatalf("read = %v", items.read)
+	}
+
+	if _, err := svc.StartJob(context.Background(), "u1", ItemImportPocket, []ItemImportEntry{{URL: "ftp://x"}}); !errors.Is(err, ErrItemImportEmpty) {
+		t.Fatalf("err = %v, want ErrItemImportEmpty", err)
+	}
+}
+
+func TestImportJobServiceSourceImport(t *testing.T) {
+	group := "Tech"
+	sources := &fakeImportSources{
+		existing: map[string]bool{"https://a.example/known.xml": true},
+		broken:   map[string]bool{"https://a.example/broken.xml": true},
+		groups:   map[string]string{},
+		list:     []model.Source{{ID: "s-old", URL: "https://www.b.example/feed/", Type: "rss"}},
+	}
+	jobs := &fakeImportJobs{}
+	events := &fakeImportEvents{}
+	svc := NewImportJobService(jobs, sources, &fakeImportItems{}, events)
+	svc.duplicates.resolve = func(_ context.Context, rawURL string) (string, error) { return rawURL, nil }
+
+	job, err := svc.StartSourceImport(context.Background(), "u1", SourceImportFeedly, false, []SourceImportEntry{
+		{URL: "https://a.example/new.xml", Group: &group},
+		{URL: " http://a.example/new.xml/ "},
+		{URL: "https://a.example/known.xml"},
+		{URL: "https://a.example/broken.xml"},
+		{URL: "http://b.example/feed"},
+		{URL: "feed/https://a.example"},
+	})
+	if err != nil {
+		t.Fatalf("StartSourceImport: %v", err)
+	}
+	if job.SourceID != nil || job.TotalCount != 5 || job.InvalidCount != 1 {
+		t.Fatalf("job = %+v", job)
+	}
+	done, err := svc.RunChunk(context.Background(), job.ID)
+	if err != nil || !done {
+		t.Fatalf("chunk done=%v err=%v", done, err)
+	}
+	if len(events.sent) != 1 {
+		t.Fatalf("sent %d events, want only the start event", len(events.sent))
+	}
+	want := []string{repository.ImportEntryAdded, repository.ImportEntryDuplicate, repository.ImportEntryFailed, repository.ImportEntryDuplicate}
+	for i, r := range jobs.results {
+		if r.Status != want[i] {
+			t.Fatalf("result %d = %+v, want %s", i, r, want[i])
+		}
+	}
+	if msg := jobs.results[3].Message; msg == nil || *msg != "near-duplicate of https://www.b.example/feed/" {
+		t.Fatalf("near-duplicate message = %v", msg)
+	}
+	if sources.groups["src-https://a.example/new.xml"] != "Tech" {
+		t.Fatalf("groups = %v", sources.groups)
+	}
+
+	view, err := svc.Get(context.Background(), job.ID, "u1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(view.Failures) != 1 || view.Failures[0].Message != "feed url rejected" {
+		t.Fatalf("failures = %+v", view.Failures)
+	}
+}
+
+type fakeImportLimiter struct{ capacity int }
+
+func (f fakeImportLimiter) CheckSourceCapacity(_ context.Context, _ string) error {
+	if f.capacity == 0 {
+		return &UsageLimitError{Limit: UsageLimitSources, Plan: UserPlanFree, Max: 1, Current: 1}
+	}
+	return nil
+}
+
+func (f fakeImportLimiter) SourceCapacity(_ context.Context, _ string) (int, error) {
+	return f.capacity, nil
+}
+
+func TestImportJobServiceSourceImportUsageLimit(t *testing.T) {
+	sources := &fakeImportSources{groups: map[string]string{}}
+	jobs := &fakeImportJobs{}
+	svc := NewImportJobService(jobs, sources, &fakeImportItems{}, &fakeImportEvents{}).WithUsageLimits(fakeImportLimiter{capacity: 1})
+	svc.duplicates.resolve = func(_ context.Context, rawURL string) (string, error) { return rawURL, nil }
+
+	job, err := svc.StartSourceImport(context.Background(), "u1", SourceImportFeedly, false, []SourceImportEntry{
+		{URL: "https://a.example/one.xml"},
+		{URL: "https://b.example/two.xml"},
+	})
+	if err != nil {
+		t.Fatalf("StartSourceImport: %v", err)
+	}
+	if _, err := svc.RunChunk(context.Background(), job.ID); err != nil {
+		t.Fatalf("RunChunk: %v", err)
+	}
+	if len(jobs.results) != 2 || jobs.results[0].Status != repository.ImportEntryAdded || jobs.results[1].Status != repository.ImportEntryFailed {
+		t.Fatalf("results = %+v", jobs.results)
+	}
+
+	svc.WithUsageLimits(fakeImportLimiter{capacity: 0})
+	var limitErr *UsageLimitError
+	if _, err := svc.StartSourceImport(context.Background(), "u1", SourceImportFeedly, false, []SourceImportEntry{{URL: "https://c.example/x.xml"}}); !errors.As(err, &limitErr) {
+		t.Fatalf("err = %v, want *UsageLimitError", err)
+	}
+}
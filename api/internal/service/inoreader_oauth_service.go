@@ -16,6 +16,11 @@ import (
 	"github.com/enjoydarts/sifto/api/internal/repository"
 )
 
+const inoreaderTokenRefreshMargin = 5 * time.Minute
+
+// InoreaderBaseURL is the Inoreader site root, shared by the OAuth flow and the reader API.
+var InoreaderBaseURL = "https://www.inoreader.com"
+
 type InoreaderOAuthService struct {
 	repo   *repository.UserSettingsRepo
 	cipher *SecretCipher
@@ -64,10 +69,11 @@ func (s *InoreaderOAuthService) BuildConnect(r *http.Request) (*InoreaderConnect
 	q.Set("client_id", strings.TrimSpace(os.Getenv("INOREADER_CLIENT_ID")))
 	q.Set("redirect_uri", s.RedirectURIFromRequest(r))
 	q.Set("response_type", "code")
-	q.Set("scope", "read")
+	// write is needed to push read state back during the recurring sync.
+	q.Set("scope", "read write")
 	q.Set("state", state)
 	return &InoreaderConnectResult{
-		URL:    "https://www.inoreader.com/oauth2/auth?" + q.Encode(),
+		URL:    InoreaderBaseURL + "/oauth2/auth?" + q.Encode(),
 		State:  state,
 		Secure: r.TLS != nil || strings.EqualFold(strings.TrimSpace(r.Header.Get("X-Forwarded-Proto")), "https"),
 	}, nil
@@ -77,17 +83,56 @@ func (s *InoreaderOAuthService) Complete(ctx context.Context, userID, code, redi
 	if strings.TrimSpace(code) == "" {
 		return fmt.Errorf("missing_code")
 	}
-	if s.cipher == nil || !s.cipher.Enabled() {
-		return fmt.Errorf("cipher")
-	}
 	form := url.Values{}
 	form.Set("grant_type", "authorization_code")
 	form.Set("code", code)
 	form.Set("redirect_uri", redirectURI)
+	return s.exchange(ctx, userID, form)
+}
+
+// AccessToken returns the user's stored Inoreader access token, refreshing it with the stored
+// refresh token when it is about to expire. It returns "" when Inoreader is not connected.
+func (s *InoreaderOAuthService) AccessToken(ctx context.Context, userID string) (string, error) {
+	if s.cipher == nil || !s.cipher.Enabled() {
+		return "", nil
+	}
+	accessEnc, refreshEnc, expiresAt, err := s.repo.GetInoreaderTokensEncrypted(ctx, userID)
+	if err != nil || accessEnc == nil || strings.TrimSpace(*accessEnc) == "" {
+		return "", err
+	}
+	expiring := expiresAt != nil && time.Until(*expiresAt) < inoreaderTokenRefreshMargin
+	if expiring && refreshEnc != nil && strings.TrimSpace(*refreshEnc) != "" {
+		refresh, err := s.cipher.DecryptString(*refreshEnc)
+		if err != nil {
+			return "", err
+		}
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", refresh)
+		if err := s.exchange(ctx, userID, form); err != nil {
+			return "", fmt.Errorf("inoreader token refresh: %w", err)
+		}
+		if accessEnc, _, _, err = s.repo.GetInoreaderTokensEncrypted(ctx, userID); err != nil || accessEnc == nil {
+			return "", err
+		}
+	}
+	token, err := s.cipher.DecryptString(*accessEnc)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// exchange posts a token grant and stores the result. Errors are short reason codes that the
+// callback passes on to the settings page.
+func (s *InoreaderOAuthService) exchange(ctx context.Context, userID string, form url.Values) error {
+	if s.cipher == nil || !s.cipher.Enabled() {
+		return fmt.Errorf("cipher")
+	}
 	form.Set("client_id", strings.TrimSpace(os.Getenv("INOREADER_CLIENT_ID")))
 	form.Set("client_secret", strings.TrimSpace(os.Getenv("INOREADER_CLIENT_SECRET")))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://www.inoreader.com/oauth2/token", strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, InoreaderBaseURL+"/oauth2/token", strings.NewReader(form.Encode()))
 	if err != nil {
 		return fmt.Errorf("token_request")
 	}
@@ -112,6 +157,7 @@ func (s *InoreaderOAuthService) Complete(ctx context.Context, userID, code, redi
 	if err != nil {
 		return fmt.Errorf("encrypt_access")
 	}
+	// nil keeps the stored refresh token when a grant does not rotate it.
 	var refreshEnc *string
 	if strings.TrimSpace(tokenResp.RefreshToken) != "" {
 		v, err := s.cipher.EncryptString(tokenResp.RefreshToken)
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	InoreaderSyncProvider = "inoreader"
+
+	inoreaderReadTag = "user/-/state/com.google/read"
+	// Bounds one user's read-state push per run; the reads left over are pushed by later runs,
+	// which resume from the stored watermark.
+	inoreaderReadSyncMaxItems = 500
+	inoreaderReadSyncMaxFeeds = 20
+	// The first sync only pushes reads from the last day, not the whole history.
+	inoreaderReadSyncInitialWindow = 24 * time.Hour
+)
+
+// InoreaderSubscription is one feed from the user's Inoreader subscription list.
+type InoreaderSubscription struct {
+	URL   string
+	Title string
+}
+
+type InoreaderSyncResult struct {
+	Total      int `json:"total"`
+	Added      int `json:"added"`
+	Linked     int `json:"linked"`
+	Removed    int `json:"removed"`
+	ReadMarked int `json:"read_marked"`
+	// OverLimit counts new subscriptions not added because the plan's source limit was reached.
+	OverLimit int `json:"over_limit,omitempty"`
+}
+
+type InoreaderSyncView struct {
+	Enabled      bool       `json:"enabled"`
+	ReadState    bool       `json:"read_state"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	LastError    *string    `json:"last_error,omitempty"`
+}
+
+func NewInoreaderSyncView(settings *model.UserSettings) InoreaderSyncView {
+	if settings == nil {
+		return InoreaderSyncView{}
+	}
+	return InoreaderSyncView{
+		Enabled:      settings.InoreaderSyncEnabled,
+		ReadState:    settings.InoreaderSyncReadState,
+		LastSyncedAt: settings.InoreaderLastSyncedAt,
+		LastError:    settings.InoreaderLastSyncError,
+	}
+}
+
+type inoreaderSyncSourceStore interface {
+	List(ctx context.Context, userID string) ([]model.Source, error)
+	Create(ctx context.Context, userID, url, srcType string, title *string) (*model.Source, error)
+	MarkSynced(ctx context.Context, userID, provider string, ids []string) error
+	FlagSyncRemoved(ctx context.Context, userID, provider string, ids []string) error
+	ListSyncedReadItems(ctx context.Context, userID, provider string, since time.Time, limit int) ([]repository.SyncedReadItem, error)
+	ListSyncTombstones(ctx context.Context, userID, provider string) ([]string, error)
+}
+
+type inoreaderSyncSettingsStore interface {
+	ListInoreaderSyncTargets(ctx context.Context) ([]model.InoreaderSyncTarget, error)
+	RecordInoreaderSync(ctx context.Context, userID string, syncedAt time.Time, syncErr *string, readSyncedUntil *time.Time) error
+}
+
+type inoreaderSourceLimiter interface {
+	SourceCapacity(ctx context.Context, userID string) (int, error)
+}
+
+type inoreaderTokenSource interface {
+	AccessToken(ctx context.Context, userID string) (string, error)
+}
+
+// InoreaderSyncService keeps Sifto sources in step with the user's Inoreader subscriptions: new
+// feeds are added, feeds unsubscribed in Inoreader are flagged, and optionally items read in
+// Sifto are marked read in Inoreader.
+type InoreaderSyncService struct {
+	tokens   inoreaderTokenSource
+	sources  inoreaderSyncSourceStore
+	settings inoreaderSyncSettingsStore
+	limits   inoreaderSourceLimiter
+	now      func() time.Time
+}
+
+func NewInoreaderSyncService(tokens inoreaderTokenSource, sources inoreaderSyncSourceStore, settings inoreaderSyncSettingsStore) *InoreaderSyncService {
+	return &InoreaderSyncService{tokens: tokens, sources: sources, settings: settings, now: time.Now}
+}
+
+// WithUsageLimits stops adding subscriptions at the user's plan source limit.
+func (s *InoreaderSyncService) WithUsageLimits(limits inoreaderSourceLimiter) *InoreaderSyncService {
+	s.limits = limits
+	return s
+}
+
+func (s *InoreaderSyncService) ListTargets(ctx context.Context) ([]model.InoreaderSyncTarget, error) {
+	return s.settings.ListInoreaderSyncTargets(ctx)
+}
+
+// SyncUser runs one sync for a user and records its outcome on the user's settings.
+func (s *InoreaderSyncService) SyncUser(ctx context.Context, target model.InoreaderSyncTarget) (InoreaderSyncResult, error) {
+	startedAt := s.now()
+	res, readSyncedUntil, err := s.syncUser(ctx, target, startedAt)
+	var syncErr *string
+	if err != nil {
+		msg := err.Error()
+		syncErr = &msg
+	}
+	if recErr := s.settings.RecordInoreaderSync(ctx, target.UserID, startedAt, syncErr, readSyncedUntil); recErr != nil && err == nil {
+		err = recErr
+	}
+	return res, err
+}
+
+// syncUser also returns how far read states were pushed, or nil when the watermark stays put.
+func (s *InoreaderSyncService) syncUser(ctx context.Context, target model.InoreaderSyncTarget, startedAt time.Time) (InoreaderSyncResult, *time.Time, error) {
+	var res InoreaderSyncResult
+	token, err := s.tokens.AccessToken(ctx, target.UserID)
+	if err != nil {
+		return res, nil, err
+	}
+	if token == "" {
+		return res, nil, errors.New("inoreader is not connected")
+	}
+	subs, err := FetchInoreaderSubscriptions(ctx, token)
+	if err != nil {
+		return res, nil, err
+	}
+	sources, err := s.sources.List(ctx, target.UserID)
+	if err != nil {
+		return res, nil, err
+	}
+	tombstones, err := s.sources.ListSyncTombstones(ctx, target.UserID, InoreaderSyncProvider)
+	if err != nil {
+		return res, nil, err
+	}
+	plan := planInoreaderSync(subs, sources, tombstones)
+	res.Total = len(subs)
+	res.Linked = len(plan.Link)
+
+	capacity := -1
+	if s.limits != nil && len(plan.Add) > 0 {
+		if capacity, err = s.limits.SourceCapacity(ctx, target.UserID); err != nil {
+			return res, nil, err
+		}
+	}
+
+	synced := append([]string(nil), plan.Keep...)
+	synced = append(synced, plan.Link...)
+	for _, sub := range plan.Add {
+		if capacity == 0 {
+			res.OverLimit++
+			continue
+		}
+		var title *string
+		if v := strings.TrimSpace(sub.Title); v != "" {
+			title = &v
+		}
+		created, err := s.sources.Create(ctx, target.UserID, sub.URL, "rss", title)
+		if errors.Is(err, repository.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return res, nil, err
+		}
+		synced = append(synced, created.ID)
+		res.Added++
+		if capacity > 0 {
+			capacity--
+		}
+	}
+	if err := s.sources.MarkSynced(ctx, target.UserID, InoreaderSyncProvider, synced); err != nil {
+		return res, nil, err
+	}
+	if err := s.sources.FlagSyncRemoved(ctx, target.UserID, InoreaderSyncProvider, plan.Removed); err != nil {
+		return res, nil, err
+	}
+	res.Removed = len(plan.Removed)
+
+	if !target.SyncReadState {
+		return res, nil, nil
+	}
+	since := startedAt.Add(-inoreaderReadSyncInitialWindow)
+	switch {
+	case target.ReadSyncedUntil != nil:
+		since = *target.ReadSyncedUntil
+	case target.LastSyncedAt != nil:
+		since = *target.LastSyncedAt
+	}
+	rows, err := s.sources.ListSyncedReadItems(ctx, target.UserID, InoreaderSyncProvider, since, inoreaderReadSyncMaxItems)
+	if err != nil {
+		return res, nil, fmt.Errorf("inoreader read state: %w", err)
+	}
+	marked, err := s.pushReadState(ctx, token, rows)
+	res.ReadMarked = marked
+	if err != nil {
+		return res, nil, fmt.Errorf("inoreader read state: %w", err)
+	}
+	until := inoreaderReadWatermark(rows, startedAt)
+	return res, &until, nil
+}
+
+// pushReadState marks the read items of the first inoreaderReadSyncMaxFeeds feeds read in
+// Inoreader; rows are visited oldest first so the feeds left for later runs hold the newest reads.
+func (s *InoreaderSyncService) pushReadState(ctx context.Context, token string, rows []repository.SyncedReadItem) (int, error) {
+	marked := 0
+	for _, feed := range groupInoreaderReadItems(rows) {
+		entries, err := fetchInoreaderUnreadEntries(ctx, token, feed.url)
+		if err != nil {
+			return marked, err
+		}
+		ids := matchInoreaderEntries(entries, feed.itemURLs)
+		if len(ids) == 0 {
+			continue
+		}
+		if err := markInoreaderRead(ctx, token, ids); err != nil {
+			return marked, err
+		}
+		marked += len(ids)
+	}
+	return marked, nil
+}
+
+type inoreaderReadFeed struct {
+	url      string
+	itemURLs []string
+}
+
+// groupInoreaderReadItems groups rows by feed in order of each feed's oldest read, keeping the
+// first inoreaderReadSyncMaxFeeds feeds.
+func groupInoreaderReadItems(rows []repository.SyncedReadItem) []inoreaderReadFeed {
+	feeds := make([]inoreaderReadFeed, 0)
+	index := map[string]int{}
+	for _, row := range rows {
+		i, ok := index[row.SourceURL]
+		if !ok {
+			if len(feeds) >= inoreaderReadSyncMaxFeeds {
+				continue
+			}
+			i = len(feeds)
+			index[row.SourceURL] = i
+			feeds = append(feeds, inoreaderReadFeed{url: row.SourceURL})
+		}
+		feeds[i].itemURLs = append(feeds[i].itemURLs, row.ItemURL)
+	}
+	return feeds
+}
+
+// inoreaderReadWatermark is the read time the next run resumes after. It stops just before the
+// oldest read left out by the feed cap or the row limit, so those reads are pushed next time;
+// reads already pushed after it are unread-filtered out by Inoreader and cost nothing.
+func inoreaderReadWatermark(rows []repository.SyncedReadItem, startedAt time.Time) time.Time {
+	pushed := map[string]bool{}
+	for _, feed := range groupInoreaderReadItems(rows) {
+		pushed[feed.url] = true
+	}
+	for _, row := range rows {
+		if !pushed[row.SourceURL] {
+			return row.ReadAt.Add(-time.Microsecond)
+		}
+	}
+	if len(rows) >= inoreaderReadSyncMaxItems {
+		// Reads sharing the last row's timestamp may have been cut off by the limit.
+		return rows[len(rows)-1].ReadAt.Add(-time.Microsecond)
+	}
+	return startedAt
+}
+
+type inoreaderSyncPlan struct {
+	// Add are subscriptions with no matching source.
+	Add []InoreaderSubscription
+	// Keep are sources already synced from Inoreader that are still subscribed.
+	Keep []string
+	// Link are existing sources that match a subscription but are not synced yet or were
+	// flagged as removed before the feed was re-subscribed.
+	Link []string
+	// Removed are synced sources whose feed is no longer subscribed.
+	Removed []string
+}
+
+// planInoreaderSync diffs the subscriptions against the user's sources by normalized feed URL.
+// Subscriptions whose source the user deleted in Sifto (tombstones) are not added back.
+func planInoreaderSync(subs []InoreaderSubscription, sources []model.Source, tombstones []string) inoreaderSyncPlan {
+	var plan inoreaderSyncPlan
+	deleted := map[string]bool{}
+	for _, u := range tombstones {
+		if key := normalizeFeedURL(u); key != "" {
+			deleted[key] = true
+		}
+	}
+	bySubURL := map[string]bool{}
+	for _, sub := range subs {
+		if key := normalizeFeedURL(sub.URL); key != "" {
+			bySubURL[key] = true
+		}
+	}
+	existing := map[string]bool{}
+	for _, src := range sources {
+		key := normalizeFeedURL(src.URL)
+		existing[key] = true
+		synced := src.SyncProvider != nil && *src.SyncProvider == InoreaderSyncProvider
+		switch {
+		case bySubURL[key] && synced && src.SyncRemovedAt == nil:
+			plan.Keep = append(plan.Keep, src.ID)
+		case bySubURL[key]:
+			plan.Link = append(plan.Link, src.ID)
+		case synced && src.SyncRemovedAt == nil && len(subs) > 0:
+			// An empty list is more likely an API hiccup than a user who dropped every feed,
+			// so nothing is flagged then.
+			plan.Removed = append(plan.Removed, src.ID)
+		}
+	}
+	added := map[string]bool{}
+	for _, sub := range subs {
+		key := normalizeFeedURL(sub.URL)
+		if key == "" || existing[key] || added[key] || deleted[key] {
+			continue
+		}
+		added[key] = true
+		plan.Add = append(plan.Add, sub)
+	}
+	return plan
+}
+
+type inoreaderSubscriptionListResponse struct {
+	Subscriptions []struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"htmlUrl"`
+	} `json:"subscriptions"`
+}
+
+// FetchInoreaderSubscriptions lists the user's Inoreader feeds. Both OAuth bearer tokens and
+// legacy ClientLogin tokens are accepted.
+func FetchInoreaderSubscriptions(ctx context.Context, accessToken string) ([]InoreaderSubscription, error) {
+	if strings.TrimSpace(accessToken) == "" {
+		return nil, errors.New("access token is required")
+	}
+	resp, err := inoreaderGet(ctx, accessToken, InoreaderBaseURL+"/reader/api/0/subscription/list?output=json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var decoded inoreaderSubscriptionListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode inoreader subscriptions: %w", err)
+	}
+	out := make([]InoreaderSubscription, 0, len(decoded.Subscriptions))
+	seen := map[string]struct{}{}
+	for _, s := range decoded.Subscriptions {
+		raw := strings.TrimSpace(s.ID)
+		if strings.HasPrefix(raw, "feed/") {
+			raw = strings.TrimPrefix(raw, "feed/")
+		}
+		if unescaped, err := url.QueryUnescape(raw); err == nil && strings.TrimSpace(unescaped) != "" {
+			raw = unescaped
+		}
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if _, ok := seen[raw]; ok {
+			continue
+		}
+		seen[raw] = struct{}{}
+		out = append(out, InoreaderSubscription{URL: raw, Title: strings.TrimSpace(s.Title)})
+	}
+	return out, nil
+}
+
+type inoreaderStreamEntry struct {
+	ID        string `json:"id"`
+	Canonical []struct {
+		Href string `json:"href"`
+	} `json:"canonical"`
+	Alternate []struct {
+		Href string `json:"href"`
+	} `json:"alternate"`
+}
+
+// fetchInoreaderUnreadEntries lists the newest unread entries of one feed.
+func fetchInoreaderUnreadEntries(ctx context.Context, token, feedURL string) ([]inoreaderStreamEntry, error) {
+	q := url.Values{}
+	q.Set("n", "100")
+	q.Set("xt", inoreaderReadTag)
+	endpoint := InoreaderBaseURL + "/reader/api/0/stream/contents/" + url.PathEscape("feed/"+feedURL) + "?" + q.Encode()
+	resp, err := inoreaderGet(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var decoded struct {
+		Items []inoreaderStreamEntry `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode inoreader stream: %w", err)
+	}
+	return decoded.Items, nil
+}
+
+// matchInoreaderEntries returns the ids of entries whose link is one of the read item URLs.
+func matchInoreaderEntries(entries []inoreaderStreamEntry, itemURLs []string) []string {
+	read := map[string]bool{}
+	for _, u := range itemURLs {
+		if key := normalizeFeedURL(u); key != "" {
+			read[key] = true
+		}
+	}
+	ids := make([]string, 0)
+	for _, e := range entries {
+		links := make([]string, 0, len(e.Canonical)+len(e.Alternate))
+		for _, l := range e.Canonical {
+			links = append(links, l.Href)
+		}
+		for _, l := range e.Alternate {
+			links = append(links, l.Href)
+		}
+		for _, l := range links {
+			if read[normalizeFeedURL(l)] {
+				ids = append(ids, e.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func markInoreaderRead(ctx context.Context, token string, ids []string) error {
+	form := url.Values{}
+	form.Set("a", inoreaderReadTag)
+	for _, id := range ids {
+		form.Add("i", id)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, InoreaderBaseURL+"/reader/api/0/edit-tag", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "Sifto/1.0")
+	resp, err := (&http.Client{Timeout: 20 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("inoreader edit-tag status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// inoreaderGet tries the ClientLogin header first and falls back to Bearer, so pasted legacy
+// tokens keep working next to OAuth tokens. Non-2xx responses are returned as errors.
+func inoreaderGet(ctx context.Context, token, endpoint string) (*http.Response, error) {
+	client := &http.Client{Timeout: 20 * time.Second}
+	call := func(authHeader string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", authHeader)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", "Sifto/1.0")
+		return client.Do(req)
+	}
+	resp, err := call("GoogleLogin auth=" + token)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		_ = resp.Body.Close()
+		resp, err = call("Bearer " + token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("inoreader api status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
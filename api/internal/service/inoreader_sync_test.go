@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestPlanInoreaderSync(t *testing.T) {
+	provider := InoreaderSyncProvider
+	removedAt := time.Now().Add(-time.Hour)
+	subs := []InoreaderSubscription{
+		{URL: "https://kept.example/feed"},
+		{URL: "https://Manual.example/feed"},
+		{URL: "https://back.example/feed"},
+		{URL: "https://new.example/feed", Title: "New"},
+		{URL: "https://new.example:443/feed"},
+		{URL: "https://deleted.example/feed"},
+	}
+	sources := []model.Source{
+		{ID: "kept", URL: "https://kept.example/feed", SyncProvider: &provider},
+		{ID: "manual", URL: "https://manual.example/feed"},
+		{ID: "back", URL: "https://back.example/feed", SyncProvider: &provider, SyncRemovedAt: &removedAt},
+		{ID: "gone", URL: "https://gone.example/feed", SyncProvider: &provider},
+		{ID: "flagged", URL: "https://flagged.example/feed", SyncProvider: &provider, SyncRemovedAt: &removedAt},
+		{ID: "other", URL: "https://other.example/feed"},
+	}
+	plan := planInoreaderSync(subs, sources, []string{"https://Deleted.example/feed"})
+	if !slices.Equal(plan.Keep, []string{"kept"}) {
+		t.Fatalf("Keep = %v", plan.Keep)
+	}
+	if !slices.Equal(plan.Link, []string{"manual", "back"}) {
+		t.Fatalf("Link = %v", plan.Link)
+	}
+	if !slices.Equal(plan.Removed, []string{"gone"}) {
+		t.Fatalf("Removed = %v", plan.Removed)
+	}
+	if len(plan.Add) != 1 || plan.Add[0].URL != "https://new.example/feed" {
+		t.Fatalf("Add = %+v", plan.Add)
+	}
+
+	if empty := planInoreaderSync(nil, sources, nil); len(empty.Removed) != 0 {
+		t.Fatalf("empty subscription list flagged %v", empty.Removed)
+	}
+}
+
+type fakeInoreaderTokens struct{ token string }
+
+func (f fakeInoreaderTokens) AccessToken(context.Context, string) (string, error) {
+	return f.token, nil
+}
+
+type fakeInoreaderSyncSources struct {
+	sources    []model.Source
+	created    []string
+	synced     []string
+	removed    []string
+	readItems  []repository.SyncedReadItem
+	tombstones []string
+}
+
+func (f *fakeInoreaderSyncSources) List(context.Context, string) ([]model.Source, error) {
+	return f.sources, nil
+}
+
+func (f *fakeInoreaderSyncSources) Create(_ context.Context, _ string, url, _ string, _ *string) (*model.Source, error) {
+	f.created = append(f.created, url)
+	return &model.Source{ID: "created-" + url, URL: url}, nil
+}
+
+func (f *fakeInoreaderSyncSources) MarkSynced(_ context.Context, _, _ string, ids []string) error {
+	f.synced = append(f.synced, ids...)
+	return nil
+}
+
+func (f *fakeInoreaderSyncSources) FlagSyncRemoved(_ context.Context, _, _ string, ids []string) error {
+	f.removed = append(f.removed, ids...)
+	return nil
+}
+
+func (f *fakeInoreaderSyncSources) ListSyncedReadItems(context.Context, string, string, time.Time, int) ([]repository.SyncedReadItem, error) {
+	return f.readItems, nil
+}
+
+func (f *fakeInoreaderSyncSources) ListSyncTombstones(context.Context, string, string) ([]string, error) {
+	return f.tombstones, nil
+}
+
+type fakeInoreaderSyncSettings struct {
+	recorded        []*string
+	readSyncedUntil []*time.Time
+}
+
+func (f *fakeInoreaderSyncSettings) ListInoreaderSyncTargets(context.Context) ([]model.InoreaderSyncTarget, error) {
+	return nil, nil
+}
+
+func (f *fakeInoreaderSyncSettings) RecordInoreaderSync(_ context.Context, _ string, _ time.Time, syncErr *string, readSyncedUntil *time.Time) error {
+	f.recorded = append(f.recorded, syncErr)
+	f.readSyncedUntil = append(f.readSyncedUntil, readSyncedUntil)
+	return nil
+}
+
+func TestInoreaderSyncServiceSyncUser(t *testing.T) {
+	var markedIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/reader/api/0/subscription/list":
+			_ = json.NewEncoder(w).Encode(map[string]any{"subscriptions": []map[string]string{
+				{"id": "feed/https://a.example/feed", "title": "A"},
+				{"id": "feed/https://b.example/feed", "title": "B"},
+			}})
+		case r.URL.Path == "/reader/api/0/stream/contents/feed/https://a.example/feed":
+			if r.URL.Query().Get("xt") != inoreaderReadTag {
+				t.Errorf("stream query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"items": [
+				{"id": "item-1", "canonical": [{"href": "https://a.example/posts/1"}]},
+				{"id": "item-2", "alternate": [{"href": "https://a.example/posts/2"}]}
+			]}`))
+		case r.URL.Path == "/reader/api/0/edit-tag" && r.Method == http.MethodPost:
+			_ = r.ParseForm()
+			if r.PostForm.Get("a") != inoreaderReadTag {
+				t.Errorf("edit-tag a = %q", r.PostForm.Get("a"))
+			}
+			markedIDs = append(markedIDs, r.PostForm["i"]...)
+			_, _ = w.Write([]byte("OK"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	orig := InoreaderBaseURL
+	InoreaderBaseURL = srv.URL
+	defer func() { InoreaderBaseURL = orig }()
+
+	provider := InoreaderSyncProvider
+	sources := &fakeInoreaderSyncSources{
+		sources: []model.Source{
+			{ID: "a", URL: "https://a.example/feed", SyncProvider: &provider},
+			{ID: "old", URL: "https://old.example/feed", SyncProvider: &provider},
+		},
+		readItems: []repository.SyncedReadItem{{SourceURL: "https://a.example/feed", ItemURL: "https://A.example/posts/2#top"}},
+	}
+	settings := &fakeInoreaderSyncSettings{}
+	svc := NewInoreaderSyncService(fakeInoreaderTokens{token: "tok"}, sources, settings)
+	startedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return startedAt }
+
+	res, err := svc.SyncUser(context.Background(), model.InoreaderSyncTarget{UserID: "u1", SyncReadState: true})
+	if err != nil {
+		t.Fatalf("SyncUser: %v", err)
+	}
+	want := InoreaderSyncResult{Total: 2, Added: 1, Removed: 1, ReadMarked: 1}
+	if res != want {
+		t.Fatalf("result = %+v, want %+v", res, want)
+	}
+	if !slices.Equal(sources.created, []string{"https://b.example/feed"}) {
+		t.Fatalf("created = %v", sources.created)
+	}
+	if !slices.Equal(sources.synced, []string{"a", "created-https://b.example/feed"}) {
+		t.Fatalf("synced = %v", sources.synced)
+	}
+	if !slices.Equal(sources.removed, []string{"old"}) {
+		t.Fatalf("removed = %v", sources.removed)
+	}
+	if !slices.Equal(markedIDs, []string{"item-2"}) {
+		t.Fatalf("marked = %v", markedIDs)
+	}
+	if len(settings.recorded) != 1 || settings.recorded[0] != nil {
+		t.Fatalf("recorded = %v", settings.recorded)
+	}
+	if until := settings.readSyncedUntil[0]; until == nil || !until.Equal(startedAt) {
+		t.Fatalf("read synced until = %v, want %v", until, startedAt)
+	}
+}
+
+func TestInoreaderReadWatermarkStopsBeforeUnpushedReads(t *testing.T) {
+	startedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	base := startedAt.Add(-time.Hour)
+	rows := make([]repository.SyncedReadItem, 0)
+	for i := 0; i <= inoreaderReadSyncMaxFeeds; i++ {
+		rows = append(rows, repository.SyncedReadItem{
+			SourceURL: fmt.Sprintf("https://feed%d.example/feed", i),
+			ItemURL:   fmt.Sprintf("https://feed%d.example/posts/1", i),
+			ReadAt:    base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	// A later read of the first feed is pushed with it, but the feed over the cap is not.
+	rows = append(rows, repository.SyncedReadItem{SourceURL: "https://feed0.example/feed", ItemURL: "https://feed0.example/posts/2", ReadAt: base.Add(30 * time.Minute)})
+
+	feeds := groupInoreaderReadItems(rows)
+	if len(feeds) != inoreaderReadSyncMaxFeeds || len(feeds[0].itemURLs) != 2 {
+		t.Fatalf("feeds = %+v", feeds)
+	}
+	skipped := rows[inoreaderReadSyncMaxFeeds].ReadAt
+	if got := inoreaderReadWatermark(rows, startedAt); !got.Before(skipped) || got.Before(skipped.Add(-time.Millisecond)) {
+		t.Fatalf("watermark = %v, want just before %v", got, skipped)
+	}
+	if got := inoreaderReadWatermark(rows[:3], startedAt); !got.Equal(startedAt) {
+		t.Fatalf("watermark without leftovers = %v, want %v", got, startedAt)
+	}
+
+	full := make([]repository.SyncedReadItem, inoreaderReadSyncMaxItems)
+	for i := range full {
+		full[i] = repository.SyncedReadItem{SourceURL: "https://feed0.example/feed", ReadAt: base.Add(time.Duration(i) * time.Second)}
+	}
+	last := full[len(full)-1].ReadAt
+	if got := inoreaderReadWatermark(full, startedAt); !got.Before(last) || !got.After(full[len(full)-2].ReadAt) {
+		t.Fatalf("watermark at row limit = %v, want just before %v", got, last)
+	}
+}
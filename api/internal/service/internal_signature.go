@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Internal calls (web → API and API → worker) carry an HMAC-SHA256 signature over the method,
+// request URI, a unix timestamp, a random nonce, the acting user's email and the SHA-256 of the
+// body, keyed with the shared secret. Receivers accept the current secret or the previous one
+// while it rotates.
+const (
+	InternalTimestampHeader = "X-Internal-Timestamp"
+	InternalNonceHeader     = "X-Internal-Nonce"
+	InternalSignatureHeader = "X-Internal-Signature"
+	InternalUserEmailHeader = "X-Internal-User-Email"
+
+	internalSignaturePrefix = "v1="
+	internalSignatureWindow = 5 * time.Minute
+)
+
+var (
+	ErrInternalSignatureMissing  = errors.New("internal request is not signed")
+	ErrInternalSignatureInvalid  = errors.New("internal request signature does not match")
+	ErrInternalSignatureExpired  = errors.New("internal request timestamp is outside the allowed window")
+	ErrInternalSignatureReplayed = errors.New("internal request nonce was already used")
+)
+
+// InternalAPISecretsFromEnv returns INTERNAL_API_SECRET followed by INTERNAL_API_SECRET_PREVIOUS
+// when set, for verifying requests during a rotation.
+func InternalAPISecretsFromEnv() []string {
+	return internalSecretsFromEnv("INTERNAL_API_SECRET")
+}
+
+func internalSecretsFromEnv(key string) []string {
+	var out []string
+	for _, k := range []string{key, key + "_PREVIOUS"} {
+		if v := strings.TrimSpace(os.Getenv(k)); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// InternalStaticSecretAllowedFromEnv reports whether the unsigned X-Internal-Secret header is
+// still accepted, which INTERNAL_AUTH_ALLOW_STATIC_SECRET=true allows while callers migrate.
+func InternalStaticSecretAllowedFromEnv() bool {
+	v, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("INTERNAL_AUTH_ALLOW_STATIC_SECRET")))
+	return v
+}
+
+// MatchInternalSecret compares a static secret header with each configured secret in
+// constant time.
+func MatchInternalSecret(provided string, secrets []string) bool {
+	provided = strings.TrimSpace(provided)
+	if provided == "" {
+		return false
+	}
+	ok := false
+	for _, s := range secrets {
+		if s != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(s)) == 1 {
+			ok = true
+		}
+	}
+	return ok
+}
+
+func internalSignature(secret, method, uri, timestamp, nonce, userEmail string, body []byte) string {
+	sum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.ToUpper(method) + "\n" + uri + "\n" + timestamp + "\n" + nonce + "\n" + userEmail + "\n" + hex.EncodeToString(sum[:])))
+	return internalSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignInternalRequest sets the signature headers on req. body must be exactly what req sends,
+// and X-Internal-User-Email, when used, must be set before signing.
+func SignInternalRequest(req *http.Request, secret string, body []byte) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := rand.Text()
+	req.Header.Set(InternalTimestampHeader, ts)
+	req.Header.Set(InternalNonceHeader, nonce)
+	req.Header.Set(InternalSignatureHeader, internalSignature(secret, req.Method, req.URL.RequestURI(), ts, nonce, strings.TrimSpace(req.Header.Get(InternalUserEmailHeader)), body))
+}
+
+// internalNonceStore claims a nonce for ttl; false means it was already used.
+type internalNonceStore interface {
+	Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// memoryNonceStore only sees the requests of this process.
+type memoryNonceStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func (s *memoryNonceStore) Claim(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n, expires := range s.seen {
+		if now.After(expires) {
+			delete(s.seen, n)
+		}
+	}
+	if _, ok := s.seen[nonce]; ok {
+		return false, nil
+	}
+	s.seen[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// redisNonceStore shares claimed nonces between instances with SET NX and a TTL.
+type redisNonceStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func (s redisNonceStore) Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+":internal_nonce:"+nonce, "1", ttl).Result()
+}
+
+// InternalRequestVerifier checks signed internal requests and claims each accepted nonce until
+// its timestamp leaves the window, so a captured request cannot be sent again.
+type InternalRequestVerifier struct {
+	window time.Duration
+	now    func() time.Time
+	nonces internalNonceStore
+}
+
+// NewInternalRequestVerifier keeps nonces in this process; WithRedis shares them between
+// instances.
+func NewInternalRequestVerifier() *InternalRequestVerifier {
+	return &InternalRequestVerifier{
+		window: internalSignatureWindow,
+		now:    time.Now,
+		nonces: &memoryNonceStore{seen: map[string]time.Time{}},
+	}
+}
+
+func (v *InternalRequestVerifier) WithRedis(client *redis.Client, prefix string) *InternalRequestVerifier {
+	if client != nil {
+		if prefix = strings.TrimSpace(prefix); prefix == "" {
+			prefix = "sifto"
+		}
+		v.nonces = redisNonceStore{client: client, prefix: prefix}
+	}
+	return v
+}
+
+// Verify checks req against any of secrets. The body is read for the digest and put back for
+// the handler; a body over the request's MaxBytesReader limit returns its *http.MaxBytesError.
+func (v *InternalRequestVerifier) Verify(req *http.Request, secrets []string) error {
+	ts := strings.TrimSpace(req.Header.Get(InternalTimestampHeader))
+	nonce := strings.TrimSpace(req.Header.Get(InternalNonceHeader))
+	provided := strings.TrimSpace(req.Header.Get(InternalSignatureHeader))
+	if ts == "" || nonce == "" || provided == "" {
+		return ErrInternalSignatureMissing
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInternalSignatureInvalid
+	}
+	signedAt := time.Unix(unix, 0)
+	now := v.now()
+	if signedAt.Before(now.Add(-v.window)) || signedAt.After(now.Add(v.window)) {
+		return ErrInternalSignatureExpired
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	userEmail := strings.TrimSpace(req.Header.Get(InternalUserEmailHeader))
+	matched := false
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		want := internalSignature(secret, req.Method, req.URL.RequestURI(), ts, nonce, userEmail, body)
+		if hmac.Equal([]byte(provided), []byte(want)) {
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrInternalSignatureInvalid
+	}
+
+	ttl := signedAt.Add(v.window).Sub(now)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	fresh, err := v.nonces.Claim(req.Context(), nonce, ttl)
+	if err != nil {
+		return fmt.Errorf("claim internal request nonce: %w", err)
+	}
+	if !fresh {
+		return ErrInternalSignatureReplayed
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInternalRequestVerifierAcceptsSignedRequestOnce(t *testing.T) {
+	v := NewInternalRequestVerifier()
+	req := httptest.NewRequest("POST", "/api/internal/users/upsert?x=1", strings.NewReader(`{"a":1}`))
+	SignInternalRequest(req, "secret", []byte(`{"a":1}`))
+	if err := v.Verify(req, []string{"secret"}); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if err := v.Verify(req, []string{"secret"}); !errors.Is(err, ErrInternalSignatureReplayed) {
+		t.Fatalf("replayed Verify() = %v, want ErrInternalSignatureReplayed", err)
+	}
+}
+
+func TestInternalRequestVerifierRejectsTampering(t *testing.T) {
+	cases := map[string]func(string) error{
+		"body": func(secret string) error {
+			req := httptest.NewRequest("POST", "/api/internal/x", strings.NewReader(`{"a":2}`))
+			SignInternalRequest(req, secret, []byte(`{"a":1}`))
+			return NewInternalRequestVerifier().Verify(req, []string{"secret"})
+		},
+		"path": func(secret string) error {
+			req := httptest.NewRequest("GET", "/api/internal/x", nil)
+			SignInternalRequest(req, secret, nil)
+			req.URL.Path = "/api/internal/y"
+			return NewInternalRequestVerifier().Verify(req, []string{"secret"})
+		},
+		"secret": func(string) error {
+			req := httptest.NewRequest("GET", "/api/internal/x", nil)
+			SignInternalRequest(req, "other", nil)
+			return NewInternalRequestVerifier().Verify(req, []string{"secret"})
+		},
+	}
+	for name, run := range cases {
+		if err := run("secret"); !errors.Is(err, ErrInternalSignatureInvalid) {
+			t.Errorf("%s: Verify() = %v, want ErrInternalSignatureInvalid", name, err)
+		}
+	}
+}
+
+func TestInternalRequestVerifierWindowAndRotation(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/internal/x", nil)
+	SignInternalRequest(req, "old", nil)
+
+	v := NewInternalRequestVerifier()
+	v.now = func() time.Time { return time.Now().Add(6 * time.Minute) }
+	if err := v.Verify(req, []string{"new", "old"}); !errors.Is(err, ErrInternalSignatureExpired) {
+		t.Fatalf("stale Verify() = %v, want ErrInternalSignatureExpired", err)
+	}
+
+	if err := NewInternalRequestVerifier().Verify(req, []string{"new", "old"}); err != nil {
+		t.Fatalf("Verify() with the previous secret = %v", err)
+	}
+	if err := NewInternalRequestVerifier().Verify(httptest.NewRequest("GET", "/api/internal/x", nil), []string{"new"}); !errors.Is(err, ErrInternalSignatureMissing) {
+		t.Fatalf("unsigned Verify() = %v, want ErrInternalSignatureMissing", err)
+	}
+}
+
+func TestInternalRequestVerifiersSharingNoncesRejectReplayAcrossInstances(t *testing.T) {
+	shared := &memoryNonceStore{seen: map[string]time.Time{}}
+	a, b := NewInternalRequestVerifier(), NewInternalRequestVerifier()
+	a.nonces, b.nonces = shared, shared
+
+	req := httptest.NewRequest("GET", "/api/internal/x", nil)
+	SignInternalRequest(req, "secret", nil)
+	if err := a.Verify(req, []string{"secret"}); err != nil {
+		t.Fatalf("Verify() on the first instance = %v", err)
+	}
+	if err := b.Verify(req, []string{"secret"}); !errors.Is(err, ErrInternalSignatureReplayed) {
+		t.Fatalf("Verify() on the second instance = %v, want ErrInternalSignatureReplayed", err)
+	}
+}
+
+func TestInternalRequestVerifierCoversUserEmail(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/internal/x", nil)
+	req.Header.Set(InternalUserEmailHeader, "user@example.com")
+	SignInternalRequest(req, "secret", nil)
+	req.Header.Set(InternalUserEmailHeader, "admin@example.com")
+	if err := NewInternalRequestVerifier().Verify(req, []string{"secret"}); !errors.Is(err, ErrInternalSignatureInvalid) {
+		t.Fatalf("Verify() with a swapped email = %v, want ErrInternalSignatureInvalid", err)
+	}
+}
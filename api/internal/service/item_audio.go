@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	ItemAudioSourceSummary = "summary"
+	ItemAudioSourceFull    = "full"
+
+	// Full text is cut so a long article stays a listenable episode and a bounded TTS bill.
+	maxItemAudioFullTextRunes  = 12000
+	itemAudioPresignExpiresSec = 60 * 60
+)
+
+var (
+	ErrItemAudioInvalidSource        = errors.New("item audio source must be summary or full")
+	ErrItemAudioMissingText          = errors.New("item has no text to read")
+	ErrItemAudioStorageNotConfigured = errors.New("item audio storage bucket is not configured")
+)
+
+type itemAudioStore interface {
+	Get(ctx context.Context, itemID, userID, source string) (*model.ItemAudio, error)
+	Upsert(ctx context.Context, v model.ItemAudio) (*model.ItemAudio, error)
+}
+
+type itemAudioItemLoader interface {
+	GetDetail(ctx context.Context, id, userID string) (*model.ItemDetail, error)
+}
+
+type itemAudioVoiceSettings interface {
+	EnsureDefaults(ctx context.Context, userID string) (*model.SummaryAudioVoiceSettings, error)
+}
+
+type itemAudioSynthesizer interface {
+	SynthesizeText(ctx context.Context, userID, text string) (*SummaryAudioSynthesis, error)
+}
+
+type itemAudioStorage interface {
+	UploadAudioBriefingObject(ctx context.Context, bucket string, objectKey string, contentBase64 string, contentType string) (*AudioBriefingUploadObjectResponse, error)
+	PresignAudioBriefingObjectInBucket(ctx context.Context, objectKey string, bucket string, expiresSec int) (*AudioBriefingPresignResponse, error)
+}
+
+// ItemAudioService voices a single item with the user's summary audio voice and keeps the
+// result in blob storage, so queuing the same item again does not pay for another synthesis.
+type ItemAudioService struct {
+	store   itemAudioStore
+	items   itemAudioItemLoader
+	voices  itemAudioVoiceSettings
+	synth   itemAudioSynthesizer
+	storage itemAudioStorage
+}
+
+func NewItemAudioService(store itemAudioStore, items itemAudioItemLoader, voices itemAudioVoiceSettings, synth itemAudioSynthesizer, storage itemAudioStorage) *ItemAudioService {
+	return &ItemAudioService{store: store, items: items, voices: voices, synth: synth, storage: storage}
+}
+
+func ItemAudioBucketFromEnv() string {
+	return firstNonEmptyTrimmed(os.Getenv("ITEM_AUDIO_R2_BUCKET"), AudioBriefingStandardBucketFromEnv())
+}
+
+func ItemAudioObjectKey(userID, itemID, source, contentType string) string {
+	return fmt.Sprintf("item-audio/%s/%s/%s.%s", strings.TrimSpace(userID), strings.TrimSpace(itemID), source, digestAudioExtension(contentType))
+}
+
+// NormalizeItemAudioSource maps an empty source to summary and rejects anything else unknown.
+func NormalizeItemAudioSource(source string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(source)) {
+	case "", ItemAudioSourceSummary:
+		return ItemAudioSourceSummary, nil
+	case ItemAudioSourceFull:
+		return ItemAudioSourceFull, nil
+	}
+	return "", ErrItemAudioInvalidSource
+}
+
+// BuildItemAudioNarration returns the text read aloud for an item: the title and summary, or
+// the title and extracted article text.
+func BuildItemAudioNarration(item *model.ItemDetail, source string) string {
+	if item == nil {
+		return ""
+	}
+	body := summaryAudioSummaryText(item)
+	if source == ItemAudioSourceFull {
+		body = truncateRunes(strings.TrimSpace(derefString(item.ContentText)), maxItemAudioFullTextRunes)
+	}
+	if body == "" {
+		return ""
+	}
+	return BuildSummaryAudioNarration(derefString(item.TranslatedTitle), derefString(item.Title), body)
+}
+
+// itemAudioVoiceKey identifies the voice a rendering was made with; changing any of these
+// settings makes the stored audio stale.
+func itemAudioVoiceKey(v *model.SummaryAudioVoiceSettings) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%.2f|%.2f|%.2f|%.2f|%.2f",
+		strings.TrimSpace(v.TTSProvider), strings.TrimSpace(v.TTSModel), strings.TrimSpace(v.VoiceModel), strings.TrimSpace(v.VoiceStyle),
+		v.SpeechRate, v.EmotionalIntensity, v.TempoDynamics, v.Pitch, v.VolumeGain)
+}
+
+func itemAudioFresh(cached *model.ItemAudio, voiceKey string, summarizedAt *time.Time) bool {
+	if cached == nil || cached.VoiceKey != voiceKey {
+		return false
+	}
+	if cached.SourceSummarizedAt == nil || summarizedAt == nil {
+		return cached.SourceSummarizedAt == nil && summarizedAt == nil
+	}
+	return cached.SourceSummarizedAt.Equal(*summarizedAt)
+}
+
+// Get returns a playable rendering of the item, reusing the stored one while the item's
+// summary and the user's voice are unchanged.
+func (s *ItemAudioService) Get(ctx context.Context, userID, itemID, source string) (*model.ItemAudio, error) {
+	if s == nil || s.store == nil || s.items == nil || s.voices == nil || s.synth == nil || s.storage == nil {
+		return nil, errors.New("item audio service is not configured")
+	}
+	source, err := NormalizeItemAudioSource(source)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.items.GetDetail(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	narration := BuildItemAudioNarration(item, source)
+	if narration == "" {
+		return nil, ErrItemAudioMissingText
+	}
+	voice, err := s.voices.EnsureDefaults(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	voiceKey := itemAudioVoiceKey(voice)
+	var summarizedAt *time.Time
+	if item.Summary != nil && !item.Summary.SummarizedAt.IsZero() {
+		t := item.Summary.SummarizedAt
+		summarizedAt = &t
+	}
+
+	cached, err := s.store.Get(ctx, item.ID, userID, source)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if itemAudioFresh(cached, voiceKey, summarizedAt) {
+		return s.withPlayableURL(ctx, cached, true)
+	}
+
+	bucket := ItemAudioBucketFromEnv()
+	if bucket == "" {
+		return nil, ErrItemAudioStorageNotConfigured
+	}
+	synthesis, err := s.synth.SynthesizeText(ctx, userID, narration)
+	if err != nil {
+		return nil, err
+	}
+	contentType := strings.TrimSpace(synthesis.ContentType)
+	if contentType == "" {
+		contentType = "audio/mpeg"
+	}
+	objectKey := ItemAudioObjectKey(userID, item.ID, source, contentType)
+	uploaded, err := s.storage.UploadAudioBriefingObject(ctx, bucket, objectKey, base64.StdEncoding.EncodeToString(synthesis.AudioBytes), contentType)
+	if err != nil {
+		return nil, err
+	}
+	if uploaded != nil && strings.TrimSpace(uploaded.ObjectKey) != "" {
+		objectKey = strings.TrimSpace(uploaded.ObjectKey)
+	}
+	saved, err := s.store.Upsert(ctx, model.ItemAudio{
+		ItemID:             item.ID,
+		Source:             source,
+		VoiceKey:           voiceKey,
+		Bucket:             bucket,
+		ObjectKey:          objectKey,
+		ContentType:        contentType,
+		DurationSec:        synthesis.DurationSec,
+		TextChars:          len([]rune(narration)),
+		SourceSummarizedAt: summarizedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.withPlayableURL(ctx, saved, false)
+}
+
+func (s *ItemAudioService) withPlayableURL(ctx context.Context, audio *model.ItemAudio, cached bool) (*model.ItemAudio, error) {
+	resp, err := s.storage.PresignAudioBriefingObjectInBucket(ctx, audio.ObjectKey, NormalizeAudioBriefingStorageBucket(audio.Bucket), itemAudioPresignExpiresSec)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || strings.TrimSpace(resp.AudioURL) == "" {
+		return nil, errors.New("item audio presign returned empty url")
+	}
+	out := *audio
+	out.AudioURL = resp.AudioURL
+	out.Cached = cached
+	return &out, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type stubItemAudioStore struct {
+	stored *model.ItemAudio
+}
+
+func (s *stubItemAudioStore) Get(_ context.Context, _, _, _ string) (*model.ItemAudio, error) {
+	if s.stored == nil {
+		return nil, repository.ErrNotFound
+	}
+	return s.stored, nil
+}
+
+func (s *stubItemAudioStore) Upsert(_ context.Context, v model.ItemAudio) (*model.ItemAudio, error) {
+	s.stored = &v
+	return &v, nil
+}
+
+type stubItemAudioItems struct{ item *model.ItemDetail }
+
+func (s stubItemAudioItems) GetDetail(_ context.Context, _, _ string) (*model.ItemDetail, error) {
+	return s.item, nil
+}
+
+type stubItemAudioVoices struct {
+	settings *model.SummaryAudioVoiceSettings
+}
+
+func (s *stubItemAudioVoices) EnsureDefaults(_ context.Context, _ string) (*model.SummaryAudioVoiceSettings, error) {
+	return s.settings, nil
+}
+
+func newItemAudioTestService(item *model.ItemDetail) (*ItemAudioService, *stubItemAudioStore, *stubDigestAudioSynth, *stubItemAudioVoices) {
+	store := &stubItemAudioStore{}
+	synth := &stubDigestAudioSynth{result: &SummaryAudioSynthesis{AudioBytes: []byte("mp3"), ContentType: "audio/mpeg", DurationSec: 30}}
+	voices := &stubItemAudioVoices{settings: &model.SummaryAudioVoiceSettings{TTSProvider: "openai", VoiceModel: "alloy", SpeechRate: 1}}
+	return NewItemAudioService(store, stubItemAudioItems{item: item}, voices, synth, &stubDigestAudioStorage{}), store, synth, voices
+}
+
+func itemAudioTestItem() *model.ItemDetail {
+	title := "Title"
+	content := "Full article text"
+	return &model.ItemDetail{
+		Item:    model.Item{ID: "i1", Title: &title, ContentText: &content},
+		Summary: &model.ItemSummary{Summary: "Short summary", SummarizedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestItemAudioGetSynthesizesThenReusesStoredAudio(t *testing.T) {
+	t.Setenv("ITEM_AUDIO_R2_BUCKET", "item-audio-bucket")
+	svc, store, synth, _ := newItemAudioTestService(itemAudioTestItem())
+
+	first, err := svc.Get(context.Background(), "u1", "i1", "")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if first.Cached || first.Source != ItemAudioSourceSummary || first.DurationSec != 30 {
+		t.Fatalf("first = %+v", first)
+	}
+	if synth.gotText != "Title\n\nShort summary" {
+		t.Fatalf("narration = %q", synth.gotText)
+	}
+	if store.stored.ObjectKey != "item-audio/u1/i1/summary.mp3" || !strings.Contains(first.AudioURL, "item-audio-bucket") {
+		t.Fatalf("stored = %+v url = %s", store.stored, first.AudioURL)
+	}
+
+	synth.gotText = ""
+	second, err := svc.Get(context.Background(), "u1", "i1", "summary")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !second.Cached || synth.gotText != "" {
+		t.Fatalf("second = %+v, synthesized %q", second, synth.gotText)
+	}
+}
+
+func TestItemAudioGetResynthesizesWhenVoiceChanges(t *testing.T) {
+	t.Setenv("ITEM_AUDIO_R2_BUCKET", "item-audio-bucket")
+	svc, _, synth, voices := newItemAudioTestService(itemAudioTestItem())
+	if _, err := svc.Get(context.Background(), "u1", "i1", "summary"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	voices.settings = &model.SummaryAudioVoiceSettings{TTSProvider: "openai", VoiceModel: "nova", SpeechRate: 1}
+	synth.gotText = ""
+	out, err := svc.Get(context.Background(), "u1", "i1", "summary")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if out.Cached || synth.gotText == "" {
+		t.Fatalf("expected a new synthesis, got %+v", out)
+	}
+}
+
+func TestItemAudioGetFullTextAndErrors(t *testing.T) {
+	t.Setenv("ITEM_AUDIO_R2_BUCKET", "item-audio-bucket")
+	svc, _, synth, _ := newItemAudioTestService(itemAudioTestItem())
+	if _, err := svc.Get(context.Background(), "u1", "i1", "full"); err != nil {
+		t.Fatalf("Get(full) error = %v", err)
+	}
+	if synth.gotText != "Title\n\nFull article text" {
+		t.Fatalf("narration = %q", synth.gotText)
+	}
+	if _, err := svc.Get(context.Background(), "u1", "i1", "podcast"); !errors.Is(err, ErrItemAudioInvalidSource) {
+		t.Fatalf("Get(podcast) error = %v", err)
+	}
+
+	empty := itemAudioTestItem()
+	empty.ContentText = nil
+	svc, _, _, _ = newItemAudioTestService(empty)
+	if _, err := svc.Get(context.Background(), "u1", "i1", "full"); !errors.Is(err, ErrItemAudioMissingText) {
+		t.Fatalf("Get(full) without content error = %v", err)
+	}
+}
+
+func TestItemAudioGetRequiresStorage(t *testing.T) {
+	t.Setenv("ITEM_AUDIO_R2_BUCKET", "")
+	t.Setenv("AUDIO_BRIEFING_R2_STANDARD_BUCKET", "")
+	t.Setenv("AUDIO_BRIEFING_R2_BUCKET", "")
+	svc, _, _, _ := newItemAudioTestService(itemAudioTestItem())
+	if _, err := svc.Get(context.Background(), "u1", "i1", "summary"); !errors.Is(err, ErrItemAudioStorageNotConfigured) {
+		t.Fatalf("Get() error = %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// summaryInputVersion changes whenever the facts or summary pipeline changes in a way that
+// should invalidate stored summaries.
+const summaryInputVersion = "v1"
+
+// ItemContentHash fingerprints the title and body that fact extraction and summarization read.
+func ItemContentHash(title, content string) string {
+	return hashParts(strings.TrimSpace(title), strings.TrimSpace(content))
+}
+
+// ItemForceReprocessReason is the item/created reason for a retry that must regenerate the
+// summary even when the inputs are unchanged.
+const ItemForceReprocessReason = "retry_force"
+
+// ItemSummaryInputHash combines a content hash with the model settings and prompt versions that
+// shape the facts and summary. A reprocessed item whose input hash matches the stored one keeps
+// its summary.
+func ItemSummaryInputHash(contentHash string, settings *model.UserSettings, prompts ...*PromptResolution) string {
+	if contentHash == "" {
+		return ""
+	}
+	parts := []string{summaryInputVersion, contentHash}
+	if settings != nil {
+		parts = append(parts,
+			stringValue(settings.FactsModel),
+			stringValue(settings.FactsSecondaryModel),
+			strconv.Itoa(settings.FactsSecondaryRatePercent),
+			stringValue(settings.FactsFallbackModel),
+			stringValue(settings.FactsCheckModel),
+			stringValue(settings.FactsCheckFallbackModel),
+			stringValue(settings.SummaryModel),
+			stringValue(settings.SummarySecondaryModel),
+			strconv.Itoa(settings.SummarySecondaryRatePercent),
+			stringValue(settings.SummaryFallbackModel),
+			stringValue(settings.FaithfulnessCheckModel),
+			stringValue(settings.FaithfulnessCheckFallbackModel),
+			stringValue(NormalizeOutputLanguage(settings.OutputLanguage)),
+		)
+	}
+	for _, prompt := range prompts {
+		// Code defaults are covered by summaryInputVersion; template versions by their id.
+		parts = append(parts, promptKey(prompt), promptSource(prompt), toVal(promptVersionID(prompt)))
+	}
+	return hashParts(parts...)
+}
+
+func hashParts(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(strconv.Itoa(len(p))))
+		h.Write([]byte{':'})
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestItemContentHashIgnoresSurroundingWhitespace(t *testing.T) {
+	if ItemContentHash("Title", "body") != ItemContentHash(" Title\n", "body\n\n") {
+		t.Fatal("whitespace-only change altered the content hash")
+	}
+	if ItemContentHash("Title", "body") == ItemContentHash("Title", "body, edited") {
+		t.Fatal("body change kept the content hash")
+	}
+	if ItemContentHash("Titlebody", "") == ItemContentHash("Title", "body") {
+		t.Fatal("moving text between title and body kept the content hash")
+	}
+}
+
+func TestItemSummaryInputHashTracksModelSettings(t *testing.T) {
+	contentHash := ItemContentHash("Title", "body")
+	summaryModel := "gpt-5-mini"
+	settings := &model.UserSettings{SummaryModel: &summaryModel}
+	base := ItemSummaryInputHash(contentHash, settings)
+
+	same := *settings
+	if got := ItemSummaryInputHash(contentHash, &same); got != base {
+		t.Fatalf("unchanged settings gave %q, want %q", got, base)
+	}
+	otherModel := "claude-haiku-4-5"
+	changed := *settings
+	changed.SummaryModel = &otherModel
+	if ItemSummaryInputHash(contentHash, &changed) == base {
+		t.Fatal("summary model change kept the input hash")
+	}
+	lang := "en"
+	changed = *settings
+	changed.OutputLanguage = &lang
+	if ItemSummaryInputHash(contentHash, &changed) == base {
+		t.Fatal("output language change kept the input hash")
+	}
+	if ItemSummaryInputHash(ItemContentHash("Title", "other body"), settings) == base {
+		t.Fatal("content change kept the input hash")
+	}
+	if got := ItemSummaryInputHash("", settings); got != "" {
+		t.Fatalf("empty content hash gave %q, want empty", got)
+	}
+}
+
+func TestItemSummaryInputHashTracksPromptVersions(t *testing.T) {
+	contentHash := ItemContentHash("Title", "body")
+	codeDefault := &PromptResolution{PromptKey: "summary.default", PromptSource: "default_code"}
+	base := ItemSummaryInputHash(contentHash, nil, codeDefault)
+	if got := ItemSummaryInputHash(contentHash, nil, &PromptResolution{PromptKey: "summary.default", PromptSource: "default_code"}); got != base {
+		t.Fatalf("same prompt gave %q, want %q", got, base)
+	}
+	v1, v2 := "version-1", "version-2"
+	template := ItemSummaryInputHash(contentHash, nil, &PromptResolution{PromptKey: "summary.default", PromptSource: "template_version", PromptVersionID: &v1})
+	if template == base {
+		t.Fatal("switching to a template version kept the input hash")
+	}
+	if ItemSummaryInputHash(contentHash, nil, &PromptResolution{PromptKey: "summary.default", PromptSource: "template_version", PromptVersionID: &v2}) == template {
+		t.Fatal("prompt version change kept the input hash")
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/urlutil"
+)
+
+const (
+	DiscussionPlatformHackerNews = "hackernews"
+	DiscussionPlatformReddit     = "reddit"
+)
+
+// DiscussionFinder looks an article URL up on Hacker News (through the Algolia search API) and
+// Reddit and returns the most popular thread on each that links to it.
+type DiscussionFinder struct {
+	http          *http.Client
+	hnSearchURL   string
+	hnItemBaseURL string
+	redditInfoURL string
+	redditBaseURL string
+}
+
+func NewDiscussionFinder() *DiscussionFinder {
+	return &DiscussionFinder{
+		http:          &http.Client{Timeout: 15 * time.Second},
+		hnSearchURL:   "https://hn.algolia.com/api/v1/search",
+		hnItemBaseURL: "https://news.ycombinator.com/item?id=",
+		redditInfoURL: "https://www.reddit.com/api/info.json",
+		redditBaseURL: "https://www.reddit.com",
+	}
+}
+
+// Find returns at most one discussion per platform. A platform that fails is skipped as long as
+// the other answered; the error is returned only when every lookup failed.
+func (f *DiscussionFinder) Find(ctx context.Context, rawURLs ...string) ([]model.ItemDiscussion, error) {
+	targets := discussionTargetKeys(rawURLs)
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	var out []model.ItemDiscussion
+	var errs []string
+	lookups := []struct {
+		platform string
+		find     func(context.Context, string, map[string]bool) (*model.ItemDiscussion, error)
+	}{
+		{DiscussionPlatformHackerNews, f.findHackerNews},
+		{DiscussionPlatformReddit, f.findReddit},
+	}
+	for _, lookup := range lookups {
+		var best *model.ItemDiscussion
+		for _, raw := range rawURLs {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			d, err := lookup.find(ctx, raw, targets)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", lookup.platform, err))
+				continue
+			}
+			if d != nil && (best == nil || d.Points > best.Points) {
+				best = d
+			}
+		}
+		if best != nil {
+			out = append(out, *best)
+		}
+	}
+	if len(out) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("discussion lookup: %s", strings.Join(errs, "; "))
+	}
+	return out, nil
+}
+
+func discussionTargetKeys(rawURLs []string) map[string]bool {
+	keys := map[string]bool{}
+	for _, raw := range rawURLs {
+		if key := urlutil.Canonicalize(strings.TrimSpace(raw)); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+func (f *DiscussionFinder) getJSON(ctx context.Context, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "Sifto/1.0")
+	req.Header.Set("Accept", "application/json")
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (f *DiscussionFinder) findHackerNews(ctx context.Context, rawURL string, targets map[string]bool) (*model.ItemDiscussion, error) {
+	q := url.Values{}
+	q.Set("query", rawURL)
+	q.Set("tags", "story")
+	q.Set("restrictSearchableAttributes", "url")
+	var res struct {
+		Hits []struct {
+			ObjectID    string `json:"objectID"`
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Points      int    `json:"points"`
+			NumComments int    `json:"num_comments"`
+		} `json:"hits"`
+	}
+	if err := f.getJSON(ctx, f.hnSearchURL+"?"+q.Encode(), &res); err != nil {
+		return nil, err
+	}
+	var best *model.ItemDiscussion
+	for _, hit := range res.Hits {
+		if hit.ObjectID == "" || !targets[urlutil.Canonicalize(hit.URL)] {
+			continue
+		}
+		if best != nil && hit.Points <= best.Points {
+			continue
+		}
+		best = &model.ItemDiscussion{
+			Platform:      DiscussionPlatformHackerNews,
+			ExternalID:    hit.ObjectID,
+			URL:           f.hnItemBaseURL + hit.ObjectID,
+			Title:         discussionTitle(hit.Title),
+			Points:        hit.Points,
+			CommentsCount: hit.NumComments,
+		}
+	}
+	return best, nil
+}
+
+func (f *DiscussionFinder) findReddit(ctx context.Context, rawURL string, targets map[string]bool) (*model.ItemDiscussion, error) {
+	var res struct {
+		Data struct {
+			Children []struct {
+				Data struct {
+					Name        string `json:"name"`
+					Title       string `json:"title"`
+					URL         string `json:"url"`
+					Permalink   string `json:"permalink"`
+					Score       int    `json:"score"`
+					NumComments int    `json:"num_comments"`
+					Over18      bool   `json:"over_18"`
+				} `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	}
+	if err := f.getJSON(ctx, f.redditInfoURL+"?url="+url.QueryEscape(rawURL), &res); err != nil {
+		return nil, err
+	}
+	var best *model.ItemDiscussion
+	for _, child := range res.Data.Children {
+		post := child.Data
+		if post.Name == "" || post.Permalink == "" || post.Over18 || !targets[urlutil.Canonicalize(post.URL)] {
+			continue
+		}
+		if best != nil && post.Score <= best.Points {
+			continue
+		}
+		best = &model.ItemDiscussion{
+			Platform:      DiscussionPlatformReddit,
+			ExternalID:    post.Name,
+			URL:           strings.TrimRight(f.redditBaseURL, "/") + post.Permalink,
+			Title:         discussionTitle(post.Title),
+			Points:        post.Score,
+			CommentsCount: post.NumComments,
+		}
+	}
+	return best, nil
+}
+
+func discussionTitle(title string) *string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil
+	}
+	return &title
+}
+
+type itemDiscussionStore interface {
+	GetTarget(ctx context.Context, itemID string) (*repository.ItemDiscussionTarget, error)
+	Replace(ctx context.Context, itemID string, discussions []model.ItemDiscussion) error
+}
+
+type discussionLookup interface {
+	Find(ctx context.Context, rawURLs ...string) ([]model.ItemDiscussion, error)
+}
+
+// ItemDiscussionService stores the Hacker News and Reddit threads about an item.
+type ItemDiscussionService struct {
+	store  itemDiscussionStore
+	finder discussionLookup
+}
+
+func NewItemDiscussionService(store itemDiscussionStore, finder discussionLookup) *ItemDiscussionService {
+	return &ItemDiscussionService{store: store, finder: finder}
+}
+
+// Enrich looks the item's URL and canonical URL up and replaces its stored discussions. It
+// returns how many were found; a deleted item finds none.
+func (s *ItemDiscussionService) Enrich(ctx context.Context, itemID string) (int, error) {
+	target, err := s.store.GetTarget(ctx, itemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	urls := []string{target.URL}
+	if target.CanonicalURL != nil && *target.CanonicalURL != target.URL {
+		urls = append(urls, *target.CanonicalURL)
+	}
+	discussions, err := s.finder.Find(ctx, urls...)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.store.Replace(ctx, itemID, discussions); err != nil {
+		return 0, err
+	}
+	return len(discussions), nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestDiscussionFinder(t *testing.T, handler http.HandlerFunc) *DiscussionFinder {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &DiscussionFinder{
+		http:          srv.Client(),
+		hnSearchURL:   srv.URL + "/hn/search",
+		hnItemBaseURL: "https://news.ycombinator.com/item?id=",
+		redditInfoURL: srv.URL + "/reddit/info.json",
+		redditBaseURL: "https://www.reddit.com",
+	}
+}
+
+func TestDiscussionFinderPicksMatchingThreads(t *testing.T) {
+	f := newTestDiscussionFinder(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hn/search":
+			if r.URL.Query().Get("query") != "https://example.com/post?utm_source=rss" {
+				t.Errorf("hn query = %q", r.URL.Query().Get("query"))
+			}
+			_, _ = w.Write([]byte(`{"hits":[
+				{"objectID":"1","title":"Other","url":"https://example.com/other","points":900,"num_comments":400},
+				{"objectID":"2","title":"Post","url":"https://example.com/post","points":120,"num_comments":45},
+				{"objectID":"3","title":"Post again","url":"http://www.example.com/post/","points":30,"num_comments":2}
+			]}`))
+		case "/reddit/info.json":
+			if r.Header.Get("User-Agent") == "" {
+				t.Errorf("reddit request without User-Agent")
+			}
+			_, _ = w.Write([]byte(`{"data":{"children":[
+				{"data":{"name":"t3_a","title":"Post","url":"https://example.com/post","permalink":"/r/golang/comments/a/post/","score":75,"num_comments":12}},
+				{"data":{"name":"t3_b","title":"NSFW","url":"https://example.com/post","permalink":"/r/x/comments/b/","score":500,"num_comments":9,"over_18":true}}
+			]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	got, err := f.Find(context.Background(), "https://example.com/post?utm_source=rss")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2: %+v", len(got), got)
+	}
+	hn, reddit := got[0], got[1]
+	if hn.Platform != DiscussionPlatformHackerNews || hn.ExternalID != "2" || hn.URL != "https://news.ycombinator.com/item?id=2" || hn.Points != 120 || hn.CommentsCount != 45 {
+		t.Fatalf("hn = %+v", hn)
+	}
+	if reddit.Platform != DiscussionPlatformReddit || reddit.ExternalID != "t3_a" || reddit.URL != "https://www.reddit.com/r/golang/comments/a/post/" || reddit.Points != 75 {
+		t.Fatalf("reddit = %+v", reddit)
+	}
+}
+
+func TestDiscussionFinderToleratesOnePlatformFailing(t *testing.T) {
+	f := newTestDiscussionFinder(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reddit/info.json" {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"9","title":"Post","url":"https://example.com/post","points":10,"num_comments":1}]}`))
+	})
+
+	got, err := f.Find(context.Background(), "https://example.com/post")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Platform != DiscussionPlatformHackerNews {
+		t.Fatalf("got = %+v", got)
+	}
+}
+
+func TestDiscussionFinderFailsWhenEveryLookupFails(t *testing.T) {
+	f := newTestDiscussionFinder(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	})
+
+	if _, err := f.Find(context.Background(), "https://example.com/post"); err == nil {
+		t.Fatal("Find() error = nil, want error")
+	}
+}
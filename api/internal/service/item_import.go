@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/urlutil"
+	"golang.org/x/net/html"
+)
+
+const (
+	ItemImportPocket     = "pocket"
+	ItemImportInstapaper = "instapaper"
+	ItemImportBookmarks  = "bookmarks"
+
+	maxItemImportEntries = 5000
+)
+
+var ErrItemImportEmpty = errors.New("no importable entries")
+
+// itemImportSources are the dedicated manual sources imported items are filed under.
+var itemImportSources = map[string]struct{ URL, Title string }{
+	ItemImportPocket:     {URL: "https://getpocket.com/", Title: "Pocket"},
+	ItemImportInstapaper: {URL: "https://www.instapaper.com/", Title: "Instapaper"},
+	// Bookmarks and history have no single origin site, so the source gets a placeholder URL.
+	ItemImportBookmarks: {URL: "import://bookmarks", Title: "Bookmarks"},
+}
+
+// ItemImportEntry is one saved article from an export file.
+type ItemImportEntry struct {
+	URL      string
+	Title    string
+	AddedAt  *time.Time
+	Archived bool
+}
+
+type itemImportSourceStore interface {
+	EnsureImportSource(ctx context.Context, userID, url, title string) (*model.Source, error)
+}
+
+type importJobStarter interface {
+	Start(ctx context.Context, userID, kind string, sourceID *string, entries []model.ImportJobEntry, invalid int) (*model.ImportJob, error)
+}
+
+// ItemImportService turns a read-later archive, bookmarks file or URL list into an import job
+// that creates manual items and feeds them through the normal processing pipeline.
+type ItemImportService struct {
+	sources itemImportSourceStore
+	jobs    importJobStarter
+}
+
+func NewItemImportService(sources itemImportSourceStore, jobs importJobStarter) *ItemImportService {
+	return &ItemImportService{sources: sources, jobs: jobs}
+}
+
+// StartJob queues the entries under the provider's import source. Entries already known to the
+// user (by canonical URL, across all sources) end up as duplicates; archived entries are marked
+// read. The newest entries are imported first.
+func (s *ItemImportService) StartJob(ctx context.Context, userID, provider string, entries []ItemImportEntry) (*model.ImportJob, error) {
+	target, ok := itemImportSources[provider]
+	if !ok {
+		return nil, &ValidationError{Field: "provider", Message: "unsupported import provider"}
+	}
+	valid, invalid := dedupeItemImportEntries(entries)
+	if len(valid) == 0 {
+		return nil, ErrItemImportEmpty
+	}
+	if len(valid) > maxItemImportEntries {
+		valid = valid[:maxItemImportEntries]
+	}
+	src, err := s.sources.EnsureImportSource(ctx, userID, target.URL, target.Title)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]model.ImportJobEntry, 0, len(valid))
+	for _, e := range valid {
+		row := model.ImportJobEntry{URL: e.URL, AddedAt: e.AddedAt, Archived: e.Archived}
+		if e.Title != "" {
+			v := e.Title
+			row.Title = &v
+		}
+		rows = append(rows, row)
+	}
+	return s.jobs.Start(ctx, userID, provider, &src.ID, rows, invalid)
+}
+
+// dedupeItemImportEntries drops entries without an http(s) URL and repeats of the same
+// canonical URL, and orders the rest newest first.
+func dedupeItemImportEntries(entries []ItemImportEntry) ([]ItemImportEntry, int) {
+	out := make([]ItemImportEntry, 0, len(entries))
+	seen := map[string]bool{}
+	invalid := 0
+	for _, e := range entries {
+		e.URL = strings.TrimSpace(e.URL)
+		e.Title = strings.TrimSpace(e.Title)
+		u, err := url.ParseRequestURI(e.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			invalid++
+			continue
+		}
+		key := urlutil.Canonicalize(e.URL)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].AddedAt, out[j].AddedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.After(*b)
+	})
+	return out, invalid
+}
+
+// ParsePocketExport reads Pocket's export, either the CSV (title,url,time_added,tags,status) or
+// the older ril_export.html where archived links follow a "Read Archive" heading.
+func ParsePocketExport(data string) ([]ItemImportEntry, error) {
+	trimmed := trimItemImportData(data)
+	if strings.HasPrefix(trimmed, "<") {
+		return parseHTMLLinkExport(trimmed, true), nil
+	}
+	rows, cols, err := readItemImportCSV(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ItemImportEntry, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ItemImportEntry{
+			URL:      csvField(row, cols, "url"),
+			Title:    csvField(row, cols, "title"),
+			AddedAt:  parseUnixField(csvField(row, cols, "time_added")),
+			Archived: strings.EqualFold(csvField(row, cols, "status"), "archive"),
+		})
+	}
+	return out, nil
+}
+
+// ParseInstapaperExport reads Instapaper's CSV export (URL,Title,Selection,Folder,Timestamp).
+func ParseInstapaperExport(data string) ([]ItemImportEntry, error) {
+	rows, cols, err := readItemImportCSV(trimItemImportData(data))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ItemImportEntry, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ItemImportEntry{
+			URL:      csvField(row, cols, "url"),
+			Title:    csvField(row, cols, "title"),
+			AddedAt:  parseUnixField(csvField(row, cols, "timestamp")),
+			Archived: strings.EqualFold(csvField(row, cols, "folder"), "archive"),
+		})
+	}
+	return out, nil
+}
+
+// ParseBookmarksHTML reads a Netscape bookmark file as exported by every major browser.
+func ParseBookmarksHTML(data string) []ItemImportEntry {
+	return parseHTMLLinkExport(trimItemImportData(data), false)
+}
+
+// ImportURLEntry is one element of a JSON URL list, e.g. an exported browser history. Timestamp
+// is Unix seconds, Unix milliseconds or an RFC 3339 string.
+type ImportURLEntry struct {
+	URL       string          `json:"url"`
+	Title     string          `json:"title"`
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+func ImportEntriesFromURLList(list []ImportURLEntry) []ItemImportEntry {
+	out := make([]ItemImportEntry, 0, len(list))
+	for _, e := range list {
+		out = append(out, ItemImportEntry{URL: e.URL, Title: e.Title, AddedAt: parseImportTimestamp(e.Timestamp)})
+	}
+	return out
+}
+
+func parseImportTimestamp(raw json.RawMessage) *time.Time {
+	if len(raw) == 0 {
+		return nil
+	}
+	var n float64
+	if err := json.Unmarshal(raw, &n); err == nil && n > 0 {
+		// Anything past the year 2286 in seconds is a millisecond timestamp.
+		if n > 1e10 {
+			t := time.UnixMilli(int64(n))
+			return &t
+		}
+		t := time.Unix(int64(n), 0)
+		return &t
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(s)); err == nil {
+			return &t
+		}
+		return parseUnixField(s)
+	}
+	return nil
+}
+
+// parseHTMLLinkExport collects the links of an HTML export. With archiveHeadings, links after a
+// heading mentioning "archive" (Pocket's "Read Archive") are marked archived.
+func parseHTMLLinkExport(data string, archiveHeadings bool) []ItemImportEntry {
+	out := make([]ItemImportEntry, 0)
+	z := html.NewTokenizer(strings.NewReader(data))
+	archived := false
+	inHeading := false
+	var current *ItemImportEntry
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return out
+		case html.StartTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "h1", "h2":
+				inHeading = true
+			case "a":
+				e := ItemImportEntry{Archived: archived}
+				for _, attr := range tok.Attr {
+					switch attr.Key {
+					case "href":
+						e.URL = attr.Val
+					case "time_added", "add_date":
+						e.AddedAt = parseUnixField(attr.Val)
+					}
+				}
+				current = &e
+			}
+		case html.TextToken:
+			text := strings.TrimSpace(string(z.Text()))
+			if archiveHeadings && inHeading && text != "" {
+				archived = strings.Contains(strings.ToLower(text), "archive")
+			}
+			if current != nil {
+				current.Title += text
+			}
+		case html.EndTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "h1", "h2":
+				inHeading = false
+			case "a":
+				if current != nil {
+					out = append(out, *current)
+					current = nil
+				}
+			}
+		}
+	}
+}
+
+// readItemImportCSV returns the data rows and a lower-cased header index.
+func readItemImportCSV(data string) ([][]string, map[string]int, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, ErrItemImportEmpty
+		}
+		return nil, nil, &ValidationError{Field: "data", Message: "invalid csv"}
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := cols["url"]; !ok {
+		return nil, nil, &ValidationError{Field: "data", Message: "csv has no url column"}
+	}
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, &ValidationError{Field: "data", Message: "invalid csv"}
+	}
+	return rows, cols, nil
+}
+
+// trimItemImportData strips the byte order mark spreadsheet tools prepend to exported CSV.
+func trimItemImportData(data string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(data), "\ufeff"))
+}
+
+func csvField(row []string, cols map[string]int, name string) string {
+	i, ok := cols[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func parseUnixField(v string) *time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	t := time.Unix(n, 0)
+	return &t
+}
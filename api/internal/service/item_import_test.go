@@ -0,0 +1,86 @@
+package service
+
+import "testing"
+
+func TestParsePocketExportCSV(t *testing.T) {
+	data := "\ufefftitle,url,time_added,tags,status\n" +
+		"Old,https://a.example/old,1600000000,,archive\n" +
+		"\"New, with comma\",https://a.example/new,1700000000,go,unread\n"
+	entries, err := ParsePocketExport(data)
+	if err != nil {
+		t.Fatalf("ParsePocketExport: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries[0].URL != "https://a.example/old" || !entries[0].Archived || entries[0].AddedAt == nil || entries[0].AddedAt.Unix() != 1600000000 {
+		t.Fatalf("first = %+v", entries[0])
+	}
+	if entries[1].Title != "New, with comma" || entries[1].Archived {
+		t.Fatalf("second = %+v", entries[1])
+	}
+}
+
+func TestParsePocketExportHTML(t *testing.T) {
+	data := `<!DOCTYPE html><html><body>
+<h1>Unread</h1><ul><li><a href="https://a.example/1" time_added="1700000000" tags="">First</a></li></ul>
+<h1>Read Archive</h1><ul><li><a href="https://a.example/2" time_added="1600000000">Second</a></li></ul>
+</body></html>`
+	entries, err := ParsePocketExport(data)
+	if err != nil {
+		t.Fatalf("ParsePocketExport: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Title != "First" || entries[0].Archived || !entries[1].Archived {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestParseInstapaperExport(t *testing.T) {
+	data := "URL,Title,Selection,Folder,Timestamp\n" +
+		"https://b.example/1,One,,Unread,1700000000\n" +
+		"https://b.example/2,Two,,Archive,1600000000\n"
+	entries, err := ParseInstapaperExport(data)
+	if err != nil {
+		t.Fatalf("ParseInstapaperExport: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Archived || !entries[1].Archived || entries[1].Title != "Two" {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if _, err := ParseInstapaperExport("Title,Folder\nx,y\n"); err == nil {
+		t.Fatal("expected error for csv without url column")
+	}
+}
+
+func TestParseBookmarksHTML(t *testing.T) {
+	data := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+<DT><H3 ADD_DATE="1600000000">Folder</H3>
+<DL><p><DT><A HREF="https://e.example/1" ADD_DATE="1700000000">One</A></DL><p>
+<DT><A HREF="https://e.example/2">Two</A>
+</DL><p>`
+	entries := ParseBookmarksHTML(data)
+	if len(entries) != 2 || entries[0].Title != "One" || entries[0].AddedAt == nil || entries[0].AddedAt.Unix() != 1700000000 || entries[1].AddedAt != nil {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestImportEntriesFromURLList(t *testing.T) {
+	entries := ImportEntriesFromURLList([]ImportURLEntry{
+		{URL: "https://f.example/s", Timestamp: []byte("1700000000")},
+		{URL: "https://f.example/ms", Timestamp: []byte("1700000000000")},
+		{URL: "https://f.example/rfc", Timestamp: []byte(`"2023-11-14T22:13:20Z"`)},
+		{URL: "https://f.example/none"},
+	})
+	if len(entries) != 4 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	for _, e := range entries[:3] {
+		if e.AddedAt == nil || e.AddedAt.Unix() != 1700000000 {
+			t.Fatalf("entry %s added_at = %v", e.URL, e.AddedAt)
+		}
+	}
+	if entries[3].AddedAt != nil {
+		t.Fatalf("entry without timestamp = %+v", entries[3])
+	}
+}
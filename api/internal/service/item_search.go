@@ -32,10 +32,10 @@ var itemGenreFilterKeys = map[string]struct{}{
 
 type ItemSearchService struct {
 	search *MeilisearchService
-	repo   *repository.ItemRepo
+	repo   repository.ItemReader
 }
 
-func NewItemSearchService(search *MeilisearchService, repo *repository.ItemRepo) *ItemSearchService {
+func NewItemSearchService(search *MeilisearchService, repo repository.ItemReader) *ItemSearchService {
 	return &ItemSearchService{search: search, repo: repo}
 }
 
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxItemSnapshotBytes          = 5 << 20
+	itemSnapshotPresignExpiresSec = 60
+)
+
+var ErrItemSnapshotStorageNotConfigured = errors.New("item snapshot storage bucket is not configured")
+
+// snapshotDroppedAtoms are removed together with their content: anything that runs code, loads
+// other documents, submits data or redirects the page.
+var snapshotDroppedAtoms = map[atom.Atom]bool{
+	atom.Script: true, atom.Noscript: true, atom.Style: true, atom.Link: true, atom.Meta: true,
+	atom.Base: true, atom.Iframe: true, atom.Frame: true, atom.Frameset: true, atom.Object: true,
+	atom.Embed: true, atom.Applet: true, atom.Form: true, atom.Input: true, atom.Button: true,
+	atom.Select: true, atom.Textarea: true, atom.Template: true, atom.Svg: true, atom.Math: true,
+	atom.Canvas: true, atom.Dialog: true,
+}
+
+var snapshotURLAttrs = map[string]bool{"href": true, "src": true, "poster": true, "cite": true, "background": true}
+
+type itemSnapshotStore interface {
+	GetTarget(ctx context.Context, itemID string) (*repository.ItemHTMLSnapshotTarget, error)
+	Get(ctx context.Context, itemID, userID string) (*model.ItemHTMLSnapshot, error)
+	Upsert(ctx context.Context, v model.ItemHTMLSnapshot) error
+}
+
+type itemSnapshotStorage interface {
+	UploadAudioBriefingObject(ctx context.Context, bucket string, objectKey string, contentBase64 string, contentType string) (*AudioBriefingUploadObjectResponse, error)
+	PresignAudioBriefingObjectInBucket(ctx context.Context, objectKey string, bucket string, expiresSec int) (*AudioBriefingPresignResponse, error)
+}
+
+// ItemSnapshotService keeps a sanitized copy of an item's page in blob storage so the article
+// stays readable after the original goes away.
+type ItemSnapshotService struct {
+	store   itemSnapshotStore
+	storage itemSnapshotStorage
+	fetch   func(ctx context.Context, url string) (*html.Node, string, error)
+	http    *http.Client
+	now     func() time.Time
+}
+
+func NewItemSnapshotService(store itemSnapshotStore, storage itemSnapshotStorage) *ItemSnapshotService {
+	return &ItemSnapshotService{
+		store:   store,
+		storage: storage,
+		fetch:   NewReadabilityExtractor().fetchDocument,
+		http:    &http.Client{Timeout: 30 * time.Second},
+		now:     time.Now,
+	}
+}
+
+func ItemSnapshotBucketFromEnv() string {
+	return firstNonEmptyTrimmed(os.Getenv("ITEM_SNAPSHOT_R2_BUCKET"), AudioBriefingStandardBucketFromEnv())
+}
+
+func ItemSnapshotObjectKey(userID, itemID string) string {
+	return fmt.Sprintf("item-snapshots/%s/%s.html", strings.TrimSpace(userID), strings.TrimSpace(itemID))
+}
+
+// Capture stores the snapshot of an item whose owner has snapshots enabled. The first capture
+// is kept, so reprocessing an item does not replace the original page. It reports whether a
+// snapshot was written.
+func (s *ItemSnapshotService) Capture(ctx context.Context, itemID string) (bool, error) {
+	target, err := s.store.GetTarget(ctx, itemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !target.Enabled || target.HasSnapshot {
+		return false, nil
+	}
+	bucket := ItemSnapshotBucketFromEnv()
+	if bucket == "" {
+		return false, ErrItemSnapshotStorageNotConfigured
+	}
+	doc, finalURL, err := s.fetch(ctx, target.URL)
+	if err != nil {
+		return false, err
+	}
+	base, err := url.Parse(finalURL)
+	if err != nil {
+		return false, fmt.Errorf("item snapshot base url: %w", err)
+	}
+	page, err := SanitizeSnapshotHTML(doc, base)
+	if err != nil {
+		return false, err
+	}
+	objectKey := ItemSnapshotObjectKey(target.UserID, target.ItemID)
+	uploaded, err := s.storage.UploadAudioBriefingObject(ctx, bucket, objectKey, base64.StdEncoding.EncodeToString(page), "text/html; charset=utf-8")
+	if err != nil {
+		return false, err
+	}
+	if uploaded != nil && strings.TrimSpace(uploaded.ObjectKey) != "" {
+		objectKey = strings.TrimSpace(uploaded.ObjectKey)
+	}
+	err = s.store.Upsert(ctx, model.ItemHTMLSnapshot{
+		ItemID:     target.ItemID,
+		Bucket:     bucket,
+		ObjectKey:  objectKey,
+		SourceURL:  finalURL,
+		SizeBytes:  len(page),
+		CapturedAt: s.now(),
+	})
+	return err == nil, err
+}
+
+// Load returns the user's snapshot of an item and its HTML.
+func (s *ItemSnapshotService) Load(ctx context.Context, itemID, userID string) (*model.ItemHTMLSnapshot, []byte, error) {
+	snap, err := s.store.Get(ctx, itemID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	presigned, err := s.storage.PresignAudioBriefingObjectInBucket(ctx, snap.ObjectKey, snap.Bucket, itemSnapshotPresignExpiresSec)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, presigned.AudioURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("item snapshot download: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("item snapshot download: status %d", resp.StatusCode)
+	}
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxItemSnapshotBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("item snapshot download: %w", err)
+	}
+	return snap, page, nil
+}
+
+// SanitizeSnapshotHTML renders doc without scripts, embedded documents, forms, styles, event
+// handlers or non-http(s) links. Relative URLs are resolved against base so the copy works
+// away from its origin. doc is modified in place.
+func SanitizeSnapshotHTML(doc *html.Node, base *url.URL) ([]byte, error) {
+	var remove []*html.Node
+	walkHTML(doc, func(n *html.Node) bool {
+		switch n.Type {
+		case html.CommentNode:
+			remove = append(remove, n)
+			return false
+		case html.ElementNode:
+			if snapshotDroppedAtoms[n.DataAtom] || n.Namespace != "" {
+				remove = append(remove, n)
+				return false
+			}
+			n.Attr = sanitizeSnapshotAttrs(n, base)
+		}
+		return true
+	})
+	for _, n := range remove {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Head {
+			return true
+		}
+		n.InsertBefore(&html.Node{
+			Type:     html.ElementNode,
+			DataAtom: atom.Meta,
+			Data:     "meta",
+			Attr:     []html.Attribute{{Key: "charset", Val: "utf-8"}},
+		}, n.FirstChild)
+		return false
+	})
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return nil, fmt.Errorf("item snapshot render: %w", err)
+	}
+	if buf.Len() > maxItemSnapshotBytes {
+		return nil, fmt.Errorf("item snapshot exceeds %d bytes", maxItemSnapshotBytes)
+	}
+	return buf.Bytes(), nil
+}
+
+func sanitizeSnapshotAttrs(n *html.Node, base *url.URL) []html.Attribute {
+	out := n.Attr[:0]
+	for _, a := range n.Attr {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" || strings.HasPrefix(key, "on") || key == "style" || key == "srcset" || key == "formaction" {
+			continue
+		}
+		if snapshotURLAttrs[key] {
+			resolved, ok := snapshotURL(a.Val, base, n.DataAtom == atom.Img && key == "src")
+			if !ok {
+				continue
+			}
+			a.Val = resolved
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// snapshotURL resolves raw against base and keeps only http(s) and mailto links, plus inline
+// data images when allowImageData is set.
+func snapshotURL(raw string, base *url.URL, allowImageData bool) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if allowImageData && strings.HasPrefix(strings.ToLower(raw), "data:image/") {
+		return raw, true
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return u.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"golang.org/x/net/html"
+)
+
+func TestSanitizeSnapshotHTMLStripsActiveContent(t *testing.T) {
+	page := `<html><head><title>Story</title><meta http-equiv="refresh" content="0;url=https://evil.example">
+<style>body{background:url(https://tracker.example/x)}</style><script>alert(1)</script></head>
+<body onload="steal()"><!-- comment --><article style="color:red"><h1>Story</h1>
+<p>Body <a href="/next" onclick="x()">next</a> <a href="javascript:alert(1)">bad</a></p>
+<img src="img/a.png" srcset="a.png 2x"><img src="data:image/png;base64,AAAA">
+<iframe src="https://ads.example"></iframe><form action="/post"><input name="q"></form>
+<svg><script>alert(2)</script></svg></article></body></html>`
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := url.Parse("https://example.com/posts/story")
+
+	out, err := SanitizeSnapshotHTML(doc, base)
+	if err != nil {
+		t.Fatalf("SanitizeSnapshotHTML() error = %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{`<meta charset="utf-8"/>`, `<h1>Story</h1>`, `href="https://example.com/next"`,
+		`src="https://example.com/posts/img/a.png"`, `src="data:image/png;base64,AAAA"`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("snapshot missing %q:\n%s", want, got)
+		}
+	}
+	for _, bad := range []string{"<script", "alert", "refresh", "<style", "tracker", "onload", "onclick", "javascript:",
+		"srcset", "style=", "<iframe", "<form", "<input", "<svg", "comment"} {
+		if strings.Contains(got, bad) {
+			t.Fatalf("snapshot contains %q:\n%s", bad, got)
+		}
+	}
+}
+
+type fakeItemSnapshotStore struct {
+	target *repository.ItemHTMLSnapshotTarget
+	saved  []model.ItemHTMLSnapshot
+}
+
+func (f *fakeItemSnapshotStore) GetTarget(context.Context, string) (*repository.ItemHTMLSnapshotTarget, error) {
+	if f.target == nil {
+		return nil, repository.ErrNotFound
+	}
+	return f.target, nil
+}
+
+func (f *fakeItemSnapshotStore) Get(context.Context, string, string) (*model.ItemHTMLSnapshot, error) {
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeItemSnapshotStore) Upsert(_ context.Context, v model.ItemHTMLSnapshot) error {
+	f.saved = append(f.saved, v)
+	return nil
+}
+
+type fakeItemSnapshotStorage struct {
+	uploads map[string]string
+}
+
+func (f *fakeItemSnapshotStorage) UploadAudioBriefingObject(_ context.Context, bucket, objectKey, contentBase64, _ string) (*AudioBriefingUploadObjectResponse, error) {
+	raw, _ := base64.StdEncoding.DecodeString(contentBase64)
+	f.uploads[bucket+"/"+objectKey] = string(raw)
+	return &AudioBriefingUploadObjectResponse{ObjectKey: objectKey}, nil
+}
+
+func (f *fakeItemSnapshotStorage) PresignAudioBriefingObjectInBucket(context.Context, string, string, int) (*AudioBriefingPresignResponse, error) {
+	return &AudioBriefingPresignResponse{}, nil
+}
+
+func TestItemSnapshotCaptureStoresOnlyEnabledNewSnapshots(t *testing.T) {
+	t.Setenv("ITEM_SNAPSHOT_R2_BUCKET", "snapshots")
+	store := &fakeItemSnapshotStore{target: &repository.ItemHTMLSnapshotTarget{ItemID: "i1", UserID: "u1", URL: "https://example.com/a", Enabled: true}}
+	storage := &fakeItemSnapshotStorage{uploads: map[string]string{}}
+	svc := NewItemSnapshotService(store, storage)
+	fetches := 0
+	svc.fetch = func(_ context.Context, rawURL string) (*html.Node, string, error) {
+		fetches++
+		doc, err := html.Parse(strings.NewReader(`<p>Hello <script>x()</script></p>`))
+		return doc, rawURL + "?final", err
+	}
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC) }
+
+	captured, err := svc.Capture(context.Background(), "i1")
+	if err != nil || !captured {
+		t.Fatalf("Capture() = %v, %v", captured, err)
+	}
+	page := storage.uploads["snapshots/item-snapshots/u1/i1.html"]
+	if !strings.Contains(page, "<p>Hello </p>") || strings.Contains(page, "script") {
+		t.Fatalf("uploaded page = %q", page)
+	}
+	if len(store.saved) != 1 || store.saved[0].SourceURL != "https://example.com/a?final" || store.saved[0].SizeBytes != len(page) {
+		t.Fatalf("saved = %+v", store.saved)
+	}
+
+	store.target.HasSnapshot = true
+	if captured, err := svc.Capture(context.Background(), "i1"); err != nil || captured {
+		t.Fatalf("Capture() with existing snapshot = %v, %v", captured, err)
+	}
+	store.target.HasSnapshot, store.target.Enabled = false, false
+	if captured, err := svc.Capture(context.Background(), "i1"); err != nil || captured {
+		t.Fatalf("Capture() with snapshots disabled = %v, %v", captured, err)
+	}
+	if fetches != 1 {
+		t.Fatalf("fetches = %d, want 1", fetches)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+var SupportedOutputLanguages = []string{"ja", "en", "zh", "ko", "fr", "de", "es"}
+
+const languageDetectSampleRunes = 4000
+
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "for", "with", "this"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "pour", "dans", "que"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "ein", "für", "auf"},
+	"es": {"el", "los", "las", "y", "es", "una", "por", "para", "con", "que"},
+}
+
+func IsSupportedOutputLanguage(code string) bool {
+	for _, v := range SupportedOutputLanguages {
+		if v == code {
+			return true
+		}
+	}
+	return false
+}
+
+func NormalizeOutputLanguage(code *string) *string {
+	if code == nil {
+		return nil
+	}
+	v := strings.ToLower(strings.TrimSpace(*code))
+	if !IsSupportedOutputLanguage(v) {
+		return nil
+	}
+	return &v
+}
+
+// DetectContentLanguage guesses a language code from script ratios and common stopwords.
+// It returns an empty string when the text is too short or ambiguous.
+func DetectContentLanguage(text string) string {
+	var kana, hangul, han, latin int
+	n := 0
+	for _, r := range text {
+		if n >= languageDetectSampleRunes {
+			break
+		}
+		n++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	letters := kana + hangul + han + latin
+	if letters < 20 {
+		return ""
+	}
+	if kana > 0 && float64(kana+han)/float64(letters) >= 0.2 {
+		return "ja"
+	}
+	if float64(hangul)/float64(letters) >= 0.2 {
+		return "ko"
+	}
+	if float64(han)/float64(letters) >= 0.2 {
+		return "zh"
+	}
+	if float64(latin)/float64(letters) < 0.5 {
+		return ""
+	}
+	return detectLatinLanguage(text)
+}
+
+func detectLatinLanguage(text string) string {
+	counts := map[string]int{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) > 1000 {
+		words = words[:1000]
+	}
+	for _, w := range words {
+		for lang, stopwords := range latinStopwords {
+			for _, sw := range stopwords {
+				if w == sw {
+					counts[lang]++
+					break
+				}
+			}
+		}
+	}
+	best := ""
+	bestCount := 0
+	for _, lang := range []string{"en", "fr", "de", "es"} {
+		if counts[lang] > bestCount {
+			best = lang
+			bestCount = counts[lang]
+		}
+	}
+	if bestCount < 3 {
+		return ""
+	}
+	return best
+}
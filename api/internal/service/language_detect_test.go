@@ -0,0 +1,40 @@
+package service
+
+import "testing"
+
+func TestDetectContentLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "japanese", text: "生成AIの新しいモデルが発表されました。開発者向けのAPIも同時に公開され、利用料金も引き下げられています。", want: "ja"},
+		{name: "korean", text: "새로운 인공지능 모델이 발표되었습니다. 개발자를 위한 API도 함께 공개되었으며 요금도 인하되었습니다.", want: "ko"},
+		{name: "chinese", text: "新的人工智能模型已经发布。面向开发者的接口也同时公开，并且价格有所下调。", want: "zh"},
+		{name: "english", text: "The new model was released today and the team said that it is faster for most of the workloads in this benchmark.", want: "en"},
+		{name: "german", text: "Das neue Modell ist seit heute verfügbar und die Entwickler sagen, dass es nicht nur schneller ist, sondern auch mit weniger Speicher auskommt.", want: "de"},
+		{name: "too short", text: "Hello", want: ""},
+		{name: "empty", text: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentLanguage(tt.text); got != tt.want {
+				t.Fatalf("DetectContentLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOutputLanguage(t *testing.T) {
+	v := " EN "
+	if got := NormalizeOutputLanguage(&v); got == nil || *got != "en" {
+		t.Fatalf("NormalizeOutputLanguage(%q) = %v, want en", v, got)
+	}
+	bad := "xx"
+	if got := NormalizeOutputLanguage(&bad); got != nil {
+		t.Fatalf("NormalizeOutputLanguage(%q) = %v, want nil", bad, *got)
+	}
+	if got := NormalizeOutputLanguage(nil); got != nil {
+		t.Fatalf("NormalizeOutputLanguage(nil) = %v, want nil", *got)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	LLMBudgetPurposeFacts            = "facts"
+	LLMBudgetPurposeSummary          = "summary"
+	LLMBudgetPurposeDigest           = "digest"
+	LLMBudgetPurposeEmbedding        = "embedding"
+	LLMBudgetPurposeSourceSuggestion = "source_suggestion"
+)
+
+var LLMBudgetPurposes = []string{
+	LLMBudgetPurposeFacts,
+	LLMBudgetPurposeSummary,
+	LLMBudgetPurposeDigest,
+	LLMBudgetPurposeEmbedding,
+	LLMBudgetPurposeSourceSuggestion,
+}
+
+// llmBudgetUsagePurposes maps a budget purpose to the llm_usage_logs purposes billed against it.
+var llmBudgetUsagePurposes = map[string][]string{
+	LLMBudgetPurposeFacts:            {"facts", "facts_localization", "facts_check"},
+	LLMBudgetPurposeSummary:          {"summary", "faithfulness_check"},
+	LLMBudgetPurposeDigest:           {"digest", "digest_cluster_draft"},
+	LLMBudgetPurposeEmbedding:        {"embedding"},
+	LLMBudgetPurposeSourceSuggestion: {"source_suggestion"},
+}
+
+func IsSupportedLLMBudgetPurpose(purpose string) bool {
+	return slices.Contains(LLMBudgetPurposes, purpose)
+}
+
+func LLMUsagePurposesForBudget(purpose string) []string {
+	return llmBudgetUsagePurposes[purpose]
+}
+
+// LLMBudgetPurposeForUsage returns the budget purpose a usage log purpose counts toward, or "".
+func LLMBudgetPurposeForUsage(usagePurpose string) string {
+	for budgetPurpose, usagePurposes := range llmBudgetUsagePurposes {
+		if slices.Contains(usagePurposes, usagePurpose) {
+			return budgetPurpose
+		}
+	}
+	return ""
+}
+
+type LLMBudgetAllocationInput struct {
+	Purpose       string  `json:"purpose"`
+	MonthlyCapUSD float64 `json:"monthly_cap_usd"`
+}
+
+type LLMBudgetAllocationView struct {
+	Purpose          string   `json:"purpose"`
+	MonthlyCapUSD    *float64 `json:"monthly_cap_usd"`
+	UsedCostUSD      float64  `json:"used_cost_usd"`
+	RemainingUSD     *float64 `json:"remaining_usd"`
+	RemainingPct     *float64 `json:"remaining_pct"`
+	Exceeded         bool     `json:"exceeded"`
+	IncludedPurposes []string `json:"included_purposes"`
+}
+
+type LLMBudgetAllocationSummary struct {
+	MonthJST         string                    `json:"month_jst"`
+	MonthlyBudgetUSD *float64                  `json:"monthly_budget_usd"`
+	AllocatedUSD     float64                   `json:"allocated_usd"`
+	UnallocatedUSD   *float64                  `json:"unallocated_usd"`
+	Allocations      []LLMBudgetAllocationView `json:"allocations"`
+}
+
+type LLMBudgetAllocationService struct {
+	repo         *repository.LLMBudgetAllocationRepo
+	usageRepo    *repository.LLMUsageLogRepo
+	settingsRepo *repository.UserSettingsRepo
+}
+
+func NewLLMBudgetAllocationService(repo *repository.LLMBudgetAllocationRepo, usageRepo *repository.LLMUsageLogRepo, settingsRepo *repository.UserSettingsRepo) *LLMBudgetAllocationService {
+	return &LLMBudgetAllocationService{repo: repo, usageRepo: usageRepo, settingsRepo: settingsRepo}
+}
+
+func (s *LLMBudgetAllocationService) Summary(ctx context.Context, userID string, month time.Time) (*LLMBudgetAllocationSummary, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.usageRepo.PurposeSummaryByUserMonth(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	var monthlyBudget *float64
+	if settings != nil {
+		monthlyBudget = settings.MonthlyBudgetUSD
+	}
+	start, _ := BudgetMonthWindowJST(month)
+	return buildLLMBudgetAllocationSummary(start.Format("2006-01"), monthlyBudget, allocations, usage), nil
+}
+
+func buildLLMBudgetAllocationSummary(monthJST string, monthlyBudgetUSD *float64, allocations []model.LLMBudgetAllocation, usage []repository.LLMUsagePurposeMonthSummary) *LLMBudgetAllocationSummary {
+	used := map[string]float64{}
+	for _, row := range usage {
+		if purpose := LLMBudgetPurposeForUsage(row.Purpose); purpose != "" {
+			used[purpose] += row.EstimatedCostUSD
+		}
+	}
+	caps := map[string]float64{}
+	for _, a := range allocations {
+		caps[a.Purpose] = a.MonthlyCapUSD
+	}
+	out := &LLMBudgetAllocationSummary{
+		MonthJST:         monthJST,
+		MonthlyBudgetUSD: monthlyBudgetUSD,
+		Allocations:      make([]LLMBudgetAllocationView, 0, len(LLMBudgetPurposes)),
+	}
+	for _, purpose := range LLMBudgetPurposes {
+		v := LLMBudgetAllocationView{
+			Purpose:          purpose,
+			UsedCostUSD:      used[purpose],
+			IncludedPurposes: LLMUsagePurposesForBudget(purpose),
+		}
+		if capUSD, ok := caps[purpose]; ok {
+			remaining := capUSD - v.UsedCostUSD
+			pct := (remaining / capUSD) * 100
+			v.MonthlyCapUSD = &capUSD
+			v.RemainingUSD = &remaining
+			v.RemainingPct = &pct
+			v.Exceeded = v.UsedCostUSD >= capUSD
+			out.AllocatedUSD += capUSD
+		}
+		out.Allocations = append(out.Allocations, v)
+	}
+	if monthlyBudgetUSD != nil && *monthlyBudgetUSD > 0 {
+		unallocated := *monthlyBudgetUSD - out.AllocatedUSD
+		out.UnallocatedUSD = &unallocated
+	}
+	return out
+}
+
+// Replace validates and stores the full allocation set. The caps may not add up to more than the monthly budget.
+func (s *LLMBudgetAllocationService) Replace(ctx context.Context, userID string, in []LLMBudgetAllocationInput) (*LLMBudgetAllocationSummary, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var monthlyBudget *float64
+	if settings != nil {
+		monthlyBudget = settings.MonthlyBudgetUSD
+	}
+	caps, err := validateLLMBudgetAllocations(in, monthlyBudget)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.ReplaceForUser(ctx, userID, caps); err != nil {
+		return nil, err
+	}
+	return s.Summary(ctx, userID, timeutil.NowJST())
+}
+
+func validateLLMBudgetAllocations(in []LLMBudgetAllocationInput, monthlyBudgetUSD *float64) (map[string]float64, error) {
+	caps := make(map[string]float64, len(in))
+	total := 0.0
+	for _, a := range in {
+		purpose := strings.TrimSpace(a.Purpose)
+		if !IsSupportedLLMBudgetPurpose(purpose) {
+			return nil, &ValidationError{Field: "purpose", Message: fmt.Sprintf("unsupported purpose: %s", purpose)}
+		}
+		if _, dup := caps[purpose]; dup {
+			return nil, &ValidationError{Field: "purpose", Message: fmt.Sprintf("duplicate purpose: %s", purpose)}
+		}
+		if a.MonthlyCapUSD <= 0 {
+			return nil, &ValidationError{Field: "monthly_cap_usd"}
+		}
+		caps[purpose] = a.MonthlyCapUSD
+		total += a.MonthlyCapUSD
+	}
+	if monthlyBudgetUSD != nil && *monthlyBudgetUSD > 0 && total > *monthlyBudgetUSD+1e-9 {
+		return nil, &ValidationError{Field: "monthly_cap_usd", Message: "allocations exceed monthly_budget_usd"}
+	}
+	return caps, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestLLMBudgetPurposeForUsage(t *testing.T) {
+	cases := map[string]string{
+		"facts":                "facts",
+		"facts_localization":   "facts",
+		"faithfulness_check":   "summary",
+		"digest_cluster_draft": "digest",
+		"embedding":            "embedding",
+		"source_suggestion":    "source_suggestion",
+		"ask":                  "",
+	}
+	for usage, want := range cases {
+		if got := LLMBudgetPurposeForUsage(usage); got != want {
+			t.Fatalf("LLMBudgetPurposeForUsage(%q) = %q, want %q", usage, got, want)
+		}
+	}
+}
+
+func TestValidateLLMBudgetAllocations(t *testing.T) {
+	budget := 10.0
+	caps, err := validateLLMBudgetAllocations([]LLMBudgetAllocationInput{
+		{Purpose: "facts", MonthlyCapUSD: 4},
+		{Purpose: "summary", MonthlyCapUSD: 6},
+	}, &budget)
+	if err != nil {
+		t.Fatalf("validateLLMBudgetAllocations() error = %v", err)
+	}
+	if len(caps) != 2 || caps["summary"] != 6 {
+		t.Fatalf("caps = %v", caps)
+	}
+
+	invalid := [][]LLMBudgetAllocationInput{
+		{{Purpose: "ask", MonthlyCapUSD: 1}},
+		{{Purpose: "facts", MonthlyCapUSD: 0}},
+		{{Purpose: "facts", MonthlyCapUSD: 1}, {Purpose: "facts", MonthlyCapUSD: 1}},
+		{{Purpose: "facts", MonthlyCapUSD: 6}, {Purpose: "digest", MonthlyCapUSD: 5}},
+	}
+	for i, in := range invalid {
+		_, err := validateLLMBudgetAllocations(in, &budget)
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("case %d: err = %v, want ValidationError", i, err)
+		}
+	}
+}
+
+func TestBuildLLMBudgetAllocationSummary(t *testing.T) {
+	budget := 10.0
+	summary := buildLLMBudgetAllocationSummary("2026-10", &budget,
+		[]model.LLMBudgetAllocation{{Purpose: "digest", MonthlyCapUSD: 2}},
+		[]repository.LLMUsagePurposeMonthSummary{
+			{Purpose: "digest", EstimatedCostUSD: 1.5},
+			{Purpose: "digest_cluster_draft", EstimatedCostUSD: 1},
+			{Purpose: "summary", EstimatedCostUSD: 0.25},
+		},
+	)
+	if len(summary.Allocations) != len(LLMBudgetPurposes) {
+		t.Fatalf("allocations = %d, want %d", len(summary.Allocations), len(LLMBudgetPurposes))
+	}
+	var digest, summ LLMBudgetAllocationView
+	for _, a := range summary.Allocations {
+		switch a.Purpose {
+		case "digest":
+			digest = a
+		case "summary":
+			summ = a
+		}
+	}
+	if digest.UsedCostUSD != 2.5 || !digest.Exceeded || digest.RemainingUSD == nil || *digest.RemainingUSD != -0.5 {
+		t.Fatalf("digest allocation = %+v", digest)
+	}
+	if summ.MonthlyCapUSD != nil || summ.UsedCostUSD != 0.25 || summ.Exceeded {
+		t.Fatalf("summary allocation = %+v", summ)
+	}
+	if summary.UnallocatedUSD == nil || *summary.UnallocatedUSD != 8 {
+		t.Fatalf("unallocated = %v, want 8", summary.UnallocatedUSD)
+	}
+}
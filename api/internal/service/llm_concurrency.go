@@ -0,0 +1,53 @@
+package service
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const maxLLMConcurrency = 10
+
+// ProcessItemUserConcurrency is how many items of one user process-item runs at once
+// (PROCESS_ITEM_USER_CONCURRENCY, default 3). It backs the Inngest concurrency key, so the
+// user's own setting can lower it but not raise it.
+func ProcessItemUserConcurrency() int {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PROCESS_ITEM_USER_CONCURRENCY"))); err == nil && v >= 1 && v <= maxLLMConcurrency {
+		return v
+	}
+	return 3
+}
+
+// EffectiveLLMConcurrency is the user's llm_max_concurrency clamped to ceiling.
+func EffectiveLLMConcurrency(settings *model.UserSettings, ceiling int) int {
+	if settings == nil || settings.LLMMaxConcurrency == nil || *settings.LLMMaxConcurrency <= 0 {
+		return ceiling
+	}
+	return min(*settings.LLMMaxConcurrency, ceiling)
+}
+
+// LLMConcurrencyView is the settings view of the per-user processing limit. Max is the user's
+// own cap (nil when unset), Effective what process-item actually applies.
+type LLMConcurrencyView struct {
+	Max       *int `json:"max"`
+	Effective int  `json:"effective"`
+	Ceiling   int  `json:"ceiling"`
+}
+
+func NewLLMConcurrencyView(settings *model.UserSettings) LLMConcurrencyView {
+	ceiling := ProcessItemUserConcurrency()
+	view := LLMConcurrencyView{Effective: EffectiveLLMConcurrency(settings, ceiling), Ceiling: ceiling}
+	if settings != nil {
+		view.Max = settings.LLMMaxConcurrency
+	}
+	return view
+}
+
+func validateLLMMaxConcurrency(v *int) error {
+	if v != nil && (*v < 1 || *v > maxLLMConcurrency) {
+		return &ValidationError{Field: "max", Message: "max must be between 1 and 10"}
+	}
+	return nil
+}
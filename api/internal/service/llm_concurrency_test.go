@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestEffectiveLLMConcurrency(t *testing.T) {
+	two, eight := 2, 8
+	cases := []struct {
+		settings *model.UserSettings
+		want     int
+	}{
+		{nil, 3},
+		{&model.UserSettings{}, 3},
+		{&model.UserSettings{LLMMaxConcurrency: &two}, 2},
+		{&model.UserSettings{LLMMaxConcurrency: &eight}, 3},
+	}
+	for _, tc := range cases {
+		if got := EffectiveLLMConcurrency(tc.settings, 3); got != tc.want {
+			t.Fatalf("EffectiveLLMConcurrency(%+v) = %d, want %d", tc.settings, got, tc.want)
+		}
+	}
+}
+
+func TestProcessItemUserConcurrencyEnv(t *testing.T) {
+	t.Setenv("PROCESS_ITEM_USER_CONCURRENCY", "")
+	if got := ProcessItemUserConcurrency(); got != 3 {
+		t.Fatalf("default = %d, want 3", got)
+	}
+	t.Setenv("PROCESS_ITEM_USER_CONCURRENCY", "6")
+	if got := ProcessItemUserConcurrency(); got != 6 {
+		t.Fatalf("override = %d, want 6", got)
+	}
+	t.Setenv("PROCESS_ITEM_USER_CONCURRENCY", "50")
+	if got := ProcessItemUserConcurrency(); got != 3 {
+		t.Fatalf("out of range = %d, want 3", got)
+	}
+}
+
+func TestValidateLLMMaxConcurrency(t *testing.T) {
+	zero, five, eleven := 0, 5, 11
+	if err := validateLLMMaxConcurrency(nil); err != nil {
+		t.Fatalf("nil: %v", err)
+	}
+	if err := validateLLMMaxConcurrency(&five); err != nil {
+		t.Fatalf("5: %v", err)
+	}
+	for _, v := range []*int{&zero, &eleven} {
+		if err := validateLLMMaxConcurrency(v); err == nil {
+			t.Fatalf("%d accepted", *v)
+		}
+	}
+}
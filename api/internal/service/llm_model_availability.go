@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	llmModelSettingEmbedding           = "embedding"
+	llmModelSettingTTSMarkupPreprocess = "tts_markup_preprocess_model"
+)
+
+type LLMAvailableModelView struct {
+	ID            string `json:"id"`
+	Provider      string `json:"provider"`
+	KeyConfigured bool   `json:"key_configured"`
+}
+
+type LLMModelSettingAvailability struct {
+	SettingKey           string                  `json:"setting_key"`
+	Purpose              string                  `json:"purpose,omitempty"`
+	RequiredCapabilities []string                `json:"required_capabilities"`
+	Models               []LLMAvailableModelView `json:"models"`
+}
+
+// LLMModelKeyRequirement reports whether the provider behind a configured model has a usable API key.
+type LLMModelKeyRequirement struct {
+	SettingKey    string `json:"setting_key"`
+	Model         string `json:"model"`
+	Provider      string `json:"provider"`
+	KeyConfigured bool   `json:"key_configured"`
+}
+
+func llmModelSettingValues(settings *model.UserSettings) map[string]*string {
+	if settings == nil {
+		return nil
+	}
+	return map[string]*string{
+		"facts":                          settings.FactsModel,
+		"facts_secondary":                settings.FactsSecondaryModel,
+		"facts_fallback":                 settings.FactsFallbackModel,
+		"summary":                        settings.SummaryModel,
+		"summary_secondary":              settings.SummarySecondaryModel,
+		"summary_fallback":               settings.SummaryFallbackModel,
+		"digest_cluster":                 settings.DigestClusterModel,
+		"digest":                         settings.DigestModel,
+		"ask":                            settings.AskModel,
+		"source_suggestion":              settings.SourceSuggestionModel,
+		"embedding":                      settings.EmbeddingModel,
+		"facts_check":                    settings.FactsCheckModel,
+		"facts_check_fallback":           settings.FactsCheckFallbackModel,
+		"faithfulness_check":             settings.FaithfulnessCheckModel,
+		"faithfulness_check_fallback":    settings.FaithfulnessCheckFallbackModel,
+		"navigator":                      settings.NavigatorModel,
+		"navigator_fallback":             settings.NavigatorFallbackModel,
+		"ai_navigator_brief":             settings.AINavigatorBriefModel,
+		"ai_navigator_brief_fallback":    settings.AINavigatorBriefFallbackModel,
+		"audio_briefing_script":          settings.AudioBriefingScriptModel,
+		"audio_briefing_script_fallback": settings.AudioBriefingScriptFallbackModel,
+		"tts_markup_preprocess_model":    settings.TTSMarkupPreprocessModel,
+	}
+}
+
+func llmModelSettingKeys() []string {
+	keys := make([]string, 0, len(modelSettingPurposes)+2)
+	for key := range modelSettingPurposes {
+		keys = append(keys, key)
+	}
+	keys = append(keys, llmModelSettingEmbedding, llmModelSettingTTSMarkupPreprocess)
+	sort.Strings(keys)
+	return keys
+}
+
+// BuildLLMModelAvailability lists, per model setting, the catalog models that pass the same
+// purpose and capability checks UpdateLLMModels enforces.
+func BuildLLMModelAvailability(catalog *LLMCatalog, keys map[string]APIKeyStatus) []LLMModelSettingAvailability {
+	if catalog == nil {
+		return nil
+	}
+	out := make([]LLMModelSettingAvailability, 0, len(modelSettingPurposes)+2)
+	for _, settingKey := range llmModelSettingKeys() {
+		entry := LLMModelSettingAvailability{
+			SettingKey:           settingKey,
+			Purpose:              modelSettingPurposes[settingKey],
+			RequiredCapabilities: append([]string{}, modelSettingRequiredCapabilities[settingKey]...),
+			Models:               []LLMAvailableModelView{},
+		}
+		candidates := catalog.ChatModels
+		if settingKey == llmModelSettingEmbedding {
+			candidates = catalog.EmbeddingModels
+		}
+		for _, m := range candidates {
+			if entry.Purpose != "" && !CatalogModelSupportsPurposeInCatalog(catalog, m.ID, entry.Purpose) {
+				continue
+			}
+			if validateCatalogModelCapabilities(catalog, &m.ID, settingKey) != nil {
+				continue
+			}
+			provider := strings.TrimSpace(m.Provider)
+			entry.Models = append(entry.Models, LLMAvailableModelView{
+				ID:            m.ID,
+				Provider:      provider,
+				KeyConfigured: keys[provider].Has,
+			})
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// BuildLLMModelKeyRequirements flags configured models whose provider key is missing so the
+// settings screen can warn before items start failing.
+func BuildLLMModelKeyRequirements(catalog *LLMCatalog, settings *model.UserSettings, keys map[string]APIKeyStatus) []LLMModelKeyRequirement {
+	values := llmModelSettingValues(settings)
+	out := []LLMModelKeyRequirement{}
+	for _, settingKey := range llmModelSettingKeys() {
+		v := values[settingKey]
+		if v == nil || strings.TrimSpace(*v) == "" {
+			continue
+		}
+		modelID := strings.TrimSpace(*v)
+		provider := ""
+		if entry := findModelCatalogInCatalog(catalog, modelID); entry != nil {
+			provider = strings.TrimSpace(entry.Provider)
+		}
+		if provider == "" {
+			provider = LLMProviderForModel(&modelID)
+		}
+		out = append(out, LLMModelKeyRequirement{
+			SettingKey:    settingKey,
+			Model:         modelID,
+			Provider:      provider,
+			KeyConfigured: keys[provider].Has,
+		})
+	}
+	return out
+}
+
+func (s *SettingsService) AvailableLLMModels(ctx context.Context, userID string) ([]LLMModelSettingAvailability, error) {
+	catalog, err := s.LLMCatalog(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.EnsureDefaults(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildLLMModelAvailability(catalog, buildLLMAPIKeyStatus(settings, GetLLMProviders())), nil
+}
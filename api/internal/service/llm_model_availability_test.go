@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func testAvailabilityCatalog() *LLMCatalog {
+	structured := &LLMModelCapabilities{SupportsStructuredOutput: true}
+	return &LLMCatalog{
+		Providers: []LLMProviderCatalog{{ID: "openai"}, {ID: "google"}},
+		ChatModels: []LLMModelCatalog{
+			{ID: "gpt-test", Provider: "openai", AvailablePurposes: []string{"facts", "summary"}, Capabilities: structured},
+			{ID: "gemini-test", Provider: "google", AvailablePurposes: []string{"ask"}, Capabilities: structured},
+			{ID: "gemini-lite", Provider: "google", AvailablePurposes: []string{"summary"}},
+		},
+		EmbeddingModels: []LLMModelCatalog{
+			{ID: "embed-test", Provider: "openai"},
+		},
+	}
+}
+
+func availabilityFor(rows []LLMModelSettingAvailability, settingKey string) *LLMModelSettingAvailability {
+	for i := range rows {
+		if rows[i].SettingKey == settingKey {
+			return &rows[i]
+		}
+	}
+	return nil
+}
+
+func TestBuildLLMModelAvailability(t *testing.T) {
+	keys := map[string]APIKeyStatus{"openai": {Has: true}}
+	rows := BuildLLMModelAvailability(testAvailabilityCatalog(), keys)
+
+	summary := availabilityFor(rows, "summary")
+	if summary == nil || len(summary.Models) != 1 || summary.Models[0].ID != "gpt-test" || !summary.Models[0].KeyConfigured {
+		t.Fatalf("summary availability = %+v, want only structured-output gpt-test with key", summary)
+	}
+	ask := availabilityFor(rows, "ask")
+	if ask == nil || len(ask.Models) != 1 || ask.Models[0].ID != "gemini-test" || ask.Models[0].KeyConfigured {
+		t.Fatalf("ask availability = %+v, want gemini-test without key", ask)
+	}
+	preprocess := availabilityFor(rows, "tts_markup_preprocess_model")
+	if preprocess == nil || len(preprocess.Models) != 3 {
+		t.Fatalf("tts_markup_preprocess_model availability = %+v, want every chat model", preprocess)
+	}
+	embedding := availabilityFor(rows, "embedding")
+	if embedding == nil || len(embedding.Models) != 1 || embedding.Models[0].ID != "embed-test" {
+		t.Fatalf("embedding availability = %+v, want embed-test", embedding)
+	}
+}
+
+func TestBuildLLMModelKeyRequirements(t *testing.T) {
+	summaryModel := "gemini-test"
+	factsModel := "gpt-test"
+	settings := &model.UserSettings{SummaryModel: &summaryModel, FactsModel: &factsModel}
+	got := BuildLLMModelKeyRequirements(testAvailabilityCatalog(), settings, map[string]APIKeyStatus{"openai": {Has: true}})
+	if len(got) != 2 {
+		t.Fatalf("len(requirements) = %d, want 2: %+v", len(got), got)
+	}
+	if got[0].SettingKey != "facts" || got[0].Provider != "openai" || !got[0].KeyConfigured {
+		t.Fatalf("requirements[0] = %+v, want facts/openai configured", got[0])
+	}
+	if got[1].SettingKey != "summary" || got[1].Provider != "google" || got[1].KeyConfigured {
+		t.Fatalf("requirements[1] = %+v, want summary/google missing key", got[1])
+	}
+}
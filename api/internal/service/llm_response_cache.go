@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LLMPricingSourceCache marks usage rows for responses served from the LLM response cache.
+// They cost nothing and carry no tokens; they are logged so usage summaries can count hits.
+const LLMPricingSourceCache = "cache"
+
+const defaultLLMResponseCacheTTL = 7 * 24 * time.Hour
+
+// LLMResponseCache keeps accepted facts and summaries keyed by their exact inputs, model and
+// prompt version, so an article arriving through another feed or a retry is not paid for
+// twice. Entries live in the shared cache (Redis).
+type LLMResponseCache struct {
+	cache JSONCache
+	ttl   time.Duration
+}
+
+// NewLLMResponseCache returns nil, which disables caching, when there is no cache or
+// LLM_RESPONSE_CACHE_TTL_HOURS is 0. The TTL defaults to a week.
+func NewLLMResponseCache(cache JSONCache) *LLMResponseCache {
+	if cache == nil {
+		return nil
+	}
+	ttl := defaultLLMResponseCacheTTL
+	if raw := strings.TrimSpace(os.Getenv("LLM_RESPONSE_CACHE_TTL_HOURS")); raw != "" {
+		hours, err := strconv.Atoi(raw)
+		if err == nil && hours >= 0 {
+			ttl = time.Duration(hours) * time.Hour
+		}
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	return &LLMResponseCache{cache: cache, ttl: ttl}
+}
+
+// LLMResponseCacheKey hashes everything that shapes a response: the purpose, the model, the
+// prompt (its version and text, so template edits miss) and the request inputs.
+func LLMResponseCacheKey(purpose, model string, prompt *PromptConfig, inputs ...string) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(strconv.Itoa(len(s))))
+		h.Write([]byte{':'})
+		h.Write([]byte(s))
+	}
+	write(purpose)
+	write(strings.TrimSpace(model))
+	if prompt != nil {
+		write(prompt.PromptKey)
+		write(prompt.PromptSource)
+		if prompt.PromptVersionID != nil {
+			write(*prompt.PromptVersionID)
+		}
+		write(prompt.PromptText)
+		write(prompt.SystemInstruction)
+	}
+	for _, in := range inputs {
+		write(in)
+	}
+	return "llm-response:" + purpose + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// CachedFacts is a facts stage that passed its check.
+type CachedFacts struct {
+	Facts *ExtractFactsResponse `json:"facts"`
+	Check *FactsCheckResponse   `json:"check"`
+}
+
+// CachedSummary is a summary that passed its faithfulness check.
+type CachedSummary struct {
+	Summary *SummarizeResponse           `json:"summary"`
+	Check   *SummaryFaithfulnessResponse `json:"check"`
+}
+
+func (c *LLMResponseCache) Get(ctx context.Context, key string, dst any) bool {
+	if c == nil || key == "" {
+		return false
+	}
+	ok, err := c.cache.GetJSON(ctx, key, dst)
+	return err == nil && ok
+}
+
+func (c *LLMResponseCache) Put(ctx context.Context, key string, value any) error {
+	if c == nil || key == "" {
+		return nil
+	}
+	return c.cache.SetJSON(ctx, key, value, c.ttl)
+}
+
+// CachedLLMUsage is usage for a response served from the cache: same provider and model,
+// no tokens, no cost.
+func CachedLLMUsage(usage *LLMUsage) *LLMUsage {
+	if usage == nil {
+		return nil
+	}
+	return &LLMUsage{
+		Provider:           usage.Provider,
+		Model:              usage.Model,
+		RequestedModel:     usage.RequestedModel,
+		ResolvedModel:      usage.ResolvedModel,
+		PricingModelFamily: usage.PricingModelFamily,
+		PricingSource:      LLMPricingSourceCache,
+	}
+}
+
+// ServedFromCache rewrites the usage of a cache hit so it is logged at zero cost.
+func (c *CachedFacts) ServedFromCache() {
+	if c.Facts != nil {
+		c.Facts.LLM = CachedLLMUsage(c.Facts.LLM)
+		c.Facts.FactsLocalizationLLM = CachedLLMUsage(c.Facts.FactsLocalizationLLM)
+	}
+	if c.Check != nil {
+		c.Check.LLM = CachedLLMUsage(c.Check.LLM)
+	}
+}
+
+func (c *CachedSummary) ServedFromCache() {
+	if c.Summary != nil {
+		c.Summary.LLM = CachedLLMUsage(c.Summary.LLM)
+	}
+	if c.Check != nil {
+		c.Check.LLM = CachedLLMUsage(c.Check.LLM)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLLMResponseCacheKeyChangesWithModelPromptAndInputs(t *testing.T) {
+	v1, v2 := "v1", "v2"
+	prompt := &PromptConfig{PromptKey: "facts.default", PromptSource: "template_version", PromptVersionID: &v1, PromptText: "extract"}
+	base := LLMResponseCacheKey("facts", "gpt-5-mini", prompt, "title", "body")
+
+	if got := LLMResponseCacheKey("facts", "gpt-5-mini", prompt, "title", "body"); got != base {
+		t.Fatalf("same inputs gave different keys: %q vs %q", got, base)
+	}
+	otherPrompt := *prompt
+	otherPrompt.PromptVersionID = &v2
+	cases := map[string]string{
+		"model":   LLMResponseCacheKey("facts", "claude-haiku-4-5", prompt, "title", "body"),
+		"prompt":  LLMResponseCacheKey("facts", "gpt-5-mini", &otherPrompt, "title", "body"),
+		"default": LLMResponseCacheKey("facts", "gpt-5-mini", nil, "title", "body"),
+		"inputs":  LLMResponseCacheKey("facts", "gpt-5-mini", prompt, "titlebody"),
+		"purpose": LLMResponseCacheKey("summary", "gpt-5-mini", prompt, "title", "body"),
+	}
+	for name, key := range cases {
+		if key == base {
+			t.Fatalf("%s change kept key %q", name, key)
+		}
+	}
+}
+
+func TestNewLLMResponseCacheDisabledByZeroTTL(t *testing.T) {
+	t.Setenv("LLM_RESPONSE_CACHE_TTL_HOURS", "0")
+	if c := NewLLMResponseCache(&memoryJSONCache{}); c != nil {
+		t.Fatalf("NewLLMResponseCache = %+v, want nil", c)
+	}
+	t.Setenv("LLM_RESPONSE_CACHE_TTL_HOURS", "")
+	if c := NewLLMResponseCache(&memoryJSONCache{}); c == nil || c.ttl != defaultLLMResponseCacheTTL {
+		t.Fatalf("NewLLMResponseCache = %+v, want default TTL", c)
+	}
+	if c := NewLLMResponseCache(nil); c != nil {
+		t.Fatalf("NewLLMResponseCache(nil) = %+v, want nil", c)
+	}
+}
+
+func TestCachedFactsServedFromCacheZeroesUsage(t *testing.T) {
+	c := NewLLMResponseCache(&memoryJSONCache{})
+	stored := &CachedFacts{
+		Facts: &ExtractFactsResponse{
+			Facts: []string{"a"},
+			LLM:   &LLMUsage{Provider: "openai", Model: "gpt-5-mini", PricingSource: "catalog", InputTokens: 900, OutputTokens: 100, EstimatedCostUSD: 0.01},
+		},
+		Check: &FactsCheckResponse{LLM: &LLMUsage{Provider: "openai", Model: "gpt-5-mini", InputTokens: 300, EstimatedCostUSD: 0.002}},
+	}
+	if err := c.Put(context.Background(), "k", stored); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	var got CachedFacts
+	if !c.Get(context.Background(), "k", &got) {
+		t.Fatal("Get missed a stored entry")
+	}
+	got.ServedFromCache()
+
+	llm := got.Facts.LLM
+	if llm.Provider != "openai" || llm.Model != "gpt-5-mini" {
+		t.Fatalf("usage lost provider/model: %+v", llm)
+	}
+	if llm.PricingSource != LLMPricingSourceCache || llm.InputTokens != 0 || llm.OutputTokens != 0 || llm.EstimatedCostUSD != 0 {
+		t.Fatalf("facts usage = %+v, want zero-cost cache usage", llm)
+	}
+	if got.Check.LLM.PricingSource != LLMPricingSourceCache || got.Check.LLM.EstimatedCostUSD != 0 {
+		t.Fatalf("check usage = %+v, want zero-cost cache usage", got.Check.LLM)
+	}
+	if got.Facts.FactsLocalizationLLM != nil {
+		t.Fatalf("localization usage = %+v, want nil", got.Facts.FactsLocalizationLLM)
+	}
+}
+
+func TestNormalizeCatalogPricedUsageKeepsCacheUsage(t *testing.T) {
+	usage := &LLMUsage{Provider: "openai", Model: "gpt-5-mini", PricingSource: LLMPricingSourceCache}
+	got := NormalizeCatalogPricedUsage("facts", usage)
+	if got.PricingSource != LLMPricingSourceCache || got.EstimatedCostUSD != 0 {
+		t.Fatalf("NormalizeCatalogPricedUsage = %+v, want cache usage unchanged", got)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	llmUsageForecastWindowDays = 14
+	// Below this many days with recorded usage the trend is too noisy to alert on.
+	llmUsageForecastMinActiveDays = 3
+)
+
+type LLMUsageForecast struct {
+	MonthJST               string   `json:"month_jst"`
+	AsOfDateJST            string   `json:"as_of_date_jst"`
+	WindowDays             int      `json:"window_days"`
+	ActiveDays             int      `json:"active_days"`
+	UsedCostUSD            float64  `json:"used_cost_usd"`
+	DailyRunRateUSD        float64  `json:"daily_run_rate_usd"`
+	DailyTrendUSD          float64  `json:"daily_trend_usd"`
+	ProjectedMonthEndUSD   float64  `json:"projected_month_end_usd"`
+	MonthlyBudgetUSD       *float64 `json:"monthly_budget_usd"`
+	ProjectedOvershootUSD  *float64 `json:"projected_overshoot_usd"`
+	ProjectedExceedDateJST *string  `json:"projected_exceed_date_jst"`
+	AlreadyExceeded        bool     `json:"already_exceeded"`
+}
+
+// Reliable reports whether enough recent usage exists for the projection to be actionable.
+func (f *LLMUsageForecast) Reliable() bool {
+	return f != nil && f.ActiveDays >= llmUsageForecastMinActiveDays
+}
+
+type LLMUsageForecastService struct {
+	usageRepo    *repository.LLMUsageLogRepo
+	settingsRepo *repository.UserSettingsRepo
+}
+
+func NewLLMUsageForecastService(usageRepo *repository.LLMUsageLogRepo, settingsRepo *repository.UserSettingsRepo) *LLMUsageForecastService {
+	return &LLMUsageForecastService{usageRepo: usageRepo, settingsRepo: settingsRepo}
+}
+
+func (s *LLMUsageForecastService) Forecast(ctx context.Context, userID string) (*LLMUsageForecast, error) {
+	var budget *float64
+	if s.settingsRepo != nil {
+		settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if settings != nil && settings.MonthlyBudgetUSD != nil && *settings.MonthlyBudgetUSD > 0 {
+			budget = settings.MonthlyBudgetUSD
+		}
+	}
+	return s.ForecastWithBudget(ctx, userID, budget, timeutil.NowJST())
+}
+
+func (s *LLMUsageForecastService) ForecastWithBudget(ctx context.Context, userID string, budget *float64, now time.Time) (*LLMUsageForecast, error) {
+	today := timeutil.StartOfDayJST(now)
+	monthStart, _ := BudgetMonthWindowJST(now)
+	since := today.AddDate(0, 0, -llmUsageForecastWindowDays)
+	if monthStart.Before(since) {
+		since = monthStart
+	}
+	daily, err := s.usageRepo.DailyCostByUserBetween(ctx, userID, since, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return forecastLLMUsage(now, daily, budget), nil
+}
+
+// forecastLLMUsage fits a least-squares line to the last 14 completed JST days and sums the
+// fitted daily cost (floored at zero) from today through the end of the month.
+func forecastLLMUsage(now time.Time, daily []repository.LLMUsageDailyCost, budget *float64) *LLMUsageForecast {
+	today := timeutil.StartOfDayJST(now)
+	monthStart, nextMonth := BudgetMonthWindowJST(now)
+	windowStart := today.AddDate(0, 0, -llmUsageForecastWindowDays)
+
+	costByDate := make(map[string]float64, len(daily))
+	for _, d := range daily {
+		costByDate[d.DateJST] += d.EstimatedCostUSD
+	}
+
+	out := &LLMUsageForecast{
+		MonthJST:    monthStart.Format("2006-01"),
+		AsOfDateJST: today.Format("2006-01-02"),
+		WindowDays:  llmUsageForecastWindowDays,
+	}
+	if budget != nil && *budget > 0 {
+		out.MonthlyBudgetUSD = budget
+	}
+	for d := monthStart; !d.After(today); d = d.AddDate(0, 0, 1) {
+		out.UsedCostUSD += costByDate[d.Format("2006-01-02")]
+	}
+
+	ys := make([]float64, llmUsageForecastWindowDays)
+	for i := range ys {
+		v := costByDate[windowStart.AddDate(0, 0, i).Format("2006-01-02")]
+		ys[i] = v
+		if v > 0 {
+			out.ActiveDays++
+		}
+	}
+	intercept, slope := linearFit(ys)
+	fitted := func(x int) float64 {
+		v := intercept + slope*float64(x)
+		if v < 0 {
+			return 0
+		}
+		return v
+	}
+	out.DailyTrendUSD = slope
+	out.DailyRunRateUSD = fitted(llmUsageForecastWindowDays)
+
+	cumulative := out.UsedCostUSD
+	if out.MonthlyBudgetUSD != nil && cumulative >= *out.MonthlyBudgetUSD {
+		out.AlreadyExceeded = true
+	}
+	x := llmUsageForecastWindowDays
+	for d := today; d.Before(nextMonth); d = d.AddDate(0, 0, 1) {
+		projected := fitted(x)
+		if d.Equal(today) {
+			// Today's usage so far is already counted in UsedCostUSD.
+			projected -= costByDate[d.Format("2006-01-02")]
+			if projected < 0 {
+				projected = 0
+			}
+		}
+		cumulative += projected
+		if out.MonthlyBudgetUSD != nil && !out.AlreadyExceeded && out.ProjectedExceedDateJST == nil && cumulative >= *out.MonthlyBudgetUSD {
+			date := d.Format("2006-01-02")
+			out.ProjectedExceedDateJST = &date
+		}
+		x++
+	}
+	out.ProjectedMonthEndUSD = cumulative
+	if out.MonthlyBudgetUSD != nil {
+		overshoot := cumulative - *out.MonthlyBudgetUSD
+		out.ProjectedOvershootUSD = &overshoot
+	}
+	return out
+}
+
+func linearFit(ys []float64) (intercept, slope float64) {
+	n := float64(len(ys))
+	if n == 0 {
+		return 0, 0
+	}
+	var sumX, sumY float64
+	for i, y := range ys {
+		sumX += float64(i)
+		sumY += y
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var num, den float64
+	for i, y := range ys {
+		dx := float64(i) - meanX
+		num += dx * (y - meanY)
+		den += dx * dx
+	}
+	if den == 0 {
+		return meanY, 0
+	}
+	slope = num / den
+	return meanY - slope*meanX, slope
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+func TestLinearFit(t *testing.T) {
+	intercept, slope := linearFit([]float64{1, 2, 3, 4})
+	if math.Abs(intercept-1) > 1e-9 || math.Abs(slope-1) > 1e-9 {
+		t.Fatalf("linearFit() = (%v, %v), want (1, 1)", intercept, slope)
+	}
+	intercept, slope = linearFit([]float64{2, 2, 2})
+	if intercept != 2 || slope != 0 {
+		t.Fatalf("linearFit(flat) = (%v, %v), want (2, 0)", intercept, slope)
+	}
+}
+
+func TestForecastLLMUsageFlatRate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, timeutil.JST)
+	var daily []repository.LLMUsageDailyCost
+	for d := now.AddDate(0, 0, -15); !d.After(now); d = d.AddDate(0, 0, 1) {
+		daily = append(daily, repository.LLMUsageDailyCost{DateJST: d.Format("2006-01-02"), EstimatedCostUSD: 1})
+	}
+	budget := 25.0
+	got := forecastLLMUsage(now, daily, &budget)
+
+	if got.UsedCostUSD != 16 {
+		t.Fatalf("UsedCostUSD = %v, want 16", got.UsedCostUSD)
+	}
+	if math.Abs(got.DailyRunRateUSD-1) > 1e-9 {
+		t.Fatalf("DailyRunRateUSD = %v, want 1", got.DailyRunRateUSD)
+	}
+	// 16 used through the 16th (today fully spent at the run rate) + 15 more days.
+	if math.Abs(got.ProjectedMonthEndUSD-31) > 1e-9 {
+		t.Fatalf("ProjectedMonthEndUSD = %v, want 31", got.ProjectedMonthEndUSD)
+	}
+	if got.ProjectedOvershootUSD == nil || math.Abs(*got.ProjectedOvershootUSD-6) > 1e-9 {
+		t.Fatalf("ProjectedOvershootUSD = %v, want 6", got.ProjectedOvershootUSD)
+	}
+	if got.ProjectedExceedDateJST == nil || *got.ProjectedExceedDateJST != "2026-10-25" {
+		t.Fatalf("ProjectedExceedDateJST = %v, want 2026-10-25", got.ProjectedExceedDateJST)
+	}
+	if !got.Reliable() || got.AlreadyExceeded {
+		t.Fatalf("forecast = %+v, want reliable and not yet exceeded", got)
+	}
+}
+
+func TestForecastLLMUsageNoHistory(t *testing.T) {
+	now := time.Date(2026, 10, 3, 9, 0, 0, 0, timeutil.JST)
+	got := forecastLLMUsage(now, nil, nil)
+	if got.ProjectedMonthEndUSD != 0 || got.Reliable() || got.ProjectedOvershootUSD != nil {
+		t.Fatalf("forecast = %+v, want empty projection", got)
+	}
+}
@@ -6,6 +6,9 @@ func NormalizeCatalogPricedUsage(purpose string, usage *LLMUsage) *LLMUsage {
 	if usage == nil {
 		return nil
 	}
+	if usage.PricingSource == LLMPricingSourceCache {
+		return usage
+	}
 	if strings.TrimSpace(usage.Provider) == "openrouter" && usage.OpenRouterCostUSD != nil {
 		normalized := *usage
 		if resolvedModelID := OpenRouterAliasModelID(CanonicalizeOpenRouterModelID(strings.TrimSpace(usage.ResolvedModel))); resolvedModelID != "" {
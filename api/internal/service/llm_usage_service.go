@@ -55,6 +55,20 @@ type LLMUsageModelSummaryView struct {
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
 }
 
+type LLMUsageSourceSummaryView struct {
+	SourceID              string   `json:"source_id"`
+	SourceTitle           *string  `json:"source_title,omitempty"`
+	SourceURL             string   `json:"source_url"`
+	SourceEnabled         bool     `json:"source_enabled"`
+	Calls                 int      `json:"calls"`
+	ItemsProcessed        int      `json:"items_processed"`
+	InputTokens           int64    `json:"input_tokens"`
+	OutputTokens          int64    `json:"output_tokens"`
+	EstimatedCostUSD      float64  `json:"estimated_cost_usd"`
+	AvgCostPerItemUSD     *float64 `json:"avg_cost_per_item_usd"`
+	ShareOfTotalCostRatio float64  `json:"share_of_total_cost_ratio"`
+}
+
 type LLMUsageProviderMonthSummaryView struct {
 	MonthJST                 string  `json:"month_jst"`
 	Provider                 string  `json:"provider"`
@@ -64,6 +78,7 @@ type LLMUsageProviderMonthSummaryView struct {
 	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens"`
 	CacheReadInputTokens     int64   `json:"cache_read_input_tokens"`
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
+	CacheHits                int     `json:"cache_hits"`
 }
 
 type LLMUsagePurposeMonthSummaryView struct {
@@ -75,6 +90,7 @@ type LLMUsagePurposeMonthSummaryView struct {
 	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens"`
 	CacheReadInputTokens     int64   `json:"cache_read_input_tokens"`
 	EstimatedCostUSD         float64 `json:"estimated_cost_usd"`
+	CacheHits                int     `json:"cache_hits"`
 }
 
 type LLMUsageAnalysisSummaryView struct {
@@ -139,6 +155,10 @@ func mapModelSummaryView(v repository.LLMUsageModelSummary) LLMUsageModelSummary
 	return LLMUsageModelSummaryView(v)
 }
 
+func mapSourceSummaryView(v repository.LLMUsageSourceSummary) LLMUsageSourceSummaryView {
+	return LLMUsageSourceSummaryView(v)
+}
+
 func mapProviderMonthSummaryView(v repository.LLMUsageProviderMonthSummary) LLMUsageProviderMonthSummaryView {
 	return LLMUsageProviderMonthSummaryView(v)
 }
@@ -203,6 +223,22 @@ func (s *LLMUsageService) ModelSummaryMonth(ctx context.Context, userID string,
 	return mapSlice(rows, mapModelSummaryView), nil
 }
 
+func (s *LLMUsageService) SourceSummary(ctx context.Context, userID string, days int) ([]LLMUsageSourceSummaryView, error) {
+	rows, err := s.repo.SourceSummaryByUser(ctx, userID, days)
+	if err != nil {
+		return nil, err
+	}
+	return mapSlice(rows, mapSourceSummaryView), nil
+}
+
+func (s *LLMUsageService) SourceSummaryMonth(ctx context.Context, userID string, month time.Time) ([]LLMUsageSourceSummaryView, error) {
+	rows, err := s.repo.SourceSummaryByUserMonth(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	return mapSlice(rows, mapSourceSummaryView), nil
+}
+
 func (s *LLMUsageService) ProviderSummaryCurrentMonth(ctx context.Context, userID string) ([]LLMUsageProviderMonthSummaryView, error) {
 	rows, err := s.repo.ProviderSummaryCurrentMonthByUser(ctx, userID)
 	if err != nil {
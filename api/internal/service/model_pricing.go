@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// ModelPricingSource is stored on usage rows whose cost was recomputed from the model_pricing catalog.
+const ModelPricingSource = "model_pricing"
+
+type ModelPricingReconcileResult struct {
+	DryRun           bool    `json:"dry_run"`
+	Matched          int     `json:"matched"`
+	Repaired         int     `json:"repaired"`
+	Failed           int     `json:"failed"`
+	CorrectedCostUSD float64 `json:"corrected_cost_usd"`
+	UserCount        int     `json:"user_count"`
+}
+
+type ModelPricingService struct {
+	repo      *repository.ModelPricingRepo
+	usageRepo *repository.LLMUsageLogRepo
+	cache     JSONCache
+}
+
+func NewModelPricingService(repo *repository.ModelPricingRepo, usageRepo *repository.LLMUsageLogRepo, cache JSONCache) *ModelPricingService {
+	return &ModelPricingService{repo: repo, usageRepo: usageRepo, cache: cache}
+}
+
+func (s *ModelPricingService) List(ctx context.Context) ([]model.ModelPricing, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *ModelPricingService) Upsert(ctx context.Context, in repository.ModelPricingInput) (*model.ModelPricing, error) {
+	in.Provider = strings.TrimSpace(in.Provider)
+	in.Model = strings.TrimSpace(in.Model)
+	if in.Note != nil {
+		note := strings.TrimSpace(*in.Note)
+		in.Note = &note
+		if note == "" {
+			in.Note = nil
+		}
+	}
+	if err := validateModelPricingInput(in); err != nil {
+		return nil, err
+	}
+	return s.repo.Upsert(ctx, in)
+}
+
+func (s *ModelPricingService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validateModelPricingInput(in repository.ModelPricingInput) error {
+	if in.Provider == "" {
+		return &ValidationError{Field: "provider", Message: "provider is required"}
+	}
+	if in.Model == "" {
+		return &ValidationError{Field: "model", Message: "model is required"}
+	}
+	prices := []struct {
+		field string
+		value float64
+	}{
+		{"input_per_mtok_usd", in.InputPerMTokUSD},
+		{"output_per_mtok_usd", in.OutputPerMTokUSD},
+		{"cache_read_per_mtok_usd", in.CacheReadPerMTokUSD},
+		{"cache_write_per_mtok_usd", in.CacheWritePerMTokUSD},
+	}
+	for _, p := range prices {
+		if p.value < 0 || math.IsNaN(p.value) || math.IsInf(p.value, 0) {
+			return &ValidationError{Field: p.field, Message: p.field + " must be a non-negative number"}
+		}
+	}
+	return nil
+}
+
+// EstimateCostWithModelPricing prices a usage row the same way NormalizeCatalogPricedUsage does
+// for catalog models: cache reads are billed separately from the remaining input tokens.
+func EstimateCostWithModelPricing(p model.ModelPricing, row repository.LLMUsageLog) float64 {
+	nonCachedInput := row.InputTokens - row.CacheReadInputTokens
+	if nonCachedInput < 0 {
+		nonCachedInput = 0
+	}
+	estimated := 0.0
+	estimated += float64(nonCachedInput) / 1_000_000 * p.InputPerMTokUSD
+	estimated += float64(row.OutputTokens) / 1_000_000 * p.OutputPerMTokUSD
+	estimated += float64(row.CacheReadInputTokens) / 1_000_000 * p.CacheReadPerMTokUSD
+	estimated += float64(row.CacheCreationInputTokens) / 1_000_000 * p.CacheWritePerMTokUSD
+	return estimated
+}
+
+// matchModelPricing prefers the resolved model so alias rows are priced by what actually ran.
+func matchModelPricing(prices []model.ModelPricing, row repository.LLMUsageLog) *model.ModelPricing {
+	provider := strings.TrimSpace(row.Provider)
+	candidates := []string{}
+	if row.ResolvedModel != nil && strings.TrimSpace(*row.ResolvedModel) != "" {
+		candidates = append(candidates, strings.TrimSpace(*row.ResolvedModel))
+	}
+	candidates = append(candidates, strings.TrimSpace(row.Model))
+	for _, modelID := range candidates {
+		for i := range prices {
+			if prices[i].Provider == provider && prices[i].Model == modelID {
+				return &prices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Reconcile recomputes estimated_cost_usd for usage rows logged with unknown pricing and
+// bumps the LLM usage cache of affected users so summaries pick up the corrected totals.
+func (s *ModelPricingService) Reconcile(ctx context.Context, limit int, dryRun bool) (*ModelPricingReconcileResult, error) {
+	prices, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &ModelPricingReconcileResult{DryRun: dryRun}
+	if len(prices) == 0 {
+		return out, nil
+	}
+	rows, err := s.usageRepo.ListUnknownPricingCandidates(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	touchedUsers := map[string]struct{}{}
+	for _, row := range rows {
+		price := matchModelPricing(prices, row)
+		if price == nil {
+			continue
+		}
+		out.Matched++
+		cost := EstimateCostWithModelPricing(*price, row)
+		if !dryRun {
+			family := price.Model
+			if err := s.usageRepo.UpdateOpenRouterBackfill(ctx, row.ID, &family, ModelPricingSource, cost); err != nil {
+				log.Printf("model pricing reconcile usage_id=%s: %v", row.ID, err)
+				out.Failed++
+				continue
+			}
+		}
+		out.Repaired++
+		out.CorrectedCostUSD += cost - row.EstimatedCostUSD
+		if row.UserID != nil && strings.TrimSpace(*row.UserID) != "" {
+			touchedUsers[strings.TrimSpace(*row.UserID)] = struct{}{}
+		}
+	}
+	out.UserCount = len(touchedUsers)
+	if !dryRun {
+		for userID := range touchedUsers {
+			_ = BumpUserLLMUsageCacheVersion(ctx, s.cache, userID)
+		}
+	}
+	return out, nil
+}
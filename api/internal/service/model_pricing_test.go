@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestEstimateCostWithModelPricing(t *testing.T) {
+	price := model.ModelPricing{InputPerMTokUSD: 2, OutputPerMTokUSD: 10, CacheReadPerMTokUSD: 0.5, CacheWritePerMTokUSD: 4}
+	row := repository.LLMUsageLog{
+		InputTokens:              1_000_000,
+		OutputTokens:             500_000,
+		CacheReadInputTokens:     400_000,
+		CacheCreationInputTokens: 100_000,
+	}
+	// 0.6M input * 2 + 0.5M output * 10 + 0.4M cache read * 0.5 + 0.1M cache write * 4
+	if got, want := EstimateCostWithModelPricing(price, row), 1.2+5+0.2+0.4; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("EstimateCostWithModelPricing() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchModelPricingPrefersResolvedModel(t *testing.T) {
+	resolved := "anthropic/claude-sonnet"
+	prices := []model.ModelPricing{
+		{Provider: "openrouter", Model: "openrouter::auto", InputPerMTokUSD: 1},
+		{Provider: "openrouter", Model: resolved, InputPerMTokUSD: 3},
+	}
+	row := repository.LLMUsageLog{Provider: "openrouter", Model: "openrouter::auto", ResolvedModel: &resolved}
+	got := matchModelPricing(prices, row)
+	if got == nil || got.Model != resolved {
+		t.Fatalf("matchModelPricing() = %+v, want resolved model price", got)
+	}
+
+	row.ResolvedModel = nil
+	if got := matchModelPricing(prices, row); got == nil || got.Model != "openrouter::auto" {
+		t.Fatalf("matchModelPricing() = %+v, want stored model price", got)
+	}
+
+	row.Provider = "openai"
+	if got := matchModelPricing(prices, row); got != nil {
+		t.Fatalf("matchModelPricing() = %+v, want nil for other provider", got)
+	}
+}
+
+func TestValidateModelPricingInput(t *testing.T) {
+	if err := validateModelPricingInput(repository.ModelPricingInput{Provider: "openai", Model: "gpt-x", InputPerMTokUSD: 1}); err != nil {
+		t.Fatalf("validateModelPricingInput() error = %v", err)
+	}
+	var ve *ValidationError
+	err := validateModelPricingInput(repository.ModelPricingInput{Provider: "openai", Model: "gpt-x", OutputPerMTokUSD: -1})
+	if !errors.As(err, &ve) || ve.Field != "output_per_mtok_usd" {
+		t.Fatalf("validateModelPricingInput() error = %v, want output_per_mtok_usd validation error", err)
+	}
+	if err := validateModelPricingInput(repository.ModelPricingInput{Model: "gpt-x"}); !errors.As(err, &ve) || ve.Field != "provider" {
+		t.Fatalf("validateModelPricingInput() error = %v, want provider validation error", err)
+	}
+}
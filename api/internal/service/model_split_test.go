@@ -19,6 +19,12 @@ func (m *memoryJSONCache) GetJSON(_ context.Context, key string, dst any) (bool,
 	switch target := dst.(type) {
 	case *modelSplitUsageCounts:
 		*target = value.(modelSplitUsageCounts)
+	case *providerCooldownEntry:
+		*target = value.(providerCooldownEntry)
+	case *CachedFacts:
+		*target = *value.(*CachedFacts)
+	case *userSessionCacheEntry:
+		*target = value.(userSessionCacheEntry)
 	default:
 		return false, nil
 	}
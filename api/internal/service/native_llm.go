@@ -0,0 +1,636 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+// Native LLM modes, selected by NATIVE_LLM_MODE.
+const (
+	NativeLLMModeOff      = "off"
+	NativeLLMModeFallback = "fallback"
+	NativeLLMModeAlways   = "always"
+)
+
+var ErrNativeLLMNoKey = errors.New("native llm: no anthropic or openai api key")
+
+// NativeLLMClient runs extract-facts, summarize and compose-digest in process against the
+// Anthropic or OpenAI API. Prompts come from shared/prompt_templates, the same files the
+// worker renders, so output stays compatible when the worker is bypassed.
+type NativeLLMClient struct {
+	anthropicBaseURL string
+	openAIBaseURL    string
+	http             *http.Client
+}
+
+func NewNativeLLMClient() *NativeLLMClient {
+	anthropicBaseURL := strings.TrimSpace(os.Getenv("ANTHROPIC_API_BASE_URL"))
+	if anthropicBaseURL == "" {
+		anthropicBaseURL = "https://api.anthropic.com"
+	}
+	openAIBaseURL := strings.TrimSpace(os.Getenv("OPENAI_API_BASE_URL"))
+	if openAIBaseURL == "" {
+		openAIBaseURL = "https://api.openai.com"
+	}
+	return &NativeLLMClient{
+		anthropicBaseURL: anthropicBaseURL,
+		openAIBaseURL:    openAIBaseURL,
+		http:             &http.Client{Timeout: 300 * time.Second},
+	}
+}
+
+func nativeLLMModeFromEnv() string {
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("NATIVE_LLM_MODE"))); v {
+	case NativeLLMModeFallback, NativeLLMModeAlways:
+		return v
+	default:
+		return NativeLLMModeOff
+	}
+}
+
+type nativeLLMTarget struct {
+	provider string
+	model    string
+	apiKey   string
+}
+
+// resolveTarget keeps the requested model when its provider has a key, and otherwise falls
+// back to the catalog default for purpose on whichever of Anthropic/OpenAI has a key.
+func (c *NativeLLMClient) resolveTarget(purpose string, model, anthropicAPIKey, openAIAPIKey *string) (nativeLLMTarget, error) {
+	keys := map[string]string{
+		"anthropic": strings.TrimSpace(derefString(anthropicAPIKey)),
+		"openai":    strings.TrimSpace(derefString(openAIAPIKey)),
+	}
+	if m := strings.TrimSpace(derefString(model)); m != "" {
+		provider := CatalogProviderForModel(m)
+		if key := keys[provider]; key != "" {
+			return nativeLLMTarget{provider: provider, model: m, apiKey: key}, nil
+		}
+	}
+	for _, provider := range []string{"anthropic", "openai"} {
+		if keys[provider] == "" {
+			continue
+		}
+		if m := CatalogDefaultModelForPurpose(provider, purpose); m != "" {
+			return nativeLLMTarget{provider: provider, model: m, apiKey: keys[provider]}, nil
+		}
+	}
+	return nativeLLMTarget{}, ErrNativeLLMNoKey
+}
+
+func (c *NativeLLMClient) ExtractFacts(ctx context.Context, title *string, content string, anthropicAPIKey, openAIAPIKey, model *string, prompt *PromptConfig) (*ExtractFactsResponse, error) {
+	target, err := c.resolveTarget("facts", model, anthropicAPIKey, openAIAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	system, user, err := renderNativePrompt("facts.default", prompt, map[string]string{
+		"title":       titleOrUnknown(title),
+		"content":     content,
+		"fact_range":  "8〜18個",
+		"output_rule": `- 出力は必ず {"facts": ["...", "..."]} のJSONオブジェクト1つのみにしてください。`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	text, usage, err := c.chat(ctx, target, "facts", system, user, 3000)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Facts []string `json:"facts"`
+	}
+	_ = json.Unmarshal([]byte(extractFirstJSONObject(text)), &out)
+	facts := dedupeNativeFacts(out.Facts, 18)
+	if len(facts) == 0 {
+		return nil, fmt.Errorf("native %s extract_facts parse failed: response_snippet=%s", target.provider, cutRunes(text, 500))
+	}
+	return &ExtractFactsResponse{Facts: facts, LLM: usage}, nil
+}
+
+var summaryTaxonomy = []string{
+	"ai", "devtools", "security", "cloud", "data", "infra", "web", "mobile", "robotics",
+	"semiconductor", "research", "product", "business", "funding", "regulation", "design",
+	"uncategorized", "other",
+}
+
+// summaryTaxonomyGuidance mirrors _SUMMARY_TAXONOMY_GUIDANCE in the worker.
+var summaryTaxonomyGuidance = "genre は必須です。固定 taxonomy から必ず 1 つだけ選んでください: " +
+	strings.Join(summaryTaxonomy, ", ") + "。" +
+	"AI、LLM、生成AI、基盤モデル、AIエージェント、マルチエージェント、AI推論基盤の記事は ai を優先してください。" +
+	"devtools は主題が開発者向けツールそのもののときだけ選んでください。" +
+	"候補に当てはまるなら other を使わず、明確に候補外のときだけ other を選んでください。" +
+	"other のときだけ other_label を返してください。" +
+	"other_label は短い日本語で 20 文字以内にし、" +
+	"それ以外では空文字にしてください。" +
+	"空文字は上流で null 扱いになります。"
+
+// summaryDepthGuidance mirrors _SUMMARY_DEPTH_GUIDANCE in the worker.
+var summaryDepthGuidance = "depth は必須です。記事の読み応えを次から 1 つだけ選んでください: " +
+	"news_brief（短い速報・発表・ニュース）, deep_dive（分析・解説・考察など腰を据えて読む記事）, " +
+	"tutorial（手順やコードを追って手を動かすハンズオン・ガイド）。"
+
+// parseSummaryDepth returns nil for an empty or unknown depth label.
+func parseSummaryDepth(raw string) *string {
+	depth := strings.ToLower(strings.TrimSpace(raw))
+	if !model.IsItemDepth(depth) {
+		return nil
+	}
+	return &depth
+}
+
+func appendSummaryTaxonomyGuidance(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return text
+	}
+	if !strings.Contains(text, summaryTaxonomyGuidance) {
+		text += "\n\n# Genre\n" + summaryTaxonomyGuidance
+	}
+	if !strings.Contains(text, summaryDepthGuidance) {
+		text += "\n\n# Depth\n" + summaryDepthGuidance
+	}
+	return text
+}
+
+func (c *NativeLLMClient) Summarize(ctx context.Context, title *string, facts []string, sourceTextChars *int, anthropicAPIKey, openAIAPIKey, model *string, prompt *PromptConfig) (*SummarizeResponse, error) {
+	target, err := c.resolveTarget("summary", model, anthropicAPIKey, openAIAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	targetChars := targetSummaryChars(sourceTextChars, facts)
+	factLines := make([]string, 0, len(facts))
+	for _, f := range facts {
+		factLines = append(factLines, "- "+f)
+	}
+	system, user, err := renderNativePrompt("summary.default", prompt, map[string]string{
+		"title":        titleOrUnknown(title),
+		"facts_text":   strings.Join(factLines, "\n"),
+		"target_chars": fmt.Sprint(targetChars),
+		"min_chars":    fmt.Sprint(clampInt(int(math.Round(float64(targetChars)*0.8)), 160, 1000)),
+		"max_chars":    fmt.Sprint(clampInt(int(math.Round(float64(targetChars)*1.2)), 260, 1400)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	system, user = appendSummaryTaxonomyGuidance(system), appendSummaryTaxonomyGuidance(user)
+	maxTokens := clampInt(int(math.Round(float64(targetChars)*1.38)), 1400, 5200)
+	text, usage, err := c.chat(ctx, target, "summary", system, user, maxTokens)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Summary         string             `json:"summary"`
+		Topics          []string           `json:"topics"`
+		TranslatedTitle string             `json:"translated_title"`
+		Genre           string             `json:"genre"`
+		OtherLabel      string             `json:"other_label"`
+		Depth           string             `json:"depth"`
+		ScoreBreakdown  map[string]float64 `json:"score_breakdown"`
+		ScoreReason     string             `json:"score_reason"`
+	}
+	_ = json.Unmarshal([]byte(extractFirstJSONObject(text)), &out)
+	summary := strings.TrimSpace(out.Summary)
+	if summary == "" {
+		return nil, fmt.Errorf("native %s summarize parse failed: response_snippet=%s", target.provider, cutRunes(text, 500))
+	}
+	topics := make([]string, 0, len(out.Topics))
+	for _, t := range out.Topics {
+		if t = strings.TrimSpace(t); t != "" {
+			topics = append(topics, t)
+		}
+	}
+	resp := &SummarizeResponse{
+		Summary:            summary,
+		Topics:             topics,
+		ScoreReason:        cutRunes(firstNonEmptyTrimmed(out.ScoreReason, "総合的な重要度・新規性・実用性を基に採点。"), 400),
+		ScorePolicyVersion: "v4",
+		LLM:                usage,
+	}
+	if genre := strings.TrimSpace(out.Genre); genre != "" {
+		resp.Genre = &genre
+		if label := cutRunes(strings.TrimSpace(out.OtherLabel), 20); genre == "other" && label != "" {
+			resp.OtherGenreLabel = &label
+		}
+	}
+	resp.Depth = parseSummaryDepth(out.Depth)
+	if !containsJapanese(derefString(title)) && containsJapanese(out.TranslatedTitle) {
+		resp.TranslatedTitle = cutRunes(strings.TrimSpace(out.TranslatedTitle), 300)
+	}
+	breakdown := normalizeScoreBreakdown(out.ScoreBreakdown)
+	resp.ScoreBreakdown = make(map[string]any, len(breakdown))
+	for k, v := range breakdown {
+		resp.ScoreBreakdown[k] = v
+	}
+	resp.Score = summaryCompositeScore(breakdown)
+	return resp, nil
+}
+
+func (c *NativeLLMClient) ComposeDigest(ctx context.Context, digestDate string, items []ComposeDigestItem, anthropicAPIKey, openAIAPIKey, model *string, prompt *PromptConfig) (*ComposeDigestResponse, error) {
+	target, err := c.resolveTarget("digest", model, anthropicAPIKey, openAIAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return &ComposeDigestResponse{
+			Subject: "Sifto Digest - " + digestDate,
+			Body:    "本日のダイジェスト対象記事はありませんでした。",
+			LLM:     &LLMUsage{Provider: target.provider, Model: target.model},
+		}, nil
+	}
+	lines := make([]string, 0, len(items))
+	for i, item := range items {
+		title := "（タイトルなし）"
+		if t := strings.TrimSpace(derefString(item.Title)); t != "" {
+			title = t
+		}
+		score := "None"
+		if item.Score != nil {
+			score = fmt.Sprint(*item.Score)
+		}
+		lines = append(lines, fmt.Sprintf("- item=%d rank=%d | title=%s | topics=%s | score=%s | summary=%s",
+			i+1, item.Rank, title, strings.Join(item.Topics, ", "), score, cutRunes(item.Summary, 260)))
+	}
+	system, user, err := renderNativePrompt("digest.default", prompt, map[string]string{
+		"digest_date":  digestDate,
+		"items_count":  fmt.Sprint(len(items)),
+		"input_mode":   "items",
+		"digest_input": strings.Join(lines, "\n"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	text, usage, err := c.chat(ctx, target, "digest", system, user, 8000)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+	}
+	_ = json.Unmarshal([]byte(extractFirstJSONObject(text)), &out)
+	subject, body := strings.TrimSpace(out.Subject), strings.TrimSpace(out.Body)
+	if subject == "" || body == "" {
+		return nil, fmt.Errorf("native %s compose_digest parse failed: response_snippet=%s", target.provider, cutRunes(text, 500))
+	}
+	return &ComposeDigestResponse{Subject: subject, Body: body, LLM: usage}, nil
+}
+
+// renderNativePrompt loads the shared default template for key, lets a prompt override from
+// the prompt registry replace either part, and fills {{var}} / {var} placeholders.
+func renderNativePrompt(key string, override *PromptConfig, vars map[string]string) (string, string, error) {
+	tmpl, err := LookupPromptTemplateDefault(key)
+	if err != nil {
+		return "", "", err
+	}
+	system, user := tmpl.SystemInstruction, tmpl.PromptText
+	if override != nil {
+		if s := strings.TrimSpace(override.SystemInstruction); s != "" {
+			system = s
+		}
+		if p := strings.TrimSpace(override.PromptText); p != "" {
+			user = p
+		}
+	}
+	return renderPromptPlaceholders(strings.TrimSpace(system), vars), renderPromptPlaceholders(strings.TrimSpace(user), vars), nil
+}
+
+var promptPlaceholderRe = regexp.MustCompile(`\{\{([a-zA-Z0-9_]+)\}\}|\{([a-zA-Z0-9_]+)\}`)
+
+func renderPromptPlaceholders(text string, vars map[string]string) string {
+	return promptPlaceholderRe.ReplaceAllStringFunc(text, func(match string) string {
+		key := strings.Trim(match, "{}")
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+func (c *NativeLLMClient) chat(ctx context.Context, target nativeLLMTarget, purpose, system, user string, maxTokens int) (string, *LLMUsage, error) {
+	var (
+		text  string
+		usage LLMUsage
+		err   error
+	)
+	switch target.provider {
+	case "anthropic":
+		text, usage, err = c.anthropicMessages(ctx, target, system, user, maxTokens)
+	case "openai":
+		text, usage, err = c.openAIChat(ctx, target, system, user, maxTokens)
+	default:
+		return "", nil, fmt.Errorf("native llm: unsupported provider %q", target.provider)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	usage.Provider = target.provider
+	usage.Model = target.model
+	usage.PricingSource = "native"
+	log.Printf("native llm call provider=%s model=%s purpose=%s input_tokens=%d output_tokens=%d", target.provider, target.model, purpose, usage.InputTokens, usage.OutputTokens)
+	return text, NormalizeCatalogPricedUsage(purpose, &usage), nil
+}
+
+func (c *NativeLLMClient) anthropicMessages(ctx context.Context, target nativeLLMTarget, system, user string, maxTokens int) (string, LLMUsage, error) {
+	var resp struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens              int `json:"input_tokens"`
+			OutputTokens             int `json:"output_tokens"`
+			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	}
+	err := c.postJSON(ctx, "anthropic messages", c.anthropicBaseURL+"/v1/messages", map[string]string{
+		"x-api-key":         target.apiKey,
+		"anthropic-version": "2023-06-01",
+	}, map[string]any{
+		"model":      target.model,
+		"max_tokens": maxTokens,
+		"system":     system,
+		"messages":   []map[string]string{{"role": "user", "content": user}},
+	}, &resp)
+	if err != nil {
+		return "", LLMUsage{}, err
+	}
+	var sb strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			sb.WriteString(block.Text)
+		}
+	}
+	return sb.String(), LLMUsage{
+		InputTokens:              resp.Usage.InputTokens + resp.Usage.CacheReadInputTokens,
+		OutputTokens:             resp.Usage.OutputTokens,
+		CacheCreationInputTokens: resp.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     resp.Usage.CacheReadInputTokens,
+	}, nil
+}
+
+func (c *NativeLLMClient) openAIChat(ctx context.Context, target nativeLLMTarget, system, user string, maxTokens int) (string, LLMUsage, error) {
+	var resp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens        int `json:"prompt_tokens"`
+			CompletionTokens    int `json:"completion_tokens"`
+			PromptTokensDetails struct {
+				CachedTokens int `json:"cached_tokens"`
+			} `json:"prompt_tokens_details"`
+		} `json:"usage"`
+	}
+	err := c.postJSON(ctx, "openai chat.completions", c.openAIBaseURL+"/v1/chat/completions", map[string]string{
+		"Authorization": "Bearer " + target.apiKey,
+	}, map[string]any{
+		"model": target.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": user},
+		},
+		"max_completion_tokens": maxTokens,
+		"response_format":       map[string]string{"type": "json_object"},
+	}, &resp)
+	if err != nil {
+		return "", LLMUsage{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return "", LLMUsage{}, fmt.Errorf("openai chat.completions failed: empty choices")
+	}
+	return resp.Choices[0].Message.Content, LLMUsage{
+		InputTokens:          resp.Usage.PromptTokens,
+		OutputTokens:         resp.Usage.CompletionTokens,
+		CacheReadInputTokens: resp.Usage.PromptTokensDetails.CachedTokens,
+	}, nil
+}
+
+func (c *NativeLLMClient) postJSON(ctx context.Context, label, url string, headers map[string]string, body, out any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("%s failed status=%d body=%s", label, resp.StatusCode, string(raw))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// extractFirstJSONObject returns the first balanced {...} in text, tolerating code fences
+// and prose around the JSON.
+func extractFirstJSONObject(text string) string {
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return ""
+	}
+	depth, inString, escaped := 0, false, false
+	for i := start; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1]
+			}
+		}
+	}
+	return ""
+}
+
+var placeholderFactRe = regexp.MustCompile(`^事実\s*\d+[.:]?$`)
+
+func dedupeNativeFacts(raw []string, maxItems int) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(raw))
+	for _, f := range raw {
+		f = strings.TrimSpace(f)
+		if f == "" || placeholderFactRe.MatchString(f) {
+			continue
+		}
+		key := strings.Join(strings.Fields(strings.ToLower(f)), " ")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, f)
+		if len(out) >= maxItems {
+			break
+		}
+	}
+	return out
+}
+
+var scoreBreakdownWeights = map[string]float64{
+	"importance":    0.38,
+	"novelty":       0.22,
+	"actionability": 0.18,
+	"reliability":   0.17,
+	"relevance":     0.05,
+}
+
+// normalizeScoreBreakdown mirrors normalize_score_breakdown in the worker: missing values
+// default to 0.5 and flat or all-zero answers are spread out.
+func normalizeScoreBreakdown(raw map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(scoreBreakdownWeights))
+	allZero, allSame := true, true
+	var first float64
+	i := 0
+	for k := range scoreBreakdownWeights {
+		v, ok := raw[k]
+		if !ok {
+			v = 0.5
+		}
+		v = math.Max(0, math.Min(1, v))
+		out[k] = v
+		if v != 0 {
+			allZero = false
+		}
+		if i == 0 {
+			first = v
+		} else if v != first {
+			allSame = false
+		}
+		i++
+	}
+	clamp := func(v float64) float64 { return math.Max(0, math.Min(1, v)) }
+	switch {
+	case allZero:
+		return map[string]float64{"importance": 0.55, "novelty": 0.45, "actionability": 0.45, "reliability": 0.5, "relevance": 0.5}
+	case allSame:
+		return map[string]float64{
+			"importance":    clamp(first + 0.08),
+			"novelty":       clamp(first - 0.04),
+			"actionability": clamp(first - 0.02),
+			"reliability":   clamp(first + 0.04),
+			"relevance":     clamp(first),
+		}
+	}
+	return out
+}
+
+func summaryCompositeScore(breakdown map[string]float64) float64 {
+	total := 0.0
+	for k, w := range scoreBreakdownWeights {
+		total += breakdown[k] * w
+	}
+	return math.Round(total*10000) / 10000
+}
+
+func targetSummaryChars(sourceTextChars *int, facts []string) int {
+	if sourceTextChars != nil && *sourceTextChars > 0 {
+		return clampInt(int(math.Round(float64(*sourceTextChars)*0.16)), 220, 1200)
+	}
+	factsChars := 0
+	for _, f := range facts {
+		factsChars += len([]rune(f))
+	}
+	if factsChars > 0 {
+		return clampInt(int(math.Round(float64(factsChars)*0.9)), 220, 900)
+	}
+	return 300
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(hi, v))
+}
+
+func containsJapanese(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
+
+func titleOrUnknown(title *string) string {
+	if t := strings.TrimSpace(derefString(title)); t != "" {
+		return t
+	}
+	return "（不明）"
+}
+
+func cutRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// withNativeLLM runs a worker LLM task according to NATIVE_LLM_MODE: "always" skips the
+// worker, "fallback" retries in process only when the worker could not be reached. The
+// worker error is kept on a failed fallback so Inngest still treats it as retryable.
+func withNativeLLM[T any](w *WorkerClient, task string, viaWorker func() (*T, error), native func(*NativeLLMClient) (*T, error)) (*T, error) {
+	if w.native == nil || w.nativeMode == NativeLLMModeOff || w.nativeMode == "" {
+		return viaWorker()
+	}
+	if w.nativeMode == NativeLLMModeAlways {
+		return native(w.native)
+	}
+	resp, err := viaWorker()
+	if err == nil || !IsWorkerUnavailable(err) {
+		return resp, err
+	}
+	log.Printf("worker unavailable, running %s natively: %v", task, err)
+	out, nativeErr := native(w.native)
+	if nativeErr != nil {
+		return nil, fmt.Errorf("%w; native fallback: %v", err, nativeErr)
+	}
+	return out, nil
+}
+
+// nativeLLMSupportsLanguage limits the native path to Japanese output, the only language the
+// shared default prompts produce.
+func nativeLLMSupportsLanguage(targetLanguage *string) bool {
+	lang := strings.ToLower(strings.TrimSpace(derefString(targetLanguage)))
+	return lang == "" || lang == "ja"
+}
+
+// NativeLLMMode reports NATIVE_LLM_MODE as applied to this client.
+func (w *WorkerClient) NativeLLMMode() string {
+	if w == nil || w.native == nil {
+		return NativeLLMModeOff
+	}
+	return w.nativeMode
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newFakeAnthropic(t *testing.T, reply string) (*NativeLLMClient, *map[string]any) {
+	t.Helper()
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" || r.Header.Get("x-api-key") != "ak" {
+			t.Errorf("unexpected request %s key=%q", r.URL.Path, r.Header.Get("x-api-key"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"content": []map[string]string{{"type": "text", "text": reply}},
+			"usage":   map[string]int{"input_tokens": 100, "output_tokens": 20},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return &NativeLLMClient{anthropicBaseURL: srv.URL, openAIBaseURL: srv.URL, http: srv.Client()}, &got
+}
+
+func TestNativeLLMExtractFactsUsesSharedPrompt(t *testing.T) {
+	c, req := newFakeAnthropic(t, "```json\n{\"facts\": [\"事実A\", \"事実A\", \"事実 1\", \"事実B\"]}\n```")
+	key := "ak"
+	title := "Example"
+
+	resp, err := c.ExtractFacts(context.Background(), &title, "本文です", &key, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ExtractFacts() error = %v", err)
+	}
+	if strings.Join(resp.Facts, ",") != "事実A,事実B" {
+		t.Fatalf("facts = %v", resp.Facts)
+	}
+	if resp.LLM == nil || resp.LLM.Provider != "anthropic" || resp.LLM.InputTokens != 100 {
+		t.Fatalf("llm = %+v", resp.LLM)
+	}
+	if (*req)["model"] != CatalogDefaultModelForPurpose("anthropic", "facts") {
+		t.Fatalf("model = %v", (*req)["model"])
+	}
+	messages, _ := (*req)["messages"].([]any)
+	if len(messages) != 1 || !strings.Contains(messages[0].(map[string]any)["content"].(string), "本文です") {
+		t.Fatalf("prompt was not rendered: %v", messages)
+	}
+	if system, _ := (*req)["system"].(string); strings.Contains(system, "{{") {
+		t.Fatalf("system prompt has unrendered placeholders: %s", system)
+	}
+}
+
+func TestNativeLLMSummarizeScoresLikeWorker(t *testing.T) {
+	c, _ := newFakeAnthropic(t, `{"summary":"要約です","topics":["AI"," "],"translated_title":"例","genre":"ai","other_label":"x","depth":" Deep_Dive ",
+		"score_breakdown":{"importance":0.5,"novelty":0.5,"actionability":0.5,"reliability":0.5,"relevance":0.5},"score_reason":""}`)
+	key := "ak"
+	title := "Example"
+
+	resp, err := c.Summarize(context.Background(), &title, []string{"fact"}, nil, &key, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if resp.Summary != "要約です" || len(resp.Topics) != 1 || resp.Genre == nil || *resp.Genre != "ai" || resp.OtherGenreLabel != nil {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if resp.Depth == nil || *resp.Depth != "deep_dive" {
+		t.Fatalf("depth = %v, want deep_dive", resp.Depth)
+	}
+	if resp.TranslatedTitle != "例" || resp.ScorePolicyVersion != "v4" || resp.ScoreReason == "" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	// A flat breakdown is spread the same way as the worker: 0.58/0.46/0.48/0.54/0.5.
+	if resp.Score != 0.5248 {
+		t.Fatalf("score = %v, want 0.5248", resp.Score)
+	}
+}
+
+func TestNativeLLMRequiresKey(t *testing.T) {
+	c, _ := newFakeAnthropic(t, "{}")
+	if _, err := c.ComposeDigest(context.Background(), "2026-10-16", nil, nil, nil, nil, nil); err != ErrNativeLLMNoKey {
+		t.Fatalf("err = %v, want ErrNativeLLMNoKey", err)
+	}
+}
+
+func TestWorkerFallsBackToNativeLLMWhenWorkerIsDown(t *testing.T) {
+	native, _ := newFakeAnthropic(t, `{"subject":"件名","body":"本文"}`)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	key := "ak"
+	items := []ComposeDigestItem{{Rank: 1, Summary: "s"}}
+
+	w := &WorkerClient{baseURL: down.URL, http: http.DefaultClient, native: native, nativeMode: NativeLLMModeFallback}
+	resp, err := w.ComposeDigest(context.Background(), "2026-10-16", items, &key, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ComposeDigest() error = %v", err)
+	}
+	if resp.Subject != "件名" || resp.LLM.Provider != "anthropic" {
+		t.Fatalf("resp = %+v", resp)
+	}
+
+	w.nativeMode = NativeLLMModeOff
+	if _, err := w.ComposeDigest(context.Background(), "2026-10-16", items, &key, nil, nil, nil, nil, nil, nil, nil, nil, nil); !IsWorkerUnavailable(err) {
+		t.Fatalf("err = %v, want worker unavailable", err)
+	}
+}
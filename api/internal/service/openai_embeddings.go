@@ -11,6 +11,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
 )
 
 type OpenAIClient struct {
@@ -36,6 +38,15 @@ func OpenAIEmbeddingModel() string {
 	return "text-embedding-3-small"
 }
 
+// UserEmbeddingModel is the model new embeddings for the user are made with: their supported
+// embedding_model setting, else OpenAIEmbeddingModel.
+func UserEmbeddingModel(settings *model.UserSettings) string {
+	if settings != nil && settings.EmbeddingModel != nil && IsSupportedOpenAIEmbeddingModel(*settings.EmbeddingModel) {
+		return *settings.EmbeddingModel
+	}
+	return OpenAIEmbeddingModel()
+}
+
 type CreateEmbeddingResponse struct {
 	Embedding []float64
 	LLM       *LLMUsage
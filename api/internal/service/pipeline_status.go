@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+type PipelineStatusStore interface {
+	QueueDepth(ctx context.Context) (map[string]int, error)
+	StuckItems(ctx context.Context, status string, olderThan time.Time, sampleLimit int) (model.PipelineStuckItems, error)
+	StepFailureRates(ctx context.Context, since time.Time) ([]model.PipelineStepFailureRate, error)
+	SlowestSources(ctx context.Context, since time.Time, limit int) ([]model.PipelineSlowSource, error)
+	AutoReprocessCounts(ctx context.Context, since time.Time, maxAttempts int) (model.PipelineAutoReprocess, error)
+}
+
+// defaultPipelineStuckSLAs is how long an item may stay in each non-terminal status before it
+// counts as stuck. budget_deferred waits for the next budget window and is left out.
+var defaultPipelineStuckSLAs = map[string]time.Duration{
+	"new":             30 * time.Minute,
+	"fetched":         2 * time.Hour,
+	"facts_extracted": 2 * time.Hour,
+}
+
+// PipelineStuckSLAs returns the per-status stuck thresholds, with PIPELINE_STUCK_SLAS
+// ("fetched=1h,new=15m") overriding the defaults. The status endpoint and the auto reprocess
+// cron share it so they agree on what is stuck.
+func PipelineStuckSLAs() map[string]time.Duration {
+	slas := make(map[string]time.Duration, len(defaultPipelineStuckSLAs))
+	for status, d := range defaultPipelineStuckSLAs {
+		slas[status] = d
+	}
+	for status, d := range parseDurationList(os.Getenv("PIPELINE_STUCK_SLAS")) {
+		slas[status] = d
+	}
+	return slas
+}
+
+// PipelineAutoReprocessMaxAttempts is how many times a stuck item is re-emitted before it is
+// marked failed (PIPELINE_AUTO_REPROCESS_MAX, default 3). Zero disables auto reprocessing.
+func PipelineAutoReprocessMaxAttempts() int {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PIPELINE_AUTO_REPROCESS_MAX"))); err == nil && v >= 0 {
+		return v
+	}
+	return 3
+}
+
+// PipelineStatusService summarizes item processing health for operators. PIPELINE_STUCK_SLAS
+// overrides per-status SLAs (see PipelineStuckSLAs); a step whose failure rate over the last
+// 24h reaches PIPELINE_FAILURE_RATE_ALERT (default 0.2) with at least
+// PIPELINE_FAILURE_MIN_ATTEMPTS attempts (default 20) marks the pipeline degraded.
+type PipelineStatusService struct {
+	store            PipelineStatusStore
+	slas             map[string]time.Duration
+	failureRateAlert float64
+	minAttempts      int
+	maxReprocess     int
+	now              func() time.Time
+}
+
+func NewPipelineStatusService(store PipelineStatusStore) *PipelineStatusService {
+	rate := 0.2
+	if v, err := strconv.ParseFloat(strings.TrimSpace(os.Getenv("PIPELINE_FAILURE_RATE_ALERT")), 64); err == nil && v > 0 && v <= 1 {
+		rate = v
+	}
+	return &PipelineStatusService{
+		store:            store,
+		slas:             PipelineStuckSLAs(),
+		failureRateAlert: rate,
+		minAttempts:      envIntOrDefault("PIPELINE_FAILURE_MIN_ATTEMPTS", 20),
+		maxReprocess:     PipelineAutoReprocessMaxAttempts(),
+		now:              time.Now,
+	}
+}
+
+func (s *PipelineStatusService) Status(ctx context.Context) (*model.PipelineStatus, error) {
+	now := s.now()
+	since := now.Add(-24 * time.Hour)
+	out := &model.PipelineStatus{Status: "ok", Alerts: []string{}, GeneratedAt: now, Stuck: []model.PipelineStuckItems{}}
+
+	depth, err := s.store.QueueDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("queue depth: %w", err)
+	}
+	out.QueueDepth = depth
+
+	statuses := make([]string, 0, len(s.slas))
+	for status := range s.slas {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		sla := s.slas[status]
+		stuck, err := s.store.StuckItems(ctx, status, now.Add(-sla), 5)
+		if err != nil {
+			return nil, fmt.Errorf("stuck items %s: %w", status, err)
+		}
+		stuck.SLAMinutes = int(sla / time.Minute)
+		if stuck.Count > 0 {
+			out.Alerts = append(out.Alerts, fmt.Sprintf("%d items in %s for more than %s", stuck.Count, status, sla))
+		}
+		out.Stuck = append(out.Stuck, stuck)
+	}
+
+	out.StepFailures, err = s.store.StepFailureRates(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("step failure rates: %w", err)
+	}
+	for _, f := range out.StepFailures {
+		if f.Attempts >= s.minAttempts && f.FailureRate >= s.failureRateAlert {
+			out.Alerts = append(out.Alerts, fmt.Sprintf("%s failed %d of %d attempts in the last 24h", f.Step, f.Failures, f.Attempts))
+		}
+	}
+
+	out.SlowestSources, err = s.store.SlowestSources(ctx, since, 10)
+	if err != nil {
+		return nil, fmt.Errorf("slowest sources: %w", err)
+	}
+
+	out.AutoReprocess, err = s.store.AutoReprocessCounts(ctx, since, s.maxReprocess)
+	if err != nil {
+		return nil, fmt.Errorf("auto reprocess counts: %w", err)
+	}
+	out.AutoReprocess.MaxAttempts = s.maxReprocess
+	if out.AutoReprocess.Exhausted > 0 {
+		out.Alerts = append(out.Alerts, fmt.Sprintf("%d items failed after %d automatic reprocesses in the last 24h", out.AutoReprocess.Exhausted, s.maxReprocess))
+	}
+	if len(out.Alerts) > 0 {
+		out.Status = "degraded"
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+type fakePipelineStatusStore struct {
+	stuck     map[string]int
+	failures  []model.PipelineStepFailureRate
+	cutoffs   map[string]time.Time
+	reprocess model.PipelineAutoReprocess
+}
+
+func (f *fakePipelineStatusStore) QueueDepth(context.Context) (map[string]int, error) {
+	return map[string]int{"fetched": 3, "summarized": 40}, nil
+}
+
+func (f *fakePipelineStatusStore) StuckItems(_ context.Context, status string, olderThan time.Time, _ int) (model.PipelineStuckItems, error) {
+	f.cutoffs[status] = olderThan
+	return model.PipelineStuckItems{Status: status, Count: f.stuck[status], SampleItemIDs: []string{}}, nil
+}
+
+func (f *fakePipelineStatusStore) StepFailureRates(context.Context, time.Time) ([]model.PipelineStepFailureRate, error) {
+	return f.failures, nil
+}
+
+func (f *fakePipelineStatusStore) SlowestSources(context.Context, time.Time, int) ([]model.PipelineSlowSource, error) {
+	return []model.PipelineSlowSource{}, nil
+}
+
+func (f *fakePipelineStatusStore) AutoReprocessCounts(context.Context, time.Time, int) (model.PipelineAutoReprocess, error) {
+	return f.reprocess, nil
+}
+
+func TestPipelineStatusFlagsStuckItemsAndFailingSteps(t *testing.T) {
+	t.Setenv("PIPELINE_STUCK_SLAS", "fetched=1h")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	store := &fakePipelineStatusStore{
+		stuck: map[string]int{"fetched": 2},
+		failures: []model.PipelineStepFailureRate{
+			{Step: "summarize", Attempts: 50, Failures: 15, FailureRate: 0.3},
+			{Step: "extract-body", Attempts: 5, Failures: 5, FailureRate: 1},
+		},
+		cutoffs: map[string]time.Time{},
+	}
+	svc := NewPipelineStatusService(store)
+	svc.now = func() time.Time { return now }
+
+	got, err := svc.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if got.Status != "degraded" || len(got.Alerts) != 2 {
+		t.Fatalf("status=%s alerts=%v", got.Status, got.Alerts)
+	}
+	if !strings.Contains(got.Alerts[0], "2 items in fetched") || !strings.Contains(got.Alerts[1], "summarize failed 15 of 50") {
+		t.Fatalf("alerts = %v", got.Alerts)
+	}
+	if !store.cutoffs["fetched"].Equal(now.Add(-time.Hour)) || !store.cutoffs["new"].Equal(now.Add(-30*time.Minute)) {
+		t.Fatalf("cutoffs = %v", store.cutoffs)
+	}
+	if len(got.Stuck) != 3 || got.Stuck[1].SLAMinutes != 60 || got.QueueDepth["fetched"] != 3 {
+		t.Fatalf("stuck = %+v depth = %v", got.Stuck, got.QueueDepth)
+	}
+}
+
+func TestPipelineStatusOKWhenQuiet(t *testing.T) {
+	svc := NewPipelineStatusService(&fakePipelineStatusStore{cutoffs: map[string]time.Time{}})
+	got, err := svc.Status(context.Background())
+	if err != nil || got.Status != "ok" || len(got.Alerts) != 0 {
+		t.Fatalf("got %+v err=%v", got, err)
+	}
+}
+
+func TestPipelineStatusReportsExhaustedAutoReprocess(t *testing.T) {
+	t.Setenv("PIPELINE_AUTO_REPROCESS_MAX", "2")
+	store := &fakePipelineStatusStore{
+		cutoffs:   map[string]time.Time{},
+		reprocess: model.PipelineAutoReprocess{Reprocessed: 7, Exhausted: 1},
+	}
+	got, err := NewPipelineStatusService(store).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if got.Status != "degraded" || got.AutoReprocess.MaxAttempts != 2 || got.AutoReprocess.Reprocessed != 7 {
+		t.Fatalf("got %+v", got)
+	}
+	if len(got.Alerts) != 1 || !strings.Contains(got.Alerts[0], "1 items failed after 2 automatic reprocesses") {
+		t.Fatalf("alerts = %v", got.Alerts)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	// PrescreenMinFeedback is how much feedback a profile needs before pre-screening trusts it.
+	PrescreenMinFeedback    = 10
+	PrescreenProjectionDays = 30
+
+	prescreenExcerptRunes  = 1200
+	prescreenStubRunes     = 240
+	prescreenMinTopicRunes = 2
+	prescreenMinInterest   = 0.2
+)
+
+// PrescreenResult is the pre-screen score of an item, from 0 (clearly unwanted) to 1, with the
+// profile topics found in its title and excerpt.
+type PrescreenResult struct {
+	Score         float64  `json:"score"`
+	MatchedTopics []string `json:"matched_topics,omitempty"`
+}
+
+// Prescreen guesses how relevant an item is from its title, the start of its body and its
+// source, using the user's preference profile. It reports false when the profile has too
+// little feedback to judge, so cold-start users never lose summaries.
+func Prescreen(title, content, sourceID string, profile *model.UserPreferenceProfile) (PrescreenResult, bool) {
+	if profile == nil || profile.FeedbackCount < PrescreenMinFeedback {
+		return PrescreenResult{}, false
+	}
+	text := strings.ToLower(title + "\n" + prescreenHead(content, prescreenExcerptRunes))
+	topicRel := 0.5
+	var matched []string
+	var sum float64
+	for topic, interest := range profile.TopicInterests {
+		if math.Abs(interest) < prescreenMinInterest || utf8.RuneCountInString(topic) < prescreenMinTopicRunes {
+			continue
+		}
+		if containsPrescreenTopic(text, strings.ToLower(topic)) {
+			matched = append(matched, topic)
+			sum += interest
+		}
+	}
+	if len(matched) > 0 {
+		topicRel = 0.5 + 0.5*sum/float64(len(matched))
+		sort.Strings(matched)
+	}
+	srcAff := 0.5
+	if v, ok := profile.SourceAffinities[sourceID]; ok {
+		srcAff = 0.5 + 0.5*v
+	}
+	score := 0.6*topicRel + 0.4*srcAff
+	return PrescreenResult{Score: math.Max(0, math.Min(1, score)), MatchedTopics: matched}, true
+}
+
+// containsPrescreenTopic matches Latin-script topics on word boundaries, so "ai" does not hit
+// "again"; other scripts have no spaces to anchor on and match as substrings.
+func containsPrescreenTopic(text, topic string) bool {
+	if !isASCII(topic) {
+		return strings.Contains(text, topic)
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], topic)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(topic)
+		if (start == 0 || !isASCIIWordByte(text[start-1])) && (end == len(text) || !isASCIIWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIIWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// PrescreenApplies reports whether process-item should pre-screen a run triggered for reason.
+// Retries are explicit requests for a full summary, so they always skip it.
+func PrescreenApplies(reason string) bool {
+	return !strings.HasPrefix(strings.TrimSpace(reason), "retry")
+}
+
+// PrescreenSkips reports whether score falls under the user's threshold.
+func PrescreenSkips(settings *model.UserSettings, score float64) bool {
+	return settings != nil && settings.PrescreenThreshold != nil && score < *settings.PrescreenThreshold
+}
+
+// PrescreenStubSummary is the summary stored for a skipped item: the start of its body, cut
+// at the last sentence end that fits.
+func PrescreenStubSummary(content string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	if utf8.RuneCountInString(content) <= prescreenStubRunes {
+		return content
+	}
+	cut := prescreenHead(content, prescreenStubRunes)
+	if i := strings.LastIndexAny(cut, "。.!?！？"); i >= len(cut)/2 {
+		_, size := utf8.DecodeRuneInString(cut[i:])
+		return cut[:i+size]
+	}
+	return strings.TrimSpace(cut) + "…"
+}
+
+func prescreenHead(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+type PrescreenSettingsView struct {
+	Threshold *float64 `json:"threshold"`
+}
+
+func NewPrescreenSettingsView(settings *model.UserSettings) PrescreenSettingsView {
+	if settings == nil {
+		return PrescreenSettingsView{}
+	}
+	return PrescreenSettingsView{Threshold: settings.PrescreenThreshold}
+}
+
+func validatePrescreenThreshold(v *float64) error {
+	if v != nil && (math.IsNaN(*v) || *v < 0 || *v > 1) {
+		return &ValidationError{Field: "threshold", Message: "threshold must be between 0 and 1"}
+	}
+	return nil
+}
+
+// PrescreenProjection estimates what a threshold would have saved over the last
+// PrescreenProjectionDays, from the scores recorded for the user's items and their average
+// fact extraction and summary cost.
+type PrescreenProjection struct {
+	Threshold           float64 `json:"threshold"`
+	WindowDays          int     `json:"window_days"`
+	ScoredItems         int     `json:"scored_items"`
+	SkippedItems        int     `json:"skipped_items"`
+	SkipRate            float64 `json:"skip_rate"`
+	AvgCostPerItemUSD   float64 `json:"avg_cost_per_item_usd"`
+	ProjectedSavingsUSD float64 `json:"projected_savings_usd"`
+}
+
+func NewPrescreenProjection(threshold float64, stats repository.PrescreenStats) PrescreenProjection {
+	out := PrescreenProjection{
+		Threshold:    threshold,
+		WindowDays:   PrescreenProjectionDays,
+		ScoredItems:  stats.ScoredItems,
+		SkippedItems: stats.BelowThreshold,
+	}
+	if stats.ScoredItems > 0 {
+		out.SkipRate = float64(stats.BelowThreshold) / float64(stats.ScoredItems)
+	}
+	if stats.CostedItems > 0 {
+		out.AvgCostPerItemUSD = stats.CostUSD / float64(stats.CostedItems)
+	}
+	out.ProjectedSavingsUSD = out.AvgCostPerItemUSD * float64(stats.BelowThreshold)
+	return out
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestPrescreenScoresTopicsAndSource(t *testing.T) {
+	profile := &model.UserPreferenceProfile{
+		FeedbackCount:    25,
+		TopicInterests:   map[string]float64{"rust": 1, "crypto": -0.8, "ai": 0.1},
+		SourceAffinities: map[string]float64{"src-low": -0.6},
+	}
+
+	liked, ok := Prescreen("Rust 1.90 released", "The Rust team announced...", "src-other", profile)
+	if !ok || math.Abs(liked.Score-0.8) > 1e-9 || len(liked.MatchedTopics) != 1 || liked.MatchedTopics[0] != "rust" {
+		t.Fatalf("liked = %+v, %v", liked, ok)
+	}
+	disliked, _ := Prescreen("Crypto market update", "Prices moved again.", "src-low", profile)
+	if math.Abs(disliked.Score-(0.6*0.1+0.4*0.2)) > 1e-9 {
+		t.Fatalf("disliked score = %v", disliked.Score)
+	}
+	neutral, _ := Prescreen("Gardening tips", "Water in the morning again; no cryptography here.", "src-other", profile)
+	if neutral.Score != 0.5 || len(neutral.MatchedTopics) != 0 {
+		t.Fatalf("neutral = %+v", neutral)
+	}
+}
+
+func TestPrescreenSkipsColdStartProfiles(t *testing.T) {
+	if _, ok := Prescreen("Rust", "", "", nil); ok {
+		t.Fatal("nil profile was scored")
+	}
+	if _, ok := Prescreen("Rust", "", "", &model.UserPreferenceProfile{FeedbackCount: PrescreenMinFeedback - 1}); ok {
+		t.Fatal("cold-start profile was scored")
+	}
+}
+
+func TestPrescreenAppliesAndSkips(t *testing.T) {
+	for reason, want := range map[string]bool{"": true, "unknown": true, "manual_source": true, "retry": false, "retry_from_facts": false, "retry_failed": false} {
+		if got := PrescreenApplies(reason); got != want {
+			t.Fatalf("PrescreenApplies(%q) = %v, want %v", reason, got, want)
+		}
+	}
+	threshold := 0.4
+	settings := &model.UserSettings{PrescreenThreshold: &threshold}
+	if !PrescreenSkips(settings, 0.39) || PrescreenSkips(settings, 0.4) || PrescreenSkips(&model.UserSettings{}, 0) || PrescreenSkips(nil, 0) {
+		t.Fatal("PrescreenSkips threshold handling")
+	}
+}
+
+func TestPrescreenStubSummary(t *testing.T) {
+	if got := PrescreenStubSummary("  short\n body "); got != "short body" {
+		t.Fatalf("short stub = %q", got)
+	}
+	long := strings.Repeat("これはテストの文です。", 40)
+	got := PrescreenStubSummary(long)
+	if !strings.HasSuffix(got, "。") || len([]rune(got)) > prescreenStubRunes {
+		t.Fatalf("sentence stub = %q", got)
+	}
+	if got := PrescreenStubSummary(strings.Repeat("a", 500)); !strings.HasSuffix(got, "…") {
+		t.Fatalf("unbroken stub = %q", got)
+	}
+}
+
+func TestNewPrescreenProjection(t *testing.T) {
+	got := NewPrescreenProjection(0.4, repository.PrescreenStats{ScoredItems: 200, BelowThreshold: 50, CostUSD: 3, CostedItems: 150})
+	if got.SkipRate != 0.25 || math.Abs(got.AvgCostPerItemUSD-0.02) > 1e-12 || math.Abs(got.ProjectedSavingsUSD-1) > 1e-9 || got.WindowDays != PrescreenProjectionDays {
+		t.Fatalf("projection = %+v", got)
+	}
+	if empty := NewPrescreenProjection(0.4, repository.PrescreenStats{}); empty.SkipRate != 0 || empty.ProjectedSavingsUSD != 0 {
+		t.Fatalf("empty projection = %+v", empty)
+	}
+}
+
+func TestValidatePrescreenThreshold(t *testing.T) {
+	for _, v := range []float64{-0.1, 1.1, math.NaN()} {
+		if err := validatePrescreenThreshold(&v); err == nil {
+			t.Fatalf("threshold %v accepted", v)
+		}
+	}
+	ok := 0.3
+	if validatePrescreenThreshold(&ok) != nil || validatePrescreenThreshold(nil) != nil {
+		t.Fatal("valid threshold rejected")
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/inngest/inngestgo"
+)
+
+// Resuming queues the paused backlog as item/created events spread out in batches, so a week
+// of fetched items does not hit the worker and the user's LLM budget all at once. The backlog
+// is queued in pages of MaxProcessingBackfillItems; a full page ends with a
+// ProcessingBackfillContinueEvent that queues the next page once the current one has started.
+const (
+	ProcessingBackfillBatchSize     = 20
+	ProcessingBackfillInterval      = time.Minute
+	MaxProcessingBackfillItems      = 1000
+	ProcessingBackfillContinueEvent = "processing/backfill.continue"
+)
+
+// ProcessingBackfillContinueData is the continuation event's payload: the pause it resumes
+// from and the last item the previous page queued.
+type ProcessingBackfillContinueData struct {
+	UserID         string    `json:"user_id"`
+	PausedAt       time.Time `json:"paused_at"`
+	AfterCreatedAt time.Time `json:"after_created_at"`
+	AfterItemID    string    `json:"after_item_id"`
+}
+
+// ProcessingPaused reports whether the user paused LLM processing. Scheduled jobs that spend
+// LLM budget on the user's behalf (digests, briefs, audio briefings, embeddings) skip them.
+func ProcessingPaused(settings *model.UserSettings) bool {
+	return settings != nil && settings.ProcessingPausedAt != nil
+}
+
+type processingPauseSettingsRepo interface {
+	EnsureDefaults(ctx context.Context, userID string) (*model.UserSettings, error)
+	SetProcessingPaused(ctx context.Context, userID string, paused bool) (*model.UserSettings, error)
+}
+
+type processingBacklogRepo interface {
+	CountProcessingBacklog(ctx context.Context, userID string) (int, error)
+	ClaimProcessingBacklog(ctx context.Context, userID string, after *repository.ItemBacklogCursor, limit int) ([]repository.ItemBacklogTarget, error)
+}
+
+type processingPausePublisher interface {
+	SendEventsE(ctx context.Context, events []inngestgo.Event) error
+}
+
+// ProcessingPauseStatus tells whether LLM processing is paused and how many items wait in
+// 'fetched' for it.
+type ProcessingPauseStatus struct {
+	Paused   bool       `json:"paused"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+	Backlog  int        `json:"backlog"`
+}
+
+// ProcessingResumeResult is the status after resuming plus what the backfill queued. The
+// last batch starts BackfillMinutes from now.
+type ProcessingResumeResult struct {
+	ProcessingPauseStatus
+	Queued          int `json:"queued"`
+	Remaining       int `json:"remaining"`
+	BackfillMinutes int `json:"backfill_minutes"`
+}
+
+// ProcessingPauseService pauses a user's LLM processing. While paused, fetch-rss keeps
+// recording new items and process-item extracts them, then stops at 'fetched'.
+type ProcessingPauseService struct {
+	settings  processingPauseSettingsRepo
+	items     processingBacklogRepo
+	publisher processingPausePublisher
+	now       func() time.Time
+}
+
+func NewProcessingPauseService(settings processingPauseSettingsRepo, items processingBacklogRepo, publisher processingPausePublisher) *ProcessingPauseService {
+	return &ProcessingPauseService{settings: settings, items: items, publisher: publisher, now: time.Now}
+}
+
+func (s *ProcessingPauseService) Status(ctx context.Context, userID string) (*ProcessingPauseStatus, error) {
+	settings, err := s.settings.EnsureDefaults(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, userID, settings)
+}
+
+func (s *ProcessingPauseService) Pause(ctx context.Context, userID string) (*ProcessingPauseStatus, error) {
+	settings, err := s.settings.SetProcessingPaused(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, userID, settings)
+}
+
+// Resume lifts the pause and queues the first page of the backlog in batches of
+// ProcessingBackfillBatchSize, one batch per ProcessingBackfillInterval. Resuming when not
+// paused queues nothing.
+func (s *ProcessingPauseService) Resume(ctx context.Context, userID string) (*ProcessingResumeResult, error) {
+	before, err := s.settings.EnsureDefaults(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settings.SetProcessingPaused(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	queued := 0
+	if before.ProcessingPausedAt != nil {
+		queued, err = s.queueBackfillPage(ctx, userID, *before.ProcessingPausedAt, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	status, err := s.status(ctx, userID, settings)
+	if err != nil {
+		return nil, err
+	}
+	out := &ProcessingResumeResult{ProcessingPauseStatus: *status, Queued: queued}
+	out.Remaining = max(status.Backlog-queued, 0)
+	if queued > 0 {
+		out.BackfillMinutes = (queued - 1) / ProcessingBackfillBatchSize * int(ProcessingBackfillInterval/time.Minute)
+	}
+	return out, nil
+}
+
+// ContinueBackfill queues the page after the one a continuation event was sent from. A user
+// who paused again in between gets nothing queued; their next resume starts from the oldest
+// waiting item.
+func (s *ProcessingPauseService) ContinueBackfill(ctx context.Context, data ProcessingBackfillContinueData) (int, error) {
+	settings, err := s.settings.EnsureDefaults(ctx, data.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if ProcessingPaused(settings) {
+		return 0, nil
+	}
+	return s.queueBackfillPage(ctx, data.UserID, data.PausedAt, &repository.ItemBacklogCursor{CreatedAt: data.AfterCreatedAt, ItemID: data.AfterItemID})
+}
+
+// queueBackfillPage claims one page of the backlog and sends its events. Event IDs are keyed
+// on the item and the pause being resumed, so a resume sent twice queues each item once.
+func (s *ProcessingPauseService) queueBackfillPage(ctx context.Context, userID string, pausedAt time.Time, after *repository.ItemBacklogCursor) (int, error) {
+	targets, err := s.items.ClaimProcessingBacklog(ctx, userID, after, MaxProcessingBackfillItems)
+	if err != nil {
+		return 0, err
+	}
+	now := s.now()
+	events := processingBackfillEvents(userID, pausedAt, targets, now)
+	if len(targets) == MaxProcessingBackfillItems {
+		last := targets[len(targets)-1]
+		events = append(events, processingBackfillContinueEvent(ProcessingBackfillContinueData{
+			UserID:         userID,
+			PausedAt:       pausedAt,
+			AfterCreatedAt: last.CreatedAt,
+			AfterItemID:    last.ItemID,
+		}, now.Add(time.Duration(len(targets)/ProcessingBackfillBatchSize)*ProcessingBackfillInterval)))
+	}
+	if err := s.publisher.SendEventsE(ctx, events); err != nil {
+		return 0, err
+	}
+	return len(targets), nil
+}
+
+func (s *ProcessingPauseService) status(ctx context.Context, userID string, settings *model.UserSettings) (*ProcessingPauseStatus, error) {
+	backlog, err := s.items.CountProcessingBacklog(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &ProcessingPauseStatus{
+		Paused:   settings.ProcessingPausedAt != nil,
+		PausedAt: settings.ProcessingPausedAt,
+		Backlog:  backlog,
+	}, nil
+}
+
+// processingBackfillEvents sends the first batch right away and schedules each later batch one
+// interval after the previous one.
+func processingBackfillEvents(userID string, pausedAt time.Time, targets []repository.ItemBacklogTarget, now time.Time) []inngestgo.Event {
+	events := make([]inngestgo.Event, 0, len(targets)+1)
+	for i, t := range targets {
+		ev := NewItemCreatedEvent(t.ItemID, t.SourceID, userID, t.URL, t.Title, "processing_resume")
+		id := fmt.Sprintf("processing-resume-%s-%d", t.ItemID, pausedAt.Unix())
+		ev.ID = &id
+		if batch := i / ProcessingBackfillBatchSize; batch > 0 {
+			ev.Timestamp = inngestgo.Timestamp(now.Add(time.Duration(batch) * ProcessingBackfillInterval))
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func processingBackfillContinueEvent(data ProcessingBackfillContinueData, at time.Time) inngestgo.Event {
+	id := fmt.Sprintf("processing-backfill-continue-%s-%d-%s", data.UserID, data.PausedAt.Unix(), data.AfterItemID)
+	return inngestgo.Event{
+		ID:   &id,
+		Name: ProcessingBackfillContinueEvent,
+		Data: map[string]any{
+			"user_id":          data.UserID,
+			"paused_at":        data.PausedAt,
+			"after_created_at": data.AfterCreatedAt,
+			"after_item_id":    data.AfterItemID,
+		},
+		Timestamp: inngestgo.Timestamp(at),
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeProcessingPauseSettings struct{ pausedAt *time.Time }
+
+func (f *fakeProcessingPauseSettings) EnsureDefaults(context.Context, string) (*model.UserSettings, error) {
+	return &model.UserSettings{UserID: "u-1", ProcessingPausedAt: f.pausedAt}, nil
+}
+
+func (f *fakeProcessingPauseSettings) SetProcessingPaused(_ context.Context, _ string, paused bool) (*model.UserSettings, error) {
+	if !paused {
+		f.pausedAt = nil
+	} else if f.pausedAt == nil {
+		now := time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC)
+		f.pausedAt = &now
+	}
+	return &model.UserSettings{UserID: "u-1", ProcessingPausedAt: f.pausedAt}, nil
+}
+
+type fakeProcessingBacklog struct {
+	targets   []repository.ItemBacklogTarget
+	claimed   bool
+	lastLimit int
+	lastAfter *repository.ItemBacklogCursor
+}
+
+func (f *fakeProcessingBacklog) CountProcessingBacklog(context.Context, string) (int, error) {
+	return len(f.targets), nil
+}
+
+func (f *fakeProcessingBacklog) ClaimProcessingBacklog(_ context.Context, _ string, after *repository.ItemBacklogCursor, limit int) ([]repository.ItemBacklogTarget, error) {
+	f.claimed, f.lastLimit, f.lastAfter = true, limit, after
+	rest := f.targets
+	if after != nil {
+		for i, t := range f.targets {
+			if t.ItemID == after.ItemID {
+				rest = f.targets[i+1:]
+			}
+		}
+	}
+	if len(rest) > limit {
+		return rest[:limit], nil
+	}
+	return rest, nil
+}
+
+func backlogTargets(n int) []repository.ItemBacklogTarget {
+	out := make([]repository.ItemBacklogTarget, n)
+	for i := range out {
+		out[i] = repository.ItemBacklogTarget{
+			ItemID:    fmt.Sprintf("i-%d", i),
+			SourceID:  "s-1",
+			URL:       fmt.Sprintf("https://example.com/%d", i),
+			CreatedAt: time.Date(2026, 8, 1, 0, 0, i, 0, time.UTC),
+		}
+	}
+	return out
+}
+
+func TestProcessingPauseReportsBacklog(t *testing.T) {
+	settings := &fakeProcessingPauseSettings{}
+	svc := NewProcessingPauseService(settings, &fakeProcessingBacklog{targets: backlogTargets(3)}, &fakeEventSender{})
+
+	status, err := svc.Pause(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if !status.Paused || status.PausedAt == nil || status.Backlog != 3 {
+		t.Fatalf("status = %+v", status)
+	}
+}
+
+func TestProcessingResumeQueuesBacklogInBatches(t *testing.T) {
+	pausedAt := time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 8, 8, 9, 0, 0, 0, time.UTC)
+	items := &fakeProcessingBacklog{targets: backlogTargets(ProcessingBackfillBatchSize*2 + 1)}
+	events := &fakeEventSender{}
+	svc := NewProcessingPauseService(&fakeProcessingPauseSettings{pausedAt: &pausedAt}, items, events)
+	svc.now = func() time.Time { return now }
+
+	res, err := svc.Resume(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if res.Paused || res.Queued != len(items.targets) || res.Remaining != 0 || res.BackfillMinutes != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+	if items.lastLimit != MaxProcessingBackfillItems {
+		t.Fatalf("claim limit = %d", items.lastLimit)
+	}
+	first, second, third := events.events[0], events.events[ProcessingBackfillBatchSize], events.events[ProcessingBackfillBatchSize*2]
+	if first.Name != "item/created" || first.Data["reason"] != "processing_resume" || first.Data["user_id"] != "u-1" || first.Timestamp != 0 {
+		t.Fatalf("first event = %+v", first)
+	}
+	if second.Timestamp != now.Add(ProcessingBackfillInterval).UnixMilli() || third.Timestamp != now.Add(2*ProcessingBackfillInterval).UnixMilli() {
+		t.Fatalf("timestamps = %d, %d", second.Timestamp, third.Timestamp)
+	}
+}
+
+func TestProcessingResumeWithoutPauseQueuesNothing(t *testing.T) {
+	items := &fakeProcessingBacklog{targets: backlogTargets(2)}
+	events := &fakeEventSender{}
+	svc := NewProcessingPauseService(&fakeProcessingPauseSettings{}, items, events)
+
+	res, err := svc.Resume(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if items.claimed || len(events.events) != 0 || res.Queued != 0 || res.Backlog != 2 || res.Remaining != 2 {
+		t.Fatalf("result = %+v claimed = %v events = %d", res, items.claimed, len(events.events))
+	}
+}
+
+func TestProcessingResumeContinuesPastOnePage(t *testing.T) {
+	pausedAt := time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 8, 8, 9, 0, 0, 0, time.UTC)
+	items := &fakeProcessingBacklog{targets: backlogTargets(MaxProcessingBackfillItems + 5)}
+	events := &fakeEventSender{}
+	settings := &fakeProcessingPauseSettings{pausedAt: &pausedAt}
+	svc := NewProcessingPauseService(settings, items, events)
+	svc.now = func() time.Time { return now }
+
+	res, err := svc.Resume(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if res.Queued != MaxProcessingBackfillItems || res.Remaining != 5 {
+		t.Fatalf("result = %+v", res)
+	}
+	if len(events.events) != MaxProcessingBackfillItems+1 {
+		t.Fatalf("events = %d", len(events.events))
+	}
+	cont := events.events[len(events.events)-1]
+	last := items.targets[MaxProcessingBackfillItems-1]
+	wantAt := now.Add(MaxProcessingBackfillItems / ProcessingBackfillBatchSize * ProcessingBackfillInterval)
+	if cont.Name != ProcessingBackfillContinueEvent || cont.Data["after_item_id"] != last.ItemID || cont.Timestamp != wantAt.UnixMilli() {
+		t.Fatalf("continue event = %+v", cont)
+	}
+
+	// A repeated resume of the same pause produces the same event IDs, so Inngest drops it.
+	first := events.events[0]
+	if first.ID == nil || *first.ID != fmt.Sprintf("processing-resume-i-0-%d", pausedAt.Unix()) {
+		t.Fatalf("first event id = %v", first.ID)
+	}
+
+	events.events = nil
+	queued, err := svc.ContinueBackfill(context.Background(), ProcessingBackfillContinueData{
+		UserID:         "u-1",
+		PausedAt:       pausedAt,
+		AfterCreatedAt: last.CreatedAt,
+		AfterItemID:    last.ItemID,
+	})
+	if err != nil {
+		t.Fatalf("ContinueBackfill: %v", err)
+	}
+	if queued != 5 || len(events.events) != 5 || items.lastAfter == nil || items.lastAfter.ItemID != last.ItemID {
+		t.Fatalf("queued = %d events = %d after = %+v", queued, len(events.events), items.lastAfter)
+	}
+	if events.events[0].Data["item_id"] != items.targets[MaxProcessingBackfillItems].ItemID {
+		t.Fatalf("second page starts at %v", events.events[0].Data["item_id"])
+	}
+}
+
+func TestProcessingContinueBackfillStopsWhenPausedAgain(t *testing.T) {
+	pausedAt := time.Date(2026, 8, 9, 9, 0, 0, 0, time.UTC)
+	items := &fakeProcessingBacklog{targets: backlogTargets(3)}
+	events := &fakeEventSender{}
+	svc := NewProcessingPauseService(&fakeProcessingPauseSettings{pausedAt: &pausedAt}, items, events)
+
+	queued, err := svc.ContinueBackfill(context.Background(), ProcessingBackfillContinueData{UserID: "u-1", AfterItemID: "i-0"})
+	if err != nil {
+		t.Fatalf("ContinueBackfill: %v", err)
+	}
+	if queued != 0 || items.claimed || len(events.events) != 0 {
+		t.Fatalf("queued = %d claimed = %v events = %d", queued, items.claimed, len(events.events))
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	providerCooldownDefault = 30 * time.Second
+	providerCooldownMax     = 10 * time.Minute
+)
+
+// ProviderCooldown remembers, per user and LLM provider, when a rate-limit window resets, so
+// every step of that user waits it out instead of spending retries on further 429s. State
+// lives in the shared cache (Redis) and expires with the window.
+type ProviderCooldown struct {
+	cache JSONCache
+	now   func() time.Time
+}
+
+func NewProviderCooldown(cache JSONCache) *ProviderCooldown {
+	return &ProviderCooldown{cache: cache, now: time.Now}
+}
+
+type providerCooldownEntry struct {
+	Until time.Time `json:"until"`
+}
+
+func providerCooldownKey(userID, provider string) string {
+	return "llm-cooldown:" + userID + ":" + strings.ToLower(strings.TrimSpace(provider))
+}
+
+// Record starts or extends the cooldown by retryAfter, clamped to 1s..10m (30s when the
+// provider sent no hint), and returns when it ends. A shorter wait never shortens a window
+// that is already recorded.
+func (c *ProviderCooldown) Record(ctx context.Context, userID, provider string, retryAfter time.Duration) time.Time {
+	switch {
+	case retryAfter <= 0:
+		retryAfter = providerCooldownDefault
+	case retryAfter < time.Second:
+		retryAfter = time.Second
+	case retryAfter > providerCooldownMax:
+		retryAfter = providerCooldownMax
+	}
+	if c == nil || c.cache == nil || userID == "" || provider == "" {
+		return time.Now().Add(retryAfter)
+	}
+	until := c.now().Add(retryAfter)
+	if current, ok := c.Until(ctx, userID, provider); ok && current.After(until) {
+		return current
+	}
+	_ = c.cache.SetJSON(ctx, providerCooldownKey(userID, provider), providerCooldownEntry{Until: until}, retryAfter)
+	return until
+}
+
+// Until reports when the user's cooldown for provider ends, if one is still running.
+func (c *ProviderCooldown) Until(ctx context.Context, userID, provider string) (time.Time, bool) {
+	if c == nil || c.cache == nil || userID == "" || provider == "" {
+		return time.Time{}, false
+	}
+	var entry providerCooldownEntry
+	ok, err := c.cache.GetJSON(ctx, providerCooldownKey(userID, provider), &entry)
+	if err != nil || !ok || !entry.Until.After(c.now()) {
+		return time.Time{}, false
+	}
+	return entry.Until, true
+}
+
+// ProviderRateLimit reports whether err is an LLM provider 429 relayed by the worker, with the
+// provider and the wait it asked for.
+func ProviderRateLimit(err error) (provider string, retryAfter time.Duration, ok bool) {
+	var we *WorkerError
+	if !errors.As(err, &we) || we.StatusCode != http.StatusTooManyRequests || we.Provider == "" {
+		return "", 0, false
+	}
+	return we.Provider, we.RetryAfter, true
+}
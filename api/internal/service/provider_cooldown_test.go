@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProviderCooldownRecordClampsAndNeverShortens(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	c := &ProviderCooldown{cache: &memoryJSONCache{}, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	if until := c.Record(ctx, "u1", "OpenAI", 0); !until.Equal(now.Add(providerCooldownDefault)) {
+		t.Fatalf("default until = %v", until)
+	}
+	if until := c.Record(ctx, "u1", "openai", 5*time.Second); !until.Equal(now.Add(providerCooldownDefault)) {
+		t.Fatalf("shorter wait shortened the window: %v", until)
+	}
+	if until := c.Record(ctx, "u1", "openai", time.Hour); !until.Equal(now.Add(providerCooldownMax)) {
+		t.Fatalf("capped until = %v", until)
+	}
+	if until, ok := c.Until(ctx, "u1", "openai"); !ok || !until.Equal(now.Add(providerCooldownMax)) {
+		t.Fatalf("Until() = %v, %v", until, ok)
+	}
+	if _, ok := c.Until(ctx, "u2", "openai"); ok {
+		t.Fatal("cooldown leaked to another user")
+	}
+
+	now = now.Add(providerCooldownMax)
+	if _, ok := c.Until(ctx, "u1", "openai"); ok {
+		t.Fatal("expired cooldown still reported")
+	}
+}
+
+func TestProviderRateLimit(t *testing.T) {
+	err := fmt.Errorf("summarize: %w", &WorkerError{Path: "/summarize", StatusCode: 429, Provider: "anthropic", RetryAfter: 12 * time.Second})
+	provider, retryAfter, ok := ProviderRateLimit(err)
+	if !ok || provider != "anthropic" || retryAfter != 12*time.Second {
+		t.Fatalf("ProviderRateLimit() = %q, %v, %v", provider, retryAfter, ok)
+	}
+	for _, err := range []error{
+		errors.New("boom"),
+		&WorkerError{Path: "/summarize", StatusCode: 429},
+		&WorkerError{Path: "/summarize", StatusCode: 500, Provider: "openai"},
+	} {
+		if _, _, ok := ProviderRateLimit(err); ok {
+			t.Fatalf("ProviderRateLimit(%v) ok", err)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+const readabilityMinContentChars = 200
+
+// ReadabilityExtractor fetches a page and extracts its main text in process, scoring block
+// containers the way Mozilla Readability does. It handles ordinary server-rendered articles;
+// pages that need JavaScript, PDFs and YouTube stay with the worker.
+type ReadabilityExtractor struct {
+	http      *http.Client
+	userAgent string
+}
+
+func NewReadabilityExtractor() *ReadabilityExtractor {
+	return &ReadabilityExtractor{
+		http:      NewPublicHTTPClient(30 * time.Second),
+		userAgent: "Mozilla/5.0 (compatible; SiftoBot/1.0; +https://sifto.app)",
+	}
+}
+
+func (e *ReadabilityExtractor) ExtractBody(ctx context.Context, url string) (*ExtractBodyResponse, error) {
+	doc, _, err := e.fetchDocument(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return ExtractReadableContent(doc)
+}
+
+// fetchDocument GETs and parses an HTML page, returning the URL it ended up at after redirects.
+func (e *ReadabilityExtractor) fetchDocument(ctx context.Context, url string) (*html.Node, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", e.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("readability fetch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, "", fmt.Errorf("readability fetch: status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return nil, "", fmt.Errorf("readability: unsupported content type %q", ct)
+	}
+	body, err := charset.NewReader(io.LimitReader(resp.Body, 5<<20), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, "", fmt.Errorf("readability charset: %w", err)
+	}
+	doc, err := html.Parse(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("readability parse: %w", err)
+	}
+	return doc, resp.Request.URL.String(), nil
+}
+
+// ExtractReadableContent returns the title, main text and metadata of a parsed page.
+func ExtractReadableContent(doc *html.Node) (*ExtractBodyResponse, error) {
+	out := &ExtractBodyResponse{}
+	meta := readabilityMeta(doc)
+	if t := firstNonEmptyTrimmed(meta["og:title"], meta["twitter:title"], readabilityTitle(doc)); t != "" {
+		out.Title = &t
+	}
+	if img := firstNonEmptyTrimmed(meta["og:image"], meta["twitter:image"]); img != "" {
+		out.ImageURL = &img
+	}
+	if published := firstNonEmptyTrimmed(meta["article:published_time"], meta["date"], meta["pubdate"]); published != "" {
+		out.PublishedAt = &published
+	}
+	if canonical := readabilityCanonicalLink(doc); canonical != "" {
+		out.CanonicalURL = &canonical
+	}
+
+	pruneReadabilityNoise(doc)
+	best := bestReadabilityCandidate(doc)
+	if best == nil {
+		return nil, fmt.Errorf("readability: no readable content")
+	}
+	out.Content = readabilityText(best)
+	if len([]rune(out.Content)) < readabilityMinContentChars {
+		return nil, fmt.Errorf("readability: no readable content")
+	}
+	return out, nil
+}
+
+func readabilityMeta(doc *html.Node) map[string]string {
+	out := map[string]string{}
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Meta {
+			return true
+		}
+		key := strings.ToLower(firstNonEmptyTrimmed(htmlAttr(n, "property"), htmlAttr(n, "name")))
+		if key != "" && out[key] == "" {
+			out[key] = strings.TrimSpace(htmlAttr(n, "content"))
+		}
+		return true
+	})
+	return out
+}
+
+func readabilityCanonicalLink(doc *html.Node) string {
+	var href string
+	walkHTML(doc, func(n *html.Node) bool {
+		if href == "" && n.DataAtom == atom.Link && strings.EqualFold(strings.TrimSpace(htmlAttr(n, "rel")), "canonical") {
+			href = strings.TrimSpace(htmlAttr(n, "href"))
+		}
+		return href == ""
+	})
+	if !strings.HasPrefix(href, "http://") && !strings.HasPrefix(href, "https://") {
+		return ""
+	}
+	return href
+}
+
+func readabilityTitle(doc *html.Node) string {
+	var title string
+	walkHTML(doc, func(n *html.Node) bool {
+		if title == "" && n.DataAtom == atom.Title {
+			title = strings.TrimSpace(nodeText(n))
+		}
+		return title == ""
+	})
+	return title
+}
+
+var readabilityNoiseAtoms = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Nav: true, atom.Header: true,
+	atom.Footer: true, atom.Aside: true, atom.Form: true, atom.Iframe: true, atom.Svg: true,
+	atom.Button: true, atom.Select: true, atom.Template: true,
+}
+
+var (
+	readabilityNegativeRe = regexp.MustCompile(`(?i)comment|\bmeta|footer|footnote|sidebar|sponsor|\bad-|advert|promo|related|share|social|\bnav|menu|breadcrumb|popup|cookie|newsletter|subscribe`)
+	readabilityPositiveRe = regexp.MustCompile(`(?i)article|body|content|entry|main|post|text|blog|story`)
+)
+
+func pruneReadabilityNoise(doc *html.Node) {
+	var remove []*html.Node
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			remove = append(remove, n)
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if readabilityNoiseAtoms[n.DataAtom] || strings.EqualFold(htmlAttr(n, "aria-hidden"), "true") || htmlAttr(n, "hidden") != "" {
+			remove = append(remove, n)
+			return false
+		}
+		if n.DataAtom != atom.Body && n.DataAtom != atom.Article && n.DataAtom != atom.Main {
+			sig := htmlAttr(n, "class") + " " + htmlAttr(n, "id")
+			if readabilityNegativeRe.MatchString(sig) && !readabilityPositiveRe.MatchString(sig) {
+				remove = append(remove, n)
+				return false
+			}
+		}
+		return true
+	})
+	for _, n := range remove {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// bestReadabilityCandidate scores each paragraph's parent and grandparent by text length and
+// comma count, adjusts by class/id hints and link density, and returns the top container.
+func bestReadabilityCandidate(doc *html.Node) *html.Node {
+	scores := map[*html.Node]float64{}
+	var order []*html.Node
+	add := func(n *html.Node, v float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			order = append(order, n)
+			scores[n] = readabilityClassWeight(n)
+			switch n.DataAtom {
+			case atom.Article, atom.Main:
+				scores[n] += 10
+			case atom.Div:
+				scores[n] += 5
+			}
+		}
+		scores[n] += v
+	}
+	walkHTML(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Blockquote && n.DataAtom != atom.Li {
+			return true
+		}
+		text := strings.TrimSpace(nodeText(n))
+		length := len([]rune(text))
+		if length < 25 {
+			return false
+		}
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "、")+strings.Count(text, "。")) + math.Min(float64(length)/100, 3)
+		add(n.Parent, score)
+		if n.Parent != nil {
+			add(n.Parent.Parent, score/2)
+		}
+		return false
+	})
+	var best *html.Node
+	bestScore := 0.0
+	for _, n := range order {
+		s := scores[n] * (1 - linkDensity(n))
+		if best == nil || s > bestScore {
+			best, bestScore = n, s
+		}
+	}
+	return best
+}
+
+func readabilityClassWeight(n *html.Node) float64 {
+	sig := htmlAttr(n, "class") + " " + htmlAttr(n, "id")
+	weight := 0.0
+	if readabilityNegativeRe.MatchString(sig) {
+		weight -= 25
+	}
+	if readabilityPositiveRe.MatchString(sig) {
+		weight += 25
+	}
+	return weight
+}
+
+func linkDensity(n *html.Node) float64 {
+	total := len([]rune(nodeText(n)))
+	if total == 0 {
+		return 0
+	}
+	links := 0
+	walkHTML(n, func(c *html.Node) bool {
+		if c.DataAtom == atom.A {
+			links += len([]rune(nodeText(c)))
+			return false
+		}
+		return true
+	})
+	return float64(links) / float64(total)
+}
+
+var readabilityBlockAtoms = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Pre: true,
+	atom.Blockquote: true, atom.Li: true, atom.Ul: true, atom.Ol: true, atom.Table: true, atom.Tr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Figure: true, atom.Figcaption: true, atom.Br: true,
+}
+
+var readabilitySpaceRe = regexp.MustCompile(`[ \t\r\f\v]+`)
+
+// readabilityText flattens n into paragraphs separated by blank lines.
+func readabilityText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		switch c.Type {
+		case html.TextNode:
+			sb.WriteString(c.Data)
+			return
+		case html.ElementNode:
+			if readabilityBlockAtoms[c.DataAtom] {
+				sb.WriteString("\n")
+				defer sb.WriteString("\n")
+			}
+		}
+		for child := c.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	var paragraphs []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if line = strings.TrimSpace(readabilitySpaceRe.ReplaceAllString(line, " ")); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func walkHTML(n *html.Node, fn func(*html.Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walkHTML(c, fn)
+		c = next
+	}
+}
+
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	walkHTML(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+		return true
+	})
+	return sb.String()
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestExtractReadableContentPicksArticleBody(t *testing.T) {
+	paragraph := "This paragraph carries the actual story, with enough words, commas, and detail to be scored as content."
+	page := `<html><head><title>Fallback title</title>
+<meta property="og:title" content="Real title">
+<meta property="og:image" content="https://example.com/a.png">
+<meta property="article:published_time" content="2026-10-15T09:00:00Z">
+<link rel="canonical" href="https://example.com/story">
+</head><body>
+<nav><a href="/">Home</a><a href="/news">News</a></nav>
+<div class="sidebar"><p>Subscribe to our newsletter to get the latest updates every single day.</p></div>
+<div class="post-content"><p>` + paragraph + `</p><p>` + paragraph + `</p><p>` + paragraph + `</p>
+<div class="share-buttons"><a href="#">Share this article on social media</a></div></div>
+<footer><p>Copyright and legal notice that should never be part of the extracted body.</p></footer>
+<script>var tracking = "noise";</script>
+</body></html>`
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ExtractReadableContent(doc)
+	if err != nil {
+		t.Fatalf("ExtractReadableContent() error = %v", err)
+	}
+	if got.Title == nil || *got.Title != "Real title" || got.ImageURL == nil || got.PublishedAt == nil ||
+		got.CanonicalURL == nil || *got.CanonicalURL != "https://example.com/story" {
+		t.Fatalf("metadata = %+v", got)
+	}
+	if strings.Count(got.Content, paragraph) != 3 || strings.Count(got.Content, "\n\n") != 2 {
+		t.Fatalf("content = %q", got.Content)
+	}
+	for _, noise := range []string{"newsletter", "Copyright", "tracking", "Share this"} {
+		if strings.Contains(got.Content, noise) {
+			t.Fatalf("content contains %q: %q", noise, got.Content)
+		}
+	}
+}
+
+func TestExtractReadableContentRejectsThinPages(t *testing.T) {
+	doc, _ := html.Parse(strings.NewReader(`<html><body><p>Just a short teaser paragraph here.</p></body></html>`))
+	if _, err := ExtractReadableContent(doc); err == nil {
+		t.Fatal("expected error for thin page")
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+// ReadingPlanSnapshotMaxAge bounds how old a precomputed plan may be before requests fall
+// back to a live computation. The snapshot job runs hourly.
+const ReadingPlanSnapshotMaxAge = 2 * time.Hour
+
+// ReadingPlanParamsFromSettings builds the params the snapshot job precomputes for a user.
+func ReadingPlanParamsFromSettings(s *model.UserSettings) repository.ReadingPlanParams {
+	params := repository.ReadingPlanParams{Window: "24h", Size: 15, DiversifyTopics: true, ExcludeRead: true}
+	if s == nil {
+		return params
+	}
+	if s.ReadingPlanWindow != "" {
+		params.Window = s.ReadingPlanWindow
+	}
+	if s.ReadingPlanSize > 0 {
+		params.Size = s.ReadingPlanSize
+	}
+	params.DiversifyTopics = s.ReadingPlanDiversifyTopics
+	params.ExcludeRead = s.ReadingPlanExcludeRead
+	params.Exploration = s.ReadingPlanExploration
+	return params
+}
+
+// ReadingPlanSnapshotUsable reports whether snap can answer a request for params. Only
+// requests without the per-request later/snoozed toggles are served from snapshots.
+func ReadingPlanSnapshotUsable(snap *repository.ReadingPlanSnapshot, params repository.ReadingPlanParams, now time.Time) bool {
+	if snap == nil || snap.Plan == nil || snap.Status != "ready" {
+		return false
+	}
+	if params.ExcludeLater || params.PrioritizeSnoozed {
+		return false
+	}
+	if snap.Params != params {
+		return false
+	}
+	return now.Sub(snap.GeneratedAt) <= ReadingPlanSnapshotMaxAge
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+func TestReadingPlanParamsFromSettings(t *testing.T) {
+	got := ReadingPlanParamsFromSettings(&model.UserSettings{
+		ReadingPlanWindow:          "7d",
+		ReadingPlanSize:            30,
+		ReadingPlanDiversifyTopics: false,
+		ReadingPlanExcludeRead:     true,
+	})
+	want := repository.ReadingPlanParams{Window: "7d", Size: 30, DiversifyTopics: false, ExcludeRead: true}
+	if got != want {
+		t.Fatalf("params = %+v, want %+v", got, want)
+	}
+	if def := ReadingPlanParamsFromSettings(nil); def.Window != "24h" || def.Size != 15 {
+		t.Fatalf("default params = %+v", def)
+	}
+}
+
+func TestReadingPlanSnapshotUsable(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	params := repository.ReadingPlanParams{Window: "24h", Size: 15, DiversifyTopics: true, ExcludeRead: true}
+	snap := &repository.ReadingPlanSnapshot{
+		Params:      params,
+		Status:      "ready",
+		Plan:        &model.ReadingPlanResponse{},
+		GeneratedAt: now.Add(-30 * time.Minute),
+	}
+	if !ReadingPlanSnapshotUsable(snap, params, now) {
+		t.Fatal("fresh matching snapshot should be usable")
+	}
+
+	other := params
+	other.Size = 20
+	later := params
+	later.ExcludeLater = true
+	stale := *snap
+	stale.Status = "stale"
+	old := *snap
+	old.GeneratedAt = now.Add(-3 * time.Hour)
+	for name, tc := range map[string]struct {
+		snap   *repository.ReadingPlanSnapshot
+		params repository.ReadingPlanParams
+	}{
+		"nil":           {nil, params},
+		"other params":  {snap, other},
+		"exclude later": {snap, later},
+		"stale":         {&stale, params},
+		"too old":       {&old, params},
+	} {
+		if ReadingPlanSnapshotUsable(tc.snap, tc.params, now) {
+			t.Errorf("%s: snapshot should not be usable", name)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	maxStreakDailyTarget            = 20
+	maxStreakFreezeMonthlyAllowance = 5
+)
+
+type StreakSettingsView struct {
+	DailyTarget            int `json:"daily_target"`
+	MonthlyFreezeAllowance int `json:"monthly_freeze_allowance"`
+}
+
+type StreakSettingsInput struct {
+	DailyTarget            *int `json:"daily_target"`
+	MonthlyFreezeAllowance *int `json:"monthly_freeze_allowance"`
+}
+
+func NewStreakSettingsView(settings *model.UserSettings) StreakSettingsView {
+	view := StreakSettingsView{
+		DailyTarget:            repository.DefaultStreakDailyTarget,
+		MonthlyFreezeAllowance: repository.DefaultStreakFreezeMonthlyAllowance,
+	}
+	if settings == nil {
+		return view
+	}
+	if settings.StreakDailyTarget > 0 {
+		view.DailyTarget = settings.StreakDailyTarget
+	}
+	view.MonthlyFreezeAllowance = settings.StreakFreezeMonthlyAllowance
+	return view
+}
+
+// NormalizeStreakSettingsInput fills fields left out of in from current and validates the result.
+func NormalizeStreakSettingsInput(in StreakSettingsInput, current StreakSettingsView) (StreakSettingsView, error) {
+	out := current
+	if in.DailyTarget != nil {
+		out.DailyTarget = *in.DailyTarget
+	}
+	if in.MonthlyFreezeAllowance != nil {
+		out.MonthlyFreezeAllowance = *in.MonthlyFreezeAllowance
+	}
+	if out.DailyTarget < 1 || out.DailyTarget > maxStreakDailyTarget {
+		return out, &ValidationError{Field: "daily_target", Message: "daily_target must be between 1 and 20"}
+	}
+	if out.MonthlyFreezeAllowance < 0 || out.MonthlyFreezeAllowance > maxStreakFreezeMonthlyAllowance {
+		return out, &ValidationError{Field: "monthly_freeze_allowance", Message: "monthly_freeze_allowance must be between 0 and 5"}
+	}
+	return out, nil
+}
+
+// StreakDisplayDays is the streak to show today: yesterday's streak, extended once today's
+// reads reach the target.
+func StreakDisplayDays(yesterdayStreak int, yesterdayCompleted bool, todayRead, target int) int {
+	if todayRead < target {
+		return yesterdayStreak
+	}
+	if yesterdayCompleted {
+		return yesterdayStreak + 1
+	}
+	return 1
+}
+
+type readingStreakStore interface {
+	DailyTarget(ctx context.Context, userID string) (int, error)
+	ListDays(ctx context.Context, userID string, from, to time.Time) ([]model.ReadingStreakDay, error)
+	FreezeBalance(ctx context.Context, userID string, month time.Time) (model.StreakFreezeBalance, error)
+	ApplyFreeze(ctx context.Context, userID string, date, today time.Time) (string, error)
+}
+
+// ReadingStreakService reports the reading streak and spends freeze tokens on missed days.
+type ReadingStreakService struct {
+	repo readingStreakStore
+	now  func() time.Time
+}
+
+func NewReadingStreakService(repo readingStreakStore) *ReadingStreakService {
+	return &ReadingStreakService{repo: repo, now: timeutil.NowJST}
+}
+
+func (s *ReadingStreakService) Status(ctx context.Context, userID string) (*model.ReadingStreakStatus, error) {
+	today := timeutil.StartOfDayJST(s.now())
+	target, err := s.repo.DailyTarget(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.repo.ListDays(ctx, userID, today.AddDate(0, 0, -repository.StreakFreezeMaxAgeDays-1), today)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.repo.FreezeBalance(ctx, userID, today)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]*model.ReadingStreakDay, len(days))
+	out := &model.ReadingStreakStatus{
+		Date:        today.Format("2006-01-02"),
+		DailyTarget: target,
+		Freezes:     balance,
+		FrozenDates: []string{},
+	}
+	for i := range days {
+		byDate[days[i].Date] = &days[i]
+		if days[i].FreezeSource != nil {
+			out.FrozenDates = append(out.FrozenDates, days[i].Date)
+		}
+	}
+	yesterday := byDate[today.AddDate(0, 0, -1).Format("2006-01-02")]
+	if t := byDate[out.Date]; t != nil {
+		out.TodayReadCount = t.ReadCount
+	}
+	if yesterday != nil {
+		out.StreakDays = StreakDisplayDays(yesterday.StreakDays, yesterday.IsCompleted, out.TodayReadCount, target)
+	} else {
+		out.StreakDays = StreakDisplayDays(0, false, out.TodayReadCount, target)
+	}
+	for back := 1; back <= repository.StreakFreezeMaxAgeDays; back++ {
+		d := today.AddDate(0, 0, -back)
+		if repository.CanFreezeStreakDay(byDate[d.AddDate(0, 0, -1).Format("2006-01-02")], byDate[d.Format("2006-01-02")]) {
+			date := d.Format("2006-01-02")
+			out.FreezableDate = &date
+			break
+		}
+	}
+	return out, nil
+}
+
+// Freeze covers a missed day (yesterday when date is empty) with a freeze token. Only days in
+// the last StreakFreezeMaxAgeDays, before today, can be frozen.
+func (s *ReadingStreakService) Freeze(ctx context.Context, userID, date string) (*model.ReadingStreakStatus, error) {
+	today := timeutil.StartOfDayJST(s.now())
+	day := today.AddDate(0, 0, -1)
+	if date = strings.TrimSpace(date); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, timeutil.JST)
+		if err != nil {
+			return nil, &ValidationError{Field: "date", Message: "date must be YYYY-MM-DD"}
+		}
+		day = parsed
+	}
+	if !day.Before(today) || day.Before(today.AddDate(0, 0, -repository.StreakFreezeMaxAgeDays)) {
+		return nil, &ValidationError{Field: "date", Message: "date must be one of the last 7 days before today"}
+	}
+	if _, err := s.repo.ApplyFreeze(ctx, userID, day, today); err != nil {
+		return nil, err
+	}
+	return s.Status(ctx, userID)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+type stubReadingStreakStore struct {
+	target      int
+	days        []model.ReadingStreakDay
+	balance     model.StreakFreezeBalance
+	frozen      time.Time
+	freezeCalls int
+}
+
+func (s *stubReadingStreakStore) DailyTarget(context.Context, string) (int, error) {
+	return s.target, nil
+}
+
+func (s *stubReadingStreakStore) ListDays(context.Context, string, time.Time, time.Time) ([]model.ReadingStreakDay, error) {
+	return s.days, nil
+}
+
+func (s *stubReadingStreakStore) FreezeBalance(context.Context, string, time.Time) (model.StreakFreezeBalance, error) {
+	return s.balance, nil
+}
+
+func (s *stubReadingStreakStore) ApplyFreeze(_ context.Context, _ string, date, _ time.Time) (string, error) {
+	s.freezeCalls++
+	s.frozen = date
+	return repository.StreakFreezeSourceMonthly, nil
+}
+
+func TestNormalizeStreakSettingsInput(t *testing.T) {
+	current := StreakSettingsView{DailyTarget: 3, MonthlyFreezeAllowance: 2}
+	target := 5
+	got, err := NormalizeStreakSettingsInput(StreakSettingsInput{DailyTarget: &target}, current)
+	if err != nil {
+		t.Fatalf("NormalizeStreakSettingsInput() error = %v", err)
+	}
+	if got.DailyTarget != 5 || got.MonthlyFreezeAllowance != 2 {
+		t.Fatalf("got %+v", got)
+	}
+
+	zero, tooMany := 0, 6
+	for _, in := range []StreakSettingsInput{{DailyTarget: &zero}, {MonthlyFreezeAllowance: &tooMany}} {
+		var ve *ValidationError
+		if _, err := NormalizeStreakSettingsInput(in, current); !errors.As(err, &ve) {
+			t.Fatalf("input %+v: err = %v, want ValidationError", in, err)
+		}
+	}
+}
+
+func TestStreakDisplayDays(t *testing.T) {
+	if got := StreakDisplayDays(4, true, 2, 3); got != 4 {
+		t.Fatalf("below target = %d, want 4", got)
+	}
+	if got := StreakDisplayDays(4, true, 3, 3); got != 5 {
+		t.Fatalf("target reached = %d, want 5", got)
+	}
+	if got := StreakDisplayDays(0, false, 1, 1); got != 1 {
+		t.Fatalf("new streak = %d, want 1", got)
+	}
+}
+
+func TestReadingStreakStatusFindsFreezableDay(t *testing.T) {
+	store := &stubReadingStreakStore{
+		target: 3,
+		days: []model.ReadingStreakDay{
+			{Date: "2026-03-08", ReadCount: 3, StreakDays: 6, IsCompleted: true},
+			{Date: "2026-03-09", ReadCount: 1, StreakDays: 6},
+			{Date: "2026-03-11", ReadCount: 2},
+		},
+		balance: model.StreakFreezeBalance{MonthlyAllowance: 2, MonthlyRemaining: 2, Available: 2},
+	}
+	svc := NewReadingStreakService(store)
+	svc.now = func() time.Time { return time.Date(2026, 3, 11, 9, 0, 0, 0, timeutil.JST) }
+
+	got, err := svc.Status(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if got.FreezableDate == nil || *got.FreezableDate != "2026-03-09" {
+		t.Fatalf("freezable date = %v, want 2026-03-09", got.FreezableDate)
+	}
+	if got.TodayReadCount != 2 || got.StreakDays != 0 || got.Freezes.Available != 2 {
+		t.Fatalf("status = %+v", got)
+	}
+}
+
+func TestReadingStreakFreezeRejectsOutOfRangeDates(t *testing.T) {
+	store := &stubReadingStreakStore{target: 3}
+	svc := NewReadingStreakService(store)
+	svc.now = func() time.Time { return time.Date(2026, 3, 11, 9, 0, 0, 0, timeutil.JST) }
+
+	for _, date := range []string{"2026-03-11", "2026-03-03", "03/10"} {
+		var ve *ValidationError
+		if _, err := svc.Freeze(context.Background(), "u1", date); !errors.As(err, &ve) {
+			t.Fatalf("date %q: err = %v, want ValidationError", date, err)
+		}
+	}
+	if store.freezeCalls != 0 {
+		t.Fatalf("ApplyFreeze called %d times", store.freezeCalls)
+	}
+	if _, err := svc.Freeze(context.Background(), "u1", ""); err != nil {
+		t.Fatalf("Freeze() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 10, 0, 0, 0, 0, timeutil.JST); !store.frozen.Equal(want) {
+		t.Fatalf("frozen date = %v, want %v", store.frozen, want)
+	}
+}
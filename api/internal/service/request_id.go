@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/inngest/inngestgo"
+)
+
+// RequestIDHeader carries the request ID from the web app to the API and from the API to the
+// worker.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDContextKey struct{}
+
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID stores the ID that worker calls, events and logs made under ctx carry.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	v, _ := ctx.Value(requestIDContextKey{}).(string)
+	return v
+}
+
+// StampRequestID adds the request ID of ctx to ev's data as request_id, so the function the
+// event triggers works under the ID of the fetch or API call that sent it. An ID already in
+// the data is kept.
+func StampRequestID(ctx context.Context, ev inngestgo.Event) inngestgo.Event {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return ev
+	}
+	if ev.Data == nil {
+		ev.Data = map[string]any{}
+	}
+	if v, _ := ev.Data["request_id"].(string); v == "" {
+		ev.Data["request_id"] = id
+	}
+	return ev
+}
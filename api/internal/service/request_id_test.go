@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStampRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	ev := StampRequestID(ctx, NewItemCreatedEvent("item-1", "src-1", "user-1", "https://example.com", nil, "fetch_rss"))
+	if got := ev.Data["request_id"]; got != "req-1" {
+		t.Fatalf("request_id = %v", got)
+	}
+
+	ev.Data["request_id"] = "fetch-run"
+	if got := StampRequestID(ctx, ev).Data["request_id"]; got != "fetch-run" {
+		t.Fatalf("existing request_id overwritten: %v", got)
+	}
+
+	plain := StampRequestID(context.Background(), NewItemCreatedEvent("item-1", "src-1", "user-1", "https://example.com", nil, "fetch_rss"))
+	if _, ok := plain.Data["request_id"]; ok {
+		t.Fatalf("request_id set without one in context: %v", plain.Data)
+	}
+}
+
+func TestApplyWorkerTraceHeadersForwardsRequestID(t *testing.T) {
+	ctx := WithRequestID(WithWorkerTraceMetadata(context.Background(), "summary", nil, nil, nil, nil), "req-1")
+	headers := applyWorkerTraceHeaders(ctx, nil)
+	if headers[RequestIDHeader] != "req-1" || headers["X-Sifto-Purpose"] != "summary" {
+		t.Fatalf("headers = %v", headers)
+	}
+	if got := applyWorkerTraceHeaders(context.Background(), nil); got[RequestIDHeader] != "" {
+		t.Fatalf("headers without request id = %v", got)
+	}
+}
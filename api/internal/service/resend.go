@@ -24,11 +24,20 @@ type ResendClient struct {
 }
 
 type DigestEmailCopy struct {
-	Subject string
-	Body    string
+	Subject  string
+	Body     string
+	AudioURL string
+	// FeedbackURL, when set, returns the one-click feedback link for an item and action.
+	// It is only set for the digest owner's own copy.
+	FeedbackURL func(itemID, action string) string
+	// UnsubscribeURL becomes the List-Unsubscribe header and a footer link; PreferencesURL is
+	// the owner's login-free frequency page and is empty for extra recipients.
+	UnsubscribeURL string
+	PreferencesURL string
 }
 
 type BudgetAlertEmail struct {
+	Locale             string
 	MonthJST           string
 	MonthlyBudgetUSD   float64
 	UsedCostUSD        float64
@@ -38,11 +47,13 @@ type BudgetAlertEmail struct {
 }
 
 type BudgetForecastAlertEmail struct {
+	Locale           string
 	MonthJST         string
 	MonthlyBudgetUSD float64
 	UsedCostUSD      float64
 	ForecastCostUSD  float64
 	ForecastDeltaUSD float64
+	ExceedDateJST    string
 }
 
 type OpenRouterModelAlertEmail struct {
@@ -52,17 +63,25 @@ type OpenRouterModelAlertEmail struct {
 	TargetURL   string
 }
 
-var digestSubjectPrefixPattern = regexp.MustCompile(`^\s*(?:【[^】]*ダイジェスト】\s*|Sifto\s*Digest\s*[-:]?\s*\d{4}-\d{1,2}-\d{1,2}\s*[-:：]?\s*|Sifto\s*Digest\s*\d{4}-\d{1,2}-\d{1,2}\s*[-:：]?\s*|\d{4}年\d{1,2}月\d{1,2}日ダイジェスト\s*[-:：]?\s*)+`)
+var digestSubjectPrefixPattern = regexp.MustCompile(`^\s*(?:【[^】]*ダイジェスト】\s*|\[Sifto\s*Digest[^\]]*\]\s*|Sifto\s*Digest\s*[-:]?\s*\d{4}-\d{1,2}-\d{1,2}\s*[-:：]?\s*|Sifto\s*Digest\s*\d{4}-\d{1,2}-\d{1,2}\s*[-:：]?\s*|\d{4}年\d{1,2}月\d{1,2}日ダイジェスト\s*[-:：]?\s*)+`)
 
 func FormatDigestEmailSubject(digestDate string, subject string) string {
+	return FormatDigestEmailSubjectForLocale(DefaultLocale, digestDate, subject)
+}
+
+func FormatDigestEmailSubjectForLocale(locale, digestDate, subject string) string {
 	dateText := strings.TrimSpace(digestDate)
+	var prefix string
 	if parsed, err := time.Parse("2006-01-02", dateText); err == nil {
-		dateText = fmt.Sprintf("%d年%d月%d日", parsed.Year(), parsed.Month(), parsed.Day())
+		prefix = emailStringsFor(locale).DigestSubjectPrefix(parsed)
+	} else if NormalizeLocale(locale) == "en" {
+		prefix = fmt.Sprintf("[Sifto Digest %s] ", dateText)
+	} else {
+		prefix = fmt.Sprintf("【%sダイジェスト】", dateText)
 	}
-	prefix := fmt.Sprintf("【%sダイジェスト】", dateText)
 	trimmed := strings.TrimSpace(digestSubjectPrefixPattern.ReplaceAllString(strings.TrimSpace(subject), ""))
 	if trimmed == "" {
-		return prefix
+		return strings.TrimSpace(prefix)
 	}
 	if strings.HasPrefix(trimmed, prefix) {
 		return trimmed
@@ -70,6 +89,30 @@ func FormatDigestEmailSubject(digestDate string, subject string) string {
 	return prefix + trimmed
 }
 
+// FormatDigestEmailSubjectForDigest picks the catch-up or daily subject prefix from the digest's compose mode.
+func FormatDigestEmailSubjectForDigest(locale string, digest *model.DigestDetail, subject string) string {
+	if digest.ComposeMode == DigestComposeModeCatchUp && digest.WindowStartDate != nil {
+		return FormatCatchUpDigestEmailSubjectForLocale(locale, *digest.WindowStartDate, digest.DigestDate, subject)
+	}
+	return FormatDigestEmailSubjectForLocale(locale, digest.DigestDate, subject)
+}
+
+// FormatCatchUpDigestEmailSubjectForLocale prefixes a catch-up digest subject with the range of
+// days it covers. It falls back to the daily prefix when either date cannot be parsed.
+func FormatCatchUpDigestEmailSubjectForLocale(locale, windowStart, digestDate, subject string) string {
+	from, errFrom := time.Parse("2006-01-02", strings.TrimSpace(windowStart))
+	to, errTo := time.Parse("2006-01-02", strings.TrimSpace(digestDate))
+	if errFrom != nil || errTo != nil {
+		return FormatDigestEmailSubjectForLocale(locale, digestDate, subject)
+	}
+	prefix := emailStringsFor(locale).CatchUpSubjectPrefix(from, to.AddDate(0, 0, -1))
+	trimmed := strings.TrimSpace(digestSubjectPrefixPattern.ReplaceAllString(strings.TrimSpace(subject), ""))
+	if trimmed == "" {
+		return strings.TrimSpace(prefix)
+	}
+	return prefix + trimmed
+}
+
 func NewResendClient() *ResendClient {
 	return &ResendClient{
 		apiKey:   os.Getenv("RESEND_API_KEY"),
@@ -83,60 +126,21 @@ func (r *ResendClient) Enabled() bool {
 	return r != nil && r.apiKey != "" && r.from != ""
 }
 
-func (r *ResendClient) SendDigest(ctx context.Context, to string, digest *model.DigestDetail, copy *DigestEmailCopy) error {
-	if !r.Enabled() {
-		log.Printf("resend disabled (missing RESEND_API_KEY or RESEND_FROM_EMAIL), skip send to %s", to)
-		return nil
-	}
-
-	subject := FormatDigestEmailSubject(digest.DigestDate, "")
-	if copy != nil && strings.TrimSpace(copy.Subject) != "" {
-		subject = FormatDigestEmailSubject(digest.DigestDate, copy.Subject)
-	}
-	html := buildDigestHTML(digest, copy)
+func (r *ResendClient) Transport() string {
+	return EmailTransportResend
+}
 
-	body, _ := json.Marshal(map[string]any{
+func (r *ResendClient) Send(ctx context.Context, msg EmailMessage) error {
+	payload := map[string]any{
 		"from":    r.formattedFrom(),
-		"to":      []string{to},
-		"subject": subject,
-		"html":    html,
-	})
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return err
+		"to":      []string{msg.To},
+		"subject": msg.Subject,
+		"html":    msg.HTML,
 	}
-	req.Header.Set("Authorization", "Bearer "+r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.http.Do(req)
-	if err != nil {
-		return err
+	if len(msg.Headers) > 0 {
+		payload["headers"] = msg.Headers
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("resend: status %d", resp.StatusCode)
-	}
-	return nil
-}
-
-func (r *ResendClient) SendBudgetAlert(ctx context.Context, to string, alert BudgetAlertEmail) error {
-	if !r.Enabled() {
-		log.Printf("resend disabled (missing RESEND_API_KEY or RESEND_FROM_EMAIL), skip budget alert to %s", to)
-		return nil
-	}
-
-	subject := fmt.Sprintf("Sifto: 月次LLM予算の残りが%d%%を下回りました", alert.ThresholdPct)
-	htmlBody := buildBudgetAlertHTML(alert)
-
-	body, _ := json.Marshal(map[string]any{
-		"from":    r.formattedFrom(),
-		"to":      []string{to},
-		"subject": subject,
-		"html":    htmlBody,
-	})
+	body, _ := json.Marshal(payload)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		"https://api.resend.com/emails", bytes.NewReader(body))
@@ -157,39 +161,16 @@ func (r *ResendClient) SendBudgetAlert(ctx context.Context, to string, alert Bud
 	return nil
 }
 
-func (r *ResendClient) SendBudgetForecastAlert(ctx context.Context, to string, alert BudgetForecastAlertEmail) error {
-	if !r.Enabled() {
-		log.Printf("resend disabled (missing RESEND_API_KEY or RESEND_FROM_EMAIL), skip budget forecast alert to %s", to)
-		return nil
-	}
-
-	subject := "Sifto: 月次LLM予算の着地予測が予算を超えそうです"
-	htmlBody := buildBudgetForecastAlertHTML(alert)
-
-	body, _ := json.Marshal(map[string]any{
-		"from":    r.formattedFrom(),
-		"to":      []string{to},
-		"subject": subject,
-		"html":    htmlBody,
-	})
+func (r *ResendClient) SendDigest(ctx context.Context, to, locale string, digest *model.DigestDetail, copy *DigestEmailCopy) error {
+	return SendDigestEmail(ctx, r, to, locale, digest, copy)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+func (r *ResendClient) SendBudgetAlert(ctx context.Context, to string, alert BudgetAlertEmail) error {
+	return SendBudgetAlertEmail(ctx, r, to, alert)
+}
 
-	resp, err := r.http.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("resend: status %d", resp.StatusCode)
-	}
-	return nil
+func (r *ResendClient) SendBudgetForecastAlert(ctx context.Context, to string, alert BudgetForecastAlertEmail) error {
+	return SendBudgetForecastAlertEmail(ctx, r, to, alert)
 }
 
 func (r *ResendClient) SendOpenRouterModelAlert(ctx context.Context, to string, alert OpenRouterModelAlertEmail) error {
@@ -198,54 +179,34 @@ func (r *ResendClient) SendOpenRouterModelAlert(ctx context.Context, to string,
 		return nil
 	}
 	total := len(alert.Added) + len(alert.Constrained) + len(alert.Removed)
-	subject := fmt.Sprintf("Sifto: OpenRouter モデル更新 %d 件", total)
-	htmlBody := buildOpenRouterModelAlertHTML(alert)
-	body, _ := json.Marshal(map[string]any{
-		"from":    r.formattedFrom(),
-		"to":      []string{to},
-		"subject": subject,
-		"html":    htmlBody,
+	return r.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf("Sifto: OpenRouter モデル更新 %d 件", total),
+		HTML:    buildOpenRouterModelAlertHTML(alert),
 	})
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.http.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("resend: status %d", resp.StatusCode)
-	}
-	return nil
 }
 
 func (r *ResendClient) formattedFrom() string {
 	if r == nil {
 		return ""
 	}
-	addr := strings.TrimSpace(r.from)
-	if addr == "" {
-		return ""
-	}
-	if strings.Contains(addr, "<") && strings.Contains(addr, ">") {
-		return addr
-	}
-	name := strings.TrimSpace(r.fromName)
-	if name == "" {
-		name = "Sifto"
-	}
-	return fmt.Sprintf("%s <%s>", name, addr)
+	return formatEmailFrom(r.from, r.fromName)
 }
 
-func buildDigestHTML(d *model.DigestDetail, copy *DigestEmailCopy) string {
+func buildDigestHTML(locale string, d *model.DigestDetail, copy *DigestEmailCopy) string {
+	strs := emailStringsFor(locale)
 	var sb strings.Builder
 	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
 	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:24px;border-bottom:2px solid #eee;padding-bottom:8px">Sifto Digest — %s</h1>`, html.EscapeString(d.DigestDate)))
+	greeting := strs.DigestGreeting
+	if d.ComposeMode == DigestComposeModeCatchUp {
+		greeting = strs.DigestCatchUpGreeting
+	}
+	sb.WriteString(fmt.Sprintf(`<p style="margin:12px 0;color:#555">%s</p>`, html.EscapeString(greeting)))
+	if copy != nil && strings.TrimSpace(copy.AudioURL) != "" {
+		sb.WriteString(fmt.Sprintf(`<p style="margin:12px 0"><a href="%s" style="display:inline-block;background:#18181b;color:#fff;padding:10px 14px;border-radius:8px;text-decoration:none">%s</a></p>`,
+			html.EscapeString(strings.TrimSpace(copy.AudioURL)), html.EscapeString(strs.DigestListenLabel)))
+	}
 	if copy != nil && strings.TrimSpace(copy.Body) != "" {
 		for _, para := range strings.Split(strings.TrimSpace(copy.Body), "\n\n") {
 			p := strings.TrimSpace(para)
@@ -270,7 +231,7 @@ func buildDigestHTML(d *model.DigestDetail, copy *DigestEmailCopy) string {
 	}
 
 	for _, item := range d.Items {
-		title := "（タイトルなし）"
+		title := strs.UntitledItem
 		if item.Item.Title != nil {
 			title = *item.Item.Title
 		}
@@ -286,45 +247,97 @@ func buildDigestHTML(d *model.DigestDetail, copy *DigestEmailCopy) string {
   <h2 style="margin:0 0 8px;font-size:18px">
     <a href="%s" style="color:#1a1a1a;text-decoration:none">%s</a>
   </h2>
-  <p style="margin:0 0 8px;color:#444;line-height:1.6">%s</p>
-  <p style="margin:0;font-size:12px;color:#888">%s</p>
+  <p style="margin:0 0 8px;color:#444;line-height:1.6">%s</p>%s
+  <p style="margin:0;font-size:12px;color:#888">%s</p>%s
 </div>`,
-			item.Rank, escapedTopics, escapedURL, escapedTitle, escapedSummary, escapedTopics))
+			item.Rank, escapedTopics, escapedURL, escapedTitle, escapedSummary, digestFollowUpHTML(strs, item.FollowUp), escapedTopics, digestFeedbackLinksHTML(strs, copy, item.Item.ID)))
 	}
 
+	sb.WriteString(digestEmailFooterHTML(strs, copy))
 	sb.WriteString(`</body></html>`)
 	return sb.String()
 }
 
+func digestFollowUpHTML(strs emailStrings, f *model.DigestFollowUp) string {
+	if f == nil || strings.TrimSpace(f.URL) == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n  "+`<p style="margin:0 0 8px;font-size:13px;color:#666">↪ %s: <a href="%s" style="color:#2563eb;text-decoration:none">%s</a></p>`,
+		html.EscapeString(fmt.Sprintf(strs.DigestFollowUpLabel, f.DigestDate)), html.EscapeString(f.URL), html.EscapeString(f.Title))
+}
+
+func digestFeedbackLinksHTML(strs emailStrings, copy *DigestEmailCopy, itemID string) string {
+	if copy == nil || copy.FeedbackURL == nil || itemID == "" {
+		return ""
+	}
+	links := make([]string, 0, 3)
+	for _, l := range []struct{ action, label string }{
+		{DigestFeedbackUp, strs.DigestFeedbackUpLabel},
+		{DigestFeedbackDown, strs.DigestFeedbackDownLabel},
+		{DigestFeedbackSave, strs.DigestFeedbackSaveLabel},
+	} {
+		u := copy.FeedbackURL(itemID, l.action)
+		if u == "" {
+			continue
+		}
+		links = append(links, fmt.Sprintf(`<a href="%s" style="color:#2563eb;text-decoration:none">%s</a>`, html.EscapeString(u), html.EscapeString(l.label)))
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return "\n  " + `<p style="margin:8px 0 0;font-size:13px">` + strings.Join(links, " &nbsp;·&nbsp; ") + `</p>`
+}
+
+func digestEmailFooterHTML(strs emailStrings, copy *DigestEmailCopy) string {
+	if copy == nil {
+		return ""
+	}
+	links := make([]string, 0, 2)
+	if u := strings.TrimSpace(copy.PreferencesURL); u != "" {
+		links = append(links, fmt.Sprintf(`<a href="%s" style="color:#888">%s</a>`, html.EscapeString(u), html.EscapeString(strs.DigestPreferencesLabel)))
+	}
+	if u := strings.TrimSpace(copy.UnsubscribeURL); u != "" {
+		links = append(links, fmt.Sprintf(`<a href="%s" style="color:#888">%s</a>`, html.EscapeString(u), html.EscapeString(strs.DigestUnsubscribeLabel)))
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return `<p style="margin:24px 0 0;padding-top:12px;border-top:1px solid #eee;font-size:12px;color:#888">` + strings.Join(links, " &nbsp;·&nbsp; ") + `</p>`
+}
+
 func buildBudgetAlertHTML(a BudgetAlertEmail) string {
+	strs := emailStringsFor(a.Locale)
 	var sb strings.Builder
 	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
-	sb.WriteString(`<h1 style="font-size:22px;margin:0 0 12px">Sifto 予算アラート</h1>`)
-	sb.WriteString(fmt.Sprintf(`<p style="line-height:1.7;color:#333">%s の月次LLM予算の残りが <strong>%d%%</strong> を下回りました。</p>`,
-		html.EscapeString(a.MonthJST), a.ThresholdPct))
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.BudgetAlertHeading)))
+	sb.WriteString(`<p style="line-height:1.7;color:#333">` + fmt.Sprintf(strs.BudgetAlertLead, html.EscapeString(a.MonthJST), a.ThresholdPct) + `</p>`)
 	sb.WriteString(`<div style="border:1px solid #e4e4e7;border-radius:10px;padding:14px 16px;background:#fafafa">`)
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">月次予算: <strong>$%.4f</strong></p>`, a.MonthlyBudgetUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">利用額（推定）: <strong>$%.4f</strong></p>`, a.UsedCostUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">残額（推定）: <strong>$%.4f</strong></p>`, a.RemainingBudgetUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0;color:#444">残り比率: <strong>%.1f%%</strong></p>`, a.RemainingPct))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">%s: <strong>$%.4f</strong></p>`, html.EscapeString(strs.BudgetAlertMonthly), a.MonthlyBudgetUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">%s: <strong>$%.4f</strong></p>`, html.EscapeString(strs.BudgetAlertUsed), a.UsedCostUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">%s: <strong>$%.4f</strong></p>`, html.EscapeString(strs.BudgetAlertRemaining), a.RemainingBudgetUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0;color:#444">%s: <strong>%.1f%%</strong></p>`, html.EscapeString(strs.BudgetAlertRemainingPct), a.RemainingPct))
 	sb.WriteString(`</div>`)
-	sb.WriteString(`<p style="margin-top:12px;color:#666;line-height:1.6">設定画面で予算・警告しきい値・Anthropic APIキー（ユーザー別）を管理できます。</p>`)
+	sb.WriteString(fmt.Sprintf(`<p style="margin-top:12px;color:#666;line-height:1.6">%s</p>`, html.EscapeString(strs.BudgetAlertFooter)))
 	sb.WriteString(`</body></html>`)
 	return sb.String()
 }
 
 func buildBudgetForecastAlertHTML(a BudgetForecastAlertEmail) string {
+	strs := emailStringsFor(a.Locale)
 	var sb strings.Builder
 	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
-	sb.WriteString(`<h1 style="font-size:22px;margin:0 0 12px">Sifto 予算着地アラート</h1>`)
-	sb.WriteString(fmt.Sprintf(`<p style="color:#444;line-height:1.7">%s の月末着地予測が、設定予算を上回っています。</p>`, html.EscapeString(a.MonthJST)))
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.ForecastHeading)))
+	sb.WriteString(fmt.Sprintf(`<p style="color:#444;line-height:1.7">%s</p>`, fmt.Sprintf(strs.ForecastLead, html.EscapeString(a.MonthJST))))
 	sb.WriteString(`<div style="margin:20px 0;padding:16px;border:1px solid #eee;border-radius:8px;background:#fafafa">`)
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>月次予算:</strong> $%.2f</p>`, a.MonthlyBudgetUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>今月使用額:</strong> $%.4f</p>`, a.UsedCostUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>月末着地予測:</strong> $%.4f</p>`, a.ForecastCostUSD))
-	sb.WriteString(fmt.Sprintf(`<p style="margin:0"><strong>予算差分:</strong> +$%.4f</p>`, a.ForecastDeltaUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>%s</strong> $%.2f</p>`, html.EscapeString(strs.ForecastMonthly), a.MonthlyBudgetUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>%s</strong> $%.4f</p>`, html.EscapeString(strs.ForecastUsed), a.UsedCostUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0 0 8px"><strong>%s</strong> $%.4f</p>`, html.EscapeString(strs.ForecastProjected), a.ForecastCostUSD))
+	sb.WriteString(fmt.Sprintf(`<p style="margin:0"><strong>%s</strong> +$%.4f</p>`, html.EscapeString(strs.ForecastDelta), a.ForecastDeltaUSD))
+	if strings.TrimSpace(a.ExceedDateJST) != "" {
+		sb.WriteString(fmt.Sprintf(`<p style="margin:8px 0 0"><strong>%s</strong> %s</p>`, html.EscapeString(strs.ForecastExceedDate), html.EscapeString(a.ExceedDateJST)))
+	}
 	sb.WriteString(`</div>`)
-	sb.WriteString(`<p style="color:#666;line-height:1.7">LLM Usage 画面で直近の利用状況と予測ペースを確認してください。</p>`)
+	sb.WriteString(fmt.Sprintf(`<p style="color:#666;line-height:1.7">%s</p>`, html.EscapeString(strs.ForecastFooter)))
 	sb.WriteString(`</body></html>`)
 	return sb.String()
 }
@@ -26,17 +26,9 @@ func NewPublicHTTPClient(timeout time.Duration) *http.Client {
 		if err != nil {
 			return nil, err
 		}
-		ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		ips, err := ResolvePublicHost(ctx, host)
 		if err != nil {
-			return nil, fmt.Errorf("resolve public URL host: %w", err)
-		}
-		if len(ips) == 0 {
-			return nil, fmt.Errorf("public URL host has no IP addresses")
-		}
-		for _, ip := range ips {
-			if !isPublicIP(ip) {
-				return nil, fmt.Errorf("public URL resolves to a non-public IP")
-			}
+			return nil, err
 		}
 		return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
 	}
@@ -61,21 +53,33 @@ func ValidatePublicHTTPURL(ctx context.Context, rawURL string) error {
 	if parsed.User != nil || parsed.Hostname() == "" {
 		return fmt.Errorf("URL must not contain credentials and must have a host")
 	}
-	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	_, err = ResolvePublicHost(ctx, parsed.Hostname())
+	return err
+}
+
+// ResolvePublicHost resolves host and fails unless every address is public. Callers dial one of
+// the returned addresses rather than the name, so a second lookup cannot be pointed elsewhere.
+func ResolvePublicHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
 	if err != nil {
-		return fmt.Errorf("resolve URL host: %w", err)
+		return nil, fmt.Errorf("resolve host: %w", err)
 	}
 	if len(ips) == 0 {
-		return fmt.Errorf("URL host has no IP addresses")
+		return nil, fmt.Errorf("host has no IP addresses")
 	}
 	for _, ip := range ips {
 		if !isPublicIP(ip) {
-			return fmt.Errorf("URL resolves to a non-public IP")
+			return nil, fmt.Errorf("host resolves to a non-public IP")
 		}
 	}
-	return nil
+	return ips, nil
 }
 
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598); some clouds serve instance
+// metadata from it.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
 func isPublicIP(ip netip.Addr) bool {
-	return ip.IsValid() && ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+	ip = ip.Unmap()
+	return !sharedAddressSpace.Contains(ip) && ip.IsValid() && ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
 }
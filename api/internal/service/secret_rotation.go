@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type SecretRotationStatus struct {
+	ActiveKeyID     string                          `json:"active_key_id"`
+	OldKeyUserCount int                             `json:"old_key_user_count"`
+	Counts          []repository.UserSecretKeyCount `json:"counts"`
+}
+
+type SecretRotationResult struct {
+	DryRun       bool    `json:"dry_run"`
+	ActiveKeyID  string  `json:"active_key_id"`
+	ScannedUsers int     `json:"scanned_users"`
+	Rotated      int     `json:"rotated"`
+	Skipped      int     `json:"skipped"`
+	Failed       int     `json:"failed"`
+	NextCursor   *string `json:"next_cursor"`
+	Remaining    int     `json:"old_key_user_count"`
+}
+
+type secretRotationStore interface {
+	ListBatchAfter(ctx context.Context, afterUserID string, limit int) ([]repository.UserSecretRow, error)
+	ReplaceIfUnchanged(ctx context.Context, userID, column, oldValue, newValue string) (bool, error)
+	CountByKeyID(ctx context.Context) ([]repository.UserSecretKeyCount, error)
+	CountRowsNotOnKey(ctx context.Context, keyID string) (int, error)
+}
+
+// SecretRotationService re-encrypts stored user secrets under the cipher's active key.
+type SecretRotationService struct {
+	repo   secretRotationStore
+	cipher *SecretCipher
+}
+
+func NewSecretRotationService(repo *repository.UserSecretRepo, cipher *SecretCipher) *SecretRotationService {
+	return &SecretRotationService{repo: repo, cipher: cipher}
+}
+
+func (s *SecretRotationService) Status(ctx context.Context) (*SecretRotationStatus, error) {
+	if !s.cipher.Enabled() {
+		return nil, ErrSecretEncryptionNotConfigured
+	}
+	counts, err := s.repo.CountByKeyID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.repo.CountRowsNotOnKey(ctx, s.cipher.ActiveKeyID())
+	if err != nil {
+		return nil, err
+	}
+	return &SecretRotationStatus{ActiveKeyID: s.cipher.ActiveKeyID(), OldKeyUserCount: remaining, Counts: counts}, nil
+}
+
+// Rotate processes up to maxBatches pages starting after cursor. A non-nil NextCursor means
+// more users remain; callers pass it back to continue.
+func (s *SecretRotationService) Rotate(ctx context.Context, cursor string, batchSize, maxBatches int, dryRun bool) (*SecretRotationResult, error) {
+	if !s.cipher.Enabled() {
+		return nil, ErrSecretEncryptionNotConfigured
+	}
+	if batchSize <= 0 || batchSize > 1000 {
+		batchSize = 200
+	}
+	if maxBatches <= 0 {
+		maxBatches = 1
+	}
+	out := &SecretRotationResult{DryRun: dryRun, ActiveKeyID: s.cipher.ActiveKeyID()}
+	after := cursor
+	for batch := 0; batch < maxBatches; batch++ {
+		rows, err := s.repo.ListBatchAfter(ctx, after, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			after = ""
+			break
+		}
+		for _, row := range rows {
+			out.ScannedUsers++
+			for _, column := range repository.UserSecretColumns {
+				enc, ok := row.Values[column]
+				if !ok || !s.cipher.NeedsRotation(enc) {
+					continue
+				}
+				if dryRun {
+					out.Rotated++
+					continue
+				}
+				next, _, err := s.cipher.Reencrypt(enc)
+				if err != nil {
+					log.Printf("secret rotation user_id=%s column=%s: %v", row.UserID, column, err)
+					out.Failed++
+					continue
+				}
+				replaced, err := s.repo.ReplaceIfUnchanged(ctx, row.UserID, column, enc, next)
+				if err != nil {
+					log.Printf("secret rotation update user_id=%s column=%s: %v", row.UserID, column, err)
+					out.Failed++
+					continue
+				}
+				if replaced {
+					out.Rotated++
+				} else {
+					out.Skipped++
+				}
+			}
+		}
+		after = rows[len(rows)-1].UserID
+		if len(rows) < batchSize {
+			after = ""
+			break
+		}
+	}
+	if after != "" {
+		out.NextCursor = &after
+	}
+	remaining, err := s.repo.CountRowsNotOnKey(ctx, out.ActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+	out.Remaining = remaining
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeSecretStore struct {
+	rows     []repository.UserSecretRow
+	replaced map[string]string
+}
+
+func (f *fakeSecretStore) ListBatchAfter(_ context.Context, after string, limit int) ([]repository.UserSecretRow, error) {
+	out := []repository.UserSecretRow{}
+	for _, row := range f.rows {
+		if row.UserID > after && len(out) < limit {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSecretStore) ReplaceIfUnchanged(_ context.Context, userID, column, _, newValue string) (bool, error) {
+	f.replaced[userID+"/"+column] = newValue
+	return true, nil
+}
+
+func (f *fakeSecretStore) CountByKeyID(context.Context) ([]repository.UserSecretKeyCount, error) {
+	return nil, nil
+}
+
+func (f *fakeSecretStore) CountRowsNotOnKey(context.Context, string) (int, error) {
+	return 0, nil
+}
+
+func TestSecretRotationServiceRotate(t *testing.T) {
+	oldCipher, _ := newSecretCipherFromConfig("k1:first", "")
+	newCipher, _ := newSecretCipherFromConfig("k2:second,k1:first", "")
+	oldEnc, _ := oldCipher.EncryptString("sk-old")
+	currentEnc, _ := newCipher.EncryptString("sk-current")
+
+	store := &fakeSecretStore{
+		rows: []repository.UserSecretRow{
+			{UserID: "u1", Values: map[string]string{"anthropic_api_key_enc": oldEnc}},
+			{UserID: "u2", Values: map[string]string{"openai_api_key_enc": currentEnc}},
+			{UserID: "u3", Values: map[string]string{"google_api_key_enc": oldEnc}},
+		},
+		replaced: map[string]string{},
+	}
+	svc := &SecretRotationService{repo: store, cipher: newCipher}
+
+	got, err := svc.Rotate(context.Background(), "", 2, 1, false)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if got.Rotated != 1 || got.ScannedUsers != 2 || got.NextCursor == nil || *got.NextCursor != "u2" {
+		t.Fatalf("Rotate() first batch = %+v, want 1 rotated and cursor u2", got)
+	}
+	plain, err := newCipher.DecryptString(store.replaced["u1/anthropic_api_key_enc"])
+	if err != nil || plain != "sk-old" {
+		t.Fatalf("rotated secret = (%q, %v), want sk-old", plain, err)
+	}
+
+	got, err = svc.Rotate(context.Background(), *got.NextCursor, 2, 1, false)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if got.Rotated != 1 || got.NextCursor != nil {
+		t.Fatalf("Rotate() second batch = %+v, want 1 rotated and no cursor", got)
+	}
+}
@@ -8,31 +8,120 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"strings"
 )
 
-type SecretCipher struct {
+// Ciphertexts written with a named key are stored as "<key id>:<base64>". Base64 never
+// contains ':', so unprefixed values are unambiguously from the legacy unnamed key.
+const secretKeyIDSeparator = ":"
+
+type secretKey struct {
+	id  string
 	key []byte
 }
 
+// SecretCipher encrypts user secrets with the active (first) key and decrypts with any
+// configured key, so USER_SECRET_ENCRYPTION_KEYS can be rotated without breaking stored rows.
+type SecretCipher struct {
+	keys []secretKey
+}
+
+func deriveSecretKey(raw string) []byte {
+	sum := sha256.Sum256([]byte(raw))
+	return sum[:]
+}
+
+// NewSecretCipher reads USER_SECRET_ENCRYPTION_KEYS ("id:secret,id:secret", newest first)
+// and the legacy USER_SECRET_ENCRYPTION_KEY, which stays readable for unprefixed ciphertexts.
 func NewSecretCipher() *SecretCipher {
-	raw := os.Getenv("USER_SECRET_ENCRYPTION_KEY")
-	if raw == "" {
-		return &SecretCipher{}
+	c, err := newSecretCipherFromConfig(os.Getenv("USER_SECRET_ENCRYPTION_KEYS"), os.Getenv("USER_SECRET_ENCRYPTION_KEY"))
+	if err != nil {
+		log.Printf("secret cipher: %v", err)
 	}
-	sum := sha256.Sum256([]byte(raw))
-	return &SecretCipher{key: sum[:]}
+	return c
+}
+
+// newSecretCipherFromConfig skips malformed keyring entries and reports them in err.
+func newSecretCipherFromConfig(keyring, legacy string) (*SecretCipher, error) {
+	c := &SecretCipher{}
+	seen := map[string]bool{}
+	var invalid []string
+	for _, entry := range strings.Split(keyring, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, raw, ok := strings.Cut(entry, secretKeyIDSeparator)
+		id = strings.TrimSpace(id)
+		if !ok || !validSecretKeyID(id) || raw == "" || seen[id] {
+			invalid = append(invalid, id)
+			continue
+		}
+		seen[id] = true
+		c.keys = append(c.keys, secretKey{id: id, key: deriveSecretKey(raw)})
+	}
+	if legacy != "" {
+		c.keys = append(c.keys, secretKey{key: deriveSecretKey(legacy)})
+	}
+	if len(invalid) > 0 {
+		return c, fmt.Errorf("ignored invalid USER_SECRET_ENCRYPTION_KEYS entries: %q", invalid)
+	}
+	return c, nil
+}
+
+func validSecretKeyID(id string) bool {
+	if id == "" || len(id) > 32 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *SecretCipher) Enabled() bool {
-	return c != nil && len(c.key) == 32
+	return c != nil && len(c.keys) > 0 && len(c.keys[0].key) == 32
+}
+
+// ActiveKeyID is the id new ciphertexts are written with; empty means the legacy key.
+func (c *SecretCipher) ActiveKeyID() string {
+	if !c.Enabled() {
+		return ""
+	}
+	return c.keys[0].id
+}
+
+// KeyIDOf returns the key id a stored ciphertext was written with.
+func KeyIDOf(enc string) string {
+	if id, _, ok := strings.Cut(enc, secretKeyIDSeparator); ok {
+		return id
+	}
+	return ""
+}
+
+// NeedsRotation reports whether enc was written with a key other than the active one.
+func (c *SecretCipher) NeedsRotation(enc string) bool {
+	return c.Enabled() && enc != "" && KeyIDOf(enc) != c.ActiveKeyID()
+}
+
+func (c *SecretCipher) keyByID(id string) []byte {
+	for _, k := range c.keys {
+		if k.id == id {
+			return k.key
+		}
+	}
+	return nil
 }
 
 func (c *SecretCipher) EncryptString(plain string) (string, error) {
 	if !c.Enabled() {
 		return "", fmt.Errorf("user secret encryption key is not configured")
 	}
-	block, err := aes.NewCipher(c.key)
+	block, err := aes.NewCipher(c.keys[0].key)
 	if err != nil {
 		return "", err
 	}
@@ -45,19 +134,30 @@ func (c *SecretCipher) EncryptString(plain string) (string, error) {
 		return "", err
 	}
 	ciphertext := gcm.Seal(nil, nonce, []byte(plain), nil)
-	out := append(nonce, ciphertext...)
-	return base64.StdEncoding.EncodeToString(out), nil
+	out := base64.StdEncoding.EncodeToString(append(nonce, ciphertext...))
+	if id := c.keys[0].id; id != "" {
+		out = id + secretKeyIDSeparator + out
+	}
+	return out, nil
 }
 
 func (c *SecretCipher) DecryptString(enc string) (string, error) {
 	if !c.Enabled() {
 		return "", fmt.Errorf("user secret encryption key is not configured")
 	}
+	keyID := KeyIDOf(enc)
+	if keyID != "" {
+		enc = strings.TrimPrefix(enc, keyID+secretKeyIDSeparator)
+	}
+	key := c.keyByID(keyID)
+	if key == nil {
+		return "", fmt.Errorf("user secret encryption key %q is not configured", keyID)
+	}
 	raw, err := base64.StdEncoding.DecodeString(enc)
 	if err != nil {
 		return "", err
 	}
-	block, err := aes.NewCipher(c.key)
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", err
 	}
@@ -76,3 +176,19 @@ func (c *SecretCipher) DecryptString(enc string) (string, error) {
 	}
 	return string(plain), nil
 }
+
+// Reencrypt rewrites enc with the active key. It returns enc unchanged when no rotation is needed.
+func (c *SecretCipher) Reencrypt(enc string) (string, bool, error) {
+	if !c.NeedsRotation(enc) {
+		return enc, false, nil
+	}
+	plain, err := c.DecryptString(enc)
+	if err != nil {
+		return "", false, err
+	}
+	out, err := c.EncryptString(plain)
+	if err != nil {
+		return "", false, err
+	}
+	return out, true, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretCipherKeyringRotation(t *testing.T) {
+	legacy, err := newSecretCipherFromConfig("", "legacy-secret")
+	if err != nil {
+		t.Fatalf("newSecretCipherFromConfig() error = %v", err)
+	}
+	legacyEnc, err := legacy.EncryptString("sk-legacy")
+	if err != nil {
+		t.Fatalf("EncryptString() error = %v", err)
+	}
+	if KeyIDOf(legacyEnc) != "" {
+		t.Fatalf("legacy ciphertext %q should not carry a key id", legacyEnc)
+	}
+
+	rotated, err := newSecretCipherFromConfig("k2:second-secret", "legacy-secret")
+	if err != nil {
+		t.Fatalf("newSecretCipherFromConfig() error = %v", err)
+	}
+	if rotated.ActiveKeyID() != "k2" {
+		t.Fatalf("ActiveKeyID() = %q, want k2", rotated.ActiveKeyID())
+	}
+	if plain, err := rotated.DecryptString(legacyEnc); err != nil || plain != "sk-legacy" {
+		t.Fatalf("DecryptString(legacy) = (%q, %v), want sk-legacy", plain, err)
+	}
+	if !rotated.NeedsRotation(legacyEnc) {
+		t.Fatal("legacy ciphertext should need rotation")
+	}
+
+	next, changed, err := rotated.Reencrypt(legacyEnc)
+	if err != nil || !changed {
+		t.Fatalf("Reencrypt() = (%q, %v, %v), want rotated value", next, changed, err)
+	}
+	if !strings.HasPrefix(next, "k2:") || rotated.NeedsRotation(next) {
+		t.Fatalf("Reencrypt() = %q, want k2-prefixed ciphertext", next)
+	}
+	if plain, err := rotated.DecryptString(next); err != nil || plain != "sk-legacy" {
+		t.Fatalf("DecryptString(rotated) = (%q, %v), want sk-legacy", plain, err)
+	}
+	if _, err := legacy.DecryptString(next); err == nil {
+		t.Fatal("cipher without k2 should fail to decrypt k2 ciphertext")
+	}
+}
+
+func TestNewSecretCipherFromConfigSkipsInvalidEntries(t *testing.T) {
+	c, err := newSecretCipherFromConfig("bad id:x, k1:one, k1:dup, k2:", "")
+	if err == nil {
+		t.Fatal("expected error for invalid entries")
+	}
+	if !c.Enabled() || c.ActiveKeyID() != "k1" || len(c.keys) != 1 {
+		t.Fatalf("cipher keys = %+v, want only k1", c.keys)
+	}
+}
@@ -109,12 +109,35 @@ type SettingsGetPayload struct {
 	Podcast                 PodcastView                     `json:"podcast"`
 	HasInoreaderOAuth       bool                            `json:"has_inoreader_oauth"`
 	InoreaderTokenExpiresAt *time.Time                      `json:"inoreader_token_expires_at,omitempty"`
+	HasFeedlyOAuth          bool                            `json:"has_feedly_oauth"`
+	FeedlyTokenExpiresAt    *time.Time                      `json:"feedly_token_expires_at,omitempty"`
+	InoreaderSync           InoreaderSyncView               `json:"inoreader_sync"`
 	MonthlyBudgetUSD        *float64                        `json:"monthly_budget_usd,omitempty"`
 	BudgetAlertEnabled      bool                            `json:"budget_alert_enabled"`
 	BudgetAlertThresholdPct int                             `json:"budget_alert_threshold_pct"`
+	BudgetEnforcement       BudgetEnforcementView           `json:"budget_enforcement"`
 	DigestEmailEnabled      bool                            `json:"digest_email_enabled"`
+	FeedAutoMigrateEnabled  bool                            `json:"feed_auto_migrate_enabled"`
+	SourceStatsSharing      bool                            `json:"source_stats_sharing_enabled"`
+	HTMLSnapshotsEnabled    bool                            `json:"html_snapshots_enabled"`
+	ExcludePaywalled        bool                            `json:"exclude_paywalled"`
+	SocialBoostEnabled      bool                            `json:"social_boost_enabled"`
+	Streak                  StreakSettingsView              `json:"streak"`
+	WeeklyRecapEnabled      bool                            `json:"weekly_recap_enabled"`
+	BriefingGreetingStyle   string                          `json:"briefing_greeting_style"`
+	LLMConcurrency          LLMConcurrencyView              `json:"llm_concurrency"`
+	Prescreen               PrescreenSettingsView           `json:"prescreen"`
+	ProcessingPausedAt      *time.Time                      `json:"processing_paused_at,omitempty"`
+	OutputLanguage          *string                         `json:"output_language,omitempty"`
+	Locale                  string                          `json:"locale"`
+	DigestAudioEnabled      bool                            `json:"digest_audio_enabled"`
+	DigestStyle             model.DigestComposeProfile      `json:"digest_style"`
+	DigestTopicPriorities   []string                        `json:"digest_topic_priorities"`
+	DigestSchedule          DigestScheduleView              `json:"digest_schedule"`
+	DigestApproval          DigestApprovalView              `json:"digest_approval"`
 	ReadingPlan             ReadingPlanView                 `json:"reading_plan"`
 	LLMModels               LLMModelsView                   `json:"llm_models"`
+	LLMModelKeyRequirements []LLMModelKeyRequirement        `json:"llm_model_key_requirements"`
 	AudioBriefing           AudioBriefingView               `json:"audio_briefing"`
 	AudioBriefingVoices     []AudioBriefingPersonaVoiceView `json:"audio_briefing_persona_voices"`
 	SummaryAudio            SummaryAudioView                `json:"summary_audio"`
@@ -483,10 +506,32 @@ func (s *SettingsService) Get(ctx context.Context, userID string) (*SettingsGetP
 		Podcast:                 NewPodcastView(settings),
 		HasInoreaderOAuth:       settings.HasInoreaderOAuth,
 		InoreaderTokenExpiresAt: settings.InoreaderTokenExpiresAt,
+		HasFeedlyOAuth:          settings.HasFeedlyOAuth,
+		FeedlyTokenExpiresAt:    settings.FeedlyTokenExpiresAt,
+		InoreaderSync:           NewInoreaderSyncView(settings),
 		MonthlyBudgetUSD:        settings.MonthlyBudgetUSD,
 		BudgetAlertEnabled:      settings.BudgetAlertEnabled,
 		BudgetAlertThresholdPct: settings.BudgetAlertThresholdPct,
+		BudgetEnforcement:       NewBudgetEnforcementView(settings, usedCostUSD),
 		DigestEmailEnabled:      settings.DigestEmailEnabled,
+		FeedAutoMigrateEnabled:  settings.FeedAutoMigrateEnabled,
+		SourceStatsSharing:      settings.SourceStatsSharingEnabled,
+		HTMLSnapshotsEnabled:    settings.HTMLSnapshotsEnabled,
+		ExcludePaywalled:        settings.ExcludePaywalled,
+		SocialBoostEnabled:      settings.SocialBoostEnabled,
+		Streak:                  NewStreakSettingsView(settings),
+		WeeklyRecapEnabled:      settings.WeeklyRecapEnabled,
+		BriefingGreetingStyle:   settings.BriefingGreetingStyle,
+		LLMConcurrency:          NewLLMConcurrencyView(settings),
+		Prescreen:               NewPrescreenSettingsView(settings),
+		ProcessingPausedAt:      settings.ProcessingPausedAt,
+		OutputLanguage:          settings.OutputLanguage,
+		Locale:                  NormalizeLocale(settings.Locale),
+		DigestAudioEnabled:      settings.DigestAudioEnabled,
+		DigestStyle:             DigestComposeProfileFromSettings(settings),
+		DigestTopicPriorities:   DigestTopicPrioritiesFromSettings(settings),
+		DigestSchedule:          NewDigestScheduleView(settings),
+		DigestApproval:          NewDigestApprovalView(settings),
 		ReadingPlan:             NewReadingPlanView(settings),
 		LLMModels:               NewLLMModelsView(settings),
 		AudioBriefing:           NewAudioBriefingView(audioBriefingSettings),
@@ -521,6 +566,8 @@ func (s *SettingsService) Get(ctx context.Context, userID string) (*SettingsGetP
 		}
 	}
 
+	payload.LLMModelKeyRequirements = BuildLLMModelKeyRequirements(LLMCatalogData(), settings, payload.LLMAPIKeys)
+
 	s.populateNotificationPriority(ctx, userID, payload)
 	return payload, nil
 }
@@ -1041,8 +1088,129 @@ func (s *SettingsService) UpdateLLMModels(ctx context.Context, userID string, in
 	)
 }
 
-func (s *SettingsService) UpdateReadingPlan(ctx context.Context, userID, window string, size int, diversifyTopics, excludeRead bool) (*model.UserSettings, error) {
-	return s.repo.UpsertReadingPlanConfig(ctx, userID, window, size, diversifyTopics, excludeRead)
+func (s *SettingsService) UpdateReadingPlan(ctx context.Context, userID, window string, size int, diversifyTopics, excludeRead bool, exploration *float64) (*model.UserSettings, error) {
+	return s.repo.UpsertReadingPlanConfig(ctx, userID, window, size, diversifyTopics, excludeRead, exploration)
+}
+
+func (s *SettingsService) UpdateFeedAutoMigrate(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetFeedAutoMigrateEnabled(ctx, userID, enabled)
+}
+
+func (s *SettingsService) UpdateSourceStatsSharing(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetSourceStatsSharingEnabled(ctx, userID, enabled)
+}
+
+// UpdateInoreaderSync turns the recurring Inoreader sync on or off. Enabling it requires a
+// connected Inoreader account; read-state sync only applies while the sync is on.
+func (s *SettingsService) UpdateInoreaderSync(ctx context.Context, userID string, enabled, readState bool) (*model.UserSettings, error) {
+	if enabled {
+		current, err := s.repo.GetByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		if current == nil || !current.HasInoreaderOAuth {
+			return nil, &ValidationError{Field: "enabled", Message: "connect Inoreader before enabling sync"}
+		}
+	}
+	return s.repo.SetInoreaderSync(ctx, userID, enabled, enabled && readState)
+}
+
+func (s *SettingsService) UpdateHTMLSnapshots(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetHTMLSnapshotsEnabled(ctx, userID, enabled)
+}
+
+func (s *SettingsService) UpdateExcludePaywalled(ctx context.Context, userID string, exclude bool) (*model.UserSettings, error) {
+	return s.repo.SetExcludePaywalled(ctx, userID, exclude)
+}
+
+func (s *SettingsService) UpdateSocialBoost(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetSocialBoostEnabled(ctx, userID, enabled)
+}
+
+func (s *SettingsService) UpdateOutputLanguage(ctx context.Context, userID string, language *string) (*model.UserSettings, error) {
+	return s.repo.SetOutputLanguage(ctx, userID, NormalizeOutputLanguage(language))
+}
+
+func (s *SettingsService) UpdateLocale(ctx context.Context, userID, locale string) (*model.UserSettings, error) {
+	return s.repo.SetLocale(ctx, userID, NormalizeLocale(locale))
+}
+
+func (s *SettingsService) UpdateDigestAudio(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetDigestAudioEnabled(ctx, userID, enabled)
+}
+
+func (s *SettingsService) UpdateDigestStyle(ctx context.Context, userID string, profile model.DigestComposeProfile) (*model.UserSettings, error) {
+	return s.repo.SetDigestStyle(ctx, userID, profile.Verbosity, profile.Tone, profile.MaxClusters)
+}
+
+func (s *SettingsService) UpdateDigestTopicPriorities(ctx context.Context, userID string, topics []string) (*model.UserSettings, error) {
+	normalized, err := NormalizeDigestTopicPriorities(topics)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SetDigestTopicPriorities(ctx, userID, normalized)
+}
+
+func (s *SettingsService) UpdateDigestSchedule(ctx context.Context, userID string, in DigestScheduleInput) (*model.UserSettings, error) {
+	schedule, err := NormalizeDigestScheduleInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SetDigestSchedule(ctx, userID, schedule.SkipWeekdays, schedule.VacationStart, schedule.VacationEnd)
+}
+
+func (s *SettingsService) UpdateBriefingGreeting(ctx context.Context, userID, style string) (*model.UserSettings, error) {
+	normalized, ok := NormalizeBriefingGreetingStyle(style)
+	if !ok {
+		return nil, &ValidationError{Field: "style", Message: "style must be one of standard, casual, formal, none"}
+	}
+	return s.repo.SetBriefingGreetingStyle(ctx, userID, normalized)
+}
+
+func (s *SettingsService) UpdateLLMConcurrency(ctx context.Context, userID string, max *int) (*model.UserSettings, error) {
+	if err := validateLLMMaxConcurrency(max); err != nil {
+		return nil, err
+	}
+	return s.repo.SetLLMMaxConcurrency(ctx, userID, max)
+}
+
+func (s *SettingsService) UpdatePrescreen(ctx context.Context, userID string, threshold *float64) (*model.UserSettings, error) {
+	if err := validatePrescreenThreshold(threshold); err != nil {
+		return nil, err
+	}
+	return s.repo.SetPrescreenThreshold(ctx, userID, threshold)
+}
+
+func (s *SettingsService) UpdateWeeklyRecap(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	return s.repo.SetWeeklyRecapEnabled(ctx, userID, enabled)
+}
+
+func (s *SettingsService) UpdateStreak(ctx context.Context, userID string, in StreakSettingsInput) (*model.UserSettings, error) {
+	current, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	streak, err := NormalizeStreakSettingsInput(in, NewStreakSettingsView(current))
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SetStreakSettings(ctx, userID, streak.DailyTarget, streak.MonthlyFreezeAllowance)
+}
+
+func (s *SettingsService) UpdateDigestApproval(ctx context.Context, userID string, in DigestApprovalInput) (*model.UserSettings, error) {
+	approval, err := NormalizeDigestApprovalInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SetDigestApproval(ctx, userID, approval.Enabled, approval.AutoApproveMinutes)
+}
+
+func (s *SettingsService) UpdateBudgetEnforcement(ctx context.Context, userID string, enabled bool, hardCapUSD *float64) (*model.UserSettings, error) {
+	var hardCap *float64
+	if hardCapUSD != nil && *hardCapUSD > 0 {
+		hardCap = hardCapUSD
+	}
+	return s.repo.SetBudgetEnforcement(ctx, userID, enabled, hardCap)
 }
 
 func (s *SettingsService) UpdateBudget(ctx context.Context, userID string, monthlyBudgetUSD *float64, enabled bool, thresholdPct int, digestEmailEnabled bool) (*model.UserSettings, error) {
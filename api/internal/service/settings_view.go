@@ -38,10 +38,11 @@ type LLMModelsView struct {
 }
 
 type ReadingPlanView struct {
-	Window          string `json:"window"`
-	Size            int    `json:"size"`
-	DiversifyTopics bool   `json:"diversify_topics"`
-	ExcludeRead     bool   `json:"exclude_read"`
+	Window          string  `json:"window"`
+	Size            int     `json:"size"`
+	DiversifyTopics bool    `json:"diversify_topics"`
+	ExcludeRead     bool    `json:"exclude_read"`
+	Exploration     float64 `json:"exploration"`
 }
 
 type PodcastView struct {
@@ -151,6 +152,13 @@ type CurrentMonthView struct {
 	RemainingBudgetPct *float64 `json:"remaining_budget_pct"`
 }
 
+type BudgetEnforcementView struct {
+	Enabled         bool     `json:"enabled"`
+	HardCapUSD      *float64 `json:"hard_cap_usd"`
+	EffectiveCapUSD *float64 `json:"effective_cap_usd"`
+	Paused          bool     `json:"paused"`
+}
+
 func NewLLMModelsView(settings *model.UserSettings) LLMModelsView {
 	return LLMModelsView{
 		Facts:                       settings.FactsModel,
@@ -190,6 +198,7 @@ func NewReadingPlanView(settings *model.UserSettings) ReadingPlanView {
 		Size:            settings.ReadingPlanSize,
 		DiversifyTopics: settings.ReadingPlanDiversifyTopics,
 		ExcludeRead:     settings.ReadingPlanExcludeRead,
+		Exploration:     settings.ReadingPlanExploration,
 	}
 }
 
@@ -354,6 +363,21 @@ func NewNotificationPriorityView(rule *model.NotificationPriorityRule) Notificat
 	}
 }
 
+func NewBudgetEnforcementView(settings *model.UserSettings, usedCostUSD float64) BudgetEnforcementView {
+	if settings == nil {
+		return BudgetEnforcementView{}
+	}
+	v := BudgetEnforcementView{
+		Enabled:    settings.BudgetEnforcementEnabled,
+		HardCapUSD: settings.BudgetHardCapUSD,
+	}
+	if capUSD, ok := EffectiveBudgetCapUSD(settings); ok {
+		v.EffectiveCapUSD = &capUSD
+		v.Paused = usedCostUSD >= capUSD
+	}
+	return v
+}
+
 func NewCurrentMonthView(monthStart, nextMonth time.Time, usedCostUSD float64, remainingBudgetUSD, remainingPct *float64) CurrentMonthView {
 	return CurrentMonthView{
 		MonthJST:           monthStart.Format("2006-01"),
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	// Feeds and pairs followed by fewer sharing users are dropped from the statistics, so a
+	// recommendation can never point at one identifiable person's subscriptions.
+	minSourceCoSubscriptionUsers = 3
+	// Caps the pairs a single heavy user contributes (n*(n-1) rows per user).
+	maxSourceStatsFeedsPerUser = 200
+
+	sourceCoSubscriptionReason = "同じソースを購読しているユーザーがよく購読"
+	sourceCoSubscriptionLookup = 200
+)
+
+type sourceCoSubscriptionStore interface {
+	ListSharedFeedURLs(ctx context.Context) (map[string][]string, error)
+	Replace(ctx context.Context, feedCounts map[string]int, pairs []model.SourceCoSubscription) error
+	ListRelated(ctx context.Context, feedURLs []string, limit int) ([]model.SourceCoSubscription, error)
+}
+
+// SourceCoSubscriptionService maintains the anonymized "users who follow X also follow Y"
+// statistics behind the co-subscription source suggestions.
+type SourceCoSubscriptionService struct {
+	repo sourceCoSubscriptionStore
+}
+
+func NewSourceCoSubscriptionService(repo sourceCoSubscriptionStore) *SourceCoSubscriptionService {
+	return &SourceCoSubscriptionService{repo: repo}
+}
+
+// Rebuild recomputes the statistics from scratch out of the opted-in users' current sources.
+func (s *SourceCoSubscriptionService) Rebuild(ctx context.Context) (feeds, pairs int, err error) {
+	userFeeds, err := s.repo.ListSharedFeedURLs(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	feedCounts, coPairs := BuildSourceCoSubscriptionStats(userFeeds)
+	if err := s.repo.Replace(ctx, feedCounts, coPairs); err != nil {
+		return 0, 0, err
+	}
+	return len(feedCounts), len(coPairs), nil
+}
+
+// BuildSourceCoSubscriptionStats counts subscribers per normalized feed URL and co-subscribers
+// per ordered feed pair, keeping only counts of at least minSourceCoSubscriptionUsers. Each
+// pair is returned in both directions so lookups only need the seed side.
+func BuildSourceCoSubscriptionStats(userFeeds map[string][]string) (map[string]int, []model.SourceCoSubscription) {
+	feedCounts := map[string]int{}
+	pairCounts := map[[2]string]int{}
+	for _, urls := range userFeeds {
+		seen := map[string]bool{}
+		feeds := make([]string, 0, len(urls))
+		for _, raw := range urls {
+			key := normalizeFeedURL(raw)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			feeds = append(feeds, key)
+		}
+		sort.Strings(feeds)
+		if len(feeds) > maxSourceStatsFeedsPerUser {
+			feeds = feeds[:maxSourceStatsFeedsPerUser]
+		}
+		for i, a := range feeds {
+			feedCounts[a]++
+			for _, b := range feeds[i+1:] {
+				pairCounts[[2]string{a, b}]++
+			}
+		}
+	}
+	for feed, n := range feedCounts {
+		if n < minSourceCoSubscriptionUsers {
+			delete(feedCounts, feed)
+		}
+	}
+	pairs := make([]model.SourceCoSubscription, 0)
+	for pair, n := range pairCounts {
+		if n < minSourceCoSubscriptionUsers {
+			continue
+		}
+		pairs = append(pairs,
+			model.SourceCoSubscription{FeedURL: pair[0], RelatedFeedURL: pair[1], CoCount: n},
+			model.SourceCoSubscription{FeedURL: pair[1], RelatedFeedURL: pair[0], CoCount: n},
+		)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].FeedURL != pairs[j].FeedURL {
+			return pairs[i].FeedURL < pairs[j].FeedURL
+		}
+		return pairs[i].RelatedFeedURL < pairs[j].RelatedFeedURL
+	})
+	return feedCounts, pairs
+}
+
+// mergeCoSubscriptionSuggestions adds co-subscription candidates to the suggestion pool. Each
+// seed source counts like a probe hit, plus up to 3 points for how many of the seed's
+// subscribers also follow the candidate.
+func mergeCoSubscriptionSuggestions(related []model.SourceCoSubscription, seedSourceIDs map[string]string, registered map[string]bool, cands map[string]*sourceSuggestionAgg) {
+	for _, rel := range related {
+		key := normalizeFeedURL(rel.RelatedFeedURL)
+		if key == "" || registered[key] {
+			continue
+		}
+		a := cands[key]
+		if a == nil {
+			a = &sourceSuggestionAgg{
+				URL:           rel.RelatedFeedURL,
+				Reasons:       map[string]bool{},
+				MatchedTopics: map[string]bool{},
+				SeedSourceIDs: map[string]bool{},
+			}
+			cands[key] = a
+		}
+		if !a.Reasons[sourceCoSubscriptionReason] {
+			a.Reasons[sourceCoSubscriptionReason] = true
+			a.Score++
+		}
+		seedID := seedSourceIDs[rel.FeedURL]
+		if seedID == "" || a.SeedSourceIDs[seedID] {
+			continue
+		}
+		a.SeedSourceIDs[seedID] = true
+		a.Score += 2
+		if rel.SubscriberCount > 0 {
+			a.Score += int(math.Round(3 * math.Min(1, float64(rel.CoCount)/float64(rel.SubscriberCount))))
+		}
+	}
+}
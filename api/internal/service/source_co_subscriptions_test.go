@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestBuildSourceCoSubscriptionStats(t *testing.T) {
+	userFeeds := map[string][]string{
+		"u1": {"https://A.example/feed", "https://b.example/feed", "https://c.example/feed"},
+		"u2": {"https://a.example:443/feed", "https://b.example/feed"},
+		"u3": {"https://a.example/feed", "https://b.example/feed", "https://a.example/feed"},
+		"u4": {"https://c.example/feed"},
+	}
+	feeds, pairs := BuildSourceCoSubscriptionStats(userFeeds)
+	if len(feeds) != 2 || feeds["https://a.example/feed"] != 3 || feeds["https://b.example/feed"] != 3 {
+		t.Fatalf("feeds = %v", feeds)
+	}
+	want := []model.SourceCoSubscription{
+		{FeedURL: "https://a.example/feed", RelatedFeedURL: "https://b.example/feed", CoCount: 3},
+		{FeedURL: "https://b.example/feed", RelatedFeedURL: "https://a.example/feed", CoCount: 3},
+	}
+	if len(pairs) != len(want) || pairs[0] != want[0] || pairs[1] != want[1] {
+		t.Fatalf("pairs = %+v", pairs)
+	}
+}
+
+func TestMergeCoSubscriptionSuggestions(t *testing.T) {
+	related := []model.SourceCoSubscription{
+		{FeedURL: "https://a.example/feed", RelatedFeedURL: "https://b.example/feed", CoCount: 6, SubscriberCount: 6},
+		{FeedURL: "https://a.example/feed", RelatedFeedURL: "https://mine.example/feed", CoCount: 6, SubscriberCount: 6},
+		{FeedURL: "https://z.example/feed", RelatedFeedURL: "https://b.example/feed", CoCount: 3, SubscriberCount: 30},
+	}
+	seeds := map[string]string{"https://a.example/feed": "s1", "https://z.example/feed": "s2"}
+	registered := map[string]bool{"https://a.example/feed": true, "https://z.example/feed": true, "https://mine.example/feed": true}
+	cands := map[string]*sourceSuggestionAgg{}
+	mergeCoSubscriptionSuggestions(related, seeds, registered, cands)
+
+	if len(cands) != 1 {
+		t.Fatalf("cands = %v", cands)
+	}
+	b := cands["https://b.example/feed"]
+	// reason 1 + seed s1 (2 + 3) + seed s2 (2 + 0)
+	if b == nil || b.Score != 8 || len(b.SeedSourceIDs) != 2 || !b.Reasons[sourceCoSubscriptionReason] {
+		t.Fatalf("b = %+v", b)
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	sourceResolveTimeout = 8 * time.Second
+	// sourceResolveConcurrency and sourceResolveBudget bound how long an import spends following
+	// redirects; entries not resolved within the budget are compared by URL only.
+	sourceResolveConcurrency = 8
+	sourceResolveBudget      = 2 * time.Minute
+)
+
+// feedburnerHosts serve the same feed under one path, so their URLs collapse to one key.
+var feedburnerHosts = map[string]bool{
+	"feeds.feedburner.com":  true,
+	"feeds2.feedburner.com": true,
+	"feedproxy.google.com":  true,
+}
+
+type sourceDuplicateStore interface {
+	List(ctx context.Context, userID string) ([]model.Source, error)
+}
+
+// SourceDuplicateChecker finds an existing source that a new feed URL only superficially
+// differs from: scheme, "www.", trailing slash, feedburner alias, or a redirect to it.
+type SourceDuplicateChecker struct {
+	sources sourceDuplicateStore
+	resolve func(ctx context.Context, rawURL string) (string, error)
+}
+
+func NewSourceDuplicateChecker(sources sourceDuplicateStore) *SourceDuplicateChecker {
+	return &SourceDuplicateChecker{sources: sources, resolve: ResolveFinalURL}
+}
+
+// Find returns the user's source of the same type that rawURL duplicates, or nil.
+func (c *SourceDuplicateChecker) Find(ctx context.Context, userID, srcType, rawURL string) (*model.Source, error) {
+	idx, err := c.Load(ctx, userID, srcType)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Match(ctx, rawURL), nil
+}
+
+// Load indexes the user's sources of one type for repeated Match calls.
+func (c *SourceDuplicateChecker) Load(ctx context.Context, userID, srcType string) (*SourceDuplicateIndex, error) {
+	sources, err := c.sources.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	idx := &SourceDuplicateIndex{byKey: map[string]*model.Source{}, resolve: c.resolve}
+	for i := range sources {
+		if sources[i].Type == srcType {
+			idx.Add(&sources[i])
+		}
+	}
+	return idx, nil
+}
+
+type SourceDuplicateIndex struct {
+	byKey   map[string]*model.Source
+	resolve func(ctx context.Context, rawURL string) (string, error)
+
+	// resolved holds the final URLs looked up by Prefetch. Once set, Match reads only from it.
+	resolved map[string]string
+}
+
+func (idx *SourceDuplicateIndex) Add(src *model.Source) {
+	if key := SourceDuplicateKey(src.URL); key != "" {
+		if _, ok := idx.byKey[key]; !ok {
+			idx.byKey[key] = src
+		}
+	}
+}
+
+// Match compares rawURL and, failing that, the URL it finally redirects to. Existing sources
+// are compared as stored; they are not resolved again.
+func (idx *SourceDuplicateIndex) Match(ctx context.Context, rawURL string) *model.Source {
+	key := SourceDuplicateKey(rawURL)
+	if key == "" {
+		return nil
+	}
+	if src := idx.byKey[key]; src != nil {
+		return src
+	}
+	var final string
+	switch {
+	case idx.resolved != nil:
+		final = idx.resolved[rawURL]
+	case idx.resolve != nil:
+		v, err := idx.resolve(ctx, rawURL)
+		if err != nil {
+			return nil
+		}
+		final = v
+	}
+	if finalKey := SourceDuplicateKey(final); finalKey != "" && finalKey != key {
+		return idx.byKey[finalKey]
+	}
+	return nil
+}
+
+// Prefetch resolves the final URL of every rawURL that does not already match by key, a few at
+// a time and within sourceResolveBudget, so Match on a long import list does not follow
+// redirects one entry after another.
+func (idx *SourceDuplicateIndex) Prefetch(ctx context.Context, rawURLs []string) {
+	resolved := map[string]string{}
+	if idx.resolve == nil {
+		idx.resolved = resolved
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, sourceResolveBudget)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, sourceResolveConcurrency)
+	for _, rawURL := range rawURLs {
+		key := SourceDuplicateKey(rawURL)
+		if key == "" || idx.byKey[key] != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(rawURL string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			final, err := idx.resolve(ctx, rawURL)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			resolved[rawURL] = final
+			mu.Unlock()
+		}(rawURL)
+	}
+	wg.Wait()
+	idx.resolved = resolved
+}
+
+// SourceDuplicateKey extends normalizeFeedURL so that feed URLs differing only in scheme,
+// a leading "www.", a trailing slash or a feedburner alias host compare equal.
+func SourceDuplicateKey(raw string) string {
+	normalized := normalizeFeedURL(raw)
+	if normalized == "" {
+		return ""
+	}
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	if feedburnerHosts[host] {
+		return "feedburner" + strings.ToLower(path)
+	}
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+	key := host + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// ResolveFinalURL follows redirects from rawURL over the public-only client and returns where
+// they end. Servers that reject HEAD are retried with GET.
+func ResolveFinalURL(ctx context.Context, rawURL string) (string, error) {
+	client := NewPublicHTTPClient(sourceResolveTimeout)
+	var lastErr error
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("User-Agent", "Sifto/1.0")
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+			lastErr = fmt.Errorf("resolve %s: status %d", method, resp.StatusCode)
+			continue
+		}
+		return resp.Request.URL.String(), nil
+	}
+	return "", lastErr
+}
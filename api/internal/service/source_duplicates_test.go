@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestSourceDuplicateKey(t *testing.T) {
+	same := [][2]string{
+		{"https://example.com/feed/", "http://www.example.com/feed"},
+		{"https://Example.com:443/feed#top", "https://example.com/feed"},
+		{"http://feeds.feedburner.com/TechBlog", "https://feedproxy.google.com/techblog/"},
+	}
+	for _, tc := range same {
+		if a, b := SourceDuplicateKey(tc[0]), SourceDuplicateKey(tc[1]); a == "" || a != b {
+			t.Fatalf("keys differ: %q=%q, %q=%q", tc[0], a, tc[1], b)
+		}
+	}
+	different := [][2]string{
+		{"https://example.com/feed", "https://example.com/feed?lang=en"},
+		{"https://example.com/feed", "https://blog.example.com/feed"},
+	}
+	for _, tc := range different {
+		if SourceDuplicateKey(tc[0]) == SourceDuplicateKey(tc[1]) {
+			t.Fatalf("keys equal for %q and %q", tc[0], tc[1])
+		}
+	}
+}
+
+type fakeSourceList []model.Source
+
+func (f fakeSourceList) List(_ context.Context, _ string) ([]model.Source, error) { return f, nil }
+
+func TestSourceDuplicateCheckerFind(t *testing.T) {
+	checker := NewSourceDuplicateChecker(fakeSourceList{
+		{ID: "rss-1", URL: "https://blog.example.com/atom.xml", Type: "rss"},
+		{ID: "manual-1", URL: "https://news.example.com/post", Type: "manual"},
+	})
+	checker.resolve = func(_ context.Context, rawURL string) (string, error) {
+		if rawURL == "https://example.com/feed" {
+			return "https://blog.example.com/atom.xml", nil
+		}
+		return rawURL, nil
+	}
+	ctx := context.Background()
+
+	if got, _ := checker.Find(ctx, "u1", "rss", "http://blog.example.com/atom.xml/"); got == nil || got.ID != "rss-1" {
+		t.Fatalf("normalized match = %+v", got)
+	}
+	if got, _ := checker.Find(ctx, "u1", "rss", "https://example.com/feed"); got == nil || got.ID != "rss-1" {
+		t.Fatalf("redirect match = %+v", got)
+	}
+	if got, _ := checker.Find(ctx, "u1", "rss", "https://news.example.com/post"); got != nil {
+		t.Fatalf("matched a source of another type: %+v", got)
+	}
+}
+
+func TestSourceDuplicateIndexPrefetchResolvesOnlyUnmatchedURLs(t *testing.T) {
+	checker := NewSourceDuplicateChecker(fakeSourceList{
+		{ID: "rss-1", URL: "https://blog.example.com/atom.xml", Type: "rss"},
+	})
+	var mu sync.Mutex
+	var calls []string
+	checker.resolve = func(_ context.Context, rawURL string) (string, error) {
+		mu.Lock()
+		calls = append(calls, rawURL)
+		mu.Unlock()
+		if rawURL == "https://example.com/feed" {
+			return "https://blog.example.com/atom.xml", nil
+		}
+		return rawURL, nil
+	}
+	ctx := context.Background()
+	idx, err := checker.Load(ctx, "u1", "rss")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	idx.Prefetch(ctx, []string{"http://blog.example.com/atom.xml/", "https://example.com/feed", "https://other.example.com/feed"})
+	if len(calls) != 2 {
+		t.Fatalf("resolve calls = %v, want the two URLs without a key match", calls)
+	}
+
+	if got := idx.Match(ctx, "https://example.com/feed"); got == nil || got.ID != "rss-1" {
+		t.Fatalf("redirect match = %+v", got)
+	}
+	// URLs missing from the prefetch are compared by key only, never resolved inline.
+	if got := idx.Match(ctx, "https://late.example.com/feed"); got != nil {
+		t.Fatalf("unprefetched match = %+v", got)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("Match resolved again: %v", calls)
+	}
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/mmcdole/gofeed"
+)
+
+const (
+	// SourceEstimateSampleSize is how many of the feed's latest entries the estimate looks at.
+	SourceEstimateSampleSize = 20
+	sourceEstimateMonthDays  = 30
+	sourceEstimateMaxBytes   = 10 << 20
+	sourceEstimateTimeout    = 15 * time.Second
+)
+
+// sourceEstimateStages mirrors what process-item sends to the LLM for one item. The overheads
+// cover the prompt templates; facts, summaries and check verdicts are typical output sizes.
+// Retries, cache hits and pre-screen skips are left out, so the estimate errs high.
+var sourceEstimateStages = []struct {
+	purpose         string
+	defaultPurpose  string
+	includesContent bool
+	inputOverhead   int
+	outputTokens    int
+}{
+	{purpose: "facts", defaultPurpose: "facts", includesContent: true, inputOverhead: 1200, outputTokens: 800},
+	{purpose: "facts_check", defaultPurpose: "facts", includesContent: true, inputOverhead: 1800, outputTokens: 200},
+	{purpose: "summary", defaultPurpose: "summary", inputOverhead: 2300, outputTokens: 900},
+	{purpose: "faithfulness_check", defaultPurpose: "summary", inputOverhead: 2700, outputTokens: 200},
+}
+
+var reSourceEstimateTag = regexp.MustCompile(`<[^>]*>`)
+
+type sourceEstimateSourceRepo interface {
+	GetByID(ctx context.Context, id, userID string) (*model.Source, error)
+}
+
+type sourceEstimateItemRepo interface {
+	StoredContentByURL(ctx context.Context, sourceID string, urls []string) (map[string]string, error)
+}
+
+type sourceEstimateSettingsRepo interface {
+	GetByUserID(ctx context.Context, userID string) (*model.UserSettings, error)
+}
+
+type sourceEstimatePricingRepo interface {
+	List(ctx context.Context) ([]model.ModelPricing, error)
+}
+
+// SourceCostStage is the projected usage of one pipeline step for the source.
+type SourceCostStage struct {
+	Purpose             string  `json:"purpose"`
+	Model               string  `json:"model"`
+	InputTokensPerItem  int     `json:"input_tokens_per_item"`
+	OutputTokensPerItem int     `json:"output_tokens_per_item"`
+	MonthlyInputTokens  int64   `json:"monthly_input_tokens"`
+	MonthlyOutputTokens int64   `json:"monthly_output_tokens"`
+	MonthlyCostUSD      float64 `json:"monthly_cost_usd"`
+	Priced              bool    `json:"priced"`
+}
+
+// SourceCostEstimate projects a month of the source's LLM usage from its latest entries.
+// EntriesPerMonth comes from the entries' dates; FrequencyKnown is false when the feed has no
+// dates and the sample size stands in for a month. Stages whose model has no known price count
+// toward the tokens but not MonthlyCostUSD.
+type SourceCostEstimate struct {
+	SourceID            string            `json:"source_id"`
+	SampledEntries      int               `json:"sampled_entries"`
+	StoredBodies        int               `json:"stored_bodies"`
+	AvgContentChars     int               `json:"avg_content_chars"`
+	AvgContentTokens    int               `json:"avg_content_tokens"`
+	EntriesPerMonth     float64           `json:"entries_per_month"`
+	FrequencyKnown      bool              `json:"frequency_known"`
+	Stages              []SourceCostStage `json:"stages"`
+	MonthlyInputTokens  int64             `json:"monthly_input_tokens"`
+	MonthlyOutputTokens int64             `json:"monthly_output_tokens"`
+	MonthlyCostUSD      float64           `json:"monthly_cost_usd"`
+	CostPerItemUSD      float64           `json:"cost_per_item_usd"`
+}
+
+// SourceEstimateService projects what a source would cost to process without calling the
+// LLM: it reads the feed, measures the latest entries and prices the user's models.
+type SourceEstimateService struct {
+	sources  sourceEstimateSourceRepo
+	items    sourceEstimateItemRepo
+	settings sourceEstimateSettingsRepo
+	pricing  sourceEstimatePricingRepo
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewSourceEstimateService(sources sourceEstimateSourceRepo, items sourceEstimateItemRepo, settings sourceEstimateSettingsRepo, pricing sourceEstimatePricingRepo) *SourceEstimateService {
+	return &SourceEstimateService{
+		sources:  sources,
+		items:    items,
+		settings: settings,
+		pricing:  pricing,
+		client:   NewPublicHTTPClient(sourceEstimateTimeout),
+		now:      time.Now,
+	}
+}
+
+// sourceEstimateEntry is a sampled entry with the text process-item would read for it.
+type sourceEstimateEntry struct {
+	url       string
+	published *time.Time
+	content   string
+}
+
+func (s *SourceEstimateService) Estimate(ctx context.Context, userID, sourceID string) (*SourceCostEstimate, error) {
+	src, err := s.sources.GetByID(ctx, sourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if src.Type != "rss" {
+		return nil, ErrSourceNotFeed
+	}
+	feed, err := s.fetchFeed(ctx, src.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSourceFeedUnavailable, err)
+	}
+	entries := sampleSourceEstimateEntries(feed, SourceEstimateSampleSize)
+	urls := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.url != "" {
+			urls = append(urls, e.url)
+		}
+	}
+	stored, err := s.items.StoredContentByURL(ctx, src.ID, urls)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settings.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	prices, err := s.pricing.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildSourceCostEstimate(src.ID, entries, stored, settings, prices, s.now()), nil
+}
+
+func (s *SourceEstimateService) fetchFeed(ctx context.Context, feedURL string) (*gofeed.Feed, error) {
+	if err := ValidatePublicHTTPURL(ctx, feedURL); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Sifto RSS Fetcher/1.0")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unexpected RSS response status: %s", res.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, sourceEstimateMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > sourceEstimateMaxBytes {
+		return nil, fmt.Errorf("RSS response exceeds %d bytes", sourceEstimateMaxBytes)
+	}
+	return gofeed.NewParser().Parse(bytes.NewReader(body))
+}
+
+// sampleSourceEstimateEntries keeps the limit newest entries. Undated entries sort last, in
+// feed order.
+func sampleSourceEstimateEntries(feed *gofeed.Feed, limit int) []sourceEstimateEntry {
+	if feed == nil {
+		return nil
+	}
+	entries := make([]sourceEstimateEntry, 0, len(feed.Items))
+	for _, it := range feed.Items {
+		if it == nil {
+			continue
+		}
+		e := sourceEstimateEntry{url: strings.TrimSpace(it.Link), content: it.Content}
+		if strings.TrimSpace(e.content) == "" {
+			e.content = it.Description
+		}
+		e.content = sourceEstimatePlainText(e.content)
+		if it.PublishedParsed != nil {
+			e.published = it.PublishedParsed
+		} else if it.UpdatedParsed != nil {
+			e.published = it.UpdatedParsed
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].published, entries[j].published
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+func sourceEstimatePlainText(s string) string {
+	s = reSourceEstimateTag.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// estimateTextTokens approximates a tokenizer: about four ASCII characters per token, and one
+// token per character for other scripts such as Japanese.
+func estimateTextTokens(s string) int {
+	ascii, other := 0, 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// sourceEntriesPerMonth extrapolates the dated entries, counted from the oldest one up to now,
+// to sourceEstimateMonthDays. Spans under a day count as a day so a burst does not explode the
+// rate.
+func sourceEntriesPerMonth(entries []sourceEstimateEntry, now time.Time) (float64, bool) {
+	var oldest *time.Time
+	dated := 0
+	for _, e := range entries {
+		if e.published == nil {
+			continue
+		}
+		dated++
+		if oldest == nil || e.published.Before(*oldest) {
+			oldest = e.published
+		}
+	}
+	if dated == 0 {
+		return float64(len(entries)), false
+	}
+	days := math.Max(now.Sub(*oldest).Hours()/24, 1)
+	return float64(dated) / days * sourceEstimateMonthDays, true
+}
+
+func sourceEstimateModel(configured *string, purpose string) string {
+	if configured != nil && strings.TrimSpace(*configured) != "" {
+		return strings.TrimSpace(*configured)
+	}
+	return DefaultLLMModelForPurpose(LLMProviderForModel(nil), purpose)
+}
+
+// sourceEstimatePrice prefers an admin-curated model_pricing row over the catalog price.
+func sourceEstimatePrice(prices []model.ModelPricing, modelID string) (input, output float64, ok bool) {
+	provider := CatalogProviderForModel(modelID)
+	for _, p := range prices {
+		if p.Model == modelID && (provider == "" || p.Provider == provider) {
+			return p.InputPerMTokUSD, p.OutputPerMTokUSD, true
+		}
+	}
+	if m := CatalogModelByID(modelID); m != nil && m.Pricing != nil {
+		return m.Pricing.InputPerMTokUSD, m.Pricing.OutputPerMTokUSD, true
+	}
+	return 0, 0, false
+}
+
+func buildSourceCostEstimate(sourceID string, entries []sourceEstimateEntry, stored map[string]string, settings *model.UserSettings, prices []model.ModelPricing, now time.Time) *SourceCostEstimate {
+	out := &SourceCostEstimate{SourceID: sourceID, SampledEntries: len(entries), Stages: []SourceCostStage{}}
+	if len(entries) == 0 {
+		return out
+	}
+	chars, tokens := 0, 0
+	for _, e := range entries {
+		content := e.content
+		if body, ok := stored[e.url]; ok {
+			content = body
+			out.StoredBodies++
+		}
+		chars += utf8.RuneCountInString(content)
+		tokens += estimateTextTokens(content)
+	}
+	out.AvgContentChars = chars / len(entries)
+	out.AvgContentTokens = tokens / len(entries)
+	out.EntriesPerMonth, out.FrequencyKnown = sourceEntriesPerMonth(entries, now)
+
+	if settings == nil {
+		settings = &model.UserSettings{}
+	}
+	configured := map[string]*string{
+		"facts":              settings.FactsModel,
+		"facts_check":        settings.FactsCheckModel,
+		"summary":            settings.SummaryModel,
+		"faithfulness_check": settings.FaithfulnessCheckModel,
+	}
+	for _, st := range sourceEstimateStages {
+		stage := SourceCostStage{
+			Purpose:             st.purpose,
+			Model:               sourceEstimateModel(configured[st.purpose], st.defaultPurpose),
+			InputTokensPerItem:  st.inputOverhead,
+			OutputTokensPerItem: st.outputTokens,
+		}
+		if st.includesContent {
+			stage.InputTokensPerItem += out.AvgContentTokens
+		}
+		stage.MonthlyInputTokens = int64(math.Round(float64(stage.InputTokensPerItem) * out.EntriesPerMonth))
+		stage.MonthlyOutputTokens = int64(math.Round(float64(stage.OutputTokensPerItem) * out.EntriesPerMonth))
+		if in, outPrice, ok := sourceEstimatePrice(prices, stage.Model); ok {
+			stage.Priced = true
+			stage.MonthlyCostUSD = float64(stage.MonthlyInputTokens)/1_000_000*in + float64(stage.MonthlyOutputTokens)/1_000_000*outPrice
+			out.CostPerItemUSD += float64(stage.InputTokensPerItem)/1_000_000*in + float64(stage.OutputTokensPerItem)/1_000_000*outPrice
+		}
+		out.MonthlyInputTokens += stage.MonthlyInputTokens
+		out.MonthlyOutputTokens += stage.MonthlyOutputTokens
+		out.MonthlyCostUSD += stage.MonthlyCostUSD
+		out.Stages = append(out.Stages, stage)
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/mmcdole/gofeed"
+)
+
+func TestEstimateTextTokens(t *testing.T) {
+	if got := estimateTextTokens("abcdefgh"); got != 2 {
+		t.Fatalf("ascii tokens = %d, want 2", got)
+	}
+	if got := estimateTextTokens("日本語です"); got != 5 {
+		t.Fatalf("japanese tokens = %d, want 5", got)
+	}
+}
+
+func TestSampleSourceEstimateEntriesKeepsNewestFirst(t *testing.T) {
+	older := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 8, 5, 0, 0, 0, 0, time.UTC)
+	feed := &gofeed.Feed{Items: []*gofeed.Item{
+		{Link: "https://example.com/undated", Description: "undated"},
+		{Link: "https://example.com/old", Description: "<p>old &amp; short</p>", PublishedParsed: &older},
+		{Link: "https://example.com/new", Content: "<div>new <b>body</b></div>", Description: "ignored", PublishedParsed: &newer},
+	}}
+
+	got := sampleSourceEstimateEntries(feed, 2)
+	if len(got) != 2 || got[0].url != "https://example.com/new" || got[1].url != "https://example.com/old" {
+		t.Fatalf("entries = %+v", got)
+	}
+	if got[0].content != "new body" || got[1].content != "old & short" {
+		t.Fatalf("contents = %q, %q", got[0].content, got[1].content)
+	}
+}
+
+func TestSourceEntriesPerMonth(t *testing.T) {
+	now := time.Date(2026, 8, 11, 0, 0, 0, 0, time.UTC)
+	tenDaysAgo := now.AddDate(0, 0, -10)
+	fiveDaysAgo := now.AddDate(0, 0, -5)
+	rate, known := sourceEntriesPerMonth([]sourceEstimateEntry{{published: &fiveDaysAgo}, {published: &tenDaysAgo}}, now)
+	if !known || math.Abs(rate-6) > 1e-9 {
+		t.Fatalf("rate = %v known = %v, want 6 true", rate, known)
+	}
+	rate, known = sourceEntriesPerMonth([]sourceEstimateEntry{{}, {}, {}}, now)
+	if known || rate != 3 {
+		t.Fatalf("undated rate = %v known = %v, want 3 false", rate, known)
+	}
+}
+
+func TestBuildSourceCostEstimateUsesStoredBodiesAndUserModels(t *testing.T) {
+	now := time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)
+	published := now.AddDate(0, 0, -30)
+	entries := []sourceEstimateEntry{
+		{url: "https://example.com/a", published: &published, content: "short"},
+		{url: "https://example.com/b", published: &published, content: "abcdefgh"},
+	}
+	stored := map[string]string{"https://example.com/a": "abcdefghabcdefgh"}
+	factsModel, summaryModel := "sifto-test-facts", "sifto-test-summary"
+	settings := &model.UserSettings{FactsModel: &factsModel, FactsCheckModel: &factsModel, SummaryModel: &summaryModel, FaithfulnessCheckModel: &summaryModel}
+	prices := []model.ModelPricing{
+		{Provider: CatalogProviderForModel(factsModel), Model: factsModel, InputPerMTokUSD: 1, OutputPerMTokUSD: 2},
+	}
+
+	got := buildSourceCostEstimate("src-1", entries, stored, settings, prices, now)
+	if got.StoredBodies != 1 || got.AvgContentChars != 12 || got.AvgContentTokens != 3 {
+		t.Fatalf("content = %+v", got)
+	}
+	if !got.FrequencyKnown || got.EntriesPerMonth != 2 {
+		t.Fatalf("frequency = %v %v", got.EntriesPerMonth, got.FrequencyKnown)
+	}
+	if len(got.Stages) != 4 {
+		t.Fatalf("stages = %+v", got.Stages)
+	}
+	facts := got.Stages[0]
+	if facts.Purpose != "facts" || facts.Model != factsModel || !facts.Priced || facts.InputTokensPerItem != 1203 || facts.MonthlyInputTokens != 2406 {
+		t.Fatalf("facts stage = %+v", facts)
+	}
+	if summary := got.Stages[2]; summary.Model != summaryModel || summary.Priced || summary.MonthlyCostUSD != 0 || summary.InputTokensPerItem != 2300 {
+		t.Fatalf("summary stage = %+v", summary)
+	}
+	wantCost := got.Stages[0].MonthlyCostUSD + got.Stages[1].MonthlyCostUSD
+	if math.Abs(got.MonthlyCostUSD-wantCost) > 1e-12 || got.MonthlyCostUSD <= 0 {
+		t.Fatalf("monthly cost = %v, want %v", got.MonthlyCostUSD, wantCost)
+	}
+}
+
+func TestBuildSourceCostEstimateWithoutEntries(t *testing.T) {
+	got := buildSourceCostEstimate("src-1", nil, nil, nil, nil, time.Now())
+	if got.SampledEntries != 0 || got.MonthlyCostUSD != 0 || got.Stages == nil {
+		t.Fatalf("estimate = %+v", got)
+	}
+}
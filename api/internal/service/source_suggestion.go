@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"path"
@@ -133,18 +134,20 @@ type suggestionProbe struct {
 }
 
 type SourceSuggestionService struct {
-	repo         *repository.SourceRepo
-	itemRepo     *repository.ItemRepo
+	repo         repository.SourceReader
+	itemRepo     repository.ItemReader
 	settingsRepo *repository.UserSettingsRepo
 	llmUsageRepo *repository.LLMUsageLogRepo
 	worker       *WorkerClient
 	cache        JSONCache
 	keyProvider  *UserKeyProvider
+	budgetGuard  *BudgetGuard
+	coSubs       sourceCoSubscriptionStore
 }
 
 func NewSourceSuggestionService(
-	repo *repository.SourceRepo,
-	itemRepo *repository.ItemRepo,
+	repo repository.SourceReader,
+	itemRepo repository.ItemReader,
 	settingsRepo *repository.UserSettingsRepo,
 	llmUsageRepo *repository.LLMUsageLogRepo,
 	worker *WorkerClient,
@@ -159,9 +162,44 @@ func NewSourceSuggestionService(
 		worker:       worker,
 		cache:        cache,
 		keyProvider:  keyProvider,
+		budgetGuard:  newSourceSuggestionBudgetGuard(llmUsageRepo),
 	}
 }
 
+func newSourceSuggestionBudgetGuard(llmUsageRepo *repository.LLMUsageLogRepo) *BudgetGuard {
+	if llmUsageRepo == nil {
+		return nil
+	}
+	return NewBudgetGuard(llmUsageRepo)
+}
+
+func (s *SourceSuggestionService) SetBudgetAllocationRepo(repo *repository.LLMBudgetAllocationRepo) {
+	if s == nil || s.budgetGuard == nil || repo == nil {
+		return
+	}
+	s.budgetGuard.WithAllocations(repo)
+}
+
+// SetCoSubscriptionRepo enables the "users who follow X also follow Y" candidates.
+func (s *SourceSuggestionService) SetCoSubscriptionRepo(repo sourceCoSubscriptionStore) {
+	if s == nil {
+		return
+	}
+	s.coSubs = repo
+}
+
+func (s *SourceSuggestionService) sourceSuggestionBudgetExceeded(ctx context.Context, userID string) bool {
+	if s.budgetGuard == nil || s.settingsRepo == nil {
+		return false
+	}
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false
+	}
+	status, err := s.budgetGuard.CheckPurposes(ctx, settings, LLMBudgetPurposeSourceSuggestion)
+	return err == nil && status.Exceeded
+}
+
 func (s *SourceSuggestionService) BuildSourceRecommendations(ctx context.Context, userID string, limit int) ([]SourceSuggestionResponse, map[string]any, error) {
 	sources, err := s.repo.List(ctx, userID)
 	if err != nil {
@@ -254,6 +292,10 @@ func (s *SourceSuggestionService) BuildSourceRecommendations(ctx context.Context
 
 	cands := map[string]*sourceSuggestionAgg{}
 	aiReady := (resolved.AnthropicAPIKey != nil || resolved.GoogleAPIKey != nil || resolved.GroqAPIKey != nil || resolved.FireworksAPIKey != nil || resolved.DeepseekAPIKey != nil || resolved.AlibabaAPIKey != nil || resolved.MistralAPIKey != nil || resolved.TogetherAPIKey != nil || resolved.MoonshotAPIKey != nil || resolved.MiniMaxAPIKey != nil || resolved.XiaomiMiMoTokenPlanAPIKey != nil || resolved.XAIAPIKey != nil || resolved.ZAIAPIKey != nil || resolved.OpenAIAPIKey != nil || resolved.OpenRouterAPIKey != nil || resolved.PoeAPIKey != nil || resolved.SiliconFlowAPIKey != nil || resolved.FeatherlessAPIKey != nil || resolved.DeepInfraAPIKey != nil) && s.worker != nil
+	budgetExceeded := aiReady && s.sourceSuggestionBudgetExceeded(ctx, userID)
+	if budgetExceeded {
+		aiReady = false
+	}
 	var seedLLMMeta map[string]any
 	timedOutInAiStep := false
 	if aiReady {
@@ -290,6 +332,7 @@ func (s *SourceSuggestionService) BuildSourceRecommendations(ctx context.Context
 	if !aiReady {
 		populateSourceSuggestionsFromProbes(ctx, probes, preferredTopics, registered, cands, remainingSuggestionBudget, DiscoverRSSFeeds)
 	}
+	s.addCoSubscriptionSuggestions(ctx, sources, registered, cands)
 
 	out := make([]SourceSuggestionResponse, 0, len(cands))
 	type sortable struct {
@@ -337,35 +380,38 @@ func (s *SourceSuggestionService) BuildSourceRecommendations(ctx context.Context
 	for _, r := range rows {
 		out = append(out, r.row)
 	}
-	llmMeta := s.rankSourceSuggestionsWithLLM(
-		ctx,
-		userID,
-		sources,
-		preferredTopics,
-		positiveExamples,
-		negativeExamples,
-		out,
-		resolved.AnthropicAPIKey,
-		resolved.GoogleAPIKey,
-		resolved.GroqAPIKey,
-		resolved.FireworksAPIKey,
-		resolved.DeepseekAPIKey,
-		resolved.AlibabaAPIKey,
-		resolved.MistralAPIKey,
-		resolved.TogetherAPIKey,
-		resolved.MoonshotAPIKey,
-		resolved.MiniMaxAPIKey,
-		resolved.OpenRouterAPIKey,
-		resolved.PoeAPIKey,
-		resolved.SiliconFlowAPIKey,
-		resolved.FeatherlessAPIKey,
-		resolved.CerebrasAPIKey,
-		resolved.XAIAPIKey,
-		resolved.ZAIAPIKey,
-		resolved.OpenAIAPIKey,
-		resolved.SelectedModel,
-		remainingSuggestionBudget,
-	)
+	var llmMeta map[string]any
+	if !budgetExceeded {
+		llmMeta = s.rankSourceSuggestionsWithLLM(
+			ctx,
+			userID,
+			sources,
+			preferredTopics,
+			positiveExamples,
+			negativeExamples,
+			out,
+			resolved.AnthropicAPIKey,
+			resolved.GoogleAPIKey,
+			resolved.GroqAPIKey,
+			resolved.FireworksAPIKey,
+			resolved.DeepseekAPIKey,
+			resolved.AlibabaAPIKey,
+			resolved.MistralAPIKey,
+			resolved.TogetherAPIKey,
+			resolved.MoonshotAPIKey,
+			resolved.MiniMaxAPIKey,
+			resolved.OpenRouterAPIKey,
+			resolved.PoeAPIKey,
+			resolved.SiliconFlowAPIKey,
+			resolved.FeatherlessAPIKey,
+			resolved.CerebrasAPIKey,
+			resolved.XAIAPIKey,
+			resolved.ZAIAPIKey,
+			resolved.OpenAIAPIKey,
+			resolved.SelectedModel,
+			remainingSuggestionBudget,
+		)
+	}
 	if llmMeta == nil && seedLLMMeta != nil {
 		llmMeta = seedLLMMeta
 	}
@@ -375,6 +421,9 @@ func (s *SourceSuggestionService) BuildSourceRecommendations(ctx context.Context
 	if timedOutInAiStep {
 		llmMeta = mergeLLMWarning(llmMeta, "source suggestion timed out during AI seed generation", "seed_generation")
 	}
+	if budgetExceeded {
+		llmMeta = mergeLLMWarning(llmMeta, "source suggestion AI steps skipped; LLM budget cap reached", "budget")
+	}
 	if resolved.SelectedModel != nil && strings.TrimSpace(*resolved.SelectedModel) != "" {
 		if llmMeta == nil {
 			llmMeta = map[string]any{}
@@ -1085,6 +1134,28 @@ func (s *SourceSuggestionService) recordSourceSuggestionLLMUsage(ctx context.Con
 	_ = BumpUserLLMUsageCacheVersion(ctx, s.cache, userID)
 }
 
+func (s *SourceSuggestionService) addCoSubscriptionSuggestions(ctx context.Context, sources []model.Source, registered map[string]bool, cands map[string]*sourceSuggestionAgg) {
+	if s.coSubs == nil {
+		return
+	}
+	seedSourceIDs := map[string]string{}
+	feedURLs := make([]string, 0, len(sources))
+	for _, src := range sources {
+		key := normalizeFeedURL(src.URL)
+		if key == "" || seedSourceIDs[key] != "" {
+			continue
+		}
+		seedSourceIDs[key] = src.ID
+		feedURLs = append(feedURLs, key)
+	}
+	related, err := s.coSubs.ListRelated(ctx, feedURLs, sourceCoSubscriptionLookup)
+	if err != nil {
+		log.Printf("source suggestion co-subscriptions failed: %v", err)
+		return
+	}
+	mergeCoSubscriptionSuggestions(related, seedSourceIDs, registered, cands)
+}
+
 func populateSourceSuggestionsFromProbes(
 	ctx context.Context,
 	probes []probeSeed,
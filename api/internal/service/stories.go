@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	minStoryClusterSize    = 2
+	maxStoryContextBullets = 6
+)
+
+var storyCitationMarkerPattern = regexp.MustCompile(`\[\[([a-zA-Z0-9-]+)\]\]`)
+
+// StoryItemDay is the JST calendar day an item belongs to on a story timeline.
+func StoryItemDay(it model.Item) string {
+	t := it.CreatedAt
+	if it.PublishedAt != nil {
+		t = *it.PublishedAt
+	}
+	return t.In(timeutil.JST).Format("2006-01-02")
+}
+
+type StoryAssignment struct {
+	StoryID string
+	Label   string
+	ItemIDs []string
+}
+
+// PlanStoryAssignments decides which clusters become or extend stories. A cluster matched to a
+// story, by its items or its centroid, extends that story; otherwise it starts a new story, but
+// only when it spans at least two days so one-off duplicates stay out.
+func PlanStoryAssignments(clusters []model.ReadingPlanCluster, storyByCluster map[string]model.ClusterStory) []StoryAssignment {
+	out := []StoryAssignment{}
+	for _, c := range clusters {
+		if len(c.Items) < minStoryClusterSize {
+			continue
+		}
+		days := map[string]struct{}{}
+		ids := make([]string, 0, len(c.Items))
+		for _, it := range c.Items {
+			ids = append(ids, it.ID)
+			days[StoryItemDay(it)] = struct{}{}
+		}
+		storyID := storyByCluster[c.ID].StoryID
+		if storyID == "" && len(days) < 2 {
+			continue
+		}
+		out = append(out, StoryAssignment{StoryID: storyID, Label: c.Label, ItemIDs: ids})
+	}
+	return out
+}
+
+// GroupStoryDays splits a story's items, in timeline order, into JST days.
+func GroupStoryDays(items []model.Item) []model.StoryTimelineDay {
+	days := []model.StoryTimelineDay{}
+	for _, it := range items {
+		day := StoryItemDay(it)
+		if n := len(days); n > 0 && days[n-1].Day == day {
+			days[n-1].Items = append(days[n-1].Items, it)
+			continue
+		}
+		days = append(days, model.StoryTimelineDay{Day: day, Bullets: []string{}, Items: []model.Item{it}})
+	}
+	return days
+}
+
+// ApplyStoryDayNotes fills each day from its stored note. A day without a note, or whose note
+// was written for a different number of items, is marked pending. It returns the pending count.
+func ApplyStoryDayNotes(days []model.StoryTimelineDay, notes map[string]model.StoryDayNote) int {
+	pending := 0
+	for i := range days {
+		note, ok := notes[days[i].Day]
+		if ok && note.ItemCount == len(days[i].Items) {
+			days[i].Bullets = note.Bullets
+			days[i].Pending = false
+			continue
+		}
+		days[i].Pending = true
+		pending++
+	}
+	return pending
+}
+
+// StoryDayQuestion asks, in the user's locale, what a day's articles added to the story given
+// the latest bullets of the days before it.
+func StoryDayQuestion(locale, label, day string, previous []string) string {
+	var b strings.Builder
+	en := NormalizeLocale(locale) == "en"
+	if en {
+		fmt.Fprintf(&b, "Summarize as bullet points what the articles from %s add to \"%s\" and how things changed since the previous days.", day, label)
+	} else {
+		fmt.Fprintf(&b, "「%s」について、%s の記事から新しく分かったことや前日までからの変化を箇条書きでまとめてください。", label, day)
+	}
+	if len(previous) > maxStoryContextBullets {
+		previous = previous[len(previous)-maxStoryContextBullets:]
+	}
+	if len(previous) > 0 {
+		if en {
+			b.WriteString("\nStory so far:\n- ")
+		} else {
+			b.WriteString("\nこれまでの経緯:\n- ")
+		}
+		b.WriteString(strings.Join(previous, "\n- "))
+	}
+	return b.String()
+}
+
+// StoryDayBullets turns an ask answer into note bullets without citation markers, falling back
+// to the answer text when the model returned no bullets.
+func StoryDayBullets(resp *AskResponse) []string {
+	bullets := []string{}
+	for _, b := range resp.Bullets {
+		if b = stripStoryCitations(b); b != "" {
+			bullets = append(bullets, b)
+		}
+	}
+	if len(bullets) == 0 {
+		if answer := stripStoryCitations(resp.Answer); answer != "" {
+			bullets = append(bullets, answer)
+		}
+	}
+	return bullets
+}
+
+func stripStoryCitations(text string) string {
+	return strings.TrimSpace(storyCitationMarkerPattern.ReplaceAllString(text, ""))
+}
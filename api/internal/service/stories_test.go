@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestStoryItemDayUsesJST(t *testing.T) {
+	published := time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC)
+	it := model.Item{PublishedAt: &published, CreatedAt: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)}
+	if got := StoryItemDay(it); got != "2026-03-02" {
+		t.Fatalf("StoryItemDay = %q, want 2026-03-02", got)
+	}
+	it.PublishedAt = nil
+	if got := StoryItemDay(it); got != "2026-03-05" {
+		t.Fatalf("StoryItemDay without published_at = %q, want 2026-03-05", got)
+	}
+}
+
+func TestPlanStoryAssignments(t *testing.T) {
+	day1 := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	item := func(id string, at time.Time) model.Item {
+		return model.Item{ID: id, PublishedAt: &at}
+	}
+	clusters := []model.ReadingPlanCluster{
+		{ID: "spanning", Label: "new story", Items: []model.Item{item("a", day1), item("b", day2)}},
+		{ID: "same-day", Label: "duplicates", Items: []model.Item{item("c", day1), item("d", day1)}},
+		{ID: "extends", Label: "existing", Items: []model.Item{item("e", day2), item("f", day2), item("g", day2)}},
+		{ID: "single", Label: "single", Items: []model.Item{item("h", day1)}},
+		{ID: "continues", Label: "same-day follow-up", Items: []model.Item{item("i", day2), item("j", day2)}},
+	}
+	storyByCluster := map[string]model.ClusterStory{
+		"extends":   {StoryID: "story-2", MatchedBy: "items"},
+		"continues": {StoryID: "story-3", MatchedBy: "centroid"},
+	}
+
+	got := PlanStoryAssignments(clusters, storyByCluster)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3: %+v", len(got), got)
+	}
+	if got[0].StoryID != "" || got[0].Label != "new story" || len(got[0].ItemIDs) != 2 {
+		t.Errorf("first assignment = %+v, want new story with 2 items", got[0])
+	}
+	if got[1].StoryID != "story-2" || len(got[1].ItemIDs) != 3 {
+		t.Errorf("second assignment = %+v, want story-2 with 3 items", got[1])
+	}
+	if got[2].StoryID != "story-3" || len(got[2].ItemIDs) != 2 {
+		t.Errorf("third assignment = %+v, want single-day cluster to continue story-3", got[2])
+	}
+}
+
+func TestGroupStoryDays(t *testing.T) {
+	at := func(s string) *time.Time {
+		v, _ := time.Parse(time.RFC3339, s)
+		return &v
+	}
+	items := []model.Item{
+		{ID: "a", PublishedAt: at("2026-03-01T01:00:00Z")},
+		{ID: "b", PublishedAt: at("2026-03-01T14:00:00Z")},
+		{ID: "c", PublishedAt: at("2026-03-01T16:00:00Z")},
+	}
+	days := GroupStoryDays(items)
+	if len(days) != 2 {
+		t.Fatalf("len = %d, want 2", len(days))
+	}
+	if days[0].Day != "2026-03-01" || len(days[0].Items) != 2 {
+		t.Errorf("day0 = %s with %d items", days[0].Day, len(days[0].Items))
+	}
+	if days[1].Day != "2026-03-02" || days[1].Items[0].ID != "c" {
+		t.Errorf("day1 = %s, first item %s", days[1].Day, days[1].Items[0].ID)
+	}
+
+	pending := ApplyStoryDayNotes(days, map[string]model.StoryDayNote{
+		"2026-03-01": {Day: "2026-03-01", Bullets: []string{"launched"}, ItemCount: 2},
+		"2026-03-02": {Day: "2026-03-02", Bullets: []string{"stale"}, ItemCount: 3},
+	})
+	if pending != 1 || days[0].Pending || days[0].Bullets[0] != "launched" || !days[1].Pending {
+		t.Fatalf("pending = %d, days = %+v", pending, days)
+	}
+}
+
+func TestStoryDayQuestionKeepsRecentContextInLocale(t *testing.T) {
+	previous := []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7"}
+	q := StoryDayQuestion("ja", "新モデル発表", "2026-03-02", previous)
+	if !strings.Contains(q, "新モデル発表") || !strings.Contains(q, "2026-03-02") || !strings.Contains(q, "これまでの経緯") {
+		t.Fatalf("question missing label, day or context: %q", q)
+	}
+	if strings.Contains(q, "p1") || !strings.Contains(q, "p7") {
+		t.Fatalf("question should keep only the latest context bullets: %q", q)
+	}
+	if q := StoryDayQuestion("en", "Model launch", "2026-03-02", previous); !strings.Contains(q, "Story so far") || strings.Contains(q, "箇条書き") {
+		t.Fatalf("english question = %q", q)
+	}
+}
+
+func TestStoryDayBulletsFallsBackToAnswer(t *testing.T) {
+	got := StoryDayBullets(&AskResponse{Answer: " 発表があった [[item-1]] "})
+	if len(got) != 1 || got[0] != "発表があった" {
+		t.Fatalf("bullets = %#v", got)
+	}
+	got = StoryDayBullets(&AskResponse{Answer: "x", Bullets: []string{"a", " ", "b"}})
+	if len(got) != 2 {
+		t.Fatalf("bullets = %#v", got)
+	}
+}
@@ -118,6 +118,28 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 	if summaryText == "" {
 		return nil, ErrSummaryAudioMissingSummary
 	}
+	narration := BuildSummaryAudioNarration(derefString(item.TranslatedTitle), derefString(item.Title), summaryText)
+	result, err := s.synthesizeNarration(ctx, userID, item.ID, narration)
+	if err != nil {
+		return nil, err
+	}
+	result.Item = item
+	return result, nil
+}
+
+// SynthesizeText voices arbitrary text with the user's summary audio voice settings.
+func (s *SummaryAudioPlayerService) SynthesizeText(ctx context.Context, userID, text string) (*SummaryAudioSynthesis, error) {
+	if s == nil || s.summaryAudio == nil || s.userSettings == nil || s.worker == nil {
+		return nil, errors.New("summary audio service is not configured")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrSummaryAudioMissingSummary
+	}
+	return s.synthesizeNarration(ctx, strings.TrimSpace(userID), "", text)
+}
+
+func (s *SummaryAudioPlayerService) synthesizeNarration(ctx context.Context, userID, refID, narration string) (*SummaryAudioSynthesis, error) {
 	settings, err := s.summaryAudio.EnsureDefaults(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -130,7 +152,6 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 	if provider == "" || voiceModel == "" {
 		return nil, ErrSummaryAudioMissingVoice
 	}
-	narration := BuildSummaryAudioNarration(derefString(item.TranslatedTitle), derefString(item.Title), summaryText)
 	var preprocessedText *string
 	var aivisAPIKey *string
 	var aivisUserDictionaryUUID *string
@@ -154,7 +175,7 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 			return nil, err
 		}
 		if s.preprocess != nil {
-			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, item.ID, provider, narration)
+			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, refID, provider, narration)
 			if preprocessErr != nil {
 				return nil, preprocessErr
 			}
@@ -170,7 +191,7 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 			return nil, err
 		}
 		if s.preprocess != nil {
-			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, item.ID, provider, narration)
+			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, refID, provider, narration)
 			if preprocessErr != nil {
 				return nil, preprocessErr
 			}
@@ -185,7 +206,7 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 			return nil, err
 		}
 		if s.preprocess != nil {
-			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, item.ID, provider, narration)
+			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, refID, provider, narration)
 			if preprocessErr != nil {
 				return nil, preprocessErr
 			}
@@ -201,7 +222,7 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 			return nil, err
 		}
 		if s.preprocess != nil {
-			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, item.ID, provider, narration)
+			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProvider(ctx, userID, refID, provider, narration)
 			if preprocessErr != nil {
 				return nil, preprocessErr
 			}
@@ -230,7 +251,7 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 			return nil, errors.New("azure speech region is not configured")
 		}
 		if s.preprocess != nil {
-			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProviderWithVariables(ctx, userID, item.ID, provider, narration, map[string]string{
+			preprocessed, preprocessErr := s.preprocess.PreprocessSummaryAudioTextForProviderWithVariables(ctx, userID, refID, provider, narration, map[string]string{
 				"voice_name":   strings.TrimSpace(voiceModel),
 				"voice_locale": "ja-JP",
 			})
@@ -281,7 +302,6 @@ func (s *SummaryAudioPlayerService) Synthesize(ctx context.Context, userID, item
 	if err != nil {
 		return nil, err
 	}
-	result.Item = item
 	result.PreprocessedText = preprocessedText
 	return result, nil
 }
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	TopicAlertChannelEmail   = "email"
+	TopicAlertChannelWebhook = "webhook"
+	TopicAlertChannelPush    = "push"
+
+	DefaultTopicAlertMinDelta = 3
+	TopicAlertCooldown        = 12 * time.Hour
+)
+
+// NormalizeTopicAlertChannels deduplicates channels and rejects unknown ones.
+func NormalizeTopicAlertChannels(channels []string) ([]string, error) {
+	out := make([]string, 0, len(channels))
+	seen := map[string]struct{}{}
+	for _, ch := range channels {
+		ch = strings.ToLower(strings.TrimSpace(ch))
+		switch ch {
+		case TopicAlertChannelEmail, TopicAlertChannelWebhook, TopicAlertChannelPush:
+		default:
+			return nil, fmt.Errorf("unsupported channel %q", ch)
+		}
+		if _, dup := seen[ch]; dup {
+			continue
+		}
+		seen[ch] = struct{}{}
+		out = append(out, ch)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one channel is required")
+	}
+	return out, nil
+}
+
+type TopicAlertMatch struct {
+	Subscription model.TopicAlertSubscription
+	Trend        model.TopicTrend
+}
+
+// MatchTopicAlerts pairs subscriptions with trends whose delta reaches their threshold.
+// Topics are compared by TopicKey so "LLMs" follows "LLM".
+func MatchTopicAlerts(subs []model.TopicAlertSubscription, trends []model.TopicTrend) []TopicAlertMatch {
+	byKey := make(map[string]model.TopicTrend, len(trends))
+	for _, t := range trends {
+		key := TopicKey(t.Topic)
+		if prev, ok := byKey[key]; ok && prev.Delta >= t.Delta {
+			continue
+		}
+		byKey[key] = t
+	}
+	out := []TopicAlertMatch{}
+	for _, s := range subs {
+		t, ok := byKey[TopicKey(s.Topic)]
+		if !ok || t.Delta < s.MinDelta {
+			continue
+		}
+		out = append(out, TopicAlertMatch{Subscription: s, Trend: t})
+	}
+	return out
+}
+
+type TopicAlertItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type TopicAlertEmail struct {
+	Locale       string
+	Topic        string
+	Count24h     int
+	CountPrev24h int
+	Delta        int
+	Items        []TopicAlertItem
+	PageURL      string
+}
+
+func SendTopicAlertEmail(ctx context.Context, sender EmailSender, to string, alert TopicAlertEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip topic alert to %s", to)
+		return nil
+	}
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf(emailStringsFor(alert.Locale).TopicAlertSubject, alert.Topic),
+		HTML:    buildTopicAlertHTML(alert),
+	})
+}
+
+func buildTopicAlertHTML(a TopicAlertEmail) string {
+	strs := emailStringsFor(a.Locale)
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.TopicAlertHeading)))
+	sb.WriteString(`<p style="line-height:1.7;color:#333">` + html.EscapeString(fmt.Sprintf(strs.TopicAlertLead, a.Topic, a.Count24h, a.Delta)) + `</p>`)
+	if len(a.Items) > 0 {
+		sb.WriteString(`<ul style="padding-left:20px;line-height:1.7">`)
+		for _, it := range a.Items {
+			title := strings.TrimSpace(it.Title)
+			if title == "" {
+				title = strs.UntitledItem
+			}
+			sb.WriteString(fmt.Sprintf(`<li><a href="%s" style="color:#2563eb">%s</a></li>`, html.EscapeString(it.URL), html.EscapeString(title)))
+		}
+		sb.WriteString(`</ul>`)
+	}
+	if a.PageURL != "" {
+		sb.WriteString(fmt.Sprintf(`<p><a href="%s" style="color:#2563eb">Sifto</a></p>`, html.EscapeString(a.PageURL)))
+	}
+	sb.WriteString(fmt.Sprintf(`<p style="margin-top:12px;color:#666;line-height:1.6">%s</p>`, html.EscapeString(strs.TopicAlertFooter)))
+	sb.WriteString(`</body></html>`)
+	return sb.String()
+}
+
+type TopicAlertWebhookPayload struct {
+	Type         string           `json:"type"`
+	Topic        string           `json:"topic"`
+	Count24h     int              `json:"count_24h"`
+	CountPrev24h int              `json:"count_prev_24h"`
+	Delta        int              `json:"delta"`
+	Items        []TopicAlertItem `json:"items"`
+	SentAt       time.Time        `json:"sent_at"`
+}
+
+// PostTopicAlertWebhook delivers the alert as JSON. client should be a public-only client
+// since the URL is user supplied.
+func PostTopicAlertWebhook(ctx context.Context, client *http.Client, url string, payload TopicAlertWebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sifto-TopicAlert/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestNormalizeTopicAlertChannels(t *testing.T) {
+	got, err := NormalizeTopicAlertChannels([]string{" Email", "push", "email"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "email,push" {
+		t.Fatalf("channels = %v", got)
+	}
+	if _, err := NormalizeTopicAlertChannels([]string{"sms"}); err == nil {
+		t.Fatal("expected error for unknown channel")
+	}
+	if _, err := NormalizeTopicAlertChannels(nil); err == nil {
+		t.Fatal("expected error for empty channels")
+	}
+}
+
+func TestMatchTopicAlerts(t *testing.T) {
+	subs := []model.TopicAlertSubscription{
+		{ID: "a", Topic: "LLM", MinDelta: 3},
+		{ID: "b", Topic: "Rust", MinDelta: 5},
+		{ID: "c", Topic: "Go", MinDelta: 1},
+	}
+	trends := []model.TopicTrend{
+		{Topic: "LLMs", Count24h: 8, CountPrev24h: 2, Delta: 6},
+		{Topic: "Rust", Count24h: 6, CountPrev24h: 2, Delta: 4},
+	}
+	got := MatchTopicAlerts(subs, trends)
+	if len(got) != 1 {
+		t.Fatalf("matches = %+v, want 1", got)
+	}
+	if got[0].Subscription.ID != "a" || got[0].Trend.Topic != "LLMs" {
+		t.Fatalf("match = %+v", got[0])
+	}
+}
+
+func TestBuildTopicAlertHTMLEscapes(t *testing.T) {
+	out := buildTopicAlertHTML(TopicAlertEmail{
+		Locale: "en",
+		Topic:  "<script>",
+		Items:  []TopicAlertItem{{Title: "A & B", URL: "https://example.com/?a=1&b=2"}},
+	})
+	if strings.Contains(out, "<script>") {
+		t.Fatal("topic was not escaped")
+	}
+	if !strings.Contains(out, "A &amp; B") || !strings.Contains(out, "a=1&amp;b=2") {
+		t.Fatalf("items were not escaped: %s", out)
+	}
+}
@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	TopicAliasSourceLexical   = "lexical"
+	TopicAliasSourceEmbedding = "embedding"
+	TopicAliasSourceManual    = "manual"
+
+	topicEmbeddingMinItems      = 3
+	topicEmbeddingMinSimilarity = 0.95
+	topicCentroidSampleSize     = 50
+	maxTopicEmbeddingCandidates = 200
+)
+
+var topicAcronymStopwords = stringSet("of", "and", "the", "for", "in", "on", "to", "a", "an")
+
+// TopicKey folds a topic label into its alias key: full-width ASCII is narrowed, case and
+// separators are folded and English plurals are singularised, so "LLMs", "llm" and "ＬＬＭ"
+// share one key.
+func TopicKey(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(raw) {
+		if r >= '！' && r <= '～' {
+			r -= 0xFEE0
+		}
+		switch {
+		case r == '-' || r == '_' || r == '/' || r == '・' || r == '.' || unicode.IsSpace(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	words := strings.Fields(b.String())
+	for i, w := range words {
+		words[i] = singularTopicWord(w)
+	}
+	return strings.Join(words, " ")
+}
+
+func singularTopicWord(w string) string {
+	for _, r := range w {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return w
+		}
+	}
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 2 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// topicAcronym returns the initials of a multi-word ASCII key ("large language model" ->
+// "llm"), or "" when the key is not such a phrase.
+func topicAcronym(key string) string {
+	words := strings.Fields(key)
+	if len(words) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	for _, w := range words {
+		if _, skip := topicAcronymStopwords[w]; skip {
+			continue
+		}
+		r := rune(w[0])
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return ""
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() < 2 {
+		return ""
+	}
+	return b.String()
+}
+
+type TopicGroup struct {
+	Key   string
+	Name  string
+	Count int
+	Names []string
+}
+
+// GroupTopicsByKey folds raw labels sharing a key. The most used spelling names the group;
+// groups are ordered by total count.
+func GroupTopicsByKey(raws []model.TopicRawCount) []TopicGroup {
+	byKey := map[string]*TopicGroup{}
+	best := map[string]int{}
+	order := []string{}
+	for _, raw := range raws {
+		key := TopicKey(raw.Name)
+		if key == "" {
+			continue
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &TopicGroup{Key: key}
+			byKey[key] = g
+			order = append(order, key)
+		}
+		g.Count += raw.Count
+		g.Names = append(g.Names, raw.Name)
+		if raw.Count > best[key] || (raw.Count == best[key] && raw.Name < g.Name) {
+			best[key] = raw.Count
+			g.Name = raw.Name
+		}
+	}
+	out := make([]TopicGroup, 0, len(order))
+	for _, key := range order {
+		out = append(out, *byKey[key])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// CanonicalizeTopics maps each label through the alias keys, dropping duplicates while
+// keeping the first position. Labels without an alias are kept as is.
+func CanonicalizeTopics(topics []string, canonicalByKey map[string]string) []string {
+	out := make([]string, 0, len(topics))
+	seen := map[string]struct{}{}
+	for _, t := range topics {
+		name := t
+		if v, ok := canonicalByKey[TopicKey(t)]; ok {
+			name = v
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	return out
+}
+
+type TopicTaxonomyService struct {
+	repo *repository.TopicRepo
+}
+
+func NewTopicTaxonomyService(repo *repository.TopicRepo) *TopicTaxonomyService {
+	return &TopicTaxonomyService{repo: repo}
+}
+
+func (s *TopicTaxonomyService) List(ctx context.Context) ([]model.Topic, error) {
+	return s.repo.List(ctx)
+}
+
+// Normalize maps every unaliased topic key to a canonical topic and rewrites summaries.
+// A key first tries the existing topics lexically (same key or acronym), then by the
+// similarity of item-embedding centroids, and otherwise becomes a topic of its own.
+func (s *TopicTaxonomyService) Normalize(ctx context.Context) (*model.TopicNormalizationResult, error) {
+	raws, err := s.repo.RawTopicCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := GroupTopicsByKey(raws)
+	t, err := s.loadTaxonomy(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	result := &model.TopicNormalizationResult{RawTopics: len(raws)}
+
+	for _, g := range groups {
+		if _, ok := t.topicByAlias[g.Key]; ok {
+			continue
+		}
+		if topicID, ok := t.lexicalMatch(g.Key); ok {
+			if err := s.repo.AddAlias(ctx, g.Key, topicID, TopicAliasSourceLexical, nil); err != nil {
+				return nil, err
+			}
+			t.attach(g, topicID)
+			result.LexicalAliases++
+			continue
+		}
+		if g.Count >= topicEmbeddingMinItems {
+			topicID, sim, err := s.embeddingMatch(ctx, t, g)
+			if err != nil {
+				return nil, err
+			}
+			if topicID != "" {
+				if err := s.repo.AddAlias(ctx, g.Key, topicID, TopicAliasSourceEmbedding, &sim); err != nil {
+					return nil, err
+				}
+				t.attach(g, topicID)
+				result.EmbeddingAliases++
+				continue
+			}
+		}
+		topicID, err := s.repo.Create(ctx, g.Name, g.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.AddAlias(ctx, g.Key, topicID, TopicAliasSourceLexical, nil); err != nil {
+			return nil, err
+		}
+		t.addTopic(topicID, g.Name, g.Key)
+		t.attach(g, topicID)
+		result.CreatedTopics++
+	}
+
+	rewritten, err := s.rewrite(ctx, raws, t, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.RewrittenSummaries = rewritten
+	return result, nil
+}
+
+// Merge folds source into target and rewrites the summaries that used source.
+func (s *TopicTaxonomyService) Merge(ctx context.Context, sourceID, targetID string) (int64, error) {
+	if sourceID == targetID {
+		return 0, repository.ErrInvalidState
+	}
+	sourceName, err := s.topicName(ctx, sourceID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.repo.Merge(ctx, sourceID, targetID); err != nil {
+		return 0, err
+	}
+	return s.rewriteAffected(ctx, []string{sourceName})
+}
+
+// Split moves the given alias keys of a topic to a new topic named name.
+func (s *TopicTaxonomyService) Split(ctx context.Context, topicID, name string, aliases []string) (string, int64, error) {
+	name = strings.TrimSpace(name)
+	key := TopicKey(name)
+	if key == "" || len(aliases) == 0 {
+		return "", 0, repository.ErrInvalidState
+	}
+	oldName, err := s.topicName(ctx, topicID)
+	if err != nil {
+		return "", 0, err
+	}
+	keys := make([]string, 0, len(aliases))
+	seen := map[string]struct{}{}
+	for _, a := range aliases {
+		if a = strings.TrimSpace(a); a == "" {
+			continue
+		}
+		if _, dup := seen[a]; dup {
+			continue
+		}
+		seen[a] = struct{}{}
+		keys = append(keys, a)
+	}
+	newID, err := s.repo.Split(ctx, topicID, name, key, keys)
+	if err != nil {
+		return "", 0, err
+	}
+	rewritten, err := s.rewriteAffected(ctx, []string{oldName})
+	if err != nil {
+		return "", 0, err
+	}
+	return newID, rewritten, nil
+}
+
+// NormalizeItem applies the current aliases to one item's freshly written topics.
+func (s *TopicTaxonomyService) NormalizeItem(ctx context.Context, itemID string) error {
+	topics, err := s.repo.SummaryTopics(ctx, itemID)
+	if err != nil || len(topics) == 0 {
+		return err
+	}
+	keys := make([]string, 0, len(topics))
+	for _, t := range topics {
+		keys = append(keys, TopicKey(t))
+	}
+	names, err := s.repo.CanonicalNames(ctx, keys)
+	if err != nil {
+		return err
+	}
+	normalized := CanonicalizeTopics(topics, names)
+	if equalStrings(normalized, topics) {
+		return nil
+	}
+	return s.repo.SetSummaryTopics(ctx, itemID, normalized)
+}
+
+func (s *TopicTaxonomyService) topicName(ctx context.Context, topicID string) (string, error) {
+	topics, err := s.repo.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range topics {
+		if t.ID == topicID {
+			return t.Name, nil
+		}
+	}
+	return "", repository.ErrNotFound
+}
+
+func (s *TopicTaxonomyService) rewriteAffected(ctx context.Context, affected []string) (int64, error) {
+	raws, err := s.repo.RawTopicCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t, err := s.loadTaxonomy(ctx, GroupTopicsByKey(raws))
+	if err != nil {
+		return 0, err
+	}
+	return s.rewrite(ctx, raws, t, affected)
+}
+
+// rewrite maps every raw label to its canonical name. Summaries still carrying a raw label
+// that differs from its canonical name are always rewritten, plus those with extra labels.
+func (s *TopicTaxonomyService) rewrite(ctx context.Context, raws []model.TopicRawCount, t *topicTaxonomy, extra []string) (int64, error) {
+	var rawNames, canonicalNames []string
+	affected := append([]string{}, extra...)
+	for _, raw := range raws {
+		topicID, ok := t.topicByAlias[TopicKey(raw.Name)]
+		if !ok {
+			continue
+		}
+		name := t.names[topicID]
+		if name == "" || name == raw.Name {
+			continue
+		}
+		rawNames = append(rawNames, raw.Name)
+		canonicalNames = append(canonicalNames, name)
+		affected = append(affected, raw.Name)
+	}
+	return s.repo.RewriteSummaryTopics(ctx, rawNames, canonicalNames, affected)
+}
+
+func (s *TopicTaxonomyService) embeddingMatch(ctx context.Context, t *topicTaxonomy, g TopicGroup) (string, float64, error) {
+	centroid, n, err := s.repo.TopicCentroid(ctx, g.Names, topicCentroidSampleSize)
+	if err != nil || n < topicEmbeddingMinItems {
+		return "", 0, err
+	}
+	bestID, bestSim := "", 0.0
+	for _, topicID := range t.embeddingCandidates() {
+		c, ok := t.centroids[topicID]
+		if !ok {
+			c, _, err = s.repo.TopicCentroid(ctx, t.rawNames[topicID], topicCentroidSampleSize)
+			if err != nil {
+				return "", 0, err
+			}
+			t.centroids[topicID] = c
+		}
+		if sim := topicCosine(centroid, c); sim > bestSim {
+			bestID, bestSim = topicID, sim
+		}
+	}
+	if bestSim < topicEmbeddingMinSimilarity {
+		return "", 0, nil
+	}
+	return bestID, bestSim, nil
+}
+
+type topicTaxonomy struct {
+	topicByAlias map[string]string
+	names        map[string]string
+	byKey        map[string]string
+	byAcronym    map[string]string
+	rawNames     map[string][]string
+	counts       map[string]int
+	centroids    map[string][]float64
+}
+
+func (s *TopicTaxonomyService) loadTaxonomy(ctx context.Context, groups []TopicGroup) (*topicTaxonomy, error) {
+	topics, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := &topicTaxonomy{
+		topicByAlias: map[string]string{},
+		names:        map[string]string{},
+		byKey:        map[string]string{},
+		byAcronym:    map[string]string{},
+		rawNames:     map[string][]string{},
+		counts:       map[string]int{},
+		centroids:    map[string][]float64{},
+	}
+	for _, topic := range topics {
+		t.addTopic(topic.ID, topic.Name, TopicKey(topic.Name))
+		for _, a := range topic.Aliases {
+			t.topicByAlias[a.Alias] = topic.ID
+		}
+	}
+	for _, g := range groups {
+		if topicID, ok := t.topicByAlias[g.Key]; ok {
+			t.attach(g, topicID)
+		}
+	}
+	return t, nil
+}
+
+func (t *topicTaxonomy) addTopic(id, name, key string) {
+	t.names[id] = name
+	t.byKey[key] = id
+	if acronym := topicAcronym(key); acronym != "" {
+		if _, taken := t.byAcronym[acronym]; !taken {
+			t.byAcronym[acronym] = id
+		}
+	}
+}
+
+func (t *topicTaxonomy) attach(g TopicGroup, topicID string) {
+	t.topicByAlias[g.Key] = topicID
+	t.rawNames[topicID] = append(t.rawNames[topicID], g.Names...)
+	t.counts[topicID] += g.Count
+	delete(t.centroids, topicID)
+}
+
+func (t *topicTaxonomy) lexicalMatch(key string) (string, bool) {
+	if id, ok := t.byKey[key]; ok {
+		return id, true
+	}
+	if id, ok := t.byAcronym[key]; ok {
+		return id, true
+	}
+	if acronym := topicAcronym(key); acronym != "" {
+		if id, ok := t.byKey[acronym]; ok {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// embeddingCandidates lists the most used topics that have enough items for a centroid.
+func (t *topicTaxonomy) embeddingCandidates() []string {
+	ids := make([]string, 0, len(t.counts))
+	for id, n := range t.counts {
+		if n >= topicEmbeddingMinItems {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if t.counts[ids[i]] != t.counts[ids[j]] {
+			return t.counts[ids[i]] > t.counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > maxTopicEmbeddingCandidates {
+		ids = ids[:maxTopicEmbeddingCandidates]
+	}
+	return ids
+}
+
+func topicCosine(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestTopicKey(t *testing.T) {
+	cases := map[string]string{
+		"LLM":                   "llm",
+		"LLMs":                  "llm",
+		"ＬＬＭ":                   "llm",
+		"Large Language Models": "large language model",
+		"large-language_model":  "large language model",
+		"Kubernetes":            "kubernete",
+		"business":              "business",
+		"Libraries":             "library",
+		"生成AI":                  "生成ai",
+		"  ":                    "",
+	}
+	for in, want := range cases {
+		if got := TopicKey(in); got != want {
+			t.Errorf("TopicKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTopicAcronym(t *testing.T) {
+	if got := topicAcronym("large language model"); got != "llm" {
+		t.Fatalf("acronym = %q, want llm", got)
+	}
+	if got := topicAcronym("return of the jedi"); got != "rj" {
+		t.Fatalf("acronym with stopwords = %q, want rj", got)
+	}
+	if got := topicAcronym("llm"); got != "" {
+		t.Fatalf("single word acronym = %q, want empty", got)
+	}
+}
+
+func TestGroupTopicsByKey(t *testing.T) {
+	groups := GroupTopicsByKey([]model.TopicRawCount{
+		{Name: "LLM", Count: 5},
+		{Name: "LLMs", Count: 2},
+		{Name: "Rust", Count: 4},
+		{Name: "llm", Count: 1},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("len = %d, want 2", len(groups))
+	}
+	if groups[0].Key != "llm" || groups[0].Name != "LLM" || groups[0].Count != 8 || len(groups[0].Names) != 3 {
+		t.Errorf("first group = %+v", groups[0])
+	}
+	if groups[1].Key != "rust" {
+		t.Errorf("second group = %+v", groups[1])
+	}
+}
+
+func TestCanonicalizeTopics(t *testing.T) {
+	got := CanonicalizeTopics([]string{"LLMs", "Rust", "large language models", "LLM"}, map[string]string{
+		"llm":                  "LLM",
+		"large language model": "LLM",
+	})
+	if want := []string{"LLM", "Rust"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("CanonicalizeTopics = %v, want %v", got, want)
+	}
+}
+
+func TestTopicTaxonomyLexicalMatch(t *testing.T) {
+	tax := &topicTaxonomy{byKey: map[string]string{}, byAcronym: map[string]string{}, names: map[string]string{}}
+	tax.addTopic("t1", "LLM", "llm")
+	tax.addTopic("t2", "Machine Learning", "machine learning")
+
+	if id, ok := tax.lexicalMatch("large language model"); !ok || id != "t1" {
+		t.Errorf("expanded phrase should match acronym topic, got %q %v", id, ok)
+	}
+	if id, ok := tax.lexicalMatch("ml"); !ok || id != "t2" {
+		t.Errorf("acronym should match expanded topic, got %q %v", id, ok)
+	}
+	if _, ok := tax.lexicalMatch("rust"); ok {
+		t.Errorf("unrelated key should not match")
+	}
+}
+
+func TestTopicCosine(t *testing.T) {
+	if got := topicCosine([]float64{1, 0}, []float64{1, 0}); got < 0.999 {
+		t.Fatalf("cosine = %v", got)
+	}
+	if got := topicCosine([]float64{1, 0}, []float64{1, 0, 0}); got != 0 {
+		t.Fatalf("mismatched dims cosine = %v, want 0", got)
+	}
+}
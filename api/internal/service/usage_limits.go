@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+	"github.com/enjoydarts/sifto/api/internal/timeutil"
+)
+
+const (
+	UserPlanFree      = "free"
+	UserPlanPro       = "pro"
+	UserPlanUnlimited = "unlimited"
+
+	UsageLimitSources        = "max_sources"
+	UsageLimitLLMItemsPerDay = "max_llm_items_per_day"
+)
+
+var defaultUsageLimits = map[string]model.UsageLimits{
+	UserPlanFree:      {MaxSources: 500, MaxLLMItemsPerDay: 1000},
+	UserPlanPro:       {MaxSources: 2000, MaxLLMItemsPerDay: 5000},
+	UserPlanUnlimited: {},
+}
+
+func IsUserPlan(plan string) bool {
+	_, ok := defaultUsageLimits[plan]
+	return ok
+}
+
+// UsageLimitsForPlan returns the plan's limits, overridable per plan with
+// USAGE_LIMIT_<PLAN>_MAX_SOURCES and USAGE_LIMIT_<PLAN>_MAX_LLM_ITEMS_PER_DAY (0 turns a limit
+// off). Unknown plans get the free plan's limits.
+func UsageLimitsForPlan(plan string) model.UsageLimits {
+	if !IsUserPlan(plan) {
+		plan = UserPlanFree
+	}
+	limits := defaultUsageLimits[plan]
+	prefix := "USAGE_LIMIT_" + strings.ToUpper(plan) + "_"
+	if v, ok := usageLimitFromEnv(prefix + "MAX_SOURCES"); ok {
+		limits.MaxSources = v
+	}
+	if v, ok := usageLimitFromEnv(prefix + "MAX_LLM_ITEMS_PER_DAY"); ok {
+		limits.MaxLLMItemsPerDay = v
+	}
+	return limits
+}
+
+func usageLimitFromEnv(key string) (int, bool) {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// UsageLimitError reports that an action would go past one of the plan's limits.
+type UsageLimitError struct {
+	Limit   string
+	Plan    string
+	Max     int
+	Current int
+}
+
+func (e *UsageLimitError) Error() string {
+	return fmt.Sprintf("%s limit of the %s plan reached (%d/%d)", e.Limit, e.Plan, e.Current, e.Max)
+}
+
+// usageRemaining is how much of limit is left after used, or -1 when the limit is off.
+func usageRemaining(limit, used int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return max(limit-used, 0)
+}
+
+type usageLimitRepo interface {
+	UsageByUsers(ctx context.Context, userIDs []string, since time.Time) (map[string]repository.UserPlanUsage, error)
+	SetPlan(ctx context.Context, userID, plan string) error
+}
+
+// UsageLimitService applies per-plan soft limits: new sources are refused past the source
+// limit, and once the LLM item limit is used up feeds stop producing items and process-item
+// defers the rest, however they were added, until the JST day rolls over. Existing sources and
+// items are never removed.
+type UsageLimitService struct {
+	repo usageLimitRepo
+	now  func() time.Time
+}
+
+func NewUsageLimitService(repo usageLimitRepo) *UsageLimitService {
+	return &UsageLimitService{repo: repo, now: timeutil.NowJST}
+}
+
+func (s *UsageLimitService) dayStart() time.Time {
+	return timeutil.StartOfDayJST(s.now())
+}
+
+func (s *UsageLimitService) usage(ctx context.Context, userID string) (repository.UserPlanUsage, error) {
+	byUser, err := s.repo.UsageByUsers(ctx, []string{userID}, s.dayStart())
+	if err != nil {
+		return repository.UserPlanUsage{}, err
+	}
+	u, ok := byUser[userID]
+	if !ok {
+		return repository.UserPlanUsage{}, repository.ErrNotFound
+	}
+	return u, nil
+}
+
+func (s *UsageLimitService) Status(ctx context.Context, userID string) (*model.UsageLimitStatus, error) {
+	u, err := s.usage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	limits := UsageLimitsForPlan(u.Plan)
+	out := &model.UsageLimitStatus{
+		Plan:          u.Plan,
+		Limits:        limits,
+		Sources:       u.Sources,
+		LLMItemsToday: u.LLMItems,
+		ResetsAt:      s.dayStart().AddDate(0, 0, 1),
+	}
+	if n := usageRemaining(limits.MaxSources, u.Sources); n >= 0 {
+		out.SourcesRemaining = &n
+	}
+	if n := usageRemaining(limits.MaxLLMItemsPerDay, u.LLMItems); n >= 0 {
+		out.LLMItemsRemaining = &n
+	}
+	return out, nil
+}
+
+// SetPlan moves the user to another plan and returns their status under it.
+func (s *UsageLimitService) SetPlan(ctx context.Context, userID, plan string) (*model.UsageLimitStatus, error) {
+	if !IsUserPlan(plan) {
+		return nil, repository.ErrInvalidState
+	}
+	if err := s.repo.SetPlan(ctx, userID, plan); err != nil {
+		return nil, err
+	}
+	return s.Status(ctx, userID)
+}
+
+// SourceCapacity is how many more sources the user may add, or -1 without a limit.
+func (s *UsageLimitService) SourceCapacity(ctx context.Context, userID string) (int, error) {
+	u, err := s.usage(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return usageRemaining(UsageLimitsForPlan(u.Plan).MaxSources, u.Sources), nil
+}
+
+// CheckSourceCapacity returns a *UsageLimitError when the user cannot add any more sources.
+func (s *UsageLimitService) CheckSourceCapacity(ctx context.Context, userID string) error {
+	u, err := s.usage(ctx, userID)
+	if err != nil {
+		return err
+	}
+	limits := UsageLimitsForPlan(u.Plan)
+	if usageRemaining(limits.MaxSources, u.Sources) == 0 {
+		return &UsageLimitError{Limit: UsageLimitSources, Plan: u.Plan, Max: limits.MaxSources, Current: u.Sources}
+	}
+	return nil
+}
+
+// LLMItemAllowances is how many more items each user may put through the LLM today (JST), or
+// -1 without a limit. Users missing from the result have no row and get no allowance.
+func (s *UsageLimitService) LLMItemAllowances(ctx context.Context, userIDs []string) (map[string]int, error) {
+	byUser, err := s.repo.UsageByUsers(ctx, userIDs, s.dayStart())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]int, len(byUser))
+	for userID, u := range byUser {
+		out[userID] = usageRemaining(UsageLimitsForPlan(u.Plan).MaxLLMItemsPerDay, u.LLMItems)
+	}
+	return out, nil
+}
+
+// LLMItemAllowance is LLMItemAllowances for one user. A user without a row gets no allowance.
+func (s *UsageLimitService) LLMItemAllowance(ctx context.Context, userID string) (int, error) {
+	allowances, err := s.LLMItemAllowances(ctx, []string{userID})
+	if err != nil {
+		return 0, err
+	}
+	return allowances[userID], nil
+}
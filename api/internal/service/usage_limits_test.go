@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeUsageLimitRepo struct {
+	usage map[string]repository.UserPlanUsage
+	since time.Time
+}
+
+func (f *fakeUsageLimitRepo) UsageByUsers(_ context.Context, userIDs []string, since time.Time) (map[string]repository.UserPlanUsage, error) {
+	f.since = since
+	out := map[string]repository.UserPlanUsage{}
+	for _, id := range userIDs {
+		if u, ok := f.usage[id]; ok {
+			out[id] = u
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeUsageLimitRepo) SetPlan(_ context.Context, userID, plan string) error {
+	u, ok := f.usage[userID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.Plan = plan
+	f.usage[userID] = u
+	return nil
+}
+
+func TestUsageLimitsForPlan(t *testing.T) {
+	t.Setenv("USAGE_LIMIT_PRO_MAX_SOURCES", "3")
+	t.Setenv("USAGE_LIMIT_PRO_MAX_LLM_ITEMS_PER_DAY", "oops")
+	if got := UsageLimitsForPlan(UserPlanPro); got.MaxSources != 3 || got.MaxLLMItemsPerDay != 5000 {
+		t.Fatalf("pro = %+v", got)
+	}
+	if got := UsageLimitsForPlan("enterprise"); got != defaultUsageLimits[UserPlanFree] {
+		t.Fatalf("unknown plan = %+v", got)
+	}
+	if got := UsageLimitsForPlan(UserPlanUnlimited); got.MaxSources != 0 || got.MaxLLMItemsPerDay != 0 {
+		t.Fatalf("unlimited = %+v", got)
+	}
+}
+
+func TestUsageLimitService(t *testing.T) {
+	t.Setenv("USAGE_LIMIT_FREE_MAX_SOURCES", "2")
+	t.Setenv("USAGE_LIMIT_FREE_MAX_LLM_ITEMS_PER_DAY", "10")
+	repo := &fakeUsageLimitRepo{usage: map[string]repository.UserPlanUsage{
+		"full":      {Plan: UserPlanFree, Sources: 2, LLMItems: 12},
+		"room":      {Plan: UserPlanFree, Sources: 1, LLMItems: 4},
+		"unlimited": {Plan: UserPlanUnlimited, Sources: 9000, LLMItems: 9000},
+	}}
+	svc := NewUsageLimitService(repo)
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 15, 30, 0, 0, time.UTC) }
+
+	var limitErr *UsageLimitError
+	if err := svc.CheckSourceCapacity(context.Background(), "full"); !errors.As(err, &limitErr) || limitErr.Limit != UsageLimitSources || limitErr.Max != 2 {
+		t.Fatalf("full err = %v", err)
+	}
+	for _, id := range []string{"room", "unlimited"} {
+		if err := svc.CheckSourceCapacity(context.Background(), id); err != nil {
+			t.Fatalf("%s err = %v", id, err)
+		}
+	}
+	if err := svc.CheckSourceCapacity(context.Background(), "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("missing err = %v", err)
+	}
+
+	allowances, err := svc.LLMItemAllowances(context.Background(), []string{"full", "room", "unlimited", "missing"})
+	if err != nil {
+		t.Fatalf("LLMItemAllowances: %v", err)
+	}
+	if len(allowances) != 3 || allowances["full"] != 0 || allowances["room"] != 6 || allowances["unlimited"] != -1 {
+		t.Fatalf("allowances = %v", allowances)
+	}
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60)); !repo.since.Equal(want) {
+		t.Fatalf("since = %v, want JST day start %v", repo.since, want)
+	}
+	for id, want := range map[string]int{"full": 0, "room": 6, "unlimited": -1, "missing": 0} {
+		if got, err := svc.LLMItemAllowance(context.Background(), id); err != nil || got != want {
+			t.Fatalf("LLMItemAllowance(%s) = %d, %v, want %d", id, got, err, want)
+		}
+	}
+
+	status, err := svc.SetPlan(context.Background(), "full", UserPlanUnlimited)
+	if err != nil {
+		t.Fatalf("SetPlan: %v", err)
+	}
+	if status.Plan != UserPlanUnlimited || status.SourcesRemaining != nil || status.LLMItemsRemaining != nil {
+		t.Fatalf("status = %+v", status)
+	}
+	status, err = svc.Status(context.Background(), "room")
+	if err != nil || *status.SourcesRemaining != 1 || *status.LLMItemsRemaining != 6 {
+		t.Fatalf("room status = %+v, %v", status, err)
+	}
+}
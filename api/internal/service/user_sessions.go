@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+const (
+	UserSessionKindBrowser    = "browser"
+	UserSessionKindDigestFeed = "digest_feed"
+
+	// UserSessionDigestFeedID is the session ID under which the digest feed token is listed.
+	UserSessionDigestFeedID = "digest_feed"
+
+	userSessionProviderClerk = "clerk"
+)
+
+const (
+	// userSessionCacheTTL bounds how often a session's last use is written and how long an
+	// instance can keep accepting a session revoked elsewhere when the cache write failed.
+	userSessionCacheTTL    = time.Minute
+	userSessionActiveSince = 30 * 24 * time.Hour
+)
+
+type userSessionRepo interface {
+	Touch(ctx context.Context, userID, provider, sessionID, ipAddress, userAgent string) (bool, error)
+	ListActive(ctx context.Context, userID string, usedSince time.Time) ([]repository.ActiveUserSession, error)
+	Revoke(ctx context.Context, userID, id string) (string, error)
+}
+
+type userSessionFeedTokens interface {
+	GetToken(ctx context.Context, userID string) (*string, error)
+	RevokeToken(ctx context.Context, userID string) error
+}
+
+type clerkSessionRevoker interface {
+	RevokeSession(ctx context.Context, sessionID string) error
+}
+
+type userSessionCacheEntry struct {
+	UserID  string `json:"user_id"`
+	Revoked bool   `json:"revoked"`
+}
+
+// UserSessionService tracks the sign-in sessions that call the API so users can see them and
+// revoke API access per session. Validation results are cached for a minute, which also
+// throttles last-used writes; revocation overwrites the cached result right away and, with a
+// Clerk revoker set, ends the session at Clerk too.
+type UserSessionService struct {
+	repo       userSessionRepo
+	feedTokens userSessionFeedTokens
+	cache      JSONCache
+	clerk      clerkSessionRevoker
+	now        func() time.Time
+}
+
+func NewUserSessionService(repo userSessionRepo, feedTokens userSessionFeedTokens, cache JSONCache) *UserSessionService {
+	if cache == nil {
+		cache = NoopJSONCache{}
+	}
+	return &UserSessionService{repo: repo, feedTokens: feedTokens, cache: cache, now: time.Now}
+}
+
+func (s *UserSessionService) WithClerkRevoker(clerk *ClerkSessionRevoker) *UserSessionService {
+	if clerk != nil {
+		s.clerk = clerk
+	}
+	return s
+}
+
+func userSessionCacheKey(sessionID string) string {
+	return "auth:session:v1:" + userSessionProviderClerk + ":" + sessionID
+}
+
+// Validate records a use of the session and reports whether it may still be used.
+func (s *UserSessionService) Validate(ctx context.Context, userID, sessionID, ipAddress, userAgent string) (bool, error) {
+	key := userSessionCacheKey(sessionID)
+	var cached userSessionCacheEntry
+	if ok, err := s.cache.GetJSON(ctx, key, &cached); err == nil && ok && cached.UserID == userID {
+		return !cached.Revoked, nil
+	} else if err != nil {
+		log.Printf("session cache get failed user_id=%s err=%v", userID, err)
+	}
+	if r := []rune(userAgent); len(r) > auditLogUserAgentMaxRunes {
+		userAgent = string(r[:auditLogUserAgentMaxRunes])
+	}
+	active, err := s.repo.Touch(ctx, userID, userSessionProviderClerk, sessionID, strings.TrimSpace(ipAddress), strings.TrimSpace(userAgent))
+	if err != nil {
+		return false, err
+	}
+	if err := s.cache.SetJSON(ctx, key, userSessionCacheEntry{UserID: userID, Revoked: !active}, userSessionCacheTTL); err != nil {
+		log.Printf("session cache set failed user_id=%s err=%v", userID, err)
+	}
+	return active, nil
+}
+
+// List returns the user's sessions used in the last 30 days, newest first, followed by the
+// digest feed token when one is issued. currentSessionID marks the caller's session.
+func (s *UserSessionService) List(ctx context.Context, userID, currentSessionID string) ([]model.UserSession, error) {
+	active, err := s.repo.ListActive(ctx, userID, s.now().Add(-userSessionActiveSince))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.UserSession, 0, len(active)+1)
+	for _, a := range active {
+		session := a.Session
+		session.Kind = UserSessionKindBrowser
+		session.Current = currentSessionID != "" && a.SessionID == currentSessionID
+		out = append(out, session)
+	}
+	if s.feedTokens != nil {
+		token, err := s.feedTokens.GetToken(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil && strings.TrimSpace(*token) != "" {
+			out = append(out, model.UserSession{ID: UserSessionDigestFeedID, Kind: UserSessionKindDigestFeed})
+		}
+	}
+	return out, nil
+}
+
+// Revoke ends API access for one session, or revokes the digest feed token for its ID. It
+// returns the kind of what was revoked.
+func (s *UserSessionService) Revoke(ctx context.Context, userID, id string) (string, error) {
+	if id == UserSessionDigestFeedID {
+		if s.feedTokens == nil {
+			return "", repository.ErrNotFound
+		}
+		return UserSessionKindDigestFeed, s.feedTokens.RevokeToken(ctx, userID)
+	}
+	sessionID, err := s.repo.Revoke(ctx, userID, id)
+	if err != nil {
+		return "", err
+	}
+	if err := s.cache.SetJSON(ctx, userSessionCacheKey(sessionID), userSessionCacheEntry{UserID: userID, Revoked: true}, userSessionCacheTTL); err != nil {
+		log.Printf("session cache set failed user_id=%s err=%v", userID, err)
+	}
+	// The session is already refused here; a failed Clerk call only leaves the web app signed in
+	// until its token expires.
+	if s.clerk != nil {
+		if err := s.clerk.RevokeSession(ctx, sessionID); err != nil {
+			log.Printf("clerk session revoke failed user_id=%s err=%v", userID, err)
+		}
+	}
+	return UserSessionKindBrowser, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type fakeUserSessionRepo struct {
+	touches int
+	revoked map[string]bool
+	active  []repository.ActiveUserSession
+}
+
+func (f *fakeUserSessionRepo) Touch(_ context.Context, _, _, sessionID, _, _ string) (bool, error) {
+	f.touches++
+	return !f.revoked[sessionID], nil
+}
+
+func (f *fakeUserSessionRepo) ListActive(context.Context, string, time.Time) ([]repository.ActiveUserSession, error) {
+	return f.active, nil
+}
+
+func (f *fakeUserSessionRepo) Revoke(_ context.Context, _, id string) (string, error) {
+	for _, a := range f.active {
+		if a.Session.ID == id {
+			f.revoked[a.SessionID] = true
+			return a.SessionID, nil
+		}
+	}
+	return "", repository.ErrNotFound
+}
+
+type fakeClerkSessionRevoker struct{ revoked []string }
+
+func (f *fakeClerkSessionRevoker) RevokeSession(_ context.Context, sessionID string) error {
+	f.revoked = append(f.revoked, sessionID)
+	return nil
+}
+
+type fakeSessionFeedTokens struct{ token *string }
+
+func (f *fakeSessionFeedTokens) GetToken(context.Context, string) (*string, error) {
+	return f.token, nil
+}
+
+func (f *fakeSessionFeedTokens) RevokeToken(context.Context, string) error {
+	f.token = nil
+	return nil
+}
+
+func TestUserSessionServiceValidateCachesAndRevokes(t *testing.T) {
+	repo := &fakeUserSessionRepo{
+		revoked: map[string]bool{},
+		active:  []repository.ActiveUserSession{{Session: model.UserSession{ID: "row-1"}, SessionID: "sess_1"}},
+	}
+	svc := NewUserSessionService(repo, nil, &memoryJSONCache{})
+	clerk := &fakeClerkSessionRevoker{}
+	svc.clerk = clerk
+
+	for range 3 {
+		ok, err := svc.Validate(context.Background(), "u1", "sess_1", "203.0.113.7", "test")
+		if err != nil || !ok {
+			t.Fatalf("Validate = %v, %v", ok, err)
+		}
+	}
+	if repo.touches != 1 {
+		t.Fatalf("touches = %d, want 1 while cached", repo.touches)
+	}
+
+	if kind, err := svc.Revoke(context.Background(), "u1", "row-1"); err != nil || kind != UserSessionKindBrowser {
+		t.Fatalf("Revoke = %q, %v", kind, err)
+	}
+	if len(clerk.revoked) != 1 || clerk.revoked[0] != "sess_1" {
+		t.Fatalf("clerk revoked = %v", clerk.revoked)
+	}
+	if ok, _ := svc.Validate(context.Background(), "u1", "sess_1", "", ""); ok {
+		t.Fatal("revoked session still validates")
+	}
+	if ok, _ := svc.Validate(context.Background(), "u2", "sess_1", "", ""); ok {
+		t.Fatal("session validates for another user")
+	}
+	if _, err := svc.Revoke(context.Background(), "u1", "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("Revoke missing err = %v", err)
+	}
+}
+
+func TestUserSessionServiceList(t *testing.T) {
+	token := "df_abc"
+	feed := &fakeSessionFeedTokens{token: &token}
+	repo := &fakeUserSessionRepo{active: []repository.ActiveUserSession{
+		{Session: model.UserSession{ID: "row-1"}, SessionID: "sess_1"},
+		{Session: model.UserSession{ID: "row-2"}, SessionID: "sess_2"},
+	}}
+	svc := NewUserSessionService(repo, feed, nil)
+
+	sessions, err := svc.List(context.Background(), "u1", "sess_2")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(sessions) != 3 || sessions[0].Current || !sessions[1].Current || sessions[2].Kind != UserSessionKindDigestFeed {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+
+	if kind, err := svc.Revoke(context.Background(), "u1", UserSessionDigestFeedID); err != nil || kind != UserSessionKindDigestFeed || feed.token != nil {
+		t.Fatalf("Revoke feed = %q, %v, token %v", kind, err, feed.token)
+	}
+	if sessions, _ := svc.List(context.Background(), "u1", ""); len(sessions) != 2 {
+		t.Fatalf("sessions after feed revoke = %+v", sessions)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"net/mail"
+	"net/netip"
+	"strings"
+
+	"github.com/enjoydarts/sifto/api/internal/repository"
+)
+
+type UserSMTPSettingsView struct {
+	Configured  bool    `json:"configured"`
+	Host        string  `json:"host,omitempty"`
+	Port        int     `json:"port,omitempty"`
+	Username    *string `json:"username,omitempty"`
+	HasPassword bool    `json:"has_password"`
+	FromEmail   string  `json:"from_email,omitempty"`
+	FromName    *string `json:"from_name,omitempty"`
+}
+
+type UserSMTPSettingsInput struct {
+	Host      string  `json:"host"`
+	Port      int     `json:"port"`
+	Username  *string `json:"username"`
+	Password  *string `json:"password"`
+	FromEmail string  `json:"from_email"`
+	FromName  *string `json:"from_name"`
+}
+
+type UserSMTPSettingsService struct {
+	repo        *repository.UserSettingsRepo
+	cipher      *SecretCipher
+	resolveHost func(ctx context.Context, host string) ([]netip.Addr, error)
+}
+
+func NewUserSMTPSettingsService(repo *repository.UserSettingsRepo, cipher *SecretCipher) *UserSMTPSettingsService {
+	return &UserSMTPSettingsService{repo: repo, cipher: cipher, resolveHost: ResolvePublicHost}
+}
+
+func (s *UserSMTPSettingsService) Get(ctx context.Context, userID string) (*UserSMTPSettingsView, error) {
+	stored, err := s.repo.GetSMTPSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return userSMTPSettingsView(stored), nil
+}
+
+// Set stores the user's SMTP server. A nil password keeps the stored one so the
+// client can edit other fields without resending it; an empty string clears it. Hosts that
+// resolve to loopback, private, link-local or metadata addresses are refused; sending checks
+// again, since DNS can change after saving.
+func (s *UserSMTPSettingsService) Set(ctx context.Context, userID string, in UserSMTPSettingsInput) (*UserSMTPSettingsView, error) {
+	next, err := normalizeUserSMTPSettingsInput(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.resolveHost(ctx, next.Host); err != nil {
+		return nil, &ValidationError{Field: "host", Message: "host must resolve to a public address"}
+	}
+	switch {
+	case in.Password == nil:
+		current, err := s.repo.GetSMTPSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil {
+			next.PasswordEnc = current.PasswordEnc
+		}
+	case *in.Password != "":
+		if s.cipher == nil || !s.cipher.Enabled() {
+			return nil, ErrSecretEncryptionNotConfigured
+		}
+		enc, err := s.cipher.EncryptString(*in.Password)
+		if err != nil {
+			return nil, err
+		}
+		next.PasswordEnc = &enc
+	}
+	if err := s.repo.SetSMTPSettings(ctx, userID, next); err != nil {
+		return nil, err
+	}
+	return userSMTPSettingsView(&next), nil
+}
+
+func (s *UserSMTPSettingsService) Clear(ctx context.Context, userID string) error {
+	return s.repo.ClearSMTPSettings(ctx, userID)
+}
+
+func normalizeUserSMTPSettingsInput(in UserSMTPSettingsInput) (repository.UserSMTPSettings, error) {
+	out := repository.UserSMTPSettings{
+		Host:      strings.TrimSpace(in.Host),
+		Port:      in.Port,
+		FromEmail: strings.TrimSpace(in.FromEmail),
+		Username:  trimmedOptional(in.Username),
+		FromName:  trimmedOptional(in.FromName),
+	}
+	if out.Host == "" {
+		return out, &ValidationError{Field: "host", Message: "host is required"}
+	}
+	if strings.ContainsAny(out.Host, " /:") {
+		return out, &ValidationError{Field: "host", Message: "host must be a hostname without scheme or port"}
+	}
+	if out.Port == 0 {
+		out.Port = defaultSMTPPort
+	}
+	if out.Port < 1 || out.Port > 65535 {
+		return out, &ValidationError{Field: "port", Message: "port must be between 1 and 65535"}
+	}
+	addr, err := mail.ParseAddress(out.FromEmail)
+	if err != nil || addr.Address != out.FromEmail {
+		return out, &ValidationError{Field: "from_email", Message: "from_email must be a plain email address"}
+	}
+	return out, nil
+}
+
+func trimmedOptional(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*v)
+	if t == "" {
+		return nil
+	}
+	return &t
+}
+
+func userSMTPSettingsView(stored *repository.UserSMTPSettings) *UserSMTPSettingsView {
+	if stored == nil {
+		return &UserSMTPSettingsView{}
+	}
+	return &UserSMTPSettingsView{
+		Configured:  true,
+		Host:        stored.Host,
+		Port:        stored.Port,
+		Username:    stored.Username,
+		HasPassword: stored.PasswordEnc != nil && *stored.PasswordEnc != "",
+		FromEmail:   stored.FromEmail,
+		FromName:    stored.FromName,
+	}
+}
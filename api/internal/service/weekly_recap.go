@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+const (
+	weeklyRecapHeavyReads  = 50
+	weeklyRecapSteadyReads = 15
+)
+
+type WeeklyRecapEmail struct {
+	Locale           string
+	Stats            model.WeeklyRecapStats
+	WeekCostUSD      float64
+	MonthCostUSD     float64
+	MonthlyBudgetUSD *float64
+	PageURL          string
+}
+
+func SendWeeklyRecapEmail(ctx context.Context, sender EmailSender, to string, recap WeeklyRecapEmail) error {
+	if sender == nil || !sender.Enabled() {
+		log.Printf("email sender disabled, skip weekly recap to %s", to)
+		return nil
+	}
+	strs := emailStringsFor(recap.Locale)
+	return sender.Send(ctx, EmailMessage{
+		To:      to,
+		Subject: fmt.Sprintf(strs.WeeklyRecapSubject, weeklyRecapDateLabel(recap.Stats.WeekStart), weeklyRecapDateLabel(recap.Stats.WeekEnd)),
+		HTML:    buildWeeklyRecapHTML(recap),
+	})
+}
+
+// weeklyRecapLead picks the opening line by how much was read, so a quiet week gets a
+// friendlier line than a busy one.
+func weeklyRecapLead(strs emailStrings, readCount int) string {
+	switch {
+	case readCount >= weeklyRecapHeavyReads:
+		return fmt.Sprintf(strs.WeeklyRecapLeadHeavy, readCount)
+	case readCount >= weeklyRecapSteadyReads:
+		return fmt.Sprintf(strs.WeeklyRecapLeadSteady, readCount)
+	default:
+		return fmt.Sprintf(strs.WeeklyRecapLeadLight, readCount)
+	}
+}
+
+func weeklyRecapDateLabel(date string) string {
+	if parsed, err := time.Parse("2006-01-02", date); err == nil {
+		return parsed.Format("1/2")
+	}
+	return date
+}
+
+func buildWeeklyRecapHTML(r WeeklyRecapEmail) string {
+	strs := emailStringsFor(r.Locale)
+	row := func(label, value string) string {
+		return fmt.Sprintf(`<p style="margin:0 0 6px;color:#444">%s: <strong>%s</strong></p>`, html.EscapeString(label), value)
+	}
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;max-width:640px;margin:0 auto;padding:20px">`)
+	sb.WriteString(fmt.Sprintf(`<h1 style="font-size:22px;margin:0 0 12px">%s</h1>`, html.EscapeString(strs.WeeklyRecapHeading)))
+	sb.WriteString(`<p style="line-height:1.7;color:#333">` + html.EscapeString(weeklyRecapLead(strs, r.Stats.ReadCount)) + `</p>`)
+	sb.WriteString(`<div style="border:1px solid #e4e4e7;border-radius:10px;padding:14px 16px;background:#fafafa">`)
+	sb.WriteString(row(strs.WeeklyRecapRead, fmt.Sprintf("%d", r.Stats.ReadCount)))
+	if len(r.Stats.TopTopics) > 0 {
+		topics := make([]string, 0, len(r.Stats.TopTopics))
+		for _, t := range r.Stats.TopTopics {
+			topics = append(topics, html.EscapeString(t.Topic))
+		}
+		sb.WriteString(row(strs.WeeklyRecapTopics, strings.Join(topics, " / ")))
+	}
+	if r.Stats.BestStreakDays > 0 {
+		sb.WriteString(row(strs.WeeklyRecapStreak, html.EscapeString(fmt.Sprintf(strs.WeeklyRecapStreakDays, r.Stats.BestStreakDays))))
+	}
+	if r.Stats.TopSource != nil {
+		sb.WriteString(row(strs.WeeklyRecapSource, html.EscapeString(fmt.Sprintf(strs.WeeklyRecapSourceCount, r.Stats.TopSource.Title, r.Stats.TopSource.ReadCount))))
+	}
+	sb.WriteString(row(strs.WeeklyRecapSpend, fmt.Sprintf("$%.4f", r.WeekCostUSD)))
+	if r.MonthlyBudgetUSD != nil && *r.MonthlyBudgetUSD > 0 {
+		sb.WriteString(row(strs.WeeklyRecapBudget, fmt.Sprintf("$%.4f / $%.2f (%.0f%%)", r.MonthCostUSD, *r.MonthlyBudgetUSD, r.MonthCostUSD / *r.MonthlyBudgetUSD * 100)))
+	}
+	sb.WriteString(`</div>`)
+	if r.PageURL != "" {
+		sb.WriteString(fmt.Sprintf(`<p><a href="%s" style="color:#2563eb">Sifto</a></p>`, html.EscapeString(r.PageURL)))
+	}
+	sb.WriteString(fmt.Sprintf(`<p style="margin-top:12px;color:#666;line-height:1.6">%s</p>`, html.EscapeString(strs.WeeklyRecapFooter)))
+	sb.WriteString(`</body></html>`)
+	return sb.String()
+}
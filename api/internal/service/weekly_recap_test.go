@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/enjoydarts/sifto/api/internal/model"
+)
+
+func TestBuildWeeklyRecapHTML(t *testing.T) {
+	budget := 10.0
+	out := buildWeeklyRecapHTML(WeeklyRecapEmail{
+		Locale: "en",
+		Stats: model.WeeklyRecapStats{
+			WeekStart:      "2026-03-09",
+			WeekEnd:        "2026-03-15",
+			ReadCount:      20,
+			TopTopics:      []model.WeeklyReviewTopic{{Topic: "AI", Count: 8}, {Topic: "<Go>", Count: 3}},
+			BestStreakDays: 6,
+			TopSource:      &model.WeeklyRecapSource{Title: "A & B", ReadCount: 9},
+		},
+		WeekCostUSD:      0.5,
+		MonthCostUSD:     2.5,
+		MonthlyBudgetUSD: &budget,
+	})
+	for _, want := range []string{
+		"You read 20 items this week. A nice, steady pace.",
+		"AI / &lt;Go&gt;",
+		"6 days",
+		"A &amp; B (9 items)",
+		"$2.5000 / $10.00 (25%)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("recap html missing %q: %s", want, out)
+		}
+	}
+}
+
+func TestBuildWeeklyRecapHTMLOmitsEmptySections(t *testing.T) {
+	out := buildWeeklyRecapHTML(WeeklyRecapEmail{Stats: model.WeeklyRecapStats{ReadCount: 2}})
+	if !strings.Contains(out, "今週は 2 件を読みました。") {
+		t.Fatalf("japanese recap missing lead: %s", out)
+	}
+	for _, unwanted := range []string{"よく読んだトピック", "最長ストリーク", "一番読んだソース", "月次予算"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("recap html has %q without data: %s", unwanted, out)
+		}
+	}
+}
+
+func TestSendWeeklyRecapEmailSubject(t *testing.T) {
+	sender := &recordingEmailSender{}
+	err := SendWeeklyRecapEmail(context.Background(), sender, "a@example.com", WeeklyRecapEmail{
+		Stats: model.WeeklyRecapStats{WeekStart: "2026-03-09", WeekEnd: "2026-03-15", ReadCount: 60},
+	})
+	if err != nil {
+		t.Fatalf("SendWeeklyRecapEmail() error = %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Subject != "Sifto: 今週のふりかえり（3/9〜3/15）" {
+		t.Fatalf("sent = %+v", sender.sent)
+	}
+	if !strings.Contains(sender.sent[0].HTML, "大食いチャンピオン") {
+		t.Fatalf("heavy week lead missing: %s", sender.sent[0].HTML)
+	}
+}
@@ -1,12 +1,10 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"strconv"
@@ -57,6 +55,14 @@ type WorkerClient struct {
 	askTimeout           time.Duration
 	audioBriefingTimeout time.Duration
 	internalSecret       string
+	// Resilience settings; the zero value means one attempt with no breaker.
+	endpoints      map[string]workerEndpointPolicy
+	maxRetries     int
+	retryBaseDelay time.Duration
+	hedgeDelay     time.Duration
+	breaker        *CircuitBreaker
+	native         *NativeLLMClient
+	nativeMode     string
 }
 
 type AudioBriefingDeleteObjectsResponse struct {
@@ -95,14 +101,27 @@ func NewWorkerClient() *WorkerClient {
 	if audioBriefingTimeout > 0 && audioBriefingTimeout+15*time.Second > httpTimeout {
 		httpTimeout = audioBriefingTimeout + 15*time.Second
 	}
-	return &WorkerClient{
+	client := &WorkerClient{
 		baseURL:              url,
 		http:                 &http.Client{Timeout: httpTimeout},
 		composeDigestTimeout: composeTimeout,
 		askTimeout:           askTimeout,
 		audioBriefingTimeout: audioBriefingTimeout,
 		internalSecret:       strings.TrimSpace(os.Getenv("INTERNAL_WORKER_SECRET")),
+		endpoints:            workerEndpointPoliciesFromEnv(),
+		maxRetries:           workerEnvInt("PYTHON_WORKER_RETRY_ATTEMPTS", 2),
+		retryBaseDelay:       time.Duration(workerEnvInt("PYTHON_WORKER_RETRY_BASE_DELAY_MS", 200)) * time.Millisecond,
+		hedgeDelay:           time.Duration(workerEnvInt("PYTHON_WORKER_HEDGE_DELAY_MS", 0)) * time.Millisecond,
+		breaker: NewCircuitBreaker(
+			workerEnvInt("PYTHON_WORKER_BREAKER_THRESHOLD", 5),
+			time.Duration(workerEnvInt("PYTHON_WORKER_BREAKER_COOLDOWN_SEC", 30))*time.Second,
+			isWorkerBreakerFailure,
+		),
 	}
+	if client.nativeMode = nativeLLMModeFromEnv(); client.nativeMode != NativeLLMModeOff {
+		client.native = NewNativeLLMClient()
+	}
+	return client
 }
 
 func workerComposeDigestTimeout() time.Duration {
@@ -137,11 +156,18 @@ type ExtractBodyResponse struct {
 	Content     string  `json:"content"`
 	PublishedAt *string `json:"published_at"`
 	ImageURL    *string `json:"image_url"`
+	Source      string  `json:"extraction_source,omitempty"`
+	ArchiveURL  *string `json:"archive_url,omitempty"`
+	// CanonicalURL is the page's rel=canonical link, when it declares one.
+	CanonicalURL *string `json:"canonical_url,omitempty"`
 }
 
+// ExtractBodyError is a failed /extract-body call. UpstreamStatus is the HTTP status the page
+// itself answered with when the worker could not fetch it, or 0.
 type ExtractBodyError struct {
-	Message string
-	Partial *ExtractBodyResponse
+	Message        string
+	Partial        *ExtractBodyResponse
+	UpstreamStatus int
 }
 
 func (e *ExtractBodyError) Error() string {
@@ -170,6 +196,7 @@ type SummarizeResponse struct {
 	Topics             []string       `json:"topics"`
 	Genre              *string        `json:"genre,omitempty"`
 	OtherGenreLabel    *string        `json:"other_label,omitempty"`
+	Depth              *string        `json:"depth,omitempty"`
 	TranslatedTitle    string         `json:"translated_title,omitempty"`
 	Score              float64        `json:"score"`
 	ScoreBreakdown     map[string]any `json:"score_breakdown,omitempty"`
@@ -195,6 +222,22 @@ type TranslateTitleResponse struct {
 	LLM             *LLMUsage `json:"llm,omitempty"`
 }
 
+// ItemTranslationInput is what /translate-item translates. Content is optional full text.
+type ItemTranslationInput struct {
+	Title   *string  `json:"title,omitempty"`
+	Summary string   `json:"summary"`
+	Facts   []string `json:"facts"`
+	Content *string  `json:"content,omitempty"`
+}
+
+type TranslateItemResponse struct {
+	Title   string    `json:"title"`
+	Summary string    `json:"summary"`
+	Facts   []string  `json:"facts"`
+	Content string    `json:"content"`
+	LLM     *LLMUsage `json:"llm,omitempty"`
+}
+
 type ComposeDigestItem struct {
 	Rank    int      `json:"rank"`
 	Title   *string  `json:"title"`
@@ -400,6 +443,20 @@ type AskResponse struct {
 	LLM       *LLMUsage     `json:"llm,omitempty"`
 }
 
+type AnswerCitation struct {
+	ItemID string  `json:"item_id"`
+	URL    string  `json:"url"`
+	Title  *string `json:"title,omitempty"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+type AnswerResponse struct {
+	Answer    string           `json:"answer"`
+	Bullets   []string         `json:"bullets"`
+	Citations []AnswerCitation `json:"citations"`
+	LLM       *LLMUsage        `json:"llm,omitempty"`
+}
+
 type AskRerankItem struct {
 	ItemID string `json:"item_id"`
 	Reason string `json:"reason,omitempty"`
@@ -517,39 +574,23 @@ func (w *WorkerClient) ExtractBody(ctx context.Context, url string) (*ExtractBod
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.baseURL+"/extract-body", bytes.NewReader(b))
+	headers := workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	body, err := w.send(ctx, http.MethodPost, "/extract-body", b, headers)
 	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	for k, v := range applyWorkerTraceHeaders(ctx, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret)) {
-		if v != "" {
-			req.Header.Set(k, v)
-		}
-	}
-
-	resp, err := w.http.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		if len(body) > 0 {
-			if detail := extractWorkerErrorDetail(body); detail != "" {
-				return nil, &ExtractBodyError{
-					Message: fmt.Sprintf("worker /extract-body: status %d detail=%s", resp.StatusCode, detail),
-					Partial: extractBodyPartialFromError(body),
-				}
+		var workerErr *WorkerError
+		if errors.As(err, &workerErr) && workerErr.StatusCode > 0 {
+			extractErr := &ExtractBodyError{Message: workerErr.Error()}
+			if workerErr.Detail != "" {
+				extractErr.Partial = extractBodyPartialFromError(body)
+				extractErr.UpstreamStatus = extractBodyUpstreamStatus(body)
 			}
-			return nil, &ExtractBodyError{Message: fmt.Sprintf("worker /extract-body: status %d body=%s", resp.StatusCode, string(body))}
+			return nil, extractErr
 		}
-		return nil, &ExtractBodyError{Message: fmt.Sprintf("worker /extract-body: status %d", resp.StatusCode)}
+		return nil, err
 	}
 
 	var result ExtractBodyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -559,59 +600,91 @@ func (w *WorkerClient) Health(ctx context.Context) error {
 	if w == nil {
 		return fmt.Errorf("worker client is nil")
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.baseURL+"/health", nil)
-	if err != nil {
-		return err
-	}
-	resp, err := w.http.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		if len(b) > 0 {
-			return fmt.Errorf("worker /health: status %d body=%s", resp.StatusCode, string(b))
-		}
-		return fmt.Errorf("worker /health: status %d", resp.StatusCode)
+	_, err := w.send(ctx, http.MethodGet, "/health", nil, nil)
+	return err
+}
+
+type VerifyAPIKeyResponse struct {
+	Provider   string           `json:"provider"`
+	Valid      bool             `json:"valid"`
+	StatusCode int              `json:"status_code"`
+	Error      *string          `json:"error,omitempty"`
+	Limits     map[string]int64 `json:"limits"`
+}
+
+// VerifyAPIKey asks the worker to make a minimal authenticated provider call with apiKey.
+func (w *WorkerClient) VerifyAPIKey(ctx context.Context, provider, apiKey string) (*VerifyAPIKeyResponse, error) {
+	var headers map[string]string
+	switch provider {
+	case "anthropic":
+		headers = workerHeaders(&apiKey, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	case "google":
+		headers = workerHeaders(nil, &apiKey, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	case "openai":
+		headers = workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, &apiKey, nil, nil, nil, nil)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", provider)
 	}
-	return nil
+	return postWithHeaders[VerifyAPIKeyResponse](ctx, w, "/verify-api-key", map[string]any{
+		"provider": provider,
+	}, headers)
 }
 
 func (w *WorkerClient) ExtractFacts(ctx context.Context, title *string, content string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string) (*ExtractFactsResponse, error) {
-	return postWithHeaders[ExtractFactsResponse](ctx, w, "/extract-facts", map[string]any{
-		"title":   title,
-		"content": content,
-		"model":   nil,
-	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil, w.internalSecret))
+	return withNativeLLM(w, "extract-facts", func() (*ExtractFactsResponse, error) {
+		return postWithHeaders[ExtractFactsResponse](ctx, w, "/extract-facts", map[string]any{
+			"title":   title,
+			"content": content,
+			"model":   nil,
+		}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil))
+	}, func(n *NativeLLMClient) (*ExtractFactsResponse, error) {
+		return n.ExtractFacts(ctx, title, content, anthropicAPIKey, openAIAPIKey, nil, nil)
+	})
 }
 
 func (w *WorkerClient) ExtractFactsWithModel(ctx context.Context, title *string, content string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string, prompt *PromptConfig) (*ExtractFactsResponse, error) {
-	return postWithHeaders[ExtractFactsResponse](ctx, w, "/extract-facts", map[string]any{
-		"title":   title,
-		"content": content,
-		"model":   model,
-		"prompt":  prompt,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	return withNativeLLM(w, "extract-facts", func() (*ExtractFactsResponse, error) {
+		return postWithHeaders[ExtractFactsResponse](ctx, w, "/extract-facts", map[string]any{
+			"title":   title,
+			"content": content,
+			"model":   model,
+			"prompt":  prompt,
+		}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
+	}, func(n *NativeLLMClient) (*ExtractFactsResponse, error) {
+		return n.ExtractFacts(ctx, title, content, anthropicAPIKey, openAIAPIKey, model, prompt)
+	})
 }
 
 func (w *WorkerClient) Summarize(ctx context.Context, title *string, facts []string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string) (*SummarizeResponse, error) {
-	return postWithHeaders[SummarizeResponse](ctx, w, "/summarize", map[string]any{
-		"title":             title,
-		"facts":             facts,
-		"model":             nil,
-		"source_text_chars": nil,
-	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil, w.internalSecret))
-}
-
-func (w *WorkerClient) SummarizeWithModel(ctx context.Context, title *string, facts []string, sourceTextChars *int, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string, prompt *PromptConfig) (*SummarizeResponse, error) {
-	return postWithHeaders[SummarizeResponse](ctx, w, "/summarize", map[string]any{
-		"title":             title,
-		"facts":             facts,
-		"model":             model,
-		"source_text_chars": sourceTextChars,
-		"prompt":            prompt,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	return withNativeLLM(w, "summarize", func() (*SummarizeResponse, error) {
+		return postWithHeaders[SummarizeResponse](ctx, w, "/summarize", map[string]any{
+			"title":             title,
+			"facts":             facts,
+			"model":             nil,
+			"source_text_chars": nil,
+		}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil))
+	}, func(n *NativeLLMClient) (*SummarizeResponse, error) {
+		return n.Summarize(ctx, title, facts, nil, anthropicAPIKey, openAIAPIKey, nil, nil)
+	})
+}
+
+func (w *WorkerClient) SummarizeWithModel(ctx context.Context, title *string, facts []string, sourceTextChars *int, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string, prompt *PromptConfig, targetLanguage *string) (*SummarizeResponse, error) {
+	viaWorker := func() (*SummarizeResponse, error) {
+		return postWithHeaders[SummarizeResponse](ctx, w, "/summarize", map[string]any{
+			"title":             title,
+			"facts":             facts,
+			"model":             model,
+			"source_text_chars": sourceTextChars,
+			"prompt":            prompt,
+			"target_language":   targetLanguage,
+		}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
+	}
+	if !nativeLLMSupportsLanguage(targetLanguage) {
+		return viaWorker()
+	}
+	return withNativeLLM(w, "summarize", viaWorker, func(n *NativeLLMClient) (*SummarizeResponse, error) {
+		return n.Summarize(ctx, title, facts, sourceTextChars, anthropicAPIKey, openAIAPIKey, model, prompt)
+	})
 }
 
 func (w *WorkerClient) CheckSummaryFaithfulnessWithModel(ctx context.Context, title *string, facts []string, summary string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string) (*SummaryFaithfulnessResponse, error) {
@@ -620,7 +693,7 @@ func (w *WorkerClient) CheckSummaryFaithfulnessWithModel(ctx context.Context, ti
 		"facts":   facts,
 		"summary": summary,
 		"model":   model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) CheckFactsWithModel(ctx context.Context, title *string, content string, facts []string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string) (*FactsCheckResponse, error) {
@@ -629,14 +702,25 @@ func (w *WorkerClient) CheckFactsWithModel(ctx context.Context, title *string, c
 		"content": content,
 		"facts":   facts,
 		"model":   model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) TranslateTitleWithModel(ctx context.Context, title string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string) (*TranslateTitleResponse, error) {
 	return postWithHeaders[TranslateTitleResponse](ctx, w, "/translate-title", map[string]any{
 		"title": title,
 		"model": model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
+}
+
+func (w *WorkerClient) TranslateItemWithModel(ctx context.Context, input ItemTranslationInput, targetLanguage string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string) (*TranslateItemResponse, error) {
+	return postWithHeaders[TranslateItemResponse](ctx, w, "/translate-item", map[string]any{
+		"title":           input.Title,
+		"summary":         input.Summary,
+		"facts":           input.Facts,
+		"content":         input.Content,
+		"target_language": targetLanguage,
+		"model":           model,
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) ComposeDigest(ctx context.Context, digestDate string, items []ComposeDigestItem, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string) (*ComposeDigestResponse, error) {
@@ -645,25 +729,41 @@ func (w *WorkerClient) ComposeDigest(ctx context.Context, digestDate string, ite
 		ctx, cancel = context.WithTimeout(ctx, w.composeDigestTimeout)
 		defer cancel()
 	}
-	return postWithHeaders[ComposeDigestResponse](ctx, w, "/compose-digest", map[string]any{
-		"digest_date": digestDate,
-		"items":       items,
-		"model":       nil,
-	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil, w.internalSecret))
+	return withNativeLLM(w, "compose-digest", func() (*ComposeDigestResponse, error) {
+		return postWithHeaders[ComposeDigestResponse](ctx, w, "/compose-digest", map[string]any{
+			"digest_date": digestDate,
+			"items":       items,
+			"model":       nil,
+		}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil))
+	}, func(n *NativeLLMClient) (*ComposeDigestResponse, error) {
+		return n.ComposeDigest(ctx, digestDate, items, anthropicAPIKey, openAIAPIKey, nil, nil)
+	})
 }
 
-func (w *WorkerClient) ComposeDigestWithModel(ctx context.Context, digestDate string, items []ComposeDigestItem, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string, prompt *PromptConfig) (*ComposeDigestResponse, error) {
+func (w *WorkerClient) ComposeDigestWithModel(ctx context.Context, digestDate string, items []ComposeDigestItem, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, model *string, prompt *PromptConfig, targetLanguage *string, locale string, verbosity string, tone string) (*ComposeDigestResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && w.composeDigestTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, w.composeDigestTimeout)
 		defer cancel()
 	}
-	return postWithHeaders[ComposeDigestResponse](ctx, w, "/compose-digest", map[string]any{
-		"digest_date": digestDate,
-		"items":       items,
-		"model":       model,
-		"prompt":      prompt,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	viaWorker := func() (*ComposeDigestResponse, error) {
+		return postWithHeaders[ComposeDigestResponse](ctx, w, "/compose-digest", map[string]any{
+			"digest_date":     digestDate,
+			"items":           items,
+			"model":           model,
+			"prompt":          prompt,
+			"target_language": targetLanguage,
+			"locale":          locale,
+			"verbosity":       verbosity,
+			"tone":            tone,
+		}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
+	}
+	if !nativeLLMSupportsLanguage(targetLanguage) {
+		return viaWorker()
+	}
+	return withNativeLLM(w, "compose-digest", viaWorker, func(n *NativeLLMClient) (*ComposeDigestResponse, error) {
+		return n.ComposeDigest(ctx, digestDate, items, anthropicAPIKey, openAIAPIKey, model, prompt)
+	})
 }
 
 func (w *WorkerClient) ComposeDigestClusterDraftWithModel(
@@ -690,7 +790,7 @@ func (w *WorkerClient) ComposeDigestClusterDraftWithModel(
 		"topics":        topics,
 		"source_lines":  sourceLines,
 		"model":         model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) AskWithModel(
@@ -718,7 +818,37 @@ func (w *WorkerClient) AskWithModel(
 		"query":      query,
 		"candidates": candidates,
 		"model":      model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
+}
+
+// AnswerWithModel asks the worker for a cited answer. Unlike AskWithModel, the worker drops
+// citations outside candidates and returns each cited item's URL.
+func (w *WorkerClient) AnswerWithModel(
+	ctx context.Context,
+	query string,
+	candidates []AskCandidate,
+	anthropicAPIKey *string,
+	googleAPIKey *string,
+	groqAPIKey *string,
+	deepseekAPIKey *string,
+	alibabaAPIKey *string,
+	mistralAPIKey *string,
+	xaiAPIKey *string,
+	zaiAPIKey *string,
+	fireworksAPIKey *string,
+	openAIAPIKey *string,
+	model *string,
+) (*AnswerResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && w.askTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.askTimeout)
+		defer cancel()
+	}
+	return postWithHeaders[AnswerResponse](ctx, w, "/answer", map[string]any{
+		"query":      query,
+		"candidates": candidates,
+		"model":      model,
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) AskRerankWithModel(
@@ -748,7 +878,7 @@ func (w *WorkerClient) AskRerankWithModel(
 		"candidates": candidates,
 		"top_k":      topK,
 		"model":      model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) GenerateAskNavigatorWithModel(
@@ -776,7 +906,7 @@ func (w *WorkerClient) GenerateAskNavigatorWithModel(
 		"persona": persona,
 		"input":   input,
 		"model":   model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) RankFeedSuggestions(
@@ -804,7 +934,7 @@ func (w *WorkerClient) RankFeedSuggestions(
 		"positive_examples": positiveExamples,
 		"negative_examples": negativeExamples,
 		"model":             nil,
-	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil))
 }
 
 func (w *WorkerClient) RankFeedSuggestionsWithModel(
@@ -841,7 +971,7 @@ func (w *WorkerClient) RankFeedSuggestionsWithModel(
 		"positive_examples": positiveExamples,
 		"negative_examples": negativeExamples,
 		"model":             model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, selectOpenAICompatibleKey(model, togetherAPIKey, moonshotAPIKey, openRouterAPIKey, poeAPIKey, siliconFlowAPIKey, minimaxAPIKey, nil, featherlessAPIKey, nil, cerebrasAPIKey, openAIAPIKey), nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, selectOpenAICompatibleKey(model, togetherAPIKey, moonshotAPIKey, openRouterAPIKey, poeAPIKey, siliconFlowAPIKey, minimaxAPIKey, nil, featherlessAPIKey, nil, cerebrasAPIKey, openAIAPIKey), nil, nil, nil))
 }
 
 func (w *WorkerClient) SuggestFeedSeedSites(
@@ -867,7 +997,7 @@ func (w *WorkerClient) SuggestFeedSeedSites(
 		"positive_examples": positiveExamples,
 		"negative_examples": negativeExamples,
 		"model":             nil,
-	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, nil))
 }
 
 func (w *WorkerClient) SuggestFeedSeedSitesWithModel(
@@ -901,7 +1031,7 @@ func (w *WorkerClient) SuggestFeedSeedSitesWithModel(
 		"positive_examples": positiveExamples,
 		"negative_examples": negativeExamples,
 		"model":             model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, selectOpenAICompatibleKey(model, togetherAPIKey, moonshotAPIKey, openRouterAPIKey, poeAPIKey, siliconFlowAPIKey, minimaxAPIKey, nil, featherlessAPIKey, nil, nil, openAIAPIKey), nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, selectOpenAICompatibleKey(model, togetherAPIKey, moonshotAPIKey, openRouterAPIKey, poeAPIKey, siliconFlowAPIKey, minimaxAPIKey, nil, featherlessAPIKey, nil, nil, openAIAPIKey), nil, nil, nil))
 }
 
 func (w *WorkerClient) GenerateBriefingNavigatorWithModel(
@@ -926,7 +1056,7 @@ func (w *WorkerClient) GenerateBriefingNavigatorWithModel(
 		"candidates":    candidates,
 		"intro_context": introContext,
 		"model":         model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) ComposeAINavigatorBriefWithModel(
@@ -951,7 +1081,7 @@ func (w *WorkerClient) ComposeAINavigatorBriefWithModel(
 		"candidates":    candidates,
 		"intro_context": introContext,
 		"model":         model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) GenerateSourceNavigatorWithModel(
@@ -965,9 +1095,6 @@ func (w *WorkerClient) GenerateSourceNavigatorWithModel(
 		"X-LLM-Provider": LLMProviderForModel(model),
 		"X-LLM-Model":    strings.TrimSpace(derefString(model)),
 	}
-	if w.internalSecret != "" {
-		headers["X-Internal-Worker-Secret"] = w.internalSecret
-	}
 	if anthropicAPIKey != "" {
 		headers["X-Anthropic-Api-Key"] = anthropicAPIKey
 	}
@@ -1023,7 +1150,7 @@ func (w *WorkerClient) GenerateItemNavigatorWithModel(
 		"persona": persona,
 		"article": article,
 		"model":   model,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) GenerateAudioBriefingScriptWithModel(
@@ -1070,7 +1197,7 @@ func (w *WorkerClient) GenerateAudioBriefingScriptWithModel(
 		"include_article_segments": includeArticleSegments,
 		"include_ending":           includeEnding,
 		"prompt":                   prompt,
-	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil, w.internalSecret))
+	}, workerHeadersForModel(model, anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, openAIAPIKey, nil, nil, nil))
 }
 
 func (w *WorkerClient) SynthesizeAudioBriefingUpload(
@@ -1130,7 +1257,7 @@ func (w *WorkerClient) SynthesizeAudioBriefingUpload(
 	if uuid := strings.TrimSpace(derefString(aivisUserDictionaryUUID)); uuid != "" {
 		requestBody["user_dictionary_uuid"] = uuid
 	}
-	headers := workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, xaiAPIKey, nil, nil, openAIAPIKey, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, nil, w.internalSecret)
+	headers := workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, xaiAPIKey, nil, nil, openAIAPIKey, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, nil)
 	if azureSpeechAPIKey != nil && strings.TrimSpace(*azureSpeechAPIKey) != "" {
 		if headers == nil {
 			headers = map[string]string{}
@@ -1167,7 +1294,7 @@ func (w *WorkerClient) SynthesizeAudioBriefingGeminiDuoUpload(
 		"turns":               turns,
 		"output_object_key":   outputObjectKey,
 	}
-	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-gemini-duo", requestBody, workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-gemini-duo", requestBody, workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 }
 
 func (w *WorkerClient) SynthesizeAudioBriefingFishDuoUpload(
@@ -1201,7 +1328,7 @@ func (w *WorkerClient) SynthesizeAudioBriefingFishDuoUpload(
 	if strings.TrimSpace(preprocessedText) != "" {
 		requestBody["preprocessed_text"] = strings.TrimSpace(preprocessedText)
 	}
-	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-fish-duo", requestBody, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fishAPIKey, nil, nil, w.internalSecret))
+	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-fish-duo", requestBody, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fishAPIKey, nil, nil))
 }
 
 func (w *WorkerClient) SynthesizeAudioBriefingElevenLabsDuoUpload(
@@ -1231,7 +1358,7 @@ func (w *WorkerClient) SynthesizeAudioBriefingElevenLabsDuoUpload(
 		"turns":               turns,
 		"output_object_key":   outputObjectKey,
 	}
-	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-elevenlabs-duo", requestBody, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, elevenLabsAPIKey, nil, w.internalSecret))
+	return postWithHeaders[AudioBriefingSynthesizeUploadResponse](ctx, w, "/audio-briefing/synthesize-upload-elevenlabs-duo", requestBody, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, elevenLabsAPIKey, nil))
 }
 
 func (w *WorkerClient) SynthesizeAudioBriefingAzureSpeechDuoUpload(
@@ -1269,7 +1396,7 @@ func (w *WorkerClient) SynthesizeAudioBriefingAzureSpeechDuoUpload(
 	if strings.TrimSpace(preprocessedText) != "" {
 		requestBody["preprocessed_text"] = strings.TrimSpace(preprocessedText)
 	}
-	headers := workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret)
+	headers := workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if azureSpeechAPIKey != nil && strings.TrimSpace(*azureSpeechAPIKey) != "" {
 		if headers == nil {
 			headers = map[string]string{}
@@ -1327,7 +1454,7 @@ func (w *WorkerClient) SynthesizeSummaryAudio(
 	if uuid := strings.TrimSpace(derefString(aivisUserDictionaryUUID)); uuid != "" {
 		requestBody["user_dictionary_uuid"] = uuid
 	}
-	headers := workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, xaiAPIKey, nil, nil, openAIAPIKey, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, cartesiaAPIKey, w.internalSecret)
+	headers := workerHeaders(nil, googleAPIKey, nil, nil, nil, nil, xaiAPIKey, nil, nil, openAIAPIKey, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, cartesiaAPIKey)
 	if azureSpeechAPIKey != nil && strings.TrimSpace(*azureSpeechAPIKey) != "" {
 		if headers == nil {
 			headers = map[string]string{}
@@ -1354,7 +1481,7 @@ func (w *WorkerClient) PreprocessTTSMarkupText(
 		"prompt_key": promptKey,
 		"variables":  variables,
 	}
-	headers := workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret)
+	headers := workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if headers == nil {
 		headers = map[string]string{}
 	}
@@ -1377,7 +1504,7 @@ func (w *WorkerClient) PresignAudioBriefingObjectInBucket(ctx context.Context, o
 		"object_key":  objectKey,
 		"bucket":      bucket,
 		"expires_sec": expiresSec,
-	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 }
 
 func (w *WorkerClient) DeleteAudioBriefingObjects(ctx context.Context, objectRefs []AudioBriefingObjectRef) error {
@@ -1397,7 +1524,7 @@ func (w *WorkerClient) DeleteAudioBriefingObjectsInBucket(ctx context.Context, b
 	_, err := postWithHeaders[AudioBriefingDeleteObjectsResponse](ctx, w, "/audio-briefing/delete-objects", map[string]any{
 		"object_keys": objectKeys,
 		"bucket":      bucket,
-	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 	return err
 }
 
@@ -1409,7 +1536,7 @@ func (w *WorkerClient) CopyAudioBriefingObjects(ctx context.Context, sourceBucke
 		"source_bucket": sourceBucket,
 		"target_bucket": targetBucket,
 		"object_keys":   objectKeys,
-	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 	return err
 }
 
@@ -1417,7 +1544,7 @@ func (w *WorkerClient) StatAudioBriefingObject(ctx context.Context, bucket strin
 	return postWithHeaders[AudioBriefingStatObjectResponse](ctx, w, "/audio-briefing/stat-object", map[string]any{
 		"bucket":     bucket,
 		"object_key": objectKey,
-	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 }
 
 func (w *WorkerClient) UploadAudioBriefingObject(ctx context.Context, bucket string, objectKey string, contentBase64 string, contentType string) (*AudioBriefingUploadObjectResponse, error) {
@@ -1426,14 +1553,11 @@ func (w *WorkerClient) UploadAudioBriefingObject(ctx context.Context, bucket str
 		"object_key":     objectKey,
 		"content_base64": contentBase64,
 		"content_type":   contentType,
-	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, w.internalSecret))
+	}, workerHeaders(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 }
 
-func workerHeaders(anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, aivisAPIKey *string, fishAudioAPIKey *string, elevenLabsAPIKey *string, cartesiaAPIKey *string, internalSecret string) map[string]string {
+func workerHeaders(anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, aivisAPIKey *string, fishAudioAPIKey *string, elevenLabsAPIKey *string, cartesiaAPIKey *string) map[string]string {
 	headers := map[string]string{}
-	if internalSecret != "" {
-		headers["X-Internal-Worker-Secret"] = internalSecret
-	}
 	if anthropicAPIKey != nil && *anthropicAPIKey != "" {
 		headers["X-Anthropic-Api-Key"] = *anthropicAPIKey
 	}
@@ -1480,8 +1604,8 @@ func workerHeaders(anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *st
 	return headers
 }
 
-func workerHeadersForModel(model *string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, aivisAPIKey *string, fishAudioAPIKey *string, elevenLabsAPIKey *string, internalSecret string) map[string]string {
-	headers := workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, nil, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, nil, internalSecret)
+func workerHeadersForModel(model *string, anthropicAPIKey *string, googleAPIKey *string, groqAPIKey *string, deepseekAPIKey *string, alibabaAPIKey *string, mistralAPIKey *string, xaiAPIKey *string, zaiAPIKey *string, fireworksAPIKey *string, openAIAPIKey *string, aivisAPIKey *string, fishAudioAPIKey *string, elevenLabsAPIKey *string) map[string]string {
+	headers := workerHeaders(anthropicAPIKey, googleAPIKey, groqAPIKey, deepseekAPIKey, alibabaAPIKey, mistralAPIKey, xaiAPIKey, zaiAPIKey, fireworksAPIKey, nil, aivisAPIKey, fishAudioAPIKey, elevenLabsAPIKey, nil)
 	if headers == nil && openAIAPIKey != nil && strings.TrimSpace(*openAIAPIKey) != "" {
 		headers = map[string]string{}
 	}
@@ -1579,6 +1703,9 @@ func applyWorkerTraceHeaders(ctx context.Context, headers map[string]string) map
 	if v, _ := ctx.Value(workerTraceDigestIDKey).(string); v != "" {
 		headers["X-Sifto-Digest-Id"] = v
 	}
+	if v := RequestIDFromContext(ctx); v != "" {
+		headers[RequestIDHeader] = v
+	}
 	return headers
 }
 
@@ -1587,36 +1714,13 @@ func postWithHeaders[T any](ctx context.Context, w *WorkerClient, path string, b
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.baseURL+path, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	for k, v := range applyWorkerTraceHeaders(ctx, headers) {
-		if v != "" {
-			req.Header.Set(k, v)
-		}
-	}
-
-	resp, err := w.http.Do(req)
+	out, err := w.send(ctx, http.MethodPost, path, b, headers)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		if len(b) > 0 {
-			if detail := extractWorkerErrorDetail(b); detail != "" {
-				return nil, fmt.Errorf("worker %s: status %d detail=%s", path, resp.StatusCode, detail)
-			}
-			return nil, fmt.Errorf("worker %s: status %d body=%s", path, resp.StatusCode, string(b))
-		}
-		return nil, fmt.Errorf("worker %s: status %d", path, resp.StatusCode)
-	}
 
 	var result T
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(out, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -1653,6 +1757,21 @@ func extractWorkerErrorDetail(body []byte) string {
 	return raw
 }
 
+// extractBodyUpstreamStatus reads the status of an "upstream_http_status" error, which the
+// worker returns when fetching the page itself failed.
+func extractBodyUpstreamStatus(body []byte) int {
+	var payload struct {
+		Detail struct {
+			Code           string `json:"code"`
+			UpstreamStatus int    `json:"upstream_status"`
+		} `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Detail.Code != "upstream_http_status" {
+		return 0
+	}
+	return payload.Detail.UpstreamStatus
+}
+
 func extractBodyPartialFromError(body []byte) *ExtractBodyResponse {
 	var payload workerErrorDetailPayload
 	if err := json.Unmarshal(body, &payload); err != nil || payload.Detail == nil {
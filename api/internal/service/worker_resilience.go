@@ -0,0 +1,340 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrWorkerCircuitOpen is returned without contacting the worker while its breaker is open.
+var ErrWorkerCircuitOpen = errors.New("worker circuit open")
+
+// WorkerError is returned by every worker call. StatusCode is 0 when no response was
+// received. Retryable tells callers (Inngest steps) whether the same request may succeed
+// later; 4xx responses other than 408/429 are permanent. On a 429 from an LLM provider,
+// Provider and RetryAfter say whose window to wait out and for how long.
+type WorkerError struct {
+	Path       string
+	StatusCode int
+	Detail     string
+	Body       string
+	Retryable  bool
+	Provider   string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *WorkerError) Error() string {
+	switch {
+	case e.StatusCode == 0 && e.Err != nil:
+		return fmt.Sprintf("worker %s: %v", e.Path, e.Err)
+	case e.Detail != "":
+		return fmt.Sprintf("worker %s: status %d detail=%s", e.Path, e.StatusCode, e.Detail)
+	case e.Body != "":
+		return fmt.Sprintf("worker %s: status %d body=%s", e.Path, e.StatusCode, e.Body)
+	default:
+		return fmt.Sprintf("worker %s: status %d", e.Path, e.StatusCode)
+	}
+}
+
+func (e *WorkerError) Unwrap() error { return e.Err }
+
+// IsRetryableWorkerError reports whether err is a worker failure worth retrying later.
+func IsRetryableWorkerError(err error) bool {
+	var we *WorkerError
+	return errors.As(err, &we) && we.Retryable
+}
+
+// IsWorkerUnavailable reports failures where the worker never answered: its breaker is open
+// or the connection failed. Unlike 5xx responses these say nothing about the request itself.
+func IsWorkerUnavailable(err error) bool {
+	var we *WorkerError
+	return errors.As(err, &we) && we.StatusCode == 0 && we.Retryable
+}
+
+func retryableWorkerStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isWorkerTransportFailure reports connection-level failures (refused, reset, DNS), which
+// mean the worker itself is unreachable. Timeouts and cancellations are excluded: a slow
+// LLM call says nothing about worker health.
+func isWorkerTransportFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isWorkerBreakerFailure(err error) bool {
+	var we *WorkerError
+	return errors.As(err, &we) && we.StatusCode == 0 && isWorkerTransportFailure(we.Err)
+}
+
+// workerEndpointPolicy describes how one worker path is called. Idempotent endpoints are
+// retried on transport failures and may be hedged; LLM endpoints are not, since a retry
+// costs another completion and Inngest already retries steps.
+type workerEndpointPolicy struct {
+	Timeout    time.Duration
+	Idempotent bool
+}
+
+var defaultWorkerEndpointPolicies = map[string]workerEndpointPolicy{
+	"/health":                        {Timeout: 5 * time.Second, Idempotent: true},
+	"/extract-body":                  {Timeout: 60 * time.Second, Idempotent: true},
+	"/verify-api-key":                {Timeout: 20 * time.Second, Idempotent: true},
+	"/tts/preprocess-text":           {Timeout: 60 * time.Second},
+	"/audio-briefing/presign":        {Timeout: 15 * time.Second, Idempotent: true},
+	"/audio-briefing/stat-object":    {Timeout: 15 * time.Second, Idempotent: true},
+	"/audio-briefing/delete-objects": {Timeout: 30 * time.Second, Idempotent: true},
+	"/audio-briefing/copy-objects":   {Timeout: 60 * time.Second, Idempotent: true},
+	"/audio-briefing/upload-object":  {Timeout: 60 * time.Second, Idempotent: true},
+}
+
+// parseDurationList reads a comma separated list of key=duration pairs such as
+// "/extract-body=45s,/summarize=90s", skipping malformed or non-positive entries.
+func parseDurationList(raw string) map[string]time.Duration {
+	out := map[string]time.Duration{}
+	for _, part := range strings.Split(raw, ",") {
+		path, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			continue
+		}
+		out[strings.TrimSpace(path)] = d
+	}
+	return out
+}
+
+func workerEndpointPoliciesFromEnv() map[string]workerEndpointPolicy {
+	out := make(map[string]workerEndpointPolicy, len(defaultWorkerEndpointPolicies))
+	for path, p := range defaultWorkerEndpointPolicies {
+		out[path] = p
+	}
+	for path, d := range parseDurationList(os.Getenv("PYTHON_WORKER_ENDPOINT_TIMEOUTS")) {
+		p := out[path]
+		p.Timeout = d
+		out[path] = p
+	}
+	return out
+}
+
+func workerEnvInt(name string, fallback int) int {
+	if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+func (w *WorkerClient) endpointPolicy(path string) workerEndpointPolicy {
+	return w.endpoints[path]
+}
+
+// BreakerSnapshot reports the worker breaker state for health checks.
+func (w *WorkerClient) BreakerSnapshot() map[string]any {
+	if w == nil || w.breaker == nil {
+		return nil
+	}
+	return w.breaker.Snapshot()
+}
+
+// send performs one logical worker call and returns the body of a successful response.
+// It applies the breaker, the endpoint timeout (unless the caller already set a deadline),
+// jittered retries for idempotent endpoints and optional hedging.
+func (w *WorkerClient) send(ctx context.Context, method, path string, body []byte, headers map[string]string) ([]byte, error) {
+	if w.breaker != nil && !w.breaker.Allow() {
+		return nil, &WorkerError{Path: path, Retryable: true, Err: ErrWorkerCircuitOpen}
+	}
+	policy := w.endpointPolicy(path)
+	if _, ok := ctx.Deadline(); !ok && policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+	attempts := 1
+	if policy.Idempotent {
+		attempts += w.maxRetries
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if waitErr := sleepCtx(ctx, workerBackoff(w.retryBaseDelay, attempt)); waitErr != nil {
+				break
+			}
+		}
+		if policy.Idempotent && w.hedgeDelay > 0 {
+			out, err = w.hedged(ctx, method, path, body, headers)
+		} else {
+			out, err = w.attempt(ctx, method, path, body, headers)
+		}
+		var we *WorkerError
+		if err == nil || !errors.As(err, &we) || we.StatusCode != 0 || !isWorkerTransportFailure(we.Err) {
+			break
+		}
+	}
+	if w.breaker != nil {
+		w.breaker.Record(err)
+	}
+	return out, err
+}
+
+func (w *WorkerClient) attempt(ctx context.Context, method, path string, body []byte, headers map[string]string) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, w.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range applyWorkerTraceHeaders(ctx, headers) {
+		if v != "" {
+			req.Header.Set(k, v)
+		}
+	}
+	if w.internalSecret != "" {
+		SignInternalRequest(req, w.internalSecret, body)
+	}
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return nil, &WorkerError{Path: path, Retryable: !errors.Is(err, context.Canceled), Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		we := &WorkerError{Path: path, StatusCode: resp.StatusCode, Retryable: retryableWorkerStatus(resp.StatusCode)}
+		if len(b) > 0 {
+			we.Detail = extractWorkerErrorDetail(b)
+			if we.Detail == "" {
+				we.Body = string(b)
+			}
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			we.Provider, we.RetryAfter = parseWorkerRateLimit(resp.Header, b)
+		}
+		return b, we
+	}
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &WorkerError{Path: path, StatusCode: resp.StatusCode, Retryable: true, Err: err}
+	}
+	return out, nil
+}
+
+// hedged starts a second attempt when the first has not answered within hedgeDelay and
+// returns whichever succeeds first.
+func (w *WorkerClient) hedged(ctx context.Context, method, path string, body []byte, headers map[string]string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		body []byte
+		err  error
+	}
+	results := make(chan result, 2)
+	launch := func() {
+		b, err := w.attempt(ctx, method, path, body, headers)
+		results <- result{b, err}
+	}
+	go launch()
+	timer := time.NewTimer(w.hedgeDelay)
+	defer timer.Stop()
+
+	inFlight := 1
+	var last result
+	for inFlight > 0 {
+		select {
+		case <-timer.C:
+			inFlight++
+			go launch()
+		case r := <-results:
+			inFlight--
+			if r.err == nil {
+				return r.body, nil
+			}
+			last = r
+			if inFlight == 0 {
+				return last.body, last.err
+			}
+		}
+	}
+	return last.body, last.err
+}
+
+// parseWorkerRateLimit reads the provider and wait from a worker 429. The worker reports them
+// in detail.provider / detail.retry_after_sec and mirrors the wait in Retry-After.
+func parseWorkerRateLimit(header http.Header, body []byte) (string, time.Duration) {
+	var payload struct {
+		Detail struct {
+			Provider      string  `json:"provider"`
+			RetryAfterSec float64 `json:"retry_after_sec"`
+		} `json:"detail"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	wait := time.Duration(payload.Detail.RetryAfterSec * float64(time.Second))
+	if wait <= 0 {
+		if sec, err := strconv.ParseFloat(strings.TrimSpace(header.Get("Retry-After")), 64); err == nil && sec > 0 {
+			wait = time.Duration(sec * float64(time.Second))
+		}
+	}
+	return strings.TrimSpace(payload.Detail.Provider), wait
+}
+
+func workerBackoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	d := base << (attempt - 1)
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	// Full jitter keeps retries from many callers from lining up after a worker restart.
+	return time.Duration(rand.Int64N(int64(d)) + 1)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
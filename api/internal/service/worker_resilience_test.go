@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newResilientTestWorker(url string) *WorkerClient {
+	return &WorkerClient{
+		baseURL:        url,
+		http:           &http.Client{Timeout: 5 * time.Second},
+		endpoints:      defaultWorkerEndpointPolicies,
+		maxRetries:     2,
+		retryBaseDelay: time.Millisecond,
+		breaker:        NewCircuitBreaker(2, time.Minute, isWorkerBreakerFailure),
+	}
+}
+
+func TestWorkerSendRetriesIdempotentCallAfterDroppedConnection(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"content":"ok"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newResilientTestWorker(srv.URL).ExtractBody(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatalf("ExtractBody() error = %v", err)
+	}
+	if resp.Content != "ok" || calls.Load() != 2 {
+		t.Fatalf("content=%q calls=%d, want ok after 2 calls", resp.Content, calls.Load())
+	}
+}
+
+func TestWorkerSendDoesNotRetryStatusErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"detail":"bad input"}`))
+	}))
+	defer srv.Close()
+
+	_, err := newResilientTestWorker(srv.URL).send(context.Background(), http.MethodPost, "/extract-body", []byte(`{}`), nil)
+	if err == nil || err.Error() != "worker /extract-body: status 422 detail=bad input" {
+		t.Fatalf("err = %v", err)
+	}
+	if IsRetryableWorkerError(err) || IsWorkerUnavailable(err) {
+		t.Fatalf("422 should be permanent: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestWorkerSendDoesNotRetryNonIdempotentEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer srv.Close()
+
+	_, err := newResilientTestWorker(srv.URL).send(context.Background(), http.MethodPost, "/summarize", []byte(`{}`), nil)
+	if !IsWorkerUnavailable(err) {
+		t.Fatalf("err = %v, want unavailable", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestWorkerBreakerFailsFastWhenWorkerIsDown(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	w := newResilientTestWorker(url)
+	w.maxRetries = 0
+	for i := 0; i < 2; i++ {
+		if err := w.Health(context.Background()); err == nil || errors.Is(err, ErrWorkerCircuitOpen) {
+			t.Fatalf("attempt %d: err = %v, want connection error", i, err)
+		}
+	}
+	err := w.Health(context.Background())
+	if !errors.Is(err, ErrWorkerCircuitOpen) || !IsWorkerUnavailable(err) {
+		t.Fatalf("err = %v, want circuit open", err)
+	}
+	if got := w.BreakerSnapshot()["state"]; got != BreakerOpen {
+		t.Fatalf("breaker state = %v, want open", got)
+	}
+}
+
+func TestWorkerZeroValueClientMakesSingleAttempt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	w := &WorkerClient{baseURL: srv.URL, http: srv.Client()}
+	err := w.Health(context.Background())
+	if err == nil || err.Error() != "worker /health: status 503" || !IsRetryableWorkerError(err) {
+		t.Fatalf("err = %v", err)
+	}
+	if calls.Load() != 1 || w.BreakerSnapshot() != nil {
+		t.Fatalf("calls = %d breaker = %v", calls.Load(), w.BreakerSnapshot())
+	}
+}
+
+func TestParseDurationList(t *testing.T) {
+	got := parseDurationList(" /extract-body=45s, /summarize=2m,bad,/x=-1s,/y=abc")
+	if len(got) != 2 || got["/extract-body"] != 45*time.Second || got["/summarize"] != 2*time.Minute {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestWorkerSendReadsProviderRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "9")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail":{"message":"slow down","provider":"openai","retry_after_sec":42.5}}`))
+	}))
+	defer srv.Close()
+
+	_, err := newResilientTestWorker(srv.URL).send(context.Background(), http.MethodPost, "/summarize", []byte(`{}`), nil)
+	provider, retryAfter, ok := ProviderRateLimit(err)
+	if !ok || provider != "openai" || retryAfter != 42500*time.Millisecond {
+		t.Fatalf("ProviderRateLimit(%v) = %q, %v, %v", err, provider, retryAfter, ok)
+	}
+}
+
+func TestParseWorkerRateLimitFallsBackToRetryAfterHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	provider, wait := parseWorkerRateLimit(header, []byte(`{"detail":{"message":"x","provider":"anthropic"}}`))
+	if provider != "anthropic" || wait != 7*time.Second {
+		t.Fatalf("parseWorkerRateLimit() = %q, %v", provider, wait)
+	}
+}
@@ -67,7 +67,6 @@ func TestWorkerHeadersUsesMinimaxHeaderForMiniMaxModels(t *testing.T) {
 		nil,
 		nil,
 		nil,
-		"",
 	)
 	if got := headers["X-Minimax-Api-Key"]; got != "minimax-key" {
 		t.Fatalf("X-Minimax-Api-Key = %q, want %q", got, "minimax-key")
@@ -93,7 +92,6 @@ func TestWorkerHeadersUsesCerebrasHeaderForCerebrasModels(t *testing.T) {
 		nil,
 		nil,
 		nil,
-		"",
 	)
 	if got := headers["X-Cerebras-Api-Key"]; got != "cerebras-key" {
 		t.Fatalf("X-Cerebras-Api-Key = %q, want %q", got, "cerebras-key")
@@ -119,7 +117,6 @@ func TestWorkerHeadersUsesPLaMoHeaderForPLaMoModels(t *testing.T) {
 		nil,
 		nil,
 		nil,
-		"",
 	)
 	if got := headers["X-Plamo-Api-Key"]; got != "plamo-key" {
 		t.Fatalf("X-Plamo-Api-Key = %q, want %q", got, "plamo-key")
@@ -175,7 +172,7 @@ func TestSummarizeWithModelDecodesGenre(t *testing.T) {
 	model := "gpt-5.4-mini"
 	openAIKey := "openai-key"
 
-	resp, err := client.SummarizeWithModel(context.Background(), nil, []string{"fact"}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &openAIKey, &model, nil)
+	resp, err := client.SummarizeWithModel(context.Background(), nil, []string{"fact"}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &openAIKey, &model, nil, nil)
 	if err != nil {
 		t.Fatalf("SummarizeWithModel: %v", err)
 	}
@@ -0,0 +1,136 @@
+package urlutil
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "gclsrc": true, "dclid": true, "msclkid": true, "yclid": true,
+	"twclid": true, "igshid": true, "mc_cid": true, "mc_eid": true, "_hsenc": true, "_hsmi": true,
+	"mkt_tok": true, "ref_src": true, "ref_url": true, "cmpid": true, "ocid": true, "spm": true,
+	"si": true, "_ga": true, "_gl": true,
+}
+
+var trackingParamPrefixes = []string{"utm_", "pk_", "mtm_", "hsa_"}
+
+// Canonicalize returns rawURL with tracking parameters, fragments, default ports and AMP
+// variants removed, so the same article reached through different feeds compares equal.
+// Remaining query parameters are sorted. Unparsable or non-HTTP(S) URLs are returned trimmed.
+func Canonicalize(rawURL string) string {
+	raw := strings.TrimSpace(rawURL)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw
+	}
+	if inner := unwrapAMPCache(u); inner != nil {
+		u = inner
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	host = strings.TrimPrefix(host, "amp.")
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	u.User = nil
+
+	u.Path = stripAMPPath(u.Path)
+	u.RawPath = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = canonicalQuery(u.RawQuery)
+	u.ForceQuery = false
+	if !strings.HasPrefix(u.Fragment, "!") {
+		u.Fragment = ""
+		u.RawFragment = ""
+	}
+	return u.String()
+}
+
+// unwrapAMPCache returns the publisher URL behind Google's AMP viewer and the AMP CDN cache,
+// e.g. https://www.google.com/amp/s/example.com/a or https://example-com.cdn.ampproject.org/c/s/example.com/a.
+func unwrapAMPCache(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	var rest string
+	switch {
+	case (host == "www.google.com" || host == "google.com") && strings.HasPrefix(u.Path, "/amp/"):
+		rest = strings.TrimPrefix(u.Path, "/amp/")
+	case strings.HasSuffix(host, ".cdn.ampproject.org"):
+		rest = strings.TrimPrefix(strings.TrimPrefix(u.Path, "/c/"), "/v/")
+		if rest == u.Path {
+			return nil
+		}
+	default:
+		return nil
+	}
+	scheme := "http://"
+	if strings.HasPrefix(rest, "s/") {
+		scheme, rest = "https://", strings.TrimPrefix(rest, "s/")
+	}
+	if rest == "" {
+		return nil
+	}
+	inner, err := url.Parse(scheme + rest)
+	if err != nil || inner.Host == "" {
+		return nil
+	}
+	if inner.RawQuery == "" {
+		inner.RawQuery = u.RawQuery
+	}
+	return inner
+}
+
+func stripAMPPath(p string) string {
+	switch {
+	case strings.HasSuffix(p, "/amp/"):
+		return strings.TrimSuffix(p, "amp/")
+	case strings.HasSuffix(p, "/amp"):
+		return strings.TrimSuffix(p, "amp")
+	case strings.HasSuffix(p, ".amp.html"):
+		return strings.TrimSuffix(p, ".amp.html") + ".html"
+	case strings.HasSuffix(p, ".amp"):
+		return strings.TrimSuffix(p, ".amp")
+	}
+	return p
+}
+
+func canonicalQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	for key, vals := range values {
+		lower := strings.ToLower(key)
+		if isTrackingParam(lower) {
+			delete(values, key)
+			continue
+		}
+		if lower == "amp" || (lower == "outputtype" && len(vals) == 1 && strings.EqualFold(vals[0], "amp")) {
+			delete(values, key)
+		}
+	}
+	return values.Encode()
+}
+
+func isTrackingParam(key string) bool {
+	if trackingParams[key] {
+		return true
+	}
+	for _, p := range trackingParamPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package urlutil
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"https://Example.com/a?utm_source=rss&utm_medium=feed&id=3&fbclid=x#section", "https://example.com/a?id=3"},
+		{"  http://example.com:80  ", "http://example.com/"},
+		{"https://example.com:8443/a?b=2&a=1", "https://example.com:8443/a?a=1&b=2"},
+		{"https://example.com/news/story/amp/", "https://example.com/news/story/"},
+		{"https://example.com/news/story.amp.html", "https://example.com/news/story.html"},
+		{"https://amp.example.com/news/story?amp=1", "https://example.com/news/story"},
+		{"https://www.google.com/amp/s/example.com/news/story/amp", "https://example.com/news/story/"},
+		{"https://example-com.cdn.ampproject.org/c/s/example.com/news/story?outputType=amp", "https://example.com/news/story"},
+		{"https://example.com/#!/route", "https://example.com/#!/route"},
+		{"mailto:someone@example.com", "mailto:someone@example.com"},
+		{"not a url", "not a url"},
+	}
+	for _, tc := range cases {
+		if got := Canonicalize(tc.in); got != tc.want {
+			t.Errorf("Canonicalize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
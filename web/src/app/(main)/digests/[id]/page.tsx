@@ -23,6 +23,7 @@ function digestStatusBadge(d: DigestDetail, t: (key: string, fallback?: string)
     case "fetch_failed":
     case "user_key_failed":
       return { label: t("digest.status.failed"), className: "bg-[#f6e8e4] text-[#7a4337]", withSendIcon: false };
+    case "auto_retry_queued":
     case "processing":
       return { label: t("digest.status.processing"), className: "bg-[#eaf0f6] text-[#38506c]", withSendIcon: false };
     case "skipped_resend_disabled":
@@ -140,6 +141,22 @@ export default function DigestDetailPage() {
               {digest.send_error}
             </div>
           ) : null}
+          {digest.status_history && digest.status_history.length > 0 ? (
+            <details className="mt-4 text-xs text-[var(--color-editorial-ink-soft)]">
+              <summary className="cursor-pointer font-semibold">
+                {t("digestDetail.statusHistory")} ({digest.status_history.length})
+              </summary>
+              <ol className="mt-2 space-y-1">
+                {digest.status_history.map((ev, i) => (
+                  <li key={`${ev.created_at}-${i}`} className="break-words">
+                    <span className="tabular-nums">{formatDateTime(ev.created_at, locale)}</span>{" "}
+                    <span className="font-semibold text-[var(--color-editorial-ink)]">{ev.status}</span>
+                    {ev.error ? <span className="text-[#7a4337]"> — {ev.error}</span> : null}
+                  </li>
+                ))}
+              </ol>
+            </details>
+          ) : null}
         </section>
 
         {digest.email_body ? (
@@ -25,6 +25,7 @@ function digestStatusBadge(d: Digest, t: (key: string, fallback?: string) => str
         label: t("digest.status.failed"),
         className: "bg-[#f6e8e4] text-[#7a4337]",
       };
+    case "auto_retry_queued":
     case "processing":
       return {
         label: t("digest.status.processing"),
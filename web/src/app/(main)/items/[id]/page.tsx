@@ -444,6 +444,15 @@ export default function ItemDetailPage() {
                 <p className="whitespace-pre-wrap break-words text-sm text-[var(--color-editorial-error)]">{item.processing_error}</p>
               </div>
             )}
+
+            {item.archive_url && (
+              <div className="mt-4 rounded-[18px] border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel)] px-4 py-3 text-sm text-[var(--color-editorial-ink-soft)]">
+                {t("itemDetail.archivedCopy")}{" "}
+                <a href={item.archive_url} target="_blank" rel="noopener noreferrer" className="underline">
+                  {t("itemDetail.archivedCopyLink")}
+                </a>
+              </div>
+            )}
           </div>
 
           {item.thumbnail_url ? (
@@ -552,7 +552,8 @@ export function useItemDetailData() {
     if (!item || retryUpdating || item.status === "deleted") return;
     setRetryUpdating(true);
     try {
-      await api.retryItem(item.id);
+      // Asked for by hand, so the summary is regenerated even if nothing changed.
+      await api.retryItem(item.id, { force: true });
       const nextItem = (prev: ItemDetail): ItemDetail => ({
         ...prev,
         status: "new" as const,
@@ -168,7 +168,7 @@ export function useLLMUsageData() {
         queryClient.fetchQuery(settingsQueryOptions()),
       ]);
       if (seq !== seqRef.current) return;
-      setSummaryRows(summary ?? []);
+      setSummaryRows(summary?.days ?? []);
       setModelRows(byModel ?? []);
       setCurrentMonthProviderRows(byProviderCurrentMonth ?? []);
       setCurrentMonthPurposeRows(byPurposeCurrentMonth ?? []);
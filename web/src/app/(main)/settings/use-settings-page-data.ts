@@ -3,7 +3,7 @@
 import { FormEvent, useCallback, useEffect, useMemo, useRef, useState } from "react";
 import { useSearchParams } from "next/navigation";
 import { useQueryClient } from "@tanstack/react-query";
-import { api, AudioBriefingPersonaVoice, AudioBriefingPreset, AzureSpeechVoiceCatalogEntry, CartesiaVoiceCatalogEntry, ElevenLabsVoiceCatalogEntry, GeminiTTSVoiceCatalogEntry, LLMCatalog, NavigatorPersonaDefinition, NotificationPriorityRule, OpenAITTSVoiceSnapshot, PodcastCategoryOption, PreferenceProfile, UserSettings, XAIVoiceSnapshot } from "@/lib/api";
+import { api, AudioBriefingPersonaVoice, AudioBriefingPreset, AzureSpeechVoiceCatalogEntry, CartesiaVoiceCatalogEntry, ElevenLabsVoiceCatalogEntry, GeminiTTSVoiceCatalogEntry, LLMCatalog, NavigatorPersonaDefinition, NotificationPriorityRule, OpenAITTSVoiceSnapshot, PodcastCategoryOption, PreferenceProfile, UsageLimitStatus, UserSettings, XAIVoiceSnapshot } from "@/lib/api";
 import { useI18n } from "@/components/i18n-provider";
 import { useToast } from "@/components/toast-provider";
 import { useConfirm } from "@/components/confirm-provider";
@@ -146,6 +146,7 @@ export function useSettingsPageData() {
   const [settings, setSettings] = useState<UserSettings | null>(null);
   const [preferenceProfile, setPreferenceProfile] = useState<PreferenceProfile | null>(null);
   const [preferenceProfileError, setPreferenceProfileError] = useState<string | null>(null);
+  const [usageLimits, setUsageLimits] = useState<UsageLimitStatus | null>(null);
   const [budgetUSD, setBudgetUSD] = useState<string>("");
   const [alertEnabled, setAlertEnabled] = useState(false);
   const [thresholdPct, setThresholdPct] = useState<number>(20);
@@ -510,11 +511,12 @@ export function useSettingsPageData() {
     setLoading(true);
     setError(null);
     try {
-      const [settingsResult, catalogResult, personasResult, profileResult] = await Promise.allSettled([
+      const [settingsResult, catalogResult, personasResult, profileResult, usageLimitsResult] = await Promise.allSettled([
         queryClient.fetchQuery(settingsQueryOptions()),
         api.getLLMCatalog(),
         api.getNavigatorPersonas(),
         api.getPreferenceProfile(),
+        api.getUsageLimits(),
       ]);
       const data: UserSettings | null = settingsResult.status === "fulfilled" ? settingsResult.value : null;
       const nextCatalog: LLMCatalog | null = catalogResult.status === "fulfilled" ? catalogResult.value : null;
@@ -573,6 +575,7 @@ export function useSettingsPageData() {
       if (navigatorPersonas) setNavigatorPersonaDefinitions(navigatorPersonas);
       setPreferenceProfile(preferenceProfileResult.profile);
       setPreferenceProfileError(preferenceProfileResult.error);
+      setUsageLimits(usageLimitsResult.status === "fulfilled" ? usageLimitsResult.value : null);
       setError(null);
     } catch (e) {
       if (seq !== loadSeqRef.current) return;
@@ -1883,6 +1886,7 @@ export function useSettingsPageData() {
     thresholdPct,
     budgetRemainingTone,
     monthJst: settings.current_month.month_jst,
+    usageLimits,
   });
 
   const budgetActions = buildBudgetActions({
@@ -14,6 +14,7 @@ import {
 import { Source, SourceHealth, SourceItemStats } from "@/lib/api";
 import { AINavigatorAvatar } from "@/components/briefing/ai-navigator-avatar";
 import Pagination from "@/components/pagination";
+import { SourceCostEstimateButton } from "@/components/sources/source-cost-estimate";
 import { PageTransition } from "@/components/page-transition";
 import { PageHeader } from "@/components/ui/page-header";
 import { Tag } from "@/components/ui/tag";
@@ -713,6 +714,7 @@ function SourceCard({
             <button type="button" onClick={() => void onDelete(src.id)} className="inline-flex min-h-[40px] items-center justify-center rounded-full border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel-strong)] px-4 text-sm text-[var(--color-editorial-ink-soft)]">
               {t("sources.delete")}
             </button>
+            {src.type === "rss" ? <SourceCostEstimateButton sourceID={src.id} /> : null}
           </div>
         </div>
 
@@ -2,7 +2,7 @@
 
 import { useState, useEffect, useCallback, useMemo, useRef } from "react";
 import { useQuery } from "@tanstack/react-query";
-import { api, Source, SourceDailyStats, SourceHealth, SourceItemStats, SourceOptimizationItem, SourceSuggestion, SourcesDailyOverview } from "@/lib/api";
+import { api, APIError, Source, SourceDailyStats, SourceHealth, SourceItemStats, SourceOptimizationItem, SourceSuggestion, SourcesDailyOverview } from "@/lib/api";
 import { useI18n } from "@/components/i18n-provider";
 import { useToast } from "@/components/toast-provider";
 import { useConfirm } from "@/components/confirm-provider";
@@ -251,15 +251,37 @@ export function useSourcesPageData() {
     }
   }, [activeSection, loadDailyStats, loadingDailyStats, sourceDailyStatsByID]);
 
+  // createSourceConfirmingDuplicate asks before adding a feed the API reports as a near-duplicate
+  // of an existing source and retries with force when confirmed. False means the user declined.
+  const createSourceConfirmingDuplicate = async (body: { url: string; title?: string; type?: string }) => {
+    try {
+      await api.createSource(body);
+      return true;
+    } catch (e) {
+      if (!(e instanceof APIError) || e.code !== "duplicate_source") throw e;
+      const existing = (e.details as { existing_source?: Source } | undefined)?.existing_source;
+      const ok = await confirm({
+        title: t("sources.duplicate.title"),
+        message: t("sources.duplicate.message").replace("{{source}}", existing?.title || existing?.url || ""),
+        confirmLabel: t("sources.duplicate.addAnyway"),
+        cancelLabel: t("common.cancel"),
+      });
+      if (!ok) return false;
+      await api.createSource({ ...body, force: true });
+      return true;
+    }
+  };
+
   const registerSource = async (feedUrl: string) => {
     if (adding) return;
     setAdding(true);
     try {
-      await api.createSource({
+      const created = await createSourceConfirmingDuplicate({
         url: feedUrl,
         type,
         title: title.trim() || undefined,
       });
+      if (!created) return;
       setUrl("");
       setTitle("");
       setCandidates([]);
@@ -287,11 +309,12 @@ export function useSourcesPageData() {
       if (!foundFeed) {
         throw new Error(t("sources.suggest.noFeedFound"));
       }
-      await api.createSource({
+      const created = await createSourceConfirmingDuplicate({
         url: targetURL,
         type: "rss",
         title: s.title ?? undefined,
       });
+      if (!created) return;
       setRecommendations((prev) => prev.filter((v) => v.url !== s.url));
       await load();
       void loadDailyStats();
@@ -428,10 +451,12 @@ export function useSourcesPageData() {
     setImportingOPML(true);
     try {
       const text = await file.text();
-      const res = await api.importSourcesOPML(text);
+      const started = await api.importSourcesOPML(text);
+      const res = await api.waitForImportJob(started.id);
+      if (res.status === "failed") throw new Error(res.error_message ?? "import failed");
       await load();
       showToast(
-        `${t("sources.toast.opmlImportedPrefix")}: ${t("sources.toast.opmlImportedAdded")} ${res.added} / ${t("sources.toast.opmlImportedSkipped")} ${res.skipped} / ${t("sources.toast.opmlImportedInvalid")} ${res.invalid}`,
+        `${t("sources.toast.opmlImportedPrefix")}: ${t("sources.toast.opmlImportedAdded")} ${res.added_count} / ${t("sources.toast.opmlImportedSkipped")} ${res.duplicate_count} / ${t("sources.toast.opmlImportedInvalid")} ${res.invalid_count}`,
         "success"
       );
     } catch (e) {
@@ -445,10 +470,12 @@ export function useSourcesPageData() {
   const handleImportInoreader = async () => {
     setImportingInoreader(true);
     try {
-      const res = await api.importInoreaderSources();
+      const started = await api.importInoreaderSources();
+      const res = await api.waitForImportJob(started.id);
+      if (res.status === "failed") throw new Error(res.error_message ?? "import failed");
       await load();
       showToast(
-        `${t("sources.toast.inoreaderImportedPrefix")}: ${t("sources.toast.opmlImportedAdded")} ${res.added} / ${t("sources.toast.opmlImportedSkipped")} ${res.skipped} / ${t("sources.toast.opmlImportedInvalid")} ${res.invalid}`,
+        `${t("sources.toast.inoreaderImportedPrefix")}: ${t("sources.toast.opmlImportedAdded")} ${res.added_count} / ${t("sources.toast.opmlImportedSkipped")} ${res.duplicate_count} / ${t("sources.toast.opmlImportedInvalid")} ${res.invalid_count}`,
         "success"
       );
     } catch (e) {
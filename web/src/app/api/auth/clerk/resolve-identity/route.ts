@@ -1,6 +1,6 @@
 import { NextResponse } from "next/server";
 import { auth, currentUser } from "@clerk/nextjs/server";
-import { getInternalAPISecret, getInternalAPISecretError } from "@/lib/internal-secret";
+import { getInternalAPISecret, getInternalAPISecretError, internalAuthHeaders } from "@/lib/internal-secret";
 import { resolveServerAPIURL } from "@/lib/server-api-url";
 
 function resolveDisplayName(user: Awaited<ReturnType<typeof currentUser>>) {
@@ -33,18 +33,20 @@ export async function POST() {
   }
 
   const apiUrl = resolveServerAPIURL();
-  const res = await fetch(`${apiUrl}/api/internal/users/resolve-identity`, {
+  const url = `${apiUrl}/api/internal/users/resolve-identity`;
+  const body = JSON.stringify({
+    provider: "clerk",
+    provider_user_id: clerkAuth.userId,
+    email,
+    name: resolveDisplayName(user),
+  });
+  const res = await fetch(url, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
-      "X-Internal-Secret": secret,
+      ...internalAuthHeaders(secret, "POST", url, body),
     },
-    body: JSON.stringify({
-      provider: "clerk",
-      provider_user_id: clerkAuth.userId,
-      email,
-      name: resolveDisplayName(user),
-    }),
+    body,
     cache: "no-store",
   });
 
@@ -1,7 +1,7 @@
 import { NextRequest, NextResponse } from "next/server";
-import { getInternalAPISecret, getInternalAPISecretError } from "@/lib/internal-secret";
+import { getInternalAPISecret, getInternalAPISecretError, internalAuthHeaders } from "@/lib/internal-secret";
 import { resolveServerAPIURL } from "@/lib/server-api-url";
-import { authorizeDebugAdmin, internalAdminEmailHeader } from "@/lib/debug-admin";
+import { authorizeDebugAdmin, internalAdminEmail } from "@/lib/debug-admin";
 
 export async function POST(req: NextRequest) {
   const authorization = await authorizeDebugAdmin();
@@ -17,12 +17,12 @@ export async function POST(req: NextRequest) {
   }
 
   const body = await req.text();
-  const res = await fetch(`${apiUrl}/api/internal/debug/digests/generate`, {
+  const url = `${apiUrl}/api/internal/debug/digests/generate`;
+  const res = await fetch(url, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
-      "X-Internal-Secret": secret,
-      ...internalAdminEmailHeader(user),
+      ...internalAuthHeaders(secret, "POST", url, body, internalAdminEmail(user)),
     },
     body,
     cache: "no-store",
@@ -1,7 +1,7 @@
 import { NextRequest, NextResponse } from "next/server";
-import { getInternalAPISecret, getInternalAPISecretError } from "@/lib/internal-secret";
+import { getInternalAPISecret, getInternalAPISecretError, internalAuthHeaders } from "@/lib/internal-secret";
 import { resolveServerAPIURL } from "@/lib/server-api-url";
-import { authorizeDebugAdmin, internalAdminEmailHeader } from "@/lib/debug-admin";
+import { authorizeDebugAdmin, internalAdminEmail } from "@/lib/debug-admin";
 
 export async function GET(req: NextRequest) {
   const authorization = await authorizeDebugAdmin();
@@ -20,11 +20,11 @@ export async function GET(req: NextRequest) {
   const limit = req.nextUrl.searchParams.get("limit");
   if (limit) qs.set("limit", limit);
 
-  const res = await fetch(`${apiUrl}/api/internal/debug/search/backfill${qs.size ? `?${qs.toString()}` : ""}`, {
+  const url = `${apiUrl}/api/internal/debug/search/backfill${qs.size ? `?${qs.toString()}` : ""}`;
+  const res = await fetch(url, {
     method: "GET",
     headers: {
-      "X-Internal-Secret": secret,
-      ...internalAdminEmailHeader(user),
+      ...internalAuthHeaders(secret, "GET", url, "", internalAdminEmail(user)),
     },
     cache: "no-store",
   });
@@ -61,11 +61,11 @@ export async function POST(req: NextRequest) {
   if (typeof payload.limit === "number") qs.set("limit", String(payload.limit));
   if (payload.all === true) qs.set("all", "1");
 
-  const res = await fetch(`${apiUrl}/api/internal/debug/search/backfill${qs.size ? `?${qs.toString()}` : ""}`, {
+  const url = `${apiUrl}/api/internal/debug/search/backfill${qs.size ? `?${qs.toString()}` : ""}`;
+  const res = await fetch(url, {
     method: "POST",
     headers: {
-      "X-Internal-Secret": secret,
-      ...internalAdminEmailHeader(user),
+      ...internalAuthHeaders(secret, "POST", url, "", internalAdminEmail(user)),
     },
     cache: "no-store",
   });
@@ -90,11 +90,11 @@ export async function DELETE() {
     return NextResponse.json({ error: getInternalAPISecretError() }, { status: 500 });
   }
 
-  const res = await fetch(`${apiUrl}/api/internal/debug/search/backfill`, {
+  const url = `${apiUrl}/api/internal/debug/search/backfill`;
+  const res = await fetch(url, {
     method: "DELETE",
     headers: {
-      "X-Internal-Secret": secret,
-      ...internalAdminEmailHeader(user),
+      ...internalAuthHeaders(secret, "DELETE", url, "", internalAdminEmail(user)),
     },
     cache: "no-store",
   });
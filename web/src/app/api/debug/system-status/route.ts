@@ -1,7 +1,7 @@
 import { NextRequest, NextResponse } from "next/server";
-import { getInternalAPISecret, getInternalAPISecretError } from "@/lib/internal-secret";
+import { getInternalAPISecret, getInternalAPISecretError, internalAuthHeaders } from "@/lib/internal-secret";
 import { resolveServerAPIURL } from "@/lib/server-api-url";
-import { authorizeDebugAdmin, internalAdminEmailHeader } from "@/lib/debug-admin";
+import { authorizeDebugAdmin, internalAdminEmail } from "@/lib/debug-admin";
 
 export async function GET(req: NextRequest) {
   const authorization = await authorizeDebugAdmin();
@@ -22,11 +22,11 @@ export async function GET(req: NextRequest) {
   const qs = q.toString();
 
   const start = Date.now();
-  const res = await fetch(`${apiUrl}/api/internal/debug/system-status${qs ? `?${qs}` : ""}`, {
+  const url = `${apiUrl}/api/internal/debug/system-status${qs ? `?${qs}` : ""}`;
+  const res = await fetch(url, {
     method: "GET",
     headers: {
-      "X-Internal-Secret": secret,
-      ...internalAdminEmailHeader(user),
+      ...internalAuthHeaders(secret, "GET", url, "", internalAdminEmail(user)),
     },
     cache: "no-store",
   });
@@ -1,6 +1,6 @@
 import { NextRequest, NextResponse } from "next/server";
 import { auth, currentUser } from "@clerk/nextjs/server";
-import { getInternalAPISecret, getInternalAPISecretError } from "@/lib/internal-secret";
+import { getInternalAPISecret, getInternalAPISecretError, internalAuthHeaders } from "@/lib/internal-secret";
 import { resolveServerAPIURL } from "@/lib/server-api-url";
 
 function appBaseURL(req: NextRequest): string {
@@ -46,18 +46,20 @@ export async function GET(req: NextRequest) {
   }
 
   const apiURL = resolveServerAPIURL();
-  const resolveIdentity = await fetch(`${apiURL}/api/internal/users/resolve-identity`, {
+  const resolveURL = `${apiURL}/api/internal/users/resolve-identity`;
+  const resolveBody = JSON.stringify({
+    provider: "clerk",
+    provider_user_id: clerkAuth.userId,
+    email,
+    name: resolveDisplayName(user),
+  });
+  const resolveIdentity = await fetch(resolveURL, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
-      "X-Internal-Secret": secret,
+      ...internalAuthHeaders(secret, "POST", resolveURL, resolveBody),
     },
-    body: JSON.stringify({
-      provider: "clerk",
-      provider_user_id: clerkAuth.userId,
-      email,
-      name: resolveDisplayName(user),
-    }),
+    body: resolveBody,
     cache: "no-store",
   });
   if (!resolveIdentity.ok) {
@@ -69,16 +71,18 @@ export async function GET(req: NextRequest) {
     return redirectWithStatus(req, "error&reason=identity");
   }
 
-  const saveRes = await fetch(`${apiURL}/api/internal/settings/obsidian-github/installation`, {
+  const saveURL = `${apiURL}/api/internal/settings/obsidian-github/installation`;
+  const saveBody = JSON.stringify({
+    user_id: internalUserID,
+    installation_id: installationID,
+  });
+  const saveRes = await fetch(saveURL, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
-      "X-Internal-Secret": secret,
+      ...internalAuthHeaders(secret, "POST", saveURL, saveBody),
     },
-    body: JSON.stringify({
-      user_id: internalUserID,
-      installation_id: installationID,
-    }),
+    body: saveBody,
     cache: "no-store",
   });
   if (!saveRes.ok) {
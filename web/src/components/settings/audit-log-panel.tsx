@@ -0,0 +1,73 @@
+"use client";
+
+import { useCallback, useEffect, useState } from "react";
+import { api, AuditLogEntry } from "@/lib/api";
+import { useI18n } from "@/components/i18n-provider";
+import { SectionCard } from "@/components/ui/section-card";
+
+export function AuditLogPanel() {
+  const { t, locale } = useI18n();
+  const [entries, setEntries] = useState<AuditLogEntry[]>([]);
+  const [nextBefore, setNextBefore] = useState<string | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const load = useCallback(async (before?: string) => {
+    setLoading(true);
+    setError(null);
+    try {
+      const page = await api.getAuditLog({ before, limit: 20 });
+      setEntries((prev) => (before ? [...prev, ...page.entries] : page.entries));
+      setNextBefore(page.next_before);
+    } catch (e) {
+      setError(String(e));
+    } finally {
+      setLoading(false);
+    }
+  }, []);
+
+  useEffect(() => {
+    void load();
+  }, [load]);
+
+  return (
+    <SectionCard>
+      <div className="text-sm font-semibold text-[var(--color-editorial-ink)]">{t("settings.auditLog.title")}</div>
+      <p className="mt-1 text-[12px] leading-6 text-[var(--color-editorial-ink-soft)]">{t("settings.auditLog.help")}</p>
+      {error ? <p className="mt-3 text-sm text-red-700">{t("settings.auditLog.loadFailed")}</p> : null}
+      {!error && !loading && entries.length === 0 ? (
+        <p className="mt-3 text-sm text-[var(--color-editorial-ink-soft)]">{t("settings.auditLog.empty")}</p>
+      ) : null}
+      {entries.length > 0 ? (
+        <ul className="mt-4 divide-y divide-[var(--color-editorial-line)] rounded-[14px] border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel-strong)]">
+          {entries.map((entry) => (
+            <li key={entry.id} className="flex flex-wrap items-baseline justify-between gap-2 px-4 py-3">
+              <div className="min-w-0">
+                <div className="text-sm font-medium text-[var(--color-editorial-ink)]">
+                  {t(`settings.auditLog.action.${entry.action}`, entry.action)}
+                  {entry.target ? <span className="ml-2 text-[var(--color-editorial-ink-soft)]">{entry.target}</span> : null}
+                </div>
+                <div className="mt-1 truncate text-[12px] text-[var(--color-editorial-ink-faint)]">
+                  {[entry.ip_address, entry.user_agent].filter(Boolean).join(" · ")}
+                </div>
+              </div>
+              <time className="text-[12px] tabular-nums text-[var(--color-editorial-ink-soft)]" dateTime={entry.created_at}>
+                {new Date(entry.created_at).toLocaleString(locale === "ja" ? "ja-JP" : "en-US", { timeZone: "Asia/Tokyo" })}
+              </time>
+            </li>
+          ))}
+        </ul>
+      ) : null}
+      {nextBefore ? (
+        <button
+          type="button"
+          disabled={loading}
+          onClick={() => void load(nextBefore)}
+          className="mt-4 inline-flex min-h-10 items-center rounded-full border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel)] px-4 py-2 text-sm font-medium text-[var(--color-editorial-ink-soft)] disabled:opacity-60"
+        >
+          {loading ? t("common.loading") : t("settings.auditLog.loadMore")}
+        </button>
+      ) : null}
+    </SectionCard>
+  );
+}
@@ -0,0 +1,100 @@
+"use client";
+
+import { useCallback, useEffect, useState } from "react";
+import { api, ProcessingPauseStatus, ProcessingResumeResult } from "@/lib/api";
+import { useI18n } from "@/components/i18n-provider";
+import { SectionCard } from "@/components/ui/section-card";
+import { Tag } from "@/components/ui/tag";
+
+export function ProcessingPausePanel() {
+  const { t, locale } = useI18n();
+  const [status, setStatus] = useState<ProcessingPauseStatus | null>(null);
+  const [resumed, setResumed] = useState<ProcessingResumeResult | null>(null);
+  const [error, setError] = useState<string | null>(null);
+  const [updating, setUpdating] = useState(false);
+
+  const load = useCallback(async () => {
+    setError(null);
+    try {
+      setStatus(await api.getProcessingPause());
+    } catch (e) {
+      setError(String(e));
+    }
+  }, []);
+
+  useEffect(() => {
+    void load();
+  }, [load]);
+
+  async function toggle() {
+    if (!status) return;
+    setUpdating(true);
+    setError(null);
+    try {
+      if (status.paused) {
+        const res = await api.resumeProcessing();
+        setStatus(res);
+        setResumed(res);
+      } else {
+        setStatus(await api.pauseProcessing());
+        setResumed(null);
+      }
+    } catch (e) {
+      setError(String(e));
+    } finally {
+      setUpdating(false);
+    }
+  }
+
+  const formatTime = (value?: string) =>
+    value ? new Date(value).toLocaleString(locale === "ja" ? "ja-JP" : "en-US", { timeZone: "Asia/Tokyo" }) : "";
+
+  return (
+    <SectionCard>
+      <div className="flex flex-wrap items-start justify-between gap-4">
+        <div className="min-w-0">
+          <div className="flex flex-wrap items-center gap-2 text-sm font-semibold text-[var(--color-editorial-ink)]">
+            {t("settings.processingPause.title")}
+            {status ? (
+              <Tag tone={status.paused ? "warning" : "success"}>
+                {status.paused ? t("settings.processingPause.paused") : t("settings.processingPause.running")}
+              </Tag>
+            ) : null}
+          </div>
+          <p className="mt-1 text-[12px] leading-6 text-[var(--color-editorial-ink-soft)]">{t("settings.processingPause.help")}</p>
+        </div>
+        <button
+          type="button"
+          disabled={!status || updating}
+          onClick={() => void toggle()}
+          className="inline-flex min-h-9 items-center rounded-full border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel)] px-3 py-1.5 text-[13px] font-medium text-[var(--color-editorial-ink-soft)] disabled:opacity-60"
+        >
+          {updating
+            ? t("settings.processingPause.updating")
+            : status?.paused
+              ? t("settings.processingPause.resume")
+              : t("settings.processingPause.pause")}
+        </button>
+      </div>
+      {error ? <p className="mt-3 text-sm text-red-700">{t("settings.processingPause.loadFailed")}</p> : null}
+      {status ? (
+        <div className="mt-3 space-y-1 text-[12px] tabular-nums text-[var(--color-editorial-ink-soft)]">
+          {status.paused && status.paused_at ? (
+            <div>{t("settings.processingPause.pausedSince").replace("{{time}}", formatTime(status.paused_at))}</div>
+          ) : null}
+          <div>{t("settings.processingPause.backlog").replace("{{count}}", String(status.backlog))}</div>
+          {resumed && resumed.queued > 0 ? (
+            <div>
+              {t("settings.processingPause.resumed")
+                .replace("{{queued}}", String(resumed.queued))
+                .replace("{{minutes}}", String(resumed.backfill_minutes))}
+            </div>
+          ) : null}
+          {resumed && resumed.remaining > 0 ? (
+            <div>{t("settings.processingPause.remaining").replace("{{count}}", String(resumed.remaining))}</div>
+          ) : null}
+        </div>
+      ) : null}
+    </SectionCard>
+  );
+}
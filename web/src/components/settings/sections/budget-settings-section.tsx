@@ -1,6 +1,7 @@
 "use client";
 
 import type { FormEvent } from "react";
+import type { UsageLimitStatus } from "@/lib/api";
 import { SectionCard } from "@/components/ui/section-card";
 
 type Translate = (key: string, fallback?: string) => string;
@@ -22,6 +23,7 @@ export default function BudgetSettingsSection({
     thresholdPct: number;
     budgetRemainingTone: string;
     monthJst: string;
+    usageLimits: UsageLimitStatus | null;
   };
   actions: {
     onChangeBudgetUSD: (value: string) => void;
@@ -30,7 +32,7 @@ export default function BudgetSettingsSection({
   };
 }) {
   const { onSubmit, saving } = form;
-  const { budgetUSD, alertEnabled, thresholdPct, budgetRemainingTone, monthJst } = state;
+  const { budgetUSD, alertEnabled, thresholdPct, budgetRemainingTone, monthJst, usageLimits } = state;
   const { onChangeBudgetUSD, onChangeAlertEnabled, onChangeThresholdPct } = actions;
 
   return (
@@ -85,6 +87,30 @@ export default function BudgetSettingsSection({
             </div>
           </div>
         </div>
+        {usageLimits ? (
+          <div className="grid gap-4 border-t border-[var(--color-editorial-line)] pt-5 lg:grid-cols-[minmax(0,240px)_minmax(0,1fr)] lg:gap-6">
+            <div>
+              <div className="text-sm font-semibold text-[var(--color-editorial-ink)]">{t("settings.usageLimits.title")}</div>
+              <p className="mt-1 text-[12px] leading-6 text-[var(--color-editorial-ink-soft)]">
+                {t("settings.usageLimits.help").replace("{{plan}}", t(`settings.usageLimits.plan.${usageLimits.plan}`, usageLimits.plan))}
+              </p>
+            </div>
+            <div className="grid gap-3 sm:grid-cols-2">
+              <UsageLimitMeter
+                t={t}
+                label={t("settings.usageLimits.sources")}
+                used={usageLimits.sources}
+                max={usageLimits.limits.max_sources}
+              />
+              <UsageLimitMeter
+                t={t}
+                label={t("settings.usageLimits.llmItemsToday")}
+                used={usageLimits.llm_items_today}
+                max={usageLimits.limits.max_llm_items_per_day}
+              />
+            </div>
+          </div>
+        ) : null}
         <div className="flex flex-wrap items-center gap-3">
           <button
             type="submit"
@@ -101,3 +127,23 @@ export default function BudgetSettingsSection({
     </SectionCard>
   );
 }
+
+function UsageLimitMeter({ t, label, used, max }: { t: Translate; label: string; used: number; max: number }) {
+  const percent = max > 0 ? Math.min(100, Math.round((used / max) * 100)) : 0;
+  return (
+    <div className="rounded-[14px] border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel-strong)] px-4 py-3">
+      <div className="text-[10px] font-semibold uppercase tracking-[0.16em] text-[var(--color-editorial-ink-faint)]">{label}</div>
+      <div className="mt-2 text-sm font-medium tabular-nums text-[var(--color-editorial-ink)]">
+        {max > 0 ? `${used} / ${max}` : `${used} / ${t("settings.usageLimits.unlimited")}`}
+      </div>
+      {max > 0 ? (
+        <div className="mt-2 h-1.5 rounded-full bg-[#e9e1d3]">
+          <div
+            className={`h-1.5 rounded-full ${percent >= 100 ? "bg-red-500" : "bg-[var(--color-editorial-ink)]"}`}
+            style={{ width: `${Math.max(percent, 4)}%` }}
+          />
+        </div>
+      ) : null}
+    </div>
+  );
+}
@@ -1,6 +1,7 @@
 "use client";
 
 import type { FormEvent } from "react";
+import type { UsageLimitStatus } from "@/lib/api";
 
 export function buildAudioBriefingSettingsState<T extends {
   presetsLoading: boolean;
@@ -284,6 +285,7 @@ export function buildBudgetState<T extends {
   thresholdPct: number;
   budgetRemainingTone: string;
   monthJst: string;
+  usageLimits: UsageLimitStatus | null;
 }>(params: T): T {
   return { ...params };
 }
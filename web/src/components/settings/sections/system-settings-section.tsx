@@ -3,6 +3,9 @@
 import type { FormEvent } from "react";
 import { KeyRound } from "lucide-react";
 import ApiKeyCard from "@/components/settings/api-key-card";
+import { AuditLogPanel } from "@/components/settings/audit-log-panel";
+import { ProcessingPausePanel } from "@/components/settings/processing-pause-panel";
+import { SessionsPanel } from "@/components/settings/sessions-panel";
 import { SectionCard } from "@/components/ui/section-card";
 
 type Translate = (key: string, fallback?: string) => string;
@@ -222,6 +225,12 @@ export default function SystemSettingsSection({
           labels={{ ...apiKeyCardLabels, notSet: activeAccessCard.notSet }}
         />
       ) : null}
+
+      <ProcessingPausePanel />
+
+      <SessionsPanel />
+
+      <AuditLogPanel />
     </div>
   );
 }
@@ -0,0 +1,89 @@
+"use client";
+
+import { useCallback, useEffect, useState } from "react";
+import { api, UserSession } from "@/lib/api";
+import { useI18n } from "@/components/i18n-provider";
+import { SectionCard } from "@/components/ui/section-card";
+import { Tag } from "@/components/ui/tag";
+
+export function SessionsPanel() {
+  const { t, locale } = useI18n();
+  const [sessions, setSessions] = useState<UserSession[]>([]);
+  const [loaded, setLoaded] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const [revokingID, setRevokingID] = useState<string | null>(null);
+
+  const load = useCallback(async () => {
+    setError(null);
+    try {
+      const res = await api.getSessions();
+      setSessions(res.sessions);
+    } catch (e) {
+      setError(String(e));
+    } finally {
+      setLoaded(true);
+    }
+  }, []);
+
+  useEffect(() => {
+    void load();
+  }, [load]);
+
+  async function revoke(session: UserSession) {
+    const confirmKey = session.current ? "settings.sessions.confirmRevokeCurrent" : "settings.sessions.confirmRevoke";
+    if (!window.confirm(t(confirmKey))) return;
+    setRevokingID(session.id);
+    try {
+      await api.revokeSession(session.id);
+      setSessions((prev) => prev.filter((s) => s.id !== session.id));
+    } catch (e) {
+      setError(String(e));
+    } finally {
+      setRevokingID(null);
+    }
+  }
+
+  const formatTime = (value?: string) =>
+    value ? new Date(value).toLocaleString(locale === "ja" ? "ja-JP" : "en-US", { timeZone: "Asia/Tokyo" }) : null;
+
+  return (
+    <SectionCard>
+      <div className="text-sm font-semibold text-[var(--color-editorial-ink)]">{t("settings.sessions.title")}</div>
+      <p className="mt-1 text-[12px] leading-6 text-[var(--color-editorial-ink-soft)]">{t("settings.sessions.help")}</p>
+      {error ? <p className="mt-3 text-sm text-red-700">{t("settings.sessions.loadFailed")}</p> : null}
+      {loaded && !error && sessions.length === 0 ? (
+        <p className="mt-3 text-sm text-[var(--color-editorial-ink-soft)]">{t("settings.sessions.empty")}</p>
+      ) : null}
+      {sessions.length > 0 ? (
+        <ul className="mt-4 divide-y divide-[var(--color-editorial-line)] rounded-[14px] border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel-strong)]">
+          {sessions.map((session) => (
+            <li key={session.id} className="flex flex-wrap items-center justify-between gap-3 px-4 py-3">
+              <div className="min-w-0">
+                <div className="flex flex-wrap items-center gap-2 text-sm font-medium text-[var(--color-editorial-ink)]">
+                  {t(`settings.sessions.kind.${session.kind}`, session.kind)}
+                  {session.current ? <Tag tone="success">{t("settings.sessions.current")}</Tag> : null}
+                </div>
+                <div className="mt-1 truncate text-[12px] text-[var(--color-editorial-ink-faint)]">
+                  {[session.ip_address, session.user_agent].filter(Boolean).join(" · ")}
+                </div>
+                {session.last_used_at ? (
+                  <div className="mt-1 text-[12px] tabular-nums text-[var(--color-editorial-ink-soft)]">
+                    {t("settings.sessions.lastUsed").replace("{{time}}", formatTime(session.last_used_at) ?? "")}
+                  </div>
+                ) : null}
+              </div>
+              <button
+                type="button"
+                disabled={revokingID === session.id}
+                onClick={() => void revoke(session)}
+                className="inline-flex min-h-9 items-center rounded-full border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel)] px-3 py-1.5 text-[13px] font-medium text-[var(--color-editorial-ink-soft)] disabled:opacity-60"
+              >
+                {revokingID === session.id ? t("settings.sessions.revoking") : t("settings.sessions.revoke")}
+              </button>
+            </li>
+          ))}
+        </ul>
+      ) : null}
+    </SectionCard>
+  );
+}
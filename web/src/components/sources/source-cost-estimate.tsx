@@ -0,0 +1,79 @@
+"use client";
+
+import { useState } from "react";
+import { api, SourceCostEstimate } from "@/lib/api";
+import { useI18n } from "@/components/i18n-provider";
+
+function formatUSD(value: number) {
+  return `$${value < 1 ? value.toFixed(4) : value.toFixed(2)}`;
+}
+
+export function SourceCostEstimateButton({ sourceID }: { sourceID: string }) {
+  const { t } = useI18n();
+  const [estimate, setEstimate] = useState<SourceCostEstimate | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  async function run() {
+    setLoading(true);
+    setError(null);
+    try {
+      setEstimate(await api.estimateSourceCost(sourceID));
+    } catch (e) {
+      setError(String(e));
+    } finally {
+      setLoading(false);
+    }
+  }
+
+  const unpriced = estimate?.stages.filter((stage) => !stage.priced) ?? [];
+
+  return (
+    <>
+      <button
+        type="button"
+        disabled={loading}
+        onClick={() => void run()}
+        className="inline-flex min-h-[40px] items-center justify-center rounded-full border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel-strong)] px-4 text-sm text-[var(--color-editorial-ink-soft)] disabled:opacity-60"
+      >
+        {loading ? t("sources.estimate.running") : t("sources.estimate.run")}
+      </button>
+      {error ? <p className="basis-full text-xs text-red-700">{t("sources.estimate.failed")}</p> : null}
+      {estimate ? (
+        <div className="basis-full rounded-[16px] border border-[var(--color-editorial-line)] bg-[var(--color-editorial-panel)] px-3 py-3 text-xs leading-6 text-[var(--color-editorial-ink-soft)]">
+          {estimate.sampled_entries === 0 ? (
+            <div>{t("sources.estimate.empty")}</div>
+          ) : (
+            <>
+              <div className="text-sm font-semibold tabular-nums text-[var(--color-editorial-ink)]">
+                {t("sources.estimate.monthlyCost").replace("{{cost}}", formatUSD(estimate.monthly_cost_usd))}
+              </div>
+              <div className="tabular-nums">
+                {t("sources.estimate.volume")
+                  .replace("{{entries}}", estimate.entries_per_month.toFixed(1))
+                  .replace("{{tokens}}", (estimate.monthly_input_tokens + estimate.monthly_output_tokens).toLocaleString())
+                  .replace("{{perItem}}", formatUSD(estimate.cost_per_item_usd))}
+              </div>
+              <div className="tabular-nums">
+                {t("sources.estimate.sample")
+                  .replace("{{sampled}}", String(estimate.sampled_entries))
+                  .replace("{{chars}}", estimate.avg_content_chars.toLocaleString())
+                  .replace("{{stored}}", String(estimate.stored_bodies))}
+              </div>
+              <ul className="mt-1 tabular-nums">
+                {estimate.stages.map((stage) => (
+                  <li key={stage.purpose}>
+                    {t(`sources.estimate.stage.${stage.purpose}`)}: {stage.model} ·{" "}
+                    {stage.priced ? formatUSD(stage.monthly_cost_usd) : t("sources.estimate.unpriced")}
+                  </li>
+                ))}
+              </ul>
+              {!estimate.frequency_known ? <div className="mt-1">{t("sources.estimate.noDates")}</div> : null}
+              {unpriced.length > 0 ? <div className="mt-1">{t("sources.estimate.unpricedNote")}</div> : null}
+            </>
+          )}
+        </div>
+      ) : null}
+    </>
+  );
+}
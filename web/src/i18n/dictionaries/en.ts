@@ -515,6 +515,7 @@ export const enDict: Dict = {
   "digestDetail.heroFallback": "The archive note for this issue is not ready yet. Review the body and included items below.",
   "digestDetail.createdAt": "Created",
   "digestDetail.sentAt": "Sent at",
+  "digestDetail.statusHistory": "Status history",
   "digestDetail.digestRetryCount": "Digest regeneration count",
   "digestDetail.clusterDraftRetryCount": "Cluster draft regeneration count",
   "digestDetail.emailSubject": "Email Subject",
@@ -586,9 +587,26 @@ export const enDict: Dict = {
   "sources.add": "Add",
   "sources.adding": "Adding…",
   "sources.delete": "Delete",
+  "sources.estimate.run": "Estimate cost",
+  "sources.estimate.running": "Estimating...",
+  "sources.estimate.failed": "Could not read the feed to estimate its cost.",
+  "sources.estimate.empty": "The feed has no entries to estimate from.",
+  "sources.estimate.monthlyCost": "About {{cost}} per month",
+  "sources.estimate.volume": "{{entries}} entries/month · {{tokens}} tokens/month · {{perItem}} per entry",
+  "sources.estimate.sample": "From the latest {{sampled}} entries, {{chars}} characters on average ({{stored}} measured from extracted bodies)",
+  "sources.estimate.stage.facts": "Fact extraction",
+  "sources.estimate.stage.facts_check": "Fact check",
+  "sources.estimate.stage.summary": "Summary",
+  "sources.estimate.stage.faithfulness_check": "Faithfulness check",
+  "sources.estimate.unpriced": "price unknown",
+  "sources.estimate.noDates": "The feed has no dates, so the volume assumes one feed's worth of entries per month.",
+  "sources.estimate.unpricedNote": "Steps with an unknown model price are not included in the cost.",
   "sources.lastFetched": "Last fetched",
   "sources.rss": "RSS Feed",
   "sources.manual": "Manual URL",
+  "sources.duplicate.title": "This source already exists",
+  "sources.duplicate.message": "This looks like the same feed as \"{{source}}\". Add it anyway?",
+  "sources.duplicate.addAnyway": "Add anyway",
   "sources.confirmDelete": "Delete this source?",
   "sources.edit": "Edit",
   "sources.openItems": "Open items",
@@ -2196,6 +2214,55 @@ export const enDict: Dict = {
   "settings.budgetPlaceholder": "Leave empty to disable",
   "settings.budgetAlertEmail": "Budget alerts",
   "settings.budgetAlertHint": "Email uses Resend and push uses OneSignal when enabled",
+  "settings.usageLimits.title": "Usage limits",
+  "settings.usageLimits.help": "Limits of the {{plan}} plan. Once reached, adding sources and processing new items for the day stop. Item counts reset at midnight JST.",
+  "settings.usageLimits.sources": "Sources",
+  "settings.usageLimits.llmItemsToday": "Items processed today",
+  "settings.usageLimits.unlimited": "unlimited",
+  "settings.usageLimits.plan.free": "Free",
+  "settings.usageLimits.plan.pro": "Pro",
+  "settings.usageLimits.plan.unlimited": "Unlimited",
+  "settings.auditLog.title": "Account activity",
+  "settings.auditLog.help": "API key, integration, mail server, budget, token and export actions are recorded with their IP address and user agent.",
+  "settings.auditLog.empty": "No activity recorded yet.",
+  "settings.auditLog.loadFailed": "Could not load account activity.",
+  "settings.auditLog.loadMore": "Load more",
+  "settings.auditLog.action.api_key.set": "API key set",
+  "settings.auditLog.action.api_key.delete": "API key deleted",
+  "settings.auditLog.action.oauth.connect": "Service connected",
+  "settings.auditLog.action.oauth.disconnect": "Service disconnected",
+  "settings.auditLog.action.budget.update": "Budget changed",
+  "settings.auditLog.action.budget.enforcement_update": "Budget enforcement changed",
+  "settings.auditLog.action.token.create": "Token created",
+  "settings.auditLog.action.token.revoke": "Token revoked",
+  "settings.auditLog.action.account.export": "Data exported",
+  "settings.sessions.title": "Sessions and tokens",
+  "settings.sessions.help": "Sessions that used the API in the last 30 days and issued feed tokens. Revoking a session stops its API access within about a minute.",
+  "settings.sessions.empty": "No active sessions.",
+  "settings.sessions.loadFailed": "Could not load sessions.",
+  "settings.sessions.current": "This device",
+  "settings.sessions.lastUsed": "Last used: {{time}}",
+  "settings.sessions.revoke": "Revoke",
+  "settings.sessions.revoking": "Revoking...",
+  "settings.sessions.confirmRevoke": "Revoke this session?",
+  "settings.sessions.confirmRevokeCurrent": "Revoking this device's session blocks API access until you sign in again. Revoke it?",
+  "settings.sessions.kind.browser": "Browser session",
+  "settings.sessions.kind.digest_feed": "Digest feed token",
+  "settings.auditLog.action.session.revoke": "Session revoked",
+  "settings.auditLog.action.smtp.set": "Mail server settings saved",
+  "settings.auditLog.action.smtp.delete": "Mail server settings removed",
+  "settings.processingPause.title": "Pause processing",
+  "settings.processingPause.help": "While paused, feeds are still fetched but summaries, embeddings and scheduled digests and briefings stop, so no LLM cost is incurred. On resume, waiting items are processed in batches of 20 per minute.",
+  "settings.processingPause.paused": "Paused",
+  "settings.processingPause.running": "Running",
+  "settings.processingPause.pausedSince": "Paused since {{time}}",
+  "settings.processingPause.backlog": "Waiting items: {{count}}",
+  "settings.processingPause.pause": "Pause",
+  "settings.processingPause.resume": "Resume",
+  "settings.processingPause.updating": "Updating...",
+  "settings.processingPause.resumed": "Queued {{queued}} items. The backfill finishes in about {{minutes}} min.",
+  "settings.processingPause.remaining": "{{count}} more items will be picked up automatically later.",
+  "settings.processingPause.loadFailed": "Could not load the processing state.",
   "settings.alertThreshold": "Alert threshold (remaining budget %)",
   "openrouterModels.title": "OpenRouter Models",
   "openrouterModels.subtitle": "Browse synced OpenRouter experimental models grouped by upstream provider.",
@@ -2508,6 +2575,8 @@ export const enDict: Dict = {
   "itemDetail.restore.restoring": "Restoring...",
   "itemDetail.failureReason": "Failure Reason",
   "itemDetail.processingMessage": "Processing Message",
+  "itemDetail.archivedCopy": "You are reading an archived snapshot because the original page was unavailable or paywalled.",
+  "itemDetail.archivedCopyLink": "Open snapshot",
   "itemDetail.execution.summary": "Summary execution history",
   "itemDetail.execution.facts": "Facts extraction history",
   "itemDetail.execution.success": "Success",
@@ -515,6 +515,7 @@ export const jaDict: Dict = {
   "digestDetail.heroFallback": "この号の本文要旨はまだ整っていません。本文面と含まれる記事から内容を確認してください。",
   "digestDetail.createdAt": "作成日時",
   "digestDetail.sentAt": "送信日時",
+  "digestDetail.statusHistory": "ステータス履歴",
   "digestDetail.digestRetryCount": "本文再生成回数",
   "digestDetail.clusterDraftRetryCount": "クラスタ草稿再生成回数",
   "digestDetail.emailSubject": "メール件名",
@@ -586,9 +587,26 @@ export const jaDict: Dict = {
   "sources.add": "追加",
   "sources.adding": "追加中…",
   "sources.delete": "削除",
+  "sources.estimate.run": "コストを試算",
+  "sources.estimate.running": "試算中...",
+  "sources.estimate.failed": "フィードを読み込めず、コストを試算できませんでした。",
+  "sources.estimate.empty": "試算に使える記事がフィードにありません。",
+  "sources.estimate.monthlyCost": "月あたり約 {{cost}}",
+  "sources.estimate.volume": "月 {{entries}} 件 · 月 {{tokens}} トークン · 1 件あたり {{perItem}}",
+  "sources.estimate.sample": "最新 {{sampled}} 件から算出、平均 {{chars}} 文字 (うち {{stored}} 件は抽出済み本文で計測)",
+  "sources.estimate.stage.facts": "事実抽出",
+  "sources.estimate.stage.facts_check": "事実チェック",
+  "sources.estimate.stage.summary": "要約",
+  "sources.estimate.stage.faithfulness_check": "忠実性チェック",
+  "sources.estimate.unpriced": "料金不明",
+  "sources.estimate.noDates": "フィードに日付がないため、月にフィード 1 回分の記事が届く想定で試算しています。",
+  "sources.estimate.unpricedNote": "料金が不明なモデルの工程はコストに含まれていません。",
   "sources.lastFetched": "最終取得",
   "sources.rss": "RSSフィード",
   "sources.manual": "手動URL",
+  "sources.duplicate.title": "登録済みのソースと重複しています",
+  "sources.duplicate.message": "「{{source}}」と同じフィードのようです。それでも追加しますか？",
+  "sources.duplicate.addAnyway": "追加する",
   "sources.confirmDelete": "このソースを削除しますか？",
   "sources.edit": "編集",
   "sources.openItems": "記事を見る",
@@ -2196,6 +2214,55 @@ export const jaDict: Dict = {
   "settings.budgetPlaceholder": "未設定で無効",
   "settings.budgetAlertEmail": "予算警告通知",
   "settings.budgetAlertHint": "メールは Resend、プッシュは OneSignal が有効な環境で送信されます",
+  "settings.usageLimits.title": "利用上限",
+  "settings.usageLimits.help": "{{plan}} プランの上限です。上限に達するとソースの追加と当日の新着記事の処理が止まります。記事数は日本時間の0時にリセットされます。",
+  "settings.usageLimits.sources": "ソース数",
+  "settings.usageLimits.llmItemsToday": "本日の処理記事数",
+  "settings.usageLimits.unlimited": "無制限",
+  "settings.usageLimits.plan.free": "Free",
+  "settings.usageLimits.plan.pro": "Pro",
+  "settings.usageLimits.plan.unlimited": "Unlimited",
+  "settings.auditLog.title": "アカウントの操作履歴",
+  "settings.auditLog.help": "API キー、外部連携、メールサーバー、予算、トークン、エクスポートに関する操作を IP アドレスとユーザーエージェント付きで記録しています。",
+  "settings.auditLog.empty": "まだ記録された操作はありません。",
+  "settings.auditLog.loadFailed": "操作履歴を読み込めませんでした。",
+  "settings.auditLog.loadMore": "さらに読み込む",
+  "settings.auditLog.action.api_key.set": "API キーを設定",
+  "settings.auditLog.action.api_key.delete": "API キーを削除",
+  "settings.auditLog.action.oauth.connect": "外部サービスと連携",
+  "settings.auditLog.action.oauth.disconnect": "外部サービスとの連携を解除",
+  "settings.auditLog.action.budget.update": "予算を変更",
+  "settings.auditLog.action.budget.enforcement_update": "予算の上限制御を変更",
+  "settings.auditLog.action.token.create": "トークンを発行",
+  "settings.auditLog.action.token.revoke": "トークンを無効化",
+  "settings.auditLog.action.account.export": "データをエクスポート",
+  "settings.sessions.title": "ログイン中のセッションとトークン",
+  "settings.sessions.help": "過去 30 日に API を利用したセッションと発行中のフィードトークンです。取り消すとそのセッションからの API 利用は約 1 分以内に止まります。",
+  "settings.sessions.empty": "有効なセッションはありません。",
+  "settings.sessions.loadFailed": "セッションを読み込めませんでした。",
+  "settings.sessions.current": "この端末",
+  "settings.sessions.lastUsed": "最終利用: {{time}}",
+  "settings.sessions.revoke": "取り消す",
+  "settings.sessions.revoking": "取り消し中...",
+  "settings.sessions.confirmRevoke": "このセッションを取り消しますか？",
+  "settings.sessions.confirmRevokeCurrent": "この端末のセッションを取り消すと、再ログインするまで API を利用できません。取り消しますか？",
+  "settings.sessions.kind.browser": "ブラウザのセッション",
+  "settings.sessions.kind.digest_feed": "ダイジェストフィードのトークン",
+  "settings.auditLog.action.session.revoke": "セッションを取り消し",
+  "settings.auditLog.action.smtp.set": "メールサーバー設定を保存",
+  "settings.auditLog.action.smtp.delete": "メールサーバー設定を削除",
+  "settings.processingPause.title": "処理の一時停止",
+  "settings.processingPause.help": "一時停止中もフィードの取得は続きますが、要約・埋め込み、Digest やブリーフィングの定期生成は止まり、LLM の費用は発生しません。再開すると、待機中の記事を 1 分あたり 20 件ずつ処理します。",
+  "settings.processingPause.paused": "一時停止中",
+  "settings.processingPause.running": "稼働中",
+  "settings.processingPause.pausedSince": "{{time}} から一時停止中",
+  "settings.processingPause.backlog": "待機中の記事: {{count}} 件",
+  "settings.processingPause.pause": "一時停止",
+  "settings.processingPause.resume": "再開",
+  "settings.processingPause.updating": "更新中...",
+  "settings.processingPause.resumed": "{{queued}} 件をキューに追加しました。約 {{minutes}} 分で処理が終わります。",
+  "settings.processingPause.remaining": "残りの {{count}} 件は後で自動的に処理されます。",
+  "settings.processingPause.loadFailed": "処理の状態を読み込めませんでした。",
   "settings.alertThreshold": "警告しきい値（残予算率 %）",
   "openrouterModels.title": "OpenRouter Models",
   "openrouterModels.subtitle": "OpenRouter 同期済みの実験モデルを provider ごとに確認できます。",
@@ -2508,6 +2575,8 @@ export const jaDict: Dict = {
   "itemDetail.restore.restoring": "復元中...",
   "itemDetail.failureReason": "失敗理由",
   "itemDetail.processingMessage": "処理メッセージ",
+  "itemDetail.archivedCopy": "元のページが取得できないか有料記事だったため、アーカイブされたスナップショットを表示しています。",
+  "itemDetail.archivedCopyLink": "スナップショットを開く",
   "itemDetail.execution.summary": "要約の実行履歴",
   "itemDetail.execution.facts": "事実抽出の実行履歴",
   "itemDetail.execution.success": "成功",
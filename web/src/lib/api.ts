@@ -12,9 +12,16 @@ import type {
   AivisUserDictionariesResponse,
   AskCandidate,
   AskCitation,
+  Collection,
+  CollectionItem,
+  CollectionSummary,
   AskInsight,
   AskNavigatorResponse,
   AskResponse,
+  FeedAnswerResponse,
+  FeedbackReason,
+  FilterRule,
+  FilterSuggestion,
   AudioBriefingDetailResponse,
   AudioBriefingJob,
   AudioBriefingPersonaVoice,
@@ -26,9 +33,34 @@ import type {
   BriefingNavigatorResponse,
   BriefingTodayResponse,
   CreatePlaybackSessionRequest,
+  DashboardHistoryResponse,
+  DashboardLayout,
+  DashboardLayoutResponse,
   DashboardSnapshot,
+  DashboardWidgetName,
+  DashboardWidgetResponse,
   Digest,
+  DigestApprovalSettings,
+  InoreaderSyncSettings,
+  ReadingStreakStatus,
+  StreakSettings,
+  LLMConcurrencySettings,
+  PrescreenSettings,
+  PrescreenProjection,
+  EmbeddingStatus,
+  EmbeddingReindexResult,
+  UsageLimitStatus,
+  AuditLogPage,
+  UserSession,
+  ProcessingPauseStatus,
+  ProcessingResumeResult,
+  DigestConfig,
+  DigestConfigInput,
+  DigestComposeProgress,
+  DigestStatsResponse,
+  DigestDelivery,
   DigestDetail,
+  DigestRecipient,
   ElevenLabsVoicesResponse,
   FeatherlessModelsResponse,
   FeatherlessSyncStatusResponse,
@@ -37,10 +69,14 @@ import type {
   FocusQueueResponse,
   UIFontCatalogResponse,
   GeminiTTSVoicesResponse,
+  Item,
+  ItemDepth,
   ItemDetail,
   ItemFeedbackResult,
+  ItemFieldsListResponse,
   ItemGenreUpdateResult,
   ItemHighlight,
+  ItemProcessingEvent,
   ItemListResponse,
   ItemNavigatorResponse,
   ItemNote,
@@ -53,7 +89,7 @@ import type {
   LLMCatalog,
   LLMExecutionCurrentMonthSummary,
   LLMUsageAnalysisSummary,
-  LLMUsageDailySummary,
+  LLMUsageDailySummaryResponse,
   LLMUsageLog,
   LLMUsageModelSummary,
   LLMUsageProviderMonthSummary,
@@ -89,9 +125,11 @@ import type {
   RelatedItemsResponse,
   ReviewQueueResponse,
   SaveAudioBriefingPresetRequest,
+  SaveCollectionRequest,
   SummaryAudioSynthesisResponse,
   SummaryAudioVoiceSettings,
   Source,
+  SourceCostEstimate,
   SourceDailyStats,
   SourceHealth,
   SourceItemStats,
@@ -102,6 +140,15 @@ import type {
   TodayQueueResponse,
   TopicPulseItem,
   TopicTrend,
+  Entity,
+  EntityItemsResponse,
+  Story,
+  StoryTimeline,
+  Topic,
+  TopicNormalizationResult,
+  TopicAlertInput,
+  TopicAlertLog,
+  TopicAlertSubscription,
   TriageQueueResponse,
   UpdatePlaybackSessionRequest,
   UserReadingPlanSettings,
@@ -112,14 +159,59 @@ import type {
   OpenAITTSVoiceSyncRun,
   AzureSpeechVoicesResponse,
   BulkMarkReadResult,
+  CatchUpRollup,
+  ItemTranslation,
+  ItemAudio,
+  TriageDecision,
+  TriageBatchResult,
   BulkMarkLaterResult,
   BulkDeleteItemsResult,
   BulkRetryFailedResult,
   BulkRetryItemsResult,
+  ImportJob,
+  ImportJobView,
   FavoritesMarkdownExportParams,
   ItemLaterResult,
+  APIErrorEnvelope,
 } from "@/types/api";
 
+// APIError keeps the "<status>: <path>: <message>" message callers match on and exposes the
+// parsed error envelope.
+export class APIError extends Error {
+  readonly status: number;
+  readonly code: string;
+  readonly details?: unknown;
+  readonly requestId?: string;
+
+  constructor(status: number, path: string, envelope: APIErrorEnvelope) {
+    super(`${status}: ${path}: ${envelope.message}`);
+    this.name = "APIError";
+    this.status = status;
+    this.code = envelope.code;
+    this.details = envelope.details;
+    this.requestId = envelope.request_id;
+  }
+}
+
+async function apiErrorFromResponse(res: Response, path: string): Promise<APIError> {
+  const text = await res.text().catch(() => "");
+  let envelope: APIErrorEnvelope = { code: "", message: text || res.statusText };
+  try {
+    const parsed = JSON.parse(text) as Partial<APIErrorEnvelope>;
+    if (parsed && typeof parsed.code === "string") {
+      envelope = {
+        code: parsed.code,
+        message: parsed.message || res.statusText,
+        details: parsed.details,
+        request_id: parsed.request_id ?? res.headers.get("X-Request-Id") ?? undefined,
+      };
+    }
+  } catch {
+    // Not an envelope (e.g. a proxy error page); keep the raw text.
+  }
+  return new APIError(res.status, path, envelope);
+}
+
 async function clientFetch<T>(path: string, options?: RequestInit, opts?: { apiPrefix?: boolean; forceFresh?: boolean }): Promise<T> {
   const requestPath = withCacheBust(path, options?.method, opts?.forceFresh !== false);
   const targetPath = `${opts?.apiPrefix === false ? "" : "/api"}${requestPath}`;
@@ -147,8 +239,7 @@ async function clientFetch<T>(path: string, options?: RequestInit, opts?: { apiP
     });
   }
   if (!res.ok) {
-    const text = await res.text().catch(() => "");
-    throw new Error(`${res.status}: ${requestPath}: ${text || res.statusText}`);
+    throw await apiErrorFromResponse(res, requestPath);
   }
   if (res.status === 204) return undefined as T;
   return res.json();
@@ -186,8 +277,7 @@ async function apiFetchBlob(path: string, options?: RequestInit): Promise<Blob>
     });
   }
   if (!res.ok) {
-    const text = await res.text().catch(() => "");
-    throw new Error(`${res.status}: ${requestPath}: ${text || res.statusText}`);
+    throw await apiErrorFromResponse(res, requestPath);
   }
   return res.blob();
 }
@@ -247,6 +337,42 @@ function decodeBase64URLHeader(value: string | null): string | null {
   }
 }
 
+type ItemListQuery = {
+  status?: string;
+  source_id?: string;
+  topic?: string;
+  genre?: string;
+  depth?: ItemDepth;
+  q?: string;
+  search_mode?: string;
+  page?: number;
+  page_size?: number;
+  sort?: string;
+  unread_only?: boolean;
+  read_only?: boolean;
+  favorite_only?: boolean;
+  later_only?: boolean;
+};
+
+function itemListSearchParams(params?: ItemListQuery): URLSearchParams {
+  const q = new URLSearchParams();
+  if (params?.status) q.set("status", params.status);
+  if (params?.source_id) q.set("source_id", params.source_id);
+  if (params?.topic) q.set("topic", params.topic);
+  if (params?.genre) q.set("genre", params.genre);
+  if (params?.depth) q.set("depth", params.depth);
+  if (params?.q) q.set("q", params.q);
+  if (params?.search_mode) q.set("search_mode", params.search_mode);
+  if (params?.page) q.set("page", String(params.page));
+  if (params?.page_size) q.set("page_size", String(params.page_size));
+  if (params?.sort) q.set("sort", params.sort);
+  if (params?.unread_only != null) q.set("unread_only", String(params.unread_only));
+  if (params?.read_only != null) q.set("read_only", String(params.read_only));
+  if (params?.favorite_only != null) q.set("favorite_only", String(params.favorite_only));
+  if (params?.later_only != null) q.set("later_only", String(params.later_only));
+  return q;
+}
+
 const FORCE_FRESH_UNTIL_KEY = "sifto.forceFreshUntil";
 
 function withCacheBust(path: string, method?: string, enabled = true): string {
@@ -332,22 +458,30 @@ export const api = {
     }
     return res.text();
   },
-  importSourcesOPML: (opml: string) =>
-    apiFetch<{ status: string; total: number; added: number; skipped: number; invalid: number; error_count: number; error_sample?: string[] }>(
+  importSourcesOPML: (opml: string, force?: boolean) =>
+    apiFetch<ImportJob>(
       "/sources/opml/import",
       {
         method: "POST",
-        body: JSON.stringify({ opml }),
+        body: JSON.stringify({ opml, force }),
       }
     ),
   importInoreaderSources: (accessToken?: string) =>
-    apiFetch<{ status: string; total: number; added: number; skipped: number; invalid: number; error_count: number; error_sample?: string[] }>(
+    apiFetch<ImportJob>(
       "/sources/inoreader/import",
       {
         method: "POST",
         ...(accessToken ? { body: JSON.stringify({ access_token: accessToken }) } : {}),
       }
     ),
+  importFeedlySources: (accessToken?: string) =>
+    apiFetch<ImportJob>(
+      "/sources/import/feedly",
+      {
+        method: "POST",
+        ...(accessToken ? { body: JSON.stringify({ access_token: accessToken }) } : {}),
+      }
+    ),
   getSourceSuggestions: (params?: { limit?: number }) => {
     const q = new URLSearchParams();
     if (params?.limit) q.set("limit", String(params.limit));
@@ -360,15 +494,17 @@ export const api = {
     const qs = q.toString();
     return apiFetch<{ items: SourceSuggestion[]; limit: number; llm?: { provider?: string; model?: string; estimated_cost_usd?: number; warning?: string; error?: string; stage?: string; items_count?: number } | null }>(`/sources/recommended${qs ? `?${qs}` : ""}`);
   },
-  createSource: (body: { url: string; title?: string; type?: string }) =>
+  createSource: (body: { url: string; title?: string; type?: string; force?: boolean }) =>
     apiFetch<Source>("/sources", { method: "POST", body: JSON.stringify(body) }),
-  updateSource: (id: string, body: { enabled?: boolean; title?: string }) =>
+  updateSource: (id: string, body: { enabled?: boolean; title?: string; group_name?: string; default_topics?: string[] }) =>
     apiFetch<Source>(`/sources/${id}`, {
       method: "PATCH",
       body: JSON.stringify(body),
     }),
   deleteSource: (id: string) =>
     apiFetch<void>(`/sources/${id}`, { method: "DELETE" }),
+  estimateSourceCost: (id: string) =>
+    apiFetch<SourceCostEstimate>(`/sources/${encodeURIComponent(id)}/estimate`, { method: "POST" }),
   discoverFeeds: (url: string) =>
     apiFetch<{ feeds: { url: string; title: string | null }[] }>(
       "/sources/discover",
@@ -376,24 +512,16 @@ export const api = {
     ),
 
   // Items
-  getItems: (params?: { status?: string; source_id?: string; topic?: string; genre?: string; q?: string; search_mode?: string; page?: number; page_size?: number; sort?: string; unread_only?: boolean; read_only?: boolean; favorite_only?: boolean; later_only?: boolean }) => {
-    const q = new URLSearchParams();
-    if (params?.status) q.set("status", params.status);
-    if (params?.source_id) q.set("source_id", params.source_id);
-    if (params?.topic) q.set("topic", params.topic);
-    if (params?.genre) q.set("genre", params.genre);
-    if (params?.q) q.set("q", params.q);
-    if (params?.search_mode) q.set("search_mode", params.search_mode);
-    if (params?.page) q.set("page", String(params.page));
-    if (params?.page_size) q.set("page_size", String(params.page_size));
-    if (params?.sort) q.set("sort", params.sort);
-    if (params?.unread_only != null) q.set("unread_only", String(params.unread_only));
-    if (params?.read_only != null) q.set("read_only", String(params.read_only));
-    if (params?.favorite_only != null) q.set("favorite_only", String(params.favorite_only));
-    if (params?.later_only != null) q.set("later_only", String(params.later_only));
-    const qs = q.toString();
+  getItems: (params?: ItemListQuery) => {
+    const qs = itemListSearchParams(params).toString();
     return apiFetch<ItemListResponse>(`/items${qs ? `?${qs}` : ""}`);
   },
+  getItemFields: <K extends keyof Item>(params: ItemListQuery & ({ fields: K[] } | { view: "minimal" })) => {
+    const q = itemListSearchParams(params);
+    if ("fields" in params) q.set("fields", params.fields.join(","));
+    if ("view" in params) q.set("view", params.view);
+    return apiFetch<ItemFieldsListResponse<K>>(`/items?${q.toString()}`);
+  },
   getItemSearchSuggestions: (params: { q: string; limit?: number }) => {
     const q = new URLSearchParams();
     q.set("q", params.q);
@@ -419,17 +547,43 @@ export const api = {
     }
     return res.text();
   },
+  getItemSnapshot: async (id: string) => {
+    const authHeaders = await getAuthHeaders();
+    const res = await fetch(`/api/items/${id}/snapshot`, {
+      cache: "no-store",
+      headers: {
+        ...authHeaders,
+      },
+    });
+    if (!res.ok) {
+      const text = await res.text().catch(() => "");
+      throw new Error(`${res.status}: ${text || res.statusText}`);
+    }
+    return {
+      html: await res.text(),
+      source_url: res.headers.get("X-Snapshot-Source-URL"),
+      captured_at: res.headers.get("X-Snapshot-Captured-At"),
+    };
+  },
   getReadingPlan: (params?: {
     window?: "24h" | "today_jst" | "7d";
     size?: number;
     diversify_topics?: boolean;
     exclude_read?: boolean;
+    exploration?: number;
+    depth?: ItemDepth;
+    available_minutes?: number;
+    recompute?: boolean;
   }) => {
     const q = new URLSearchParams();
     if (params?.window) q.set("window", params.window);
     if (params?.size) q.set("size", String(params.size));
     if (params?.diversify_topics != null) q.set("diversify_topics", String(params.diversify_topics));
     if (params?.exclude_read != null) q.set("exclude_read", String(params.exclude_read));
+    if (params?.exploration != null) q.set("exploration", String(params.exploration));
+    if (params?.depth) q.set("depth", params.depth);
+    if (params?.available_minutes) q.set("available_minutes", String(params.available_minutes));
+    if (params?.recompute) q.set("recompute", "1");
     const qs = q.toString();
     return apiFetch<ReadingPlanResponse>(`/items/reading-plan${qs ? `?${qs}` : ""}`);
   },
@@ -485,6 +639,26 @@ export const api = {
     const qs = q.toString();
     return apiFetch<DashboardSnapshot>(`/dashboard${qs ? `?${qs}` : ""}`);
   },
+  getDashboardHistory: (params?: { days?: number }) => {
+    const q = new URLSearchParams();
+    if (params?.days) q.set("days", String(params.days));
+    const qs = q.toString();
+    return apiFetch<DashboardHistoryResponse>(`/dashboard/history${qs ? `?${qs}` : ""}`);
+  },
+  getDashboardLayout: () => apiFetch<DashboardLayoutResponse>("/dashboard/layout"),
+  updateDashboardLayout: (layout: DashboardLayout) =>
+    apiFetch<DashboardLayoutResponse>("/dashboard/layout", {
+      method: "PUT",
+      body: JSON.stringify(layout),
+    }),
+  resetDashboardLayout: () =>
+    apiFetch<DashboardLayoutResponse>("/dashboard/layout", { method: "DELETE" }),
+  getDashboardWidget: <T = unknown>(name: DashboardWidgetName, params?: Record<string, number>) => {
+    const q = new URLSearchParams();
+    for (const [key, value] of Object.entries(params ?? {})) q.set(key, String(value));
+    const qs = q.toString();
+    return apiFetch<DashboardWidgetResponse<T>>(`/dashboard/widgets/${name}${qs ? `?${qs}` : ""}`);
+  },
   getBriefingToday: (params?: { size?: number; cache_bust?: boolean }) => {
     const q = new URLSearchParams();
     if (params?.size) q.set("size", String(params.size));
@@ -492,6 +666,12 @@ export const api = {
     const qs = q.toString();
     return apiFetch<BriefingTodayResponse>(`/briefing/today${qs ? `?${qs}` : ""}`);
   },
+  getStreak: () => apiFetch<ReadingStreakStatus>("/streak"),
+  applyStreakFreeze: (date?: string) =>
+    apiFetch<ReadingStreakStatus>("/streak/freezes", {
+      method: "POST",
+      body: JSON.stringify(date ? { date } : {}),
+    }),
   getBriefingNavigator: (params?: { cache_bust?: boolean; navigator_preview?: boolean }) => {
     const q = new URLSearchParams();
     if (params?.cache_bust) q.set("cache_bust", "1");
@@ -542,6 +722,11 @@ export const api = {
       citations: Array.isArray(resp?.citations) ? resp.citations : [],
       related_items: Array.isArray(resp?.related_items) ? resp.related_items : [],
     })),
+  askFeed: (body: { question: string; days?: number; top_k?: number; source_ids?: string[] }) =>
+    apiFetch<FeedAnswerResponse>("/items/ask", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
   getAskNavigator: (body: {
     query: string;
     answer: string;
@@ -585,6 +770,27 @@ export const api = {
     const qs = q.toString();
     return apiFetch<{ items: TopicTrend[]; limit: number }>(`/items/topic-trends${qs ? `?${qs}` : ""}`);
   },
+  getEntities: (params?: { q?: string; kind?: string; limit?: number }) => {
+    const q = new URLSearchParams();
+    if (params?.q) q.set("q", params.q);
+    if (params?.kind) q.set("kind", params.kind);
+    if (params?.limit) q.set("limit", String(params.limit));
+    const qs = q.toString();
+    return apiFetch<{ entities: Entity[] }>(`/entities${qs ? `?${qs}` : ""}`);
+  },
+  getEntityItems: (id: string, params?: { limit?: number }) => {
+    const q = new URLSearchParams();
+    if (params?.limit) q.set("limit", String(params.limit));
+    const qs = q.toString();
+    return apiFetch<EntityItemsResponse>(`/entities/${id}/items${qs ? `?${qs}` : ""}`);
+  },
+  getStories: (params?: { limit?: number }) => {
+    const q = new URLSearchParams();
+    if (params?.limit) q.set("limit", String(params.limit));
+    const qs = q.toString();
+    return apiFetch<{ stories: Story[] }>(`/stories${qs ? `?${qs}` : ""}`);
+  },
+  getStoryTimeline: (id: string) => apiFetch<StoryTimeline>(`/stories/${id}/timeline`),
   getTopicPulse: (params?: { days?: number; limit?: number }) => {
     const q = new URLSearchParams();
     if (params?.days) q.set("days", String(params.days));
@@ -593,6 +799,15 @@ export const api = {
     return apiFetch<{ days: number; limit: number; items: TopicPulseItem[] }>(`/topics/pulse${qs ? `?${qs}` : ""}`);
   },
   getItem: (id: string) => apiFetch<ItemDetail>(`/items/${id}`),
+  translateItem: (id: string, body?: { include_content?: boolean }) =>
+    apiFetch<ItemTranslation>(`/items/${id}/translate`, {
+      method: "POST",
+      body: JSON.stringify(body ?? {}),
+    }),
+  getItemAudio: (id: string, source: "summary" | "full" = "summary") =>
+    apiFetch<ItemAudio>(`/items/${id}/audio?source=${source}`),
+  getItemTrace: (id: string) =>
+    apiFetch<{ item_id: string; events: ItemProcessingEvent[] }>(`/items/${id}/trace`),
   updateItemGenre: (id: string, body: { user_genre: string | null; user_other_genre_label?: string | null }) =>
     apiFetch<ItemGenreUpdateResult>(`/items/${id}/genre`, {
       method: "PATCH",
@@ -615,9 +830,22 @@ export const api = {
     apiFetch<void>(`/items/${id}/highlights/${highlightId}`, {
       method: "DELETE",
     }),
-  getRelatedItems: (id: string, params?: { limit?: number }) => {
+  getRelatedItems: (
+    id: string,
+    params?: {
+      limit?: number;
+      ranking?: "embedding" | "hybrid";
+      embedding_weight?: number;
+      text_weight?: number;
+      rrf_k?: number;
+    }
+  ) => {
     const q = new URLSearchParams();
     if (params?.limit) q.set("limit", String(params.limit));
+    if (params?.ranking) q.set("ranking", params.ranking);
+    if (params?.embedding_weight != null) q.set("embedding_weight", String(params.embedding_weight));
+    if (params?.text_weight != null) q.set("text_weight", String(params.text_weight));
+    if (params?.rrf_k) q.set("rrf_k", String(params.rrf_k));
     const qs = q.toString();
     return apiFetch<RelatedItemsResponse>(`/items/${id}/related${qs ? `?${qs}` : ""}`);
   },
@@ -625,6 +853,48 @@ export const api = {
     apiFetch<ItemReadResult>(`/items/${id}/read`, { method: "POST" }),
   markItemUnread: (id: string) =>
     apiFetch<ItemReadResult>(`/items/${id}/read`, { method: "DELETE" }),
+  markItemClicked: (id: string, via: "item" | "digest" | "email" | "reading_plan" | "search" = "item") =>
+    apiFetch<void>(`/items/${id}/clicked`, { method: "POST", body: JSON.stringify({ via }) }),
+  pinItemToReadingPlan: (id: string) =>
+    apiFetch<{ item_id: string; plan_date: string; action: "pin" }>(`/items/${id}/plan`, { method: "POST" }),
+  removeItemFromReadingPlan: (id: string) =>
+    apiFetch<{ item_id: string; plan_date: string; action: "remove" }>(`/items/${id}/plan`, { method: "DELETE" }),
+  snoozeItem: (id: string, until: string) =>
+    apiFetch<{ item_id: string; snoozed_until: string | null }>(`/items/${id}/snooze`, {
+      method: "POST",
+      body: JSON.stringify({ until }),
+    }),
+  unsnoozeItem: (id: string) =>
+    apiFetch<{ item_id: string; snoozed_until: string | null }>(`/items/${id}/snooze`, { method: "DELETE" }),
+  listCollections: () =>
+    apiFetch<{ collections: Collection[] }>("/collections").then((resp) => resp.collections ?? []),
+  getCollection: (id: string) =>
+    apiFetch<{ collection: Collection; items: CollectionItem[] }>(`/collections/${id}`),
+  createCollection: (body: SaveCollectionRequest) =>
+    apiFetch<{ collection: Collection }>("/collections", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }).then((resp) => resp.collection),
+  updateCollection: (id: string, body: SaveCollectionRequest) =>
+    apiFetch<{ collection: Collection }>(`/collections/${id}`, {
+      method: "PUT",
+      body: JSON.stringify(body),
+    }).then((resp) => resp.collection),
+  deleteCollection: (id: string) => apiFetch<void>(`/collections/${id}`, { method: "DELETE" }),
+  addCollectionItem: (id: string, itemId: string) =>
+    apiFetch<void>(`/collections/${id}/items`, {
+      method: "POST",
+      body: JSON.stringify({ item_id: itemId }),
+    }),
+  removeCollectionItem: (id: string, itemId: string) =>
+    apiFetch<void>(`/collections/${id}/items/${itemId}`, { method: "DELETE" }),
+  reorderCollectionItems: (id: string, itemIds: string[]) =>
+    apiFetch<void>(`/collections/${id}/items/order`, {
+      method: "PUT",
+      body: JSON.stringify({ item_ids: itemIds }),
+    }),
+  getCollectionSummary: (id: string) =>
+    apiFetch<{ summary: CollectionSummary | null }>(`/collections/${id}/summary`).then((resp) => resp.summary),
   markItemsReadBulk: (body: {
     item_ids?: string[];
     status?: string | null;
@@ -645,6 +915,21 @@ export const api = {
       method: "POST",
       body: JSON.stringify({ item_ids: itemIds }),
     }),
+  composeCatchUp: (body?: { days?: number }) =>
+    apiFetch<CatchUpRollup>("/items/catch-up", {
+      method: "POST",
+      body: JSON.stringify(body ?? {}),
+    }),
+  markCatchUpRead: (itemIds: string[]) =>
+    apiFetch<BulkMarkReadResult>("/items/catch-up/mark-read", {
+      method: "POST",
+      body: JSON.stringify({ item_ids: itemIds }),
+    }),
+  applyTriage: (decisions: TriageDecision[]) =>
+    apiFetch<TriageBatchResult>("/items/triage", {
+      method: "POST",
+      body: JSON.stringify({ decisions }),
+    }),
   markItemLater: (id: string) =>
     apiFetch<ItemLaterResult>(`/items/${id}/later`, { method: "POST" }),
   markItemsLaterBulk: (body: { item_ids: string[] }) =>
@@ -663,13 +948,13 @@ export const api = {
     }),
   restoreItem: (id: string) =>
     apiFetch<ItemDetail>(`/items/${id}/restore`, { method: "POST" }),
-  setItemFeedback: (id: string, body: { rating: number; is_favorite: boolean }) =>
+  setItemFeedback: (id: string, body: { rating: number; is_favorite: boolean; reason?: FeedbackReason }) =>
     apiFetch<ItemFeedbackResult>(`/items/${id}/feedback`, {
       method: "PATCH",
       body: JSON.stringify(body),
     }),
-  retryItem: (id: string) =>
-    apiFetch<ItemRetryResult>(`/items/${id}/retry`, { method: "POST" }),
+  retryItem: (id: string, opts?: { force?: boolean }) =>
+    apiFetch<ItemRetryResult>(`/items/${id}/retry${opts?.force ? "?force=true" : ""}`, { method: "POST" }),
   retryItemsBulk: (itemIds: string[]) =>
     apiFetch<BulkRetryItemsResult>("/items/retry-bulk", {
       method: "POST",
@@ -703,6 +988,24 @@ export const api = {
       method: "POST",
     });
   },
+  importReadLaterItems: (provider: "pocket" | "instapaper", data: string) =>
+    apiFetch<ImportJob>(`/items/import/${provider}`, {
+      method: "POST",
+      body: JSON.stringify({ data }),
+    }),
+  importItems: (body: { html: string } | { items: { url: string; title?: string; timestamp?: number | string }[] }) =>
+    apiFetch<ImportJob>("/items/import", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
+  getImportJob: (id: string) => apiFetch<ImportJobView>(`/imports/${id}`),
+  waitForImportJob: async (id: string, intervalMs = 1500): Promise<ImportJobView> => {
+    for (;;) {
+      const job = await apiFetch<ImportJobView>(`/imports/${id}`);
+      if (job.status === "completed" || job.status === "failed") return job;
+      await new Promise((resolve) => setTimeout(resolve, intervalMs));
+    }
+  },
 
   // LLM Usage
   getLLMUsage: (params?: { limit?: number; month?: string }) => {
@@ -717,7 +1020,7 @@ export const api = {
     if (params?.days) q.set("days", String(params.days));
     if (params?.month) q.set("month", params.month);
     const qs = q.toString();
-    return apiFetch<LLMUsageDailySummary[]>(`/llm-usage/summary${qs ? `?${qs}` : ""}`);
+    return apiFetch<LLMUsageDailySummaryResponse>(`/llm-usage/summary${qs ? `?${qs}` : ""}`);
   },
   getLLMUsageByModel: (params?: { days?: number; month?: string }) => {
     const q = new URLSearchParams();
@@ -938,7 +1241,87 @@ export const api = {
       method: "PATCH",
       body: JSON.stringify(body),
     }),
-  updateReadingPlanSettings: (body: Pick<UserReadingPlanSettings, "window" | "size" | "diversify_topics">) =>
+  updateDigestApprovalSettings: (body: { enabled: boolean; auto_approve_minutes?: number }) =>
+    apiFetch<{ user_id: string; digest_approval: DigestApprovalSettings }>("/settings/digest-approval", {
+      method: "PATCH",
+      body: JSON.stringify(body),
+    }),
+  updateSourceStatsSharing: (enabled: boolean) =>
+    apiFetch<{ user_id: string; source_stats_sharing_enabled: boolean }>("/settings/source-stats-sharing", {
+      method: "PATCH",
+      body: JSON.stringify({ enabled }),
+    }),
+  updateHTMLSnapshots: (enabled: boolean) =>
+    apiFetch<{ user_id: string; html_snapshots_enabled: boolean }>("/settings/html-snapshots", {
+      method: "PATCH",
+      body: JSON.stringify({ enabled }),
+    }),
+  updateExcludePaywalled: (exclude: boolean) =>
+    apiFetch<{ user_id: string; exclude_paywalled: boolean }>("/settings/paywalled-items", {
+      method: "PATCH",
+      body: JSON.stringify({ exclude }),
+    }),
+  updateSocialBoost: (enabled: boolean) =>
+    apiFetch<{ user_id: string; social_boost_enabled: boolean }>("/settings/social-boost", {
+      method: "PATCH",
+      body: JSON.stringify({ enabled }),
+    }),
+  updateWeeklyRecap: (enabled: boolean) =>
+    apiFetch<{ user_id: string; weekly_recap_enabled: boolean }>("/settings/weekly-recap", {
+      method: "PATCH",
+      body: JSON.stringify({ enabled }),
+    }),
+  updateLLMConcurrency: (max: number | null) =>
+    apiFetch<{ user_id: string; llm_concurrency: LLMConcurrencySettings }>("/settings/llm-concurrency", {
+      method: "PATCH",
+      body: JSON.stringify({ max }),
+    }),
+  updatePrescreen: (threshold: number | null) =>
+    apiFetch<{ user_id: string; prescreen: PrescreenSettings }>("/settings/prescreen", {
+      method: "PATCH",
+      body: JSON.stringify({ threshold }),
+    }),
+  getPrescreenProjection: (threshold?: number) => {
+    const q = new URLSearchParams();
+    if (threshold !== undefined) q.set("threshold", String(threshold));
+    const qs = q.toString();
+    return apiFetch<PrescreenProjection>(`/settings/prescreen/projection${qs ? `?${qs}` : ""}`);
+  },
+  getEmbeddingStatus: () => apiFetch<EmbeddingStatus>("/settings/embeddings"),
+  reindexEmbeddings: () =>
+    apiFetch<EmbeddingReindexResult>("/settings/embeddings/reindex", { method: "POST" }),
+  getUsageLimits: () => apiFetch<UsageLimitStatus>("/settings/usage-limits"),
+  getAuditLog: (params?: { before?: string; limit?: number }) => {
+    const q = new URLSearchParams();
+    if (params?.before) q.set("before", params.before);
+    if (params?.limit !== undefined) q.set("limit", String(params.limit));
+    const qs = q.toString();
+    return apiFetch<AuditLogPage>(`/settings/audit-log${qs ? `?${qs}` : ""}`);
+  },
+  getSessions: () => apiFetch<{ sessions: UserSession[] }>("/settings/sessions"),
+  revokeSession: (id: string) =>
+    apiFetch<void>(`/settings/sessions/${encodeURIComponent(id)}`, { method: "DELETE" }),
+  getProcessingPause: () => apiFetch<ProcessingPauseStatus>("/settings/processing"),
+  pauseProcessing: () => apiFetch<ProcessingPauseStatus>("/settings/processing/pause", { method: "POST" }),
+  resumeProcessing: () => apiFetch<ProcessingResumeResult>("/settings/processing/resume", { method: "POST" }),
+  updateBriefingGreeting: (style: string) =>
+    apiFetch<{ user_id: string; briefing_greeting_style: string }>("/settings/briefing-greeting", {
+      method: "PATCH",
+      body: JSON.stringify({ style }),
+    }),
+  updateStreakSettings: (body: Partial<StreakSettings>) =>
+    apiFetch<{ user_id: string; streak: StreakSettings }>("/settings/streak", {
+      method: "PATCH",
+      body: JSON.stringify(body),
+    }),
+  updateInoreaderSync: (body: { enabled: boolean; read_state?: boolean }) =>
+    apiFetch<{ user_id: string; inoreader_sync: InoreaderSyncSettings }>("/settings/inoreader-sync", {
+      method: "PATCH",
+      body: JSON.stringify(body),
+    }),
+  updateReadingPlanSettings: (
+    body: Pick<UserReadingPlanSettings, "window" | "size" | "diversify_topics"> & Partial<Pick<UserReadingPlanSettings, "exploration">>
+  ) =>
     apiFetch<{ user_id: string; reading_plan: UserReadingPlanSettings }>("/settings/reading-plan", {
       method: "PATCH",
       body: JSON.stringify(body),
@@ -1347,6 +1730,48 @@ export const api = {
       method: "PATCH",
       body: JSON.stringify(body),
     }),
+  getAdminTopics: () => apiFetch<{ topics: Topic[] }>("/topic-admin/topics"),
+  normalizeTopics: () =>
+    apiFetch<TopicNormalizationResult>("/topic-admin/normalize", { method: "POST" }),
+  mergeTopics: (sourceTopicId: string, targetTopicId: string) =>
+    apiFetch<{ topic_id: string; rewritten_summaries: number }>("/topic-admin/merge", {
+      method: "POST",
+      body: JSON.stringify({ source_topic_id: sourceTopicId, target_topic_id: targetTopicId }),
+    }),
+  splitTopic: (id: string, body: { name: string; aliases: string[] }) =>
+    apiFetch<{ topic_id: string; rewritten_summaries: number }>(`/topic-admin/topics/${id}/split`, {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
+  getTopicAlerts: () =>
+    apiFetch<{ subscriptions: TopicAlertSubscription[]; recent_alerts: TopicAlertLog[] }>("/topic-alerts"),
+  createTopicAlert: (body: TopicAlertInput) =>
+    apiFetch<TopicAlertSubscription>("/topic-alerts", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
+  updateTopicAlert: (id: string, body: Omit<TopicAlertInput, "topic">) =>
+    apiFetch<TopicAlertSubscription>(`/topic-alerts/${id}`, {
+      method: "PATCH",
+      body: JSON.stringify(body),
+    }),
+  deleteTopicAlert: (id: string) =>
+    apiFetch<void>(`/topic-alerts/${id}`, { method: "DELETE" }),
+  getFilterSuggestions: () =>
+    apiFetch<{ suggestions: FilterSuggestion[] }>("/suggestions/filters"),
+  acceptFilterSuggestion: (id: string) =>
+    apiFetch<FilterSuggestion>(`/suggestions/filters/${id}/accept`, { method: "POST" }),
+  dismissFilterSuggestion: (id: string) =>
+    apiFetch<FilterSuggestion>(`/suggestions/filters/${id}/dismiss`, { method: "POST" }),
+  getFilterRules: () =>
+    apiFetch<{ rules: FilterRule[] }>("/filter-rules"),
+  createFilterRule: (topic: string) =>
+    apiFetch<FilterRule>("/filter-rules", {
+      method: "POST",
+      body: JSON.stringify({ topic }),
+    }),
+  deleteFilterRule: (id: string) =>
+    apiFetch<void>(`/filter-rules/${id}`, { method: "DELETE" }),
   setAivisUserDictionary: (uuid: string) =>
     apiFetch<{ user_id: string; aivis_user_dictionary_uuid: string | null }>(
       "/settings/aivis-user-dictionary",
@@ -1432,9 +1857,49 @@ export const api = {
       "/settings/inoreader-oauth",
       { method: "DELETE" }
     ),
+  deleteFeedlyOAuth: () =>
+    apiFetch<{ user_id: string; has_feedly_oauth: boolean; feedly_token_expires_at: string | null }>(
+      "/settings/feedly-oauth",
+      { method: "DELETE" }
+    ),
 
   // Digests
-  getDigests: () => apiFetch<Digest[]>("/digests"),
+  getDigests: (params?: { config_id?: string }) =>
+    apiFetch<Digest[]>(`/digests${params?.config_id ? `?config_id=${encodeURIComponent(params.config_id)}` : ""}`),
+  getDigestConfigs: () => apiFetch<{ configs: DigestConfig[] }>("/digest-configs"),
+  createDigestConfig: (body: DigestConfigInput) =>
+    apiFetch<DigestConfig>("/digest-configs", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
+  updateDigestConfig: (id: string, body: DigestConfigInput) =>
+    apiFetch<DigestConfig>(`/digest-configs/${id}`, {
+      method: "PATCH",
+      body: JSON.stringify(body),
+    }),
+  deleteDigestConfig: (id: string) =>
+    apiFetch<void>(`/digest-configs/${id}`, { method: "DELETE" }),
+  getDigestRecipients: () => apiFetch<{ recipients: DigestRecipient[] }>("/digest-recipients"),
+  addDigestRecipient: (body: { email: string; digest_config_id?: string | null }) =>
+    apiFetch<DigestRecipient>("/digest-recipients", {
+      method: "POST",
+      body: JSON.stringify(body),
+    }),
+  resendDigestRecipientConfirmation: (id: string) =>
+    apiFetch<{ status: string }>(`/digest-recipients/${id}/resend`, { method: "POST" }),
+  deleteDigestRecipient: (id: string) =>
+    apiFetch<void>(`/digest-recipients/${id}`, { method: "DELETE" }),
+  getDigestDeliveries: (id: string) =>
+    apiFetch<{ deliveries: DigestDelivery[] }>(`/digests/${id}/deliveries`),
   getDigest: (id: string) => apiFetch<DigestDetail>(`/digests/${id}`),
+  getDigestProgress: (id: string) => apiFetch<DigestComposeProgress>(`/digests/${id}/progress`),
   getLatestDigest: () => apiFetch<DigestDetail>("/digests/latest"),
+  getDigestStats: (months?: number) =>
+    apiFetch<DigestStatsResponse>(`/digests/stats${months ? `?months=${months}` : ""}`),
+  removeDigestItem: (id: string, itemId: string) =>
+    apiFetch<{ status: string; digest_id: string; item_id: string }>(`/digests/${id}/items/${itemId}`, {
+      method: "DELETE",
+    }),
+  approveDigest: (id: string) =>
+    apiFetch<{ status: string; digest_id: string }>(`/digests/${id}/approve`, { method: "POST" }),
 };
@@ -23,6 +23,7 @@ export async function authorizeDebugAdmin(): Promise<DebugAdminAuthorization> {
   return { authorized: true, user };
 }
 
-export function internalAdminEmailHeader(user: ServerAuthUser): Record<string, string> {
-  return { "X-Internal-User-Email": user.email?.trim().toLowerCase() ?? "" };
+// internalAdminEmail is the email internal admin requests are signed for.
+export function internalAdminEmail(user: ServerAuthUser): string {
+  return user.email?.trim().toLowerCase() ?? "";
 }
@@ -1,3 +1,5 @@
+import { createHash, createHmac, randomBytes } from "node:crypto";
+
 export function getInternalAPISecret(): string {
   return process.env.INTERNAL_API_SECRET ?? "";
 }
@@ -5,3 +7,22 @@ export function getInternalAPISecret(): string {
 export function getInternalAPISecretError(): string {
   return "INTERNAL_API_SECRET is not set";
 }
+
+// internalAuthHeaders signs an API internal request the way the API verifies it: HMAC-SHA256
+// over method, path with query, unix timestamp, nonce, the acting user's email and the SHA-256
+// of the body. A userEmail is sent as X-Internal-User-Email and covered by the signature.
+export function internalAuthHeaders(secret: string, method: string, url: string, body = "", userEmail = ""): Record<string, string> {
+  const { pathname, search } = new URL(url);
+  const timestamp = String(Math.floor(Date.now() / 1000));
+  const nonce = randomBytes(16).toString("hex");
+  const bodyHash = createHash("sha256").update(body).digest("hex");
+  const email = userEmail.trim();
+  const payload = [method.toUpperCase(), `${pathname}${search}`, timestamp, nonce, email, bodyHash].join("\n");
+  const headers: Record<string, string> = {
+    "X-Internal-Timestamp": timestamp,
+    "X-Internal-Nonce": nonce,
+    "X-Internal-Signature": `v1=${createHmac("sha256", secret).update(payload).digest("hex")}`,
+  };
+  if (email) headers["X-Internal-User-Email"] = email;
+  return headers;
+}
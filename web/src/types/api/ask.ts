@@ -50,6 +50,14 @@ export interface AskResponse {
   ask_llm?: AskLLM | null;
 }
 
+export interface FeedAnswerResponse {
+  question: string;
+  answer: string;
+  bullets?: string[];
+  citations: AskCitation[];
+  ask_llm?: AskLLM | null;
+}
+
 export interface AskNavigator {
   enabled: boolean;
   persona: string;
@@ -56,14 +56,29 @@ export interface BriefingCluster {
   items: Item[];
 }
 
+export interface BriefingBlock {
+  type: "favorite_follow_up" | "reading_time_nudge" | string;
+  title: string;
+  message?: string;
+  topics?: string[];
+  items?: Item[];
+  hour?: number;
+  share?: number;
+}
+
 export interface BriefingTodayResponse {
   date: string;
   greeting: string;
   greeting_key?: "morning" | "afternoon" | "evening" | string;
+  greeting_style?: "standard" | "casual" | "formal" | "none" | string;
   status: "pending" | "ready" | "stale" | string;
   generated_at?: string | null;
+  stale?: boolean;
+  age_sec?: number;
+  refresh_limited?: boolean;
   highlight_items: Item[];
   clusters: BriefingCluster[];
+  blocks?: BriefingBlock[];
   stats: {
     total_unread: number;
     today_highlight_count: number;
@@ -74,6 +89,8 @@ export interface BriefingTodayResponse {
     streak_target?: number;
     streak_remaining?: number;
     streak_at_risk?: boolean;
+    streak_freezes_available?: number;
+    streak_yesterday_frozen?: boolean;
   };
   navigator?: {
     enabled: boolean;
@@ -127,3 +144,21 @@ export interface ItemNavigatorResponse {
     llm?: NavigatorLLM | null;
   } | null;
 }
+
+export interface StreakFreezeBalance {
+  monthly_allowance: number;
+  monthly_used: number;
+  monthly_remaining: number;
+  earned: number;
+  available: number;
+}
+
+export interface ReadingStreakStatus {
+  date: string;
+  daily_target: number;
+  today_read_count: number;
+  streak_days: number;
+  freezes: StreakFreezeBalance;
+  freezable_date?: string | null;
+  frozen_dates: string[];
+}
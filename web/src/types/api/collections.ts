@@ -0,0 +1,42 @@
+export interface Collection {
+  id: string;
+  user_id: string;
+  name: string;
+  description?: string | null;
+  weekly_summary_enabled: boolean;
+  item_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CollectionItem {
+  item_id: string;
+  position: number;
+  url: string;
+  title: string | null;
+  translated_title?: string | null;
+  thumbnail_url?: string | null;
+  status: string;
+  is_read: boolean;
+  summary?: string | null;
+  topics?: string[];
+  summary_score?: number | null;
+  published_at?: string | null;
+  added_at: string;
+}
+
+export interface CollectionSummary {
+  collection_id: string;
+  week_start: string;
+  subject: string;
+  body: string;
+  item_count: number;
+  model?: string | null;
+  created_at: string;
+}
+
+export interface SaveCollectionRequest {
+  name: string;
+  description?: string | null;
+  weekly_summary_enabled: boolean;
+}
@@ -1,4 +1,5 @@
 import type { Digest } from "./digests";
+import type { EntityTrend } from "./entities";
 import type { ItemStats, ItemListResponse, TopicTrend } from "./items";
 import type { LLMUsageDailySummary } from "./llm-usage";
 
@@ -30,6 +31,71 @@ export interface DashboardSnapshot {
   digests: Digest[];
   llm_summary: LLMUsageDailySummary[];
   topic_trends: { items: TopicTrend[]; limit: number };
+  entity_trends?: { items: EntityTrend[]; limit: number };
   failed_items_preview?: ItemListResponse | null;
   llm_days: number;
+  degraded?: boolean;
 }
+
+export type DashboardWidgetName =
+  | "sources_count"
+  | "item_stats"
+  | "digests"
+  | "llm_summary"
+  | "topic_trends"
+  | "entity_trends"
+  | "failed_items_preview";
+
+export interface DashboardWidgetConfig {
+  name: DashboardWidgetName;
+  params?: Record<string, number>;
+}
+
+export interface DashboardLayout {
+  widgets: DashboardWidgetConfig[];
+}
+
+export interface DashboardWidgetSpec {
+  name: DashboardWidgetName;
+  params: { name: string; default: number; min: number; max: number }[];
+}
+
+export interface DashboardLayoutResponse {
+  layout: DashboardLayout;
+  is_default: boolean;
+  available: DashboardWidgetSpec[];
+}
+
+export interface DashboardWidgetResponse<T = unknown> {
+  name: DashboardWidgetName;
+  params: Record<string, number> | null;
+  data: T;
+}
+
+export interface DashboardMetricsDaily {
+  date: string;
+  items_ingested: number;
+  items_summarized: number;
+  items_read: number;
+  llm_calls: number;
+  llm_cost_usd: number;
+  digests_sent: number;
+}
+
+export interface DashboardHistoryResponse {
+  days: number;
+  metrics: DashboardMetricsDaily[];
+}
+
+// Body of every API error answer; codes are listed in docs/api-errors.md.
+export interface APIErrorEnvelope<Code extends string = string, Details = unknown> {
+  code: Code;
+  message: string;
+  details?: Details;
+  request_id?: string;
+}
+
+export type RequestValidationError = APIErrorEnvelope<
+  "body_too_large" | "empty_body" | "invalid_json" | "unknown_field" | "invalid_field_type",
+  { field?: string; limit_bytes?: number }
+>;
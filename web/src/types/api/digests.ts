@@ -12,14 +12,96 @@ export interface Digest {
   send_error?: string | null;
   send_tried_at?: string | null;
   sent_at: string | null;
+  compose_mode?: "daily" | "catch_up";
+  window_start_date?: string | null;
+  approval_requested_at?: string | null;
+  approved_at?: string | null;
+  approved_by?: "user" | "auto" | null;
+  digest_config_id?: string | null;
   created_at: string;
 }
 
+export type DigestComposeStage = "clustering" | "cluster_drafts" | "sections" | "final" | "done";
+
+export interface DigestComposeProgress {
+  digest_id: string;
+  send_status?: string | null;
+  stage?: DigestComposeStage | null;
+  clusters_total: number;
+  clusters_done: number;
+  sections_total: number;
+  sections_done: number;
+  started_at?: string | null;
+  stage_started_at?: string | null;
+  updated_at?: string | null;
+  eta_sec?: number | null;
+}
+
+export type DigestScopeType = "sources" | "search" | "topics";
+
+export interface DigestConfig {
+  id: string;
+  name: string;
+  scope_type: DigestScopeType;
+  source_ids: string[];
+  search_query?: string | null;
+  topics: string[];
+  send_hour_jst: number;
+  send_weekdays: number[];
+  enabled: boolean;
+  last_generated_at?: string | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface DigestConfigInput {
+  name?: string;
+  scope_type?: DigestScopeType;
+  source_ids?: string[];
+  search_query?: string | null;
+  topics?: string[];
+  send_hour_jst?: number;
+  send_weekdays?: number[];
+  enabled?: boolean;
+}
+
+export type DigestRecipientStatus = "pending" | "confirmed";
+
+export interface DigestRecipient {
+  id: string;
+  digest_config_id?: string | null;
+  email: string;
+  status: DigestRecipientStatus;
+  confirm_sent_at?: string | null;
+  confirmed_at?: string | null;
+  created_at: string;
+}
+
+export interface DigestDelivery {
+  id: string;
+  digest_id: string;
+  recipient_id?: string | null;
+  email: string;
+  status: "sent" | "failed";
+  error?: string | null;
+  attempted_at: string;
+}
+
 export interface DigestItemDetail {
   rank: number;
   item: Item;
   summary: ItemSummary;
   facts?: string[];
+  follow_up?: DigestFollowUp | null;
+}
+
+export interface DigestFollowUp {
+  item_id: string;
+  title: string;
+  url: string;
+  digest_id: string;
+  digest_date: string;
+  similarity: number;
 }
 
 export interface DigestClusterDraft {
@@ -32,13 +114,40 @@ export interface DigestClusterDraft {
   topics: string[];
   max_score?: number | null;
   draft_summary: string;
+  follow_up?: DigestFollowUp | null;
   created_at: string;
   updated_at: string;
 }
 
+export interface DigestStatusEvent {
+  status: string;
+  error?: string | null;
+  created_at: string;
+}
+
 export interface DigestDetail extends Digest {
   digest_llm?: ItemSummaryLLM | null;
   cluster_draft_llm?: ItemSummaryLLM | null;
   items: DigestItemDetail[];
   cluster_drafts?: DigestClusterDraft[];
+  status_history?: DigestStatusEvent[];
+}
+
+export interface DigestMonthStats {
+  month: string;
+  generated: number;
+  sent: number;
+  failed: number;
+  skipped: number;
+  avg_items: number;
+  avg_compose_sec: number | null;
+  costed_digests: number;
+  total_cost_usd: number;
+  avg_cost_usd: number | null;
+}
+
+export interface DigestStatsResponse {
+  months: number;
+  since: string;
+  stats: DigestMonthStats[];
 }
@@ -0,0 +1,25 @@
+import type { Item } from "./items";
+
+export type EntityKind = "company" | "person" | "product" | "other";
+
+export interface Entity {
+  id: string;
+  name: string;
+  kind: EntityKind;
+  item_count: number;
+  last_seen_at?: string | null;
+}
+
+export interface EntityTrend {
+  entity_id: string;
+  name: string;
+  kind: EntityKind;
+  count_24h: number;
+  count_prev_24h: number;
+  delta: number;
+}
+
+export interface EntityItemsResponse {
+  entity: Entity;
+  items: Item[];
+}
@@ -0,0 +1,21 @@
+export type FeedbackReason = "off_topic" | "clickbait" | "too_long" | "duplicate";
+
+export interface FilterRule {
+  id: string;
+  user_id: string;
+  topic: string;
+  created_at: string;
+}
+
+export interface FilterSuggestion {
+  id: string;
+  user_id: string;
+  kind: "topic" | "source";
+  target: string;
+  source_title?: string | null;
+  reason: FeedbackReason | string;
+  evidence_count: number;
+  status: "pending" | "accepted" | "dismissed";
+  created_at: string;
+  updated_at: string;
+}
@@ -12,3 +12,9 @@ export * from "./reviews";
 export * from "./reading-goals";
 export * from "./voice-catalog";
 export * from "./model-catalog";
+export * from "./collections";
+export * from "./entities";
+export * from "./stories";
+export * from "./topics";
+export * from "./topic-alerts";
+export * from "./filters";
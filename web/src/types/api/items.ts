@@ -17,6 +17,9 @@ export interface Item {
   summary_score?: number | null;
   summary_topics?: string[];
   recommendation_reason?: string | null;
+  plan_pinned?: boolean;
+  exploration?: boolean;
+  snoozed_until?: string | null;
   personal_score?: number;
   personal_score_reason?: string;
   personal_score_breakdown?: PersonalScoreBreakdown | null;
@@ -24,6 +27,8 @@ export interface Item {
   other_genre_label?: string | null;
   user_genre?: string | null;
   user_other_genre_label?: string | null;
+  summary_depth?: ItemDepth | null;
+  paywalled?: boolean;
   search_match_count?: number;
   search_snippets?: ItemSearchSnippet[];
   published_at: string | null;
@@ -72,6 +77,8 @@ export interface ItemFacts {
   extracted_at: string;
 }
 
+export type ItemDepth = "news_brief" | "deep_dive" | "tutorial";
+
 export interface ItemSummary {
   id: string;
   item_id: string;
@@ -79,6 +86,7 @@ export interface ItemSummary {
   topics: string[];
   genre?: string | null;
   other_genre_label?: string | null;
+  depth?: ItemDepth | null;
   translated_title?: string | null;
   score: number | null;
   score_breakdown?: {
@@ -150,6 +158,7 @@ export interface ItemFeedback {
   item_id: string;
   rating: -1 | 0 | 1 | number;
   is_favorite: boolean;
+  reason?: FeedbackReason | null;
   updated_at: string;
 }
 
@@ -173,8 +182,61 @@ export interface ItemHighlight {
   created_at: string;
 }
 
+export interface ItemProcessingEvent {
+  id: string;
+  item_id: string;
+  trigger_id?: string | null;
+  trigger_reason?: string | null;
+  step: string;
+  status: "succeeded" | "failed";
+  model?: string | null;
+  error_message?: string | null;
+  started_at: string;
+  finished_at: string;
+  duration_ms: number;
+}
+
+export interface ItemDiscussion {
+  item_id: string;
+  platform: "hackernews" | "reddit";
+  external_id: string;
+  url: string;
+  title?: string | null;
+  points: number;
+  comments_count: number;
+  fetched_at: string;
+}
+
+export interface ItemTranslation {
+  item_id: string;
+  language: string;
+  title?: string | null;
+  summary: string;
+  facts: string[];
+  content?: string | null;
+  model?: string | null;
+  cached: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ItemAudio {
+  item_id: string;
+  source: "summary" | "full";
+  audio_url: string;
+  content_type: string;
+  duration_sec: number;
+  text_chars: number;
+  cached: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
 export interface ItemDetail extends Item {
   processing_error?: string | null;
+  extraction_source?: "worker" | "readability" | "wayback" | "archive_today" | null;
+  archive_url?: string | null;
+  canonical_url?: string | null;
   facts: ItemFacts | null;
   facts_llm?: ItemSummaryLLM | null;
   facts_executions?: ItemLLMExecutionAttempt[];
@@ -188,6 +250,21 @@ export interface ItemDetail extends Item {
   feedback?: ItemFeedback | null;
   note?: ItemNote | null;
   highlights?: ItemHighlight[];
+  discussions?: ItemDiscussion[];
+  processing_queue?: ItemProcessingQueue;
+  prescreen?: ItemPrescreen;
+}
+
+export interface ItemPrescreen {
+  score: number;
+  skipped: boolean;
+}
+
+export interface ItemProcessingQueue {
+  state: "running" | "queued" | string;
+  position: number;
+  in_flight: number;
+  limit: number;
 }
 
 export interface PreferenceProfileWeight {
@@ -249,6 +326,24 @@ export interface RelatedItem {
   reason_topics?: string[];
   published_at?: string | null;
   created_at: string;
+  fusion?: RelatedFusion;
+}
+
+export interface RelatedFusion {
+  embedding_rank?: number;
+  text_rank?: number;
+  text_score: number;
+  embedding_contribution: number;
+  text_contribution: number;
+  score: number;
+}
+
+export interface EmbeddingCoverage {
+  model?: string;
+  dimensions?: number;
+  comparable: number;
+  total: number;
+  percent: number;
 }
 
 export interface RelatedItemsResponse {
@@ -263,6 +358,7 @@ export interface RelatedItemsResponse {
     representative: RelatedItem;
     items: RelatedItem[];
   }[];
+  embedding_coverage?: EmbeddingCoverage;
 }
 
 export interface ItemRetryResult {
@@ -290,6 +386,27 @@ export interface BulkMarkReadResult {
   updated_count: number;
 }
 
+export type TriageAction = "read" | "skip" | "favorite" | "snooze" | "rate";
+
+export interface TriageDecision {
+  item_id: string;
+  action: TriageAction;
+  rating?: -1 | 0 | 1;
+  reason?: string;
+  until?: string;
+}
+
+export interface TriageBatchResult {
+  applied: boolean;
+  read_count: number;
+  streak_counted: boolean;
+  results: {
+    item_id: string;
+    action: TriageAction;
+    status: "applied" | "unchanged" | "skipped" | "not_found" | "deleted" | "not_applied";
+  }[];
+}
+
 export interface BulkMarkLaterResult {
   status: "ok";
   updated_count: number;
@@ -342,12 +459,21 @@ export interface ItemListResponse {
   search_unavailable?: boolean;
 }
 
+export interface ItemFieldsListResponse<K extends keyof Item = keyof Item>
+  extends Omit<ItemListResponse, "items" | "genre_counts"> {
+  items: (Pick<Item, K> & Pick<Item, "id">)[];
+  fields: (K | "id")[];
+}
+
 export interface ReadingPlanResponse {
   items: Item[];
   window: "24h" | "today_jst" | "7d" | string;
   size: number;
   diversify_topics: boolean;
   exclude_read: boolean;
+  exploration?: number;
+  depth?: ItemDepth;
+  available_minutes?: number;
   source_pool_count: number;
   topics: { topic: string; count: number; max_score?: number | null }[];
   clusters?: {
@@ -357,7 +483,11 @@ export interface ReadingPlanResponse {
     max_similarity: number;
     representative: Item;
     items: Item[];
+    story?: ClusterStory;
   }[];
+  embedding_coverage?: EmbeddingCoverage;
+  source?: "snapshot" | "live";
+  generated_at?: string | null;
 }
 
 export interface FocusQueueResponse {
@@ -412,6 +542,24 @@ export interface TodayQueueResponse {
   items: TodayQueueItem[];
 }
 
+export interface CatchUpCluster {
+  id: string;
+  label: string;
+  size: number;
+  item_ids: string[];
+}
+
+export interface CatchUpRollup {
+  window_start: string;
+  window_days: number;
+  subject: string;
+  body: string;
+  item_count: number;
+  clusters: CatchUpCluster[];
+  item_ids: string[];
+  llm?: NavigatorLLM | null;
+}
+
 export interface ItemStats {
   total: number;
   read: number;
@@ -461,7 +609,32 @@ export interface BulkRetryFailedResult {
   failed_count: number;
 }
 
+import type { NavigatorLLM } from "./briefing";
+import type { FeedbackReason } from "./filters";
 import type { ReadingGoal } from "./reading-goals";
+import type { ClusterStory } from "./stories";
+
+export interface ImportJob {
+  id: string;
+  user_id: string;
+  kind: string;
+  source_id?: string | null;
+  status: "queued" | "running" | "completed" | "failed";
+  total_count: number;
+  processed_count: number;
+  added_count: number;
+  duplicate_count: number;
+  invalid_count: number;
+  failed_count: number;
+  error_message?: string | null;
+  created_at: string;
+  updated_at: string;
+  completed_at?: string | null;
+}
+
+export interface ImportJobView extends ImportJob {
+  failures: { position: number; url: string; title?: string | null; message: string }[];
+}
 
 export interface BulkRetryItemsResult {
   status: "queued";
@@ -30,6 +30,29 @@ export interface LLMUsageDailySummary {
   estimated_cost_usd: number;
 }
 
+export interface LLMBudgetAllocationView {
+  purpose: string;
+  monthly_cap_usd: number | null;
+  used_cost_usd: number;
+  remaining_usd: number | null;
+  remaining_pct: number | null;
+  exceeded: boolean;
+  included_purposes: string[];
+}
+
+export interface LLMBudgetAllocationSummary {
+  month_jst: string;
+  monthly_budget_usd: number | null;
+  allocated_usd: number;
+  unallocated_usd: number | null;
+  allocations: LLMBudgetAllocationView[];
+}
+
+export interface LLMUsageDailySummaryResponse {
+  days: LLMUsageDailySummary[];
+  budget_allocations: LLMBudgetAllocationSummary | null;
+}
+
 export interface LLMUsageModelSummary {
   provider: string;
   model: string;
@@ -51,6 +74,7 @@ export interface LLMUsageProviderMonthSummary {
   cache_creation_input_tokens: number;
   cache_read_input_tokens: number;
   estimated_cost_usd: number;
+  cache_hits: number;
 }
 
 export interface LLMUsagePurposeMonthSummary {
@@ -62,6 +86,7 @@ export interface LLMUsagePurposeMonthSummary {
   cache_creation_input_tokens: number;
   cache_read_input_tokens: number;
   estimated_cost_usd: number;
+  cache_hits: number;
 }
 
 export interface LLMUsageAnalysisSummary {
@@ -1,3 +1,4 @@
+import type { APIErrorEnvelope } from "./common";
 import type { PodcastSettings } from "./podcasts";
 import type { AudioBriefingSettings, AudioBriefingPersonaVoice, SummaryAudioVoiceSettings } from "./audio-briefing";
 
@@ -15,6 +16,7 @@ export interface UserReadingPlanSettings {
   size: number;
   diversify_topics: boolean;
   exclude_read: boolean;
+  exploration: number;
 }
 
 export interface NotificationPriorityRule {
@@ -59,6 +61,121 @@ export interface NavigatorPersonaDefinition {
   item?: NavigatorPersonaTaskHints;
 }
 
+export interface DigestApprovalSettings {
+  enabled: boolean;
+  auto_approve_minutes: number;
+}
+
+export interface StreakSettings {
+  daily_target: number;
+  monthly_freeze_allowance: number;
+}
+
+export interface LLMConcurrencySettings {
+  max: number | null;
+  effective: number;
+  ceiling: number;
+}
+
+export interface PrescreenSettings {
+  threshold: number | null;
+}
+
+export interface PrescreenProjection {
+  threshold: number;
+  window_days: number;
+  scored_items: number;
+  skipped_items: number;
+  skip_rate: number;
+  avg_cost_per_item_usd: number;
+  projected_savings_usd: number;
+}
+
+export interface EmbeddingStatus {
+  model: string;
+  summarized: number;
+  current: number;
+  stale: number;
+  missing: number;
+}
+
+export interface EmbeddingReindexResult {
+  model: string;
+  queued: number;
+  remaining: number;
+}
+
+export interface UserSession {
+  id: string;
+  kind: "browser" | "digest_feed";
+  ip_address?: string;
+  user_agent?: string;
+  created_at?: string;
+  last_used_at?: string;
+  current: boolean;
+}
+
+export interface ProcessingPauseStatus {
+  paused: boolean;
+  paused_at?: string;
+  backlog: number;
+}
+
+export interface ProcessingResumeResult extends ProcessingPauseStatus {
+  queued: number;
+  remaining: number;
+  backfill_minutes: number;
+}
+
+export interface AuditLogEntry {
+  id: string;
+  action: string;
+  target?: string;
+  metadata: Record<string, unknown>;
+  ip_address?: string;
+  user_agent?: string;
+  created_at: string;
+}
+
+export interface AuditLogPage {
+  entries: AuditLogEntry[];
+  next_before: string | null;
+}
+
+export type UserPlan = "free" | "pro" | "unlimited";
+
+export interface UsageLimits {
+  max_sources: number;
+  max_llm_items_per_day: number;
+}
+
+export interface UsageLimitStatus {
+  plan: UserPlan;
+  limits: UsageLimits;
+  sources: number;
+  llm_items_today: number;
+  sources_remaining: number | null;
+  llm_items_remaining: number | null;
+  resets_at: string;
+}
+
+export type UsageLimitError = APIErrorEnvelope<
+  "usage_limit_exceeded",
+  {
+    limit: "max_sources" | "max_llm_items_per_day";
+    plan: UserPlan;
+    max: number;
+    current: number;
+  }
+>;
+
+export interface InoreaderSyncSettings {
+  enabled: boolean;
+  read_state: boolean;
+  last_synced_at?: string | null;
+  last_error?: string | null;
+}
+
 export interface UserSettings {
   user_id: string;
   has_anthropic_api_key: boolean;
@@ -123,10 +240,23 @@ export interface UserSettings {
   podcast?: PodcastSettings;
   has_inoreader_oauth?: boolean;
   inoreader_token_expires_at?: string | null;
+  inoreader_sync?: InoreaderSyncSettings;
+  has_feedly_oauth?: boolean;
+  feedly_token_expires_at?: string | null;
   monthly_budget_usd: number | null;
   budget_alert_enabled: boolean;
   budget_alert_threshold_pct: number;
   digest_email_enabled: boolean;
+  digest_approval?: DigestApprovalSettings;
+  source_stats_sharing_enabled?: boolean;
+  html_snapshots_enabled?: boolean;
+  exclude_paywalled?: boolean;
+  social_boost_enabled?: boolean;
+  streak?: StreakSettings;
+  weekly_recap_enabled?: boolean;
+  briefing_greeting_style?: "standard" | "casual" | "formal" | "none" | string;
+  llm_concurrency?: LLMConcurrencySettings;
+  prescreen?: PrescreenSettings;
   reading_plan: UserReadingPlanSettings;
   llm_models?: {
     facts?: string | null;
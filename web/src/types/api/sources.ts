@@ -1,9 +1,15 @@
+import type { APIErrorEnvelope } from "./common";
+
 export interface Source {
   id: string;
   user_id: string;
   url: string;
   type: "rss" | "manual";
   title: string | null;
+  group_name?: string | null;
+  sync_provider?: string | null;
+  sync_removed_at?: string | null;
+  default_topics?: string[];
   enabled: boolean;
   last_fetched_at: string | null;
   created_at: string;
@@ -112,3 +118,31 @@ export interface SourceNavigatorResponse {
     generated_at?: string | null;
   } | null;
 }
+
+export type DuplicateSourceError = APIErrorEnvelope<"duplicate_source", { existing_source: Source }>;
+
+export interface SourceCostStage {
+  purpose: "facts" | "facts_check" | "summary" | "faithfulness_check";
+  model: string;
+  input_tokens_per_item: number;
+  output_tokens_per_item: number;
+  monthly_input_tokens: number;
+  monthly_output_tokens: number;
+  monthly_cost_usd: number;
+  priced: boolean;
+}
+
+export interface SourceCostEstimate {
+  source_id: string;
+  sampled_entries: number;
+  stored_bodies: number;
+  avg_content_chars: number;
+  avg_content_tokens: number;
+  entries_per_month: number;
+  frequency_known: boolean;
+  stages: SourceCostStage[];
+  monthly_input_tokens: number;
+  monthly_output_tokens: number;
+  monthly_cost_usd: number;
+  cost_per_item_usd: number;
+}
@@ -0,0 +1,32 @@
+import type { Item } from "./items";
+
+export interface Story {
+  id: string;
+  label: string;
+  item_count: number;
+  first_seen_at: string;
+  last_seen_at: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ClusterStory {
+  story_id: string;
+  label: string;
+  first_seen_at: string;
+  ongoing: boolean;
+  matched_by: "items" | "centroid" | string;
+  similarity?: number;
+}
+
+export interface StoryTimelineDay {
+  day: string;
+  bullets: string[];
+  pending: boolean;
+  items: Item[];
+}
+
+export interface StoryTimeline {
+  story: Story;
+  days: StoryTimelineDay[];
+}
@@ -0,0 +1,33 @@
+export type TopicAlertChannel = "email" | "webhook" | "push";
+
+export interface TopicAlertSubscription {
+  id: string;
+  topic: string;
+  min_delta: number;
+  channels: TopicAlertChannel[];
+  webhook_url?: string | null;
+  enabled: boolean;
+  last_alerted_at?: string | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface TopicAlertLog {
+  id: string;
+  subscription_id: string;
+  topic: string;
+  count_24h: number;
+  count_prev_24h: number;
+  delta: number;
+  item_ids: string[];
+  sent_channels: TopicAlertChannel[];
+  created_at: string;
+}
+
+export interface TopicAlertInput {
+  topic?: string;
+  min_delta?: number;
+  channels?: TopicAlertChannel[];
+  webhook_url?: string | null;
+  enabled?: boolean;
+}
@@ -0,0 +1,24 @@
+export type TopicAliasSource = "lexical" | "embedding" | "manual";
+
+export interface TopicAlias {
+  alias: string;
+  topic_id: string;
+  source: TopicAliasSource;
+  similarity?: number | null;
+}
+
+export interface Topic {
+  id: string;
+  name: string;
+  aliases: TopicAlias[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface TopicNormalizationResult {
+  raw_topics: number;
+  created_topics: number;
+  lexical_aliases: number;
+  embedding_aliases: number;
+  rewritten_summaries: number;
+}
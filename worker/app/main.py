@@ -1,17 +1,20 @@
 import os
 import logging
-import secrets
+import math
 from contextlib import asynccontextmanager
 
 from fastapi import FastAPI, Request
 from fastapi.responses import JSONResponse
 import sentry_sdk
 from sentry_sdk.integrations.fastapi import FastApiIntegration
-from app.routers import ai_navigator_brief, ask, ask_navigator, audio_briefing_script, audio_briefing_tts, briefing_navigator, digest, extract, facts, facts_check, feed_seed_suggestions, feed_suggestions, item_navigator, source_navigator, summary_audio_player, summarize, summary_faithfulness, translate_title, tts_markup_preprocess
+from app.routers import ai_navigator_brief, api_key_verify, ask, ask_navigator, audio_briefing_script, audio_briefing_tts, briefing_navigator, digest, extract, facts, facts_check, feed_seed_suggestions, feed_suggestions, item_navigator, source_navigator, summary_audio_player, summarize, summary_faithfulness, translate_item, translate_title, tts_markup_preprocess
+from app.services import internal_auth, request_context
+from app.services.provider_rate_limit import ProviderRateLimited
 from app.services.langfuse_client import flush as langfuse_flush, log_runtime_status as langfuse_log_runtime_status, span as langfuse_span, update_current as langfuse_update_current, update_current_trace as langfuse_update_current_trace
 
 _SENTRY_DSN = os.getenv("SENTRY_DSN", "").strip()
 _log = logging.getLogger(__name__)
+request_context.install_log_record_factory()
 if _SENTRY_DSN:
     sentry_sdk.init(
         dsn=_SENTRY_DSN,
@@ -36,9 +39,7 @@ app = FastAPI(title="sifto-worker", lifespan=lifespan)
 
 
 def _public_error_detail(request: Request, exc: Exception) -> str:
-    internal_secret = _INTERNAL_WORKER_SECRET
-    provided = str(request.headers.get("x-internal-worker-secret") or "").strip()
-    if internal_secret and provided == internal_secret:
+    if getattr(request.state, "internal_authenticated", False):
         detail = str(exc).strip()
         if detail:
             return detail[:1000]
@@ -47,25 +48,70 @@ def _public_error_detail(request: Request, exc: Exception) -> str:
 
 @app.exception_handler(Exception)
 async def global_exception_handler(request: Request, exc: Exception):
-    _log.error("unhandled exception on %s %s: %s", request.method, request.url.path, exc, exc_info=True)
+    request_id = request_context.normalize_request_id(request.headers.get(request_context.REQUEST_ID_HEADER))
+    _log.error("unhandled exception on %s %s request_id=%s: %s", request.method, request.url.path, request_id, exc, exc_info=True)
     return JSONResponse(
         status_code=500,
         content={"detail": _public_error_detail(request, exc)},
     )
 
-_INTERNAL_WORKER_SECRET = os.getenv("INTERNAL_WORKER_SECRET", "").strip()
 
+@app.exception_handler(ProviderRateLimited)
+async def provider_rate_limited_handler(request: Request, exc: ProviderRateLimited):
+    # The API persists a per-user cooldown from provider and retry_after_sec and sleeps the
+    # Inngest run until the window resets, instead of retrying into more 429s.
+    _log.warning("provider rate limited on %s %s provider=%s retry_after=%s", request.method, request.url.path, exc.provider, exc.retry_after_sec)
+    headers = {}
+    if exc.retry_after_sec is not None:
+        headers["Retry-After"] = str(max(1, math.ceil(exc.retry_after_sec)))
+    return JSONResponse(
+        status_code=429,
+        content={
+            "detail": {
+                "message": _public_error_detail(request, exc),
+                "provider": exc.provider,
+                "retry_after_sec": exc.retry_after_sec,
+            }
+        },
+        headers=headers,
+    )
 
-def _worker_auth_error_status(path: str, provided: str, configured: str) -> int | None:
+_INTERNAL_WORKER_SECRETS = internal_auth.configured_secrets()
+_INTERNAL_AUTH_ALLOW_STATIC_SECRET = internal_auth.static_secret_allowed()
+_INTERNAL_AUTH_NONCES = internal_auth.nonce_cache_from_env()
+
+
+def _worker_auth_error_status(
+    path: str,
+    method: str,
+    uri: str,
+    headers: dict[str, str],
+    body: bytes,
+    configured: list[str],
+    allow_static: bool = False,
+    nonces: internal_auth.NonceCache | internal_auth.RedisNonceCache | None = None,
+    now: float | None = None,
+) -> int | None:
     if path == "/health":
         return None
     if not configured:
         return 503
-    if not provided or not secrets.compare_digest(provided, configured):
+    if not headers.get(internal_auth.SIGNATURE_HEADER):
+        if allow_static and internal_auth.matches_static_secret(headers.get(internal_auth.LEGACY_SECRET_HEADER, ""), configured):
+            return None
+        return 401
+    if not internal_auth.verify_signature(headers, method, uri, body, configured, nonces or _INTERNAL_AUTH_NONCES, now):
         return 401
     return None
 
 
+def _request_uri(request: Request) -> str:
+    path = request.scope.get("raw_path", b"").decode("latin-1") or request.url.path
+    if request.url.query:
+        return f"{path}?{request.url.query}"
+    return path
+
+
 def _normalize_string_for_trace(value: str | None, limit: int | None = None) -> str:
     if value is None:
         return ""
@@ -79,14 +125,25 @@ def _normalize_string_for_trace(value: str | None, limit: int | None = None) ->
 
 @app.middleware("http")
 async def require_internal_worker_secret(request: Request, call_next):
-    provided = str(request.headers.get("x-internal-worker-secret") or "").strip()
-    auth_error = _worker_auth_error_status(request.url.path, provided, _INTERNAL_WORKER_SECRET)
+    if request.url.path == "/health":
+        return await call_next(request)
+    body = await request.body() if _INTERNAL_WORKER_SECRETS else b""
+    auth_error = _worker_auth_error_status(
+        request.url.path,
+        request.method,
+        _request_uri(request),
+        dict(request.headers),
+        body,
+        _INTERNAL_WORKER_SECRETS,
+        _INTERNAL_AUTH_ALLOW_STATIC_SECRET,
+    )
     if auth_error == 503:
         return JSONResponse(status_code=503, content={"detail": "worker authentication is not configured"})
     if auth_error == 401:
         return JSONResponse(status_code=401, content={"detail": "unauthorized"})
     if auth_error is not None:
         return JSONResponse(status_code=auth_error, content={"detail": "unauthorized"})
+    request.state.internal_authenticated = True
     return await call_next(request)
 
 
@@ -111,6 +168,7 @@ async def langfuse_request_tracing(request: Request, call_next):
         "digest_id": digest_id,
         "source_id": source_id,
         "purpose": purpose,
+        "request_id": request_context.current_request_id(),
     }
     observation_type = "span" if request.url.path == "/extract-body" else "generation"
     with langfuse_span(
@@ -149,12 +207,27 @@ async def langfuse_request_tracing(request: Request, call_next):
             raise
 
 
+# Declared last so it wraps the other middleware and the ID is set before tracing starts.
+@app.middleware("http")
+async def propagate_request_id(request: Request, call_next):
+    token = request_context.set_request_id(request.headers.get(request_context.REQUEST_ID_HEADER))
+    try:
+        response = await call_next(request)
+    finally:
+        request_context.reset_request_id(token)
+    request_id = request_context.normalize_request_id(request.headers.get(request_context.REQUEST_ID_HEADER))
+    if request_id:
+        response.headers["X-Request-Id"] = request_id
+    return response
+
+
 app.include_router(extract.router)
 app.include_router(facts.router)
 app.include_router(facts_check.router)
 app.include_router(summarize.router)
 app.include_router(summary_faithfulness.router)
 app.include_router(translate_title.router)
+app.include_router(translate_item.router)
 app.include_router(audio_briefing_tts.router)
 app.include_router(summary_audio_player.router)
 app.include_router(tts_markup_preprocess.router)
@@ -168,6 +241,7 @@ app.include_router(briefing_navigator.router)
 app.include_router(ai_navigator_brief.router)
 app.include_router(item_navigator.router)
 app.include_router(source_navigator.router)
+app.include_router(api_key_verify.router)
 
 
 @app.get("/health")
@@ -0,0 +1,35 @@
+from fastapi import APIRouter, HTTPException, Request
+from pydantic import BaseModel
+
+from app.services.api_key_verify import SUPPORTED_PROVIDERS, verify_api_key
+
+router = APIRouter()
+
+_KEY_HEADERS = {
+    "anthropic": "x-anthropic-api-key",
+    "openai": "x-openai-api-key",
+    "google": "x-google-api-key",
+}
+
+
+class VerifyAPIKeyRequest(BaseModel):
+    provider: str
+
+
+class VerifyAPIKeyResponse(BaseModel):
+    provider: str
+    valid: bool
+    status_code: int
+    error: str | None = None
+    limits: dict[str, int] = {}
+
+
+@router.post("/verify-api-key", response_model=VerifyAPIKeyResponse)
+async def verify_api_key_endpoint(req: VerifyAPIKeyRequest, request: Request):
+    provider = (req.provider or "").strip().lower()
+    if provider not in SUPPORTED_PROVIDERS:
+        raise HTTPException(status_code=400, detail=f"unsupported provider: {provider}")
+    api_key = request.headers.get(_KEY_HEADERS[provider], "").strip()
+    if not api_key:
+        raise HTTPException(status_code=400, detail="api key header is required")
+    return await verify_api_key(provider, api_key)
@@ -41,6 +41,20 @@ class AskResponse(BaseModel):
     llm: dict | None = None
 
 
+class AnswerCitation(BaseModel):
+    item_id: str
+    url: str
+    title: str | None = None
+    reason: str = ""
+
+
+class AnswerResponse(BaseModel):
+    answer: str
+    bullets: list[str]
+    citations: list[AnswerCitation]
+    llm: dict | None = None
+
+
 class AskRerankRequest(BaseModel):
     query: str
     candidates: list[AskCandidate]
@@ -83,6 +97,57 @@ async def ask_endpoint(req: AskRequest, request: Request):
     return AskResponse(**result)
 
 
+def build_answer_citations(citations: list[dict], candidates: list[dict]) -> list[dict]:
+    by_id = {c["item_id"]: c for c in candidates}
+    out: list[dict] = []
+    seen: set[str] = set()
+    for citation in citations:
+        item_id = str(citation.get("item_id") or "")
+        candidate = by_id.get(item_id)
+        if candidate is None or item_id in seen:
+            continue
+        seen.add(item_id)
+        out.append(
+            {
+                "item_id": item_id,
+                "url": candidate["url"],
+                "title": candidate.get("translated_title") or candidate.get("title"),
+                "reason": str(citation.get("reason") or ""),
+            }
+        )
+    return out
+
+
+@router.post("/answer", response_model=AnswerResponse)
+async def answer_endpoint(req: AskRequest, request: Request):
+    candidates = [c.model_dump() for c in req.candidates]
+    result = await run_observed_request_async(
+        request,
+        metadata={"model": req.model or "", "candidates_count": len(candidates), "query_chars": len(req.query or "")},
+        input_payload={"query": req.query, "candidates_count": len(candidates), "model": req.model},
+        call=lambda: dispatch_by_model_async(
+            request,
+            req.model,
+            handlers=build_handler_map_async(
+                "ask_question",
+                args_fn=lambda func, api_key: func(req.query, candidates, model=str(req.model), api_key=api_key or ""),
+                anthropic_args_fn=lambda func, api_key: func(req.query, candidates, api_key=api_key, model=req.model),
+            ),
+        ),
+        output_builder=lambda result: {
+            "answer_chars": len(result.get("answer") or ""),
+            "citations_count": len(result.get("citations") or []),
+            **llm_usage_summary(result),
+        },
+    )
+    return AnswerResponse(
+        answer=result.get("answer") or "",
+        bullets=result.get("bullets") or [],
+        citations=build_answer_citations(result.get("citations") or [], candidates),
+        llm=result.get("llm"),
+    )
+
+
 @router.post("/ask-rerank", response_model=AskRerankResponse)
 async def ask_rerank_endpoint(req: AskRerankRequest, request: Request):
     candidates = [c.model_dump() for c in req.candidates]
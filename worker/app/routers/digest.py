@@ -2,7 +2,7 @@ from fastapi import APIRouter, Request
 from pydantic import BaseModel
 from app.services.claude_service import compose_digest, compose_digest_cluster_draft
 from app.services.llm_dispatch import dispatch_by_model_async
-from app.services.runtime_prompt_overrides import bind_prompt_override
+from app.services.runtime_prompt_overrides import bind_digest_style, bind_prompt_override, bind_target_language
 from app.services.router_observe import llm_usage_summary, run_observed_request_async
 from app.auto_dispatch import build_handler_map_async
 
@@ -23,6 +23,10 @@ class ComposeDigestRequest(BaseModel):
     items: list[DigestItem]
     model: str | None = None
     prompt: dict | None = None
+    target_language: str | None = None
+    locale: str | None = None
+    verbosity: str | None = None
+    tone: str | None = None
 
 
 class ComposeDigestResponse(BaseModel):
@@ -57,7 +61,7 @@ async def compose_digest_endpoint(req: ComposeDigestRequest, request: Request):
         }
         for i in req.items
     ]
-    with bind_prompt_override((req.prompt or {}).get("prompt_key"), (req.prompt or {}).get("prompt_text"), (req.prompt or {}).get("system_instruction")):
+    with bind_prompt_override((req.prompt or {}).get("prompt_key"), (req.prompt or {}).get("prompt_text"), (req.prompt or {}).get("system_instruction")), bind_target_language(req.target_language or (req.locale if req.locale and req.locale != "ja" else None)), bind_digest_style(req.verbosity, req.tone):
         result = await run_observed_request_async(
             request,
             metadata={"model": req.model or "", "digest_date": req.digest_date, "items_count": len(req.items or [])},
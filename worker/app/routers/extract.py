@@ -1,6 +1,6 @@
 from fastapi import APIRouter, HTTPException, Request
 from pydantic import BaseModel
-from app.services.trafilatura_service import extract_body
+from app.services.trafilatura_service import UpstreamHTTPError, extract_body
 from app.services.youtube_extract_service import (
     YouTubeTranscriptUnavailableError,
     extract_body as extract_youtube_body,
@@ -20,6 +20,7 @@ class ExtractResponse(BaseModel):
     content: str
     published_at: str | None
     image_url: str | None
+    canonical_url: str | None = None
 
 
 @router.post("/extract-body", response_model=ExtractResponse)
@@ -61,6 +62,16 @@ def extract_body_endpoint(req: ExtractRequest, request: Request):
                         "image_url": call_error.image_url,
                     },
                 )
+            if isinstance(call_error, UpstreamHTTPError):
+                # The API only falls back to web archives when the page itself is gone or blocked.
+                raise HTTPException(
+                    status_code=422,
+                    detail={
+                        "code": "upstream_http_status",
+                        "message": str(call_error),
+                        "upstream_status": call_error.status_code,
+                    },
+                )
             raise HTTPException(status_code=422, detail=str(call_error))
         raise HTTPException(status_code=422, detail="Failed to extract body")
     return result
@@ -2,7 +2,7 @@ from fastapi import APIRouter, Request
 from pydantic import BaseModel
 from app.services.claude_service import summarize
 from app.services.llm_dispatch import dispatch_by_model_async
-from app.services.runtime_prompt_overrides import bind_prompt_override
+from app.services.runtime_prompt_overrides import bind_prompt_override, bind_target_language
 from app.services.router_observe import llm_usage_summary, run_observed_request_async
 from app.auto_dispatch import build_handler_map_async
 
@@ -15,6 +15,7 @@ class SummarizeRequest(BaseModel):
     model: str | None = None
     source_text_chars: int | None = None
     prompt: dict | None = None
+    target_language: str | None = None
 
 
 class SummarizeResponse(BaseModel):
@@ -22,6 +23,7 @@ class SummarizeResponse(BaseModel):
     topics: list[str]
     genre: str = ""
     other_label: str = ""
+    depth: str = ""
     translated_title: str = ""
     score: float
     score_breakdown: dict | None = None
@@ -32,7 +34,7 @@ class SummarizeResponse(BaseModel):
 
 @router.post("/summarize", response_model=SummarizeResponse)
 async def summarize_endpoint(req: SummarizeRequest, request: Request):
-    with bind_prompt_override((req.prompt or {}).get("prompt_key"), (req.prompt or {}).get("prompt_text"), (req.prompt or {}).get("system_instruction")):
+    with bind_prompt_override((req.prompt or {}).get("prompt_key"), (req.prompt or {}).get("prompt_text"), (req.prompt or {}).get("system_instruction")), bind_target_language(req.target_language):
         result = await run_observed_request_async(
             request,
             metadata={"model": req.model or "", "facts_count": len(req.facts or []), "source_text_chars": req.source_text_chars or 0},
@@ -0,0 +1,53 @@
+from fastapi import APIRouter, Request
+from pydantic import BaseModel
+from app.services.llm_dispatch import dispatch_by_model_async
+from app.services.router_observe import llm_usage_summary, run_observed_request_async
+from app.auto_dispatch import build_handler_map_async
+
+router = APIRouter()
+
+
+class TranslateItemRequest(BaseModel):
+    title: str | None = None
+    summary: str
+    facts: list[str] = []
+    content: str | None = None
+    target_language: str = "ja"
+    model: str | None = None
+
+
+class TranslateItemResponse(BaseModel):
+    title: str = ""
+    summary: str = ""
+    facts: list[str] = []
+    content: str = ""
+    llm: dict | None = None
+
+
+@router.post("/translate-item", response_model=TranslateItemResponse)
+async def translate_item_endpoint(req: TranslateItemRequest, request: Request):
+    result = await run_observed_request_async(
+        request,
+        metadata={
+            "model": req.model or "",
+            "target_language": req.target_language,
+            "facts_count": len(req.facts),
+            "content_chars": len(req.content or ""),
+        },
+        input_payload={"target_language": req.target_language, "model": req.model, "has_content": bool(req.content)},
+        call=lambda: dispatch_by_model_async(
+            request,
+            req.model,
+            handlers=build_handler_map_async(
+                "translate_item",
+                args_fn=lambda func, api_key: func(req.title, req.summary, req.facts, req.content, req.target_language, model=str(req.model), api_key=api_key or ""),
+                anthropic_args_fn=lambda func, api_key: func(req.title, req.summary, req.facts, req.content, req.target_language, api_key=api_key, model=req.model),
+            ),
+        ),
+        output_builder=lambda result: {
+            "summary_chars": len(result.get("summary") or ""),
+            "content_chars": len(result.get("content") or ""),
+            **llm_usage_summary(result),
+        },
+    )
+    return result
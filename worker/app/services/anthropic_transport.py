@@ -3,6 +3,8 @@ import time
 
 import anthropic
 
+from app.services.provider_rate_limit import ProviderRateLimited, retry_after_seconds
+
 
 def message_text(message) -> str:
     if message is None:
@@ -127,6 +129,12 @@ def is_rate_limit_error(err: Exception) -> bool:
     return "429" in s or "rate_limit" in s
 
 
+def rate_limit_exception(provider_label: str, err: Exception) -> ProviderRateLimited:
+    response = getattr(err, "response", None)
+    headers = getattr(response, "headers", None)
+    return ProviderRateLimited(provider_label, retry_after_seconds(headers), f"{provider_label} rate limited status=429: {err}")
+
+
 def call_with_retries(
     prompt: str,
     model: str,
@@ -163,8 +171,10 @@ def call_with_retries(
             )
         except Exception as e:
             last_err = e
-            if attempt >= retries or not is_rate_limit_error(e):
+            if not is_rate_limit_error(e):
                 raise
+            if attempt >= retries:
+                raise rate_limit_exception(provider_label, e) from e
             sleep_sec = 1.0 * (2**attempt)
             if logger is not None:
                 logger.warning(
@@ -226,6 +236,7 @@ def call_with_model_fallback(
         failures.append({"model": primary_model, "reason": str(e)})
         if logger is not None:
             logger.warning("%s call failed model=%s err=%s", provider_label, primary_model, e)
+        rate_limited = e if isinstance(e, ProviderRateLimited) else None
         if fallback_model and fallback_model != primary_model:
             try:
                 return (
@@ -252,6 +263,11 @@ def call_with_model_fallback(
                 failures.append({"model": fallback_model, "reason": str(e2)})
                 if logger is not None:
                     logger.warning("%s fallback failed model=%s err=%s", provider_label, fallback_model, e2)
+                rate_limited = e2 if isinstance(e2, ProviderRateLimited) else rate_limited
+        # A 429 is raised rather than reported as a failure so the API records the cooldown
+        # and waits out the window instead of retrying right away.
+        if rate_limited is not None:
+            raise rate_limited
         return None, None, failures
 
 
@@ -331,8 +347,10 @@ async def call_with_retries_async(
             )
         except Exception as e:
             last_err = e
-            if attempt >= retries or not is_rate_limit_error(e):
+            if not is_rate_limit_error(e):
                 raise
+            if attempt >= retries:
+                raise rate_limit_exception(provider_label, e) from e
             sleep_sec = 1.0 * (2**attempt)
             if logger is not None:
                 logger.warning(
@@ -394,6 +412,7 @@ async def call_with_model_fallback_async(
         failures.append({"model": primary_model, "reason": str(e)})
         if logger is not None:
             logger.warning("%s call failed model=%s err=%s", provider_label, primary_model, e)
+        rate_limited = e if isinstance(e, ProviderRateLimited) else None
         if fallback_model and fallback_model != primary_model:
             try:
                 return (
@@ -420,4 +439,9 @@ async def call_with_model_fallback_async(
                 failures.append({"model": fallback_model, "reason": str(e2)})
                 if logger is not None:
                     logger.warning("%s fallback failed model=%s err=%s", provider_label, fallback_model, e2)
+                rate_limited = e2 if isinstance(e2, ProviderRateLimited) else rate_limited
+        # A 429 is raised rather than reported as a failure so the API records the cooldown
+        # and waits out the window instead of retrying right away.
+        if rate_limited is not None:
+            raise rate_limited
         return None, None, failures
@@ -0,0 +1,84 @@
+import os
+
+import httpx
+
+ANTHROPIC_MODELS_URL = "https://api.anthropic.com/v1/models?limit=1"
+OPENAI_MODELS_URL = "https://api.openai.com/v1/models"
+GOOGLE_MODELS_URL = "https://generativelanguage.googleapis.com/v1beta/models?pageSize=1"
+
+SUPPORTED_PROVIDERS = ("anthropic", "openai", "google")
+
+# Normalized limit name -> provider response header.
+_RATE_LIMIT_HEADERS = {
+    "anthropic": {
+        "requests_per_minute": "anthropic-ratelimit-requests-limit",
+        "tokens_per_minute": "anthropic-ratelimit-tokens-limit",
+        "input_tokens_per_minute": "anthropic-ratelimit-input-tokens-limit",
+        "output_tokens_per_minute": "anthropic-ratelimit-output-tokens-limit",
+    },
+    "openai": {
+        "requests_per_minute": "x-ratelimit-limit-requests",
+        "tokens_per_minute": "x-ratelimit-limit-tokens",
+    },
+    "google": {},
+}
+
+
+def _timeout_seconds() -> float:
+    raw = os.getenv("API_KEY_VERIFY_TIMEOUT_SEC", "")
+    try:
+        v = float(raw)
+        return v if v > 0 else 10.0
+    except ValueError:
+        return 10.0
+
+
+def parse_rate_limits(provider: str, headers) -> dict[str, int]:
+    limits: dict[str, int] = {}
+    for name, header in _RATE_LIMIT_HEADERS.get(provider, {}).items():
+        raw = str(headers.get(header) or "").strip()
+        if not raw:
+            continue
+        try:
+            limits[name] = int(raw)
+        except ValueError:
+            continue
+    return limits
+
+
+def verification_result(provider: str, status_code: int, headers, body_text: str = "") -> dict:
+    valid = 200 <= status_code < 300
+    error = None
+    if not valid:
+        if status_code in (401, 403):
+            error = "invalid api key"
+        elif status_code == 429:
+            # The key authenticated but the account is throttled; treat it as usable.
+            valid = True
+        else:
+            error = (body_text or f"status {status_code}").strip()[:300]
+    return {
+        "provider": provider,
+        "valid": valid,
+        "status_code": status_code,
+        "error": error,
+        "limits": parse_rate_limits(provider, headers),
+    }
+
+
+def _request(provider: str, api_key: str) -> tuple[str, dict[str, str]]:
+    if provider == "anthropic":
+        return ANTHROPIC_MODELS_URL, {"x-api-key": api_key, "anthropic-version": "2023-06-01"}
+    if provider == "openai":
+        return OPENAI_MODELS_URL, {"Authorization": f"Bearer {api_key}"}
+    if provider == "google":
+        return GOOGLE_MODELS_URL, {"x-goog-api-key": api_key}
+    raise ValueError(f"unsupported provider: {provider}")
+
+
+async def verify_api_key(provider: str, api_key: str) -> dict:
+    url, headers = _request(provider, api_key)
+    async with httpx.AsyncClient(timeout=_timeout_seconds()) as client:
+        resp = await client.get(url, headers=headers)
+    body_text = "" if resp.is_success else resp.text
+    return verification_result(provider, resp.status_code, resp.headers, body_text)
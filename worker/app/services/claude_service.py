@@ -52,6 +52,7 @@ from app.services.digest_task_common import (
     parse_cluster_draft_result,
     parse_digest_result,
 )
+from app.services.item_translation_common import build_item_translation_task, parse_item_translation_result
 from app.services.feed_task_common import (
     ASK_MAX_OUTPUT_TOKENS,
     build_ai_navigator_brief_task,
@@ -483,6 +484,7 @@ def summarize(
         topics=topics,
         genre=str(data.get("genre") or "").strip(),
         other_label=str(data.get("other_label") or "").strip(),
+        depth=str(data.get("depth") or "").strip(),
         raw_score_breakdown=data.get("score_breakdown") if isinstance(data.get("score_breakdown"), dict) else {},
         score_reason=str(data.get("score_reason") or "").strip(),
         translated_title=str(data.get("translated_title") or "").strip(),
@@ -991,6 +993,33 @@ def generate_item_navigator(
     }
 
 
+def translate_item(
+    title: str | None,
+    summary: str,
+    facts: list[str],
+    content: str | None,
+    target_language: str,
+    api_key: str | None = None,
+    model: str | None = None,
+) -> dict:
+    resolved_model = _require_model(model, "translation")
+    task = build_item_translation_task(title, summary, facts, content, target_language)
+    _require_api_key(api_key, "translation")
+    message, used_model, _execution_failures = _call_with_model_fallback(
+        task["prompt"],
+        resolved_model,
+        None,
+        max_tokens=task["max_output_tokens"],
+        api_key=api_key,
+        temperature=0.2,
+    )
+    if message is None:
+        _raise_execution_failure("translation", _execution_failures, "anthropic translation returned no message")
+
+    out = parse_item_translation_result(_message_text(message), task["source"])
+    return {**out, "llm": _llm_meta(message, "translation", used_model or resolved_model)}
+
+
 def generate_audio_briefing_script(
     persona: str,
     articles: list[dict],
@@ -1264,6 +1293,7 @@ async def summarize_async(
         topics=topics,
         genre=str(data.get("genre") or "").strip(),
         other_label=str(data.get("other_label") or "").strip(),
+        depth=str(data.get("depth") or "").strip(),
         raw_score_breakdown=data.get("score_breakdown") if isinstance(data.get("score_breakdown"), dict) else {},
         score_reason=str(data.get("score_reason") or "").strip(),
         translated_title=str(data.get("translated_title") or "").strip(),
@@ -1687,6 +1717,33 @@ async def generate_item_navigator_async(
     }
 
 
+async def translate_item_async(
+    title: str | None,
+    summary: str,
+    facts: list[str],
+    content: str | None,
+    target_language: str,
+    api_key: str | None = None,
+    model: str | None = None,
+) -> dict:
+    resolved_model = _require_model(model, "translation")
+    task = build_item_translation_task(title, summary, facts, content, target_language)
+    _require_api_key(api_key, "translation")
+    message, used_model, _execution_failures = await _call_with_model_fallback_async(
+        task["prompt"],
+        resolved_model,
+        None,
+        max_tokens=task["max_output_tokens"],
+        api_key=api_key,
+        temperature=0.2,
+    )
+    if message is None:
+        _raise_execution_failure("translation", _execution_failures, "anthropic translation returned no message")
+
+    out = parse_item_translation_result(_message_text(message), task["source"])
+    return {**out, "llm": _llm_meta(message, "translation", used_model or resolved_model)}
+
+
 async def generate_audio_briefing_script_async(
     persona: str,
     articles: list[dict],
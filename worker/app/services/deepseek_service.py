@@ -26,6 +26,7 @@ rank_feed_suggestions = _p.rank_feed_suggestions
 generate_briefing_navigator = _p.generate_briefing_navigator
 compose_ai_navigator_brief = _p.compose_ai_navigator_brief
 generate_item_navigator = _p.generate_item_navigator
+translate_item = _p.translate_item
 generate_audio_briefing_script = _p.generate_audio_briefing_script
 generate_ask_navigator = _p.generate_ask_navigator
 generate_source_navigator = _p.generate_source_navigator
@@ -44,6 +45,7 @@ rank_feed_suggestions_async = _p.rank_feed_suggestions_async
 generate_briefing_navigator_async = _p.generate_briefing_navigator_async
 compose_ai_navigator_brief_async = _p.compose_ai_navigator_brief_async
 generate_item_navigator_async = _p.generate_item_navigator_async
+translate_item_async = _p.translate_item_async
 generate_audio_briefing_script_async = _p.generate_audio_briefing_script_async
 generate_ask_navigator_async = _p.generate_ask_navigator_async
 generate_source_navigator_async = _p.generate_source_navigator_async
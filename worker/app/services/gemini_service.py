@@ -53,6 +53,7 @@ from app.services.digest_task_common import (
     parse_cluster_draft_result,
     parse_digest_result,
 )
+from app.services.item_translation_common import build_item_translation_task, parse_item_translation_result
 from app.services.feed_task_common import (
     ASK_MAX_OUTPUT_TOKENS,
     build_ai_navigator_brief_task,
@@ -424,6 +425,20 @@ def generate_item_navigator(persona: str, article: dict, model: str, api_key: st
     return {"headline": out["headline"], "commentary": out["commentary"], "stance_tags": out["stance_tags"], "llm": _llm_meta(model, "item_navigator", usage)}
 
 
+def translate_item(title: str | None, summary: str, facts: list[str], content: str | None, target_language: str, model: str, api_key: str) -> dict:
+    task = build_item_translation_task(title, summary, facts, content, target_language)
+    text, usage = _generate_content(
+        task["prompt"],
+        model=model,
+        api_key=api_key,
+        max_output_tokens=task["max_output_tokens"],
+        response_schema=task["schema"],
+        temperature=0.2,
+    )
+    out = parse_item_translation_result(text, task["source"])
+    return {**out, "llm": _llm_meta(model, "translation", usage)}
+
+
 def generate_audio_briefing_script(
     persona: str,
     articles: list[dict],
@@ -623,6 +638,7 @@ def summarize(
         topics=topics,
         genre=str(data.get("genre") or "").strip(),
         other_label=str(data.get("other_label") or "").strip(),
+        depth=str(data.get("depth") or "").strip(),
         raw_score_breakdown=data.get("score_breakdown") if isinstance(data.get("score_breakdown"), dict) else {},
         score_reason=str(data.get("score_reason") or "").strip(),
         translated_title=str(data.get("translated_title") or "").strip(),
@@ -898,6 +914,20 @@ async def generate_item_navigator_async(persona: str, article: dict, model: str,
     return {"headline": out["headline"], "commentary": out["commentary"], "stance_tags": out["stance_tags"], "llm": _llm_meta(model, "item_navigator", usage)}
 
 
+async def translate_item_async(title: str | None, summary: str, facts: list[str], content: str | None, target_language: str, model: str, api_key: str) -> dict:
+    task = build_item_translation_task(title, summary, facts, content, target_language)
+    text, usage = await _generate_content_async(
+        task["prompt"],
+        model=model,
+        api_key=api_key,
+        max_output_tokens=task["max_output_tokens"],
+        response_schema=task["schema"],
+        temperature=0.2,
+    )
+    out = parse_item_translation_result(text, task["source"])
+    return {**out, "llm": _llm_meta(model, "translation", usage)}
+
+
 async def generate_audio_briefing_script_async(
     persona: str,
     articles: list[dict],
@@ -1098,6 +1128,7 @@ async def summarize_async(
         topics=topics,
         genre=str(data.get("genre") or "").strip(),
         other_label=str(data.get("other_label") or "").strip(),
+        depth=str(data.get("depth") or "").strip(),
         raw_score_breakdown=data.get("score_breakdown") if isinstance(data.get("score_breakdown"), dict) else {},
         score_reason=str(data.get("score_reason") or "").strip(),
         translated_title=str(data.get("translated_title") or "").strip(),
@@ -6,6 +6,7 @@ import hashlib
 from datetime import datetime, timezone
 
 import httpx
+from app.services.provider_rate_limit import ProviderRateLimited, google_retry_delay_seconds, retry_after_seconds
 try:
     import redis
 except Exception:  # pragma: no cover
@@ -155,6 +156,17 @@ def redis_cache_set(cache_key: str, name: str, exp_ts: float, ttl_sec: int, logg
         logger.warning("gemini context cache redis set failed: %s", e)
 
 
+def gemini_retry_after_seconds(resp: httpx.Response) -> float | None:
+    """Gemini states the wait of a 429 in the body's RetryInfo rather than in headers."""
+    retry_after = retry_after_seconds(resp.headers)
+    if retry_after is not None:
+        return retry_after
+    try:
+        return google_retry_delay_seconds(resp.json())
+    except ValueError:
+        return None
+
+
 def is_cached_content_too_small_error(status_code: int, body_text: str) -> bool:
     if status_code != 400:
         return False
@@ -296,6 +308,10 @@ def generate_content(
             cached_content_name = ""
             if i < attempts - 1:
                 continue
+        if resp.status_code == 429:
+            retry_after = gemini_retry_after_seconds(resp)
+            if retry_after is not None or i == attempts - 1:
+                raise ProviderRateLimited("google", retry_after, f"gemini generateContent failed status=429 body={resp.text[:1000]}")
         if resp.status_code in retryable_status and i < attempts - 1:
             time.sleep(base_sleep_sec * (2**i))
             continue
@@ -452,6 +468,10 @@ async def generate_content_async(
             cached_content_name = ""
             if i < attempts - 1:
                 continue
+        if resp.status_code == 429:
+            retry_after = gemini_retry_after_seconds(resp)
+            if retry_after is not None or i == attempts - 1:
+                raise ProviderRateLimited("google", retry_after, f"gemini generateContent failed status=429 body={resp.text[:1000]}")
         if resp.status_code in retryable_status and i < attempts - 1:
             await asyncio.sleep(base_sleep_sec * (2**i))
             continue
@@ -0,0 +1,135 @@
+from __future__ import annotations
+
+import hashlib
+import hmac
+import logging
+import os
+import threading
+import time
+
+try:
+    import redis
+except Exception:  # pragma: no cover
+    redis = None
+
+# Mirrors api/internal/service/internal_signature.go: the API signs every worker request with
+# HMAC-SHA256 over method, request URI, unix timestamp, nonce, the acting user's email and the
+# SHA-256 of the body.
+TIMESTAMP_HEADER = "x-internal-timestamp"
+NONCE_HEADER = "x-internal-nonce"
+SIGNATURE_HEADER = "x-internal-signature"
+USER_EMAIL_HEADER = "x-internal-user-email"
+LEGACY_SECRET_HEADER = "x-internal-worker-secret"
+
+SIGNATURE_WINDOW_SEC = 300
+_SIGNATURE_PREFIX = "v1="
+
+_log = logging.getLogger(__name__)
+
+
+def configured_secrets() -> list[str]:
+    """INTERNAL_WORKER_SECRET, then INTERNAL_WORKER_SECRET_PREVIOUS while a rotation is in progress."""
+    out = []
+    for key in ("INTERNAL_WORKER_SECRET", "INTERNAL_WORKER_SECRET_PREVIOUS"):
+        value = os.getenv(key, "").strip()
+        if value:
+            out.append(value)
+    return out
+
+
+def static_secret_allowed() -> bool:
+    return os.getenv("INTERNAL_AUTH_ALLOW_STATIC_SECRET", "").strip().lower() in {"1", "true", "yes", "on"}
+
+
+def internal_signature(secret: str, method: str, uri: str, timestamp: str, nonce: str, body: bytes, user_email: str = "") -> str:
+    body_hash = hashlib.sha256(body or b"").hexdigest()
+    payload = "\n".join([method.upper(), uri, timestamp, nonce, (user_email or "").strip(), body_hash])
+    return _SIGNATURE_PREFIX + hmac.new(secret.encode("utf-8"), payload.encode("utf-8"), hashlib.sha256).hexdigest()
+
+
+class NonceCache:
+    """Remembers accepted nonces until their timestamp leaves the window, per process."""
+
+    def __init__(self) -> None:
+        self._lock = threading.Lock()
+        self._seen: dict[str, float] = {}
+
+    def add(self, nonce: str, expires_at: float, now: float) -> bool:
+        with self._lock:
+            for key in [k for k, exp in self._seen.items() if exp < now]:
+                del self._seen[key]
+            if nonce in self._seen:
+                return False
+            self._seen[nonce] = expires_at
+            return True
+
+
+class RedisNonceCache:
+    """Shares accepted nonces between worker processes with SET NX and a TTL."""
+
+    def __init__(self, client, prefix: str = "sifto") -> None:
+        self.client = client
+        self.prefix = prefix
+
+    def add(self, nonce: str, expires_at: float, now: float) -> bool:
+        ttl_ms = max(int((expires_at - now) * 1000), 1000)
+        try:
+            return bool(self.client.set(f"{self.prefix}:internal_nonce:{nonce}", "1", nx=True, px=ttl_ms))
+        except Exception as exc:
+            _log.warning("internal auth nonce claim failed: %s", exc)
+            return False
+
+
+def nonce_cache_from_env() -> NonceCache | RedisNonceCache:
+    """Redis when REDIS_URL (or UPSTASH_REDIS_URL) is set, otherwise this process only."""
+    redis_url = os.getenv("REDIS_URL") or os.getenv("UPSTASH_REDIS_URL") or ""
+    if redis is None or not redis_url:
+        return NonceCache()
+    try:
+        client = redis.Redis.from_url(redis_url, decode_responses=True)
+    except Exception as exc:
+        _log.warning("internal auth redis unavailable, keeping nonces in process: %s", exc)
+        return NonceCache()
+    return RedisNonceCache(client, os.getenv("REDIS_CACHE_PREFIX", "").strip() or "sifto")
+
+
+def verify_signature(
+    headers: dict[str, str],
+    method: str,
+    uri: str,
+    body: bytes,
+    secrets: list[str],
+    nonces: NonceCache | RedisNonceCache,
+    now: float | None = None,
+) -> bool:
+    timestamp = str(headers.get(TIMESTAMP_HEADER) or "").strip()
+    nonce = str(headers.get(NONCE_HEADER) or "").strip()
+    provided = str(headers.get(SIGNATURE_HEADER) or "").strip()
+    if not timestamp or not nonce or not provided:
+        return False
+    try:
+        signed_at = int(timestamp)
+    except ValueError:
+        return False
+    now = time.time() if now is None else now
+    if abs(now - signed_at) > SIGNATURE_WINDOW_SEC:
+        return False
+    user_email = str(headers.get(USER_EMAIL_HEADER) or "").strip()
+    matched = False
+    for secret in secrets:
+        if secret and hmac.compare_digest(provided.encode("utf-8"), internal_signature(secret, method, uri, timestamp, nonce, body, user_email).encode("utf-8")):
+            matched = True
+    if not matched:
+        return False
+    return nonces.add(nonce, signed_at + SIGNATURE_WINDOW_SEC, now)
+
+
+def matches_static_secret(provided: str, secrets: list[str]) -> bool:
+    provided = str(provided or "").strip()
+    if not provided:
+        return False
+    matched = False
+    for secret in secrets:
+        if secret and hmac.compare_digest(provided.encode("utf-8"), secret.encode("utf-8")):
+            matched = True
+    return matched
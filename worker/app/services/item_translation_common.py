@@ -0,0 +1,76 @@
+import json
+
+from app.services.llm_text_utils import extract_first_json_object
+from app.services.runtime_prompt_overrides import target_language_name
+
+ITEM_TRANSLATION_SCHEMA = {
+    "type": "object",
+    "properties": {
+        "title": {"type": "string"},
+        "summary": {"type": "string"},
+        "facts": {"type": "array", "items": {"type": "string"}},
+        "content": {"type": "string"},
+    },
+    "required": ["title", "summary", "facts", "content"],
+    "additionalProperties": False,
+}
+
+
+def item_translation_max_output_tokens(content: str | None) -> int:
+    if str(content or "").strip():
+        return 12000
+    return 3000
+
+
+def build_item_translation_task(
+    title: str | None,
+    summary: str,
+    facts: list[str],
+    content: str | None,
+    target_language: str,
+) -> dict:
+    code = str(target_language or "").strip().lower() or "ja"
+    language = target_language_name(code)
+    source = {
+        "title": str(title or "").strip(),
+        "summary": str(summary or "").strip(),
+        "facts": [str(v).strip() for v in facts or [] if str(v).strip()],
+        "content": str(content or "").strip(),
+    }
+    prompt = f"""Translate the article fields below into {language}.
+
+Rules:
+- Return JSON only, with the keys title / summary / facts / content
+- Translate faithfully. Do not summarize, add, or drop information
+- Keep proper nouns, product names, code, numbers and URLs as they are
+- facts must have exactly {len(source["facts"])} entries, in the same order as the input
+- If a field is already in {language}, return it unchanged
+- If a field is empty, return an empty string for it
+- content may be long; keep its paragraph breaks
+
+Input:
+{json.dumps(source, ensure_ascii=False)}
+"""
+    return {
+        "prompt": prompt,
+        "schema": ITEM_TRANSLATION_SCHEMA,
+        "source": source,
+        "target_language": code,
+        "max_output_tokens": item_translation_max_output_tokens(source["content"]),
+    }
+
+
+def parse_item_translation_result(text: str, source: dict) -> dict:
+    data = extract_first_json_object(text) or {}
+    facts = [str(v).strip() for v in (data.get("facts") or []) if str(v).strip()]
+    source_facts = source.get("facts") or []
+    # A fact list of another length no longer lines up with the original; keep the source.
+    if len(facts) != len(source_facts):
+        facts = list(source_facts)
+    content = str(data.get("content") or "").strip() if source.get("content") else ""
+    return {
+        "title": str(data.get("title") or "").strip()[:300],
+        "summary": str(data.get("summary") or "").strip(),
+        "facts": facts,
+        "content": content,
+    }
@@ -7,6 +7,8 @@ import os
 import threading
 from contextlib import asynccontextmanager, contextmanager
 
+from app.services.provider_rate_limit import rate_limited_error, retry_after_seconds
+
 try:
     import redis
 except Exception:  # pragma: no cover
@@ -557,6 +559,8 @@ def run_chat_json(
                 if retry_usage.get("execution_failures"):
                     usage["execution_failures"] = list(retry_usage["execution_failures"])
                 return text, usage
+            if resp.status_code == 429 and retry_after_seconds(resp.headers) is not None:
+                break
             if resp.status_code in retryable_status and i < attempts - 1:
                 _append_execution_failure(retry_usage, requested_model, f"status={resp.status_code} body={resp.text[:1000]}")
                 sleep_sec = base_sleep_sec * (2**i)
@@ -577,6 +581,9 @@ def run_chat_json(
         if last_error is not None:
             raise RuntimeError(f"{provider_name} chat.completions request failed: {last_error}") from last_error
         raise RuntimeError(f"{provider_name} chat.completions failed: no response")
+    limited = rate_limited_error(provider_name, resp.status_code, resp.headers, f"{provider_name} chat.completions failed status=429 body={resp.text[:1000]}")
+    if limited is not None:
+        raise limited
     if resp.status_code >= 400:
         raise RuntimeError(f"{provider_name} chat.completions failed status={resp.status_code} body={resp.text[:1000]}")
     raise RuntimeError(f"{provider_name} chat.completions failed: unexpected retry exit")
@@ -751,6 +758,8 @@ async def run_chat_json_async(
                 if retry_usage.get("execution_failures"):
                     usage["execution_failures"] = list(retry_usage["execution_failures"])
                 return text, usage
+            if resp.status_code == 429 and retry_after_seconds(resp.headers) is not None:
+                break
             if resp.status_code in retryable_status and i < attempts - 1:
                 _append_execution_failure(retry_usage, requested_model, f"status={resp.status_code} body={resp.text[:1000]}")
                 sleep_sec = base_sleep_sec * (2**i)
@@ -771,6 +780,9 @@ async def run_chat_json_async(
         if last_error is not None:
             raise RuntimeError(f"{provider_name} chat.completions request failed: {last_error}") from last_error
         raise RuntimeError(f"{provider_name} chat.completions failed: no response")
+    limited = rate_limited_error(provider_name, resp.status_code, resp.headers, f"{provider_name} chat.completions failed status=429 body={resp.text[:1000]}")
+    if limited is not None:
+        raise limited
     if resp.status_code >= 400:
         raise RuntimeError(f"{provider_name} chat.completions failed status={resp.status_code} body={resp.text[:1000]}")
     raise RuntimeError(f"{provider_name} chat.completions failed: unexpected retry exit")
@@ -4,6 +4,8 @@ import time
 
 import httpx
 
+from app.services.provider_rate_limit import rate_limited_error, retry_after_seconds
+
 
 def _env_timeout_seconds(name: str, default: float) -> float:
     raw = os.getenv(name)
@@ -121,6 +123,8 @@ def run_responses_json(
                 raise RuntimeError(f"openai responses request failed: {e}") from e
             if resp.status_code < 400:
                 break
+            if resp.status_code == 429 and retry_after_seconds(resp.headers) is not None:
+                break
             if resp.status_code in retryable_status and i < attempts - 1:
                 time.sleep(base_sleep_sec * (2**i))
                 continue
@@ -129,6 +133,9 @@ def run_responses_json(
         if last_error is not None:
             raise RuntimeError(f"openai responses request failed: {last_error}") from last_error
         raise RuntimeError("openai responses failed: no response")
+    limited = rate_limited_error("openai", resp.status_code, resp.headers, f"openai responses failed status=429 body={resp.text[:1000]}")
+    if limited is not None:
+        raise limited
     if resp.status_code >= 400:
         raise RuntimeError(f"openai responses failed status={resp.status_code} body={resp.text[:1000]}")
     data = resp.json() if resp.content else {}
@@ -219,6 +226,8 @@ async def run_responses_json_async(
                 raise RuntimeError(f"openai responses request failed: {e}") from e
             if resp.status_code < 400:
                 break
+            if resp.status_code == 429 and retry_after_seconds(resp.headers) is not None:
+                break
             if resp.status_code in retryable_status and i < attempts - 1:
                 await asyncio.sleep(base_sleep_sec * (2**i))
                 continue
@@ -227,6 +236,9 @@ async def run_responses_json_async(
         if last_error is not None:
             raise RuntimeError(f"openai responses request failed: {last_error}") from last_error
         raise RuntimeError("openai responses failed: no response")
+    limited = rate_limited_error("openai", resp.status_code, resp.headers, f"openai responses failed status=429 body={resp.text[:1000]}")
+    if limited is not None:
+        raise limited
     if resp.status_code >= 400:
         raise RuntimeError(f"openai responses failed status={resp.status_code} body={resp.text[:1000]}")
     data = resp.json() if resp.content else {}
@@ -43,6 +43,7 @@ from app.services.digest_task_common import (
     parse_cluster_draft_result,
     parse_digest_result,
 )
+from app.services.item_translation_common import build_item_translation_task, parse_item_translation_result
 from app.services.feed_task_common import (
     ASK_MAX_OUTPUT_TOKENS,
     build_ai_navigator_brief_task,
@@ -391,6 +392,7 @@ class OpenAICompatProvider:
             topics=topics,
             genre=str(data.get("genre") or "").strip(),
             other_label=str(data.get("other_label") or "").strip(),
+            depth=str(data.get("depth") or "").strip(),
             raw_score_breakdown=data.get("score_breakdown"),
             score_reason=str(data.get("score_reason") or "").strip(),
             translated_title=str(data.get("translated_title") or "").strip(),
@@ -570,6 +572,12 @@ class OpenAICompatProvider:
         out = parse_item_navigator_result(text, task["article"])
         return {"headline": out["headline"], "commentary": out["commentary"], "stance_tags": out["stance_tags"], "llm": self._llm_meta(model, "item_navigator", usage)}
 
+    def translate_item(self, title: str | None, summary: str, facts: list[str], content: str | None, target_language: str, model: str, api_key: str) -> dict:
+        task = build_item_translation_task(title, summary, facts, content, target_language)
+        text, usage = self._chat_json(task["prompt"], model, api_key, max_output_tokens=task["max_output_tokens"], response_schema=task["schema"], schema_name="item_translation", temperature=0.2)
+        out = parse_item_translation_result(text, task["source"])
+        return {**out, "llm": self._llm_meta(model, "translation", usage)}
+
     def generate_audio_briefing_script(
         self,
         persona: str,
@@ -710,6 +718,7 @@ class OpenAICompatProvider:
             topics=topics,
             genre=str(data.get("genre") or "").strip(),
             other_label=str(data.get("other_label") or "").strip(),
+            depth=str(data.get("depth") or "").strip(),
             raw_score_breakdown=data.get("score_breakdown"),
             score_reason=str(data.get("score_reason") or "").strip(),
             translated_title=str(data.get("translated_title") or "").strip(),
@@ -891,6 +900,12 @@ class OpenAICompatProvider:
         out = parse_item_navigator_result(text, task["article"])
         return {"headline": out["headline"], "commentary": out["commentary"], "stance_tags": out["stance_tags"], "llm": self._llm_meta(model, "item_navigator", usage)}
 
+    async def translate_item_async(self, title: str | None, summary: str, facts: list[str], content: str | None, target_language: str, model: str, api_key: str) -> dict:
+        task = build_item_translation_task(title, summary, facts, content, target_language)
+        text, usage = await self._chat_json_async(task["prompt"], model, api_key, max_output_tokens=task["max_output_tokens"], response_schema=task["schema"], schema_name="item_translation", temperature=0.2)
+        out = parse_item_translation_result(text, task["source"])
+        return {**out, "llm": self._llm_meta(model, "translation", usage)}
+
     async def generate_audio_briefing_script_async(
         self,
         persona: str,
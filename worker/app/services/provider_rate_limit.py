@@ -0,0 +1,99 @@
+import re
+from datetime import datetime, timezone
+from email.utils import parsedate_to_datetime
+
+# Reset hints checked in order. Anthropic reports RFC 3339 timestamps per limit, OpenAI and
+# most OpenAI-compatible providers report durations such as "1s" or "6m0s".
+_RESET_TIMESTAMP_HEADERS = (
+    "anthropic-ratelimit-requests-reset",
+    "anthropic-ratelimit-input-tokens-reset",
+    "anthropic-ratelimit-output-tokens-reset",
+    "anthropic-ratelimit-tokens-reset",
+)
+_RESET_DURATION_HEADERS = (
+    "x-ratelimit-reset-requests",
+    "x-ratelimit-reset-tokens",
+)
+_DURATION_PART = re.compile(r"(\d+(?:\.\d+)?)(ms|h|m|s)")
+_DURATION_UNITS = {"h": 3600.0, "m": 60.0, "s": 1.0, "ms": 0.001}
+
+
+class ProviderRateLimited(RuntimeError):
+    """A provider answered 429. The API waits retry_after_sec before calling it again."""
+
+    def __init__(self, provider: str, retry_after_sec: float | None, message: str):
+        super().__init__(message)
+        self.provider = str(provider or "").strip().lower()
+        self.retry_after_sec = retry_after_sec
+
+
+def parse_reset_duration(raw: str) -> float | None:
+    value = str(raw or "").strip().lower()
+    if not value:
+        return None
+    try:
+        return float(value)
+    except ValueError:
+        pass
+    parts = _DURATION_PART.findall(value)
+    if not parts or "".join(n + u for n, u in parts) != value:
+        return None
+    return sum(float(n) * _DURATION_UNITS[u] for n, u in parts)
+
+
+def retry_after_seconds(headers, now: datetime | None = None) -> float | None:
+    """Returns how long the provider asked us to wait, or None when it gave no hint."""
+    if headers is None:
+        return None
+    now = now or datetime.now(timezone.utc)
+    raw_ms = str(headers.get("retry-after-ms") or "").strip()
+    if raw_ms:
+        try:
+            return max(float(raw_ms) / 1000.0, 0.0)
+        except ValueError:
+            pass
+    raw = str(headers.get("retry-after") or "").strip()
+    if raw:
+        try:
+            return max(float(raw), 0.0)
+        except ValueError:
+            try:
+                return max((parsedate_to_datetime(raw) - now).total_seconds(), 0.0)
+            except (TypeError, ValueError):
+                pass
+    waits: list[float] = []
+    for name in _RESET_TIMESTAMP_HEADERS:
+        value = str(headers.get(name) or "").strip()
+        if not value:
+            continue
+        try:
+            reset_at = datetime.fromisoformat(value.replace("Z", "+00:00"))
+        except ValueError:
+            continue
+        waits.append((reset_at - now).total_seconds())
+    for name in _RESET_DURATION_HEADERS:
+        seconds = parse_reset_duration(headers.get(name) or "")
+        if seconds is not None:
+            waits.append(seconds)
+    waits = [w for w in waits if w > 0]
+    return max(waits) if waits else None
+
+
+def google_retry_delay_seconds(payload) -> float | None:
+    """Reads the RetryInfo retryDelay (e.g. "37s") Gemini puts in the details of a 429 body."""
+    error = payload.get("error") if isinstance(payload, dict) else None
+    details = error.get("details") if isinstance(error, dict) else None
+    if not isinstance(details, list):
+        return None
+    for detail in details:
+        if isinstance(detail, dict) and str(detail.get("@type") or "").endswith("google.rpc.RetryInfo"):
+            seconds = parse_reset_duration(detail.get("retryDelay") or "")
+            if seconds is not None and seconds > 0:
+                return seconds
+    return None
+
+
+def rate_limited_error(provider: str, status_code: int, headers, message: str) -> ProviderRateLimited | None:
+    if status_code != 429:
+        return None
+    return ProviderRateLimited(provider, retry_after_seconds(headers), message)
@@ -0,0 +1,49 @@
+from __future__ import annotations
+
+import contextvars
+import logging
+import re
+
+# The API forwards the request ID of the fetch run or API call behind each worker request, so
+# a failure logged here can be traced back to where it started.
+REQUEST_ID_HEADER = "x-request-id"
+
+_REQUEST_ID_PATTERN = re.compile(r"^[A-Za-z0-9._-]{1,64}$")
+_request_id_var = contextvars.ContextVar("request_id", default="")
+
+
+def normalize_request_id(value: str | None) -> str:
+    value = str(value or "").strip()
+    if not _REQUEST_ID_PATTERN.match(value):
+        return ""
+    return value
+
+
+def set_request_id(value: str | None) -> contextvars.Token:
+    return _request_id_var.set(normalize_request_id(value))
+
+
+def reset_request_id(token: contextvars.Token) -> None:
+    _request_id_var.reset(token)
+
+
+def current_request_id() -> str:
+    return _request_id_var.get()
+
+
+def install_log_record_factory() -> None:
+    """Gives every log record a request_id attribute and tags messages logged during a request."""
+    previous = logging.getLogRecordFactory()
+    if getattr(previous, "_sifto_request_id", False):
+        return
+
+    def factory(*args, **kwargs):
+        record = previous(*args, **kwargs)
+        request_id = current_request_id()
+        record.request_id = request_id
+        if request_id and isinstance(record.msg, str):
+            record.msg = f"[request_id={request_id}] {record.msg}"
+        return record
+
+    factory._sifto_request_id = True
+    logging.setLogRecordFactory(factory)
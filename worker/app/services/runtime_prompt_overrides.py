@@ -4,6 +4,28 @@ from contextlib import contextmanager
 from app.services.prompt_template_defaults import render_prompt_template
 
 _prompt_override_var = contextvars.ContextVar("runtime_prompt_override", default=None)
+_target_language_var = contextvars.ContextVar("runtime_target_language", default=None)
+_digest_style_var = contextvars.ContextVar("runtime_digest_style", default=None)
+
+_TARGET_LANGUAGE_NAMES = {
+    "ja": "Japanese",
+    "en": "English",
+    "zh": "Chinese",
+    "ko": "Korean",
+    "fr": "French",
+    "de": "German",
+    "es": "Spanish",
+}
+
+_DIGEST_VERBOSITY_DIRECTIVES = {
+    "short": "Keep the digest brief: a short overview and one or two sentences per topic.",
+    "deep_dive": "Write an in-depth digest: explain context, implications and connections between topics in detail.",
+}
+
+_DIGEST_TONE_DIRECTIVES = {
+    "analytical": "Use an analytical tone that highlights causes, trade-offs and implications.",
+    "casual": "Use a friendly, conversational tone.",
+}
 
 
 @contextmanager
@@ -22,6 +44,60 @@ def bind_prompt_override(prompt_key: str | None, prompt_text: str | None, system
         _prompt_override_var.reset(token)
 
 
+@contextmanager
+def bind_target_language(target_language: str | None):
+    token = _target_language_var.set(str(target_language or "").strip().lower() or None)
+    try:
+        yield
+    finally:
+        _target_language_var.reset(token)
+
+
+@contextmanager
+def bind_digest_style(verbosity: str | None, tone: str | None):
+    token = _digest_style_var.set(
+        {
+            "verbosity": str(verbosity or "").strip().lower(),
+            "tone": str(tone or "").strip().lower(),
+        }
+    )
+    try:
+        yield
+    finally:
+        _digest_style_var.reset(token)
+
+
+def _append_directive(system_instruction: str, directive: str) -> str:
+    if not system_instruction:
+        return directive
+    return f"{system_instruction}\n\n{directive}"
+
+
+def target_language_name(code: str) -> str:
+    return _TARGET_LANGUAGE_NAMES.get(code, code)
+
+
+def _append_target_language_instruction(system_instruction: str) -> str:
+    code = _target_language_var.get()
+    if not code:
+        return system_instruction
+    name = target_language_name(code)
+    return _append_directive(system_instruction, f"Write all natural-language output fields in {name}, regardless of the source language.")
+
+
+def _append_digest_style_instruction(system_instruction: str) -> str:
+    style = _digest_style_var.get()
+    if not style:
+        return system_instruction
+    for directive in (
+        _DIGEST_VERBOSITY_DIRECTIVES.get(style.get("verbosity", "")),
+        _DIGEST_TONE_DIRECTIVES.get(style.get("tone", "")),
+    ):
+        if directive:
+            system_instruction = _append_directive(system_instruction, directive)
+    return system_instruction
+
+
 class PromptStrategy:
     def render(self, default_system_instruction: str, default_prompt_text: str, variables: dict[str, object] | None = None) -> tuple[str, str]:
         raise NotImplementedError
@@ -65,4 +141,6 @@ def resolve_prompt_strategy(prompt_key: str) -> PromptStrategy:
 
 
 def apply_prompt_override(prompt_key: str, system_instruction: str, prompt_text: str, variables: dict[str, object] | None = None) -> tuple[str, str]:
-    return resolve_prompt_strategy(prompt_key).render(system_instruction, prompt_text, variables)
+    next_system_instruction, next_prompt_text = resolve_prompt_strategy(prompt_key).render(system_instruction, prompt_text, variables)
+    next_system_instruction = _append_digest_style_instruction(next_system_instruction)
+    return _append_target_language_instruction(next_system_instruction), next_prompt_text
@@ -2,6 +2,7 @@ from collections.abc import Callable
 
 from app.services.llm_text_utils import extract_json_string_value_loose, summary_composite_score
 from app.services.summary_result_common import finalize_translated_title, normalize_score_breakdown
+from app.services.summary_task_common import SUMMARY_DEPTHS
 
 DEFAULT_SCORE_REASON = "総合的な重要度・新規性・実用性を基に採点。"
 
@@ -13,6 +14,7 @@ def finalize_summary_result(
     topics: list[str] | None,
     genre: str | None,
     other_label: str | None = None,
+    depth: str | None = None,
     raw_score_breakdown: dict | None,
     score_reason: str,
     translated_title: str,
@@ -34,12 +36,18 @@ def finalize_summary_result(
         normalized_other_label = extract_json_string_value_loose(response_text, "other_label")
     if normalized_genre != "other":
         normalized_other_label = ""
+    normalized_depth = str(depth or "").strip().lower()
+    if not normalized_depth:
+        normalized_depth = extract_json_string_value_loose(response_text, "depth").strip().lower()
+    if normalized_depth not in SUMMARY_DEPTHS:
+        normalized_depth = ""
     score_breakdown = normalize_score_breakdown(raw_score_breakdown)
     return {
         "summary": summary,
         "topics": [str(t).strip() for t in (topics or []) if str(t).strip()],
         "genre": str(normalized_genre or "").strip(),
         "other_label": str(normalized_other_label or "").strip()[:20],
+        "depth": normalized_depth,
         "translated_title": finalize_translated_title(
             title,
             str(translated_title or "").strip(),
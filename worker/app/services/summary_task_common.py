@@ -38,14 +38,23 @@ _SUMMARY_TAXONOMY_GUIDANCE = (
     "空文字は上流で null 扱いになります。"
 )
 
+SUMMARY_DEPTHS = ["news_brief", "deep_dive", "tutorial"]
+_SUMMARY_DEPTH_GUIDANCE = (
+    "depth は必須です。記事の読み応えを次から 1 つだけ選んでください: "
+    "news_brief（短い速報・発表・ニュース）, deep_dive（分析・解説・考察など腰を据えて読む記事）, "
+    "tutorial（手順やコードを追って手を動かすハンズオン・ガイド）。"
+)
+
 
 def _append_summary_taxonomy_guidance(text: str) -> str:
     rendered = str(text or "").strip()
     if not rendered:
         return ""
-    if _SUMMARY_TAXONOMY_GUIDANCE in rendered:
-        return rendered
-    return f"{rendered}\n\n# Genre\n{_SUMMARY_TAXONOMY_GUIDANCE}"
+    if _SUMMARY_TAXONOMY_GUIDANCE not in rendered:
+        rendered = f"{rendered}\n\n# Genre\n{_SUMMARY_TAXONOMY_GUIDANCE}"
+    if _SUMMARY_DEPTH_GUIDANCE not in rendered:
+        rendered = f"{rendered}\n\n# Depth\n{_SUMMARY_DEPTH_GUIDANCE}"
+    return rendered
 
 
 SUMMARY_SYSTEM_INSTRUCTION = _append_summary_taxonomy_guidance(
@@ -61,6 +70,7 @@ SUMMARY_SCHEMA = {
         "translated_title": {"type": "string"},
         "genre": {"type": "string", "enum": SUMMARY_TAXONOMY},
         "other_label": {"type": "string", "maxLength": SUMMARY_OTHER_LABEL_MAX_LENGTH},
+        "depth": {"type": "string", "enum": SUMMARY_DEPTHS},
         "score_breakdown": {
             "type": "object",
             "properties": {
@@ -75,7 +85,7 @@ SUMMARY_SCHEMA = {
         },
         "score_reason": {"type": "string"},
     },
-    "required": ["summary", "topics", "translated_title", "score_breakdown", "score_reason", "genre", "other_label"],
+    "required": ["summary", "topics", "translated_title", "score_breakdown", "score_reason", "genre", "other_label", "depth"],
     "additionalProperties": False,
 }
 
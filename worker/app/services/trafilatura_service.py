@@ -13,6 +13,14 @@ from trafilatura.settings import use_config
 
 _log = logging.getLogger(__name__)
 
+
+class UpstreamHTTPError(Exception):
+    """The page itself answered with an error status, e.g. 403 or 404."""
+
+    def __init__(self, status_code: int, message: str):
+        super().__init__(message)
+        self.status_code = status_code
+
 _META_IMAGE_PATTERNS = [
     re.compile(r'(?is)<meta[^>]+property=["\']og:image(?::secure_url)?["\'][^>]+content=["\']([^"\']+)["\']'),
     re.compile(r'(?is)<meta[^>]+content=["\']([^"\']+)["\'][^>]+property=["\']og:image(?::secure_url)?["\']'),
@@ -20,6 +28,11 @@ _META_IMAGE_PATTERNS = [
     re.compile(r'(?is)<meta[^>]+content=["\']([^"\']+)["\'][^>]+name=["\']twitter:image(?::src)?["\']'),
 ]
 
+_CANONICAL_LINK_PATTERNS = [
+    re.compile(r'(?is)<link[^>]+rel=["\']canonical["\'][^>]+href=["\']([^"\']+)["\']'),
+    re.compile(r'(?is)<link[^>]+href=["\']([^"\']+)["\'][^>]+rel=["\']canonical["\']'),
+]
+
 _META_CHARSET_PATTERNS = [
     re.compile(br'(?is)<meta[^>]+charset=["\']?\s*([a-zA-Z0-9._\-]+)'),
     re.compile(br'(?is)<meta[^>]+content=["\'][^"\']*charset=\s*([a-zA-Z0-9._\-]+)[^"\']*["\']'),
@@ -186,6 +199,20 @@ def _extract_image_url(downloaded: str, page_url: str) -> str | None:
     return None
 
 
+def _extract_canonical_url(downloaded: str, page_url: str) -> str | None:
+    for pattern in _CANONICAL_LINK_PATTERNS:
+        m = pattern.search(downloaded)
+        if not m:
+            continue
+        raw = html.unescape(m.group(1).strip())
+        if not raw:
+            continue
+        resolved = urljoin(page_url, raw)
+        if resolved.startswith(("http://", "https://")):
+            return resolved
+    return None
+
+
 def _fallback_extract(downloaded: str, url: str) -> dict | None:
     title_match = re.search(r"<title[^>]*>(.*?)</title>", downloaded, flags=re.IGNORECASE | re.DOTALL)
     title = None
@@ -204,6 +231,7 @@ def _fallback_extract(downloaded: str, url: str) -> dict | None:
         "content": text,
         "published_at": None,
         "image_url": _extract_image_url(downloaded, url),
+        "canonical_url": _extract_canonical_url(downloaded, url),
     }
 
 
@@ -249,6 +277,8 @@ def extract_body(url: str) -> dict | None:
                         "published_at": None,
                         "image_url": None,
                     }
+                if isinstance(e, httpx.HTTPStatusError):
+                    raise UpstreamHTTPError(e.response.status_code, str(e)) from e
                 return None
 
         try:
@@ -295,7 +325,10 @@ def extract_body(url: str) -> dict | None:
             "content": content,
             "published_at": _result_value(result, "date"),
             "image_url": _extract_image_url(downloaded, url),
+            "canonical_url": _extract_canonical_url(downloaded, url),
         }
+    except UpstreamHTTPError:
+        raise
     except Exception:
         _log.exception("extract_body unexpected failure url=%s", url)
         if os.getenv("ALLOW_DEV_EXTRACT_PLACEHOLDER") == "true":
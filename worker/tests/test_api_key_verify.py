@@ -0,0 +1,32 @@
+from app.services.api_key_verify import parse_rate_limits, verification_result
+
+
+def test_parse_rate_limits_reads_anthropic_headers():
+    headers = {
+        "anthropic-ratelimit-requests-limit": "50",
+        "anthropic-ratelimit-input-tokens-limit": "30000",
+        "anthropic-ratelimit-output-tokens-limit": "not-a-number",
+    }
+    assert parse_rate_limits("anthropic", headers) == {
+        "requests_per_minute": 50,
+        "input_tokens_per_minute": 30000,
+    }
+
+
+def test_verification_result_marks_auth_failures_invalid():
+    result = verification_result("openai", 401, {}, "Incorrect API key provided")
+    assert result["valid"] is False
+    assert result["error"] == "invalid api key"
+
+
+def test_verification_result_treats_rate_limited_key_as_valid():
+    result = verification_result("openai", 429, {"x-ratelimit-limit-requests": "500"})
+    assert result["valid"] is True
+    assert result["error"] is None
+    assert result["limits"] == {"requests_per_minute": 500}
+
+
+def test_verification_result_keeps_other_errors():
+    result = verification_result("google", 500, {}, "backend unavailable")
+    assert result["valid"] is False
+    assert result["error"] == "backend unavailable"
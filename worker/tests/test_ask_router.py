@@ -0,0 +1,31 @@
+import unittest
+
+from app.routers.ask import build_answer_citations
+
+
+class AnswerCitationTests(unittest.TestCase):
+    def test_keeps_only_retrieved_items_and_attaches_urls(self):
+        candidates = [
+            {"item_id": "a", "url": "https://example.com/a", "title": "A", "translated_title": "エー"},
+            {"item_id": "b", "url": "https://example.com/b", "title": "B", "translated_title": None},
+        ]
+        citations = [
+            {"item_id": "b", "reason": "根拠"},
+            {"item_id": "x", "reason": "unknown"},
+            {"item_id": "a"},
+            {"item_id": "b", "reason": "duplicate"},
+        ]
+
+        got = build_answer_citations(citations, candidates)
+
+        self.assertEqual(
+            got,
+            [
+                {"item_id": "b", "url": "https://example.com/b", "title": "B", "reason": "根拠"},
+                {"item_id": "a", "url": "https://example.com/a", "title": "エー", "reason": ""},
+            ],
+        )
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,54 @@
+import unittest
+
+from app.services.item_translation_common import (
+    ITEM_TRANSLATION_SCHEMA,
+    build_item_translation_task,
+    parse_item_translation_result,
+)
+
+
+class ItemTranslationCommonTests(unittest.TestCase):
+    def test_schema_requires_all_fields_for_strict_json_schema(self):
+        self.assertEqual(ITEM_TRANSLATION_SCHEMA["required"], ["title", "summary", "facts", "content"])
+
+    def test_build_task_names_target_language_and_fact_count(self):
+        task = build_item_translation_task("Title", "Summary", ["One", " ", "Two"], None, "en")
+
+        self.assertIn("into English", task["prompt"])
+        self.assertIn("exactly 2 entries", task["prompt"])
+        self.assertEqual(task["source"]["facts"], ["One", "Two"])
+        self.assertEqual(task["max_output_tokens"], 3000)
+
+    def test_build_task_raises_output_budget_for_full_text(self):
+        task = build_item_translation_task("Title", "Summary", [], "Body text", "ja")
+
+        self.assertEqual(task["target_language"], "ja")
+        self.assertGreater(task["max_output_tokens"], 3000)
+
+    def test_parse_keeps_source_facts_when_counts_differ(self):
+        source = {"title": "Title", "summary": "Summary", "facts": ["One", "Two"], "content": ""}
+
+        out = parse_item_translation_result(
+            '{"title":"タイトル","summary":"要約","facts":["一"],"content":"本文"}',
+            source,
+        )
+
+        self.assertEqual(out["title"], "タイトル")
+        self.assertEqual(out["summary"], "要約")
+        self.assertEqual(out["facts"], ["One", "Two"])
+        self.assertEqual(out["content"], "")
+
+    def test_parse_returns_translated_facts_and_content(self):
+        source = {"title": "", "summary": "Summary", "facts": ["One"], "content": "Body"}
+
+        out = parse_item_translation_result(
+            '```json\n{"title":"","summary":"要約","facts":["一つ目"],"content":"本文"}\n```',
+            source,
+        )
+
+        self.assertEqual(out["facts"], ["一つ目"])
+        self.assertEqual(out["content"], "本文")
+
+
+if __name__ == "__main__":
+    unittest.main()
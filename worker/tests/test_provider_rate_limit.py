@@ -0,0 +1,114 @@
+import unittest
+from datetime import datetime, timezone
+from unittest.mock import patch
+
+from app.services import anthropic_transport
+from app.services.provider_rate_limit import (
+    ProviderRateLimited,
+    google_retry_delay_seconds,
+    parse_reset_duration,
+    rate_limited_error,
+    retry_after_seconds,
+)
+
+
+class RetryAfterSecondsTests(unittest.TestCase):
+    now = datetime(2026, 3, 10, 12, 0, 0, tzinfo=timezone.utc)
+
+    def test_retry_after_ms_wins_over_retry_after(self):
+        headers = {"retry-after-ms": "1500", "retry-after": "30"}
+
+        self.assertEqual(retry_after_seconds(headers, now=self.now), 1.5)
+
+    def test_retry_after_accepts_seconds_and_http_date(self):
+        self.assertEqual(retry_after_seconds({"retry-after": "12"}, now=self.now), 12.0)
+        self.assertEqual(
+            retry_after_seconds({"retry-after": "Tue, 10 Mar 2026 12:01:00 GMT"}, now=self.now),
+            60.0,
+        )
+
+    def test_anthropic_reset_timestamps_use_the_latest_window(self):
+        headers = {
+            "anthropic-ratelimit-requests-reset": "2026-03-10T12:00:05Z",
+            "anthropic-ratelimit-input-tokens-reset": "2026-03-10T12:00:40Z",
+        }
+
+        self.assertEqual(retry_after_seconds(headers, now=self.now), 40.0)
+
+    def test_openai_reset_durations(self):
+        headers = {"x-ratelimit-reset-requests": "20ms", "x-ratelimit-reset-tokens": "6m0s"}
+
+        self.assertEqual(retry_after_seconds(headers, now=self.now), 360.0)
+
+    def test_no_hint_returns_none(self):
+        self.assertIsNone(retry_after_seconds({}, now=self.now))
+        self.assertIsNone(retry_after_seconds({"x-ratelimit-reset-requests": "soon"}, now=self.now))
+        self.assertIsNone(retry_after_seconds(None, now=self.now))
+
+
+class ParseResetDurationTests(unittest.TestCase):
+    def test_parses_compound_durations(self):
+        self.assertEqual(parse_reset_duration("1h2m3s"), 3723.0)
+        self.assertEqual(parse_reset_duration("1.5s"), 1.5)
+        self.assertEqual(parse_reset_duration("7"), 7.0)
+
+    def test_rejects_garbage(self):
+        self.assertIsNone(parse_reset_duration(""))
+        self.assertIsNone(parse_reset_duration("3x"))
+
+
+class RateLimitedErrorTests(unittest.TestCase):
+    def test_only_429_becomes_provider_rate_limited(self):
+        self.assertIsNone(rate_limited_error("openai", 500, {"retry-after": "3"}, "boom"))
+
+        err = rate_limited_error("OpenAI", 429, {"retry-after": "3"}, "rate limited")
+
+        self.assertIsInstance(err, ProviderRateLimited)
+        self.assertEqual(err.provider, "openai")
+        self.assertEqual(err.retry_after_sec, 3.0)
+        self.assertEqual(str(err), "rate limited")
+
+
+class GoogleRetryDelayTests(unittest.TestCase):
+    def test_reads_retry_info_from_error_details(self):
+        payload = {
+            "error": {
+                "code": 429,
+                "status": "RESOURCE_EXHAUSTED",
+                "details": [
+                    {"@type": "type.googleapis.com/google.rpc.QuotaFailure", "violations": []},
+                    {"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "37s"},
+                ],
+            }
+        }
+
+        self.assertEqual(google_retry_delay_seconds(payload), 37.0)
+
+    def test_missing_retry_info_returns_none(self):
+        self.assertIsNone(google_retry_delay_seconds({"error": {"code": 429}}))
+        self.assertIsNone(google_retry_delay_seconds([]))
+
+
+class ModelFallbackRateLimitTests(unittest.TestCase):
+    def test_rate_limit_is_raised_after_fallback_fails(self):
+        limited = ProviderRateLimited("anthropic", 12.0, "anthropic rate limited status=429")
+        with patch.object(anthropic_transport, "client_for_api_key", return_value=object()), patch.object(
+            anthropic_transport, "call_with_retries", side_effect=[limited, RuntimeError("overloaded")]
+        ):
+            with self.assertRaises(ProviderRateLimited) as ctx:
+                anthropic_transport.call_with_model_fallback("p", "claude-a", "claude-b", api_key="k")
+
+        self.assertEqual(ctx.exception.retry_after_sec, 12.0)
+
+    def test_fallback_success_wins_over_rate_limit(self):
+        limited = ProviderRateLimited("anthropic", 12.0, "anthropic rate limited status=429")
+        with patch.object(anthropic_transport, "client_for_api_key", return_value=object()), patch.object(
+            anthropic_transport, "call_with_retries", side_effect=[limited, "message"]
+        ):
+            message, model, failures = anthropic_transport.call_with_model_fallback("p", "claude-a", "claude-b", api_key="k")
+
+        self.assertEqual((message, model), ("message", "claude-b"))
+        self.assertEqual(len(failures), 1)
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,31 @@
+import logging
+import unittest
+
+from app.services import request_context
+
+
+class RequestContextTests(unittest.TestCase):
+    def test_normalize_request_id_rejects_unsafe_values(self):
+        self.assertEqual(request_context.normalize_request_id(" fetch-01J9.run_1 "), "fetch-01J9.run_1")
+        self.assertEqual(request_context.normalize_request_id("has space"), "")
+        self.assertEqual(request_context.normalize_request_id("a" * 65), "")
+        self.assertEqual(request_context.normalize_request_id(None), "")
+
+    def test_log_records_carry_the_current_request_id(self):
+        request_context.install_log_record_factory()
+        request_context.install_log_record_factory()
+        token = request_context.set_request_id("req-1")
+        try:
+            record = logging.getLogger("test").makeRecord("test", logging.INFO, __file__, 1, "summarize failed", (), None)
+        finally:
+            request_context.reset_request_id(token)
+        self.assertEqual(record.request_id, "req-1")
+        self.assertEqual(record.getMessage(), "[request_id=req-1] summarize failed")
+
+        record = logging.getLogger("test").makeRecord("test", logging.INFO, __file__, 1, "idle", (), None)
+        self.assertEqual(record.request_id, "")
+        self.assertEqual(record.getMessage(), "idle")
+
+
+if __name__ == "__main__":
+    unittest.main()
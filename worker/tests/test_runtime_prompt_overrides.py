@@ -1,6 +1,6 @@
 import unittest
 
-from app.services.runtime_prompt_overrides import apply_prompt_override, bind_prompt_override
+from app.services.runtime_prompt_overrides import apply_prompt_override, bind_digest_style, bind_prompt_override, bind_target_language
 
 
 class RuntimePromptOverridesTest(unittest.TestCase):
@@ -16,6 +16,39 @@ class RuntimePromptOverridesTest(unittest.TestCase):
         self.assertEqual(system_instruction, "")
         self.assertEqual(prompt_text, "")
 
+    def test_apply_prompt_override_appends_target_language(self):
+        with bind_target_language("en"):
+            system_instruction, prompt_text = apply_prompt_override(
+                "summary.default",
+                "default system",
+                "default prompt",
+            )
+
+        self.assertTrue(system_instruction.startswith("default system"))
+        self.assertIn("in English", system_instruction)
+        self.assertEqual(prompt_text, "default prompt")
+
+    def test_apply_prompt_override_appends_digest_style(self):
+        with bind_digest_style("short", "casual"):
+            system_instruction, _ = apply_prompt_override(
+                "digest.default",
+                "default system",
+                "default prompt",
+            )
+
+        self.assertIn("Keep the digest brief", system_instruction)
+        self.assertIn("conversational tone", system_instruction)
+
+    def test_apply_prompt_override_ignores_default_digest_style(self):
+        with bind_digest_style("standard", "neutral"):
+            system_instruction, _ = apply_prompt_override(
+                "digest.default",
+                "default system",
+                "default prompt",
+            )
+
+        self.assertEqual(system_instruction, "default system")
+
 
 if __name__ == "__main__":
     unittest.main()
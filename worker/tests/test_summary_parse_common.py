@@ -78,6 +78,26 @@ class SummaryParseCommonTests(unittest.TestCase):
         self.assertEqual(result["score_policy_version"], "v4")
         self.assertEqual(result["other_label"], "")
 
+    def test_finalize_summary_result_keeps_known_depth_only(self):
+        common = dict(
+            title="Example",
+            summary_text="要約です。",
+            topics=["AI"],
+            genre="research",
+            raw_score_breakdown=None,
+            score_reason="",
+            translated_title="",
+            translate_func=lambda raw: raw,
+            llm={"provider": "test", "model": "test"},
+            error_prefix="test",
+        )
+
+        result = finalize_summary_result(**common, depth="", response_text='{"summary":"要約です。","depth":"Tutorial"}')
+        self.assertEqual(result["depth"], "tutorial")
+
+        result = finalize_summary_result(**common, depth="longread", response_text='{"summary":"要約です。"}')
+        self.assertEqual(result["depth"], "")
+
 
 if __name__ == "__main__":
     unittest.main()
@@ -2,7 +2,7 @@ import unittest
 
 from app.services.prompt_template_defaults import get_default_prompt_template
 from app.services.runtime_prompt_overrides import bind_prompt_override
-from app.services.summary_task_common import SUMMARY_SYSTEM_INSTRUCTION, SUMMARY_TAXONOMY, build_summary_task
+from app.services.summary_task_common import SUMMARY_DEPTHS, SUMMARY_SYSTEM_INSTRUCTION, SUMMARY_TAXONOMY, build_summary_task
 
 
 class SummaryTaskCommonTests(unittest.TestCase):
@@ -43,7 +43,9 @@ class SummaryTaskCommonTests(unittest.TestCase):
         self.assertIn("genre は必須です", prompt)
         self.assertIn("other_label", prompt)
         self.assertIn("固定 taxonomy", prompt)
-        self.assertEqual(task["schema"]["required"], ["summary", "topics", "translated_title", "score_breakdown", "score_reason", "genre", "other_label"])
+        self.assertEqual(task["schema"]["required"], ["summary", "topics", "translated_title", "score_breakdown", "score_reason", "genre", "other_label", "depth"])
+        self.assertIn("depth は必須です", prompt)
+        self.assertEqual(task["schema"]["properties"]["depth"]["enum"], SUMMARY_DEPTHS)
         genre_schema = task["schema"]["properties"]["genre"]
         self.assertEqual(genre_schema["enum"], SUMMARY_TAXONOMY)
         self.assertEqual(task["schema"]["properties"]["other_label"]["type"], "string")
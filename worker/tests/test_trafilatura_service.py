@@ -1,7 +1,9 @@
 import unittest
 from unittest.mock import Mock, patch
 
-from app.services.trafilatura_service import extract_body, is_pdf_response
+import httpx
+
+from app.services.trafilatura_service import UpstreamHTTPError, extract_body, is_pdf_response
 
 
 class TrafilaturaServiceTests(unittest.TestCase):
@@ -62,6 +64,16 @@ class TrafilaturaServiceTests(unittest.TestCase):
         self.assertEqual(result["title"], "50歳独身男性のインシデント対応を分析")
         self.assertEqual(result["content"], "GoogleがM-Trends 2026公開")
 
+    def test_extract_body_returns_canonical_link(self):
+        html = '<html><head><link href="/articles/1" rel="canonical"><title>t</title></head><body>本文</body></html>'
+        with patch("app.services.trafilatura_service.trafilatura.fetch_url", return_value=html), patch(
+            "app.services.trafilatura_service.trafilatura.bare_extraction",
+            return_value={"title": "t", "text": "本文", "date": None},
+        ):
+            result = extract_body("https://example.com/articles/1?utm_source=rss")
+
+        self.assertEqual(result["canonical_url"], "https://example.com/articles/1")
+
     def test_extract_body_refetches_when_fetch_url_result_is_mojibake(self):
         html = "<html><head><title>映画『CUBA JAZZ』始動</title></head><body>キューバの音楽文化を追う</body></html>"
         response = Mock()
@@ -281,6 +293,18 @@ class TrafilaturaServiceTests(unittest.TestCase):
         self.assertEqual(result["title"], "TOPPAN、ギリシャ語写本の本文を解読できるAI-OCRを開発")
         self.assertEqual(result["content"], "ギリシャ語写本の本文を解読できるAI-OCRを開発したと発表した。")
 
+    def test_extract_body_raises_upstream_status_when_page_refuses(self):
+        request = httpx.Request("GET", "https://example.com/start")
+        response = httpx.Response(403, request=request)
+        with patch("app.services.trafilatura_service.validate_public_http_url", side_effect=lambda url: url), patch(
+            "app.services.trafilatura_service.trafilatura.fetch_url", return_value=None
+        ), patch("app.services.trafilatura_service.httpx.get", return_value=response):
+            with self.assertRaises(UpstreamHTTPError) as ctx:
+                extract_body("https://example.com/start")
+
+        self.assertEqual(ctx.exception.status_code, 403)
+        self.assertIn("Client error '403 Forbidden'", str(ctx.exception))
+
 
 if __name__ == "__main__":
     unittest.main()
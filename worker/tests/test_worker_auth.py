@@ -1,15 +1,84 @@
+import time
+
 from app.main import _worker_auth_error_status
+from app.services.internal_auth import NonceCache, RedisNonceCache, internal_signature
+
+
+def _signed_headers(secret: str, method: str, uri: str, body: bytes, nonce: str = "nonce-1", ts: int | None = None) -> dict[str, str]:
+    timestamp = str(int(time.time()) if ts is None else ts)
+    return {
+        "x-internal-timestamp": timestamp,
+        "x-internal-nonce": nonce,
+        "x-internal-signature": internal_signature(secret, method, uri, timestamp, nonce, body),
+    }
 
 
 def test_worker_auth_fails_closed_without_configured_secret():
-    assert _worker_auth_error_status("/extract-body", "", "") == 503
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", {}, b"", []) == 503
+
+
+def test_worker_auth_requires_signature():
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", {}, b"", ["secret"], nonces=NonceCache()) == 401
+    legacy = {"x-internal-worker-secret": "secret"}
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", legacy, b"", ["secret"], nonces=NonceCache()) == 401
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", legacy, b"", ["secret"], allow_static=True, nonces=NonceCache()) is None
+
+
+def test_worker_auth_accepts_signed_request_once():
+    nonces = NonceCache()
+    body = b'{"url":"https://example.com"}'
+    headers = _signed_headers("secret", "POST", "/extract-body", body)
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, body, ["secret"], nonces=nonces) is None
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, body, ["secret"], nonces=nonces) == 401
+
 
+def test_worker_auth_rejects_tampered_or_stale_requests():
+    body = b'{"url":"https://example.com"}'
+    headers = _signed_headers("secret", "POST", "/extract-body", body)
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, b"{}", ["secret"], nonces=NonceCache()) == 401
+    assert _worker_auth_error_status("/summarize", "POST", "/summarize", headers, body, ["secret"], nonces=NonceCache()) == 401
+    stale = _signed_headers("secret", "POST", "/extract-body", body, ts=int(time.time()) - 600)
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", stale, body, ["secret"], nonces=NonceCache()) == 401
 
-def test_worker_auth_requires_matching_secret():
-    assert _worker_auth_error_status("/extract-body", "", "secret") == 401
-    assert _worker_auth_error_status("/extract-body", "wrong", "secret") == 401
-    assert _worker_auth_error_status("/extract-body", "secret", "secret") is None
+
+def test_worker_auth_accepts_previous_secret_during_rotation():
+    headers = _signed_headers("old", "POST", "/extract-body", b"")
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, b"", ["new", "old"], nonces=NonceCache()) is None
 
 
 def test_worker_health_remains_public():
-    assert _worker_auth_error_status("/health", "", "") is None
+    assert _worker_auth_error_status("/health", "GET", "/health", {}, b"", []) is None
+
+
+class _FakeRedis:
+    def __init__(self) -> None:
+        self.keys: dict[str, int] = {}
+
+    def set(self, key, value, nx=False, px=None):
+        if nx and key in self.keys:
+            return None
+        self.keys[key] = px
+        return True
+
+
+def test_worker_auth_redis_nonces_reject_replay_across_processes():
+    shared = _FakeRedis()
+    body = b"{}"
+    headers = _signed_headers("secret", "POST", "/extract-body", body)
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, body, ["secret"], nonces=RedisNonceCache(shared)) is None
+    assert _worker_auth_error_status("/extract-body", "POST", "/extract-body", headers, body, ["secret"], nonces=RedisNonceCache(shared)) == 401
+    (ttl_ms,) = shared.keys.values()
+    assert 0 < ttl_ms <= 300_000
+
+
+def test_worker_auth_signature_covers_user_email():
+    timestamp = str(int(time.time()))
+    headers = {
+        "x-internal-timestamp": timestamp,
+        "x-internal-nonce": "nonce-email",
+        "x-internal-user-email": "admin@example.com",
+        "x-internal-signature": internal_signature("secret", "GET", "/health-detail", timestamp, "nonce-email", b"", "user@example.com"),
+    }
+    assert _worker_auth_error_status("/health-detail", "GET", "/health-detail", headers, b"", ["secret"], nonces=NonceCache()) == 401
+    headers["x-internal-user-email"] = "user@example.com"
+    assert _worker_auth_error_status("/health-detail", "GET", "/health-detail", headers, b"", ["secret"], nonces=NonceCache()) is None